@@ -0,0 +1,360 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	scriptdb "gateway/internal/script/db"
+)
+
+// newFlagSet 创建子命令参数解析器
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+// parseFlags 解析子命令参数，允许选项出现在位置参数之后
+// 返回:
+//
+//	[]string: 位置参数
+//	error: 参数错误时返回 usageError，请求帮助时返回 flag.ErrHelp
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			if err == flag.ErrHelp {
+				return nil, err
+			}
+			// 解析器已输出错误和选项说明
+			return nil, usageError("")
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// runMigrate 执行升级脚本、查看迁移状态或回滚单个脚本
+func runMigrate(tool *dbtool, args []string) error {
+	flags := newFlagSet("migrate")
+	scriptName := flags.String("script", "", "回滚的脚本文件名（仅 down）")
+	confirmed := flags.Bool("yes", false, "确认执行回滚（仅 down）")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageError("必须指定 up、down 或 status")
+	}
+
+	switch positional[0] {
+	case "up":
+		return migrateUp(tool)
+	case "status":
+		return migrateStatus(tool)
+	case "down":
+		if *scriptName == "" {
+			return usageError("必须通过 -script 指定回滚的脚本文件名")
+		}
+		if !*confirmed {
+			return usageError("回滚会执行回滚脚本并清除该脚本的执行历史，请确认后加上 -yes")
+		}
+		return migrateDown(tool, *scriptName)
+	default:
+		return usageError(fmt.Sprintf("不支持的迁移操作: %s", positional[0]))
+	}
+}
+
+// migrateUp 执行目标数据库的升级脚本
+func migrateUp(tool *dbtool) error {
+	ctx, cancel := tool.commandContext()
+	defer cancel()
+	result, err := scriptdb.MigrateUp(ctx, tool.historyConn, tool.targetConn, tool.targetName)
+	if err != nil {
+		return err
+	}
+
+	if tool.jsonOutput {
+		errorMessage := ""
+		if result.Error != nil {
+			errorMessage = result.Error.Error()
+		}
+		if err := printJSON(map[string]interface{}{
+			"database":           result.DatabaseName,
+			"driver":             result.Driver,
+			"success":            result.Success,
+			"statementsExecuted": result.StatementsExecuted,
+			"statementsFailed":   result.StatementsFailed,
+			"statementsSkipped":  result.StatementsSkipped,
+			"durationMs":         result.Duration.Milliseconds(),
+			"error":              errorMessage,
+		}); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("数据库 %s (%s): 执行 %d 条, 失败 %d 条, 跳过 %d 条, 耗时 %s\n",
+			result.DatabaseName, result.Driver, result.StatementsExecuted, result.StatementsFailed,
+			result.StatementsSkipped, result.Duration.Round(time.Millisecond))
+	}
+
+	if result.Error != nil && !result.Success {
+		return result.Error
+	}
+	if result.StatementsFailed > 0 {
+		return fmt.Errorf("%d 条语句执行失败，可通过 history -status FAILED 查看", result.StatementsFailed)
+	}
+	return nil
+}
+
+// migrateStatus 输出目标数据库各脚本的执行情况
+func migrateStatus(tool *dbtool) error {
+	ctx, cancel := tool.commandContext()
+	defer cancel()
+	statuses, err := scriptdb.GetMigrationStatus(ctx, tool.historyConn, tool.targetConn.GetDriver())
+	if err != nil {
+		return err
+	}
+	if tool.jsonOutput {
+		return printJSON(statuses)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SCRIPT\tSTATE\tTOTAL\tSUCCESS\tFAILED\tPENDING\tDOWN")
+	for _, status := range statuses {
+		state := "applied"
+		if status.FailedStatements > 0 {
+			state = "failed"
+		} else if status.PendingStatements > 0 {
+			state = "pending"
+		}
+		down := "-"
+		if status.HasDownScript {
+			down = "Y"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%s\n",
+			status.ScriptName, state, status.TotalStatements, status.SuccessStatements,
+			status.FailedStatements, status.PendingStatements, down)
+	}
+	return w.Flush()
+}
+
+// migrateDown 回滚单个脚本
+func migrateDown(tool *dbtool, scriptName string) error {
+	ctx, cancel := tool.commandContext()
+	defer cancel()
+	executed, err := scriptdb.MigrateDown(ctx, tool.historyConn, tool.targetConn, scriptName)
+	if err != nil {
+		if executed > 0 {
+			fmt.Fprintf(os.Stderr, "已执行 %d 条回滚语句，执行历史未清除\n", executed)
+		}
+		return err
+	}
+	if tool.jsonOutput {
+		return printJSON(map[string]interface{}{
+			"script":             scriptName,
+			"statementsExecuted": executed,
+		})
+	}
+	fmt.Printf("脚本 %s 已回滚，执行 %d 条回滚语句，执行历史已清除\n", scriptName, executed)
+	return nil
+}
+
+// runHistory 列出语句执行历史
+func runHistory(tool *dbtool, args []string) error {
+	flags := newFlagSet("history")
+	scriptName := flags.String("script", "", "脚本文件名")
+	status := flags.String("status", "", "执行状态: SUCCESS、FAILED 或 SKIPPED")
+	limit := flags.Int("n", 50, "最多显示的记录数，0表示不限制")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageError(fmt.Sprintf("多余的参数: %s", strings.Join(positional, " ")))
+	}
+
+	ctx, cancel := tool.commandContext()
+	defer cancel()
+	histories, err := scriptdb.ListStatementExecutionHistory(ctx, tool.historyConn, scriptdb.StatementHistoryQuery{
+		ScriptName:      *scriptName,
+		ExecutionStatus: strings.ToUpper(*status),
+		DatabaseDriver:  tool.targetConn.GetDriver(),
+		Limit:           *limit,
+	})
+	if err != nil {
+		return err
+	}
+	if tool.jsonOutput {
+		return printJSON(histories)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STATEMENT_ID\tSCRIPT\tTYPE\tSTATUS\tEXECUTED_AT\tERROR")
+	for _, history := range histories {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			history.StatementId, history.ScriptName, history.StatementType, history.ExecutionStatus,
+			history.ExecutionTime.Format("2006-01-02 15:04:05"), truncate(history.ErrorMessage, 80))
+	}
+	return w.Flush()
+}
+
+// runRerun 重新执行历史中的单条语句
+func runRerun(tool *dbtool, args []string) error {
+	flags := newFlagSet("rerun")
+	statementId := flags.String("id", "", "语句执行记录ID，可通过 history 命令查看")
+	force := flags.Bool("force", false, "允许重新执行已成功的语句")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageError(fmt.Sprintf("多余的参数: %s", strings.Join(positional, " ")))
+	}
+	if *statementId == "" {
+		return usageError("必须通过 -id 指定语句执行记录ID")
+	}
+
+	ctx, cancel := tool.commandContext()
+	defer cancel()
+	history, err := scriptdb.RerunStatement(ctx, tool.historyConn, tool.targetConn, *statementId, *force)
+	if err != nil {
+		return err
+	}
+	if tool.jsonOutput {
+		return printJSON(map[string]interface{}{
+			"statementId":    history.StatementId,
+			"scriptName":     history.ScriptName,
+			"previousStatus": history.ExecutionStatus,
+			"status":         "SUCCESS",
+		})
+	}
+	fmt.Printf("语句 %s (%s) 重新执行成功，状态 %s -> SUCCESS\n", history.StatementId, history.ScriptName, history.ExecutionStatus)
+	return nil
+}
+
+// runExport 导出脚本或语句执行历史
+func runExport(tool *dbtool, args []string) error {
+	flags := newFlagSet("export")
+	historyType := flags.String("type", "statement", "导出类型: statement（语句级）或 script（脚本级）")
+	format := flags.String("format", "csv", "导出格式: csv 或 json")
+	outFile := flags.String("out", "", "输出文件，默认输出到标准输出")
+	scriptName := flags.String("script", "", "脚本文件名")
+	status := flags.String("status", "", "执行状态，为空时导出全部")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageError(fmt.Sprintf("多余的参数: %s", strings.Join(positional, " ")))
+	}
+	if *historyType != "statement" && *historyType != "script" {
+		return usageError(fmt.Sprintf("不支持的导出类型: %s", *historyType))
+	}
+	if *format != "csv" && *format != "json" {
+		return usageError(fmt.Sprintf("不支持的导出格式: %s", *format))
+	}
+
+	ctx, cancel := tool.commandContext()
+	defer cancel()
+
+	driver := tool.targetConn.GetDriver()
+	var header []string
+	var rows [][]string
+	var records interface{}
+	if *historyType == "statement" {
+		histories, err := scriptdb.ListStatementExecutionHistory(ctx, tool.historyConn, scriptdb.StatementHistoryQuery{
+			ScriptName:      *scriptName,
+			ExecutionStatus: strings.ToUpper(*status),
+			DatabaseDriver:  driver,
+		})
+		if err != nil {
+			return err
+		}
+		header = []string{"statementId", "scriptName", "statementHash", "statementType", "databaseDriver",
+			"executionStatus", "executionTime", "executionDuration", "errorMessage", "statementContent"}
+		for _, h := range histories {
+			rows = append(rows, []string{h.StatementId, h.ScriptName, h.StatementHash, h.StatementType, h.DatabaseDriver,
+				h.ExecutionStatus, h.ExecutionTime.Format(time.RFC3339), strconv.FormatInt(h.ExecutionDuration, 10),
+				h.ErrorMessage, h.StatementContent})
+		}
+		records = histories
+	} else {
+		histories, err := scriptdb.GetScriptExecutionHistory(ctx, tool.historyConn, *scriptName, 0)
+		if err != nil {
+			return err
+		}
+		// 脚本级历史不区分目标连接，按目标驱动和状态过滤
+		filtered := make([]scriptdb.ScriptExecutionHistory, 0, len(histories))
+		for _, h := range histories {
+			if h.DatabaseDriver != driver || (*status != "" && !strings.EqualFold(h.ExecutionStatus, *status)) {
+				continue
+			}
+			filtered = append(filtered, h)
+		}
+		header = []string{"executionId", "scriptName", "scriptPath", "scriptVersion", "databaseDriver",
+			"executionStatus", "executionTime", "executionDuration", "statementsExecuted", "errorMessage"}
+		for _, h := range filtered {
+			rows = append(rows, []string{h.ExecutionId, h.ScriptName, h.ScriptPath, h.ScriptVersion, h.DatabaseDriver,
+				h.ExecutionStatus, h.ExecutionTime.Format(time.RFC3339), strconv.FormatInt(h.ExecutionDuration, 10),
+				strconv.Itoa(h.StatementsExecuted), h.ErrorMessage})
+		}
+		records = filtered
+	}
+
+	var out io.Writer = os.Stdout
+	if *outFile != "" {
+		file, err := os.Create(*outFile)
+		if err != nil {
+			return fmt.Errorf("创建输出文件失败: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(records)
+	} else {
+		writer := csv.NewWriter(out)
+		writer.Write(header)
+		writer.WriteAll(rows)
+		err = writer.Error()
+	}
+	if err != nil {
+		return fmt.Errorf("写入导出数据失败: %w", err)
+	}
+	if *outFile != "" {
+		fmt.Fprintf(os.Stderr, "已导出 %d 条记录到 %s\n", len(rows), *outFile)
+	}
+	return nil
+}
+
+// printJSON 以缩进格式输出JSON
+func printJSON(value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// truncate 截断过长的文本并去除换行，便于表格输出
+func truncate(s string, maxLen int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len([]rune(s)) <= maxLen {
+		return s
+	}
+	return string([]rune(s)[:maxLen]) + "..."
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"gateway/pkg/config"
+	"gateway/pkg/database"
+	_ "gateway/pkg/database/alldriver" // 导入数据库驱动以确保注册
+	"gateway/pkg/logger"
+)
+
+const version = "1.0.0"
+
+// 退出码
+const (
+	exitOK    = 0 // 执行成功
+	exitError = 1 // 执行失败
+	exitUsage = 2 // 参数错误
+)
+
+// command 子命令定义
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(tool *dbtool, args []string) error
+}
+
+// dbtool 命令执行上下文
+type dbtool struct {
+	historyConn database.Database // 记录脚本执行历史的主数据库连接
+	targetConn  database.Database // 执行脚本的目标数据库连接
+	targetName  string            // 目标数据库连接名称
+	timeout     time.Duration
+	jsonOutput  bool
+}
+
+var commands = []command{
+	{"migrate", "migrate up|status | migrate down -script <脚本名> -yes", "执行升级脚本、查看迁移状态或回滚单个脚本", runMigrate},
+	{"history", "history [-script <脚本名>] [-status FAILED] [-n 50]", "列出语句执行历史，用于查找需要重新执行的语句", runHistory},
+	{"rerun", "rerun -id <语句记录ID> [-force]", "重新执行历史中的单条语句并更新执行状态", runRerun},
+	{"export", "export [-type statement|script] [-format csv|json] [-out <文件>]", "导出脚本或语句执行历史", runExport},
+}
+
+func main() {
+	var (
+		connName    = flag.String("db", "", "目标数据库连接名称，默认为 database.default；执行历史始终记录在默认连接中")
+		output      = flag.String("o", "text", "输出格式: text 或 json")
+		timeout     = flag.Duration("timeout", 30*time.Minute, "命令执行超时时间")
+		verbose     = flag.Bool("verbose", false, "按配置文件输出执行日志")
+		showVersion = flag.Bool("v", false, "显示版本信息")
+	)
+	flag.Usage = usage
+	// 命令行由 config 包统一解析，同时处理 -config 参数和 GATEWAY_CONFIG_DIR 环境变量
+	configDir := config.GetConfigDir()
+
+	if *showVersion {
+		fmt.Printf("dbtool version %s\n", version)
+		return
+	}
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(exitUsage)
+	}
+	if *output != "text" && *output != "json" {
+		fmt.Fprintf(os.Stderr, "不支持的输出格式: %s\n", *output)
+		os.Exit(exitUsage)
+	}
+
+	name := flag.Arg(0)
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		tool, err := open(configDir, *connName, *verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+			os.Exit(exitError)
+		}
+		tool.timeout = *timeout
+		tool.jsonOutput = *output == "json"
+
+		code := runCommand(tool, cmd, flag.Args()[1:])
+		database.CloseAllConnections()
+		os.Exit(code)
+	}
+
+	fmt.Fprintf(os.Stderr, "未知命令: %s\n\n", name)
+	usage()
+	os.Exit(exitUsage)
+}
+
+// open 加载配置并打开数据库连接
+// 参数:
+//
+//	configDir: 配置目录
+//	connName: 目标数据库连接名称，为空时使用默认连接
+//	verbose: 是否初始化日志
+func open(configDir, connName string, verbose bool) (*dbtool, error) {
+	if err := config.InitializeConfig(configDir, config.LoadOptions{
+		ClearExisting: false,
+		AllowOverride: true,
+	}); err != nil {
+		return nil, fmt.Errorf("加载配置失败: %w", err)
+	}
+	if verbose {
+		if err := logger.Setup(); err != nil {
+			return nil, fmt.Errorf("初始化日志失败: %w", err)
+		}
+	}
+
+	defaultConn := config.GetString("database.default", "")
+	if defaultConn == "" {
+		return nil, fmt.Errorf("未指定默认数据库连接")
+	}
+	if connName == "" {
+		connName = defaultConn
+	}
+
+	connections, err := database.LoadAllConnections(config.GetConfigPath("database.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	historyConn, ok := connections[defaultConn]
+	if !ok {
+		return nil, fmt.Errorf("默认数据库连接 '%s' 未找到或未启用", defaultConn)
+	}
+	targetConn, ok := connections[connName]
+	if !ok {
+		return nil, fmt.Errorf("数据库连接 '%s' 未找到或未启用", connName)
+	}
+
+	return &dbtool{
+		historyConn: historyConn,
+		targetConn:  targetConn,
+		targetName:  connName,
+	}, nil
+}
+
+// runCommand 执行子命令并返回退出码
+func runCommand(tool *dbtool, cmd command, args []string) int {
+	err := cmd.run(tool, args)
+	if err == nil || err == flag.ErrHelp {
+		return exitOK
+	}
+	if _, ok := err.(usageError); ok {
+		if err.Error() != "" {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		fmt.Fprintf(os.Stderr, "用法: dbtool [全局选项] %s\n", cmd.usage)
+		return exitUsage
+	}
+	fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+	return exitError
+}
+
+// usageError 参数错误，以退出码2退出并输出子命令用法
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// commandContext 创建命令执行的超时上下文
+func (tool *dbtool) commandContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), tool.timeout)
+}
+
+// usage 输出帮助信息
+func usage() {
+	fmt.Fprintf(os.Stderr, "dbtool - 数据库脚本迁移与执行历史管理工具 (version %s)\n", version)
+	fmt.Fprintf(os.Stderr, "\n用法: dbtool [全局选项] <命令> [命令选项]\n\n")
+	fmt.Fprintf(os.Stderr, "命令:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-66s %s\n", cmd.usage, cmd.summary)
+	}
+	fmt.Fprintf(os.Stderr, "\n全局选项:\n")
+	flag.PrintDefaults()
+	fmt.Fprintf(os.Stderr, "\n示例:\n")
+	fmt.Fprintf(os.Stderr, "  # 查看各脚本的执行情况\n")
+	fmt.Fprintf(os.Stderr, "  dbtool -config ./configs migrate status\n\n")
+	fmt.Fprintf(os.Stderr, "  # 查找执行失败的语句并在修复后重新执行\n")
+	fmt.Fprintf(os.Stderr, "  dbtool history -status FAILED\n")
+	fmt.Fprintf(os.Stderr, "  dbtool rerun -id 3f2a9c0e8b7d4e1f9a6b5c4d3e2f1a0b\n\n")
+	fmt.Fprintf(os.Stderr, "  # 回滚脚本（执行 <驱动目录>/down/ 下的同名脚本）\n")
+	fmt.Fprintf(os.Stderr, "  dbtool migrate down -script HUB_GW_ROUTE.sql -yes\n\n")
+	fmt.Fprintf(os.Stderr, "  # 导出ClickHouse语句执行历史\n")
+	fmt.Fprintf(os.Stderr, "  dbtool -db clickhouse_main export -format csv -out ck_history.csv\n\n")
+	fmt.Fprintf(os.Stderr, "退出码: 0 成功, 1 执行失败, 2 参数错误\n")
+}
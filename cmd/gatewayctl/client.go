@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// apiResponse 管理接口统一响应结构，与 web/utils/response.JsonData 对应
+type apiResponse struct {
+	OK            bool   `json:"oK"`
+	BizData       string `json:"bizData"`
+	PageQueryData string `json:"pageQueryData"`
+	MessageId     string `json:"messageId"`
+	ErrMsg        string `json:"errMsg"`
+}
+
+// adminClient 网关管理接口客户端
+type adminClient struct {
+	server    string
+	sessionId string
+	userId    string
+	password  string
+	http      *http.Client
+}
+
+// newAdminClient 创建管理接口客户端
+// 参数:
+//
+//	server: 管理服务地址，例如 http://127.0.0.1:12003
+//	sessionId: 已有会话ID，为空时使用用户名密码登录
+//	userId: 登录用户名
+//	password: 登录密码
+//	timeout: 单次请求超时时间
+func newAdminClient(server, sessionId, userId, password string, timeout time.Duration) *adminClient {
+	return &adminClient{
+		server:    strings.TrimRight(server, "/"),
+		sessionId: sessionId,
+		userId:    userId,
+		password:  password,
+		http:      &http.Client{Timeout: timeout},
+	}
+}
+
+// login 使用用户名密码登录并保存会话ID
+func (c *adminClient) login() error {
+	if c.userId == "" || c.password == "" {
+		return fmt.Errorf("未提供会话ID，请通过 -session 或 GATEWAYCTL_SESSION 指定，或提供 -u/-p 登录")
+	}
+	resp, err := c.do("/gateway/user/login", map[string]interface{}{
+		"userId":   c.userId,
+		"password": c.password,
+	})
+	if err != nil {
+		return fmt.Errorf("登录失败: %w", err)
+	}
+
+	var session struct {
+		SessionId string `json:"sessionId"`
+	}
+	if err := json.Unmarshal([]byte(resp.BizData), &session); err != nil || session.SessionId == "" {
+		return fmt.Errorf("登录失败: 响应中没有会话ID")
+	}
+	c.sessionId = session.SessionId
+	return nil
+}
+
+// call 调用管理接口，未登录时先登录
+// 参数:
+//
+//	path: 接口路径，例如 /gateway/hub0020/reloadGatewayInstance
+//	params: 请求参数，以JSON请求体发送
+//
+// 返回:
+//
+//	*apiResponse: 接口响应，oK为false时返回错误
+//	error: 网络错误或接口返回失败
+func (c *adminClient) call(path string, params map[string]interface{}) (*apiResponse, error) {
+	if c.sessionId == "" {
+		if err := c.login(); err != nil {
+			return nil, err
+		}
+	}
+	return c.do(path, params)
+}
+
+// do 发送POST请求并解析统一响应结构
+func (c *adminClient) do(path string, params map[string]interface{}) (*apiResponse, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.server+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.sessionId != "" {
+		req.Header.Set("X-Session-Id", c.sessionId)
+	}
+
+	httpResp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求 %s 失败: %w", path, err)
+	}
+	defer httpResp.Body.Close()
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	resp := &apiResponse{}
+	if err := json.Unmarshal(data, resp); err != nil {
+		return nil, fmt.Errorf("请求 %s 失败: HTTP %d", path, httpResp.StatusCode)
+	}
+	if !resp.OK {
+		message := resp.ErrMsg
+		if message == "" {
+			message = fmt.Sprintf("HTTP %d", httpResp.StatusCode)
+		}
+		return resp, fmt.Errorf("%s", message)
+	}
+	return resp, nil
+}
+
+// decodeBizData 将响应中的业务数据解析到目标结构
+func decodeBizData(resp *apiResponse, target interface{}) error {
+	if resp.BizData == "" {
+		return fmt.Errorf("响应中没有业务数据")
+	}
+	if err := json.Unmarshal([]byte(resp.BizData), target); err != nil {
+		return fmt.Errorf("解析业务数据失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,438 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+)
+
+// 节点运行状态，与 HUB_GW_SERVICE_NODE.nodeStatus 对应
+const (
+	nodeStatusOnline      = 1
+	nodeStatusMaintenance = 2
+)
+
+// maxLogPageSize 访问日志查询接口允许的最大分页大小
+const maxLogPageSize = 100
+
+// newFlagSet 创建子命令参数解析器
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+// parseFlags 解析子命令参数，允许选项出现在位置参数之后
+// 返回:
+//
+//	[]string: 位置参数
+//	error: 参数错误时返回 usageError，请求帮助时返回 flag.ErrHelp
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			if err == flag.ErrHelp {
+				return nil, err
+			}
+			// 解析器已输出错误和选项说明
+			return nil, usageError("")
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// requireInstance 检查必填的网关实例ID
+func requireInstance(instanceId string) error {
+	if instanceId == "" {
+		return usageError("必须通过 -i 指定网关实例ID")
+	}
+	return nil
+}
+
+// runLogin 登录并输出会话ID
+func runLogin(ctl *gatewayctl, args []string) error {
+	if _, err := parseFlags(newFlagSet("login"), args); err != nil {
+		return err
+	}
+	if err := ctl.client.login(); err != nil {
+		return err
+	}
+	if ctl.jsonOutput {
+		return printJSON(map[string]string{"sessionId": ctl.client.sessionId})
+	}
+	fmt.Println(ctl.client.sessionId)
+	return nil
+}
+
+// runtimeConfig 生效配置接口返回的数据，只解析命令需要的字段
+type runtimeConfig struct {
+	GatewayInstanceId string `json:"gatewayInstanceId"`
+	ConfigVersion     string `json:"configVersion"`
+	MaintenanceMode   string `json:"maintenanceMode"`
+	Config            struct {
+		Router struct {
+			Routes []struct {
+				ID         string   `json:"id"`
+				Name       string   `json:"name"`
+				Path       string   `json:"path"`
+				Methods    []string `json:"methods"`
+				ServiceID  string   `json:"service_id"`
+				ServiceIDs []string `json:"service_ids"`
+				Priority   int      `json:"priority"`
+				Enabled    bool     `json:"enabled"`
+			} `json:"routes"`
+		} `json:"router"`
+	} `json:"config"`
+}
+
+// runRoutes 列出网关实例当前生效的路由
+func runRoutes(ctl *gatewayctl, args []string) error {
+	flags := newFlagSet("routes")
+	instanceId := flags.String("i", "", "网关实例ID")
+	if _, err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireInstance(*instanceId); err != nil {
+		return err
+	}
+
+	resp, err := ctl.client.call("/gateway/hub0020/getGatewayRuntimeConfig", map[string]interface{}{
+		"gatewayInstanceId": *instanceId,
+	})
+	if err != nil {
+		return err
+	}
+	var runtime runtimeConfig
+	if err := decodeBizData(resp, &runtime); err != nil {
+		return err
+	}
+
+	routes := runtime.Config.Router.Routes
+	if ctl.jsonOutput {
+		return printJSON(routes)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tPATH\tMETHODS\tSERVICE\tPRIORITY\tENABLED")
+	for _, route := range routes {
+		services := route.ServiceIDs
+		if len(services) == 0 && route.ServiceID != "" {
+			services = []string{route.ServiceID}
+		}
+		methods := "*"
+		if len(route.Methods) > 0 {
+			methods = strings.Join(route.Methods, ",")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%t\n",
+			route.ID, route.Name, route.Path, methods, strings.Join(services, ","), route.Priority, route.Enabled)
+	}
+	return w.Flush()
+}
+
+// runDrain 将服务节点置为维护状态，网关重新加载配置后该节点不再接收流量
+// 指定实例时立即重载配置；-undo 恢复节点为在线状态
+func runDrain(ctl *gatewayctl, args []string) error {
+	flags := newFlagSet("drain")
+	serviceNodeId := flags.String("node", "", "服务节点ID")
+	instanceId := flags.String("i", "", "网关实例ID，指定时更新节点状态后立即重载该实例配置")
+	undo := flags.Bool("undo", false, "恢复节点为在线状态")
+	if _, err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *serviceNodeId == "" {
+		return usageError("必须通过 -node 指定服务节点ID")
+	}
+
+	nodeStatus := nodeStatusMaintenance
+	if *undo {
+		nodeStatus = nodeStatusOnline
+	}
+	resp, err := ctl.client.call("/gateway/hub0022/updateNodeStatus", map[string]interface{}{
+		"serviceNodeId": *serviceNodeId,
+		"nodeStatus":    nodeStatus,
+	})
+	if err != nil {
+		return err
+	}
+
+	reloaded := false
+	if *instanceId != "" {
+		if _, err := ctl.client.call("/gateway/hub0020/reloadGatewayInstance", map[string]interface{}{
+			"gatewayInstanceId": *instanceId,
+		}); err != nil {
+			return fmt.Errorf("节点状态已更新，但重载网关配置失败: %w", err)
+		}
+		reloaded = true
+	}
+
+	if ctl.jsonOutput {
+		var node map[string]interface{}
+		if err := decodeBizData(resp, &node); err != nil {
+			return err
+		}
+		return printJSON(map[string]interface{}{"node": node, "reloaded": reloaded})
+	}
+	action := "已摘除流量"
+	if *undo {
+		action = "已恢复在线"
+	}
+	fmt.Printf("服务节点 %s %s\n", *serviceNodeId, action)
+	if !reloaded {
+		fmt.Println("提示: 未指定 -i，变更将在网关实例下次重载配置后生效")
+	}
+	return nil
+}
+
+// runMaintenance 开启或关闭网关实例维护模式
+func runMaintenance(ctl *gatewayctl, args []string) error {
+	flags := newFlagSet("maintenance")
+	instanceId := flags.String("i", "", "网关实例ID")
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := requireInstance(*instanceId); err != nil {
+		return err
+	}
+	if len(positional) != 1 || (positional[0] != "on" && positional[0] != "off") {
+		return usageError("必须指定 on 或 off")
+	}
+
+	maintenanceMode := "N"
+	if positional[0] == "on" {
+		maintenanceMode = "Y"
+	}
+	resp, err := ctl.client.call("/gateway/hub0020/updateGatewayMaintenance", map[string]interface{}{
+		"gatewayInstanceId": *instanceId,
+		"maintenanceMode":   maintenanceMode,
+	})
+	if err != nil {
+		return err
+	}
+	if ctl.jsonOutput {
+		return printBizData(resp)
+	}
+	fmt.Printf("网关实例 %s 维护模式已%s\n", *instanceId, map[string]string{"Y": "开启", "N": "关闭"}[maintenanceMode])
+	return nil
+}
+
+// runReload 从数据库重新加载网关实例配置
+func runReload(ctl *gatewayctl, args []string) error {
+	flags := newFlagSet("reload")
+	instanceId := flags.String("i", "", "网关实例ID")
+	if _, err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireInstance(*instanceId); err != nil {
+		return err
+	}
+
+	resp, err := ctl.client.call("/gateway/hub0020/reloadGatewayInstance", map[string]interface{}{
+		"gatewayInstanceId": *instanceId,
+	})
+	if err != nil {
+		return err
+	}
+	if ctl.jsonOutput {
+		return printBizData(resp)
+	}
+	fmt.Printf("网关实例 %s 配置重载成功\n", *instanceId)
+	return nil
+}
+
+// runConfig 输出网关实例当前生效的配置
+func runConfig(ctl *gatewayctl, args []string) error {
+	flags := newFlagSet("config")
+	instanceId := flags.String("i", "", "网关实例ID")
+	if _, err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireInstance(*instanceId); err != nil {
+		return err
+	}
+
+	resp, err := ctl.client.call("/gateway/hub0020/getGatewayRuntimeConfig", map[string]interface{}{
+		"gatewayInstanceId": *instanceId,
+	})
+	if err != nil {
+		return err
+	}
+	// 配置本身是结构化数据，文本和JSON输出一致
+	return printBizData(resp)
+}
+
+// accessLog 访问日志查询接口返回的日志摘要，只解析命令需要的字段
+type accessLog struct {
+	TraceId                    string    `json:"traceId"`
+	GatewayStartProcessingTime time.Time `json:"gatewayStartProcessingTime"`
+	RequestMethod              string    `json:"requestMethod"`
+	RequestPath                string    `json:"requestPath"`
+	GatewayStatusCode          int       `json:"gatewayStatusCode"`
+	TotalProcessingTimeMs      int       `json:"totalProcessingTimeMs"`
+	ClientIpAddress            string    `json:"clientIpAddress"`
+	RouteName                  string    `json:"routeName"`
+}
+
+// runLogs 输出最近的访问日志，-f 时按间隔轮询并持续输出新日志，直到收到中断信号
+func runLogs(ctl *gatewayctl, args []string) error {
+	flags := newFlagSet("logs")
+	instanceId := flags.String("i", "", "网关实例ID")
+	count := flags.Int("n", 20, "首次输出的日志条数（最大100）")
+	follow := flags.Bool("f", false, "持续输出新日志")
+	interval := flags.Duration("interval", 2*time.Second, "持续输出时的轮询间隔")
+	if _, err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireInstance(*instanceId); err != nil {
+		return err
+	}
+	if *count < 1 || *count > maxLogPageSize {
+		return usageError(fmt.Sprintf("-n 必须在1到%d之间", maxLogPageSize))
+	}
+	if *interval <= 0 {
+		return usageError("-interval 必须大于0")
+	}
+
+	logs, err := queryAccessLogs(ctl.client, *instanceId, *count, time.Time{})
+	if err != nil {
+		return err
+	}
+	printAccessLogs(ctl, logs)
+	if !*follow {
+		return nil
+	}
+
+	// 以已输出的最新日志时间为起点轮询，同一时间点的日志按traceId去重
+	var since time.Time
+	seen := make(map[string]bool)
+	markSeen := func(logs []accessLog) {
+		for _, log := range logs {
+			if log.GatewayStartProcessingTime.After(since) {
+				since = log.GatewayStartProcessingTime
+				seen = make(map[string]bool)
+			}
+		}
+		for _, log := range logs {
+			if log.GatewayStartProcessingTime.Equal(since) {
+				seen[log.TraceId] = true
+			}
+		}
+	}
+	markSeen(logs)
+	if since.IsZero() {
+		since = time.Now()
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-signals:
+			return nil
+		case <-ticker.C:
+		}
+
+		logs, err := queryAccessLogs(ctl.client, *instanceId, maxLogPageSize, since)
+		if err != nil {
+			// 轮询期间的临时错误不中断输出
+			fmt.Fprintf(os.Stderr, "查询访问日志失败: %v\n", err)
+			continue
+		}
+		var fresh []accessLog
+		for _, log := range logs {
+			if !seen[log.TraceId] {
+				fresh = append(fresh, log)
+			}
+		}
+		printAccessLogs(ctl, fresh)
+		markSeen(fresh)
+	}
+}
+
+// queryAccessLogs 查询网关实例的访问日志
+// 参数:
+//
+//	client: 管理接口客户端
+//	instanceId: 网关实例ID
+//	limit: 最多返回的条数
+//	since: 开始时间（包含），零值表示不限制
+//
+// 返回:
+//
+//	[]accessLog: 按时间升序排列的访问日志
+//	error: 查询失败时返回错误
+func queryAccessLogs(client *adminClient, instanceId string, limit int, since time.Time) ([]accessLog, error) {
+	params := map[string]interface{}{
+		"pageIndex":         1,
+		"pageSize":          limit,
+		"gatewayInstanceId": instanceId,
+	}
+	if !since.IsZero() {
+		params["startTime"] = since.Format(time.RFC3339Nano)
+	}
+
+	resp, err := client.call("/gateway/hub0023/gateway-log/query", params)
+	if err != nil {
+		return nil, err
+	}
+	var logs []accessLog
+	if resp.BizData != "" {
+		if err := decodeBizData(resp, &logs); err != nil {
+			return nil, err
+		}
+	}
+
+	// 接口按时间倒序返回，输出时按时间顺序
+	for i, j := 0, len(logs)-1; i < j; i, j = i+1, j-1 {
+		logs[i], logs[j] = logs[j], logs[i]
+	}
+	return logs, nil
+}
+
+// printAccessLogs 输出访问日志，JSON格式时每行一条
+func printAccessLogs(ctl *gatewayctl, logs []accessLog) {
+	for _, log := range logs {
+		if ctl.jsonOutput {
+			data, _ := json.Marshal(log)
+			fmt.Println(string(data))
+			continue
+		}
+		fmt.Printf("%s %d %-6s %s %dms client=%s route=%s trace=%s\n",
+			log.GatewayStartProcessingTime.Local().Format("2006-01-02 15:04:05.000"),
+			log.GatewayStatusCode, log.RequestMethod, log.RequestPath,
+			log.TotalProcessingTimeMs, log.ClientIpAddress, log.RouteName, log.TraceId)
+	}
+}
+
+// printJSON 以缩进格式输出JSON
+func printJSON(value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// printBizData 以缩进格式输出响应中的业务数据
+func printBizData(resp *apiResponse) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(resp.BizData), "", "  "); err != nil {
+		fmt.Println(resp.BizData)
+		return nil
+	}
+	fmt.Println(buf.String())
+	return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+const version = "1.0.0"
+
+// 退出码
+const (
+	exitOK    = 0 // 执行成功
+	exitError = 1 // 执行失败
+	exitUsage = 2 // 参数错误
+)
+
+// command 子命令定义
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(ctl *gatewayctl, args []string) error
+}
+
+// gatewayctl 命令执行上下文
+type gatewayctl struct {
+	client     *adminClient
+	jsonOutput bool
+}
+
+var commands = []command{
+	{"login", "login", "登录并输出会话ID，可保存到 GATEWAYCTL_SESSION 供后续命令使用", runLogin},
+	{"routes", "routes -i <实例ID>", "列出网关实例当前生效的路由", runRoutes},
+	{"drain", "drain -node <服务节点ID> [-i <实例ID>] [-undo]", "将服务节点置为维护状态以摘除流量，指定实例时立即重载配置", runDrain},
+	{"maintenance", "maintenance -i <实例ID> on|off", "开启或关闭网关实例维护模式，维护模式下新请求返回503", runMaintenance},
+	{"reload", "reload -i <实例ID>", "从数据库重新加载网关实例配置", runReload},
+	{"logs", "logs -i <实例ID> [-n 20] [-f] [-interval 2s]", "查看最近的访问日志，-f 持续输出新日志", runLogs},
+	{"config", "config -i <实例ID>", "输出网关实例当前生效的配置", runConfig},
+}
+
+func main() {
+	var (
+		server      = flag.String("server", envOrDefault("GATEWAYCTL_SERVER", "http://127.0.0.1:12003"), "管理服务地址（环境变量 GATEWAYCTL_SERVER）")
+		session     = flag.String("session", os.Getenv("GATEWAYCTL_SESSION"), "会话ID（环境变量 GATEWAYCTL_SESSION）")
+		userId      = flag.String("u", os.Getenv("GATEWAYCTL_USER"), "登录用户名，未提供会话ID时使用（环境变量 GATEWAYCTL_USER）")
+		password    = flag.String("p", os.Getenv("GATEWAYCTL_PASSWORD"), "登录密码（环境变量 GATEWAYCTL_PASSWORD）")
+		output      = flag.String("o", "text", "输出格式: text 或 json")
+		timeout     = flag.Duration("timeout", 10*time.Second, "单次请求超时时间")
+		showVersion = flag.Bool("v", false, "显示版本信息")
+	)
+	flag.Usage = usage
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Printf("gatewayctl version %s\n", version)
+		return
+	}
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(exitUsage)
+	}
+	if *output != "text" && *output != "json" {
+		fmt.Fprintf(os.Stderr, "不支持的输出格式: %s\n", *output)
+		os.Exit(exitUsage)
+	}
+
+	name := flag.Arg(0)
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		ctl := &gatewayctl{
+			client:     newAdminClient(*server, *session, *userId, *password, *timeout),
+			jsonOutput: *output == "json",
+		}
+		if err := cmd.run(ctl, flag.Args()[1:]); err != nil {
+			if err == flag.ErrHelp {
+				os.Exit(exitOK)
+			}
+			if _, ok := err.(usageError); ok {
+				if err.Error() != "" {
+					fmt.Fprintln(os.Stderr, err)
+				}
+				fmt.Fprintf(os.Stderr, "用法: gatewayctl [全局选项] %s\n", cmd.usage)
+				os.Exit(exitUsage)
+			}
+			fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+			os.Exit(exitError)
+		}
+		os.Exit(exitOK)
+	}
+
+	fmt.Fprintf(os.Stderr, "未知命令: %s\n\n", name)
+	usage()
+	os.Exit(exitUsage)
+}
+
+// usageError 参数错误，以退出码2退出并输出子命令用法
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// usage 输出帮助信息
+func usage() {
+	fmt.Fprintf(os.Stderr, "gatewayctl - 网关运行时管理工具 (version %s)\n", version)
+	fmt.Fprintf(os.Stderr, "\n用法: gatewayctl [全局选项] <命令> [命令选项]\n\n")
+	fmt.Fprintf(os.Stderr, "命令:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-50s %s\n", cmd.usage, cmd.summary)
+	}
+	fmt.Fprintf(os.Stderr, "\n全局选项:\n")
+	flag.PrintDefaults()
+	fmt.Fprintf(os.Stderr, "\n示例:\n")
+	fmt.Fprintf(os.Stderr, "  # 登录并保存会话\n")
+	fmt.Fprintf(os.Stderr, "  export GATEWAYCTL_SESSION=$(gatewayctl -u admin -p '******' login)\n\n")
+	fmt.Fprintf(os.Stderr, "  # 摘除节点流量并立即生效\n")
+	fmt.Fprintf(os.Stderr, "  gatewayctl drain -node node-001 -i gw-001\n\n")
+	fmt.Fprintf(os.Stderr, "  # 开启维护模式\n")
+	fmt.Fprintf(os.Stderr, "  gatewayctl maintenance -i gw-001 on\n\n")
+	fmt.Fprintf(os.Stderr, "  # 持续输出访问日志\n")
+	fmt.Fprintf(os.Stderr, "  gatewayctl logs -i gw-001 -f\n\n")
+	fmt.Fprintf(os.Stderr, "退出码: 0 成功, 1 执行失败, 2 参数错误\n")
+}
+
+// envOrDefault 读取环境变量，未设置时返回默认值
+func envOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"gateway/internal/gateway/bootstrap"
 	gatewayconfig "gateway/internal/gateway/config"
@@ -94,7 +95,53 @@ func (app *GatewayApp) Stop() error {
 	return nil
 }
 
-// GetStatus 获取网关状态
+// StartInstance 启动单个网关实例
+func (app *GatewayApp) StartInstance(instanceID string) error {
+	return app.pool.StartInstance(instanceID)
+}
+
+// StopInstance 停止单个网关实例，其他实例不受影响
+func (app *GatewayApp) StopInstance(instanceID string) error {
+	return app.pool.StopInstance(instanceID)
+}
+
+// RestartInstance 重启单个网关实例
+func (app *GatewayApp) RestartInstance(instanceID string) error {
+	return app.pool.RestartInstance(instanceID)
+}
+
+// ApplyInstanceConfig 为单个网关实例应用配置
+// 实例已存在时热重载配置，不存在时创建实例；start为true时确保实例处于运行状态
+func (app *GatewayApp) ApplyInstanceConfig(cfg *gatewayconfig.GatewayConfig, source string, start bool) error {
+	if cfg == nil || cfg.InstanceID == "" {
+		return huberrors.NewError("网关配置缺少实例ID")
+	}
+
+	if app.pool.Exists(cfg.InstanceID) {
+		gateway, err := app.pool.Get(cfg.InstanceID)
+		if err != nil {
+			return err
+		}
+		if err := gateway.Reload(cfg); err != nil {
+			return huberrors.WrapError(err, "应用网关实例配置失败: %s", cfg.InstanceID)
+		}
+		logger.Info("网关实例配置已应用", "instanceId", cfg.InstanceID, "source", source)
+	} else if err := app.createGatewayInstance(cfg, source); err != nil {
+		return err
+	}
+
+	if !start {
+		return nil
+	}
+	return app.pool.StartInstance(cfg.InstanceID)
+}
+
+// GetInstanceStatus 获取单个网关实例的运行状态
+func (app *GatewayApp) GetInstanceStatus(instanceID string) (bootstrap.InstanceStatus, error) {
+	return app.pool.GetInstanceStatus(instanceID)
+}
+
+// GetStatus 获取网关状态，包含汇总计数和每个实例的端口、路由数、运行时长和错误数
 func (app *GatewayApp) GetStatus() map[string]interface{} {
 	status := map[string]interface{}{
 		"version":           GatewayVersion,
@@ -102,6 +149,7 @@ func (app *GatewayApp) GetStatus() map[string]interface{} {
 		"total_instances":   app.pool.Count(),
 		"running_instances": len(app.pool.GetRunningGateways()),
 		"instance_ids":      app.pool.GetInstanceIDs(),
+		"instances":         app.pool.GetAllInstanceStatus(),
 	}
 	return status
 }
@@ -122,20 +170,43 @@ func (app *GatewayApp) loadGatewayFromConfig() error {
 }
 
 // loadFromFile 从文件加载网关配置
+// 配置了 app.gateway.config_files 时每个文件创建一个网关实例，否则只加载 app.gateway.config_file
 func (app *GatewayApp) loadFromFile() error {
+	configFiles := config.GetStringSlice("app.gateway.config_files", nil)
+	if len(configFiles) == 0 {
+		return app.loadConfigFile(resolveGatewayConfigFile(config.GetString("app.gateway.config_file", "")))
+	}
+
+	var errors []string
+	for _, configFile := range configFiles {
+		if err := app.loadConfigFile(resolveGatewayConfigFile(configFile)); err != nil {
+			errors = append(errors, err.Error())
+			logger.Error("加载网关配置文件失败", err, "file", configFile)
+		}
+	}
+	if len(errors) == len(configFiles) {
+		return huberrors.NewError("所有网关配置文件加载失败: %v", errors)
+	}
+	return nil
+}
+
+// resolveGatewayConfigFile 解析网关配置文件路径
+func resolveGatewayConfigFile(configFile string) string {
 	// 使用统一的配置文件路径构建方式
-	configFile := config.GetString("app.gateway.config_file", "")
 	if configFile == "" {
 		// 如果配置中没有指定文件路径，使用默认路径
-		configFile = config.GetConfigPath("gateway.yaml")
-	} else {
-		// 如果指定了相对路径，且不是绝对路径，则基于配置目录构建
-		if !filepath.IsAbs(configFile) && !strings.HasPrefix(configFile, "./") && !strings.HasPrefix(configFile, "../") {
-			// 如果是纯文件名，则基于配置目录构建
-			configFile = config.GetConfigPath(configFile)
-		}
+		return config.GetConfigPath("gateway.yaml")
 	}
+	// 如果指定了相对路径，且不是绝对路径，则基于配置目录构建
+	if !filepath.IsAbs(configFile) && !strings.HasPrefix(configFile, "./") && !strings.HasPrefix(configFile, "../") {
+		// 如果是纯文件名，则基于配置目录构建
+		return config.GetConfigPath(configFile)
+	}
+	return configFile
+}
 
+// loadConfigFile 加载单个网关配置文件并创建网关实例
+func (app *GatewayApp) loadConfigFile(configFile string) error {
 	logger.Info("从文件加载网关配置", "file", configFile)
 
 	// 选择配置加载器
@@ -178,7 +249,8 @@ func (app *GatewayApp) loadFromDatabase() error {
 	ctx := context.Background()
 	err := app.db.Query(ctx, &gatewayInstances, query, nil, true)
 	if err != nil {
-		return huberrors.WrapError(err, "查询网关实例失败")
+		// 数据库不可用时从本地快照恢复网关实例
+		return app.loadFromSnapshot(huberrors.WrapError(err, "查询网关实例失败"))
 	}
 
 	// 检查是否找到实例
@@ -195,17 +267,22 @@ func (app *GatewayApp) loadFromDatabase() error {
 		// 创建数据库配置加载器，使用实例对应的租户ID
 		dbLoader := loader.NewDatabaseConfigLoader(app.db, instance.TenantID)
 
-		// 加载网关配置
+		// 加载网关配置，失败时从本地快照恢复
+		source := "database:" + instance.GatewayInstanceID
 		cfg, err := dbLoader.LoadGatewayConfig(instance.GatewayInstanceID)
 		if err != nil {
 			logger.Error("加载网关配置失败", err,
 				"tenantId", instance.TenantID,
 				"instanceId", instance.GatewayInstanceID)
-			continue
+			cfg, err = app.restoreConfigSnapshot(instance.GatewayInstanceID)
+			if err != nil {
+				continue
+			}
+			source = "snapshot:" + instance.GatewayInstanceID
 		}
 
 		// 创建网关实例
-		if err := app.createGatewayInstance(cfg, "database:"+instance.GatewayInstanceID); err != nil {
+		if err := app.createGatewayInstance(cfg, source); err != nil {
 			logger.Error("创建网关实例失败", err,
 				"tenantId", instance.TenantID,
 				"instanceId", instance.GatewayInstanceID)
@@ -220,6 +297,50 @@ func (app *GatewayApp) loadFromDatabase() error {
 	return nil
 }
 
+// loadFromSnapshot 数据库不可用时从本地快照恢复所有网关实例，以降级模式运行
+// 未启用快照或没有可用快照时返回 dbErr
+func (app *GatewayApp) loadFromSnapshot(dbErr error) error {
+	instanceIds, err := loader.ListGatewayConfigSnapshots()
+	if err != nil {
+		logger.Warn("读取网关配置快照失败", "error", err)
+	}
+	if len(instanceIds) == 0 {
+		return dbErr
+	}
+
+	logger.Warn("数据库不可用，从本地快照恢复网关实例", "count", len(instanceIds), "error", dbErr)
+	restored := 0
+	for _, instanceId := range instanceIds {
+		cfg, err := app.restoreConfigSnapshot(instanceId)
+		if err != nil {
+			continue
+		}
+		if err := app.createGatewayInstance(cfg, "snapshot:"+instanceId); err != nil {
+			logger.Error("创建网关实例失败", err, "instanceId", instanceId)
+			continue
+		}
+		restored++
+	}
+	if restored == 0 {
+		return dbErr
+	}
+	return nil
+}
+
+// restoreConfigSnapshot 从本地快照恢复网关实例配置
+func (app *GatewayApp) restoreConfigSnapshot(instanceId string) (*gatewayconfig.GatewayConfig, error) {
+	cfg, info, err := loader.RestoreGatewayConfigSnapshot(instanceId)
+	if err != nil {
+		logger.Warn("从快照恢复网关配置失败", "instanceId", instanceId, "error", err)
+		return nil, err
+	}
+	logger.Warn("已从本地快照恢复网关配置，以降级模式运行",
+		"instanceId", instanceId,
+		"savedAt", info.SavedAt.Format(time.RFC3339),
+		"age", info.Age(time.Now()).Round(time.Second))
+	return cfg, nil
+}
+
 // createGatewayInstance 创建网关实例并添加到连接池
 func (app *GatewayApp) createGatewayInstance(cfg *gatewayconfig.GatewayConfig, source string) error {
 	// 创建网关工厂
@@ -0,0 +1,32 @@
+package init
+
+import (
+	"fmt"
+
+	"gateway/pkg/config"
+	"gateway/pkg/logger"
+	"gateway/pkg/utils/geoip"
+)
+
+// InitGeoIP 初始化客户端地理位置库
+// 启用后网关按客户端IP查询国家/地区，写入请求上下文供路由断言和访问日志使用
+func InitGeoIP() error {
+	if !config.GetBool("app.geoip.enabled", false) {
+		logger.Info("地理位置库未启用")
+		return nil
+	}
+
+	databasePath := config.GetString("app.geoip.database_path", "")
+	if databasePath == "" {
+		return fmt.Errorf("已启用地理位置库但未配置 app.geoip.database_path")
+	}
+
+	reader, err := geoip.Open(databasePath)
+	if err != nil {
+		return fmt.Errorf("加载地理位置库失败: %w", err)
+	}
+	geoip.SetDefault(reader)
+
+	logger.Info("地理位置库加载完成", "path", databasePath, "databaseType", reader.DatabaseType)
+	return nil
+}
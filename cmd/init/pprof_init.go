@@ -61,6 +61,17 @@ func loadPprofConfig() *pprof.Config {
 	pprofConfig.ServiceName = config.GetString("app.pprof.service_name", pprofConfig.ServiceName)
 	pprofConfig.EnableAuth = config.GetBool("app.pprof.enable_auth", pprofConfig.EnableAuth)
 	pprofConfig.AuthToken = config.GetString("app.pprof.auth_token", pprofConfig.AuthToken)
+	pprofConfig.AllowedIPs = config.GetStringSlice("app.pprof.allowed_ips", pprofConfig.AllowedIPs)
+	if maxProfileStr := config.GetString("app.pprof.max_profile_duration", ""); maxProfileStr != "" {
+		if duration, err := time.ParseDuration(maxProfileStr); err == nil {
+			pprofConfig.MaxProfileDuration = duration
+		}
+	}
+	if sampleIntervalStr := config.GetString("app.pprof.runtime_sample_interval", ""); sampleIntervalStr != "" {
+		if duration, err := time.ParseDuration(sampleIntervalStr); err == nil {
+			pprofConfig.RuntimeSampleInterval = duration
+		}
+	}
 
 	// 读取timeout配置字符串并解析为Duration
 	if readTimeoutStr := config.GetString("app.pprof.read_timeout", "30s"); readTimeoutStr != "" {
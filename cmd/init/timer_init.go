@@ -3,11 +3,14 @@ package init
 import (
 	"context"
 	"fmt"
-	"gateway/internal/timerinit/sftp"
-	"gateway/pkg/config"
+	"gateway/internal/timerinit/common/taskinit"
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
 	"gateway/pkg/timer"
+
+	// 导入各任务模块，模块在包初始化时注册自己的执行器工厂
+	_ "gateway/internal/timerinit/cleanup"
+	_ "gateway/internal/timerinit/sftp"
 )
 
 // InitAllTimerTasks 初始化所有定时任务
@@ -28,19 +31,12 @@ func InitAllTimerTasks(ctx context.Context, db database.Database, tenantIds ...s
 	if db == nil {
 		return fmt.Errorf("数据库连接不能为空")
 	}
-	if config.GetBool("app.timer.sftp.enabled", false) {
-		// 初始化SFTP定时任务
-		if err := initSFTPTasks(ctx, db, tenantIds...); err != nil {
-			return fmt.Errorf("初始化SFTP定时任务失败: %w", err)
-		}
+	// 按已注册的执行器工厂初始化数据库中配置的任务，各执行器类型由自己的启用开关控制
+	// 新增任务类型只需在模块中注册执行器工厂并导入该模块
+	if err := taskinit.RegisterAllTasks(ctx, db, tenantIds...); err != nil {
+		return fmt.Errorf("初始化定时任务失败: %w", err)
 	}
 
-	// 这里可以添加其他类型的定时任务初始化
-	// 例如：SSH任务、FTP任务等
-	// if err := initSSHTasks(ctx, db, tenantIds...); err != nil {
-	//     return fmt.Errorf("初始化SSH定时任务失败: %w", err)
-	// }
-
 	logger.Info("所有定时任务初始化完成")
 	return nil
 }
@@ -64,28 +60,3 @@ func StopAllTimerTasks() error {
 	logger.Info("所有定时任务停止完成")
 	return nil
 }
-
-// initSFTPTasks 初始化SFTP定时任务
-// 内部函数，用于初始化SFTP定时任务
-func initSFTPTasks(ctx context.Context, db database.Database, tenantIds ...string) error {
-	logger.Info("开始初始化SFTP定时任务")
-
-	if err := sftp.RegisterSFTPTasks(ctx, db, tenantIds...); err != nil {
-		return err
-	}
-
-	logger.Info("SFTP定时任务初始化完成")
-	return nil
-}
-
-// 预留的SSH任务初始化函数，当SSH模块实现后可以启用
-// func initSSHTasks(ctx context.Context, db database.Database, tenantIds ...string) error {
-//     logger.Info("开始初始化SSH定时任务")
-//
-//     if err := ssh.RegisterSSHTasks(ctx, db, tenantIds...); err != nil {
-//         return err
-//     }
-//
-//     logger.Info("SSH定时任务初始化完成")
-//     return nil
-// }
@@ -0,0 +1,421 @@
+// openapigen 扫描各模块控制器源码，生成 OpenAPI 接口说明注册代码
+//
+// 对每个以 *gin.Context 为参数的控制器方法，提取：
+//   - 接口摘要和描述：取 @Summary / @Description 注释，没有时取文档注释首行
+//   - 请求模型：request.Bind* / BindSafely / ShouldBind* 绑定的变量类型
+//   - 请求参数：request.GetParam* 读取的参数名
+//   - 是否分页：调用了 request.GetPaginationParams 或 response.PageJSON
+//
+// 用法（在项目根目录执行，通常通过 go generate ./web/moduleimports 调用）:
+//
+//	go run ./cmd/openapigen -views web/views -out web/moduleimports/openapi_specs.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// modulePath 项目模块路径
+const modulePath = "gateway"
+
+// handlerInfo 单个处理函数的说明
+type handlerInfo struct {
+	name        string // 处理函数全名
+	summary     string
+	description string
+	request     string // 请求模型类型，格式为 包路径.类型名
+	params      []string
+	paged       bool
+}
+
+func main() {
+	var (
+		viewsDir = flag.String("views", "web/views", "模块源码目录")
+		outFile  = flag.String("out", "web/moduleimports/openapi_specs.go", "生成文件路径")
+	)
+	flag.Parse()
+
+	handlers, err := scanViews(*viewsDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "扫描控制器失败:", err)
+		os.Exit(1)
+	}
+	src, err := render(handlers)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "生成代码失败:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*outFile, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, "写入文件失败:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("已生成 %s，共 %d 个接口说明\n", *outFile, len(handlers))
+}
+
+// scanViews 扫描目录下所有 controllers 包
+func scanViews(viewsDir string) ([]handlerInfo, error) {
+	var handlers []handlerInfo
+	err := filepath.Walk(viewsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() || info.Name() != "controllers" {
+			return nil
+		}
+		pkgHandlers, err := scanPackage(path)
+		if err != nil {
+			return err
+		}
+		handlers = append(handlers, pkgHandlers...)
+		return nil
+	})
+	sort.Slice(handlers, func(i, j int) bool { return handlers[i].name < handlers[j].name })
+	return handlers, err
+}
+
+// scanPackage 扫描单个控制器包
+func scanPackage(dir string) ([]handlerInfo, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	pkgPath, err := importPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var handlers []handlerInfo
+	for _, pkg := range pkgs {
+		localTypes := exportedTypes(pkg)
+		for _, file := range pkg.Files {
+			imports := fileImports(file)
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv == nil || !fn.Name.IsExported() || !isGinHandler(fn) {
+					continue
+				}
+				recv := receiverName(fn)
+				if recv == "" {
+					continue
+				}
+				h := handlerInfo{name: pkgPath + "." + recv + "." + fn.Name.Name}
+				h.summary, h.description = docOf(fn)
+				inspectBody(fn, &h, pkgPath, imports, localTypes)
+				handlers = append(handlers, h)
+			}
+		}
+	}
+	return handlers, nil
+}
+
+// importPath 根据目录所在模块根目录（go.mod 所在目录）计算包导入路径
+func importPath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for root := abs; ; {
+		if _, err := os.Stat(filepath.Join(root, "go.mod")); err == nil {
+			rel, err := filepath.Rel(root, abs)
+			if err != nil {
+				return "", err
+			}
+			return modulePath + "/" + filepath.ToSlash(rel), nil
+		}
+		parent := filepath.Dir(root)
+		if parent == root {
+			return "", fmt.Errorf("目录 %s 不在模块 %s 中", dir, modulePath)
+		}
+		root = parent
+	}
+}
+
+// exportedTypes 包内声明的非泛型导出类型
+func exportedTypes(pkg *ast.Package) map[string]bool {
+	types := make(map[string]bool)
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if ts.Name.IsExported() && ts.TypeParams == nil {
+					types[ts.Name.Name] = true
+				}
+			}
+		}
+	}
+	return types
+}
+
+// fileImports 文件导入的包，键为包名
+func fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = path
+	}
+	return imports
+}
+
+// isGinHandler 方法是否为 func(*gin.Context) 形式的处理函数
+func isGinHandler(fn *ast.FuncDecl) bool {
+	params := fn.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) > 1 || fn.Type.Results != nil {
+		return false
+	}
+	star, ok := params[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "gin" && sel.Sel.Name == "Context"
+}
+
+// receiverName 接收者名称，与 runtime.FuncForPC 的格式一致，如 (*UserController)
+func receiverName(fn *ast.FuncDecl) string {
+	switch t := fn.Recv.List[0].Type.(type) {
+	case *ast.StarExpr:
+		if ident, ok := t.X.(*ast.Ident); ok {
+			return "(*" + ident.Name + ")"
+		}
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// docOf 从文档注释中提取摘要和描述
+func docOf(fn *ast.FuncDecl) (summary, description string) {
+	if fn.Doc == nil {
+		return "", ""
+	}
+	var first string
+	for _, line := range strings.Split(fn.Doc.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "@Summary"):
+			summary = strings.TrimSpace(strings.TrimPrefix(line, "@Summary"))
+		case strings.HasPrefix(line, "@Description"):
+			description = strings.TrimSpace(strings.TrimPrefix(line, "@Description"))
+		case first == "" && line != "" && !strings.HasPrefix(line, "@"):
+			first = line
+		}
+	}
+	if summary == "" {
+		// 文档注释首行以方法名开头，如 "QueryUsers 获取用户列表"
+		summary = strings.TrimSpace(strings.TrimPrefix(first, fn.Name.Name))
+	}
+	if description == summary {
+		description = ""
+	}
+	return summary, description
+}
+
+// inspectBody 分析方法体，提取请求模型、请求参数和分页标记
+func inspectBody(fn *ast.FuncDecl, h *handlerInfo, pkgPath string, imports map[string]string, localTypes map[string]bool) {
+	if fn.Body == nil {
+		return
+	}
+	// 局部变量声明的类型
+	varTypes := make(map[string]ast.Expr)
+	params := make(map[string]bool)
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.ValueSpec:
+			if node.Type != nil {
+				for _, name := range node.Names {
+					varTypes[name.Name] = node.Type
+				}
+			}
+		case *ast.AssignStmt:
+			if node.Tok == token.DEFINE && len(node.Lhs) == len(node.Rhs) {
+				for i, lhs := range node.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						if typ := literalType(node.Rhs[i]); typ != nil {
+							varTypes[ident.Name] = typ
+						}
+					}
+				}
+			}
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			pkg, _ := sel.X.(*ast.Ident)
+			method := sel.Sel.Name
+			switch {
+			case pkg != nil && pkg.Name == "request" && strings.HasPrefix(method, "Bind") && len(node.Args) == 2:
+				if h.request == "" {
+					h.request = resolveType(varTypes[boundVar(node.Args[1])], pkgPath, imports, localTypes)
+				}
+			case strings.HasPrefix(method, "ShouldBind") && len(node.Args) == 1:
+				if h.request == "" {
+					h.request = resolveType(varTypes[boundVar(node.Args[0])], pkgPath, imports, localTypes)
+				}
+			case pkg != nil && pkg.Name == "request" && strings.HasPrefix(method, "GetParam") && len(node.Args) >= 2:
+				if lit, ok := node.Args[1].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					if name, err := strconv.Unquote(lit.Value); err == nil && name != "" {
+						params[name] = true
+					}
+				}
+			case pkg != nil && pkg.Name == "request" && method == "GetPaginationParams",
+				pkg != nil && pkg.Name == "response" && method == "PageJSON":
+				h.paged = true
+			}
+		}
+		return true
+	})
+
+	for name := range params {
+		h.params = append(h.params, name)
+	}
+	sort.Strings(h.params)
+}
+
+// literalType 复合字面量的类型，如 models.X{} 或 &models.X{}
+func literalType(expr ast.Expr) ast.Expr {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	if lit, ok := expr.(*ast.CompositeLit); ok {
+		return lit.Type
+	}
+	return nil
+}
+
+// boundVar 绑定目标的变量名，支持 &req 和 req
+func boundVar(expr ast.Expr) string {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// resolveType 将类型表达式解析为 包路径.类型名，匿名结构体、切片等无法引用的类型返回空
+func resolveType(expr ast.Expr, pkgPath string, imports map[string]string, localTypes map[string]bool) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if localTypes[t.Name] {
+			return pkgPath + "." + t.Name
+		}
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok || !t.Sel.IsExported() {
+			return ""
+		}
+		if path, ok := imports[pkg.Name]; ok && strings.HasPrefix(path, modulePath+"/") {
+			return path + "." + t.Sel.Name
+		}
+	}
+	return ""
+}
+
+// render 生成注册代码
+func render(handlers []handlerInfo) ([]byte, error) {
+	aliases := make(map[string]string) // 包路径 -> 别名
+	used := make(map[string]bool)
+	for _, h := range handlers {
+		if h.request == "" {
+			continue
+		}
+		path := h.request[:strings.LastIndex(h.request, ".")]
+		if _, ok := aliases[path]; ok {
+			continue
+		}
+		alias := importAlias(path)
+		for i := 2; used[alias]; i++ {
+			alias = importAlias(path) + strconv.Itoa(i)
+		}
+		aliases[path] = alias
+		used[alias] = true
+	}
+	paths := make([]string, 0, len(aliases))
+	for path := range aliases {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by cmd/openapigen; DO NOT EDIT.\n\n")
+	buf.WriteString("package moduleimports\n\nimport (\n\t\"gateway/web/utils/openapi\"\n")
+	for _, path := range paths {
+		fmt.Fprintf(&buf, "\t%s %q\n", aliases[path], path)
+	}
+	buf.WriteString(")\n\nfunc init() {\n")
+	for _, h := range handlers {
+		fmt.Fprintf(&buf, "\topenapi.RegisterHandler(%q, openapi.HandlerSpec{", h.name)
+		var fields []string
+		if h.summary != "" {
+			fields = append(fields, "Summary: "+strconv.Quote(h.summary))
+		}
+		if h.description != "" {
+			fields = append(fields, "Description: "+strconv.Quote(h.description))
+		}
+		if h.request != "" {
+			idx := strings.LastIndex(h.request, ".")
+			fields = append(fields, "Request: "+aliases[h.request[:idx]]+h.request[idx:]+"{}")
+		}
+		if len(h.params) > 0 {
+			quoted := make([]string, len(h.params))
+			for i, p := range h.params {
+				quoted[i] = strconv.Quote(p)
+			}
+			fields = append(fields, "Params: []string{"+strings.Join(quoted, ", ")+"}")
+		}
+		if h.paged {
+			fields = append(fields, "Paged: true")
+		}
+		buf.WriteString(strings.Join(fields, ", "))
+		buf.WriteString("})\n")
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
+
+// importAlias 包导入别名，如 gateway/web/views/hub0002/models 为 hub0002models
+func importAlias(path string) string {
+	segments := strings.Split(path, "/")
+	alias := segments[len(segments)-1]
+	if len(segments) >= 2 {
+		alias = segments[len(segments)-2] + alias
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return -1
+	}, strings.ToLower(alias))
+}
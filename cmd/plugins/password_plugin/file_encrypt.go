@@ -0,0 +1,345 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gateway/pkg/config"
+	"gateway/pkg/security"
+	"gateway/pkg/utils/mask"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultSensitiveKeys 默认需要加密的配置键名后缀
+const defaultSensitiveKeys = "password,secret,key"
+
+// protectedKeyPaths 加密密钥本身的配置路径，解密配置时需要先读取这些值，不能加密
+var protectedKeyPaths = []string{"app.encryption_key", "app.encryption_keys", "app.key_provider"}
+
+// fileChange 配置文件中一处待加密的配置值
+type fileChange struct {
+	path       string // 配置项路径，如 database.connections.mysql_main.password
+	line       int    // 行号，从1开始
+	oldLine    string // 原始行（敏感值已脱敏）
+	newLine    string // 加密后的行
+	ciphertext string // 密文
+}
+
+// fileResult 单个配置文件的JSON输出结果
+type fileResult struct {
+	File    string         `json:"file"`
+	Changes []changeResult `json:"changes"`
+	Skipped []string       `json:"skipped,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// changeResult 单个配置项的JSON输出结果
+type changeResult struct {
+	Path       string `json:"path"`
+	Line       int    `json:"line"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+// valueLocation 配置值在文件中的位置
+type valueLocation struct {
+	path string
+	node *yaml.Node
+}
+
+// encryptConfigFiles 批量加密配置文件中的明文敏感值
+// 按键名匹配敏感配置项，将明文值原地替换为 ENCY_ 密文，保留注释和格式，并输出变更摘要
+// 参数:
+//
+//	files: 配置文件路径列表
+//	secretKey: 加密密钥，为空时使用配置中的默认密钥
+//	keys: 逗号分隔的敏感键名，配置项键名（忽略大小写和分隔符）以其中任一项结尾即视为敏感
+//	dryRun: 只输出变更摘要，不写入文件
+//
+// 返回:
+//
+//	int: 进程退出码，0表示全部成功
+func encryptConfigFiles(files []string, secretKey, keys string, dryRun bool) int {
+	var suffixes []string
+	for _, key := range strings.Split(keys, ",") {
+		if normalized := normalizeKeyName(key); normalized != "" {
+			suffixes = append(suffixes, normalized)
+		}
+	}
+	if len(suffixes) == 0 {
+		fail("敏感键名不能为空")
+	}
+
+	encrypt := security.EncryptWithDefaultKey
+	if secretKey != "" {
+		encrypt = func(plaintext string) (string, error) {
+			return security.AESEncryptToString(secretKey, plaintext)
+		}
+	}
+
+	exitCode := 0
+	total := 0
+	results := []fileResult{}
+	for _, file := range files {
+		file = strings.TrimSpace(file)
+		if file == "" {
+			continue
+		}
+		changes, skipped, err := encryptConfigFile(file, suffixes, encrypt, dryRun)
+		if err != nil {
+			exitCode = 1
+			if jsonOutput {
+				results = append(results, fileResult{File: file, Changes: []changeResult{}, Error: err.Error()})
+			} else {
+				fmt.Fprintf(os.Stderr, "错误: %s: %v\n", file, err)
+			}
+			continue
+		}
+		total += len(changes)
+		if !jsonOutput {
+			printFileChanges(file, changes, skipped)
+			continue
+		}
+		result := fileResult{File: file, Changes: []changeResult{}, Skipped: skipped}
+		for _, change := range changes {
+			result.Changes = append(result.Changes, changeResult{Path: change.path, Line: change.line, Ciphertext: change.ciphertext})
+		}
+		results = append(results, result)
+	}
+
+	if jsonOutput {
+		printJSON(map[string]interface{}{
+			"success": exitCode == 0,
+			"dryRun":  dryRun,
+			"total":   total,
+			"files":   results,
+		})
+		return exitCode
+	}
+
+	fmt.Println(strings.Repeat("=", 70))
+	if dryRun {
+		fmt.Printf("预览模式: 共 %d 项待加密，未修改文件\n", total)
+	} else {
+		fmt.Printf("共加密 %d 项\n", total)
+	}
+	return exitCode
+}
+
+// encryptConfigFile 加密单个配置文件
+// 返回:
+//
+//	[]fileChange: 已加密（预览模式下为待加密）的配置项
+//	[]string: 匹配敏感键名但无法原地替换而跳过的配置项说明
+//	error: 读取、解析、加密或写入失败时返回错误
+func encryptConfigFile(file string, suffixes []string, encrypt func(string) (string, error), dryRun bool) ([]fileChange, []string, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	if config.IsEncryptedValue(string(data)) {
+		return nil, []string{"整个文件已加密"}, nil
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, nil, fmt.Errorf("解析YAML失败: %w", err)
+	}
+	var locations []valueLocation
+	collectSensitiveValues(&root, "", suffixes, &locations)
+
+	lines := strings.Split(string(data), "\n")
+	var changes []fileChange
+	var skipped []string
+	for _, location := range locations {
+		node := location.node
+		value := node.Value
+		if value == "" || config.IsEncryptedValue(value) || strings.Contains(value, "${") {
+			continue
+		}
+		if node.Style == yaml.LiteralStyle || node.Style == yaml.FoldedStyle || strings.Contains(value, "\n") {
+			skipped = append(skipped, fmt.Sprintf("第%d行 %s: 不支持多行值", node.Line, location.path))
+			continue
+		}
+
+		lineIndex := node.Line - 1
+		line := []rune(lines[lineIndex])
+		start := node.Column - 1
+		end := scalarTokenEnd(line, start, node.Style)
+		if end < 0 || !tokenMatchesValue(string(line[start:end]), value) {
+			skipped = append(skipped, fmt.Sprintf("第%d行 %s: 无法定位配置值", node.Line, location.path))
+			continue
+		}
+
+		ciphertext, err := encrypt(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("加密 %s 失败: %w", location.path, err)
+		}
+
+		quote := ""
+		switch node.Style {
+		case yaml.DoubleQuotedStyle:
+			quote = `"`
+		case yaml.SingleQuotedStyle:
+			quote = "'"
+		}
+		prefix, suffix := string(line[:start]), string(line[end:])
+		changes = append(changes, fileChange{
+			path:       location.path,
+			line:       node.Line,
+			oldLine:    prefix + quote + mask.Masked + quote + suffix,
+			newLine:    prefix + quote + ciphertext + quote + suffix,
+			ciphertext: ciphertext,
+		})
+		lines[lineIndex] = prefix + quote + ciphertext + quote + suffix
+	}
+
+	if len(changes) > 0 && !dryRun {
+		if err := os.WriteFile(file, []byte(strings.Join(lines, "\n")), info.Mode().Perm()); err != nil {
+			return nil, nil, fmt.Errorf("写入文件失败: %w", err)
+		}
+	}
+	return changes, skipped, nil
+}
+
+// collectSensitiveValues 递归收集键名匹配敏感后缀的字符串值节点
+func collectSensitiveValues(node *yaml.Node, path string, suffixes []string, locations *[]valueLocation) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			collectSensitiveValues(child, path, suffixes, locations)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			collectSensitiveValues(child, fmt.Sprintf("%s[%d]", path, i), suffixes, locations)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			childPath := keyNode.Value
+			if path != "" {
+				childPath = path + "." + keyNode.Value
+			}
+			if isProtectedKeyPath(childPath) {
+				continue
+			}
+			if valueNode.Kind == yaml.ScalarNode {
+				// 纯数字的密码同样需要加密，布尔值和空值不是敏感值
+				isText := valueNode.Tag == "!!str" || valueNode.Tag == "!!int" || valueNode.Tag == "!!float"
+				if isText && hasSensitiveSuffix(keyNode.Value, suffixes) {
+					*locations = append(*locations, valueLocation{path: childPath, node: valueNode})
+				}
+				continue
+			}
+			collectSensitiveValues(valueNode, childPath, suffixes, locations)
+		}
+	}
+}
+
+// scalarTokenEnd 查找单行标量值在行内的结束位置（不含）
+// 返回-1表示无法确定
+func scalarTokenEnd(line []rune, start int, style yaml.Style) int {
+	if start < 0 || start >= len(line) {
+		return -1
+	}
+	switch style {
+	case yaml.DoubleQuotedStyle:
+		if line[start] != '"' {
+			return -1
+		}
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\\' {
+				i++
+				continue
+			}
+			if line[i] == '"' {
+				return i + 1
+			}
+		}
+		return -1
+	case yaml.SingleQuotedStyle:
+		if line[start] != '\'' {
+			return -1
+		}
+		for i := start + 1; i < len(line); i++ {
+			if line[i] != '\'' {
+				continue
+			}
+			if i+1 < len(line) && line[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i + 1
+		}
+		return -1
+	default:
+		end := len(line)
+		for i := start; i < len(line); i++ {
+			if line[i] == '#' && i > start && (line[i-1] == ' ' || line[i-1] == '\t') {
+				end = i
+				break
+			}
+		}
+		for end > start && (line[end-1] == ' ' || line[end-1] == '\t' || line[end-1] == '\r') {
+			end--
+		}
+		return end
+	}
+}
+
+// tokenMatchesValue 校验定位到的原始文本解析后与配置值一致，避免误替换
+func tokenMatchesValue(token, value string) bool {
+	var parsed string
+	if err := yaml.Unmarshal([]byte(token), &parsed); err != nil {
+		return false
+	}
+	return parsed == value
+}
+
+// hasSensitiveSuffix 判断键名是否以敏感键名结尾
+func hasSensitiveSuffix(key string, suffixes []string) bool {
+	normalized := normalizeKeyName(key)
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(normalized, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isProtectedKeyPath 判断配置路径是否为加密密钥配置
+func isProtectedKeyPath(path string) bool {
+	for _, protected := range protectedKeyPaths {
+		if path == protected || strings.HasPrefix(path, protected+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeKeyName 统一键名格式：转小写并去除分隔符
+func normalizeKeyName(key string) string {
+	return strings.NewReplacer("_", "", "-", "", ".", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(key)))
+}
+
+// printFileChanges 以diff格式输出文件变更摘要，原值已脱敏
+func printFileChanges(file string, changes []fileChange, skipped []string) {
+	fmt.Printf("--- %s\n+++ %s\n", file, file)
+	for _, change := range changes {
+		fmt.Printf("@@ 第%d行 %s @@\n", change.line, change.path)
+		fmt.Printf("-%s\n", change.oldLine)
+		fmt.Printf("+%s\n", change.newLine)
+	}
+	for _, reason := range skipped {
+		fmt.Printf("跳过: %s\n", reason)
+	}
+	if len(changes) == 0 && len(skipped) == 0 {
+		fmt.Println("没有需要加密的明文配置")
+	}
+	fmt.Println()
+}
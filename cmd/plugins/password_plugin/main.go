@@ -36,7 +36,11 @@ func main() {
 		showVersion  = flag.Bool("v", false, "显示版本信息")
 		generateKey  = flag.Bool("g", false, "生成新的随机密钥")
 		configDir    = flag.String("config", "./configs", "配置文件目录")
+		files        = flag.String("f", "", "批量加密的YAML配置文件，多个文件以逗号分隔，明文敏感值会被原地替换为密文")
+		keys         = flag.String("keys", defaultSensitiveKeys, "批量加密时匹配的敏感键名，配置项键名以其中任一项结尾即加密")
+		dryRun       = flag.Bool("dry-run", false, "批量加密时只输出变更摘要，不修改文件")
 	)
+	flag.BoolVar(&jsonOutput, "json", false, "以JSON格式输出结果，不输出横幅和提示，未提供 -p/-c 时从标准输入读取")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, banner, version)
@@ -56,15 +60,24 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %s -d -c \"ENCY_AQAM...\"\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  # 生成新的随机密钥\n")
 		fmt.Fprintf(os.Stderr, "  %s -g\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  # 预览并批量加密配置文件中的明文密码\n")
+		fmt.Fprintf(os.Stderr, "  %s -f configs/database.yaml -dry-run\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -f configs/database.yaml,configs/web.yaml\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  # 从环境变量读取密码（Linux/Mac）\n")
 		fmt.Fprintf(os.Stderr, "  echo \"my-password\" | %s\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  # 在自动化脚本中使用JSON输出\n")
+		fmt.Fprintf(os.Stderr, "  echo \"my-password\" | %s -json | jq -r .ciphertext\n\n", os.Args[0])
 	}
 
 	flag.Parse()
 
 	// 显示版本信息
 	if *showVersion {
-		fmt.Printf("Gateway 密码加密工具 v%s\n", version)
+		if jsonOutput {
+			printJSON(jsonResult{Success: true, Version: version})
+		} else {
+			fmt.Printf("Gateway 密码加密工具 v%s\n", version)
+		}
 		os.Exit(0)
 	}
 
@@ -82,11 +95,17 @@ func main() {
 	}
 
 	// 加载配置（如果需要使用默认密钥）
+	// JSON模式下警告同样输出到标准错误，不影响标准输出的解析
 	if err := config.LoadConfig(*configDir); err != nil {
 		fmt.Fprintf(os.Stderr, "警告: 加载配置文件失败: %v\n", err)
 		fmt.Fprintf(os.Stderr, "将使用硬编码的默认密钥\n")
 	}
 
+	// 批量加密配置文件
+	if *files != "" {
+		os.Exit(encryptConfigFiles(strings.Split(*files, ","), *key, *keys, *dryRun))
+	}
+
 	// 解密模式
 	if *decrypt {
 		decryptPassword(*ciphertext, *key)
@@ -94,8 +113,8 @@ func main() {
 		return
 	}
 
-	// 如果没有任何参数（除了程序名），显示交互式菜单
-	if len(flag.Args()) == 0 && *password == "" && !*useRandomKey {
+	// 如果没有任何参数（除了程序名），显示交互式菜单；JSON模式下直接从标准输入读取密码
+	if !jsonOutput && len(flag.Args()) == 0 && *password == "" && !*useRandomKey {
 		interactiveMenu()
 		waitBeforeExit()
 		return
@@ -105,17 +124,24 @@ func main() {
 	var plaintext string
 	if *password != "" {
 		plaintext = *password
+	} else if jsonOutput {
+		var err error
+		plaintext, err = readStdinValue("密码")
+		if err != nil {
+			fail("%v", err)
+		}
+		if plaintext == "" {
+			fail("密码不能为空")
+		}
 	} else {
 		// 交互式输入
 		var err error
 		plaintext, err = readPassword("请输入密码: ")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "错误: 读取密码失败: %v\n", err)
-			os.Exit(1)
+			fail("读取密码失败: %v", err)
 		}
 		if plaintext == "" {
-			fmt.Fprintf(os.Stderr, "错误: 密码不能为空\n")
-			os.Exit(1)
+			fail("密码不能为空")
 		}
 	}
 
@@ -123,6 +149,9 @@ func main() {
 	if *useRandomKey {
 		randomKey, err := security.GenerateSecretKey()
 		if err != nil {
+			if jsonOutput {
+				fail("生成随机密钥失败: %v", err)
+			}
 			fmt.Fprintf(os.Stderr, "错误: 生成随机密钥失败: %v\n", err)
 			waitBeforeExit()
 			os.Exit(1)
@@ -139,20 +168,30 @@ func encryptPassword(plaintext, secretKey string) {
 	var ciphertext string
 	var err error
 
+	keySource := keySourceDefault
 	if secretKey != "" {
 		// 使用指定的密钥
-		fmt.Printf("使用指定的密钥进行加密...\n")
+		keySource = keySourceSpecified
 		ciphertext, err = security.AESEncryptToString(secretKey, plaintext)
 	} else {
 		// 使用默认密钥
-		fmt.Printf("使用默认密钥进行加密（从配置文件读取）...\n")
 		ciphertext, err = security.EncryptWithDefaultKey(plaintext)
-		fmt.Printf("提示: 默认密钥已从配置文件读取\n")
 	}
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "错误: 加密失败: %v\n", err)
-		os.Exit(1)
+		fail("加密失败: %v", err)
+	}
+
+	if jsonOutput {
+		printJSON(jsonResult{Success: true, Ciphertext: ciphertext, KeySource: keySource})
+		return
+	}
+
+	if keySource == keySourceSpecified {
+		fmt.Printf("使用指定的密钥进行加密...\n")
+	} else {
+		fmt.Printf("使用默认密钥进行加密（从配置文件读取）...\n")
+		fmt.Printf("提示: 默认密钥已从配置文件读取\n")
 	}
 
 	fmt.Println("\n" + strings.Repeat("=", 70))
@@ -169,14 +208,18 @@ func encryptPassword(plaintext, secretKey string) {
 
 // encryptPasswordWithRandomKey 使用随机密钥加密密码
 func encryptPasswordWithRandomKey(plaintext, randomKey string) {
-	fmt.Printf("正在生成随机密钥并加密...\n")
-
 	ciphertext, err := security.AESEncryptToString(randomKey, plaintext)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "错误: 加密失败: %v\n", err)
-		os.Exit(1)
+		fail("加密失败: %v", err)
 	}
 
+	if jsonOutput {
+		printJSON(jsonResult{Success: true, Ciphertext: ciphertext, Key: randomKey, KeySource: keySourceRandom})
+		return
+	}
+
+	fmt.Printf("正在生成随机密钥并加密...\n")
+
 	fmt.Println("\n" + strings.Repeat("=", 70))
 	fmt.Println("加密成功！")
 	fmt.Println(strings.Repeat("=", 70))
@@ -196,32 +239,48 @@ func encryptPasswordWithRandomKey(plaintext, randomKey string) {
 // decryptPassword 解密密码
 func decryptPassword(ciphertext, secretKey string) {
 	if ciphertext == "" {
-		// 交互式输入密文
+		// 交互式输入密文，JSON模式下从标准输入读取
 		var err error
-		ciphertext, err = readInput("请输入密文: ")
+		if jsonOutput {
+			ciphertext, err = readStdinValue("密文")
+		} else {
+			ciphertext, err = readInput("请输入密文: ")
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "错误: 读取密文失败: %v\n", err)
-			os.Exit(1)
+			fail("读取密文失败: %v", err)
 		}
 		if ciphertext == "" {
-			fmt.Fprintf(os.Stderr, "错误: 密文不能为空\n")
-			os.Exit(1)
+			fail("密文不能为空")
 		}
 	}
 
 	var plaintext string
 	var err error
 
+	keySource := keySourceDefault
 	if secretKey != "" {
 		// 使用指定的密钥
-		fmt.Printf("使用指定的密钥进行解密...\n")
+		keySource = keySourceSpecified
 		plaintext, err = security.AESDecryptFromString(secretKey, ciphertext)
 	} else {
 		// 使用默认密钥
-		fmt.Printf("使用默认密钥进行解密（从配置文件读取）...\n")
 		plaintext, err = security.DecryptWithDefaultKey(ciphertext)
 	}
 
+	if jsonOutput {
+		if err != nil {
+			fail("解密失败: %v", err)
+		}
+		printJSON(jsonResult{Success: true, Plaintext: plaintext, KeySource: keySource})
+		return
+	}
+
+	if keySource == keySourceSpecified {
+		fmt.Printf("使用指定的密钥进行解密...\n")
+	} else {
+		fmt.Printf("使用默认密钥进行解密（从配置文件读取）...\n")
+	}
+
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "错误: 解密失败: %v\n", err)
 		fmt.Fprintf(os.Stderr, "提示: 请检查密文是否正确，或确认使用的密钥是否正确\n")
@@ -238,8 +297,12 @@ func decryptPassword(ciphertext, secretKey string) {
 func generateNewKey() {
 	key, err := security.GenerateSecretKey()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "错误: 生成密钥失败: %v\n", err)
-		os.Exit(1)
+		fail("生成密钥失败: %v", err)
+	}
+
+	if jsonOutput {
+		printJSON(jsonResult{Success: true, Key: key})
+		return
 	}
 
 	fmt.Println(strings.Repeat("=", 70))
@@ -292,8 +355,8 @@ func readInput(prompt string) (string, error) {
 
 // waitBeforeExit 在程序退出前等待（仅 Windows 下双击运行时）
 func waitBeforeExit() {
-	// 只在 Windows 平台下检查
-	if runtime.GOOS != "windows" {
+	// 只在 Windows 平台下检查，JSON模式供自动化调用，不等待
+	if runtime.GOOS != "windows" || jsonOutput {
 		return
 	}
 
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// jsonOutput 是否以JSON格式输出结果，JSON模式下不输出横幅、提示和交互菜单
+var jsonOutput bool
+
+// 密钥来源
+const (
+	keySourceDefault   = "default"   // 配置文件中的默认密钥
+	keySourceSpecified = "specified" // 通过 -k 指定的密钥
+	keySourceRandom    = "random"    // 随机生成的密钥
+)
+
+// jsonResult 加密、解密和生成密钥的JSON输出结果
+type jsonResult struct {
+	Success    bool   `json:"success"`
+	Version    string `json:"version,omitempty"`
+	Ciphertext string `json:"ciphertext,omitempty"`
+	Plaintext  string `json:"plaintext,omitempty"`
+	Key        string `json:"key,omitempty"`
+	KeySource  string `json:"keySource,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// printJSON 输出单行JSON到标准输出
+func printJSON(value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "错误: 序列化输出失败: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
+
+// fail 输出错误并以退出码1退出
+// JSON模式下错误以 {"success":false,"error":"..."} 输出到标准输出，便于调用方统一解析
+func fail(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	if jsonOutput {
+		printJSON(jsonResult{Success: false, Error: message})
+	} else {
+		fmt.Fprintf(os.Stderr, "错误: %s\n", message)
+	}
+	os.Exit(1)
+}
+
+// readStdinValue JSON模式下从标准输入读取一行，不输出提示
+// 标准输入为终端时直接报错，避免自动化流程阻塞等待输入
+func readStdinValue(name string) (string, error) {
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("JSON模式下必须通过参数或标准输入提供%s", name)
+	}
+	input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && input == "" {
+		return "", fmt.Errorf("读取%s失败: %w", name, err)
+	}
+	return strings.TrimSpace(input), nil
+}
@@ -0,0 +1,512 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	pb "gateway/internal/servicecenter/server/proto"
+	"gateway/internal/servicecenter/server/subscriber"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultGroupName 服务端未指定分组时使用的默认分组
+const defaultGroupName = "DEFAULT_GROUP"
+
+// newFlagSet 创建子命令参数解析器
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+// parseFlags 解析子命令参数，解析器已输出错误和选项说明时返回空的 usageError
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+		return usageError("")
+	}
+	if flags.NArg() > 0 {
+		return usageError(fmt.Sprintf("多余的参数: %s", strings.Join(flags.Args(), " ")))
+	}
+	return nil
+}
+
+// require 按顺序检查必填参数
+// 参数:
+//
+//	pairs: 参数名和参数值交替排列，例如 "ns", namespaceId, "service", serviceName
+func require(pairs ...string) error {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] == "" {
+			return usageError(fmt.Sprintf("必须指定 -%s", pairs[i]))
+		}
+	}
+	return nil
+}
+
+// runServices 列出命名空间下的服务
+func runServices(ctl *regctl, args []string) error {
+	flags := newFlagSet("services")
+	namespaceId := flags.String("ns", "", "命名空间ID")
+	groupName := flags.String("group", "", "分组名称，为空时列出所有分组")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := require("ns", *namespaceId); err != nil {
+		return err
+	}
+
+	ctx, cancel := ctl.requestContext()
+	defer cancel()
+	resp, err := ctl.client.ListServices(ctx, &pb.ListServicesRequest{
+		NamespaceId: *namespaceId,
+		GroupName:   *groupName,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Message)
+	}
+	if ctl.jsonOutput {
+		return printProto(resp)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "GROUP\tSERVICE\tTYPE\tVERSION\tNODES\tHEALTHY")
+	for _, summary := range resp.Services {
+		service := summary.GetService()
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\n",
+			service.GetGroupName(), service.GetServiceName(), service.GetServiceType(), service.GetServiceVersion(),
+			summary.NodeCount, summary.HealthyNodeCount)
+	}
+	return w.Flush()
+}
+
+// runNodes 列出服务的节点
+func runNodes(ctl *regctl, args []string) error {
+	flags := newFlagSet("nodes")
+	namespaceId := flags.String("ns", "", "命名空间ID")
+	groupName := flags.String("group", defaultGroupName, "分组名称")
+	serviceName := flags.String("service", "", "服务名称")
+	healthyOnly := flags.Bool("healthy", false, "只列出健康节点")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := require("ns", *namespaceId, "service", *serviceName); err != nil {
+		return err
+	}
+
+	ctx, cancel := ctl.requestContext()
+	defer cancel()
+	resp, err := ctl.client.DiscoverNodes(ctx, &pb.DiscoverNodesRequest{
+		NamespaceId: *namespaceId,
+		GroupName:   *groupName,
+		ServiceName: *serviceName,
+		HealthyOnly: *healthyOnly,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Message)
+	}
+	if ctl.jsonOutput {
+		return printProto(resp)
+	}
+	printNodes(resp.Nodes)
+	return nil
+}
+
+// runSelect 由服务端按负载均衡策略选择一个可调用节点
+func runSelect(ctl *regctl, args []string) error {
+	flags := newFlagSet("select")
+	namespaceId := flags.String("ns", "", "命名空间ID")
+	groupName := flags.String("group", defaultGroupName, "分组名称")
+	serviceName := flags.String("service", "", "服务名称")
+	zone := flags.String("zone", "", "调用方所在可用区")
+	strategy := flags.String("strategy", "", "负载均衡策略 WEIGHTED_RANDOM 或 ZONE_AFFINITY，为空时使用服务配置")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := require("ns", *namespaceId, "service", *serviceName); err != nil {
+		return err
+	}
+
+	ctx, cancel := ctl.requestContext()
+	defer cancel()
+	resp, err := ctl.client.SelectInstance(ctx, &pb.SelectInstanceRequest{
+		NamespaceId: *namespaceId,
+		GroupName:   *groupName,
+		ServiceName: *serviceName,
+		Zone:        *zone,
+		Strategy:    *strategy,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Message)
+	}
+	if ctl.jsonOutput {
+		return printProto(resp)
+	}
+	fmt.Printf("%s\t%s\tstrategy=%s\tsameZone=%t\n", resp.Address, resp.Node.GetNodeId(), resp.Strategy, resp.SameZone)
+	return nil
+}
+
+// runRegister 注册测试节点
+// 指定 -keepalive 时按间隔发送心跳直到收到中断信号，退出前注销节点，避免留下无心跳的测试节点
+func runRegister(ctl *regctl, args []string) error {
+	flags := newFlagSet("register")
+	namespaceId := flags.String("ns", "", "命名空间ID")
+	groupName := flags.String("group", defaultGroupName, "分组名称")
+	serviceName := flags.String("service", "", "服务名称")
+	ipAddress := flags.String("ip", "", "节点IP地址")
+	port := flags.Int("port", 0, "节点端口")
+	weight := flags.Float64("weight", 1, "节点权重（0.01-10000.00）")
+	ephemeral := flags.String("ephemeral", "Y", "是否临时节点（Y/N），临时节点停止心跳后会被移除")
+	metadata := flags.String("meta", "", "节点元数据，格式 key=value,key2=value2")
+	keepalive := flags.Duration("keepalive", 0, "心跳间隔，大于0时保持运行并持续发送心跳")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := require("ns", *namespaceId, "service", *serviceName, "ip", *ipAddress); err != nil {
+		return err
+	}
+	if *port <= 0 || *port > 65535 {
+		return usageError("-port 必须在1到65535之间")
+	}
+	if *ephemeral != "Y" && *ephemeral != "N" {
+		return usageError("-ephemeral 只能为Y或N")
+	}
+	nodeMetadata, err := parseMetadata(*metadata)
+	if err != nil {
+		return usageError(err.Error())
+	}
+
+	service := &pb.Service{
+		NamespaceId: *namespaceId,
+		GroupName:   *groupName,
+		ServiceName: *serviceName,
+		Node: &pb.Node{
+			NamespaceId:    *namespaceId,
+			GroupName:      *groupName,
+			ServiceName:    *serviceName,
+			IpAddress:      *ipAddress,
+			PortNumber:     int32(*port),
+			Weight:         *weight,
+			Ephemeral:      *ephemeral,
+			InstanceStatus: "UP",
+			HealthyStatus:  "HEALTHY",
+			Metadata:       nodeMetadata,
+		},
+	}
+
+	ctx, cancel := ctl.requestContext()
+	resp, err := ctl.client.RegisterService(ctx, service)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Message)
+	}
+	nodeId := resp.NodeId
+	service.Node.NodeId = nodeId
+
+	if ctl.jsonOutput {
+		if err := printProto(resp); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println(nodeId)
+	}
+	if *keepalive <= 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "节点 %s 已注册，每 %s 发送一次心跳，按 Ctrl+C 注销并退出\n", nodeId, *keepalive)
+	runCtx, stop := signalContext()
+	defer stop()
+	ticker := time.NewTicker(*keepalive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-runCtx.Done():
+			ctx, cancel := ctl.requestContext()
+			defer cancel()
+			unregisterResp, err := ctl.client.UnregisterNode(ctx, &pb.NodeKey{NodeId: nodeId})
+			if err != nil {
+				return fmt.Errorf("注销节点失败: %w", err)
+			}
+			if !unregisterResp.Success {
+				return fmt.Errorf("注销节点失败: %s", unregisterResp.Message)
+			}
+			fmt.Fprintf(os.Stderr, "节点 %s 已注销\n", nodeId)
+			return nil
+		case <-ticker.C:
+			// 心跳携带完整服务信息，注册中心重启后可以自动恢复节点
+			if err := ctl.sendHeartbeat(&pb.HeartbeatRequest{NodeId: nodeId, Service: service}); err != nil {
+				fmt.Fprintf(os.Stderr, "%s 心跳失败: %v\n", time.Now().Format("15:04:05"), err)
+			}
+		}
+	}
+}
+
+// runDeregister 注销节点或整个服务
+func runDeregister(ctl *regctl, args []string) error {
+	flags := newFlagSet("deregister")
+	nodeId := flags.String("node", "", "节点ID，指定时只注销该节点")
+	namespaceId := flags.String("ns", "", "命名空间ID，注销整个服务时必填")
+	groupName := flags.String("group", defaultGroupName, "分组名称")
+	serviceName := flags.String("service", "", "服务名称，注销整个服务时必填")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	ctx, cancel := ctl.requestContext()
+	defer cancel()
+
+	var resp *pb.RegistryResponse
+	var err error
+	if *serviceName == "" {
+		if *nodeId == "" {
+			return usageError("必须指定 -node，或同时指定 -ns 和 -service")
+		}
+		resp, err = ctl.client.UnregisterNode(ctx, &pb.NodeKey{NodeId: *nodeId})
+	} else {
+		if err := require("ns", *namespaceId); err != nil {
+			return err
+		}
+		resp, err = ctl.client.UnregisterService(ctx, &pb.ServiceKey{
+			NamespaceId: *namespaceId,
+			GroupName:   *groupName,
+			ServiceName: *serviceName,
+			NodeId:      *nodeId,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Message)
+	}
+	if ctl.jsonOutput {
+		return printProto(resp)
+	}
+	fmt.Println(resp.Message)
+	return nil
+}
+
+// runDrain 节点准备下线，宽限期内不再参与服务发现，宽限期结束后由服务端注销
+func runDrain(ctl *regctl, args []string) error {
+	flags := newFlagSet("drain")
+	nodeId := flags.String("node", "", "节点ID")
+	grace := flags.Duration("grace", 0, "宽限期，为0时使用服务端默认值")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := require("node", *nodeId); err != nil {
+		return err
+	}
+
+	ctx, cancel := ctl.requestContext()
+	defer cancel()
+	resp, err := ctl.client.PrepareShutdown(ctx, &pb.PrepareShutdownRequest{
+		NodeId:             *nodeId,
+		GracePeriodSeconds: int32(grace.Seconds()),
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Message)
+	}
+	if ctl.jsonOutput {
+		return printProto(resp)
+	}
+	if resp.RemoveTime != "" {
+		fmt.Printf("%s，预计 %s 移除\n", resp.Message, resp.RemoveTime)
+		return nil
+	}
+	fmt.Println(resp.Message)
+	return nil
+}
+
+// runHeartbeat 为节点发送心跳，-count 为0时持续发送直到收到中断信号
+func runHeartbeat(ctl *regctl, args []string) error {
+	flags := newFlagSet("heartbeat")
+	nodeId := flags.String("node", "", "节点ID")
+	interval := flags.Duration("interval", 5*time.Second, "心跳间隔")
+	count := flags.Int("count", 1, "发送次数，0表示持续发送")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := require("node", *nodeId); err != nil {
+		return err
+	}
+	if *count < 0 {
+		return usageError("-count 不能为负数")
+	}
+	if *interval <= 0 {
+		return usageError("-interval 必须大于0")
+	}
+
+	runCtx, stop := signalContext()
+	defer stop()
+	for sent := 0; *count == 0 || sent < *count; sent++ {
+		if sent > 0 {
+			select {
+			case <-runCtx.Done():
+				return nil
+			case <-time.After(*interval):
+			}
+		}
+		if err := ctl.sendHeartbeat(&pb.HeartbeatRequest{NodeId: *nodeId}); err != nil {
+			// 单次心跳直接返回错误，持续发送时只输出错误继续重试
+			if *count == 1 {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "%s 心跳失败: %v\n", time.Now().Format("15:04:05"), err)
+			continue
+		}
+		if !ctl.jsonOutput {
+			fmt.Printf("%s 心跳成功: %s\n", time.Now().Format("15:04:05"), *nodeId)
+		}
+	}
+	return nil
+}
+
+// sendHeartbeat 发送一次心跳
+func (ctl *regctl) sendHeartbeat(req *pb.HeartbeatRequest) error {
+	ctx, cancel := ctl.requestContext()
+	defer cancel()
+	resp, err := ctl.client.Heartbeat(ctx, req)
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Message)
+	}
+	return nil
+}
+
+// runWatch 订阅并输出服务变更事件，直到收到中断信号或服务端关闭连接
+func runWatch(ctl *regctl, args []string) error {
+	flags := newFlagSet("watch")
+	namespaceId := flags.String("ns", "", "命名空间ID")
+	groupName := flags.String("group", defaultGroupName, "分组名称")
+	services := flags.String("service", "", "服务名称，多个用逗号分隔；为空时订阅整个命名空间")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := require("ns", *namespaceId); err != nil {
+		return err
+	}
+
+	runCtx, stop := signalContext()
+	defer stop()
+
+	var stream interface {
+		Recv() (*pb.ServiceChangeEvent, error)
+	}
+	var err error
+	if serviceNames := splitList(*services); len(serviceNames) > 0 {
+		stream, err = ctl.client.SubscribeServices(runCtx, &pb.SubscribeServicesRequest{
+			NamespaceId:  *namespaceId,
+			GroupName:    *groupName,
+			ServiceNames: serviceNames,
+		})
+	} else {
+		stream, err = ctl.client.SubscribeNamespace(runCtx, &pb.SubscribeNamespaceRequest{
+			NamespaceId: *namespaceId,
+			GroupName:   *groupName,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "已订阅 %s/%s，等待变更事件，按 Ctrl+C 退出\n", *namespaceId, *groupName)
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if runCtx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("订阅中断: %w", err)
+		}
+		if event.EventType == subscriber.EventTypePing {
+			continue
+		}
+		if ctl.jsonOutput {
+			data, err := protojson.Marshal(event)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			continue
+		}
+		fmt.Printf("%s %-16s %s/%s/%s nodes=%d",
+			event.Timestamp, event.EventType, event.NamespaceId, event.GroupName, event.ServiceName, len(event.Nodes))
+		if node := event.ChangedNode; node != nil {
+			fmt.Printf(" changed=%s %s:%d %s/%s", node.NodeId, node.IpAddress, node.PortNumber, node.InstanceStatus, node.HealthyStatus)
+		}
+		fmt.Println()
+	}
+}
+
+// printNodes 以表格输出节点列表
+func printNodes(nodes []*pb.Node) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE_ID\tADDRESS\tWEIGHT\tEPHEMERAL\tSTATUS\tHEALTH\tMETADATA")
+	for _, node := range nodes {
+		metadata, _ := json.Marshal(node.Metadata)
+		fmt.Fprintf(w, "%s\t%s:%d\t%.2f\t%s\t%s\t%s\t%s\n",
+			node.NodeId, node.IpAddress, node.PortNumber, node.Weight, node.Ephemeral,
+			node.InstanceStatus, node.HealthyStatus, metadata)
+	}
+	w.Flush()
+}
+
+// printProto 以缩进的JSON格式输出protobuf消息
+func printProto(message proto.Message) error {
+	data, err := protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(message)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// parseMetadata 解析 key=value,key2=value2 格式的元数据
+func parseMetadata(value string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	for _, pair := range splitList(value) {
+		key, val, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("元数据格式错误: %s，应为 key=value", pair)
+		}
+		metadata[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	return metadata, nil
+}
+
+// splitList 拆分逗号分隔的列表，去除空白项
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
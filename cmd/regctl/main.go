@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	pb "gateway/internal/servicecenter/server/proto"
+)
+
+const version = "1.0.0"
+
+// 退出码
+const (
+	exitOK    = 0 // 执行成功
+	exitError = 1 // 执行失败
+	exitUsage = 2 // 参数错误
+)
+
+// command 子命令定义
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(ctl *regctl, args []string) error
+}
+
+// regctl 命令执行上下文
+type regctl struct {
+	client     pb.ServiceRegistryClient
+	timeout    time.Duration
+	jsonOutput bool
+}
+
+var commands = []command{
+	{"services", "services -ns <命名空间> [-group <分组>]", "列出命名空间下的服务及节点数量", runServices},
+	{"nodes", "nodes -ns <命名空间> [-group <分组>] -service <服务> [-healthy]", "列出服务的节点", runNodes},
+	{"select", "select -ns <命名空间> -service <服务> [-zone <可用区>] [-strategy <策略>]", "由服务端按负载均衡策略选择一个可调用节点", runSelect},
+	{"register", "register -ns <命名空间> -service <服务> -ip <IP> -port <端口> [-keepalive 5s]", "注册测试节点，-keepalive 时持续发送心跳，退出时注销", runRegister},
+	{"deregister", "deregister -node <节点ID> | -ns <命名空间> -service <服务>", "注销节点或整个服务", runDeregister},
+	{"drain", "drain -node <节点ID> [-grace 30s]", "节点准备下线，宽限期内不参与服务发现，到期后注销", runDrain},
+	{"heartbeat", "heartbeat -node <节点ID> [-interval 5s] [-count 1]", "为节点发送心跳", runHeartbeat},
+	{"watch", "watch -ns <命名空间> [-group <分组>] [-service a,b]", "订阅并输出服务变更事件，未指定服务时订阅整个命名空间", runWatch},
+}
+
+func main() {
+	var (
+		server      = flag.String("server", envOrDefault("REGCTL_SERVER", "127.0.0.1:12004"), "注册中心gRPC地址（环境变量 REGCTL_SERVER）")
+		userId      = flag.String("u", os.Getenv("REGCTL_USER"), "Basic认证用户ID（环境变量 REGCTL_USER）")
+		password    = flag.String("p", os.Getenv("REGCTL_PASSWORD"), "Basic认证密码（环境变量 REGCTL_PASSWORD）")
+		token       = flag.String("token", os.Getenv("REGCTL_TOKEN"), "Bearer认证令牌（环境变量 REGCTL_TOKEN）")
+		useTLS      = flag.Bool("tls", false, "使用TLS连接")
+		caFile      = flag.String("ca", "", "校验服务端证书的CA证书文件，未指定时使用系统证书")
+		skipVerify  = flag.Bool("insecure-skip-verify", false, "跳过服务端证书校验（仅用于测试）")
+		output      = flag.String("o", "text", "输出格式: text 或 json")
+		timeout     = flag.Duration("timeout", 10*time.Second, "单次请求超时时间")
+		showVersion = flag.Bool("v", false, "显示版本信息")
+	)
+	flag.Usage = usage
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Printf("regctl version %s\n", version)
+		return
+	}
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(exitUsage)
+	}
+	if *output != "text" && *output != "json" {
+		fmt.Fprintf(os.Stderr, "不支持的输出格式: %s\n", *output)
+		os.Exit(exitUsage)
+	}
+
+	name := flag.Arg(0)
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		conn, err := dial(dialOptions{
+			server:     *server,
+			userId:     *userId,
+			password:   *password,
+			token:      *token,
+			useTLS:     *useTLS,
+			caFile:     *caFile,
+			skipVerify: *skipVerify,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+			os.Exit(exitError)
+		}
+
+		ctl := &regctl{
+			client:     pb.NewServiceRegistryClient(conn),
+			timeout:    *timeout,
+			jsonOutput: *output == "json",
+		}
+		code := runCommand(ctl, cmd, flag.Args()[1:])
+		conn.Close()
+		os.Exit(code)
+	}
+
+	fmt.Fprintf(os.Stderr, "未知命令: %s\n\n", name)
+	usage()
+	os.Exit(exitUsage)
+}
+
+// runCommand 执行子命令并返回退出码
+func runCommand(ctl *regctl, cmd command, args []string) int {
+	err := cmd.run(ctl, args)
+	if err == nil || err == flag.ErrHelp {
+		return exitOK
+	}
+	if _, ok := err.(usageError); ok {
+		if err.Error() != "" {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		fmt.Fprintf(os.Stderr, "用法: regctl [全局选项] %s\n", cmd.usage)
+		return exitUsage
+	}
+	fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+	return exitError
+}
+
+// usageError 参数错误，以退出码2退出并输出子命令用法
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// requestContext 创建单次请求的超时上下文
+func (ctl *regctl) requestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), ctl.timeout)
+}
+
+// signalContext 创建收到中断信号时取消的上下文，用于持续运行的命令
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// usage 输出帮助信息
+func usage() {
+	fmt.Fprintf(os.Stderr, "regctl - 服务注册中心命令行工具 (version %s)\n", version)
+	fmt.Fprintf(os.Stderr, "\n用法: regctl [全局选项] <命令> [命令选项]\n\n")
+	fmt.Fprintf(os.Stderr, "命令:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-72s %s\n", cmd.usage, cmd.summary)
+	}
+	fmt.Fprintf(os.Stderr, "\n全局选项:\n")
+	flag.PrintDefaults()
+	fmt.Fprintf(os.Stderr, "\n示例:\n")
+	fmt.Fprintf(os.Stderr, "  # 查看命名空间下的服务\n")
+	fmt.Fprintf(os.Stderr, "  regctl services -ns public\n\n")
+	fmt.Fprintf(os.Stderr, "  # 注册一个测试节点并保持心跳，Ctrl+C 退出时自动注销\n")
+	fmt.Fprintf(os.Stderr, "  regctl register -ns public -service user-service -ip 127.0.0.1 -port 8080 -keepalive 5s\n\n")
+	fmt.Fprintf(os.Stderr, "  # 在另一个终端观察订阅方收到的变更事件\n")
+	fmt.Fprintf(os.Stderr, "  regctl watch -ns public -service user-service\n\n")
+	fmt.Fprintf(os.Stderr, "退出码: 0 成功, 1 执行失败, 2 参数错误\n")
+}
+
+// envOrDefault 读取环境变量，未设置时返回默认值
+func envOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,185 @@
+package starter
+
+import (
+	"context"
+	"fmt"
+	"gateway/internal/servicecenter"
+	"gateway/internal/servicecenter/server"
+	"gateway/pkg/cache"
+	"gateway/pkg/config"
+	"gateway/pkg/crash"
+	"gateway/pkg/health"
+	"gateway/pkg/timer"
+	"sort"
+	"time"
+)
+
+// registerHealthChecks 注册各子系统的健康检查，由 /healthz、/readyz 汇总
+// 默认数据库、缓存、网关实例为关键组件，注册中心、定时任务和后台协程崩溃只降级不影响就绪
+func registerHealthChecks() {
+	health.Register(health.Check{Name: "database", Critical: true, Func: checkDatabase})
+	health.Register(health.Check{Name: "cache", Critical: true, Func: checkCache})
+	if config.GetBool("app.gateway.enabled", false) {
+		health.Register(health.Check{Name: "gateway", Critical: true, Func: checkGateway})
+	}
+	if config.GetBool("app.registry.enabled", false) {
+		health.Register(health.Check{Name: "registry", Func: checkRegistry})
+	}
+	if config.GetBool("app.timer.enabled", false) {
+		health.Register(health.Check{Name: "timer", Func: checkTimer})
+	}
+	if config.GetBool("cache.snapshot.enabled", false) {
+		health.Register(health.Check{Name: "cache_snapshot", Func: checkCacheSnapshot})
+	}
+	health.Register(health.Check{Name: "crash", Func: checkCrash})
+}
+
+// checkDatabase 检查所有数据库连接，默认连接不可用时为 DOWN，其他连接不可用时为 DEGRADED
+func checkDatabase(ctx context.Context) (health.Status, map[string]interface{}, error) {
+	if db == nil {
+		return health.StatusDown, nil, fmt.Errorf("默认数据库连接未初始化")
+	}
+	if err := db.Ping(ctx); err != nil {
+		return health.StatusDown, nil, fmt.Errorf("默认数据库连接不可用: %w", err)
+	}
+
+	status := health.StatusUp
+	connections := make(map[string]interface{}, len(dbConnections))
+	for name, conn := range dbConnections {
+		if conn == nil || conn == db {
+			connections[name] = string(health.StatusUp)
+			continue
+		}
+		if err := conn.Ping(ctx); err != nil {
+			connections[name] = err.Error()
+			status = health.StatusDegraded
+			continue
+		}
+		connections[name] = string(health.StatusUp)
+	}
+	return status, map[string]interface{}{"connections": connections}, nil
+}
+
+// checkCache 检查所有缓存连接，全部不可用时为 DOWN，部分不可用时为 DEGRADED
+func checkCache(ctx context.Context) (health.Status, map[string]interface{}, error) {
+	results := cache.HealthCheck(ctx)
+	connections := make(map[string]interface{}, len(results))
+	failed := 0
+	for name, err := range results {
+		if err != nil {
+			connections[name] = err.Error()
+			failed++
+			continue
+		}
+		connections[name] = string(health.StatusUp)
+	}
+	details := map[string]interface{}{"connections": connections}
+	switch {
+	case failed > 0 && failed == len(results):
+		return health.StatusDown, details, fmt.Errorf("缓存连接均不可用")
+	case failed > 0:
+		return health.StatusDegraded, details, nil
+	}
+	return health.StatusUp, details, nil
+}
+
+// checkGateway 检查网关实例运行状态，没有运行中的实例时为 DOWN，部分实例未运行时为 DEGRADED
+func checkGateway(ctx context.Context) (health.Status, map[string]interface{}, error) {
+	if gatewayApp == nil {
+		return health.StatusDown, nil, fmt.Errorf("网关应用未初始化")
+	}
+	status := gatewayApp.GetStatus()
+	total, _ := status["total_instances"].(int)
+	running, _ := status["running_instances"].(int)
+	details := map[string]interface{}{
+		"totalInstances":   total,
+		"runningInstances": running,
+		"instances":        status["instances"],
+	}
+	switch {
+	case total > 0 && running == 0:
+		return health.StatusDown, details, fmt.Errorf("没有运行中的网关实例")
+	case running < total:
+		return health.StatusDegraded, details, nil
+	}
+	return health.StatusUp, details, nil
+}
+
+// checkRegistry 检查注册中心实例运行状态
+func checkRegistry(ctx context.Context) (health.Status, map[string]interface{}, error) {
+	manager := servicecenter.GetManager()
+	if manager == nil {
+		return health.StatusDown, nil, fmt.Errorf("注册中心未初始化")
+	}
+	instances := make(map[string]interface{})
+	total, running := 0, 0
+	_ = manager.ForEachInstance(func(instanceName string, srv *server.Server) error {
+		total++
+		if srv != nil && srv.IsRunning() {
+			running++
+			instances[instanceName] = string(health.StatusUp)
+		} else {
+			instances[instanceName] = string(health.StatusDown)
+		}
+		return nil
+	})
+	details := map[string]interface{}{"totalInstances": total, "runningInstances": running, "instances": instances}
+	switch {
+	case total > 0 && running == 0:
+		return health.StatusDown, details, fmt.Errorf("没有运行中的注册中心实例")
+	case running < total:
+		return health.StatusDegraded, details, nil
+	}
+	return health.StatusUp, details, nil
+}
+
+// checkTimer 检查定时任务调度器，存在已停止的调度器时为 DEGRADED
+func checkTimer(ctx context.Context) (health.Status, map[string]interface{}, error) {
+	pool := timer.GetTimerPool()
+	running := pool.GetRunningSchedulers()
+	stopped := pool.GetStoppedSchedulers()
+	sort.Strings(stopped)
+	details := map[string]interface{}{
+		"totalSchedulers":   pool.GetSchedulerCount(),
+		"runningSchedulers": len(running),
+		"stoppedSchedulers": stopped,
+	}
+	if len(stopped) > 0 {
+		return health.StatusDegraded, details, nil
+	}
+	return health.StatusUp, details, nil
+}
+
+// checkCacheSnapshot 检查是否有数据从本地快照恢复，存在时为 DEGRADED，stale 标记数据可能已过时
+func checkCacheSnapshot(ctx context.Context) (health.Status, map[string]interface{}, error) {
+	restored := cache.RestoredSnapshots()
+	if len(restored) == 0 {
+		return health.StatusUp, map[string]interface{}{"stale": false}, nil
+	}
+	now := time.Now()
+	snapshots := make([]map[string]interface{}, 0, len(restored))
+	for _, info := range restored {
+		snapshots = append(snapshots, map[string]interface{}{
+			"name":       info.Name,
+			"savedAt":    info.SavedAt,
+			"restoredAt": info.RestoredAt,
+			"ageSeconds": int64(info.Age(now).Seconds()),
+		})
+	}
+	return health.StatusDegraded, map[string]interface{}{"stale": true, "snapshots": snapshots}, nil
+}
+
+// checkCrash 汇总后台协程的崩溃统计，存在重启次数超过上限而停止运行的组件时为 DEGRADED
+func checkCrash(ctx context.Context) (health.Status, map[string]interface{}, error) {
+	stats := crash.Stats()
+	details := map[string]interface{}{"totalCrashes": crash.TotalCrashes()}
+	if len(stats) > 0 {
+		details["components"] = stats
+	}
+	for _, s := range stats {
+		if s.GaveUp {
+			return health.StatusDegraded, details, nil
+		}
+	}
+	return health.StatusUp, details, nil
+}
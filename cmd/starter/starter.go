@@ -9,6 +9,8 @@ import (
 	"gateway/pkg/config"
 	"gateway/pkg/database"
 	_ "gateway/pkg/database/alldriver" // 导入数据库驱动以确保注册
+	"gateway/pkg/health"
+	"gateway/pkg/lifecycle"
 	"gateway/pkg/logger"
 	"gateway/pkg/utils/huberrors"
 	"log"
@@ -16,7 +18,9 @@ import (
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"syscall"
+	"time"
 
 	"gopkg.in/natefinch/lumberjack.v2"
 )
@@ -29,12 +33,19 @@ var (
 	dbConnections map[string]database.Database
 	// gatewayApp 网关应用实例
 	gatewayApp *appinit.GatewayApp
+	// lifecycleManager 子系统生命周期管理器
+	lifecycleManager *lifecycle.Manager
 	// 应用上下文
 	appContext context.Context
 	appCancel  context.CancelFunc
 )
 
 func Starter() {
+	// 配置校验子命令只校验配置文件，不启动应用
+	if isValidateCommand() {
+		os.Exit(runValidateCommand(os.Args[2:]))
+	}
+
 	// 检查是否在Windows服务模式下运行
 	if runtime.GOOS == "windows" && config.IsServiceMode() {
 		log.Println("检测到Windows服务模式，启动Windows服务...")
@@ -70,6 +81,7 @@ func Starter() {
 	fmt.Printf("支持的命令行参数:\n")
 	fmt.Printf("  --config <dir>  指定配置文件目录路径\n")
 	fmt.Printf("  --service       以服务模式运行\n")
+	fmt.Printf("  validate -c <file>  校验网关配置文件后退出\n")
 	fmt.Printf("环境变量: GATEWAY_CONFIG_DIR\n")
 	fmt.Printf("优先级: 命令行参数 > 环境变量 > 默认值(./configs)\n")
 	fmt.Println()
@@ -97,106 +109,161 @@ func Starter() {
 }
 
 // initializeAndStartApplication 初始化并启动应用
+// 各子系统注册到生命周期管理器，按依赖顺序初始化和启动，启动完成后输出启动报告
 func initializeAndStartApplication() error {
-	// 初始化配置（加载配置文件并设置全局时区）
-	configDir := config.GetConfigDir()
-	if err := config.InitializeConfig(configDir, config.LoadOptions{
-		ClearExisting: false,
-		AllowOverride: true,
-	}); err != nil {
-		return huberrors.WrapError(err, "初始化配置失败")
-	}
-
-	// 初始化日志
-	if err := logger.Setup(); err != nil {
-		return huberrors.WrapError(err, "初始化日志失败")
-	}
-
-	// 初始化数据库
-	if err := initDatabase(); err != nil {
-		return huberrors.WrapError(err, "初始化数据库失败")
-	}
-
-	// 初始化缓存
-	if _, err := appinit.InitCache(); err != nil {
-		return huberrors.WrapError(err, "初始化缓存失败")
-	}
-
-	// 初始化MongoDB
-	if _, err := appinit.InitializeMongoDB(); err != nil {
-		return huberrors.WrapError(err, "初始化MongoDB失败")
-	}
-
-	// 初始化数据库脚本
-	if err := appinit.InitializeDatabaseScriptsWithConfig(appContext, db); err != nil {
-		return huberrors.WrapError(err, "初始化数据库脚本失败")
-	}
-
-	// 初始化告警系统（在数据库、MongoDB、Redis等组件初始化之后）
-	if err := appinit.InitializeAlert(appContext, db, "default"); err != nil {
-		return huberrors.WrapError(err, "初始化告警系统失败")
-	}
-
-	// 初始化集群服务（在定时任务之前初始化）
-	if err := appinit.InitClusterWithConfig(appContext, db); err != nil {
-		return huberrors.WrapError(err, "初始化集群服务失败")
-	}
-
-	// 初始化定时任务
-	if err := appinit.InitAllTimerTasks(appContext, db); err != nil {
-		return huberrors.WrapError(err, "初始化定时任务失败")
-	}
-
-	// 初始化服务中心（失败不影响应用启动）
-	if err := appinit.InitServiceCenterWithConfig(appContext, db); err != nil {
-		logger.Error("初始化服务中心失败", map[string]interface{}{
-			"error": err.Error(),
-		})
-		// 不返回错误，允许应用继续启动
-	}
-
-	// 初始化网关应用
-	if err := initGateway(db); err != nil {
-		return huberrors.WrapError(err, "初始化网关应用失败")
-	}
-
-	// 启动网关服务
-	if err := startGatewayServices(); err != nil {
-		return huberrors.WrapError(err, "启动网关服务失败")
-	}
-
-	// 初始化pprof服务
-	if err := appinit.InitPprofService(appContext); err != nil {
-		return huberrors.WrapError(err, "初始化pprof服务失败")
-	}
-
-	// 初始化指标收集器
-	if err := appinit.InitializeMetricCollector(db); err != nil {
-		return huberrors.WrapError(err, "初始化指标收集器失败")
+	lifecycleManager = newLifecycleManager()
+	registerHealthChecks()
+	report, err := lifecycleManager.Start(appContext)
+	printMessage("%s", strings.TrimRight(report.String(), "\n"))
+	if err != nil {
+		return huberrors.WrapError(err, "启动应用失败")
 	}
+	// 所有子系统启动完成后就绪检查才可能通过
+	health.SetStarted(true)
+	return nil
+}
 
-	// 初始化隧道管理器（失败不影响应用启动）
-	if err := appinit.InitializeTunnelManager(appContext, db); err != nil {
-		logger.Error("初始化隧道管理器失败", map[string]interface{}{
-			"error": err.Error(),
-		})
-		// 不返回错误，允许应用继续启动
-	}
+// newLifecycleManager 创建生命周期管理器并注册所有子系统
+// 注册顺序即为没有依赖关系时的启动顺序，停止时按启动的逆序执行
+func newLifecycleManager() *lifecycle.Manager {
+	manager := lifecycle.NewManager()
+	manager.SetTimeoutResolver(moduleTimeout)
+	manager.MustRegister(
+		lifecycle.Module{
+			Name: "config",
+			Init: func(ctx context.Context) error {
+				// 加载配置文件并设置全局时区
+				return config.InitializeConfig(config.GetConfigDir(), config.LoadOptions{
+					ClearExisting: false,
+					AllowOverride: true,
+				})
+			},
+		},
+		lifecycle.Module{
+			Name:      "logger",
+			DependsOn: []string{"config"},
+			Init:      func(ctx context.Context) error { return logger.Setup() },
+		},
+		lifecycle.Module{
+			Name:      "database",
+			DependsOn: []string{"logger"},
+			Init:      func(ctx context.Context) error { return initDatabase() },
+			Stop:      func(ctx context.Context) error { return database.CloseAllConnections() },
+		},
+		lifecycle.Module{
+			Name:      "cache",
+			DependsOn: []string{"logger"},
+			Init: func(ctx context.Context) error {
+				_, err := appinit.InitCache()
+				return err
+			},
+			Stop: func(ctx context.Context) error { return cache.CloseAllConnections() },
+		},
+		lifecycle.Module{
+			Name:      "mongodb",
+			DependsOn: []string{"logger"},
+			Init: func(ctx context.Context) error {
+				_, err := appinit.InitializeMongoDB()
+				return err
+			},
+			Stop: func(ctx context.Context) error { return appinit.StopMongoDB() },
+		},
+		lifecycle.Module{
+			Name:      "dbscripts",
+			DependsOn: []string{"database"},
+			Init: func(ctx context.Context) error {
+				return appinit.InitializeDatabaseScriptsWithConfig(appContext, db)
+			},
+		},
+		lifecycle.Module{
+			// 告警系统依赖数据库、MongoDB、Redis等组件
+			Name:      "alert",
+			DependsOn: []string{"database", "cache", "mongodb", "dbscripts"},
+			Init:      func(ctx context.Context) error { return appinit.InitializeAlert(appContext, db, "default") },
+			Stop: func(ctx context.Context) error {
+				appinit.ShutdownAlert(ctx)
+				return nil
+			},
+		},
+		lifecycle.Module{
+			Name:      "cluster",
+			DependsOn: []string{"database", "dbscripts"},
+			Init:      func(ctx context.Context) error { return appinit.InitClusterWithConfig(appContext, db) },
+			Stop:      func(ctx context.Context) error { return appinit.StopCluster(ctx) },
+		},
+		lifecycle.Module{
+			// 定时任务在集群服务之后初始化
+			Name:      "timer",
+			DependsOn: []string{"database", "cluster"},
+			Init:      func(ctx context.Context) error { return appinit.InitAllTimerTasks(appContext, db) },
+			Stop:      func(ctx context.Context) error { return appinit.StopAllTimerTasks() },
+		},
+		lifecycle.Module{
+			// 服务中心失败不影响应用启动
+			Name:      "servicecenter",
+			DependsOn: []string{"database", "dbscripts"},
+			Optional:  true,
+			Init:      func(ctx context.Context) error { return appinit.InitServiceCenterWithConfig(appContext, db) },
+			Stop:      func(ctx context.Context) error { return appinit.StopServiceCenter(ctx) },
+		},
+		lifecycle.Module{
+			// 地理位置库失败时仅关闭地理位置功能
+			Name:      "geoip",
+			DependsOn: []string{"logger"},
+			Optional:  true,
+			Init:      func(ctx context.Context) error { return appinit.InitGeoIP() },
+		},
+		lifecycle.Module{
+			Name:      "gateway",
+			DependsOn: []string{"database", "cache", "dbscripts"},
+			Init:      func(ctx context.Context) error { return initGateway(db) },
+			Start:     func(ctx context.Context) error { return startGatewayServices() },
+			Stop:      func(ctx context.Context) error { return stopGateway() },
+		},
+		lifecycle.Module{
+			Name:      "pprof",
+			DependsOn: []string{"logger"},
+			Init:      func(ctx context.Context) error { return appinit.InitPprofService(appContext) },
+			Stop:      func(ctx context.Context) error { return appinit.StopPprofService() },
+		},
+		lifecycle.Module{
+			Name:      "metriccollector",
+			DependsOn: []string{"database"},
+			Init:      func(ctx context.Context) error { return appinit.InitializeMetricCollector(db) },
+			Stop:      func(ctx context.Context) error { return appinit.StopMetricCollector() },
+		},
+		lifecycle.Module{
+			// 隧道管理器失败不影响应用启动
+			Name:      "tunnel",
+			DependsOn: []string{"database", "dbscripts"},
+			Optional:  true,
+			Init:      func(ctx context.Context) error { return appinit.InitializeTunnelManager(appContext, db) },
+			Start:     func(ctx context.Context) error { return appinit.StartTunnelManager(appContext) },
+			Stop:      func(ctx context.Context) error { return appinit.StopTunnelManager(ctx) },
+		},
+		lifecycle.Module{
+			// Web应用放在最后启动
+			Name:      "web",
+			DependsOn: []string{"database", "cache", "dbscripts", "alert", "timer", "gateway"},
+			Init:      func(ctx context.Context) error { return webapp.StartWebApp(db) },
+		},
+	)
+	return manager
+}
 
-	// 启动隧道管理器（失败不影响应用启动）
-	if err := appinit.StartTunnelManager(appContext); err != nil {
-		logger.Error("启动隧道管理器失败", map[string]interface{}{
-			"error": err.Error(),
-		})
-		// 不返回错误，允许应用继续启动
+// moduleTimeout 读取模块超时配置
+// app.lifecycle.start_timeout_seconds / stop_timeout_seconds 为默认值，
+// app.lifecycle.modules.<模块>.start_timeout_seconds / stop_timeout_seconds 为单个模块的设置
+func moduleTimeout(module string, phase lifecycle.Phase) time.Duration {
+	key := "start_timeout_seconds"
+	if phase == lifecycle.PhaseStop {
+		key = "stop_timeout_seconds"
 	}
-
-	// 启动Web应用（放在最后启动）
-	if err := webapp.StartWebApp(db); err != nil {
-		return huberrors.WrapError(err, "启动Web应用失败")
+	seconds := config.GetInt("app.lifecycle.modules."+module+"."+key, 0)
+	if seconds <= 0 {
+		seconds = config.GetInt("app.lifecycle."+key, 0)
 	}
-
-	return nil
+	return time.Duration(seconds) * time.Second
 }
 
 // setupServiceLogging 设置服务模式日志
@@ -306,30 +373,7 @@ func stopApplication() {
 	// 取消应用上下文
 	appCancel()
 
-	// 停止pprof服务
-	if err := appinit.StopPprofService(); err != nil {
-		logger.Error("停止pprof服务失败", "error", err)
-	}
-
-	// 停止指标收集器
-	if err := appinit.StopMetricCollector(); err != nil {
-		logger.Error("停止指标收集器失败", "error", err)
-	}
-
-	// 停止隧道管理器
-	if err := appinit.StopTunnelManager(appContext); err != nil {
-		logger.Error("停止隧道管理器失败", "error", err)
-	}
-
-	// 停止集群服务
-	if err := appinit.StopCluster(appContext); err != nil {
-		logger.Error("停止集群服务失败", "error", err)
-	}
-
-	// 关闭告警系统
-	appinit.ShutdownAlert(appContext)
-
-	// 清理资源
+	// 按启动的逆序停止各子系统
 	cleanupResources()
 
 	if config.IsServiceMode() {
@@ -418,74 +462,45 @@ func startGatewayServices() error {
 	return nil
 }
 
-// cleanupResources 清理资源
-func cleanupResources() {
-	logMsg := func(msg string, args ...interface{}) {
-		if config.IsServiceMode() {
-			log.Printf(msg, args...)
-		} else {
-			fmt.Printf(msg+"\n", args...)
-		}
-	}
-
-	logMsg("开始清理应用资源...")
-
-	// 停止所有定时任务
-	if err := appinit.StopAllTimerTasks(); err != nil {
-		logMsg("停止定时任务时发生错误: %v", err)
-	} else {
-		logMsg("定时任务已成功停止")
-	}
-
-	// 关闭网关应用
-	if gatewayApp != nil {
-		logMsg("正在关闭网关应用...")
-
-		// 获取网关状态信息
-		status := gatewayApp.GetStatus()
-		logMsg("网关状态信息 - enabled: %v, total_instances: %v, running_instances: %v",
-			status["enabled"], status["total_instances"], status["running_instances"])
-
-		if err := gatewayApp.Stop(); err != nil {
-			logMsg("关闭网关应用时发生错误: %v", err)
-		} else {
-			logMsg("网关应用已成功关闭")
-		}
-	} else {
-		logMsg("网关应用未启动，跳过关闭")
+// stopGateway 关闭网关应用
+func stopGateway() error {
+	if gatewayApp == nil {
+		printMessage("网关应用未启动，跳过关闭")
+		return nil
 	}
 
-	// 关闭所有缓存连接
-	logMsg("正在关闭缓存连接...")
-	if err := cache.CloseAllConnections(); err != nil {
-		logMsg("关闭缓存连接时发生错误: %v", err)
-	} else {
-		logMsg("缓存连接已成功关闭")
-	}
+	// 获取网关状态信息
+	status := gatewayApp.GetStatus()
+	printMessage("网关状态信息 - enabled: %v, total_instances: %v, running_instances: %v",
+		status["enabled"], status["total_instances"], status["running_instances"])
+	return gatewayApp.Stop()
+}
 
-	// 关闭所有MongoDB连接
-	logMsg("正在关闭MongoDB连接...")
-	if err := appinit.StopMongoDB(); err != nil {
-		logMsg("关闭MongoDB连接时发生错误: %v", err)
-	} else {
-		logMsg("MongoDB连接已成功关闭")
+// cleanupResources 清理资源
+// 按启动的逆序停止已启动的子系统，输出停止报告
+func cleanupResources() {
+	printMessage("开始清理应用资源...")
+	health.SetStarted(false)
+	if lifecycleManager == nil {
+		printMessage("应用未启动，跳过清理")
+		return
 	}
 
-	// 停止服务中心服务
-	logMsg("正在停止服务中心服务...")
-	if err := appinit.StopServiceCenter(appContext); err != nil {
-		logMsg("停止服务中心服务时发生错误: %v", err)
-	} else {
-		logMsg("服务中心服务已成功停止")
+	report := lifecycleManager.Stop(context.Background())
+	printMessage("%s", strings.TrimRight(report.String(), "\n"))
+	// 日志模块最后停止，此时日志已关闭，失败详情直接输出
+	if err := report.Err(); err != nil {
+		printMessage("应用资源清理完成，部分模块停止失败:\n%+v", err)
+		return
 	}
+	printMessage("应用资源清理完成")
+}
 
-	// 关闭所有数据库连接
-	logMsg("正在关闭数据库连接...")
-	if err := database.CloseAllConnections(); err != nil {
-		logMsg("关闭数据库连接时发生错误: %v", err)
+// printMessage 输出启动和停止过程信息，服务模式下写入服务日志，否则输出到控制台
+func printMessage(msg string, args ...interface{}) {
+	if config.IsServiceMode() {
+		log.Printf(msg, args...)
 	} else {
-		logMsg("数据库连接已成功关闭")
+		fmt.Printf(msg+"\n", args...)
 	}
-
-	logMsg("应用资源清理完成")
 }
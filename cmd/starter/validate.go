@@ -0,0 +1,55 @@
+package starter
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"gateway/internal/gateway/loader"
+	"gateway/pkg/config"
+)
+
+// validateCommand 配置校验子命令名称
+const validateCommand = "validate"
+
+// isValidateCommand 检查命令行是否为配置校验子命令
+func isValidateCommand() bool {
+	return len(os.Args) > 1 && os.Args[1] == validateCommand
+}
+
+// runValidateCommand 执行配置校验子命令
+// 用法: gateway validate [-c gateway.yaml] [-strict]
+// 加载网关配置并按启动流程试创建路由、过滤器和负载均衡器，不连接数据库、不监听端口，
+// 输出校验报告；存在错误（-strict 时包括警告）返回非0退出码，便于在CI中拦截配置变更
+// 参数:
+//
+//	args: 子命令之后的命令行参数
+//
+// 返回:
+//
+//	int: 进程退出码，0表示校验通过，1表示校验失败，2表示参数错误
+func runValidateCommand(args []string) int {
+	flags := flag.NewFlagSet(validateCommand, flag.ContinueOnError)
+	configPath := flags.String("c", "", "网关配置文件路径，默认为配置目录下的 gateway.yaml")
+	strict := flags.Bool("strict", false, "将警告视为错误")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	path := *configPath
+	if path == "" {
+		path = config.GetConfigPath("gateway.yaml")
+	}
+
+	report := loader.ValidateConfigFileReport(path)
+	report.Print(os.Stdout)
+
+	if report.HasErrors() {
+		return 1
+	}
+	if *strict && report.WarningCount() > 0 {
+		fmt.Println("严格模式下存在警告，校验失败")
+		return 1
+	}
+	return 0
+}
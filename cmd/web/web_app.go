@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"gateway/pkg/config"
 	"gateway/pkg/database"
+	"gateway/pkg/health"
 	"gateway/pkg/logger"
 	"gateway/pkg/utils/cert"
 	"gateway/pkg/utils/huberrors"
 	"gateway/web/middleware"
 	"gateway/web/routes"
+	"gateway/web/utils/audit"
+	"gateway/web/utils/openapi"
 	"io"
 	"net/http"
 	"os"
@@ -183,6 +186,12 @@ func setupGinLogger() {
 	logger.Info("GIN日志输出已配置", "file", ginLogFile)
 }
 
+// OpenAPI文档和Swagger UI页面路径
+const (
+	openAPISpecPath = "/api/openapi.json"
+	openAPIUIPath   = "/api/docs"
+)
+
 // startWebApp 初始化并启动Web应用
 func StartWebApp(db database.Database) error {
 	// 创建Web应用实例
@@ -338,6 +347,13 @@ func (app *WebApp) Router() *gin.Engine {
 func (app *WebApp) Init() error {
 	logger.Info("初始化Web应用")
 
+	// 初始化操作审计，启用时使用记录数据变更的数据库包装器，审计记录直接写入原数据库
+	audit.Init(app.db)
+	if audit.Enabled() {
+		app.db = database.NewAuditDatabase(app.db)
+		logger.Info("操作审计已启用")
+	}
+
 	// 初始化权限服务（必须在路由注册之前）
 	logger.Info("初始化权限服务")
 	middleware.InitPermissionService(app.db)
@@ -350,6 +366,9 @@ func (app *WebApp) Init() error {
 			"time":   time.Now().Unix(),
 		})
 	})
+	// 存活检查和就绪检查，汇总数据库、缓存、注册中心、定时任务、网关实例的状态
+	app.router.GET("/healthz", gin.WrapF(health.LivenessHandler))
+	app.router.GET("/readyz", gin.WrapF(health.ReadinessHandler))
 
 	// 应用全局中间件
 	routes.ApplyGlobalMiddleware(app.router)
@@ -372,10 +391,43 @@ func (app *WebApp) Init() error {
 	// 应用所有模块的路由
 	manager.RegisterAll(app.router)
 
+	// 注册OpenAPI文档和Swagger UI
+	app.registerOpenAPI()
+
 	logger.Info("Web应用路由初始化完成", "模块数量", len(modules))
 	return nil
 }
 
+// registerOpenAPI 注册OpenAPI文档和Swagger UI页面
+// 文档在首次访问时根据已注册的路由生成，可通过 web.openapi.enabled 关闭，默认需要登录（web.openapi.require_login）；
+// Swagger UI 页面从 web.openapi.ui_asset_url 加载静态资源，未配置时不注册页面，避免默认从公网CDN加载脚本
+func (app *WebApp) registerOpenAPI() {
+	if !config.GetBool("web.openapi.enabled", true) {
+		return
+	}
+
+	var handlers []gin.HandlerFunc
+	if config.GetBool("web.openapi.require_login", true) {
+		handlers = append(handlers, routes.AuthRequired())
+	}
+
+	appName := config.GetString("web.name", "Gateway Web服务")
+	info := openapi.Info{
+		Title:       appName,
+		Description: "管理端接口文档，根据已注册的路由和请求模型生成",
+		Version:     config.GetString("web.openapi.version", "1.0.0"),
+	}
+	app.router.GET(openAPISpecPath, append(handlers, openapi.SpecHandler(app.router, info))...)
+
+	assetURL := config.GetString("web.openapi.ui_asset_url", "")
+	if assetURL == "" {
+		logger.Info("OpenAPI文档已注册，未配置 web.openapi.ui_asset_url，不提供Swagger UI页面", "spec", openAPISpecPath)
+		return
+	}
+	app.router.GET(openAPIUIPath, append(handlers, openapi.UIHandler(appName+" API", openAPISpecPath, assetURL))...)
+	logger.Info("OpenAPI文档已注册", "spec", openAPISpecPath, "ui", openAPIUIPath)
+}
+
 // Start 启动Web服务器
 func (app *WebApp) Start() error {
 	readTimeout := config.GetInt("web.read_timeout", 120)
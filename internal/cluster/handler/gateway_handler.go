@@ -58,6 +58,10 @@ func (h *GatewayEventHandler) Handle(ctx context.Context, event *types.ClusterEv
 		return h.handleReload(ctx, &payload)
 	case "RESTART":
 		return h.handleRestart(ctx, &payload)
+	case "PUBLISH":
+		return h.handlePublish(ctx, &payload)
+	case "ROLLBACK":
+		return h.handleRollback(ctx, &payload)
 	default:
 		return types.NewSkippedResult(fmt.Sprintf("未知的事件动作: %s", event.EventAction))
 	}
@@ -268,6 +272,89 @@ func (h *GatewayEventHandler) handleRestart(ctx context.Context, payload *gatewa
 	return result
 }
 
+// handlePublish 处理配置版本下发事件
+// 事件只携带版本号，配置快照从加密存储的配置版本历史读取；校验失败或重载失败时网关保持原配置，返回失败结果记录到事件确认中
+func (h *GatewayEventHandler) handlePublish(ctx context.Context, payload *gatewayEventPayload) *types.HandleResult {
+	gatewayInstanceId := payload.GatewayInstanceId
+
+	logger.Info("处理网关配置下发事件",
+		"gatewayInstanceId", gatewayInstanceId,
+		"configVersion", payload.ConfigVersion)
+
+	if payload.ConfigVersion == "" {
+		return types.NewFailedResult(nil, "配置版本号不能为空")
+	}
+
+	gatewayPool := bootstrap.GetGlobalPool()
+	if !gatewayPool.Exists(gatewayInstanceId) {
+		return types.NewSkippedResult("网关实例不在连接池中，跳过配置下发")
+	}
+
+	gateway, err := gatewayPool.Get(gatewayInstanceId)
+	if err != nil {
+		return types.NewFailedResult(err, fmt.Sprintf("获取网关实例失败: %v", err))
+	}
+
+	if !gateway.IsRunning() {
+		return types.NewSkippedResult("网关实例未运行，跳过配置下发")
+	}
+
+	snapshot, err := bootstrap.GetConfigVersion(payload.TenantId, gatewayInstanceId, payload.ConfigVersion)
+	if err != nil {
+		return types.NewFailedResult(err, fmt.Sprintf("加载配置版本失败: %v", err))
+	}
+
+	if err := gateway.ApplyConfigSnapshot(snapshot); err != nil {
+		return types.NewFailedResult(err, fmt.Sprintf("应用配置版本失败: %v", err))
+	}
+
+	result := types.NewSuccessResult("网关配置版本应用成功")
+	result.Data = map[string]interface{}{
+		"gatewayInstanceId": gatewayInstanceId,
+		"configVersion":     snapshot.Version,
+		"action":            "published",
+	}
+	return result
+}
+
+// handleRollback 处理配置回滚事件
+func (h *GatewayEventHandler) handleRollback(ctx context.Context, payload *gatewayEventPayload) *types.HandleResult {
+	gatewayInstanceId := payload.GatewayInstanceId
+
+	logger.Info("处理网关配置回滚事件",
+		"gatewayInstanceId", gatewayInstanceId,
+		"configVersion", payload.ConfigVersion)
+
+	gatewayPool := bootstrap.GetGlobalPool()
+	if !gatewayPool.Exists(gatewayInstanceId) {
+		return types.NewSkippedResult("网关实例不在连接池中，跳过配置回滚")
+	}
+
+	gateway, err := gatewayPool.Get(gatewayInstanceId)
+	if err != nil {
+		return types.NewFailedResult(err, fmt.Sprintf("获取网关实例失败: %v", err))
+	}
+
+	// 发布节点已回滚并移除了被撤销的版本，事件携带目标版本时直接切换到该版本
+	version := payload.ConfigVersion
+	if version != "" {
+		err = gateway.RollbackConfigTo(version)
+	} else {
+		version, err = gateway.RollbackConfig()
+	}
+	if err != nil {
+		return types.NewFailedResult(err, fmt.Sprintf("回滚网关配置失败: %v", err))
+	}
+
+	result := types.NewSuccessResult("网关配置回滚成功")
+	result.Data = map[string]interface{}{
+		"gatewayInstanceId": gatewayInstanceId,
+		"configVersion":     version,
+		"action":            "rolledBack",
+	}
+	return result
+}
+
 // gatewayEventPayload 网关事件数据（内部使用）
 type gatewayEventPayload struct {
 	GatewayInstanceId string `json:"gatewayInstanceId"` // 网关实例ID
@@ -275,4 +362,6 @@ type gatewayEventPayload struct {
 	InstanceName      string `json:"instanceName"`      // 实例名称（可选）
 	ConfigFilePath    string `json:"configFilePath"`    // 配置文件路径（可选）
 	Operator          string `json:"operator"`          // 操作人（可选）
+
+	ConfigVersion string `json:"configVersion,omitempty"` // 配置版本号（PUBLISH，ROLLBACK时为目标版本）
 }
@@ -38,8 +38,50 @@ func (p *GatewayEventPublisher) PublishRestartEvent(ctx context.Context, gateway
 	return p.publishEvent(ctx, "RESTART", gatewayInstanceId, tenantId, instanceName, configFilePath, operator)
 }
 
+// PublishConfigEvent 发布网关配置版本下发事件
+// 事件只携带版本号，配置快照由发布方先写入加密存储的配置版本历史（见 bootstrap.SaveConfigVersion），
+// 各节点收到后按版本号读取并应用，校验或重载失败时保持原配置
+func (p *GatewayEventPublisher) PublishConfigEvent(ctx context.Context, gatewayInstanceId, tenantId, instanceName, configVersion, operator string) error {
+	if configVersion == "" {
+		return fmt.Errorf("配置版本号不能为空")
+	}
+	return p.publishEventWithPayload(ctx, "PUBLISH", GatewayEventPayload{
+		GatewayInstanceId: gatewayInstanceId,
+		TenantId:          tenantId,
+		InstanceName:      instanceName,
+		Operator:          operator,
+		ConfigVersion:     configVersion,
+	})
+}
+
+// PublishRollbackEvent 发布网关配置回滚事件
+// configVersion 为发布节点回滚后生效的版本，各节点切换到同一版本，避免每个节点各自再回退一个版本
+func (p *GatewayEventPublisher) PublishRollbackEvent(ctx context.Context, gatewayInstanceId, tenantId, instanceName, configVersion, operator string) error {
+	return p.publishEventWithPayload(ctx, "ROLLBACK", GatewayEventPayload{
+		GatewayInstanceId: gatewayInstanceId,
+		TenantId:          tenantId,
+		InstanceName:      instanceName,
+		Operator:          operator,
+		ConfigVersion:     configVersion,
+	})
+}
+
 // publishEvent 发布网关事件的通用方法
 func (p *GatewayEventPublisher) publishEvent(ctx context.Context, action, gatewayInstanceId, tenantId, instanceName, configFilePath, operator string) error {
+	return p.publishEventWithPayload(ctx, action, GatewayEventPayload{
+		GatewayInstanceId: gatewayInstanceId,
+		TenantId:          tenantId,
+		InstanceName:      instanceName,
+		ConfigFilePath:    configFilePath,
+		Operator:          operator,
+	})
+}
+
+// publishEventWithPayload 使用完整事件数据发布网关事件
+func (p *GatewayEventPublisher) publishEventWithPayload(ctx context.Context, action string, payload GatewayEventPayload) error {
+	gatewayInstanceId := payload.GatewayInstanceId
+	tenantId := payload.TenantId
+
 	// 检查集群服务是否已初始化
 	if !clusterInit.IsClusterInitialized() {
 		logger.Debug("集群服务未初始化，跳过事件发布",
@@ -72,14 +114,6 @@ func (p *GatewayEventPublisher) publishEvent(ctx context.Context, action, gatewa
 	}
 
 	// 设置事件数据
-	payload := GatewayEventPayload{
-		GatewayInstanceId: gatewayInstanceId,
-		TenantId:          tenantId,
-		InstanceName:      instanceName,
-		ConfigFilePath:    configFilePath,
-		Operator:          operator,
-	}
-
 	if err := event.SetPayload(payload); err != nil {
 		return fmt.Errorf("设置事件数据失败: %w", err)
 	}
@@ -105,4 +139,7 @@ type GatewayEventPayload struct {
 	InstanceName      string `json:"instanceName"`      // 实例名称（可选）
 	ConfigFilePath    string `json:"configFilePath"`    // 配置文件路径（可选）
 	Operator          string `json:"operator"`          // 操作人（可选）
+
+	// 配置下发（PUBLISH）和回滚（ROLLBACK）字段，配置内容不放入事件，避免敏感配置以明文写入集群事件表
+	ConfigVersion string `json:"configVersion,omitempty"` // 配置版本号，回滚时为回滚后生效的版本
 }
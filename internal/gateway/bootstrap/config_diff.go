@@ -0,0 +1,185 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"gateway/internal/gateway/config"
+)
+
+// 配置差异的类别
+const (
+	ConfigDiffCategoryRoute   = "route"
+	ConfigDiffCategoryService = "service"
+	ConfigDiffCategoryFilter  = "filter"
+	ConfigDiffCategorySection = "section"
+)
+
+// 配置差异的变更类型
+const (
+	ConfigDiffActionAdded    = "ADDED"
+	ConfigDiffActionRemoved  = "REMOVED"
+	ConfigDiffActionModified = "MODIFIED"
+)
+
+// ConfigChange 两个配置版本之间的一项差异
+type ConfigChange struct {
+	// Category 差异类别：route/service/filter/section
+	Category string `json:"category"`
+	// ID 路由、服务或过滤器ID；配置段差异时为配置段名称，如 base、cors
+	ID string `json:"id"`
+	// Name 名称，便于管理端展示
+	Name string `json:"name,omitempty"`
+	// Action 变更类型：ADDED/REMOVED/MODIFIED
+	Action string `json:"action"`
+	// Fields 发生变化的字段（JSON字段名），仅 MODIFIED 时有值
+	Fields []string `json:"fields,omitempty"`
+}
+
+// diffItem 参与比较的配置项
+type diffItem struct {
+	name  string
+	value interface{}
+}
+
+// DiffGatewayConfig 比较当前生效配置和待发布配置，返回差异列表
+// 路由、服务和全局过滤器按ID比较，其余配置段整体比较；current为空时所有路由、服务和过滤器都视为新增
+func DiffGatewayConfig(current, next *config.GatewayConfig) []ConfigChange {
+	if current == nil {
+		current = &config.GatewayConfig{}
+	}
+	if next == nil {
+		next = &config.GatewayConfig{}
+	}
+
+	changes := make([]ConfigChange, 0)
+
+	for _, section := range []struct {
+		name          string
+		current, next interface{}
+	}{
+		{"base", current.Base, next.Base},
+		{"security", current.Security, next.Security},
+		{"auth", current.Auth, next.Auth},
+		{"cors", current.CORS, next.CORS},
+		{"rate_limit", current.RateLimit, next.RateLimit},
+		{"log", current.Log, next.Log},
+		{"error_response", current.ErrorResponse, next.ErrorResponse},
+		{"proxy", proxyWithoutServices(current), proxyWithoutServices(next)},
+	} {
+		if fields := diffFields(section.current, section.next); len(fields) > 0 {
+			changes = append(changes, ConfigChange{
+				Category: ConfigDiffCategorySection,
+				ID:       section.name,
+				Action:   ConfigDiffActionModified,
+				Fields:   fields,
+			})
+		}
+	}
+
+	currentRoutes := make(map[string]diffItem, len(current.Router.Routes))
+	for _, route := range current.Router.Routes {
+		currentRoutes[route.ID] = diffItem{name: route.Name, value: route}
+	}
+	nextRoutes := make(map[string]diffItem, len(next.Router.Routes))
+	for _, route := range next.Router.Routes {
+		nextRoutes[route.ID] = diffItem{name: route.Name, value: route}
+	}
+	changes = append(changes, diffItems(ConfigDiffCategoryRoute, currentRoutes, nextRoutes)...)
+
+	currentServices := make(map[string]diffItem, len(current.Proxy.Service))
+	for _, svc := range current.Proxy.Service {
+		if svc != nil {
+			currentServices[svc.ID] = diffItem{name: svc.Name, value: svc}
+		}
+	}
+	nextServices := make(map[string]diffItem, len(next.Proxy.Service))
+	for _, svc := range next.Proxy.Service {
+		if svc != nil {
+			nextServices[svc.ID] = diffItem{name: svc.Name, value: svc}
+		}
+	}
+	changes = append(changes, diffItems(ConfigDiffCategoryService, currentServices, nextServices)...)
+
+	currentFilters := make(map[string]diffItem, len(current.Router.FilterConfig))
+	for _, f := range current.Router.FilterConfig {
+		currentFilters[f.ID] = diffItem{name: f.Name, value: f}
+	}
+	nextFilters := make(map[string]diffItem, len(next.Router.FilterConfig))
+	for _, f := range next.Router.FilterConfig {
+		nextFilters[f.ID] = diffItem{name: f.Name, value: f}
+	}
+	changes = append(changes, diffItems(ConfigDiffCategoryFilter, currentFilters, nextFilters)...)
+
+	return changes
+}
+
+// proxyWithoutServices 返回去掉服务列表的代理配置，服务差异单独按ID比较
+func proxyWithoutServices(cfg *config.GatewayConfig) interface{} {
+	proxyConfig := cfg.Proxy
+	proxyConfig.Service = nil
+	return proxyConfig
+}
+
+// diffItems 按ID比较两组配置项，结果按ID排序保证输出稳定
+func diffItems(category string, current, next map[string]diffItem) []ConfigChange {
+	ids := make([]string, 0, len(current)+len(next))
+	for id := range current {
+		ids = append(ids, id)
+	}
+	for id := range next {
+		if _, exists := current[id]; !exists {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	changes := make([]ConfigChange, 0)
+	for _, id := range ids {
+		oldItem, inCurrent := current[id]
+		newItem, inNext := next[id]
+		switch {
+		case !inCurrent:
+			changes = append(changes, ConfigChange{Category: category, ID: id, Name: newItem.name, Action: ConfigDiffActionAdded})
+		case !inNext:
+			changes = append(changes, ConfigChange{Category: category, ID: id, Name: oldItem.name, Action: ConfigDiffActionRemoved})
+		default:
+			if fields := diffFields(oldItem.value, newItem.value); len(fields) > 0 {
+				changes = append(changes, ConfigChange{Category: category, ID: id, Name: newItem.name, Action: ConfigDiffActionModified, Fields: fields})
+			}
+		}
+	}
+	return changes
+}
+
+// diffFields 以JSON形式比较两个配置对象，返回值不同的顶层字段
+func diffFields(current, next interface{}) []string {
+	currentFields := toFieldMap(current)
+	nextFields := toFieldMap(next)
+
+	fields := make([]string, 0)
+	for key, value := range currentFields {
+		if !reflect.DeepEqual(value, nextFields[key]) {
+			fields = append(fields, key)
+		}
+	}
+	for key := range nextFields {
+		if _, exists := currentFields[key]; !exists {
+			fields = append(fields, key)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// toFieldMap 把配置对象转换为JSON字段映射
+func toFieldMap(value interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fields
+	}
+	_ = json.Unmarshal(data, &fields)
+	return fields
+}
@@ -0,0 +1,328 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"gateway/internal/gateway/config"
+	"gateway/internal/gateway/handler/assertion"
+	"gateway/internal/gateway/handler/filter"
+	"gateway/internal/gateway/handler/router"
+	"gateway/pkg/logger"
+)
+
+// maxConfigVersionHistory 每个网关实例保留的配置版本数量
+const maxConfigVersionHistory = 10
+
+// ConfigSnapshot 网关配置版本快照
+// 由管理端从数据库组装后下发到各网关节点，节点按版本应用并保留历史用于回滚
+type ConfigSnapshot struct {
+	// Version 配置版本号（配置内容的摘要）
+	Version string `json:"version"`
+	// Config 网关完整配置
+	Config *config.GatewayConfig `json:"config"`
+	// Operator 发布人
+	Operator string `json:"operator"`
+	// AppliedAt 在本节点生效的时间
+	AppliedAt time.Time `json:"appliedAt"`
+}
+
+// appliedConfigVersions 本节点各实例当前生效的配置版本，键为实例ID
+// 版本历史由集群节点共享，是否已生效只能以本节点记录为准
+var appliedConfigVersions sync.Map
+
+// ComputeConfigVersion 计算网关配置的版本号
+// 使用配置JSON的SHA256摘要前16位，内容相同的配置得到相同版本号
+func ComputeConfigVersion(cfg *config.GatewayConfig) (string, error) {
+	if cfg == nil {
+		return "", fmt.Errorf("网关配置不能为空")
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("序列化网关配置失败: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16], nil
+}
+
+// NewConfigSnapshot 创建配置快照并计算版本号
+func NewConfigSnapshot(cfg *config.GatewayConfig, operator string) (*ConfigSnapshot, error) {
+	version, err := ComputeConfigVersion(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &ConfigSnapshot{
+		Version:  version,
+		Config:   cfg,
+		Operator: operator,
+	}, nil
+}
+
+// ValidateGatewayConfig 校验下发的网关配置是否可以应用
+// 路由、断言组和过滤器使用与网关构建处理器时相同的校验逻辑，处理器级别的其他错误由Reload构建新代际时发现
+func ValidateGatewayConfig(cfg *config.GatewayConfig) error {
+	if cfg == nil {
+		return fmt.Errorf("网关配置不能为空")
+	}
+	if cfg.Base.Listen == "" {
+		return fmt.Errorf("监听地址不能为空")
+	}
+	if cfg.Base.EnableHTTPS && (cfg.Base.CertFile == "" || cfg.Base.KeyFile == "") {
+		return fmt.Errorf("启用HTTPS时必须配置证书和私钥")
+	}
+
+	serviceIDs := make(map[string]struct{}, len(cfg.Proxy.Service))
+	for _, svc := range cfg.Proxy.Service {
+		if svc != nil {
+			serviceIDs[svc.ID] = struct{}{}
+		}
+	}
+
+	filterFactory := filter.NewFilterFactory()
+	for _, filterConfig := range cfg.Router.FilterConfig {
+		if _, err := filterFactory.CreateFilter(filterConfig); err != nil {
+			return fmt.Errorf("全局过滤器 %s 配置无效: %w", filterConfig.ID, err)
+		}
+	}
+
+	routeIDs := make(map[string]struct{}, len(cfg.Router.Routes))
+	for i := range cfg.Router.Routes {
+		route := &cfg.Router.Routes[i]
+		if route.ID == "" {
+			return fmt.Errorf("路由ID不能为空")
+		}
+		if _, exists := routeIDs[route.ID]; exists {
+			return fmt.Errorf("路由ID重复: %s", route.ID)
+		}
+		routeIDs[route.ID] = struct{}{}
+
+		if err := route.Validate(); err != nil {
+			return fmt.Errorf("路由 %s 配置无效: %w", route.ID, err)
+		}
+		if route.AssertionGroupConfig != nil {
+			if _, err := assertion.NewAssertionGroupFromConfig(route.AssertionGroupConfig); err != nil {
+				return fmt.Errorf("路由 %s 断言配置无效: %w", route.ID, err)
+			}
+		}
+		for _, filterConfig := range route.FilterConfig {
+			if _, err := filterFactory.CreateFilter(filterConfig); err != nil {
+				return fmt.Errorf("路由 %s 的过滤器 %s 配置无效: %w", route.ID, filterConfig.ID, err)
+			}
+		}
+
+		// 配置中包含服务定义时，路由引用的服务必须存在
+		if len(serviceIDs) > 0 {
+			for _, serviceID := range routeServiceIDs(route) {
+				if _, exists := serviceIDs[serviceID]; !exists {
+					return fmt.Errorf("路由 %s 引用的服务 %s 不存在", route.ID, serviceID)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// routeServiceIDs 获取路由引用的服务ID，ServiceIDs 优先于 ServiceID
+func routeServiceIDs(route *router.RouteConfig) []string {
+	if len(route.ServiceIDs) > 0 {
+		return route.ServiceIDs
+	}
+	if route.ServiceID != "" {
+		return []string{route.ServiceID}
+	}
+	return nil
+}
+
+// ApplyConfigSnapshot 应用管理端下发的配置快照
+// 校验失败时保持当前配置不变；重载失败时回滚到应用前的配置；生效后写入配置版本历史
+func (g *Gateway) ApplyConfigSnapshot(snapshot *ConfigSnapshot) error {
+	if snapshot == nil || snapshot.Config == nil {
+		return fmt.Errorf("配置快照不能为空")
+	}
+	if err := ValidateGatewayConfig(snapshot.Config); err != nil {
+		return fmt.Errorf("配置版本 %s 校验失败: %w", snapshot.Version, err)
+	}
+
+	previousCfg := g.GetConfig()
+	instanceID := previousCfg.InstanceID
+	tenantId := previousCfg.Log.TenantID
+	if current, ok := appliedConfigVersions.Load(instanceID); ok && current.(string) == snapshot.Version {
+		logger.Info("配置版本已生效，跳过应用", "instanceId", instanceID, "version", snapshot.Version)
+		return nil
+	}
+
+	store := getConfigVersionStore(tenantId)
+	history, err := listConfigVersions(store, tenantId, instanceID)
+	if err != nil {
+		return fmt.Errorf("读取配置版本历史失败，未应用配置版本 %s: %w", snapshot.Version, err)
+	}
+
+	if err := g.Reload(snapshot.Config); err != nil {
+		// Reload失败时尽量恢复到应用前的配置，保证节点继续以旧版本提供服务
+		if g.GetConfig() != previousCfg && g.IsRunning() {
+			if rollbackErr := g.Reload(previousCfg); rollbackErr != nil {
+				logger.Error("配置回滚失败", "instanceId", instanceID, "version", snapshot.Version, "error", rollbackErr)
+			}
+		}
+		return fmt.Errorf("应用配置版本 %s 失败，已保持原配置: %w", snapshot.Version, err)
+	}
+	appliedConfigVersions.Store(instanceID, snapshot.Version)
+
+	// Reload可能等待较长时间，写入版本历史使用新的超时
+	ctx, cancel := context.WithTimeout(context.Background(), configVersionStoreTimeout)
+	defer cancel()
+
+	// 首次下发时把当前运行配置记为基线，便于回滚
+	if len(history) == 0 {
+		if baseline, err := NewConfigSnapshot(previousCfg, "system"); err == nil {
+			baseline.AppliedAt = time.Now()
+			if err := store.SaveConfigVersion(ctx, tenantId, instanceID, baseline); err != nil {
+				logger.Error("保存基线配置版本失败", "instanceId", instanceID, "version", baseline.Version, "error", err)
+			}
+		}
+	}
+
+	// 配置已经生效，版本历史写入失败只影响回滚，不作为应用失败返回
+	applied := *snapshot
+	applied.AppliedAt = time.Now()
+	if err := store.SaveConfigVersion(ctx, tenantId, instanceID, &applied); err != nil {
+		logger.Error("保存配置版本失败", "instanceId", instanceID, "version", snapshot.Version, "error", err)
+	}
+
+	logger.Info("配置版本应用成功",
+		"instanceId", instanceID,
+		"version", snapshot.Version,
+		"operator", snapshot.Operator)
+	return nil
+}
+
+// RollbackConfig 回滚到上一个已生效的配置版本，并从版本历史中移除被撤销的最新版本
+// 返回回滚后生效的版本号，集群其他节点通过 RollbackConfigTo 切换到同一版本
+func (g *Gateway) RollbackConfig() (string, error) {
+	instanceID := g.GetConfig().InstanceID
+	tenantId := g.GetConfig().Log.TenantID
+
+	store := getConfigVersionStore(tenantId)
+	history, err := listConfigVersions(store, tenantId, instanceID)
+	if err != nil {
+		return "", fmt.Errorf("读取配置版本历史失败: %w", err)
+	}
+	if len(history) < 2 {
+		return "", fmt.Errorf("没有可回滚的历史配置版本")
+	}
+
+	latest, previous := history[len(history)-1], history[len(history)-2]
+	if err := g.Reload(previous.Config); err != nil {
+		return "", fmt.Errorf("回滚到配置版本 %s 失败: %w", previous.Version, err)
+	}
+	appliedConfigVersions.Store(instanceID, previous.Version)
+	ctx, cancel := context.WithTimeout(context.Background(), configVersionStoreTimeout)
+	defer cancel()
+	if err := store.RemoveConfigVersion(ctx, tenantId, instanceID, latest.Version); err != nil {
+		logger.Error("移除已回滚的配置版本失败", "instanceId", instanceID, "version", latest.Version, "error", err)
+	}
+
+	logger.Info("配置已回滚", "instanceId", instanceID, "version", previous.Version)
+	return previous.Version, nil
+}
+
+// RollbackConfigTo 回滚到版本历史中的指定版本
+// 用于集群其他节点响应回滚事件：发布节点回滚时已经移除了被撤销的版本，这里切换到目标版本，
+// 并移除历史中比目标版本更新的版本（使用内存存储时各节点的历史互相独立）
+func (g *Gateway) RollbackConfigTo(version string) error {
+	instanceID := g.GetConfig().InstanceID
+	tenantId := g.GetConfig().Log.TenantID
+	if current, ok := appliedConfigVersions.Load(instanceID); ok && current.(string) == version {
+		logger.Info("配置版本已生效，跳过回滚", "instanceId", instanceID, "version", version)
+		return nil
+	}
+
+	store := getConfigVersionStore(tenantId)
+	history, err := listConfigVersions(store, tenantId, instanceID)
+	if err != nil {
+		return fmt.Errorf("读取配置版本历史失败: %w", err)
+	}
+
+	target := -1
+	for i, snapshot := range history {
+		if snapshot.Version == version {
+			target = i
+		}
+	}
+	if target < 0 {
+		return fmt.Errorf("配置版本 %s 不在版本历史中", version)
+	}
+	if err := g.Reload(history[target].Config); err != nil {
+		return fmt.Errorf("回滚到配置版本 %s 失败: %w", version, err)
+	}
+	appliedConfigVersions.Store(instanceID, version)
+	ctx, cancel := context.WithTimeout(context.Background(), configVersionStoreTimeout)
+	defer cancel()
+	for _, snapshot := range history[target+1:] {
+		if err := store.RemoveConfigVersion(ctx, tenantId, instanceID, snapshot.Version); err != nil {
+			logger.Error("移除已回滚的配置版本失败", "instanceId", instanceID, "version", snapshot.Version, "error", err)
+		}
+	}
+
+	logger.Info("配置已回滚", "instanceId", instanceID, "version", version)
+	return nil
+}
+
+// listConfigVersions 在超时时间内读取实例的配置版本历史
+func listConfigVersions(store ConfigVersionStore, tenantId, instanceID string) ([]*ConfigSnapshot, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), configVersionStoreTimeout)
+	defer cancel()
+	return store.ListConfigVersions(ctx, tenantId, instanceID)
+}
+
+// GetConfigVersions 获取实例的配置版本历史，最新版本在末尾
+func GetConfigVersions(tenantId, instanceID string) ([]*ConfigSnapshot, error) {
+	return listConfigVersions(getConfigVersionStore(tenantId), tenantId, instanceID)
+}
+
+// SaveConfigVersion 将发布的配置快照写入版本历史
+// 管理端发布配置时调用，集群其他节点收到下发事件后按版本号从版本历史读取配置，事件中不携带配置内容
+func SaveConfigVersion(tenantId, instanceID string, snapshot *ConfigSnapshot) error {
+	if snapshot == nil || snapshot.Config == nil {
+		return fmt.Errorf("配置快照不能为空")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), configVersionStoreTimeout)
+	defer cancel()
+	saved := *snapshot
+	if saved.AppliedAt.IsZero() {
+		saved.AppliedAt = time.Now()
+	}
+	return getConfigVersionStore(tenantId).SaveConfigVersion(ctx, tenantId, instanceID, &saved)
+}
+
+// GetConfigVersion 从版本历史中获取指定版本的配置快照
+func GetConfigVersion(tenantId, instanceID, version string) (*ConfigSnapshot, error) {
+	history, err := GetConfigVersions(tenantId, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置版本历史失败: %w", err)
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Version == version {
+			return history[i], nil
+		}
+	}
+	return nil, fmt.Errorf("配置版本 %s 不在版本历史中", version)
+}
+
+// GetCurrentConfigVersion 获取实例当前生效的配置版本号
+// 优先返回本节点已生效的版本，本节点未应用过快照时返回版本历史中的最新版本，都没有时返回空字符串
+func GetCurrentConfigVersion(tenantId, instanceID string) string {
+	if current, ok := appliedConfigVersions.Load(instanceID); ok {
+		return current.(string)
+	}
+	history, err := GetConfigVersions(tenantId, instanceID)
+	if err != nil || len(history) == 0 {
+		return ""
+	}
+	return history[len(history)-1].Version
+}
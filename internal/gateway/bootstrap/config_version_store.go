@@ -0,0 +1,284 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"gateway/internal/gateway/config"
+	"gateway/pkg/database"
+	"gateway/pkg/security"
+	"gateway/pkg/utils/random"
+)
+
+// configVersionStoreTimeout 读写配置版本历史的超时时间
+const configVersionStoreTimeout = 5 * time.Second
+
+// ConfigVersionStore 配置版本历史存储
+// 同一版本只保留一条记录，重复生效时刷新生效时间并成为最新版本；每个实例只保留最近 maxConfigVersionHistory 个版本
+type ConfigVersionStore interface {
+	// SaveConfigVersion 保存一次成功生效的配置版本
+	SaveConfigVersion(ctx context.Context, tenantId, instanceId string, snapshot *ConfigSnapshot) error
+
+	// ListConfigVersions 获取实例的配置版本历史，最新版本在末尾
+	ListConfigVersions(ctx context.Context, tenantId, instanceId string) ([]*ConfigSnapshot, error)
+
+	// RemoveConfigVersion 删除配置版本，版本不存在时不做任何操作
+	RemoveConfigVersion(ctx context.Context, tenantId, instanceId, version string) error
+}
+
+var (
+	// configVersionStoreMu 保护 customConfigVersionStore
+	configVersionStoreMu sync.RWMutex
+
+	// customConfigVersionStore 通过 SetConfigVersionStore 指定的存储
+	customConfigVersionStore ConfigVersionStore
+
+	// memoryConfigVersions 没有数据库连接或实例未配置租户时使用的内存存储
+	memoryConfigVersions = NewMemoryConfigVersionStore()
+)
+
+// SetConfigVersionStore 指定配置版本历史存储，传入nil时恢复默认存储
+// 默认存储为默认数据库连接上的 HUB_GW_CONFIG_VERSION 表，集群节点共享同一份版本历史
+func SetConfigVersionStore(store ConfigVersionStore) {
+	configVersionStoreMu.Lock()
+	defer configVersionStoreMu.Unlock()
+	customConfigVersionStore = store
+}
+
+// getConfigVersionStore 获取实例使用的配置版本历史存储
+// 缺少租户ID或默认数据库连接时退回内存存储，版本历史只在本节点进程内有效
+func getConfigVersionStore(tenantId string) ConfigVersionStore {
+	configVersionStoreMu.RLock()
+	store := customConfigVersionStore
+	configVersionStoreMu.RUnlock()
+	if store != nil {
+		return store
+	}
+	if tenantId != "" {
+		if db := database.GetDefaultConnection(); db != nil {
+			return NewDBConfigVersionStore(db)
+		}
+	}
+	return memoryConfigVersions
+}
+
+// MemoryConfigVersionStore 内存配置版本存储
+type MemoryConfigVersionStore struct {
+	mu       sync.RWMutex
+	versions map[string][]*ConfigSnapshot
+}
+
+// NewMemoryConfigVersionStore 创建内存配置版本存储
+func NewMemoryConfigVersionStore() *MemoryConfigVersionStore {
+	return &MemoryConfigVersionStore{
+		versions: make(map[string][]*ConfigSnapshot),
+	}
+}
+
+// SaveConfigVersion 保存一次成功生效的配置版本
+func (s *MemoryConfigVersionStore) SaveConfigVersion(ctx context.Context, tenantId, instanceId string, snapshot *ConfigSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := tenantId + "/" + instanceId
+	history := removeConfigVersion(s.versions[key], snapshot.Version)
+	history = append(history, snapshot)
+	if len(history) > maxConfigVersionHistory {
+		history = history[len(history)-maxConfigVersionHistory:]
+	}
+	s.versions[key] = history
+	return nil
+}
+
+// ListConfigVersions 获取实例的配置版本历史副本，最新版本在末尾
+func (s *MemoryConfigVersionStore) ListConfigVersions(ctx context.Context, tenantId, instanceId string) ([]*ConfigSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	history := s.versions[tenantId+"/"+instanceId]
+	result := make([]*ConfigSnapshot, len(history))
+	copy(result, history)
+	return result, nil
+}
+
+// RemoveConfigVersion 删除配置版本
+func (s *MemoryConfigVersionStore) RemoveConfigVersion(ctx context.Context, tenantId, instanceId, version string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := tenantId + "/" + instanceId
+	s.versions[key] = removeConfigVersion(s.versions[key], version)
+	return nil
+}
+
+// removeConfigVersion 返回移除指定版本后的新切片，不修改原切片
+func removeConfigVersion(history []*ConfigSnapshot, version string) []*ConfigSnapshot {
+	result := make([]*ConfigSnapshot, 0, len(history)+1)
+	for _, snapshot := range history {
+		if snapshot.Version != version {
+			result = append(result, snapshot)
+		}
+	}
+	return result
+}
+
+// DBConfigVersionStore 数据库配置版本存储
+// 配置内容序列化为JSON后使用默认密钥加密写入 HUB_GW_CONFIG_VERSION，避免证书、密钥等敏感配置以明文落库
+type DBConfigVersionStore struct {
+	db database.Database
+}
+
+// NewDBConfigVersionStore 创建数据库配置版本存储
+func NewDBConfigVersionStore(db database.Database) *DBConfigVersionStore {
+	return &DBConfigVersionStore{db: db}
+}
+
+// configVersionRecord 配置版本表记录
+type configVersionRecord struct {
+	ConfigVersion string    `db:"configVersion"`
+	ConfigData    string    `db:"configData"`
+	Operator      *string   `db:"operator"`
+	AppliedTime   time.Time `db:"appliedTime"`
+	AppliedSeq    int64     `db:"appliedSeq"`
+}
+
+// SaveConfigVersion 保存一次成功生效的配置版本
+// 版本已存在时刷新配置和生效时间，否则新增记录；保存后清理超出保留数量的旧版本
+func (s *DBConfigVersionStore) SaveConfigVersion(ctx context.Context, tenantId, instanceId string, snapshot *ConfigSnapshot) error {
+	data, err := json.Marshal(snapshot.Config)
+	if err != nil {
+		return fmt.Errorf("序列化网关配置失败: %w", err)
+	}
+	configData, err := security.EncryptBytesWithDefaultKey(data)
+	if err != nil {
+		return fmt.Errorf("加密网关配置失败: %w", err)
+	}
+
+	now := time.Now()
+	appliedAt := snapshot.AppliedAt
+	if appliedAt.IsZero() {
+		appliedAt = now
+	}
+	operator := snapshot.Operator
+	if operator == "" {
+		operator = "system"
+	}
+
+	updated, err := s.updateConfigVersion(ctx, tenantId, instanceId, snapshot.Version, configData, operator, appliedAt, now)
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		insertSQL := `
+			INSERT INTO HUB_GW_CONFIG_VERSION (
+				tenantId, gatewayInstanceId, configVersion, configData, operator, appliedTime, appliedSeq,
+				addTime, addWho, editTime, editWho, oprSeqFlag, currentVersion, activeFlag
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1, 'Y')
+		`
+		args := []interface{}{
+			tenantId, instanceId, snapshot.Version, configData, operator, appliedAt, appliedAt.UnixNano(),
+			now, operator, now, operator, random.Generate32BitRandomString(),
+		}
+		if _, err := s.db.Exec(ctx, insertSQL, args, true); err != nil {
+			// 集群其他节点可能同时写入了同一版本，此时改为刷新该版本
+			updated, updateErr := s.updateConfigVersion(ctx, tenantId, instanceId, snapshot.Version, configData, operator, appliedAt, now)
+			if updateErr != nil || updated == 0 {
+				return fmt.Errorf("保存配置版本 %s 失败: %w", snapshot.Version, err)
+			}
+		}
+	}
+
+	return s.trimConfigVersions(ctx, tenantId, instanceId)
+}
+
+// updateConfigVersion 刷新已存在的配置版本，返回受影响的行数
+func (s *DBConfigVersionStore) updateConfigVersion(ctx context.Context, tenantId, instanceId, version, configData, operator string, appliedAt, now time.Time) (int64, error) {
+	updateSQL := `
+		UPDATE HUB_GW_CONFIG_VERSION
+		SET configData = ?, operator = ?, appliedTime = ?, appliedSeq = ?, editTime = ?, editWho = ?, activeFlag = 'Y'
+		WHERE tenantId = ? AND gatewayInstanceId = ? AND configVersion = ?
+	`
+	args := []interface{}{configData, operator, appliedAt, appliedAt.UnixNano(), now, operator, tenantId, instanceId, version}
+	updated, err := s.db.Exec(ctx, updateSQL, args, true)
+	if err != nil {
+		return 0, fmt.Errorf("更新配置版本 %s 失败: %w", version, err)
+	}
+	return updated, nil
+}
+
+// trimConfigVersions 删除超出保留数量的旧版本
+func (s *DBConfigVersionStore) trimConfigVersions(ctx context.Context, tenantId, instanceId string) error {
+	var records []configVersionRecord
+	querySQL := `
+		SELECT configVersion, appliedSeq
+		FROM HUB_GW_CONFIG_VERSION
+		WHERE tenantId = ? AND gatewayInstanceId = ?
+		ORDER BY appliedSeq DESC
+	`
+	if err := s.db.Query(ctx, &records, querySQL, []interface{}{tenantId, instanceId}, true); err != nil {
+		return fmt.Errorf("查询配置版本历史失败: %w", err)
+	}
+	for i := maxConfigVersionHistory; i < len(records); i++ {
+		if err := s.RemoveConfigVersion(ctx, tenantId, instanceId, records[i].ConfigVersion); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListConfigVersions 获取实例的配置版本历史，最新版本在末尾
+func (s *DBConfigVersionStore) ListConfigVersions(ctx context.Context, tenantId, instanceId string) ([]*ConfigSnapshot, error) {
+	var records []configVersionRecord
+	querySQL := `
+		SELECT configVersion, configData, operator, appliedTime, appliedSeq
+		FROM HUB_GW_CONFIG_VERSION
+		WHERE tenantId = ? AND gatewayInstanceId = ? AND activeFlag = 'Y'
+		ORDER BY appliedSeq DESC
+	`
+	if err := s.db.Query(ctx, &records, querySQL, []interface{}{tenantId, instanceId}, true); err != nil {
+		return nil, fmt.Errorf("查询配置版本历史失败: %w", err)
+	}
+	if len(records) > maxConfigVersionHistory {
+		records = records[:maxConfigVersionHistory]
+	}
+
+	history := make([]*ConfigSnapshot, 0, len(records))
+	for _, record := range records {
+		data, err := security.DecryptWithDefaultKey(record.ConfigData)
+		if err != nil {
+			return nil, fmt.Errorf("解密配置版本 %s 失败: %w", record.ConfigVersion, err)
+		}
+		cfg := &config.GatewayConfig{}
+		if err := json.Unmarshal([]byte(data), cfg); err != nil {
+			return nil, fmt.Errorf("解析配置版本 %s 失败: %w", record.ConfigVersion, err)
+		}
+		snapshot := &ConfigSnapshot{
+			Version:   record.ConfigVersion,
+			Config:    cfg,
+			AppliedAt: record.AppliedTime,
+		}
+		if record.Operator != nil {
+			snapshot.Operator = *record.Operator
+		}
+		history = append(history, snapshot)
+	}
+
+	// 查询结果按生效序号倒序，返回时最新版本在末尾
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
+	}
+	return history, nil
+}
+
+// RemoveConfigVersion 删除配置版本
+func (s *DBConfigVersionStore) RemoveConfigVersion(ctx context.Context, tenantId, instanceId, version string) error {
+	deleteSQL := `
+		DELETE FROM HUB_GW_CONFIG_VERSION
+		WHERE tenantId = ? AND gatewayInstanceId = ? AND configVersion = ?
+	`
+	if _, err := s.db.Exec(ctx, deleteSQL, []interface{}{tenantId, instanceId, version}, true); err != nil {
+		return fmt.Errorf("删除配置版本 %s 失败: %w", version, err)
+	}
+	return nil
+}
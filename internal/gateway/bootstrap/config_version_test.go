@@ -0,0 +1,351 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gateway/internal/gateway/config"
+	"gateway/pkg/database"
+	"gateway/pkg/database/dbtypes"
+	_ "gateway/pkg/database/sqlite"
+)
+
+// openConfigVersionDB 创建包含配置版本表的临时SQLite数据库，表结构读取 scripts/db/sqlite 下的建表脚本
+func openConfigVersionDB(t *testing.T) database.Database {
+	t.Helper()
+	db, err := database.Open(&dbtypes.DbConfig{
+		Name:    t.Name(),
+		Enabled: true,
+		Driver:  dbtypes.DriverSQLite,
+		DSN:     filepath.Join(t.TempDir(), "config_version.db"),
+		Pool:    dbtypes.PoolConfig{MaxOpenConns: 1, MaxIdleConns: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	script, err := os.ReadFile(filepath.Join("..", "..", "..", "scripts", "db", "sqlite", "HUB_GW_CONFIG_VERSION.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(context.Background(), string(script), nil, true); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// configVersionStores 返回内存和数据库两种配置版本存储
+func configVersionStores(t *testing.T) map[string]func(t *testing.T) ConfigVersionStore {
+	return map[string]func(t *testing.T) ConfigVersionStore{
+		"memory": func(t *testing.T) ConfigVersionStore {
+			return NewMemoryConfigVersionStore()
+		},
+		"database": func(t *testing.T) ConfigVersionStore {
+			return NewDBConfigVersionStore(openConfigVersionDB(t))
+		},
+	}
+}
+
+// startVersionedGateway 使用指定的配置版本存储启动网关，测试结束后停止网关并恢复默认存储
+func startVersionedGateway(t *testing.T, store ConfigVersionStore) *Gateway {
+	t.Helper()
+	SetConfigVersionStore(store)
+
+	cfg := config.DefaultGatewayConfig
+	cfg.InstanceID = "config-version-test"
+	cfg.Log.TenantID = "default"
+	cfg.Base.Listen = "127.0.0.1:0"
+	cfg.Base.GracefulShutdownTimeout = 200 * time.Millisecond
+	appliedConfigVersions.Delete(cfg.InstanceID)
+
+	gateway, err := NewGatewayFactory().CreateGateway(&cfg, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gateway.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = gateway.Stop()
+		appliedConfigVersions.Delete(cfg.InstanceID)
+		SetConfigVersionStore(nil)
+	})
+	return gateway
+}
+
+// newIdleTimeoutSnapshot 基于网关当前配置生成只修改空闲超时的配置快照
+func newIdleTimeoutSnapshot(t *testing.T, gateway *Gateway, idleTimeout time.Duration) *ConfigSnapshot {
+	t.Helper()
+	next := *gateway.GetConfig()
+	next.Base.IdleTimeout = idleTimeout
+	snapshot, err := NewConfigSnapshot(&next, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return snapshot
+}
+
+// historyVersions 读取实例的版本历史版本号，最新版本在末尾
+func historyVersions(t *testing.T, gateway *Gateway) []string {
+	t.Helper()
+	cfg := gateway.GetConfig()
+	history, err := GetConfigVersions(cfg.Log.TenantID, cfg.InstanceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions := make([]string, 0, len(history))
+	for _, snapshot := range history {
+		versions = append(versions, snapshot.Version)
+	}
+	return versions
+}
+
+func TestApplyConfigSnapshot(t *testing.T) {
+	for name, newStore := range configVersionStores(t) {
+		t.Run(name, func(t *testing.T) {
+			gateway := startVersionedGateway(t, newStore(t))
+			baseline, err := ComputeConfigVersion(gateway.GetConfig())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			snapshot := newIdleTimeoutSnapshot(t, gateway, 3*time.Second)
+			if err := gateway.ApplyConfigSnapshot(snapshot); err != nil {
+				t.Fatal(err)
+			}
+			if got := gateway.GetConfig().Base.IdleTimeout; got != 3*time.Second {
+				t.Fatalf("IdleTimeout = %v, want 3s", got)
+			}
+			if got := historyVersions(t, gateway); strings.Join(got, ",") != baseline+","+snapshot.Version {
+				t.Fatalf("history = %v, want baseline then %s", got, snapshot.Version)
+			}
+			if got := GetCurrentConfigVersion("default", "config-version-test"); got != snapshot.Version {
+				t.Fatalf("current version = %q, want %q", got, snapshot.Version)
+			}
+
+			// 同一版本重复下发时不重载
+			cfgBefore := gateway.GetConfig()
+			if err := gateway.ApplyConfigSnapshot(snapshot); err != nil {
+				t.Fatal(err)
+			}
+			if gateway.GetConfig() != cfgBefore {
+				t.Fatal("applying the current version should not reload the gateway")
+			}
+			if got := historyVersions(t, gateway); len(got) != 2 {
+				t.Fatalf("history = %v, want 2 versions", got)
+			}
+
+			// 校验失败时保持原配置，不写入版本历史
+			invalid := newIdleTimeoutSnapshot(t, gateway, 4*time.Second)
+			invalid.Config.Base.Listen = ""
+			if err := gateway.ApplyConfigSnapshot(invalid); err == nil {
+				t.Fatal("expected validation error for empty listen address")
+			}
+			if gateway.GetConfig() != cfgBefore {
+				t.Fatal("invalid snapshot should keep the current config")
+			}
+			if got := historyVersions(t, gateway); len(got) != 2 {
+				t.Fatalf("history = %v, want 2 versions", got)
+			}
+		})
+	}
+}
+
+func TestRollbackConfig(t *testing.T) {
+	for name, newStore := range configVersionStores(t) {
+		t.Run(name, func(t *testing.T) {
+			gateway := startVersionedGateway(t, newStore(t))
+			if _, err := gateway.RollbackConfig(); err == nil {
+				t.Fatal("expected error without version history")
+			}
+			baselineIdle := gateway.GetConfig().Base.IdleTimeout
+
+			first := newIdleTimeoutSnapshot(t, gateway, 3*time.Second)
+			if err := gateway.ApplyConfigSnapshot(first); err != nil {
+				t.Fatal(err)
+			}
+			second := newIdleTimeoutSnapshot(t, gateway, 5*time.Second)
+			if err := gateway.ApplyConfigSnapshot(second); err != nil {
+				t.Fatal(err)
+			}
+
+			version, err := gateway.RollbackConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if version != first.Version {
+				t.Fatalf("rollback version = %q, want %q", version, first.Version)
+			}
+			if got := gateway.GetConfig().Base.IdleTimeout; got != 3*time.Second {
+				t.Fatalf("IdleTimeout after rollback = %v, want 3s", got)
+			}
+			if got := GetCurrentConfigVersion("default", "config-version-test"); got != first.Version {
+				t.Fatalf("current version = %q, want %q", got, first.Version)
+			}
+			// 被撤销的版本从历史中移除，再次回滚回到基线
+			if got := historyVersions(t, gateway); len(got) != 2 || got[1] != first.Version {
+				t.Fatalf("history after rollback = %v", got)
+			}
+
+			if _, err := gateway.RollbackConfig(); err != nil {
+				t.Fatal(err)
+			}
+			if got := gateway.GetConfig().Base.IdleTimeout; got != baselineIdle {
+				t.Fatalf("IdleTimeout after second rollback = %v, want %v", got, baselineIdle)
+			}
+			if _, err := gateway.RollbackConfig(); err == nil {
+				t.Fatal("expected error after rolling back to the baseline")
+			}
+		})
+	}
+}
+
+func TestRollbackConfigTo(t *testing.T) {
+	gateway := startVersionedGateway(t, NewMemoryConfigVersionStore())
+
+	first := newIdleTimeoutSnapshot(t, gateway, 3*time.Second)
+	if err := gateway.ApplyConfigSnapshot(first); err != nil {
+		t.Fatal(err)
+	}
+	second := newIdleTimeoutSnapshot(t, gateway, 5*time.Second)
+	if err := gateway.ApplyConfigSnapshot(second); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gateway.RollbackConfigTo("missing"); err == nil {
+		t.Fatal("expected error for a version outside the history")
+	}
+	if err := gateway.RollbackConfigTo(first.Version); err != nil {
+		t.Fatal(err)
+	}
+	if got := gateway.GetConfig().Base.IdleTimeout; got != 3*time.Second {
+		t.Fatalf("IdleTimeout after rollback = %v, want 3s", got)
+	}
+	if got := historyVersions(t, gateway); got[len(got)-1] != first.Version {
+		t.Fatalf("versions newer than the target should be removed, history = %v", got)
+	}
+
+	// 目标版本已生效时不重载
+	cfgBefore := gateway.GetConfig()
+	if err := gateway.RollbackConfigTo(first.Version); err != nil {
+		t.Fatal(err)
+	}
+	if gateway.GetConfig() != cfgBefore {
+		t.Fatal("rolling back to the current version should not reload the gateway")
+	}
+}
+
+func TestDBConfigVersionStore(t *testing.T) {
+	ctx := context.Background()
+	db := openConfigVersionDB(t)
+	store := NewDBConfigVersionStore(db)
+
+	base := time.Now()
+	for i := 0; i < maxConfigVersionHistory+2; i++ {
+		cfg := config.DefaultGatewayConfig
+		cfg.Base.CertFile = "/etc/gateway/secret-cert.pem"
+		cfg.Base.IdleTimeout = time.Duration(i+1) * time.Second
+		err := store.SaveConfigVersion(ctx, "default", "gw-1", &ConfigSnapshot{
+			Version:   fmt.Sprintf("v%02d", i),
+			Config:    &cfg,
+			Operator:  "admin",
+			AppliedAt: base.Add(time.Duration(i) * time.Millisecond),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	history, err := store.ListConfigVersions(ctx, "default", "gw-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != maxConfigVersionHistory {
+		t.Fatalf("history length = %d, want %d", len(history), maxConfigVersionHistory)
+	}
+	if history[0].Version != "v02" || history[len(history)-1].Version != "v11" {
+		t.Fatalf("history should keep the newest versions oldest first, got %s..%s",
+			history[0].Version, history[len(history)-1].Version)
+	}
+	if got := history[len(history)-1].Config.Base.IdleTimeout; got != 12*time.Second {
+		t.Fatalf("decoded IdleTimeout = %v, want 12s", got)
+	}
+	if history[0].Operator != "admin" {
+		t.Fatalf("operator = %q, want admin", history[0].Operator)
+	}
+
+	// 配置内容加密存储
+	var rows []configVersionRecord
+	if err := db.Query(ctx, &rows, "SELECT configVersion, configData FROM HUB_GW_CONFIG_VERSION", nil, true); err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range rows {
+		if strings.Contains(row.ConfigData, "secret-cert") {
+			t.Fatalf("config data of %s is stored in plaintext", row.ConfigVersion)
+		}
+	}
+
+	// 重新保存已有版本时成为最新版本
+	resaved := *history[0]
+	resaved.AppliedAt = base.Add(time.Second)
+	if err := store.SaveConfigVersion(ctx, "default", "gw-1", &resaved); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RemoveConfigVersion(ctx, "default", "gw-1", "v11"); err != nil {
+		t.Fatal(err)
+	}
+	history, err = store.ListConfigVersions(ctx, "default", "gw-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != maxConfigVersionHistory-1 || history[len(history)-1].Version != "v02" || history[len(history)-2].Version != "v10" {
+		t.Fatalf("unexpected history after resave and remove: %d versions, newest %s", len(history), history[len(history)-1].Version)
+	}
+
+	// 其他实例的历史互不影响
+	other, err := store.ListConfigVersions(ctx, "default", "gw-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(other) != 0 {
+		t.Fatalf("gw-2 history = %d versions, want 0", len(other))
+	}
+}
+
+// TestSaveAndGetConfigVersion 验证发布方写入的配置版本可按版本号从版本历史读取，未写入的版本返回错误
+func TestSaveAndGetConfigVersion(t *testing.T) {
+	SetConfigVersionStore(NewDBConfigVersionStore(openConfigVersionDB(t)))
+	t.Cleanup(func() { SetConfigVersionStore(nil) })
+
+	cfg := config.DefaultGatewayConfig
+	cfg.Base.IdleTimeout = 42 * time.Second
+	if err := SaveConfigVersion("default", "gw-1", &ConfigSnapshot{Version: "v1", Config: &cfg, Operator: "admin"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveConfigVersion("default", "gw-1", nil); err == nil {
+		t.Fatal("saving a nil snapshot should fail")
+	}
+
+	snapshot, err := GetConfigVersion("default", "gw-1", "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Config.Base.IdleTimeout != 42*time.Second {
+		t.Fatalf("IdleTimeout = %v, want 42s", snapshot.Config.Base.IdleTimeout)
+	}
+	if snapshot.AppliedAt.IsZero() {
+		t.Fatal("AppliedAt should be filled when saving")
+	}
+	if _, err := GetConfigVersion("default", "gw-1", "v2"); err == nil {
+		t.Fatal("loading an unknown version should fail")
+	}
+	if _, err := GetConfigVersion("default", "gw-2", "v1"); err == nil {
+		t.Fatal("versions of other instances should not be visible")
+	}
+}
@@ -0,0 +1,45 @@
+package bootstrap
+
+import (
+	"context"
+
+	"gateway/internal/gateway/handler/featureflag"
+	"gateway/internal/gateway/loader/dbloader"
+	"gateway/pkg/cache"
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+)
+
+// startFeatureFlags 加载实例的功能开关并注册，供过滤器按请求判定
+// 缺少实例ID、租户ID或默认数据库连接时不启用功能开关，过滤器判定开关时视为关闭
+func (g *Gateway) startFeatureFlags() {
+	instanceId := g.gatewayConfig.InstanceID
+	tenantId := g.gatewayConfig.Log.TenantID
+	if instanceId == "" || tenantId == "" {
+		return
+	}
+	db := database.GetDefaultConnection()
+	if db == nil {
+		logger.Debug("无法获取默认数据库连接，跳过功能开关加载", "instanceId", instanceId)
+		return
+	}
+
+	loader := dbloader.NewFeatureFlagLoader(db, tenantId)
+	manager := featureflag.NewManager(tenantId, instanceId, func(ctx context.Context) ([]featureflag.Flag, error) {
+		return loader.LoadFeatureFlags(ctx, instanceId)
+	}, cache.GetDefaultCache(), featureflag.DefaultRefreshInterval)
+	manager.Start()
+	featureflag.Register(manager)
+	g.featureFlags = manager
+	logger.Info("功能开关已启用", "instanceId", instanceId, "count", manager.Count())
+}
+
+// stopFeatureFlags 注销并停止实例的功能开关管理器
+func (g *Gateway) stopFeatureFlags() {
+	if g.featureFlags == nil {
+		return
+	}
+	featureflag.Unregister(g.featureFlags)
+	g.featureFlags.Stop()
+	g.featureFlags = nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"gateway/internal/gateway/core"
 	"gateway/internal/gateway/handler/auth"
 	"gateway/internal/gateway/handler/cors"
+	"gateway/internal/gateway/handler/featureflag"
 	"gateway/internal/gateway/handler/limiter"
 	"gateway/internal/gateway/handler/proxy"
 	"gateway/internal/gateway/handler/router"
@@ -22,8 +24,11 @@ import (
 	"gateway/internal/gateway/helper/reqhand"
 	"gateway/internal/gateway/loader/dbloader"
 	"gateway/internal/gateway/logwrite"
+	"gateway/internal/gateway/logwrite/slowreq"
 	appconfig "gateway/pkg/config"
+	"gateway/pkg/crash"
 	"gateway/pkg/logger"
+	"gateway/pkg/utils/geoip"
 )
 
 // Gateway 网关核心结构
@@ -84,6 +89,12 @@ type Gateway struct {
 	generationWG sync.WaitGroup
 	// requestLimiter 对所有运行时代际实施统一的在途请求上限。
 	requestLimiter requestAdmissionLimiter
+	// maintenance 维护模式开关，开启后新请求直接返回503，不随配置重载改变。
+	maintenance atomic.Bool
+	// featureFlags 实例的功能开关管理器，未配置数据库时为nil。
+	featureFlags *featureflag.Manager
+	// stats 实例运行统计，供按实例上报运行状态。
+	stats gatewayStats
 }
 
 // setCompatibilityHandlers 更新原有处理器字段，供现有管理接口和测试继续访问。
@@ -304,6 +315,19 @@ func (g *Gateway) serveHTTPGeneration(generation *gatewayGeneration, w http.Resp
 func (g *Gateway) serveHTTPWithRuntime(cfg *config.GatewayConfig, engine *core.Engine, w http.ResponseWriter, r *http.Request) {
 	ctx, traceID := g.prepareRequestContext(cfg, w, r)
 	defer ctx.Cancel()
+	if g.maintenance.Load() {
+		ctx.AddError(fmt.Errorf("网关处于维护模式"))
+		w.Header().Set("Retry-After", "60")
+		ctx.Abort(http.StatusServiceUnavailable, helper.BuildGatewayResponse(
+			constants.ErrorCodeMaintenance,
+			constants.StatusMessageServiceUnavailable,
+			"",
+			r.URL.Path,
+			traceID,
+		))
+		g.finishRequest(ctx, cfg)
+		return
+	}
 	if !g.requestLimiter.tryAcquire() {
 		err := fmt.Errorf("网关当前在途请求数已达到上限")
 		ctx.AddError(err)
@@ -343,14 +367,50 @@ func (g *Gateway) prepareRequestContext(cfg *config.GatewayConfig, w http.Respon
 	ctx.Set(constants.ContextKeyTenantID, cfg.Log.TenantID)
 	// 直接设置日志配置到上下文，避免重复获取
 	ctx.SetLogConfig(&cfg.Log)
+	// 启用慢请求检测时启动看门狗，超过阈值仍未完成时采集诊断信息
+	if cfg.Base.EnableAccessLog {
+		slowreq.Start(ctx, cfg.Log.GetSlowRequestConfig())
+	}
+	if cfg.ErrorResponse.Enabled {
+		ctx.SetErrorResponseConfig(&cfg.ErrorResponse)
+	}
 	traceID := core.InitializeRequestContext(ctx)
+	// 附加请求级日志字段，处理器中的 logger.*WithTrace(ctx.Ctx, ...) 自动携带
+	ctx.Ctx = logger.WithTraceID(ctx.Ctx, traceID)
+	clientIP := requestClientIP(r)
+	ctx.AddLogFields(
+		"tenantId", cfg.Log.TenantID,
+		"gatewayInstanceId", cfg.InstanceID,
+		"clientIp", clientIP)
+	// 启用地理位置库时解析客户端地理位置，供路由断言和访问日志使用
+	if location := geoip.Lookup(clientIP); location != nil {
+		ctx.Set(constants.ContextKeyClientGeoLocation, location)
+	}
 	return ctx, traceID
 }
 
+// requestClientIP 获取客户端IP，优先使用 X-Forwarded-For 的第一个地址
+func requestClientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		if ip := strings.TrimSpace(strings.Split(forwarded, ",")[0]); ip != "" {
+			return ip
+		}
+	}
+	if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+		return strings.TrimSpace(realIP)
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
 // finishRequest 固化响应时间和HTTP快照，并异步写入访问日志。
 func (g *Gateway) finishRequest(ctx *core.Context, cfg *config.GatewayConfig) {
 	// 响应时间必须在快照和异步日志之前记录，避免日志准备耗时混入请求处理耗时。
 	ctx.SetResponseTime(time.Now())
+	slowreq.FromContext(ctx).Stop()
+	g.stats.recordRequest(ctx)
 	if !cfg.Base.EnableAccessLog {
 		return
 	}
@@ -362,11 +422,7 @@ func (g *Gateway) finishRequest(ctx *core.Context, cfg *config.GatewayConfig) {
 	// Context 对象本身可以安全使用（data、时间字段等），只是不能访问 Request 和 Writer
 	go func() {
 		// 添加panic恢复机制，防止日志写入错误导致整个服务崩溃
-		defer func() {
-			if r := recover(); r != nil {
-				logger.Error("Panic in access log writer", "error", r, "instanceID", cfg.InstanceID)
-			}
-		}()
+		defer crash.Recover("gateway.accesslog")
 
 		// 创建独立的context用于日志写入，替换原来的 HTTP 请求 context
 		logCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -506,18 +562,22 @@ func (g *Gateway) drainGeneration(generation *gatewayGeneration) error {
 }
 
 // Start 启动网关
-func (g *Gateway) Start() error {
+func (g *Gateway) Start() (err error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
 	if g.running || g.stopping {
 		return fmt.Errorf("网关已经在运行")
 	}
+	defer func() {
+		if err != nil {
+			g.stats.recordStartFailure(err)
+		}
+	}()
 
 	generation := g.currentGeneration.Load()
 	if generation == nil {
 		// Stop会关闭Server和处理器；再次Start时必须创建全新代际，不能复用已关闭资源。
-		var err error
 		generation, err = NewGatewayFactory().buildGeneration(g, g.gatewayConfig)
 		if err != nil {
 			return fmt.Errorf("重新构建网关运行时代际失败: %w", err)
@@ -530,6 +590,12 @@ func (g *Gateway) Start() error {
 		g.currentGeneration.Store(generation)
 	}
 
+	// 绑定端口前等待路由、服务中心和数据库/缓存就绪，未就绪时不接收流量
+	if err := g.waitStartupReadiness(generation); err != nil {
+		g.updateHealthStatus("N", err.Error())
+		return err
+	}
+
 	// 在启动前检查端口是否已被占用
 	listener, err := net.Listen("tcp", g.server.Addr)
 	if err != nil {
@@ -559,9 +625,12 @@ func (g *Gateway) Start() error {
 	g.dispatcher = dispatcher
 	dispatcher.start()
 
+	g.startFeatureFlags()
+
 	g.running = true
 	g.stopping = false
 	g.stopCh = make(chan struct{})
+	g.stats.recordStarted()
 	// 启动成功，更新数据库状态
 	g.updateHealthStatus("Y", "")
 	logger.Info("网关服务启动成功")
@@ -609,6 +678,7 @@ func (g *Gateway) Stop() error {
 	// 关闭日志处理器
 	instanceID := g.gatewayConfig.InstanceID
 	logwrite.CloseLogWriter(instanceID)
+	g.stopFeatureFlags()
 
 	// 等待所有goroutine结束
 	// 这确保了所有后台任务（包括请求处理）都已完成
@@ -620,6 +690,7 @@ func (g *Gateway) Stop() error {
 	g.stopping = false
 	g.dispatcher = nil
 	g.currentGeneration.CompareAndSwap(current, nil)
+	g.stats.recordStopped()
 	g.mu.Unlock()
 	// 实例随进程退出而停止时，starter 已先置 IsInstanceStopping；此时不再把库中健康状态改为 N，
 	// 避免与其它节点或注册中心对实例存活判断不一致（由集群/下线流程统一收敛状态）。
@@ -641,6 +712,18 @@ func (g *Gateway) IsRunning() bool {
 	return g.running
 }
 
+// SetMaintenanceMode 开启或关闭维护模式
+// 维护模式下网关保持监听，但新请求直接返回503和Retry-After，用于发布或故障处理期间摘除流量
+func (g *Gateway) SetMaintenanceMode(enabled bool) {
+	g.maintenance.Store(enabled)
+	logger.Info("网关维护模式已变更", "instanceId", g.GetConfig().InstanceID, "maintenance", enabled)
+}
+
+// IsMaintenanceMode 检查网关是否处于维护模式
+func (g *Gateway) IsMaintenanceMode() bool {
+	return g.maintenance.Load()
+}
+
 // GetConfig 获取配置
 func (g *Gateway) GetConfig() *config.GatewayConfig {
 	if generation := g.currentGeneration.Load(); generation != nil {
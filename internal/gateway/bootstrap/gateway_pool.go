@@ -1,6 +1,8 @@
 package bootstrap
 
 import (
+	"fmt"
+	"sort"
 	"sync"
 
 	"gateway/pkg/logger"
@@ -208,11 +210,11 @@ func (p *gatewayPool) StopAll() error {
 	}
 	p.mu.RUnlock()
 
-	var errors []string
+	stopErrors := huberrors.NewMultiError("部分网关实例停止失败")
 	for id, gateway := range gateways {
 		if gateway.IsRunning() {
 			if err := gateway.Stop(); err != nil {
-				errors = append(errors, id+": "+err.Error())
+				huberrors.Append(stopErrors, fmt.Errorf("%s: %w", id, err))
 				logger.Error("停止网关实例失败", err, "instanceId", id)
 			} else {
 				logger.Info("网关实例停止成功", "instanceId", id)
@@ -220,8 +222,8 @@ func (p *gatewayPool) StopAll() error {
 		}
 	}
 
-	if len(errors) > 0 {
-		return huberrors.NewError("部分网关实例停止失败: %v", errors)
+	if err := stopErrors.ErrorOrNil(); err != nil {
+		return err
 	}
 
 	logger.Info("所有网关实例停止成功", "count", len(gateways))
@@ -258,6 +260,80 @@ func (p *gatewayPool) GetInstanceIDs() []string {
 	return ids
 }
 
+// StartInstance 启动单个网关实例，已在运行时直接返回
+func (p *gatewayPool) StartInstance(instanceID string) error {
+	gateway, err := p.Get(instanceID)
+	if err != nil {
+		return err
+	}
+	if gateway.IsRunning() {
+		return nil
+	}
+	if err := gateway.Start(); err != nil {
+		logger.Error("启动网关实例失败", err, "instanceId", instanceID)
+		return huberrors.WrapError(err, "启动网关实例失败")
+	}
+	logger.Info("网关实例启动成功", "instanceId", instanceID)
+	return nil
+}
+
+// StopInstance 停止单个网关实例，实例保留在连接池中，可再次启动
+func (p *gatewayPool) StopInstance(instanceID string) error {
+	gateway, err := p.Get(instanceID)
+	if err != nil {
+		return err
+	}
+	if !gateway.IsRunning() {
+		return nil
+	}
+	if err := gateway.Stop(); err != nil {
+		logger.Error("停止网关实例失败", err, "instanceId", instanceID)
+		return huberrors.WrapError(err, "停止网关实例失败")
+	}
+	logger.Info("网关实例停止成功", "instanceId", instanceID)
+	return nil
+}
+
+// RestartInstance 重启单个网关实例，未运行时直接启动
+func (p *gatewayPool) RestartInstance(instanceID string) error {
+	gateway, err := p.Get(instanceID)
+	if err != nil {
+		return err
+	}
+	if err := gateway.Restart(); err != nil {
+		logger.Error("重启网关实例失败", err, "instanceId", instanceID)
+		return huberrors.WrapError(err, "重启网关实例失败")
+	}
+	logger.Info("网关实例重启成功", "instanceId", instanceID)
+	return nil
+}
+
+// GetInstanceStatus 获取单个网关实例的运行状态
+func (p *gatewayPool) GetInstanceStatus(instanceID string) (InstanceStatus, error) {
+	gateway, err := p.Get(instanceID)
+	if err != nil {
+		return InstanceStatus{}, err
+	}
+	status := gateway.Status()
+	status.InstanceID = instanceID
+	return status, nil
+}
+
+// GetAllInstanceStatus 获取所有网关实例的运行状态，按实例ID排序
+func (p *gatewayPool) GetAllInstanceStatus() []InstanceStatus {
+	gateways := p.GetAll()
+	result := make([]InstanceStatus, 0, len(gateways))
+	for id, gateway := range gateways {
+		status := gateway.Status()
+		status.InstanceID = id
+		result = append(result, status)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].InstanceID < result[j].InstanceID
+	})
+	return result
+}
+
 // GatewayPool 网关连接池接口（公开接口）
 type GatewayPool interface {
 	Add(instanceID string, gateway *Gateway) error
@@ -271,6 +347,11 @@ type GatewayPool interface {
 	StopAll() error
 	Clear() error
 	GetInstanceIDs() []string
+	StartInstance(instanceID string) error
+	StopInstance(instanceID string) error
+	RestartInstance(instanceID string) error
+	GetInstanceStatus(instanceID string) (InstanceStatus, error)
+	GetAllInstanceStatus() []InstanceStatus
 }
 
 // 全局网关连接池实例
@@ -0,0 +1,99 @@
+package bootstrap
+
+import (
+	"sync/atomic"
+	"time"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+)
+
+// InstanceStatus 单个网关实例的运行状态
+type InstanceStatus struct {
+	InstanceID    string    `json:"instanceId"`    // 网关实例ID
+	Name          string    `json:"name"`          // 网关实例名称
+	Listen        string    `json:"listen"`        // 监听地址
+	Running       bool      `json:"running"`       // 是否运行中
+	Maintenance   bool      `json:"maintenance"`   // 是否处于维护模式
+	StartedAt     time.Time `json:"startedAt"`     // 最近一次启动时间，未运行时为零值
+	UptimeSeconds int64     `json:"uptimeSeconds"` // 运行时长(秒)
+	RouteCount    int       `json:"routeCount"`    // 已加载路由数
+	RequestCount  int64     `json:"requestCount"`  // 累计处理请求数
+	ErrorCount    int64     `json:"errorCount"`    // 累计错误请求数（处理出错或网关状态码>=500）
+	StartFailures int64     `json:"startFailures"` // 累计启动失败次数
+	LastError     string    `json:"lastError"`     // 最近一次启动失败原因
+}
+
+// gatewayStats 网关实例运行统计，跨运行时代际累计，进程重启后清零
+type gatewayStats struct {
+	startedAt     atomic.Int64 // 最近一次启动时间(UnixNano)，未运行时为0
+	requestCount  atomic.Int64
+	errorCount    atomic.Int64
+	startFailures atomic.Int64
+	lastError     atomic.Value // string
+}
+
+// recordRequest 统计一次请求，处理出错或网关状态码>=500时计为错误请求
+func (s *gatewayStats) recordRequest(ctx *core.Context) {
+	s.requestCount.Add(1)
+	if ctx.HasErrors() {
+		s.errorCount.Add(1)
+		return
+	}
+	if statusCode, ok := ctx.GetInt(constants.GatewayStatusCode); ok && statusCode >= 500 {
+		s.errorCount.Add(1)
+	}
+}
+
+// recordStarted 记录启动成功
+func (s *gatewayStats) recordStarted() {
+	s.startedAt.Store(time.Now().UnixNano())
+	s.lastError.Store("")
+}
+
+// recordStartFailure 记录启动失败
+func (s *gatewayStats) recordStartFailure(err error) {
+	s.startFailures.Add(1)
+	s.lastError.Store(err.Error())
+}
+
+// recordStopped 记录停止
+func (s *gatewayStats) recordStopped() {
+	s.startedAt.Store(0)
+}
+
+// Status 获取网关实例运行状态
+func (g *Gateway) Status() InstanceStatus {
+	cfg := g.GetConfig()
+	status := InstanceStatus{
+		Running:       g.IsRunning(),
+		Maintenance:   g.IsMaintenanceMode(),
+		RequestCount:  g.stats.requestCount.Load(),
+		ErrorCount:    g.stats.errorCount.Load(),
+		StartFailures: g.stats.startFailures.Load(),
+	}
+	if cfg != nil {
+		status.InstanceID = cfg.InstanceID
+		status.Name = cfg.Base.Name
+		status.Listen = cfg.Base.Listen
+	}
+	if lastError, ok := g.stats.lastError.Load().(string); ok {
+		status.LastError = lastError
+	}
+	if startedAt := g.stats.startedAt.Load(); startedAt > 0 && status.Running {
+		status.StartedAt = time.Unix(0, startedAt)
+		status.UptimeSeconds = int64(time.Since(status.StartedAt).Seconds())
+	}
+	if generation := g.currentGeneration.Load(); generation != nil && generation.handlers.router != nil {
+		status.RouteCount = len(generation.handlers.router.ListRoutes())
+	}
+	return status
+}
+
+// Restart 重启网关实例，使用当前配置重新构建运行时代际
+func (g *Gateway) Restart() error {
+	if err := g.Stop(); err != nil {
+		return err
+	}
+	return g.Start()
+}
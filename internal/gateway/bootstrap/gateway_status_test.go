@@ -0,0 +1,89 @@
+package bootstrap
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gateway/internal/gateway/config"
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+)
+
+func TestGatewayStatsCountsErrors(t *testing.T) {
+	var stats gatewayStats
+	newCtx := func() *core.Context {
+		return core.NewContext(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	}
+
+	ok := newCtx()
+	ok.Set(constants.GatewayStatusCode, 200)
+	stats.recordRequest(ok)
+
+	badGateway := newCtx()
+	badGateway.Set(constants.GatewayStatusCode, 502)
+	stats.recordRequest(badGateway)
+
+	failed := newCtx()
+	failed.AddError(errors.New("route not found"))
+	stats.recordRequest(failed)
+
+	if got := stats.requestCount.Load(); got != 3 {
+		t.Fatalf("requestCount = %d, want 3", got)
+	}
+	if got := stats.errorCount.Load(); got != 2 {
+		t.Fatalf("errorCount = %d, want 2", got)
+	}
+}
+
+func TestGatewayStatusReportsInstance(t *testing.T) {
+	cfg := &config.GatewayConfig{InstanceID: "gw-1"}
+	cfg.Base.Name = "edge"
+	cfg.Base.Listen = ":18080"
+	gateway := &Gateway{gatewayConfig: cfg, running: true}
+	gateway.stats.recordStartFailure(errors.New("port in use"))
+	gateway.stats.recordStarted()
+	gateway.stats.startedAt.Store(time.Now().Add(-time.Minute).UnixNano())
+
+	status := gateway.Status()
+	if status.InstanceID != "gw-1" || status.Name != "edge" || status.Listen != ":18080" {
+		t.Fatalf("unexpected instance identity: %+v", status)
+	}
+	if !status.Running || status.UptimeSeconds < 59 {
+		t.Fatalf("expected running gateway with uptime, got %+v", status)
+	}
+	if status.StartFailures != 1 || status.LastError != "" {
+		t.Fatalf("successful start should keep failure count and clear last error, got %+v", status)
+	}
+
+	gateway.running = false
+	gateway.stats.recordStopped()
+	if status := gateway.Status(); status.UptimeSeconds != 0 || !status.StartedAt.IsZero() {
+		t.Fatalf("stopped gateway should not report uptime, got %+v", status)
+	}
+}
+
+func TestGatewayPoolInstanceStatus(t *testing.T) {
+	pool := newGatewayPool()
+	for _, id := range []string{"gw-b", "gw-a"} {
+		if err := pool.Add(id, &Gateway{gatewayConfig: &config.GatewayConfig{InstanceID: id}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	statuses := pool.GetAllInstanceStatus()
+	if len(statuses) != 2 || statuses[0].InstanceID != "gw-a" || statuses[1].InstanceID != "gw-b" {
+		t.Fatalf("unexpected statuses: %+v", statuses)
+	}
+	if _, err := pool.GetInstanceStatus("missing"); err == nil {
+		t.Fatal("expected error for unknown instance")
+	}
+	if err := pool.StartInstance("missing"); err == nil {
+		t.Fatal("expected error when starting unknown instance")
+	}
+	// 未运行的实例停止时直接返回
+	if err := pool.StopInstance("gw-a"); err != nil {
+		t.Fatal(err)
+	}
+}
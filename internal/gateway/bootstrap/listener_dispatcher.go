@@ -6,6 +6,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"gateway/pkg/crash"
 	"gateway/pkg/logger"
 )
 
@@ -43,9 +44,14 @@ func (d *listenerDispatcher) start() {
 	})
 }
 
-// run 持续接收底层连接，并确保切换前已接收的连接仍归属旧代际。
+// run 运行连接接收循环，接收循环panic后按崩溃重启策略重新开始接收。
 func (d *listenerDispatcher) run() {
 	defer close(d.runDone)
+	crash.Supervise("gateway.listener", d.acceptLoop)
+}
+
+// acceptLoop 持续接收底层连接，并确保切换前已接收的连接仍归属旧代际。
+func (d *listenerDispatcher) acceptLoop() {
 	var retryDelay time.Duration
 	for {
 		conn, err := d.base.Accept()
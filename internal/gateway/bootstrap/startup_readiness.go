@@ -0,0 +1,177 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gateway/internal/gateway/config"
+	proxyutils "gateway/internal/gateway/handler/proxy/proxy-utils"
+	"gateway/pkg/cache"
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+)
+
+// startupCheckTimeout 单个检查项单次执行的超时时间，避免连接探测阻塞整个就绪等待
+const startupCheckTimeout = 5 * time.Second
+
+// startupCheck 启动就绪检查项
+type startupCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// waitStartupReadiness 绑定监听端口前等待代际依赖就绪
+// 超时后按配置的处理方式决定放弃启动还是记录告警后继续
+func (g *Gateway) waitStartupReadiness(generation *gatewayGeneration) error {
+	readiness := generation.config.Base.StartupReadiness
+	if !readiness.Enabled {
+		return nil
+	}
+
+	checks := buildStartupChecks(generation, &readiness)
+	logger.Info("等待网关启动就绪检查",
+		"instanceId", generation.config.InstanceID,
+		"checks", startupCheckNames(checks),
+		"timeout", readiness.Timeout)
+
+	start := time.Now()
+	err := runStartupChecks(readiness, checks)
+	if err == nil {
+		logger.Info("网关启动就绪检查通过", "instanceId", generation.config.InstanceID, "elapsed", time.Since(start))
+		return nil
+	}
+	if readiness.ContinueOnFailure() {
+		logger.Warn("网关启动就绪检查未通过，按配置继续启动", "instanceId", generation.config.InstanceID, "error", err)
+		return nil
+	}
+	return err
+}
+
+// buildStartupChecks 按配置构建启动就绪检查项
+func buildStartupChecks(generation *gatewayGeneration, readiness *config.StartupReadinessConfig) []startupCheck {
+	var checks []startupCheck
+	if readiness.HasCheck(config.StartupCheckRoutes) {
+		checks = append(checks, startupCheck{name: config.StartupCheckRoutes, check: func(context.Context) error {
+			return checkRoutesLoaded(generation)
+		}})
+	}
+	if readiness.HasCheck(config.StartupCheckRegistry) {
+		checks = append(checks, startupCheck{name: config.StartupCheckRegistry, check: func(context.Context) error {
+			return checkRegistrySynced(generation.config)
+		}})
+	}
+	if readiness.HasCheck(config.StartupCheckDatabase) {
+		checks = append(checks, startupCheck{name: config.StartupCheckDatabase, check: checkDatabaseConnected})
+	}
+	if readiness.HasCheck(config.StartupCheckCache) {
+		checks = append(checks, startupCheck{name: config.StartupCheckCache, check: checkCacheConnected})
+	}
+	return checks
+}
+
+// runStartupChecks 周期执行检查项直到全部通过或超时，已通过的检查项不再重复执行
+// 返回值:
+// - error: 超时时返回仍未通过的检查项及最近一次失败原因
+func runStartupChecks(readiness config.StartupReadinessConfig, checks []startupCheck) error {
+	timeout := readiness.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultStartupReadinessConfig.Timeout
+	}
+	interval := readiness.CheckInterval
+	if interval <= 0 {
+		interval = config.DefaultStartupReadinessConfig.CheckInterval
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	pending := checks
+	failures := make(map[string]error)
+	for {
+		remaining := pending[:0:0]
+		for _, check := range pending {
+			checkCtx, checkCancel := context.WithTimeout(ctx, startupCheckTimeout)
+			err := check.check(checkCtx)
+			checkCancel()
+			if err != nil {
+				failures[check.name] = err
+				remaining = append(remaining, check)
+				continue
+			}
+			delete(failures, check.name)
+		}
+		pending = remaining
+		if len(pending) == 0 {
+			return nil
+		}
+		logger.Debug("网关启动就绪检查未全部通过，等待重试", "pending", startupCheckNames(pending))
+
+		select {
+		case <-ctx.Done():
+			reasons := make([]string, 0, len(pending))
+			for _, check := range pending {
+				reasons = append(reasons, fmt.Sprintf("%s: %v", check.name, failures[check.name]))
+			}
+			return fmt.Errorf("启动就绪检查在 %s 内未通过: %s", timeout, strings.Join(reasons, "; "))
+		case <-time.After(interval):
+		}
+	}
+}
+
+// startupCheckNames 检查项名称列表
+func startupCheckNames(checks []startupCheck) []string {
+	names := make([]string, 0, len(checks))
+	for _, check := range checks {
+		names = append(names, check.name)
+	}
+	return names
+}
+
+// checkRoutesLoaded 检查配置的路由已全部加载到路由处理器
+func checkRoutesLoaded(generation *gatewayGeneration) error {
+	configured := len(generation.config.Router.Routes)
+	if generation.handlers.router == nil {
+		if configured == 0 {
+			return nil
+		}
+		return fmt.Errorf("路由处理器未初始化")
+	}
+	if loaded := len(generation.handlers.router.ListRoutes()); loaded < configured {
+		return fmt.Errorf("已加载路由 %d/%d", loaded, configured)
+	}
+	return nil
+}
+
+// checkRegistrySynced 检查引用服务中心的服务已同步到本地缓存
+func checkRegistrySynced(cfg *config.GatewayConfig) error {
+	for _, serviceConfig := range cfg.Proxy.Service {
+		if err := proxyutils.CheckServiceCenterServiceSynced(serviceConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDatabaseConnected 检查默认数据库连接可用，未配置数据库时跳过
+func checkDatabaseConnected(ctx context.Context) error {
+	db := database.GetDefaultConnection()
+	if db == nil {
+		return nil
+	}
+	if err := db.Ping(ctx); err != nil {
+		return fmt.Errorf("默认数据库连接不可用: %w", err)
+	}
+	return nil
+}
+
+// checkCacheConnected 检查已配置的缓存连接可用，未配置缓存时跳过
+func checkCacheConnected(ctx context.Context) error {
+	for name, err := range cache.HealthCheck(ctx) {
+		if err != nil {
+			return fmt.Errorf("缓存连接 %s 不可用: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gateway/internal/gateway/config"
+)
+
+func TestRunStartupChecksWaitsUntilReady(t *testing.T) {
+	routeCalls, registryCalls := 0, 0
+	checks := []startupCheck{
+		{name: config.StartupCheckRoutes, check: func(context.Context) error {
+			routeCalls++
+			return nil
+		}},
+		{name: config.StartupCheckRegistry, check: func(context.Context) error {
+			registryCalls++
+			if registryCalls < 3 {
+				return errors.New("not synced")
+			}
+			return nil
+		}},
+	}
+	readiness := config.StartupReadinessConfig{Enabled: true, Timeout: time.Second, CheckInterval: 5 * time.Millisecond}
+	if err := runStartupChecks(readiness, checks); err != nil {
+		t.Fatalf("readiness failed: %v", err)
+	}
+	if routeCalls != 1 {
+		t.Fatalf("passed check re-ran %d times, want 1", routeCalls)
+	}
+	if registryCalls != 3 {
+		t.Fatalf("registry check ran %d times, want 3", registryCalls)
+	}
+}
+
+func TestRunStartupChecksTimeout(t *testing.T) {
+	checks := []startupCheck{
+		{name: config.StartupCheckDatabase, check: func(context.Context) error {
+			return errors.New("connection refused")
+		}},
+	}
+	readiness := config.StartupReadinessConfig{Enabled: true, Timeout: 30 * time.Millisecond, CheckInterval: 5 * time.Millisecond}
+	err := runStartupChecks(readiness, checks)
+	if err == nil {
+		t.Fatal("expected readiness timeout")
+	}
+	if !strings.Contains(err.Error(), "database") || !strings.Contains(err.Error(), "connection refused") {
+		t.Fatalf("timeout error does not name the pending check: %v", err)
+	}
+}
+
+func TestStartupReadinessConfigChecks(t *testing.T) {
+	readiness := config.StartupReadinessConfig{Checks: []string{" Routes ", "cache"}, FailureMode: "CONTINUE"}
+	if !readiness.HasCheck(config.StartupCheckRoutes) || !readiness.HasCheck(config.StartupCheckCache) {
+		t.Fatal("configured checks should be enabled")
+	}
+	if readiness.HasCheck(config.StartupCheckDatabase) {
+		t.Fatal("unconfigured check should be skipped")
+	}
+	if !readiness.ContinueOnFailure() {
+		t.Fatal("failure mode continue should be case insensitive")
+	}
+	if !(&config.StartupReadinessConfig{}).HasCheck(config.StartupCheckRegistry) {
+		t.Fatal("empty check list should enable all checks")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"gateway/internal/gateway/handler/proxy"
 	"gateway/internal/gateway/handler/router"
 	"gateway/internal/gateway/handler/security"
+	"gateway/internal/gateway/helper"
 	"gateway/internal/gateway/logwrite/types"
 )
 
@@ -31,6 +32,8 @@ type GatewayConfig struct {
 	RateLimit limiter.RateLimitConfig `json:"rate_limit" yaml:"rate_limit" mapstructure:"rate_limit"`
 	// 注意：熔断器配置不在全局级别，而是在路由级别或服务级别进行配置
 	Log types.LogConfig `json:"log" yaml:"log" mapstructure:"log"`
+	// 错误响应标准化配置（网关自身产生的失败统一返回 code/message/traceId 结构）
+	ErrorResponse helper.ErrorResponseConfig `json:"error_response" yaml:"error_response" mapstructure:"error_response"`
 }
 
 // BaseConfig 基础配置
@@ -75,6 +78,8 @@ type BaseConfig struct {
 	KeepAliveEnabled bool `json:"keep_alive_enabled" yaml:"keep_alive_enabled" mapstructure:"keep_alive_enabled"`
 	// 是否启用TCP Keep-Alive（需要在net.Listener层面设置）
 	TCPKeepAliveEnabled bool `json:"tcp_keep_alive_enabled" yaml:"tcp_keep_alive_enabled" mapstructure:"tcp_keep_alive_enabled"`
+	// 启动就绪检查：绑定监听端口前等待路由、服务中心和数据库/缓存就绪
+	StartupReadiness StartupReadinessConfig `json:"startup_readiness" yaml:"startup_readiness" mapstructure:"startup_readiness"`
 }
 
 // DefaultGatewayConfig 默认网关配置
@@ -97,6 +102,7 @@ var DefaultGatewayConfig = GatewayConfig{
 		EnableGzip:              true,
 		KeepAliveEnabled:        true, // 默认启用HTTP Keep-Alive
 		TCPKeepAliveEnabled:     true, // 默认启用TCP Keep-Alive
+		StartupReadiness:        DefaultStartupReadinessConfig,
 	},
 	Router: router.DefaultRouterConfig,
 	// 直接使用proxy模块的默认配置
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// 启动就绪检查项
+const (
+	StartupCheckRoutes   = "routes"   // 路由配置已加载
+	StartupCheckRegistry = "registry" // 引用的服务中心服务已同步到本地缓存
+	StartupCheckDatabase = "database" // 默认数据库连接可用
+	StartupCheckCache    = "cache"    // 已配置的缓存连接可用
+)
+
+// 启动就绪检查超时后的处理方式
+const (
+	StartupFailureModeFail     = "fail"     // 放弃启动，不绑定监听端口
+	StartupFailureModeContinue = "continue" // 记录告警后继续启动监听
+)
+
+// StartupReadinessConfig 启动就绪检查配置
+// 启用后网关在绑定监听端口前等待路由加载、服务中心同步和数据库/缓存连通，全部通过后才开始接收流量
+type StartupReadinessConfig struct {
+	// 是否启用启动就绪检查
+	Enabled bool `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
+	// 等待就绪的最长时间
+	Timeout time.Duration `json:"timeout" yaml:"timeout" mapstructure:"timeout"`
+	// 未就绪时的重试间隔
+	CheckInterval time.Duration `json:"check_interval" yaml:"check_interval" mapstructure:"check_interval"`
+	// 检查项(routes,registry,database,cache)，为空时检查全部
+	Checks []string `json:"checks" yaml:"checks" mapstructure:"checks"`
+	// 超时后的处理方式(fail,continue)，默认fail
+	FailureMode string `json:"failure_mode" yaml:"failure_mode" mapstructure:"failure_mode"`
+}
+
+// DefaultStartupReadinessConfig 默认启动就绪检查配置
+var DefaultStartupReadinessConfig = StartupReadinessConfig{
+	Enabled:       true,
+	Timeout:       60 * time.Second,
+	CheckInterval: 500 * time.Millisecond,
+	FailureMode:   StartupFailureModeFail,
+}
+
+// HasCheck 是否需要执行指定检查项，未配置检查项时检查全部
+func (c *StartupReadinessConfig) HasCheck(name string) bool {
+	if len(c.Checks) == 0 {
+		return true
+	}
+	for _, check := range c.Checks {
+		if strings.EqualFold(strings.TrimSpace(check), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContinueOnFailure 超时后是否继续启动
+func (c *StartupReadinessConfig) ContinueOnFailure() bool {
+	return strings.EqualFold(strings.TrimSpace(c.FailureMode), StartupFailureModeContinue)
+}
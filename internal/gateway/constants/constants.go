@@ -18,8 +18,15 @@ const (
 	ContextKeyRouteRewritePath      = "route_rewrite_path"       // 路由重写路径
 	ContextKeyRouteEnableWebSocket  = "route_enable_websocket"   // 路由 WebSocket 标记（N 仍兼容允许升级）
 	ContextKeyRouteTimeout          = "route_timeout"            // 路由请求总超时(>0才覆盖代理)
+	ContextKeyRouteConnectTimeout   = "route_connect_timeout"    // 路由连接超时(>0才覆盖服务和代理)
+	ContextKeyRouteTLSTimeout       = "route_tls_timeout"        // 路由TLS握手超时(>0才覆盖服务和代理)
+	ContextKeyRouteHeaderTimeout    = "route_header_timeout"     // 路由等待响应头超时(>0才覆盖服务和代理)
 	ContextKeyRouteRetryCount       = "route_retry_count"        // 路由重试次数
 	ContextKeyRouteRetryInterval    = "route_retry_interval"     // 路由重试间隔
+	ContextKeyRouteCoalescePolicy   = "route_coalesce_policy"    // 路由请求合并策略
+	ContextKeyRequestCoalesced      = "request_coalesced"        // 响应来自合并请求的共享结果
+	ContextKeyRouteIdempotency      = "route_idempotency"        // 路由幂等键去重策略
+	ContextKeyIdempotentReplayed    = "idempotent_replayed"      // 响应来自幂等键缓存的重放结果
 	ContextKeyServiceDefinitionID   = "service_definition_ids"   // 服务定义ID列表
 	ContextKeyServiceDefinitionName = "service_definition_names" // 服务定义名称列表
 	ContextKeyLogConfigID           = "log_config_id"            // 日志配置ID
@@ -43,6 +50,25 @@ const (
 	ContextKeyWebSocketBytesSent     = "websocket_bytes_sent"     // 上游发往客户端的字节数
 	ContextKeyResponseSize           = "response_size"            // 访问日志响应大小（SSE/WS等显式写入）
 
+	// 客户端地理位置（启用地理位置库时由网关在请求入口写入，值为 *geoip.Location）
+	ContextKeyClientGeoLocation = "client_geo_location"
+
+	// 慢请求诊断相关（启用慢请求检测时写入）
+	ContextKeySlowRequestWatchdog = "slow_request_watchdog" // 慢请求看门狗，值为 *logwrite.SlowRequestWatchdog
+	ContextKeyUpstreamConnStates  = "upstream_conn_states"  // 上游连接状态，值为 *types.UpstreamConnStates
+
+	// 功能开关判定结果（开关配置了记录判定结果时写入，值为 map[string]bool，键为开关标识）
+	ContextKeyFeatureFlagEvaluations = "feature_flag_evaluations"
+
+	// A/B测试分组结果（A/B测试过滤器写入，值为 map[string]string，键为实验标识，值为实验组）
+	ContextKeyABTestAssignments = "ab_test_assignments"
+
+	// 注入的故障（故障注入过滤器写入，值为逗号分隔的故障描述，如 "delay=200ms,abort=503"）
+	ContextKeyFaultInjected = "fault_injected"
+
+	// 限流排队时间（削峰限流器写入，值为 time.Duration，访问日志记录到 queueTimeMs 字段）
+	ContextKeyRateLimitQueueTime = "rate_limit_queue_time"
+
 	// 原始请求信息保存相关常量
 	ContextKeyOriginalMethod      = "original_method"       // 原始HTTP方法
 	ContextKeyOriginalURLPath     = "original_url_path"     // 原始URL路径
@@ -132,11 +158,13 @@ const (
 	ErrorCodeAuthorizationFail  = "AUTHORIZATION_FAILED"
 	ErrorCodeRateLimitExceeded  = "RATE_LIMIT_EXCEEDED"
 	ErrorCodeGatewayOverloaded  = "GATEWAY_OVERLOADED"
+	ErrorCodeMaintenance        = "GATEWAY_MAINTENANCE"
 	ErrorCodeCircuitBreakerOpen = "CIRCUIT_BREAKER_OPEN"
 	ErrorCodeInvalidRequest     = "INVALID_REQUEST"
 	ErrorCodeUpstreamError      = "UPSTREAM_ERROR"
 	ErrorCodeTimeout            = "TIMEOUT"
 	ErrorCodeInternalError      = "INTERNAL_ERROR"
+	ErrorCodeFaultInjected      = "FAULT_INJECTED"
 )
 
 // Default Values - 默认值常量
@@ -13,6 +13,7 @@ import (
 	"gateway/internal/gateway/constants"
 	"gateway/internal/gateway/helper"
 	"gateway/internal/gateway/logwrite/types"
+	"gateway/pkg/logger"
 )
 
 // Context 是网关请求上下文，贯穿整个请求生命周期
@@ -39,8 +40,12 @@ type Context struct {
 	// 用于在不同处理器之间传递数据，如认证信息、路由结果等
 	data map[string]interface{}
 
+	// 请求变量
+	// 由前序过滤器写入、供后续过滤器和头部/日志模板（如 ${ctx.user_id}）读取的变量，按需创建
+	vars map[string]interface{}
+
 	// 数据锁
-	// 保护data map的并发安全
+	// 保护data和vars map的并发安全
 	mu sync.RWMutex
 
 	// 标志位，是否已经完成响应
@@ -87,6 +92,10 @@ type Context struct {
 	// 存储当前请求的日志配置，避免重复获取
 	// 在请求处理开始时设置，供日志记录使用
 	logConfig *types.LogConfig
+
+	// 错误响应标准化配置
+	// 启用时由网关在请求处理开始时设置，Abort 按此配置输出统一的错误响应结构
+	errorResponseConfig *helper.ErrorResponseConfig
 }
 
 // NewContext 创建新的请求上下文
@@ -489,9 +498,23 @@ func (c *Context) Abort(statusCode int, obj interface{}) {
 		c.Cancel() // 仍然取消上下文
 		return
 	}
+	response := c.normalizeAbortPayload(statusCode, obj)
+	// 启用错误响应标准化时，按映射规则转换为统一的错误响应结构
+	c.mu.RLock()
+	config := c.errorResponseConfig
+	c.mu.RUnlock()
+	if config != nil {
+		if gatewayResponse, ok := response.(helper.GatewayResponse); ok {
+			var errorCode string
+			if payload, ok := obj.(map[string]string); ok {
+				errorCode = payload["error_code"]
+			}
+			tenantID, _ := c.GetString(constants.ContextKeyTenantID)
+			statusCode, response = config.Map(tenantID, statusCode, gatewayResponse, errorCode)
+		}
+	}
 	//设置终止状态码防止有些链路处理器没有设置
 	c.Set(constants.GatewayStatusCode, statusCode)
-	response := c.normalizeAbortPayload(statusCode, obj)
 	c.JSON(statusCode, response)
 	c.Cancel() // 取消上下文，可能触发资源清理
 }
@@ -569,6 +592,7 @@ func (c *Context) Reset() {
 
 	// 重置所有字段为初始状态
 	c.data = make(map[string]interface{})
+	c.vars = nil
 	c.responded = false
 	c.targetURL = ""
 	c.routeID = ""
@@ -582,6 +606,7 @@ func (c *Context) Reset() {
 
 	// 重置日志配置
 	c.logConfig = nil
+	c.errorResponseConfig = nil
 }
 
 // SetPathParams 设置路径参数
@@ -606,6 +631,14 @@ func (c *Context) GetPathParams() map[string]string {
 	return make(map[string]string)
 }
 
+// AddLogFields 为当前请求附加日志字段
+// 参数:
+// - args: 字段，格式与 logger.Info 的附加参数相同
+// 字段附加到 c.Ctx 上，之后使用 logger.*WithTrace(c.Ctx, ...) 的日志会自动带上这些字段
+func (c *Context) AddLogFields(args ...any) {
+	c.Ctx = logger.WithFields(c.Ctx, args...)
+}
+
 // SetLogConfig 设置日志配置
 // 参数:
 // - config: 日志配置对象
@@ -626,3 +659,13 @@ func (c *Context) GetLogConfig() *types.LogConfig {
 	defer c.mu.RUnlock()
 	return c.logConfig
 }
+
+// SetErrorResponseConfig 设置错误响应标准化配置
+// 参数:
+// - config: 错误响应标准化配置，nil表示使用原有响应结构
+// 在请求处理开始时设置，供 Abort 输出统一的错误响应
+func (c *Context) SetErrorResponseConfig(config *helper.ErrorResponseConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errorResponseConfig = config
+}
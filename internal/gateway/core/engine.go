@@ -161,8 +161,7 @@ func (e *Engine) HandleWithContext(gatewayCtx *Context, w http.ResponseWriter, r
 			traceID,
 		)
 
-		ctx.JSON(http.StatusNotFound, response)
-		// 设置网关状态码
-		ctx.Set(constants.GatewayStatusCode, http.StatusNotFound)
+		// 通过 Abort 返回，统一设置网关状态码并应用错误响应标准化
+		ctx.Abort(http.StatusNotFound, response)
 	}
 }
@@ -0,0 +1,250 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 模板变量来源
+const (
+	// VarSourceContext 请求变量，先查 SetVar 写入的变量，再查 Set 写入的标量值（如认证写入的 user_id）
+	VarSourceContext = "ctx"
+
+	// VarSourceHeader 请求头，如 ${header.X-Tenant-Id}
+	VarSourceHeader = "header"
+
+	// VarSourceQuery 查询参数，如 ${query.version}
+	VarSourceQuery = "query"
+
+	// VarSourceRequest 请求属性，支持 method、path、host、query
+	VarSourceRequest = "request"
+
+	// VarSourceRoute 路由属性，支持 id、path
+	VarSourceRoute = "route"
+)
+
+// varTemplatePattern 模板变量格式 ${来源.名称} 或 ${来源.名称:-默认值}
+var varTemplatePattern = regexp.MustCompile(`\$\{([A-Za-z]+)\.([^}:]+)(?::-([^}]*))?\}`)
+
+// SetVar 设置请求变量
+// 参数:
+// - name: 变量名
+// - value: 变量值
+// 请求变量与 Set 写入的内部数据分开存放，用于过滤器之间、过滤器与日志之间显式传递数据，
+// 避免通过自定义请求头夹带
+func (c *Context) SetVar(name string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.vars == nil {
+		c.vars = make(map[string]interface{})
+	}
+	c.vars[name] = value
+}
+
+// GetVar 获取请求变量
+// 参数:
+// - name: 变量名
+// 返回值:
+// - value: 变量值
+// - exists: 变量是否存在
+func (c *Context) GetVar(name string) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	value, exists := c.vars[name]
+	return value, exists
+}
+
+// DeleteVar 删除请求变量；变量不存在时不做任何操作
+func (c *Context) DeleteVar(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.vars, name)
+}
+
+// Vars 获取全部请求变量的副本，没有变量时返回nil
+func (c *Context) Vars() map[string]interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.vars) == 0 {
+		return nil
+	}
+	result := make(map[string]interface{}, len(c.vars))
+	for name, value := range c.vars {
+		result[name] = value
+	}
+	return result
+}
+
+// GetVarString 获取字符串形式的请求变量
+// 参数:
+// - name: 变量名
+// 返回值:
+// - 字符串值，数值和布尔值会转换为字符串，其他类型序列化为JSON
+// - 变量是否存在
+func (c *Context) GetVarString(name string) (string, bool) {
+	value, exists := c.GetVar(name)
+	if !exists {
+		return "", false
+	}
+	return formatVarValue(value), true
+}
+
+// GetVarInt 获取整数形式的请求变量
+// 参数:
+// - name: 变量名
+// 返回值:
+// - 整数值
+// - 是否成功(变量存在且可转换为整数，字符串按十进制解析)
+func (c *Context) GetVarInt(name string) (int, bool) {
+	value, exists := c.GetVar(name)
+	if !exists {
+		return 0, false
+	}
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), true
+		}
+	case string:
+		if i, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// GetVarBool 获取布尔形式的请求变量
+// 参数:
+// - name: 变量名
+// 返回值:
+// - 布尔值
+// - 是否成功(变量存在且可转换为布尔值，字符串按 strconv.ParseBool 解析)
+func (c *Context) GetVarBool(name string) (bool, bool) {
+	value, exists := c.GetVar(name)
+	if !exists {
+		return false, false
+	}
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+			return b, true
+		}
+	}
+	return false, false
+}
+
+// ExpandVars 展开模板中的变量
+// 参数:
+// - template: 包含 ${来源.名称} 的模板，如 "user-${ctx.user_id:-anonymous}"
+// 返回值:
+// - 展开后的字符串
+// 变量不存在或为空时使用默认值，没有默认值时展开为空字符串；
+// 不认识的来源保持原样，避免误改恰好包含 ${...} 的普通文本
+func (c *Context) ExpandVars(template string) string {
+	if !strings.Contains(template, "${") {
+		return template
+	}
+	return varTemplatePattern.ReplaceAllStringFunc(template, func(match string) string {
+		groups := varTemplatePattern.FindStringSubmatch(match)
+		value, known := c.lookupTemplateVar(strings.ToLower(groups[1]), groups[2])
+		if !known {
+			return match
+		}
+		if value == "" {
+			return groups[3]
+		}
+		return value
+	})
+}
+
+// lookupTemplateVar 按来源查找模板变量，第二个返回值表示来源是否受支持
+func (c *Context) lookupTemplateVar(source, name string) (string, bool) {
+	switch source {
+	case VarSourceContext:
+		if value, exists := c.GetVarString(name); exists {
+			return value, true
+		}
+		if value, exists := c.Get(name); exists && isScalarVarValue(value) {
+			return formatVarValue(value), true
+		}
+		return "", true
+	case VarSourceHeader:
+		if c.Request == nil {
+			return "", true
+		}
+		return c.Request.Header.Get(name), true
+	case VarSourceQuery:
+		if c.Request == nil || c.Request.URL == nil {
+			return "", true
+		}
+		return c.Request.URL.Query().Get(name), true
+	case VarSourceRequest:
+		if c.Request == nil {
+			return "", true
+		}
+		switch strings.ToLower(name) {
+		case "method":
+			return c.Request.Method, true
+		case "host":
+			return c.Request.Host, true
+		case "path":
+			if c.Request.URL != nil {
+				return c.Request.URL.Path, true
+			}
+		case "query":
+			if c.Request.URL != nil {
+				return c.Request.URL.RawQuery, true
+			}
+		}
+		return "", true
+	case VarSourceRoute:
+		switch strings.ToLower(name) {
+		case "id":
+			return c.GetRouteID(), true
+		case "path":
+			return c.GetMatchedPath(), true
+		}
+		return "", true
+	default:
+		return "", false
+	}
+}
+
+// isScalarVarValue 判断是否为可直接用于模板的标量值
+func isScalarVarValue(value interface{}) bool {
+	switch value.(type) {
+	case string, bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// formatVarValue 将变量值格式化为字符串
+func formatVarValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if str, ok := value.(string); ok {
+		return str
+	}
+	if isScalarVarValue(value) {
+		return fmt.Sprint(value)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -39,6 +39,10 @@ const (
 	// PathAssertion 路径断言
 	// 根据请求路径进行断言
 	PathAssertion AssertionType = "path"
+
+	// GeoAssertion 地理位置断言
+	// 根据客户端IP解析出的国家、大洲、地区或城市进行断言（需启用地理位置库）
+	GeoAssertion AssertionType = "geo"
 )
 
 // ComparisonOperator 比较操作符
@@ -175,6 +179,8 @@ func (b *BaseAssertion) GetDescription() string {
 		typeStr = "IP地址"
 	case PathAssertion:
 		typeStr = "路径"
+	case GeoAssertion:
+		typeStr = "地理位置"
 	}
 
 	if b.Operator == Exists || b.Operator == NotExists {
@@ -139,6 +139,8 @@ func (f *AssertionFactory) CreateAssertion(config AssertionConfig) (Assertion, e
 		return IPAsserterFromConfig(config, operator)
 	case BodyContentAssertion:
 		return BodyContentAsserterFromConfig(config, operator)
+	case GeoAssertion:
+		return GeoAsserterFromConfig(config, operator)
 	default:
 		return nil, fmt.Errorf("不支持的断言类型: %s", config.Type)
 	}
@@ -198,6 +200,8 @@ func (f *AssertionFactory) normalizeAssertionType(assertionType string) Assertio
 		return IPAssertion
 	case "body", "body_content":
 		return BodyContentAssertion
+	case "geo", "geoip", "client-geo", "client_geo":
+		return GeoAssertion
 	default:
 		return AssertionType(strings.ToLower(assertionType))
 	}
@@ -0,0 +1,83 @@
+package assertion
+
+import (
+	"fmt"
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+	"gateway/pkg/utils/geoip"
+	"strings"
+)
+
+// geoFields 地理位置断言支持的字段
+var geoFields = map[string]string{
+	"continent":    "continent",
+	"country":      "country",
+	"country_name": "country_name",
+	"countryname":  "country_name",
+	"region":       "region",
+	"region_name":  "region_name",
+	"regionname":   "region_name",
+	"city":         "city",
+}
+
+// GeoAsserter 地理位置断言器
+// 根据网关在请求入口解析出的客户端地理位置进行断言，例如将大洲为EU的流量路由到欧洲后端服务
+// 字段名称支持: continent（大洲代码）、country（国家ISO代码）、country_name、region（地区代码）、region_name、city
+// 未启用地理位置库或无法解析客户端地理位置时，字段值按空字符串比较
+type GeoAsserter struct {
+	BaseAssertion
+}
+
+// GeoAsserterFromConfig 从配置创建地理位置断言器
+func GeoAsserterFromConfig(config AssertionConfig, operator ComparisonOperator) (Assertion, error) {
+	field, ok := geoFields[strings.ToLower(strings.TrimSpace(config.Name))]
+	if !ok {
+		return nil, fmt.Errorf("地理位置断言不支持的字段: %s", config.Name)
+	}
+
+	return &GeoAsserter{
+		BaseAssertion: BaseAssertion{
+			Type:          GeoAssertion,
+			FieldName:     field,
+			ExpectedValue: config.Value,
+			Operator:      operator,
+			CaseSensitive: false,
+			Description:   config.Description,
+			Config:        config,
+		},
+	}, nil
+}
+
+// Evaluate 实现Assertion接口
+func (a *GeoAsserter) Evaluate(ctx *core.Context) (bool, error) {
+	var location *geoip.Location
+	if value, exists := ctx.Get(constants.ContextKeyClientGeoLocation); exists {
+		location, _ = value.(*geoip.Location)
+	}
+
+	// 应用比较规则
+	return a.compare(geoFieldValue(location, a.FieldName), a.ExpectedValue), nil
+}
+
+// geoFieldValue 获取地理位置字段值
+func geoFieldValue(location *geoip.Location, field string) string {
+	if location == nil {
+		return ""
+	}
+	switch field {
+	case "continent":
+		return location.ContinentCode
+	case "country":
+		return location.CountryCode
+	case "country_name":
+		return location.Country
+	case "region":
+		return location.RegionCode
+	case "region_name":
+		return location.Region
+	case "city":
+		return location.City
+	default:
+		return ""
+	}
+}
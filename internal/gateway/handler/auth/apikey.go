@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strings"
 
+	"gateway/internal/gateway/constants"
 	"gateway/internal/gateway/core"
 )
 
@@ -183,7 +184,8 @@ func (a *APIKeyAuth) handleError(ctx *core.Context, err error) {
 		statusCode = http.StatusUnauthorized
 	}
 	ctx.Abort(statusCode, map[string]string{
-		"error": "Unauthorized: " + err.Error(),
+		"error":      "Unauthorized: " + err.Error(),
+		"error_code": constants.ErrorCodeAuthenticationFail,
 	})
 }
 
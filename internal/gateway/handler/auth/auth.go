@@ -125,9 +125,9 @@ type JWTConfig struct {
 	Issuer string `yaml:"issuer" json:"issuer" mapstructure:"issuer"`
 	// 过期时间（秒）
 	Expiration int `yaml:"expiration" json:"expiration" mapstructure:"expiration"`
-	// 签名算法：HS256/HS384/HS512 使用 secret；RS256/RS384/RS512 使用 publicKey
+	// 签名算法：HS256/HS384/HS512 使用 secret；RS*/PS*/ES* 使用 publicKey
 	Algorithm string `yaml:"algorithm" json:"algorithm" mapstructure:"algorithm"`
-	// RSA 等非对称算法使用的公钥（PEM 格式）
+	// RSA、ECDSA 等非对称算法使用的公钥（PEM 格式，支持 PUBLIC KEY、RSA PUBLIC KEY 和证书）
 	PublicKey string `yaml:"public_key" json:"public_key" mapstructure:"public_key"`
 	// 是否验证过期时间
 	VerifyExpiration bool `yaml:"verify_expiration" json:"verify_expiration" mapstructure:"verify_expiration"`
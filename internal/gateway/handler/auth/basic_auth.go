@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strings"
 
+	"gateway/internal/gateway/constants"
 	"gateway/internal/gateway/core"
 )
 
@@ -107,7 +108,8 @@ func (b *BasicAuth) handleError(ctx *core.Context, message string) {
 			ctx.Writer.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
 		}
 		ctx.Abort(http.StatusUnauthorized, map[string]string{
-			"error": "Unauthorized: " + message,
+			"error":      "Unauthorized: " + message,
+			"error_code": constants.ErrorCodeAuthenticationFail,
 		})
 	}
 }
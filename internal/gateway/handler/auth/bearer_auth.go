@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 
+	"gateway/internal/gateway/constants"
 	"gateway/internal/gateway/core"
 )
 
@@ -101,7 +102,8 @@ func (b *BearerTokenAuth) handleError(ctx *core.Context, message string) {
 		status = http.StatusUnauthorized
 	}
 	ctx.Abort(status, map[string]string{
-		"error": "Unauthorized: " + message,
+		"error":      "Unauthorized: " + message,
+		"error_code": constants.ErrorCodeAuthenticationFail,
 	})
 }
 
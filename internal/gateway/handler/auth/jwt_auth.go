@@ -1,14 +1,14 @@
 package auth
 
 import (
-	"crypto/rsa"
-	"crypto/x509"
-	"encoding/pem"
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 
+	"gateway/internal/gateway/constants"
 	"gateway/internal/gateway/core"
+	"gateway/pkg/security"
 
 	"github.com/golang-jwt/jwt/v4"
 )
@@ -22,6 +22,11 @@ type JWTAuth struct {
 
 	// 原始配置
 	originalConfig AuthConfig
+
+	// 按配置创建的令牌校验器
+	verifierOnce sync.Once
+	verifier     *security.JWTSigner
+	verifierErr  error
 }
 
 // NewJWTAuth 创建JWT认证处理器
@@ -138,117 +143,44 @@ func (j *JWTAuth) validateToken(tokenString string) (map[string]interface{}, err
 		return nil, fmt.Errorf("empty token")
 	}
 
-	expectedMethod, err := j.resolveSigningMethod()
+	verifier, err := j.tokenVerifier()
 	if err != nil {
 		return nil, err
 	}
 
 	claims := jwt.MapClaims{}
-	parserOpts := []jwt.ParserOption{
-		jwt.WithValidMethods([]string{expectedMethod.Alg()}),
-	}
-	if !j.jwtConfig.VerifyExpiration {
-		parserOpts = append(parserOpts, jwt.WithoutClaimsValidation())
-	}
-
-	parser := jwt.NewParser(parserOpts...)
-	token, err := parser.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return j.verificationKey(token)
-	})
-	if err != nil {
-		return nil, fmt.Errorf("invalid token: %w", err)
-	}
-	if !token.Valid {
-		return nil, fmt.Errorf("invalid token")
-	}
-
-	if j.jwtConfig.VerifyIssuer {
-		if err := j.verifyIssuer(claims); err != nil {
-			return nil, err
-		}
+	if _, err := verifier.Parse(tokenString, claims); err != nil {
+		return nil, err
 	}
 
 	return mapClaimsToMap(claims), nil
 }
 
-// resolveSigningMethod 根据配置解析签名算法
-func (j *JWTAuth) resolveSigningMethod() (jwt.SigningMethod, error) {
-	switch strings.ToUpper(j.jwtConfig.Algorithm) {
-	case "HS256":
-		return jwt.SigningMethodHS256, nil
-	case "HS384":
-		return jwt.SigningMethodHS384, nil
-	case "HS512":
-		return jwt.SigningMethodHS512, nil
-	case "RS256":
-		return jwt.SigningMethodRS256, nil
-	case "RS384":
-		return jwt.SigningMethodRS384, nil
-	case "RS512":
-		return jwt.SigningMethodRS512, nil
-	default:
-		return nil, fmt.Errorf("unsupported JWT algorithm: %s", j.jwtConfig.Algorithm)
-	}
-}
-
-// verificationKey 返回验签所需的密钥
-func (j *JWTAuth) verificationKey(token *jwt.Token) (interface{}, error) {
-	switch strings.ToUpper(j.jwtConfig.Algorithm) {
-	case "HS256", "HS384", "HS512":
-		if j.jwtConfig.Secret == "" {
-			return nil, fmt.Errorf("JWT secret is not configured")
+// tokenVerifier 按配置创建JWT校验器，首次使用时创建并缓存
+// HS 系列算法使用 secret，RS/PS/ES 系列算法使用 PEM 公钥
+func (j *JWTAuth) tokenVerifier() (*security.JWTSigner, error) {
+	j.verifierOnce.Do(func() {
+		opts := security.JWTOptions{
+			Issuer:               j.jwtConfig.Issuer,
+			VerifyIssuer:         j.jwtConfig.VerifyIssuer,
+			SkipClaimsValidation: !j.jwtConfig.VerifyExpiration,
 		}
-		return []byte(j.jwtConfig.Secret), nil
-	case "RS256", "RS384", "RS512":
-		if j.jwtConfig.PublicKey == "" {
-			return nil, fmt.Errorf("JWT public key is not configured for %s", j.jwtConfig.Algorithm)
+		if security.IsHMACAlgorithm(j.jwtConfig.Algorithm) {
+			j.verifier, j.verifierErr = security.NewHMACJWTSigner(j.jwtConfig.Algorithm, j.jwtConfig.Secret, opts)
+			return
 		}
-		return parseRSAPublicKey(j.jwtConfig.PublicKey)
-	default:
-		return nil, fmt.Errorf("unsupported JWT algorithm: %s", j.jwtConfig.Algorithm)
-	}
-}
-
-// verifyIssuer 校验 token 签发者
-func (j *JWTAuth) verifyIssuer(claims jwt.MapClaims) error {
-	if j.jwtConfig.Issuer == "" {
-		return fmt.Errorf("issuer verification enabled but issuer is not configured")
-	}
-
-	iss, _ := claims["iss"].(string)
-	if iss != j.jwtConfig.Issuer {
-		return fmt.Errorf("invalid issuer")
-	}
-	return nil
-}
-
-// parseRSAPublicKey 解析 PEM 格式的 RSA 公钥
-func parseRSAPublicKey(pemData string) (*rsa.PublicKey, error) {
-	block, _ := pem.Decode([]byte(pemData))
-	if block == nil {
-		return nil, fmt.Errorf("failed to parse PEM public key")
-	}
-
-	switch block.Type {
-	case "RSA PUBLIC KEY":
-		pub, err := x509.ParsePKCS1PublicKey(block.Bytes)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse RSA public key: %w", err)
+		if j.jwtConfig.PublicKey == "" {
+			j.verifierErr = fmt.Errorf("JWT public key is not configured for %s", j.jwtConfig.Algorithm)
+			return
 		}
-		return pub, nil
-	case "PUBLIC KEY":
-		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		publicKey, err := security.ParsePublicKeyPEM([]byte(j.jwtConfig.PublicKey))
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse PKIX public key: %w", err)
-		}
-		pub, ok := key.(*rsa.PublicKey)
-		if !ok {
-			return nil, fmt.Errorf("public key is not RSA")
+			j.verifierErr = err
+			return
 		}
-		return pub, nil
-	default:
-		return nil, fmt.Errorf("unsupported public key type: %s", block.Type)
-	}
+		j.verifier, j.verifierErr = security.NewJWTVerifier(j.jwtConfig.Algorithm, publicKey, opts)
+	})
+	return j.verifier, j.verifierErr
 }
 
 // mapClaimsToMap 将 jwt.MapClaims 转为通用 map
@@ -285,7 +217,8 @@ func (j *JWTAuth) storeAuthInfo(ctx *core.Context, token string, claims map[stri
 func (j *JWTAuth) handleError(ctx *core.Context, message string) {
 	ctx.AddError(fmt.Errorf("JWT authentication failed: %s", message))
 	ctx.Abort(http.StatusUnauthorized, map[string]string{
-		"error": "Unauthorized: " + message,
+		"error":      "Unauthorized: " + message,
+		"error_code": constants.ErrorCodeAuthenticationFail,
 	})
 }
 
@@ -309,18 +242,15 @@ func ValidateJWTConfig(config *JWTConfig) error {
 		return fmt.Errorf("JWT algorithm cannot be empty")
 	}
 
-	algorithm := strings.ToUpper(config.Algorithm)
-	switch algorithm {
-	case "HS256", "HS384", "HS512":
+	if _, err := security.JWTSigningMethod(config.Algorithm); err != nil {
+		return fmt.Errorf("unsupported JWT algorithm: %s", config.Algorithm)
+	}
+	if security.IsHMACAlgorithm(config.Algorithm) {
 		if config.Secret == "" {
 			return fmt.Errorf("JWT secret cannot be empty")
 		}
-	case "RS256", "RS384", "RS512":
-		if config.PublicKey == "" {
-			return fmt.Errorf("JWT public key cannot be empty for %s", config.Algorithm)
-		}
-	default:
-		return fmt.Errorf("unsupported JWT algorithm: %s", config.Algorithm)
+	} else if config.PublicKey == "" {
+		return fmt.Errorf("JWT public key cannot be empty for %s", config.Algorithm)
 	}
 
 	if config.Expiration <= 0 {
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"fmt"
+	"gateway/internal/gateway/constants"
 	"gateway/internal/gateway/core"
 	"strings"
 )
@@ -112,7 +113,8 @@ func (o *OAuth2Auth) introspectToken(token string) (map[string]interface{}, erro
 func (o *OAuth2Auth) handleError(ctx *core.Context, message string) {
 	ctx.AddError(fmt.Errorf("OAuth2 authentication failed: %s", message))
 	ctx.Abort(401, map[string]string{
-		"error": "Unauthorized: " + message,
+		"error":      "Unauthorized: " + message,
+		"error_code": constants.ErrorCodeAuthenticationFail,
 	})
 }
 
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"gateway/internal/gateway/constants"
 	"gateway/internal/gateway/core"
 )
 
@@ -63,7 +64,8 @@ func (cb *circuitBreakerImpl) Handle(ctx *core.Context) bool {
 		// 熔断开启，拒绝请求
 		ctx.AddError(fmt.Errorf("circuit breaker is open for key: %s", key))
 		ctx.Abort(cb.config.ErrorStatusCode, map[string]string{
-			"error": cb.config.ErrorMessage,
+			"error":      cb.config.ErrorMessage,
+			"error_code": constants.ErrorCodeCircuitBreakerOpen,
 		})
 
 		// 通知监听器（在锁外调用，避免死锁）
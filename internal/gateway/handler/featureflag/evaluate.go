@@ -0,0 +1,121 @@
+package featureflag
+
+import (
+	"strings"
+	"sync"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+)
+
+// evaluationsMu 保护上下文中的判定结果，同一请求内的处理器可能并发判定开关
+var evaluationsMu sync.Mutex
+
+// Enabled 判断功能开关对当前请求是否开启
+// 按请求所属网关实例和路由查找开关，再按开关的分桶依据判断是否在放量范围内；
+// 未注册管理器或未配置该开关时视为关闭，开关配置了记录判定结果时写入上下文供访问日志使用
+// 参数:
+// - ctx: 请求上下文
+// - key: 开关标识
+// 返回值:
+// - bool: 开关是否开启
+func Enabled(ctx *core.Context, key string) bool {
+	if ctx == nil || key == "" {
+		return false
+	}
+	instanceID, _ := ctx.GetString(constants.ContextKeyGatewayInstanceID)
+	m := GetManager(instanceID)
+	if m == nil {
+		return false
+	}
+	flag := m.Lookup(ctx.GetRouteID(), key)
+	if flag == nil {
+		return false
+	}
+
+	result := flag.InRollout(bucketValue(ctx, flag))
+	if flag.LogEvaluation {
+		recordEvaluation(ctx, key, result)
+	}
+	return result
+}
+
+// Evaluations 获取请求中记录的开关判定结果，未记录时返回nil
+func Evaluations(ctx *core.Context) map[string]bool {
+	if ctx == nil {
+		return nil
+	}
+	value, exists := ctx.Get(constants.ContextKeyFeatureFlagEvaluations)
+	if !exists {
+		return nil
+	}
+	evaluations, _ := value.(map[string]bool)
+	if len(evaluations) == 0 {
+		return nil
+	}
+
+	evaluationsMu.Lock()
+	defer evaluationsMu.Unlock()
+	result := make(map[string]bool, len(evaluations))
+	for key, enabled := range evaluations {
+		result[key] = enabled
+	}
+	return result
+}
+
+// recordEvaluation 记录开关判定结果
+func recordEvaluation(ctx *core.Context, key string, enabled bool) {
+	evaluationsMu.Lock()
+	defer evaluationsMu.Unlock()
+
+	evaluations, _ := ctx.Get(constants.ContextKeyFeatureFlagEvaluations)
+	recorded, ok := evaluations.(map[string]bool)
+	if !ok {
+		recorded = make(map[string]bool)
+		ctx.Set(constants.ContextKeyFeatureFlagEvaluations, recorded)
+	}
+	recorded[key] = enabled
+}
+
+// bucketValue 按开关的分桶依据取分桶值，取不到时退化为按链路追踪ID分桶
+func bucketValue(ctx *core.Context, flag *Flag) string {
+	var value string
+	switch flag.BucketBy {
+	case BucketByHeader:
+		if flag.BucketKey != "" && ctx.Request != nil {
+			value = ctx.Request.Header.Get(flag.BucketKey)
+		}
+	case BucketByQuery:
+		if flag.BucketKey != "" && ctx.Request != nil {
+			value = ctx.Request.URL.Query().Get(flag.BucketKey)
+		}
+	case BucketByTraceID:
+	default:
+		value = clientIP(ctx)
+	}
+	if value != "" {
+		return value
+	}
+	traceID, _ := ctx.GetString(constants.ContextKeyTraceID)
+	return traceID
+}
+
+// clientIP 获取客户端IP，优先使用 X-Forwarded-For 的第一个地址
+func clientIP(ctx *core.Context) string {
+	if ctx.Request == nil {
+		return ""
+	}
+	if forwarded := ctx.Request.Header.Get("X-Forwarded-For"); forwarded != "" {
+		if ip := strings.TrimSpace(strings.Split(forwarded, ",")[0]); ip != "" {
+			return ip
+		}
+	}
+	if realIP := ctx.Request.Header.Get("X-Real-IP"); realIP != "" {
+		return strings.TrimSpace(realIP)
+	}
+	remoteAddr := ctx.Request.RemoteAddr
+	if idx := strings.LastIndex(remoteAddr, ":"); idx > 0 {
+		return remoteAddr[:idx]
+	}
+	return remoteAddr
+}
@@ -0,0 +1,176 @@
+package featureflag
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gateway/pkg/cache"
+	"gateway/pkg/logger"
+)
+
+// DefaultRefreshInterval 默认的开关版本检查间隔
+const DefaultRefreshInterval = 10 * time.Second
+
+// Loader 从数据库加载租户下的功能开关
+type Loader func(ctx context.Context) ([]Flag, error)
+
+// Manager 单个网关实例的功能开关管理器
+// 启动时加载一次开关，之后按间隔检查缓存中的版本号，版本变化时重新加载；
+// 未配置缓存或读取版本失败时每个间隔都从数据库重新加载，保证开关最终生效
+type Manager struct {
+	tenantID   string
+	instanceID string
+	loader     Loader
+	cache      cache.Cache
+	interval   time.Duration
+
+	index   atomic.Pointer[flagIndex]
+	version string // 最近一次加载时的版本号，只在刷新协程中访问
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	done     chan struct{}
+}
+
+// NewManager 创建功能开关管理器
+// 参数:
+// - tenantID: 租户ID
+// - instanceID: 网关实例ID
+// - loader: 开关加载函数
+// - versionCache: 保存开关版本号的缓存，为nil时按间隔直接从数据库加载
+// - interval: 版本检查间隔，<=0时使用默认值
+func NewManager(tenantID, instanceID string, loader Loader, versionCache cache.Cache, interval time.Duration) *Manager {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	m := &Manager{
+		tenantID:   tenantID,
+		instanceID: instanceID,
+		loader:     loader,
+		cache:      versionCache,
+		interval:   interval,
+		stopCh:     make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	m.index.Store(newFlagIndex(instanceID, nil))
+	return m
+}
+
+// Start 加载开关并启动后台刷新，首次加载失败不阻止启动，由后台刷新重试
+func (m *Manager) Start() {
+	m.refresh(true)
+	go m.run()
+}
+
+// Stop 停止后台刷新
+func (m *Manager) Stop() {
+	if m == nil {
+		return
+	}
+	m.stopOnce.Do(func() {
+		close(m.stopCh)
+	})
+	<-m.done
+}
+
+// InstanceID 获取管理器所属的网关实例ID
+func (m *Manager) InstanceID() string {
+	return m.instanceID
+}
+
+// Count 当前生效的开关数量
+func (m *Manager) Count() int {
+	return m.index.Load().count
+}
+
+// Lookup 查找对路由生效的开关
+// 返回值:
+// - *Flag: 开关，未配置时返回nil
+func (m *Manager) Lookup(routeID, key string) *Flag {
+	return m.index.Load().match(routeID, key)
+}
+
+// Replace 直接替换开关集合，用于测试或不依赖数据库的场景
+func (m *Manager) Replace(flags []Flag) {
+	m.index.Store(newFlagIndex(m.instanceID, flags))
+}
+
+// run 按间隔检查版本并刷新开关
+func (m *Manager) run() {
+	defer close(m.done)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+			m.refresh(false)
+		}
+	}
+}
+
+// refresh 检查缓存中的版本号，版本变化或无法确认版本时重新加载开关
+func (m *Manager) refresh(force bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.interval)
+	defer cancel()
+
+	version, versionKnown := m.currentVersion(ctx)
+	if !force && versionKnown && version == m.version {
+		return
+	}
+
+	flags, err := m.loader(ctx)
+	if err != nil {
+		logger.Warn("加载功能开关失败，继续使用当前开关", "instanceId", m.instanceID, "error", err)
+		return
+	}
+	m.Replace(flags)
+	if versionKnown {
+		m.version = version
+	}
+	logger.Debug("功能开关已加载", "instanceId", m.instanceID, "version", version, "count", m.Count())
+}
+
+// currentVersion 读取缓存中的开关版本号
+// 返回值:
+// - string: 版本号，缓存中不存在时为空字符串
+// - bool: 是否成功读取版本号
+func (m *Manager) currentVersion(ctx context.Context) (string, bool) {
+	if m.cache == nil {
+		return "", false
+	}
+	version, err := m.cache.GetString(ctx, VersionCacheKey(m.tenantID))
+	if err != nil {
+		logger.Debug("读取功能开关版本失败", "tenantId", m.tenantID, "error", err)
+		return "", false
+	}
+	return version, true
+}
+
+// managers 网关实例ID -> 功能开关管理器
+var managers sync.Map
+
+// Register 注册网关实例的功能开关管理器，替换同一实例之前的管理器
+func Register(m *Manager) {
+	managers.Store(m.instanceID, m)
+}
+
+// Unregister 注销网关实例的功能开关管理器，仅当注册的仍是该管理器时移除
+func Unregister(m *Manager) {
+	if m == nil {
+		return
+	}
+	managers.CompareAndDelete(m.instanceID, m)
+}
+
+// GetManager 获取网关实例的功能开关管理器，未注册时返回nil
+func GetManager(instanceID string) *Manager {
+	value, ok := managers.Load(instanceID)
+	if !ok {
+		return nil
+	}
+	return value.(*Manager)
+}
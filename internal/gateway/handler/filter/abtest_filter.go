@@ -0,0 +1,340 @@
+package filter
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+)
+
+// ABTestBucketBy A/B测试分桶依据
+type ABTestBucketBy string
+
+const (
+	// ABTestBucketByCookie 按Cookie分桶
+	// 首次访问时按链路追踪ID随机分配实验组，并写入Cookie，后续请求沿用Cookie中的实验组
+	ABTestBucketByCookie ABTestBucketBy = "cookie"
+
+	// ABTestBucketByHeader 按请求头分桶
+	// 对请求头中的用户ID哈希分配实验组，同一用户在不同设备上分到同一组；请求头为空时退化为按Cookie分桶
+	ABTestBucketByHeader ABTestBucketBy = "header"
+)
+
+// A/B测试过滤器默认值
+const (
+	// DefaultABTestVariantHeader 转发给后端的实验组请求头
+	DefaultABTestVariantHeader = "X-AB-Variant"
+
+	// DefaultABTestCookiePrefix 实验组Cookie名称前缀，完整名称为前缀加实验标识
+	DefaultABTestCookiePrefix = "gw_ab_"
+
+	// DefaultABTestCookieMaxAge 实验组Cookie有效期，单位秒（30天）
+	DefaultABTestCookieMaxAge = 30 * 24 * 3600
+)
+
+// experimentNamePattern 实验标识只允许字母、数字、下划线和中划线，以便直接用作Cookie名称
+var experimentNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ABTestVariant 实验组
+type ABTestVariant struct {
+	// 实验组名称，转发给后端并记录到访问日志
+	Name string
+
+	// 权重，按权重比例分配流量
+	Weight int
+}
+
+// ABTestFilter A/B测试分组过滤器
+// 按Cookie或用户ID哈希把客户端稳定地分配到实验组，通过请求头把实验组转发给后端，
+// 并把分组结果写入上下文，由访问日志记录到扩展属性中供后续分析
+type ABTestFilter struct {
+	BaseFilter
+
+	// 实验标识
+	Experiment string
+
+	// 实验组列表
+	Variants []ABTestVariant
+
+	// 分桶依据
+	BucketBy ABTestBucketBy
+
+	// 分桶键，按请求头分桶时为用户ID请求头名称
+	BucketKey string
+
+	// 转发给后端的实验组请求头名称
+	VariantHeader string
+
+	// 保存实验组的Cookie名称
+	CookieName string
+
+	// Cookie有效期，单位秒
+	CookieMaxAge int
+
+	// 权重之和
+	totalWeight int
+}
+
+// ABTestFilterFromConfig 从配置创建A/B测试过滤器
+func ABTestFilterFromConfig(config FilterConfig) (Filter, error) {
+	action := getFilterActionFromConfig(config)
+
+	// 使用配置中的order字段，如果没有则使用默认值100
+	order := config.Order
+	if order <= 0 {
+		order = 100
+	}
+
+	abTestFilter := NewABTestFilter(config.Name, action, order)
+	abTestFilter.originalConfig = config
+
+	if err := configureABTestFilter(abTestFilter, config.Config); err != nil {
+		return nil, fmt.Errorf("配置A/B测试过滤器失败: %w", err)
+	}
+
+	return abTestFilter, nil
+}
+
+// NewABTestFilter 创建A/B测试过滤器
+func NewABTestFilter(name string, action FilterAction, priority int) *ABTestFilter {
+	baseFilter := NewBaseFilter(ABTestFilterType, action, priority, true, name)
+	return &ABTestFilter{
+		BaseFilter:    *baseFilter,
+		BucketBy:      ABTestBucketByCookie,
+		VariantHeader: DefaultABTestVariantHeader,
+		CookieMaxAge:  DefaultABTestCookieMaxAge,
+	}
+}
+
+// Apply 实现Filter接口
+func (f *ABTestFilter) Apply(ctx *core.Context) error {
+	if ctx.Request == nil {
+		return fmt.Errorf("request is nil")
+	}
+	if f.totalWeight <= 0 {
+		return fmt.Errorf("A/B测试 %s 没有可分配的实验组", f.Experiment)
+	}
+
+	variant := f.assign(ctx)
+
+	// 覆盖客户端自带的同名请求头，避免客户端指定实验组
+	ctx.Request.Header.Set(f.VariantHeader, variant)
+	RecordABTestAssignment(ctx, f.Experiment, variant)
+	return nil
+}
+
+// assign 分配实验组
+func (f *ABTestFilter) assign(ctx *core.Context) string {
+	if f.BucketBy == ABTestBucketByHeader {
+		if userID := strings.TrimSpace(ctx.Request.Header.Get(f.BucketKey)); userID != "" {
+			return f.Pick(userID)
+		}
+	}
+
+	// 沿用Cookie中仍然有效的实验组，实验组被删除或权重调为0时重新分配
+	if cookie, err := ctx.Request.Cookie(f.CookieName); err == nil {
+		if f.isActiveVariant(cookie.Value) {
+			return cookie.Value
+		}
+	}
+
+	bucketValue, _ := ctx.GetString(constants.ContextKeyTraceID)
+	variant := f.Pick(bucketValue)
+	if ctx.Writer != nil {
+		http.SetCookie(ctx.Writer, &http.Cookie{
+			Name:     f.CookieName,
+			Value:    variant,
+			Path:     "/",
+			MaxAge:   f.CookieMaxAge,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+	return variant
+}
+
+// Pick 按分桶值选择实验组
+// 以实验标识参与哈希，避免不同实验总是把同一批客户端分到相同位置的实验组
+// 参数:
+// - bucketValue: 分桶值，相同分桶值在实验组和权重不变时结果稳定
+// 返回值:
+// - string: 实验组名称
+func (f *ABTestFilter) Pick(bucketValue string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(f.Experiment))
+	_, _ = hash.Write([]byte{':'})
+	_, _ = hash.Write([]byte(bucketValue))
+	point := int(hash.Sum32() % uint32(f.totalWeight))
+
+	for _, variant := range f.Variants {
+		if point < variant.Weight {
+			return variant.Name
+		}
+		point -= variant.Weight
+	}
+	return f.Variants[len(f.Variants)-1].Name
+}
+
+// isActiveVariant 判断实验组是否存在且权重大于0
+func (f *ABTestFilter) isActiveVariant(name string) bool {
+	for _, variant := range f.Variants {
+		if variant.Name == name {
+			return variant.Weight > 0
+		}
+	}
+	return false
+}
+
+// RecordABTestAssignment 记录请求的实验分组结果，供访问日志使用
+func RecordABTestAssignment(ctx *core.Context, experiment, variant string) {
+	value, _ := ctx.Get(constants.ContextKeyABTestAssignments)
+	assignments, ok := value.(map[string]string)
+	if !ok {
+		assignments = make(map[string]string)
+		ctx.Set(constants.ContextKeyABTestAssignments, assignments)
+	}
+	assignments[experiment] = variant
+}
+
+// ABTestAssignments 获取请求中记录的实验分组结果，键为实验标识，值为实验组，未记录时返回nil
+func ABTestAssignments(ctx *core.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	value, exists := ctx.Get(constants.ContextKeyABTestAssignments)
+	if !exists {
+		return nil
+	}
+	assignments, _ := value.(map[string]string)
+	if len(assignments) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(assignments))
+	for experiment, variant := range assignments {
+		result[experiment] = variant
+	}
+	return result
+}
+
+// configureABTestFilter 配置A/B测试过滤器
+func configureABTestFilter(abTestFilter *ABTestFilter, config map[string]interface{}) error {
+	if config == nil {
+		return fmt.Errorf("experiment 不能为空")
+	}
+
+	// 首先检查是否有嵌套的 abTestConfig 配置
+	abTestConfig := config
+	if nestedConfig, ok := config["abTestConfig"].(map[string]interface{}); ok {
+		abTestConfig = nestedConfig
+	}
+
+	experiment, _ := abTestConfig["experiment"].(string)
+	experiment = strings.TrimSpace(experiment)
+	if experiment == "" {
+		return fmt.Errorf("experiment 不能为空")
+	}
+	if !experimentNamePattern.MatchString(experiment) {
+		return fmt.Errorf("无效的experiment: %s，只允许字母、数字、下划线和中划线", experiment)
+	}
+	abTestFilter.Experiment = experiment
+	abTestFilter.CookieName = DefaultABTestCookiePrefix + experiment
+
+	variants, err := parseABTestVariants(abTestConfig["variants"])
+	if err != nil {
+		return err
+	}
+	abTestFilter.Variants = variants
+	for _, variant := range variants {
+		abTestFilter.totalWeight += variant.Weight
+	}
+	if abTestFilter.totalWeight <= 0 {
+		return fmt.Errorf("variants 的权重之和必须大于0")
+	}
+
+	if bucketBy, ok := abTestConfig["bucketBy"].(string); ok && bucketBy != "" {
+		switch ABTestBucketBy(strings.ToLower(bucketBy)) {
+		case ABTestBucketByCookie:
+			abTestFilter.BucketBy = ABTestBucketByCookie
+		case ABTestBucketByHeader:
+			abTestFilter.BucketBy = ABTestBucketByHeader
+		default:
+			return fmt.Errorf("无效的bucketBy: %s，支持的类型: cookie, header", bucketBy)
+		}
+	}
+	if bucketKey, ok := abTestConfig["bucketKey"].(string); ok {
+		abTestFilter.BucketKey = strings.TrimSpace(bucketKey)
+	}
+	if abTestFilter.BucketBy == ABTestBucketByHeader && abTestFilter.BucketKey == "" {
+		return fmt.Errorf("按请求头分桶时 bucketKey 不能为空")
+	}
+
+	if header, ok := abTestConfig["variantHeader"].(string); ok && strings.TrimSpace(header) != "" {
+		abTestFilter.VariantHeader = strings.TrimSpace(header)
+	}
+	if cookieName, ok := abTestConfig["cookieName"].(string); ok && strings.TrimSpace(cookieName) != "" {
+		abTestFilter.CookieName = strings.TrimSpace(cookieName)
+	}
+	if maxAge, ok := toInt(abTestConfig["cookieMaxAge"]); ok && maxAge > 0 {
+		abTestFilter.CookieMaxAge = maxAge
+	}
+
+	return nil
+}
+
+// parseABTestVariants 解析实验组配置
+// 支持对象列表 [{"name":"A","weight":50}] 和名称列表 ["A","B"]，未配置权重时权重为1
+func parseABTestVariants(value interface{}) ([]ABTestVariant, error) {
+	items, ok := value.([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("variants 不能为空")
+	}
+
+	variants := make([]ABTestVariant, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		variant := ABTestVariant{Weight: 1}
+		switch v := item.(type) {
+		case string:
+			variant.Name = v
+		case map[string]interface{}:
+			variant.Name, _ = v["name"].(string)
+			if weight, ok := toInt(v["weight"]); ok {
+				variant.Weight = weight
+			}
+		default:
+			return nil, fmt.Errorf("variants[%d] 格式无效", i)
+		}
+
+		variant.Name = strings.TrimSpace(variant.Name)
+		if variant.Name == "" {
+			return nil, fmt.Errorf("variants[%d] 的 name 不能为空", i)
+		}
+		if seen[variant.Name] {
+			return nil, fmt.Errorf("实验组名称重复: %s", variant.Name)
+		}
+		if variant.Weight < 0 {
+			return nil, fmt.Errorf("实验组 %s 的权重不能为负数", variant.Name)
+		}
+		seen[variant.Name] = true
+		variants = append(variants, variant)
+	}
+	return variants, nil
+}
+
+// toInt 兼容JSON解析出的float64和YAML解析出的int
+func toInt(value interface{}) (int, bool) {
+	switch n := value.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
@@ -55,6 +55,12 @@ func (f *FilterFactory) CreateFilter(config FilterConfig) (Filter, error) {
 		return CookieFilterFromConfig(config)
 	case ResponseFilterType:
 		return ResponseFilterFromConfig(config)
+	case ABTestFilterType:
+		return ABTestFilterFromConfig(config)
+	case FaultInjectionFilterType:
+		return FaultInjectionFilterFromConfig(config)
+	case VariableFilterType:
+		return VariableFilterFromConfig(config)
 	default:
 		return nil, fmt.Errorf("不支持的过滤器类型: %s", config.Type)
 	}
@@ -94,21 +100,27 @@ func GetSupportedFilterTypes() []FilterType {
 		MethodFilterType,
 		CookieFilterType,
 		ResponseFilterType,
+		ABTestFilterType,
+		FaultInjectionFilterType,
+		VariableFilterType,
 	}
 }
 
 // GetFilterTypeDescription 获取过滤器类型描述
 func GetFilterTypeDescription(filterType FilterType) string {
 	descriptions := map[FilterType]string{
-		HeaderFilterType:     "请求头/响应头过滤器",
-		QueryParamFilterType: "查询参数过滤器",
-		URLFilterType:        "URL路径过滤器（通用）",
-		StripFilterType:      "前缀剥离过滤器",
-		RewriteFilterType:    "路径重写过滤器",
-		BodyFilterType:       "请求体过滤器",
-		MethodFilterType:     "HTTP方法过滤器",
-		CookieFilterType:     "Cookie过滤器",
-		ResponseFilterType:   "响应过滤器",
+		HeaderFilterType:         "请求头/响应头过滤器",
+		QueryParamFilterType:     "查询参数过滤器",
+		URLFilterType:            "URL路径过滤器（通用）",
+		StripFilterType:          "前缀剥离过滤器",
+		RewriteFilterType:        "路径重写过滤器",
+		BodyFilterType:           "请求体过滤器",
+		MethodFilterType:         "HTTP方法过滤器",
+		CookieFilterType:         "Cookie过滤器",
+		ResponseFilterType:       "响应过滤器",
+		ABTestFilterType:         "A/B测试分组过滤器",
+		FaultInjectionFilterType: "故障注入过滤器",
+		VariableFilterType:       "请求变量过滤器",
 	}
 
 	if desc, exists := descriptions[filterType]; exists {
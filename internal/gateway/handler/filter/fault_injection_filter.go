@@ -0,0 +1,325 @@
+package filter
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+	"gateway/pkg/config"
+)
+
+// FaultInjectionEnabledKey 故障注入总开关配置键
+// 故障注入只用于测试环境的混沌实验，总开关默认关闭，关闭时所有故障注入过滤器都不生效
+const FaultInjectionEnabledKey = "app.gateway.fault_injection.enabled"
+
+// FaultCorruptMode 响应损坏方式
+type FaultCorruptMode string
+
+const (
+	// CorruptGarble 篡改响应体内容，保持长度不变
+	CorruptGarble FaultCorruptMode = "garble"
+
+	// CorruptTruncate 截断响应体，只返回前若干字节
+	CorruptTruncate FaultCorruptMode = "truncate"
+)
+
+// 故障注入过滤器默认值和上限
+const (
+	defaultFaultAbortStatus   = http.StatusServiceUnavailable
+	defaultFaultAbortMessage  = "Fault injected"
+	defaultFaultTruncateBytes = 64
+	maxFaultDelay             = 5 * time.Minute
+)
+
+// FaultDelay 延迟故障
+type FaultDelay struct {
+	// 注入比例，0-100
+	Percent float64
+
+	// 延迟时间
+	Duration time.Duration
+}
+
+// FaultAbort 中止故障，直接返回指定状态码，不转发给后端
+type FaultAbort struct {
+	// 注入比例，0-100
+	Percent float64
+
+	// 返回的状态码
+	StatusCode int
+
+	// 返回的错误消息
+	Message string
+}
+
+// FaultCorrupt 响应损坏故障
+type FaultCorrupt struct {
+	// 注入比例，0-100
+	Percent float64
+
+	// 损坏方式
+	Mode FaultCorruptMode
+
+	// 截断时保留的字节数
+	TruncateBytes int
+}
+
+// FaultInjectionFilter 故障注入过滤器
+// 按比例为请求注入延迟、直接返回错误状态码或损坏后端响应，用于在测试环境对调用方进行混沌实验；
+// 只有总开关 app.gateway.fault_injection.enabled 开启时才生效，三类故障按各自比例独立判定
+type FaultInjectionFilter struct {
+	BaseFilter
+
+	// 延迟故障，未配置时为nil
+	Delay *FaultDelay
+
+	// 中止故障，未配置时为nil
+	Abort *FaultAbort
+
+	// 响应损坏故障，未配置时为nil
+	Corrupt *FaultCorrupt
+
+	// 随机数函数，返回[0,1)，测试时可替换
+	random func() float64
+}
+
+// FaultInjectionFilterFromConfig 从配置创建故障注入过滤器
+func FaultInjectionFilterFromConfig(config FilterConfig) (Filter, error) {
+	action := getFilterActionFromConfig(config)
+
+	// 使用配置中的order字段，如果没有则使用默认值100
+	order := config.Order
+	if order <= 0 {
+		order = 100
+	}
+
+	faultFilter := NewFaultInjectionFilter(config.Name, action, order)
+	faultFilter.originalConfig = config
+
+	if err := configureFaultInjectionFilter(faultFilter, config.Config); err != nil {
+		return nil, fmt.Errorf("配置故障注入过滤器失败: %w", err)
+	}
+
+	return faultFilter, nil
+}
+
+// NewFaultInjectionFilter 创建故障注入过滤器
+func NewFaultInjectionFilter(name string, action FilterAction, priority int) *FaultInjectionFilter {
+	baseFilter := NewBaseFilter(FaultInjectionFilterType, action, priority, true, name)
+	return &FaultInjectionFilter{
+		BaseFilter: *baseFilter,
+		random:     rand.Float64,
+	}
+}
+
+// SetRandom 设置随机数函数，返回值范围[0,1)
+func (f *FaultInjectionFilter) SetRandom(random func() float64) *FaultInjectionFilter {
+	f.random = random
+	return f
+}
+
+// Apply 实现Filter接口
+func (f *FaultInjectionFilter) Apply(ctx *core.Context) error {
+	if ctx.Request == nil {
+		return fmt.Errorf("request is nil")
+	}
+	if !config.GetBool(FaultInjectionEnabledKey, false) {
+		return nil
+	}
+
+	var injected []string
+
+	if f.Delay != nil && f.hit(f.Delay.Percent) {
+		injected = append(injected, "delay="+f.Delay.Duration.String())
+		ctx.Set(constants.ContextKeyFaultInjected, strings.Join(injected, ","))
+		timer := time.NewTimer(f.Delay.Duration)
+		select {
+		case <-timer.C:
+		case <-ctx.Request.Context().Done():
+			timer.Stop()
+			return fmt.Errorf("故障注入延迟期间请求已取消: %w", ctx.Request.Context().Err())
+		}
+	}
+
+	if f.Abort != nil && f.hit(f.Abort.Percent) {
+		injected = append(injected, fmt.Sprintf("abort=%d", f.Abort.StatusCode))
+		ctx.Set(constants.ContextKeyFaultInjected, strings.Join(injected, ","))
+		ctx.Abort(f.Abort.StatusCode, map[string]string{
+			"error":      f.Abort.Message,
+			"error_code": constants.ErrorCodeFaultInjected,
+		})
+		return fmt.Errorf("故障注入中止请求，状态码: %d", f.Abort.StatusCode)
+	}
+
+	// WebSocket升级请求需要劫持原始连接，不包装响应写入器
+	if f.Corrupt != nil && !strings.EqualFold(ctx.Request.Header.Get("Upgrade"), "websocket") && f.hit(f.Corrupt.Percent) {
+		injected = append(injected, "corrupt="+string(f.Corrupt.Mode))
+		ctx.Set(constants.ContextKeyFaultInjected, strings.Join(injected, ","))
+		ctx.Writer = &corruptingWriter{ResponseWriter: ctx.Writer, corrupt: f.Corrupt}
+	}
+
+	return nil
+}
+
+// hit 按比例判定是否注入
+func (f *FaultInjectionFilter) hit(percent float64) bool {
+	if percent <= 0 {
+		return false
+	}
+	if percent >= 100 {
+		return true
+	}
+	return f.random()*100 < percent
+}
+
+// corruptingWriter 损坏响应体的写入器
+type corruptingWriter struct {
+	http.ResponseWriter
+	corrupt *FaultCorrupt
+	written int
+}
+
+// WriteHeader 截断时删除Content-Length，保证截断后的响应仍是合法的HTTP响应
+func (w *corruptingWriter) WriteHeader(statusCode int) {
+	if w.corrupt.Mode == CorruptTruncate {
+		w.Header().Del("Content-Length")
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write 按损坏方式写入响应体，返回值始终为原始数据长度，避免上游拷贝中断
+func (w *corruptingWriter) Write(data []byte) (int, error) {
+	switch w.corrupt.Mode {
+	case CorruptTruncate:
+		if w.written == 0 {
+			w.Header().Del("Content-Length")
+		}
+		remaining := w.corrupt.TruncateBytes - w.written
+		w.written += len(data)
+		if remaining <= 0 {
+			return len(data), nil
+		}
+		if len(data) > remaining {
+			if _, err := w.ResponseWriter.Write(data[:remaining]); err != nil {
+				return 0, err
+			}
+			return len(data), nil
+		}
+		return w.ResponseWriter.Write(data)
+	default:
+		// 每8个字节翻转一个字节，长度不变但内容无法正常解析
+		garbled := make([]byte, len(data))
+		copy(garbled, data)
+		for i := range garbled {
+			if (w.written+i)%8 == 0 {
+				garbled[i] ^= 0xFF
+			}
+		}
+		w.written += len(data)
+		return w.ResponseWriter.Write(garbled)
+	}
+}
+
+// Unwrap 返回原始写入器，供 http.ResponseController 刷新和设置超时
+func (w *corruptingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// configureFaultInjectionFilter 配置故障注入过滤器
+func configureFaultInjectionFilter(faultFilter *FaultInjectionFilter, config map[string]interface{}) error {
+	if config == nil {
+		return fmt.Errorf("至少需要配置 delay、abort、corrupt 中的一种故障")
+	}
+
+	// 首先检查是否有嵌套的 faultConfig 配置
+	faultConfig := config
+	if nestedConfig, ok := config["faultConfig"].(map[string]interface{}); ok {
+		faultConfig = nestedConfig
+	}
+
+	if delayConfig, ok := faultConfig["delay"].(map[string]interface{}); ok {
+		percent, err := faultPercent("delay", delayConfig)
+		if err != nil {
+			return err
+		}
+		durationMs, _ := toInt(delayConfig["durationMs"])
+		duration := time.Duration(durationMs) * time.Millisecond
+		if duration <= 0 || duration > maxFaultDelay {
+			return fmt.Errorf("delay.durationMs 必须在 1 到 %d 之间", maxFaultDelay.Milliseconds())
+		}
+		faultFilter.Delay = &FaultDelay{Percent: percent, Duration: duration}
+	}
+
+	if abortConfig, ok := faultConfig["abort"].(map[string]interface{}); ok {
+		percent, err := faultPercent("abort", abortConfig)
+		if err != nil {
+			return err
+		}
+		abort := &FaultAbort{Percent: percent, StatusCode: defaultFaultAbortStatus, Message: defaultFaultAbortMessage}
+		if statusCode, ok := toInt(abortConfig["statusCode"]); ok {
+			if statusCode < 400 || statusCode > 599 {
+				return fmt.Errorf("abort.statusCode 必须在 400 到 599 之间")
+			}
+			abort.StatusCode = statusCode
+		}
+		if message, ok := abortConfig["message"].(string); ok && message != "" {
+			abort.Message = message
+		}
+		faultFilter.Abort = abort
+	}
+
+	if corruptConfig, ok := faultConfig["corrupt"].(map[string]interface{}); ok {
+		percent, err := faultPercent("corrupt", corruptConfig)
+		if err != nil {
+			return err
+		}
+		corrupt := &FaultCorrupt{Percent: percent, Mode: CorruptGarble, TruncateBytes: defaultFaultTruncateBytes}
+		if mode, ok := corruptConfig["mode"].(string); ok && mode != "" {
+			switch FaultCorruptMode(strings.ToLower(mode)) {
+			case CorruptGarble:
+				corrupt.Mode = CorruptGarble
+			case CorruptTruncate:
+				corrupt.Mode = CorruptTruncate
+			default:
+				return fmt.Errorf("无效的corrupt.mode: %s，支持的类型: garble, truncate", mode)
+			}
+		}
+		if truncateBytes, ok := toInt(corruptConfig["truncateBytes"]); ok {
+			if truncateBytes < 0 {
+				return fmt.Errorf("corrupt.truncateBytes 不能为负数")
+			}
+			corrupt.TruncateBytes = truncateBytes
+		}
+		faultFilter.Corrupt = corrupt
+	}
+
+	if faultFilter.Delay == nil && faultFilter.Abort == nil && faultFilter.Corrupt == nil {
+		return fmt.Errorf("至少需要配置 delay、abort、corrupt 中的一种故障")
+	}
+	return nil
+}
+
+// faultPercent 读取并校验注入比例
+func faultPercent(name string, config map[string]interface{}) (float64, error) {
+	var percent float64
+	switch v := config["percent"].(type) {
+	case float64:
+		percent = v
+	case int:
+		percent = float64(v)
+	case int64:
+		percent = float64(v)
+	case nil:
+		return 0, fmt.Errorf("%s.percent 不能为空", name)
+	default:
+		return 0, fmt.Errorf("%s.percent 格式无效", name)
+	}
+	if percent < 0 || percent > 100 {
+		return 0, fmt.Errorf("%s.percent 必须在 0 到 100 之间", name)
+	}
+	return percent, nil
+}
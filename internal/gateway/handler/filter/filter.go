@@ -1,7 +1,10 @@
 package filter
 
 import (
+	"strings"
+
 	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/featureflag"
 )
 
 // FilterType 过滤器类型
@@ -44,6 +47,18 @@ const (
 	// ResponseFilterType 响应过滤器
 	// 用于修改响应体内容
 	ResponseFilterType FilterType = "response"
+
+	// ABTestFilterType A/B测试过滤器
+	// 用于把客户端分配到实验组并转发实验组给后端
+	ABTestFilterType FilterType = "ab-test"
+
+	// FaultInjectionFilterType 故障注入过滤器
+	// 用于混沌实验，按比例注入延迟、中止请求或损坏响应
+	FaultInjectionFilterType FilterType = "fault-injection"
+
+	// VariableFilterType 请求变量过滤器
+	// 用于按模板设置请求变量，供后续过滤器和访问日志通过 ${ctx.名称} 读取
+	VariableFilterType FilterType = "variable"
 )
 
 // FilterAction 过滤器执行时机
@@ -64,6 +79,10 @@ const (
 	PreResponse FilterAction = "pre-response"
 )
 
+// FeatureFlagConfigKey 过滤器配置中关联功能开关的键
+// 配置后过滤器仅在开关对当前请求开启时执行，用于按比例灰度新的过滤规则
+const FeatureFlagConfigKey = "featureFlag"
+
 // FilterConfig 过滤器配置结构
 type FilterConfig struct {
 	ID      string                 `yaml:"id" json:"id" mapstructure:"id"`
@@ -196,3 +215,16 @@ func NewBaseFilter(filterType FilterType, action FilterAction, priority int, ena
 		originalConfig: config,
 	}
 }
+
+// ShouldApply 判断过滤器是否对当前请求执行
+// 过滤器需处于启用状态；配置了功能开关时，开关还需对当前请求开启
+func ShouldApply(ctx *core.Context, f Filter) bool {
+	if !f.IsEnabled() {
+		return false
+	}
+	flagKey, _ := f.GetConfig().Config[FeatureFlagConfigKey].(string)
+	if flagKey = strings.TrimSpace(flagKey); flagKey == "" {
+		return true
+	}
+	return featureflag.Enabled(ctx, flagKey)
+}
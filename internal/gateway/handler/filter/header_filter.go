@@ -35,6 +35,7 @@ type HeaderFilter struct {
 	HeaderName string
 
 	// 头部值
+	// 支持 ${ctx.user_id}、${header.X-Tenant-Id} 等模板变量，应用时按当前请求展开
 	HeaderValue string
 
 	// 目标头部名称（用于RenameHeader）
@@ -145,10 +146,10 @@ func (f *HeaderFilter) applyToRequest(ctx *core.Context) error {
 	switch f.ModifierType {
 	case AddHeader:
 		// 添加头部（不替换已有值）
-		req.Header.Add(f.HeaderName, f.HeaderValue)
+		req.Header.Add(f.HeaderName, ctx.ExpandVars(f.HeaderValue))
 	case SetHeader:
 		// 设置头部（替换已有值）
-		req.Header.Set(f.HeaderName, f.HeaderValue)
+		req.Header.Set(f.HeaderName, ctx.ExpandVars(f.HeaderValue))
 	case RemoveHeader:
 		// 移除头部
 		req.Header.Del(f.HeaderName)
@@ -189,10 +190,10 @@ func (f *HeaderFilter) applyToResponse(ctx *core.Context) error {
 	switch f.ModifierType {
 	case AddHeader:
 		// 添加头部（不替换已有值）
-		header[f.HeaderName] = append(header[f.HeaderName], f.HeaderValue)
+		header[f.HeaderName] = append(header[f.HeaderName], ctx.ExpandVars(f.HeaderValue))
 	case SetHeader:
 		// 设置头部（替换已有值）
-		header[f.HeaderName] = []string{f.HeaderValue}
+		header[f.HeaderName] = []string{ctx.ExpandVars(f.HeaderValue)}
 	case RemoveHeader:
 		// 移除头部
 		delete(header, f.HeaderName)
@@ -35,6 +35,7 @@ type QueryParamFilter struct {
 	ParamName string
 
 	// 参数值
+	// 支持 ${ctx.名称} 等模板变量，应用时按当前请求展开
 	ParamValue string
 
 	// 目标参数名称（用于RenameQueryParam）
@@ -114,18 +115,21 @@ func (f *QueryParamFilter) Apply(ctx *core.Context) error {
 	// 解析查询参数
 	query := req.URL.Query()
 
+	// 展开参数值中的模板变量
+	paramValue := ctx.ExpandVars(f.ParamValue)
+
 	// 修改查询参数
 	switch f.ModifierType {
 	case AddQueryParam:
 		// 添加查询参数（不替换已有值）
-		query.Add(f.ParamName, f.ParamValue)
+		query.Add(f.ParamName, paramValue)
 	case SetQueryParam:
 		// 设置查询参数（只对已存在的参数替换值）
 		if _, exists := query[f.ParamName]; !exists {
 			// 参数不存在，跳过设置操作
 			return nil
 		}
-		query.Set(f.ParamName, f.ParamValue)
+		query.Set(f.ParamName, paramValue)
 	case RemoveQueryParam:
 		// 移除查询参数（只对已存在的参数执行删除）
 		if _, exists := query[f.ParamName]; !exists {
@@ -150,7 +154,7 @@ func (f *QueryParamFilter) Apply(ctx *core.Context) error {
 		// 设置新参数
 		if f.ParamValue != "" {
 			// 如果提供了新值，使用新值
-			query.Set(f.TargetParamName, f.ParamValue)
+			query.Set(f.TargetParamName, paramValue)
 		} else {
 			// 复制原有值到新的参数名
 			for _, value := range values {
@@ -160,7 +164,7 @@ func (f *QueryParamFilter) Apply(ctx *core.Context) error {
 
 		// 支持同名参数修改（当目标参数名与源参数名相同时）
 		if f.TargetParamName == f.ParamName && f.ParamValue != "" {
-			query.Set(f.ParamName, f.ParamValue)
+			query.Set(f.ParamName, paramValue)
 		}
 	}
 
@@ -0,0 +1,123 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gateway/internal/gateway/core"
+)
+
+// variableNamePattern 变量名只允许字母、数字、下划线、中划线和点，保证可以在 ${ctx.名称} 模板中引用
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// VariableFilter 请求变量过滤器
+// 按模板计算变量值并写入请求上下文，后续过滤器的头部/查询参数值和访问日志可以通过 ${ctx.名称} 读取，
+// 例如把认证得到的用户ID与租户请求头组合成一个变量，再由头部过滤器转发给后端
+type VariableFilter struct {
+	BaseFilter
+
+	// 变量定义，键为变量名，值为模板，如 {"caller": "${ctx.user_id:-anonymous}@${header.X-Tenant-Id}"}
+	Variables map[string]string
+
+	// 已存在同名变量时是否保留原值
+	KeepExisting bool
+
+	// 按名称排序的变量名，保证执行顺序稳定
+	names []string
+}
+
+// VariableFilterFromConfig 从配置创建请求变量过滤器
+func VariableFilterFromConfig(config FilterConfig) (Filter, error) {
+	action := getFilterActionFromConfig(config)
+
+	// 使用配置中的order字段，如果没有则使用默认值100
+	order := config.Order
+	if order <= 0 {
+		order = 100
+	}
+
+	variableFilter := NewVariableFilter(config.Name, action, order)
+	variableFilter.originalConfig = config
+
+	if err := configureVariableFilter(variableFilter, config.Config); err != nil {
+		return nil, fmt.Errorf("配置请求变量过滤器失败: %w", err)
+	}
+
+	return variableFilter, nil
+}
+
+// NewVariableFilter 创建请求变量过滤器
+func NewVariableFilter(name string, action FilterAction, priority int) *VariableFilter {
+	baseFilter := NewBaseFilter(VariableFilterType, action, priority, true, name)
+	return &VariableFilter{
+		BaseFilter: *baseFilter,
+		Variables:  make(map[string]string),
+	}
+}
+
+// SetVariable 添加变量定义
+func (f *VariableFilter) SetVariable(name, template string) *VariableFilter {
+	if _, exists := f.Variables[name]; !exists {
+		f.names = append(f.names, name)
+		sort.Strings(f.names)
+	}
+	f.Variables[name] = template
+	return f
+}
+
+// Apply 实现Filter接口
+// 同一过滤器内的模板先全部展开再写入，模板之间不能互相引用；需要引用时拆成多个过滤器按顺序执行
+func (f *VariableFilter) Apply(ctx *core.Context) error {
+	values := make(map[string]string, len(f.names))
+	for _, name := range f.names {
+		if f.KeepExisting {
+			if _, exists := ctx.GetVar(name); exists {
+				continue
+			}
+		}
+		values[name] = ctx.ExpandVars(f.Variables[name])
+	}
+	for _, name := range f.names {
+		if value, ok := values[name]; ok {
+			ctx.SetVar(name, value)
+		}
+	}
+	return nil
+}
+
+// configureVariableFilter 配置请求变量过滤器
+func configureVariableFilter(variableFilter *VariableFilter, config map[string]interface{}) error {
+	if config == nil {
+		return fmt.Errorf("variables 不能为空")
+	}
+
+	// 首先检查是否有嵌套的 variableConfig 配置
+	variableConfig := config
+	if nestedConfig, ok := config["variableConfig"].(map[string]interface{}); ok {
+		variableConfig = nestedConfig
+	}
+
+	variables, ok := variableConfig["variables"].(map[string]interface{})
+	if !ok || len(variables) == 0 {
+		return fmt.Errorf("variables 不能为空")
+	}
+	for name, value := range variables {
+		name = strings.TrimSpace(name)
+		if !variableNamePattern.MatchString(name) {
+			return fmt.Errorf("无效的变量名: %s，只允许字母、数字、下划线、中划线和点", name)
+		}
+		template, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("变量 %s 的值必须是字符串模板", name)
+		}
+		variableFilter.SetVariable(name, template)
+	}
+
+	if keepExisting, ok := variableConfig["keepExisting"].(bool); ok {
+		variableFilter.KeepExisting = keepExisting
+	}
+
+	return nil
+}
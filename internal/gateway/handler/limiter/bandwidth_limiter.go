@@ -0,0 +1,262 @@
+package limiter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gateway/internal/gateway/core"
+)
+
+// bandwidthChunkSize 单次读写的最大字节数，避免大块写入一次性透支令牌后长时间阻塞
+const bandwidthChunkSize = 32 * 1024
+
+// bandwidthCleanupInterval 清理空闲字节桶的间隔
+const bandwidthCleanupInterval = time.Minute
+
+// BandwidthConfig 带宽限制配置
+// 按限流键（KeyStrategy）分别限制请求体上传和响应体下载的字节速率，0表示该方向不限制
+type BandwidthConfig struct {
+	UploadRate   int64 `yaml:"upload_rate,omitempty" json:"upload_rate,omitempty" mapstructure:"upload_rate,omitempty"`       // 上传速率（字节/秒）
+	DownloadRate int64 `yaml:"download_rate,omitempty" json:"download_rate,omitempty" mapstructure:"download_rate,omitempty"` // 下载速率（字节/秒）
+	Burst        int64 `yaml:"burst,omitempty" json:"burst,omitempty" mapstructure:"burst,omitempty"`                         // 突发字节数，默认等于1秒的速率
+}
+
+// IsActive 是否配置了任一方向的带宽限制
+func (c *BandwidthConfig) IsActive() bool {
+	return c != nil && (c.UploadRate > 0 || c.DownloadRate > 0)
+}
+
+// ParseBandwidthConfig 从限流自定义配置中解析带宽限制
+// 支持的键: uploadBytesPerSecond、downloadBytesPerSecond、bandwidthBurstBytes
+// 未配置任一方向速率时返回nil
+func ParseBandwidthConfig(customConfig map[string]interface{}) *BandwidthConfig {
+	config := &BandwidthConfig{
+		UploadRate:   customConfigInt64(customConfig, "uploadBytesPerSecond"),
+		DownloadRate: customConfigInt64(customConfig, "downloadBytesPerSecond"),
+		Burst:        customConfigInt64(customConfig, "bandwidthBurstBytes"),
+	}
+	if !config.IsActive() {
+		return nil
+	}
+	return config
+}
+
+// customConfigInt64 读取自定义配置中的整数值，JSON数字和数字字符串均可
+func customConfigInt64(customConfig map[string]interface{}, key string) int64 {
+	switch value := customConfig[key].(type) {
+	case float64:
+		return int64(value)
+	case int:
+		return int64(value)
+	case int64:
+		return value
+	case string:
+		parsed, _ := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		return parsed
+	default:
+		return 0
+	}
+}
+
+// BandwidthLimiter 带宽限制器
+//
+// 使用按字节计量的令牌桶限制单个客户端（由限流键区分）的上传和下载速率，
+// 避免单个客户端下载大文件时占满网关实例的出口带宽。
+// 与请求数限流不同，带宽限制不拒绝请求，而是在读取请求体和写入响应体时等待令牌，
+// 同一限流键下的并发请求共享同一个字节桶。
+type BandwidthLimiter struct {
+	config       *BandwidthConfig
+	keyExtractor KeyExtractorFunc
+	mu           sync.Mutex
+	upload       map[string]*byteBucket // 限流键到上传字节桶的映射
+	download     map[string]*byteBucket // 限流键到下载字节桶的映射
+	lastCleanup  time.Time
+}
+
+// byteBucket 字节令牌桶
+// 令牌允许透支：先预留字节再按欠额等待，保证并发读写按到达顺序排队
+type byteBucket struct {
+	rate       float64   // 每秒填充字节数
+	capacity   float64   // 桶容量（突发字节数）
+	tokens     float64   // 当前令牌数，可为负数表示已透支
+	lastUpdate time.Time // 上次更新时间
+}
+
+// NewBandwidthLimiter 创建带宽限制器
+//
+// 参数：
+//   - config: 带宽限制配置，必须至少配置一个方向的速率
+//   - keyStrategy: 限流键策略（ip/user/api_key/route等），为空时按IP
+//
+// 返回：
+//   - *BandwidthLimiter: 带宽限制器，配置无效时返回nil
+func NewBandwidthLimiter(config *BandwidthConfig, keyStrategy string) *BandwidthLimiter {
+	if !config.IsActive() {
+		return nil
+	}
+	return &BandwidthLimiter{
+		config:       config,
+		keyExtractor: GetKeyExtractor(keyStrategy),
+		upload:       make(map[string]*byteBucket),
+		download:     make(map[string]*byteBucket),
+		lastCleanup:  time.Now(),
+	}
+}
+
+// Apply 为请求包装限速的请求体和响应写入器
+// WebSocket升级请求需要劫持原始连接，不做包装
+//
+// 上下文设置：
+//   - bandwidth_limit_key: 带宽限制键
+func (l *BandwidthLimiter) Apply(ctx *core.Context) {
+	if strings.EqualFold(ctx.Request.Header.Get("Upgrade"), "websocket") {
+		return
+	}
+
+	key := l.keyExtractor(ctx)
+	requestCtx := ctx.Request.Context()
+	if l.config.UploadRate > 0 && ctx.Request.Body != nil && ctx.Request.Body != http.NoBody {
+		ctx.Request.Body = &throttledReader{
+			ReadCloser: ctx.Request.Body,
+			wait: func(n int) error {
+				return l.wait(requestCtx, l.upload, key, l.config.UploadRate, n)
+			},
+		}
+	}
+	if l.config.DownloadRate > 0 {
+		ctx.Writer = &throttledWriter{
+			ResponseWriter: ctx.Writer,
+			wait: func(n int) error {
+				return l.wait(requestCtx, l.download, key, l.config.DownloadRate, n)
+			},
+		}
+	}
+	ctx.Set("bandwidth_limit_key", key)
+}
+
+// wait 从字节桶中预留n字节，令牌不足时等待，请求取消时返回错误
+func (l *BandwidthLimiter) wait(ctx context.Context, buckets map[string]*byteBucket, key string, rate int64, n int) error {
+	delay := l.reserve(buckets, key, rate, n)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve 预留n字节并返回需要等待的时间
+func (l *BandwidthLimiter) reserve(buckets map[string]*byteBucket, key string, rate int64, n int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastCleanup) > bandwidthCleanupInterval {
+		l.cleanupLocked(now)
+	}
+
+	bucket, exists := buckets[key]
+	if !exists {
+		capacity := float64(l.config.Burst)
+		if capacity <= 0 {
+			capacity = float64(rate)
+		}
+		// 新桶初始填满，允许立即发送一个突发量
+		bucket = &byteBucket{
+			rate:       float64(rate),
+			capacity:   capacity,
+			tokens:     capacity,
+			lastUpdate: now,
+		}
+		buckets[key] = bucket
+	}
+
+	elapsed := now.Sub(bucket.lastUpdate).Seconds()
+	bucket.tokens = minFloat64(bucket.capacity, bucket.tokens+elapsed*bucket.rate)
+	bucket.lastUpdate = now
+	bucket.tokens -= float64(n)
+	if bucket.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-bucket.tokens / bucket.rate * float64(time.Second))
+}
+
+// cleanupLocked 删除已回满且长时间未使用的字节桶，调用方需持有锁
+// 回满后删除不影响限速准确性，因为重新创建的桶同样初始填满
+func (l *BandwidthLimiter) cleanupLocked(now time.Time) {
+	for _, buckets := range []map[string]*byteBucket{l.upload, l.download} {
+		for key, bucket := range buckets {
+			fillTime := (bucket.capacity - bucket.tokens) / bucket.rate
+			if now.Sub(bucket.lastUpdate).Seconds() > fillTime+bandwidthCleanupInterval.Seconds() {
+				delete(buckets, key)
+			}
+		}
+	}
+	l.lastCleanup = now
+}
+
+// GetConfig 获取带宽限制配置
+func (l *BandwidthLimiter) GetConfig() *BandwidthConfig {
+	return l.config
+}
+
+// throttledReader 限速读取请求体
+type throttledReader struct {
+	io.ReadCloser
+	wait func(n int) error
+}
+
+// Read 读取后按实际字节数等待令牌
+func (r *throttledReader) Read(p []byte) (int, error) {
+	if len(p) > bandwidthChunkSize {
+		p = p[:bandwidthChunkSize]
+	}
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		if waitErr := r.wait(n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
+
+// throttledWriter 限速写入响应体
+type throttledWriter struct {
+	http.ResponseWriter
+	wait func(n int) error
+}
+
+// Write 分块等待令牌后写入
+func (w *throttledWriter) Write(data []byte) (int, error) {
+	written := 0
+	for written < len(data) {
+		chunk := data[written:]
+		if len(chunk) > bandwidthChunkSize {
+			chunk = chunk[:bandwidthChunkSize]
+		}
+		if err := w.wait(len(chunk)); err != nil {
+			return written, err
+		}
+		n, err := w.ResponseWriter.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// Unwrap 返回原始写入器，供 http.ResponseController 刷新和设置超时
+func (w *throttledWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
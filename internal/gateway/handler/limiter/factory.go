@@ -27,6 +27,8 @@ func (f *LimiterFactory) CreateLimiter(config *RateLimitConfig) (LimiterHandler,
 		return NewSlidingWindowLimiter(config)
 	case AlgorithmLeakyBucket:
 		return NewLeakyBucketLimiter(config)
+	case AlgorithmSpikeArrest:
+		return NewSpikeArrestLimiter(config)
 	case AlgorithmNone:
 		return NewNoneLimiter(config)
 	default:
@@ -44,6 +46,7 @@ func (f *LimiterFactory) GetSupportedAlgorithms() []RateLimitAlgorithm {
 		AlgorithmFixedWindow,
 		AlgorithmSlidingWindow,
 		AlgorithmLeakyBucket,
+		AlgorithmSpikeArrest,
 		AlgorithmNone,
 	}
 }
@@ -55,6 +58,7 @@ func (f *LimiterFactory) GetAlgorithmDescription(algorithm RateLimitAlgorithm) s
 		AlgorithmFixedWindow:   "固定窗口算法，按时间窗口统计",
 		AlgorithmSlidingWindow: "滑动窗口算法，更平滑的限流",
 		AlgorithmLeakyBucket:   "漏桶算法，平滑流量输出",
+		AlgorithmSpikeArrest:   "削峰算法，突发请求短暂排队后放行，超出排队上限时拒绝",
 		AlgorithmNone:          "无限制，不进行任何限制",
 	}
 
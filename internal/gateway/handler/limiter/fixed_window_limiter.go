@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"gateway/internal/gateway/constants"
 	"gateway/internal/gateway/core"
 )
 
@@ -116,7 +117,8 @@ func (f *FixedWindowLimiter) Handle(ctx *core.Context) bool {
 		config := f.GetConfig()
 		ctx.AddError(fmt.Errorf("fixed window rate limit exceeded for key: %s", key))
 		ctx.Abort(config.ErrorStatusCode, map[string]string{
-			"error": config.ErrorMessage,
+			"error":      config.ErrorMessage,
+			"error_code": constants.ErrorCodeRateLimitExceeded,
 		})
 		return false
 	}
@@ -1,6 +1,8 @@
 package limiter
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"net"
 	"strings"
 
@@ -19,6 +21,8 @@ const (
 	AlgorithmTokenBucket RateLimitAlgorithm = "token-bucket"
 	// AlgorithmLeakyBucket 漏桶算法
 	AlgorithmLeakyBucket RateLimitAlgorithm = "leaky-bucket"
+	// AlgorithmSpikeArrest 削峰算法，突发请求排队等待后放行
+	AlgorithmSpikeArrest RateLimitAlgorithm = "spike-arrest"
 	// AlgorithmNone 无限制
 	AlgorithmNone RateLimitAlgorithm = "none"
 )
@@ -66,6 +70,9 @@ type RateLimitConfig struct {
 	ErrorStatusCode int    `yaml:"error_status_code,omitempty" json:"error_status_code,omitempty" mapstructure:"error_status_code,omitempty"` // 限流时返回的HTTP状态码
 	ErrorMessage    string `yaml:"error_message,omitempty" json:"error_message,omitempty" mapstructure:"error_message,omitempty"`             // 限流时返回的错误信息
 
+	// 带宽限制，按 KeyStrategy 分别限制上传和下载字节速率，为空表示不限制
+	Bandwidth *BandwidthConfig `yaml:"bandwidth,omitempty" json:"bandwidth,omitempty" mapstructure:"bandwidth,omitempty"`
+
 	// 扩展配置
 	CustomConfig map[string]interface{} `yaml:"custom_config,omitempty" json:"custom_config,omitempty" mapstructure:"custom_config,omitempty"` // 自定义配置
 }
@@ -136,6 +143,8 @@ func GetKeyExtractor(strategy string) KeyExtractorFunc {
 		return ExtractServiceKey
 	case "route":
 		return ExtractRouteKey
+	case "api_key":
+		return ExtractAPIKeyKey
 	default:
 		return ExtractIPKey
 	}
@@ -191,6 +200,17 @@ func ExtractUserKey(ctx *core.Context) string {
 	return ExtractIPKey(ctx)
 }
 
+// ExtractAPIKeyKey 提取API Key键
+// 使用API Key认证后写入上下文的key，以摘要形式作为限流键，避免明文出现在日志和错误信息中
+// 未经过API Key认证时退回IP键
+func ExtractAPIKeyKey(ctx *core.Context) string {
+	if apiKey, ok := ctx.GetString("api_key"); ok && apiKey != "" {
+		sum := sha256.Sum256([]byte(apiKey))
+		return "apikey:" + hex.EncodeToString(sum[:8])
+	}
+	return ExtractIPKey(ctx)
+}
+
 // ExtractPathKey 提取路径键
 func ExtractPathKey(ctx *core.Context) string {
 	return "path:" + ctx.Request.URL.Path
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"gateway/internal/gateway/constants"
 	"gateway/internal/gateway/core"
 )
 
@@ -134,7 +135,8 @@ func (l *LeakyBucketLimiter) Handle(ctx *core.Context) bool {
 		config := l.GetConfig()
 		ctx.AddError(fmt.Errorf("leaky bucket rate limit exceeded for key: %s", key))
 		ctx.Abort(config.ErrorStatusCode, map[string]string{
-			"error": config.ErrorMessage,
+			"error":      config.ErrorMessage,
+			"error_code": constants.ErrorCodeRateLimitExceeded,
 		})
 		return false
 	}
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"gateway/internal/gateway/constants"
 	"gateway/internal/gateway/core"
 )
 
@@ -128,7 +129,8 @@ func (s *SlidingWindowLimiter) Handle(ctx *core.Context) bool {
 		config := s.GetConfig()
 		ctx.AddError(fmt.Errorf("sliding window rate limit exceeded for key: %s", key))
 		ctx.Abort(config.ErrorStatusCode, map[string]string{
-			"error": config.ErrorMessage,
+			"error":      config.ErrorMessage,
+			"error_code": constants.ErrorCodeRateLimitExceeded,
 		})
 		return false
 	}
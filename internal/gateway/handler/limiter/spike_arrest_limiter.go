@@ -0,0 +1,273 @@
+package limiter
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+)
+
+// 削峰限流器默认值
+const (
+	// DefaultSpikeArrestMaxQueueDelay 默认最大排队等待时间
+	DefaultSpikeArrestMaxQueueDelay = 500 * time.Millisecond
+
+	// maxSpikeArrestQueueDelay 最大排队等待时间上限，避免请求长时间占用连接
+	maxSpikeArrestQueueDelay = 30 * time.Second
+)
+
+// SpikeArrestLimiter 削峰限流器
+//
+// 削峰（Spike Arrest）与普通限流的区别在于：短时间内超出稳定速率的突发请求不会被立即拒绝，
+// 而是先排队等待令牌，在有限的等待时间内按稳定速率放行，只有预计等待时间超过上限时才拒绝。
+// 用于保护无法承受突发流量的老旧后端，把突发流量整形为平滑流量。
+//
+// 算法原理：
+//   - 令牌以固定速率（rate）添加到桶中，桶容量为 burst
+//   - 每个请求预占一个令牌，令牌数允许为负，负数部分表示排队中的请求
+//   - 预占后令牌数为负时，需要等待 -tokens/rate 秒才能轮到当前请求
+//   - 等待时间不超过 maxQueueDelayMs 时排队等待后放行，否则撤销预占并拒绝
+//
+// 示例：
+//
+//	config := &RateLimitConfig{
+//	    Algorithm:    AlgorithmSpikeArrest,
+//	    Rate:         20,                                         // 稳定速率每秒20个请求
+//	    Burst:        1,                                          // 不允许瞬时突发，严格按速率放行
+//	    KeyStrategy:  "route",                                    // 按路由削峰
+//	    CustomConfig: map[string]interface{}{"maxQueueDelayMs": 500}, // 最多排队500毫秒
+//	}
+//	limiter, err := NewSpikeArrestLimiter(config)
+//
+// 注意：
+//   - 排队时间写入上下文，由访问日志记录到 queueTimeMs 字段，并计入网关处理时间
+//   - 排队期间客户端断开连接时，归还预占的令牌，不影响后续请求
+type SpikeArrestLimiter struct {
+	*BaseLimiterHandler
+	buckets       map[string]*spikeBucket // 限流键到令牌桶的映射
+	mu            sync.Mutex              // 保护buckets的互斥锁
+	keyExtractor  KeyExtractorFunc        // 限流键提取函数
+	maxQueueDelay time.Duration           // 最大排队等待时间
+}
+
+// spikeBucket 削峰令牌桶
+//
+// 与 tokenBucket 不同，tokens 允许为负数，表示已预占但尚未轮到的请求数。
+type spikeBucket struct {
+	tokens     float64   // 当前令牌数（-rate*maxQueueDelay <= tokens <= capacity）
+	lastUpdate time.Time // 上次更新时间
+}
+
+// NewSpikeArrestLimiter 创建削峰限流器
+//
+// 参数：
+//   - config: 限流配置，如果为nil则使用默认配置
+//
+// 返回：
+//   - LimiterHandler: 限流处理器实例
+//   - error: 创建过程中的错误
+//
+// 配置说明：
+//   - Rate: 稳定速率，每秒放行的请求数（必须 > 0）
+//   - Burst: 允许立即放行的突发请求数，默认1（不允许突发）
+//   - CustomConfig.maxQueueDelayMs: 最大排队等待时间（毫秒），默认500，0表示不排队
+//   - KeyStrategy、ErrorStatusCode、ErrorMessage: 同其他限流算法
+func NewSpikeArrestLimiter(config *RateLimitConfig) (LimiterHandler, error) {
+	if config == nil {
+		config = &DefaultRateLimitConfig
+	}
+
+	// 应用默认值
+	if config.Rate <= 0 {
+		config.Rate = DefaultRateLimitConfig.Rate
+	}
+	// 削峰的目的是平滑流量，未配置突发容量时不允许瞬时突发
+	if config.Burst <= 0 {
+		config.Burst = 1
+	}
+	if config.KeyStrategy == "" {
+		config.KeyStrategy = DefaultRateLimitConfig.KeyStrategy
+	}
+	if config.ErrorStatusCode == 0 {
+		config.ErrorStatusCode = DefaultRateLimitConfig.ErrorStatusCode
+	}
+	if config.ErrorMessage == "" {
+		config.ErrorMessage = DefaultRateLimitConfig.ErrorMessage
+	}
+
+	maxQueueDelay := DefaultSpikeArrestMaxQueueDelay
+	if _, exists := config.CustomConfig["maxQueueDelayMs"]; exists {
+		maxQueueDelay = time.Duration(customConfigInt64(config.CustomConfig, "maxQueueDelayMs")) * time.Millisecond
+	}
+
+	config.Algorithm = AlgorithmSpikeArrest
+	keyExtractor := GetKeyExtractor(config.KeyStrategy)
+
+	return &SpikeArrestLimiter{
+		BaseLimiterHandler: NewBaseLimiterHandler(config),
+		buckets:            make(map[string]*spikeBucket),
+		keyExtractor:       keyExtractor,
+		maxQueueDelay:      maxQueueDelay,
+	}, nil
+}
+
+// Handle 处理削峰限流
+//
+// 预占令牌后按需排队等待，等待时间超过上限时拒绝请求。
+//
+// 参数：
+//   - ctx: 请求上下文
+//
+// 返回：
+//   - bool: true表示请求通过限流检查，false表示被拒绝
+//
+// 上下文设置：
+//   - rate_limited: 是否被限流（false）
+//   - rate_limit_key: 限流键
+//   - rate_limit_algorithm: 限流算法（"spike-arrest"）
+//   - constants.ContextKeyRateLimitQueueTime: 排队等待时间（仅排队时设置）
+func (s *SpikeArrestLimiter) Handle(ctx *core.Context) bool {
+	if !s.IsEnabled() {
+		return true
+	}
+
+	key := s.keyExtractor(ctx)
+	config := s.GetConfig()
+
+	wait, ok := s.reserve(key, time.Now())
+	if !ok {
+		ctx.AddError(fmt.Errorf("spike arrest queue full for key: %s", key))
+		ctx.Abort(config.ErrorStatusCode, map[string]string{
+			"error":      config.ErrorMessage,
+			"error_code": constants.ErrorCodeRateLimitExceeded,
+		})
+		return false
+	}
+
+	if wait > 0 {
+		start := time.Now()
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Request.Context().Done():
+			// 客户端已断开，归还预占的令牌
+			timer.Stop()
+			s.cancel(key)
+			ctx.Set(constants.ContextKeyRateLimitQueueTime, time.Since(start))
+			ctx.AddError(fmt.Errorf("spike arrest queueing canceled for key: %s: %w", key, ctx.Request.Context().Err()))
+			return false
+		}
+		ctx.Set(constants.ContextKeyRateLimitQueueTime, time.Since(start))
+	}
+
+	ctx.Set("rate_limited", false)
+	ctx.Set("rate_limit_key", key)
+	ctx.Set("rate_limit_algorithm", "spike-arrest")
+
+	return true
+}
+
+// reserve 预占令牌
+//
+// 参数：
+//   - key: 限流键
+//   - now: 当前时间
+//
+// 返回：
+//   - time.Duration: 需要排队等待的时间，0表示可以立即放行
+//   - bool: false表示预计等待时间超过上限，请求应被拒绝（此时不占用令牌）
+func (s *SpikeArrestLimiter) reserve(key string, now time.Time) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	config := s.GetConfig()
+	rate := float64(config.Rate)
+	capacity := float64(config.Burst)
+
+	bucket, exists := s.buckets[key]
+	if !exists {
+		bucket = &spikeBucket{tokens: capacity, lastUpdate: now}
+		s.buckets[key] = bucket
+	} else {
+		elapsed := now.Sub(bucket.lastUpdate).Seconds()
+		bucket.tokens = minFloat64(capacity, bucket.tokens+elapsed*rate)
+		bucket.lastUpdate = now
+	}
+
+	bucket.tokens--
+	if bucket.tokens >= 0 {
+		s.cleanup(now)
+		return 0, true
+	}
+
+	wait := time.Duration(-bucket.tokens / rate * float64(time.Second))
+	if wait > s.maxQueueDelay {
+		// 超出排队上限，撤销预占
+		bucket.tokens++
+		return 0, false
+	}
+	return wait, true
+}
+
+// cancel 归还预占的令牌
+func (s *SpikeArrestLimiter) cancel(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if bucket, exists := s.buckets[key]; exists {
+		bucket.tokens = minFloat64(float64(s.GetConfig().Burst), bucket.tokens+1)
+	}
+}
+
+// cleanup 清理长时间未使用的令牌桶
+//
+// 桶数量较多时才清理，清理阈值为 max(60秒, 2 * (capacity / rate))，
+// 超过阈值未使用的桶早已填满，删除后重新创建的桶状态相同。
+// 调用方必须持有锁。
+func (s *SpikeArrestLimiter) cleanup(now time.Time) {
+	if len(s.buckets) < 1024 {
+		return
+	}
+
+	config := s.GetConfig()
+	threshold := 60 * time.Second
+	if fillTime := time.Duration(float64(config.Burst) / float64(config.Rate) * float64(time.Second)); fillTime*2 > threshold {
+		threshold = fillTime * 2
+	}
+	for key, bucket := range s.buckets {
+		if now.Sub(bucket.lastUpdate) > threshold {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// Validate 验证配置
+//
+// 验证规则：
+//   - Rate必须大于0
+//   - Burst不能为负数
+//   - 最大排队等待时间在0到30秒之间
+func (s *SpikeArrestLimiter) Validate() error {
+	config := s.GetConfig()
+	if config.Rate <= 0 {
+		return fmt.Errorf("削峰限流速率必须大于0")
+	}
+
+	if config.Burst < 0 {
+		return fmt.Errorf("削峰突发容量不能为负数")
+	}
+
+	if s.maxQueueDelay < 0 || s.maxQueueDelay > maxSpikeArrestQueueDelay {
+		return fmt.Errorf("削峰最大排队等待时间必须在 0 到 %d 毫秒之间", maxSpikeArrestQueueDelay.Milliseconds())
+	}
+
+	return nil
+}
+
+// OnResponse 处理响应结果
+//
+// 令牌在放行时已经消耗，响应阶段不需要额外操作。
+func (s *SpikeArrestLimiter) OnResponse(ctx *core.Context, err error) {
+}
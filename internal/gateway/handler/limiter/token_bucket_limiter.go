@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"gateway/internal/gateway/constants"
 	"gateway/internal/gateway/core"
 )
 
@@ -134,7 +135,8 @@ func (t *TokenBucketLimiter) Handle(ctx *core.Context) bool {
 		config := t.GetConfig()
 		ctx.AddError(fmt.Errorf("token bucket rate limit exceeded for key: %s", key))
 		ctx.Abort(config.ErrorStatusCode, map[string]string{
-			"error": config.ErrorMessage,
+			"error":      config.ErrorMessage,
+			"error_code": constants.ErrorCodeRateLimitExceeded,
 		})
 		return false
 	}
@@ -0,0 +1,235 @@
+package proxy
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/router"
+)
+
+// requestCoalescer 合并相同的并发请求（singleflight）
+// 同一合并键下只有首个请求向后端转发，其余请求等待并复用其响应
+type requestCoalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+// coalescedCall 一次正在进行的合并请求
+type coalescedCall struct {
+	// 首个请求完成后关闭
+	done chan struct{}
+
+	// 可共享的响应，首个请求的响应无法共享时为nil
+	response *coalescedResponse
+}
+
+// coalescedResponse 首个请求记录下来的响应，用于分发给等待的请求
+type coalescedResponse struct {
+	ok                bool
+	statusCode        int
+	header            http.Header
+	body              []byte
+	targetURL         string
+	backendStatusCode interface{}
+	gatewayStatusCode interface{}
+	backendDuration   time.Duration
+}
+
+// newRequestCoalescer 创建请求合并器
+func newRequestCoalescer() *requestCoalescer {
+	return &requestCoalescer{calls: make(map[string]*coalescedCall)}
+}
+
+// join 加入合并键对应的请求
+// 返回值:
+// - *coalescedCall: 合并键对应的请求
+// - bool: 是否为首个请求，首个请求负责转发并调用 finish
+func (c *requestCoalescer) join(key string) (*coalescedCall, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if call, exists := c.calls[key]; exists {
+		return call, false
+	}
+	call := &coalescedCall{done: make(chan struct{})}
+	c.calls[key] = call
+	return call, true
+}
+
+// finish 结束合并请求并唤醒所有等待的请求
+// 先从合并表中移除，之后到达的相同请求会重新向后端转发
+func (c *requestCoalescer) finish(key string, call *coalescedCall, response *coalescedResponse) {
+	c.mu.Lock()
+	delete(c.calls, key)
+	c.mu.Unlock()
+	call.response = response
+	close(call.done)
+}
+
+// handleCoalesced 按路由合并策略处理单服务GET请求
+// 首个请求正常转发并记录响应；等待的请求直接复用记录的状态码、响应头和响应体。
+// 首个请求的响应无法共享（SSE、响应体超过限制或首个请求的客户端已断开）时，等待的请求各自转发。
+// 等待的请求携带的 If-None-Match/If-Modified-Since 与共享响应的 ETag/Last-Modified 匹配时返回304，
+// 首个请求得到304而等待的请求验证条件不匹配时各自转发。
+func (h *HTTPProxy) handleCoalesced(ctx *core.Context, policy *router.CoalescePolicy, forward func() bool) bool {
+	key := policy.Key(ctx.Request)
+	call, leader := h.coalescer.join(key)
+	if leader {
+		var response *coalescedResponse
+		defer func() {
+			h.coalescer.finish(key, call, response)
+		}()
+		var ok bool
+		response, ok = h.recordResponse(ctx, policy.MaxBodyBytes, forward)
+		return ok
+	}
+
+	select {
+	case <-call.done:
+	case <-ctx.Request.Context().Done():
+		ctx.AddError(fmt.Errorf("等待合并请求响应时客户端已断开: %w", ctx.Request.Context().Err()))
+		return false
+	}
+	response := call.response
+	if response == nil {
+		return forward()
+	}
+	switch {
+	case response.statusCode == http.StatusNotModified && !validatorsMatch(ctx.Request, response.header):
+		// 首个请求的304只对携带相同验证条件的请求有效，其余请求各自转发获取完整响应
+		return forward()
+	case response.statusCode == http.StatusOK && validatorsMatch(ctx.Request, response.header):
+		response = notModifiedResponse(response)
+	}
+	h.replayResponse(ctx, response)
+	ctx.Set(constants.ContextKeyRequestCoalesced, true)
+	if !response.ok {
+		ctx.AddError(fmt.Errorf("合并请求转发失败，已复用首个请求的错误响应"))
+	}
+	return response.ok
+}
+
+// recordResponse 执行转发并记录响应，响应仍实时写给首个请求的客户端
+// 返回值:
+// - *coalescedResponse: 可共享的响应，无法共享时为nil
+// - bool: 转发结果
+func (h *HTTPProxy) recordResponse(ctx *core.Context, maxBodyBytes int64, forward func() bool) (*coalescedResponse, bool) {
+	writer := ctx.Writer
+	recorder := newCoalesceRecorder(writer, maxBodyBytes)
+	ctx.Writer = recorder
+	ok := forward()
+	ctx.Writer = writer
+
+	if recorder.statusCode == 0 || recorder.unshareable || ctx.Request.Context().Err() != nil {
+		return nil, ok
+	}
+	if _, isSSE := ctx.Get(constants.ContextKeySSEResponse); isSSE {
+		return nil, ok
+	}
+
+	response := &coalescedResponse{
+		ok:              ok,
+		statusCode:      recorder.statusCode,
+		header:          recorder.header,
+		body:            recorder.body.Bytes(),
+		targetURL:       ctx.GetTargetURL(),
+		backendDuration: time.Duration(ctx.GetMaxBackendDuration()) * time.Millisecond,
+	}
+	response.backendStatusCode, _ = ctx.Get(constants.BackendStatusCode)
+	response.gatewayStatusCode, _ = ctx.Get(constants.GatewayStatusCode)
+	return response, ok
+}
+
+// replayResponse 将共享响应写给等待的请求，并补齐访问日志需要的上下文信息
+// 请求合并和幂等键重放共用，调用方负责标记响应来源
+func (h *HTTPProxy) replayResponse(ctx *core.Context, response *coalescedResponse) {
+	for name, values := range response.header {
+		ctx.Writer.Header()[name] = append([]string(nil), values...)
+	}
+	ctx.Writer.WriteHeader(response.statusCode)
+	ctx.SetResponded()
+	ctx.SetResponseTime(time.Time{})
+	if _, err := ctx.Writer.Write(response.body); err != nil {
+		ctx.AddError(fmt.Errorf("写入合并请求响应体失败: %w", err))
+	}
+
+	ctx.Set(constants.ContextKeyProxyType, h.GetType())
+	ctx.SetTargetURL(response.targetURL)
+	ctx.SetMaxBackendDuration(response.backendDuration)
+	if response.backendStatusCode != nil {
+		ctx.Set(constants.BackendStatusCode, response.backendStatusCode)
+	}
+	if response.gatewayStatusCode != nil {
+		ctx.Set(constants.GatewayStatusCode, response.gatewayStatusCode)
+	}
+	if h.shouldRecordResponseBody(ctx) {
+		ctx.Set("response_body", response.body)
+	}
+}
+
+// coalesceRecorder 记录首个请求响应的写入器
+// 响应照常写给客户端，同时记录本次转发写入的响应头、状态码和响应体
+type coalesceRecorder struct {
+	http.ResponseWriter
+
+	// 转发前已存在的响应头（如CORS、链路追踪头），不属于共享响应
+	baseHeader http.Header
+
+	header       http.Header
+	statusCode   int
+	body         bytes.Buffer
+	maxBodyBytes int64
+	unshareable  bool
+}
+
+// newCoalesceRecorder 创建响应记录写入器
+func newCoalesceRecorder(writer http.ResponseWriter, maxBodyBytes int64) *coalesceRecorder {
+	return &coalesceRecorder{
+		ResponseWriter: writer,
+		baseHeader:     writer.Header().Clone(),
+		maxBodyBytes:   maxBodyBytes,
+	}
+}
+
+// WriteHeader 写入状态码并记录转发过程中新增或修改的响应头
+func (r *coalesceRecorder) WriteHeader(statusCode int) {
+	if r.statusCode == 0 {
+		r.statusCode = statusCode
+		r.header = make(http.Header)
+		for name, values := range r.ResponseWriter.Header() {
+			if !slices.Equal(r.baseHeader[name], values) {
+				r.header[name] = append([]string(nil), values...)
+			}
+		}
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write 写入响应体，超过共享大小限制或写入失败后不再记录
+func (r *coalesceRecorder) Write(data []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.WriteHeader(http.StatusOK)
+	}
+	n, err := r.ResponseWriter.Write(data)
+	if r.unshareable {
+		return n, err
+	}
+	// 写入失败时记录的响应体不完整，同样不共享
+	if err != nil || int64(r.body.Len()+n) > r.maxBodyBytes {
+		r.unshareable = true
+		r.body = bytes.Buffer{}
+		return n, err
+	}
+	r.body.Write(data)
+	return n, err
+}
+
+// Unwrap 返回原始写入器，供 http.ResponseController 刷新和设置超时
+func (r *coalesceRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,65 @@
+package proxy
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// notModifiedHeaders 304响应中保留的响应头（RFC 9110 15.4.5），其余响应头和响应体不发送
+var notModifiedHeaders = []string{"Cache-Control", "Content-Location", "Date", "ETag", "Expires", "Last-Modified", "Vary"}
+
+// validatorsMatch 判断请求的缓存验证条件是否与响应的 ETag/Last-Modified 匹配，即客户端缓存的副本仍然有效
+// If-None-Match 优先于 If-Modified-Since（RFC 9110 13.2.2），只对GET和HEAD请求生效
+func validatorsMatch(req *http.Request, header http.Header) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+
+	if ifNoneMatch := req.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		etag := header.Get("ETag")
+		if etag == "" {
+			return false
+		}
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || weakETagEqual(candidate, etag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ifModifiedSince := req.Header.Get("If-Modified-Since"); ifModifiedSince != "" {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+		lastModified, err := http.ParseTime(header.Get("Last-Modified"))
+		if err != nil {
+			return false
+		}
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+	return false
+}
+
+// weakETagEqual ETag弱比较：忽略 W/ 前缀后比较不透明标签
+func weakETagEqual(a, b string) bool {
+	return strings.TrimPrefix(a, "W/") == strings.TrimPrefix(b, "W/")
+}
+
+// notModifiedResponse 由共享的200响应为验证条件匹配的请求生成304响应，只保留验证器和缓存相关的响应头
+func notModifiedResponse(response *coalescedResponse) *coalescedResponse {
+	header := make(http.Header)
+	for _, name := range notModifiedHeaders {
+		if values := response.header.Values(name); len(values) > 0 {
+			header[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+		}
+	}
+	notModified := *response
+	notModified.statusCode = http.StatusNotModified
+	notModified.header = header
+	notModified.body = nil
+	return &notModified
+}
@@ -143,6 +143,19 @@ func (p *HTTPConfigParser) ParseConfig(configMap map[string]interface{}, httpCon
 		}
 	}
 
+	// tlsHandshakeTimeout 字段解析
+	if tlsHandshakeTimeout := getConfigValue(configMap, "tlsHandshakeTimeout", "tls_handshake_timeout"); tlsHandshakeTimeout != nil {
+		if timeoutStr, ok := tlsHandshakeTimeout.(string); ok {
+			if d, err := time.ParseDuration(timeoutStr); err == nil {
+				httpConfig.TLSHandshakeTimeout = d
+			}
+		}
+		// 支持整型和浮点型按秒转换
+		if duration := parseDurationFromNumber(tlsHandshakeTimeout); duration > 0 {
+			httpConfig.TLSHandshakeTimeout = duration
+		}
+	}
+
 	// followRedirects 字段解析
 	if followRedirects := getConfigValue(configMap, "followRedirects", "follow_redirects"); followRedirects != nil {
 		if b, ok := followRedirects.(bool); ok {
@@ -177,6 +190,32 @@ func (p *HTTPConfigParser) ParseConfig(configMap map[string]interface{}, httpCon
 		}
 	}
 
+	// dnsCacheTTL 字段解析
+	if dnsCacheTTL := getConfigValue(configMap, "dnsCacheTTL", "dns_cache_ttl"); dnsCacheTTL != nil {
+		if ttlStr, ok := dnsCacheTTL.(string); ok {
+			if d, err := time.ParseDuration(ttlStr); err == nil {
+				httpConfig.DNSCacheTTL = d
+			}
+		}
+		// 支持整型和浮点型按秒转换
+		if duration := parseDurationFromNumber(dnsCacheTTL); duration > 0 {
+			httpConfig.DNSCacheTTL = duration
+		}
+	}
+
+	// dnsRefreshInterval 字段解析
+	if dnsRefreshInterval := getConfigValue(configMap, "dnsRefreshInterval", "dns_refresh_interval"); dnsRefreshInterval != nil {
+		if intervalStr, ok := dnsRefreshInterval.(string); ok {
+			if d, err := time.ParseDuration(intervalStr); err == nil {
+				httpConfig.DNSRefreshInterval = d
+			}
+		}
+		// 支持整型和浮点型按秒转换
+		if duration := parseDurationFromNumber(dnsRefreshInterval); duration > 0 {
+			httpConfig.DNSRefreshInterval = duration
+		}
+	}
+
 	// copyResponseBody 字段解析
 	if copyResponseBody := getConfigValue(configMap, "copyResponseBody", "copy_response_body"); copyResponseBody != nil {
 		if b, ok := copyResponseBody.(bool); ok {
@@ -16,6 +16,7 @@ import (
 	"gateway/internal/gateway/handler/router"
 	"gateway/internal/gateway/handler/service"
 	"gateway/internal/gateway/logwrite"
+	"gateway/internal/gateway/logwrite/slowreq"
 )
 
 // errMultiServiceSSE 表示聚合路径收到无法安全缓冲或合并的SSE响应。
@@ -40,8 +41,7 @@ type ServiceResponse struct {
 // 负责处理一个请求转发到多个后端服务的场景
 // 使用 HTTPProxy 的节点选择和路径构建方法，复用负载均衡逻辑
 type HTTPMultiServiceProxy struct {
-	httpProxy *HTTPProxy // HTTP代理实例，用于复用节点选择和路径构建方法
-	client    *http.Client
+	httpProxy *HTTPProxy // HTTP代理实例，用于复用节点选择、路径构建方法和HTTP客户端
 	config    *HTTPProxyConfig
 }
 
@@ -49,7 +49,6 @@ type HTTPMultiServiceProxy struct {
 func NewHTTPMultiServiceProxy(httpProxy *HTTPProxy) *HTTPMultiServiceProxy {
 	return &HTTPMultiServiceProxy{
 		httpProxy: httpProxy,
-		client:    httpProxy.client,
 		config:    httpProxy.config,
 	}
 }
@@ -222,7 +221,7 @@ func (m *HTTPMultiServiceProxy) proxyRequestToServiceWithRetry(
 		}
 
 		// 执行代理请求（每次调用都会记录后端追踪日志）
-		response, attemptDuration := m.proxyRequestToService(ctx, serviceConfig, node, requestBody, attempt)
+		response, attemptDuration := m.proxyAttemptToService(ctx, serviceID, serviceConfig, node, requestBody, attempt)
 
 		// 累加本次请求的耗时
 		totalBackendDuration += attemptDuration
@@ -255,6 +254,20 @@ func (m *HTTPMultiServiceProxy) proxyRequestToServiceWithRetry(
 	return lastResponse
 }
 
+// proxyAttemptToService 向指定服务执行一次代理请求
+// 请求完成（包括发生panic）后释放负载均衡器统计的在途请求
+func (m *HTTPMultiServiceProxy) proxyAttemptToService(
+	ctx *core.Context,
+	serviceID string,
+	serviceConfig *service.ServiceConfig,
+	node *service.NodeConfig,
+	requestBody []byte,
+	attempt int,
+) (*ServiceResponse, time.Duration) {
+	defer m.httpProxy.serviceManager.ReleaseNode(serviceID, node.ID)
+	return m.proxyRequestToService(ctx, serviceConfig, node, requestBody, attempt)
+}
+
 // proxyRequestToService 向指定服务发送代理请求
 // 复用 http_proxy.go 的 ProxyRequest 逻辑，但不写入响应，只返回响应信息
 // 日志写入直接调用日志写入类，与 ProxyRequest 保持一致
@@ -310,12 +323,14 @@ func (m *HTTPMultiServiceProxy) proxyRequestToService(
 		body = bytes.NewReader(requestBody)
 	}
 
-	requestCtx := ctx.Request.Context()
-	if timeout := m.httpProxy.resolveRequestTimeout(ctx); timeout > 0 {
-		var cancel context.CancelFunc
-		requestCtx, cancel = context.WithTimeout(requestCtx, timeout)
-		defer cancel()
+	requestCtx, cancelRequest := context.WithCancelCause(ctx.Request.Context())
+	defer cancelRequest(nil)
+	timeouts := m.httpProxy.resolveUpstreamTimeouts(ctx, serviceConfig)
+	if timeouts.total > 0 {
+		totalTimeoutTimer := time.AfterFunc(timeouts.total, func() { cancelRequest(errProxyRequestTimeout) })
+		defer totalTimeoutTimer.Stop()
 	}
+	requestCtx = withUpstreamTimeouts(requestCtx, timeouts)
 	proxyReq, err := http.NewRequestWithContext(
 		requestCtx,
 		ctx.Request.Method,
@@ -420,8 +435,23 @@ func (m *HTTPMultiServiceProxy) proxyRequestToService(
 		)
 	}()
 
+	// 启用慢请求检测时记录上游连接状态
+	proxyReq = slowreq.TraceRequest(slowreq.FromContext(ctx), proxyReq)
+
+	// 请求发送完成后开始等待响应头计时
+	proxyReq, stopHeaderTimer := withResponseHeaderTimeout(proxyReq, timeouts.responseHeader, cancelRequest)
+	defer stopHeaderTimer()
+
 	// 发送代理请求（异常直接抛出）
-	resp, err := m.client.Do(proxyReq)
+	var resp *http.Response
+	client, err := m.httpProxy.clientForService(serviceConfig)
+	if err == nil {
+		resp, err = client.Do(proxyReq)
+	}
+	if cause := context.Cause(requestCtx); err != nil && errors.Is(cause, errProxyRequestTimeout) {
+		// 总超时或响应头超时取消的请求标记为超时
+		err = fmt.Errorf("%w: %v", cause, err)
+	}
 	if err != nil {
 		// 请求失败时记录错误和后端请求结束时间
 		responseErr = err
@@ -3,6 +3,7 @@ package proxy
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -10,6 +11,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"crypto/tls"
@@ -19,6 +21,7 @@ import (
 	"gateway/internal/gateway/handler/router"
 	"gateway/internal/gateway/handler/service"
 	"gateway/internal/gateway/logwrite"
+	"gateway/internal/gateway/logwrite/slowreq"
 	"gateway/internal/gateway/logwrite/types"
 )
 
@@ -29,6 +32,10 @@ type HTTPProxy struct {
 	serviceManager   service.ServiceManager
 	config           *HTTPProxyConfig
 	wsUpgradeHandler *WebSocketUpgradeHandler // WebSocket升级处理器
+	coalescer        *requestCoalescer        // 路由级请求合并器
+	idempotency      *idempotencyStore        // 路由级幂等键响应缓存
+	dnsCache         *proxyutils.DNSCache     // 上游主机名DNS解析缓存，未配置缓存时间时为nil
+	tlsClients       sync.Map                 // 服务ID -> *upstreamTLSClient，配置了上游TLS的服务使用的客户端
 }
 
 // Handle 处理HTTP代理请求
@@ -53,6 +60,21 @@ func (h *HTTPProxy) Handle(ctx *core.Context) bool {
 		return false
 	}
 
+	// 携带幂等键的请求按路由缓存首个成功的响应，客户端重试时直接返回缓存的响应
+	if value, exists := ctx.Get(constants.ContextKeyRouteIdempotency); exists {
+		if policy, ok := value.(*router.IdempotencyPolicy); ok && policy != nil {
+			if key, ok := policy.Key(ctx.Request, idempotencyIdentity(ctx)); ok {
+				return h.handleIdempotent(ctx, policy, key, func() bool {
+					return h.forwardToServices(ctx, serviceIDs)
+				})
+			}
+		}
+	}
+	return h.forwardToServices(ctx, serviceIDs)
+}
+
+// forwardToServices 按服务ID数量选择单服务或多服务转发
+func (h *HTTPProxy) forwardToServices(ctx *core.Context, serviceIDs []string) bool {
 	// 只有多个服务ID时才进入聚合路径；单服务即使携带聚合配置也保留流式能力。
 	isMultiService := len(serviceIDs) > 1
 
@@ -70,6 +92,21 @@ func (h *HTTPProxy) Handle(ctx *core.Context) bool {
 
 	// 单服务场景，使用原有的单服务处理逻辑（带重试）
 	serviceID := serviceIDs[0]
+	// 开启请求合并的路由，相同的并发GET请求只向后端转发一次
+	if ctx.Request.Method == http.MethodGet {
+		if value, exists := ctx.Get(constants.ContextKeyRouteCoalescePolicy); exists {
+			if policy, ok := value.(*router.CoalescePolicy); ok && policy != nil {
+				return h.handleCoalesced(ctx, policy, func() bool {
+					return h.handleSingleService(ctx, serviceID)
+				})
+			}
+		}
+	}
+	return h.handleSingleService(ctx, serviceID)
+}
+
+// handleSingleService 转发单服务请求，失败时按配置重试
+func (h *HTTPProxy) handleSingleService(ctx *core.Context, serviceID string) bool {
 	config := h.GetHTTPConfig()
 	maxRetries := config.RetryCount
 	if routeRetries, exists := ctx.GetInt(constants.ContextKeyRouteRetryCount); exists {
@@ -120,7 +157,7 @@ func (h *HTTPProxy) Handle(ctx *core.Context) bool {
 		}
 
 		// 执行代理请求（每次调用都会记录后端追踪日志）
-		err, attemptDuration := h.proxyRequest(ctx, serviceConfig, node, attempt)
+		err, attemptDuration := h.proxyAttempt(ctx, serviceID, serviceConfig, node, attempt)
 
 		// 累加本次请求的耗时
 		totalBackendDuration += attemptDuration
@@ -165,8 +202,19 @@ func (h *HTTPProxy) Handle(ctx *core.Context) bool {
 	if lastNode != nil {
 		targetURL = lastNode.URL
 	}
+	if isTimeoutError(lastErr) {
+		ctx.Abort(http.StatusGatewayTimeout, map[string]string{
+			"error":      "proxy request timeout",
+			"error_code": constants.ErrorCodeTimeout,
+			"details":    lastErr.Error(),
+			"target_url": targetURL,
+			"service":    serviceID,
+		})
+		return false
+	}
 	ctx.Abort(http.StatusBadGateway, map[string]string{
 		"error":      "proxy request failed",
+		"error_code": constants.ErrorCodeUpstreamError,
 		"details":    lastErr.Error(),
 		"target_url": targetURL,
 		"service":    serviceID,
@@ -175,6 +223,25 @@ func (h *HTTPProxy) Handle(ctx *core.Context) bool {
 	return false
 }
 
+// errProxyRequestTimeout 代理请求超过路由或代理配置的总超时
+var errProxyRequestTimeout = errors.New("代理请求超时")
+
+// isTimeoutError 判断代理错误是否为超时（总超时、上下文截止或网络超时）
+func isTimeoutError(err error) bool {
+	if errors.Is(err, errProxyRequestTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// proxyAttempt 执行一次代理请求
+// 请求完成（包括流式响应写完或发生panic）后释放负载均衡器统计的在途请求
+func (h *HTTPProxy) proxyAttempt(ctx *core.Context, serviceID string, serviceConfig *service.ServiceConfig, node *service.NodeConfig, attempt int) (error, time.Duration) {
+	defer h.serviceManager.ReleaseNode(serviceID, node.ID)
+	return h.proxyRequest(ctx, serviceConfig, node, attempt)
+}
+
 // proxyRequest 代理请求到指定节点（内部方法）
 // retryCount: 当前请求是第几次重试（0表示首次请求）
 // 返回值:
@@ -220,13 +287,16 @@ func (h *HTTPProxy) proxyRequest(ctx *core.Context, serviceConfig *service.Servi
 		}
 	}
 
-	proxyCtx, cancelProxy := context.WithCancel(ctx.Request.Context())
-	defer cancelProxy()
+	proxyCtx, cancelProxy := context.WithCancelCause(ctx.Request.Context())
+	defer cancelProxy(nil)
+	timeouts := h.resolveUpstreamTimeouts(ctx, serviceConfig)
 	var totalTimeoutTimer *time.Timer
-	if timeout := h.resolveRequestTimeout(ctx); timeout > 0 {
-		totalTimeoutTimer = time.AfterFunc(timeout, cancelProxy)
+	if timeouts.total > 0 {
+		// 以 errProxyRequestTimeout 作为取消原因，便于区分总超时和客户端断开
+		totalTimeoutTimer = time.AfterFunc(timeouts.total, func() { cancelProxy(errProxyRequestTimeout) })
 		defer totalTimeoutTimer.Stop()
 	}
+	proxyCtx = withUpstreamTimeouts(proxyCtx, timeouts)
 
 	proxyReq, err := http.NewRequestWithContext(
 		proxyCtx,
@@ -346,8 +416,20 @@ func (h *HTTPProxy) proxyRequest(ctx *core.Context, serviceConfig *service.Servi
 		)
 	}()
 
+	// 启用慢请求检测时记录上游连接状态
+	proxyReq = slowreq.TraceRequest(slowreq.FromContext(ctx), proxyReq)
+
+	// 请求发送完成后开始等待响应头计时
+	proxyReq, stopHeaderTimer := withResponseHeaderTimeout(proxyReq, timeouts.responseHeader, cancelProxy)
+	defer stopHeaderTimer()
+
 	// 发送代理请求（异常直接抛出）
-	resp, err := h.client.Do(proxyReq)
+	client, err := h.clientForService(serviceConfig)
+	if err != nil {
+		responseErr = err
+		return err, time.Since(requestStartTime)
+	}
+	resp, err := client.Do(proxyReq)
 	if err != nil {
 		// 请求失败时记录错误和后端请求结束时间
 		responseErr = err
@@ -356,6 +438,10 @@ func (h *HTTPProxy) proxyRequest(ctx *core.Context, serviceConfig *service.Servi
 		// 计算本次请求的耗时（请求失败时，耗时从请求开始到失败的时间）
 		attemptDuration := time.Since(requestStartTime)
 		// 注意：不在这里设置 MaxBackendDuration，由重试循环累加后统一设置
+		if cause := context.Cause(proxyCtx); errors.Is(cause, errProxyRequestTimeout) {
+			// 总超时或响应头超时取消的请求标记为超时，供错误响应区分超时和其他上游错误
+			return fmt.Errorf("%w: %v", cause, err), attemptDuration
+		}
 		return err, attemptDuration // 直接返回错误和耗时，不包装
 	}
 	// defer resp.Body.Close() 位置正确：只有在成功获取响应时才设置 defer
@@ -428,17 +514,6 @@ func (h *HTTPProxy) proxyRequest(ctx *core.Context, serviceConfig *service.Servi
 	return err, attemptDuration
 }
 
-// resolveRequestTimeout 返回本次请求应使用的绝对总超时。
-// 路由 timeoutMs>0 时覆盖代理配置；0 或未设置时优先使用代理 Timeout。
-func (h *HTTPProxy) resolveRequestTimeout(ctx *core.Context) time.Duration {
-	if value, exists := ctx.Get(constants.ContextKeyRouteTimeout); exists {
-		if timeout, ok := value.(time.Duration); ok && timeout > 0 {
-			return timeout
-		}
-	}
-	return h.GetHTTPConfig().Timeout
-}
-
 // GetHTTPConfig 获取HTTP配置
 func (h *HTTPProxy) GetHTTPConfig() HTTPProxyConfig {
 	if h.config != nil {
@@ -459,6 +534,12 @@ func (h *HTTPProxy) Validate() error {
 	if config.IdleConnTimeout < 0 {
 		return fmt.Errorf("空闲连接超时不能为负数")
 	}
+	if config.ConnectTimeout < 0 || config.TLSHandshakeTimeout < 0 || config.ReadTimeout < 0 {
+		return fmt.Errorf("连接超时、TLS握手超时和读取超时不能为负数")
+	}
+	if config.DNSCacheTTL < 0 || config.DNSRefreshInterval < 0 {
+		return fmt.Errorf("DNS缓存时间和重新解析间隔不能为负数")
+	}
 	if config.BufferSize <= 0 {
 		return fmt.Errorf("缓冲区大小必须大于0")
 	}
@@ -493,6 +574,12 @@ func (h *HTTPProxy) Close() error {
 			transport.CloseIdleConnections()
 		}
 	}
+	h.closeUpstreamTLSClients()
+
+	// 停止DNS后台重新解析
+	if h.dnsCache != nil {
+		h.dnsCache.Stop()
+	}
 
 	// 关闭服务管理器
 	// 服务管理器包含健康检查器等需要清理的资源
@@ -545,6 +632,8 @@ func NewHTTPProxy(config ProxyConfig, serviceManager service.ServiceManager) (*H
 		serviceManager:   serviceManager,
 		config:           &httpConfig,
 		wsUpgradeHandler: wsUpgradeHandler,
+		coalescer:        newRequestCoalescer(),
+		idempotency:      newIdempotencyStore(),
 	}
 
 	// 使用配置创建HTTP客户端
@@ -904,19 +993,8 @@ func parseTLSVersion(version string) uint16 {
 
 // createHTTPClient 创建HTTP客户端
 func (h *HTTPProxy) createHTTPClient(config HTTPProxyConfig) *http.Client {
-	// 设置超时配置
-	connectTimeout := config.ConnectTimeout
-	if connectTimeout == 0 {
-		connectTimeout = config.Timeout
-	}
-	if connectTimeout == 0 {
-		connectTimeout = 30 * time.Second
-	}
-
-	readTimeout := config.ReadTimeout
-	if readTimeout == 0 {
-		readTimeout = 30 * time.Second
-	}
+	// 代理级别的默认超时，转发时可被服务和路由配置逐项覆盖
+	timeouts := proxyUpstreamTimeouts(config)
 
 	// 创建TLS配置
 	tlsConfig := &tls.Config{
@@ -940,6 +1018,11 @@ func (h *HTTPProxy) createHTTPClient(config HTTPProxyConfig) *http.Client {
 		writeBufferSize = 1024 // 1KB，更适合实时流
 	}
 
+	// 连接拨号配置，连接超时由 withConnectTimeout 按转发请求控制
+	dialer := &net.Dialer{
+		KeepAlive: 30 * time.Second, // TCP Keep-Alive间隔
+	}
+
 	// 创建传输层配置
 	transport := &http.Transport{
 		// 连接池配置
@@ -948,10 +1031,9 @@ func (h *HTTPProxy) createHTTPClient(config HTTPProxyConfig) *http.Client {
 		MaxConnsPerHost:     config.MaxIdleConns * 2, // 每个主机的最大连接数
 		IdleConnTimeout:     config.IdleConnTimeout,  // 空闲连接超时
 
-		// 超时配置
-		TLSHandshakeTimeout:   10 * time.Second, // TLS握手超时
-		ResponseHeaderTimeout: readTimeout,      // 响应头超时
-		ExpectContinueTimeout: 1 * time.Second,  // 100-continue超时
+		// 超时配置：TLS握手超时由 upstreamTLSDialer 控制，响应头超时由 withResponseHeaderTimeout 按转发请求控制
+		TLSHandshakeTimeout:   timeouts.tlsHandshake, // TLS握手超时
+		ExpectContinueTimeout: 1 * time.Second,       // 100-continue超时
 
 		// Keep-Alive配置
 		DisableKeepAlives: !config.KeepAlive, // 根据配置决定是否禁用Keep-Alive
@@ -961,15 +1043,25 @@ func (h *HTTPProxy) createHTTPClient(config HTTPProxyConfig) *http.Client {
 		WriteBufferSize: writeBufferSize, // 写缓冲区大小
 
 		// 连接拨号配置
-		DialContext: (&net.Dialer{
-			Timeout:   connectTimeout,   // 连接超时
-			KeepAlive: 30 * time.Second, // TCP Keep-Alive间隔
-		}).DialContext,
+		DialContext: dialer.DialContext,
 
 		// 使用配置的TLS设置
 		TLSClientConfig: tlsConfig,
 	}
 
+	// 配置DNS缓存时，主机名由缓存解析并在所有A/AAAA记录之间轮询拨号
+	if h.dnsCache != nil {
+		h.dnsCache.Stop()
+		h.dnsCache = nil
+	}
+	if dnsCache := proxyutils.NewDNSCache(nil, config.DNSCacheTTL); dnsCache != nil {
+		dnsCache.Start(config.DNSRefreshInterval)
+		h.dnsCache = dnsCache
+		transport.DialContext = dnsCache.DialContext(dialer)
+	}
+	transport.DialContext = withConnectTimeout(transport.DialContext, timeouts.connect)
+	transport.DialTLSContext = upstreamTLSDialer(transport.DialContext, tlsConfig, timeouts.tlsHandshake)
+
 	// 创建客户端
 	client := &http.Client{
 		Transport: transport,
@@ -0,0 +1,222 @@
+package proxy
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/router"
+)
+
+// IdempotentReplayedHeader 重放幂等缓存响应时添加的响应头
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// idempotencySweepInterval 清理过期幂等缓存的最小间隔
+const idempotencySweepInterval = time.Minute
+
+const (
+	// maxIdempotencyEntries 幂等缓存最多保存的幂等键数量，超过时淘汰最早缓存的响应
+	maxIdempotencyEntries = 10000
+	// maxIdempotencyCachedBytes 幂等缓存的响应体总字节数上限（64MB），超过时淘汰最早缓存的响应
+	maxIdempotencyCachedBytes int64 = 64 << 20
+)
+
+// errIdempotencyKeyReused 相同幂等键携带了不同的请求
+var errIdempotencyKeyReused = errors.New("幂等键已用于不同的请求")
+
+// idempotencyStore 幂等键响应缓存
+// 同一幂等键同时只有一个请求向后端转发；转发成功的响应按路由TTL缓存，之后的重复请求直接复用。
+// 缓存的幂等键数量和响应体总大小有上限，超过时按缓存先后淘汰已完成的响应
+type idempotencyStore struct {
+	mu          sync.Mutex
+	entries     map[string]*idempotencyEntry
+	order       *list.List // 幂等键按加入或缓存响应的先后排列，用于淘汰
+	cachedBytes int64      // 已缓存的响应体总字节数
+	maxEntries  int
+	maxBytes    int64
+	lastSweep   time.Time
+}
+
+// idempotencyEntry 幂等键对应的请求
+type idempotencyEntry struct {
+	// 首个请求完成后关闭
+	done chan struct{}
+
+	// 首个请求的指纹（请求方法和请求体摘要）
+	fingerprint string
+
+	// 缓存的响应，首个请求的响应不可缓存时为nil
+	response *coalescedResponse
+
+	// 缓存过期时间，首个请求完成前为零值
+	expiresAt time.Time
+
+	// 在淘汰队列中的位置
+	element *list.Element
+}
+
+// newIdempotencyStore 创建幂等键响应缓存
+func newIdempotencyStore() *idempotencyStore {
+	return newIdempotencyStoreWithLimit(maxIdempotencyEntries, maxIdempotencyCachedBytes)
+}
+
+// newIdempotencyStoreWithLimit 创建指定容量上限的幂等键响应缓存
+func newIdempotencyStoreWithLimit(maxEntries int, maxBytes int64) *idempotencyStore {
+	return &idempotencyStore{
+		entries:    make(map[string]*idempotencyEntry),
+		order:      list.New(),
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+	}
+}
+
+// acquire 获取幂等键对应的请求
+// 返回值:
+// - *idempotencyEntry: 幂等键对应的请求
+// - bool: 是否由当前请求转发，转发的请求负责调用 complete
+// - error: 幂等键已用于指纹不同的请求时返回 errIdempotencyKeyReused
+func (s *idempotencyStore) acquire(key, fingerprint string, now time.Time) (*idempotencyEntry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+	if entry, exists := s.entries[key]; exists {
+		if entry.expiresAt.IsZero() || now.Before(entry.expiresAt) {
+			if entry.fingerprint != fingerprint {
+				return nil, false, errIdempotencyKeyReused
+			}
+			return entry, false, nil
+		}
+		s.remove(key, entry)
+	}
+	entry := &idempotencyEntry{done: make(chan struct{}), fingerprint: fingerprint}
+	entry.element = s.order.PushBack(key)
+	s.entries[key] = entry
+	s.evict()
+	return entry, true, nil
+}
+
+// complete 结束转发并唤醒等待的重复请求
+// 响应不可缓存时移除幂等键，客户端重试会重新向后端转发
+func (s *idempotencyStore) complete(key string, entry *idempotencyEntry, response *coalescedResponse, ttl time.Duration) {
+	s.mu.Lock()
+	if response == nil {
+		if s.entries[key] == entry {
+			s.remove(key, entry)
+		}
+	} else {
+		entry.response = response
+		entry.expiresAt = time.Now().Add(ttl)
+		if s.entries[key] == entry {
+			s.cachedBytes += int64(len(response.body))
+			s.order.MoveToBack(entry.element)
+			s.evict()
+		}
+	}
+	s.mu.Unlock()
+	close(entry.done)
+}
+
+// remove 移除幂等键，调用方必须持有锁
+func (s *idempotencyStore) remove(key string, entry *idempotencyEntry) {
+	delete(s.entries, key)
+	s.order.Remove(entry.element)
+	if entry.response != nil {
+		s.cachedBytes -= int64(len(entry.response.body))
+	}
+}
+
+// evict 超过容量上限时按缓存先后淘汰已完成的响应，转发中的请求不淘汰，调用方必须持有锁
+func (s *idempotencyStore) evict() {
+	element := s.order.Front()
+	for element != nil && (len(s.entries) > s.maxEntries || s.cachedBytes > s.maxBytes) {
+		next := element.Next()
+		key := element.Value.(string)
+		if entry := s.entries[key]; !entry.expiresAt.IsZero() {
+			s.remove(key, entry)
+		}
+		element = next
+	}
+}
+
+// sweep 清理过期的幂等缓存，调用方必须持有锁
+func (s *idempotencyStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < idempotencySweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, entry := range s.entries {
+		if !entry.expiresAt.IsZero() && !now.Before(entry.expiresAt) {
+			s.remove(key, entry)
+		}
+	}
+}
+
+// idempotencyIdentity 请求的认证身份，参与幂等键计算
+// API Key认证时为key摘要，其他认证方式写入用户ID时为用户ID，未认证时为空
+func idempotencyIdentity(ctx *core.Context) string {
+	if apiKey, ok := ctx.GetString("api_key"); ok && apiKey != "" {
+		sum := sha256.Sum256([]byte(apiKey))
+		return "apikey:" + hex.EncodeToString(sum[:])
+	}
+	if userID, ok := ctx.GetString("user_id"); ok && userID != "" {
+		return "user:" + userID
+	}
+	return ""
+}
+
+// handleIdempotent 按路由幂等策略处理携带幂等键的请求
+// 首个请求正常转发并记录响应；转发成功且状态码小于500的响应按TTL缓存，重复请求直接返回缓存的响应。
+// 复用幂等键但请求方法或请求体不同的请求返回422，不转发也不返回缓存的响应。
+// 首个请求仍在转发时，重复请求等待其完成；首个请求失败或响应不可缓存时，等待的请求依次重新转发，
+// 保证同一幂等键同时只有一个请求到达后端。
+func (h *HTTPProxy) handleIdempotent(ctx *core.Context, policy *router.IdempotencyPolicy, key string, forward func() bool) bool {
+	fingerprint, err := policy.Fingerprint(ctx.Request)
+	if err != nil {
+		ctx.AddError(fmt.Errorf("计算幂等请求指纹失败: %w", err))
+		ctx.Abort(http.StatusBadRequest, map[string]string{
+			"error": "failed to read request body",
+		})
+		return false
+	}
+	for {
+		entry, leader, err := h.idempotency.acquire(key, fingerprint, time.Now())
+		if err != nil {
+			ctx.AddError(err)
+			ctx.Abort(http.StatusUnprocessableEntity, map[string]string{
+				"error": "idempotency key was already used for a different request",
+			})
+			return false
+		}
+		if leader {
+			var response *coalescedResponse
+			defer func() {
+				h.idempotency.complete(key, entry, response, policy.TTL)
+			}()
+			recorded, ok := h.recordResponse(ctx, policy.MaxBodyBytes, forward)
+			if recorded != nil && recorded.ok && recorded.statusCode < http.StatusInternalServerError {
+				response = recorded
+			}
+			return ok
+		}
+
+		select {
+		case <-entry.done:
+		case <-ctx.Request.Context().Done():
+			ctx.AddError(fmt.Errorf("等待幂等请求响应时客户端已断开: %w", ctx.Request.Context().Err()))
+			return false
+		}
+		if entry.response != nil {
+			ctx.Writer.Header().Set(IdempotentReplayedHeader, "true")
+			h.replayResponse(ctx, entry.response)
+			ctx.Set(constants.ContextKeyIdempotentReplayed, true)
+			return true
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package proxy
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestIdempotencyStoreLimit 验证幂等缓存超过数量或大小上限时淘汰最早缓存的响应，转发中的请求不被淘汰
+func TestIdempotencyStoreLimit(t *testing.T) {
+	store := newIdempotencyStoreWithLimit(2, 10)
+	now := time.Now()
+
+	inflight, leader, err := store.acquire("inflight", "fp", now)
+	if err != nil || !leader {
+		t.Fatalf("acquire inflight: leader=%v err=%v", leader, err)
+	}
+	for i := 0; i < 3; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		entry, _, err := store.acquire(key, "fp", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		store.complete(key, entry, &coalescedResponse{ok: true, body: []byte("abc")}, time.Hour)
+	}
+	if _, ok := store.entries["inflight"]; !ok {
+		t.Fatal("转发中的请求不应被淘汰")
+	}
+	if len(store.entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(store.entries))
+	}
+	if _, ok := store.entries["key-2"]; !ok {
+		t.Fatal("最新缓存的响应应保留")
+	}
+
+	// 超过响应体总大小时淘汰
+	store.complete("inflight", inflight, &coalescedResponse{ok: true, body: []byte("0123456789")}, time.Hour)
+	if store.cachedBytes > 10 {
+		t.Fatalf("cachedBytes = %d, want <= 10", store.cachedBytes)
+	}
+	if _, ok := store.entries["key-2"]; ok {
+		t.Fatal("超过大小上限时应淘汰较早的响应")
+	}
+	if _, _, err := store.acquire("inflight", "other", now); err != errIdempotencyKeyReused {
+		t.Fatalf("指纹不同的请求应返回 errIdempotencyKeyReused, got %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+package proxyutils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gateway/pkg/logger"
+)
+
+// dnsIdleExpireFactor 缓存条目超过 TTL*dnsIdleExpireFactor 未被使用时，后台刷新不再维护并删除
+const dnsIdleExpireFactor = 10
+
+// IPResolver DNS解析接口，net.DefaultResolver 实现了该接口，测试中可替换
+type IPResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// DNSCache 上游主机名DNS解析缓存
+//
+// 后端节点以主机名配置时，按TTL缓存解析结果，后台定期重新解析正在使用的主机名，
+// 拨号时在全部A/AAAA记录之间轮询，使连接均匀分布到每个地址，而不是每次请求依赖系统解析器。
+// 重新解析失败时继续使用上一次的结果，避免DNS短暂故障导致上游不可用。
+type DNSCache struct {
+	resolver IPResolver
+	ttl      time.Duration
+
+	mu      sync.RWMutex
+	entries map[string]*dnsCacheEntry
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// dnsCacheEntry 单个主机名的解析结果
+type dnsCacheEntry struct {
+	ips        []net.IP
+	resolvedAt time.Time
+	lastUsed   atomic.Int64  // 最近一次使用时间（UnixNano）
+	next       atomic.Uint32 // 轮询游标
+}
+
+// NewDNSCache 创建DNS解析缓存
+// 参数:
+// - resolver: DNS解析器，为nil时使用 net.DefaultResolver
+// - ttl: 解析结果缓存时间
+// 返回值:
+// - *DNSCache: DNS解析缓存，ttl<=0时返回nil表示不启用缓存
+func NewDNSCache(resolver IPResolver, ttl time.Duration) *DNSCache {
+	if ttl <= 0 {
+		return nil
+	}
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &DNSCache{
+		resolver: resolver,
+		ttl:      ttl,
+		entries:  make(map[string]*dnsCacheEntry),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start 启动后台重新解析
+// 参数:
+// - interval: 重新解析间隔，<=0时使用TTL的一半
+func (c *DNSCache) Start(interval time.Duration) {
+	if interval <= 0 {
+		interval = c.ttl / 2
+	}
+	if interval <= 0 {
+		interval = c.ttl
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.stopCh:
+				return
+			case <-ticker.C:
+				c.Refresh(context.Background())
+			}
+		}
+	}()
+}
+
+// Stop 停止后台重新解析
+func (c *DNSCache) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stopCh)
+	})
+}
+
+// Lookup 获取主机名的解析结果
+// 缓存有效时直接返回；过期时同步重新解析，解析失败但存在旧结果时返回旧结果
+func (c *DNSCache) Lookup(ctx context.Context, host string) ([]net.IP, error) {
+	entry := c.getEntry(host)
+	if entry != nil {
+		entry.lastUsed.Store(time.Now().UnixNano())
+		if time.Since(entry.resolvedAt) < c.ttl {
+			return entry.ips, nil
+		}
+	}
+
+	refreshed, err := c.resolve(ctx, host)
+	if err != nil {
+		if entry != nil {
+			if logger.RateLimit("dns:"+host, time.Minute) {
+				logger.Warn("DNS重新解析失败，继续使用缓存结果", "host", host, "error", err)
+			}
+			return entry.ips, nil
+		}
+		return nil, err
+	}
+	return refreshed.ips, nil
+}
+
+// Refresh 重新解析所有仍在使用的主机名，并删除长时间未使用的条目
+func (c *DNSCache) Refresh(ctx context.Context) {
+	idleBefore := time.Now().Add(-c.ttl * dnsIdleExpireFactor).UnixNano()
+
+	c.mu.Lock()
+	hosts := make([]string, 0, len(c.entries))
+	for host, entry := range c.entries {
+		if entry.lastUsed.Load() < idleBefore {
+			delete(c.entries, host)
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+	c.mu.Unlock()
+
+	for _, host := range hosts {
+		if _, err := c.resolve(ctx, host); err != nil {
+			if logger.RateLimit("dns:"+host, time.Minute) {
+				logger.Warn("DNS后台重新解析失败，保留缓存结果", "host", host, "error", err)
+			}
+		}
+	}
+}
+
+// DialContext 包装拨号函数，主机名通过缓存解析并在所有地址之间轮询
+// IP地址直接拨号；某个地址连接失败时依次尝试其余地址
+func (c *DNSCache) DialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil || net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		ips, err := c.Lookup(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		ips = filterIPsByNetwork(ips, network)
+		if len(ips) == 0 {
+			return nil, fmt.Errorf("主机 %s 没有可用于 %s 的地址", host, network)
+		}
+
+		entry := c.getEntry(host)
+		start := 0
+		if entry != nil {
+			start = int(entry.next.Add(1)-1) % len(ips)
+		}
+
+		var lastErr error
+		for i := range ips {
+			ip := ips[(start+i)%len(ips)]
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		return nil, lastErr
+	}
+}
+
+// getEntry 获取缓存条目
+func (c *DNSCache) getEntry(host string) *dnsCacheEntry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.entries[host]
+}
+
+// resolve 解析主机名并更新缓存，保留原条目的使用时间和轮询游标
+func (c *DNSCache) resolve(ctx context.Context, host string) (*dnsCacheEntry, error) {
+	addrs, err := c.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("主机 %s 没有解析到地址", host)
+	}
+	ips := make([]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, addr.IP)
+	}
+
+	entry := &dnsCacheEntry{ips: ips, resolvedAt: time.Now()}
+	c.mu.Lock()
+	if previous, exists := c.entries[host]; exists {
+		entry.lastUsed.Store(previous.lastUsed.Load())
+		entry.next.Store(previous.next.Load())
+	} else {
+		entry.lastUsed.Store(time.Now().UnixNano())
+	}
+	c.entries[host] = entry
+	c.mu.Unlock()
+	return entry, nil
+}
+
+// filterIPsByNetwork 按网络类型过滤地址，tcp4/tcp6 只保留对应版本
+func filterIPsByNetwork(ips []net.IP, network string) []net.IP {
+	switch network {
+	case "tcp4", "udp4":
+		return filterIPs(ips, true)
+	case "tcp6", "udp6":
+		return filterIPs(ips, false)
+	default:
+		return ips
+	}
+}
+
+// filterIPs 过滤出IPv4或IPv6地址
+func filterIPs(ips []net.IP, ipv4 bool) []net.IP {
+	filtered := make([]net.IP, 0, len(ips))
+	for _, ip := range ips {
+		if (ip.To4() != nil) == ipv4 {
+			filtered = append(filtered, ip)
+		}
+	}
+	return filtered
+}
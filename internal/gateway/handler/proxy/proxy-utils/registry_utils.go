@@ -7,7 +7,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"strconv"
+	"time"
 
 	"gateway/internal/gateway/core"
 	"gateway/internal/gateway/handler/service"
@@ -48,6 +50,7 @@ func IsServiceCenterService(metadata map[string]string) bool {
 //  3. 使用 cache.GetGlobalCache().GetService 取服务聚合对象；未找到则返回「服务不存在」。
 //  4. 遍历 svc.Nodes：仅保留 InstanceStatus==UP 且 HealthyStatus==Healthy 的实例，其余视为不可转发（含已下线、不健康）。
 //  5. 将每个合格实例转为 service.NodeConfig（URL、权重、元数据等），供负载均衡器按策略挑选其一。
+//     服务元数据配置了 warmupSeconds 时，预热期内的实例按注册时长使用递增的有效权重（对加权策略生效）。
 //
 // 实例下线与缓存：
 //   - 本函数不缓存结果；后端注销或置为不健康后，是否立刻从列表中消失取决于服务中心同步到 GetGlobalCache 的时效。
@@ -91,11 +94,15 @@ func CollectHealthyNodesFromServiceCenter(ctx *core.Context, serviceConfig *serv
 	)
 
 	if !found || svc == nil {
-		logger.WarnWithTrace(ctx.Ctx, "未找到服务",
-			"tenantId", metadata.TenantID,
-			"namespaceId", metadata.NamespaceID,
-			"groupName", metadata.GroupName,
-			"serviceName", metadata.ServiceName)
+		// 后端服务下线时每个请求都会走到这里，按服务限流避免日志风暴
+		serviceKey := metadata.TenantID + "/" + metadata.NamespaceID + "/" + metadata.GroupName + "/" + metadata.ServiceName
+		if logger.RateLimit("serviceNotFound:"+serviceKey, time.Minute) {
+			logger.WarnWithTrace(ctx.Ctx, "未找到服务",
+				"tenantId", metadata.TenantID,
+				"namespaceId", metadata.NamespaceID,
+				"groupName", metadata.GroupName,
+				"serviceName", metadata.ServiceName)
+		}
 		return nil, fmt.Errorf("服务不存在")
 	}
 
@@ -109,13 +116,17 @@ func CollectHealthyNodesFromServiceCenter(ctx *core.Context, serviceConfig *serv
 		protocol = "http"
 	}
 
+	// 实例预热时长来自服务中心的服务元数据，每次转发按当前时间计算有效权重
+	warmup := svc.WarmupDuration()
+	now := time.Now()
+
 	var nodes []*service.NodeConfig
 	for _, node := range svc.Nodes {
 		// 与注册中心约定一致：仅 UP 且 Healthy 的实例参与均衡；下线或非健康实例跳过
 		if node.InstanceStatus != types.NodeStatusUp || node.HealthyStatus != types.HealthyStatusHealthy {
 			continue
 		}
-		nodes = append(nodes, convertServiceNodeToNodeConfig(node, protocol))
+		nodes = append(nodes, convertServiceNodeToNodeConfig(node, protocol, warmup, now))
 	}
 
 	if len(nodes) == 0 {
@@ -133,10 +144,41 @@ func CollectHealthyNodesFromServiceCenter(ctx *core.Context, serviceConfig *serv
 	return nodes, nil
 }
 
+// CheckServiceCenterServiceSynced 检查服务中心服务是否已同步到全局缓存，供网关启动就绪检查使用。
+// 只要求服务已出现在缓存中；实例列表为空或全部不健康属于运行时状态，由转发时的重试/熔断处理。
+func CheckServiceCenterServiceSynced(serviceConfig *service.ServiceConfig) error {
+	if serviceConfig == nil || !IsServiceCenterService(serviceConfig.ServiceMetadata) {
+		return nil
+	}
+
+	metadata := serviceConfig.ServiceMetadata
+	if metadata["tenantId"] == "" || metadata["namespaceId"] == "" ||
+		metadata["groupName"] == "" || metadata["serviceName"] == "" {
+		return fmt.Errorf("服务 %s 元数据不完整：需要 tenantId、namespaceId、groupName 和 serviceName", serviceConfig.ID)
+	}
+
+	globalCache := cache.GetGlobalCache()
+	if globalCache == nil {
+		return fmt.Errorf("服务中心缓存未初始化")
+	}
+	if _, found := globalCache.GetService(context.Background(),
+		metadata["tenantId"], metadata["namespaceId"], metadata["groupName"], metadata["serviceName"]); !found {
+		return fmt.Errorf("服务 %s/%s/%s 尚未同步", metadata["namespaceId"], metadata["groupName"], metadata["serviceName"])
+	}
+	return nil
+}
+
+// warmupWeightScale 启用实例预热时节点权重的放大倍数。
+// 网关节点权重为整数而注册中心默认权重为1，放大后预热期内的有效权重才能逐步递增；
+// 同一服务的所有节点按相同倍数放大，不改变节点之间的权重比例。
+const warmupWeightScale = 100
+
 // convertServiceNodeToNodeConfig 将服务中心的 ServiceNode 转为网关统一的 NodeConfig。
 // protocol 为访问该实例的 scheme（http/https），与 NodeConfig.URL 前缀一致。
 // Health/Enabled 与注册中心状态对齐，供负载均衡器内与其它路径相同的过滤逻辑使用。
-func convertServiceNodeToNodeConfig(node *types.ServiceNode, protocol string) *service.NodeConfig {
+// warmup 大于0时 Weight 使用预热期内的有效权重（见 types.ServiceNode.EffectiveWeight）。
+// 节点在注册中心被手动调整权重时使用调整后的权重，调整为0的节点视为未启用。
+func convertServiceNodeToNodeConfig(node *types.ServiceNode, protocol string, warmup time.Duration, now time.Time) *service.NodeConfig {
 	if node == nil {
 		return nil
 	}
@@ -160,13 +202,21 @@ func convertServiceNodeToNodeConfig(node *types.ServiceNode, protocol string) *s
 		url += contextPath
 	}
 
+	weight := int(node.DiscoveryWeight())
+	if warmup > 0 && !node.IsWeightDrained() {
+		weight = int(math.Round(node.EffectiveWeight(warmup, now) * warmupWeightScale))
+		if weight < 1 {
+			weight = 1
+		}
+	}
+
 	// NodeConfig.Metadata 保留注册中心关键字段，便于日志与上下文透传
 	nodeConfig := &service.NodeConfig{
 		ID:      node.NodeId,
 		URL:     url,
-		Weight:  int(node.Weight),
+		Weight:  weight,
 		Health:  node.HealthyStatus == types.HealthyStatusHealthy,
-		Enabled: node.InstanceStatus == types.NodeStatusUp,
+		Enabled: node.InstanceStatus == types.NodeStatusUp && !node.IsWeightDrained(),
 		Metadata: map[string]string{
 			"nodeId":         node.NodeId,
 			"serviceName":    node.ServiceName,
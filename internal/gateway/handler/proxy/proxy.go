@@ -42,12 +42,19 @@ type HTTPProxyConfig struct {
 	ReadTimeout    time.Duration `yaml:"read_timeout" json:"read_timeout" mapstructure:"read_timeout"`          // 读取超时
 	ConnectTimeout time.Duration `yaml:"connect_timeout" json:"connect_timeout" mapstructure:"connect_timeout"` // 连接超时
 
+	// TLS握手超时，0表示使用默认值10秒；连接、TLS握手、读取（响应头）超时可被服务和路由配置覆盖
+	TLSHandshakeTimeout time.Duration `yaml:"tls_handshake_timeout,omitempty" json:"tls_handshake_timeout,omitempty" mapstructure:"tls_handshake_timeout,omitempty"`
+
 	// 连接配置
 	FollowRedirects bool          `yaml:"follow_redirects" json:"follow_redirects" mapstructure:"follow_redirects"`    // 是否跟随重定向
 	KeepAlive       bool          `yaml:"keep_alive" json:"keep_alive" mapstructure:"keep_alive"`                      // 是否保持连接
 	MaxIdleConns    int           `yaml:"max_idle_conns" json:"max_idle_conns" mapstructure:"max_idle_conns"`          // 最大空闲连接数
 	IdleConnTimeout time.Duration `yaml:"idle_conn_timeout" json:"idle_conn_timeout" mapstructure:"idle_conn_timeout"` // 空闲连接超时
 
+	// DNS解析配置，后端以主机名配置时生效
+	DNSCacheTTL        time.Duration `yaml:"dns_cache_ttl,omitempty" json:"dns_cache_ttl,omitempty" mapstructure:"dns_cache_ttl,omitempty"`                      // DNS解析结果缓存时间，0表示不缓存，每次由系统解析器解析
+	DNSRefreshInterval time.Duration `yaml:"dns_refresh_interval,omitempty" json:"dns_refresh_interval,omitempty" mapstructure:"dns_refresh_interval,omitempty"` // 后台重新解析间隔，0表示使用缓存时间的一半
+
 	// 响应处理配置
 	CopyResponseBody bool `yaml:"copy_response_body" json:"copy_response_body" mapstructure:"copy_response_body"` // 是否复制响应体
 	BufferSize       int  `yaml:"buffer_size" json:"buffer_size" mapstructure:"buffer_size"`                      // 缓冲区大小
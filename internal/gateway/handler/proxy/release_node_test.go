@@ -0,0 +1,53 @@
+package proxy
+
+import (
+	"testing"
+
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/service"
+)
+
+// releaseCountingManager 记录 ReleaseNode 调用次数，只实现测试用到的方法
+type releaseCountingManager struct {
+	service.ServiceManager
+	released int
+}
+
+func (m *releaseCountingManager) ReleaseNode(serviceID, nodeID string) {
+	m.released++
+}
+
+// expectPanic 执行函数并确认发生了panic
+func expectPanic(t *testing.T, fn func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("期望发生panic")
+		}
+	}()
+	fn()
+}
+
+// TestProxyAttemptReleasesNodeOnPanic 验证代理请求发生panic时仍释放节点的在途请求
+func TestProxyAttemptReleasesNodeOnPanic(t *testing.T) {
+	manager := &releaseCountingManager{}
+	httpProxy := &HTTPProxy{serviceManager: manager}
+	multiProxy := &HTTPMultiServiceProxy{httpProxy: httpProxy}
+	node := &service.NodeConfig{ID: "node-1", URL: "http://127.0.0.1:8080"}
+	// 未设置请求的上下文会在代理过程中触发panic
+	ctx := &core.Context{}
+
+	expectPanic(t, func() {
+		httpProxy.proxyAttempt(ctx, "svc-1", &service.ServiceConfig{ID: "svc-1"}, node, 0)
+	})
+	if manager.released != 1 {
+		t.Fatalf("单服务代理panic后应释放节点1次，实际%d次", manager.released)
+	}
+
+	expectPanic(t, func() {
+		multiProxy.proxyAttemptToService(ctx, "svc-1", &service.ServiceConfig{ID: "svc-1"}, node, nil, 0)
+	})
+	if manager.released != 2 {
+		t.Fatalf("多服务代理panic后应释放节点，累计期望2次，实际%d次", manager.released)
+	}
+}
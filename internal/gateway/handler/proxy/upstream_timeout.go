@@ -0,0 +1,207 @@
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/service"
+)
+
+// 代理未配置对应超时时使用的默认值
+const (
+	defaultConnectTimeout        = 30 * time.Second
+	defaultTLSHandshakeTimeout   = 10 * time.Second
+	defaultResponseHeaderTimeout = 30 * time.Second
+)
+
+// errResponseHeaderTimeout 请求发送完成后等待后端响应头超时
+var errResponseHeaderTimeout = fmt.Errorf("%w: 等待后端响应头超时", errProxyRequestTimeout)
+
+// upstreamTimeoutsKey 转发请求上下文中保存本次转发生效的分阶段超时
+type upstreamTimeoutsKey struct{}
+
+// upstreamTimeouts 本次转发生效的分阶段超时，按 路由 > 服务 > 代理 的顺序逐项继承
+type upstreamTimeouts struct {
+	connect        time.Duration // 建立TCP连接超时
+	tlsHandshake   time.Duration // TLS握手超时
+	responseHeader time.Duration // 请求发送完成后等待响应头的超时
+	total          time.Duration // 请求总超时，0表示不限制
+}
+
+// override 用大于0的值逐项覆盖
+func (t *upstreamTimeouts) override(connect, tlsHandshake, responseHeader, total time.Duration) {
+	if connect > 0 {
+		t.connect = connect
+	}
+	if tlsHandshake > 0 {
+		t.tlsHandshake = tlsHandshake
+	}
+	if responseHeader > 0 {
+		t.responseHeader = responseHeader
+	}
+	if total > 0 {
+		t.total = total
+	}
+}
+
+// proxyUpstreamTimeouts 代理（网关实例）级别的默认超时
+// 连接超时未配置时沿用总超时，读取超时即等待响应头的超时
+func proxyUpstreamTimeouts(config HTTPProxyConfig) upstreamTimeouts {
+	timeouts := upstreamTimeouts{
+		connect:        defaultConnectTimeout,
+		tlsHandshake:   defaultTLSHandshakeTimeout,
+		responseHeader: defaultResponseHeaderTimeout,
+	}
+	timeouts.override(config.Timeout, 0, 0, config.Timeout)
+	timeouts.override(config.ConnectTimeout, config.TLSHandshakeTimeout, config.ReadTimeout, 0)
+	return timeouts
+}
+
+// resolveUpstreamTimeouts 解析转发到指定服务时生效的分阶段超时
+// 代理配置为默认值，服务配置覆盖代理，路由（开启覆盖代理超时后）再覆盖服务，各阶段独立继承
+func (h *HTTPProxy) resolveUpstreamTimeouts(ctx *core.Context, serviceConfig *service.ServiceConfig) upstreamTimeouts {
+	timeouts := proxyUpstreamTimeouts(h.GetHTTPConfig())
+	if serviceConfig != nil && serviceConfig.Timeouts != nil {
+		serviceTimeouts := serviceConfig.Timeouts
+		timeouts.override(serviceTimeouts.ConnectTimeout, serviceTimeouts.TLSHandshakeTimeout,
+			serviceTimeouts.ResponseHeaderTimeout, serviceTimeouts.Timeout)
+	}
+	timeouts.override(
+		routeDuration(ctx, constants.ContextKeyRouteConnectTimeout),
+		routeDuration(ctx, constants.ContextKeyRouteTLSTimeout),
+		routeDuration(ctx, constants.ContextKeyRouteHeaderTimeout),
+		routeDuration(ctx, constants.ContextKeyRouteTimeout),
+	)
+	return timeouts
+}
+
+// routeDuration 读取路由写入上下文的超时，未设置时返回0
+func routeDuration(ctx *core.Context, key string) time.Duration {
+	value, exists := ctx.Get(key)
+	if !exists {
+		return 0
+	}
+	duration, _ := value.(time.Duration)
+	return duration
+}
+
+// withUpstreamTimeouts 将本次转发生效的分阶段超时写入转发请求上下文，供拨号函数读取
+func withUpstreamTimeouts(ctx context.Context, timeouts upstreamTimeouts) context.Context {
+	return context.WithValue(ctx, upstreamTimeoutsKey{}, timeouts)
+}
+
+// upstreamTimeoutsFrom 读取转发请求上下文中的分阶段超时
+func upstreamTimeoutsFrom(ctx context.Context) (upstreamTimeouts, bool) {
+	timeouts, ok := ctx.Value(upstreamTimeoutsKey{}).(upstreamTimeouts)
+	return timeouts, ok
+}
+
+// dialFunc 建立网络连接的函数
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// withConnectTimeout 为拨号函数增加连接超时，优先使用转发请求上下文中的连接超时
+// Transport 拨号时保留请求上下文中的值但不继承取消，后台继续完成的拨号同样受该超时限制
+func withConnectTimeout(dial dialFunc, fallback time.Duration) dialFunc {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		timeout := fallback
+		if timeouts, ok := upstreamTimeoutsFrom(ctx); ok && timeouts.connect > 0 {
+			timeout = timeouts.connect
+		}
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		conn, err := dial(ctx, network, addr)
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("连接后端超时(%s): %w", timeout, err)
+		}
+		return conn, err
+	}
+}
+
+// upstreamTLSDialer 建立TLS连接的拨号函数，握手超时优先使用转发请求上下文中的TLS握手超时
+// Transport 的 TLSHandshakeTimeout 只能按传输层统一配置，因此由该函数完成握手
+func upstreamTLSDialer(dial dialFunc, tlsConfig *tls.Config, fallback time.Duration) dialFunc {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		config := &tls.Config{}
+		if tlsConfig != nil {
+			config = tlsConfig.Clone()
+		}
+		if config.ServerName == "" {
+			host, _, splitErr := net.SplitHostPort(addr)
+			if splitErr != nil {
+				host = addr
+			}
+			config.ServerName = host
+		}
+
+		timeout := fallback
+		if timeouts, ok := upstreamTimeoutsFrom(ctx); ok && timeouts.tlsHandshake > 0 {
+			timeout = timeouts.tlsHandshake
+		}
+		handshakeCtx := ctx
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			handshakeCtx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		tlsConn := tls.Client(conn, config)
+		if err := tlsConn.HandshakeContext(handshakeCtx); err != nil {
+			_ = conn.Close()
+			if errors.Is(handshakeCtx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("TLS握手超时(%s): %w", timeout, err)
+			}
+			return nil, err
+		}
+		return tlsConn, nil
+	}
+}
+
+// withResponseHeaderTimeout 请求发送完成后开始计时，超过等待响应头超时时以 errResponseHeaderTimeout 取消请求
+// 收到响应头后计时停止；返回的函数用于请求结束时停止计时
+func withResponseHeaderTimeout(req *http.Request, timeout time.Duration, cancel context.CancelCauseFunc) (*http.Request, func()) {
+	if timeout <= 0 {
+		return req, func() {}
+	}
+
+	var (
+		mu      sync.Mutex
+		timer   *time.Timer
+		stopped bool
+	)
+	stop := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		stopped = true
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+	trace := &httptrace.ClientTrace{
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+			if !stopped && timer == nil {
+				timer = time.AfterFunc(timeout, func() { cancel(errResponseHeaderTimeout) })
+			}
+		},
+		GotFirstResponseByte: stop,
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace)), stop
+}
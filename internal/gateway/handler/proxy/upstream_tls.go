@@ -0,0 +1,76 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+
+	"gateway/internal/gateway/handler/service"
+)
+
+// upstreamTLSClient 使用服务级上游TLS配置的HTTP客户端
+type upstreamTLSClient struct {
+	tlsConfig *service.UpstreamTLSConfig // 构建客户端时的配置，服务配置更新后指针变化，据此重建
+	client    *http.Client
+}
+
+// clientForService 获取转发到指定服务使用的HTTP客户端
+// 服务未配置上游TLS时使用代理级别的客户端；配置了上游TLS时复制代理级别的传输层配置
+// （连接池、超时、DNS缓存等），仅替换TLS设置，并按服务缓存，服务配置更新后自动重建。
+// 分阶段超时按转发请求从上下文读取，服务和路由级超时不需要单独的客户端
+func (h *HTTPProxy) clientForService(serviceConfig *service.ServiceConfig) (*http.Client, error) {
+	if serviceConfig == nil || serviceConfig.TLS == nil {
+		return h.client, nil
+	}
+
+	if cached, ok := h.tlsClients.Load(serviceConfig.ID); ok {
+		entry := cached.(*upstreamTLSClient)
+		if entry.tlsConfig == serviceConfig.TLS {
+			return entry.client, nil
+		}
+	}
+
+	baseTransport, ok := h.client.Transport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("代理传输层不支持服务级TLS配置")
+	}
+	tlsConfig, err := serviceConfig.TLS.BuildClientTLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("服务 %s 的上游TLS配置无效: %w", serviceConfig.ID, err)
+	}
+	// 未配置SNI和最低版本时沿用代理级别的设置
+	if base := baseTransport.TLSClientConfig; base != nil {
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = base.ServerName
+		}
+		if serviceConfig.TLS.MinVersion == "" && base.MinVersion > tlsConfig.MinVersion {
+			tlsConfig.MinVersion = base.MinVersion
+		}
+	}
+
+	transport := baseTransport.Clone()
+	transport.TLSClientConfig = tlsConfig
+	// TLS连接由拨号函数完成握手，按服务TLS配置重建（连接超时沿用代理传输层的拨号函数）
+	transport.DialTLSContext = upstreamTLSDialer(baseTransport.DialContext, tlsConfig, baseTransport.TLSHandshakeTimeout)
+	client := *h.client
+	client.Transport = transport
+
+	entry := &upstreamTLSClient{tlsConfig: serviceConfig.TLS, client: &client}
+	if previous, loaded := h.tlsClients.Swap(serviceConfig.ID, entry); loaded {
+		// 旧配置的空闲连接不再复用
+		if old, ok := previous.(*upstreamTLSClient); ok && old.client != nil {
+			old.client.CloseIdleConnections()
+		}
+	}
+	return entry.client, nil
+}
+
+// closeUpstreamTLSClients 关闭所有服务级TLS客户端的空闲连接
+func (h *HTTPProxy) closeUpstreamTLSClients() {
+	h.tlsClients.Range(func(key, value interface{}) bool {
+		if entry, ok := value.(*upstreamTLSClient); ok && entry.client != nil {
+			entry.client.CloseIdleConnections()
+		}
+		h.tlsClients.Delete(key)
+		return true
+	})
+}
@@ -146,6 +146,8 @@ func (b *WebSocketBridge) Proxy(ctx *core.Context, proxyName, proxyType string)
 		b.failed.Add(1)
 		return fmt.Errorf("选择目标节点失败: %w", err)
 	}
+	// 会话结束后释放负载均衡器统计的在途请求
+	defer b.serviceManager.ReleaseNode(serviceID, node.ID)
 	config := b.resolveConfig(serviceConfig)
 	if len(config.Subprotocols) == 0 {
 		config.Subprotocols = websocket.Subprotocols(ctx.Request)
@@ -164,7 +166,7 @@ func (b *WebSocketBridge) Proxy(ctx *core.Context, proxyName, proxyType string)
 	var responseHeaders map[string][]string
 	var responseErr error
 
-	targetConn, response, err := b.connectTarget(targetURL, ctx.Request, &config)
+	targetConn, response, err := b.connectTarget(targetURL, ctx.Request, &config, serviceConfig.TLS)
 	if err != nil {
 		b.failed.Add(1)
 		responseErr = err
@@ -360,7 +362,7 @@ func (b *WebSocketBridge) buildTargetURL(ctx *core.Context, targetValue string)
 	}, nil
 }
 
-func (b *WebSocketBridge) connectTarget(targetURL *url.URL, req *http.Request, config *WebSocketConfig) (*websocket.Conn, *http.Response, error) {
+func (b *WebSocketBridge) connectTarget(targetURL *url.URL, req *http.Request, config *WebSocketConfig, upstreamTLS *service.UpstreamTLSConfig) (*websocket.Conn, *http.Response, error) {
 	dialer := websocket.Dialer{
 		HandshakeTimeout:  10 * time.Second,
 		ReadBufferSize:    config.ReadBufferSize,
@@ -368,6 +370,14 @@ func (b *WebSocketBridge) connectTarget(targetURL *url.URL, req *http.Request, c
 		EnableCompression: config.EnableCompression,
 		Subprotocols:      config.Subprotocols,
 	}
+	// wss上游使用服务级别的TLS配置（CA证书、客户端证书、SNI）
+	if upstreamTLS != nil && targetURL.Scheme == "wss" {
+		tlsConfig, err := upstreamTLS.BuildClientTLSConfig()
+		if err != nil {
+			return nil, nil, fmt.Errorf("上游TLS配置无效: %w", err)
+		}
+		dialer.TLSClientConfig = tlsConfig
+	}
 	headers := make(http.Header)
 	for name, values := range req.Header {
 		if isWebSocketHeader(name) || isHopByHopHeader(name) {
@@ -0,0 +1,162 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultCoalesceKeyTemplate 默认请求合并键模板
+// 包含认证头和Cookie，避免不同用户的请求被合并后互相拿到对方的响应
+const DefaultCoalesceKeyTemplate = "{method} {host}{path}?{query} {header.Authorization} {header.Cookie}"
+
+// DefaultCoalesceMaxBodyBytes 默认可共享的最大响应体大小（4MB）
+const DefaultCoalesceMaxBodyBytes int64 = 4 << 20
+
+// CoalesceConfig 路由级请求合并配置
+// 开启后，同一路由下合并键相同的并发GET请求只向后端转发一次，响应分发给所有等待的请求
+type CoalesceConfig struct {
+	// 是否启用请求合并
+	Enabled bool `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
+
+	// 合并键模板，为空时使用 DefaultCoalesceKeyTemplate
+	// 支持的占位符:
+	// - {method}、{host}、{path}: 请求方法、Host和路径
+	// - {query}: 按参数名排序后的完整查询字符串
+	// - {query.name}: 指定查询参数
+	// - {header.Name}: 指定请求头
+	// - {cookie.name}: 指定Cookie
+	KeyTemplate string `json:"key_template,omitempty" yaml:"key_template,omitempty" mapstructure:"key_template,omitempty"`
+
+	// 可共享的最大响应体字节数，超过时等待的请求各自转发，0表示使用默认值
+	MaxBodyBytes int64 `json:"max_body_bytes,omitempty" yaml:"max_body_bytes,omitempty" mapstructure:"max_body_bytes,omitempty"`
+}
+
+// CoalescePolicy 编译后的路由请求合并策略，由路由写入请求上下文供HTTP代理使用
+type CoalescePolicy struct {
+	// 路由ID，作为合并键前缀，保证不同路由之间不会合并
+	RouteID string
+
+	// 可共享的最大响应体字节数
+	MaxBodyBytes int64
+
+	// 合并键模板片段
+	parts []coalesceKeyPart
+}
+
+// coalesceKeyPart 合并键模板片段：字面量或占位符
+type coalesceKeyPart struct {
+	literal string
+	source  string // 占位符来源: method/host/path/query/query./header./cookie.，为空表示字面量
+	name    string // query./header./cookie. 占位符的参数名
+}
+
+// NewCoalescePolicy 根据路由配置编译请求合并策略
+// 参数:
+// - routeID: 路由ID
+// - config: 请求合并配置
+// 返回值:
+// - *CoalescePolicy: 编译后的策略，配置为空或未启用时返回nil
+// - error: 合并键模板无效时返回错误
+func NewCoalescePolicy(routeID string, config *CoalesceConfig) (*CoalescePolicy, error) {
+	if config == nil || !config.Enabled {
+		return nil, nil
+	}
+
+	template := strings.TrimSpace(config.KeyTemplate)
+	if template == "" {
+		template = DefaultCoalesceKeyTemplate
+	}
+	parts, err := parseCoalesceKeyTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+
+	maxBodyBytes := config.MaxBodyBytes
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultCoalesceMaxBodyBytes
+	}
+	return &CoalescePolicy{
+		RouteID:      routeID,
+		MaxBodyBytes: maxBodyBytes,
+		parts:        parts,
+	}, nil
+}
+
+// parseCoalesceKeyTemplate 将合并键模板解析为片段列表
+func parseCoalesceKeyTemplate(template string) ([]coalesceKeyPart, error) {
+	var parts []coalesceKeyPart
+	rest := template
+	for rest != "" {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			parts = append(parts, coalesceKeyPart{literal: rest})
+			break
+		}
+		if start > 0 {
+			parts = append(parts, coalesceKeyPart{literal: rest[:start]})
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("invalid coalesce key template %q: unclosed placeholder", template)
+		}
+		placeholder := strings.TrimSpace(rest[start+1 : start+end])
+		part, err := parseCoalesceKeyPlaceholder(placeholder)
+		if err != nil {
+			return nil, fmt.Errorf("invalid coalesce key template %q: %w", template, err)
+		}
+		parts = append(parts, part)
+		rest = rest[start+end+1:]
+	}
+	return parts, nil
+}
+
+// parseCoalesceKeyPlaceholder 解析单个占位符
+func parseCoalesceKeyPlaceholder(placeholder string) (coalesceKeyPart, error) {
+	switch placeholder {
+	case "method", "host", "path", "query":
+		return coalesceKeyPart{source: placeholder}, nil
+	}
+	for _, prefix := range []string{"query.", "header.", "cookie."} {
+		if !strings.HasPrefix(placeholder, prefix) {
+			continue
+		}
+		name := strings.TrimSpace(strings.TrimPrefix(placeholder, prefix))
+		if name == "" {
+			return coalesceKeyPart{}, fmt.Errorf("placeholder {%s} requires a name", placeholder)
+		}
+		return coalesceKeyPart{source: prefix, name: name}, nil
+	}
+	return coalesceKeyPart{}, fmt.Errorf("unknown placeholder {%s}", placeholder)
+}
+
+// Key 按模板生成请求的合并键
+// 合并键以路由ID开头，同一路由下合并键相同的请求视为相同请求
+func (p *CoalescePolicy) Key(req *http.Request) string {
+	var builder strings.Builder
+	builder.WriteString(p.RouteID)
+	builder.WriteByte('\n')
+	for _, part := range p.parts {
+		switch part.source {
+		case "":
+			builder.WriteString(part.literal)
+		case "method":
+			builder.WriteString(req.Method)
+		case "host":
+			builder.WriteString(req.Host)
+		case "path":
+			builder.WriteString(req.URL.Path)
+		case "query":
+			builder.WriteString(req.URL.Query().Encode())
+		case "query.":
+			builder.WriteString(strings.Join(req.URL.Query()[part.name], ","))
+		case "header.":
+			builder.WriteString(strings.Join(req.Header.Values(part.name), ","))
+		case "cookie.":
+			if cookie, err := req.Cookie(part.name); err == nil {
+				builder.WriteString(cookie.Value)
+			}
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,163 @@
+package router
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultIdempotencyHeader 默认幂等键请求头
+const DefaultIdempotencyHeader = "Idempotency-Key"
+
+// DefaultIdempotencyTTL 默认幂等响应缓存时间
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// DefaultIdempotencyMaxBodyBytes 默认可缓存的最大响应体大小（1MB）
+const DefaultIdempotencyMaxBodyBytes int64 = 1 << 20
+
+// maxIdempotencyKeyLength 幂等键最大长度，超长的键不参与去重
+const maxIdempotencyKeyLength = 255
+
+// DefaultIdempotencyMethods 默认参与幂等去重的请求方法（非幂等或可能产生副作用的方法）
+var DefaultIdempotencyMethods = []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// IdempotencyConfig 路由级幂等键去重配置
+// 开启后，同一路由下携带相同幂等键的请求只向后端转发一次：首个完成的响应按TTL缓存，
+// 重复请求（客户端重试）直接返回缓存的响应，保护支付类不可重入的后端
+type IdempotencyConfig struct {
+	// 是否启用幂等键去重
+	Enabled bool `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
+
+	// 幂等键请求头，为空时使用 DefaultIdempotencyHeader
+	Header string `json:"header,omitempty" yaml:"header,omitempty" mapstructure:"header,omitempty"`
+
+	// 响应缓存时间，0表示使用默认值
+	TTL time.Duration `json:"ttl,omitempty" yaml:"ttl,omitempty" mapstructure:"ttl,omitempty"`
+
+	// 可缓存的最大响应体字节数，超过时不缓存，0表示使用默认值
+	MaxBodyBytes int64 `json:"max_body_bytes,omitempty" yaml:"max_body_bytes,omitempty" mapstructure:"max_body_bytes,omitempty"`
+
+	// 参与去重的请求方法，为空时使用 DefaultIdempotencyMethods
+	Methods []string `json:"methods,omitempty" yaml:"methods,omitempty" mapstructure:"methods,omitempty"`
+}
+
+// IdempotencyPolicy 编译后的路由幂等键去重策略，由路由写入请求上下文供HTTP代理使用
+type IdempotencyPolicy struct {
+	// 路由ID，作为幂等键前缀，保证不同路由之间不会共享响应
+	RouteID string
+
+	// 幂等键请求头
+	Header string
+
+	// 响应缓存时间
+	TTL time.Duration
+
+	// 可缓存的最大响应体字节数
+	MaxBodyBytes int64
+
+	// 参与去重的请求方法
+	methods map[string]struct{}
+}
+
+// NewIdempotencyPolicy 根据路由配置编译幂等键去重策略
+// 参数:
+// - routeID: 路由ID
+// - config: 幂等键去重配置
+// 返回值:
+// - *IdempotencyPolicy: 编译后的策略，配置为空或未启用时返回nil
+// - error: 配置无效时返回错误
+func NewIdempotencyPolicy(routeID string, config *IdempotencyConfig) (*IdempotencyPolicy, error) {
+	if config == nil || !config.Enabled {
+		return nil, nil
+	}
+	if config.TTL < 0 {
+		return nil, fmt.Errorf("invalid idempotency ttl %s: must not be negative", config.TTL)
+	}
+
+	header := strings.TrimSpace(config.Header)
+	if header == "" {
+		header = DefaultIdempotencyHeader
+	}
+	ttl := config.TTL
+	if ttl == 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	maxBodyBytes := config.MaxBodyBytes
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultIdempotencyMaxBodyBytes
+	}
+
+	methods := config.Methods
+	if len(methods) == 0 {
+		methods = DefaultIdempotencyMethods
+	}
+	methodSet := make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		method = strings.ToUpper(strings.TrimSpace(method))
+		if method == "" {
+			continue
+		}
+		methodSet[method] = struct{}{}
+	}
+	if len(methodSet) == 0 {
+		return nil, fmt.Errorf("invalid idempotency methods: at least one method is required")
+	}
+
+	return &IdempotencyPolicy{
+		RouteID:      routeID,
+		Header:       http.CanonicalHeaderKey(header),
+		TTL:          ttl,
+		MaxBodyBytes: maxBodyBytes,
+		methods:      methodSet,
+	}, nil
+}
+
+// Key 生成请求的幂等键
+// 幂等键以路由ID开头并包含认证头、Cookie和网关认证得到的身份（如API Key摘要），
+// 不同路由、不同用户使用相同的幂等键不会拿到对方的响应；认证信息以摘要形式保存在键中
+// 参数:
+// - req: 客户端请求
+// - identity: 网关认证得到的调用方身份，未认证时为空
+// 返回值:
+// - string: 幂等键
+// - bool: 请求是否参与去重，方法不匹配、未携带幂等键或幂等键超长时为false
+func (p *IdempotencyPolicy) Key(req *http.Request, identity string) (string, bool) {
+	if _, ok := p.methods[req.Method]; !ok {
+		return "", false
+	}
+	value := strings.TrimSpace(req.Header.Get(p.Header))
+	if value == "" || len(value) > maxIdempotencyKeyLength {
+		return "", false
+	}
+
+	hash := sha256.New()
+	for _, part := range []string{req.Header.Get("Authorization"), req.Header.Get("Cookie"), identity, value} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{'\n'})
+	}
+	return p.RouteID + "\n" + hex.EncodeToString(hash.Sum(nil)), true
+}
+
+// Fingerprint 计算请求指纹（请求方法和请求体的摘要）
+// 相同幂等键的请求指纹不一致时说明客户端复用了幂等键发送不同的请求，不能返回缓存的响应。
+// 读取请求体后会重置 req.Body，后续转发不受影响
+func (p *IdempotencyPolicy) Fingerprint(req *http.Request) (string, error) {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method))
+	hash.Write([]byte{'\n'})
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return "", fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		hash.Write(body)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
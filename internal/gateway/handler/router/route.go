@@ -136,6 +136,12 @@ type RouteConfig struct {
 	EnableWebSocket bool `json:"enable_websocket,omitempty" yaml:"enable_websocket,omitempty" mapstructure:"enable_websocket,omitempty"`
 	// Timeout 是路由请求总超时；仅当 OverrideProxyTimeout 且值大于0时覆盖代理。
 	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout,omitempty"`
+	// ConnectTimeout 是路由级连接超时；仅当 OverrideProxyTimeout 且值大于0时覆盖服务和代理。
+	ConnectTimeout time.Duration `json:"connect_timeout,omitempty" yaml:"connect_timeout,omitempty" mapstructure:"connect_timeout,omitempty"`
+	// TLSHandshakeTimeout 是路由级TLS握手超时；仅当 OverrideProxyTimeout 且值大于0时覆盖服务和代理。
+	TLSHandshakeTimeout time.Duration `json:"tls_handshake_timeout,omitempty" yaml:"tls_handshake_timeout,omitempty" mapstructure:"tls_handshake_timeout,omitempty"`
+	// ResponseHeaderTimeout 是路由级等待响应头超时；仅当 OverrideProxyTimeout 且值大于0时覆盖服务和代理。
+	ResponseHeaderTimeout time.Duration `json:"response_header_timeout,omitempty" yaml:"response_header_timeout,omitempty" mapstructure:"response_header_timeout,omitempty"`
 	// RetryCount 是路由级重试次数；须开启 OverrideProxyTimeout，且与 RetryInterval 同时大于0才覆盖代理。
 	RetryCount int `json:"retry_count,omitempty" yaml:"retry_count,omitempty" mapstructure:"retry_count,omitempty"`
 	// RetryInterval 是路由级重试间隔；须开启 OverrideProxyTimeout，且与 RetryCount 同时大于0才覆盖代理。
@@ -143,6 +149,10 @@ type RouteConfig struct {
 	// OverrideProxyTimeout 为true时，timeoutMs/重试才可覆盖代理；默认 false 兼容历史。
 	// 仅当 routeMetadata.overrideProxyTimeout 精确为 "Y" 时开启。
 	OverrideProxyTimeout bool `json:"override_proxy_timeout,omitempty" yaml:"override_proxy_timeout,omitempty" mapstructure:"override_proxy_timeout,omitempty"`
+	// CoalesceConfig 是路由级请求合并配置；为空或未启用时不合并，不受 OverrideProxyTimeout 影响。
+	CoalesceConfig *CoalesceConfig `json:"coalesce_config,omitempty" yaml:"coalesce_config,omitempty" mapstructure:"coalesce_config,omitempty"`
+	// IdempotencyConfig 是路由级幂等键去重配置；为空或未启用时不去重，不受 OverrideProxyTimeout 影响。
+	IdempotencyConfig *IdempotencyConfig `json:"idempotency_config,omitempty" yaml:"idempotency_config,omitempty" mapstructure:"idempotency_config,omitempty"`
 	// WebSocketPolicyConfigured 标记数据库路由已显式提供WebSocket开关。
 	WebSocketPolicyConfigured bool `json:"-" yaml:"-" mapstructure:"-"`
 
@@ -220,11 +230,18 @@ type Route struct {
 	// 编译后的正则表达式，用于正则匹配模式
 	compiledRegex *regexp.Regexp
 
+	// 编译后的请求合并策略，未启用时为nil
+	coalescePolicy *CoalescePolicy
+
+	// 编译后的幂等键去重策略，未启用时为nil
+	idempotencyPolicy *IdempotencyPolicy
+
 	// 功能模块处理器
-	corsHandler     cors.CORSHandler
-	limiterHandler  limiter.LimiterHandler
-	authHandler     auth.Authenticator
-	securityHandler security.SecurityHandler
+	corsHandler      cors.CORSHandler
+	limiterHandler   limiter.LimiterHandler
+	bandwidthLimiter *limiter.BandwidthLimiter
+	authHandler      auth.Authenticator
+	securityHandler  security.SecurityHandler
 }
 
 // NewRoute 创建新的路由实例
@@ -281,6 +298,20 @@ func NewRoute(config RouteConfig) (*Route, error) {
 		route.compiledRegex = compiledRegex
 	}
 
+	// 编译请求合并策略
+	coalescePolicy, err := NewCoalescePolicy(config.ID, config.CoalesceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create coalesce policy failed: %w", err)
+	}
+	route.coalescePolicy = coalescePolicy
+
+	// 编译幂等键去重策略
+	idempotencyPolicy, err := NewIdempotencyPolicy(config.ID, config.IdempotencyConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create idempotency policy failed: %w", err)
+	}
+	route.idempotencyPolicy = idempotencyPolicy
+
 	// 初始化功能模块处理器
 	if err := route.initHandlers(); err != nil {
 		return nil, fmt.Errorf("init handlers failed: %w", err)
@@ -311,6 +342,11 @@ func (r *Route) initHandlers() error {
 		r.limiterHandler = limiterHandler
 	}
 
+	// 初始化带宽限制器，与请求数限流共用限流键策略
+	if r.config.LimiterConfig != nil && r.config.LimiterConfig.Enabled && r.config.LimiterConfig.Bandwidth.IsActive() {
+		r.bandwidthLimiter = limiter.NewBandwidthLimiter(r.config.LimiterConfig.Bandwidth, r.config.LimiterConfig.KeyStrategy)
+	}
+
 	// 初始化认证处理器
 	if r.config.AuthConfig != nil && r.config.AuthConfig.Strategy != auth.StrategyNoAuth {
 		// 使用工厂创建认证处理器
@@ -346,6 +382,7 @@ func (r *Route) Handle(ctx *core.Context) bool {
 	ctx.SetRouteID(r.config.ID)
 	//设置路由名称
 	ctx.Set(constants.ContextKeyRouteConfigName, r.config.Name)
+	ctx.AddLogFields("routeId", r.config.ID)
 	ctx.SetMatchedPath(r.config.Path)
 	r.applyRuntimePolicies(ctx)
 
@@ -395,12 +432,16 @@ func (r *Route) Handle(ctx *core.Context) bool {
 			return false
 		}
 	}
+	// 带宽限制不拒绝请求，只为请求体和响应写入器限速
+	if r.bandwidthLimiter != nil {
+		r.bandwidthLimiter.Apply(ctx)
+	}
 
 	// 5. 执行路由级别过滤器
 	if len(r.routeFilters) > 0 {
 		// 按照过滤器的定义执行不同阶段的过滤器
 		for _, f := range r.routeFilters {
-			if !f.IsEnabled() {
+			if !filter.ShouldApply(ctx, f) {
 				continue
 			}
 			if err := f.Apply(ctx); err != nil {
@@ -425,6 +466,14 @@ func (r *Route) applyRuntimePolicies(ctx *core.Context) {
 	if r.config.WebSocketPolicyConfigured || r.config.EnableWebSocket {
 		ctx.Set(constants.ContextKeyRouteEnableWebSocket, r.config.EnableWebSocket)
 	}
+	// 请求合并只对GET生效，合并键由代理在过滤器执行后按最终请求生成。
+	if r.coalescePolicy != nil {
+		ctx.Set(constants.ContextKeyRouteCoalescePolicy, r.coalescePolicy)
+	}
+	// 幂等键去重在代理转发时按最终请求判断方法和幂等键。
+	if r.idempotencyPolicy != nil {
+		ctx.Set(constants.ContextKeyRouteIdempotency, r.idempotencyPolicy)
+	}
 	// 未开启覆盖时，超时与重试一律走代理，避免历史 timeoutMs/retry 默认值误覆盖。
 	if !r.config.OverrideProxyTimeout {
 		return
@@ -432,6 +481,16 @@ func (r *Route) applyRuntimePolicies(ctx *core.Context) {
 	if r.config.Timeout > 0 {
 		ctx.Set(constants.ContextKeyRouteTimeout, r.config.Timeout)
 	}
+	// 分阶段超时逐项覆盖，未配置的阶段沿用服务和代理配置
+	if r.config.ConnectTimeout > 0 {
+		ctx.Set(constants.ContextKeyRouteConnectTimeout, r.config.ConnectTimeout)
+	}
+	if r.config.TLSHandshakeTimeout > 0 {
+		ctx.Set(constants.ContextKeyRouteTLSTimeout, r.config.TLSHandshakeTimeout)
+	}
+	if r.config.ResponseHeaderTimeout > 0 {
+		ctx.Set(constants.ContextKeyRouteHeaderTimeout, r.config.ResponseHeaderTimeout)
+	}
 	// 重试次数与间隔需同时大于0才覆盖代理；缺一则整组沿用代理重试配置。
 	if r.config.RetryCount > 0 && r.config.RetryInterval > 0 {
 		ctx.Set(constants.ContextKeyRouteRetryCount, r.config.RetryCount)
@@ -140,7 +140,7 @@ func (r *Router) Handle(ctx *core.Context) bool {
 	// 应用全局前置过滤器
 	if len(r.routerFilters) > 0 {
 		for _, f := range r.routerFilters {
-			if !f.IsEnabled() {
+			if !filter.ShouldApply(ctx, f) {
 				continue
 			}
 
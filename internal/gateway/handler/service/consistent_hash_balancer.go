@@ -3,7 +3,9 @@ package service
 import (
 	"crypto/md5"
 	"fmt"
+	"math"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,11 +13,17 @@ import (
 )
 
 // ConsistentHashBalancer 一致性哈希负载均衡器
+// 配置 LoadFactor 时使用有界负载（Consistent Hashing with Bounded Loads）变体：
+// 每个节点的在途请求数不超过 ceil(LoadFactor * (总在途请求数+1) * 节点权重 / 总权重)，
+// 哈希命中的节点已满时沿哈希环顺时针选择下一个未满的节点，既保持键的亲和性又避免热点键压垮单个节点
 type ConsistentHashBalancer struct {
 	*BaseLoadBalancer
 	ring       map[uint32]string // hash -> nodeID
 	sortedKeys []uint32
+	ringNodes  string // 构建哈希环时的节点签名，节点或权重变化时重建哈希环
 	replicas   int
+	loadFactor float64        // 有界负载系数，0表示不限制
+	loads      map[string]int // nodeID -> 在途请求数，仅有界负载时统计
 	mu         sync.RWMutex
 }
 
@@ -25,20 +33,28 @@ func NewConsistentHashBalancer(config *LoadBalancerConfig) LoadBalancer {
 		config = &DefaultConfig
 	}
 
+	// 负载系数小于1时任何分配都会超限，按1处理（所有节点严格平均）
+	loadFactor := config.LoadFactor
+	if loadFactor > 0 && loadFactor < 1 {
+		loadFactor = 1
+	}
+
 	return &ConsistentHashBalancer{
 		BaseLoadBalancer: NewBaseLoadBalancer(config),
 		ring:             make(map[uint32]string),
 		replicas:         150, // 虚拟节点数量
+		loadFactor:       loadFactor,
+		loads:            make(map[string]int),
 	}
 }
 
 // Select 使用一致性哈希选择节点
 // 一致性哈希算法说明：
 // 1. 将节点映射到哈希环上（每个节点根据权重创建多个虚拟节点）
-// 2. 将请求的key（通常是客户端IP）也映射到哈希环上
-// 3. 在环上顺时针查找第一个节点
+// 2. 将请求的key（按 HashOn 配置取客户端IP、请求头、路径或查询参数）也映射到哈希环上
+// 3. 在环上顺时针查找第一个节点，有界负载时跳过在途请求数已满的节点
 // 优点：节点变化时，只有少量请求会重新路由，适合缓存场景
-// 注意：如果节点列表变化，需要调用 Reset() 清除哈希环，下次选择时会重新构建
+// 注意：有界负载时选中的节点计入在途请求，请求完成后需要调用 ReleaseNode 释放
 func (c *ConsistentHashBalancer) Select(service *ServiceConfig, ctx *core.Context) *NodeConfig {
 	if len(service.Nodes) == 0 {
 		return nil
@@ -57,6 +73,11 @@ func (c *ConsistentHashBalancer) Select(service *ServiceConfig, ctx *core.Contex
 	}
 
 	if len(healthyNodes) == 1 {
+		if c.loadFactor > 0 {
+			c.mu.Lock()
+			c.loads[healthyNodes[0].ID]++
+			c.mu.Unlock()
+		}
 		return healthyNodes[0]
 	}
 
@@ -67,6 +88,10 @@ func (c *ConsistentHashBalancer) Select(service *ServiceConfig, ctx *core.Contex
 	key := c.getHashKey(ctx)
 	hash := c.hashFunc(key)
 
+	if c.loadFactor > 0 {
+		return c.selectBounded(hash, healthyNodes)
+	}
+
 	// 在环中查找节点
 	nodeID := c.getNode(hash)
 	if nodeID == "" {
@@ -84,6 +109,69 @@ func (c *ConsistentHashBalancer) Select(service *ServiceConfig, ctx *core.Contex
 	return healthyNodes[0]
 }
 
+// selectBounded 有界负载选择节点
+// 从哈希位置顺时针遍历哈希环，返回第一个在途请求数未达到容量上限的节点并计入在途请求；
+// 负载系数不小于1时总有节点未满，哈希环与节点列表不一致时退化为选择负载率最低的节点
+func (c *ConsistentHashBalancer) selectBounded(hash uint32, nodes []*NodeConfig) *NodeConfig {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	nodeByID := make(map[string]*NodeConfig, len(nodes))
+	totalWeight, inFlight := 0, 0
+	for _, node := range nodes {
+		nodeByID[node.ID] = node
+		totalWeight += nodeWeight(node)
+		inFlight += c.loads[node.ID]
+	}
+
+	capacity := func(node *NodeConfig) int {
+		return int(math.Ceil(c.loadFactor * float64(inFlight+1) * float64(nodeWeight(node)) / float64(totalWeight)))
+	}
+
+	if len(c.sortedKeys) > 0 {
+		start := sort.Search(len(c.sortedKeys), func(i int) bool {
+			return c.sortedKeys[i] >= hash
+		})
+		visited := make(map[string]bool, len(nodes))
+		for i := 0; i < len(c.sortedKeys) && len(visited) < len(nodeByID); i++ {
+			nodeID := c.ring[c.sortedKeys[(start+i)%len(c.sortedKeys)]]
+			node, ok := nodeByID[nodeID]
+			if !ok || visited[nodeID] {
+				continue
+			}
+			visited[nodeID] = true
+			if c.loads[nodeID] < capacity(node) {
+				c.loads[nodeID]++
+				return node
+			}
+		}
+	}
+
+	selected := nodes[0]
+	for _, node := range nodes[1:] {
+		if float64(c.loads[node.ID])/float64(nodeWeight(node)) < float64(c.loads[selected.ID])/float64(nodeWeight(selected)) {
+			selected = node
+		}
+	}
+	c.loads[selected.ID]++
+	return selected
+}
+
+// ReleaseNode 释放节点上的一个在途请求
+func (c *ConsistentHashBalancer) ReleaseNode(nodeID string) {
+	if c.loadFactor <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.loads[nodeID] > 1 {
+		c.loads[nodeID]--
+	} else {
+		delete(c.loads, nodeID)
+	}
+}
+
 // GetStrategy 获取策略
 func (c *ConsistentHashBalancer) GetStrategy() Strategy {
 	return ConsistentHash
@@ -97,6 +185,7 @@ func (c *ConsistentHashBalancer) UpdateNodeWeight(serviceID, nodeID string, weig
 	// 清除环，下次选择时重新构建
 	c.ring = make(map[uint32]string)
 	c.sortedKeys = nil
+	c.ringNodes = ""
 	return nil
 }
 
@@ -109,6 +198,15 @@ func (c *ConsistentHashBalancer) GetStats() map[string]interface{} {
 	stats["strategy"] = "consistent-hash"
 	stats["replicas"] = c.replicas
 	stats["ring_size"] = len(c.ring)
+	stats["hash_on"] = string(c.hashOn())
+	if c.loadFactor > 0 {
+		stats["load_factor"] = c.loadFactor
+		loads := make(map[string]int, len(c.loads))
+		for nodeID, load := range c.loads {
+			loads[nodeID] = load
+		}
+		stats["in_flight"] = loads
+	}
 	return stats
 }
 
@@ -118,28 +216,28 @@ func (c *ConsistentHashBalancer) Reset() {
 	defer c.mu.Unlock()
 	c.ring = make(map[uint32]string)
 	c.sortedKeys = nil
+	c.ringNodes = ""
+	c.loads = make(map[string]int)
 }
 
 // buildRing 构建哈希环
-// 注意：此方法会检查环是否已构建，如果已构建则直接返回
-// 这可能导致节点变化时环不会更新，需要在节点变化时调用 Reset() 清除环
+// 按节点ID和权重计算签名，签名与已构建的哈希环一致时直接返回，
+// 节点上下线（包括注册中心实例变化）或权重变化时自动重建
 func (c *ConsistentHashBalancer) buildRing(nodes []*NodeConfig) {
+	signature := ringSignature(nodes)
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// 如果环已构建且节点数量相同，则不需要重建
-	// 注意：这里只检查环是否为空，不检查节点是否变化
-	// 如果节点发生变化，需要先调用 Reset() 清除环
-	if len(c.ring) > 0 {
+	if len(c.ring) > 0 && c.ringNodes == signature {
 		return // 已经构建过
 	}
 
 	// 构建哈希环
+	c.ring = make(map[uint32]string)
+	c.ringNodes = signature
 	for _, node := range nodes {
-		weight := node.Weight
-		if weight <= 0 {
-			weight = 1 // 默认权重为1
-		}
+		weight := nodeWeight(node)
 
 		// 根据权重创建虚拟节点
 		// 权重越大，虚拟节点越多，被选中的概率越高
@@ -183,9 +281,52 @@ func (c *ConsistentHashBalancer) getNode(hash uint32) string {
 	return c.ring[c.sortedKeys[idx]]
 }
 
+// ringSignature 计算节点签名，节点顺序不影响结果
+func ringSignature(nodes []*NodeConfig) string {
+	parts := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		parts = append(parts, fmt.Sprintf("%s:%d", node.ID, nodeWeight(node)))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// nodeWeight 节点权重，未配置时为1
+func nodeWeight(node *NodeConfig) int {
+	if node.Weight <= 0 {
+		return 1
+	}
+	return node.Weight
+}
+
+// hashOn 哈希键来源，未配置时按客户端IP
+func (c *ConsistentHashBalancer) hashOn() HashOn {
+	if hashOn := c.GetConfig().HashOn; hashOn != "" {
+		return hashOn
+	}
+	return HashOnIP
+}
+
 // getHashKey 获取哈希键
+// 按请求头或查询参数哈希但请求中没有该值时，退化为按客户端IP哈希
 func (c *ConsistentHashBalancer) getHashKey(ctx *core.Context) string {
-	// 优先使用IP
+	config := c.GetConfig()
+	switch c.hashOn() {
+	case HashOnHeader:
+		if value := ctx.Request.Header.Get(config.HashKey); value != "" {
+			return value
+		}
+	case HashOnQuery:
+		if value := ctx.Request.URL.Query().Get(config.HashKey); value != "" {
+			return value
+		}
+	case HashOnPath:
+		if path := ctx.Request.URL.Path; path != "" {
+			return path
+		}
+	}
+
+	// 默认使用IP
 	if ip := c.getClientIP(ctx); ip != "" {
 		return ip
 	}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"sync"
 	"time"
+
+	"gateway/pkg/crash"
 )
 
 // HTTPHealthChecker HTTP健康检查器
@@ -237,7 +239,8 @@ func (h *HTTPHealthChecker) checkNodeHealth(status *nodeHealthStatus) {
 			go func(cb HealthCheckCallback) {
 				defer func() {
 					if r := recover(); r != nil {
-						// 忽略回调中的 panic，防止影响其他回调
+						// 回调中的 panic 只记录崩溃信息，防止影响其他回调
+						crash.Handle("gateway.healthcheck.callback", r)
 					}
 				}()
 				cb(nodeID, newHealth)
@@ -266,7 +269,8 @@ func (h *HTTPHealthChecker) notifyCallbacks(nodeID string, healthy bool) {
 			go func() {
 				defer func() {
 					if r := recover(); r != nil {
-						// 忽略回调中的 panic，防止影响其他回调
+						// 回调中的 panic 只记录崩溃信息，防止影响其他回调
+						crash.Handle("gateway.healthcheck.callback", r)
 					}
 					close(done)
 				}()
@@ -623,7 +627,8 @@ func (a *AdvancedHealthChecker) checkNodeHealth(status *nodeHealthStatus) {
 			go func(cb HealthCheckCallback) {
 				defer func() {
 					if r := recover(); r != nil {
-						// 忽略回调中的 panic，防止影响其他回调
+						// 回调中的 panic 只记录崩溃信息，防止影响其他回调
+						crash.Handle("gateway.healthcheck.callback", r)
 					}
 				}()
 				cb(nodeID, newHealth)
@@ -652,7 +657,8 @@ func (a *AdvancedHealthChecker) notifyCallbacks(nodeID string, healthy bool) {
 			go func() {
 				defer func() {
 					if r := recover(); r != nil {
-						// 忽略回调中的 panic，防止影响其他回调
+						// 回调中的 panic 只记录崩溃信息，防止影响其他回调
+						crash.Handle("gateway.healthcheck.callback", r)
 					}
 					close(done)
 				}()
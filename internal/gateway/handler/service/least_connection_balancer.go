@@ -26,7 +26,7 @@ func NewLeastConnBalancer(config *LoadBalancerConfig) LoadBalancer {
 }
 
 // Select 选择连接数最少的节点
-// 注意：此方法会增加选中节点的连接计数，需要在请求完成后调用 ReleaseConnection 释放
+// 注意：此方法会增加选中节点的连接计数，代理在请求完成后通过 ReleaseNode 释放
 // 如果忘记释放连接，会导致连接计数不准确，影响负载均衡效果
 func (l *LeastConnectionBalancer) Select(service *ServiceConfig, ctx *core.Context) *NodeConfig {
 	if len(service.Nodes) == 0 {
@@ -122,3 +122,8 @@ func (l *LeastConnectionBalancer) ReleaseConnection(nodeID string) {
 		l.connections[nodeID]--
 	}
 }
+
+// ReleaseNode 实现 NodeReleaser 接口，请求完成后由代理调用
+func (l *LeastConnectionBalancer) ReleaseNode(nodeID string) {
+	l.ReleaseConnection(nodeID)
+}
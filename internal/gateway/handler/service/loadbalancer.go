@@ -26,6 +26,20 @@ const (
 	ConsistentHash Strategy = "consistent-hash"
 )
 
+// HashOn 一致性哈希的哈希键来源
+type HashOn string
+
+const (
+	// HashOnIP 按客户端IP哈希（默认）
+	HashOnIP HashOn = "ip"
+	// HashOnHeader 按请求头哈希，请求头名称由 HashKey 指定
+	HashOnHeader HashOn = "header"
+	// HashOnPath 按请求路径哈希
+	HashOnPath HashOn = "path"
+	// HashOnQuery 按查询参数哈希，参数名称由 HashKey 指定
+	HashOnQuery HashOn = "query"
+)
+
 // NodeConfig 服务节点配置
 type NodeConfig struct {
 	ID       string            `yaml:"id" json:"id" mapstructure:"id"`                   // 节点ID
@@ -53,6 +67,10 @@ type ServiceConfig struct {
 	HealthCheck *HealthConfig `yaml:"health_check,omitempty" json:"health_check,omitempty" mapstructure:"health_check,omitempty"` // 该服务的健康检查配置
 	// 服务元数据
 	ServiceMetadata map[string]string `yaml:"service_metadata,omitempty" json:"service_metadata,omitempty" mapstructure:"service_metadata,omitempty"` // 服务级别的元数据配置
+	// 上游TLS配置
+	TLS *UpstreamTLSConfig `yaml:"tls,omitempty" json:"tls,omitempty" mapstructure:"tls,omitempty"` // 连接后端时使用的TLS配置，覆盖代理级别的TLS设置
+	// 分阶段超时配置
+	Timeouts *UpstreamTimeoutConfig `yaml:"timeouts,omitempty" json:"timeouts,omitempty" mapstructure:"timeouts,omitempty"` // 连接、TLS握手、响应头和总超时，覆盖代理级别的超时设置
 }
 
 // LoadBalancer 负载均衡器接口
@@ -103,6 +121,18 @@ type LoadBalancerConfig struct {
 	MaxRetries      int           `yaml:"max_retries" json:"max_retries" mapstructure:"max_retries"`                // 最大重试次数
 	RetryTimeout    time.Duration `yaml:"retry_timeout" json:"retry_timeout" mapstructure:"retry_timeout"`          // 重试超时
 	CircuitBreaker  bool          `yaml:"circuit_breaker" json:"circuit_breaker" mapstructure:"circuit_breaker"`    // 是否启用熔断器
+
+	// 一致性哈希配置，仅 consistent-hash 策略使用
+	HashOn     HashOn  `yaml:"hash_on,omitempty" json:"hash_on,omitempty" mapstructure:"hash_on,omitempty"`             // 哈希键来源，默认按客户端IP
+	HashKey    string  `yaml:"hash_key,omitempty" json:"hash_key,omitempty" mapstructure:"hash_key,omitempty"`          // 请求头或查询参数名称
+	LoadFactor float64 `yaml:"load_factor,omitempty" json:"load_factor,omitempty" mapstructure:"load_factor,omitempty"` // 有界负载系数，节点在途请求数不超过平均值的该倍数，0表示不限制
+}
+
+// NodeReleaser 需要跟踪节点在途请求数的负载均衡器实现此接口
+// 代理在请求完成（包括失败）后调用 ReleaseNode，与 Select 一一对应
+type NodeReleaser interface {
+	// ReleaseNode 释放节点上的一个在途请求
+	ReleaseNode(nodeID string)
 }
 
 // HealthConfig 健康检查配置
@@ -51,6 +51,20 @@ func NewService(config *ServiceConfig, useSharedChecker bool) (*Service, error)
 		useSharedChecker: useSharedChecker,
 	}
 
+	// 校验上游TLS配置，证书错误在加载配置时暴露
+	if config.TLS != nil {
+		if err := config.TLS.Validate(); err != nil {
+			return nil, fmt.Errorf("service %s upstream tls config invalid: %w", config.ID, err)
+		}
+	}
+
+	// 校验分阶段超时配置
+	if config.Timeouts != nil {
+		if err := config.Timeouts.Validate(); err != nil {
+			return nil, fmt.Errorf("service %s timeout config invalid: %w", config.ID, err)
+		}
+	}
+
 	// 初始化负载均衡器
 	if err := service.initLoadBalancer(); err != nil {
 		return nil, err
@@ -180,6 +194,14 @@ func (s *Service) SelectNode(ctx *core.Context) (*NodeConfig, error) {
 	return selectedNode, nil
 }
 
+// ReleaseNode 请求完成后释放节点
+// 负载均衡器跟踪在途请求数（最少连接、有界负载一致性哈希）时释放一个在途请求，其他策略无操作
+func (s *Service) ReleaseNode(nodeID string) {
+	if releaser, ok := s.loadBalancer.(NodeReleaser); ok {
+		releaser.ReleaseNode(nodeID)
+	}
+}
+
 // SelectNodeFromDiscoveredNodes 使用本服务已初始化的负载均衡器，在注册中心发现的节点列表中选择目标节点。
 //
 // Service 是否每次重建：
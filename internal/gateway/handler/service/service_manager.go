@@ -53,6 +53,9 @@ type ServiceManager interface {
 	// SelectNode 为服务选择节点
 	SelectNode(serviceID string, ctx *core.Context) (*NodeConfig, error)
 
+	// ReleaseNode 请求完成后释放 SelectNode 选中的节点
+	ReleaseNode(serviceID, nodeID string)
+
 	// RecordServiceSuccess 记录服务调用成功
 	RecordServiceSuccess(serviceID string, responseTime time.Duration)
 
@@ -342,6 +345,17 @@ func (m *DefaultServiceManager) SelectNode(serviceID string, ctx *core.Context)
 	return service.SelectNode(ctx)
 }
 
+// ReleaseNode 请求完成后释放节点
+func (m *DefaultServiceManager) ReleaseNode(serviceID, nodeID string) {
+	m.mu.RLock()
+	service, exists := m.services[serviceID]
+	m.mu.RUnlock()
+
+	if exists {
+		service.ReleaseNode(nodeID)
+	}
+}
+
 // RecordServiceSuccess 记录服务调用成功
 func (m *DefaultServiceManager) RecordServiceSuccess(serviceID string, responseTime time.Duration) {
 	m.mu.RLock()
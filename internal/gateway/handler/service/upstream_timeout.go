@@ -0,0 +1,28 @@
+package service
+
+import (
+	"fmt"
+	"time"
+)
+
+// UpstreamTimeoutConfig 服务级别的分阶段超时配置
+// 各项为0时沿用代理（网关实例）级别的配置；路由开启覆盖代理超时后，路由配置的非0项优先于服务配置
+type UpstreamTimeoutConfig struct {
+	ConnectTimeout        time.Duration `yaml:"connect_timeout,omitempty" json:"connect_timeout,omitempty" mapstructure:"connect_timeout,omitempty"`                         // 建立TCP连接超时
+	TLSHandshakeTimeout   time.Duration `yaml:"tls_handshake_timeout,omitempty" json:"tls_handshake_timeout,omitempty" mapstructure:"tls_handshake_timeout,omitempty"`       // TLS握手超时，仅https节点生效
+	ResponseHeaderTimeout time.Duration `yaml:"response_header_timeout,omitempty" json:"response_header_timeout,omitempty" mapstructure:"response_header_timeout,omitempty"` // 请求发送完成后等待响应头的超时
+	Timeout               time.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout,omitempty"`                                                 // 请求总超时
+}
+
+// IsEmpty 是否未配置任何超时，未配置时整体沿用代理级别的配置
+func (c *UpstreamTimeoutConfig) IsEmpty() bool {
+	return c == nil || *c == (UpstreamTimeoutConfig{})
+}
+
+// Validate 校验分阶段超时配置
+func (c *UpstreamTimeoutConfig) Validate() error {
+	if c.ConnectTimeout < 0 || c.TLSHandshakeTimeout < 0 || c.ResponseHeaderTimeout < 0 || c.Timeout < 0 {
+		return fmt.Errorf("upstream timeouts must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"gateway/pkg/utils/cert"
+)
+
+// UpstreamTLSConfig 服务级别的上游TLS配置
+// 网关终止客户端TLS后，使用该配置与后端节点（https）重新建立TLS连接，覆盖代理级别的TLS设置。
+// 证书和私钥支持文件路径和PEM内容两种方式，同时配置时优先使用PEM内容
+type UpstreamTLSConfig struct {
+	// 校验后端证书的CA证书，未配置时使用系统根证书
+	CACertFile string `yaml:"ca_cert_file,omitempty" json:"ca_cert_file,omitempty" mapstructure:"ca_cert_file,omitempty"` // CA证书文件路径
+	CACert     string `yaml:"ca_cert,omitempty" json:"ca_cert,omitempty" mapstructure:"ca_cert,omitempty"`                // CA证书内容（PEM格式，可包含多个证书）
+
+	// 双向TLS时出示给后端的客户端证书
+	ClientCertFile    string `yaml:"client_cert_file,omitempty" json:"client_cert_file,omitempty" mapstructure:"client_cert_file,omitempty"`          // 客户端证书文件路径
+	ClientKeyFile     string `yaml:"client_key_file,omitempty" json:"client_key_file,omitempty" mapstructure:"client_key_file,omitempty"`             // 客户端私钥文件路径
+	ClientCert        string `yaml:"client_cert,omitempty" json:"client_cert,omitempty" mapstructure:"client_cert,omitempty"`                         // 客户端证书内容（PEM格式）
+	ClientKey         string `yaml:"client_key,omitempty" json:"client_key,omitempty" mapstructure:"client_key,omitempty"`                            // 客户端私钥内容（PEM格式）
+	ClientKeyPassword string `yaml:"client_key_password,omitempty" json:"client_key_password,omitempty" mapstructure:"client_key_password,omitempty"` // 客户端私钥密码（私钥加密时）
+
+	ServerName         string `yaml:"server_name,omitempty" json:"server_name,omitempty" mapstructure:"server_name,omitempty"`                            // SNI及证书校验使用的主机名，未配置时使用节点URL中的主机名
+	MinVersion         string `yaml:"min_version,omitempty" json:"min_version,omitempty" mapstructure:"min_version,omitempty"`                            // 最低TLS版本（TLS1.0-TLS1.3），未配置时为TLS1.2
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty" json:"insecure_skip_verify,omitempty" mapstructure:"insecure_skip_verify,omitempty"` // 是否跳过后端证书校验，仅用于测试环境
+}
+
+// Validate 校验上游TLS配置
+// 会实际加载CA证书和客户端证书，保证配置错误在加载配置时暴露，而不是在转发请求时
+func (c *UpstreamTLSConfig) Validate() error {
+	_, err := c.BuildClientTLSConfig()
+	return err
+}
+
+// BuildClientTLSConfig 构建连接后端使用的TLS客户端配置
+// 返回:
+//
+//	*tls.Config: TLS客户端配置
+//	error: CA证书或客户端证书加载失败、TLS版本无效时返回错误
+func (c *UpstreamTLSConfig) BuildClientTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         strings.TrimSpace(c.ServerName),
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if minVersion := strings.TrimSpace(c.MinVersion); minVersion != "" {
+		version := cert.ParseTLSVersion(minVersion)
+		if version == 0 {
+			return nil, fmt.Errorf("无效的上游TLS最低版本: %s，支持的版本: TLS1.0, TLS1.1, TLS1.2, TLS1.3", c.MinVersion)
+		}
+		tlsConfig.MinVersion = version
+	}
+
+	if c.CACert != "" || c.CACertFile != "" {
+		caPEM := []byte(c.CACert)
+		if c.CACert == "" {
+			data, err := os.ReadFile(c.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("读取上游CA证书文件失败: %w", err)
+			}
+			caPEM = data
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("上游CA证书中没有有效的PEM证书")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	hasClientCert := c.ClientCert != "" || c.ClientCertFile != ""
+	hasClientKey := c.ClientKey != "" || c.ClientKeyFile != ""
+	if hasClientCert != hasClientKey {
+		return nil, fmt.Errorf("客户端证书和私钥必须同时配置")
+	}
+	if hasClientCert {
+		certLoader := cert.NewCertLoader(&cert.CertConfig{
+			CertFile:    c.ClientCertFile,
+			KeyFile:     c.ClientKeyFile,
+			CertContent: c.ClientCert,
+			KeyContent:  c.ClientKey,
+			KeyPassword: c.ClientKeyPassword,
+		})
+		clientCert, err := certLoader.LoadCertificate()
+		if err != nil {
+			return nil, fmt.Errorf("加载上游客户端证书失败: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{*clientCert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,149 @@
+package helper
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gateway/internal/gateway/constants"
+	"gateway/pkg/utils/ctime"
+)
+
+// ErrorResponseConfig 网关错误响应标准化配置
+// 启用后，网关自身产生的失败（超时、熔断、限流、认证失败等）统一返回 ErrorEnvelope 结构，
+// 未启用时保持原有的 GatewayResponse 结构
+type ErrorResponseConfig struct {
+	// 是否启用错误响应标准化
+	Enabled bool `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
+
+	// 全局映射规则，按顺序匹配，首个命中的规则生效
+	Rules []ErrorMappingRule `json:"rules,omitempty" yaml:"rules,omitempty" mapstructure:"rules,omitempty"`
+
+	// 租户级映射规则，键为租户ID，优先于全局规则匹配
+	TenantRules map[string][]ErrorMappingRule `json:"tenant_rules,omitempty" yaml:"tenant_rules,omitempty" mapstructure:"tenant_rules,omitempty"`
+}
+
+// ErrorMappingRule 错误映射规则
+// 匹配条件为空时表示任意，命中后用非空的输出字段覆盖默认响应
+type ErrorMappingRule struct {
+	// 匹配的网关错误码，如 RATE_LIMIT_EXCEEDED，为空或*表示任意
+	Code string `json:"code,omitempty" yaml:"code,omitempty" mapstructure:"code,omitempty"`
+
+	// 匹配的原始HTTP状态码，0表示任意
+	Status int `json:"status,omitempty" yaml:"status,omitempty" mapstructure:"status,omitempty"`
+
+	// 返回给客户端的HTTP状态码，0表示保持原状态码
+	ResponseStatus int `json:"response_status,omitempty" yaml:"response_status,omitempty" mapstructure:"response_status,omitempty"`
+
+	// 返回给客户端的错误码，为空表示保持原错误码
+	ResponseCode string `json:"response_code,omitempty" yaml:"response_code,omitempty" mapstructure:"response_code,omitempty"`
+
+	// 返回给客户端的错误消息，为空表示保持原消息
+	Message string `json:"message,omitempty" yaml:"message,omitempty" mapstructure:"message,omitempty"`
+}
+
+// ErrorEnvelope 标准化后的错误响应结构
+type ErrorEnvelope struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	TraceID   string `json:"traceId"`
+	Status    int    `json:"status"`
+	Path      string `json:"path,omitempty"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Map 将网关错误响应转换为标准化错误响应
+// 参数:
+// - tenantID: 租户ID，用于匹配租户级规则
+// - statusCode: 原始HTTP状态码
+// - response: 原始网关响应
+// - errorCode: 处理器指定的网关错误码，为空时根据响应码和状态码推断
+// 返回值:
+// - int: 返回给客户端的HTTP状态码
+// - ErrorEnvelope: 标准化后的错误响应
+func (c *ErrorResponseConfig) Map(tenantID string, statusCode int, response GatewayResponse, errorCode string) (int, ErrorEnvelope) {
+	code := ResolveErrorCode(statusCode, response.Code, errorCode)
+	envelope := ErrorEnvelope{
+		Code:      code,
+		Message:   response.Error,
+		TraceID:   response.TraceID,
+		Status:    statusCode,
+		Path:      response.Path,
+		Timestamp: response.Timestamp,
+	}
+	if envelope.Message == "" {
+		envelope.Message = http.StatusText(statusCode)
+	}
+	if envelope.Timestamp == "" {
+		envelope.Timestamp = ctime.GetCurrentTimeString(ctime.FormatISO8601Milli)
+	}
+
+	rule := c.matchRule(tenantID, statusCode, code)
+	if rule == nil {
+		return statusCode, envelope
+	}
+	if rule.ResponseStatus >= http.StatusContinue && rule.ResponseStatus <= 599 {
+		envelope.Status = rule.ResponseStatus
+	}
+	if rule.ResponseCode != "" {
+		envelope.Code = rule.ResponseCode
+	}
+	if rule.Message != "" {
+		envelope.Message = rule.Message
+	}
+	return envelope.Status, envelope
+}
+
+// matchRule 查找首个命中的映射规则，租户规则优先
+func (c *ErrorResponseConfig) matchRule(tenantID string, statusCode int, code string) *ErrorMappingRule {
+	for _, rules := range [][]ErrorMappingRule{c.TenantRules[tenantID], c.Rules} {
+		for i := range rules {
+			if rules[i].matches(statusCode, code) {
+				return &rules[i]
+			}
+		}
+	}
+	return nil
+}
+
+// matches 判断规则是否命中
+func (r *ErrorMappingRule) matches(statusCode int, code string) bool {
+	if r.Status != 0 && r.Status != statusCode {
+		return false
+	}
+	ruleCode := strings.TrimSpace(r.Code)
+	return ruleCode == "" || ruleCode == "*" || strings.EqualFold(ruleCode, code)
+}
+
+// ResolveErrorCode 确定网关错误码
+// 优先使用处理器指定的错误码，其次使用响应中的非数字错误码，最后根据HTTP状态码推断
+func ResolveErrorCode(statusCode int, responseCode, errorCode string) string {
+	if errorCode != "" {
+		return errorCode
+	}
+	if responseCode != "" {
+		if _, err := strconv.Atoi(responseCode); err != nil {
+			return responseCode
+		}
+	}
+	switch statusCode {
+	case http.StatusBadRequest:
+		return constants.ErrorCodeInvalidRequest
+	case http.StatusUnauthorized:
+		return constants.ErrorCodeAuthenticationFail
+	case http.StatusForbidden:
+		return constants.ErrorCodeAuthorizationFail
+	case http.StatusNotFound:
+		return constants.ErrorCodeRouteNotFound
+	case http.StatusTooManyRequests:
+		return constants.ErrorCodeRateLimitExceeded
+	case http.StatusBadGateway:
+		return constants.ErrorCodeUpstreamError
+	case http.StatusServiceUnavailable:
+		return constants.ErrorCodeServiceUnavailable
+	case http.StatusGatewayTimeout:
+		return constants.ErrorCodeTimeout
+	default:
+		return constants.ErrorCodeInternalError
+	}
+}
@@ -217,6 +217,10 @@ func setDefaultValues(v *viper.Viper) {
 	v.SetDefault("base.log_format", "json")
 	v.SetDefault("base.log_level", "info")
 	v.SetDefault("base.enable_gzip", true)
+	v.SetDefault("base.startup_readiness.enabled", true)
+	v.SetDefault("base.startup_readiness.timeout", "60s")
+	v.SetDefault("base.startup_readiness.check_interval", "500ms")
+	v.SetDefault("base.startup_readiness.failure_mode", "fail")
 
 	// 认证配置默认值
 	v.SetDefault("auth.enabled", false)
@@ -275,6 +279,12 @@ func (f *GatewayConfigFactory) mergeDefaultConfig(cfg *config.GatewayConfig) {
 	if cfg.Base.LogLevel == "" {
 		cfg.Base.LogLevel = defaultCfg.Base.LogLevel
 	}
+	if cfg.Base.StartupReadiness.Timeout == 0 {
+		cfg.Base.StartupReadiness.Timeout = defaultCfg.Base.StartupReadiness.Timeout
+	}
+	if cfg.Base.StartupReadiness.CheckInterval == 0 {
+		cfg.Base.StartupReadiness.CheckInterval = defaultCfg.Base.StartupReadiness.CheckInterval
+	}
 
 	// 合并各模块默认配置
 	if cfg.Router.ID == "" {
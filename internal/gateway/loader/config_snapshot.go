@@ -0,0 +1,82 @@
+package loader
+
+import (
+	"fmt"
+	"strings"
+
+	"gateway/internal/gateway/config"
+	"gateway/pkg/cache"
+	"gateway/pkg/logger"
+)
+
+// gatewayConfigSnapshotPrefix 网关配置快照名称前缀，后接网关实例ID
+const gatewayConfigSnapshotPrefix = "gateway_config."
+
+// GatewayConfigSnapshotName 返回网关实例配置的本地快照名称
+func GatewayConfigSnapshotName(instanceId string) string {
+	return gatewayConfigSnapshotPrefix + instanceId
+}
+
+// saveGatewayConfigSnapshot 将从数据库加载成功的网关配置写入本地快照
+// 配置中包含JWT密钥、OAuth2客户端密钥和TLS私钥密码，快照使用默认密钥加密后写入磁盘；
+// 未启用 cache.snapshot 时不写入；写入成功说明数据库可用，同时退出该实例的降级模式
+func saveGatewayConfigSnapshot(cfg *config.GatewayConfig) {
+	store := cache.GetSnapshotStore()
+	if store == nil || cfg == nil || cfg.InstanceID == "" {
+		return
+	}
+
+	name := GatewayConfigSnapshotName(cfg.InstanceID)
+	if err := store.SaveEncrypted(name, cfg); err != nil {
+		logger.Warn("写入网关配置快照失败", "instanceId", cfg.InstanceID, "error", err)
+		return
+	}
+	if cache.ClearSnapshotRestored(name) {
+		logger.Info("已从数据库重新加载网关配置，退出降级模式", "instanceId", cfg.InstanceID)
+	}
+}
+
+// RestoreGatewayConfigSnapshot 从本地快照恢复网关实例配置，并标记该实例进入降级模式
+// 数据库不可用时启动网关使用，恢复的配置为最近一次从数据库加载成功的配置
+// 参数:
+//   - instanceId: 网关实例ID
+//
+// 返回:
+//   - *config.GatewayConfig: 快照中的网关配置
+//   - *cache.SnapshotInfo: 快照元信息
+//   - error: 未启用快照、快照不存在或已过期时返回错误信息
+func RestoreGatewayConfigSnapshot(instanceId string) (*config.GatewayConfig, *cache.SnapshotInfo, error) {
+	store := cache.GetSnapshotStore()
+	if store == nil {
+		return nil, nil, fmt.Errorf("未启用缓存快照")
+	}
+
+	var cfg config.GatewayConfig
+	info, err := store.Load(GatewayConfigSnapshotName(instanceId), &cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取网关实例 %s 的配置快照失败: %w", instanceId, err)
+	}
+	cache.MarkSnapshotRestored(*info)
+	return &cfg, info, nil
+}
+
+// ListGatewayConfigSnapshots 列出存在配置快照的网关实例
+// 返回:
+//   - []string: 网关实例ID列表，未启用快照时返回空列表
+//   - error: 读取快照目录失败时返回错误信息
+func ListGatewayConfigSnapshots() ([]string, error) {
+	store := cache.GetSnapshotStore()
+	if store == nil {
+		return nil, nil
+	}
+
+	names, err := store.List(gatewayConfigSnapshotPrefix)
+	if err != nil {
+		return nil, err
+	}
+	instanceIds := make([]string, 0, len(names))
+	for _, name := range names {
+		instanceIds = append(instanceIds, strings.TrimPrefix(name, gatewayConfigSnapshotPrefix))
+	}
+	return instanceIds, nil
+}
@@ -0,0 +1,333 @@
+package loader
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"gateway/internal/gateway/config"
+	"gateway/internal/gateway/handler/filter"
+	"gateway/internal/gateway/handler/router"
+	"gateway/internal/gateway/handler/service"
+)
+
+// IssueLevel 校验问题级别
+type IssueLevel string
+
+const (
+	IssueLevelError   IssueLevel = "ERROR"   // 错误，网关无法按该配置正常运行
+	IssueLevelWarning IssueLevel = "WARNING" // 警告，配置可以加载但可能与预期不符
+)
+
+// ValidationIssue 配置校验问题
+type ValidationIssue struct {
+	Level   IssueLevel // 问题级别
+	Path    string     // 问题所在的配置路径，例如 router.routes[user-service-route]
+	Message string     // 问题说明
+}
+
+// ValidationReport 配置校验报告
+type ValidationReport struct {
+	ConfigPath string            // 被校验的配置文件路径
+	Routes     int               // 路由数量
+	Services   int               // 服务数量
+	Nodes      int               // 服务节点数量
+	Issues     []ValidationIssue // 校验发现的问题
+}
+
+// addError 记录错误
+func (r *ValidationReport) addError(path, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, ValidationIssue{Level: IssueLevelError, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// addWarning 记录警告
+func (r *ValidationReport) addWarning(path, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, ValidationIssue{Level: IssueLevelWarning, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// ErrorCount 错误数量
+func (r *ValidationReport) ErrorCount() int {
+	return r.countLevel(IssueLevelError)
+}
+
+// WarningCount 警告数量
+func (r *ValidationReport) WarningCount() int {
+	return r.countLevel(IssueLevelWarning)
+}
+
+// HasErrors 是否存在错误
+func (r *ValidationReport) HasErrors() bool {
+	return r.ErrorCount() > 0
+}
+
+func (r *ValidationReport) countLevel(level IssueLevel) int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Level == level {
+			count++
+		}
+	}
+	return count
+}
+
+// Print 输出可读的校验报告
+func (r *ValidationReport) Print(w io.Writer) {
+	fmt.Fprintf(w, "配置文件: %s\n", r.ConfigPath)
+	fmt.Fprintf(w, "路由: %d, 服务: %d, 节点: %d\n", r.Routes, r.Services, r.Nodes)
+	for _, issue := range r.Issues {
+		fmt.Fprintf(w, "  [%s] %s: %s\n", issue.Level, issue.Path, issue.Message)
+	}
+	if r.HasErrors() {
+		fmt.Fprintf(w, "校验失败: %d 个错误, %d 个警告\n", r.ErrorCount(), r.WarningCount())
+	} else {
+		fmt.Fprintf(w, "校验通过: %d 个警告\n", r.WarningCount())
+	}
+}
+
+// ValidateConfigFileReport 加载并完整校验网关配置文件，不启动任何服务
+// 依次执行结构校验（未知配置项）、字段校验、路由到服务再到节点的引用校验，
+// 并按启动流程创建路由、过滤器和负载均衡器实例进行试运行
+// 参数:
+//
+//	configPath: YAML配置文件路径
+//
+// 返回:
+//
+//	*ValidationReport: 校验报告，配置文件无法读取或解析时报告中只包含对应错误
+func ValidateConfigFileReport(configPath string) *ValidationReport {
+	report := &ValidationReport{ConfigPath: configPath}
+
+	factory := NewGatewayConfigFactory(ConfigSourceYAML)
+	if err := factory.ValidateConfigFile(configPath); err != nil {
+		report.addError("file", "%v", err)
+		return report
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		report.addError("file", "读取配置文件失败: %v", err)
+		return report
+	}
+	raw := checkUnknownFields(data, report)
+
+	cfg, err := factory.LoadConfig(configPath)
+	if err != nil {
+		report.addError("file", "%v", err)
+		return report
+	}
+
+	// 加载时路由器或代理ID为空会整体替换为默认配置，其中的路由和服务不会生效
+	if raw.Router.ID == "" && len(raw.Router.Routes) > 0 {
+		report.addError("router.id", "路由器ID为空，配置的 %d 条路由将被默认配置替换", len(raw.Router.Routes))
+	}
+	if raw.Proxy.ID == "" && len(raw.Proxy.Service) > 0 {
+		report.addError("proxy.id", "代理ID为空，配置的 %d 个服务将被默认配置替换", len(raw.Proxy.Service))
+	}
+
+	validateGatewayConfig(cfg, report)
+	return report
+}
+
+// ValidateGatewayConfig 校验已加载的网关配置
+func ValidateGatewayConfig(cfg *config.GatewayConfig) *ValidationReport {
+	report := &ValidationReport{}
+	validateGatewayConfig(cfg, report)
+	return report
+}
+
+// checkUnknownFields 严格解析配置，拼写错误或已废弃的配置项在加载时会被静默忽略，作为警告报告
+// 返回:
+//
+//	*config.GatewayConfig: 未合并默认值的原始配置，解析失败的字段保持零值
+func checkUnknownFields(data []byte, report *ValidationReport) *config.GatewayConfig {
+	raw := &config.GatewayConfig{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err := decoder.Decode(raw)
+
+	var typeErr *yaml.TypeError
+	if err == nil || !errors.As(err, &typeErr) {
+		return raw
+	}
+	for _, message := range typeErr.Errors {
+		if strings.Contains(message, "not found in type") {
+			report.addWarning("schema", "未知配置项: %s", message)
+		}
+	}
+	return raw
+}
+
+// validateGatewayConfig 校验基础配置、路由、服务及其相互引用
+func validateGatewayConfig(cfg *config.GatewayConfig, report *ValidationReport) {
+	validateBaseConfig(&cfg.Base, report)
+	services := validateServices(cfg.Proxy.Service, report)
+	referenced := validateRoutes(&cfg.Router, services, report)
+
+	for _, svc := range cfg.Proxy.Service {
+		if svc != nil && svc.ID != "" && !referenced[svc.ID] {
+			report.addWarning(servicePath(svc.ID), "服务未被任何路由引用")
+		}
+	}
+}
+
+// validateBaseConfig 校验监听地址和HTTPS证书配置
+func validateBaseConfig(base *config.BaseConfig, report *ValidationReport) {
+	if base.Listen == "" {
+		report.addError("base.listen", "监听地址不能为空")
+	}
+	if base.EnableHTTPS {
+		files := []struct {
+			path string
+			file string
+		}{
+			{"base.cert_file", base.CertFile},
+			{"base.key_file", base.KeyFile},
+		}
+		for _, f := range files {
+			if f.file == "" {
+				report.addError(f.path, "启用HTTPS时必须配置")
+			} else if _, err := os.Stat(f.file); err != nil {
+				report.addError(f.path, "文件不可用: %v", err)
+			}
+		}
+	}
+}
+
+// validateServices 校验服务及节点配置
+// 返回:
+//
+//	map[string]*service.ServiceConfig: 服务ID到服务配置的映射，用于路由引用校验
+func validateServices(services []*service.ServiceConfig, report *ValidationReport) map[string]*service.ServiceConfig {
+	result := make(map[string]*service.ServiceConfig, len(services))
+	balancerFactory := service.NewLoadBalancerFactory()
+
+	for i, svc := range services {
+		if svc == nil {
+			continue
+		}
+		report.Services++
+		if svc.ID == "" {
+			report.addError(fmt.Sprintf("proxy.service[%d]", i), "服务ID不能为空")
+			continue
+		}
+		path := servicePath(svc.ID)
+		if _, exists := result[svc.ID]; exists {
+			report.addError(path, "服务ID重复")
+			continue
+		}
+		result[svc.ID] = svc
+
+		if svc.Strategy != "" {
+			if _, err := balancerFactory.CreateLoadBalancer(&service.LoadBalancerConfig{Strategy: svc.Strategy}); err != nil {
+				report.addError(path+".strategy", "不支持的负载均衡策略: %s", svc.Strategy)
+			}
+		}
+
+		if len(svc.Nodes) == 0 {
+			report.addError(path+".nodes", "服务没有配置节点")
+			continue
+		}
+		validateNodes(path, svc.Nodes, report)
+	}
+	return result
+}
+
+// validateNodes 校验服务节点：ID唯一、URL可解析、权重非负，并至少有一个启用的节点
+func validateNodes(servicePath string, nodes []*service.NodeConfig, report *ValidationReport) {
+	nodeIDs := make(map[string]bool, len(nodes))
+	enabled := 0
+	for i, node := range nodes {
+		if node == nil {
+			continue
+		}
+		report.Nodes++
+		path := fmt.Sprintf("%s.nodes[%d]", servicePath, i)
+		if node.ID != "" {
+			path = fmt.Sprintf("%s.nodes[%s]", servicePath, node.ID)
+			if nodeIDs[node.ID] {
+				report.addError(path, "节点ID重复")
+			}
+			nodeIDs[node.ID] = true
+		}
+
+		if node.URL == "" {
+			report.addError(path+".url", "节点URL不能为空")
+		} else if parsed, err := url.Parse(node.URL); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			report.addError(path+".url", "节点URL格式错误: %s", node.URL)
+		}
+		if node.Weight < 0 {
+			report.addError(path+".weight", "节点权重不能为负数")
+		}
+		if node.Enabled {
+			enabled++
+		}
+	}
+	if enabled == 0 {
+		report.addWarning(servicePath+".nodes", "服务没有启用的节点")
+	}
+}
+
+// validateRoutes 校验路由及全局过滤器，检查路由引用的服务是否存在
+// 返回:
+//
+//	map[string]bool: 被路由引用的服务ID
+func validateRoutes(routerConfig *router.RouterConfig, services map[string]*service.ServiceConfig, report *ValidationReport) map[string]bool {
+	referenced := make(map[string]bool)
+	routeIDs := make(map[string]bool, len(routerConfig.Routes))
+	filterFactory := filter.NewFilterFactory()
+
+	for i, filterConfig := range routerConfig.FilterConfig {
+		if _, err := filterFactory.CreateFilter(filterConfig); err != nil {
+			report.addError(filterPath("router", i, filterConfig.ID), "创建过滤器失败: %v", err)
+		}
+	}
+
+	for i, routeConfig := range routerConfig.Routes {
+		report.Routes++
+		path := fmt.Sprintf("router.routes[%d]", i)
+		if routeConfig.ID != "" {
+			path = fmt.Sprintf("router.routes[%s]", routeConfig.ID)
+			if routeIDs[routeConfig.ID] {
+				report.addError(path, "路由ID重复")
+			}
+			routeIDs[routeConfig.ID] = true
+		}
+
+		// 按启动流程创建路由，覆盖路由字段、断言和路由级过滤器的校验
+		if _, err := router.NewRoute(routeConfig); err != nil {
+			report.addError(path, "%v", err)
+		}
+
+		serviceIDs := routeConfig.ServiceIDs
+		if len(serviceIDs) == 0 && routeConfig.ServiceID != "" {
+			serviceIDs = []string{routeConfig.ServiceID}
+		}
+		for _, serviceID := range serviceIDs {
+			if serviceID == "" {
+				continue
+			}
+			referenced[serviceID] = true
+			if _, exists := services[serviceID]; !exists {
+				report.addError(path+".service_id", "引用的服务不存在: %s", serviceID)
+			}
+		}
+	}
+	return referenced
+}
+
+func servicePath(serviceID string) string {
+	return fmt.Sprintf("proxy.service[%s]", serviceID)
+}
+
+func filterPath(parent string, index int, filterID string) string {
+	if filterID != "" {
+		return fmt.Sprintf("%s.filter_config[%s]", parent, filterID)
+	}
+	return fmt.Sprintf("%s.filter_config[%d]", parent, index)
+}
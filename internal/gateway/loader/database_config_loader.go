@@ -61,6 +61,7 @@ func (loader *DatabaseConfigLoader) LoadGatewayConfig(instanceId string) (*confi
 		InstanceID: instanceId,
 		Base:       loader.baseLoader.BuildBaseConfig(instance),
 	}
+	gatewayConfig.ErrorResponse = loader.baseLoader.BuildErrorResponseConfig(instance)
 
 	// 3. 加载Router配置
 	routerConfig, err := loader.routerLoader.LoadRouterConfig(ctx, instanceId)
@@ -223,5 +224,8 @@ func (loader *DatabaseConfigLoader) LoadGatewayConfig(instanceId string) (*confi
 		// TODO: 在 router.RouteConfig 中添加 LogConfigId 和 LogConfig 字段
 	}
 
+	// 11. 写入本地快照，数据库不可用时启动可从快照恢复
+	saveGatewayConfigSnapshot(gatewayConfig)
+
 	return gatewayConfig, nil
 }
@@ -2,12 +2,14 @@ package dbloader
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
 	"gateway/internal/gateway/config"
+	"gateway/internal/gateway/helper"
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
 )
@@ -93,6 +95,7 @@ func (loader *BaseConfigLoader) BuildBaseConfig(instance *GatewayInstanceRecord)
 	if baseConfig.MaxWorkers <= 0 {
 		baseConfig.MaxWorkers = config.DefaultGatewayConfig.Base.MaxWorkers
 	}
+	baseConfig.StartupReadiness = loader.buildStartupReadinessConfig(instance)
 
 	// 处理TLS相关配置
 	if instance.TLSEnabled == "Y" {
@@ -120,6 +123,73 @@ func (loader *BaseConfigLoader) BuildBaseConfig(instance *GatewayInstanceRecord)
 	return baseConfig
 }
 
+// BuildErrorResponseConfig 从实例元数据构建错误响应标准化配置
+// 实例元数据格式: {"errorResponse": {"enabled": true, "rules": [...], "tenant_rules": {"tenantId": [...]}}}
+// 未配置或解析失败时返回未启用的配置
+func (loader *BaseConfigLoader) BuildErrorResponseConfig(instance *GatewayInstanceRecord) helper.ErrorResponseConfig {
+	var errorResponse helper.ErrorResponseConfig
+	if instance.InstanceMetadata == nil || *instance.InstanceMetadata == "" {
+		return errorResponse
+	}
+
+	var metadata struct {
+		ErrorResponse *helper.ErrorResponseConfig `json:"errorResponse"`
+	}
+	if err := json.Unmarshal([]byte(*instance.InstanceMetadata), &metadata); err != nil {
+		logger.Warn("解析实例元数据中的错误响应配置失败", "instanceId", instance.InstanceId, "error", err)
+		return errorResponse
+	}
+	if metadata.ErrorResponse != nil {
+		errorResponse = *metadata.ErrorResponse
+	}
+	return errorResponse
+}
+
+// buildStartupReadinessConfig 从实例元数据构建启动就绪检查配置
+// 实例元数据格式: {"startupReadiness": {"enabled": true, "timeoutMs": 60000, "checkIntervalMs": 500, "checks": ["routes","registry"], "failureMode": "fail"}}
+// 未配置的字段使用默认值
+func (loader *BaseConfigLoader) buildStartupReadinessConfig(instance *GatewayInstanceRecord) config.StartupReadinessConfig {
+	readiness := config.DefaultStartupReadinessConfig
+	if instance.InstanceMetadata == nil || *instance.InstanceMetadata == "" {
+		return readiness
+	}
+
+	var metadata struct {
+		StartupReadiness *struct {
+			Enabled         *bool    `json:"enabled"`
+			TimeoutMs       int      `json:"timeoutMs"`
+			CheckIntervalMs int      `json:"checkIntervalMs"`
+			Checks          []string `json:"checks"`
+			FailureMode     string   `json:"failureMode"`
+		} `json:"startupReadiness"`
+	}
+	if err := json.Unmarshal([]byte(*instance.InstanceMetadata), &metadata); err != nil {
+		logger.Warn("解析实例元数据中的启动就绪检查配置失败", "instanceId", instance.InstanceId, "error", err)
+		return readiness
+	}
+	if metadata.StartupReadiness == nil {
+		return readiness
+	}
+
+	custom := metadata.StartupReadiness
+	if custom.Enabled != nil {
+		readiness.Enabled = *custom.Enabled
+	}
+	if custom.TimeoutMs > 0 {
+		readiness.Timeout = time.Duration(custom.TimeoutMs) * time.Millisecond
+	}
+	if custom.CheckIntervalMs > 0 {
+		readiness.CheckInterval = time.Duration(custom.CheckIntervalMs) * time.Millisecond
+	}
+	if len(custom.Checks) > 0 {
+		readiness.Checks = custom.Checks
+	}
+	if custom.FailureMode != "" {
+		readiness.FailureMode = custom.FailureMode
+	}
+	return readiness
+}
+
 // writeCertificatesToFiles 将数据库中的证书内容写入临时文件
 func (loader *BaseConfigLoader) writeCertificatesToFiles(instance *GatewayInstanceRecord, baseConfig *config.BaseConfig) error {
 	// 创建临时目录用于存储证书文件
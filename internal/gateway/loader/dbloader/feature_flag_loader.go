@@ -0,0 +1,69 @@
+package dbloader
+
+import (
+	"context"
+	"fmt"
+
+	"gateway/internal/gateway/handler/featureflag"
+	"gateway/pkg/database"
+)
+
+// FeatureFlagLoader 功能开关加载器
+type FeatureFlagLoader struct {
+	db       database.Database
+	tenantId string
+}
+
+// NewFeatureFlagLoader 创建功能开关加载器
+func NewFeatureFlagLoader(db database.Database, tenantId string) *FeatureFlagLoader {
+	return &FeatureFlagLoader{
+		db:       db,
+		tenantId: tenantId,
+	}
+}
+
+// LoadFeatureFlags 加载对网关实例可见的功能开关，包括租户级开关和限定到该实例的开关
+func (loader *FeatureFlagLoader) LoadFeatureFlags(ctx context.Context, instanceId string) ([]featureflag.Flag, error) {
+	query := `
+		SELECT tenantId, featureFlagId, gatewayInstanceId, routeConfigId, flagKey, flagName,
+		       flagEnabled, rolloutPercent, bucketBy, bucketKey, logEvaluation
+		FROM HUB_GW_FEATURE_FLAG
+		WHERE tenantId = ? AND activeFlag = 'Y'
+		  AND (gatewayInstanceId IS NULL OR gatewayInstanceId = '' OR gatewayInstanceId = ?)
+	`
+
+	var records []FeatureFlagRecord
+	err := loader.db.Query(ctx, &records, query, []interface{}{loader.tenantId, instanceId}, true)
+	if err != nil {
+		return nil, fmt.Errorf("查询功能开关失败: %w", err)
+	}
+
+	flags := make([]featureflag.Flag, 0, len(records))
+	for _, record := range records {
+		flags = append(flags, buildFeatureFlag(record))
+	}
+	return flags, nil
+}
+
+// buildFeatureFlag 将数据库记录转换为功能开关
+func buildFeatureFlag(record FeatureFlagRecord) featureflag.Flag {
+	flag := featureflag.Flag{
+		FlagID:         record.FeatureFlagId,
+		Key:            record.FlagKey,
+		Name:           record.FlagName,
+		Enabled:        record.FlagEnabled == "Y",
+		RolloutPercent: record.RolloutPercent,
+		BucketBy:       record.BucketBy,
+		LogEvaluation:  record.LogEvaluation == "Y",
+	}
+	if record.GatewayInstanceId != nil {
+		flag.GatewayInstanceID = *record.GatewayInstanceId
+	}
+	if record.RouteConfigId != nil {
+		flag.RouteConfigID = *record.RouteConfigId
+	}
+	if record.BucketKey != nil {
+		flag.BucketKey = *record.BucketKey
+	}
+	return flag
+}
@@ -80,6 +80,7 @@ func (loader *LimiterServiceLoader) LoadRateLimitConfig(ctx context.Context, ins
 	// 支持的算法：
 	// - TOKEN_BUCKET: 令牌桶算法（平滑突发流量）
 	// - LEAKY_BUCKET: 漏桶算法（固定速率处理）
+	// - SPIKE_ARREST: 削峰算法（突发请求排队等待）
 	// - SLIDING_WINDOW: 滑动窗口算法（更精确的时间窗口）
 	// - FIXED_WINDOW: 固定窗口算法（简单高效）
 	// - NONE: 无限制（不进行限流）
@@ -88,6 +89,8 @@ func (loader *LimiterServiceLoader) LoadRateLimitConfig(ctx context.Context, ins
 		rateLimitConf.Algorithm = limiter.AlgorithmTokenBucket
 	case "LEAKY_BUCKET":
 		rateLimitConf.Algorithm = limiter.AlgorithmLeakyBucket
+	case "SPIKE_ARREST":
+		rateLimitConf.Algorithm = limiter.AlgorithmSpikeArrest
 	case "SLIDING_WINDOW":
 		rateLimitConf.Algorithm = limiter.AlgorithmSlidingWindow
 	case "FIXED_WINDOW":
@@ -112,6 +115,7 @@ func (loader *LimiterServiceLoader) LoadRateLimitConfig(ctx context.Context, ins
 		var customConfig map[string]interface{}
 		if err := json.Unmarshal([]byte(record.CustomConfig), &customConfig); err == nil {
 			rateLimitConf.CustomConfig = customConfig
+			rateLimitConf.Bandwidth = limiter.ParseBandwidthConfig(customConfig)
 		}
 	}
 
@@ -170,6 +174,7 @@ func (loader *LimiterServiceLoader) LoadRouteRateLimitConfig(ctx context.Context
 	// 支持的算法：
 	// - token-bucket: 令牌桶算法（平滑突发流量）
 	// - leaky-bucket: 漏桶算法（固定速率处理）
+	// - spike-arrest: 削峰算法（突发请求排队等待）
 	// - sliding-window: 滑动窗口算法（更精确的时间窗口）
 	// - fixed-window: 固定窗口算法（简单高效）
 	// - none: 无限制（不进行限流）
@@ -178,6 +183,8 @@ func (loader *LimiterServiceLoader) LoadRouteRateLimitConfig(ctx context.Context
 		rateLimitConf.Algorithm = limiter.AlgorithmTokenBucket
 	case "leaky-bucket":
 		rateLimitConf.Algorithm = limiter.AlgorithmLeakyBucket
+	case "spike-arrest":
+		rateLimitConf.Algorithm = limiter.AlgorithmSpikeArrest
 	case "sliding-window":
 		rateLimitConf.Algorithm = limiter.AlgorithmSlidingWindow
 	case "fixed-window":
@@ -202,6 +209,7 @@ func (loader *LimiterServiceLoader) LoadRouteRateLimitConfig(ctx context.Context
 		var customConfig map[string]interface{}
 		if err := json.Unmarshal([]byte(record.CustomConfig), &customConfig); err == nil {
 			rateLimitConf.CustomConfig = customConfig
+			rateLimitConf.Bandwidth = limiter.ParseBandwidthConfig(customConfig)
 		}
 	}
 
@@ -251,7 +259,7 @@ func (loader *LimiterServiceLoader) LoadProxyConfig(ctx context.Context, instanc
 			       healthCheckEnabled, healthCheckPath, healthCheckMethod,
 			       healthCheckIntervalSeconds, healthCheckTimeoutMs, healthyThreshold,
 			       unhealthyThreshold, expectedStatusCodes, healthCheckHeaders,
-			       loadBalancerConfig, serviceMetadata, activeFlag
+			       loadBalancerConfig, serviceMetadata, upstreamTlsConfig, activeFlag
 			FROM HUB_GW_SERVICE_DEFINITION 
 			WHERE tenantId = ? AND activeFlag = 'Y' AND proxyConfigId = ?
 		`
@@ -341,7 +349,7 @@ func (loader *LimiterServiceLoader) LoadProxyConfig(ctx context.Context, instanc
 		       healthCheckEnabled, healthCheckPath, healthCheckMethod,
 		       healthCheckIntervalSeconds, healthCheckTimeoutMs, healthyThreshold,
 		       unhealthyThreshold, expectedStatusCodes, healthCheckHeaders,
-		       loadBalancerConfig, serviceMetadata, activeFlag
+		       loadBalancerConfig, serviceMetadata, upstreamTlsConfig, activeFlag
 		FROM HUB_GW_SERVICE_DEFINITION 
 		WHERE tenantId = ? AND activeFlag = 'Y' 
 		AND (proxyConfigId = ? OR proxyConfigId = ?)
@@ -371,6 +379,78 @@ func (loader *LimiterServiceLoader) LoadProxyConfig(ctx context.Context, instanc
 	return proxyConf, nil
 }
 
+// loadBalancerExtConfig 负载均衡器完整配置（loadBalancerConfig 字段）中网关使用的扩展项
+type loadBalancerExtConfig struct {
+	HashOn     string  `json:"hashOn"`     // 一致性哈希的哈希键来源：ip、header、path、query
+	HashKey    string  `json:"hashKey"`    // 请求头或查询参数名称
+	LoadFactor float64 `json:"loadFactor"` // 有界负载系数，0表示不限制
+
+	// 服务级分阶段超时（毫秒），0表示沿用代理配置
+	ConnectTimeoutMs        int `json:"connectTimeoutMs"`        // 连接超时
+	TLSHandshakeTimeoutMs   int `json:"tlsHandshakeTimeoutMs"`   // TLS握手超时
+	ResponseHeaderTimeoutMs int `json:"responseHeaderTimeoutMs"` // 等待响应头超时
+	RequestTimeoutMs        int `json:"requestTimeoutMs"`        // 请求总超时
+}
+
+// hashOn 标准化哈希键来源
+func (c loadBalancerExtConfig) hashOn() service.HashOn {
+	return service.HashOn(strings.ToLower(strings.TrimSpace(c.HashOn)))
+}
+
+// timeouts 构建服务级分阶段超时配置，未配置任何超时时返回nil
+func (c loadBalancerExtConfig) timeouts() *service.UpstreamTimeoutConfig {
+	timeouts := &service.UpstreamTimeoutConfig{
+		ConnectTimeout:        time.Duration(c.ConnectTimeoutMs) * time.Millisecond,
+		TLSHandshakeTimeout:   time.Duration(c.TLSHandshakeTimeoutMs) * time.Millisecond,
+		ResponseHeaderTimeout: time.Duration(c.ResponseHeaderTimeoutMs) * time.Millisecond,
+		Timeout:               time.Duration(c.RequestTimeoutMs) * time.Millisecond,
+	}
+	if timeouts.IsEmpty() {
+		return nil
+	}
+	return timeouts
+}
+
+// upstreamTLSRecordConfig 上游TLS配置（upstreamTlsConfig 字段）
+type upstreamTLSRecordConfig struct {
+	CACertFile         string `json:"caCertFile"`         // CA证书文件路径
+	CACert             string `json:"caCert"`             // CA证书内容（PEM格式）
+	ClientCertFile     string `json:"clientCertFile"`     // 客户端证书文件路径
+	ClientKeyFile      string `json:"clientKeyFile"`      // 客户端私钥文件路径
+	ClientCert         string `json:"clientCert"`         // 客户端证书内容（PEM格式）
+	ClientKey          string `json:"clientKey"`          // 客户端私钥内容（PEM格式）
+	ClientKeyPassword  string `json:"clientKeyPassword"`  // 客户端私钥密码
+	ServerName         string `json:"serverName"`         // SNI覆盖
+	MinVersion         string `json:"minVersion"`         // 最低TLS版本
+	InsecureSkipVerify bool   `json:"insecureSkipVerify"` // 是否跳过后端证书校验
+}
+
+// parseUpstreamTLSConfig 解析上游TLS配置，未配置任何项时返回nil
+func parseUpstreamTLSConfig(value *string) (*service.UpstreamTLSConfig, error) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil, nil
+	}
+	var recordConfig upstreamTLSRecordConfig
+	if err := json.Unmarshal([]byte(*value), &recordConfig); err != nil {
+		return nil, err
+	}
+	if recordConfig == (upstreamTLSRecordConfig{}) {
+		return nil, nil
+	}
+	return &service.UpstreamTLSConfig{
+		CACertFile:         strings.TrimSpace(recordConfig.CACertFile),
+		CACert:             recordConfig.CACert,
+		ClientCertFile:     strings.TrimSpace(recordConfig.ClientCertFile),
+		ClientKeyFile:      strings.TrimSpace(recordConfig.ClientKeyFile),
+		ClientCert:         recordConfig.ClientCert,
+		ClientKey:          recordConfig.ClientKey,
+		ClientKeyPassword:  recordConfig.ClientKeyPassword,
+		ServerName:         strings.TrimSpace(recordConfig.ServerName),
+		MinVersion:         strings.TrimSpace(recordConfig.MinVersion),
+		InsecureSkipVerify: recordConfig.InsecureSkipVerify,
+	}, nil
+}
+
 // buildServiceConfigFromRecord 从数据库记录构建服务配置（内部辅助方法，不包含节点数据）
 func (loader *LimiterServiceLoader) buildServiceConfigFromRecord(record ServiceConfigRecord) *service.ServiceConfig {
 	// 构建服务配置
@@ -379,22 +459,10 @@ func (loader *LimiterServiceLoader) buildServiceConfigFromRecord(record ServiceC
 		Name: record.ServiceName,
 	}
 
-	// 解析负载均衡策略
-	switch record.LoadBalanceStrategy {
-	case "ROUND_ROBIN":
-		serviceConf.Strategy = service.RoundRobin
-	case "RANDOM":
-		serviceConf.Strategy = service.Random
-	case "WEIGHTED_ROUND_ROBIN":
-		serviceConf.Strategy = service.WeightedRoundRobin
-	case "LEAST_CONNECTION":
-		serviceConf.Strategy = service.LeastConn
-	case "IP_HASH":
-		serviceConf.Strategy = service.IPHash
-	case "CONSISTENT_HASH":
-		serviceConf.Strategy = service.ConsistentHash
-	default:
-		serviceConf.Strategy = service.RoundRobin
+	// 解析负载均衡策略，未配置或无法识别时使用轮询
+	serviceConf.Strategy = service.RoundRobin
+	if strategy, ok := loadBalanceStrategies[record.LoadBalanceStrategy]; ok {
+		serviceConf.Strategy = strategy
 	}
 
 	// 设置负载均衡器配置
@@ -407,6 +475,17 @@ func (loader *LimiterServiceLoader) buildServiceConfigFromRecord(record ServiceC
 		RetryTimeout:    time.Duration(record.RetryTimeoutMs) * time.Millisecond,
 		CircuitBreaker:  record.EnableCircuitBreaker == "Y",
 	}
+
+	// 解析负载均衡器扩展配置（一致性哈希的哈希键来源、有界负载系数和服务级分阶段超时）
+	if record.LoadBalancerConfig != nil && *record.LoadBalancerConfig != "" {
+		var extConfig loadBalancerExtConfig
+		if err := json.Unmarshal([]byte(*record.LoadBalancerConfig), &extConfig); err == nil {
+			lbConfig.HashOn = extConfig.hashOn()
+			lbConfig.HashKey = strings.TrimSpace(extConfig.HashKey)
+			lbConfig.LoadFactor = extConfig.LoadFactor
+			serviceConf.Timeouts = extConfig.timeouts()
+		}
+	}
 	serviceConf.LoadBalancer = lbConfig
 
 	// 设置健康检查配置（服务级别配置）
@@ -492,6 +571,11 @@ func (loader *LimiterServiceLoader) buildServiceConfigFromRecord(record ServiceC
 		}
 	}
 
+	// 解析上游TLS配置，格式错误的记录已在校验阶段跳过
+	if tlsConfig, err := parseUpstreamTLSConfig(record.UpstreamTlsConfig); err == nil {
+		serviceConf.TLS = tlsConfig
+	}
+
 	return serviceConf
 }
 
@@ -505,7 +589,7 @@ func (loader *LimiterServiceLoader) LoadServiceConfig(ctx context.Context, servi
 		       healthCheckEnabled, healthCheckPath, healthCheckMethod,
 		       healthCheckIntervalSeconds, healthCheckTimeoutMs, healthyThreshold,
 		       unhealthyThreshold, expectedStatusCodes, healthCheckHeaders,
-		       loadBalancerConfig, serviceMetadata, activeFlag
+		       loadBalancerConfig, serviceMetadata, upstreamTlsConfig, activeFlag
 		FROM HUB_GW_SERVICE_DEFINITION 
 		WHERE tenantId = ? AND serviceDefinitionId = ? AND activeFlag = 'Y'
 	`
@@ -551,12 +635,13 @@ func (loader *LimiterServiceLoader) LoadServiceNodes(ctx context.Context, servic
 
 	var nodes []*service.NodeConfig
 	for _, record := range records {
+		// 维护状态的节点已被摘除流量，加载为禁用节点不参与负载均衡
 		node := &service.NodeConfig{
 			ID:      record.ServiceNodeId,
 			URL:     record.NodeUrl,
 			Weight:  record.NodeWeight,
 			Health:  record.HealthStatus == "Y",
-			Enabled: record.ActiveFlag == "Y",
+			Enabled: record.ActiveFlag == "Y" && record.NodeStatus != NodeStatusMaintenance,
 		}
 
 		// 解析节点元数据
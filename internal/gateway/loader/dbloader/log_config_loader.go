@@ -111,6 +111,14 @@ func (loader *LogConfigLoader) buildLogConfig(record *LogConfigRecord) *types.Lo
 	cleanupCfg := types.ParseCleanupConfigFromExtProperty(config.ExtProperty)
 	config.SetCleanupConfig(cleanupCfg)
 
+	// 预解析 extProperty 中的慢请求诊断配置（构建时解析一次，避免后续重复解析）
+	slowRequestCfg := types.ParseSlowRequestConfigFromExtProperty(config.ExtProperty)
+	config.SetSlowRequestConfig(slowRequestCfg)
+
+	// 预解析 extProperty 中的失败日志落盘重试配置（构建时解析一次，避免后续重复解析）
+	logSpoolCfg := types.ParseLogSpoolConfigFromExtProperty(config.ExtProperty)
+	config.SetLogSpoolConfig(logSpoolCfg)
+
 	return config
 }
 
@@ -0,0 +1,186 @@
+package dbloader
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gateway/internal/gateway/handler/filter"
+	"gateway/internal/gateway/handler/service"
+)
+
+// loadBalanceStrategies 数据库中可配置的负载均衡策略
+// 兼容历史的大写枚举值和管理端使用的小写策略名
+var loadBalanceStrategies = map[string]service.Strategy{
+	"ROUND_ROBIN":          service.RoundRobin,
+	"RANDOM":               service.Random,
+	"WEIGHTED_ROUND_ROBIN": service.WeightedRoundRobin,
+	"LEAST_CONNECTION":     service.LeastConn,
+	"IP_HASH":              service.IPHash,
+	"CONSISTENT_HASH":      service.ConsistentHash,
+
+	string(service.RoundRobin):         service.RoundRobin,
+	string(service.Random):             service.Random,
+	string(service.WeightedRoundRobin): service.WeightedRoundRobin,
+	string(service.LeastConn):          service.LeastConn,
+	string(service.IPHash):             service.IPHash,
+	string(service.ConsistentHash):     service.ConsistentHash,
+}
+
+// ValidateRouteRecord 校验路由配置记录
+// 使用与加载器相同的转换逻辑构建路由运行时配置并执行路由校验，
+// 管理端保存前调用，避免保存后网关加载或重载失败
+//
+// 参数:
+//   - record: 路由配置记录
+//
+// 返回:
+//   - error: 校验失败原因
+func ValidateRouteRecord(record RouteConfigRecord) error {
+	if record.AllowedMethods != nil && strings.TrimSpace(*record.AllowedMethods) != "" {
+		var methods []string
+		if err := json.Unmarshal([]byte(*record.AllowedMethods), &methods); err != nil {
+			return fmt.Errorf("允许的HTTP方法必须是JSON数组: %w", err)
+		}
+	}
+	if record.RouteMetadata != nil && strings.TrimSpace(*record.RouteMetadata) != "" {
+		var metadata map[string]interface{}
+		if err := json.Unmarshal([]byte(*record.RouteMetadata), &metadata); err != nil {
+			return fmt.Errorf("路由元数据必须是JSON对象: %w", err)
+		}
+	}
+
+	// 新建路由在保存前尚未生成ID，使用占位ID完成其余校验
+	if record.RouteConfigId == "" {
+		record.RouteConfigId = "new-route"
+	}
+	config := buildRouteConfig(record)
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("路由配置校验失败: %w", err)
+	}
+	return nil
+}
+
+// ValidateFilterRecord 校验过滤器配置记录
+// 使用与加载器相同的转换逻辑构建过滤器配置，并通过过滤器工厂创建过滤器实例完成校验
+//
+// 参数:
+//   - record: 过滤器配置记录
+//
+// 返回:
+//   - error: 校验失败原因
+func ValidateFilterRecord(record FilterConfigRecord) error {
+	if strings.TrimSpace(record.FilterConfig) == "" {
+		record.FilterConfig = "{}"
+	}
+	config, err := buildFilterConfig(record)
+	if err != nil {
+		return err
+	}
+	if _, err := filter.NewFilterFactory().CreateFilter(config); err != nil {
+		return fmt.Errorf("过滤器配置校验失败: %w", err)
+	}
+	return nil
+}
+
+// ValidateServiceRecord 校验服务定义记录
+// 加载器会把未知的负载均衡策略静默回退为轮询、忽略无法解析的JSON字段，保存前在此拒绝这类配置
+//
+// 参数:
+//   - record: 服务定义记录
+//
+// 返回:
+//   - error: 校验失败原因
+func ValidateServiceRecord(record ServiceConfigRecord) error {
+	if strings.TrimSpace(record.ServiceName) == "" {
+		return fmt.Errorf("服务名称不能为空")
+	}
+	if record.LoadBalanceStrategy != "" {
+		if _, ok := loadBalanceStrategies[record.LoadBalanceStrategy]; !ok {
+			return fmt.Errorf("不支持的负载均衡策略: %s", record.LoadBalanceStrategy)
+		}
+	}
+	if record.MaxRetries < 0 {
+		return fmt.Errorf("最大重试次数不能为负数")
+	}
+	if record.RetryTimeoutMs < 0 {
+		return fmt.Errorf("重试超时时间不能为负数")
+	}
+
+	if record.HealthCheckEnabled == "Y" {
+		if record.HealthCheckPath != "" && !strings.HasPrefix(record.HealthCheckPath, "/") {
+			return fmt.Errorf("健康检查路径必须以/开头")
+		}
+		if record.ExpectedStatusCodes != "" && len(parseStatusCodes(record.ExpectedStatusCodes)) == 0 {
+			return fmt.Errorf("健康检查期望状态码格式错误: %s", record.ExpectedStatusCodes)
+		}
+		if record.HealthCheckHeaders != nil && strings.TrimSpace(*record.HealthCheckHeaders) != "" {
+			var headers map[string]string
+			if err := json.Unmarshal([]byte(*record.HealthCheckHeaders), &headers); err != nil {
+				return fmt.Errorf("健康检查请求头必须是字符串键值的JSON对象: %w", err)
+			}
+		}
+	}
+
+	jsonFields := []struct {
+		name  string
+		value *string
+	}{
+		{"负载均衡配置", record.LoadBalancerConfig},
+		{"服务元数据", record.ServiceMetadata},
+		{"服务发现配置", record.DiscoveryConfig},
+		{"上游TLS配置", record.UpstreamTlsConfig},
+	}
+	for _, field := range jsonFields {
+		if field.value == nil || strings.TrimSpace(*field.value) == "" {
+			continue
+		}
+		if !json.Valid([]byte(*field.value)) {
+			return fmt.Errorf("%s不是有效的JSON", field.name)
+		}
+	}
+
+	if loadBalanceStrategies[record.LoadBalanceStrategy] == service.ConsistentHash &&
+		record.LoadBalancerConfig != nil && strings.TrimSpace(*record.LoadBalancerConfig) != "" {
+		var extConfig loadBalancerExtConfig
+		if err := json.Unmarshal([]byte(*record.LoadBalancerConfig), &extConfig); err != nil {
+			return fmt.Errorf("负载均衡配置格式错误: %w", err)
+		}
+		switch extConfig.hashOn() {
+		case "", service.HashOnIP, service.HashOnPath:
+		case service.HashOnHeader, service.HashOnQuery:
+			if strings.TrimSpace(extConfig.HashKey) == "" {
+				return fmt.Errorf("按%s哈希时哈希键名称不能为空", extConfig.hashOn())
+			}
+		default:
+			return fmt.Errorf("不支持的哈希键来源: %s，支持的类型: ip, header, path, query", extConfig.HashOn)
+		}
+		if extConfig.LoadFactor < 0 {
+			return fmt.Errorf("有界负载系数不能为负数")
+		}
+	}
+
+	// 分阶段超时对所有负载均衡策略生效
+	if record.LoadBalancerConfig != nil && strings.TrimSpace(*record.LoadBalancerConfig) != "" {
+		var extConfig loadBalancerExtConfig
+		if err := json.Unmarshal([]byte(*record.LoadBalancerConfig), &extConfig); err != nil {
+			return fmt.Errorf("负载均衡配置格式错误: %w", err)
+		}
+		if extConfig.ConnectTimeoutMs < 0 || extConfig.TLSHandshakeTimeoutMs < 0 ||
+			extConfig.ResponseHeaderTimeoutMs < 0 || extConfig.RequestTimeoutMs < 0 {
+			return fmt.Errorf("服务超时配置不能为负数")
+		}
+	}
+
+	// 加载证书校验上游TLS配置，避免证书错误到转发请求时才暴露
+	tlsConfig, err := parseUpstreamTLSConfig(record.UpstreamTlsConfig)
+	if err != nil {
+		return fmt.Errorf("上游TLS配置格式错误: %w", err)
+	}
+	if tlsConfig != nil {
+		if err := tlsConfig.Validate(); err != nil {
+			return fmt.Errorf("上游TLS配置无效: %w", err)
+		}
+	}
+	return nil
+}
@@ -133,80 +133,9 @@ func (loader *RouterConfigLoader) LoadRoutes(ctx context.Context, instanceId str
 
 	var routes []router.RouteConfig
 	for _, record := range records {
-		// 构建路由配置
+		// 构建路由配置（服务、方法、元数据）
 		routeConfig := buildRouteConfig(record)
 
-		// 处理服务ID（支持单服务和多服务模式）
-		if record.ServiceDefinitionId != nil && *record.ServiceDefinitionId != "" {
-			serviceIdStr := strings.TrimSpace(*record.ServiceDefinitionId)
-			// 检查是否包含逗号（多服务模式）
-			if strings.Contains(serviceIdStr, ",") {
-				// 多服务模式：分割服务ID
-				serviceIds := strings.Split(serviceIdStr, ",")
-				var trimmedServiceIds []string
-				for _, id := range serviceIds {
-					trimmed := strings.TrimSpace(id)
-					if trimmed != "" {
-						trimmedServiceIds = append(trimmedServiceIds, trimmed)
-					}
-				}
-				if len(trimmedServiceIds) > 0 {
-					routeConfig.ServiceIDs = trimmedServiceIds
-				}
-			} else {
-				// 单服务模式（向后兼容）
-				routeConfig.ServiceID = serviceIdStr
-			}
-		}
-
-		// 解析允许的方法
-		if record.AllowedMethods != nil {
-			var methods []string
-			if err := json.Unmarshal([]byte(*record.AllowedMethods), &methods); err == nil {
-				routeConfig.Methods = methods
-			}
-		}
-
-		// 构建元数据
-		metadata := make(map[string]interface{})
-
-		if record.RouteMetadata != nil {
-			// 尝试解析JSON元数据
-			var routeMetadata map[string]interface{}
-			if err := json.Unmarshal([]byte(*record.RouteMetadata), &routeMetadata); err == nil {
-				for k, v := range routeMetadata {
-					metadata[k] = v
-				}
-				routeConfig.OverrideProxyTimeout = metadataEnabledFlag(routeMetadata,
-					"overrideProxyTimeout", "override_proxy_timeout")
-
-				// 如果是多服务模式，从 routeMetadata 中提取多服务配置
-				if len(routeConfig.ServiceIDs) > 0 {
-					multiServiceConfig := &router.MultiServiceConfig{
-						ResponseMergeStrategy: "first", // 默认值
-						MaxConcurrentRequests: 0,       // 默认值：不限制
-						RequireAllSuccess:     false,   // 默认值
-					}
-
-					// 从 routeMetadata 中提取多服务配置字段
-					if responseMergeStrategy, ok := routeMetadata["responseMergeStrategy"].(string); ok {
-						multiServiceConfig.ResponseMergeStrategy = responseMergeStrategy
-					}
-					if maxConcurrentRequests, ok := routeMetadata["maxConcurrentRequests"].(float64); ok {
-						multiServiceConfig.MaxConcurrentRequests = int(maxConcurrentRequests)
-					} else if maxConcurrentRequestsInt, ok := routeMetadata["maxConcurrentRequests"].(int); ok {
-						multiServiceConfig.MaxConcurrentRequests = maxConcurrentRequestsInt
-					}
-					if requireAllSuccess, ok := routeMetadata["requireAllSuccess"].(bool); ok {
-						multiServiceConfig.RequireAllSuccess = requireAllSuccess
-					}
-
-					routeConfig.MultiServiceConfig = multiServiceConfig
-				}
-			}
-		}
-		routeConfig.Metadata = metadata
-
 		// 加载断言组配置
 		assertionGroupConfig, err := loader.LoadRouteAssertionGroup(ctx, record.RouteConfigId)
 		if err != nil {
@@ -233,7 +162,7 @@ func (loader *RouterConfigLoader) LoadRoutes(ctx context.Context, instanceId str
 	return routes, nil
 }
 
-// buildRouteConfig 将数据库基础字段转换为路由运行时配置。
+// buildRouteConfig 将数据库记录转换为路由运行时配置（不含断言组和过滤器）。
 func buildRouteConfig(record RouteConfigRecord) router.RouteConfig {
 	config := router.RouteConfig{
 		ID:                        record.RouteConfigId,
@@ -252,9 +181,127 @@ func buildRouteConfig(record RouteConfigRecord) router.RouteConfig {
 	if record.RewritePath != nil {
 		config.RewritePath = *record.RewritePath
 	}
+
+	// 处理服务ID（支持单服务和多服务模式）
+	if record.ServiceDefinitionId != nil && *record.ServiceDefinitionId != "" {
+		serviceIdStr := strings.TrimSpace(*record.ServiceDefinitionId)
+		// 检查是否包含逗号（多服务模式）
+		if strings.Contains(serviceIdStr, ",") {
+			// 多服务模式：分割服务ID
+			serviceIds := strings.Split(serviceIdStr, ",")
+			var trimmedServiceIds []string
+			for _, id := range serviceIds {
+				trimmed := strings.TrimSpace(id)
+				if trimmed != "" {
+					trimmedServiceIds = append(trimmedServiceIds, trimmed)
+				}
+			}
+			if len(trimmedServiceIds) > 0 {
+				config.ServiceIDs = trimmedServiceIds
+			}
+		} else {
+			// 单服务模式（向后兼容）
+			config.ServiceID = serviceIdStr
+		}
+	}
+
+	// 解析允许的方法
+	if record.AllowedMethods != nil {
+		var methods []string
+		if err := json.Unmarshal([]byte(*record.AllowedMethods), &methods); err == nil {
+			config.Methods = methods
+		}
+	}
+
+	// 构建元数据
+	metadata := make(map[string]interface{})
+
+	if record.RouteMetadata != nil {
+		// 尝试解析JSON元数据
+		var routeMetadata map[string]interface{}
+		if err := json.Unmarshal([]byte(*record.RouteMetadata), &routeMetadata); err == nil {
+			for k, v := range routeMetadata {
+				metadata[k] = v
+			}
+			config.OverrideProxyTimeout = metadataEnabledFlag(routeMetadata,
+				"overrideProxyTimeout", "override_proxy_timeout")
+			config.ConnectTimeout = metadataMilliseconds(routeMetadata, "connectTimeoutMs")
+			config.TLSHandshakeTimeout = metadataMilliseconds(routeMetadata, "tlsHandshakeTimeoutMs")
+			config.ResponseHeaderTimeout = metadataMilliseconds(routeMetadata, "responseHeaderTimeoutMs")
+			config.CoalesceConfig = buildCoalesceConfig(routeMetadata)
+			config.IdempotencyConfig = buildIdempotencyConfig(routeMetadata)
+
+			// 如果是多服务模式，从 routeMetadata 中提取多服务配置
+			if len(config.ServiceIDs) > 0 {
+				multiServiceConfig := &router.MultiServiceConfig{
+					ResponseMergeStrategy: "first", // 默认值
+					MaxConcurrentRequests: 0,       // 默认值：不限制
+					RequireAllSuccess:     false,   // 默认值
+				}
+
+				// 从 routeMetadata 中提取多服务配置字段
+				if responseMergeStrategy, ok := routeMetadata["responseMergeStrategy"].(string); ok {
+					multiServiceConfig.ResponseMergeStrategy = responseMergeStrategy
+				}
+				if maxConcurrentRequests, ok := routeMetadata["maxConcurrentRequests"].(float64); ok {
+					multiServiceConfig.MaxConcurrentRequests = int(maxConcurrentRequests)
+				} else if maxConcurrentRequestsInt, ok := routeMetadata["maxConcurrentRequests"].(int); ok {
+					multiServiceConfig.MaxConcurrentRequests = maxConcurrentRequestsInt
+				}
+				if requireAllSuccess, ok := routeMetadata["requireAllSuccess"].(bool); ok {
+					multiServiceConfig.RequireAllSuccess = requireAllSuccess
+				}
+
+				config.MultiServiceConfig = multiServiceConfig
+			}
+		}
+	}
+	config.Metadata = metadata
+
 	return config
 }
 
+// buildCoalesceConfig 从路由元数据解析请求合并配置，requestCoalescing 不为 "Y" 时返回nil。
+func buildCoalesceConfig(metadata map[string]interface{}) *router.CoalesceConfig {
+	if !metadataEnabledFlag(metadata, "requestCoalescing", "request_coalescing") {
+		return nil
+	}
+	config := &router.CoalesceConfig{Enabled: true}
+	if template, ok := metadata["coalesceKeyTemplate"].(string); ok {
+		config.KeyTemplate = template
+	}
+	if maxBodyBytes, ok := metadata["coalesceMaxBodyBytes"].(float64); ok {
+		config.MaxBodyBytes = int64(maxBodyBytes)
+	}
+	return config
+}
+
+// buildIdempotencyConfig 从路由元数据解析幂等键去重配置，idempotency 不为 "Y" 时返回nil。
+func buildIdempotencyConfig(metadata map[string]interface{}) *router.IdempotencyConfig {
+	if !metadataEnabledFlag(metadata, "idempotency") {
+		return nil
+	}
+	config := &router.IdempotencyConfig{Enabled: true}
+	if header, ok := metadata["idempotencyHeader"].(string); ok {
+		config.Header = header
+	}
+	if ttlSeconds, ok := metadata["idempotencyTtlSeconds"].(float64); ok && ttlSeconds > 0 {
+		config.TTL = time.Duration(ttlSeconds) * time.Second
+	}
+	if maxBodyBytes, ok := metadata["idempotencyMaxBodyBytes"].(float64); ok {
+		config.MaxBodyBytes = int64(maxBodyBytes)
+	}
+	return config
+}
+
+// metadataMilliseconds 解析路由元数据中的毫秒数，未配置或不是正数时返回0
+func metadataMilliseconds(metadata map[string]interface{}, key string) time.Duration {
+	if ms, ok := metadata[key].(float64); ok && ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+	return 0
+}
+
 // metadataEnabledFlag 解析路由元数据开关：仅字符串 "Y" 为开启，其余（含 N/true/1）均关闭。
 func metadataEnabledFlag(metadata map[string]interface{}, keys ...string) bool {
 	for _, key := range keys {
@@ -428,41 +475,13 @@ func (loader *RouterConfigLoader) LoadRouteFilters(ctx context.Context, routeId
 
 	var filters []filter.FilterConfig
 	for _, record := range records {
-		// 解析过滤器配置
-		var config map[string]interface{}
-		if err := json.Unmarshal([]byte(record.FilterConfig), &config); err != nil {
+		filterCfg, err := buildFilterConfig(record)
+		if err != nil {
 			logger.Error("解析过滤器配置失败",
 				"filterId", record.FilterConfigId,
 				"error", err)
 			continue
 		}
-
-		// 修复配置层级问题：直接使用解析的配置，不再嵌套
-		// 如果配置中有特定的子配置对象（如headerConfig），将其提升到顶层
-		flatConfig := make(map[string]interface{})
-		for key, value := range config {
-			if subConfig, ok := value.(map[string]interface{}); ok &&
-				(key == "headerConfig" || key == "queryConfig" || key == "bodyConfig" || key == "urlConfig") {
-				// 将子配置的内容提升到顶层
-				for subKey, subValue := range subConfig {
-					flatConfig[subKey] = subValue
-				}
-			} else {
-				flatConfig[key] = value
-			}
-		}
-
-		// 构建过滤器配置
-		filterCfg := filter.FilterConfig{
-			ID:      record.FilterConfigId,
-			Name:    record.FilterName,
-			Type:    record.FilterType,
-			Enabled: record.ActiveFlag == "Y",
-			Order:   record.FilterOrder,
-			Action:  record.FilterAction,
-			Config:  flatConfig, // 使用扁平化的配置
-		}
-
 		filters = append(filters, filterCfg)
 	}
 
@@ -487,44 +506,48 @@ func (loader *RouterConfigLoader) LoadGlobalFilters(ctx context.Context, instanc
 
 	var filters []filter.FilterConfig
 	for _, record := range records {
-		// 解析过滤器配置
-		var config map[string]interface{}
-		if err := json.Unmarshal([]byte(record.FilterConfig), &config); err != nil {
+		filterCfg, err := buildFilterConfig(record)
+		if err != nil {
 			logger.Error("解析全局过滤器配置失败",
 				"filterId", record.FilterConfigId,
 				"error", err)
 			continue
 		}
+		filters = append(filters, filterCfg)
+	}
 
-		// 修复配置层级问题：直接使用解析的配置，不再嵌套
-		flatConfig := make(map[string]interface{})
-		for key, value := range config {
-			if subConfig, ok := value.(map[string]interface{}); ok &&
-				(key == "headerConfig" || key == "queryConfig" || key == "bodyConfig" || key == "urlConfig") {
-				// 将子配置的内容提升到顶层
-				for subKey, subValue := range subConfig {
-					flatConfig[subKey] = subValue
-				}
-			} else {
-				flatConfig[key] = value
-			}
-		}
+	return filters, nil
+}
 
-		// 构建过滤器配置
-		filterCfg := filter.FilterConfig{
-			ID:      record.FilterConfigId,
-			Name:    record.FilterName,
-			Type:    record.FilterType,
-			Enabled: record.ActiveFlag == "Y",
-			Order:   record.FilterOrder,
-			Action:  record.FilterAction,
-			Config:  flatConfig, // 使用扁平化的配置
-		}
+// buildFilterConfig 将数据库记录转换为过滤器运行时配置
+// headerConfig、queryConfig 等子配置对象的内容会提升到顶层，与过滤器工厂读取的配置层级一致
+func buildFilterConfig(record FilterConfigRecord) (filter.FilterConfig, error) {
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(record.FilterConfig), &config); err != nil {
+		return filter.FilterConfig{}, fmt.Errorf("过滤器配置不是有效的JSON: %w", err)
+	}
 
-		filters = append(filters, filterCfg)
+	flatConfig := make(map[string]interface{})
+	for key, value := range config {
+		if subConfig, ok := value.(map[string]interface{}); ok &&
+			(key == "headerConfig" || key == "queryConfig" || key == "bodyConfig" || key == "urlConfig") {
+			for subKey, subValue := range subConfig {
+				flatConfig[subKey] = subValue
+			}
+		} else {
+			flatConfig[key] = value
+		}
 	}
 
-	return filters, nil
+	return filter.FilterConfig{
+		ID:      record.FilterConfigId,
+		Name:    record.FilterName,
+		Type:    record.FilterType,
+		Enabled: record.ActiveFlag == "Y",
+		Order:   record.FilterOrder,
+		Action:  record.FilterAction,
+		Config:  flatConfig,
+	}, nil
 }
 
 // parseStringArray 解析逗号分隔的字符串或JSON数组，处理空白字符和空字符串
@@ -259,9 +259,14 @@ type ServiceConfigRecord struct {
 	HealthCheckHeaders         *string `db:"healthCheckHeaders"`
 	LoadBalancerConfig         *string `db:"loadBalancerConfig"`
 	ServiceMetadata            *string `db:"serviceMetadata"`
+	UpstreamTlsConfig          *string `db:"upstreamTlsConfig"`
 	ActiveFlag                 string  `db:"activeFlag"`
 }
 
+// NodeStatusMaintenance 服务节点维护状态，对应 HUB_GW_SERVICE_NODE.nodeStatus = 2
+// 处于维护状态的节点加载为禁用节点，用于摘除流量（节点排空）
+const NodeStatusMaintenance = 2
+
 // ServiceNodeRecord 服务节点数据库记录
 type ServiceNodeRecord struct {
 	TenantId            string  `db:"tenantId"`
@@ -326,3 +331,18 @@ type LogConfigRecord struct {
 	ActiveFlag                 string `db:"activeFlag"`
 	ExtProperty                string `db:"extProperty"`
 }
+
+// FeatureFlagRecord 功能开关数据库记录
+type FeatureFlagRecord struct {
+	TenantId          string  `db:"tenantId"`
+	FeatureFlagId     string  `db:"featureFlagId"`
+	GatewayInstanceId *string `db:"gatewayInstanceId"`
+	RouteConfigId     *string `db:"routeConfigId"`
+	FlagKey           string  `db:"flagKey"`
+	FlagName          string  `db:"flagName"`
+	FlagEnabled       string  `db:"flagEnabled"`
+	RolloutPercent    int     `db:"rolloutPercent"`
+	BucketBy          string  `db:"bucketBy"`
+	BucketKey         *string `db:"bucketKey"`
+	LogEvaluation     string  `db:"logEvaluation"`
+}
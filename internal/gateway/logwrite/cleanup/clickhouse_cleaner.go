@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"gateway/internal/gateway/logwrite/types"
+	"gateway/internal/script/ck"
 	"gateway/pkg/database"
+	"gateway/pkg/database/clickhouse"
 	"gateway/pkg/logger"
 )
 
@@ -168,118 +170,44 @@ func (c *ClickHouseLogCleaner) cleanup() {
 
 // cleanupAccessLogs 清理ClickHouse访问日志（按分区删除）
 func (c *ClickHouseLogCleaner) cleanupAccessLogs(ctx context.Context) (int, error) {
-	cleanupConfig := c.config.GetCleanupConfig()
-	cutoffDate := time.Now().AddDate(0, 0, -cleanupConfig.RetentionDays)
-
-	logger.Info("Cleaning up ClickHouse access logs by partition",
-		"table", "HUB_GW_ACCESS_LOG",
-		"instanceId", c.instanceID,
-		"cutoffDate", cutoffDate.Format("2006-01-02"),
-		"retentionDays", cleanupConfig.RetentionDays)
-
-	// 查询需要删除的分区列表
-	querySQL := `
-		SELECT DISTINCT partition
-		FROM system.parts
-		WHERE database = currentDatabase()
-		  AND table = 'HUB_GW_ACCESS_LOG'
-		  AND partition < ?
-		  AND active = 1
-		ORDER BY partition
-	`
-
-	var partitions []struct {
-		Partition string `db:"partition"`
-	}
-	err := c.db.Query(ctx, &partitions, querySQL, []interface{}{
-		cutoffDate.Format("2006-01-02"),
-	}, true)
-	if err != nil {
-		return 0, fmt.Errorf("query partitions failed: %w", err)
-	}
-
-	if len(partitions) == 0 {
-		logger.Info("No partitions to drop for access logs")
-		return 0, nil
-	}
-
-	// 删除每个分区
-	droppedCount := 0
-	for _, p := range partitions {
-		dropSQL := fmt.Sprintf("ALTER TABLE HUB_GW_ACCESS_LOG DROP PARTITION '%s'", p.Partition)
-		_, err := c.db.Exec(ctx, dropSQL, nil, true)
-		if err != nil {
-			logger.Error("Failed to drop partition",
-				"table", "HUB_GW_ACCESS_LOG",
-				"partition", p.Partition,
-				"error", err)
-			continue
-		}
-		droppedCount++
-		logger.Info("Dropped partition",
-			"table", "HUB_GW_ACCESS_LOG",
-			"partition", p.Partition)
-	}
-
-	return droppedCount, nil
+	return c.dropExpiredPartitions(ctx, ck.AccessLogTable)
 }
 
 // cleanupBackendTraceLogs 清理ClickHouse后端追踪日志（按分区删除）
 func (c *ClickHouseLogCleaner) cleanupBackendTraceLogs(ctx context.Context) (int, error) {
+	return c.dropExpiredPartitions(ctx, ck.BackendTraceLogTable)
+}
+
+// dropExpiredPartitions 删除表中超过保留天数的日期分区
+func (c *ClickHouseLogCleaner) dropExpiredPartitions(ctx context.Context, table string) (int, error) {
 	cleanupConfig := c.config.GetCleanupConfig()
-	cutoffDate := time.Now().AddDate(0, 0, -cleanupConfig.RetentionDays)
+	cutoffDate := time.Now().AddDate(0, 0, -cleanupConfig.RetentionDays).Format("2006-01-02")
 
-	logger.Info("Cleaning up ClickHouse backend trace logs by partition",
-		"table", "HUB_GW_BACKEND_TRACE_LOG",
+	logger.Info("Cleaning up ClickHouse logs by partition",
+		"table", table,
 		"instanceId", c.instanceID,
-		"cutoffDate", cutoffDate.Format("2006-01-02"),
+		"cutoffDate", cutoffDate,
 		"retentionDays", cleanupConfig.RetentionDays)
 
-	// 查询需要删除的分区列表
-	querySQL := `
-		SELECT DISTINCT partition
-		FROM system.parts
-		WHERE database = currentDatabase()
-		  AND table = 'HUB_GW_BACKEND_TRACE_LOG'
-		  AND partition < ?
-		  AND active = 1
-		ORDER BY partition
-	`
-
-	var partitions []struct {
-		Partition string `db:"partition"`
+	dropped, err := clickhouse.DropPartitionsBefore(ctx, c.db, table, cutoffDate)
+	for _, p := range dropped {
+		logger.Info("Dropped partition",
+			"table", table,
+			"partition", p.Partition)
 	}
-	err := c.db.Query(ctx, &partitions, querySQL, []interface{}{
-		cutoffDate.Format("2006-01-02"),
-	}, true)
 	if err != nil {
-		return 0, fmt.Errorf("query partitions failed: %w", err)
-	}
-
-	if len(partitions) == 0 {
-		logger.Info("No partitions to drop for backend trace logs")
-		return 0, nil
-	}
-
-	// 删除每个分区
-	droppedCount := 0
-	for _, p := range partitions {
-		dropSQL := fmt.Sprintf("ALTER TABLE HUB_GW_BACKEND_TRACE_LOG DROP PARTITION '%s'", p.Partition)
-		_, err := c.db.Exec(ctx, dropSQL, nil, true)
-		if err != nil {
-			logger.Error("Failed to drop partition",
-				"table", "HUB_GW_BACKEND_TRACE_LOG",
-				"partition", p.Partition,
-				"error", err)
-			continue
+		// 部分分区删除失败时记录错误，已删除的分区仍计入结果
+		if len(dropped) > 0 {
+			logger.Error("Failed to drop some partitions", "table", table, "error", err)
+			return len(dropped), nil
 		}
-		droppedCount++
-		logger.Info("Dropped partition",
-			"table", "HUB_GW_BACKEND_TRACE_LOG",
-			"partition", p.Partition)
+		return 0, err
 	}
 
-	return droppedCount, nil
+	if len(dropped) == 0 {
+		logger.Info("No partitions to drop", "table", table)
+	}
+	return len(dropped), nil
 }
 
 // CleanupNow 立即执行一次清理
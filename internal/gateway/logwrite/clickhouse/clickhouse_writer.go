@@ -18,6 +18,9 @@ import (
 // - 利用ClickHouse的列式存储特性
 // - 优化内存使用和写入性能
 type ClickHouseWriter struct {
+	// 落库失败上报器，落库失败的访问日志由落盘重试组件接管
+	types.AccessLogFailureReporter
+
 	// 日志配置
 	config *types.LogConfig
 
@@ -121,7 +124,9 @@ func (w *ClickHouseWriter) UpdateAccessLog(ctx context.Context, log *types.Acces
 // Write 写入单条访问日志
 func (w *ClickHouseWriter) Write(ctx context.Context, log *types.AccessLog) error {
 	if w.closed {
-		return fmt.Errorf("writer is closed")
+		err := fmt.Errorf("writer is closed")
+		w.ReportFailure([]*types.AccessLog{log}, err)
+		return err
 	}
 
 	// 如果启用异步模式，将日志放入队列
@@ -130,11 +135,16 @@ func (w *ClickHouseWriter) Write(ctx context.Context, log *types.AccessLog) erro
 		case w.logQueue <- log:
 			return nil
 		case <-ctx.Done():
+			w.ReportFailure([]*types.AccessLog{log}, ctx.Err())
 			return ctx.Err()
 		default:
 			// 队列满时的处理策略
-			logger.Warn("ClickHouse log queue is full, dropping log entry", "traceId", log.TraceID)
-			return fmt.Errorf("log queue is full")
+			if logger.RateLimit("clickhouse:logQueueFull", 10*time.Second) {
+				logger.Warn("ClickHouse log queue is full, dropping log entry", "traceId", log.TraceID)
+			}
+			err := fmt.Errorf("log queue is full")
+			w.ReportFailure([]*types.AccessLog{log}, err)
+			return err
 		}
 	}
 
@@ -149,26 +159,36 @@ func (w *ClickHouseWriter) BatchWrite(ctx context.Context, logs []*types.AccessL
 	}
 
 	if w.closed {
-		return fmt.Errorf("writer is closed")
+		err := fmt.Errorf("writer is closed")
+		w.ReportFailure(logs, err)
+		return err
 	}
 
 	// 如果启用异步模式，将所有日志放入队列
 	if w.config.IsAsyncLogging() {
-		for _, log := range logs {
+		for i, log := range logs {
 			select {
 			case w.logQueue <- log:
 				// 成功放入队列
 			case <-ctx.Done():
+				w.ReportFailure(logs[i:], ctx.Err())
 				return ctx.Err()
 			default:
-				logger.Warn("ClickHouse log queue is full, dropping log entry", "traceId", log.TraceID)
+				if logger.RateLimit("clickhouse:logQueueFull", 10*time.Second) {
+					logger.Warn("ClickHouse log queue is full, dropping log entry", "traceId", log.TraceID)
+				}
+				w.ReportFailure([]*types.AccessLog{log}, fmt.Errorf("log queue is full"))
 			}
 		}
 		return nil
 	}
 
 	// 同步模式：直接批量写入
-	return w.batchWriteDirectly(ctx, logs)
+	if err := w.batchWriteDirectly(ctx, logs); err != nil {
+		w.ReportFailure(logs, err)
+		return err
+	}
+	return nil
 }
 
 // Flush 刷新缓冲区，将缓存的日志写入ClickHouse
@@ -176,7 +196,11 @@ func (w *ClickHouseWriter) Flush(ctx context.Context) error {
 	if w.closed {
 		return nil
 	}
+	return w.flushBatchBuffer(ctx)
+}
 
+// flushBatchBuffer 将批量缓冲区写入数据库，关闭流程在标记关闭后仍需调用
+func (w *ClickHouseWriter) flushBatchBuffer(ctx context.Context) error {
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 
@@ -205,6 +229,7 @@ func (w *ClickHouseWriter) Flush(ctx context.Context) error {
 				"forwardMethodLen", len(log.ForwardMethod),
 				"clientIp", log.ClientIPAddress)
 		}
+		w.ReportFailure(w.batchBuffer, err)
 	}
 
 	// 无论成功或失败都清空缓冲区，避免失败数据重复写入导致死循环
@@ -234,7 +259,7 @@ func (w *ClickHouseWriter) Close() error {
 
 	// 刷新剩余的缓冲区数据
 	ctx := context.Background()
-	if err := w.Flush(ctx); err != nil {
+	if err := w.flushBatchBuffer(ctx); err != nil {
 		logger.Error("Failed to flush ClickHouse buffer during close", "error", err)
 	}
 	if err := w.FlushBackendTrace(ctx); err != nil {
@@ -280,7 +305,9 @@ func (w *ClickHouseWriter) WriteBackendTraceLog(ctx context.Context, log *types.
 			return ctx.Err()
 		default:
 			// 队列满时的处理策略
-			logger.Warn("ClickHouse backend trace log queue is full, dropping log entry", "traceId", log.TraceID, "backendTraceId", log.BackendTraceID)
+			if logger.RateLimit("clickhouse:backendTraceQueueFull", 10*time.Second) {
+				logger.Warn("ClickHouse backend trace log queue is full, dropping log entry", "traceId", log.TraceID, "backendTraceId", log.BackendTraceID)
+			}
 			return fmt.Errorf("backend trace log queue is full")
 		}
 	}
@@ -320,7 +347,9 @@ func (w *ClickHouseWriter) BatchWriteBackendTraceLog(ctx context.Context, logs [
 			case <-ctx.Done():
 				return ctx.Err()
 			default:
-				logger.Warn("ClickHouse backend trace log queue is full, dropping log entry", "traceId", log.TraceID, "backendTraceId", log.BackendTraceID)
+				if logger.RateLimit("clickhouse:backendTraceQueueFull", 10*time.Second) {
+					logger.Warn("ClickHouse backend trace log queue is full, dropping log entry", "traceId", log.TraceID, "backendTraceId", log.BackendTraceID)
+				}
 			}
 		}
 		return nil
@@ -445,7 +474,7 @@ func (w *ClickHouseWriter) drainQueue() {
 			// 队列为空，执行最终刷新
 			if count > 0 {
 				ctx := context.Background()
-				if err := w.Flush(ctx); err != nil {
+				if err := w.flushBatchBuffer(ctx); err != nil {
 					logger.Error("Failed to flush ClickHouse during queue drain", "error", err)
 				}
 			}
@@ -602,6 +631,9 @@ func (w *ClickHouseWriter) addToBatch(log *types.AccessLog) error {
 		ctx := context.Background()
 		if err := w.batchWriteDirectly(ctx, w.batchBuffer); err != nil {
 			logger.Error("Failed to write ClickHouse full batch", "error", err, "count", len(w.batchBuffer))
+			// 失败批次已交给失败回调，清空缓冲区避免下次刷新重复写入
+			w.ReportFailure(w.batchBuffer, err)
+			w.batchBuffer = w.batchBuffer[:0]
 			return err
 		}
 		w.batchBuffer = w.batchBuffer[:0]
@@ -610,6 +642,30 @@ func (w *ClickHouseWriter) addToBatch(log *types.AccessLog) error {
 	return nil
 }
 
+// RetryAccessLog 同步重写一条此前落库失败的访问日志，供失败日志落盘重试组件调用
+// ClickHouse 的 ALTER UPDATE 无法返回受影响行数，先按 tenantId+traceId 查询是否已落库：
+// 已落库时更新并自增 resetCount、标记已重置，否则直接插入。失败时不上报失败回调，由调用方保留日志继续重试
+func (w *ClickHouseWriter) RetryAccessLog(ctx context.Context, log *types.AccessLog) error {
+	if w.closed {
+		return fmt.Errorf("writer is closed")
+	}
+
+	var count int64
+	query := "SELECT count() FROM HUB_GW_ACCESS_LOG WHERE tenantId = ? AND traceId = ?"
+	if err := w.db.QueryOne(ctx, &count, query, []interface{}{log.TenantID, log.TraceID}, true); err != nil {
+		return fmt.Errorf("query existing access log: %w", err)
+	}
+	if count > 0 {
+		reconciled := *log
+		reconciled.ResetFlag = types.ResetFlagYes
+		if _, err := w.UpdateAccessLog(ctx, &reconciled); err != nil {
+			return fmt.Errorf("reconcile access log: %w", err)
+		}
+		return nil
+	}
+	return w.batchWriteDirectly(ctx, []*types.AccessLog{log})
+}
+
 // batchWriteDirectly 直接批量写入日志到ClickHouse
 func (w *ClickHouseWriter) batchWriteDirectly(ctx context.Context, logs []*types.AccessLog) error {
 	if len(logs) == 0 {
@@ -22,7 +22,11 @@ import (
 //   - 定时刷新机制确保日志及时写入
 //   - 线程安全的并发操作
 //   - 优雅关闭确保数据不丢失
+//   - 落库失败的访问日志上报给失败回调，由落盘重试组件接管
 type DBWriter struct {
+	// 落库失败上报器
+	types.AccessLogFailureReporter
+
 	// 日志配置，包含异步和批量写入配置
 	config *types.LogConfig
 
@@ -193,7 +197,9 @@ func (w *DBWriter) UpdateAccessLog(ctx context.Context, log *types.AccessLog) (i
 //   - error: 写入失败时返回错误信息
 func (w *DBWriter) Write(ctx context.Context, log *types.AccessLog) error {
 	if w.closed {
-		return fmt.Errorf("writer is closed")
+		err := fmt.Errorf("writer is closed")
+		w.ReportFailure([]*types.AccessLog{log}, err)
+		return err
 	}
 
 	// 如果启用异步模式，将日志放入队列
@@ -202,11 +208,16 @@ func (w *DBWriter) Write(ctx context.Context, log *types.AccessLog) error {
 		case w.logQueue <- log:
 			return nil
 		case <-ctx.Done():
+			w.ReportFailure([]*types.AccessLog{log}, ctx.Err())
 			return ctx.Err()
 		default:
 			// 队列满时的处理策略
-			logger.Warn("Log queue is full, dropping log entry", "traceId", log.TraceID)
-			return fmt.Errorf("log queue is full")
+			if logger.RateLimit("dbwrite:logQueueFull", 10*time.Second) {
+				logger.Warn("Log queue is full, dropping log entry", "traceId", log.TraceID)
+			}
+			err := fmt.Errorf("log queue is full")
+			w.ReportFailure([]*types.AccessLog{log}, err)
+			return err
 		}
 	}
 
@@ -216,7 +227,11 @@ func (w *DBWriter) Write(ctx context.Context, log *types.AccessLog) error {
 	}
 
 	// 直接写入数据库
-	return w.writeDirectly(ctx, log)
+	if err := w.writeDirectly(ctx, log); err != nil {
+		w.ReportFailure([]*types.AccessLog{log}, err)
+		return err
+	}
+	return nil
 }
 
 // BatchWrite 批量写入多条访问日志
@@ -233,26 +248,36 @@ func (w *DBWriter) BatchWrite(ctx context.Context, logs []*types.AccessLog) erro
 	}
 
 	if w.closed {
-		return fmt.Errorf("writer is closed")
+		err := fmt.Errorf("writer is closed")
+		w.ReportFailure(logs, err)
+		return err
 	}
 
 	// 如果启用异步模式，将所有日志放入队列
 	if w.config.IsAsyncLogging() {
-		for _, log := range logs {
+		for i, log := range logs {
 			select {
 			case w.logQueue <- log:
 				// 成功放入队列
 			case <-ctx.Done():
+				w.ReportFailure(logs[i:], ctx.Err())
 				return ctx.Err()
 			default:
-				logger.Warn("Log queue is full, dropping log entry", "traceId", log.TraceID)
+				if logger.RateLimit("dbwrite:logQueueFull", 10*time.Second) {
+					logger.Warn("Log queue is full, dropping log entry", "traceId", log.TraceID)
+				}
+				w.ReportFailure([]*types.AccessLog{log}, fmt.Errorf("log queue is full"))
 			}
 		}
 		return nil
 	}
 
 	// 同步模式：直接批量写入数据库
-	return w.batchWriteDirectly(ctx, logs)
+	if err := w.batchWriteDirectly(ctx, logs); err != nil {
+		w.ReportFailure(logs, err)
+		return err
+	}
+	return nil
 }
 
 // Flush 刷新缓冲区，将缓存的日志写入数据库
@@ -266,7 +291,11 @@ func (w *DBWriter) Flush(ctx context.Context) error {
 	if w.closed {
 		return nil
 	}
+	return w.flushBatchBuffer(ctx)
+}
 
+// flushBatchBuffer 将批量缓冲区写入数据库，关闭流程在标记关闭后仍需调用
+func (w *DBWriter) flushBatchBuffer(ctx context.Context) error {
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 
@@ -295,6 +324,7 @@ func (w *DBWriter) Flush(ctx context.Context) error {
 				"forwardMethodLen", len(log.ForwardMethod),
 				"clientIp", log.ClientIPAddress)
 		}
+		w.ReportFailure(w.batchBuffer, err)
 	}
 
 	// 无论成功或失败都清空缓冲区，避免失败数据重复写入导致死循环
@@ -327,7 +357,7 @@ func (w *DBWriter) Close() error {
 
 	// 刷新剩余的缓冲区数据
 	ctx := context.Background()
-	if err := w.Flush(ctx); err != nil {
+	if err := w.flushBatchBuffer(ctx); err != nil {
 		logger.Error("Failed to flush buffer during close", "error", err)
 	}
 	if err := w.FlushBackendTrace(ctx); err != nil {
@@ -366,6 +396,7 @@ func (w *DBWriter) startAsyncProcessor() {
 					ctx := context.Background()
 					if err := w.writeDirectly(ctx, log); err != nil {
 						logger.Error("Failed to write log in async mode", "error", err, "traceId", log.TraceID)
+						w.ReportFailure([]*types.AccessLog{log}, err)
 					}
 				}
 
@@ -425,6 +456,7 @@ func (w *DBWriter) drainQueue() {
 				ctx := context.Background()
 				if err := w.writeDirectly(ctx, log); err != nil {
 					logger.Error("Failed to write log while draining queue", "error", err, "traceId", log.TraceID)
+					w.ReportFailure([]*types.AccessLog{log}, err)
 				}
 			}
 			count++
@@ -433,7 +465,7 @@ func (w *DBWriter) drainQueue() {
 			// 队列为空，执行最终刷新确保缓冲区数据写入
 			if count > 0 {
 				ctx := context.Background()
-				if err := w.Flush(ctx); err != nil {
+				if err := w.flushBatchBuffer(ctx); err != nil {
 					logger.Error("Failed to flush during queue drain", "error", err)
 				}
 			}
@@ -537,6 +569,9 @@ func (w *DBWriter) addToBatch(log *types.AccessLog) error {
 		ctx := context.Background()
 		if err := w.batchWriteDirectly(ctx, w.batchBuffer); err != nil {
 			logger.Error("Failed to write full batch", "error", err, "count", len(w.batchBuffer))
+			// 失败批次已交给失败回调，清空缓冲区避免下次刷新重复写入
+			w.ReportFailure(w.batchBuffer, err)
+			w.batchBuffer = w.batchBuffer[:0]
 			return err
 		}
 		w.batchBuffer = w.batchBuffer[:0]
@@ -545,6 +580,26 @@ func (w *DBWriter) addToBatch(log *types.AccessLog) error {
 	return nil
 }
 
+// RetryAccessLog 同步重写一条此前落库失败的访问日志，供失败日志落盘重试组件调用
+// 原写入可能已经落库（如提交成功但响应超时），因此先按 tenantId+traceId 更新并自增 resetCount、
+// 标记已重置，未找到记录时再插入。该方法不经过异步队列，失败时不上报失败回调，由调用方保留日志继续重试
+func (w *DBWriter) RetryAccessLog(ctx context.Context, log *types.AccessLog) error {
+	if w.closed {
+		return fmt.Errorf("writer is closed")
+	}
+
+	reconciled := *log
+	reconciled.ResetFlag = types.ResetFlagYes
+	rows, err := w.UpdateAccessLog(ctx, &reconciled)
+	if err != nil {
+		return fmt.Errorf("reconcile access log: %w", err)
+	}
+	if rows > 0 {
+		return nil
+	}
+	return w.writeDirectly(ctx, log)
+}
+
 // writeDirectly 直接写入单条日志到数据库
 func (w *DBWriter) writeDirectly(ctx context.Context, log *types.AccessLog) error {
 	_, err := w.db.Insert(ctx, "HUB_GW_ACCESS_LOG", log, true)
@@ -620,7 +675,9 @@ func (w *DBWriter) WriteBackendTraceLog(ctx context.Context, log *types.BackendT
 			return ctx.Err()
 		default:
 			// 队列满时的处理策略
-			logger.Warn("Backend trace log queue is full, dropping log entry", "traceId", log.TraceID, "backendTraceId", log.BackendTraceID)
+			if logger.RateLimit("dbwrite:backendTraceQueueFull", 10*time.Second) {
+				logger.Warn("Backend trace log queue is full, dropping log entry", "traceId", log.TraceID, "backendTraceId", log.BackendTraceID)
+			}
 			return fmt.Errorf("backend trace log queue is full")
 		}
 	}
@@ -660,7 +717,9 @@ func (w *DBWriter) BatchWriteBackendTraceLog(ctx context.Context, logs []*types.
 			case <-ctx.Done():
 				return ctx.Err()
 			default:
-				logger.Warn("Backend trace log queue is full, dropping log entry", "traceId", log.TraceID, "backendTraceId", log.BackendTraceID)
+				if logger.RateLimit("dbwrite:backendTraceQueueFull", 10*time.Second) {
+					logger.Warn("Backend trace log queue is full, dropping log entry", "traceId", log.TraceID, "backendTraceId", log.BackendTraceID)
+				}
 			}
 		}
 		return nil
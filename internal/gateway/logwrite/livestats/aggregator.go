@@ -0,0 +1,366 @@
+// Package livestats 基于异步访问日志管道的实时统计
+//
+// 访问日志写入成功后同步累加到内存中的秒级环形窗口，
+// 管理端通过快照获取最近一段时间的RPS、错误率、各路由P95延迟和实例健康状态，
+// 无需周期性查询日志存储（如ClickHouse）
+package livestats
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"gateway/internal/gateway/logwrite/types"
+)
+
+const (
+	// MaxWindowSeconds 统计窗口最大秒数，快照窗口不能超过该值
+	MaxWindowSeconds = 300
+	// DefaultWindowSeconds 默认快照窗口秒数
+	DefaultWindowSeconds = 60
+	// instanceRetention 实例超过该时长没有访问日志时从快照中移除
+	instanceRetention = time.Hour
+)
+
+// 实例健康状态
+const (
+	HealthStatusHealthy   = "HEALTHY"   // 错误率低于降级阈值
+	HealthStatusDegraded  = "DEGRADED"  // 错误率达到降级阈值
+	HealthStatusUnhealthy = "UNHEALTHY" // 错误率达到不健康阈值
+	HealthStatusIdle      = "IDLE"      // 统计窗口内没有请求
+)
+
+// 健康状态的错误率阈值
+const (
+	degradedErrorRate  = 0.05
+	unhealthyErrorRate = 0.5
+)
+
+// latencyBounds 延迟直方图的桶上界(毫秒)，超过最后一个上界的请求计入溢出桶
+var latencyBounds = [...]int{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000}
+
+// routeKey 路由统计键，同一路由在不同实例上分别统计
+type routeKey struct {
+	tenantId   string
+	instanceId string
+	routeId    string
+}
+
+// counter 单个路由在一秒内的计数
+type counter struct {
+	routeName    string
+	requests     int64
+	errors       int64
+	clientErrors int64
+	latencySumMs int64
+	latencyMaxMs int
+	histogram    [len(latencyBounds) + 1]int64
+}
+
+// add 累加一条访问日志
+func (c *counter) add(statusCode, latencyMs int) {
+	c.requests++
+	switch {
+	case statusCode >= 500 || statusCode == 0:
+		c.errors++
+	case statusCode >= 400:
+		c.clientErrors++
+	}
+	if latencyMs < 0 {
+		latencyMs = 0
+	}
+	c.latencySumMs += int64(latencyMs)
+	if latencyMs > c.latencyMaxMs {
+		c.latencyMaxMs = latencyMs
+	}
+	idx := sort.SearchInts(latencyBounds[:], latencyMs)
+	c.histogram[idx]++
+}
+
+// merge 合并另一个计数
+func (c *counter) merge(other *counter) {
+	if c.routeName == "" {
+		c.routeName = other.routeName
+	}
+	c.requests += other.requests
+	c.errors += other.errors
+	c.clientErrors += other.clientErrors
+	c.latencySumMs += other.latencySumMs
+	if other.latencyMaxMs > c.latencyMaxMs {
+		c.latencyMaxMs = other.latencyMaxMs
+	}
+	for i := range c.histogram {
+		c.histogram[i] += other.histogram[i]
+	}
+}
+
+// percentile 根据直方图估算分位延迟，返回所在桶的上界，溢出桶返回观测到的最大延迟
+func (c *counter) percentile(p float64) int {
+	if c.requests == 0 {
+		return 0
+	}
+	target := int64(float64(c.requests)*p + 0.999999)
+	var cumulative int64
+	for i, n := range c.histogram {
+		cumulative += n
+		if cumulative >= target {
+			if i < len(latencyBounds) && latencyBounds[i] < c.latencyMaxMs {
+				return latencyBounds[i]
+			}
+			return c.latencyMaxMs
+		}
+	}
+	return c.latencyMaxMs
+}
+
+// bucket 一秒的统计桶
+type bucket struct {
+	second int64
+	routes map[routeKey]*counter
+}
+
+// instanceState 实例最近一次出现访问日志的信息
+type instanceState struct {
+	tenantId     string
+	instanceName string
+	lastSeen     time.Time
+}
+
+// Aggregator 实时统计聚合器，按秒保存最近 MaxWindowSeconds 秒的路由计数
+type Aggregator struct {
+	mu        sync.Mutex
+	buckets   [MaxWindowSeconds]bucket
+	instances map[string]*instanceState
+	now       func() time.Time
+}
+
+// NewAggregator 创建实时统计聚合器
+func NewAggregator() *Aggregator {
+	return NewAggregatorWithClock(time.Now)
+}
+
+// NewAggregatorWithClock 使用指定时钟创建实时统计聚合器，便于测试
+//
+// 参数:
+//   - now: 当前时间函数
+//
+// 返回:
+//   - *Aggregator: 实时统计聚合器
+func NewAggregatorWithClock(now func() time.Time) *Aggregator {
+	return &Aggregator{
+		instances: make(map[string]*instanceState),
+		now:       now,
+	}
+}
+
+// Record 记录一条访问日志，统计时间取记录时刻，保证异步写入延迟不影响窗口计算
+//
+// 参数:
+//   - accessLog: 已写入的访问日志
+func (a *Aggregator) Record(accessLog *types.AccessLog) {
+	if accessLog == nil {
+		return
+	}
+	now := a.now()
+	sec := now.Unix()
+	key := routeKey{
+		tenantId:   accessLog.TenantID,
+		instanceId: accessLog.GatewayInstanceID,
+		routeId:    accessLog.RouteConfigID,
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	b := &a.buckets[sec%MaxWindowSeconds]
+	if b.second != sec || b.routes == nil {
+		b.second = sec
+		b.routes = make(map[routeKey]*counter)
+	}
+	c, ok := b.routes[key]
+	if !ok {
+		c = &counter{routeName: accessLog.RouteName}
+		b.routes[key] = c
+	}
+	c.add(accessLog.GatewayStatusCode, accessLog.TotalProcessingTimeMs)
+
+	state, ok := a.instances[accessLog.GatewayInstanceID]
+	if !ok {
+		state = &instanceState{}
+		a.instances[accessLog.GatewayInstanceID] = state
+	}
+	state.tenantId = accessLog.TenantID
+	if accessLog.GatewayInstanceName != "" {
+		state.instanceName = accessLog.GatewayInstanceName
+	}
+	state.lastSeen = now
+}
+
+// Snapshot 获取指定租户最近窗口内的统计快照
+//
+// 参数:
+//   - query: 快照查询条件
+//
+// 返回:
+//   - *Snapshot: 统计快照，路由按请求数倒序
+func (a *Aggregator) Snapshot(query SnapshotQuery) *Snapshot {
+	window := query.WindowSeconds
+	if window <= 0 {
+		window = DefaultWindowSeconds
+	}
+	if window > MaxWindowSeconds {
+		window = MaxWindowSeconds
+	}
+	now := a.now()
+	nowSec := now.Unix()
+
+	routes := make(map[routeKey]*counter)
+	instances := make(map[string]*counter)
+	total := &counter{}
+
+	a.mu.Lock()
+	for i := range a.buckets {
+		b := &a.buckets[i]
+		if b.routes == nil || b.second > nowSec || nowSec-b.second >= int64(window) {
+			continue
+		}
+		for key, c := range b.routes {
+			if key.tenantId != query.TenantId {
+				continue
+			}
+			if query.GatewayInstanceId != "" && key.instanceId != query.GatewayInstanceId {
+				continue
+			}
+			mergeInto(routes, key, c)
+			instanceCounter, ok := instances[key.instanceId]
+			if !ok {
+				instanceCounter = &counter{}
+				instances[key.instanceId] = instanceCounter
+			}
+			instanceCounter.merge(c)
+			total.merge(c)
+		}
+	}
+
+	snapshot := &Snapshot{
+		TenantId:      query.TenantId,
+		Timestamp:     now,
+		WindowSeconds: window,
+		Routes:        make([]RouteStats, 0, len(routes)),
+		Instances:     make([]InstanceStats, 0),
+	}
+	for instanceId, state := range a.instances {
+		if now.Sub(state.lastSeen) > instanceRetention {
+			delete(a.instances, instanceId)
+			continue
+		}
+		if state.tenantId != query.TenantId {
+			continue
+		}
+		if query.GatewayInstanceId != "" && instanceId != query.GatewayInstanceId {
+			continue
+		}
+		c := instances[instanceId]
+		if c == nil {
+			c = &counter{}
+		}
+		lastSeen := state.lastSeen
+		snapshot.Instances = append(snapshot.Instances, InstanceStats{
+			GatewayInstanceId:   instanceId,
+			GatewayInstanceName: state.instanceName,
+			TrafficStats:        newTrafficStats(c, window),
+			LastSeenTime:        &lastSeen,
+			HealthStatus:        healthOf(c),
+		})
+	}
+	a.mu.Unlock()
+
+	snapshot.TrafficStats = newTrafficStats(total, window)
+	for key, c := range routes {
+		snapshot.Routes = append(snapshot.Routes, RouteStats{
+			GatewayInstanceId: key.instanceId,
+			RouteConfigId:     key.routeId,
+			RouteName:         c.routeName,
+			TrafficStats:      newTrafficStats(c, window),
+		})
+	}
+	sort.Slice(snapshot.Routes, func(i, j int) bool {
+		if snapshot.Routes[i].Requests != snapshot.Routes[j].Requests {
+			return snapshot.Routes[i].Requests > snapshot.Routes[j].Requests
+		}
+		return snapshot.Routes[i].RouteConfigId < snapshot.Routes[j].RouteConfigId
+	})
+	if query.MaxRoutes > 0 && len(snapshot.Routes) > query.MaxRoutes {
+		snapshot.Routes = snapshot.Routes[:query.MaxRoutes]
+	}
+	sort.Slice(snapshot.Instances, func(i, j int) bool {
+		return snapshot.Instances[i].GatewayInstanceId < snapshot.Instances[j].GatewayInstanceId
+	})
+	return snapshot
+}
+
+// mergeInto 将计数合并到按键汇总的结果中
+func mergeInto(target map[routeKey]*counter, key routeKey, c *counter) {
+	existing, ok := target[key]
+	if !ok {
+		existing = &counter{}
+		target[key] = existing
+	}
+	existing.merge(c)
+}
+
+// newTrafficStats 根据计数计算流量指标
+func newTrafficStats(c *counter, window int) TrafficStats {
+	stats := TrafficStats{
+		Requests:     c.requests,
+		Errors:       c.errors,
+		ClientErrors: c.clientErrors,
+		RPS:          float64(c.requests) / float64(window),
+		P95LatencyMs: c.percentile(0.95),
+		MaxLatencyMs: c.latencyMaxMs,
+	}
+	if c.requests > 0 {
+		stats.ErrorRate = float64(c.errors) / float64(c.requests)
+		stats.AvgLatencyMs = float64(c.latencySumMs) / float64(c.requests)
+	}
+	return stats
+}
+
+// healthOf 根据窗口内的错误率判断实例健康状态
+func healthOf(c *counter) string {
+	return healthByCount(c.requests, c.errors)
+}
+
+// HealthOf 根据流量指标的错误率判断健康状态，判定规则与实例健康状态一致，可用于单个路由
+func HealthOf(stats TrafficStats) string {
+	return healthByCount(stats.Requests, stats.Errors)
+}
+
+// healthByCount 根据请求数和错误数判断健康状态
+func healthByCount(requests, errors int64) string {
+	if requests == 0 {
+		return HealthStatusIdle
+	}
+	rate := float64(errors) / float64(requests)
+	switch {
+	case rate >= unhealthyErrorRate:
+		return HealthStatusUnhealthy
+	case rate >= degradedErrorRate:
+		return HealthStatusDegraded
+	default:
+		return HealthStatusHealthy
+	}
+}
+
+// defaultAggregator 全局实时统计聚合器，由访问日志写入流程累加
+var defaultAggregator = NewAggregator()
+
+// Default 获取全局实时统计聚合器
+func Default() *Aggregator {
+	return defaultAggregator
+}
+
+// Record 将访问日志记录到全局实时统计聚合器
+func Record(accessLog *types.AccessLog) {
+	defaultAggregator.Record(accessLog)
+}
@@ -0,0 +1,52 @@
+package livestats
+
+import (
+	"time"
+)
+
+// SnapshotQuery 快照查询条件
+type SnapshotQuery struct {
+	TenantId          string // 租户ID
+	GatewayInstanceId string // 网关实例ID，为空时统计租户下全部实例
+	WindowSeconds     int    // 统计窗口秒数，0使用默认窗口，超过 MaxWindowSeconds 时取最大值
+	MaxRoutes         int    // 返回的路由数上限，按请求数倒序截取，0表示不限制
+}
+
+// TrafficStats 流量指标
+type TrafficStats struct {
+	Requests     int64   `json:"requests"`     // 窗口内请求数
+	Errors       int64   `json:"errors"`       // 窗口内错误数(网关状态码>=500或未返回状态码)
+	ClientErrors int64   `json:"clientErrors"` // 窗口内客户端错误数(网关状态码4xx)
+	RPS          float64 `json:"rps"`          // 每秒请求数
+	ErrorRate    float64 `json:"errorRate"`    // 错误率(0-1)
+	AvgLatencyMs float64 `json:"avgLatencyMs"` // 平均总处理时间(毫秒)
+	P95LatencyMs int     `json:"p95LatencyMs"` // P95总处理时间(毫秒)，按直方图桶上界估算
+	MaxLatencyMs int     `json:"maxLatencyMs"` // 最大总处理时间(毫秒)
+}
+
+// RouteStats 单个实例上单个路由的实时指标
+type RouteStats struct {
+	GatewayInstanceId string `json:"gatewayInstanceId"` // 网关实例ID
+	RouteConfigId     string `json:"routeConfigId"`     // 路由配置ID
+	RouteName         string `json:"routeName"`         // 路由名称
+	TrafficStats
+}
+
+// InstanceStats 网关实例的实时指标和健康状态
+type InstanceStats struct {
+	GatewayInstanceId   string     `json:"gatewayInstanceId"`   // 网关实例ID
+	GatewayInstanceName string     `json:"gatewayInstanceName"` // 网关实例名称
+	LastSeenTime        *time.Time `json:"lastSeenTime"`        // 最近一次访问日志时间
+	HealthStatus        string     `json:"healthStatus"`        // 健康状态(HEALTHY,DEGRADED,UNHEALTHY,IDLE)
+	TrafficStats
+}
+
+// Snapshot 实时统计快照
+type Snapshot struct {
+	TenantId      string          `json:"tenantId"`      // 租户ID
+	Timestamp     time.Time       `json:"timestamp"`     // 快照时间
+	WindowSeconds int             `json:"windowSeconds"` // 统计窗口秒数
+	Routes        []RouteStats    `json:"routes"`        // 路由指标，按请求数倒序
+	Instances     []InstanceStats `json:"instances"`     // 实例指标
+	TrafficStats
+}
@@ -0,0 +1,99 @@
+package logwrite
+
+import (
+	"sync"
+
+	"gateway/internal/gateway/logwrite/spool"
+	"gateway/internal/gateway/logwrite/types"
+	"gateway/pkg/logger"
+)
+
+// failureReportingWriter 支持上报落库失败日志的写入器（数据库、ClickHouse、MongoDB）
+type failureReportingWriter interface {
+	SetFailureHandler(handler types.FailedAccessLogHandler)
+}
+
+var (
+	// 全局落盘重试器缓存 - 按实例ID缓存
+	spoolCache = make(map[string]*spool.Spool)
+	// 保护落盘重试器缓存的互斥锁
+	spoolMutex sync.Mutex
+)
+
+// attachLogSpool 为写入器挂载失败日志落盘重试器
+// 配置未启用或写入器不支持失败上报与重写时关闭已有的重试器（遗留的待重试日志保留在磁盘上，重新启用后继续重试）；
+// 配置未变化时复用已有的重试器，写入器动态更新后重试自动使用新的写入器
+func attachLogSpool(instanceID string, config *types.LogConfig, writer LogWriter) {
+	spoolConfig := config.GetLogSpoolConfig()
+	reporter, canReport := writer.(failureReportingWriter)
+	_, canRetry := writer.(spool.Retrier)
+
+	spoolMutex.Lock()
+	defer spoolMutex.Unlock()
+
+	existing := spoolCache[instanceID]
+	if spoolConfig == nil || !spoolConfig.SpoolEnabled || !canReport || !canRetry {
+		if existing != nil {
+			delete(spoolCache, instanceID)
+			go closeLogSpool(instanceID, existing)
+		}
+		return
+	}
+
+	if existing == nil || existing.Config() != *spoolConfig {
+		s, err := spool.New(instanceID, spoolConfig, func() (spool.Retrier, bool) {
+			current, err := GetLogWriter(instanceID)
+			if err != nil {
+				return nil, false
+			}
+			retrier, ok := current.(spool.Retrier)
+			return retrier, ok
+		})
+		if err != nil {
+			logger.Error("Failed to create access log spool, failed logs will not be retried",
+				"instanceID", instanceID, "error", err)
+			return
+		}
+		if existing != nil {
+			go closeLogSpool(instanceID, existing)
+		}
+		spoolCache[instanceID] = s
+		existing = s
+		logger.Info("Access log spool initialized",
+			"instanceID", instanceID, "dir", spoolConfig.SpoolDir, "maxRetries", spoolConfig.MaxRetries)
+	}
+
+	reporter.SetFailureHandler(existing.Append)
+}
+
+// detachLogSpool 从缓存中移除并关闭指定实例的落盘重试器
+// 调用方不能持有写入器缓存锁：重试协程通过 GetLogWriter 获取写入器，关闭时会等待其退出
+func detachLogSpool(instanceID string) {
+	spoolMutex.Lock()
+	s, exists := spoolCache[instanceID]
+	delete(spoolCache, instanceID)
+	spoolMutex.Unlock()
+
+	if exists {
+		closeLogSpool(instanceID, s)
+	}
+}
+
+// detachAllLogSpools 关闭全部落盘重试器
+func detachAllLogSpools() {
+	spoolMutex.Lock()
+	spools := spoolCache
+	spoolCache = make(map[string]*spool.Spool)
+	spoolMutex.Unlock()
+
+	for instanceID, s := range spools {
+		closeLogSpool(instanceID, s)
+	}
+}
+
+// closeLogSpool 关闭落盘重试器
+func closeLogSpool(instanceID string, s *spool.Spool) {
+	if err := s.Close(); err != nil {
+		logger.Error("Failed to close access log spool", "instanceID", instanceID, "error", err)
+	}
+}
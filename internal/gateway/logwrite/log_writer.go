@@ -12,7 +12,10 @@ import (
 
 	"gateway/internal/gateway/constants"
 	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/featureflag"
+	"gateway/internal/gateway/handler/filter"
 	"gateway/internal/gateway/logwrite/cleanup"
+	"gateway/internal/gateway/logwrite/livestats"
 	"gateway/internal/gateway/logwrite/types"
 	"gateway/pkg/logger"
 	"gateway/pkg/utils/random"
@@ -112,6 +115,9 @@ func UnregisterLogWriter(instanceID string) error {
 		return fmt.Errorf("instanceID cannot be empty")
 	}
 
+	// 落盘重试器在释放写入器缓存锁后关闭（defer 后进先出），写入器关闭时刷新失败的日志仍会落盘
+	defer detachLogSpool(instanceID)
+
 	cacheMutex.Lock()
 	defer cacheMutex.Unlock()
 
@@ -159,6 +165,9 @@ func InitLogManager(instanceID string, config *types.LogConfig) error {
 		return fmt.Errorf("failed to create writer: %v", err)
 	}
 
+	// 挂载失败日志落盘重试器（如果在ExtProperty中启用）
+	attachLogSpool(instanceID, config, writer)
+
 	// 注册写入器
 	if err := RegisterLogWriter(instanceID, writer); err != nil {
 		return err
@@ -289,9 +298,19 @@ func WriteLog(instanceID string, gatewayCtx *core.Context) error {
 		return fmt.Errorf("failed to write access log: %w", writeErr)
 	}
 
+	// 端口重放更新的是已统计过的请求，不重复计入实时统计
+	if !isReplay {
+		livestats.Record(accessLog)
+	}
+
 	// 根据日志配置和日志内容判断是否需要告警
 	HandleGatewayLogWrite(config, accessLog)
 
+	// 超过慢请求阈值时记录诊断信息，端口重放不重复记录
+	if !isReplay {
+		HandleSlowRequest(gatewayCtx.Ctx, config, accessLog, gatewayCtx)
+	}
+
 	// 注意：多服务转发的后端追踪日志由每个服务单独调用 WriteBackendTraceLogSync 写入
 	// 这里不再统一写入，避免重复和混淆
 
@@ -314,6 +333,9 @@ func FlushLogWriter(instanceID string) error {
 
 // CloseAllLogWriters 关闭所有写入器
 func CloseAllLogWriters() error {
+	// 落盘重试器在释放写入器缓存锁后关闭
+	defer detachAllLogSpools()
+
 	cacheMutex.Lock()
 	defer cacheMutex.Unlock()
 
@@ -370,6 +392,9 @@ func UpdateLogWriter(instanceID string, newConfig *types.LogConfig) error {
 		logger.Debug("Log cleaner manager not created during update", "instanceID", instanceID, "reason", err.Error())
 	}
 
+	// 挂载失败日志落盘重试器，新写入器生效前挂载，避免切换期间的失败日志未被接管
+	attachLogSpool(instanceID, newConfig, newWriter)
+
 	// 获取锁，进行原子性替换
 	cacheMutex.Lock()
 
@@ -639,6 +664,13 @@ func buildAccessLogWithConfig(instanceID string, gatewayCtx *core.Context, confi
 		accessLog.BackendResponseTimeMs = int(backendDurationMs)
 	}
 
+	// 削峰限流排队等待的时间，已包含在网关处理时间内，单独记录便于区分网关排队和网关自身耗时
+	if queueTime, ok := gatewayCtx.Get(constants.ContextKeyRateLimitQueueTime); ok {
+		if d, ok := queueTime.(time.Duration); ok && d > 0 {
+			accessLog.QueueTimeMs = int(d.Milliseconds())
+		}
+	}
+
 	// 重新设置正确的完成时间并计算时间指标
 	if !gatewayCtx.GetResponseTime().IsZero() {
 		responseTime := gatewayCtx.GetResponseTime()
@@ -678,9 +710,41 @@ func buildAccessLogWithConfig(instanceID string, gatewayCtx *core.Context, confi
 	// SSE/WebSocket 诊断信息不抬升日志级别，便于按断开原因检索。
 	appendStreamingDiagnostics(accessLog, gatewayCtx)
 
+	// 客户端地理位置、功能开关判定结果、A/B测试分组和注入的故障写入扩展属性，供按国家/地区统计访问量、分析开关放量效果和实验效果
+	accessLog.ExtProperty = buildExtProperty(gatewayCtx)
+
 	return accessLog
 }
 
+// buildExtProperty 将客户端地理位置、功能开关判定结果、A/B测试分组、注入的故障和请求变量序列化为扩展属性JSON，均不存在时返回空字符串
+// 格式: {"geo":{"continentCode":"EU","countryCode":"DE","country":"Germany",...},"featureFlags":{"new-waf-rules":true},"abTests":{"checkout":"B"},"faultInjected":"delay=200ms","vars":{"caller":"u1@t1"}}
+func buildExtProperty(gatewayCtx *core.Context) string {
+	ext := make(map[string]interface{}, 5)
+	if location, exists := gatewayCtx.Get(constants.ContextKeyClientGeoLocation); exists && location != nil {
+		ext["geo"] = location
+	}
+	if evaluations := featureflag.Evaluations(gatewayCtx); len(evaluations) > 0 {
+		ext["featureFlags"] = evaluations
+	}
+	if assignments := filter.ABTestAssignments(gatewayCtx); len(assignments) > 0 {
+		ext["abTests"] = assignments
+	}
+	if faults, ok := gatewayCtx.GetString(constants.ContextKeyFaultInjected); ok && faults != "" {
+		ext["faultInjected"] = faults
+	}
+	if vars := gatewayCtx.Vars(); len(vars) > 0 {
+		ext["vars"] = vars
+	}
+	if len(ext) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(ext)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
 // appendStreamingDiagnostics 将SSE/WebSocket断开原因与流量摘要写入 ErrorMessage。
 // 正常结束也会记录，避免只能靠 responseSize=-1 推断长连接行为；不调用 SetErrorInfo以免改 LogLevel。
 func appendStreamingDiagnostics(accessLog *types.AccessLog, gatewayCtx *core.Context) {
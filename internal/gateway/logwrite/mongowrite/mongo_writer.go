@@ -16,7 +16,11 @@ import (
 
 // MongoWriter MongoDB日志写入器
 // 支持异步批量写入、连接池管理和自动重连
+// 落库失败的访问日志上报给失败回调，由落盘重试组件接管
 type MongoWriter struct {
+	// 落库失败上报器
+	types.AccessLogFailureReporter
+
 	// 配置
 	config *types.LogConfig
 
@@ -118,7 +122,11 @@ func (w *MongoWriter) UpdateAccessLog(ctx context.Context, log *types.AccessLog)
 func (w *MongoWriter) Write(ctx context.Context, log *types.AccessLog) error {
 	if !w.config.IsAsyncLogging() {
 		// 同步写入
-		return w.insertOne(ctx, log)
+		if err := w.insertOne(ctx, log); err != nil {
+			w.ReportFailure([]*types.AccessLog{log}, err)
+			return err
+		}
+		return nil
 	}
 
 	// 异步写入
@@ -126,9 +134,12 @@ func (w *MongoWriter) Write(ctx context.Context, log *types.AccessLog) error {
 	case w.logChan <- log:
 		return nil
 	case <-ctx.Done():
+		w.ReportFailure([]*types.AccessLog{log}, ctx.Err())
 		return ctx.Err()
 	default:
-		return fmt.Errorf("log channel is full")
+		err := fmt.Errorf("log channel is full")
+		w.ReportFailure([]*types.AccessLog{log}, err)
+		return err
 	}
 }
 
@@ -140,7 +151,11 @@ func (w *MongoWriter) BatchWrite(ctx context.Context, logs []*types.AccessLog) e
 
 	if !w.config.IsAsyncLogging() {
 		// 同步批量写入
-		return w.insertMany(ctx, logs)
+		if err := w.insertMany(ctx, logs); err != nil {
+			w.ReportFailure(logs, err)
+			return err
+		}
+		return nil
 	}
 
 	// 异步批量写入
@@ -148,9 +163,12 @@ func (w *MongoWriter) BatchWrite(ctx context.Context, logs []*types.AccessLog) e
 	case w.batchChan <- logs:
 		return nil
 	case <-ctx.Done():
+		w.ReportFailure(logs, ctx.Err())
 		return ctx.Err()
 	default:
-		return fmt.Errorf("batch channel is full")
+		err := fmt.Errorf("batch channel is full")
+		w.ReportFailure(logs, err)
+		return err
 	}
 }
 
@@ -169,7 +187,11 @@ func (w *MongoWriter) Flush(ctx context.Context) error {
 	w.buffer = w.buffer[:0]
 
 	// 执行批量插入
-	return w.insertMany(ctx, documents)
+	if err := w.insertMany(ctx, documents); err != nil {
+		w.ReportFailure(documents, err)
+		return err
+	}
+	return nil
 }
 
 // Close 关闭写入器
@@ -177,6 +199,9 @@ func (w *MongoWriter) Close() error {
 	close(w.closeChan)
 	w.wg.Wait()
 
+	// 工作协程退出后通道中可能仍有日志，排空后随缓冲区一起写入
+	w.drainLogChannels()
+
 	// 刷新剩余缓冲区
 	if err := w.Flush(context.Background()); err != nil {
 		logger.Error("Failed to flush buffer on close", "error", err)
@@ -304,6 +329,22 @@ func (w *MongoWriter) insertBackendTraceLogMany(ctx context.Context, logs []*typ
 	return nil
 }
 
+// RetryAccessLog 同步重写一条此前落库失败的访问日志，供失败日志落盘重试组件调用
+// 原写入可能已经落库（如插入成功但响应超时），因此先按 tenantId+traceId 更新并自增 resetCount、
+// 标记已重置，未匹配到文档时再插入。失败时不上报失败回调，由调用方保留日志继续重试
+func (w *MongoWriter) RetryAccessLog(ctx context.Context, log *types.AccessLog) error {
+	reconciled := *log
+	reconciled.ResetFlag = types.ResetFlagYes
+	matched, err := w.UpdateAccessLog(ctx, &reconciled)
+	if err != nil {
+		return fmt.Errorf("reconcile access log: %w", err)
+	}
+	if matched > 0 {
+		return nil
+	}
+	return w.insertOne(ctx, log)
+}
+
 // insertOne 插入单条文档
 func (w *MongoWriter) insertOne(ctx context.Context, log *types.AccessLog) error {
 	// 使用公共转换方法将结构体转换为 Document
@@ -445,6 +486,7 @@ func (w *MongoWriter) batchLogWorker() {
 			ctx, cancel := context.WithTimeout(context.Background(), w.mongoClient.GetConfig().SocketTimeoutMS)
 			if err := w.insertMany(ctx, documents); err != nil {
 				logger.Error("Failed to insert batch documents", "error", err, "count", len(documents))
+				w.ReportFailure(documents, err)
 			}
 			cancel()
 
@@ -474,6 +516,25 @@ func (w *MongoWriter) bufferFlushWorker() {
 	}
 }
 
+// drainLogChannels 排空访问日志通道：单条日志放入缓冲区，批量日志直接插入
+func (w *MongoWriter) drainLogChannels() {
+	for {
+		select {
+		case log := <-w.logChan:
+			w.bufferMutex.Lock()
+			w.buffer = append(w.buffer, log)
+			w.bufferMutex.Unlock()
+		case documents := <-w.batchChan:
+			if err := w.insertMany(context.Background(), documents); err != nil {
+				logger.Error("Failed to insert batch documents while draining", "error", err, "count", len(documents))
+				w.ReportFailure(documents, err)
+			}
+		default:
+			return
+		}
+	}
+}
+
 // singleBackendTraceLogWorker 单条后端追踪日志处理协程
 func (w *MongoWriter) singleBackendTraceLogWorker() {
 	defer w.wg.Done()
@@ -0,0 +1,72 @@
+package logwrite
+
+import (
+	"context"
+	"encoding/json"
+
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/logwrite/slowreq"
+	"gateway/internal/gateway/logwrite/types"
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/pkg/utils/random"
+)
+
+// HandleSlowRequest 处理慢请求诊断记录
+// 访问日志总耗时超过慢请求阈值时，汇总看门狗采集的诊断信息写入 HUB_GW_SLOW_REQ 表
+// 慢请求记录与访问日志输出目标无关，固定写入默认数据库连接
+func HandleSlowRequest(ctx context.Context, config *types.LogConfig, accessLog *types.AccessLog, gatewayCtx *core.Context) {
+	if config == nil || accessLog == nil {
+		return
+	}
+	slowCfg := config.GetSlowRequestConfig()
+	if slowCfg == nil || !slowCfg.SlowRequestEnabled || accessLog.TotalProcessingTimeMs < slowCfg.ThresholdMs {
+		return
+	}
+	if !slowreq.Allow(slowCfg.MaxPerMinute) {
+		logger.Debug("慢请求记录已达到每分钟上限，跳过", "traceId", accessLog.TraceID, "maxPerMinute", slowCfg.MaxPerMinute)
+		return
+	}
+
+	db := database.GetDefaultConnection()
+	if db == nil {
+		logger.Debug("默认数据库连接不可用，跳过慢请求记录", "traceId", accessLog.TraceID)
+		return
+	}
+
+	record := buildSlowRequestLog(accessLog, slowCfg, slowreq.FromContext(gatewayCtx))
+	if _, err := db.Insert(ctx, record.TableName(), record, true); err != nil {
+		logger.Error("写入慢请求记录失败", "traceId", accessLog.TraceID, "error", err)
+	}
+}
+
+// buildSlowRequestLog 构建慢请求诊断记录
+func buildSlowRequestLog(accessLog *types.AccessLog, slowCfg *types.SlowRequestConfig, watchdog *slowreq.Watchdog) *types.SlowRequestLog {
+	record := types.NewSlowRequestLog(random.Generate32BitRandomString(), accessLog, slowCfg.ThresholdMs)
+
+	var detection slowreq.Detection
+	states := []slowreq.UpstreamConnState{}
+	if watchdog != nil {
+		detection = watchdog.Result()
+		states = watchdog.Conns().Snapshot()
+	}
+	record.GoroutineCount = detection.GoroutineCount
+	record.GoroutineSnapshotRef = detection.SnapshotRef
+	record.DetectedTime = detection.DetectedAt
+
+	timing := slowreq.BuildTiming(
+		accessLog.TotalProcessingTimeMs,
+		accessLog.GatewayProcessingTimeMs,
+		accessLog.BackendResponseTimeMs,
+		accessLog.GatewayStartProcessingTime,
+		detection,
+		states,
+	)
+	if data, err := json.Marshal(timing); err == nil {
+		record.TimingBreakdown = string(data)
+	}
+	if data, err := json.Marshal(states); err == nil {
+		record.UpstreamConnState = string(data)
+	}
+	return record
+}
@@ -0,0 +1,90 @@
+package slowreq
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sync"
+	"time"
+)
+
+// snapshotReuseWindow 同一时间窗口内的慢请求共享一份协程快照，避免故障时频繁全量dump
+const snapshotReuseWindow = 10 * time.Second
+
+// snapshotter 协程快照采集器
+type snapshotter struct {
+	mu      sync.Mutex
+	lastDir string
+	lastAt  time.Time
+	lastRef string
+}
+
+var defaultSnapshotter = &snapshotter{}
+
+// capture 将全部协程堆栈写入快照目录，返回快照文件路径作为引用
+func (s *snapshotter) capture(dir string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lastRef != "" && s.lastDir == dir && time.Since(s.lastAt) < snapshotReuseWindow {
+		return s.lastRef, nil
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("创建协程快照目录失败: %w", err)
+	}
+	now := time.Now()
+	path := filepath.Join(dir, fmt.Sprintf("goroutine-%s-%d-%d.txt", now.Format("20060102150405"), os.Getpid(), now.Nanosecond()))
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("创建协程快照文件失败: %w", err)
+	}
+	writeErr := pprof.Lookup("goroutine").WriteTo(file, 2)
+	if closeErr := file.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		_ = os.Remove(path)
+		return "", fmt.Errorf("写入协程快照失败: %w", writeErr)
+	}
+
+	s.lastDir, s.lastAt, s.lastRef = dir, now, path
+	return path, nil
+}
+
+// recordLimiter 慢请求记录限流器，按自然分钟计数
+type recordLimiter struct {
+	mu          sync.Mutex
+	windowStart time.Time
+	count       int
+}
+
+var defaultRecordLimiter = &recordLimiter{}
+
+// Allow 判断当前分钟内是否还能记录慢请求，防止上游整体变慢时写入风暴
+// 参数:
+// - maxPerMinute: 每分钟最多记录数，<=0表示不限制
+func Allow(maxPerMinute int) bool {
+	return defaultRecordLimiter.allow(maxPerMinute, time.Now())
+}
+
+// allow 按指定时间判断是否允许记录
+func (l *recordLimiter) allow(maxPerMinute int, now time.Time) bool {
+	if maxPerMinute <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	window := now.Truncate(time.Minute)
+	if !window.Equal(l.windowStart) {
+		l.windowStart = window
+		l.count = 0
+	}
+	if l.count >= maxPerMinute {
+		return false
+	}
+	l.count++
+	return true
+}
@@ -0,0 +1,175 @@
+package slowreq
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// UpstreamConnState 单次转发尝试的上游连接状态
+type UpstreamConnState struct {
+	Target       string `json:"target"`                // 目标地址 host:port
+	RemoteAddr   string `json:"remoteAddr,omitempty"`  // 实际连接的远端地址
+	Reused       bool   `json:"reused"`                // 是否复用连接池中的连接
+	WasIdle      bool   `json:"wasIdle"`               // 复用的连接此前是否空闲
+	IdleTimeMs   int64  `json:"idleTimeMs,omitempty"`  // 复用连接的空闲时长
+	WaitConnMs   int64  `json:"waitConnMs"`            // 获取连接耗时（含排队、DNS、建连、TLS）
+	DNSMs        int64  `json:"dnsMs,omitempty"`       // DNS解析耗时
+	ConnectMs    int64  `json:"connectMs,omitempty"`   // TCP建连耗时
+	TLSMs        int64  `json:"tlsMs,omitempty"`       // TLS握手耗时
+	FirstByteMs  int64  `json:"firstByteMs,omitempty"` // 请求写完到收到首字节耗时
+	GotConn      bool   `json:"gotConn"`               // 是否获取到连接
+	WroteRequest bool   `json:"wroteRequest"`          // 请求是否已完整写出
+	GotFirstByte bool   `json:"gotFirstByte"`          // 是否收到响应首字节
+	Error        string `json:"error,omitempty"`       // 建连、TLS或写请求错误
+	StartTime    string `json:"startTime"`             // 开始获取连接的时间
+	ElapsedMs    int64  `json:"elapsedMs"`             // 采集时距开始获取连接的时长
+	startedAt    time.Time
+}
+
+// UpstreamConnStates 请求的全部上游连接状态（多服务、重试时每次尝试一条）
+type UpstreamConnStates struct {
+	mu     sync.Mutex
+	states []*UpstreamConnState
+}
+
+// Snapshot 复制当前的连接状态
+func (s *UpstreamConnStates) Snapshot() []UpstreamConnState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]UpstreamConnState, 0, len(s.states))
+	for _, state := range s.states {
+		copied := *state
+		copied.ElapsedMs = time.Since(state.startedAt).Milliseconds()
+		result = append(result, copied)
+	}
+	return result
+}
+
+// update 加锁修改连接状态，httptrace 回调可能来自不同协程
+func (s *UpstreamConnStates) update(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn()
+}
+
+// TraceRequest 为转发请求挂载上游连接追踪
+// 参数:
+// - watchdog: 请求的慢请求看门狗，为nil时不追踪
+// - req: 转发请求
+// 返回值:
+// - *http.Request: 挂载追踪后的请求，未启用慢请求检测时原样返回
+func TraceRequest(watchdog *Watchdog, req *http.Request) *http.Request {
+	if watchdog == nil || req == nil {
+		return req
+	}
+	states := watchdog.conns
+	state := &UpstreamConnState{Target: req.URL.Host, startedAt: time.Now()}
+	state.StartTime = state.startedAt.Format("2006-01-02 15:04:05.000")
+	states.update(func() { states.states = append(states.states, state) })
+
+	var dnsStart, connectStart, tlsStart, wroteAt time.Time
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			states.update(func() {
+				state.GotConn = true
+				state.Reused = info.Reused
+				state.WasIdle = info.WasIdle
+				state.IdleTimeMs = info.IdleTime.Milliseconds()
+				state.WaitConnMs = time.Since(state.startedAt).Milliseconds()
+				if info.Conn != nil {
+					state.RemoteAddr = info.Conn.RemoteAddr().String()
+				}
+			})
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			states.update(func() { dnsStart = time.Now() })
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			states.update(func() { state.DNSMs = time.Since(dnsStart).Milliseconds() })
+		},
+		ConnectStart: func(string, string) {
+			states.update(func() { connectStart = time.Now() })
+		},
+		ConnectDone: func(_, _ string, err error) {
+			states.update(func() {
+				state.ConnectMs = time.Since(connectStart).Milliseconds()
+				if err != nil {
+					state.Error = err.Error()
+				}
+			})
+		},
+		TLSHandshakeStart: func() {
+			states.update(func() { tlsStart = time.Now() })
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			states.update(func() {
+				state.TLSMs = time.Since(tlsStart).Milliseconds()
+				if err != nil {
+					state.Error = err.Error()
+				}
+			})
+		},
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			states.update(func() {
+				state.WroteRequest = info.Err == nil
+				wroteAt = time.Now()
+				if info.Err != nil {
+					state.Error = info.Err.Error()
+				}
+			})
+		},
+		GotFirstResponseByte: func() {
+			states.update(func() {
+				state.GotFirstByte = true
+				if !wroteAt.IsZero() {
+					state.FirstByteMs = time.Since(wroteAt).Milliseconds()
+				}
+			})
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
+
+// Timing 慢请求耗时分解
+type Timing struct {
+	TotalMs          int   `json:"totalMs"`                   // 总处理耗时
+	GatewayMs        int   `json:"gatewayMs"`                 // 网关自身处理耗时
+	BackendMs        int   `json:"backendMs"`                 // 后端最大响应耗时
+	DetectedAfterMs  int64 `json:"detectedAfterMs,omitempty"` // 看门狗触发时距请求开始的耗时
+	UpstreamAttempts int   `json:"upstreamAttempts"`          // 上游转发尝试次数
+	WaitConnMs       int64 `json:"waitConnMs"`                // 各次尝试获取连接耗时之和
+	DNSMs            int64 `json:"dnsMs"`                     // 各次尝试DNS解析耗时之和
+	ConnectMs        int64 `json:"connectMs"`                 // 各次尝试TCP建连耗时之和
+	TLSMs            int64 `json:"tlsMs"`                     // 各次尝试TLS握手耗时之和
+	FirstByteMs      int64 `json:"firstByteMs"`               // 各次尝试等待首字节耗时之和
+}
+
+// BuildTiming 汇总耗时分解
+// 参数:
+// - totalMs/gatewayMs/backendMs: 访问日志中的耗时指标
+// - startTime: 请求开始时间
+// - detection: 看门狗检测结果
+// - states: 上游连接状态
+func BuildTiming(totalMs, gatewayMs, backendMs int, startTime time.Time, detection Detection, states []UpstreamConnState) Timing {
+	timing := Timing{
+		TotalMs:          totalMs,
+		GatewayMs:        gatewayMs,
+		BackendMs:        backendMs,
+		UpstreamAttempts: len(states),
+	}
+	if detection.Detected && !startTime.IsZero() {
+		timing.DetectedAfterMs = detection.DetectedAt.Sub(startTime).Milliseconds()
+	}
+	for _, state := range states {
+		timing.WaitConnMs += state.WaitConnMs
+		timing.DNSMs += state.DNSMs
+		timing.ConnectMs += state.ConnectMs
+		timing.TLSMs += state.TLSMs
+		timing.FirstByteMs += state.FirstByteMs
+	}
+	return timing
+}
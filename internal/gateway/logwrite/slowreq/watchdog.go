@@ -0,0 +1,110 @@
+// Package slowreq 慢请求检测与诊断信息采集
+//
+// 请求开始时启动看门狗，超过阈值仍未完成时采集协程数量和协程快照；
+// 转发时通过 httptrace 记录上游连接状态；请求结束后由日志写入器汇总为 HUB_GW_SLOW_REQ 记录。
+package slowreq
+
+import (
+	"runtime"
+	"sync"
+	"time"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/logwrite/types"
+	"gateway/pkg/logger"
+)
+
+// Watchdog 单个请求的慢请求看门狗
+type Watchdog struct {
+	config *types.SlowRequestConfig
+	timer  *time.Timer
+	conns  *UpstreamConnStates
+
+	mu     sync.Mutex
+	result Detection
+}
+
+// Detection 看门狗检测结果
+type Detection struct {
+	Detected       bool      // 请求结束前是否已超过阈值
+	DetectedAt     time.Time // 超过阈值的时间
+	GoroutineCount int       // 超过阈值时的协程数量
+	SnapshotRef    string    // 协程快照文件引用
+}
+
+// Start 为请求启动慢请求看门狗并写入上下文
+// 参数:
+// - gatewayCtx: 网关上下文
+// - config: 慢请求诊断配置
+// 返回值:
+// - *Watchdog: 看门狗，未启用慢请求检测时返回nil
+func Start(gatewayCtx *core.Context, config *types.SlowRequestConfig) *Watchdog {
+	if gatewayCtx == nil || config == nil || !config.SlowRequestEnabled || config.ThresholdMs <= 0 {
+		return nil
+	}
+	watchdog := &Watchdog{
+		config: config,
+		conns:  &UpstreamConnStates{},
+	}
+	watchdog.timer = time.AfterFunc(time.Duration(config.ThresholdMs)*time.Millisecond, watchdog.detect)
+	gatewayCtx.Set(constants.ContextKeySlowRequestWatchdog, watchdog)
+	return watchdog
+}
+
+// FromContext 获取请求的慢请求看门狗，未启用时返回nil
+func FromContext(gatewayCtx *core.Context) *Watchdog {
+	if gatewayCtx == nil {
+		return nil
+	}
+	value, exists := gatewayCtx.Get(constants.ContextKeySlowRequestWatchdog)
+	if !exists {
+		return nil
+	}
+	watchdog, _ := value.(*Watchdog)
+	return watchdog
+}
+
+// Stop 请求结束时停止看门狗，已触发的检测结果保留
+func (w *Watchdog) Stop() {
+	if w == nil {
+		return
+	}
+	w.timer.Stop()
+}
+
+// Result 获取检测结果，采集进行中时等待采集完成
+func (w *Watchdog) Result() Detection {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.result
+}
+
+// Config 获取慢请求诊断配置
+func (w *Watchdog) Config() *types.SlowRequestConfig {
+	return w.config
+}
+
+// Conns 获取请求的上游连接状态
+func (w *Watchdog) Conns() *UpstreamConnStates {
+	return w.conns
+}
+
+// detect 超过阈值时采集诊断信息，请求仍在处理中，协程快照可反映其阻塞位置
+func (w *Watchdog) detect() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.result.Detected = true
+	w.result.DetectedAt = time.Now()
+	w.result.GoroutineCount = runtime.NumGoroutine()
+	if !w.config.GoroutineDump {
+		return
+	}
+	ref, err := defaultSnapshotter.capture(w.config.SnapshotDir)
+	if err != nil {
+		logger.Warn("采集慢请求协程快照失败", "dir", w.config.SnapshotDir, "error", err)
+		return
+	}
+	w.result.SnapshotRef = ref
+}
@@ -0,0 +1,446 @@
+// Package spool 访问日志落盘重试
+//
+// 写入器落库失败（数据库不可用、异步队列已满、批量刷新失败等）的访问日志先追加到本地磁盘，
+// 后台协程按指数退避重新写入；重写时由写入器按 tenantId+traceId 对账：原写入实际已落库的记录
+// 只更新并自增 resetCount，未落库的记录重新插入。超过最大重试次数的日志转入死信目录并打印错误日志，
+// 落盘目录超过大小上限时拒绝追加并打印错误日志，任何情况下请求记录都不会被静默丢弃。
+package spool
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gateway/internal/gateway/logwrite/types"
+	"gateway/pkg/logger"
+)
+
+const (
+	// segmentPrefix 待重试分段文件前缀
+	segmentPrefix = "pending-"
+
+	// segmentExt 分段文件扩展名，每行一条JSON格式的访问日志
+	segmentExt = ".jsonl"
+
+	// deadLetterDir 超过最大重试次数的日志保存目录
+	deadLetterDir = "dead"
+
+	// retryTimeout 单条日志重写的超时时间
+	retryTimeout = 10 * time.Second
+)
+
+// Retrier 支持同步重写失败访问日志的写入器
+type Retrier interface {
+	// RetryAccessLog 同步重写一条访问日志，原写入已落库时只对账更新，返回nil表示已持久化
+	RetryAccessLog(ctx context.Context, log *types.AccessLog) error
+}
+
+// ResolveFunc 获取当前使用的写入器，写入器动态更新后重试使用新的写入器
+type ResolveFunc func() (Retrier, bool)
+
+// Spool 单个网关实例的访问日志落盘重试器
+type Spool struct {
+	instanceID string
+	config     types.LogSpoolConfig
+	dir        string
+	resolve    ResolveFunc
+
+	// 保护分段序号和落盘大小统计
+	mu       sync.Mutex
+	seq      uint64
+	size     int64
+	maxBytes int64
+
+	stopChan  chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// New 创建访问日志落盘重试器并启动后台重试协程
+// 参数:
+//   - instanceID: 网关实例ID，落盘文件保存在 SpoolDir/instanceID 目录下
+//   - config: 落盘重试配置
+//   - resolve: 获取当前写入器的函数
+//
+// 返回:
+//   - *Spool: 落盘重试器，启动前遗留的待重试日志会继续重试
+//   - error: 创建落盘目录失败时返回错误
+func New(instanceID string, config *types.LogSpoolConfig, resolve ResolveFunc) (*Spool, error) {
+	if instanceID == "" {
+		return nil, fmt.Errorf("instanceID cannot be empty")
+	}
+	if config == nil {
+		return nil, fmt.Errorf("spool config cannot be nil")
+	}
+
+	dir := filepath.Join(config.SpoolDir, instanceID)
+	if err := os.MkdirAll(filepath.Join(dir, deadLetterDir), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create spool dir: %w", err)
+	}
+
+	s := &Spool{
+		instanceID: instanceID,
+		config:     *config,
+		dir:        dir,
+		resolve:    resolve,
+		maxBytes:   int64(config.MaxSpoolSizeMB) << 20,
+		stopChan:   make(chan struct{}),
+	}
+
+	segments, err := s.segments()
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range segments {
+		if info, err := os.Stat(segment); err == nil {
+			s.size += info.Size()
+		}
+	}
+	if len(segments) > 0 {
+		logger.Info("Access log spool has pending segments from previous run",
+			"instanceID", instanceID, "segments", len(segments), "bytes", s.size)
+	}
+
+	s.wg.Add(1)
+	go s.run()
+	return s, nil
+}
+
+// Config 获取落盘重试配置
+func (s *Spool) Config() types.LogSpoolConfig {
+	return s.config
+}
+
+// Append 追加落库失败的访问日志，签名与 types.FailedAccessLogHandler 一致
+// 重试协程停止后仍可追加，日志保留在磁盘上，下次启动时继续重试
+func (s *Spool) Append(logs []*types.AccessLog, cause error) {
+	if len(logs) == 0 {
+		return
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, log := range logs {
+		if err := encoder.Encode(log); err != nil {
+			logger.Error("Failed to encode access log for spool, log lost",
+				"instanceID", s.instanceID, "traceId", log.TraceID, "error", err)
+		}
+	}
+	if buf.Len() == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	if s.maxBytes > 0 && s.size+int64(buf.Len()) > s.maxBytes {
+		s.mu.Unlock()
+		logger.Error("Access log spool is full, dropping failed logs",
+			"instanceID", s.instanceID, "count", len(logs), "traceIds", traceIDs(logs),
+			"spoolBytes", s.size, "cause", cause)
+		return
+	}
+	s.seq++
+	name := fmt.Sprintf("%s%020d-%06d%s", segmentPrefix, time.Now().UnixNano(), s.seq%1000000, segmentExt)
+	s.size += int64(buf.Len())
+	s.mu.Unlock()
+
+	if err := writeFileAtomic(filepath.Join(s.dir, name), buf.Bytes()); err != nil {
+		s.addSize(-int64(buf.Len()))
+		logger.Error("Failed to spool access logs, logs lost",
+			"instanceID", s.instanceID, "count", len(logs), "traceIds", traceIDs(logs),
+			"error", err, "cause", cause)
+		return
+	}
+	logger.Warn("Access logs spooled for retry",
+		"instanceID", s.instanceID, "count", len(logs), "cause", cause)
+}
+
+// Retry 重试全部待重试日志一次
+// 写入器不可用或某条日志重写失败时停止本轮重试，剩余日志等待下一轮
+// 返回:
+//   - int: 本轮成功持久化的日志条数
+//   - error: 本轮存在失败时返回错误
+func (s *Spool) Retry(ctx context.Context) (int, error) {
+	segments, err := s.segments()
+	if err != nil {
+		return 0, err
+	}
+	if len(segments) == 0 {
+		return 0, nil
+	}
+
+	retrier, ok := s.resolve()
+	if !ok || retrier == nil {
+		return 0, fmt.Errorf("no retryable log writer for instance %s", s.instanceID)
+	}
+
+	total := 0
+	for _, segment := range segments {
+		n, err := s.retrySegment(ctx, retrier, segment)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	if total > 0 {
+		logger.Info("Spooled access logs retried", "instanceID", s.instanceID, "count", total)
+	}
+	return total, nil
+}
+
+// Pending 获取待重试的日志条数
+func (s *Spool) Pending() int {
+	segments, err := s.segments()
+	if err != nil {
+		return 0
+	}
+	count := 0
+	for _, segment := range segments {
+		logs, err := readSegment(segment)
+		if err == nil {
+			count += len(logs)
+		}
+	}
+	return count
+}
+
+// Close 停止后台重试协程，待重试日志保留在磁盘上
+func (s *Spool) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.stopChan)
+		s.wg.Wait()
+	})
+	return nil
+}
+
+// run 后台重试协程：成功后恢复初始间隔，失败后按2倍退避直到最大间隔
+func (s *Spool) run() {
+	defer s.wg.Done()
+
+	initial := time.Duration(s.config.RetryIntervalMs) * time.Millisecond
+	maxInterval := time.Duration(s.config.MaxRetryIntervalMs) * time.Millisecond
+	interval := initial
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				select {
+				case <-s.stopChan:
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+			if _, err := s.Retry(ctx); err != nil {
+				interval *= 2
+				if interval > maxInterval {
+					interval = maxInterval
+				}
+				logger.Warn("Access log spool retry failed, backing off",
+					"instanceID", s.instanceID, "nextRetry", interval, "error", err)
+			} else {
+				interval = initial
+			}
+			cancel()
+			timer.Reset(interval)
+
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// retrySegment 重试单个分段文件中的日志
+// 已持久化和转入死信的日志从分段中移除，其余日志（含本轮失败的日志，重试次数已加1）写回分段
+func (s *Spool) retrySegment(ctx context.Context, retrier Retrier, segment string) (int, error) {
+	logs, err := readSegment(segment)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		remaining []*types.AccessLog
+		dead      []*types.AccessLog
+		retryErr  error
+		persisted int
+	)
+	for i, log := range logs {
+		if retryErr != nil || ctx.Err() != nil {
+			remaining = append(remaining, logs[i:]...)
+			break
+		}
+		log.RetryCount++
+		logCtx, cancel := context.WithTimeout(ctx, retryTimeout)
+		err := retrier.RetryAccessLog(logCtx, log)
+		cancel()
+		if err == nil {
+			persisted++
+			continue
+		}
+		if log.RetryCount >= s.config.MaxRetries {
+			dead = append(dead, log)
+			continue
+		}
+		retryErr = fmt.Errorf("retry access log %s failed: %w", log.TraceID, err)
+		remaining = append(remaining, log)
+	}
+
+	if len(dead) > 0 {
+		s.moveToDeadLetter(segment, dead)
+	}
+	if err := s.rewriteSegment(segment, remaining); err != nil {
+		return persisted, err
+	}
+	if retryErr == nil && ctx.Err() != nil {
+		retryErr = ctx.Err()
+	}
+	return persisted, retryErr
+}
+
+// rewriteSegment 将未完成的日志写回分段，全部完成时删除分段
+func (s *Spool) rewriteSegment(segment string, logs []*types.AccessLog) error {
+	var oldSize int64
+	if info, err := os.Stat(segment); err == nil {
+		oldSize = info.Size()
+	}
+
+	if len(logs) == 0 {
+		if err := os.Remove(segment); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove spool segment: %w", err)
+		}
+		s.addSize(-oldSize)
+		return nil
+	}
+
+	data, err := encodeLogs(logs)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(segment, data); err != nil {
+		return fmt.Errorf("failed to rewrite spool segment: %w", err)
+	}
+	s.addSize(int64(len(data)) - oldSize)
+	return nil
+}
+
+// moveToDeadLetter 将超过最大重试次数的日志写入死信目录，需要人工处理
+func (s *Spool) moveToDeadLetter(segment string, logs []*types.AccessLog) {
+	logger.Error("Access logs exceeded max retries, moved to dead letter",
+		"instanceID", s.instanceID, "count", len(logs), "traceIds", traceIDs(logs),
+		"maxRetries", s.config.MaxRetries)
+
+	data, err := encodeLogs(logs)
+	if err == nil {
+		name := strings.TrimPrefix(filepath.Base(segment), segmentPrefix)
+		name = fmt.Sprintf("%d-%s", time.Now().UnixNano(), name)
+		err = writeFileAtomic(filepath.Join(s.dir, deadLetterDir, name), data)
+	}
+	if err != nil {
+		logger.Error("Failed to write dead letter access logs, logs lost",
+			"instanceID", s.instanceID, "traceIds", traceIDs(logs), "error", err)
+	}
+}
+
+// segments 按时间顺序列出待重试分段文件
+func (s *Spool) segments() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read spool dir: %w", err)
+	}
+	segments := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, segmentPrefix) || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		segments = append(segments, filepath.Join(s.dir, name))
+	}
+	sort.Strings(segments)
+	return segments, nil
+}
+
+// addSize 更新落盘大小统计
+func (s *Spool) addSize(delta int64) {
+	s.mu.Lock()
+	s.size += delta
+	if s.size < 0 {
+		s.size = 0
+	}
+	s.mu.Unlock()
+}
+
+// readSegment 读取分段文件中的访问日志，跳过无法解析的行
+func readSegment(segment string) ([]*types.AccessLog, error) {
+	file, err := os.Open(segment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open spool segment: %w", err)
+	}
+	defer file.Close()
+
+	var logs []*types.AccessLog
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64<<20)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		log := &types.AccessLog{}
+		if err := json.Unmarshal(line, log); err != nil {
+			logger.Error("Skipping corrupted spooled access log", "segment", segment, "error", err)
+			continue
+		}
+		logs = append(logs, log)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read spool segment: %w", err)
+	}
+	return logs, nil
+}
+
+// encodeLogs 将访问日志编码为每行一条的JSON
+func encodeLogs(logs []*types.AccessLog) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, log := range logs {
+		if err := encoder.Encode(log); err != nil {
+			return nil, fmt.Errorf("failed to encode spooled access log %s: %w", log.TraceID, err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// writeFileAtomic 先写临时文件再重命名，避免进程中断时留下不完整的分段
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// traceIDs 提取日志的 traceId，便于从错误日志中定位丢失或需人工处理的请求
+func traceIDs(logs []*types.AccessLog) []string {
+	ids := make([]string, 0, len(logs))
+	for _, log := range logs {
+		ids = append(ids, log.TraceID)
+	}
+	return ids
+}
@@ -57,6 +57,7 @@ type AccessLog struct {
 	TotalProcessingTimeMs   int `json:"totalProcessingTimeMs" db:"totalProcessingTimeMs" bson:"totalProcessingTimeMs"`       // 总处理时间(从开始处理到处理完成)
 	GatewayProcessingTimeMs int `json:"gatewayProcessingTimeMs" db:"gatewayProcessingTimeMs" bson:"gatewayProcessingTimeMs"` // 网关自身处理时间
 	BackendResponseTimeMs   int `json:"backendResponseTimeMs" db:"backendResponseTimeMs" bson:"backendResponseTimeMs"`       // 后端服务响应时间（0表示未设置）
+	QueueTimeMs             int `json:"queueTimeMs" db:"queueTimeMs" bson:"queueTimeMs"`                                     // 限流排队等待时间（已计入网关处理时间，0表示未排队）
 
 	// 响应信息 - 记录网关和后端服务的响应详情
 	GatewayStatusCode int    `json:"gatewayStatusCode" db:"gatewayStatusCode" bson:"gatewayStatusCode"` // 网关返回的HTTP状态码
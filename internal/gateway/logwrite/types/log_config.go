@@ -132,6 +132,12 @@ type LogConfig struct {
 
 	// 解析后的清理配置（构建时预解析，避免重复解析JSON）
 	cleanupConfig *CleanupConfig // 私有字段，通过 GetCleanupConfig() 访问
+
+	// 解析后的慢请求诊断配置（构建时预解析，避免重复解析JSON）
+	slowRequestConfig *SlowRequestConfig // 私有字段，通过 GetSlowRequestConfig() 访问
+
+	// 解析后的失败日志落盘重试配置（构建时预解析，避免重复解析JSON）
+	logSpoolConfig *LogSpoolConfig // 私有字段，通过 GetLogSpoolConfig() 访问
 }
 
 // SetAlertConfig 设置告警配置（供构建时使用）
@@ -144,6 +150,16 @@ func (c *LogConfig) SetCleanupConfig(cfg *CleanupConfig) {
 	c.cleanupConfig = cfg
 }
 
+// SetSlowRequestConfig 设置慢请求诊断配置（供构建时使用）
+func (c *LogConfig) SetSlowRequestConfig(cfg *SlowRequestConfig) {
+	c.slowRequestConfig = cfg
+}
+
+// SetLogSpoolConfig 设置失败日志落盘重试配置（供构建时使用）
+func (c *LogConfig) SetLogSpoolConfig(cfg *LogSpoolConfig) {
+	c.logSpoolConfig = cfg
+}
+
 // AlertConfig 告警配置（从 extProperty 解析）
 type AlertConfig struct {
 	AlertEnabled       bool
@@ -162,6 +178,25 @@ type CleanupConfig struct {
 	ScheduledTime       string // 清理执行时间（格式：HH:MM，如 "02:00"）
 }
 
+// SlowRequestConfig 慢请求诊断配置（从 extProperty 解析）
+type SlowRequestConfig struct {
+	SlowRequestEnabled bool   // 是否启用慢请求检测
+	ThresholdMs        int    // 慢请求阈值（毫秒），默认3000
+	GoroutineDump      bool   // 超过阈值时是否采集协程快照
+	SnapshotDir        string // 协程快照保存目录，默认 ./logs/slow_request
+	MaxPerMinute       int    // 每分钟最多记录的慢请求数，默认60，防止故障时写入风暴
+}
+
+// LogSpoolConfig 失败日志落盘重试配置（从 extProperty 解析）
+type LogSpoolConfig struct {
+	SpoolEnabled       bool   // 是否启用失败日志落盘重试
+	SpoolDir           string // 落盘目录，默认 ./logs/spool，按网关实例分子目录
+	MaxRetries         int    // 单条日志最大重试次数，默认10，超过后转入死信目录
+	RetryIntervalMs    int    // 首次重试间隔（毫秒），默认5000，连续失败时按2倍退避
+	MaxRetryIntervalMs int    // 最大重试间隔（毫秒），默认300000
+	MaxSpoolSizeMB     int    // 落盘文件总大小上限（MB），默认512，0表示不限制
+}
+
 // GetAlertConfig 获取告警配置（如果未解析则解析，已解析则直接返回）
 func (c *LogConfig) GetAlertConfig() *AlertConfig {
 	if c.alertConfig != nil {
@@ -182,6 +217,26 @@ func (c *LogConfig) GetCleanupConfig() *CleanupConfig {
 	return c.cleanupConfig
 }
 
+// GetSlowRequestConfig 获取慢请求诊断配置（如果未解析则解析，已解析则直接返回）
+func (c *LogConfig) GetSlowRequestConfig() *SlowRequestConfig {
+	if c.slowRequestConfig != nil {
+		return c.slowRequestConfig
+	}
+	// 如果未解析，则解析一次（延迟解析，兼容旧代码）
+	c.slowRequestConfig = ParseSlowRequestConfigFromExtProperty(c.ExtProperty)
+	return c.slowRequestConfig
+}
+
+// GetLogSpoolConfig 获取失败日志落盘重试配置（如果未解析则解析，已解析则直接返回）
+func (c *LogConfig) GetLogSpoolConfig() *LogSpoolConfig {
+	if c.logSpoolConfig != nil {
+		return c.logSpoolConfig
+	}
+	// 如果未解析，则解析一次（延迟解析，兼容旧代码）
+	c.logSpoolConfig = ParseLogSpoolConfigFromExtProperty(c.ExtProperty)
+	return c.logSpoolConfig
+}
+
 // ParseAlertConfigFromExtProperty 从 extProperty JSON 字符串解析告警配置（导出函数，供其他包使用）
 // 按照前端实际保存的格式解析：
 // - alertEnabled: 'Y'/'N' 字符串
@@ -327,6 +382,168 @@ func ParseCleanupConfigFromExtProperty(extProperty string) *CleanupConfig {
 	return cfg
 }
 
+// ParseSlowRequestConfigFromExtProperty 从 extProperty JSON 字符串解析慢请求诊断配置
+// 按照前端实际保存的格式解析：
+// - slowRequestEnabled: 'Y'/'N' 字符串
+// - slowRequestThresholdMs: number
+// - slowRequestGoroutineDump: 'Y'/'N' 字符串
+// - slowRequestSnapshotDir: string
+// - slowRequestMaxPerMinute: number
+func ParseSlowRequestConfigFromExtProperty(extProperty string) *SlowRequestConfig {
+	cfg := &SlowRequestConfig{
+		SlowRequestEnabled: false,
+		ThresholdMs:        3000,
+		GoroutineDump:      false,
+		SnapshotDir:        "./logs/slow_request",
+		MaxPerMinute:       60,
+	}
+
+	if strings.TrimSpace(extProperty) == "" {
+		return cfg
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(extProperty), &m); err != nil {
+		return cfg
+	}
+
+	// slowRequestEnabled: 'Y'/'N' 字符串
+	if v, ok := m["slowRequestEnabled"].(string); ok {
+		cfg.SlowRequestEnabled = strings.TrimSpace(strings.ToUpper(v)) == "Y"
+	}
+
+	// slowRequestThresholdMs: number
+	if v, ok := m["slowRequestThresholdMs"]; ok {
+		switch t := v.(type) {
+		case float64:
+			cfg.ThresholdMs = int(t)
+		case int:
+			cfg.ThresholdMs = t
+		}
+	}
+
+	// slowRequestGoroutineDump: 'Y'/'N' 字符串
+	if v, ok := m["slowRequestGoroutineDump"].(string); ok {
+		cfg.GoroutineDump = strings.TrimSpace(strings.ToUpper(v)) == "Y"
+	}
+
+	// slowRequestSnapshotDir: string
+	if v, ok := m["slowRequestSnapshotDir"].(string); ok && strings.TrimSpace(v) != "" {
+		cfg.SnapshotDir = strings.TrimSpace(v)
+	}
+
+	// slowRequestMaxPerMinute: number
+	if v, ok := m["slowRequestMaxPerMinute"]; ok {
+		switch t := v.(type) {
+		case float64:
+			cfg.MaxPerMinute = int(t)
+		case int:
+			cfg.MaxPerMinute = t
+		}
+	}
+
+	// 阈值无效时不启用，避免每个请求都被当作慢请求
+	if cfg.ThresholdMs <= 0 {
+		cfg.SlowRequestEnabled = false
+	}
+
+	return cfg
+}
+
+// ParseLogSpoolConfigFromExtProperty 从 extProperty JSON 字符串解析失败日志落盘重试配置
+// 按照前端实际保存的格式解析：
+// - logSpoolEnabled: 'Y'/'N' 字符串
+// - logSpoolDir: string
+// - logSpoolMaxRetries: number
+// - logSpoolRetryIntervalMs: number
+// - logSpoolMaxRetryIntervalMs: number
+// - logSpoolMaxSizeMB: number
+func ParseLogSpoolConfigFromExtProperty(extProperty string) *LogSpoolConfig {
+	cfg := &LogSpoolConfig{
+		SpoolEnabled:       false,
+		SpoolDir:           "./logs/spool",
+		MaxRetries:         10,
+		RetryIntervalMs:    5000,
+		MaxRetryIntervalMs: 300000,
+		MaxSpoolSizeMB:     512,
+	}
+
+	if strings.TrimSpace(extProperty) == "" {
+		return cfg
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(extProperty), &m); err != nil {
+		return cfg
+	}
+
+	// logSpoolEnabled: 'Y'/'N' 字符串
+	if v, ok := m["logSpoolEnabled"].(string); ok {
+		cfg.SpoolEnabled = strings.TrimSpace(strings.ToUpper(v)) == "Y"
+	}
+
+	// logSpoolDir: string
+	if v, ok := m["logSpoolDir"].(string); ok && strings.TrimSpace(v) != "" {
+		cfg.SpoolDir = strings.TrimSpace(v)
+	}
+
+	// logSpoolMaxRetries: number
+	if v, ok := m["logSpoolMaxRetries"]; ok {
+		switch t := v.(type) {
+		case float64:
+			cfg.MaxRetries = int(t)
+		case int:
+			cfg.MaxRetries = t
+		}
+	}
+
+	// logSpoolRetryIntervalMs: number（毫秒）
+	if v, ok := m["logSpoolRetryIntervalMs"]; ok {
+		switch t := v.(type) {
+		case float64:
+			cfg.RetryIntervalMs = int(t)
+		case int:
+			cfg.RetryIntervalMs = t
+		}
+	}
+
+	// logSpoolMaxRetryIntervalMs: number（毫秒）
+	if v, ok := m["logSpoolMaxRetryIntervalMs"]; ok {
+		switch t := v.(type) {
+		case float64:
+			cfg.MaxRetryIntervalMs = int(t)
+		case int:
+			cfg.MaxRetryIntervalMs = t
+		}
+	}
+
+	// logSpoolMaxSizeMB: number（MB）
+	if v, ok := m["logSpoolMaxSizeMB"]; ok {
+		switch t := v.(type) {
+		case float64:
+			cfg.MaxSpoolSizeMB = int(t)
+		case int:
+			cfg.MaxSpoolSizeMB = t
+		}
+	}
+
+	// 重试次数和间隔无效时使用默认值，避免无限重试或重试风暴
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = 10
+	}
+	if cfg.RetryIntervalMs <= 0 {
+		cfg.RetryIntervalMs = 5000
+	}
+	if cfg.MaxRetryIntervalMs < cfg.RetryIntervalMs {
+		cfg.MaxRetryIntervalMs = cfg.RetryIntervalMs
+	}
+	if cfg.MaxSpoolSizeMB < 0 {
+		cfg.MaxSpoolSizeMB = 0
+	}
+
+	return cfg
+}
+
 // FileOutputConfig 文件输出配置
 type FileOutputConfig struct {
 	Path         string `json:"path"`          // 日志文件路径
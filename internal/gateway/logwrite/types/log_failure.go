@@ -0,0 +1,32 @@
+package types
+
+import "sync/atomic"
+
+// FailedAccessLogHandler 访问日志落库失败回调
+// 写入器在访问日志最终未能持久化时调用（同步写入失败、异步队列已满被丢弃、批量刷新失败等），
+// 由失败日志落盘重试组件接管，保证请求记录不会被静默丢弃
+type FailedAccessLogHandler func(logs []*AccessLog, cause error)
+
+// AccessLogFailureReporter 访问日志落库失败上报器，内嵌到持久化写入器中使用
+// 未设置回调时上报为空操作，写入器保持原有的仅打印日志行为
+type AccessLogFailureReporter struct {
+	handler atomic.Value // FailedAccessLogHandler
+}
+
+// SetFailureHandler 设置落库失败回调，可在写入器运行期间安全调用
+func (r *AccessLogFailureReporter) SetFailureHandler(handler FailedAccessLogHandler) {
+	r.handler.Store(handler)
+}
+
+// ReportFailure 上报落库失败的访问日志
+func (r *AccessLogFailureReporter) ReportFailure(logs []*AccessLog, cause error) {
+	if len(logs) == 0 {
+		return
+	}
+	handler, _ := r.handler.Load().(FailedAccessLogHandler)
+	if handler == nil {
+		return
+	}
+	// 调用方可能复用切片（如批量缓冲区），复制后再交给回调
+	handler(append([]*AccessLog(nil), logs...), cause)
+}
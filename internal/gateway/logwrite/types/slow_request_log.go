@@ -0,0 +1,116 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// SlowRequestLog 慢请求诊断记录结构体，对应数据库表 HUB_GW_SLOW_REQ
+//
+// 设计说明：
+// 1. 请求总耗时超过日志配置中的慢请求阈值时记录一条，通过 tenantId 和 traceId 关联访问日志主表
+// 2. 记录完整耗时分解、上游连接状态和协程快照引用，供事后分析慢请求原因
+// 3. 协程快照体积较大，只保存文件引用，不入库
+type SlowRequestLog struct {
+	// 主键和关联字段
+	TenantID  string `json:"tenantId" db:"tenantId" bson:"tenantId"`    // 租户ID
+	SlowReqID string `json:"slowReqId" db:"slowReqId" bson:"slowReqId"` // 慢请求记录ID（主键）
+	TraceID   string `json:"traceId" db:"traceId" bson:"traceId"`       // 链路追踪ID，关联主表 HUB_GW_ACCESS_LOG.traceId
+
+	// 网关和路由信息
+	GatewayInstanceID   string `json:"gatewayInstanceId" db:"gatewayInstanceId" bson:"gatewayInstanceId"`       // 网关实例ID
+	GatewayInstanceName string `json:"gatewayInstanceName" db:"gatewayInstanceName" bson:"gatewayInstanceName"` // 网关实例名称
+	GatewayNodeIP       string `json:"gatewayNodeIp" db:"gatewayNodeIp" bson:"gatewayNodeIp"`                   // 网关节点IP
+	RouteConfigID       string `json:"routeConfigId" db:"routeConfigId" bson:"routeConfigId"`                   // 路由配置ID
+	RouteName           string `json:"routeName" db:"routeName" bson:"routeName"`                               // 路由名称
+	ServiceDefinitionID string `json:"serviceDefinitionId" db:"serviceDefinitionId" bson:"serviceDefinitionId"` // 服务定义ID
+	ServiceName         string `json:"serviceName" db:"serviceName" bson:"serviceName"`                         // 服务名称
+
+	// 请求信息
+	RequestMethod     string `json:"requestMethod" db:"requestMethod" bson:"requestMethod"`             // 请求方法
+	RequestPath       string `json:"requestPath" db:"requestPath" bson:"requestPath"`                   // 请求路径
+	ClientIPAddress   string `json:"clientIpAddress" db:"clientIpAddress" bson:"clientIpAddress"`       // 客户端IP
+	GatewayStatusCode int    `json:"gatewayStatusCode" db:"gatewayStatusCode" bson:"gatewayStatusCode"` // 网关状态码
+	BackendStatusCode int    `json:"backendStatusCode" db:"backendStatusCode" bson:"backendStatusCode"` // 后端状态码（0表示未调用后端）
+
+	// 耗时信息
+	ThresholdMs             int       `json:"thresholdMs" db:"thresholdMs" bson:"thresholdMs"`                                     // 触发时的慢请求阈值(毫秒)
+	TotalProcessingTimeMs   int       `json:"totalProcessingTimeMs" db:"totalProcessingTimeMs" bson:"totalProcessingTimeMs"`       // 总处理时间(毫秒)
+	GatewayProcessingTimeMs int       `json:"gatewayProcessingTimeMs" db:"gatewayProcessingTimeMs" bson:"gatewayProcessingTimeMs"` // 网关自身处理时间(毫秒)
+	BackendResponseTimeMs   int       `json:"backendResponseTimeMs" db:"backendResponseTimeMs" bson:"backendResponseTimeMs"`       // 后端响应时间(毫秒)
+	RequestStartTime        time.Time `json:"requestStartTime" db:"requestStartTime" bson:"requestStartTime"`                      // 网关开始处理时间
+	RequestFinishTime       time.Time `json:"requestFinishTime" db:"requestFinishTime" bson:"requestFinishTime"`                   // 网关处理完成时间
+
+	// 诊断信息
+	TimingBreakdown      string    `json:"timingBreakdown" db:"timingBreakdown" bson:"timingBreakdown"`                // 耗时分解(JSON格式)
+	UpstreamConnState    string    `json:"upstreamConnState" db:"upstreamConnState" bson:"upstreamConnState"`          // 上游连接状态(JSON数组，每次转发尝试一条)
+	GoroutineCount       int       `json:"goroutineCount" db:"goroutineCount" bson:"goroutineCount"`                   // 超过阈值时的协程数量（0表示请求结束前未触发看门狗）
+	GoroutineSnapshotRef string    `json:"goroutineSnapshotRef" db:"goroutineSnapshotRef" bson:"goroutineSnapshotRef"` // 协程快照文件引用（未采集时为空）
+	DetectedTime         time.Time `json:"detectedTime" db:"detectedTime" bson:"detectedTime"`                         // 看门狗检测到慢请求的时间（零时间表示请求结束时才判定）
+	ErrorMessage         string    `json:"errorMessage" db:"errorMessage" bson:"errorMessage"`                         // 错误信息
+
+	// 扩展信息
+	ExtProperty string `json:"extProperty" db:"extProperty" bson:"extProperty"` // 扩展属性(JSON格式)
+
+	// 标准数据库字段
+	AddTime        time.Time `json:"addTime" db:"addTime" bson:"addTime"`                      // 记录创建时间
+	AddWho         string    `json:"addWho" db:"addWho" bson:"addWho"`                         // 记录创建者
+	EditTime       time.Time `json:"editTime" db:"editTime" bson:"editTime"`                   // 记录修改时间
+	EditWho        string    `json:"editWho" db:"editWho" bson:"editWho"`                      // 记录修改者
+	OprSeqFlag     string    `json:"oprSeqFlag" db:"oprSeqFlag" bson:"oprSeqFlag"`             // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" db:"currentVersion" bson:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" db:"activeFlag" bson:"activeFlag"`             // 活动状态标记
+	NoteText       string    `json:"noteText" db:"noteText" bson:"noteText"`                   // 备注信息
+}
+
+// SlowRequestLogTableName 慢请求诊断表名
+const SlowRequestLogTableName = "HUB_GW_SLOW_REQ"
+
+// TableName 返回表名，实现ORM接口
+func (s *SlowRequestLog) TableName() string {
+	return SlowRequestLogTableName
+}
+
+// NewSlowRequestLog 创建慢请求诊断记录，由访问日志复制公共字段
+//
+// 参数：
+//   - slowReqID: 慢请求记录ID
+//   - accessLog: 已计算耗时的访问日志
+//   - thresholdMs: 慢请求阈值(毫秒)
+//
+// 返回：
+//   - *SlowRequestLog: 初始化的慢请求诊断记录
+func NewSlowRequestLog(slowReqID string, accessLog *AccessLog, thresholdMs int) *SlowRequestLog {
+	now := time.Now()
+	return &SlowRequestLog{
+		TenantID:                accessLog.TenantID,
+		SlowReqID:               slowReqID,
+		TraceID:                 accessLog.TraceID,
+		GatewayInstanceID:       accessLog.GatewayInstanceID,
+		GatewayInstanceName:     accessLog.GatewayInstanceName,
+		GatewayNodeIP:           accessLog.GatewayNodeIP,
+		RouteConfigID:           accessLog.RouteConfigID,
+		RouteName:               accessLog.RouteName,
+		ServiceDefinitionID:     accessLog.ServiceDefinitionID,
+		ServiceName:             accessLog.ServiceName,
+		RequestMethod:           accessLog.RequestMethod,
+		RequestPath:             accessLog.RequestPath,
+		ClientIPAddress:         accessLog.ClientIPAddress,
+		GatewayStatusCode:       accessLog.GatewayStatusCode,
+		BackendStatusCode:       accessLog.BackendStatusCode,
+		ThresholdMs:             thresholdMs,
+		TotalProcessingTimeMs:   accessLog.TotalProcessingTimeMs,
+		GatewayProcessingTimeMs: accessLog.GatewayProcessingTimeMs,
+		BackendResponseTimeMs:   accessLog.BackendResponseTimeMs,
+		RequestStartTime:        accessLog.GatewayStartProcessingTime,
+		RequestFinishTime:       accessLog.GatewayFinishedProcessingTime,
+		ErrorMessage:            accessLog.ErrorMessage,
+		AddTime:                 now,
+		EditTime:                now,
+		AddWho:                  DefaultAddWho,
+		EditWho:                 DefaultEditWho,
+		OprSeqFlag:              fmt.Sprintf("SR-%d", now.UnixNano()),
+		CurrentVersion:          DefaultVersion,
+		ActiveFlag:              DefaultActiveFlag,
+	}
+}
@@ -66,7 +66,7 @@ func (a *Analyzer) RunAnalysis() error {
 
 // collectProfiles 收集profile数据
 func (a *Analyzer) collectProfiles(outputDir string) error {
-	baseURL := fmt.Sprintf("http://localhost%s/debug/pprof", a.config.Listen)
+	baseURL := fmt.Sprintf("http://%s/debug/pprof", a.config.localAddress())
 
 	profiles := map[string]string{
 		"cpu":          fmt.Sprintf("%s/profile?seconds=%d", baseURL, int(a.config.AutoAnalysis.CPUSampleDuration.Seconds())),
@@ -98,7 +98,15 @@ func (a *Analyzer) downloadProfile(url, filename string) error {
 		Timeout: 60 * time.Second,
 	}
 
-	resp, err := client.Get(url)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if a.config.EnableAuth {
+		req.Header.Set("Authorization", "Bearer "+a.config.AuthToken)
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return err
 	}
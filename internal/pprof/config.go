@@ -1,6 +1,8 @@
 package pprof
 
 import (
+	"fmt"
+	"net"
 	"time"
 )
 
@@ -20,6 +22,12 @@ type Config struct {
 	EnableAuth bool `json:"enable_auth" yaml:"enable_auth" mapstructure:"enable_auth"`
 	// 认证token
 	AuthToken string `json:"auth_token" yaml:"auth_token" mapstructure:"auth_token"`
+	// 允许访问的客户端IP或CIDR，为空时不限制
+	AllowedIPs []string `json:"allowed_ips" yaml:"allowed_ips" mapstructure:"allowed_ips"`
+	// CPU profile 和 trace 的最大采样时间，超过时按最大值采样
+	MaxProfileDuration time.Duration `json:"max_profile_duration" yaml:"max_profile_duration" mapstructure:"max_profile_duration"`
+	// 运行时统计的采样间隔，用于计算内存分配速率
+	RuntimeSampleInterval time.Duration `json:"runtime_sample_interval" yaml:"runtime_sample_interval" mapstructure:"runtime_sample_interval"`
 	// 自动分析配置
 	AutoAnalysis AutoAnalysisConfig `json:"auto_analysis" yaml:"auto_analysis" mapstructure:"auto_analysis"`
 }
@@ -42,13 +50,15 @@ type AutoAnalysisConfig struct {
 
 // DefaultConfig 默认配置
 var DefaultConfig = Config{
-	Enabled:      false,
-	Listen:       ":6060",
-	ServiceName:  "Gateway-pprof",
-	ReadTimeout:  30 * time.Second,
-	WriteTimeout: 30 * time.Second,
-	EnableAuth:   false,
-	AuthToken:    "",
+	Enabled:               false,
+	Listen:                "127.0.0.1:6060",
+	ServiceName:           "Gateway-pprof",
+	ReadTimeout:           30 * time.Second,
+	WriteTimeout:          30 * time.Second,
+	EnableAuth:            false,
+	AuthToken:             "",
+	MaxProfileDuration:    60 * time.Second,
+	RuntimeSampleInterval: 10 * time.Second,
 	AutoAnalysis: AutoAnalysisConfig{
 		Enabled:              false,
 		Interval:             30 * time.Minute,
@@ -58,3 +68,59 @@ var DefaultConfig = Config{
 		HistoryRetentionDays: 7,
 	},
 }
+
+// Validate 校验安全配置
+// 启用认证时必须配置token；未启用认证时只能监听本机回环地址，避免诊断接口暴露到网络
+func (c *Config) Validate() error {
+	if c.EnableAuth {
+		if c.AuthToken == "" {
+			return fmt.Errorf("pprof已启用认证但未配置auth_token")
+		}
+	} else if !isLoopbackListen(c.Listen) {
+		return fmt.Errorf("pprof未启用认证时只能监听本机地址，当前监听地址: %s", c.Listen)
+	}
+
+	for _, item := range c.AllowedIPs {
+		if net.ParseIP(item) == nil {
+			if _, _, err := net.ParseCIDR(item); err != nil {
+				return fmt.Errorf("pprof允许访问的IP配置无效: %s", item)
+			}
+		}
+	}
+	return nil
+}
+
+// isLoopbackListen 判断监听地址是否为本机回环地址
+func isLoopbackListen(listen string) bool {
+	host, _, err := net.SplitHostPort(listen)
+	if err != nil || host == "" {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// maxProfileDuration CPU profile 和 trace 实际允许的最大采样时间
+// 采样时间不能达到写入超时，否则pprof会拒绝采样，因此最多为写入超时减1秒
+func (c *Config) maxProfileDuration() time.Duration {
+	max := c.MaxProfileDuration
+	if c.WriteTimeout > time.Second && (max <= 0 || max >= c.WriteTimeout) {
+		max = c.WriteTimeout - time.Second
+	}
+	return max
+}
+
+// localAddress 本机访问pprof服务的地址，供自动分析器采集数据
+func (c *Config) localAddress() string {
+	host, port, err := net.SplitHostPort(c.Listen)
+	if err != nil {
+		return c.Listen
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
@@ -2,10 +2,15 @@ package pprof
 
 import (
 	"context"
+	"crypto/subtle"
+	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
-	_ "net/http/pprof"
+	"net/http/pprof"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,12 +22,15 @@ type Manager struct {
 	config   *Config
 	server   *http.Server
 	analyzer *Analyzer
+	sampler  *RuntimeSampler
 	running  bool
-	mu       sync.RWMutex
-	stopCh   chan struct{}
-	wg       sync.WaitGroup
-	ctx      context.Context
-	cancel   context.CancelFunc
+	// profileSlots 限制同时进行的CPU profile和trace采样，避免诊断本身拖慢生产流量
+	profileSlots chan struct{}
+	mu           sync.RWMutex
+	stopCh       chan struct{}
+	wg           sync.WaitGroup
+	ctx          context.Context
+	cancel       context.CancelFunc
 }
 
 // NewManager 创建pprof管理器
@@ -30,11 +38,13 @@ func NewManager(config *Config) *Manager {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &Manager{
-		config:   config,
-		analyzer: NewAnalyzer(config),
-		stopCh:   make(chan struct{}),
-		ctx:      ctx,
-		cancel:   cancel,
+		config:       config,
+		analyzer:     NewAnalyzer(config),
+		sampler:      NewRuntimeSampler(config.RuntimeSampleInterval),
+		profileSlots: make(chan struct{}, 1),
+		stopCh:       make(chan struct{}),
+		ctx:          ctx,
+		cancel:       cancel,
 	}
 }
 
@@ -52,18 +62,14 @@ func (m *Manager) Start() error {
 		return nil
 	}
 
-	// 创建HTTP服务器
-	mux := http.NewServeMux()
-
-	// 注册pprof路由
-	m.registerPprofRoutes(mux)
-
-	// 注册自定义路由
-	m.registerCustomRoutes(mux)
+	// 校验认证和监听地址等安全配置
+	if err := m.config.Validate(); err != nil {
+		return err
+	}
 
 	m.server = &http.Server{
 		Addr:         m.config.Listen,
-		Handler:      mux,
+		Handler:      m.Handler(),
 		ReadTimeout:  m.config.ReadTimeout,
 		WriteTimeout: m.config.WriteTimeout,
 	}
@@ -79,6 +85,13 @@ func (m *Manager) Start() error {
 		}
 	}()
 
+	// 启动运行时统计采样
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.sampler.Run(m.stopCh)
+	}()
+
 	// 启动自动分析器
 	if m.config.AutoAnalysis.Enabled {
 		m.wg.Add(1)
@@ -93,7 +106,8 @@ func (m *Manager) Start() error {
 	logger.Info("pprof服务启动成功",
 		"service", m.config.ServiceName,
 		"listen", m.config.Listen,
-		"web_ui", fmt.Sprintf("http://localhost%s/debug/pprof/", m.config.Listen),
+		"web_ui", fmt.Sprintf("http://%s/debug/pprof/", m.config.localAddress()),
+		"auth_enabled", m.config.EnableAuth,
 		"auto_analysis", m.config.AutoAnalysis.Enabled,
 	)
 
@@ -141,17 +155,67 @@ func (m *Manager) IsRunning() bool {
 	return m.running
 }
 
+// Handler 创建pprof服务的HTTP处理器
+// 除 /health 外的所有接口都经过访问控制：客户端IP在 allowed_ips 范围内，启用认证时还需携带token
+func (m *Manager) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	// 注册pprof路由
+	m.registerPprofRoutes(mux)
+
+	// 注册自定义路由
+	m.registerCustomRoutes(mux)
+
+	guarded := m.authMiddleware(mux)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			mux.ServeHTTP(w, r)
+			return
+		}
+		guarded.ServeHTTP(w, r)
+	})
+}
+
 // registerPprofRoutes 注册pprof路由
+// 显式注册pprof处理器，不使用 http.DefaultServeMux，避免其他使用默认路由的服务暴露pprof
 func (m *Manager) registerPprofRoutes(mux *http.ServeMux) {
-	// 如果启用了认证，添加认证中间件
-	if m.config.EnableAuth {
-		mux.HandleFunc("/debug/pprof/", m.authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			http.DefaultServeMux.ServeHTTP(w, r)
-		})))
-	} else {
-		mux.HandleFunc("/debug/pprof/", func(w http.ResponseWriter, r *http.Request) {
-			http.DefaultServeMux.ServeHTTP(w, r)
-		})
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/profile", m.limitProfile(pprof.Profile))
+	mux.HandleFunc("/debug/pprof/trace", m.limitProfile(pprof.Trace))
+}
+
+// limitProfile 限制CPU profile和trace的采样时间和并发数
+// 采样时间超过 max_profile_duration（且小于写入超时）时按最大值采样，已有采样进行中时返回429
+func (m *Manager) limitProfile(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if max := m.config.maxProfileDuration(); max > 0 {
+			seconds, err := strconv.ParseFloat(r.URL.Query().Get("seconds"), 64)
+			if err != nil || seconds <= 0 || seconds > max.Seconds() {
+				query := r.URL.Query()
+				if err != nil || seconds <= 0 {
+					// 未指定时使用pprof的默认采样时间，并确保不超过最大值
+					seconds = 30
+				}
+				if seconds > max.Seconds() {
+					seconds = max.Seconds()
+				}
+				query.Set("seconds", strconv.FormatFloat(seconds, 'f', -1, 64))
+				r.URL.RawQuery = query.Encode()
+			}
+		}
+
+		select {
+		case m.profileSlots <- struct{}{}:
+			defer func() { <-m.profileSlots }()
+		default:
+			http.Error(w, "已有采样任务进行中，请稍后重试", http.StatusTooManyRequests)
+			return
+		}
+
+		logger.Info("pprof采样开始", "path", r.URL.Path, "seconds", r.URL.Query().Get("seconds"), "remote", r.RemoteAddr)
+		next(w, r)
 	}
 }
 
@@ -165,22 +229,12 @@ func (m *Manager) registerCustomRoutes(mux *http.ServeMux) {
 
 	// 服务信息
 	mux.HandleFunc("/info", func(w http.ResponseWriter, r *http.Request) {
-		info := map[string]interface{}{
-			"service":       m.config.ServiceName,
-			"listen":        m.config.Listen,
-			"auto_analysis": m.config.AutoAnalysis.Enabled,
-			"running":       m.running,
-			"pprof_enabled": m.config.Enabled,
-		}
+		writeJSON(w, m.GetStatus())
+	})
 
-		w.Header().Set("Content-Type", "application/json")
-		fmt.Fprintf(w, `{
-			"service": "%s",
-			"listen": "%s",
-			"auto_analysis": %t,
-			"running": %t,
-			"pprof_enabled": %t
-		}`, info["service"], info["listen"], info["auto_analysis"], info["running"], info["pprof_enabled"])
+	// 运行时统计：协程数、GC暂停、内存分配速率等
+	mux.HandleFunc("/debug/runtime", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, m.sampler.Snapshot())
 	})
 
 	// 手动触发分析
@@ -201,24 +255,74 @@ func (m *Manager) registerCustomRoutes(mux *http.ServeMux) {
 	})
 }
 
-// authMiddleware 认证中间件
+// writeJSON 输出JSON响应
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(v)
+}
+
+// authMiddleware 访问控制中间件
+// 校验客户端IP是否在允许范围内；启用认证时校验 Authorization 请求头（支持 Bearer 前缀）或 token 查询参数
 func (m *Manager) authMiddleware(next http.Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		token := r.Header.Get("Authorization")
-		if token == "" {
-			token = r.URL.Query().Get("token")
+		if !m.ipAllowed(r.RemoteAddr) {
+			logger.Warn("pprof访问被拒绝：客户端IP不在允许范围内", "remote", r.RemoteAddr, "path", r.URL.Path)
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("Forbidden"))
+			return
 		}
 
-		if token != m.config.AuthToken {
-			w.WriteHeader(http.StatusUnauthorized)
-			w.Write([]byte("Unauthorized"))
-			return
+		if m.config.EnableAuth {
+			token := strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+			if token == "" {
+				token = r.URL.Query().Get("token")
+			}
+
+			if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(m.config.AuthToken)) != 1 {
+				logger.Warn("pprof访问被拒绝：认证失败", "remote", r.RemoteAddr, "path", r.URL.Path)
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte("Unauthorized"))
+				return
+			}
 		}
 
 		next.ServeHTTP(w, r)
 	}
 }
 
+// ipAllowed 判断客户端IP是否在 allowed_ips 范围内，未配置时允许所有IP
+// 本机回环地址始终允许，保证自动分析器可以采集数据
+func (m *Manager) ipAllowed(remoteAddr string) bool {
+	if len(m.config.AllowedIPs) == 0 {
+		return true
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	if ip.IsLoopback() {
+		return true
+	}
+	for _, item := range m.config.AllowedIPs {
+		if allowed := net.ParseIP(item); allowed != nil {
+			if allowed.Equal(ip) {
+				return true
+			}
+			continue
+		}
+		if _, network, err := net.ParseCIDR(item); err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // runAutoAnalysis 运行自动分析
 func (m *Manager) runAutoAnalysis() {
 	ticker := time.NewTicker(m.config.AutoAnalysis.Interval)
@@ -251,12 +355,15 @@ func (m *Manager) GetStatus() map[string]interface{} {
 	defer m.mu.RUnlock()
 
 	return map[string]interface{}{
-		"running":       m.running,
-		"service_name":  m.config.ServiceName,
-		"listen":        m.config.Listen,
-		"auto_analysis": m.config.AutoAnalysis.Enabled,
-		"auth_enabled":  m.config.EnableAuth,
-		"output_dir":    m.config.AutoAnalysis.OutputDir,
+		"running":              m.running,
+		"service_name":         m.config.ServiceName,
+		"listen":               m.config.Listen,
+		"pprof_enabled":        m.config.Enabled,
+		"auto_analysis":        m.config.AutoAnalysis.Enabled,
+		"auth_enabled":         m.config.EnableAuth,
+		"allowed_ips":          m.config.AllowedIPs,
+		"max_profile_duration": m.config.MaxProfileDuration.String(),
+		"output_dir":           m.config.AutoAnalysis.OutputDir,
 	}
 }
 
@@ -271,6 +378,7 @@ func (m *Manager) UpdateConfig(newConfig *Config) error {
 
 	m.config = newConfig
 	m.analyzer = NewAnalyzer(newConfig)
+	m.sampler = NewRuntimeSampler(newConfig.RuntimeSampleInterval)
 
 	logger.Info("pprof配置已更新")
 	return nil
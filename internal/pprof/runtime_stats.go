@@ -0,0 +1,230 @@
+package pprof
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// recentPauseCount 运行时统计中返回的最近GC暂停次数
+const recentPauseCount = 32
+
+// RuntimeStats 运行时统计
+type RuntimeStats struct {
+	Time       time.Time `json:"time"`
+	GoVersion  string    `json:"goVersion"`
+	NumCPU     int       `json:"numCpu"`
+	GOMAXPROCS int       `json:"gomaxprocs"`
+	Goroutines int       `json:"goroutines"`
+	CgoCalls   int64     `json:"cgoCalls"`
+
+	Memory MemoryStats `json:"memory"`
+	GC     GCStats     `json:"gc"`
+	Rates  RateStats   `json:"rates"`
+}
+
+// MemoryStats 内存统计，单位字节
+type MemoryStats struct {
+	HeapAlloc   uint64 `json:"heapAlloc"`
+	HeapInuse   uint64 `json:"heapInuse"`
+	HeapIdle    uint64 `json:"heapIdle"`
+	HeapObjects uint64 `json:"heapObjects"`
+	StackInuse  uint64 `json:"stackInuse"`
+	Sys         uint64 `json:"sys"`
+	TotalAlloc  uint64 `json:"totalAlloc"`
+	NextGC      uint64 `json:"nextGc"`
+}
+
+// GCStats GC统计，暂停时间单位毫秒
+type GCStats struct {
+	NumGC          uint32    `json:"numGc"`
+	NumForcedGC    uint32    `json:"numForcedGc"`
+	LastGC         time.Time `json:"lastGc"`
+	PauseTotalMs   float64   `json:"pauseTotalMs"`
+	RecentPausesMs []float64 `json:"recentPausesMs"` // 最近的GC暂停时间，从新到旧
+	PauseP50Ms     float64   `json:"pauseP50Ms"`
+	PauseP99Ms     float64   `json:"pauseP99Ms"`
+	PauseMaxMs     float64   `json:"pauseMaxMs"`
+	CPUFraction    float64   `json:"cpuFraction"` // GC占用的CPU比例
+}
+
+// RateStats 最近一个采样周期内的速率
+type RateStats struct {
+	WindowSeconds            float64 `json:"windowSeconds"`
+	AllocBytesPerSecond      float64 `json:"allocBytesPerSecond"`
+	MallocsPerSecond         float64 `json:"mallocsPerSecond"`
+	FreesPerSecond           float64 `json:"freesPerSecond"`
+	GCPerMinute              float64 `json:"gcPerMinute"`
+	PauseMsPerMinute         float64 `json:"pauseMsPerMinute"`
+	GoroutineGrowthPerSecond float64 `json:"goroutineGrowthPerSecond"`
+	CgoCallsPerSecond        float64 `json:"cgoCallsPerSecond"`
+	SampleIntervalSeconds    float64 `json:"sampleIntervalSeconds"`
+}
+
+// runtimeSample 计算速率用的累计值快照
+type runtimeSample struct {
+	time       time.Time
+	totalAlloc uint64
+	mallocs    uint64
+	frees      uint64
+	numGC      uint32
+	pauseTotal uint64
+	goroutines int
+	cgoCalls   int64
+}
+
+// RuntimeSampler 运行时统计采样器
+// 按采样间隔记录累计值，速率为最近两次采样之间的平均值；尚未完成一个采样周期时使用创建以来的平均值
+type RuntimeSampler struct {
+	interval time.Duration
+	mu       sync.RWMutex
+	prev     runtimeSample
+	cur      runtimeSample
+	sampled  bool
+}
+
+// NewRuntimeSampler 创建运行时统计采样器
+func NewRuntimeSampler(interval time.Duration) *RuntimeSampler {
+	if interval <= 0 {
+		interval = DefaultConfig.RuntimeSampleInterval
+	}
+	sample := takeSample()
+	return &RuntimeSampler{interval: interval, prev: sample, cur: sample}
+}
+
+// Run 定期采样，stopCh 关闭时退出
+func (s *RuntimeSampler) Run(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			sample := takeSample()
+			s.mu.Lock()
+			s.prev, s.cur, s.sampled = s.cur, sample, true
+			s.mu.Unlock()
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// Snapshot 获取当前运行时统计
+func (s *RuntimeSampler) Snapshot() *RuntimeStats {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	now := time.Now()
+
+	stats := &RuntimeStats{
+		Time:       now,
+		GoVersion:  runtime.Version(),
+		NumCPU:     runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		Goroutines: runtime.NumGoroutine(),
+		CgoCalls:   runtime.NumCgoCall(),
+		Memory: MemoryStats{
+			HeapAlloc:   m.HeapAlloc,
+			HeapInuse:   m.HeapInuse,
+			HeapIdle:    m.HeapIdle,
+			HeapObjects: m.HeapObjects,
+			StackInuse:  m.StackInuse,
+			Sys:         m.Sys,
+			TotalAlloc:  m.TotalAlloc,
+			NextGC:      m.NextGC,
+		},
+		GC: gcStats(&m),
+	}
+
+	s.mu.RLock()
+	from, to := s.prev, s.cur
+	if !s.sampled {
+		to = runtimeSample{
+			time:       now,
+			totalAlloc: m.TotalAlloc,
+			mallocs:    m.Mallocs,
+			frees:      m.Frees,
+			numGC:      m.NumGC,
+			pauseTotal: m.PauseTotalNs,
+			goroutines: stats.Goroutines,
+			cgoCalls:   stats.CgoCalls,
+		}
+	}
+	s.mu.RUnlock()
+	stats.Rates = rates(from, to)
+	stats.Rates.SampleIntervalSeconds = s.interval.Seconds()
+	return stats
+}
+
+// takeSample 读取当前累计值
+func takeSample() runtimeSample {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return runtimeSample{
+		time:       time.Now(),
+		totalAlloc: m.TotalAlloc,
+		mallocs:    m.Mallocs,
+		frees:      m.Frees,
+		numGC:      m.NumGC,
+		pauseTotal: m.PauseTotalNs,
+		goroutines: runtime.NumGoroutine(),
+		cgoCalls:   runtime.NumCgoCall(),
+	}
+}
+
+// rates 计算两次采样之间的速率
+func rates(from, to runtimeSample) RateStats {
+	seconds := to.time.Sub(from.time).Seconds()
+	if seconds <= 0 {
+		return RateStats{}
+	}
+	return RateStats{
+		WindowSeconds:            seconds,
+		AllocBytesPerSecond:      float64(to.totalAlloc-from.totalAlloc) / seconds,
+		MallocsPerSecond:         float64(to.mallocs-from.mallocs) / seconds,
+		FreesPerSecond:           float64(to.frees-from.frees) / seconds,
+		GCPerMinute:              float64(to.numGC-from.numGC) / seconds * 60,
+		PauseMsPerMinute:         float64(to.pauseTotal-from.pauseTotal) / float64(time.Millisecond) / seconds * 60,
+		GoroutineGrowthPerSecond: float64(to.goroutines-from.goroutines) / seconds,
+		CgoCallsPerSecond:        float64(to.cgoCalls-from.cgoCalls) / seconds,
+	}
+}
+
+// gcStats 从 MemStats 的GC暂停环形缓冲区提取最近的暂停时间和分位数
+func gcStats(m *runtime.MemStats) GCStats {
+	stats := GCStats{
+		NumGC:        m.NumGC,
+		NumForcedGC:  m.NumForcedGC,
+		PauseTotalMs: float64(m.PauseTotalNs) / float64(time.Millisecond),
+		CPUFraction:  m.GCCPUFraction,
+	}
+	if m.LastGC > 0 {
+		stats.LastGC = time.Unix(0, int64(m.LastGC))
+	}
+
+	count := int(m.NumGC)
+	if count > recentPauseCount {
+		count = recentPauseCount
+	}
+	stats.RecentPausesMs = make([]float64, 0, count)
+	for i := 0; i < count; i++ {
+		// PauseNs[(NumGC+255)%256] 为最近一次GC的暂停时间
+		index := (int(m.NumGC) - 1 - i + len(m.PauseNs)) % len(m.PauseNs)
+		stats.RecentPausesMs = append(stats.RecentPausesMs, float64(m.PauseNs[index])/float64(time.Millisecond))
+	}
+
+	if count > 0 {
+		sorted := append([]float64(nil), stats.RecentPausesMs...)
+		sort.Float64s(sorted)
+		stats.PauseP50Ms = percentile(sorted, 0.50)
+		stats.PauseP99Ms = percentile(sorted, 0.99)
+		stats.PauseMaxMs = sorted[len(sorted)-1]
+	}
+	return stats
+}
+
+// percentile 已排序数据的分位数
+func percentile(sorted []float64, p float64) float64 {
+	index := int(float64(len(sorted)-1) * p)
+	return sorted[index]
+}
@@ -0,0 +1,108 @@
+package ck
+
+import (
+	"context"
+	"fmt"
+
+	"gateway/pkg/config"
+	"gateway/pkg/database"
+	"gateway/pkg/database/clickhouse"
+	"gateway/pkg/logger"
+)
+
+// 日志表名称
+const (
+	AccessLogTable       = "HUB_GW_ACCESS_LOG"
+	BackendTraceLogTable = "HUB_GW_BACKEND_TRACE_LOG"
+)
+
+// LogTablePolicies 返回 ClickHouse 日志表的保留策略
+// 分区键与 scripts/db/clickhouse/clickhouse.sql 的建表语句保持一致，保留天数读取
+// database.clickhouse_retention 配置，配置为0时表上不设置TTL，由日志清理器按分区清理
+// 返回:
+//   - []clickhouse.TablePolicy: 访问日志表和后端追踪日志表的保留策略
+func LogTablePolicies() []clickhouse.TablePolicy {
+	return []clickhouse.TablePolicy{
+		{
+			Table:       AccessLogTable,
+			PartitionBy: "toDate(gatewayStartProcessingTime)",
+			TTLColumn:   "gatewayStartProcessingTime",
+			TTLDays:     config.GetInt("database.clickhouse_retention.access_log_ttl_days", 0),
+		},
+		{
+			Table:       BackendTraceLogTable,
+			PartitionBy: "toDate(requestStartTime)",
+			TTLColumn:   "requestStartTime",
+			TTLDays:     config.GetInt("database.clickhouse_retention.backend_trace_log_ttl_days", 0),
+		},
+	}
+}
+
+// IsLogTable 判断表是否为受管理的 ClickHouse 日志表
+// 参数:
+//   - table: 表名
+//
+// 返回:
+//   - bool: true 表示表在 LogTablePolicies 中声明
+func IsLogTable(table string) bool {
+	for _, policy := range LogTablePolicies() {
+		if policy.Table == table {
+			return true
+		}
+	}
+	return false
+}
+
+// EnsureLogTablePolicies 校验 ClickHouse 日志表的分区和TTL策略
+// 未启用 database.clickhouse_retention.enabled 或未配置 ClickHouse 时直接返回；
+// database.clickhouse_retention.apply 为 true 时自动修正不一致的TTL，否则只输出告警
+// 参数:
+//   - ctx: 上下文对象
+//
+// 返回:
+//   - error: 查询或修改表定义失败时返回错误信息
+func EnsureLogTablePolicies(ctx context.Context) error {
+	if !config.GetBool("database.clickhouse_retention.enabled", false) {
+		return nil
+	}
+	clickhouseDB := GetClickHouseConnection()
+	if clickhouseDB == nil {
+		return nil
+	}
+	return ensureTablePolicies(ctx, clickhouseDB, LogTablePolicies(), config.GetBool("database.clickhouse_retention.apply", false))
+}
+
+// ensureTablePolicies 逐表校验保留策略，单表失败不影响其余表，返回遇到的第一个错误
+func ensureTablePolicies(ctx context.Context, db database.Database, policies []clickhouse.TablePolicy, apply bool) error {
+	var firstErr error
+	for _, policy := range policies {
+		state, err := clickhouse.EnsureTablePolicy(ctx, db, policy, apply)
+		if err != nil {
+			logger.Error("校验 ClickHouse 日志表保留策略失败", "table", policy.Table, "error", err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("校验表 %s 保留策略失败: %w", policy.Table, err)
+			}
+			continue
+		}
+
+		switch {
+		case !state.Exists:
+			logger.Warn("ClickHouse 日志表不存在，跳过保留策略校验", "table", policy.Table)
+			continue
+		case !state.PartitionMatched:
+			logger.Warn("ClickHouse 日志表分区键与声明不一致，分区键无法修改，需要重建表",
+				"table", policy.Table, "expected", policy.PartitionBy, "actual", state.PartitionKey)
+		}
+
+		switch {
+		case state.Altered:
+			logger.Info("已修正 ClickHouse 日志表TTL", "table", policy.Table, "ttl", state.TTL)
+		case !state.TTLMatched:
+			logger.Warn("ClickHouse 日志表TTL与声明不一致，未开启自动修正",
+				"table", policy.Table, "expected", policy.TTLExpression(), "actual", state.TTL)
+		default:
+			logger.Debug("ClickHouse 日志表保留策略一致", "table", policy.Table, "ttl", state.TTL)
+		}
+	}
+	return firstErr
+}
@@ -10,6 +10,7 @@ import (
 	"gateway/pkg/database"
 	"gateway/pkg/database/sqlutils"
 	"gateway/pkg/logger"
+	"gateway/pkg/utils/huberrors"
 	"gateway/pkg/utils/random"
 )
 
@@ -50,9 +51,7 @@ func getStatementExecutionStatus(ctx context.Context, conn database.Database, dr
 	err = conn.QueryOne(ctx, &result, query, args, true)
 	if err != nil {
 		// 判断是否是预期的"记录不存在"或"表不存在"错误
-		isRecordNotFound := strings.Contains(err.Error(), "no rows") ||
-			strings.Contains(err.Error(), "record not found") ||
-			strings.Contains(err.Error(), "not found")
+		isRecordNotFound := huberrors.IsNotFound(err)
 		isTableNotExist := strings.Contains(err.Error(), "no such table") ||
 			strings.Contains(err.Error(), "doesn't exist") ||
 			(strings.Contains(err.Error(), "table") && strings.Contains(err.Error(), "not exist"))
@@ -110,9 +109,7 @@ func recordScriptExecution(ctx context.Context, conn database.Database, driver,
 	err := conn.QueryOne(ctx, &existing, checkQuery, []interface{}{tenantId, scriptName, scriptVersion, driver}, true)
 
 	// 判断是否是真正的错误（排除"记录不存在"的情况）
-	isRecordNotFound := err != nil && (strings.Contains(err.Error(), "no rows") ||
-		strings.Contains(err.Error(), "record not found") ||
-		strings.Contains(err.Error(), "not found"))
+	isRecordNotFound := huberrors.IsNotFound(err)
 	isTableNotExist := err != nil && (strings.Contains(err.Error(), "no such table") ||
 		strings.Contains(err.Error(), "doesn't exist") ||
 		(strings.Contains(err.Error(), "table") && strings.Contains(err.Error(), "not exist")))
@@ -209,9 +206,7 @@ func recordStatementExecution(ctx context.Context, conn database.Database, drive
 	err := conn.QueryOne(ctx, &existing, checkQuery, []interface{}{tenantId, scriptName, statementHash, driver}, true)
 
 	// 判断是否是真正的错误（排除"记录不存在"的情况）
-	isRecordNotFound := err != nil && (strings.Contains(err.Error(), "no rows") ||
-		strings.Contains(err.Error(), "record not found") ||
-		strings.Contains(err.Error(), "not found"))
+	isRecordNotFound := huberrors.IsNotFound(err)
 	isTableNotExist := err != nil && (strings.Contains(err.Error(), "no such table") ||
 		strings.Contains(err.Error(), "doesn't exist") ||
 		strings.Contains(err.Error(), "table") && strings.Contains(err.Error(), "not exist"))
@@ -0,0 +1,332 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gateway/pkg/config"
+	"gateway/pkg/database"
+	"gateway/pkg/database/sqlutils"
+	"gateway/pkg/logger"
+	"gateway/pkg/utils/huberrors"
+)
+
+// downScriptDirectory 回滚脚本子目录名称
+// 回滚脚本与升级脚本同名，放在驱动脚本目录下的 down 子目录中，例如 scripts/db/mysql/down/HUB_GW_ROUTE.sql
+const downScriptDirectory = "down"
+
+// ScriptMigrationStatus 脚本迁移状态
+// 汇总单个脚本文件中各语句在执行历史中的状态
+type ScriptMigrationStatus struct {
+	// ScriptName 脚本文件名
+	ScriptName string `json:"scriptName"`
+
+	// ScriptPath 脚本完整路径
+	ScriptPath string `json:"scriptPath"`
+
+	// ScriptVersion 脚本版本（MD5哈希）
+	ScriptVersion string `json:"scriptVersion"`
+
+	// TotalStatements 脚本中的语句总数
+	TotalStatements int `json:"totalStatements"`
+
+	// SuccessStatements 已成功执行的语句数
+	SuccessStatements int `json:"successStatements"`
+
+	// FailedStatements 最近一次执行失败的语句数
+	FailedStatements int `json:"failedStatements"`
+
+	// PendingStatements 未执行过的语句数
+	PendingStatements int `json:"pendingStatements"`
+
+	// HasDownScript 是否存在回滚脚本
+	HasDownScript bool `json:"hasDownScript"`
+}
+
+// StatementHistoryQuery 语句执行历史查询条件
+type StatementHistoryQuery struct {
+	// ScriptName 脚本文件名，为空时不过滤
+	ScriptName string
+
+	// ExecutionStatus 执行状态（SUCCESS, FAILED, SKIPPED），为空时不过滤
+	ExecutionStatus string
+
+	// DatabaseDriver 目标数据库驱动类型，为空时不过滤
+	DatabaseDriver string
+
+	// Limit 限制返回记录数，0表示不限制
+	Limit int
+}
+
+// resolveScriptDirectory 获取配置的脚本目录，考虑服务启动模式下的路径解析
+func resolveScriptDirectory() string {
+	return config.ResolvePath(config.GetString("database.script_directory", "scripts/db"))
+}
+
+// MigrateUp 执行目标数据库的升级脚本
+// 与启动时的脚本初始化使用相同的增量执行逻辑：已成功的语句跳过，未执行和失败的语句重新执行
+// 参数:
+//   - ctx: 上下文对象，用于控制执行超时和取消
+//   - historyConn: 记录执行历史的数据库连接（主数据库）
+//   - targetConn: 执行脚本的目标数据库连接
+//   - databaseName: 目标数据库连接名称（用于日志和结果）
+//
+// 返回:
+//   - *ScriptExecutionResult: 脚本执行结果
+//   - error: 历史表创建失败时返回错误信息
+func MigrateUp(ctx context.Context, historyConn, targetConn database.Database, databaseName string) (*ScriptExecutionResult, error) {
+	if err := ensureScriptHistoryTable(ctx, historyConn, historyConn.GetDriver()); err != nil {
+		return nil, fmt.Errorf("创建脚本执行历史表失败: %w", err)
+	}
+
+	result := executeScriptForDatabase(ctx, databaseName, historyConn, targetConn, targetConn.GetDriver(), resolveScriptDirectory())
+	return &result, nil
+}
+
+// GetMigrationStatus 获取目标数据库驱动的脚本迁移状态
+// 按执行顺序列出脚本目录中的脚本文件，并与语句执行历史比对统计每个脚本的执行情况
+// 参数:
+//   - ctx: 上下文对象，用于控制查询超时和取消
+//   - historyConn: 记录执行历史的数据库连接（主数据库）
+//   - driver: 目标数据库驱动类型
+//
+// 返回:
+//   - []ScriptMigrationStatus: 脚本迁移状态列表
+//   - error: 读取脚本或查询历史失败时返回错误信息
+func GetMigrationStatus(ctx context.Context, historyConn database.Database, driver string) ([]ScriptMigrationStatus, error) {
+	scriptFiles, err := findScriptFiles(driver, resolveScriptDirectory())
+	if err != nil {
+		return nil, err
+	}
+
+	histories, err := ListStatementExecutionHistory(ctx, historyConn, StatementHistoryQuery{DatabaseDriver: driver})
+	if err != nil {
+		return nil, err
+	}
+	statusByStatement := make(map[string]string, len(histories))
+	for _, history := range histories {
+		statusByStatement[history.ScriptName+"/"+history.StatementHash] = history.ExecutionStatus
+	}
+
+	statuses := make([]ScriptMigrationStatus, 0, len(scriptFiles))
+	for _, scriptFile := range scriptFiles {
+		scriptContent, err := os.ReadFile(scriptFile)
+		if err != nil {
+			return nil, fmt.Errorf("读取脚本文件 %s 失败: %w", filepath.Base(scriptFile), err)
+		}
+
+		status := ScriptMigrationStatus{
+			ScriptName:    filepath.Base(scriptFile),
+			ScriptPath:    scriptFile,
+			ScriptVersion: calculateScriptVersion(scriptContent),
+		}
+		if _, err := os.Stat(downScriptPath(scriptFile)); err == nil {
+			status.HasDownScript = true
+		}
+
+		for _, stmt := range executableStatements(string(scriptContent)) {
+			status.TotalStatements++
+			switch statusByStatement[status.ScriptName+"/"+calculateStatementHash(stmt)] {
+			case "SUCCESS":
+				status.SuccessStatements++
+			case "FAILED":
+				status.FailedStatements++
+			default:
+				status.PendingStatements++
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// MigrateDown 执行脚本的回滚脚本并清除其执行历史
+// 回滚脚本按顺序执行，任一语句失败立即停止且保留执行历史；全部成功后删除该脚本的
+// 脚本级和语句级执行历史，下次升级时脚本会重新执行
+// 参数:
+//   - ctx: 上下文对象，用于控制执行超时和取消
+//   - historyConn: 记录执行历史的数据库连接（主数据库）
+//   - targetConn: 执行回滚脚本的目标数据库连接
+//   - scriptName: 要回滚的脚本文件名
+//
+// 返回:
+//   - int: 成功执行的回滚语句数量
+//   - error: 脚本不存在、缺少回滚脚本或执行失败时返回错误信息
+func MigrateDown(ctx context.Context, historyConn, targetConn database.Database, scriptName string) (int, error) {
+	driver := targetConn.GetDriver()
+	scriptFiles, err := findScriptFiles(driver, resolveScriptDirectory())
+	if err != nil {
+		return 0, err
+	}
+
+	scriptFile := ""
+	for _, file := range scriptFiles {
+		if filepath.Base(file) == scriptName {
+			scriptFile = file
+			break
+		}
+	}
+	if scriptFile == "" {
+		return 0, fmt.Errorf("未找到数据库 %s 的脚本文件: %s", driver, scriptName)
+	}
+
+	downFile := downScriptPath(scriptFile)
+	downContent, err := os.ReadFile(downFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, fmt.Errorf("脚本 %s 没有回滚脚本，需要提供 %s", scriptName, downFile)
+		}
+		return 0, fmt.Errorf("读取回滚脚本失败: %w", err)
+	}
+
+	executed := 0
+	for i, stmt := range executableStatements(string(downContent)) {
+		if _, err := targetConn.Exec(ctx, prepareStatementForExec(driver, stmt), nil, false); err != nil {
+			return executed, fmt.Errorf("回滚脚本第%d条语句执行失败: %s: %w", i+1, truncateString(stmt, 100), err)
+		}
+		executed++
+	}
+
+	tenantId := config.GetString("database.tenant_id", "default")
+	historyDriver := historyConn.GetDriver()
+	for _, tableName := range []string{TableNameStatementHistory(historyDriver), TableNameScriptHistory(historyDriver)} {
+		deleteSQL := fmt.Sprintf("DELETE FROM %s WHERE tenantId = ? AND scriptName = ? AND databaseDriver = ?", tableName)
+		if _, err := historyConn.Exec(ctx, deleteSQL, []interface{}{tenantId, scriptName, driver}, true); err != nil {
+			return executed, fmt.Errorf("清除脚本执行历史失败: %w", err)
+		}
+	}
+
+	logger.Info("脚本回滚完成",
+		"script", scriptName,
+		"driver", driver,
+		"executed", executed)
+
+	return executed, nil
+}
+
+// RerunStatement 重新执行执行历史中的单条语句
+// 按语句记录ID查找语句内容并在目标数据库执行，执行结果写回语句执行历史
+// 参数:
+//   - ctx: 上下文对象，用于控制执行超时和取消
+//   - historyConn: 记录执行历史的数据库连接（主数据库）
+//   - targetConn: 执行语句的目标数据库连接
+//   - statementId: 语句执行记录ID
+//   - force: 是否允许重新执行已成功的语句
+//
+// 返回:
+//   - *StatementExecutionHistory: 重新执行前的语句执行记录
+//   - error: 记录不存在、驱动不匹配或执行失败时返回错误信息
+func RerunStatement(ctx context.Context, historyConn, targetConn database.Database, statementId string, force bool) (*StatementExecutionHistory, error) {
+	tenantId := config.GetString("database.tenant_id", "default")
+	query := fmt.Sprintf("SELECT statementId, tenantId, scriptName, statementHash, statementType, statementContent, databaseDriver, executionStatus, executionTime, executionDuration, errorMessage, createdAt FROM %s WHERE tenantId = ? AND statementId = ?",
+		TableNameStatementHistory(historyConn.GetDriver()))
+
+	var history StatementExecutionHistory
+	if err := historyConn.QueryOne(ctx, &history, query, []interface{}{tenantId, statementId}, true); err != nil {
+		if huberrors.IsNotFound(err) {
+			return nil, fmt.Errorf("语句执行记录不存在: %s", statementId)
+		}
+		return nil, fmt.Errorf("查询语句执行记录失败: %w", err)
+	}
+
+	driver := targetConn.GetDriver()
+	if history.DatabaseDriver != driver {
+		return nil, fmt.Errorf("语句属于 %s 数据库，与目标连接驱动 %s 不一致", history.DatabaseDriver, driver)
+	}
+	if history.ExecutionStatus == "SUCCESS" && !force {
+		return nil, fmt.Errorf("语句已执行成功，如需重新执行请使用强制模式")
+	}
+
+	startTime := time.Now()
+	_, err := targetConn.Exec(ctx, prepareStatementForExec(driver, history.StatementContent), nil, false)
+	duration := time.Since(startTime)
+
+	if err != nil {
+		recordStatementExecution(ctx, historyConn, driver, history.ScriptName, history.StatementHash, history.StatementType,
+			history.StatementContent, "FAILED", duration, err.Error())
+		return &history, fmt.Errorf("语句执行失败: %w", err)
+	}
+
+	recordStatementExecution(ctx, historyConn, driver, history.ScriptName, history.StatementHash, history.StatementType,
+		history.StatementContent, "SUCCESS", duration, "")
+
+	logger.Info("语句重新执行成功",
+		"statement_id", statementId,
+		"script", history.ScriptName,
+		"previous_status", history.ExecutionStatus,
+		"duration", duration)
+
+	return &history, nil
+}
+
+// ListStatementExecutionHistory 查询语句执行历史
+// 参数:
+//   - ctx: 上下文对象，用于控制查询超时和取消
+//   - conn: 记录执行历史的数据库连接（主数据库）
+//   - query: 查询条件
+//
+// 返回:
+//   - []StatementExecutionHistory: 语句执行历史记录列表，按执行时间倒序
+//   - error: 查询失败时返回错误信息
+func ListStatementExecutionHistory(ctx context.Context, conn database.Database, query StatementHistoryQuery) ([]StatementExecutionHistory, error) {
+	driver := conn.GetDriver()
+
+	conditions := []string{"tenantId = ?"}
+	args := []interface{}{config.GetString("database.tenant_id", "default")}
+	if query.ScriptName != "" {
+		conditions = append(conditions, "scriptName = ?")
+		args = append(args, query.ScriptName)
+	}
+	if query.ExecutionStatus != "" {
+		conditions = append(conditions, "executionStatus = ?")
+		args = append(args, query.ExecutionStatus)
+	}
+	if query.DatabaseDriver != "" {
+		conditions = append(conditions, "databaseDriver = ?")
+		args = append(args, query.DatabaseDriver)
+	}
+
+	baseQuery := fmt.Sprintf("SELECT statementId, tenantId, scriptName, statementHash, statementType, statementContent, databaseDriver, executionStatus, executionTime, executionDuration, errorMessage, createdAt FROM %s WHERE %s ORDER BY executionTime DESC",
+		TableNameStatementHistory(driver), strings.Join(conditions, " AND "))
+
+	if query.Limit > 0 {
+		// 使用 sqlutils 构建分页语句，兼容 Oracle（不支持 LIMIT）
+		pagination := sqlutils.NewPaginationInfo(1, query.Limit)
+		paginatedQuery, paginationArgs, err := sqlutils.BuildPaginationQuery(sqlutils.DatabaseType(driver), baseQuery, pagination)
+		if err != nil {
+			return nil, fmt.Errorf("构建分页查询失败: %w", err)
+		}
+		baseQuery = paginatedQuery
+		args = append(args, paginationArgs...)
+	}
+
+	var histories []StatementExecutionHistory
+	if err := conn.Query(ctx, &histories, baseQuery, args, true); err != nil {
+		return nil, fmt.Errorf("查询语句执行历史失败: %w", err)
+	}
+
+	return histories, nil
+}
+
+// executableStatements 分割脚本并过滤空语句和注释，与脚本执行时的过滤规则一致
+func executableStatements(scriptContent string) []string {
+	var statements []string
+	for _, stmt := range splitSQLStatements(scriptContent) {
+		stmt = strings.TrimSpace(stmt)
+		if stmt == "" || strings.HasPrefix(stmt, "--") || strings.HasPrefix(stmt, "/*") {
+			continue
+		}
+		statements = append(statements, stmt)
+	}
+	return statements
+}
+
+// downScriptPath 获取脚本对应的回滚脚本路径
+func downScriptPath(scriptFile string) string {
+	return filepath.Join(filepath.Dir(scriptFile), downScriptDirectory, filepath.Base(scriptFile))
+}
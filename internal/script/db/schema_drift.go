@@ -0,0 +1,37 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"gateway/pkg/database"
+)
+
+// DetectSchemaDrift 检测两个环境数据库结构的差异
+// 读取源库和目标库的表、列和索引并比对，用于在迁移前后确认目标环境与源环境结构一致
+// 参数:
+//   - ctx: 上下文对象，用于控制查询超时和取消
+//   - sourceConn: 源数据库连接（如预发布环境）
+//   - targetConn: 目标数据库连接（如生产环境）
+//   - tables: 只比对指定的表，为空时比对全部表
+//
+// 返回:
+//   - []database.SchemaDifference: 目标库相对源库的差异，没有差异时为空
+//   - error: 两个连接驱动不同或读取结构失败时返回错误信息
+func DetectSchemaDrift(ctx context.Context, sourceConn, targetConn database.Database, tables []string) ([]database.SchemaDifference, error) {
+	if sourceConn.GetDriver() != targetConn.GetDriver() {
+		return nil, fmt.Errorf("源库(%s)与目标库(%s)驱动不同，无法比对数据库结构", sourceConn.GetDriver(), targetConn.GetDriver())
+	}
+
+	options := &database.SchemaOptions{Tables: tables}
+	source, err := sourceConn.Schema(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("读取源库 %s 结构失败: %w", sourceConn.GetName(), err)
+	}
+	target, err := targetConn.Schema(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("读取目标库 %s 结构失败: %w", targetConn.GetName(), err)
+	}
+
+	return database.DiffSchema(source, target), nil
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"gateway/internal/script/ck"
 	mongoscript "gateway/internal/script/mongo"
 	"gateway/pkg/config"
 	"gateway/pkg/database"
@@ -230,6 +231,11 @@ func InitializeDatabaseScripts(ctx context.Context, db database.Database) (*Init
 		// 执行 ClickHouse 脚本，但历史记录保存在主数据库中
 		ckResult := executeScriptForDatabase(ctx, "clickhouse_main", db, clickhouseConn, clickhouseDriver, scriptDir)
 		results = append(results, ckResult)
+
+		// 建表脚本执行后校验日志表的分区和TTL策略，校验失败不影响启动
+		if err := ck.EnsureLogTablePolicies(ctx); err != nil {
+			logger.Warn("ClickHouse 日志表保留策略校验未通过", "error", err)
+		}
 	}
 
 	// 3. 检查是否配置了 MongoDB，如果有则也执行 MongoDB 脚本
@@ -557,12 +563,7 @@ func executeSQLScriptByStatements(ctx context.Context, historyConn database.Data
 			"statement_hash", stmtHash,
 			"statement_preview", truncateString(stmt, 200))
 
-		// Oracle OCI/ODPI 执行时末尾分号会导致 ORA-00933/ORA-02158，防御性清理
-		stmtToExec := strings.TrimSpace(strings.TrimSuffix(stmt, ";"))
-		// Oracle 会将字符串内的 : 解析为绑定变量，需转义为 CHR(58)
-		if driver == dbtypes.DriverOracle || driver == dbtypes.DriverOracle11g {
-			stmtToExec = escapeColonsInStringLiteralsForOracle(stmtToExec)
-		}
+		stmtToExec := prepareStatementForExec(driver, stmt)
 
 		startTime := time.Now()
 		_, err = targetConn.Exec(ctx, stmtToExec, nil, false)
@@ -655,6 +656,17 @@ func escapeColonsInStringLiteralsForOracle(sql string) string {
 	return result.String()
 }
 
+// prepareStatementForExec 整理待执行的SQL语句
+// Oracle OCI/ODPI 执行时末尾分号会导致 ORA-00933/ORA-02158，防御性清理；
+// Oracle 会将字符串内的 : 解析为绑定变量，需转义为 CHR(58)
+func prepareStatementForExec(driver, stmt string) string {
+	stmtToExec := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(stmt), ";"))
+	if driver == dbtypes.DriverOracle || driver == dbtypes.DriverOracle11g {
+		stmtToExec = escapeColonsInStringLiteralsForOracle(stmtToExec)
+	}
+	return stmtToExec
+}
+
 // splitSQLStatements 分割SQL脚本为独立的语句
 // 按分号分割SQL语句，处理多行语句和注释，确保正确的执行顺序
 func splitSQLStatements(scriptContent string) []string {
@@ -0,0 +1,274 @@
+// Package bundle 命名空间导入导出
+// 将命名空间下的服务、分组和配置导出为带版本号的 JSON/YAML 包，
+// 导入时先与目标命名空间比对生成变更计划（支持只预览不执行），用于将预发布环境的服务拓扑可靠地提升到生产环境
+package bundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gateway/internal/servicecenter/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version 当前导出包格式版本，格式不兼容的变更需要递增
+const Version = 1
+
+// 导出包格式
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// defaultGroupName 未指定分组时使用的分组名称
+const defaultGroupName = "DEFAULT_GROUP"
+
+// Bundle 命名空间导出包
+// 只包含服务定义和配置，不包含运行期注册的服务节点、配置灰度状态等与环境相关的数据
+type Bundle struct {
+	BundleVersion int            `json:"bundleVersion" yaml:"bundleVersion"`           // 导出包格式版本
+	ExportTime    time.Time      `json:"exportTime" yaml:"exportTime"`                 // 导出时间
+	ExportBy      string         `json:"exportBy,omitempty" yaml:"exportBy,omitempty"` // 导出人
+	Namespace     NamespaceSpec  `json:"namespace" yaml:"namespace"`                   // 来源命名空间
+	Groups        []string       `json:"groups" yaml:"groups"`                         // 服务和配置使用的分组，按名称排序
+	Services      []*ServiceSpec `json:"services" yaml:"services"`                     // 服务定义，按分组和服务名称排序
+	Configs       []*ConfigSpec  `json:"configs" yaml:"configs"`                       // 配置，按分组和配置ID排序
+}
+
+// NamespaceSpec 来源命名空间信息，导入时仅用于展示，不会修改目标命名空间
+type NamespaceSpec struct {
+	NamespaceId          string `json:"namespaceId" yaml:"namespaceId"`
+	NamespaceName        string `json:"namespaceName" yaml:"namespaceName"`
+	NamespaceDescription string `json:"namespaceDescription,omitempty" yaml:"namespaceDescription,omitempty"`
+	Environment          string `json:"environment,omitempty" yaml:"environment,omitempty"`
+}
+
+// ServiceSpec 服务定义
+type ServiceSpec struct {
+	GroupName             string  `json:"groupName" yaml:"groupName"`
+	ServiceName           string  `json:"serviceName" yaml:"serviceName"`
+	ServiceType           string  `json:"serviceType,omitempty" yaml:"serviceType,omitempty"`
+	ServiceVersion        string  `json:"serviceVersion,omitempty" yaml:"serviceVersion,omitempty"`
+	ServiceDescription    string  `json:"serviceDescription,omitempty" yaml:"serviceDescription,omitempty"`
+	ExternalServiceConfig string  `json:"externalServiceConfig,omitempty" yaml:"externalServiceConfig,omitempty"`
+	MetadataJson          string  `json:"metadataJson,omitempty" yaml:"metadataJson,omitempty"`
+	TagsJson              string  `json:"tagsJson,omitempty" yaml:"tagsJson,omitempty"`
+	ProtectThreshold      float64 `json:"protectThreshold,omitempty" yaml:"protectThreshold,omitempty"`
+	SelectorJson          string  `json:"selectorJson,omitempty" yaml:"selectorJson,omitempty"`
+	NoteText              string  `json:"noteText,omitempty" yaml:"noteText,omitempty"`
+}
+
+// ConfigSpec 配置
+type ConfigSpec struct {
+	GroupName         string `json:"groupName" yaml:"groupName"`
+	ConfigDataId      string `json:"configDataId" yaml:"configDataId"`
+	ContentType       string `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+	ConfigContent     string `json:"configContent" yaml:"configContent"`
+	ConfigDescription string `json:"configDescription,omitempty" yaml:"configDescription,omitempty"`
+	Encrypted         string `json:"encrypted,omitempty" yaml:"encrypted,omitempty"`
+}
+
+// Build 由命名空间下的服务和配置构建导出包
+// 参数:
+//   - namespace: 来源命名空间
+//   - services: 命名空间下的服务
+//   - configs: 命名空间下的配置
+//
+// 返回值:
+//   - *Bundle: 导出包，服务、配置和分组均已排序，相同数据多次导出结果一致（导出时间除外）
+func Build(namespace *types.Namespace, services []*types.Service, configs []*types.ConfigData) *Bundle {
+	b := &Bundle{
+		BundleVersion: Version,
+		ExportTime:    time.Now(),
+		Namespace: NamespaceSpec{
+			NamespaceId:          namespace.NamespaceId,
+			NamespaceName:        namespace.NamespaceName,
+			NamespaceDescription: namespace.NamespaceDesc,
+			Environment:          namespace.Environment,
+		},
+		Services: make([]*ServiceSpec, 0, len(services)),
+		Configs:  make([]*ConfigSpec, 0, len(configs)),
+	}
+	for _, service := range services {
+		b.Services = append(b.Services, ServiceSpecOf(service))
+	}
+	for _, config := range configs {
+		b.Configs = append(b.Configs, ConfigSpecOf(config))
+	}
+	b.normalize()
+	return b
+}
+
+// ServiceSpecOf 服务转换为服务定义
+func ServiceSpecOf(service *types.Service) *ServiceSpec {
+	return &ServiceSpec{
+		GroupName:             service.GroupName,
+		ServiceName:           service.ServiceName,
+		ServiceType:           service.ServiceType,
+		ServiceVersion:        service.ServiceVersion,
+		ServiceDescription:    service.ServiceDescription,
+		ExternalServiceConfig: service.ExternalServiceConfig,
+		MetadataJson:          service.MetadataJson,
+		TagsJson:              service.TagsJson,
+		ProtectThreshold:      service.ProtectThreshold,
+		SelectorJson:          service.SelectorJson,
+		NoteText:              service.NoteText,
+	}
+}
+
+// ConfigSpecOf 配置转换为导出的配置（不包含灰度状态等扩展属性）
+func ConfigSpecOf(config *types.ConfigData) *ConfigSpec {
+	return &ConfigSpec{
+		GroupName:         config.GroupName,
+		ConfigDataId:      config.ConfigDataId,
+		ContentType:       config.ContentType,
+		ConfigContent:     config.ConfigContent,
+		ConfigDescription: config.ConfigDescription,
+		Encrypted:         config.Encrypted,
+	}
+}
+
+// ApplyTo 将服务定义写入服务实体（主键和通用字段保持不变）
+func (s *ServiceSpec) ApplyTo(service *types.Service) {
+	service.GroupName = s.GroupName
+	service.ServiceName = s.ServiceName
+	service.ServiceType = s.ServiceType
+	service.ServiceVersion = s.ServiceVersion
+	service.ServiceDescription = s.ServiceDescription
+	service.ExternalServiceConfig = s.ExternalServiceConfig
+	service.MetadataJson = s.MetadataJson
+	service.TagsJson = s.TagsJson
+	service.ProtectThreshold = s.ProtectThreshold
+	service.SelectorJson = s.SelectorJson
+	service.NoteText = s.NoteText
+}
+
+// ApplyTo 将导出的配置写入配置实体（主键、版本号和通用字段保持不变）
+func (c *ConfigSpec) ApplyTo(config *types.ConfigData) {
+	config.GroupName = c.GroupName
+	config.ConfigDataId = c.ConfigDataId
+	config.ContentType = c.ContentType
+	config.ConfigContent = c.ConfigContent
+	config.ConfigDescription = c.ConfigDescription
+	config.Encrypted = c.Encrypted
+}
+
+// Marshal 按格式序列化导出包
+func Marshal(b *Bundle, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case "", FormatJSON:
+		return json.MarshalIndent(b, "", "  ")
+	case FormatYAML, "yml":
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(b); err != nil {
+			return nil, fmt.Errorf("序列化导出包失败: %w", err)
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, fmt.Errorf("序列化导出包失败: %w", err)
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("不支持的导出格式: %s", format)
+	}
+}
+
+// Parse 解析导出包，以 { 开头的内容按 JSON 解析，否则按 YAML 解析
+// 返回值:
+//   - *Bundle: 校验通过并规范化（补全默认分组、排序）的导出包
+//   - error: 格式错误、版本不兼容或存在重复/缺失的服务和配置标识时返回错误
+func Parse(data []byte) (*Bundle, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("导入文件内容为空")
+	}
+
+	var b Bundle
+	if data[0] == '{' {
+		if err := json.Unmarshal(data, &b); err != nil {
+			return nil, fmt.Errorf("解析 JSON 导出包失败: %w", err)
+		}
+	} else if err := yaml.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("解析 YAML 导出包失败: %w", err)
+	}
+
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+	b.normalize()
+	return &b, nil
+}
+
+// validate 校验导出包版本和服务、配置标识
+func (b *Bundle) validate() error {
+	if b.BundleVersion <= 0 {
+		return fmt.Errorf("导出包缺少 bundleVersion")
+	}
+	if b.BundleVersion > Version {
+		return fmt.Errorf("导出包版本 %d 高于当前支持的版本 %d，请升级后再导入", b.BundleVersion, Version)
+	}
+
+	seen := make(map[string]bool)
+	for i, service := range b.Services {
+		if service == nil || strings.TrimSpace(service.ServiceName) == "" {
+			return fmt.Errorf("第 %d 个服务缺少 serviceName", i+1)
+		}
+		if service.GroupName == "" {
+			service.GroupName = defaultGroupName
+		}
+		key := KindService + "/" + service.GroupName + "/" + service.ServiceName
+		if seen[key] {
+			return fmt.Errorf("服务重复: %s/%s", service.GroupName, service.ServiceName)
+		}
+		seen[key] = true
+	}
+	for i, config := range b.Configs {
+		if config == nil || strings.TrimSpace(config.ConfigDataId) == "" {
+			return fmt.Errorf("第 %d 个配置缺少 configDataId", i+1)
+		}
+		if config.GroupName == "" {
+			config.GroupName = defaultGroupName
+		}
+		key := KindConfig + "/" + config.GroupName + "/" + config.ConfigDataId
+		if seen[key] {
+			return fmt.Errorf("配置重复: %s/%s", config.GroupName, config.ConfigDataId)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// normalize 排序服务和配置，并由服务和配置汇总分组
+func (b *Bundle) normalize() {
+	sort.Slice(b.Services, func(i, j int) bool {
+		if b.Services[i].GroupName != b.Services[j].GroupName {
+			return b.Services[i].GroupName < b.Services[j].GroupName
+		}
+		return b.Services[i].ServiceName < b.Services[j].ServiceName
+	})
+	sort.Slice(b.Configs, func(i, j int) bool {
+		if b.Configs[i].GroupName != b.Configs[j].GroupName {
+			return b.Configs[i].GroupName < b.Configs[j].GroupName
+		}
+		return b.Configs[i].ConfigDataId < b.Configs[j].ConfigDataId
+	})
+
+	groups := make(map[string]bool)
+	for _, service := range b.Services {
+		groups[service.GroupName] = true
+	}
+	for _, config := range b.Configs {
+		groups[config.GroupName] = true
+	}
+	b.Groups = make([]string, 0, len(groups))
+	for group := range groups {
+		b.Groups = append(b.Groups, group)
+	}
+	sort.Strings(b.Groups)
+}
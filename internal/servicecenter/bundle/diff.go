@@ -0,0 +1,182 @@
+package bundle
+
+import (
+	"crypto/md5"
+	"fmt"
+
+	"gateway/internal/servicecenter/types"
+)
+
+// 变更对象类型
+const (
+	KindService = "SERVICE" // 服务
+	KindConfig  = "CONFIG"  // 配置
+)
+
+// 变更动作
+const (
+	ActionCreate    = "CREATE"    // 目标命名空间不存在，导入时新增
+	ActionUpdate    = "UPDATE"    // 目标命名空间已存在且内容不同，导入时覆盖
+	ActionUnchanged = "UNCHANGED" // 目标命名空间已存在且内容相同
+	ActionRetain    = "RETAIN"    // 只存在于目标命名空间，导入时保留不删除
+)
+
+// Change 一项变更
+type Change struct {
+	Kind      string   `json:"kind"`             // 对象类型(SERVICE/CONFIG)
+	GroupName string   `json:"groupName"`        // 分组名称
+	Name      string   `json:"name"`             // 服务名称或配置ID
+	Action    string   `json:"action"`           // 变更动作(CREATE/UPDATE/UNCHANGED/RETAIN)
+	Fields    []string `json:"fields,omitempty"` // UPDATE 时内容不同的字段
+	Applied   bool     `json:"applied"`          // 是否已执行（预览时均为 false）
+	Error     string   `json:"error,omitempty"`  // 执行失败原因
+}
+
+// Plan 导入变更计划
+type Plan struct {
+	SourceNamespaceId string    `json:"sourceNamespaceId"` // 导出包来源命名空间ID
+	TargetNamespaceId string    `json:"targetNamespaceId"` // 导入目标命名空间ID
+	BundleVersion     int       `json:"bundleVersion"`     // 导出包格式版本
+	DryRun            bool      `json:"dryRun"`            // 是否只预览不执行
+	Changes           []*Change `json:"changes"`           // 变更列表，服务在前、配置在后，各自按分组和名称排序
+	Summary           Summary   `json:"summary"`           // 变更统计
+}
+
+// Summary 变更统计
+type Summary struct {
+	Create    int `json:"create"`    // 新增数
+	Update    int `json:"update"`    // 覆盖数
+	Unchanged int `json:"unchanged"` // 无变化数
+	Retain    int `json:"retain"`    // 只存在于目标命名空间的数量
+	Failed    int `json:"failed"`    // 执行失败数
+}
+
+// Diff 比对导出包与目标命名空间现有的服务和配置，生成导入变更计划
+// 参数:
+//   - b: 导出包
+//   - targetNamespaceId: 导入目标命名空间ID
+//   - services: 目标命名空间现有的服务
+//   - configs: 目标命名空间现有的配置
+//
+// 返回值:
+//   - *Plan: 变更计划，DryRun 为 true
+func Diff(b *Bundle, targetNamespaceId string, services []*types.Service, configs []*types.ConfigData) *Plan {
+	plan := &Plan{
+		SourceNamespaceId: b.Namespace.NamespaceId,
+		TargetNamespaceId: targetNamespaceId,
+		BundleVersion:     b.BundleVersion,
+		DryRun:            true,
+		Changes:           make([]*Change, 0, len(b.Services)+len(b.Configs)),
+	}
+
+	existingServices := make(map[string]*types.Service, len(services))
+	for _, service := range services {
+		existingServices[service.GroupName+"/"+service.ServiceName] = service
+	}
+	var retained []*Change
+	for _, spec := range b.Services {
+		key := spec.GroupName + "/" + spec.ServiceName
+		change := &Change{Kind: KindService, GroupName: spec.GroupName, Name: spec.ServiceName, Action: ActionCreate}
+		if existing, ok := existingServices[key]; ok {
+			change.Fields = serviceFieldDiff(spec, ServiceSpecOf(existing))
+			change.Action = updateOrUnchanged(change.Fields)
+			delete(existingServices, key)
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+	for _, service := range services {
+		if _, ok := existingServices[service.GroupName+"/"+service.ServiceName]; ok {
+			retained = append(retained, &Change{Kind: KindService, GroupName: service.GroupName, Name: service.ServiceName, Action: ActionRetain})
+		}
+	}
+
+	existingConfigs := make(map[string]*types.ConfigData, len(configs))
+	for _, config := range configs {
+		existingConfigs[config.GroupName+"/"+config.ConfigDataId] = config
+	}
+	for _, spec := range b.Configs {
+		key := spec.GroupName + "/" + spec.ConfigDataId
+		change := &Change{Kind: KindConfig, GroupName: spec.GroupName, Name: spec.ConfigDataId, Action: ActionCreate}
+		if existing, ok := existingConfigs[key]; ok {
+			change.Fields = configFieldDiff(spec, ConfigSpecOf(existing))
+			change.Action = updateOrUnchanged(change.Fields)
+			delete(existingConfigs, key)
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+	for _, config := range configs {
+		if _, ok := existingConfigs[config.GroupName+"/"+config.ConfigDataId]; ok {
+			retained = append(retained, &Change{Kind: KindConfig, GroupName: config.GroupName, Name: config.ConfigDataId, Action: ActionRetain})
+		}
+	}
+
+	plan.Changes = append(plan.Changes, retained...)
+	plan.Summarize()
+	return plan
+}
+
+// Summarize 重新统计变更数量（执行导入后调用以统计失败数）
+func (p *Plan) Summarize() {
+	p.Summary = Summary{}
+	for _, change := range p.Changes {
+		switch change.Action {
+		case ActionCreate:
+			p.Summary.Create++
+		case ActionUpdate:
+			p.Summary.Update++
+		case ActionUnchanged:
+			p.Summary.Unchanged++
+		case ActionRetain:
+			p.Summary.Retain++
+		}
+		if change.Error != "" {
+			p.Summary.Failed++
+		}
+	}
+}
+
+// updateOrUnchanged 有字段不同时为 UPDATE，否则为 UNCHANGED
+func updateOrUnchanged(fields []string) string {
+	if len(fields) > 0 {
+		return ActionUpdate
+	}
+	return ActionUnchanged
+}
+
+// serviceFieldDiff 比对服务定义，返回内容不同的字段
+func serviceFieldDiff(want, have *ServiceSpec) []string {
+	var fields []string
+	compare := func(name, a, b string) {
+		if a != b {
+			fields = append(fields, name)
+		}
+	}
+	compare("serviceType", want.ServiceType, have.ServiceType)
+	compare("serviceVersion", want.ServiceVersion, have.ServiceVersion)
+	compare("serviceDescription", want.ServiceDescription, have.ServiceDescription)
+	compare("externalServiceConfig", want.ExternalServiceConfig, have.ExternalServiceConfig)
+	compare("metadataJson", want.MetadataJson, have.MetadataJson)
+	compare("tagsJson", want.TagsJson, have.TagsJson)
+	compare("protectThreshold", fmt.Sprintf("%.2f", want.ProtectThreshold), fmt.Sprintf("%.2f", have.ProtectThreshold))
+	compare("selectorJson", want.SelectorJson, have.SelectorJson)
+	compare("noteText", want.NoteText, have.NoteText)
+	return fields
+}
+
+// configFieldDiff 比对配置，返回内容不同的字段（配置内容按MD5比较）
+func configFieldDiff(want, have *ConfigSpec) []string {
+	var fields []string
+	if md5.Sum([]byte(want.ConfigContent)) != md5.Sum([]byte(have.ConfigContent)) {
+		fields = append(fields, "configContent")
+	}
+	if want.ContentType != have.ContentType {
+		fields = append(fields, "contentType")
+	}
+	if want.ConfigDescription != have.ConfigDescription {
+		fields = append(fields, "configDescription")
+	}
+	if want.Encrypted != have.Encrypted {
+		fields = append(fields, "encrypted")
+	}
+	return fields
+}
@@ -380,6 +380,21 @@ type IServiceCache interface {
 	//	cache.DeleteNamespace(ctx, "default", "public")
 	DeleteNamespace(ctx context.Context, tenantId, namespaceId string)
 
+	// GetAllNamespaces 遍历所有命名空间
+	//
+	// 注意事项：
+	//   - 回调函数中应避免长时间阻塞操作（如网络 I/O）
+	//
+	// 参数：
+	//   - fn: 回调函数，参数为命名空间信息
+	//
+	// 示例：
+	//
+	//	cache.GetAllNamespaces(func(namespace *types.Namespace) {
+	//	    fmt.Printf("命名空间: %s\n", namespace.NamespaceId)
+	//	})
+	GetAllNamespaces(fn func(*types.Namespace))
+
 	// ==================== 其他操作 ====================
 
 	// GetServiceWithNodes 获取服务及其节点（原子操作）
@@ -58,6 +58,14 @@ func GetLocalCache() *ServiceCache {
 	return localCache
 }
 
+// GetAllNamespaces 遍历所有命名空间（用于快照等操作）
+func (c *ServiceCache) GetAllNamespaces(fn func(*types.Namespace)) {
+	c.namespaces.Range(func(key, value interface{}) bool {
+		fn(value.(*types.Namespace))
+		return true
+	})
+}
+
 // GetService 获取服务信息（包含节点）
 func (c *ServiceCache) GetService(ctx context.Context, tenantId, namespaceId, groupName, serviceName string) (*types.Service, bool) {
 	key := c.serviceKey(tenantId, namespaceId, groupName, serviceName)
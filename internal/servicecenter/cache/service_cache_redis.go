@@ -581,6 +581,33 @@ func (r *RedisServiceCache) GetAllServices(fn func(*types.Service)) {
 	}
 }
 
+// GetAllNamespaces 遍历所有命名空间（用于快照等操作）
+func (r *RedisServiceCache) GetAllNamespaces(fn func(*types.Namespace)) {
+	ctx := context.Background()
+
+	// 从索引集合获取所有命名空间键
+	namespaceKeys, err := r.redisCache.SMembers(ctx, r.namespaceSetKey)
+	if err != nil {
+		logger.Warn("获取命名空间键集合失败", "error", err)
+		return
+	}
+
+	for _, key := range namespaceKeys {
+		data, err := r.redisCache.Get(ctx, r.namespacePrefix+key)
+		if err != nil || data == nil {
+			continue
+		}
+
+		var namespace types.Namespace
+		if err := r.unmarshalData(data, &namespace); err != nil {
+			logger.Warn("反序列化命名空间数据失败", "error", err, "key", key)
+			continue
+		}
+
+		fn(&namespace)
+	}
+}
+
 // ========== 辅助方法 ==========
 
 // serviceKey 生成服务缓存键
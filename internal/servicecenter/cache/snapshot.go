@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"context"
+
+	"gateway/internal/servicecenter/types"
+)
+
+// RegistrySnapshotName 注册中心缓存的本地快照名称
+const RegistrySnapshotName = "registry"
+
+// RegistrySnapshot 注册中心缓存快照，包含命名空间、服务及其节点
+type RegistrySnapshot struct {
+	Namespaces []*types.Namespace `json:"namespaces"`
+	Services   []*types.Service   `json:"services"` // 服务的 Nodes 中包含快照时的节点列表
+}
+
+// CollectRegistrySnapshot 从服务缓存收集快照数据
+//
+// 参数：
+//   - c: 服务缓存
+//
+// 返回：
+//   - *RegistrySnapshot: 缓存中全部命名空间、服务和节点
+func CollectRegistrySnapshot(c IServiceCache) *RegistrySnapshot {
+	snapshot := &RegistrySnapshot{
+		Namespaces: make([]*types.Namespace, 0),
+		Services:   make([]*types.Service, 0),
+	}
+	c.GetAllNamespaces(func(namespace *types.Namespace) {
+		snapshot.Namespaces = append(snapshot.Namespaces, namespace)
+	})
+	c.GetAllServices(func(service *types.Service) {
+		// 复制服务和节点列表，避免序列化期间与心跳更新并发读写
+		copied := *service
+		copied.Nodes = append([]*types.ServiceNode(nil), service.Nodes...)
+		snapshot.Services = append(snapshot.Services, &copied)
+	})
+	return snapshot
+}
+
+// RestoreRegistrySnapshot 将快照数据恢复到服务缓存
+//
+// 注意：
+//   - 缓存中已存在的命名空间和服务以缓存为准，不被快照覆盖
+//   - 恢复的临时节点如果不再发送心跳，会被健康检查按超时驱逐
+//
+// 参数：
+//   - ctx: 上下文
+//   - c: 服务缓存
+//   - snapshot: 快照数据
+//
+// 返回：
+//   - int: 恢复的命名空间数
+//   - int: 恢复的服务数
+func RestoreRegistrySnapshot(ctx context.Context, c IServiceCache, snapshot *RegistrySnapshot) (int, int) {
+	if snapshot == nil {
+		return 0, 0
+	}
+
+	namespaceCount := 0
+	for _, namespace := range snapshot.Namespaces {
+		if namespace == nil {
+			continue
+		}
+		if _, ok := c.GetNamespace(ctx, namespace.TenantId, namespace.NamespaceId); ok {
+			continue
+		}
+		c.SetNamespace(ctx, namespace)
+		namespaceCount++
+	}
+
+	serviceCount := 0
+	for _, service := range snapshot.Services {
+		if service == nil {
+			continue
+		}
+		if _, ok := c.GetService(ctx, service.TenantId, service.NamespaceId, service.GroupName, service.ServiceName); ok {
+			continue
+		}
+		nodes := service.Nodes
+		service.Nodes = nil
+		c.SetService(ctx, service)
+		// 逐个添加节点，同时建立节点索引
+		for _, node := range nodes {
+			c.AddNode(ctx, node)
+		}
+		serviceCount++
+	}
+	return namespaceCount, serviceCount
+}
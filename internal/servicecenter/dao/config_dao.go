@@ -118,6 +118,27 @@ func (d *ConfigDAO) SaveConfig(ctx context.Context, config *types.ConfigData) er
 	return nil
 }
 
+// ReplaceConfig 更新配置的全部字段（包括零值字段），版本号递增并重新计算MD5
+// 用于导入时以导出内容完整覆盖已存在的配置，config 需包含数据库中的当前版本号
+func (d *ConfigDAO) ReplaceConfig(ctx context.Context, config *types.ConfigData) error {
+	hash := md5.Sum([]byte(config.ConfigContent))
+	config.Md5Value = fmt.Sprintf("%x", hash)
+	config.Version++
+	config.CurrentVersion++
+	config.EditTime = time.Now()
+	if config.EditWho == "" {
+		config.EditWho = "system"
+	}
+	config.OprSeqFlag = random.Generate32BitRandomString()
+
+	where := "tenantId = ? AND namespaceId = ? AND groupName = ? AND configDataId = ?"
+	args := []interface{}{config.TenantId, config.NamespaceId, config.GroupName, config.ConfigDataId}
+	if _, err := d.db.Update(ctx, "HUB_SERVICE_CONFIG_DATA", config, where, args, true, false); err != nil {
+		return fmt.Errorf("更新配置失败: %w", err)
+	}
+	return nil
+}
+
 // DeleteConfig 删除配置（物理删除）
 func (d *ConfigDAO) DeleteConfig(ctx context.Context, tenantId, namespaceId, groupName, configDataId string) error {
 	query := "DELETE FROM HUB_SERVICE_CONFIG_DATA WHERE tenantId = ? AND namespaceId = ? AND groupName = ? AND configDataId = ?"
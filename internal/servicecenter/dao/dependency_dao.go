@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gateway/internal/servicecenter/types"
+	"gateway/pkg/database"
+	"gateway/pkg/utils/random"
+)
+
+// DependencyDAO 服务依赖关系数据访问对象
+// 管理 HUB_SERVICE_DEPENDENCY 表（订阅方 -> 被订阅服务）
+type DependencyDAO struct {
+	db database.Database
+}
+
+// NewDependencyDAO 创建服务依赖关系DAO
+func NewDependencyDAO(db database.Database) *DependencyDAO {
+	return &DependencyDAO{db: db}
+}
+
+// SaveDependency 保存依赖关系：已存在时刷新最近一次订阅时间，不存在时新增
+func (d *DependencyDAO) SaveDependency(ctx context.Context, dependency *types.ServiceDependency) error {
+	now := time.Now()
+	if dependency.LastSeenTime.IsZero() {
+		dependency.LastSeenTime = now
+	}
+
+	query := "UPDATE HUB_SERVICE_DEPENDENCY SET lastSeenTime = ?, editTime = ?, activeFlag = 'Y' WHERE tenantId = ? AND dependencyId = ?"
+	args := []interface{}{dependency.LastSeenTime, now, dependency.TenantId, dependency.DependencyId}
+	affected, err := d.db.Exec(ctx, query, args, true)
+	if err != nil {
+		return fmt.Errorf("更新服务依赖关系失败: %w", err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	if dependency.FirstSeenTime.IsZero() {
+		dependency.FirstSeenTime = dependency.LastSeenTime
+	}
+	dependency.AddTime = now
+	dependency.EditTime = now
+	if dependency.AddWho == "" {
+		dependency.AddWho = "system"
+	}
+	if dependency.EditWho == "" {
+		dependency.EditWho = dependency.AddWho
+	}
+	dependency.OprSeqFlag = random.Generate32BitRandomString()
+	dependency.CurrentVersion = 1
+	dependency.ActiveFlag = "Y"
+
+	if _, err := d.db.Insert(ctx, "HUB_SERVICE_DEPENDENCY", dependency, true); err != nil {
+		return fmt.Errorf("创建服务依赖关系失败: %w", err)
+	}
+	return nil
+}
+
+// ListDependencies 列出与命名空间相关的依赖关系（被订阅服务或订阅方属于该命名空间）
+func (d *DependencyDAO) ListDependencies(ctx context.Context, tenantId, namespaceId string) ([]*types.ServiceDependency, error) {
+	query := "SELECT * FROM HUB_SERVICE_DEPENDENCY WHERE tenantId = ? AND (namespaceId = ? OR consumerNamespaceId = ?) AND activeFlag = 'Y' ORDER BY lastSeenTime DESC"
+	args := []interface{}{tenantId, namespaceId, namespaceId}
+
+	var dependencies []*types.ServiceDependency
+	if err := d.db.Query(ctx, &dependencies, query, args, true); err != nil {
+		return nil, fmt.Errorf("查询服务依赖关系失败: %w", err)
+	}
+	return dependencies, nil
+}
@@ -69,6 +69,18 @@ func (d *ServiceDAO) GetService(ctx context.Context, tenantId, namespaceId, grou
 	return &service, nil
 }
 
+// ListServices 列出命名空间下的有效服务，按分组和服务名称排序
+func (d *ServiceDAO) ListServices(ctx context.Context, tenantId, namespaceId string) ([]*types.Service, error) {
+	query := "SELECT * FROM HUB_SERVICE WHERE tenantId = ? AND namespaceId = ? AND activeFlag = 'Y' ORDER BY groupName, serviceName"
+	args := []interface{}{tenantId, namespaceId}
+
+	var services []*types.Service
+	if err := d.db.Query(ctx, &services, query, args, true); err != nil {
+		return nil, fmt.Errorf("查询服务列表失败: %w", err)
+	}
+	return services, nil
+}
+
 // UpdateService 更新服务
 func (d *ServiceDAO) UpdateService(ctx context.Context, service *types.Service) error {
 	if service.EditTime.IsZero() {
@@ -87,6 +99,24 @@ func (d *ServiceDAO) UpdateService(ctx context.Context, service *types.Service)
 	return nil
 }
 
+// ReplaceService 更新服务的全部字段（包括零值字段），用于导入时以导出内容完整覆盖服务定义
+func (d *ServiceDAO) ReplaceService(ctx context.Context, service *types.Service) error {
+	if service.EditTime.IsZero() {
+		service.EditTime = time.Now()
+	}
+	if service.EditWho == "" {
+		service.EditWho = "system"
+	}
+	service.OprSeqFlag = random.Generate32BitRandomString()
+	where := "tenantId = ? AND namespaceId = ? AND groupName = ? AND serviceName = ?"
+	args := []interface{}{service.TenantId, service.NamespaceId, service.GroupName, service.ServiceName}
+	_, err := d.db.Update(ctx, "HUB_SERVICE", service, where, args, true, false)
+	if err != nil {
+		return fmt.Errorf("更新服务失败: %w", err)
+	}
+	return nil
+}
+
 // DeleteService 删除服务（物理删除）
 func (d *ServiceDAO) DeleteService(ctx context.Context, tenantId, namespaceId, groupName, serviceName string) error {
 	query := "DELETE FROM HUB_SERVICE WHERE tenantId = ? AND namespaceId = ? AND groupName = ? AND serviceName = ?"
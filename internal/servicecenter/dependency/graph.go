@@ -0,0 +1,255 @@
+package dependency
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"gateway/internal/servicecenter/cache"
+	"gateway/internal/servicecenter/types"
+)
+
+// Graph 命名空间的服务依赖拓扑
+type Graph struct {
+	TenantId    string       `json:"tenantId"`           // 租户ID
+	NamespaceId string       `json:"namespaceId"`        // 命名空间ID
+	Nodes       []*GraphNode `json:"nodes"`              // 拓扑节点（服务、客户端和命名空间/分组订阅），按ID排序
+	Edges       []*GraphEdge `json:"edges"`              // 依赖关系：source（订阅方）依赖 target（被订阅方），按ID排序
+	Impacted    []*Impact    `json:"impacted,omitempty"` // 指定服务时，直接或间接依赖该服务的订阅方（影响范围）
+	StatTime    time.Time    `json:"statTime"`           // 统计时间
+}
+
+// GraphNode 拓扑节点
+type GraphNode struct {
+	Id           string `json:"id"`           // 节点ID：服务为 namespaceId/groupName/serviceName，客户端为 client:客户端标识
+	Type         string `json:"type"`         // 节点类型(SERVICE/CLIENT)
+	NamespaceId  string `json:"namespaceId"`  // 命名空间ID
+	GroupName    string `json:"groupName"`    // 分组名称，订阅整个命名空间时为空
+	ServiceName  string `json:"serviceName"`  // 服务名称，订阅整个命名空间/分组时为 *，客户端为客户端标识
+	Registered   bool   `json:"registered"`   // 服务是否在注册中心中存在
+	NodeCount    int    `json:"nodeCount"`    // 服务节点总数
+	HealthyNodes int    `json:"healthyNodes"` // 健康节点数
+}
+
+// GraphEdge 依赖关系
+type GraphEdge struct {
+	Id                string    `json:"id"`                // 依赖关系ID
+	Source            string    `json:"source"`            // 订阅方节点ID
+	Target            string    `json:"target"`            // 被订阅方节点ID
+	SubscribeType     string    `json:"subscribeType"`     // 订阅方式(SERVICE/NAMESPACE)
+	ActiveSubscribers int       `json:"activeSubscribers"` // 当前仍在订阅的订阅者数，0表示仅有历史记录
+	FirstSeenTime     time.Time `json:"firstSeenTime"`     // 首次订阅时间
+	LastSeenTime      time.Time `json:"lastSeenTime"`      // 最近一次订阅时间
+}
+
+// Impact 影响范围中的订阅方
+type Impact struct {
+	NodeId string `json:"nodeId"` // 订阅方节点ID
+	Depth  int    `json:"depth"`  // 依赖深度，1表示直接依赖
+	Active bool   `json:"active"` // 依赖路径上是否存在仍在订阅的订阅者
+}
+
+// CollectGraph 汇总命名空间的服务依赖拓扑
+// 依赖关系来自持久化记录和本次运行期间的订阅，服务节点状态来自注册中心缓存
+// 参数:
+//   - ctx: 上下文
+//   - tenantId: 租户ID
+//   - namespaceId: 命名空间ID
+//   - groupName, serviceName: 评估影响范围的服务，serviceName 为空时不计算影响范围
+//
+// 返回值:
+//   - *Graph: 依赖拓扑
+//   - error: 读取持久化记录失败时返回错误
+func CollectGraph(ctx context.Context, tenantId, namespaceId, groupName, serviceName string) (*Graph, error) {
+	return defaultTracker.CollectGraph(ctx, tenantId, namespaceId, groupName, serviceName)
+}
+
+// CollectGraph 汇总命名空间的服务依赖拓扑
+func (t *Tracker) CollectGraph(ctx context.Context, tenantId, namespaceId, groupName, serviceName string) (*Graph, error) {
+	t.mu.Lock()
+	store := t.store
+	dependencies := make(map[string]*types.ServiceDependency)
+	active := make(map[string]int)
+	for id, live := range t.edges {
+		if live.tenantId != tenantId || (live.namespaceId != namespaceId && live.consumer.NamespaceId != namespaceId) {
+			continue
+		}
+		dependencies[id] = live.toDependency(id)
+		active[id] = live.subscribers
+	}
+	t.mu.Unlock()
+
+	if store != nil {
+		persisted, err := store.ListDependencies(ctx, tenantId, namespaceId)
+		if err != nil {
+			return nil, fmt.Errorf("读取服务依赖关系失败: %w", err)
+		}
+		for _, dependency := range persisted {
+			if current, exists := dependencies[dependency.DependencyId]; exists {
+				// 运行期间的记录为准，首次订阅时间取持久化记录中更早的时间
+				if !dependency.FirstSeenTime.IsZero() && dependency.FirstSeenTime.Before(current.FirstSeenTime) {
+					current.FirstSeenTime = dependency.FirstSeenTime
+				}
+				continue
+			}
+			dependencies[dependency.DependencyId] = dependency
+		}
+	}
+
+	var services []*types.Service
+	cache.GetGlobalCache().GetAllServices(func(service *types.Service) {
+		if service != nil && service.TenantId == tenantId && service.NamespaceId == namespaceId {
+			services = append(services, service)
+		}
+	})
+
+	graph := buildGraph(tenantId, namespaceId, services, dependencies, active, time.Now())
+	if serviceName != "" {
+		if groupName == "" {
+			groupName = defaultGroupName
+		}
+		graph.Impacted = graph.blastRadius(serviceNodeId(namespaceId, groupName, serviceName))
+	}
+	return graph, nil
+}
+
+// buildGraph 由服务列表和依赖关系构建拓扑
+func buildGraph(tenantId, namespaceId string, services []*types.Service, dependencies map[string]*types.ServiceDependency, active map[string]int, now time.Time) *Graph {
+	nodes := make(map[string]*GraphNode)
+	node := func(id string, build func() *GraphNode) *GraphNode {
+		n, exists := nodes[id]
+		if !exists {
+			n = build()
+			n.Id = id
+			nodes[id] = n
+		}
+		return n
+	}
+	serviceNode := func(ns, group, name string) *GraphNode {
+		return node(serviceNodeId(ns, group, name), func() *GraphNode {
+			return &GraphNode{Type: types.ConsumerTypeService, NamespaceId: ns, GroupName: group, ServiceName: name}
+		})
+	}
+
+	for _, service := range services {
+		n := serviceNode(service.NamespaceId, service.GroupName, service.ServiceName)
+		n.Registered = true
+		for _, serviceNode := range service.Nodes {
+			if serviceNode == nil {
+				continue
+			}
+			n.NodeCount++
+			if serviceNode.HealthyStatus == types.HealthyStatusHealthy {
+				n.HealthyNodes++
+			}
+		}
+	}
+
+	graph := &Graph{TenantId: tenantId, NamespaceId: namespaceId, StatTime: now}
+	for id, dependency := range dependencies {
+		var source *GraphNode
+		if dependency.ConsumerType == types.ConsumerTypeClient {
+			source = node(clientNodeId(dependency.ConsumerName), func() *GraphNode {
+				return &GraphNode{Type: types.ConsumerTypeClient, ServiceName: dependency.ConsumerName}
+			})
+		} else {
+			source = serviceNode(dependency.ConsumerNamespaceId, dependency.ConsumerGroupName, dependency.ConsumerName)
+		}
+		target := serviceNode(dependency.NamespaceId, dependency.ProviderGroupName, dependency.ProviderServiceName)
+
+		graph.Edges = append(graph.Edges, &GraphEdge{
+			Id:                id,
+			Source:            source.Id,
+			Target:            target.Id,
+			SubscribeType:     dependency.SubscribeType,
+			ActiveSubscribers: active[id],
+			FirstSeenTime:     dependency.FirstSeenTime,
+			LastSeenTime:      dependency.LastSeenTime,
+		})
+	}
+
+	graph.Nodes = make([]*GraphNode, 0, len(nodes))
+	for _, n := range nodes {
+		graph.Nodes = append(graph.Nodes, n)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].Id < graph.Nodes[j].Id })
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].Source != graph.Edges[j].Source {
+			return graph.Edges[i].Source < graph.Edges[j].Source
+		}
+		return graph.Edges[i].Target < graph.Edges[j].Target
+	})
+	return graph
+}
+
+// blastRadius 计算直接或间接依赖指定服务的订阅方
+// 订阅整个命名空间/分组的订阅方同样依赖其中的每个服务；只有服务类型的订阅方会继续向上传播
+func (g *Graph) blastRadius(targetId string) []*Impact {
+	nodes := make(map[string]*GraphNode, len(g.Nodes))
+	for _, n := range g.Nodes {
+		nodes[n.Id] = n
+	}
+
+	// dependsOn 订阅方的依赖关系是否指向 target（包括覆盖 target 的命名空间/分组订阅）
+	dependsOn := func(edge *GraphEdge, target *GraphNode) bool {
+		if edge.Target == target.Id {
+			return true
+		}
+		provider := nodes[edge.Target]
+		return provider != nil && provider.ServiceName == types.AllServices && target.Type == types.ConsumerTypeService &&
+			provider.NamespaceId == target.NamespaceId && (provider.GroupName == "" || provider.GroupName == target.GroupName)
+	}
+
+	target := nodes[targetId]
+	if target == nil {
+		return nil
+	}
+
+	impacts := make(map[string]*Impact)
+	queue := []*GraphNode{target}
+	activePath := map[string]bool{targetId: true}
+	for depth := 1; len(queue) > 0; depth++ {
+		var next []*GraphNode
+		for _, current := range queue {
+			for _, edge := range g.Edges {
+				if edge.Source == targetId || !dependsOn(edge, current) {
+					continue
+				}
+				active := activePath[current.Id] && edge.ActiveSubscribers > 0
+				if impact, seen := impacts[edge.Source]; seen {
+					impact.Active = impact.Active || active
+					continue
+				}
+				impacts[edge.Source] = &Impact{NodeId: edge.Source, Depth: depth, Active: active}
+				activePath[edge.Source] = active
+				if consumer := nodes[edge.Source]; consumer != nil && consumer.Type == types.ConsumerTypeService {
+					next = append(next, consumer)
+				}
+			}
+		}
+		queue = next
+	}
+
+	result := make([]*Impact, 0, len(impacts))
+	for _, impact := range impacts {
+		result = append(result, impact)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Depth != result[j].Depth {
+			return result[i].Depth < result[j].Depth
+		}
+		return result[i].NodeId < result[j].NodeId
+	})
+	return result
+}
+
+// serviceNodeId 服务节点ID
+func serviceNodeId(namespaceId, groupName, serviceName string) string {
+	return namespaceId + "/" + groupName + "/" + serviceName
+}
+
+// clientNodeId 客户端节点ID
+func clientNodeId(clientId string) string {
+	return "client:" + clientId
+}
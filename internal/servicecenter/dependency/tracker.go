@@ -0,0 +1,263 @@
+package dependency
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"gateway/internal/servicecenter/types"
+	"gateway/pkg/logger"
+)
+
+// PersistInterval 同一依赖关系两次持久化的最小间隔，期间重复订阅只更新内存中的最近订阅时间
+const PersistInterval = 10 * time.Minute
+
+// persistTimeout 单次持久化的超时时间
+const persistTimeout = 5 * time.Second
+
+// 订阅方在客户端标签（或一元调用的 gRPC metadata）中声明服务身份使用的键
+const (
+	LabelServiceName = "serviceName" // 订阅方服务名称
+	LabelGroupName   = "groupName"   // 订阅方分组名称，未声明时为 DEFAULT_GROUP
+	LabelNamespaceId = "namespaceId" // 订阅方命名空间ID，未声明时与被订阅服务相同
+)
+
+// defaultGroupName 订阅方未声明分组时使用的分组名称
+const defaultGroupName = "DEFAULT_GROUP"
+
+// Consumer 订阅方身份
+type Consumer struct {
+	Type        string // 订阅方类型(SERVICE/CLIENT)
+	NamespaceId string // 订阅方命名空间ID，CLIENT 类型为空
+	GroupName   string // 订阅方分组名称，CLIENT 类型为空
+	Name        string // 订阅方服务名称，CLIENT 类型时为客户端ID或IP
+}
+
+// ServiceConsumer 已声明服务身份的订阅方
+func ServiceConsumer(namespaceId, groupName, serviceName string) Consumer {
+	if groupName == "" {
+		groupName = defaultGroupName
+	}
+	return Consumer{Type: types.ConsumerTypeService, NamespaceId: namespaceId, GroupName: groupName, Name: serviceName}
+}
+
+// ClientConsumer 未声明服务身份的客户端，以客户端ID或IP标识
+func ClientConsumer(clientId string) Consumer {
+	return Consumer{Type: types.ConsumerTypeClient, Name: clientId}
+}
+
+// ConsumerFromLabels 从客户端标签解析订阅方服务身份
+// 参数:
+//   - labels: 客户端标签
+//   - namespaceId: 未声明命名空间时使用的命名空间ID（被订阅服务的命名空间）
+//
+// 返回值:
+//   - Consumer: 订阅方身份
+//   - bool: 标签中是否声明了服务名称
+func ConsumerFromLabels(labels map[string]string, namespaceId string) (Consumer, bool) {
+	serviceName := strings.TrimSpace(labels[LabelServiceName])
+	if serviceName == "" {
+		return Consumer{}, false
+	}
+	if ns := strings.TrimSpace(labels[LabelNamespaceId]); ns != "" {
+		namespaceId = ns
+	}
+	return ServiceConsumer(namespaceId, strings.TrimSpace(labels[LabelGroupName]), serviceName), true
+}
+
+// Store 依赖关系持久化存储，由 dao.DependencyDAO 实现
+type Store interface {
+	SaveDependency(ctx context.Context, dependency *types.ServiceDependency) error
+	ListDependencies(ctx context.Context, tenantId, namespaceId string) ([]*types.ServiceDependency, error)
+}
+
+// edge 一条依赖关系：订阅方 -> 被订阅服务（ServiceName 为 * 表示整个命名空间/分组）
+type edge struct {
+	tenantId      string
+	consumer      Consumer
+	namespaceId   string
+	groupName     string
+	serviceName   string
+	subscribeType string
+}
+
+// id 依赖关系ID，同一订阅方对同一服务的订阅只记录一条
+func (e edge) id() string {
+	key := strings.Join([]string{e.tenantId, e.consumer.Type, e.consumer.NamespaceId, e.consumer.GroupName, e.consumer.Name,
+		e.namespaceId, e.groupName, e.serviceName}, "\x00")
+	sum := md5.Sum([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// liveEdge 运行期间记录的依赖关系
+type liveEdge struct {
+	edge
+	subscribers   int       // 当前仍在订阅的订阅者数
+	firstSeenTime time.Time // 本次运行期间首次订阅时间
+	lastSeenTime  time.Time // 最近一次订阅时间
+	persistedAt   time.Time // 最近一次持久化时间
+}
+
+// Tracker 服务依赖关系记录器
+// 订阅时按订阅者记录依赖关系并按 PersistInterval 节流持久化，取消订阅时只减少活跃订阅数，依赖关系保留用于拓扑展示
+type Tracker struct {
+	mu            sync.Mutex
+	store         Store
+	edges         map[string]*liveEdge // key: 依赖关系ID
+	subscriptions map[string][]string  // key: subscriberID -> 依赖关系ID列表
+}
+
+// NewTracker 创建依赖关系记录器，store 为 nil 时只在内存中记录
+func NewTracker(store Store) *Tracker {
+	return &Tracker{
+		store:         store,
+		edges:         make(map[string]*liveEdge),
+		subscriptions: make(map[string][]string),
+	}
+}
+
+// defaultTracker 全局依赖关系记录器，注册中心处理订阅时写入
+var defaultTracker = NewTracker(nil)
+
+// SetStore 设置全局记录器的持久化存储
+func SetStore(store Store) {
+	defaultTracker.mu.Lock()
+	defer defaultTracker.mu.Unlock()
+	defaultTracker.store = store
+}
+
+// TrackServices 记录订阅方对指定服务的订阅
+func TrackServices(subscriberID, tenantId string, consumer Consumer, namespaceId, groupName string, serviceNames []string) {
+	defaultTracker.TrackServices(subscriberID, tenantId, consumer, namespaceId, groupName, serviceNames)
+}
+
+// TrackNamespace 记录订阅方对整个命名空间/分组的订阅
+func TrackNamespace(subscriberID, tenantId string, consumer Consumer, namespaceId, groupName string) {
+	defaultTracker.TrackNamespace(subscriberID, tenantId, consumer, namespaceId, groupName)
+}
+
+// Untrack 订阅者取消订阅
+func Untrack(subscriberID string) {
+	defaultTracker.Untrack(subscriberID)
+}
+
+// UntrackPrefix 取消ID以 prefix 开头的全部订阅者，双向流连接断开时按连接ID清理
+func UntrackPrefix(prefix string) {
+	defaultTracker.UntrackPrefix(prefix)
+}
+
+// TrackServices 记录订阅方对指定服务的订阅
+func (t *Tracker) TrackServices(subscriberID, tenantId string, consumer Consumer, namespaceId, groupName string, serviceNames []string) {
+	edges := make([]edge, 0, len(serviceNames))
+	for _, serviceName := range serviceNames {
+		edges = append(edges, edge{
+			tenantId: tenantId, consumer: consumer,
+			namespaceId: namespaceId, groupName: groupName, serviceName: serviceName,
+			subscribeType: types.SubscribeTypeService,
+		})
+	}
+	t.track(subscriberID, edges, time.Now())
+}
+
+// TrackNamespace 记录订阅方对整个命名空间/分组的订阅，groupName 为空表示整个命名空间
+func (t *Tracker) TrackNamespace(subscriberID, tenantId string, consumer Consumer, namespaceId, groupName string) {
+	t.track(subscriberID, []edge{{
+		tenantId: tenantId, consumer: consumer,
+		namespaceId: namespaceId, groupName: groupName, serviceName: types.AllServices,
+		subscribeType: types.SubscribeTypeNamespace,
+	}}, time.Now())
+}
+
+// track 记录订阅者的依赖关系，超过持久化间隔的依赖关系异步写入存储
+func (t *Tracker) track(subscriberID string, edges []edge, now time.Time) {
+	var pending []*types.ServiceDependency
+
+	t.mu.Lock()
+	for _, e := range edges {
+		id := e.id()
+		live, exists := t.edges[id]
+		if !exists {
+			live = &liveEdge{edge: e, firstSeenTime: now}
+			t.edges[id] = live
+		}
+		live.subscribers++
+		live.lastSeenTime = now
+		t.subscriptions[subscriberID] = append(t.subscriptions[subscriberID], id)
+
+		if t.store != nil && now.Sub(live.persistedAt) >= PersistInterval {
+			live.persistedAt = now
+			pending = append(pending, live.toDependency(id))
+		}
+	}
+	store := t.store
+	t.mu.Unlock()
+
+	if len(pending) > 0 {
+		go persist(store, pending)
+	}
+}
+
+// Untrack 订阅者取消订阅，依赖关系的活跃订阅数减少
+func (t *Tracker) Untrack(subscriberID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.untrackLocked(subscriberID)
+}
+
+// UntrackPrefix 取消ID以 prefix 开头的全部订阅者
+func (t *Tracker) UntrackPrefix(prefix string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for subscriberID := range t.subscriptions {
+		if strings.HasPrefix(subscriberID, prefix) {
+			t.untrackLocked(subscriberID)
+		}
+	}
+}
+
+// untrackLocked 取消订阅者的全部依赖关系，调用方需持有锁
+func (t *Tracker) untrackLocked(subscriberID string) {
+	for _, id := range t.subscriptions[subscriberID] {
+		if live, exists := t.edges[id]; exists && live.subscribers > 0 {
+			live.subscribers--
+		}
+	}
+	delete(t.subscriptions, subscriberID)
+}
+
+// toDependency 转换为持久化记录
+func (e *liveEdge) toDependency(id string) *types.ServiceDependency {
+	return &types.ServiceDependency{
+		DependencyId:        id,
+		TenantId:            e.tenantId,
+		NamespaceId:         e.namespaceId,
+		ConsumerType:        e.consumer.Type,
+		ConsumerNamespaceId: e.consumer.NamespaceId,
+		ConsumerGroupName:   e.consumer.GroupName,
+		ConsumerName:        e.consumer.Name,
+		ProviderGroupName:   e.groupName,
+		ProviderServiceName: e.serviceName,
+		SubscribeType:       e.subscribeType,
+		FirstSeenTime:       e.firstSeenTime,
+		LastSeenTime:        e.lastSeenTime,
+	}
+}
+
+// persist 写入依赖关系，失败只记录日志，下次订阅超过持久化间隔后重试
+func persist(store Store, dependencies []*types.ServiceDependency) {
+	ctx, cancel := context.WithTimeout(context.Background(), persistTimeout)
+	defer cancel()
+	for _, dependency := range dependencies {
+		if err := store.SaveDependency(ctx, dependency); err != nil {
+			logger.Warn("保存服务依赖关系失败",
+				"error", err,
+				"consumer", dependency.ConsumerName,
+				"namespaceId", dependency.NamespaceId,
+				"groupName", dependency.ProviderGroupName,
+				"serviceName", dependency.ProviderServiceName)
+		}
+	}
+}
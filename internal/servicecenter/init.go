@@ -8,6 +8,7 @@ import (
 	"gateway/internal/servicecenter/server"
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
+	"gateway/pkg/utils/huberrors"
 )
 
 // ServiceCenter 服务中心全局实例
@@ -82,13 +83,13 @@ func StopAll(ctx context.Context) error {
 		return fmt.Errorf("服务中心管理器未初始化")
 	}
 
-	var errors []string
+	stopErrors := huberrors.NewMultiError("部分服务中心实例停止失败")
 	count := 0
 
 	ServiceCenter.ForEachInstance(func(instanceName string, srv *server.Server) error {
 		if srv.IsRunning() {
 			if err := ServiceCenter.StopInstance(ctx, instanceName); err != nil {
-				errors = append(errors, fmt.Sprintf("%s: %v", instanceName, err))
+				huberrors.Append(stopErrors, fmt.Errorf("%s: %w", instanceName, err))
 				logger.Error("停止服务中心实例失败", err, "instanceName", instanceName)
 			} else {
 				count++
@@ -97,8 +98,8 @@ func StopAll(ctx context.Context) error {
 		return nil
 	})
 
-	if len(errors) > 0 {
-		return fmt.Errorf("部分服务中心实例停止失败: %v", errors)
+	if err := stopErrors.ErrorOrNil(); err != nil {
+		return err
 	}
 
 	logger.Info("所有服务中心实例停止成功", "count", count)
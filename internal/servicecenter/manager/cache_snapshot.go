@@ -0,0 +1,102 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gateway/internal/servicecenter/cache"
+	pkgcache "gateway/pkg/cache"
+	"gateway/pkg/logger"
+)
+
+// ========== 缓存本地快照 ==========
+
+// restoreCacheSnapshot 从本地快照恢复注册中心缓存，进入降级模式
+// 数据库加载缓存失败时调用，未启用快照或快照不可用时只记录日志
+func (m *ServiceCenterManager) restoreCacheSnapshot(ctx context.Context) {
+	store := pkgcache.GetSnapshotStore()
+	if store == nil {
+		return
+	}
+
+	var snapshot cache.RegistrySnapshot
+	info, err := store.Load(cache.RegistrySnapshotName, &snapshot)
+	if err != nil {
+		if errors.Is(err, pkgcache.ErrSnapshotNotFound) {
+			logger.Warn("注册中心缓存快照不存在，无法恢复")
+		} else {
+			logger.Warn("读取注册中心缓存快照失败", "error", err)
+		}
+		return
+	}
+
+	namespaceCount, serviceCount := cache.RestoreRegistrySnapshot(ctx, cache.GetGlobalCache(), &snapshot)
+	pkgcache.MarkSnapshotRestored(*info)
+	logger.Warn("已从本地快照恢复注册中心缓存，以降级模式运行",
+		"savedAt", info.SavedAt.Format(time.RFC3339),
+		"age", info.Age(time.Now()).Round(time.Second),
+		"namespaceCount", namespaceCount,
+		"serviceCount", serviceCount)
+}
+
+// startCacheSnapshot 启动注册中心缓存的定期快照，只启动一次
+// 未启用快照时不启动；降级模式下不写快照，而是尝试从数据库重新加载，加载成功后退出降级模式
+func (m *ServiceCenterManager) startCacheSnapshot(tenantId string) {
+	store := pkgcache.GetSnapshotStore()
+	if store == nil {
+		return
+	}
+
+	m.snapshotOnce.Do(func() {
+		interval := pkgcache.GetSnapshotInterval()
+		m.snapshotWg.Add(1)
+		go func() {
+			defer m.snapshotWg.Done()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+					m.refreshCacheSnapshot(store, tenantId)
+				case <-m.snapshotStopCh:
+					// 停止前写入最后一次快照
+					m.refreshCacheSnapshot(store, tenantId)
+					return
+				}
+			}
+		}()
+		logger.Info("注册中心缓存定期快照已启动", "interval", interval)
+	})
+}
+
+// refreshCacheSnapshot 执行一次快照写入或降级恢复
+func (m *ServiceCenterManager) refreshCacheSnapshot(store *pkgcache.SnapshotStore, tenantId string) {
+	ctx := context.Background()
+
+	if pkgcache.IsSnapshotRestored(cache.RegistrySnapshotName) {
+		if err := m.loadNamespacesToCache(ctx, tenantId); err != nil {
+			logger.Debug("数据库仍不可用，注册中心缓存保持降级模式", "error", err)
+			return
+		}
+		if err := m.loadServicesToCache(ctx, tenantId); err != nil {
+			logger.Debug("数据库仍不可用，注册中心缓存保持降级模式", "error", err)
+			return
+		}
+		pkgcache.ClearSnapshotRestored(cache.RegistrySnapshotName)
+		logger.Info("已从数据库重新加载注册中心缓存，退出降级模式")
+	}
+
+	if err := store.Save(cache.RegistrySnapshotName, cache.CollectRegistrySnapshot(cache.GetGlobalCache())); err != nil {
+		logger.Warn("写入注册中心缓存快照失败", "error", err)
+	}
+}
+
+// stopCacheSnapshot 停止定期快照
+func (m *ServiceCenterManager) stopCacheSnapshot() {
+	m.snapshotStopOnce.Do(func() {
+		close(m.snapshotStopCh)
+	})
+	m.snapshotWg.Wait()
+}
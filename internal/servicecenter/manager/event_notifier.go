@@ -204,7 +204,7 @@ func (n *EventNotifier) nodeToProto(node *types.ServiceNode) *pb.Node {
 		InstanceStatus: node.InstanceStatus,
 		HealthyStatus:  node.HealthyStatus,
 		Ephemeral:      node.Ephemeral,
-		Weight:         node.Weight,
+		Weight:         node.DiscoveryWeight(),
 		Metadata:       n.parseMetadataJson(node.MetadataJson),
 	}
 }
@@ -11,6 +11,7 @@ import (
 	"gateway/internal/servicecenter/cache"
 	"gateway/internal/servicecenter/centerlog"
 	pb "gateway/internal/servicecenter/server/proto"
+	"gateway/internal/servicecenter/stats"
 	"gateway/internal/servicecenter/types"
 	"gateway/pkg/logger"
 )
@@ -151,7 +152,10 @@ func (hc *HealthChecker) performHealthCheck() {
 	// 类似 Nacos 的实现：定期检查所有节点的心跳时间，超时则驱逐
 	evictedCount := hc.checkAndEvictTimeoutNodes(ctx)
 
-	// 2. 执行缓存同步
+	// 2. 解除到期的抖动隔离，隔离期内心跳正常的节点恢复为健康
+	hc.releaseFlapQuarantines(ctx)
+
+	// 3. 执行缓存同步
 	if err := hc.syncCacheToDB(ctx); err != nil {
 		logger.Warn("缓存同步失败",
 			"instanceName", hc.instanceName,
@@ -357,6 +361,8 @@ func (hc *HealthChecker) checkAndEvictTimeoutNodes(ctx context.Context) int {
 		node    *types.ServiceNode
 	}
 	var evictItems []evictItem
+	// 标记为不健康后判定为抖动的持久节点
+	var flappingNodes []*types.ServiceNode
 
 	// 遍历所有服务，检查节点心跳超时
 	globalCache.GetAllServices(func(service *types.Service) {
@@ -404,20 +410,35 @@ func (hc *HealthChecker) checkAndEvictTimeoutNodes(ctx context.Context) int {
 							"serviceName", node.ServiceName,
 							"lastBeatTime", node.LastBeatTime.Format("2006-01-02 15:04:05"),
 							"timeout", timeSinceLastBeat)
+						if stats.ObserveNodeHealth(node.TenantId, node.NodeId, node.HealthyStatus, "心跳超时") {
+							flappingNodes = append(flappingNodes, node)
+						}
 					}
 				}
 			}
 		}
 	})
 
+	// 在回调外推送抖动隔离事件（避免阻塞缓存遍历）
+	for _, node := range flappingNodes {
+		logger.Warn("持久节点健康状态频繁切换，判定为抖动并隔离",
+			"nodeId", node.NodeId,
+			"serviceName", node.ServiceName,
+			"quarantine", stats.DefaultFlapQuarantine)
+		hc.notifyNodeChange(ctx, node, "NODE_FLAPPING")
+	}
+
 	// 在回调外执行驱逐操作（避免阻塞缓存遍历）
 	for _, item := range evictItems {
 		// 保存节点信息（用于构建事件）
 		savedNode := item.node
+		// 记录驱逐前的健康状态切换，节点使用相同 nodeId 重新注册时据此判断是否抖动
+		stats.ObserveNodeHealth(savedNode.TenantId, savedNode.NodeId, types.HealthyStatusUnhealthy, "心跳超时驱逐")
 
 		// 从缓存中移除节点
 		globalCache.RemoveNode(ctx, item.node.TenantId, item.node.NamespaceId,
 			item.node.GroupName, item.node.ServiceName, item.node.NodeId)
+		stats.RecordDeregister(item.node.TenantId, item.node.NamespaceId, item.node.GroupName)
 
 		// 从缓存获取完整的服务信息（包括删除后的所有节点列表）
 		service, serviceFound := globalCache.GetService(ctx, item.node.TenantId, item.node.NamespaceId,
@@ -492,6 +513,65 @@ func (hc *HealthChecker) checkAndEvictTimeoutNodes(ctx context.Context) int {
 	return evictedCount
 }
 
+// releaseFlapQuarantines 解除到期的抖动隔离
+// 隔离期内仍按时心跳的节点恢复为健康并推送 NODE_UPDATED 事件，心跳已超时的节点保持不健康
+func (hc *HealthChecker) releaseFlapQuarantines(ctx context.Context) {
+	globalCache := cache.GetGlobalCache()
+	now := time.Now()
+	for _, ref := range stats.ReleaseExpiredQuarantines() {
+		node, found := globalCache.GetNode(ctx, ref.TenantId, ref.NodeId)
+		if !found || node == nil {
+			continue
+		}
+		if node.LastBeatTime == nil || now.Sub(*node.LastBeatTime) > hc.interval {
+			logger.Info("抖动隔离到期，节点心跳已超时，保持不健康",
+				"nodeId", node.NodeId,
+				"serviceName", node.ServiceName)
+			continue
+		}
+
+		node.HealthyStatus = types.HealthyStatusHealthy
+		node.EditTime = now
+		globalCache.UpdateNode(ctx, node)
+		stats.ObserveNodeHealth(node.TenantId, node.NodeId, node.HealthyStatus, "抖动隔离到期")
+		logger.Info("抖动隔离到期，节点恢复为健康",
+			"nodeId", node.NodeId,
+			"serviceName", node.ServiceName,
+			"namespaceId", node.NamespaceId,
+			"groupName", node.GroupName)
+		hc.notifyNodeChange(ctx, node, "NODE_UPDATED")
+	}
+}
+
+// notifyNodeChange 推送节点变更事件（包含服务信息和全部节点列表）
+func (hc *HealthChecker) notifyNodeChange(ctx context.Context, node *types.ServiceNode, eventType string) {
+	service, found := cache.GetGlobalCache().GetService(ctx, node.TenantId, node.NamespaceId, node.GroupName, node.ServiceName)
+	if !found || service == nil {
+		return
+	}
+	pbNodes := make([]*pb.Node, 0, len(service.Nodes))
+	for _, n := range service.Nodes {
+		pbNodes = append(pbNodes, convertNodeToProto(n))
+	}
+	event := &pb.ServiceChangeEvent{
+		EventType:   eventType,
+		Timestamp:   time.Now().Format("2006-01-02 15:04:05"),
+		NamespaceId: node.NamespaceId,
+		GroupName:   node.GroupName,
+		ServiceName: node.ServiceName,
+		Service:     convertServiceToProto(service),
+		Nodes:       pbNodes,
+		ChangedNode: convertNodeToProto(node),
+	}
+	if err := hc.manager.NotifyServiceChange(ctx, hc.instanceName, node.TenantId,
+		node.NamespaceId, node.GroupName, node.ServiceName, event); err != nil {
+		logger.Warn("通知服务变更失败",
+			"nodeId", node.NodeId,
+			"eventType", eventType,
+			"error", err)
+	}
+}
+
 // convertServiceToProto 转换服务为 protobuf 格式
 func convertServiceToProto(service *types.Service) *pb.Service {
 	if service == nil {
@@ -547,7 +627,7 @@ func convertNodeToProto(node *types.ServiceNode) *pb.Node {
 		ServiceName:    node.ServiceName,
 		IpAddress:      node.IpAddress,
 		PortNumber:     int32(node.PortNumber),
-		Weight:         node.Weight,
+		Weight:         node.DiscoveryWeight(),
 		Ephemeral:      node.Ephemeral,
 		InstanceStatus: node.InstanceStatus,
 		HealthyStatus:  node.HealthyStatus,
@@ -8,11 +8,14 @@ import (
 
 	"gateway/internal/servicecenter/cache"
 	"gateway/internal/servicecenter/dao"
+	"gateway/internal/servicecenter/dependency"
 	"gateway/internal/servicecenter/server"
 	pb "gateway/internal/servicecenter/server/proto"
+	"gateway/internal/servicecenter/server/subscriber"
 	"gateway/internal/servicecenter/types"
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
+	"gateway/pkg/utils/huberrors"
 )
 
 // ServiceCenterManager 服务中心管理器
@@ -40,6 +43,12 @@ type ServiceCenterManager struct {
 
 	// 事件通知器（辅助类）
 	eventNotifier *EventNotifier
+
+	// 缓存定期快照（cache.snapshot 启用时运行）
+	snapshotOnce     sync.Once
+	snapshotStopOnce sync.Once
+	snapshotStopCh   chan struct{}
+	snapshotWg       sync.WaitGroup
 }
 
 // NewServiceCenterManager 创建服务中心管理器
@@ -48,6 +57,7 @@ func NewServiceCenterManager(db database.Database) *ServiceCenterManager {
 		db:             db,
 		instances:      make(map[string]*server.Server),
 		healthCheckers: make(map[string]*HealthChecker),
+		snapshotStopCh: make(chan struct{}),
 	}
 
 	// 初始化共享的 DAO 层
@@ -58,6 +68,9 @@ func NewServiceCenterManager(db database.Database) *ServiceCenterManager {
 	manager.historyDAO = dao.NewHistoryDAO(db)
 	manager.instanceDAO = dao.NewInstanceDAO(db)
 
+	// 服务依赖关系在订阅时记录，持久化到 HUB_SERVICE_DEPENDENCY
+	dependency.SetStore(dao.NewDependencyDAO(db))
+
 	// 初始化事件通知器
 	manager.eventNotifier = NewEventNotifier(manager)
 
@@ -87,11 +100,16 @@ func (m *ServiceCenterManager) ForEachInstance(fn func(string, *server.Server) e
 }
 
 // LoadAllInstancesFromDB 从数据库加载指定租户的所有实例配置并创建 Server（所有环境）
-// 同时从数据库恢复命名空间和服务到缓存
+// 同时从数据库恢复命名空间和服务到缓存，数据库不可用时从本地快照恢复缓存（启用 cache.snapshot 时）
 func (m *ServiceCenterManager) LoadAllInstancesFromDB(ctx context.Context, tenantId string) error {
+	// 启动缓存定期快照，降级模式下由快照任务负责重新从数据库加载
+	m.startCacheSnapshot(tenantId)
+
 	// 查询指定租户的所有实例配置（所有环境）
 	configs, err := m.instanceDAO.ListAllInstances(ctx, tenantId)
 	if err != nil {
+		// 数据库不可用，从本地快照恢复缓存，供进程内的服务发现使用
+		m.restoreCacheSnapshot(ctx)
 		return fmt.Errorf("加载实例配置失败: %w", err)
 	}
 
@@ -135,15 +153,23 @@ func (m *ServiceCenterManager) LoadAllInstancesFromDB(ctx context.Context, tenan
 	logger.Info("所有服务中心实例加载完成", "count", len(configs))
 
 	// 从数据库恢复命名空间到缓存
+	cacheLoaded := true
 	if err := m.loadNamespacesToCache(ctx, tenantId); err != nil {
 		logger.Warn("加载命名空间到缓存失败", "error", err)
 		// 命名空间加载失败不影响主流程，只记录警告
+		cacheLoaded = false
 	}
 
 	// 从数据库恢复服务和节点到缓存
 	if err := m.loadServicesToCache(ctx, tenantId); err != nil {
 		logger.Warn("加载服务和节点到缓存失败", "error", err)
 		// 服务加载失败不影响主流程，只记录警告
+		cacheLoaded = false
+	}
+
+	// 数据库加载失败时从本地快照补齐缓存
+	if !cacheLoaded {
+		m.restoreCacheSnapshot(ctx)
 	}
 
 	return nil
@@ -449,6 +475,59 @@ func (m *ServiceCenterManager) NotifyConfigChange(ctx context.Context, instanceN
 	return nil
 }
 
+// NotifyMatchingConfigWatchers 只向命中 match 的配置监听者推送事件（用于配置灰度发布和灰度回滚）
+//
+// 返回值:
+//   - int: 通知的监听者数量
+//   - error: 实例不存在或未初始化时返回错误
+func (m *ServiceCenterManager) NotifyMatchingConfigWatchers(ctx context.Context, instanceName, tenantId, namespaceId, groupName, configDataId string, event *pb.ConfigChangeEvent, match func(types.ConfigClient) bool) (int, error) {
+	configWatcher, err := m.getConfigWatcher(instanceName)
+	if err != nil {
+		return 0, err
+	}
+
+	if event.Timestamp == "" {
+		event.Timestamp = time.Now().Format("2006-01-02 15:04:05")
+	}
+	notified := configWatcher.NotifyMatchingWatchers(tenantId, namespaceId, groupName, configDataId, event, match)
+
+	logger.Info("按规则触发配置变更事件通知",
+		"instanceName", instanceName,
+		"namespaceId", namespaceId,
+		"groupName", groupName,
+		"configDataId", configDataId,
+		"eventType", event.EventType,
+		"notified", notified)
+
+	return notified, nil
+}
+
+// ListConfigWatchers 列出正在监听指定配置的客户端实例（用于灰度发布时核对灰度范围）
+func (m *ServiceCenterManager) ListConfigWatchers(instanceName, tenantId, namespaceId, groupName, configDataId string) ([]types.ConfigClient, error) {
+	configWatcher, err := m.getConfigWatcher(instanceName)
+	if err != nil {
+		return nil, err
+	}
+	return configWatcher.ListWatcherClients(tenantId, namespaceId, groupName, configDataId), nil
+}
+
+// getConfigWatcher 获取指定实例的配置监听管理器
+func (m *ServiceCenterManager) getConfigWatcher(instanceName string) (*subscriber.ConfigWatcher, error) {
+	srv := m.GetInstance(instanceName)
+	if srv == nil {
+		return nil, fmt.Errorf("服务中心实例 '%s' 不存在", instanceName)
+	}
+	configHandler := srv.GetConfigHandler()
+	if configHandler == nil {
+		return nil, fmt.Errorf("服务中心实例 '%s' 的 ConfigHandler 未初始化", instanceName)
+	}
+	configWatcher := configHandler.GetConfigWatcher()
+	if configWatcher == nil {
+		return nil, fmt.Errorf("服务中心实例 '%s' 的 ConfigWatcher 未初始化", instanceName)
+	}
+	return configWatcher, nil
+}
+
 // ========== 缓存恢复（初始化时从数据库加载） ==========
 
 // loadNamespacesToCache 从数据库加载命名空间到缓存
@@ -963,6 +1042,60 @@ func (m *ServiceCenterManager) OfflineNodeInCache(ctx context.Context, tenantId,
 	return nil
 }
 
+// SetNodeWeightOverrideInCache 手动调整节点权重（自动通知订阅者）
+//
+// 手动调整的权重保存在节点扩展属性中，与节点注册的权重分开保存，节点心跳或重连注册更新注册权重时不会覆盖；
+// 调整为0表示摘除节点流量，节点不再参与服务发现和实例选择
+//
+// 参数:
+//   - tenantId: 租户ID
+//   - nodeId: 节点ID
+//   - weight: 手动调整的权重，nil 表示恢复使用注册权重
+//   - operatorId: 操作人ID（可选，用于记录操作人）
+//
+// 返回值:
+//   - *types.ServiceNode: 更新后的节点
+//   - *float64: 调整前手动调整的权重，未调整过时为 nil
+//   - error: 节点不存在或扩展属性无法解析时返回错误
+func (m *ServiceCenterManager) SetNodeWeightOverrideInCache(ctx context.Context, tenantId, nodeId string, weight *float64, operatorId string) (*types.ServiceNode, *float64, error) {
+	if tenantId == "" || nodeId == "" {
+		return nil, nil, fmt.Errorf("tenantId和nodeId不能为空")
+	}
+
+	globalCache := cache.GetGlobalCache()
+	currentNode, found := globalCache.GetNode(ctx, tenantId, nodeId)
+	if !found || currentNode == nil {
+		return nil, nil, fmt.Errorf("节点不存在: nodeId=%s", nodeId)
+	}
+
+	var previous *float64
+	if value, ok := currentNode.WeightOverride(); ok {
+		previous = &value
+	}
+	if err := currentNode.SetWeightOverride(weight); err != nil {
+		return nil, nil, err
+	}
+	currentNode.EditTime = time.Now()
+	if operatorId != "" {
+		currentNode.EditWho = operatorId
+	}
+
+	globalCache.UpdateNode(ctx, currentNode)
+
+	logger.Info("节点权重已手动调整",
+		"tenantId", tenantId,
+		"nodeId", nodeId,
+		"serviceName", currentNode.ServiceName,
+		"registeredWeight", currentNode.Weight,
+		"discoveryWeight", currentNode.DiscoveryWeight(),
+		"operatorId", operatorId)
+
+	// 自动通知订阅者（节点状态更新事件）
+	m.eventNotifier.NotifyServiceChange(ctx, currentNode.TenantId, currentNode.NamespaceId, currentNode.GroupName, currentNode.ServiceName, "NODE_UPDATED")
+
+	return currentNode, previous, nil
+}
+
 // Close 关闭管理器，释放所有资源
 func (m *ServiceCenterManager) Close() error {
 	ctx := context.Background()
@@ -974,26 +1107,25 @@ func (m *ServiceCenterManager) Close() error {
 	m.healthCheckers = make(map[string]*HealthChecker)
 	m.hcMu.Unlock()
 
+	// 停止缓存定期快照，停止前写入最后一次快照
+	m.stopCacheSnapshot()
+
 	// 停止所有实例
-	var errors []string
+	stopErrors := huberrors.NewMultiError("部分实例停止失败")
 	m.ForEachInstance(func(instanceName string, srv *server.Server) error {
 		if srv.IsRunning() {
 			if err := m.StopInstance(ctx, instanceName); err != nil {
-				errors = append(errors, fmt.Sprintf("%s: %v", instanceName, err))
+				huberrors.Append(stopErrors, fmt.Errorf("%s: %w", instanceName, err))
 				logger.Error("停止服务中心实例失败", err, "instanceName", instanceName)
 			}
 		}
 		return nil
 	})
 
-	if len(errors) > 0 {
-		logger.Warn("部分实例停止失败", "errors", errors)
-	}
-
 	// 注意：缓存是全局单例，不需要在此处关闭
 
 	logger.Info("服务中心管理器已关闭")
-	return nil
+	return stopErrors.ErrorOrNil()
 }
 
 // ========== 健康检查器管理 ==========
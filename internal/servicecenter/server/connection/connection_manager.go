@@ -168,9 +168,11 @@ func (m *ConnectionManager) CleanupTimeoutConnections(timeout time.Duration) int
 	})
 
 	for _, conn := range toRemove {
-		logger.Warn("连接心跳超时",
-			"connectionId", conn.ConnectionID,
-			"lastPingTime", conn.GetLastPingTime())
+		if logger.RateLimit("servicecenter:heartbeatTimeout", time.Minute) {
+			logger.Warn("连接心跳超时",
+				"connectionId", conn.ConnectionID,
+				"lastPingTime", conn.GetLastPingTime())
+		}
 
 		// 发送关闭通知
 		closeNotification := &pb.ServerMessage{
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"gateway/internal/servicecenter/server/connection"
+	"gateway/internal/servicecenter/types"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// grayLabelPrefix 一元调用通过 gRPC metadata 声明客户端标签时使用的键前缀，如 label-env: gray
+const grayLabelPrefix = "label-"
+
+// configClientKey 上下文中客户端实例的键
+type configClientKey struct{}
+
+// withConfigClient 在上下文中记录客户端实例，双向流处理配置请求时使用连接上的客户端信息
+func withConfigClient(ctx context.Context, client types.ConfigClient) context.Context {
+	return context.WithValue(ctx, configClientKey{}, client)
+}
+
+// configClientFromContext 解析请求对应的客户端实例，用于匹配配置灰度规则
+// 优先使用双向流记录的客户端信息；一元调用时IP来自 gRPC peer，标签来自 label- 前缀的 metadata
+func configClientFromContext(ctx context.Context) types.ConfigClient {
+	if client, ok := ctx.Value(configClientKey{}).(types.ConfigClient); ok {
+		return client
+	}
+
+	var client types.ConfigClient
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		client.ClientIp = p.Addr.String()
+		if host, _, err := net.SplitHostPort(client.ClientIp); err == nil {
+			client.ClientIp = host
+		}
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for key, values := range md {
+			if !strings.HasPrefix(key, grayLabelPrefix) || len(values) == 0 {
+				continue
+			}
+			if client.Labels == nil {
+				client.Labels = make(map[string]string)
+			}
+			client.Labels[strings.TrimPrefix(key, grayLabelPrefix)] = values[0]
+		}
+	}
+	return client
+}
+
+// streamConfigClient 双向流连接对应的客户端实例
+func streamConfigClient(conn *connection.StreamConnection) types.ConfigClient {
+	client := types.ConfigClient{ClientIp: conn.ClientIP}
+	if conn.Metadata != nil {
+		client.Labels = conn.Metadata.GetLabels()
+	}
+	return client
+}
@@ -152,8 +152,8 @@ func (h *ConfigHandler) GetConfig(ctx context.Context, req *pb.ConfigKey) (*pb.G
 		}, nil
 	}
 
-	// 转换为 protobuf 格式
-	pbConfig := convertConfigToProto(config)
+	// 转换为 protobuf 格式（命中灰度规则的客户端获取灰度内容）
+	pbConfig := convertConfigToProto(config.ForClient(configClientFromContext(ctx)))
 
 	return &pb.GetConfigResponse{
 		Success: true,
@@ -208,6 +208,14 @@ func (h *ConfigHandler) SaveConfig(ctx context.Context, req *pb.ConfigData) (*pb
 
 	// 注意：GetConfig 在记录不存在时返回 (nil, nil)，需要同时检查 err 和 existingConfig
 	if err == nil && existingConfig != nil {
+		// 灰度发布期间不允许直接修改，需先全量发布或回滚灰度
+		if existingConfig.GrayRelease() != nil {
+			return &pb.SaveConfigResponse{
+				Success: false,
+				Message: "config is in gray release, promote or cancel it first",
+			}, nil
+		}
+
 		// 配置已存在，更新
 		changeType = "UPDATE"
 		newVersion = existingConfig.Version + 1
@@ -483,6 +491,8 @@ func (h *ConfigHandler) WatchConfig(req *pb.WatchConfigRequest, stream pb.Config
 		req.ConfigDataIds,
 		watcherID,
 	)
+	client := configClientFromContext(stream.Context())
+	h.configWatcher.SetWatcherClient(watcherID, client)
 	defer func() {
 		logger.Info("配置监听注销",
 			"watcherID", watcherID,
@@ -511,8 +521,9 @@ func (h *ConfigHandler) WatchConfig(req *pb.WatchConfigRequest, stream pb.Config
 				continue
 			}
 
-			// 如果配置存在，直接发送到当前订阅者的 channel
+			// 如果配置存在，直接发送到当前订阅者的 channel（命中灰度规则时推送灰度内容）
 			if config != nil {
+				config = config.ForClient(client)
 				initialEvent := &pb.ConfigChangeEvent{
 					EventType:    "CONFIG_UPDATED", // 使用 CONFIG_UPDATED 表示这是当前配置
 					Timestamp:    time.Now().Format("2006-01-02 15:04:05"),
@@ -655,6 +666,12 @@ func (h *ConfigHandler) RollbackConfig(ctx context.Context, req *pb.RollbackConf
 
 	// 获取当前配置（用于记录回滚前的状态和获取 ContentType）
 	currentConfig, err := h.deps.ConfigDAO.GetConfig(ctx, tenantID, req.NamespaceId, req.GroupName, req.ConfigDataId)
+	if currentConfig.GrayRelease() != nil {
+		return &pb.RollbackConfigResponse{
+			Success: false,
+			Message: "config is in gray release, promote or cancel it first",
+		}, nil
+	}
 	var newVersion int64
 	var contentType string
 	if err == nil {
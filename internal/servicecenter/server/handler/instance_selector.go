@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"time"
+
+	"gateway/internal/servicecenter/types"
+)
+
+// 服务端实例选择的负载均衡策略
+const (
+	LoadBalanceWeightedRandom = "WEIGHTED_RANDOM" // 按节点权重随机选择（默认）
+	LoadBalanceZoneAffinity   = "ZONE_AFFINITY"   // 优先同可用区节点，同可用区无可用节点时跨可用区选择
+)
+
+// 实例选择使用的元数据键
+const (
+	MetadataKeyLoadBalanceStrategy = "loadBalanceStrategy" // 服务元数据：负载均衡策略
+	MetadataKeyZone                = "zone"                // 节点元数据：节点所在可用区
+)
+
+// resolveLoadBalanceStrategy 确定实例选择使用的负载均衡策略
+// 请求指定的策略优先，其次是服务元数据中配置的策略，均未配置或不支持时使用加权随机
+func resolveLoadBalanceStrategy(service *types.Service, requested string) string {
+	strategy := strings.ToUpper(strings.TrimSpace(requested))
+	if strategy == "" && service != nil && service.MetadataJson != "" {
+		metadata := make(map[string]string)
+		if err := json.Unmarshal([]byte(service.MetadataJson), &metadata); err == nil {
+			strategy = strings.ToUpper(strings.TrimSpace(metadata[MetadataKeyLoadBalanceStrategy]))
+		}
+	}
+	switch strategy {
+	case LoadBalanceWeightedRandom, LoadBalanceZoneAffinity:
+		return strategy
+	default:
+		return LoadBalanceWeightedRandom
+	}
+}
+
+// selectInstance 按负载均衡策略从节点中选择一个可调用节点
+// 只从运行中、健康且未被手动调整为0权重的节点中选择，处于预热期的节点按有效权重参与选择
+// 参数:
+//   - nodes: 服务的全部节点
+//   - strategy: 负载均衡策略
+//   - zone: 调用方所在可用区，ZONE_AFFINITY 策略使用
+//   - warmup: 服务配置的实例预热时长，<=0 表示不预热
+//
+// 返回值:
+//   - *types.ServiceNode: 选中的节点，无可用节点时返回 nil
+//   - bool: 选中的节点是否与调用方在同一可用区
+func selectInstance(nodes []*types.ServiceNode, strategy, zone string, warmup time.Duration) (*types.ServiceNode, bool) {
+	available := make([]*types.ServiceNode, 0, len(nodes))
+	for _, node := range nodes {
+		if node.InstanceStatus == types.NodeStatusUp && node.HealthyStatus == types.HealthyStatusHealthy && !node.IsWeightDrained() {
+			available = append(available, node)
+		}
+	}
+	if len(available) == 0 {
+		return nil, false
+	}
+
+	if strategy == LoadBalanceZoneAffinity && zone != "" {
+		sameZone := make([]*types.ServiceNode, 0, len(available))
+		for _, node := range available {
+			if nodeZone(node) == zone {
+				sameZone = append(sameZone, node)
+			}
+		}
+		if len(sameZone) > 0 {
+			return weightedRandom(sameZone, warmup), true
+		}
+	}
+
+	selected := weightedRandom(available, warmup)
+	return selected, zone != "" && nodeZone(selected) == zone
+}
+
+// weightedRandom 按有效权重随机选择节点，权重不大于0的节点按最小权重参与选择
+func weightedRandom(nodes []*types.ServiceNode, warmup time.Duration) *types.ServiceNode {
+	if len(nodes) == 1 {
+		return nodes[0]
+	}
+
+	now := time.Now()
+	weights := make([]float64, len(nodes))
+	total := 0.0
+	for i, node := range nodes {
+		weights[i] = nodeWeight(node, warmup, now)
+		total += weights[i]
+	}
+	target := rand.Float64() * total
+	for i, node := range nodes {
+		target -= weights[i]
+		if target < 0 {
+			return node
+		}
+	}
+	return nodes[len(nodes)-1]
+}
+
+// nodeWeight 节点参与选择的有效权重，权重范围下限为0.01
+func nodeWeight(node *types.ServiceNode, warmup time.Duration, now time.Time) float64 {
+	weight := node.EffectiveWeight(warmup, now)
+	if weight <= 0.01 {
+		return 0.01
+	}
+	return weight
+}
+
+// nodeZone 从节点元数据中读取可用区，未配置时返回空字符串
+func nodeZone(node *types.ServiceNode) string {
+	if node.MetadataJson == "" {
+		return ""
+	}
+	metadata := make(map[string]string)
+	if err := json.Unmarshal([]byte(node.MetadataJson), &metadata); err != nil {
+		return ""
+	}
+	return metadata[MetadataKeyZone]
+}
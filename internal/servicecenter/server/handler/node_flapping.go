@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"gateway/internal/servicecenter/cache"
+	pb "gateway/internal/servicecenter/server/proto"
+	"gateway/internal/servicecenter/stats"
+	"gateway/internal/servicecenter/types"
+	"gateway/pkg/logger"
+)
+
+// EventNodeFlapping 节点健康状态抖动被隔离时推送的服务变更事件类型
+const EventNodeFlapping = "NODE_FLAPPING"
+
+// observeNodeHealth 记录节点即将写入缓存的健康状态，并应用抖动隔离
+// 节点处于隔离期时健康状态保持为不健康，心跳和重连注册不能恢复；
+// 本次记录触发抖动时将节点标记为不健康并开始隔离
+// 参数:
+//   - node: 已设置好本次健康状态的节点
+//   - reason: 状态来源（如心跳、重连注册）
+//
+// 返回值:
+//   - bool: 本次是否触发了抖动隔离，触发时调用方需推送 NODE_FLAPPING 事件
+func observeNodeHealth(node *types.ServiceNode, reason string) bool {
+	if stats.IsNodeQuarantined(node.TenantId, node.NodeId) {
+		node.HealthyStatus = types.HealthyStatusUnhealthy
+		return false
+	}
+	if !stats.ObserveNodeHealth(node.TenantId, node.NodeId, node.HealthyStatus, reason) {
+		return false
+	}
+
+	node.HealthyStatus = types.HealthyStatusUnhealthy
+	stats.ObserveNodeHealth(node.TenantId, node.NodeId, node.HealthyStatus, "健康状态抖动隔离")
+	logger.Warn("节点健康状态频繁切换，判定为抖动并隔离",
+		"nodeId", node.NodeId,
+		"namespaceId", node.NamespaceId,
+		"serviceName", node.ServiceName,
+		"ipAddress", node.IpAddress,
+		"quarantine", stats.DefaultFlapQuarantine)
+	return true
+}
+
+// notifyNodeFlapping 推送节点抖动隔离事件，订阅方（如网关）据此停止向节点转发请求
+func (h *RegistryHandler) notifyNodeFlapping(ctx context.Context, node *types.ServiceNode) {
+	service, found := cache.GetGlobalCache().GetService(ctx, node.TenantId, node.NamespaceId, node.GroupName, node.ServiceName)
+	if !found || service == nil {
+		return
+	}
+	pbNodes := make([]*pb.Node, 0, len(service.Nodes))
+	for _, n := range service.Nodes {
+		pbNodes = append(pbNodes, convertNodeToProto(n))
+	}
+	event := &pb.ServiceChangeEvent{
+		EventType:   EventNodeFlapping,
+		Timestamp:   time.Now().Format("2006-01-02 15:04:05"),
+		NamespaceId: node.NamespaceId,
+		GroupName:   node.GroupName,
+		ServiceName: node.ServiceName,
+		Service:     convertServiceToProto(service),
+		Nodes:       pbNodes,
+		ChangedNode: convertNodeToProto(node),
+	}
+	h.serviceSubMgr.NotifyServiceChange(node.TenantId, node.NamespaceId, node.GroupName, node.ServiceName, event)
+}
@@ -4,17 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
+	"sort"
+	"strconv"
 	"time"
 
 	"gateway/internal/servicecenter/cache"
 	"gateway/internal/servicecenter/centerlog"
+	"gateway/internal/servicecenter/dependency"
 	pb "gateway/internal/servicecenter/server/proto"
 	"gateway/internal/servicecenter/server/subscriber"
+	"gateway/internal/servicecenter/stats"
 	"gateway/internal/servicecenter/types"
 	"gateway/pkg/logger"
 	"gateway/pkg/utils/random"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -48,6 +55,7 @@ type RegistryHandler struct {
 	pb.UnimplementedServiceRegistryServer
 	serviceSubMgr  *subscriber.ServiceSubscriber
 	configProvider ConfigProvider // 配置提供者（用于告警等功能）
+	pingInterval   time.Duration  // 订阅流保活事件推送间隔
 }
 
 // NewRegistryHandler 创建服务注册发现处理器
@@ -55,9 +63,19 @@ func NewRegistryHandler(configProvider ConfigProvider) *RegistryHandler {
 	return &RegistryHandler{
 		serviceSubMgr:  subscriber.NewServiceSubscriber(),
 		configProvider: configProvider,
+		pingInterval:   subscriber.DefaultPingInterval,
 	}
 }
 
+// SetPingInterval 设置订阅流保活事件推送间隔，<=0 时使用 DefaultPingInterval
+// 只影响之后建立的订阅流
+func (h *RegistryHandler) SetPingInterval(interval time.Duration) {
+	if interval <= 0 {
+		interval = subscriber.DefaultPingInterval
+	}
+	h.pingInterval = interval
+}
+
 // validateNamespace 验证命名空间是否存在且有效（纯缓存操作）
 // 如果命名空间不存在或已被禁用，返回权限错误
 // 注意：命名空间应该在服务启动时已加载到缓存，这里只从缓存校验
@@ -80,6 +98,23 @@ func (h *RegistryHandler) validateNamespace(ctx context.Context, tenantId, names
 	return nil
 }
 
+// namespaceMetadataSchema 获取命名空间的元数据规范（纯缓存操作）
+// 规范配置格式错误时记录告警并跳过校验，避免错误配置导致服务无法注册
+func (h *RegistryHandler) namespaceMetadataSchema(ctx context.Context, tenantId, namespaceId string) *types.MetadataSchema {
+	namespace, found := cache.GetGlobalCache().GetNamespace(ctx, tenantId, namespaceId)
+	if !found || namespace == nil {
+		return nil
+	}
+	schema, err := namespace.GetMetadataSchema()
+	if err != nil {
+		logger.Warn("命名空间元数据规范配置错误，跳过元数据校验",
+			"namespaceId", namespaceId,
+			"error", err)
+		return nil
+	}
+	return schema
+}
+
 // GetServiceSubscriber 获取服务订阅管理器（供外部手动触发事件使用）
 func (h *RegistryHandler) GetServiceSubscriber() *subscriber.ServiceSubscriber {
 	return h.serviceSubMgr
@@ -120,6 +155,23 @@ func (h *RegistryHandler) RegisterService(ctx context.Context, req *pb.Service)
 		}, nil
 	}
 
+	// 按命名空间的元数据规范校验服务和节点元数据
+	schema := h.namespaceMetadataSchema(ctx, tenantID, req.NamespaceId)
+	if err := schema.ValidateService(req.Metadata); err != nil {
+		return &pb.RegisterServiceResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	if req.Node != nil {
+		if err := schema.ValidateNode(req.Node.Metadata); err != nil {
+			return &pb.RegisterServiceResponse{
+				Success: false,
+				Message: err.Error(),
+			}, nil
+		}
+	}
+
 	// 设置默认值
 	groupName := req.GroupName
 	if groupName == "" {
@@ -265,6 +317,7 @@ func (h *RegistryHandler) RegisterService(ctx context.Context, req *pb.Service)
 
 		// 直接添加到缓存（不写数据库）
 		cache.GetGlobalCache().AddNode(ctx, node)
+		stats.RecordRegister(node.TenantId, node.NamespaceId, node.GroupName)
 
 		// 从缓存获取完整的服务信息（包括所有节点）
 		// 注意：AddNode 会自动创建服务（如果不存在），所以服务应该存在
@@ -345,6 +398,7 @@ func (h *RegistryHandler) UnregisterService(ctx context.Context, req *pb.Service
 
 		// 直接调用 cache 删除节点
 		cache.GetGlobalCache().RemoveNode(ctx, tenantID, req.NamespaceId, req.GroupName, req.ServiceName, req.NodeId)
+		stats.RecordDeregister(tenantID, req.NamespaceId, req.GroupName)
 
 		// 从缓存获取完整的服务信息（包括删除后的所有节点列表）
 		service, serviceFound := cache.GetGlobalCache().GetService(ctx, tenantID, req.NamespaceId, req.GroupName, req.ServiceName)
@@ -385,7 +439,12 @@ func (h *RegistryHandler) UnregisterService(ctx context.Context, req *pb.Service
 		}, nil
 	}
 
-	// 如果没有指定 nodeId，删除整个服务
+	// 如果没有指定 nodeId，删除整个服务（服务下的节点计为注销）
+	if service, found := cache.GetGlobalCache().GetService(ctx, tenantID, req.NamespaceId, req.GroupName, req.ServiceName); found && service != nil {
+		for range service.Nodes {
+			stats.RecordDeregister(tenantID, req.NamespaceId, req.GroupName)
+		}
+	}
 	cache.GetGlobalCache().DeleteService(ctx, tenantID, req.NamespaceId, req.GroupName, req.ServiceName)
 
 	return &pb.RegistryResponse{
@@ -432,6 +491,59 @@ func (h *RegistryHandler) GetService(ctx context.Context, req *pb.ServiceKey) (*
 	}, nil
 }
 
+// ListServices 列出命名空间下的服务（包含节点统计）
+// 分组为空时列出整个命名空间的服务，结果按分组和服务名排序
+func (h *RegistryHandler) ListServices(ctx context.Context, req *pb.ListServicesRequest) (*pb.ListServicesResponse, error) {
+	tenantID := "default" // TODO: 从 context 获取
+
+	// 验证命名空间是否存在
+	if err := h.validateNamespace(ctx, tenantID, req.NamespaceId); err != nil {
+		return &pb.ListServicesResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	// 遍历缓存时只收集数据，转换和排序在遍历之外进行
+	var services []*types.Service
+	cache.GetGlobalCache().GetAllServices(func(service *types.Service) {
+		if service.TenantId != tenantID || service.NamespaceId != req.NamespaceId {
+			return
+		}
+		if req.GroupName != "" && service.GroupName != req.GroupName {
+			return
+		}
+		services = append(services, service)
+	})
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].GroupName != services[j].GroupName {
+			return services[i].GroupName < services[j].GroupName
+		}
+		return services[i].ServiceName < services[j].ServiceName
+	})
+
+	summaries := make([]*pb.ServiceSummary, 0, len(services))
+	for _, service := range services {
+		healthy := 0
+		for _, node := range service.Nodes {
+			if node.HealthyStatus == "HEALTHY" {
+				healthy++
+			}
+		}
+		summaries = append(summaries, &pb.ServiceSummary{
+			Service:          convertServiceToProto(service),
+			NodeCount:        int32(len(service.Nodes)),
+			HealthyNodeCount: int32(healthy),
+		})
+	}
+
+	return &pb.ListServicesResponse{
+		Success:  true,
+		Message:  fmt.Sprintf("found %d services", len(summaries)),
+		Services: summaries,
+	}, nil
+}
+
 // 节点注册/注销
 
 // RegisterNode 注册服务节点
@@ -489,6 +601,14 @@ func (h *RegistryHandler) RegisterNode(ctx context.Context, req *pb.Node) (*pb.R
 		}, nil
 	}
 
+	// 按命名空间的元数据规范校验节点元数据
+	if err := h.namespaceMetadataSchema(ctx, tenantID, req.NamespaceId).ValidateNode(req.Metadata); err != nil {
+		return &pb.RegisterNodeResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
 	// 判断是否为重连注册（客户端传入了 nodeId）
 	var nodeID string
 	var isReconnect bool
@@ -552,6 +672,7 @@ func (h *RegistryHandler) RegisterNode(ctx context.Context, req *pb.Node) (*pb.R
 
 	nodeNow := time.Now()
 	var node *types.ServiceNode
+	flapping := false
 
 	if isReconnect && existingNode != nil {
 		// 重连场景：更新已存在的节点信息
@@ -571,6 +692,9 @@ func (h *RegistryHandler) RegisterNode(ctx context.Context, req *pb.Node) (*pb.R
 		node.EditTime = nodeNow
 		node.ActiveFlag = "Y"
 
+		// 重连注册不能解除抖动隔离，频繁断开重连同样计入健康状态切换
+		flapping = observeNodeHealth(node, "重连注册")
+
 		// 更新缓存中的节点信息
 		cache.GetGlobalCache().UpdateNode(ctx, node)
 
@@ -608,9 +732,13 @@ func (h *RegistryHandler) RegisterNode(ctx context.Context, req *pb.Node) (*pb.R
 			ExtProperty:    "",
 		}
 
+		// 使用客户端提供的 nodeId 重新注册的节点（如被驱逐后重新注册）沿用抖动隔离状态
+		flapping = observeNodeHealth(node, "节点注册")
+
 		// 直接添加到缓存（不写数据库）
 		// 注意：AddNode 会自动创建服务（如果不存在）
 		cache.GetGlobalCache().AddNode(ctx, node)
+		stats.RecordRegister(node.TenantId, node.NamespaceId, node.GroupName)
 	}
 
 	// 从缓存获取完整的服务信息（包括所有节点）
@@ -647,6 +775,9 @@ func (h *RegistryHandler) RegisterNode(ctx context.Context, req *pb.Node) (*pb.R
 	if isReconnect {
 		eventType = "NODE_UPDATED"
 	}
+	if flapping {
+		eventType = EventNodeFlapping
+	}
 
 	event := &pb.ServiceChangeEvent{
 		EventType:   eventType,
@@ -720,6 +851,8 @@ func (h *RegistryHandler) UnregisterNode(ctx context.Context, req *pb.NodeKey) (
 
 	// 直接从缓存删除（不操作数据库）
 	cache.GetGlobalCache().RemoveNode(ctx, node.TenantId, node.NamespaceId, node.GroupName, node.ServiceName, node.NodeId)
+	stats.RecordDeregister(node.TenantId, node.NamespaceId, node.GroupName)
+	stats.ForgetNodeHealth(node.TenantId, node.NodeId)
 
 	// 从缓存获取完整的服务信息（包括删除后的所有节点列表）
 	service, serviceFound := cache.GetGlobalCache().GetService(ctx, node.TenantId, node.NamespaceId, node.GroupName, node.ServiceName)
@@ -782,6 +915,110 @@ func (h *RegistryHandler) UnregisterNode(ctx context.Context, req *pb.NodeKey) (
 	}, nil
 }
 
+// 优雅下线
+
+// 节点排空宽限期
+const (
+	DefaultDrainGracePeriod = 30 * time.Second // 客户端未指定宽限期时使用
+	MaxDrainGracePeriod     = 10 * time.Minute // 宽限期上限，避免节点长期停留在排空状态
+)
+
+// PrepareShutdown 准备下线
+// 节点标记为 DRAINING 并推送 NODE_DRAINING 事件，订阅方（如网关）据此停止向节点转发新请求；
+// 排空期间节点仍按心跳做健康检查，宽限期结束后如果节点仍处于排空状态则注销节点
+func (h *RegistryHandler) PrepareShutdown(ctx context.Context, req *pb.PrepareShutdownRequest) (*pb.PrepareShutdownResponse, error) {
+	if req.NodeId == "" {
+		return &pb.PrepareShutdownResponse{
+			Success: false,
+			Message: "nodeId is required",
+		}, nil
+	}
+
+	tenantID := "default" // TODO: 从 context 获取
+
+	node, found := cache.GetGlobalCache().GetNode(ctx, tenantID, req.NodeId)
+	if !found || node == nil {
+		// 如果节点不存在，直接返回成功（幂等性）
+		return &pb.PrepareShutdownResponse{
+			Success: true,
+			Message: "node not found or already removed",
+		}, nil
+	}
+
+	gracePeriod := time.Duration(req.GracePeriodSeconds) * time.Second
+	if gracePeriod <= 0 {
+		gracePeriod = DefaultDrainGracePeriod
+	}
+	if gracePeriod > MaxDrainGracePeriod {
+		gracePeriod = MaxDrainGracePeriod
+	}
+	removeTime := time.Now().Add(gracePeriod)
+
+	if node.InstanceStatus == types.NodeStatusDraining {
+		// 重复调用不重新计时，以首次进入排空状态时安排的移除时间为准
+		return &pb.PrepareShutdownResponse{
+			Success: true,
+			Message: "node is already draining",
+		}, nil
+	}
+
+	node.InstanceStatus = types.NodeStatusDraining
+	node.EditTime = time.Now()
+	cache.GetGlobalCache().UpdateNode(ctx, node)
+
+	logger.Info("节点进入排空状态，宽限期后移除",
+		"nodeId", node.NodeId,
+		"namespaceId", node.NamespaceId,
+		"serviceName", node.ServiceName,
+		"gracePeriod", gracePeriod)
+
+	// 通知订阅者节点进入排空状态
+	if service, serviceFound := cache.GetGlobalCache().GetService(ctx, node.TenantId, node.NamespaceId, node.GroupName, node.ServiceName); serviceFound && service != nil {
+		pbNodes := make([]*pb.Node, 0, len(service.Nodes))
+		for _, n := range service.Nodes {
+			pbNodes = append(pbNodes, convertNodeToProto(n))
+		}
+		event := &pb.ServiceChangeEvent{
+			EventType:   "NODE_DRAINING",
+			Timestamp:   time.Now().Format("2006-01-02 15:04:05"),
+			NamespaceId: node.NamespaceId,
+			GroupName:   node.GroupName,
+			ServiceName: node.ServiceName,
+			Service:     convertServiceToProto(service),
+			Nodes:       pbNodes,
+			ChangedNode: convertNodeToProto(node),
+		}
+		h.serviceSubMgr.NotifyServiceChange(node.TenantId, node.NamespaceId, node.GroupName, node.ServiceName, event)
+	}
+
+	nodeID := node.NodeId
+	time.AfterFunc(gracePeriod, func() {
+		h.removeDrainedNode(tenantID, nodeID)
+	})
+
+	return &pb.PrepareShutdownResponse{
+		Success:            true,
+		Message:            "node is draining",
+		GracePeriodSeconds: int32(gracePeriod / time.Second),
+		RemoveTime:         removeTime.Format("2006-01-02 15:04:05"),
+	}, nil
+}
+
+// removeDrainedNode 宽限期结束后注销仍处于排空状态的节点
+// 节点已被注销、驱逐或重新注册时不做处理
+func (h *RegistryHandler) removeDrainedNode(tenantID, nodeID string) {
+	ctx := context.Background()
+	node, found := cache.GetGlobalCache().GetNode(ctx, tenantID, nodeID)
+	if !found || node == nil || node.InstanceStatus != types.NodeStatusDraining {
+		return
+	}
+	if _, err := h.UnregisterNode(ctx, &pb.NodeKey{NodeId: nodeID}); err != nil {
+		logger.Warn("移除排空节点失败", "nodeId", nodeID, "error", err)
+		return
+	}
+	logger.Info("排空宽限期结束，节点已移除", "nodeId", nodeID, "serviceName", node.ServiceName)
+}
+
 // 服务发现
 
 // DiscoverNodes 发现服务节点
@@ -807,16 +1044,16 @@ func (h *RegistryHandler) DiscoverNodes(ctx context.Context, req *pb.DiscoverNod
 		}, nil
 	}
 
-	// 过滤节点
-	nodes := service.Nodes
-	if req.HealthyOnly {
-		healthyNodes := make([]*types.ServiceNode, 0)
-		for _, node := range nodes {
-			if node.HealthyStatus == "HEALTHY" {
-				healthyNodes = append(healthyNodes, node)
-			}
+	// 过滤节点（排空中和手动调整为0权重的节点不参与服务发现）
+	nodes := make([]*types.ServiceNode, 0, len(service.Nodes))
+	for _, node := range service.Nodes {
+		if node.InstanceStatus == types.NodeStatusDraining || node.IsWeightDrained() {
+			continue
+		}
+		if req.HealthyOnly && node.HealthyStatus != "HEALTHY" {
+			continue
 		}
-		nodes = healthyNodes
+		nodes = append(nodes, node)
 	}
 
 	// 转换为 protobuf 格式
@@ -832,6 +1069,51 @@ func (h *RegistryHandler) DiscoverNodes(ctx context.Context, req *pb.DiscoverNod
 	}, nil
 }
 
+// SelectInstance 选择服务实例
+// 服务端按负载均衡策略从运行中且健康的节点中选出一个，供不实现负载均衡的轻量客户端直接调用
+func (h *RegistryHandler) SelectInstance(ctx context.Context, req *pb.SelectInstanceRequest) (*pb.SelectInstanceResponse, error) {
+	tenantID := "default" // TODO: 从 context 获取
+
+	// 验证命名空间是否存在
+	if err := h.validateNamespace(ctx, tenantID, req.NamespaceId); err != nil {
+		return &pb.SelectInstanceResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	if req.ServiceName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "serviceName is required")
+	}
+
+	// 从缓存获取（使用全局单例）
+	service, found := cache.GetGlobalCache().GetService(ctx, tenantID, req.NamespaceId, req.GroupName, req.ServiceName)
+	if !found || service == nil {
+		return &pb.SelectInstanceResponse{
+			Success: false,
+			Message: fmt.Sprintf("service not found: %s", req.ServiceName),
+		}, nil
+	}
+
+	strategy := resolveLoadBalanceStrategy(service, req.Strategy)
+	node, sameZone := selectInstance(service.Nodes, strategy, req.Zone, service.WarmupDuration())
+	if node == nil {
+		return &pb.SelectInstanceResponse{
+			Success:  false,
+			Message:  "no available nodes",
+			Strategy: strategy,
+		}, nil
+	}
+
+	return &pb.SelectInstanceResponse{
+		Success:  true,
+		Message:  "selected",
+		Node:     convertNodeToProto(node),
+		Address:  net.JoinHostPort(node.IpAddress, strconv.Itoa(node.PortNumber)),
+		Strategy: strategy,
+		SameZone: sameZone,
+	}, nil
+}
+
 // 服务订阅（实时推送）
 //
 // ================================================================================
@@ -950,6 +1232,8 @@ func (h *RegistryHandler) SubscribeServices(req *pb.SubscribeServicesRequest, st
 		req.ServiceNames,
 		subscriberID,
 	)
+	dependency.TrackServices(subscriberID, tenantID, subscriberIdentity(stream.Context(), req.NamespaceId),
+		req.NamespaceId, groupName, req.ServiceNames)
 	defer func() {
 		logger.Info("服务订阅注销",
 			"subscriberID", subscriberID,
@@ -958,6 +1242,7 @@ func (h *RegistryHandler) SubscribeServices(req *pb.SubscribeServicesRequest, st
 			"groupName", groupName,
 			"serviceNames", req.ServiceNames)
 		h.serviceSubMgr.UnsubscribeMultipleServices(subscriberID)
+		dependency.Untrack(subscriberID)
 	}()
 
 	// 订阅成功后，立即推送当前服务信息给客户端（全量推送）
@@ -1023,19 +1308,7 @@ func (h *RegistryHandler) SubscribeServices(req *pb.SubscribeServicesRequest, st
 
 	// 持续监听变更事件并推送给客户端
 	// 所有服务的变更事件都会通过同一个 channel 推送
-	for {
-		select {
-		case event, ok := <-ch:
-			if !ok {
-				return nil // 通道已关闭
-			}
-			if err := stream.Send(event); err != nil {
-				return err
-			}
-		case <-stream.Context().Done():
-			return stream.Context().Err()
-		}
-	}
+	return h.pumpSubscription(stream.Context(), subscriberID, ch, stream.Send)
 }
 
 // SubscribeNamespace 订阅整个命名空间/分组下的所有服务
@@ -1084,6 +1357,8 @@ func (h *RegistryHandler) SubscribeNamespace(req *pb.SubscribeNamespaceRequest,
 		groupName,
 		subscriberID,
 	)
+	dependency.TrackNamespace(subscriberID, tenantID, subscriberIdentity(stream.Context(), req.NamespaceId),
+		req.NamespaceId, groupName)
 	defer func() {
 		logger.Info("命名空间订阅注销",
 			"subscriberID", subscriberID,
@@ -1091,22 +1366,82 @@ func (h *RegistryHandler) SubscribeNamespace(req *pb.SubscribeNamespaceRequest,
 			"namespaceId", req.NamespaceId,
 			"groupName", groupName)
 		h.serviceSubMgr.UnsubscribeNamespace(tenantID, req.NamespaceId, groupName, subscriberID)
+		dependency.Untrack(subscriberID)
 	}()
 
 	// 持续监听变更事件并推送给客户端
 	// 命名空间下所有服务的变更事件都会通过 channel 推送
+	return h.pumpSubscription(stream.Context(), subscriberID, ch, stream.Send)
+}
+
+// subscriberIdentity 解析一元订阅调用的订阅方身份，用于记录服务依赖关系
+// 订阅方通过 gRPC metadata（service-name、group-name、namespace-id）声明服务身份，未声明时以客户端IP标识
+func subscriberIdentity(ctx context.Context, namespaceId string) dependency.Consumer {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		labels := make(map[string]string, 3)
+		for key, label := range map[string]string{
+			"service-name": dependency.LabelServiceName,
+			"group-name":   dependency.LabelGroupName,
+			"namespace-id": dependency.LabelNamespaceId,
+		} {
+			if values := md.Get(key); len(values) > 0 {
+				labels[label] = values[0]
+			}
+		}
+		if consumer, ok := dependency.ConsumerFromLabels(labels, namespaceId); ok {
+			return consumer
+		}
+	}
+
+	clientIP := "unknown"
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		clientIP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(clientIP); err == nil {
+			clientIP = host
+		}
+	}
+	return dependency.ClientConsumer(clientIP)
+}
+
+// pumpSubscription 持续从订阅 channel 读取事件推送给客户端，并定期推送保活事件
+//
+// 处理流程：
+//  1. 读取到变更事件时推送给客户端
+//  2. 每隔 pingInterval 推送一次 PING 事件，探测客户端是否仍然存活
+//  3. 每次推送成功后刷新订阅者活跃时间；推送失败说明流已断开，直接返回
+//  4. channel 被空闲清理关闭时返回 Unavailable，客户端应重新订阅
+func (h *RegistryHandler) pumpSubscription(
+	ctx context.Context,
+	subscriberID string,
+	ch <-chan *pb.ServiceChangeEvent,
+	send func(*pb.ServiceChangeEvent) error,
+) error {
+	pingTicker := time.NewTicker(h.pingInterval)
+	defer pingTicker.Stop()
+
 	for {
+		var event *pb.ServiceChangeEvent
 		select {
-		case event, ok := <-ch:
+		case e, ok := <-ch:
 			if !ok {
-				return nil // 通道已关闭
+				// 通道已关闭（订阅空闲超时被清理）
+				return status.Errorf(codes.Unavailable, "subscription %s closed by server after idle timeout", subscriberID)
 			}
-			if err := stream.Send(event); err != nil {
-				return err
-			}
-		case <-stream.Context().Done():
-			return stream.Context().Err()
+			event = e
+		case <-pingTicker.C:
+			event = subscriber.NewPingEvent()
+		case <-ctx.Done():
+			return ctx.Err()
 		}
+
+		if err := send(event); err != nil {
+			logger.Warn("推送订阅事件失败，结束订阅流",
+				"subscriberID", subscriberID,
+				"eventType", event.EventType,
+				"error", err)
+			return err
+		}
+		h.serviceSubMgr.Touch(subscriberID)
 	}
 }
 
@@ -1287,7 +1622,9 @@ func (h *RegistryHandler) Heartbeat(ctx context.Context, req *pb.HeartbeatReques
 				targetNode.Weight = req.Service.Node.Weight
 				nodeUpdated = true
 			}
-			if req.Service.Node.InstanceStatus != "" && targetNode.InstanceStatus != req.Service.Node.InstanceStatus {
+			// 排空中的节点不允许通过心跳恢复状态，避免准备下线的节点重新参与服务发现
+			if req.Service.Node.InstanceStatus != "" && targetNode.InstanceStatus != req.Service.Node.InstanceStatus &&
+				targetNode.InstanceStatus != types.NodeStatusDraining {
 				targetNode.InstanceStatus = req.Service.Node.InstanceStatus
 				nodeUpdated = true
 			}
@@ -1306,14 +1643,18 @@ func (h *RegistryHandler) Heartbeat(ctx context.Context, req *pb.HeartbeatReques
 		}
 	}
 
-	// 更新节点的最后心跳时间和健康状态
+	// 更新节点的最后心跳时间和健康状态（处于抖动隔离期的节点保持不健康）
 	now := time.Now()
 	targetNode.LastBeatTime = &now
 	targetNode.HealthyStatus = types.HealthyStatusHealthy
 	targetNode.EditTime = now
+	flapping := observeNodeHealth(targetNode, "心跳")
 
 	// 更新缓存（使用 UpdateNode 方法）
 	cache.GetGlobalCache().UpdateNode(ctx, targetNode)
+	if flapping {
+		h.notifyNodeFlapping(ctx, targetNode)
+	}
 
 	return &pb.RegistryResponse{
 		Success: true,
@@ -1455,6 +1796,7 @@ func (h *RegistryHandler) recoverServiceAndNodeFromHeartbeat(ctx context.Context
 	// 直接添加到缓存（不写数据库）
 	// 注意：AddNode 会自动创建服务（如果不存在）
 	cache.GetGlobalCache().AddNode(ctx, node)
+	stats.RecordRegister(node.TenantId, node.NamespaceId, node.GroupName)
 
 	// 从缓存获取完整的服务信息（包括所有节点）
 	// 注意：AddNode 会自动创建服务（如果不存在），所以服务应该存在
@@ -1556,7 +1898,7 @@ func convertNodeToProto(node *types.ServiceNode) *pb.Node {
 		ServiceName:    node.ServiceName,
 		IpAddress:      node.IpAddress,
 		PortNumber:     int32(node.PortNumber),
-		Weight:         node.Weight,
+		Weight:         node.DiscoveryWeight(),
 		Ephemeral:      node.Ephemeral,
 		InstanceStatus: node.InstanceStatus,
 		HealthyStatus:  node.HealthyStatus,
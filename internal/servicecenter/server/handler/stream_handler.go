@@ -9,8 +9,11 @@ import (
 
 	"gateway/internal/servicecenter/cache"
 	"gateway/internal/servicecenter/centerlog"
+	"gateway/internal/servicecenter/dependency"
 	"gateway/internal/servicecenter/server/connection"
 	pb "gateway/internal/servicecenter/server/proto"
+	"gateway/internal/servicecenter/server/subscriber"
+	"gateway/pkg/crash"
 	"gateway/pkg/logger"
 
 	"google.golang.org/grpc/peer"
@@ -513,9 +516,11 @@ func (h *StreamHandler) handleSubscribeServices(conn *connection.StreamConnectio
 		serviceNames,
 		subscriberId,
 	)
+	dependency.TrackServices(subscriberId, tenantId, h.subscriberIdentity(conn, namespaceId), namespaceId, groupName, serviceNames)
 
 	// 立即推送当前缓存中的服务状态（初始快照）
 	go func() {
+		defer crash.Recover("servicecenter.subscriber.snapshot")
 		globalCache := cache.GetGlobalCache()
 		for _, serviceName := range serviceNames {
 			// 获取服务及其节点
@@ -558,7 +563,7 @@ func (h *StreamHandler) handleSubscribeServices(conn *connection.StreamConnectio
 						InstanceStatus: node.InstanceStatus,
 						HealthyStatus:  node.HealthyStatus,
 						Ephemeral:      node.Ephemeral,
-						Weight:         node.Weight,
+						Weight:         node.DiscoveryWeight(),
 					})
 				}
 			}
@@ -576,16 +581,26 @@ func (h *StreamHandler) handleSubscribeServices(conn *connection.StreamConnectio
 
 	// 启动 goroutine 接收后续变更事件并转发到连接
 	go func() {
+		defer crash.Recover("servicecenter.subscriber")
 		defer func() {
 			// 连接断开时取消订阅
 			h.registryHandler.GetServiceSubscriber().UnsubscribeMultipleServices(subscriberId)
+			dependency.Untrack(subscriberId)
 			logger.Info("取消服务订阅",
 				"connectionId", conn.ConnectionID,
 				"subscriberId", subscriberId)
 		}()
 
+		// 双向流的存活由连接 Context 和 gRPC keep-alive 判断，
+		// 连接未断开时定期刷新活跃时间，避免无变更事件的订阅被当作僵尸订阅清理
+		livenessTicker := time.NewTicker(subscriber.DefaultPingInterval)
+		defer livenessTicker.Stop()
+
 		for {
 			select {
+			case <-livenessTicker.C:
+				h.registryHandler.GetServiceSubscriber().Touch(subscriberId)
+
 			case event, ok := <-ch:
 				if !ok {
 					// Channel 已关闭
@@ -606,6 +621,7 @@ func (h *StreamHandler) handleSubscribeServices(conn *connection.StreamConnectio
 						"subscriberId", subscriberId)
 					return
 				}
+				h.registryHandler.GetServiceSubscriber().Touch(subscriberId)
 
 				logger.Debug("推送服务变更事件",
 					"connectionId", conn.ConnectionID,
@@ -645,6 +661,13 @@ func (h *StreamHandler) handleSubscribeNamespace(conn *connection.StreamConnecti
 	// 如果 groupName 不为空，订阅该组下的所有服务
 	conn.AddServiceSubscription(namespaceId, groupName, nil) // nil 表示订阅所有服务
 
+	// 记录依赖关系，连接断开时按连接ID清理
+	tenantId := conn.TenantID
+	if tenantId == "" {
+		tenantId = "default"
+	}
+	dependency.TrackNamespace(conn.ConnectionID+"_"+msg.GetRequestId(), tenantId, h.subscriberIdentity(conn, namespaceId), namespaceId, groupName)
+
 	logger.Info("客户端订阅命名空间成功",
 		"connectionId", conn.ConnectionID,
 		"clientId", conn.ClientID,
@@ -655,6 +678,25 @@ func (h *StreamHandler) handleSubscribeNamespace(conn *connection.StreamConnecti
 	return nil
 }
 
+// subscriberIdentity 解析双向流订阅方的身份，用于记录服务依赖关系
+// 优先使用握手时客户端标签声明的服务身份，其次使用该连接注册的第一个节点所属服务，都没有时以客户端ID或IP标识
+func (h *StreamHandler) subscriberIdentity(conn *connection.StreamConnection, namespaceId string) dependency.Consumer {
+	if conn.Metadata != nil {
+		if consumer, ok := dependency.ConsumerFromLabels(conn.Metadata.GetLabels(), namespaceId); ok {
+			return consumer
+		}
+	}
+	for _, nodeId := range conn.GetRegisteredNodes() {
+		if node, exists := cache.GetGlobalCache().GetNode(conn.Context, conn.TenantID, nodeId); exists && node != nil {
+			return dependency.ServiceConsumer(node.NamespaceId, node.GroupName, node.ServiceName)
+		}
+	}
+	if conn.ClientID != "" {
+		return dependency.ClientConsumer(conn.ClientID)
+	}
+	return dependency.ClientConsumer(conn.ClientIP)
+}
+
 // ========== 配置中心处理（委托给 ConfigHandler）==========
 
 // handleGetConfig 处理获取配置
@@ -665,7 +707,7 @@ func (h *StreamHandler) handleGetConfig(conn *connection.StreamConnection, msg *
 	}
 
 	// 调用 ConfigHandler
-	resp, err := h.configHandler.GetConfig(conn.Context, req)
+	resp, err := h.configHandler.GetConfig(withConfigClient(conn.Context, streamConfigClient(conn)), req)
 	if err != nil {
 		return err
 	}
@@ -811,9 +853,12 @@ func (h *StreamHandler) handleWatchConfig(conn *connection.StreamConnection, msg
 		configDataIds,
 		watcherId,
 	)
+	client := streamConfigClient(conn)
+	h.configHandler.GetConfigWatcher().SetWatcherClient(watcherId, client)
 
-	// 立即推送当前配置快照
+	// 立即推送当前配置快照（命中灰度规则时推送灰度内容）
 	go func() {
+		defer crash.Recover("servicecenter.watcher.snapshot")
 		for _, configDataId := range configDataIds {
 			// 获取配置内容
 			getReq := &pb.ConfigKey{
@@ -822,7 +867,7 @@ func (h *StreamHandler) handleWatchConfig(conn *connection.StreamConnection, msg
 				ConfigDataId: configDataId,
 			}
 
-			configResp, err := h.configHandler.GetConfig(conn.Context, getReq)
+			configResp, err := h.configHandler.GetConfig(withConfigClient(conn.Context, client), getReq)
 			if err != nil {
 				logger.Debug("获取配置快照失败，跳过初始推送",
 					"configDataId", configDataId,
@@ -862,6 +907,7 @@ func (h *StreamHandler) handleWatchConfig(conn *connection.StreamConnection, msg
 
 	// 启动 goroutine 接收后续变更事件并转发到连接
 	go func() {
+		defer crash.Recover("servicecenter.watcher")
 		defer func() {
 			// 连接断开时取消监听
 			h.configHandler.GetConfigWatcher().Unwatch(watcherId)
@@ -1035,7 +1081,10 @@ func (h *StreamHandler) cleanupConnection(conn *connection.StreamConnection) {
 		}
 	}
 
-	// 2. 从连接管理器中移除
+	// 2. 清理依赖关系中该连接的订阅（依赖关系本身保留）
+	dependency.UntrackPrefix(conn.ConnectionID + "_")
+
+	// 3. 从连接管理器中移除
 	h.connectionManager.RemoveConnection(conn.ConnectionID)
 
 	logger.Info("连接资源清理完成",
@@ -885,7 +885,7 @@ func (x *SubscribeNamespaceRequest) GetGroupName() string {
 // 服务变更事件（Server-Side Streaming）
 type ServiceChangeEvent struct {
 	state     protoimpl.MessageState `protogen:"open.v1"`
-	EventType string                 `protobuf:"bytes,1,opt,name=eventType,proto3" json:"eventType,omitempty"` // NODE_ADDED, NODE_UPDATED, NODE_REMOVED, SERVICE_UPDATED
+	EventType string                 `protobuf:"bytes,1,opt,name=eventType,proto3" json:"eventType,omitempty"` // NODE_ADDED, NODE_UPDATED, NODE_DRAINING, NODE_REMOVED, SERVICE_UPDATED
 	Timestamp string                 `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // 事件时间戳
 	// 服务标识（用于批量订阅时区分是哪个服务的变更）
 	NamespaceId   string   `protobuf:"bytes,3,opt,name=namespaceId,proto3" json:"namespaceId,omitempty"`
@@ -1036,6 +1036,469 @@ func (x *HeartbeatRequest) GetService() *Service {
 	return nil
 }
 
+// 列出服务请求
+type ListServicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId   string                 `protobuf:"bytes,1,opt,name=namespaceId,proto3" json:"namespaceId,omitempty"`
+	GroupName     string                 `protobuf:"bytes,2,opt,name=groupName,proto3" json:"groupName,omitempty"` // 可选：如果为空则列出整个命名空间的服务
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServicesRequest) Reset() {
+	*x = ListServicesRequest{}
+	mi := &file_registry_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServicesRequest) ProtoMessage() {}
+
+func (x *ListServicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_registry_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServicesRequest.ProtoReflect.Descriptor instead.
+func (*ListServicesRequest) Descriptor() ([]byte, []int) {
+	return file_registry_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListServicesRequest) GetNamespaceId() string {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return ""
+}
+
+func (x *ListServicesRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+// 服务摘要（服务信息和节点统计）
+type ServiceSummary struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Service          *Service               `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`                    // 服务基本信息（service.node 字段不使用）
+	NodeCount        int32                  `protobuf:"varint,2,opt,name=nodeCount,proto3" json:"nodeCount,omitempty"`               // 节点总数
+	HealthyNodeCount int32                  `protobuf:"varint,3,opt,name=healthyNodeCount,proto3" json:"healthyNodeCount,omitempty"` // 健康节点数
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ServiceSummary) Reset() {
+	*x = ServiceSummary{}
+	mi := &file_registry_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceSummary) ProtoMessage() {}
+
+func (x *ServiceSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_registry_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceSummary.ProtoReflect.Descriptor instead.
+func (*ServiceSummary) Descriptor() ([]byte, []int) {
+	return file_registry_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ServiceSummary) GetService() *Service {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *ServiceSummary) GetNodeCount() int32 {
+	if x != nil {
+		return x.NodeCount
+	}
+	return 0
+}
+
+func (x *ServiceSummary) GetHealthyNodeCount() int32 {
+	if x != nil {
+		return x.HealthyNodeCount
+	}
+	return 0
+}
+
+// 列出服务响应
+type ListServicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Services      []*ServiceSummary      `protobuf:"bytes,3,rep,name=services,proto3" json:"services,omitempty"` // 按分组和服务名排序
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServicesResponse) Reset() {
+	*x = ListServicesResponse{}
+	mi := &file_registry_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServicesResponse) ProtoMessage() {}
+
+func (x *ListServicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_registry_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServicesResponse.ProtoReflect.Descriptor instead.
+func (*ListServicesResponse) Descriptor() ([]byte, []int) {
+	return file_registry_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListServicesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListServicesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListServicesResponse) GetServices() []*ServiceSummary {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+// 选择服务实例请求
+// 负载均衡策略默认取服务元数据 loadBalanceStrategy，未配置时使用加权随机：
+//   - WEIGHTED_RANDOM：按节点权重随机选择
+//   - ZONE_AFFINITY：优先在调用方同可用区（节点元数据 zone）的节点中加权随机，同可用区无可用节点时跨可用区选择
+type SelectInstanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NamespaceId   string                 `protobuf:"bytes,1,opt,name=namespaceId,proto3" json:"namespaceId,omitempty"`
+	GroupName     string                 `protobuf:"bytes,2,opt,name=groupName,proto3" json:"groupName,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,3,opt,name=serviceName,proto3" json:"serviceName,omitempty"`
+	Zone          string                 `protobuf:"bytes,4,opt,name=zone,proto3" json:"zone,omitempty"`         // 调用方所在可用区（ZONE_AFFINITY 策略使用）
+	Strategy      string                 `protobuf:"bytes,5,opt,name=strategy,proto3" json:"strategy,omitempty"` // 可选：覆盖服务配置的负载均衡策略
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelectInstanceRequest) Reset() {
+	*x = SelectInstanceRequest{}
+	mi := &file_registry_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectInstanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectInstanceRequest) ProtoMessage() {}
+
+func (x *SelectInstanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_registry_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectInstanceRequest.ProtoReflect.Descriptor instead.
+func (*SelectInstanceRequest) Descriptor() ([]byte, []int) {
+	return file_registry_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SelectInstanceRequest) GetNamespaceId() string {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return ""
+}
+
+func (x *SelectInstanceRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *SelectInstanceRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *SelectInstanceRequest) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *SelectInstanceRequest) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+// 选择服务实例响应
+type SelectInstanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Node          *Node                  `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`          // 选中的节点（只从运行中且健康的节点中选择）
+	Address       string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`    // 节点调用地址 ip:port
+	Strategy      string                 `protobuf:"bytes,5,opt,name=strategy,proto3" json:"strategy,omitempty"`  // 实际使用的负载均衡策略
+	SameZone      bool                   `protobuf:"varint,6,opt,name=sameZone,proto3" json:"sameZone,omitempty"` // 选中的节点是否与调用方在同一可用区
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelectInstanceResponse) Reset() {
+	*x = SelectInstanceResponse{}
+	mi := &file_registry_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectInstanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectInstanceResponse) ProtoMessage() {}
+
+func (x *SelectInstanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_registry_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectInstanceResponse.ProtoReflect.Descriptor instead.
+func (*SelectInstanceResponse) Descriptor() ([]byte, []int) {
+	return file_registry_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SelectInstanceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SelectInstanceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SelectInstanceResponse) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *SelectInstanceResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *SelectInstanceResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *SelectInstanceResponse) GetSameZone() bool {
+	if x != nil {
+		return x.SameZone
+	}
+	return false
+}
+
+// 准备下线请求
+// 客户端停止前调用，节点标记为 DRAINING 后仍接受心跳检查，宽限期结束后由服务端移除
+type PrepareShutdownRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	NodeId             string                 `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`                          // 节点ID（必需）
+	GracePeriodSeconds int32                  `protobuf:"varint,2,opt,name=gracePeriodSeconds,proto3" json:"gracePeriodSeconds,omitempty"` // 宽限期（秒），<=0 时使用服务端默认值
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PrepareShutdownRequest) Reset() {
+	*x = PrepareShutdownRequest{}
+	mi := &file_registry_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrepareShutdownRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrepareShutdownRequest) ProtoMessage() {}
+
+func (x *PrepareShutdownRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_registry_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrepareShutdownRequest.ProtoReflect.Descriptor instead.
+func (*PrepareShutdownRequest) Descriptor() ([]byte, []int) {
+	return file_registry_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PrepareShutdownRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *PrepareShutdownRequest) GetGracePeriodSeconds() int32 {
+	if x != nil {
+		return x.GracePeriodSeconds
+	}
+	return 0
+}
+
+// 准备下线响应
+type PrepareShutdownResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Success            bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message            string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	GracePeriodSeconds int32                  `protobuf:"varint,3,opt,name=gracePeriodSeconds,proto3" json:"gracePeriodSeconds,omitempty"` // 实际使用的宽限期（秒）
+	RemoveTime         string                 `protobuf:"bytes,4,opt,name=removeTime,proto3" json:"removeTime,omitempty"`                  // 预计移除节点的时间
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PrepareShutdownResponse) Reset() {
+	*x = PrepareShutdownResponse{}
+	mi := &file_registry_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrepareShutdownResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrepareShutdownResponse) ProtoMessage() {}
+
+func (x *PrepareShutdownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_registry_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrepareShutdownResponse.ProtoReflect.Descriptor instead.
+func (*PrepareShutdownResponse) Descriptor() ([]byte, []int) {
+	return file_registry_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PrepareShutdownResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PrepareShutdownResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PrepareShutdownResponse) GetGracePeriodSeconds() int32 {
+	if x != nil {
+		return x.GracePeriodSeconds
+	}
+	return 0
+}
+
+func (x *PrepareShutdownResponse) GetRemoveTime() string {
+	if x != nil {
+		return x.RemoveTime
+	}
+	return ""
+}
+
 var File_registry_proto protoreflect.FileDescriptor
 
 const file_registry_proto_rawDesc = "" +
@@ -1130,15 +1593,52 @@ const file_registry_proto_rawDesc = "" +
 	"\vchangedNode\x18\b \x01(\v2\x0e.registry.NodeR\vchangedNode\"W\n" +
 	"\x10HeartbeatRequest\x12\x16\n" +
 	"\x06nodeId\x18\x01 \x01(\tR\x06nodeId\x12+\n" +
-	"\aservice\x18\x02 \x01(\v2\x11.registry.ServiceR\aservice2\xaf\x05\n" +
+	"\aservice\x18\x02 \x01(\v2\x11.registry.ServiceR\aservice\"U\n" +
+	"\x13ListServicesRequest\x12 \n" +
+	"\vnamespaceId\x18\x01 \x01(\tR\vnamespaceId\x12\x1c\n" +
+	"\tgroupName\x18\x02 \x01(\tR\tgroupName\"\x87\x01\n" +
+	"\x0eServiceSummary\x12+\n" +
+	"\aservice\x18\x01 \x01(\v2\x11.registry.ServiceR\aservice\x12\x1c\n" +
+	"\tnodeCount\x18\x02 \x01(\x05R\tnodeCount\x12*\n" +
+	"\x10healthyNodeCount\x18\x03 \x01(\x05R\x10healthyNodeCount\"\x80\x01\n" +
+	"\x14ListServicesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x124\n" +
+	"\bservices\x18\x03 \x03(\v2\x18.registry.ServiceSummaryR\bservices\"\xa9\x01\n" +
+	"\x15SelectInstanceRequest\x12 \n" +
+	"\vnamespaceId\x18\x01 \x01(\tR\vnamespaceId\x12\x1c\n" +
+	"\tgroupName\x18\x02 \x01(\tR\tgroupName\x12 \n" +
+	"\vserviceName\x18\x03 \x01(\tR\vserviceName\x12\x12\n" +
+	"\x04zone\x18\x04 \x01(\tR\x04zone\x12\x1a\n" +
+	"\bstrategy\x18\x05 \x01(\tR\bstrategy\"\xc2\x01\n" +
+	"\x16SelectInstanceResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\"\n" +
+	"\x04node\x18\x03 \x01(\v2\x0e.registry.NodeR\x04node\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x1a\n" +
+	"\bstrategy\x18\x05 \x01(\tR\bstrategy\x12\x1a\n" +
+	"\bsameZone\x18\x06 \x01(\bR\bsameZone\"`\n" +
+	"\x16PrepareShutdownRequest\x12\x16\n" +
+	"\x06nodeId\x18\x01 \x01(\tR\x06nodeId\x12.\n" +
+	"\x12gracePeriodSeconds\x18\x02 \x01(\x05R\x12gracePeriodSeconds\"\x9d\x01\n" +
+	"\x17PrepareShutdownResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
+	"\x12gracePeriodSeconds\x18\x03 \x01(\x05R\x12gracePeriodSeconds\x12\x1e\n" +
+	"\n" +
+	"removeTime\x18\x04 \x01(\tR\n" +
+	"removeTime2\xab\a\n" +
 	"\x0fServiceRegistry\x12G\n" +
 	"\x0fRegisterService\x12\x11.registry.Service\x1a!.registry.RegisterServiceResponse\x12E\n" +
 	"\x11UnregisterService\x12\x14.registry.ServiceKey\x1a\x1a.registry.RegistryResponse\x12@\n" +
 	"\n" +
-	"GetService\x12\x14.registry.ServiceKey\x1a\x1c.registry.GetServiceResponse\x12>\n" +
+	"GetService\x12\x14.registry.ServiceKey\x1a\x1c.registry.GetServiceResponse\x12M\n" +
+	"\fListServices\x12\x1d.registry.ListServicesRequest\x1a\x1e.registry.ListServicesResponse\x12>\n" +
 	"\fRegisterNode\x12\x0e.registry.Node\x1a\x1e.registry.RegisterNodeResponse\x12?\n" +
-	"\x0eUnregisterNode\x12\x11.registry.NodeKey\x1a\x1a.registry.RegistryResponse\x12P\n" +
-	"\rDiscoverNodes\x12\x1e.registry.DiscoverNodesRequest\x1a\x1f.registry.DiscoverNodesResponse\x12W\n" +
+	"\x0eUnregisterNode\x12\x11.registry.NodeKey\x1a\x1a.registry.RegistryResponse\x12V\n" +
+	"\x0fPrepareShutdown\x12 .registry.PrepareShutdownRequest\x1a!.registry.PrepareShutdownResponse\x12P\n" +
+	"\rDiscoverNodes\x12\x1e.registry.DiscoverNodesRequest\x1a\x1f.registry.DiscoverNodesResponse\x12S\n" +
+	"\x0eSelectInstance\x12\x1f.registry.SelectInstanceRequest\x1a .registry.SelectInstanceResponse\x12W\n" +
 	"\x11SubscribeServices\x12\".registry.SubscribeServicesRequest\x1a\x1c.registry.ServiceChangeEvent0\x01\x12Y\n" +
 	"\x12SubscribeNamespace\x12#.registry.SubscribeNamespaceRequest\x1a\x1c.registry.ServiceChangeEvent0\x01\x12C\n" +
 	"\tHeartbeat\x12\x1a.registry.HeartbeatRequest\x1a\x1a.registry.RegistryResponseB3Z1gateway/internal/servicecenter/server/proto;protob\x06proto3"
@@ -1155,7 +1655,7 @@ func file_registry_proto_rawDescGZIP() []byte {
 	return file_registry_proto_rawDescData
 }
 
-var file_registry_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_registry_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_registry_proto_goTypes = []any{
 	(*RegistryResponse)(nil),          // 0: registry.RegistryResponse
 	(*Service)(nil),                   // 1: registry.Service
@@ -1171,15 +1671,22 @@ var file_registry_proto_goTypes = []any{
 	(*SubscribeNamespaceRequest)(nil), // 11: registry.SubscribeNamespaceRequest
 	(*ServiceChangeEvent)(nil),        // 12: registry.ServiceChangeEvent
 	(*HeartbeatRequest)(nil),          // 13: registry.HeartbeatRequest
-	nil,                               // 14: registry.Service.MetadataEntry
-	nil,                               // 15: registry.Service.TagsEntry
-	nil,                               // 16: registry.Node.MetadataEntry
+	(*ListServicesRequest)(nil),       // 14: registry.ListServicesRequest
+	(*ServiceSummary)(nil),            // 15: registry.ServiceSummary
+	(*ListServicesResponse)(nil),      // 16: registry.ListServicesResponse
+	(*SelectInstanceRequest)(nil),     // 17: registry.SelectInstanceRequest
+	(*SelectInstanceResponse)(nil),    // 18: registry.SelectInstanceResponse
+	(*PrepareShutdownRequest)(nil),    // 19: registry.PrepareShutdownRequest
+	(*PrepareShutdownResponse)(nil),   // 20: registry.PrepareShutdownResponse
+	nil,                               // 21: registry.Service.MetadataEntry
+	nil,                               // 22: registry.Service.TagsEntry
+	nil,                               // 23: registry.Node.MetadataEntry
 }
 var file_registry_proto_depIdxs = []int32{
-	14, // 0: registry.Service.metadata:type_name -> registry.Service.MetadataEntry
-	15, // 1: registry.Service.tags:type_name -> registry.Service.TagsEntry
+	21, // 0: registry.Service.metadata:type_name -> registry.Service.MetadataEntry
+	22, // 1: registry.Service.tags:type_name -> registry.Service.TagsEntry
 	2,  // 2: registry.Service.node:type_name -> registry.Node
-	16, // 3: registry.Node.metadata:type_name -> registry.Node.MetadataEntry
+	23, // 3: registry.Node.metadata:type_name -> registry.Node.MetadataEntry
 	1,  // 4: registry.GetServiceResponse.service:type_name -> registry.Service
 	2,  // 5: registry.GetServiceResponse.nodes:type_name -> registry.Node
 	2,  // 6: registry.DiscoverNodesResponse.nodes:type_name -> registry.Node
@@ -1187,29 +1694,38 @@ var file_registry_proto_depIdxs = []int32{
 	2,  // 8: registry.ServiceChangeEvent.nodes:type_name -> registry.Node
 	2,  // 9: registry.ServiceChangeEvent.changedNode:type_name -> registry.Node
 	1,  // 10: registry.HeartbeatRequest.service:type_name -> registry.Service
-	1,  // 11: registry.ServiceRegistry.RegisterService:input_type -> registry.Service
-	3,  // 12: registry.ServiceRegistry.UnregisterService:input_type -> registry.ServiceKey
-	3,  // 13: registry.ServiceRegistry.GetService:input_type -> registry.ServiceKey
-	2,  // 14: registry.ServiceRegistry.RegisterNode:input_type -> registry.Node
-	4,  // 15: registry.ServiceRegistry.UnregisterNode:input_type -> registry.NodeKey
-	9,  // 16: registry.ServiceRegistry.DiscoverNodes:input_type -> registry.DiscoverNodesRequest
-	10, // 17: registry.ServiceRegistry.SubscribeServices:input_type -> registry.SubscribeServicesRequest
-	11, // 18: registry.ServiceRegistry.SubscribeNamespace:input_type -> registry.SubscribeNamespaceRequest
-	13, // 19: registry.ServiceRegistry.Heartbeat:input_type -> registry.HeartbeatRequest
-	5,  // 20: registry.ServiceRegistry.RegisterService:output_type -> registry.RegisterServiceResponse
-	0,  // 21: registry.ServiceRegistry.UnregisterService:output_type -> registry.RegistryResponse
-	6,  // 22: registry.ServiceRegistry.GetService:output_type -> registry.GetServiceResponse
-	7,  // 23: registry.ServiceRegistry.RegisterNode:output_type -> registry.RegisterNodeResponse
-	0,  // 24: registry.ServiceRegistry.UnregisterNode:output_type -> registry.RegistryResponse
-	8,  // 25: registry.ServiceRegistry.DiscoverNodes:output_type -> registry.DiscoverNodesResponse
-	12, // 26: registry.ServiceRegistry.SubscribeServices:output_type -> registry.ServiceChangeEvent
-	12, // 27: registry.ServiceRegistry.SubscribeNamespace:output_type -> registry.ServiceChangeEvent
-	0,  // 28: registry.ServiceRegistry.Heartbeat:output_type -> registry.RegistryResponse
-	20, // [20:29] is the sub-list for method output_type
-	11, // [11:20] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	1,  // 11: registry.ServiceSummary.service:type_name -> registry.Service
+	15, // 12: registry.ListServicesResponse.services:type_name -> registry.ServiceSummary
+	2,  // 13: registry.SelectInstanceResponse.node:type_name -> registry.Node
+	1,  // 14: registry.ServiceRegistry.RegisterService:input_type -> registry.Service
+	3,  // 15: registry.ServiceRegistry.UnregisterService:input_type -> registry.ServiceKey
+	3,  // 16: registry.ServiceRegistry.GetService:input_type -> registry.ServiceKey
+	14, // 17: registry.ServiceRegistry.ListServices:input_type -> registry.ListServicesRequest
+	2,  // 18: registry.ServiceRegistry.RegisterNode:input_type -> registry.Node
+	4,  // 19: registry.ServiceRegistry.UnregisterNode:input_type -> registry.NodeKey
+	19, // 20: registry.ServiceRegistry.PrepareShutdown:input_type -> registry.PrepareShutdownRequest
+	9,  // 21: registry.ServiceRegistry.DiscoverNodes:input_type -> registry.DiscoverNodesRequest
+	17, // 22: registry.ServiceRegistry.SelectInstance:input_type -> registry.SelectInstanceRequest
+	10, // 23: registry.ServiceRegistry.SubscribeServices:input_type -> registry.SubscribeServicesRequest
+	11, // 24: registry.ServiceRegistry.SubscribeNamespace:input_type -> registry.SubscribeNamespaceRequest
+	13, // 25: registry.ServiceRegistry.Heartbeat:input_type -> registry.HeartbeatRequest
+	5,  // 26: registry.ServiceRegistry.RegisterService:output_type -> registry.RegisterServiceResponse
+	0,  // 27: registry.ServiceRegistry.UnregisterService:output_type -> registry.RegistryResponse
+	6,  // 28: registry.ServiceRegistry.GetService:output_type -> registry.GetServiceResponse
+	16, // 29: registry.ServiceRegistry.ListServices:output_type -> registry.ListServicesResponse
+	7,  // 30: registry.ServiceRegistry.RegisterNode:output_type -> registry.RegisterNodeResponse
+	0,  // 31: registry.ServiceRegistry.UnregisterNode:output_type -> registry.RegistryResponse
+	20, // 32: registry.ServiceRegistry.PrepareShutdown:output_type -> registry.PrepareShutdownResponse
+	8,  // 33: registry.ServiceRegistry.DiscoverNodes:output_type -> registry.DiscoverNodesResponse
+	18, // 34: registry.ServiceRegistry.SelectInstance:output_type -> registry.SelectInstanceResponse
+	12, // 35: registry.ServiceRegistry.SubscribeServices:output_type -> registry.ServiceChangeEvent
+	12, // 36: registry.ServiceRegistry.SubscribeNamespace:output_type -> registry.ServiceChangeEvent
+	0,  // 37: registry.ServiceRegistry.Heartbeat:output_type -> registry.RegistryResponse
+	26, // [26:38] is the sub-list for method output_type
+	14, // [14:26] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_registry_proto_init() }
@@ -1223,7 +1739,7 @@ func file_registry_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_registry_proto_rawDesc), len(file_registry_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,9 +22,12 @@ const (
 	ServiceRegistry_RegisterService_FullMethodName    = "/registry.ServiceRegistry/RegisterService"
 	ServiceRegistry_UnregisterService_FullMethodName  = "/registry.ServiceRegistry/UnregisterService"
 	ServiceRegistry_GetService_FullMethodName         = "/registry.ServiceRegistry/GetService"
+	ServiceRegistry_ListServices_FullMethodName       = "/registry.ServiceRegistry/ListServices"
 	ServiceRegistry_RegisterNode_FullMethodName       = "/registry.ServiceRegistry/RegisterNode"
 	ServiceRegistry_UnregisterNode_FullMethodName     = "/registry.ServiceRegistry/UnregisterNode"
+	ServiceRegistry_PrepareShutdown_FullMethodName    = "/registry.ServiceRegistry/PrepareShutdown"
 	ServiceRegistry_DiscoverNodes_FullMethodName      = "/registry.ServiceRegistry/DiscoverNodes"
+	ServiceRegistry_SelectInstance_FullMethodName     = "/registry.ServiceRegistry/SelectInstance"
 	ServiceRegistry_SubscribeServices_FullMethodName  = "/registry.ServiceRegistry/SubscribeServices"
 	ServiceRegistry_SubscribeNamespace_FullMethodName = "/registry.ServiceRegistry/SubscribeNamespace"
 	ServiceRegistry_Heartbeat_FullMethodName          = "/registry.ServiceRegistry/Heartbeat"
@@ -42,12 +45,18 @@ type ServiceRegistryClient interface {
 	UnregisterService(ctx context.Context, in *ServiceKey, opts ...grpc.CallOption) (*RegistryResponse, error)
 	// 获取服务信息（包含服务基本信息和节点列表）
 	GetService(ctx context.Context, in *ServiceKey, opts ...grpc.CallOption) (*GetServiceResponse, error)
+	// 列出命名空间/分组下的服务（包含节点统计）
+	ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error)
 	// 注册服务节点（nodeId 由服务端自动生成）
 	RegisterNode(ctx context.Context, in *Node, opts ...grpc.CallOption) (*RegisterNodeResponse, error)
 	// 注销服务节点
 	UnregisterNode(ctx context.Context, in *NodeKey, opts ...grpc.CallOption) (*RegistryResponse, error)
+	// 准备下线：节点进入 DRAINING 状态，不再参与服务发现，宽限期结束后移除
+	PrepareShutdown(ctx context.Context, in *PrepareShutdownRequest, opts ...grpc.CallOption) (*PrepareShutdownResponse, error)
 	// 发现服务节点（一次性查询）
 	DiscoverNodes(ctx context.Context, in *DiscoverNodesRequest, opts ...grpc.CallOption) (*DiscoverNodesResponse, error)
+	// 选择服务实例（服务端按负载均衡策略选出一个可调用节点）
+	SelectInstance(ctx context.Context, in *SelectInstanceRequest, opts ...grpc.CallOption) (*SelectInstanceResponse, error)
 	// 订阅服务节点变更（统一接口，支持订阅单个或多个服务）- Server-Side Streaming
 	// 单个服务订阅：serviceNames 数组只包含一个服务名
 	// 多个服务订阅：serviceNames 数组包含多个服务名，所有服务共用同一个 channel
@@ -96,6 +105,16 @@ func (c *serviceRegistryClient) GetService(ctx context.Context, in *ServiceKey,
 	return out, nil
 }
 
+func (c *serviceRegistryClient) ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListServicesResponse)
+	err := c.cc.Invoke(ctx, ServiceRegistry_ListServices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceRegistryClient) RegisterNode(ctx context.Context, in *Node, opts ...grpc.CallOption) (*RegisterNodeResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RegisterNodeResponse)
@@ -116,6 +135,16 @@ func (c *serviceRegistryClient) UnregisterNode(ctx context.Context, in *NodeKey,
 	return out, nil
 }
 
+func (c *serviceRegistryClient) PrepareShutdown(ctx context.Context, in *PrepareShutdownRequest, opts ...grpc.CallOption) (*PrepareShutdownResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PrepareShutdownResponse)
+	err := c.cc.Invoke(ctx, ServiceRegistry_PrepareShutdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceRegistryClient) DiscoverNodes(ctx context.Context, in *DiscoverNodesRequest, opts ...grpc.CallOption) (*DiscoverNodesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DiscoverNodesResponse)
@@ -126,6 +155,16 @@ func (c *serviceRegistryClient) DiscoverNodes(ctx context.Context, in *DiscoverN
 	return out, nil
 }
 
+func (c *serviceRegistryClient) SelectInstance(ctx context.Context, in *SelectInstanceRequest, opts ...grpc.CallOption) (*SelectInstanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SelectInstanceResponse)
+	err := c.cc.Invoke(ctx, ServiceRegistry_SelectInstance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceRegistryClient) SubscribeServices(ctx context.Context, in *SubscribeServicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ServiceChangeEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &ServiceRegistry_ServiceDesc.Streams[0], ServiceRegistry_SubscribeServices_FullMethodName, cOpts...)
@@ -186,12 +225,18 @@ type ServiceRegistryServer interface {
 	UnregisterService(context.Context, *ServiceKey) (*RegistryResponse, error)
 	// 获取服务信息（包含服务基本信息和节点列表）
 	GetService(context.Context, *ServiceKey) (*GetServiceResponse, error)
+	// 列出命名空间/分组下的服务（包含节点统计）
+	ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error)
 	// 注册服务节点（nodeId 由服务端自动生成）
 	RegisterNode(context.Context, *Node) (*RegisterNodeResponse, error)
 	// 注销服务节点
 	UnregisterNode(context.Context, *NodeKey) (*RegistryResponse, error)
+	// 准备下线：节点进入 DRAINING 状态，不再参与服务发现，宽限期结束后移除
+	PrepareShutdown(context.Context, *PrepareShutdownRequest) (*PrepareShutdownResponse, error)
 	// 发现服务节点（一次性查询）
 	DiscoverNodes(context.Context, *DiscoverNodesRequest) (*DiscoverNodesResponse, error)
+	// 选择服务实例（服务端按负载均衡策略选出一个可调用节点）
+	SelectInstance(context.Context, *SelectInstanceRequest) (*SelectInstanceResponse, error)
 	// 订阅服务节点变更（统一接口，支持订阅单个或多个服务）- Server-Side Streaming
 	// 单个服务订阅：serviceNames 数组只包含一个服务名
 	// 多个服务订阅：serviceNames 数组包含多个服务名，所有服务共用同一个 channel
@@ -219,15 +264,24 @@ func (UnimplementedServiceRegistryServer) UnregisterService(context.Context, *Se
 func (UnimplementedServiceRegistryServer) GetService(context.Context, *ServiceKey) (*GetServiceResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetService not implemented")
 }
+func (UnimplementedServiceRegistryServer) ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListServices not implemented")
+}
 func (UnimplementedServiceRegistryServer) RegisterNode(context.Context, *Node) (*RegisterNodeResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RegisterNode not implemented")
 }
 func (UnimplementedServiceRegistryServer) UnregisterNode(context.Context, *NodeKey) (*RegistryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UnregisterNode not implemented")
 }
+func (UnimplementedServiceRegistryServer) PrepareShutdown(context.Context, *PrepareShutdownRequest) (*PrepareShutdownResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PrepareShutdown not implemented")
+}
 func (UnimplementedServiceRegistryServer) DiscoverNodes(context.Context, *DiscoverNodesRequest) (*DiscoverNodesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DiscoverNodes not implemented")
 }
+func (UnimplementedServiceRegistryServer) SelectInstance(context.Context, *SelectInstanceRequest) (*SelectInstanceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SelectInstance not implemented")
+}
 func (UnimplementedServiceRegistryServer) SubscribeServices(*SubscribeServicesRequest, grpc.ServerStreamingServer[ServiceChangeEvent]) error {
 	return status.Error(codes.Unimplemented, "method SubscribeServices not implemented")
 }
@@ -312,6 +366,24 @@ func _ServiceRegistry_GetService_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServiceRegistry_ListServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceRegistryServer).ListServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceRegistry_ListServices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceRegistryServer).ListServices(ctx, req.(*ListServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ServiceRegistry_RegisterNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Node)
 	if err := dec(in); err != nil {
@@ -348,6 +420,24 @@ func _ServiceRegistry_UnregisterNode_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServiceRegistry_PrepareShutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrepareShutdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceRegistryServer).PrepareShutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceRegistry_PrepareShutdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceRegistryServer).PrepareShutdown(ctx, req.(*PrepareShutdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ServiceRegistry_DiscoverNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DiscoverNodesRequest)
 	if err := dec(in); err != nil {
@@ -366,6 +456,24 @@ func _ServiceRegistry_DiscoverNodes_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServiceRegistry_SelectInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelectInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceRegistryServer).SelectInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceRegistry_SelectInstance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceRegistryServer).SelectInstance(ctx, req.(*SelectInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ServiceRegistry_SubscribeServices_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeServicesRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -425,6 +533,10 @@ var ServiceRegistry_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetService",
 			Handler:    _ServiceRegistry_GetService_Handler,
 		},
+		{
+			MethodName: "ListServices",
+			Handler:    _ServiceRegistry_ListServices_Handler,
+		},
 		{
 			MethodName: "RegisterNode",
 			Handler:    _ServiceRegistry_RegisterNode_Handler,
@@ -433,10 +545,18 @@ var ServiceRegistry_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UnregisterNode",
 			Handler:    _ServiceRegistry_UnregisterNode_Handler,
 		},
+		{
+			MethodName: "PrepareShutdown",
+			Handler:    _ServiceRegistry_PrepareShutdown_Handler,
+		},
 		{
 			MethodName: "DiscoverNodes",
 			Handler:    _ServiceRegistry_DiscoverNodes_Handler,
 		},
+		{
+			MethodName: "SelectInstance",
+			Handler:    _ServiceRegistry_SelectInstance_Handler,
+		},
 		{
 			MethodName: "Heartbeat",
 			Handler:    _ServiceRegistry_Heartbeat_Handler,
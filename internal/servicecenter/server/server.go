@@ -14,6 +14,7 @@ import (
 	"gateway/internal/servicecenter/server/handler"
 	"gateway/internal/servicecenter/server/interceptor"
 	pb "gateway/internal/servicecenter/server/proto"
+	"gateway/internal/servicecenter/server/subscriber"
 	"gateway/internal/servicecenter/types"
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
@@ -431,6 +432,7 @@ func (s *Server) Start(ctx context.Context) error {
 	s.grpcServer = grpcServer
 	s.registryHandler = registryHandler
 	s.configHandler = configHandler
+	stopCh := s.stopCh
 	s.mu.Unlock()
 
 	listenAddr := fmt.Sprintf("%s:%d", config.ListenAddress, config.ListenPort)
@@ -454,6 +456,9 @@ func (s *Server) Start(ctx context.Context) error {
 	s.listener = listener
 	s.listenerMu.Unlock()
 
+	// 定期清理客户端异常消失后残留的僵尸订阅，服务器停止时退出
+	registryHandler.GetServiceSubscriber().StartIdleReaper(stopCh, subscriber.DefaultIdleTimeout)
+
 	logger.Info("启动 gRPC 服务器", "instanceName", config.InstanceName, "listenAddr", listenAddr)
 
 	// 创建一个通道用于接收启动错误
@@ -2,6 +2,7 @@ package subscriber
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -29,12 +30,16 @@ type ConfigWatcher struct {
 	mu sync.RWMutex
 	// 统一监听：一个 watcherID 可以监听多个配置，所有配置共用同一个 channel
 	watchers map[string]map[string]chan *pb.ConfigChangeEvent // key: watcherID -> configKey -> channel (同一个 channel)
+
+	// 监听者对应的客户端实例（IP和标签），用于灰度发布时按规则推送
+	clients map[string]types.ConfigClient // key: watcherID
 }
 
 // NewConfigWatcher 创建配置监听管理器
 func NewConfigWatcher() *ConfigWatcher {
 	return &ConfigWatcher{
 		watchers: make(map[string]map[string]chan *pb.ConfigChangeEvent),
+		clients:  make(map[string]types.ConfigClient),
 	}
 }
 
@@ -113,6 +118,19 @@ func (w *ConfigWatcher) Unwatch(watcherID string) {
 		// 删除监听记录
 		delete(w.watchers, watcherID)
 	}
+	delete(w.clients, watcherID)
+}
+
+// SetWatcherClient 记录监听者对应的客户端实例（在 Watch 之后调用）
+func (w *ConfigWatcher) SetWatcherClient(watcherID string, client types.ConfigClient) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.watchers[watcherID]; !ok {
+		return
+	}
+	client.WatcherId = watcherID
+	w.clients[watcherID] = client
 }
 
 // SendToWatcher 向特定订阅者的 channel 发送事件（用于初始推送）
@@ -193,6 +211,59 @@ func (w *ConfigWatcher) NotifyConfigChange(tenantId, namespaceId, groupName, con
 	}
 }
 
+// NotifyMatchingWatchers 只通知命中 match 的监听者（用于灰度发布和灰度回滚）
+//
+// 处理流程：
+//  1. 生成配置唯一键（configKey）
+//  2. 遍历监听了该配置的监听者，按其客户端实例判断是否命中
+//  3. 命中时通过对应的 channel 发送事件（非阻塞）
+//
+// 返回：
+//   - int: 通知的监听者数量
+func (w *ConfigWatcher) NotifyMatchingWatchers(tenantId, namespaceId, groupName, configDataId string, event *pb.ConfigChangeEvent, match func(types.ConfigClient) bool) int {
+	configKey := w.makeConfigKey(tenantId, namespaceId, groupName, configDataId)
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	notified := 0
+	for watcherID, configs := range w.watchers {
+		ch, ok := configs[configKey]
+		if !ok || !match(w.clients[watcherID]) {
+			continue
+		}
+		select {
+		case ch <- event:
+			notified++
+		default:
+			// 通道已满，丢弃事件（避免阻塞）
+		}
+	}
+	return notified
+}
+
+// ListWatcherClients 列出监听了指定配置的客户端实例，按监听者ID排序
+func (w *ConfigWatcher) ListWatcherClients(tenantId, namespaceId, groupName, configDataId string) []types.ConfigClient {
+	configKey := w.makeConfigKey(tenantId, namespaceId, groupName, configDataId)
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	clients := make([]types.ConfigClient, 0)
+	for watcherID, configs := range w.watchers {
+		if _, ok := configs[configKey]; !ok {
+			continue
+		}
+		client, ok := w.clients[watcherID]
+		if !ok {
+			client = types.ConfigClient{WatcherId: watcherID}
+		}
+		clients = append(clients, client)
+	}
+	sort.Slice(clients, func(i, j int) bool { return clients[i].WatcherId < clients[j].WatcherId })
+	return clients
+}
+
 // GetWatcherCount 获取监听者数量
 //
 // 处理流程：
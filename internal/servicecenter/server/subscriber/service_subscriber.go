@@ -74,6 +74,7 @@ import (
 //
 //	客户端断开连接时，Handler 调用 UnsubscribeMultipleServices 清理资源
 //	订阅管理器关闭 channel 并删除订阅记录
+//	客户端异常消失未关闭流时，由空闲清理（StartIdleReaper）关闭 channel，见 subscriber_liveness.go
 type ServiceSubscriber struct {
 	mu sync.RWMutex
 	// 批量订阅：一个 subscriberID 可以订阅多个服务，所有服务共用同一个 channel
@@ -81,6 +82,9 @@ type ServiceSubscriber struct {
 
 	// 命名空间订阅：订阅整个命名空间/分组
 	namespaceSubscribers map[string]map[string]chan *pb.ServiceChangeEvent // key: namespaceKey -> subscriberID -> channel
+
+	// 订阅者最近一次成功推送事件的时间，用于清理僵尸订阅
+	activity sync.Map // key: subscriberID -> *atomic.Int64(UnixNano)
 }
 
 // NewServiceSubscriber 创建服务订阅管理器
@@ -140,6 +144,7 @@ func (s *ServiceSubscriber) SubscribeMultipleServices(
 		s.batchSubscribers[subscriberID][serviceKey] = ch
 		serviceKeys = append(serviceKeys, serviceKey)
 	}
+	s.markActive(subscriberID)
 
 	logger.Info("注册批量服务订阅",
 		"subscriberID", subscriberID,
@@ -175,6 +180,7 @@ func (s *ServiceSubscriber) UnsubscribeMultipleServices(subscriberID string) {
 
 		// 删除订阅记录
 		delete(s.batchSubscribers, subscriberID)
+		s.activity.Delete(subscriberID)
 	}
 }
 
@@ -249,6 +255,7 @@ func (s *ServiceSubscriber) SubscribeNamespace(
 	// 创建订阅通道
 	ch := make(chan *pb.ServiceChangeEvent, 100)
 	s.namespaceSubscribers[namespaceKey][subscriberID] = ch
+	s.markActive(subscriberID)
 
 	return ch
 }
@@ -271,6 +278,7 @@ func (s *ServiceSubscriber) UnsubscribeNamespace(tenantId, namespaceId, groupNam
 		if ch, exists := subs[subscriberID]; exists {
 			close(ch)
 			delete(subs, subscriberID)
+			s.activity.Delete(subscriberID)
 		}
 
 		// 如果没有订阅者了，删除整个命名空间的订阅记录
@@ -457,7 +465,7 @@ func convertNodeToProto(node *types.ServiceNode) *pb.Node {
 		ServiceName:    node.ServiceName,
 		IpAddress:      node.IpAddress,
 		PortNumber:     int32(node.PortNumber),
-		Weight:         node.Weight,
+		Weight:         node.DiscoveryWeight(),
 		Ephemeral:      node.Ephemeral,
 		InstanceStatus: node.InstanceStatus,
 		HealthyStatus:  node.HealthyStatus,
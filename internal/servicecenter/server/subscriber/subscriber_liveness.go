@@ -0,0 +1,137 @@
+package subscriber
+
+import (
+	"sync/atomic"
+	"time"
+
+	pb "gateway/internal/servicecenter/server/proto"
+	"gateway/pkg/crash"
+	"gateway/pkg/logger"
+)
+
+// 订阅流保活说明：
+//
+//	客户端异常消失（断电、网络中断、进程被杀）时服务端可能长时间感知不到流断开，
+//	订阅映射中会残留无人读取的 channel。为此：
+//	1. Handler 按 DefaultPingInterval 在订阅流上主动推送 PING 事件，
+//	   每次成功推送（包括 PING）后调用 Touch 刷新订阅者活跃时间
+//	2. 订阅管理器按间隔检查活跃时间，超过空闲超时仍未刷新的订阅者视为僵尸订阅，
+//	   关闭其 channel 并删除订阅记录，Handler 读到关闭的 channel 后结束流
+const (
+	// EventTypePing 服务端保活事件类型，客户端收到后直接忽略
+	EventTypePing = "PING"
+
+	// DefaultPingInterval 订阅流保活事件推送间隔
+	DefaultPingInterval = 30 * time.Second
+
+	// DefaultIdleTimeout 订阅者空闲超时，超过该时长未成功推送任何事件的订阅者会被清理
+	DefaultIdleTimeout = 3 * DefaultPingInterval
+)
+
+// NewPingEvent 创建订阅流保活事件
+func NewPingEvent() *pb.ServiceChangeEvent {
+	return &pb.ServiceChangeEvent{
+		EventType: EventTypePing,
+		Timestamp: time.Now().Format("2006-01-02 15:04:05"),
+	}
+}
+
+// Touch 刷新订阅者活跃时间，Handler 成功向客户端推送事件后调用
+func (s *ServiceSubscriber) Touch(subscriberID string) {
+	if value, ok := s.activity.Load(subscriberID); ok {
+		value.(*atomic.Int64).Store(time.Now().UnixNano())
+	}
+}
+
+// markActive 登记订阅者并初始化活跃时间
+func (s *ServiceSubscriber) markActive(subscriberID string) {
+	lastActive := &atomic.Int64{}
+	lastActive.Store(time.Now().UnixNano())
+	s.activity.Store(subscriberID, lastActive)
+}
+
+// isIdle 判断订阅者是否超过空闲超时
+func (s *ServiceSubscriber) isIdle(subscriberID string, deadline int64) bool {
+	value, ok := s.activity.Load(subscriberID)
+	if !ok {
+		return false
+	}
+	return value.(*atomic.Int64).Load() < deadline
+}
+
+// ReapIdleSubscribers 清理超过空闲超时的订阅者
+//
+// 处理流程：
+//  1. 计算截止时间，活跃时间早于截止时间的订阅者视为僵尸订阅
+//  2. 关闭僵尸订阅者的 channel（Handler 读到关闭的 channel 后结束流）
+//  3. 删除批量订阅和命名空间订阅记录
+//
+// 返回：
+//   - int: 清理的订阅者数量
+func (s *ServiceSubscriber) ReapIdleSubscribers(idleTimeout time.Duration) int {
+	deadline := time.Now().Add(-idleTimeout).UnixNano()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reaped := 0
+	for subscriberID, services := range s.batchSubscribers {
+		if !s.isIdle(subscriberID, deadline) {
+			continue
+		}
+		for _, ch := range services {
+			close(ch)
+			break // 所有服务共用同一个 channel，只需关闭一次
+		}
+		delete(s.batchSubscribers, subscriberID)
+		s.activity.Delete(subscriberID)
+		reaped++
+		logger.Warn("清理空闲的服务订阅", "subscriberID", subscriberID, "idleTimeout", idleTimeout)
+	}
+
+	for namespaceKey, subs := range s.namespaceSubscribers {
+		for subscriberID, ch := range subs {
+			if !s.isIdle(subscriberID, deadline) {
+				continue
+			}
+			close(ch)
+			delete(subs, subscriberID)
+			s.activity.Delete(subscriberID)
+			reaped++
+			logger.Warn("清理空闲的命名空间订阅",
+				"subscriberID", subscriberID,
+				"namespaceKey", namespaceKey,
+				"idleTimeout", idleTimeout)
+		}
+		if len(subs) == 0 {
+			delete(s.namespaceSubscribers, namespaceKey)
+		}
+	}
+
+	return reaped
+}
+
+// StartIdleReaper 启动后台僵尸订阅清理，stopCh 关闭时退出
+//
+// 参数：
+//   - stopCh: 停止信号
+//   - idleTimeout: 订阅者空闲超时，<=0 时使用 DefaultIdleTimeout
+func (s *ServiceSubscriber) StartIdleReaper(stopCh <-chan struct{}, idleTimeout time.Duration) {
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultIdleTimeout
+	}
+	crash.GoSupervised("servicecenter.subscriber.reaper", func() {
+		ticker := time.NewTicker(idleTimeout / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				if reaped := s.ReapIdleSubscribers(idleTimeout); reaped > 0 {
+					logger.Info("僵尸订阅清理完成", "reaped", reaped)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,254 @@
+package stats
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 健康状态抖动检测默认参数：统计窗口内健康状态切换次数达到阈值即判定为抖动
+const (
+	DefaultFlapThreshold  = 4                // 窗口内健康状态切换次数阈值
+	DefaultFlapWindow     = 5 * time.Minute  // 统计窗口
+	DefaultFlapQuarantine = 10 * time.Minute // 判定抖动后的隔离时长
+)
+
+// healthHistoryLimit 每个节点保留的健康状态切换记录数
+const healthHistoryLimit = 50
+
+// healthHistoryRetention 节点超过该时长没有健康状态切换且不在隔离期时清理记录
+const healthHistoryRetention = 60 * time.Minute
+
+// FlapPolicy 健康状态抖动检测策略
+type FlapPolicy struct {
+	Threshold  int           // 窗口内健康状态切换次数阈值
+	Window     time.Duration // 统计窗口
+	Quarantine time.Duration // 判定抖动后的隔离时长，隔离期内节点保持不健康，心跳不能恢复健康状态
+}
+
+// HealthTransition 一次健康状态切换
+type HealthTransition struct {
+	FromStatus string    `json:"fromStatus"`       // 切换前健康状态
+	ToStatus   string    `json:"toStatus"`         // 切换后健康状态
+	Reason     string    `json:"reason,omitempty"` // 切换原因
+	Time       time.Time `json:"time"`             // 切换时间
+}
+
+// NodeHealthHistory 节点健康状态切换历史
+type NodeHealthHistory struct {
+	TenantId        string             `json:"tenantId"`                  // 租户ID
+	NodeId          string             `json:"nodeId"`                    // 节点ID
+	CurrentStatus   string             `json:"currentStatus"`             // 最近一次记录的健康状态
+	Transitions     []HealthTransition `json:"transitions"`               // 最近的健康状态切换，按时间升序
+	Flapping        bool               `json:"flapping"`                  // 是否处于抖动隔离期
+	QuarantineUntil *time.Time         `json:"quarantineUntil,omitempty"` // 隔离结束时间
+	FlapCount       int                `json:"flapCount"`                 // 累计判定为抖动的次数
+	LastFlapTime    *time.Time         `json:"lastFlapTime,omitempty"`    // 最近一次判定为抖动的时间
+}
+
+// NodeRef 节点标识
+type NodeRef struct {
+	TenantId string
+	NodeId   string
+}
+
+// nodeHealth 单个节点的健康状态记录
+type nodeHealth struct {
+	status          string             // 最近一次记录的健康状态
+	transitions     []HealthTransition // 最近的健康状态切换，按时间升序
+	countFrom       time.Time          // 只统计该时间之后的切换（隔离解除时间），避免解除后被旧记录立即再次判定为抖动
+	quarantineUntil time.Time          // 隔离结束时间，零值表示未隔离
+	flapCount       int
+	lastFlapTime    time.Time
+	lastSeen        time.Time // 最近一次记录时间
+}
+
+// HealthTracker 节点健康状态切换记录和抖动检测
+// 记录每个节点最近的健康状态切换，统计窗口内切换次数达到阈值时判定节点抖动并隔离，
+// 隔离期内节点保持不健康，避免负载均衡在不稳定的节点上反复切换流量
+type HealthTracker struct {
+	mu     sync.Mutex
+	policy FlapPolicy
+	nodes  map[string]*nodeHealth // key: tenantId/nodeId
+}
+
+// NewHealthTracker 创建健康状态记录器，策略参数不大于0时使用默认值
+func NewHealthTracker(policy FlapPolicy) *HealthTracker {
+	if policy.Threshold <= 0 {
+		policy.Threshold = DefaultFlapThreshold
+	}
+	if policy.Window <= 0 {
+		policy.Window = DefaultFlapWindow
+	}
+	if policy.Quarantine <= 0 {
+		policy.Quarantine = DefaultFlapQuarantine
+	}
+	return &HealthTracker{policy: policy, nodes: make(map[string]*nodeHealth)}
+}
+
+// defaultHealthTracker 全局健康状态记录器，注册中心处理注册、心跳和健康检查时写入
+var defaultHealthTracker = NewHealthTracker(FlapPolicy{})
+
+// Observe 记录节点当前的健康状态
+// 与上次记录的状态不同时记录一次切换，节点首次记录时只保存状态不计为切换；
+// 切换后统计窗口内的切换次数达到阈值时判定为抖动并开始隔离
+// 参数:
+//   - tenantId: 租户ID
+//   - nodeId: 节点ID
+//   - status: 当前健康状态
+//   - reason: 状态来源（如心跳、心跳超时）
+//   - now: 当前时间
+//
+// 返回值:
+//   - bool: 本次记录是否触发了抖动隔离
+func (t *HealthTracker) Observe(tenantId, nodeId, status, reason string, now time.Time) bool {
+	key := tenantId + "/" + nodeId
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, ok := t.nodes[key]
+	if !ok {
+		t.nodes[key] = &nodeHealth{status: status, lastSeen: now}
+		return false
+	}
+	h.lastSeen = now
+	if h.status == status {
+		return false
+	}
+
+	h.transitions = append(h.transitions, HealthTransition{FromStatus: h.status, ToStatus: status, Reason: reason, Time: now})
+	if len(h.transitions) > healthHistoryLimit {
+		h.transitions = h.transitions[len(h.transitions)-healthHistoryLimit:]
+	}
+	h.status = status
+
+	if now.Before(h.quarantineUntil) {
+		return false
+	}
+
+	since := now.Add(-t.policy.Window)
+	if since.Before(h.countFrom) {
+		since = h.countFrom
+	}
+	count := 0
+	for i := len(h.transitions) - 1; i >= 0 && h.transitions[i].Time.After(since); i-- {
+		count++
+	}
+	if count < t.policy.Threshold {
+		return false
+	}
+
+	h.quarantineUntil = now.Add(t.policy.Quarantine)
+	h.flapCount++
+	h.lastFlapTime = now
+	return true
+}
+
+// InQuarantine 判断节点是否处于抖动隔离期
+func (t *HealthTracker) InQuarantine(tenantId, nodeId string, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, ok := t.nodes[tenantId+"/"+nodeId]
+	return ok && now.Before(h.quarantineUntil)
+}
+
+// Sweep 解除已到期的隔离，并清理长时间没有变化的节点记录
+// 返回值:
+//   - []NodeRef: 本次解除隔离的节点，按租户和节点ID排序
+func (t *HealthTracker) Sweep(now time.Time) []NodeRef {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var released []NodeRef
+	for key, h := range t.nodes {
+		if !h.quarantineUntil.IsZero() {
+			if now.Before(h.quarantineUntil) {
+				continue
+			}
+			h.quarantineUntil = time.Time{}
+			h.countFrom = now
+			tenantId, nodeId := splitNodeKey(key)
+			released = append(released, NodeRef{TenantId: tenantId, NodeId: nodeId})
+			continue
+		}
+		if now.Sub(h.lastSeen) > healthHistoryRetention {
+			delete(t.nodes, key)
+		}
+	}
+	sort.Slice(released, func(i, j int) bool {
+		if released[i].TenantId != released[j].TenantId {
+			return released[i].TenantId < released[j].TenantId
+		}
+		return released[i].NodeId < released[j].NodeId
+	})
+	return released
+}
+
+// History 获取节点的健康状态切换历史，没有记录时返回 nil
+func (t *HealthTracker) History(tenantId, nodeId string, now time.Time) *NodeHealthHistory {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, ok := t.nodes[tenantId+"/"+nodeId]
+	if !ok {
+		return nil
+	}
+	history := &NodeHealthHistory{
+		TenantId:      tenantId,
+		NodeId:        nodeId,
+		CurrentStatus: h.status,
+		Transitions:   append([]HealthTransition(nil), h.transitions...),
+		Flapping:      now.Before(h.quarantineUntil),
+		FlapCount:     h.flapCount,
+	}
+	if history.Flapping {
+		until := h.quarantineUntil
+		history.QuarantineUntil = &until
+	}
+	if !h.lastFlapTime.IsZero() {
+		lastFlapTime := h.lastFlapTime
+		history.LastFlapTime = &lastFlapTime
+	}
+	return history
+}
+
+// Forget 删除节点的健康状态记录（节点主动注销时调用）
+func (t *HealthTracker) Forget(tenantId, nodeId string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.nodes, tenantId+"/"+nodeId)
+}
+
+// splitNodeKey 拆分节点记录的键
+func splitNodeKey(key string) (string, string) {
+	tenantId, nodeId, _ := strings.Cut(key, "/")
+	return tenantId, nodeId
+}
+
+// ObserveNodeHealth 记录节点当前的健康状态，返回本次记录是否触发了抖动隔离
+func ObserveNodeHealth(tenantId, nodeId, status, reason string) bool {
+	return defaultHealthTracker.Observe(tenantId, nodeId, status, reason, time.Now())
+}
+
+// IsNodeQuarantined 判断节点是否处于抖动隔离期
+func IsNodeQuarantined(tenantId, nodeId string) bool {
+	return defaultHealthTracker.InQuarantine(tenantId, nodeId, time.Now())
+}
+
+// ReleaseExpiredQuarantines 解除已到期的抖动隔离，返回解除隔离的节点
+func ReleaseExpiredQuarantines() []NodeRef {
+	return defaultHealthTracker.Sweep(time.Now())
+}
+
+// GetNodeHealthHistory 获取节点的健康状态切换历史，没有记录时返回 nil
+func GetNodeHealthHistory(tenantId, nodeId string) *NodeHealthHistory {
+	return defaultHealthTracker.History(tenantId, nodeId, time.Now())
+}
+
+// ForgetNodeHealth 删除节点的健康状态记录
+func ForgetNodeHealth(tenantId, nodeId string) {
+	defaultHealthTracker.Forget(tenantId, nodeId)
+}
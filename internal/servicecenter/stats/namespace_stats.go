@@ -0,0 +1,278 @@
+package stats
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"gateway/internal/servicecenter/cache"
+	"gateway/internal/servicecenter/types"
+)
+
+// ChurnWindow 注册/注销变动率的统计窗口
+const ChurnWindow = 60 * time.Minute
+
+// 心跳延迟分布的区间上限，按节点最近一次心跳的间隔归入第一个不超过上限的区间
+var heartbeatBuckets = []struct {
+	label string
+	upper time.Duration
+}{
+	{"<5s", 5 * time.Second},
+	{"5s-15s", 15 * time.Second},
+	{"15s-30s", 30 * time.Second},
+	{"30s-60s", 60 * time.Second},
+	{">60s", 0},
+}
+
+// HeartbeatBucketNone 从未上报心跳的节点所在区间
+const HeartbeatBucketNone = "none"
+
+// NamespaceStats 命名空间的服务统计
+type NamespaceStats struct {
+	TenantId    string        `json:"tenantId"`    // 租户ID
+	NamespaceId string        `json:"namespaceId"` // 命名空间ID
+	Summary     *GroupStats   `json:"summary"`     // 命名空间汇总（groupName 为查询条件，未指定时为空）
+	Groups      []*GroupStats `json:"groups"`      // 按分组统计，按分组名称排序
+	StatTime    time.Time     `json:"statTime"`    // 统计时间
+}
+
+// GroupStats 分组的服务统计
+type GroupStats struct {
+	GroupName      string `json:"groupName"`      // 分组名称
+	ServiceCount   int    `json:"serviceCount"`   // 服务总数
+	NodeCount      int    `json:"nodeCount"`      // 节点总数
+	HealthyNodes   int    `json:"healthyNodes"`   // 健康节点数
+	UnhealthyNodes int    `json:"unhealthyNodes"` // 不健康节点数
+	UnknownNodes   int    `json:"unknownNodes"`   // 健康状态未知的节点数
+
+	Registrations   int     `json:"registrations"`   // 统计窗口内的节点注册次数
+	Deregistrations int     `json:"deregistrations"` // 统计窗口内的节点注销/驱逐次数
+	ChurnPerMinute  float64 `json:"churnPerMinute"`  // 统计窗口内平均每分钟的注册+注销次数
+	WindowMinutes   int     `json:"windowMinutes"`   // 统计窗口（分钟）
+
+	// 心跳延迟分布：区间标签 -> 节点数，按节点当前距最近一次心跳的时长归类
+	HeartbeatLatency map[string]int `json:"heartbeatLatency"`
+}
+
+// churnBucket 一分钟内的注册/注销计数
+type churnBucket struct {
+	minute          int64 // Unix 分钟
+	registrations   int
+	deregistrations int
+}
+
+// churnRecorder 按命名空间分组记录节点注册/注销变动，只保留统计窗口内的分钟桶
+type churnRecorder struct {
+	mu      sync.Mutex
+	buckets map[string][]churnBucket // key: tenantId/namespaceId/groupName
+}
+
+// defaultRecorder 全局变动记录器，注册中心处理注册、注销和驱逐时写入
+var defaultRecorder = &churnRecorder{buckets: make(map[string][]churnBucket)}
+
+// RecordRegister 记录一次节点注册
+func RecordRegister(tenantId, namespaceId, groupName string) {
+	defaultRecorder.record(tenantId, namespaceId, groupName, time.Now(), 1, 0)
+}
+
+// RecordDeregister 记录一次节点注销（包括心跳超时驱逐和排空后移除）
+func RecordDeregister(tenantId, namespaceId, groupName string) {
+	defaultRecorder.record(tenantId, namespaceId, groupName, time.Now(), 0, 1)
+}
+
+// record 累加当前分钟桶的计数，并清理窗口外的分钟桶
+func (r *churnRecorder) record(tenantId, namespaceId, groupName string, now time.Time, registrations, deregistrations int) {
+	key := churnKey(tenantId, namespaceId, groupName)
+	minute := now.Unix() / 60
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	buckets := pruneBuckets(r.buckets[key], minute)
+	if n := len(buckets); n > 0 && buckets[n-1].minute == minute {
+		buckets[n-1].registrations += registrations
+		buckets[n-1].deregistrations += deregistrations
+	} else {
+		buckets = append(buckets, churnBucket{minute: minute, registrations: registrations, deregistrations: deregistrations})
+	}
+	r.buckets[key] = buckets
+}
+
+// counts 汇总命名空间在统计窗口内的变动，返回分组名称 -> [注册次数, 注销次数]
+func (r *churnRecorder) counts(tenantId, namespaceId string, now time.Time) map[string][2]int {
+	prefix := tenantId + "/" + namespaceId + "/"
+	minute := now.Unix() / 60
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make(map[string][2]int)
+	for key, buckets := range r.buckets {
+		if len(key) <= len(prefix) || key[:len(prefix)] != prefix {
+			continue
+		}
+		buckets = pruneBuckets(buckets, minute)
+		if len(buckets) == 0 {
+			delete(r.buckets, key)
+			continue
+		}
+		r.buckets[key] = buckets
+
+		var c [2]int
+		for _, b := range buckets {
+			c[0] += b.registrations
+			c[1] += b.deregistrations
+		}
+		result[key[len(prefix):]] = c
+	}
+	return result
+}
+
+// pruneBuckets 丢弃统计窗口之外的分钟桶，分钟桶按时间升序排列
+func pruneBuckets(buckets []churnBucket, minute int64) []churnBucket {
+	oldest := minute - int64(ChurnWindow/time.Minute) + 1
+	i := 0
+	for i < len(buckets) && buckets[i].minute < oldest {
+		i++
+	}
+	return buckets[i:]
+}
+
+// churnKey 变动记录的键
+func churnKey(tenantId, namespaceId, groupName string) string {
+	return tenantId + "/" + namespaceId + "/" + groupName
+}
+
+// CollectNamespaceStats 统计命名空间下的服务、节点健康状态、变动率和心跳延迟分布
+// 服务和节点数据来自注册中心缓存，变动率来自注册中心运行期间的记录
+// 参数:
+//   - tenantId: 租户ID
+//   - namespaceId: 命名空间ID
+//   - groupName: 分组名称，为空时统计全部分组
+//
+// 返回值:
+//   - *NamespaceStats: 命名空间统计
+func CollectNamespaceStats(tenantId, namespaceId, groupName string) *NamespaceStats {
+	var services []*types.Service
+	cache.GetGlobalCache().GetAllServices(func(service *types.Service) {
+		if service != nil && service.TenantId == tenantId && service.NamespaceId == namespaceId {
+			services = append(services, service)
+		}
+	})
+	return aggregate(tenantId, namespaceId, groupName, services, defaultRecorder.counts(tenantId, namespaceId, time.Now()), time.Now())
+}
+
+// aggregate 按分组汇总服务统计
+func aggregate(tenantId, namespaceId, groupName string, services []*types.Service, churn map[string][2]int, now time.Time) *NamespaceStats {
+	summary := newGroupStats(groupName)
+	groups := make(map[string]*GroupStats)
+	group := func(name string) *GroupStats {
+		g, ok := groups[name]
+		if !ok {
+			g = newGroupStats(name)
+			groups[name] = g
+		}
+		return g
+	}
+
+	for _, service := range services {
+		if groupName != "" && service.GroupName != groupName {
+			continue
+		}
+		g := group(service.GroupName)
+		g.ServiceCount++
+		for _, node := range service.Nodes {
+			if node == nil {
+				continue
+			}
+			g.addNode(node, now)
+		}
+	}
+	for name, c := range churn {
+		if groupName != "" && name != groupName {
+			continue
+		}
+		g := group(name)
+		g.Registrations = c[0]
+		g.Deregistrations = c[1]
+	}
+
+	result := &NamespaceStats{
+		TenantId:    tenantId,
+		NamespaceId: namespaceId,
+		Summary:     summary,
+		Groups:      make([]*GroupStats, 0, len(groups)),
+		StatTime:    now,
+	}
+	for _, g := range groups {
+		g.ChurnPerMinute = churnPerMinute(g.Registrations + g.Deregistrations)
+		summary.merge(g)
+		result.Groups = append(result.Groups, g)
+	}
+	summary.ChurnPerMinute = churnPerMinute(summary.Registrations + summary.Deregistrations)
+	sort.Slice(result.Groups, func(i, j int) bool {
+		return result.Groups[i].GroupName < result.Groups[j].GroupName
+	})
+	return result
+}
+
+// newGroupStats 创建分组统计，心跳延迟分布预置全部区间
+func newGroupStats(groupName string) *GroupStats {
+	latency := make(map[string]int, len(heartbeatBuckets)+1)
+	for _, b := range heartbeatBuckets {
+		latency[b.label] = 0
+	}
+	latency[HeartbeatBucketNone] = 0
+	return &GroupStats{
+		GroupName:        groupName,
+		WindowMinutes:    int(ChurnWindow / time.Minute),
+		HeartbeatLatency: latency,
+	}
+}
+
+// addNode 累加节点的健康状态和心跳延迟
+func (g *GroupStats) addNode(node *types.ServiceNode, now time.Time) {
+	g.NodeCount++
+	switch node.HealthyStatus {
+	case types.HealthyStatusHealthy:
+		g.HealthyNodes++
+	case types.HealthyStatusUnhealthy:
+		g.UnhealthyNodes++
+	default:
+		g.UnknownNodes++
+	}
+	g.HeartbeatLatency[heartbeatBucket(node.LastBeatTime, now)]++
+}
+
+// merge 将分组统计累加到汇总
+func (g *GroupStats) merge(other *GroupStats) {
+	g.ServiceCount += other.ServiceCount
+	g.NodeCount += other.NodeCount
+	g.HealthyNodes += other.HealthyNodes
+	g.UnhealthyNodes += other.UnhealthyNodes
+	g.UnknownNodes += other.UnknownNodes
+	g.Registrations += other.Registrations
+	g.Deregistrations += other.Deregistrations
+	for label, count := range other.HeartbeatLatency {
+		g.HeartbeatLatency[label] += count
+	}
+}
+
+// heartbeatBucket 按距最近一次心跳的时长确定心跳延迟区间
+func heartbeatBucket(lastBeatTime *time.Time, now time.Time) string {
+	if lastBeatTime == nil {
+		return HeartbeatBucketNone
+	}
+	elapsed := now.Sub(*lastBeatTime)
+	for _, b := range heartbeatBuckets {
+		if b.upper == 0 || elapsed < b.upper {
+			return b.label
+		}
+	}
+	return heartbeatBuckets[len(heartbeatBuckets)-1].label
+}
+
+// churnPerMinute 统计窗口内平均每分钟的变动次数
+func churnPerMinute(total int) float64 {
+	return float64(total) / (ChurnWindow.Minutes())
+}
@@ -0,0 +1,145 @@
+package types
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// grayReleaseKey 灰度发布状态在 ConfigData.ExtProperty 中的键
+const grayReleaseKey = "grayRelease"
+
+// ConfigClient 监听配置的客户端实例，用于匹配灰度规则
+type ConfigClient struct {
+	WatcherId string            `json:"watcherId"`        // 监听者ID
+	ClientIp  string            `json:"clientIp"`         // 客户端IP
+	Labels    map[string]string `json:"labels,omitempty"` // 客户端标签
+}
+
+// ConfigGrayRelease 配置灰度发布状态
+// 灰度期间正式配置保持不变，只有匹配规则的客户端实例获取并接收灰度内容；
+// 全量发布时灰度内容成为正式配置，回滚时清除灰度状态并向灰度实例重新推送正式配置
+type ConfigGrayRelease struct {
+	ConfigContent string            `json:"configContent"`          // 灰度配置内容
+	Md5Value      string            `json:"md5Value"`               // 灰度配置内容的MD5值
+	ClientIps     []string          `json:"clientIps,omitempty"`    // 灰度客户端IP列表
+	Labels        map[string]string `json:"labels,omitempty"`       // 灰度客户端标签，客户端需包含全部标签
+	BaseVersion   int64             `json:"baseVersion"`            // 发起灰度时的正式配置版本号
+	ChangeReason  string            `json:"changeReason,omitempty"` // 变更原因
+	StartTime     time.Time         `json:"startTime"`              // 灰度开始时间
+	Operator      string            `json:"operator"`               // 发起人
+}
+
+// Validate 校验灰度规则，并计算灰度内容的MD5值
+func (g *ConfigGrayRelease) Validate() error {
+	if g.ConfigContent == "" {
+		return fmt.Errorf("灰度配置内容不能为空")
+	}
+
+	ips := make([]string, 0, len(g.ClientIps))
+	for _, ip := range g.ClientIps {
+		if ip = strings.TrimSpace(ip); ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	g.ClientIps = ips
+	if len(g.ClientIps) == 0 && len(g.Labels) == 0 {
+		return fmt.Errorf("灰度规则不能为空，至少需要指定客户端IP或标签")
+	}
+
+	g.Md5Value = fmt.Sprintf("%x", md5.Sum([]byte(g.ConfigContent)))
+	return nil
+}
+
+// Matches 判断客户端是否命中灰度规则
+// 同时指定IP和标签时，命中任意一项即可
+func (g *ConfigGrayRelease) Matches(client ConfigClient) bool {
+	for _, ip := range g.ClientIps {
+		if ip == client.ClientIp {
+			return true
+		}
+	}
+	if len(g.Labels) == 0 {
+		return false
+	}
+	for key, value := range g.Labels {
+		if client.Labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// GrayRelease 解析配置的灰度发布状态，未处于灰度时返回 nil
+func (c *ConfigData) GrayRelease() *ConfigGrayRelease {
+	if c == nil || c.ExtProperty == "" {
+		return nil
+	}
+	var ext map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(c.ExtProperty), &ext); err != nil {
+		return nil
+	}
+	raw, ok := ext[grayReleaseKey]
+	if !ok || string(raw) == "null" {
+		return nil
+	}
+	var gray ConfigGrayRelease
+	if err := json.Unmarshal(raw, &gray); err != nil || gray.ConfigContent == "" {
+		return nil
+	}
+	return &gray
+}
+
+// SetGrayRelease 写入灰度发布状态，gray 为 nil 时清除；扩展属性中的其他键保持不变
+func (c *ConfigData) SetGrayRelease(gray *ConfigGrayRelease) error {
+	ext := make(map[string]json.RawMessage)
+	if c.ExtProperty != "" {
+		if err := json.Unmarshal([]byte(c.ExtProperty), &ext); err != nil {
+			return fmt.Errorf("解析配置扩展属性失败: %w", err)
+		}
+	}
+
+	if gray == nil {
+		delete(ext, grayReleaseKey)
+	} else {
+		raw, err := json.Marshal(gray)
+		if err != nil {
+			return fmt.Errorf("序列化灰度发布状态失败: %w", err)
+		}
+		ext[grayReleaseKey] = raw
+	}
+
+	if len(ext) == 0 {
+		c.ExtProperty = ""
+		return nil
+	}
+	data, err := json.Marshal(ext)
+	if err != nil {
+		return fmt.Errorf("序列化配置扩展属性失败: %w", err)
+	}
+	c.ExtProperty = string(data)
+	return nil
+}
+
+// ForClient 返回客户端实际应获取的配置：命中灰度规则时为灰度内容，否则为正式配置
+func (c *ConfigData) ForClient(client ConfigClient) *ConfigData {
+	gray := c.GrayRelease()
+	if gray == nil || !gray.Matches(client) {
+		return c
+	}
+	return c.GrayView()
+}
+
+// GrayView 返回以灰度内容替换正式内容的配置副本，未处于灰度时返回配置本身
+func (c *ConfigData) GrayView() *ConfigData {
+	gray := c.GrayRelease()
+	if gray == nil {
+		return c
+	}
+	view := *c
+	view.ConfigContent = gray.ConfigContent
+	view.Md5Value = gray.Md5Value
+	return &view
+}
@@ -0,0 +1,174 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 元数据值格式
+const (
+	MetadataFormatAny    = ""       // 不限制格式
+	MetadataFormatSemver = "SEMVER" // 语义化版本号，如 1.2.3、v1.2.3-beta.1
+	MetadataFormatEnum   = "ENUM"   // 取值必须在 values 列表中
+	MetadataFormatRegex  = "REGEX"  // 取值必须匹配 pattern 正则表达式
+	MetadataFormatNumber = "NUMBER" // 数字
+)
+
+// metadataSchemaKey 元数据规范在命名空间 extProperty 中的键名
+const metadataSchemaKey = "metadataSchema"
+
+// semverPattern 语义化版本号格式，允许 v 前缀
+var semverPattern = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// MetadataSchema 命名空间的元数据规范（从 ExtProperty 解析）
+// 服务和节点注册时按规范校验元数据，保证服务发现使用的元数据一致
+type MetadataSchema struct {
+	Service []MetadataRule `json:"service"` // 服务元数据规则
+	Node    []MetadataRule `json:"node"`    // 节点元数据规则
+}
+
+// MetadataRule 单个元数据键的校验规则
+type MetadataRule struct {
+	Key      string   `json:"key"`      // 元数据键
+	Required bool     `json:"required"` // 是否必填
+	Format   string   `json:"format"`   // 值格式：SEMVER、ENUM、REGEX、NUMBER，为空不限制
+	Values   []string `json:"values"`   // ENUM 格式的可选值
+	Pattern  string   `json:"pattern"`  // REGEX 格式的正则表达式
+
+	regex *regexp.Regexp // 解析时预编译的正则
+}
+
+// ParseMetadataSchemaFromExtProperty 从命名空间 extProperty JSON 字符串解析元数据规范
+// 格式示例：
+//
+//	{"metadataSchema": {
+//	  "service": [{"key": "version", "required": true, "format": "SEMVER"}],
+//	  "node": [{"key": "zone", "required": true, "format": "ENUM", "values": ["az1", "az2"]}]
+//	}}
+//
+// 返回值:
+//   - *MetadataSchema: 元数据规范，未配置时返回 nil
+//   - error: 规范配置格式错误
+func ParseMetadataSchemaFromExtProperty(extProperty string) (*MetadataSchema, error) {
+	if strings.TrimSpace(extProperty) == "" {
+		return nil, nil
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(extProperty), &m); err != nil {
+		// extProperty 不是 JSON 对象时视为未配置规范
+		return nil, nil
+	}
+	raw, ok := m[metadataSchemaKey]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var schema MetadataSchema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, fmt.Errorf("invalid metadataSchema: %w", err)
+	}
+	for _, rules := range [][]MetadataRule{schema.Service, schema.Node} {
+		for i := range rules {
+			if err := rules[i].compile(); err != nil {
+				return nil, fmt.Errorf("invalid metadataSchema: %w", err)
+			}
+		}
+	}
+	if len(schema.Service) == 0 && len(schema.Node) == 0 {
+		return nil, nil
+	}
+	return &schema, nil
+}
+
+// compile 规范化并校验规则配置
+func (r *MetadataRule) compile() error {
+	r.Key = strings.TrimSpace(r.Key)
+	if r.Key == "" {
+		return fmt.Errorf("rule key is required")
+	}
+	r.Format = strings.ToUpper(strings.TrimSpace(r.Format))
+	switch r.Format {
+	case MetadataFormatAny, MetadataFormatSemver, MetadataFormatNumber:
+	case MetadataFormatEnum:
+		if len(r.Values) == 0 {
+			return fmt.Errorf("rule %q: values is required for ENUM format", r.Key)
+		}
+	case MetadataFormatRegex:
+		regex, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return fmt.Errorf("rule %q: invalid pattern: %v", r.Key, err)
+		}
+		r.regex = regex
+	default:
+		return fmt.Errorf("rule %q: unsupported format %q", r.Key, r.Format)
+	}
+	return nil
+}
+
+// ValidateService 按规范校验服务元数据
+func (s *MetadataSchema) ValidateService(metadata map[string]string) error {
+	if s == nil {
+		return nil
+	}
+	return validateMetadata("service", s.Service, metadata)
+}
+
+// ValidateNode 按规范校验节点元数据
+func (s *MetadataSchema) ValidateNode(metadata map[string]string) error {
+	if s == nil {
+		return nil
+	}
+	return validateMetadata("node", s.Node, metadata)
+}
+
+// validateMetadata 校验元数据，返回所有不符合规则的项
+func validateMetadata(target string, rules []MetadataRule, metadata map[string]string) error {
+	var violations []string
+	for i := range rules {
+		if violation := rules[i].check(metadata); violation != "" {
+			violations = append(violations, fmt.Sprintf("%s metadata %s", target, violation))
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("metadata validation failed: %s", strings.Join(violations, "; "))
+}
+
+// check 校验单个元数据键，符合规则时返回空字符串
+func (r *MetadataRule) check(metadata map[string]string) string {
+	value, exists := metadata[r.Key]
+	if !exists || value == "" {
+		if r.Required {
+			return fmt.Sprintf("%q is required", r.Key)
+		}
+		return ""
+	}
+
+	switch r.Format {
+	case MetadataFormatSemver:
+		if !semverPattern.MatchString(value) {
+			return fmt.Sprintf("%q must be a semantic version (e.g. 1.2.3), got %q", r.Key, value)
+		}
+	case MetadataFormatNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Sprintf("%q must be a number, got %q", r.Key, value)
+		}
+	case MetadataFormatEnum:
+		for _, allowed := range r.Values {
+			if value == allowed {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%q must be one of [%s], got %q", r.Key, strings.Join(r.Values, ", "), value)
+	case MetadataFormatRegex:
+		if r.regex != nil && !r.regex.MatchString(value) {
+			return fmt.Sprintf("%q must match pattern %s, got %q", r.Key, r.Pattern, value)
+		}
+	}
+	return ""
+}
@@ -1,6 +1,11 @@
 package types
 
-import "time"
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Service 服务实体
 // 对应数据库表：HUB_SERVICE
@@ -53,3 +58,35 @@ const (
 	ServiceTypeEtcd      = "ETCD"      // ETCD
 	ServiceTypeZookeeper = "ZOOKEEPER" // ZooKeeper
 )
+
+// ServiceMetadataKeyWarmupSeconds 服务元数据中配置实例预热时长（秒）的键
+const ServiceMetadataKeyWarmupSeconds = "warmupSeconds"
+
+// WarmupDuration 解析服务元数据中配置的实例预热时长
+// 新注册的节点在预热时长内按注册时长线性提升有效权重，避免刚启动的后端（如 JVM 未完成 JIT 和缓存预热）承受全量流量
+// 返回值:
+//   - time.Duration: 预热时长，未配置或配置无效时返回0，表示不预热
+func (s *Service) WarmupDuration() time.Duration {
+	if s == nil || s.MetadataJson == "" {
+		return 0
+	}
+	var metadata map[string]interface{}
+	if err := json.Unmarshal([]byte(s.MetadataJson), &metadata); err != nil {
+		return 0
+	}
+	var seconds float64
+	switch value := metadata[ServiceMetadataKeyWarmupSeconds].(type) {
+	case float64:
+		seconds = value
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return 0
+		}
+		seconds = parsed
+	}
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,54 @@
+package types
+
+import "time"
+
+// ServiceDependency 服务依赖关系（订阅方 -> 被订阅服务）
+// 对应数据库表：HUB_SERVICE_DEPENDENCY
+// 订阅方订阅服务或命名空间时记录，用于服务依赖拓扑和维护前的影响范围评估
+type ServiceDependency struct {
+	// 主键和租户信息
+	DependencyId string `json:"dependencyId" db:"dependencyId" query:"dependencyId"`                 // 依赖关系ID，由订阅方和被订阅服务计算得出，主键
+	TenantId     string `json:"tenantId" db:"tenantId" form:"tenantId" query:"tenantId"`             // 租户ID，用于多租户数据隔离
+	NamespaceId  string `json:"namespaceId" db:"namespaceId" form:"namespaceId" query:"namespaceId"` // 被订阅服务的命名空间ID
+
+	// 订阅方（消费者）
+	ConsumerType        string `json:"consumerType" db:"consumerType"`               // 订阅方类型(SERVICE:已注册的服务,CLIENT:未声明服务身份的客户端)
+	ConsumerNamespaceId string `json:"consumerNamespaceId" db:"consumerNamespaceId"` // 订阅方命名空间ID
+	ConsumerGroupName   string `json:"consumerGroupName" db:"consumerGroupName"`     // 订阅方分组名称
+	ConsumerName        string `json:"consumerName" db:"consumerName"`               // 订阅方服务名称，CLIENT 类型时为客户端ID或IP
+
+	// 被订阅方（提供者）
+	ProviderGroupName   string `json:"providerGroupName" db:"providerGroupName"`     // 被订阅服务分组名称
+	ProviderServiceName string `json:"providerServiceName" db:"providerServiceName"` // 被订阅服务名称，订阅整个命名空间/分组时为 *
+
+	// 依赖信息
+	SubscribeType string    `json:"subscribeType" db:"subscribeType"` // 订阅方式(SERVICE:订阅服务,NAMESPACE:订阅命名空间/分组)
+	FirstSeenTime time.Time `json:"firstSeenTime" db:"firstSeenTime"` // 首次订阅时间
+	LastSeenTime  time.Time `json:"lastSeenTime" db:"lastSeenTime"`   // 最近一次订阅时间
+
+	// 通用字段（对应数据库 DATETIME/DATE 类型）
+	AddTime        time.Time `json:"addTime" db:"addTime"`                                            // 创建时间（DATETIME/DATE NOT NULL）
+	AddWho         string    `json:"addWho" db:"addWho" form:"addWho"`                                // 创建人ID
+	EditTime       time.Time `json:"editTime" db:"editTime"`                                          // 最后修改时间（DATETIME/DATE NOT NULL）
+	EditWho        string    `json:"editWho" db:"editWho" form:"editWho"`                             // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" db:"oprSeqFlag"`                                      // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" db:"currentVersion"`                              // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" db:"activeFlag" form:"activeFlag" query:"activeFlag"` // 活动状态标记(N非活动,Y活动)
+	NoteText       string    `json:"noteText" db:"noteText" form:"noteText"`                          // 备注信息
+	ExtProperty    string    `json:"extProperty" db:"extProperty" form:"extProperty"`                 // 扩展属性，JSON格式
+}
+
+// 订阅方类型常量
+const (
+	ConsumerTypeService = "SERVICE" // 已声明服务身份的订阅方
+	ConsumerTypeClient  = "CLIENT"  // 未声明服务身份的客户端
+)
+
+// 订阅方式常量
+const (
+	SubscribeTypeService   = "SERVICE"   // 订阅指定服务
+	SubscribeTypeNamespace = "NAMESPACE" // 订阅整个命名空间/分组
+)
+
+// AllServices 订阅整个命名空间/分组时的被订阅服务名称
+const AllServices = "*"
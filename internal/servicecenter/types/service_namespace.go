@@ -30,3 +30,11 @@ type Namespace struct {
 	NoteText       string    `json:"noteText" db:"noteText" form:"noteText"`                          // 备注信息
 	ExtProperty    string    `json:"extProperty" db:"extProperty" form:"extProperty"`                 // 扩展属性，JSON格式
 }
+
+// GetMetadataSchema 解析命名空间的元数据规范（保存在 ExtProperty 中）
+// 返回值:
+//   - *MetadataSchema: 元数据规范，未配置时返回 nil
+//   - error: 规范配置格式错误
+func (n *Namespace) GetMetadataSchema() (*MetadataSchema, error) {
+	return ParseMetadataSchemaFromExtProperty(n.ExtProperty)
+}
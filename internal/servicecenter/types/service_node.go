@@ -1,6 +1,10 @@
 package types
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // ServiceNode 服务节点实体
 // 对应数据库表：HUB_SERVICE_NODE
@@ -52,6 +56,7 @@ const (
 	NodeStatusDown         = "DOWN"           // 已停止
 	NodeStatusStarting     = "STARTING"       // 启动中
 	NodeStatusOutOfService = "OUT_OF_SERVICE" // 暂停服务
+	NodeStatusDraining     = "DRAINING"       // 排空中（准备下线，不参与服务发现，宽限期后移除）
 )
 
 // HealthyStatus 健康状态常量
@@ -60,3 +65,103 @@ const (
 	HealthyStatusUnhealthy = "UNHEALTHY" // 不健康
 	HealthyStatusUnknown   = "UNKNOWN"   // 未知
 )
+
+// warmupMinWeightRatio 预热刚开始时有效权重占配置权重的最低比例
+const warmupMinWeightRatio = 0.01
+
+// EffectiveWeight 计算节点参与负载均衡的有效权重
+// 节点注册时长未达到预热时长时，有效权重按注册时长占预热时长的比例线性增长，最低为配置权重的1%；
+// 配置权重为手动调整的权重（如有），否则为注册权重
+// 参数:
+//   - warmup: 预热时长，<=0 表示不预热
+//   - now: 当前时间
+//
+// 返回值:
+//   - float64: 有效权重，预热结束或未预热时等于配置权重
+func (n *ServiceNode) EffectiveWeight(warmup time.Duration, now time.Time) float64 {
+	weight := n.DiscoveryWeight()
+	if warmup <= 0 || n.RegisterTime.IsZero() {
+		return weight
+	}
+	uptime := now.Sub(n.RegisterTime)
+	if uptime >= warmup {
+		return weight
+	}
+	ratio := float64(uptime) / float64(warmup)
+	if ratio < warmupMinWeightRatio {
+		ratio = warmupMinWeightRatio
+	}
+	return weight * ratio
+}
+
+// nodeWeightOverrideKey 手动调整的权重在 ServiceNode.ExtProperty 中的键
+const nodeWeightOverrideKey = "weightOverride"
+
+// WeightOverride 获取手动调整的权重
+// 返回值:
+//   - float64: 手动调整的权重
+//   - bool: 是否设置了手动调整的权重
+func (n *ServiceNode) WeightOverride() (float64, bool) {
+	if n == nil || n.ExtProperty == "" {
+		return 0, false
+	}
+	var ext map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(n.ExtProperty), &ext); err != nil {
+		return 0, false
+	}
+	raw, ok := ext[nodeWeightOverrideKey]
+	if !ok {
+		return 0, false
+	}
+	var weight float64
+	if err := json.Unmarshal(raw, &weight); err != nil {
+		return 0, false
+	}
+	return weight, true
+}
+
+// SetWeightOverride 设置手动调整的权重，weight 为 nil 时清除；扩展属性中的其他键保持不变
+// 手动调整的权重与注册权重分开保存，节点重新注册或心跳更新注册权重时不会覆盖手动调整的权重
+func (n *ServiceNode) SetWeightOverride(weight *float64) error {
+	ext := make(map[string]json.RawMessage)
+	if n.ExtProperty != "" {
+		if err := json.Unmarshal([]byte(n.ExtProperty), &ext); err != nil {
+			return fmt.Errorf("解析节点扩展属性失败: %w", err)
+		}
+	}
+
+	if weight == nil {
+		delete(ext, nodeWeightOverrideKey)
+	} else {
+		raw, err := json.Marshal(*weight)
+		if err != nil {
+			return fmt.Errorf("序列化节点权重失败: %w", err)
+		}
+		ext[nodeWeightOverrideKey] = raw
+	}
+
+	if len(ext) == 0 {
+		n.ExtProperty = ""
+		return nil
+	}
+	data, err := json.Marshal(ext)
+	if err != nil {
+		return fmt.Errorf("序列化节点扩展属性失败: %w", err)
+	}
+	n.ExtProperty = string(data)
+	return nil
+}
+
+// DiscoveryWeight 服务发现使用的权重：设置了手动调整的权重时使用手动调整的权重，否则使用注册权重
+func (n *ServiceNode) DiscoveryWeight() float64 {
+	if weight, ok := n.WeightOverride(); ok {
+		return weight
+	}
+	return n.Weight
+}
+
+// IsWeightDrained 判断节点是否被手动调整为0权重（摘除流量），摘除流量的节点不参与服务发现和实例选择
+func (n *ServiceNode) IsWeightDrained() bool {
+	weight, ok := n.WeightOverride()
+	return ok && weight <= 0
+}
@@ -0,0 +1,62 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/pkg/timer"
+)
+
+// CleanupTaskExecutor 数据清理任务执行器
+// 删除指定表中时间字段早于保留天数的数据
+type CleanupTaskExecutor struct {
+	db       database.Database
+	taskId   string
+	tenantId string
+	config   CleanupConfig
+}
+
+// Execute 执行数据清理
+func (e *CleanupTaskExecutor) Execute(ctx context.Context, params interface{}) (*timer.ExecuteResult, error) {
+	cutoff := time.Now().AddDate(0, 0, -e.config.RetentionDays)
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s < ?", e.config.TableName, e.config.TimeColumn)
+	args := []interface{}{cutoff}
+	if !e.config.AllTenants {
+		query += " AND tenantId = ?"
+		args = append(args, e.tenantId)
+	}
+
+	deleted, err := e.db.Exec(ctx, query, args, true)
+	if err != nil {
+		return &timer.ExecuteResult{
+			Success: false,
+			Message: fmt.Sprintf("清理 %s 失败: %v", e.config.TableName, err),
+		}, err
+	}
+
+	logger.Info("数据清理完成", "taskId", e.taskId, "tableName", e.config.TableName,
+		"cutoff", cutoff, "deleted", deleted)
+	return &timer.ExecuteResult{
+		Success: true,
+		Message: fmt.Sprintf("清理 %s 完成，删除 %d 条数据", e.config.TableName, deleted),
+		Data: map[string]interface{}{
+			"tableName": e.config.TableName,
+			"cutoff":    cutoff,
+			"deleted":   deleted,
+		},
+	}, nil
+}
+
+// GetName 获取执行器名称
+func (e *CleanupTaskExecutor) GetName() string {
+	return "CleanupTaskExecutor"
+}
+
+// Close 关闭执行器，数据库连接由全局管理，无需释放
+func (e *CleanupTaskExecutor) Close() error {
+	return nil
+}
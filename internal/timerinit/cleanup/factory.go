@@ -0,0 +1,95 @@
+package cleanup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"gateway/internal/timerinit/common/dao"
+	"gateway/internal/timerinit/common/taskinit"
+	"gateway/internal/types/timertypes"
+	"gateway/pkg/timer"
+)
+
+// ExecutorType 数据清理执行器类型
+const ExecutorType = "DATA_CLEANUP"
+
+var (
+	// tableNamePattern 允许清理的表名，仅限网关自身的 HUB_ 表
+	tableNamePattern = regexp.MustCompile(`^HUB_[A-Z0-9_]+$`)
+	// columnNamePattern 时间字段名
+	columnNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// init 注册数据清理执行器工厂，是否在启动时注册由 app.timer.cleanup.enabled 控制，默认启用
+func init() {
+	taskinit.RegisterExecutorFactory(&taskinit.ExecutorFactoryDefinition{
+		ExecutorType:     ExecutorType,
+		Description:      "数据清理，按保留天数删除日志等历史数据",
+		EnabledConfigKey: "app.timer.cleanup.enabled",
+		EnabledByDefault: true,
+		NewFactory:       NewCleanupExecutorFactory,
+	})
+}
+
+// CleanupConfig 数据清理配置，保存在任务的 operationConfig 中，例如:
+//
+//	{"tableName": "HUB_TIMER_EXECUTION_LOG", "timeColumn": "executionStartTime", "retentionDays": 30}
+type CleanupConfig struct {
+	TableName     string `json:"tableName"`     // 清理的表名
+	TimeColumn    string `json:"timeColumn"`    // 判断数据时间的字段
+	RetentionDays int    `json:"retentionDays"` // 保留天数，早于该天数的数据被删除
+	AllTenants    bool   `json:"allTenants"`    // 是否清理所有租户的数据，默认只清理任务所属租户
+}
+
+// CleanupExecutorFactory 数据清理执行器工厂
+type CleanupExecutorFactory struct {
+	daoManager *dao.DAOManager
+}
+
+// NewCleanupExecutorFactory 创建数据清理执行器工厂实例
+func NewCleanupExecutorFactory(daoManager *dao.DAOManager) taskinit.TaskExecutorFactory {
+	return &CleanupExecutorFactory{daoManager: daoManager}
+}
+
+// GetExecutorType 获取执行器类型
+func (f *CleanupExecutorFactory) GetExecutorType() string {
+	return ExecutorType
+}
+
+// CreateExecutor 根据任务的 operationConfig 创建数据清理执行器
+func (f *CleanupExecutorFactory) CreateExecutor(ctx context.Context, task *timertypes.TimerTask) (timer.TaskExecutor, error) {
+	if task.OperationConfig == nil || *task.OperationConfig == "" {
+		return nil, fmt.Errorf("任务 %s 缺少清理配置", task.TaskId)
+	}
+
+	var config CleanupConfig
+	if err := json.Unmarshal([]byte(*task.OperationConfig), &config); err != nil {
+		return nil, fmt.Errorf("解析清理配置失败: %w", err)
+	}
+	if err := ValidateCleanupConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &CleanupTaskExecutor{
+		db:       f.daoManager.GetDatabase(),
+		taskId:   task.TaskId,
+		tenantId: task.TenantId,
+		config:   config,
+	}, nil
+}
+
+// ValidateCleanupConfig 校验数据清理配置，表名和字段名会拼接到SQL中，只允许合法的标识符
+func ValidateCleanupConfig(config *CleanupConfig) error {
+	if !tableNamePattern.MatchString(config.TableName) {
+		return fmt.Errorf("清理表名不合法: %s", config.TableName)
+	}
+	if !columnNamePattern.MatchString(config.TimeColumn) {
+		return fmt.Errorf("时间字段名不合法: %s", config.TimeColumn)
+	}
+	if config.RetentionDays <= 0 {
+		return fmt.Errorf("保留天数必须大于0")
+	}
+	return nil
+}
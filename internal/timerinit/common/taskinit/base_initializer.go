@@ -248,10 +248,16 @@ func (init *BaseTaskInitializer) convertToTimerConfig(task *timertypes.TimerTask
 		ID:            task.TaskId,
 		Name:          task.TaskName,
 		Priority:      init.convertPriority(task.TaskPriority),
-		Enabled:       task.IsActive(),
+		Enabled:       task.IsActive() && task.TaskStatus != timertypes.TaskStatusPaused,
 		MaxRetries:    task.MaxRetries,
 		Timeout:       time.Duration(task.TimeoutSeconds) * time.Second,
 		RetryInterval: time.Duration(task.RetryIntervalSeconds) * time.Second,
+		RetryBackoff:  task.RetryBackoffRate,
+
+		DeadLetterThreshold: task.DeadLetterThreshold,
+	}
+	if task.MaxRetryIntervalSeconds != nil {
+		config.MaxRetryInterval = time.Duration(*task.MaxRetryIntervalSeconds) * time.Second
 	}
 
 	// 设置任务描述
@@ -272,6 +278,7 @@ func (init *BaseTaskInitializer) convertToTimerConfig(task *timertypes.TimerTask
 	config.Status = init.convertTaskStatus(task.TaskStatus)
 	config.RunCount = task.RunCount
 	config.FailureCount = task.FailureCount
+	config.ConsecutiveFailures = task.ConsecutiveFailures
 
 	// 设置时间信息
 	if task.NextRunTime != nil {
@@ -312,12 +319,25 @@ func (init *BaseTaskInitializer) setScheduleConfig(config *timer.TaskConfig, tas
 		config.EndTime = task.EndTime
 	}
 
+	// 设置依赖配置，配置了上游任务时由上游任务执行结束后触发
+	if task.DependsOnTaskIds != nil {
+		config.DependsOn = timer.SplitDependsOn(*task.DependsOnTaskIds)
+	}
+	policy, err := timer.ParseDependencyPolicy(task.DependencyPolicy)
+	if err != nil {
+		return err
+	}
+	config.DependencyPolicy = policy
+
 	// 根据调度类型设置相应的调度参数
 	switch task.ScheduleType {
 	case timertypes.ScheduleTypeCron:
 		// Cron表达式调度：需要有效的Cron表达式
 		if task.CronExpression != nil {
 			config.CronExpr = *task.CronExpression
+			if task.TimeZone != nil {
+				config.TimeZone = *task.TimeZone
+			}
 		} else {
 			return fmt.Errorf("Cron调度类型必须提供Cron表达式")
 		}
@@ -403,13 +423,16 @@ func (init *BaseTaskInitializer) convertTaskStatus(status int) timer.TaskStatus
 	case timertypes.TaskStatusPending:
 		return timer.TaskStatusPending
 	case timertypes.TaskStatusRunning:
-		return timer.TaskStatusRunning
+		// 数据库中的运行中表示任务已启动，新加载的任务尚未在本进程执行，按待执行参与调度
+		return timer.TaskStatusPending
 	case timertypes.TaskStatusCompleted:
 		return timer.TaskStatusCompleted
 	case timertypes.TaskStatusFailed:
 		return timer.TaskStatusFailed
 	case timertypes.TaskStatusCancelled:
 		return timer.TaskStatusCancelled
+	case timertypes.TaskStatusDeadLetter:
+		return timer.TaskStatusDeadLetter
 	default:
 		return timer.TaskStatusPending
 	}
@@ -0,0 +1,157 @@
+package taskinit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"gateway/internal/timerinit/common/dao"
+	"gateway/pkg/config"
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+)
+
+// ExecutorFactoryDefinition 执行器工厂定义
+// 各类任务模块在包初始化时注册自己的执行器工厂，启动初始化和管理接口按任务的 executorType 查找工厂，
+// 新增定时任务类型只需注册工厂并在 HUB_TIMER_TASK 中配置任务，无需修改启动初始化代码
+type ExecutorFactoryDefinition struct {
+	ExecutorType     string // 执行器类型，对应 HUB_TIMER_TASK.executorType
+	Description      string // 执行器说明，用于管理界面展示
+	EnabledConfigKey string // 启用开关配置项，为空时始终启用
+	EnabledByDefault bool   // 未配置启用开关时的默认值
+
+	// NewFactory 创建执行器工厂
+	NewFactory func(daoManager *dao.DAOManager) TaskExecutorFactory
+}
+
+// IsEnabled 判断执行器类型是否启用
+func (d *ExecutorFactoryDefinition) IsEnabled() bool {
+	if d.EnabledConfigKey == "" {
+		return true
+	}
+	return config.GetBool(d.EnabledConfigKey, d.EnabledByDefault)
+}
+
+var (
+	factoryMu          sync.RWMutex
+	factoryDefinitions = make(map[string]*ExecutorFactoryDefinition)
+)
+
+// RegisterExecutorFactory 注册执行器工厂定义，同一执行器类型重复注册时后注册的覆盖先注册的
+// 参数:
+//
+//	definition: 执行器工厂定义
+func RegisterExecutorFactory(definition *ExecutorFactoryDefinition) {
+	if definition == nil || definition.ExecutorType == "" || definition.NewFactory == nil {
+		panic("taskinit: 执行器工厂定义缺少执行器类型或工厂创建函数")
+	}
+
+	factoryMu.Lock()
+	defer factoryMu.Unlock()
+	factoryDefinitions[definition.ExecutorType] = definition
+}
+
+// GetExecutorFactory 获取执行器类型对应的工厂定义
+// 返回:
+//
+//	*ExecutorFactoryDefinition: 工厂定义
+//	bool: 执行器类型是否已注册
+func GetExecutorFactory(executorType string) (*ExecutorFactoryDefinition, bool) {
+	factoryMu.RLock()
+	defer factoryMu.RUnlock()
+	definition, ok := factoryDefinitions[executorType]
+	return definition, ok
+}
+
+// ListExecutorFactories 按执行器类型排序列出已注册的工厂定义
+func ListExecutorFactories() []*ExecutorFactoryDefinition {
+	factoryMu.RLock()
+	defer factoryMu.RUnlock()
+
+	definitions := make([]*ExecutorFactoryDefinition, 0, len(factoryDefinitions))
+	for _, definition := range factoryDefinitions {
+		definitions = append(definitions, definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].ExecutorType < definitions[j].ExecutorType
+	})
+	return definitions
+}
+
+// RegisterAllTasks 为所有已启用的执行器类型注册数据库中的任务
+// 单个执行器类型注册失败不影响其他类型，失败信息汇总后返回
+// 参数:
+//
+//	ctx: 上下文对象
+//	db: 数据库连接实例
+//	tenantIds: 需要初始化的租户ID列表，如果为空则初始化所有租户
+//
+// 返回:
+//
+//	error: 注册失败时返回错误信息
+func RegisterAllTasks(ctx context.Context, db database.Database, tenantIds ...string) error {
+	daoManager := dao.NewDAOManager(db)
+	register := NewTaskRegister(db)
+
+	var registerErrors []error
+	for _, definition := range ListExecutorFactories() {
+		if !definition.IsEnabled() {
+			logger.Info("执行器类型未启用，跳过任务注册", "executorType", definition.ExecutorType)
+			continue
+		}
+		if err := register.RegisterTasks(ctx, definition.NewFactory(daoManager), tenantIds...); err != nil {
+			logger.Error("注册任务失败", "executorType", definition.ExecutorType, "error", err)
+			registerErrors = append(registerErrors, fmt.Errorf("%s: %w", definition.ExecutorType, err))
+		}
+	}
+
+	if len(registerErrors) > 0 {
+		return fmt.Errorf("部分执行器类型任务注册失败: %v", registerErrors)
+	}
+	return nil
+}
+
+// RegisterTaskById 根据任务ID注册单个任务，按任务的执行器类型查找工厂创建执行器并加入调度器
+// 用于管理接口在运行时注册新增或修改后的任务
+// 参数:
+//
+//	ctx: 上下文对象
+//	db: 数据库连接实例
+//	tenantId: 租户ID
+//	taskId: 任务ID
+//
+// 返回:
+//
+//	error: 注册失败时返回错误信息
+func RegisterTaskById(ctx context.Context, db database.Database, tenantId, taskId string) error {
+	if tenantId == "" {
+		return fmt.Errorf("租户ID不能为空")
+	}
+	if taskId == "" {
+		return fmt.Errorf("任务ID不能为空")
+	}
+
+	daoManager := dao.NewDAOManager(db)
+	task, err := daoManager.GetTaskDAO().GetTaskById(ctx, tenantId, taskId)
+	if err != nil {
+		return fmt.Errorf("查询任务失败: %w", err)
+	}
+	if task == nil {
+		return fmt.Errorf("未找到指定的任务: taskId=%s", taskId)
+	}
+	if task.ExecutorType == "" {
+		return fmt.Errorf("任务 %s 未配置执行器类型", taskId)
+	}
+
+	definition, ok := GetExecutorFactory(task.ExecutorType)
+	if !ok {
+		return fmt.Errorf("不支持的执行器类型: %s", task.ExecutorType)
+	}
+	if !definition.IsEnabled() {
+		return fmt.Errorf("执行器类型 %s 未启用", task.ExecutorType)
+	}
+
+	initializer := NewBaseTaskInitializer(daoManager, definition.NewFactory(daoManager))
+	return initializer.InitializeSingleTask(ctx, task)
+}
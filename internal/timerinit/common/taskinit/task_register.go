@@ -165,6 +165,10 @@ func (r *TaskRegister) GetTaskStatus(ctx context.Context, executorType, tenantId
 			statusMap["failed"]++
 		case timertypes.TaskStatusCancelled:
 			statusMap["cancelled"]++
+		case timertypes.TaskStatusDeadLetter:
+			statusMap["deadLetter"]++
+		case timertypes.TaskStatusPaused:
+			statusMap["paused"]++
 		}
 	}
 
@@ -10,6 +10,17 @@ import (
 	"gateway/pkg/logger"
 )
 
+// init 注册SFTP执行器工厂，启动初始化和任务管理接口按执行器类型查找
+// 是否在启动时注册SFTP任务由 app.timer.sftp.enabled 控制
+func init() {
+	taskinit.RegisterExecutorFactory(&taskinit.ExecutorFactoryDefinition{
+		ExecutorType:     "SFTP_TRANSFER",
+		Description:      "SFTP文件传输",
+		EnabledConfigKey: "app.timer.sftp.enabled",
+		NewFactory:       NewSFTPExecutorFactory,
+	})
+}
+
 // RegisterSFTPTasks 注册SFTP定时任务
 // 这是SFTP定时任务初始化的统一入口函数
 // 参数:
@@ -232,10 +232,12 @@ func (hm *heartbeatManager) heartbeatLoop() {
 			return
 		case <-ticker.C:
 			if err := hm.SendHeartbeat(hm.ctx); err != nil {
-				logger.Error("Failed to send heartbeat", map[string]interface{}{
-					"error":               err.Error(),
-					"consecutiveFailures": consecutiveFailures + 1,
-				})
+				if logger.RateLimit("tunnelHeartbeat:"+hm.client.config.TunnelClientId, time.Minute) {
+					logger.Error("Failed to send heartbeat", map[string]interface{}{
+						"error":               err.Error(),
+						"consecutiveFailures": consecutiveFailures + 1,
+					})
+				}
 
 				consecutiveFailures++
 
@@ -41,6 +41,8 @@ const (
 	TaskStatusCompleted = 3 // 已完成
 	TaskStatusFailed    = 4 // 执行失败
 	TaskStatusCancelled = 5 // 已取消
+	TaskStatusDeadLetter = 6 // 死信（连续失败达到阈值，需手动触发）
+	TaskStatusPaused    = 7 // 已暂停（保留在调度器中但不调度，恢复后继续）
 )
 
 // 执行状态常量
@@ -22,14 +22,20 @@ type TimerTask struct {
 	// 调度配置
 	ScheduleType      int     `json:"scheduleType" db:"scheduleType"`
 	CronExpression    *string `json:"cronExpression" db:"cronExpression"`
+	TimeZone          *string `json:"timeZone" db:"timeZone"`
 	IntervalSeconds   *int64  `json:"intervalSeconds" db:"intervalSeconds"`
 	DelaySeconds      *int64  `json:"delaySeconds" db:"delaySeconds"`
 	StartTime         *time.Time `json:"startTime" db:"startTime"`
 	EndTime           *time.Time `json:"endTime" db:"endTime"`
+	DependsOnTaskIds  *string `json:"dependsOnTaskIds" db:"dependsOnTaskIds"`
+	DependencyPolicy  string  `json:"dependencyPolicy" db:"dependencyPolicy"`
 	
 	// 执行配置
 	MaxRetries        int     `json:"maxRetries" db:"maxRetries"`
 	RetryIntervalSeconds int64 `json:"retryIntervalSeconds" db:"retryIntervalSeconds"`
+	RetryBackoffRate  float64 `json:"retryBackoffRate" db:"retryBackoffRate"`
+	MaxRetryIntervalSeconds *int64 `json:"maxRetryIntervalSeconds" db:"maxRetryIntervalSeconds"`
+	DeadLetterThreshold int   `json:"deadLetterThreshold" db:"deadLetterThreshold"`
 	TimeoutSeconds    int64   `json:"timeoutSeconds" db:"timeoutSeconds"`
 	TaskParams        *string `json:"taskParams" db:"taskParams"`
 	// -- 任务执行器配置 - 关联到具体工具配置
@@ -46,6 +52,7 @@ type TimerTask struct {
 	RunCount          int64   `json:"runCount" db:"runCount"`
 	SuccessCount      int64   `json:"successCount" db:"successCount"`
 	FailureCount      int64   `json:"failureCount" db:"failureCount"`
+	ConsecutiveFailures int64 `json:"consecutiveFailures" db:"consecutiveFailures"`
 	
 	// 最后执行结果
 	LastExecutionId   *string `json:"lastExecutionId" db:"lastExecutionId"`
@@ -124,6 +131,10 @@ func (t *TimerTask) GetStatusName() string {
 		return "执行失败"
 	case TaskStatusCancelled:
 		return "已取消"
+	case TaskStatusDeadLetter:
+		return "死信"
+	case TaskStatusPaused:
+		return "已暂停"
 	default:
 		return "未知状态"
 	}
@@ -0,0 +1,375 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"gateway/pkg/alert"
+	"gateway/pkg/httpclient"
+)
+
+// DingTalkServerConfig 钉钉服务器配置（可公用）
+type DingTalkServerConfig struct {
+	// WebhookURL 钉钉机器人Webhook地址（包含access_token）
+	WebhookURL string
+	// Secret 加签密钥（可选，机器人安全设置为加签时必填）
+	Secret string
+	// MessageType 消息类型：text, markdown
+	MessageType string
+	// Timeout 超时时间（秒）
+	Timeout int
+	// TitleTemplate 标题模板（可选）
+	TitleTemplate string
+	// ContentTemplate 内容模板（可选）
+	ContentTemplate string
+}
+
+// Validate 验证服务器配置
+func (c *DingTalkServerConfig) Validate() error {
+	if c.WebhookURL == "" {
+		return fmt.Errorf("钉钉Webhook地址不能为空")
+	}
+	if c.MessageType == "" {
+		c.MessageType = "markdown"
+	}
+	if c.MessageType != "text" && c.MessageType != "markdown" {
+		return fmt.Errorf("消息类型必须是text或markdown")
+	}
+	return nil
+}
+
+// DingTalkSendConfig 钉钉发送配置（每次发送可不同）
+type DingTalkSendConfig struct {
+	// AtMobiles @指定成员手机号列表
+	AtMobiles []string
+	// AtUserIds @指定成员userId列表
+	AtUserIds []string
+	// AtAll 是否@所有人
+	AtAll bool
+}
+
+// DingTalkChannel 钉钉告警渠道
+type DingTalkChannel struct {
+	name             string
+	channelType      alert.AlertType
+	enabled          bool
+	serverConfig     *DingTalkServerConfig
+	sendConfig       *DingTalkSendConfig
+	httpClient       httpclient.Client
+	templateReplacer *TemplateReplacer
+}
+
+// NewDingTalkChannel 创建钉钉告警渠道
+// 参数:
+//
+//	name: 渠道名称
+//	serverConfig: 钉钉服务器配置（可公用）
+//	sendConfig: 默认发送配置（可在发送时覆盖）
+//	httpClient: HTTP客户端（可选，如果为nil则创建默认客户端）
+func NewDingTalkChannel(name string, serverConfig *DingTalkServerConfig, sendConfig *DingTalkSendConfig, httpClient httpclient.Client) (*DingTalkChannel, error) {
+	if serverConfig == nil {
+		return nil, fmt.Errorf("服务器配置不能为空")
+	}
+	if err := serverConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("服务器配置验证失败: %w", err)
+	}
+	if sendConfig == nil {
+		sendConfig = &DingTalkSendConfig{}
+	}
+
+	if httpClient == nil {
+		timeout := 30 * time.Second
+		if serverConfig.Timeout > 0 {
+			timeout = time.Duration(serverConfig.Timeout) * time.Second
+		}
+		client, err := httpclient.NewClient(&httpclient.ClientConfig{
+			Timeout: timeout,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("创建HTTP客户端失败: %w", err)
+		}
+		httpClient = client
+	}
+
+	return &DingTalkChannel{
+		name:             name,
+		channelType:      alert.AlertTypeDingTalk,
+		enabled:          true,
+		serverConfig:     serverConfig,
+		sendConfig:       sendConfig,
+		httpClient:       httpClient,
+		templateReplacer: NewTemplateReplacer(),
+	}, nil
+}
+
+// dingtalkMessage 钉钉消息结构
+type dingtalkMessage struct {
+	MsgType  string                   `json:"msgtype"`
+	Text     *dingtalkTextContent     `json:"text,omitempty"`
+	Markdown *dingtalkMarkdownContent `json:"markdown,omitempty"`
+	At       *dingtalkAt              `json:"at,omitempty"`
+}
+
+// dingtalkTextContent 文本消息内容
+type dingtalkTextContent struct {
+	Content string `json:"content"`
+}
+
+// dingtalkMarkdownContent Markdown消息内容
+type dingtalkMarkdownContent struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+}
+
+// dingtalkAt @成员信息
+type dingtalkAt struct {
+	AtMobiles []string `json:"atMobiles,omitempty"`
+	AtUserIds []string `json:"atUserIds,omitempty"`
+	IsAtAll   bool     `json:"isAtAll"`
+}
+
+// dingtalkResponse 钉钉响应
+type dingtalkResponse struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+// Send 发送钉钉告警
+// 可以在 message.Extra 中传入 "send_config" 来覆盖默认的发送配置
+func (d *DingTalkChannel) Send(ctx context.Context, message *alert.Message, options *alert.SendOptions) *alert.SendResult {
+	if !d.IsEnabled() {
+		return &alert.SendResult{
+			Timestamp: time.Now(),
+			Error:     fmt.Errorf("钉钉告警渠道未启用"),
+			Extra:     make(map[string]interface{}),
+		}
+	}
+	return sendWithRetry(ctx, options, func(ctx context.Context) (string, error) {
+		return d.sendMessage(ctx, message)
+	})
+}
+
+// sendMessage 实际发送消息的内部方法
+// 参考钉钉自定义机器人文档：https://open.dingtalk.com/document/robots/custom-robot-access
+func (d *DingTalkChannel) sendMessage(ctx context.Context, message *alert.Message) (string, error) {
+	sendConfig := d.sendConfig
+	if customConfig, ok := message.Extra["send_config"].(*DingTalkSendConfig); ok && customConfig != nil {
+		sendConfig = customConfig
+	}
+
+	msg := dingtalkMessage{
+		At: &dingtalkAt{
+			AtMobiles: sendConfig.AtMobiles,
+			AtUserIds: sendConfig.AtUserIds,
+			IsAtAll:   sendConfig.AtAll,
+		},
+	}
+	title := d.buildTitle(message)
+	if d.serverConfig.MessageType == "markdown" {
+		msg.MsgType = "markdown"
+		msg.Markdown = &dingtalkMarkdownContent{
+			Title: title,
+			Text:  d.buildMarkdownContent(title, message, sendConfig),
+		}
+	} else {
+		msg.MsgType = "text"
+		msg.Text = &dingtalkTextContent{
+			Content: d.buildTextContent(title, message),
+		}
+	}
+
+	webhookURL := d.serverConfig.WebhookURL
+	// 加签：timestamp(毫秒) + "\n" + secret 做 HMAC-SHA256，Base64 后 URL 编码
+	if d.serverConfig.Secret != "" {
+		timestamp := time.Now().UnixMilli()
+		separator := "?"
+		if strings.Contains(webhookURL, "?") {
+			separator = "&"
+		}
+		webhookURL = fmt.Sprintf("%s%stimestamp=%d&sign=%s", webhookURL, separator, timestamp, url.QueryEscape(d.generateSign(timestamp)))
+	}
+
+	resp, err := d.httpClient.Post(ctx, webhookURL, msg,
+		httpclient.WithContentType("application/json"),
+	)
+	if err != nil {
+		return "", fmt.Errorf("发送请求失败: %w", err)
+	}
+
+	responseBody := resp.BodyString()
+	if !resp.IsSuccess() {
+		return responseBody, fmt.Errorf("HTTP请求失败，状态码: %d, 响应: %s", resp.StatusCode, responseBody)
+	}
+
+	var dingResp dingtalkResponse
+	if err := json.Unmarshal(resp.Body, &dingResp); err != nil {
+		return responseBody, fmt.Errorf("解析响应失败: %w", err)
+	}
+	if dingResp.ErrCode != 0 {
+		return responseBody, fmt.Errorf("钉钉返回错误，代码: %d, 消息: %s", dingResp.ErrCode, dingResp.ErrMsg)
+	}
+	return responseBody, nil
+}
+
+// buildTitle 构建消息标题
+func (d *DingTalkChannel) buildTitle(message *alert.Message) string {
+	if d.serverConfig.TitleTemplate != "" {
+		return d.templateReplacer.Replace(d.serverConfig.TitleTemplate, message, nil)
+	}
+	if message.Title != "" {
+		return message.Title
+	}
+	return "告警通知"
+}
+
+// buildTextContent 构建文本消息内容
+func (d *DingTalkChannel) buildTextContent(title string, message *alert.Message) string {
+	if d.serverConfig.ContentTemplate != "" {
+		return d.templateReplacer.Replace(d.serverConfig.ContentTemplate, message, nil)
+	}
+
+	var content bytes.Buffer
+	content.WriteString(fmt.Sprintf("【%s】\n", title))
+	if message.Content != "" {
+		content.WriteString(message.Content)
+		content.WriteString("\n")
+	}
+	if len(message.TableData) > 0 && (message.DisplayFormat == alert.DisplayFormatTable || message.DisplayFormat == "") {
+		for _, key := range sortedKeys(message.TableData) {
+			content.WriteString(fmt.Sprintf("%s: %v\n", key, message.TableData[key]))
+		}
+	}
+	if !message.Timestamp.IsZero() {
+		content.WriteString(fmt.Sprintf("时间: %s", message.Timestamp.Format("2006-01-02 15:04:05")))
+	}
+	return content.String()
+}
+
+// buildMarkdownContent 构建Markdown消息内容
+// 钉钉Markdown中@成员需要在正文中包含 @手机号 或 @userId
+func (d *DingTalkChannel) buildMarkdownContent(title string, message *alert.Message, sendConfig *DingTalkSendConfig) string {
+	if d.serverConfig.ContentTemplate != "" {
+		return d.templateReplacer.Replace(d.serverConfig.ContentTemplate, message, nil)
+	}
+
+	var content bytes.Buffer
+	content.WriteString(fmt.Sprintf("### %s\n\n", title))
+	if message.Content != "" {
+		for _, line := range strings.Split(message.Content, "\n") {
+			content.WriteString(fmt.Sprintf("> %s\n", line))
+		}
+		content.WriteString("\n")
+	}
+	if len(message.TableData) > 0 && (message.DisplayFormat == alert.DisplayFormatTable || message.DisplayFormat == "") {
+		for _, key := range sortedKeys(message.TableData) {
+			content.WriteString(fmt.Sprintf("- **%s**: %v\n", key, message.TableData[key]))
+		}
+		content.WriteString("\n")
+	}
+	if !message.Timestamp.IsZero() {
+		content.WriteString(fmt.Sprintf("**告警时间**: %s\n", message.Timestamp.Format("2006-01-02 15:04:05")))
+	}
+
+	mentions := make([]string, 0, len(sendConfig.AtMobiles)+len(sendConfig.AtUserIds))
+	for _, mobile := range sendConfig.AtMobiles {
+		mentions = append(mentions, "@"+mobile)
+	}
+	for _, userId := range sendConfig.AtUserIds {
+		mentions = append(mentions, "@"+userId)
+	}
+	if len(mentions) > 0 {
+		content.WriteString("\n")
+		content.WriteString(strings.Join(mentions, " "))
+	}
+	return content.String()
+}
+
+// generateSign 生成加签签名
+func (d *DingTalkChannel) generateSign(timestamp int64) string {
+	stringToSign := fmt.Sprintf("%d\n%s", timestamp, d.serverConfig.Secret)
+	h := hmac.New(sha256.New, []byte(d.serverConfig.Secret))
+	h.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// sortedKeys 返回排序后的表格数据键，保证消息内容顺序稳定
+func sortedKeys(data map[string]interface{}) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Type 返回渠道类型
+func (d *DingTalkChannel) Type() alert.AlertType {
+	return d.channelType
+}
+
+// Name 返回渠道名称
+func (d *DingTalkChannel) Name() string {
+	return d.name
+}
+
+// IsEnabled 检查渠道是否启用
+func (d *DingTalkChannel) IsEnabled() bool {
+	return d.enabled
+}
+
+// Enable 启用渠道
+func (d *DingTalkChannel) Enable() error {
+	d.enabled = true
+	return nil
+}
+
+// Disable 禁用渠道
+func (d *DingTalkChannel) Disable() error {
+	d.enabled = false
+	return nil
+}
+
+// Close 关闭渠道
+func (d *DingTalkChannel) Close() error {
+	if d.httpClient != nil {
+		return d.httpClient.Close()
+	}
+	return nil
+}
+
+// HealthCheck 健康检查
+func (d *DingTalkChannel) HealthCheck(ctx context.Context) *alert.HealthCheckResult {
+	startTime := time.Now()
+	result := &alert.HealthCheckResult{
+		Timestamp: startTime,
+		Extra:     make(map[string]interface{}),
+	}
+
+	responseBody, err := d.sendMessage(ctx, &alert.Message{
+		Title:     "健康检查",
+		Content:   "这是一条健康检查消息",
+		Timestamp: time.Now(),
+	})
+	result.Duration = time.Since(startTime)
+	if responseBody != "" {
+		result.Extra["response_body"] = responseBody
+	}
+	if err != nil {
+		result.Error = fmt.Errorf("健康检查失败: %w", err)
+		result.Message = fmt.Sprintf("钉钉健康检查失败: %s", err.Error())
+		return result
+	}
+
+	result.Success = true
+	result.Message = "钉钉渠道健康检查通过"
+	return result
+}
@@ -3,7 +3,9 @@ package channel
 import (
 	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"fmt"
+	"mime"
 	"net"
 	"net/smtp"
 	"strings"
@@ -349,6 +351,10 @@ func (e *EmailChannel) buildEmailContent(message *alert.Message, sendConfig *Ema
 
 	// MIME类型
 	content.WriteString("MIME-Version: 1.0\r\n")
+	if len(message.Attachments) > 0 {
+		e.writeMultipartBody(&content, message)
+		return []byte(content.String())
+	}
 	content.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
 	content.WriteString("\r\n")
 
@@ -358,6 +364,43 @@ func (e *EmailChannel) buildEmailContent(message *alert.Message, sendConfig *Ema
 	return []byte(content.String())
 }
 
+// writeMultipartBody 写入带附件的 multipart/mixed 邮件体，正文为HTML，附件使用base64编码
+func (e *EmailChannel) writeMultipartBody(content *strings.Builder, message *alert.Message) {
+	boundary := fmt.Sprintf("----=_Part_%d", time.Now().UnixNano())
+	content.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=\"%s\"\r\n", boundary))
+	content.WriteString("\r\n")
+
+	content.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+	content.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	content.WriteString("\r\n")
+	content.WriteString(e.buildHTMLBody(message))
+	content.WriteString("\r\n")
+
+	for _, attachment := range message.Attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		filename := mime.BEncoding.Encode("UTF-8", attachment.Name)
+		content.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+		content.WriteString(fmt.Sprintf("Content-Type: %s; name=\"%s\"\r\n", contentType, filename))
+		content.WriteString("Content-Transfer-Encoding: base64\r\n")
+		content.WriteString(fmt.Sprintf("Content-Disposition: attachment; filename=\"%s\"\r\n", filename))
+		content.WriteString("\r\n")
+
+		// base64内容按76字符换行
+		encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+		for len(encoded) > 76 {
+			content.WriteString(encoded[:76])
+			content.WriteString("\r\n")
+			encoded = encoded[76:]
+		}
+		content.WriteString(encoded)
+		content.WriteString("\r\n")
+	}
+	content.WriteString(fmt.Sprintf("--%s--\r\n", boundary))
+}
+
 // buildHTMLBody 构建HTML格式的邮件正文
 func (e *EmailChannel) buildHTMLBody(message *alert.Message) string {
 	// 如果配置了正文模板，使用模板替换
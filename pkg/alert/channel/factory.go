@@ -10,7 +10,7 @@ import (
 // 配置结构:
 //
 //	{
-//	    "type": "email",           // 必需：告警类型 (email/qq/wechat_work)
+//	    "type": "email",           // 必需：告警类型 (email/qq/wechat_work/dingtalk/webhook)
 //	    "name": "email-prod",      // 必需：渠道名称
 //	    "server": {                // 必需：服务器配置
 //	        "smtp_host": "smtp.example.com",
@@ -86,6 +86,12 @@ func CreateChannel(config map[string]interface{}) (alert.Channel, error) {
 	case alert.AlertTypeWeChatWork:
 		return createWeChatWorkChannel(name, serverConfig, sendConfig)
 
+	case alert.AlertTypeDingTalk:
+		return createDingTalkChannel(name, serverConfig, sendConfig)
+
+	case alert.AlertTypeWebhook:
+		return createWebhookChannel(name, serverConfig)
+
 	default:
 		return nil, fmt.Errorf("不支持的告警类型: %s", typeStr)
 	}
@@ -310,6 +316,110 @@ func createWeChatWorkChannel(name string, serverConfig, sendConfig map[string]in
 	return NewWeChatWorkChannel(name, srvCfg, sendCfg, nil)
 }
 
+// createDingTalkChannel 创建钉钉告警渠道
+func createDingTalkChannel(name string, serverConfig, sendConfig map[string]interface{}) (alert.Channel, error) {
+	// 解析服务器配置
+	srvCfg := &DingTalkServerConfig{}
+
+	// 必需字段
+	if webhookURL, ok := serverConfig["webhook_url"].(string); ok {
+		srvCfg.WebhookURL = webhookURL
+	} else {
+		return nil, fmt.Errorf("钉钉渠道配置缺少必需字段: server.webhook_url")
+	}
+
+	// 可选字段
+	if secret, ok := serverConfig["secret"].(string); ok {
+		srvCfg.Secret = secret
+	}
+
+	if msgType, ok := serverConfig["message_type"].(string); ok {
+		srvCfg.MessageType = msgType
+	} else {
+		srvCfg.MessageType = "markdown" // 默认使用markdown
+	}
+
+	if timeout, ok := serverConfig["timeout"].(int); ok {
+		srvCfg.Timeout = timeout
+	} else if timeout, ok := serverConfig["timeout"].(float64); ok {
+		srvCfg.Timeout = int(timeout)
+	}
+
+	// 可选：模板配置
+	if tpl, ok := serverConfig["TitleTemplate"].(string); ok {
+		srvCfg.TitleTemplate = tpl
+	}
+	if tpl, ok := serverConfig["ContentTemplate"].(string); ok {
+		srvCfg.ContentTemplate = tpl
+	}
+
+	// 解析发送配置
+	sendCfg := &DingTalkSendConfig{}
+
+	// 可选字段
+	if atAll, ok := sendConfig["at_all"].(bool); ok {
+		sendCfg.AtAll = atAll
+	}
+	sendCfg.AtMobiles = toStringSlice(sendConfig["at_mobiles"])
+	sendCfg.AtUserIds = toStringSlice(sendConfig["at_user_ids"])
+
+	return NewDingTalkChannel(name, srvCfg, sendCfg, nil)
+}
+
+// createWebhookChannel 创建通用Webhook告警渠道，Webhook没有发送配置
+func createWebhookChannel(name string, serverConfig map[string]interface{}) (alert.Channel, error) {
+	// 解析服务器配置
+	srvCfg := &WebhookServerConfig{}
+
+	// 必需字段
+	if url, ok := serverConfig["url"].(string); ok {
+		srvCfg.URL = url
+	} else {
+		return nil, fmt.Errorf("Webhook渠道配置缺少必需字段: server.url")
+	}
+
+	// 可选字段
+	if method, ok := serverConfig["method"].(string); ok {
+		srvCfg.Method = method
+	}
+
+	if headers, ok := serverConfig["headers"].(map[string]string); ok {
+		srvCfg.Headers = headers
+	} else if headers, ok := serverConfig["headers"].(map[string]interface{}); ok {
+		srvCfg.Headers = make(map[string]string, len(headers))
+		for k, v := range headers {
+			if str, ok := v.(string); ok {
+				srvCfg.Headers[k] = str
+			}
+		}
+	}
+
+	if timeout, ok := serverConfig["timeout"].(int); ok {
+		srvCfg.Timeout = timeout
+	} else if timeout, ok := serverConfig["timeout"].(float64); ok {
+		srvCfg.Timeout = int(timeout)
+	}
+
+	return NewWebhookChannel(name, srvCfg, nil)
+}
+
+// toStringSlice 把配置中的字符串数组（[]string 或 JSON 解析得到的 []interface{}）转换为 []string
+func toStringSlice(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				result = append(result, str)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
 // CreateChannels 批量创建告警渠道
 // 参数:
 //
@@ -360,5 +470,7 @@ func GetSupportedTypes() []string {
 		string(alert.AlertTypeEmail),
 		string(alert.AlertTypeQQ),
 		string(alert.AlertTypeWeChatWork),
+		string(alert.AlertTypeDingTalk),
+		string(alert.AlertTypeWebhook),
 	}
 }
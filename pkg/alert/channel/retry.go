@@ -0,0 +1,73 @@
+package channel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gateway/pkg/alert"
+)
+
+// sendWithRetry 按发送选项执行带超时和重试的发送，返回统一的发送结果
+// send 返回原始响应体和错误，响应体保存到 result.Extra["response_body"]
+func sendWithRetry(ctx context.Context, options *alert.SendOptions, send func(ctx context.Context) (string, error)) *alert.SendResult {
+	startTime := time.Now()
+	result := &alert.SendResult{
+		Success:   false,
+		Timestamp: startTime,
+		Extra:     make(map[string]interface{}),
+	}
+
+	if options == nil {
+		options = alert.DefaultSendOptions()
+	}
+
+	sendCtx := ctx
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		sendCtx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	maxRetries := options.Retry
+	if maxRetries < 1 {
+		maxRetries = 1
+	}
+
+	var lastErr error
+	for i := 0; i < maxRetries; i++ {
+		select {
+		case <-sendCtx.Done():
+			result.Error = fmt.Errorf("发送超时或被取消: %w", sendCtx.Err())
+			result.Duration = time.Since(startTime)
+			return result
+		default:
+		}
+
+		responseBody, err := send(sendCtx)
+		if responseBody != "" {
+			result.Extra["response_body"] = responseBody
+		}
+		if err == nil {
+			result.Success = true
+			result.Duration = time.Since(startTime)
+			return result
+		}
+		result.Extra["last_error"] = err.Error()
+		lastErr = err
+
+		if i < maxRetries-1 && options.RetryInterval > 0 {
+			select {
+			case <-sendCtx.Done():
+				result.Error = fmt.Errorf("重试等待期间被取消: %w", sendCtx.Err())
+				result.Duration = time.Since(startTime)
+				return result
+			case <-time.After(options.RetryInterval):
+			}
+		}
+	}
+
+	result.Error = fmt.Errorf("发送失败（重试%d次）: %w", maxRetries, lastErr)
+	result.Duration = time.Since(startTime)
+	return result
+}
@@ -0,0 +1,220 @@
+package channel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"gateway/pkg/alert"
+	"gateway/pkg/httpclient"
+)
+
+// WebhookServerConfig 通用Webhook服务器配置（可公用）
+type WebhookServerConfig struct {
+	// URL 接收告警的地址
+	URL string
+	// Method 请求方法：POST（默认）或 PUT
+	Method string
+	// Headers 附加请求头，如鉴权头
+	Headers map[string]string
+	// Timeout 超时时间（秒）
+	Timeout int
+}
+
+// Validate 验证服务器配置
+func (c *WebhookServerConfig) Validate() error {
+	if c.URL == "" {
+		return fmt.Errorf("Webhook地址不能为空")
+	}
+	if c.Method == "" {
+		c.Method = http.MethodPost
+	}
+	c.Method = strings.ToUpper(c.Method)
+	if c.Method != http.MethodPost && c.Method != http.MethodPut {
+		return fmt.Errorf("Webhook请求方法必须是POST或PUT")
+	}
+	return nil
+}
+
+// WebhookChannel 通用Webhook告警渠道
+// 以JSON格式推送告警消息，接收方返回2xx即视为成功
+type WebhookChannel struct {
+	name         string
+	channelType  alert.AlertType
+	enabled      bool
+	serverConfig *WebhookServerConfig
+	httpClient   httpclient.Client
+}
+
+// webhookPayload Webhook推送的消息体
+type webhookPayload struct {
+	Channel   string                 `json:"channel"`
+	Title     string                 `json:"title"`
+	Content   string                 `json:"content"`
+	Timestamp string                 `json:"timestamp"`
+	Tags      map[string]string      `json:"tags,omitempty"`
+	TableData map[string]interface{} `json:"tableData,omitempty"`
+	Extra     map[string]interface{} `json:"extra,omitempty"`
+}
+
+// NewWebhookChannel 创建Webhook告警渠道
+// 参数:
+//
+//	name: 渠道名称
+//	serverConfig: Webhook服务器配置
+//	httpClient: HTTP客户端（可选，如果为nil则创建默认客户端）
+func NewWebhookChannel(name string, serverConfig *WebhookServerConfig, httpClient httpclient.Client) (*WebhookChannel, error) {
+	if serverConfig == nil {
+		return nil, fmt.Errorf("服务器配置不能为空")
+	}
+	if err := serverConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("服务器配置验证失败: %w", err)
+	}
+
+	if httpClient == nil {
+		timeout := 30 * time.Second
+		if serverConfig.Timeout > 0 {
+			timeout = time.Duration(serverConfig.Timeout) * time.Second
+		}
+		client, err := httpclient.NewClient(&httpclient.ClientConfig{
+			Timeout: timeout,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("创建HTTP客户端失败: %w", err)
+		}
+		httpClient = client
+	}
+
+	return &WebhookChannel{
+		name:         name,
+		channelType:  alert.AlertTypeWebhook,
+		enabled:      true,
+		serverConfig: serverConfig,
+		httpClient:   httpClient,
+	}, nil
+}
+
+// Send 发送Webhook告警
+func (w *WebhookChannel) Send(ctx context.Context, message *alert.Message, options *alert.SendOptions) *alert.SendResult {
+	if !w.IsEnabled() {
+		return &alert.SendResult{
+			Timestamp: time.Now(),
+			Error:     fmt.Errorf("Webhook告警渠道未启用"),
+			Extra:     make(map[string]interface{}),
+		}
+	}
+	return sendWithRetry(ctx, options, func(ctx context.Context) (string, error) {
+		return w.sendMessage(ctx, message)
+	})
+}
+
+// sendMessage 实际发送消息的内部方法
+func (w *WebhookChannel) sendMessage(ctx context.Context, message *alert.Message) (string, error) {
+	payload := webhookPayload{
+		Channel:   w.name,
+		Title:     message.Title,
+		Content:   message.Content,
+		Timestamp: message.Timestamp.Format(time.RFC3339),
+		Tags:      message.Tags,
+		TableData: message.TableData,
+	}
+	// 只推送可序列化的扩展数据，send_config 等渠道内部对象不外发
+	for key, value := range message.Extra {
+		if key == "send_config" {
+			continue
+		}
+		if payload.Extra == nil {
+			payload.Extra = make(map[string]interface{})
+		}
+		payload.Extra[key] = value
+	}
+
+	opts := []httpclient.RequestOption{httpclient.WithContentType("application/json")}
+	if len(w.serverConfig.Headers) > 0 {
+		opts = append(opts, httpclient.WithHeaders(w.serverConfig.Headers))
+	}
+
+	var (
+		resp *httpclient.Response
+		err  error
+	)
+	if w.serverConfig.Method == http.MethodPut {
+		resp, err = w.httpClient.Put(ctx, w.serverConfig.URL, payload, opts...)
+	} else {
+		resp, err = w.httpClient.Post(ctx, w.serverConfig.URL, payload, opts...)
+	}
+	if err != nil {
+		return "", fmt.Errorf("发送请求失败: %w", err)
+	}
+
+	responseBody := resp.BodyString()
+	if !resp.IsSuccess() {
+		return responseBody, fmt.Errorf("HTTP请求失败，状态码: %d, 响应: %s", resp.StatusCode, responseBody)
+	}
+	return responseBody, nil
+}
+
+// Type 返回渠道类型
+func (w *WebhookChannel) Type() alert.AlertType {
+	return w.channelType
+}
+
+// Name 返回渠道名称
+func (w *WebhookChannel) Name() string {
+	return w.name
+}
+
+// IsEnabled 检查渠道是否启用
+func (w *WebhookChannel) IsEnabled() bool {
+	return w.enabled
+}
+
+// Enable 启用渠道
+func (w *WebhookChannel) Enable() error {
+	w.enabled = true
+	return nil
+}
+
+// Disable 禁用渠道
+func (w *WebhookChannel) Disable() error {
+	w.enabled = false
+	return nil
+}
+
+// Close 关闭渠道
+func (w *WebhookChannel) Close() error {
+	if w.httpClient != nil {
+		return w.httpClient.Close()
+	}
+	return nil
+}
+
+// HealthCheck 健康检查
+func (w *WebhookChannel) HealthCheck(ctx context.Context) *alert.HealthCheckResult {
+	startTime := time.Now()
+	result := &alert.HealthCheckResult{
+		Timestamp: startTime,
+		Extra:     make(map[string]interface{}),
+	}
+
+	responseBody, err := w.sendMessage(ctx, &alert.Message{
+		Title:     "健康检查",
+		Content:   "这是一条健康检查消息",
+		Timestamp: time.Now(),
+	})
+	result.Duration = time.Since(startTime)
+	if responseBody != "" {
+		result.Extra["response_body"] = responseBody
+	}
+	if err != nil {
+		result.Error = fmt.Errorf("健康检查失败: %w", err)
+		result.Message = fmt.Sprintf("Webhook健康检查失败: %s", err.Error())
+		return result
+	}
+
+	result.Success = true
+	result.Message = "Webhook渠道健康检查通过"
+	return result
+}
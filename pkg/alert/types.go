@@ -52,6 +52,18 @@ type Message struct {
 	// TableData 表格数据，当 DisplayFormat 为 table 时使用
 	// 是一个 map[string]interface{}，键为列名或行标识，值为对应的数据
 	TableData map[string]interface{}
+	// Attachments 附件列表，仅邮件渠道支持，其余渠道忽略
+	Attachments []Attachment
+}
+
+// Attachment 消息附件
+type Attachment struct {
+	// Name 附件文件名
+	Name string
+	// ContentType 附件MIME类型，为空时使用 application/octet-stream
+	ContentType string
+	// Data 附件内容
+	Data []byte
 }
 
 // NewMessage 创建新的告警消息
@@ -141,6 +153,21 @@ func (m *Message) WithExtra(key string, value interface{}) *Message {
 	return m
 }
 
+// WithAttachment 添加附件（链式调用）
+// 参数:
+//
+//	name: 附件文件名
+//	contentType: 附件MIME类型
+//	data: 附件内容
+//
+// 返回:
+//
+//	*Message: 消息实例，支持链式调用
+func (m *Message) WithAttachment(name, contentType string, data []byte) *Message {
+	m.Attachments = append(m.Attachments, Attachment{Name: name, ContentType: contentType, Data: data})
+	return m
+}
+
 // WithTimestamp 设置时间戳（链式调用）
 // 参数:
 //
@@ -0,0 +1,245 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"gateway/pkg/logger"
+)
+
+// loaderEnvelopeMagic 加载器写入缓存的数据头，数据头后依次为过期时间和加载耗时
+var loaderEnvelopeMagic = []byte("\x00GWL")
+
+// loaderEnvelopeHeaderSize 数据头长度：魔数 + 过期时间(UnixNano) + 加载耗时(纳秒)
+var loaderEnvelopeHeaderSize = len(loaderEnvelopeMagic) + 16
+
+// DefaultLoaderOptions 默认的防击穿选项：过期时间随机缩短最多10%，开启概率提前刷新
+var DefaultLoaderOptions = LoaderOptions{
+	TTLJitter:        0.1,
+	EarlyRefreshBeta: 1,
+}
+
+// sharedLoaders 每个缓存实例共享的默认加载器，key为缓存实例
+var sharedLoaders sync.Map
+
+// LoaderOptions 缓存加载器的防击穿选项
+type LoaderOptions struct {
+	// TTLJitter 过期时间随机抖动比例(0~1)，实际过期时间在 [ttl*(1-TTLJitter), ttl] 内随机，
+	// 避免同一时间写入的大量缓存同时过期；<=0 表示不抖动
+	TTLJitter float64
+
+	// EarlyRefreshBeta 概率提前刷新系数，<=0 表示关闭
+	// 缓存命中时按 XFetch 算法判断是否提前刷新：越接近过期、加载耗时越长，提前刷新的概率越大，
+	// 提前刷新在后台执行，当前请求仍返回缓存值；1 为推荐值，越大越倾向于提前刷新
+	EarlyRefreshBeta float64
+}
+
+// LoadFunc 缓存未命中时加载数据的函数
+// 返回:
+//   - []byte: 加载的数据
+//   - bool: 是否写入缓存，为 false 时只返回给本次等待的调用方
+//   - error: 加载失败时返回错误信息，错误不缓存
+type LoadFunc func(ctx context.Context) ([]byte, bool, error)
+
+// Loader 带防击穿保护的缓存加载器
+// 核心特性:
+// 1. 单飞加载 - 同一进程内同一个键同时未命中时只加载一次，其余调用等待并共享结果
+// 2. 过期时间抖动 - 写入缓存时随机缩短过期时间，避免批量写入的键同时过期
+// 3. 概率提前刷新 - 热点键过期前按概率在后台提前加载，过期时缓存已被刷新，不会集中回源
+//
+// 加载器写入的数据带有过期时间和加载耗时的数据头，应通过同一加载器读取；
+// 读到不带数据头的数据时按普通缓存值返回，不参与提前刷新
+type Loader struct {
+	cache   Cache
+	options LoaderOptions
+	group   singleflight.Group
+}
+
+// NewLoader 创建缓存加载器
+// 参数:
+//   - c: 缓存实例
+//   - options: 防击穿选项
+//
+// 返回:
+//   - *Loader: 缓存加载器
+func NewLoader(c Cache, options LoaderOptions) *Loader {
+	return &Loader{cache: c, options: options}
+}
+
+// SharedLoader 获取缓存实例共享的加载器，使用 DefaultLoaderOptions
+// 同一缓存实例的调用方共享单飞加载，适用于在请求处理中按需获取加载器的场景
+// 参数:
+//   - c: 缓存实例
+//
+// 返回:
+//   - *Loader: 缓存加载器
+func SharedLoader(c Cache) *Loader {
+	if loader, ok := sharedLoaders.Load(c); ok {
+		return loader.(*Loader)
+	}
+	loader, _ := sharedLoaders.LoadOrStore(c, NewLoader(c, DefaultLoaderOptions))
+	return loader.(*Loader)
+}
+
+// Get 获取缓存值，未命中时通过 load 加载并写入缓存
+// 参数:
+//   - ctx: 上下文
+//   - key: 缓存键
+//   - ttl: 缓存有效期，写入时按 TTLJitter 抖动；<=0 表示永不过期，此时不抖动也不提前刷新
+//   - load: 加载函数
+//
+// 返回:
+//   - []byte: 缓存值或加载的数据
+//   - error: 加载失败时返回错误信息，读写缓存失败不算错误
+func (l *Loader) Get(ctx context.Context, key string, ttl time.Duration, load LoadFunc) ([]byte, error) {
+	if data, err := l.cache.Get(ctx, key); err == nil && len(data) > 0 {
+		value, expiry, delta, ok := decodeLoaderEnvelope(data)
+		if !ok {
+			return data, nil
+		}
+		if l.options.EarlyRefreshBeta > 0 && ShouldRefreshEarly(time.Now(), expiry, delta, l.options.EarlyRefreshBeta, rand.Float64()) {
+			l.refreshAsync(ctx, key, ttl, load)
+		}
+		return value, nil
+	}
+
+	value, err, _ := l.group.Do(key, func() (interface{}, error) {
+		return l.load(ctx, key, ttl, load)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]byte), nil
+}
+
+// Forget 忘记正在进行的加载，之后的未命中会重新加载
+// 数据源变更并删除缓存后调用，避免等待中的调用方拿到变更前的加载结果
+// 参数:
+//   - key: 缓存键
+func (l *Loader) Forget(key string) {
+	l.group.Forget(key)
+}
+
+// refreshAsync 在后台提前刷新缓存，同一个键正在加载时不重复刷新
+func (l *Loader) refreshAsync(ctx context.Context, key string, ttl time.Duration, load LoadFunc) {
+	refreshCtx := context.WithoutCancel(ctx)
+	l.group.DoChan(key, func() (interface{}, error) {
+		value, err := l.load(refreshCtx, key, ttl, load)
+		if err != nil {
+			logger.Warn("提前刷新缓存失败", "key", key, "error", err)
+		}
+		return value, err
+	})
+}
+
+// load 执行加载并按需写入缓存
+func (l *Loader) load(ctx context.Context, key string, ttl time.Duration, load LoadFunc) ([]byte, error) {
+	start := time.Now()
+	value, store, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if store {
+		if err := l.Store(ctx, key, value, ttl, time.Since(start)); err != nil {
+			logger.Warn("写入缓存失败", "key", key, "error", err)
+		}
+	}
+	return value, nil
+}
+
+// Store 按加载器的格式写入缓存值
+// 用于需要自行控制写入时机的调用方（如写入前需加锁校验数据是否已失效），LoadFunc 中写入后返回 false 即可
+// 参数:
+//   - ctx: 上下文
+//   - key: 缓存键
+//   - value: 缓存值
+//   - ttl: 缓存有效期，按 TTLJitter 抖动；<=0 表示永不过期
+//   - loadTime: 加载耗时，用于计算提前刷新的概率
+//
+// 返回:
+//   - error: 写入缓存失败时返回错误信息
+func (l *Loader) Store(ctx context.Context, key string, value []byte, ttl time.Duration, loadTime time.Duration) error {
+	var expiry time.Time
+	if ttl > 0 {
+		ttl = JitterTTL(ttl, l.options.TTLJitter, rand.Float64())
+		expiry = time.Now().Add(ttl)
+	}
+	return l.cache.Set(ctx, key, encodeLoaderEnvelope(value, expiry, loadTime), ttl)
+}
+
+// JitterTTL 按抖动比例随机缩短过期时间
+// 参数:
+//   - ttl: 过期时间
+//   - jitter: 抖动比例(0~1)，<=0 时不抖动
+//   - r: [0,1) 的随机数
+//
+// 返回:
+//   - time.Duration: 抖动后的过期时间，范围 [ttl*(1-jitter), ttl]，至少为1毫秒
+func JitterTTL(ttl time.Duration, jitter float64, r float64) time.Duration {
+	if ttl <= 0 || jitter <= 0 {
+		return ttl
+	}
+	jitter = math.Min(jitter, 1)
+	result := ttl - time.Duration(float64(ttl)*jitter*r)
+	if result < time.Millisecond {
+		return time.Millisecond
+	}
+	return result
+}
+
+// ShouldRefreshEarly 按 XFetch 算法判断是否提前刷新缓存
+// 当 now - delta*beta*ln(r) >= expiry 时提前刷新：加载耗时 delta 越长、越接近过期时间，提前刷新的概率越大
+// 参数:
+//   - now: 当前时间
+//   - expiry: 缓存过期时间，零值表示永不过期
+//   - delta: 上次加载耗时
+//   - beta: 提前刷新系数，<=0 时不提前刷新
+//   - r: (0,1] 的随机数
+//
+// 返回:
+//   - bool: 是否提前刷新
+func ShouldRefreshEarly(now, expiry time.Time, delta time.Duration, beta float64, r float64) bool {
+	if expiry.IsZero() || beta <= 0 {
+		return false
+	}
+	if r <= 0 {
+		r = math.SmallestNonzeroFloat64
+	}
+	gap := time.Duration(-float64(delta) * beta * math.Log(r))
+	return !now.Add(gap).Before(expiry)
+}
+
+// encodeLoaderEnvelope 编码带过期时间和加载耗时数据头的缓存值
+func encodeLoaderEnvelope(value []byte, expiry time.Time, delta time.Duration) []byte {
+	data := make([]byte, loaderEnvelopeHeaderSize+len(value))
+	n := copy(data, loaderEnvelopeMagic)
+	var expiryNano int64
+	if !expiry.IsZero() {
+		expiryNano = expiry.UnixNano()
+	}
+	binary.BigEndian.PutUint64(data[n:], uint64(expiryNano))
+	binary.BigEndian.PutUint64(data[n+8:], uint64(delta))
+	copy(data[loaderEnvelopeHeaderSize:], value)
+	return data
+}
+
+// decodeLoaderEnvelope 解码缓存值，不带数据头时返回 false
+func decodeLoaderEnvelope(data []byte) ([]byte, time.Time, time.Duration, bool) {
+	if len(data) < loaderEnvelopeHeaderSize || !bytes.HasPrefix(data, loaderEnvelopeMagic) {
+		return nil, time.Time{}, 0, false
+	}
+	n := len(loaderEnvelopeMagic)
+	var expiry time.Time
+	if expiryNano := int64(binary.BigEndian.Uint64(data[n:])); expiryNano > 0 {
+		expiry = time.Unix(0, expiryNano)
+	}
+	delta := time.Duration(binary.BigEndian.Uint64(data[n+8:]))
+	return data[loaderEnvelopeHeaderSize:], expiry, delta, true
+}
@@ -93,10 +93,11 @@ func (m *MemoryCache) HSet(ctx context.Context, key, field, value string) error
 func (m *MemoryCache) HGet(ctx context.Context, key, field string) (string, error) {
 	fullKey := m.buildKey(key)
 
+	// 读取字段期间持有读锁，HSet 会原地修改哈希
 	m.mu.RLock()
-	item, exists := m.items[fullKey]
-	m.mu.RUnlock()
+	defer m.mu.RUnlock()
 
+	item, exists := m.items[fullKey]
 	if !exists || m.isExpired(item) {
 		return "", nil
 	}
@@ -129,10 +130,11 @@ func (m *MemoryCache) HGet(ctx context.Context, key, field string) (string, erro
 func (m *MemoryCache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
 	fullKey := m.buildKey(key)
 
+	// 复制字段期间持有读锁，HSet 会原地修改哈希
 	m.mu.RLock()
-	item, exists := m.items[fullKey]
-	m.mu.RUnlock()
+	defer m.mu.RUnlock()
 
+	item, exists := m.items[fullKey]
 	if !exists || m.isExpired(item) {
 		return make(map[string]string), nil
 	}
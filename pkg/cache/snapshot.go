@@ -0,0 +1,296 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gateway/pkg/config"
+	"gateway/pkg/security"
+)
+
+// snapshotFileSuffix 快照文件扩展名
+const snapshotFileSuffix = ".json"
+
+var (
+	// ErrSnapshotNotFound 快照不存在
+	ErrSnapshotNotFound = errors.New("cache snapshot not found")
+
+	// ErrSnapshotExpired 快照超过最大保留时长，不再用于恢复
+	ErrSnapshotExpired = errors.New("cache snapshot expired")
+)
+
+// restoredSnapshots 已从快照恢复、仍处于降级模式的数据，key为快照名称，value为 SnapshotInfo
+var restoredSnapshots sync.Map
+
+// SnapshotInfo 快照的元信息
+type SnapshotInfo struct {
+	Name       string    `json:"name"`                 // 快照名称
+	SavedAt    time.Time `json:"savedAt"`              // 快照写入时间，即数据最后一次确认有效的时间
+	RestoredAt time.Time `json:"restoredAt,omitempty"` // 从快照恢复的时间，未恢复时为零值
+}
+
+// Age 返回快照数据距今的时长
+// 参数:
+//   - now: 当前时间
+//
+// 返回:
+//   - time.Duration: now 与快照写入时间的差值
+func (i SnapshotInfo) Age(now time.Time) time.Duration {
+	return now.Sub(i.SavedAt)
+}
+
+// snapshotFile 快照文件内容
+type snapshotFile struct {
+	Name      string          `json:"name"`
+	SavedAt   time.Time       `json:"savedAt"`
+	Encrypted bool            `json:"encrypted,omitempty"` // 为true时 Data 是数据JSON加密后的字符串密文
+	Data      json.RawMessage `json:"data"`
+}
+
+// SnapshotStore 本地磁盘快照存储
+// 内存中的缓存数据（注册中心缓存、网关路由配置等）定期以JSON写入本地目录，
+// 进程重启时如果 Redis 或数据库不可用，可从快照恢复最近一次有效的数据，以降级模式继续提供服务。
+// 每个快照一个文件，先写临时文件再重命名，写入中途崩溃不会损坏已有快照
+type SnapshotStore struct {
+	dir    string
+	maxAge time.Duration
+}
+
+// NewSnapshotStore 创建快照存储
+// 参数:
+//   - dir: 快照目录，不存在时在首次写入时创建
+//   - maxAge: 快照最大保留时长，超过后不再用于恢复；<=0 表示不限制
+//
+// 返回:
+//   - *SnapshotStore: 快照存储
+func NewSnapshotStore(dir string, maxAge time.Duration) *SnapshotStore {
+	return &SnapshotStore{dir: dir, maxAge: maxAge}
+}
+
+// GetSnapshotStore 按 cache.snapshot 配置创建快照存储
+// 返回:
+//   - *SnapshotStore: 快照存储，未启用 cache.snapshot.enabled 时返回 nil
+func GetSnapshotStore() *SnapshotStore {
+	if !config.GetBool("cache.snapshot.enabled", false) {
+		return nil
+	}
+	dir := config.GetString("cache.snapshot.directory", "./data/cache_snapshot")
+	maxAge := time.Duration(config.GetInt("cache.snapshot.max_age_hours", 0)) * time.Hour
+	return NewSnapshotStore(dir, maxAge)
+}
+
+// GetSnapshotInterval 获取定期写入快照的间隔
+// 返回:
+//   - time.Duration: cache.snapshot.interval_seconds 配置的间隔，默认60秒
+func GetSnapshotInterval() time.Duration {
+	seconds := config.GetInt("cache.snapshot.interval_seconds", 60)
+	if seconds <= 0 {
+		seconds = 60
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// Save 写入快照，同名快照被覆盖
+// 参数:
+//   - name: 快照名称
+//   - data: 快照数据，必须可以JSON序列化
+//
+// 返回:
+//   - error: 序列化或写入文件失败时返回错误信息
+func (s *SnapshotStore) Save(name string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("序列化快照 %s 失败: %w", name, err)
+	}
+	return s.write(name, payload, false)
+}
+
+// SaveEncrypted 使用默认密钥加密后写入快照，用于包含密钥、密码等敏感字段的数据
+// 读取时 Load 按快照文件中的加密标记自动解密，解密所用密钥需与写入时一致
+// 参数:
+//   - name: 快照名称
+//   - data: 快照数据，必须可以JSON序列化
+//
+// 返回:
+//   - error: 序列化、加密或写入文件失败时返回错误信息
+func (s *SnapshotStore) SaveEncrypted(name string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("序列化快照 %s 失败: %w", name, err)
+	}
+	ciphertext, err := security.EncryptBytesWithDefaultKey(payload)
+	if err != nil {
+		return fmt.Errorf("加密快照 %s 失败: %w", name, err)
+	}
+	payload, err = json.Marshal(ciphertext)
+	if err != nil {
+		return fmt.Errorf("序列化快照 %s 失败: %w", name, err)
+	}
+	return s.write(name, payload, true)
+}
+
+// write 将快照数据写入文件，先写临时文件再重命名
+func (s *SnapshotStore) write(name string, payload json.RawMessage, encrypted bool) error {
+	content, err := json.Marshal(snapshotFile{Name: name, SavedAt: time.Now(), Encrypted: encrypted, Data: payload})
+	if err != nil {
+		return fmt.Errorf("序列化快照 %s 失败: %w", name, err)
+	}
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("创建快照目录失败: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, ".snapshot-*")
+	if err != nil {
+		return fmt.Errorf("创建快照临时文件失败: %w", err)
+	}
+	tmpPath := tmp.Name()
+	_, writeErr := tmp.Write(content)
+	if writeErr == nil {
+		writeErr = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("写入快照 %s 失败: %w", name, writeErr)
+	}
+	if err := os.Rename(tmpPath, s.path(name)); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("写入快照 %s 失败: %w", name, err)
+	}
+	return nil
+}
+
+// Load 读取快照
+// 参数:
+//   - name: 快照名称
+//   - dest: 快照数据的反序列化目标，必须为指针
+//
+// 返回:
+//   - *SnapshotInfo: 快照元信息
+//   - error: 快照不存在时返回 ErrSnapshotNotFound，超过最大保留时长时返回 ErrSnapshotExpired
+func (s *SnapshotStore) Load(name string, dest interface{}) (*SnapshotInfo, error) {
+	content, err := os.ReadFile(s.path(name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrSnapshotNotFound
+		}
+		return nil, fmt.Errorf("读取快照 %s 失败: %w", name, err)
+	}
+
+	var file snapshotFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("解析快照 %s 失败: %w", name, err)
+	}
+	info := &SnapshotInfo{Name: name, SavedAt: file.SavedAt}
+	if s.maxAge > 0 && info.Age(time.Now()) > s.maxAge {
+		return info, ErrSnapshotExpired
+	}
+	if file.Encrypted {
+		var ciphertext string
+		if err := json.Unmarshal(file.Data, &ciphertext); err != nil {
+			return nil, fmt.Errorf("解析快照 %s 数据失败: %w", name, err)
+		}
+		plaintext, err := security.DecryptWithDefaultKey(ciphertext)
+		if err != nil {
+			return nil, fmt.Errorf("解密快照 %s 失败: %w", name, err)
+		}
+		file.Data = json.RawMessage(plaintext)
+	}
+	if err := json.Unmarshal(file.Data, dest); err != nil {
+		return nil, fmt.Errorf("解析快照 %s 数据失败: %w", name, err)
+	}
+	return info, nil
+}
+
+// List 列出名称以 prefix 开头的快照
+// 参数:
+//   - prefix: 快照名称前缀，为空时列出全部
+//
+// 返回:
+//   - []string: 按名称排序的快照名称
+//   - error: 读取目录失败时返回错误信息，目录不存在时返回空列表
+func (s *SnapshotStore) List(prefix string) ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("读取快照目录失败: %w", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		fileName := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(fileName, snapshotFileSuffix) {
+			continue
+		}
+		name, err := url.PathUnescape(strings.TrimSuffix(fileName, snapshotFileSuffix))
+		if err != nil || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// path 返回快照文件路径，名称经过转义，可包含任意字符
+func (s *SnapshotStore) path(name string) string {
+	return filepath.Join(s.dir, url.PathEscape(name)+snapshotFileSuffix)
+}
+
+// MarkSnapshotRestored 标记数据已从快照恢复，进入降级模式
+// 数据源恢复并重新加载成功后应调用 ClearSnapshotRestored 退出降级模式
+// 参数:
+//   - info: 恢复所用快照的元信息，RestoredAt 为零值时取当前时间
+func MarkSnapshotRestored(info SnapshotInfo) {
+	if info.RestoredAt.IsZero() {
+		info.RestoredAt = time.Now()
+	}
+	restoredSnapshots.Store(info.Name, info)
+}
+
+// ClearSnapshotRestored 清除快照恢复标记，退出降级模式
+// 参数:
+//   - name: 快照名称
+//
+// 返回:
+//   - bool: 清除前是否处于降级模式
+func ClearSnapshotRestored(name string) bool {
+	_, ok := restoredSnapshots.LoadAndDelete(name)
+	return ok
+}
+
+// IsSnapshotRestored 判断数据当前是否来自快照
+// 参数:
+//   - name: 快照名称
+//
+// 返回:
+//   - bool: true 表示数据从快照恢复，可能已过时
+func IsSnapshotRestored(name string) bool {
+	_, ok := restoredSnapshots.Load(name)
+	return ok
+}
+
+// RestoredSnapshots 列出当前处于降级模式的快照
+// 返回:
+//   - []SnapshotInfo: 按名称排序的快照元信息
+func RestoredSnapshots() []SnapshotInfo {
+	infos := make([]SnapshotInfo, 0)
+	restoredSnapshots.Range(func(key, value interface{}) bool {
+		infos = append(infos, value.(SnapshotInfo))
+		return true
+	})
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
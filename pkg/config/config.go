@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"crypto/md5"
 	"fmt"
 	"log"
@@ -37,6 +38,9 @@ var (
 type Config struct {
 	// viper 配置实例
 	viper *viper.Viper
+	// loaded 是否已加载过配置文件
+	// 加密配置文件通过 MergeConfig 合并，ConfigFileUsed 不会记录，需单独标记
+	loaded bool
 }
 
 // LoadOptions 配置加载选项
@@ -90,9 +94,11 @@ func LoadConfig(configDir string, options ...LoadOptions) error {
 	// 如果需要清除已有配置，则重新创建实例
 	if opts.ClearExisting {
 		global.viper = viper.New()
+		global.loaded = false
+		resetLoadedFiles()
 	} else if !opts.AllowOverride {
 		// 检查是否已加载配置
-		if global.viper.ConfigFileUsed() != "" {
+		if global.loaded {
 			return fmt.Errorf("配置已加载，不允许覆盖")
 		}
 	}
@@ -108,27 +114,55 @@ func LoadConfig(configDir string, options ...LoadOptions) error {
 	global.viper.SetEnvPrefix("GATEWAY")
 	global.viper.AutomaticEnv()
 
-	// 读取配置文件
-	global.viper.SetConfigName("app")
-	if err := global.viper.ReadInConfig(); err != nil {
-		// 允许app.yaml不存在
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return fmt.Errorf("读取app.yaml配置失败: %w", err)
+	// 配置文件搜索目录，与上面AddConfigPath的顺序一致
+	searchDirs := []string{configDir, "./configs", "."}
+
+	// 读取配置文件（整文件加密时先解密再合并）
+	if handled, err := mergeEncryptedConfigFile(global.viper, searchDirs, "app"); err != nil {
+		return fmt.Errorf("读取app.yaml配置失败: %w", err)
+	} else if !handled {
+		global.viper.SetConfigName("app")
+		if err := global.viper.ReadInConfig(); err != nil {
+			// 允许app.yaml不存在
+			if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+				return fmt.Errorf("读取app.yaml配置失败: %w", err)
+			}
+		} else {
+			global.loaded = true
+			recordLoadedFile(global.viper.ConfigFileUsed())
 		}
+	} else {
+		global.loaded = true
+		recordLoadedFile(findConfigFile(searchDirs, "app"))
 	}
 
 	// 加载其他配置文件
 	configs := []string{"logger", "database", "web"}
 	for _, config := range configs {
+		handled, err := mergeEncryptedConfigFile(global.viper, searchDirs, config)
+		if err != nil {
+			return fmt.Errorf("读取%s.yaml配置失败: %w", config, err)
+		}
+		if handled {
+			global.loaded = true
+			recordLoadedFile(findConfigFile(searchDirs, config))
+			continue
+		}
 		global.viper.SetConfigName(config)
 		if err := global.viper.MergeInConfig(); err != nil {
 			// 允许配置文件不存在
 			if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
 				return fmt.Errorf("读取%s.yaml配置失败: %w", config, err)
 			}
+		} else {
+			global.loaded = true
+			recordLoadedFile(global.viper.ConfigFileUsed())
 		}
 	}
 
+	// 解密以 ENCY_ 开头的配置项
+	decryptSettings(global.viper)
+
 	return nil
 }
 
@@ -304,9 +338,11 @@ func LoadConfigFile(filePath string, options ...LoadOptions) error {
 	// 如果需要清除已有配置，则重新创建实例
 	if opts.ClearExisting {
 		global.viper = viper.New()
+		global.loaded = false
+		resetLoadedFiles()
 	} else if !opts.AllowOverride {
 		// 检查是否已加载配置
-		if global.viper.ConfigFileUsed() != "" {
+		if global.loaded {
 			return fmt.Errorf("配置已加载，不允许覆盖")
 		}
 	}
@@ -321,16 +357,20 @@ func LoadConfigFile(filePath string, options ...LoadOptions) error {
 	configType := ext[1:] // 去掉点号
 	global.viper.SetConfigType(configType)
 
-	// 读取配置文件
-	file, err := os.Open(filePath)
+	// 读取配置文件（整文件加密时自动解密）
+	content, err := ReadConfigFileContent(filePath)
 	if err != nil {
 		return fmt.Errorf("打开配置文件失败: %w", err)
 	}
-	defer file.Close()
 
-	if err := global.viper.MergeConfig(file); err != nil {
+	if err := global.viper.MergeConfig(bytes.NewReader(content)); err != nil {
 		return fmt.Errorf("合并配置文件失败: %w", err)
 	}
+	global.loaded = true
+	recordLoadedFile(filePath)
+
+	// 解密以 ENCY_ 开头的配置项
+	decryptSettings(global.viper)
 
 	return nil
 }
@@ -339,6 +379,8 @@ func LoadConfigFile(filePath string, options ...LoadOptions) error {
 // 用于重置配置状态
 func Clear() {
 	global.viper = viper.New()
+	global.loaded = false
+	resetLoadedFiles()
 }
 
 // Load 加载配置文件
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// EncryptedValuePrefix 加密配置值前缀
+// 与 pkg/security 的 ENCY_ 格式保持一致，config 包不直接依赖 security 以避免循环引用
+const EncryptedValuePrefix = "ENCY_"
+
+// ValueDecryptor 配置值解密函数
+// 参数为带 ENCY_ 前缀的密文，返回解密后的明文
+type ValueDecryptor func(ciphertext string) (string, error)
+
+var (
+	// decryptor 已注册的配置解密函数，由 pkg/security 在 init 阶段注册
+	decryptor ValueDecryptor
+	// decryptorMu 保护 decryptor 的读写
+	decryptorMu sync.RWMutex
+)
+
+// RegisterDecryptor 注册配置值解密函数
+// 注册后，加载配置时会自动解密整文件加密的配置文件以及以 ENCY_ 开头的配置项
+// 参数:
+//   - fn: 解密函数，传入nil表示取消注册
+func RegisterDecryptor(fn ValueDecryptor) {
+	decryptorMu.Lock()
+	defer decryptorMu.Unlock()
+	decryptor = fn
+}
+
+// getDecryptor 获取已注册的解密函数
+func getDecryptor() ValueDecryptor {
+	decryptorMu.RLock()
+	defer decryptorMu.RUnlock()
+	return decryptor
+}
+
+// IsEncryptedValue 判断配置值是否为加密格式
+// 参数:
+//   - value: 配置值
+//
+// 返回:
+//   - bool: 以 ENCY_ 开头返回true
+func IsEncryptedValue(value string) bool {
+	return strings.HasPrefix(strings.TrimSpace(value), EncryptedValuePrefix)
+}
+
+// DecryptValue 解密单个配置值
+// 非加密格式的值原样返回，便于兼容明文配置
+// 参数:
+//   - value: 配置值（密文或明文）
+//
+// 返回:
+//   - string: 明文值
+//   - error: 未注册解密函数或解密失败时返回错误
+func DecryptValue(value string) (string, error) {
+	if !IsEncryptedValue(value) {
+		return value, nil
+	}
+	fn := getDecryptor()
+	if fn == nil {
+		return "", fmt.Errorf("未注册配置解密函数，无法解密加密配置")
+	}
+	return fn(strings.TrimSpace(value))
+}
+
+// ReadConfigFileContent 读取配置文件内容，整文件加密时自动解密
+// 整文件加密的格式为：文件内容整体是一个 ENCY_ 密文字符串
+// 参数:
+//   - filePath: 配置文件路径
+//
+// 返回:
+//   - []byte: 明文文件内容
+//   - error: 读取或解密失败时返回错误
+func ReadConfigFileContent(filePath string) ([]byte, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if !IsEncryptedValue(string(data)) {
+		return data, nil
+	}
+	plain, err := DecryptValue(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("解密配置文件 %s 失败: %w", filePath, err)
+	}
+	return []byte(plain), nil
+}
+
+// findConfigFile 在搜索目录中查找指定名称的yaml配置文件
+// 返回第一个存在的文件路径，未找到返回空字符串
+func findConfigFile(dirs []string, name string) string {
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		for _, ext := range []string{".yaml", ".yml"} {
+			candidate := filepath.Join(dir, name+ext)
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate
+			}
+		}
+	}
+	return ""
+}
+
+// mergeEncryptedConfigFile 如果找到的配置文件是整文件加密的，则解密后合并到viper
+// 参数:
+//   - v: viper实例
+//   - dirs: 搜索目录
+//   - name: 配置文件名（不含扩展名）
+//
+// 返回:
+//   - bool: 是否已按加密文件处理
+//   - error: 解密或合并失败时返回错误
+func mergeEncryptedConfigFile(v *viper.Viper, dirs []string, name string) (bool, error) {
+	filePath := findConfigFile(dirs, name)
+	if filePath == "" {
+		return false, nil
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil || !IsEncryptedValue(string(data)) {
+		return false, nil
+	}
+	plain, err := ReadConfigFileContent(filePath)
+	if err != nil {
+		return true, err
+	}
+	if err := v.MergeConfig(bytes.NewReader(plain)); err != nil {
+		return true, fmt.Errorf("合并加密配置文件 %s 失败: %w", filePath, err)
+	}
+	return true, nil
+}
+
+// decryptSettings 遍历viper中所有配置项，将 ENCY_ 开头的字符串值解密
+// 解密结果合并到配置文件层而非 Set 覆盖层，GATEWAY_ 环境变量仍优先于配置文件生效；
+// 值本身来自环境变量时解密后写回覆盖层，生效值仍是该环境变量的明文
+// 单个配置项解密失败只记录警告并保留原值，由使用方按需再次解密
+func decryptSettings(v *viper.Viper) {
+	if getDecryptor() == nil {
+		return
+	}
+	decrypted := make(map[string]interface{})
+	for _, key := range v.AllKeys() {
+		raw, ok := v.Get(key).(string)
+		if !ok || !IsEncryptedValue(raw) {
+			continue
+		}
+		plain, err := DecryptValue(raw)
+		if err != nil {
+			log.Printf("警告: 配置项 %s 解密失败，保留原值: %v", key, err)
+			continue
+		}
+		if env, ok := os.LookupEnv(EnvVarName(key)); ok && env != "" {
+			v.Set(key, plain)
+		} else {
+			setNestedValue(decrypted, key, plain)
+		}
+		markDecrypted(key)
+	}
+	if len(decrypted) == 0 {
+		return
+	}
+	if err := v.MergeConfigMap(decrypted); err != nil {
+		log.Printf("警告: 合并解密后的配置项失败: %v", err)
+	}
+}
+
+// setNestedValue 按点号分隔的配置键将值写入嵌套map，供 MergeConfigMap 合并
+func setNestedValue(m map[string]interface{}, key string, value interface{}) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := m[part].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			m[part] = child
+		}
+		m = child
+	}
+	m[parts[len(parts)-1]] = value
+}
@@ -0,0 +1,319 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"gateway/pkg/utils/mask"
+
+	"github.com/spf13/viper"
+)
+
+// 配置值来源
+const (
+	SourceEnv     = "env"     // 环境变量（GATEWAY_ 前缀，优先级最高）
+	SourceFile    = "file"    // 配置文件
+	SourceRuntime = "runtime" // 运行时写入，不来自配置文件和环境变量
+	SourceDefault = "default" // 未配置，使用代码中的默认值
+)
+
+// 配置文件差异类型
+const (
+	DiffAdded   = "added"   // 文件中新增的配置项
+	DiffRemoved = "removed" // 文件中删除的配置项
+	DiffChanged = "changed" // 文件中修改的配置项
+)
+
+// envPrefix 环境变量前缀，与 LoadConfig 中 SetEnvPrefix 一致
+const envPrefix = "GATEWAY"
+
+// loadedFile 启动时加载的配置文件及加载时的内容
+type loadedFile struct {
+	path     string
+	settings map[string]interface{}
+}
+
+var (
+	// inspectMu 保护已加载文件和已解密配置项的记录
+	inspectMu sync.RWMutex
+	// loadedFiles 按加载顺序记录的配置文件，后加载的文件覆盖先加载的同名配置项
+	loadedFiles []loadedFile
+	// decryptedKeys 加载时解密过的配置项
+	decryptedKeys = make(map[string]bool)
+)
+
+// KeyInspection 配置项的生效值及来源
+type KeyInspection struct {
+	Key       string      `json:"key"`             // 配置键
+	Value     interface{} `json:"value"`           // 生效值，敏感配置项已脱敏；未配置时为 nil
+	Source    string      `json:"source"`          // 生效值来源：env、file、runtime、default
+	File      string      `json:"file,omitempty"`  // 来源为 file 时生效的配置文件
+	Files     []string    `json:"files,omitempty"` // 定义了该配置项的全部配置文件，按加载顺序，最后一个生效
+	EnvVar    string      `json:"envVar"`          // 可覆盖该配置项的环境变量名
+	Masked    bool        `json:"masked"`          // 值是否已脱敏
+	Decrypted bool        `json:"decrypted"`       // 值是否为加载时解密的 ENCY_ 密文
+}
+
+// FileDiff 配置文件当前内容与启动时加载内容的差异
+type FileDiff struct {
+	File    string      `json:"file"`              // 配置文件
+	Key     string      `json:"key"`               // 配置键
+	Kind    string      `json:"kind"`              // 差异类型：added、removed、changed
+	Booted  interface{} `json:"booted,omitempty"`  // 启动时的值，敏感配置项已脱敏
+	Current interface{} `json:"current,omitempty"` // 文件当前的值，敏感配置项已脱敏
+}
+
+// resetLoadedFiles 清空已加载文件的记录
+func resetLoadedFiles() {
+	inspectMu.Lock()
+	defer inspectMu.Unlock()
+	loadedFiles = nil
+	decryptedKeys = make(map[string]bool)
+}
+
+// recordLoadedFile 记录已加载的配置文件及其内容，读取失败时忽略，不影响配置加载
+func recordLoadedFile(path string) {
+	if path == "" {
+		return
+	}
+	settings, err := readConfigSettings(path)
+	if err != nil {
+		return
+	}
+	if absPath, err := filepath.Abs(path); err == nil {
+		path = absPath
+	}
+
+	inspectMu.Lock()
+	defer inspectMu.Unlock()
+	loadedFiles = append(loadedFiles, loadedFile{path: path, settings: settings})
+}
+
+// markDecrypted 记录加载时解密过的配置项
+func markDecrypted(key string) {
+	inspectMu.Lock()
+	defer inspectMu.Unlock()
+	decryptedKeys[key] = true
+}
+
+// readConfigSettings 读取配置文件并展开为 键路径 -> 值，整文件加密时先解密
+func readConfigSettings(path string) (map[string]interface{}, error) {
+	content, err := ReadConfigFileContent(path)
+	if err != nil {
+		return nil, err
+	}
+	v := viper.New()
+	v.SetConfigType(strings.TrimPrefix(filepath.Ext(path), "."))
+	if err := v.ReadConfig(bytes.NewReader(content)); err != nil {
+		return nil, fmt.Errorf("解析配置文件 %s 失败: %w", path, err)
+	}
+	settings := make(map[string]interface{})
+	for _, key := range v.AllKeys() {
+		settings[key] = v.Get(key)
+	}
+	return settings, nil
+}
+
+// EnvVarName 返回可覆盖配置项的环境变量名
+// 与 viper AutomaticEnv 的规则一致：前缀加下划线加配置键，整体大写，点号保留
+// 参数:
+//   - key: 配置键，如 app.name
+//
+// 返回:
+//   - string: 环境变量名，如 GATEWAY_APP.NAME
+func EnvVarName(key string) string {
+	return strings.ToUpper(envPrefix + "_" + key)
+}
+
+// LoadedConfigFiles 返回启动时加载的配置文件
+// 返回:
+//   - []string: 配置文件绝对路径，按加载顺序
+func LoadedConfigFiles() []string {
+	inspectMu.RLock()
+	defer inspectMu.RUnlock()
+	files := make([]string, 0, len(loadedFiles))
+	for _, file := range loadedFiles {
+		files = append(files, file.path)
+	}
+	return files
+}
+
+// Inspect 查询配置项的生效值及来源，用于排查配置项为何取到某个值
+// 敏感配置项（密码、令牌、密钥等）和加载时解密的配置项的值会被脱敏
+// 参数:
+//   - key: 配置键，不区分大小写；为配置段（如 database.clickhouse_retention）时返回整段的值
+//
+// 返回:
+//   - KeyInspection: 配置项的生效值及来源
+func Inspect(key string) KeyInspection {
+	key = strings.ToLower(strings.TrimSpace(key))
+	envVar := EnvVarName(key)
+	result := KeyInspection{Key: key, EnvVar: envVar, Source: SourceDefault}
+
+	inspectMu.RLock()
+	for _, file := range loadedFiles {
+		if definesKey(file.settings, key) {
+			result.Files = append(result.Files, file.path)
+		}
+	}
+	result.Decrypted = decryptedKeys[key]
+	inspectMu.RUnlock()
+
+	if !IsExist(key) {
+		return result
+	}
+
+	// 优先级与 viper 一致：非空环境变量 > 配置文件 > 运行时写入
+	switch value, ok := os.LookupEnv(envVar); {
+	case ok && value != "":
+		result.Source = SourceEnv
+	case len(result.Files) > 0:
+		result.Source = SourceFile
+		result.File = result.Files[len(result.Files)-1]
+	default:
+		result.Source = SourceRuntime
+	}
+	result.Value, result.Masked = maskConfigValue(key, global.viper.Get(key), result.Decrypted)
+	return result
+}
+
+// InspectAll 查询指定前缀下所有配置项的生效值及来源
+// 参数:
+//   - prefix: 配置键前缀，如 database.connections；为空时返回全部配置项
+//
+// 返回:
+//   - []KeyInspection: 按配置键排序的查询结果
+func InspectAll(prefix string) []KeyInspection {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if global == nil || global.viper == nil {
+		return []KeyInspection{}
+	}
+
+	keys := global.viper.AllKeys()
+	sort.Strings(keys)
+	results := make([]KeyInspection, 0, len(keys))
+	for _, key := range keys {
+		if prefix != "" && key != prefix && !strings.HasPrefix(key, prefix+".") {
+			continue
+		}
+		results = append(results, Inspect(key))
+	}
+	return results
+}
+
+// DiffLoadedFiles 比较启动时加载的配置文件与文件当前内容
+// 配置文件修改后不会自动生效，比较结果为重启后将发生变化的配置项；文件已删除时其全部配置项视为删除
+// 返回:
+//   - []FileDiff: 按文件加载顺序和配置键排序的差异，敏感配置项已脱敏
+//   - error: 配置文件读取或解析失败时返回错误信息
+func DiffLoadedFiles() ([]FileDiff, error) {
+	inspectMu.RLock()
+	files := append([]loadedFile(nil), loadedFiles...)
+	inspectMu.RUnlock()
+
+	diffs := make([]FileDiff, 0)
+	for _, file := range files {
+		current, err := readConfigSettings(file.path)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			current = map[string]interface{}{}
+		}
+
+		keys := make([]string, 0, len(file.settings)+len(current))
+		for key := range file.settings {
+			keys = append(keys, key)
+		}
+		for key := range current {
+			if _, ok := file.settings[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			booted, inBooted := file.settings[key]
+			now, inCurrent := current[key]
+			diff := FileDiff{File: file.path, Key: key}
+			switch {
+			case !inCurrent:
+				diff.Kind = DiffRemoved
+			case !inBooted:
+				diff.Kind = DiffAdded
+			case !reflect.DeepEqual(booted, now):
+				diff.Kind = DiffChanged
+			default:
+				continue
+			}
+			if inBooted {
+				diff.Booted, _ = maskConfigValue(key, booted, false)
+			}
+			if inCurrent {
+				diff.Current, _ = maskConfigValue(key, now, false)
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs, nil
+}
+
+// definesKey 判断展开后的配置中是否定义了配置项或配置段
+func definesKey(settings map[string]interface{}, key string) bool {
+	if _, ok := settings[key]; ok {
+		return true
+	}
+	for k := range settings {
+		if strings.HasPrefix(k, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// maskConfigValue 对敏感配置项脱敏，配置段按子键递归处理
+// 返回脱敏后的值和是否有值被脱敏
+func maskConfigValue(key string, value interface{}, decrypted bool) (interface{}, bool) {
+	if section, ok := value.(map[string]interface{}); ok {
+		maskedSection := make(map[string]interface{}, len(section))
+		masked := false
+		for k, v := range section {
+			childKey := key + "." + k
+			var childMasked bool
+			maskedSection[k], childMasked = maskConfigValue(childKey, v, isDecrypted(childKey))
+			masked = masked || childMasked
+		}
+		return maskedSection, masked
+	}
+	if value == nil {
+		return nil, false
+	}
+	if s, ok := value.(string); decrypted || (ok && IsEncryptedValue(s)) {
+		return mask.Masked, true
+	}
+	// 敏感键名整体脱敏，其余字符串值中的 DSN 密码、令牌等按内容脱敏
+	sanitized := mask.SanitizeValue(lastKeySegment(key), value)
+	return sanitized, !reflect.DeepEqual(sanitized, value)
+}
+
+// isDecrypted 判断配置项是否为加载时解密的配置项
+func isDecrypted(key string) bool {
+	inspectMu.RLock()
+	defer inspectMu.RUnlock()
+	return decryptedKeys[key]
+}
+
+// lastKeySegment 返回配置键的最后一段，避免 token_cache.enabled 之类的配置段名误判为敏感
+func lastKeySegment(key string) string {
+	if idx := strings.LastIndex(key, "."); idx >= 0 {
+		return key[idx+1:]
+	}
+	return key
+}
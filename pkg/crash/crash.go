@@ -0,0 +1,319 @@
+// Package crash 后台协程的panic恢复和崩溃报告
+//
+// 网关工作协程、定时任务、订阅者等后台协程通过本包启动或在defer中恢复panic：
+//   - 记录包含完整堆栈的错误日志
+//   - 按组件累计崩溃次数，可通过 Stats 查询
+//   - 在 app.crash.report_dir 目录写入崩溃报告文件，超过 app.crash.max_reports 时删除最早的报告
+//   - 通过 Supervise 运行的长期协程在panic后按退避间隔重启，在时间窗口内重启次数超过上限后停止重启
+//
+// 单个组件panic只影响该组件，不会导致整个进程退出
+package crash
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gateway/pkg/config"
+	"gateway/pkg/logger"
+)
+
+// 默认配置
+const (
+	DefaultReportDir     = "./logs/crash" // 崩溃报告目录
+	DefaultMaxReports    = 100            // 保留的崩溃报告文件数
+	DefaultMaxRestarts   = 5              // 时间窗口内的最大重启次数
+	DefaultRestartWindow = time.Minute    // 重启次数统计的时间窗口
+	DefaultRestartDelay  = time.Second    // 首次重启的等待时间，之后每次翻倍
+	maxRestartDelay      = time.Minute    // 重启等待时间上限
+)
+
+// ErrPanic Call 捕获到panic时返回的错误，可通过 errors.Is 判断
+var ErrPanic = errors.New("panic recovered")
+
+// Crash 一次panic的信息
+type Crash struct {
+	Component  string    `json:"component"`
+	Value      string    `json:"value"`
+	Stack      string    `json:"stack"`
+	Time       time.Time `json:"time"`
+	ReportFile string    `json:"reportFile,omitempty"` // 崩溃报告文件路径，写入失败时为空
+}
+
+// ComponentStats 组件的崩溃统计
+type ComponentStats struct {
+	Component  string    `json:"component"`
+	Crashes    int64     `json:"crashes"`              // 累计panic次数
+	Restarts   int64     `json:"restarts"`             // 累计重启次数
+	GaveUp     bool      `json:"gaveUp"`               // 重启次数超过上限，已停止重启
+	LastValue  string    `json:"lastValue"`            // 最近一次panic的值
+	LastCrash  time.Time `json:"lastCrash"`            // 最近一次panic的时间
+	LastReport string    `json:"lastReport,omitempty"` // 最近一次崩溃报告文件
+}
+
+var (
+	mu    sync.Mutex
+	stats = make(map[string]*ComponentStats)
+	// reportMu 串行写入崩溃报告，避免清理旧报告时并发删除
+	reportMu sync.Mutex
+)
+
+// Recover 在defer中调用，恢复当前协程的panic并记录崩溃信息
+//
+// 用法: defer crash.Recover("gateway.accesslog")
+func Recover(component string) {
+	if r := recover(); r != nil {
+		Handle(component, r)
+	}
+}
+
+// Handle 记录已恢复的panic：输出堆栈日志、累计崩溃次数、写入崩溃报告
+// 供已有的 recover() 位置调用，必须在recover所在的defer函数中调用以保留panic现场的堆栈
+// 参数:
+//
+//	component: 组件名称，如 gateway.listener、timer.task
+//	value: recover() 的返回值
+//
+// 返回:
+//
+//	*Crash: 崩溃信息
+func Handle(component string, value interface{}) *Crash {
+	c := &Crash{
+		Component: component,
+		Value:     fmt.Sprint(value),
+		Stack:     string(debug.Stack()),
+		Time:      time.Now(),
+	}
+	if path, err := writeReport(c); err != nil {
+		logger.Warn("写入崩溃报告失败", "component", component, "error", err)
+	} else {
+		c.ReportFile = path
+	}
+
+	mu.Lock()
+	s := statsOf(component)
+	s.Crashes++
+	s.LastValue = c.Value
+	s.LastCrash = c.Time
+	s.LastReport = c.ReportFile
+	mu.Unlock()
+
+	logger.Error("后台协程发生panic", "component", component, "panic", c.Value,
+		"reportFile", c.ReportFile, "stack", c.Stack)
+	return c
+}
+
+// Go 启动协程执行一次性任务，panic时记录崩溃信息，不重启
+func Go(component string, fn func()) {
+	go func() {
+		defer Recover(component)
+		fn()
+	}()
+}
+
+// Call 执行函数并将panic转换为错误，适用于定时任务等需要把panic作为执行失败处理的场景
+// 参数:
+//
+//	component: 组件名称
+//	fn: 要执行的函数
+//
+// 返回:
+//
+//	error: fn 返回的错误；panic时返回包装了 ErrPanic 的错误
+func Call(component string, fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			c := Handle(component, r)
+			err = fmt.Errorf("%w: %s", ErrPanic, c.Value)
+		}
+	}()
+	return fn()
+}
+
+// Supervise 同步运行长期执行的函数，panic后按退避间隔重启
+// fn 正常返回时 Supervise 返回；未启用重启（app.crash.restart.enabled=false）或
+// app.crash.restart.window_seconds 内重启次数超过 app.crash.restart.max_restarts 时不再重启，组件停止运行
+// 参数:
+//
+//	component: 组件名称
+//	fn: 要执行的函数，应在自身的停止信号到达时返回
+//
+// 返回:
+//
+//	*Crash: 组件因panic停止时返回最后一次崩溃信息，正常返回时为nil
+func Supervise(component string, fn func()) *Crash {
+	var restarts []time.Time
+	delay := restartDelay()
+	for {
+		c := runOnce(component, fn)
+		if c == nil {
+			return nil
+		}
+
+		if !config.GetBool("app.crash.restart.enabled", true) {
+			markGaveUp(component)
+			return c
+		}
+		now := time.Now()
+		window := time.Duration(config.GetInt("app.crash.restart.window_seconds", int(DefaultRestartWindow/time.Second))) * time.Second
+		kept := restarts[:0]
+		for _, t := range restarts {
+			if now.Sub(t) < window {
+				kept = append(kept, t)
+			}
+		}
+		restarts = kept
+		if len(restarts) >= config.GetInt("app.crash.restart.max_restarts", DefaultMaxRestarts) {
+			markGaveUp(component)
+			logger.Error("组件重启次数超过上限，停止重启", "component", component, "restarts", len(restarts), "window", window)
+			return c
+		}
+		restarts = append(restarts, now)
+
+		logger.Warn("组件panic后重启", "component", component, "delay", delay, "attempt", len(restarts))
+		time.Sleep(delay)
+		delay *= 2
+		if delay > maxRestartDelay {
+			delay = maxRestartDelay
+		}
+
+		mu.Lock()
+		statsOf(component).Restarts++
+		mu.Unlock()
+	}
+}
+
+// GoSupervised 启动协程以 Supervise 方式运行长期执行的函数
+func GoSupervised(component string, fn func()) {
+	go Supervise(component, fn)
+}
+
+// runOnce 执行一次函数，panic时返回崩溃信息
+func runOnce(component string, fn func()) (c *Crash) {
+	defer func() {
+		if r := recover(); r != nil {
+			c = Handle(component, r)
+		}
+	}()
+	fn()
+	return nil
+}
+
+// restartDelay 首次重启的等待时间
+func restartDelay() time.Duration {
+	delay := time.Duration(config.GetInt("app.crash.restart.delay_ms", int(DefaultRestartDelay/time.Millisecond))) * time.Millisecond
+	if delay <= 0 {
+		delay = DefaultRestartDelay
+	}
+	return delay
+}
+
+// markGaveUp 标记组件已停止重启
+func markGaveUp(component string) {
+	mu.Lock()
+	statsOf(component).GaveUp = true
+	mu.Unlock()
+}
+
+// statsOf 获取组件统计，调用方需持有 mu
+func statsOf(component string) *ComponentStats {
+	s, ok := stats[component]
+	if !ok {
+		s = &ComponentStats{Component: component}
+		stats[component] = s
+	}
+	return s
+}
+
+// Stats 获取所有发生过panic的组件统计，按组件名称排序
+func Stats() []ComponentStats {
+	mu.Lock()
+	defer mu.Unlock()
+	result := make([]ComponentStats, 0, len(stats))
+	for _, s := range stats {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Component < result[j].Component })
+	return result
+}
+
+// TotalCrashes 累计panic次数
+func TotalCrashes() int64 {
+	mu.Lock()
+	defer mu.Unlock()
+	var total int64
+	for _, s := range stats {
+		total += s.Crashes
+	}
+	return total
+}
+
+// Reset 清空崩溃统计
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	stats = make(map[string]*ComponentStats)
+}
+
+// unsafeFileChars 组件名称中不能用于文件名的字符
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// writeReport 写入崩溃报告文件并清理超出数量上限的旧报告
+// app.crash.report_dir 配置为 "-" 时不写入报告文件
+func writeReport(c *Crash) (string, error) {
+	dir := config.GetString("app.crash.report_dir", DefaultReportDir)
+	if dir == "-" {
+		return "", nil
+	}
+
+	reportMu.Lock()
+	defer reportMu.Unlock()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("crash-%s-%s.log", c.Time.Format("20060102-150405.000000"),
+		unsafeFileChars.ReplaceAllString(c.Component, "_"))
+	path := filepath.Join(dir, name)
+
+	hostname, _ := os.Hostname()
+	var b strings.Builder
+	fmt.Fprintf(&b, "component: %s\n", c.Component)
+	fmt.Fprintf(&b, "time: %s\n", c.Time.Format(time.RFC3339Nano))
+	fmt.Fprintf(&b, "panic: %s\n", c.Value)
+	fmt.Fprintf(&b, "node: %s\n", config.GetString(config.APP_NODE_ID, ""))
+	fmt.Fprintf(&b, "host: %s\n", hostname)
+	fmt.Fprintf(&b, "pid: %d\n", os.Getpid())
+	fmt.Fprintf(&b, "go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "goroutines: %d\n", runtime.NumGoroutine())
+	fmt.Fprintf(&b, "\n%s", c.Stack)
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return "", err
+	}
+
+	pruneReports(dir, config.GetInt("app.crash.max_reports", DefaultMaxReports))
+	return path, nil
+}
+
+// pruneReports 保留最新的 max 个崩溃报告，文件名包含时间戳，按名称排序即按时间排序
+func pruneReports(dir string, max int) {
+	if max <= 0 {
+		return
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "crash-*.log"))
+	if err != nil || len(files) <= max {
+		return
+	}
+	sort.Strings(files)
+	for _, file := range files[:len(files)-max] {
+		_ = os.Remove(file)
+	}
+}
@@ -0,0 +1,703 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"gateway/pkg/utils/mask"
+)
+
+// AuditRecorderKey 数据变更收集器在上下文中的键名
+// 使用字符串键，gin.Context 可通过 Set(AuditRecorderKey, ...) 直接携带
+const AuditRecorderKey = "gateway.database.audit.recorder"
+
+// auditTxKey 事务中待提交数据变更在上下文中的键名
+const auditTxKey = "gateway.database.audit.tx"
+
+// 数据变更操作类型
+const (
+	AuditOperationCreate = "CREATE"
+	AuditOperationUpdate = "UPDATE"
+	AuditOperationDelete = "DELETE"
+)
+
+// maxAuditRows 每次变更最多记录的变更前后数据行数
+const maxAuditRows = 100
+
+// AuditFieldChange 字段变更前后的值
+type AuditFieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditChange 一次数据变更
+type AuditChange struct {
+	Table        string                        `json:"table"`                  // 表名，原始SQL无法解析时为空
+	Operation    string                        `json:"operation"`              // 操作类型：CREATE/UPDATE/DELETE
+	Affected     int64                         `json:"affected"`               // 影响行数
+	Before       []map[string]interface{}      `json:"before,omitempty"`       // 变更前数据
+	After        []map[string]interface{}      `json:"after,omitempty"`        // 变更后数据
+	Diff         []map[string]AuditFieldChange `json:"diff,omitempty"`         // 更新时逐行的字段差异
+	Statement    string                        `json:"statement,omitempty"`    // 原始SQL写入的语句，无法记录前后数据
+	Truncated    bool                          `json:"truncated,omitempty"`    // 数据行数超过上限被截断
+	CaptureError string                        `json:"captureError,omitempty"` // 获取变更前后数据失败的原因
+}
+
+// AuditRecorder 收集一次请求中的数据变更，可并发使用
+type AuditRecorder struct {
+	mu       sync.Mutex
+	changes  []AuditChange
+	excluded map[string]bool // 规范化表名 -> 不记录
+}
+
+// NewAuditRecorder 创建数据变更收集器
+// 参数:
+//
+//	excludeTables: 不记录变更的表（如审计表本身、登录日志表）
+//
+// 返回:
+//
+//	*AuditRecorder: 数据变更收集器
+func NewAuditRecorder(excludeTables ...string) *AuditRecorder {
+	r := &AuditRecorder{excluded: make(map[string]bool)}
+	for _, table := range normalizeTables(excludeTables) {
+		r.excluded[table] = true
+	}
+	return r
+}
+
+// Changes 获取已提交的数据变更，事务回滚的变更不包含在内
+func (r *AuditRecorder) Changes() []AuditChange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]AuditChange(nil), r.changes...)
+}
+
+// add 记录已提交的数据变更
+func (r *AuditRecorder) add(changes ...AuditChange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changes = append(r.changes, changes...)
+}
+
+// Record 记录不经过数据库的数据变更（如只保存在缓存中的运行期数据），表不记录变更时忽略
+// 参数:
+//
+//	change: 数据变更，Table 为变更数据对应的表
+func (r *AuditRecorder) Record(change AuditChange) {
+	if r == nil || r.isExcluded(change.Table) {
+		return
+	}
+	r.add(change)
+}
+
+// isExcluded 判断表是否不记录变更
+func (r *AuditRecorder) isExcluded(table string) bool {
+	names := normalizeTables([]string{table})
+	return len(names) > 0 && r.excluded[names[0]]
+}
+
+// WithAuditRecorder 为上下文设置数据变更收集器，审计包装器将该上下文中的数据变更记录到收集器
+func WithAuditRecorder(ctx context.Context, recorder *AuditRecorder) context.Context {
+	return context.WithValue(ctx, AuditRecorderKey, recorder)
+}
+
+// AuditRecorderFromContext 获取上下文中的数据变更收集器，未设置时返回nil
+func AuditRecorderFromContext(ctx context.Context) *AuditRecorder {
+	recorder, _ := ctx.Value(AuditRecorderKey).(*AuditRecorder)
+	return recorder
+}
+
+// auditTx 事务中待提交的数据变更，提交后合并到上层事务或收集器，回滚时丢弃
+type auditTx struct {
+	mu      sync.Mutex
+	changes []AuditChange
+	parent  *auditTx
+}
+
+// add 记录事务中的数据变更
+func (t *auditTx) add(change AuditChange) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.changes = append(t.changes, change)
+}
+
+// flush 事务提交后将变更合并到上层事务或收集器
+func (t *auditTx) flush(recorder *AuditRecorder) {
+	t.mu.Lock()
+	changes := t.changes
+	t.changes = nil
+	t.mu.Unlock()
+	if t.parent != nil {
+		for _, change := range changes {
+			t.parent.add(change)
+		}
+		return
+	}
+	recorder.add(changes...)
+}
+
+// AuditDatabase 记录数据变更的数据库包装器
+// 核心特性:
+// 1. 按请求记录 - 只有上下文中设置了 AuditRecorder 时才记录，其他调用直接访问被包装的数据库
+// 2. 前后数据 - 更新和删除前按相同条件查询变更前数据，更新后再次查询变更后数据并计算字段差异，插入记录写入的数据
+// 3. 事务感知 - 事务中的变更在提交后才进入收集器，回滚的变更不记录
+// 4. 敏感字段 - 列名包含 password/secret/token/privateKey 的值以 ****** 记录
+//
+// 注意：前后数据查询与写入使用相同的事务设置；原始SQL写入（Exec）只记录语句，不记录前后数据
+type AuditDatabase struct {
+	Database
+}
+
+// NewAuditDatabase 创建记录数据变更的数据库包装器
+// 参数:
+//
+//	db: 被包装的数据库实例
+//
+// 返回:
+//
+//	*AuditDatabase: 数据库包装器，实现 Database 接口
+func NewAuditDatabase(db Database) *AuditDatabase {
+	return &AuditDatabase{Database: db}
+}
+
+// Unwrap 获取被包装的数据库实例
+func (a *AuditDatabase) Unwrap() Database {
+	return a.Database
+}
+
+// recorder 获取需要记录变更的收集器，上下文未设置或表不记录时返回nil
+func (a *AuditDatabase) recorder(ctx context.Context, table string) *AuditRecorder {
+	recorder := AuditRecorderFromContext(ctx)
+	if recorder == nil || (table != "" && recorder.isExcluded(table)) {
+		return nil
+	}
+	return recorder
+}
+
+// record 记录数据变更，事务中的变更暂存到事务
+func (a *AuditDatabase) record(ctx context.Context, recorder *AuditRecorder, change AuditChange) {
+	if tx, ok := ctx.Value(auditTxKey).(*auditTx); ok {
+		tx.add(change)
+		return
+	}
+	recorder.add(change)
+}
+
+// Exec 执行SQL语句，写入语句记录语句文本
+func (a *AuditDatabase) Exec(ctx context.Context, query string, args []interface{}, autoCommit bool) (int64, error) {
+	affected, err := a.Database.Exec(ctx, query, args, autoCommit)
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+	operation, table := parseAuditStatement(query)
+	if operation == "" {
+		return affected, nil
+	}
+	if recorder := a.recorder(ctx, table); recorder != nil {
+		a.record(ctx, recorder, AuditChange{
+			Table:     table,
+			Operation: operation,
+			Affected:  affected,
+			Statement: strings.TrimSpace(query),
+		})
+	}
+	return affected, nil
+}
+
+// Insert 插入记录，记录插入的数据
+func (a *AuditDatabase) Insert(ctx context.Context, table string, data interface{}, autoCommit bool) (int64, error) {
+	affected, err := a.Database.Insert(ctx, table, data, autoCommit)
+	if err != nil {
+		return affected, err
+	}
+	if recorder := a.recorder(ctx, table); recorder != nil {
+		change := AuditChange{Table: table, Operation: AuditOperationCreate, Affected: affected}
+		change.After, change.Truncated = auditRowsFromData(data)
+		a.record(ctx, recorder, change)
+	}
+	return affected, nil
+}
+
+// BatchInsert 批量插入记录，记录插入的数据
+func (a *AuditDatabase) BatchInsert(ctx context.Context, table string, dataSlice interface{}, autoCommit bool) (int64, error) {
+	affected, err := a.Database.BatchInsert(ctx, table, dataSlice, autoCommit)
+	if err != nil {
+		return affected, err
+	}
+	if recorder := a.recorder(ctx, table); recorder != nil {
+		change := AuditChange{Table: table, Operation: AuditOperationCreate, Affected: affected}
+		change.After, change.Truncated = auditRowsFromData(dataSlice)
+		a.record(ctx, recorder, change)
+	}
+	return affected, nil
+}
+
+// Update 更新记录，记录更新前后的数据和字段差异
+func (a *AuditDatabase) Update(ctx context.Context, table string, data interface{}, where string, args []interface{}, autoCommit bool, skipZero bool) (int64, error) {
+	recorder := a.recorder(ctx, table)
+	if recorder == nil {
+		return a.Database.Update(ctx, table, data, where, args, autoCommit, skipZero)
+	}
+
+	change := AuditChange{Table: table, Operation: AuditOperationUpdate}
+	before, truncated, captureErr := a.selectAuditRows(ctx, table, where, args, autoCommit)
+	affected, err := a.Database.Update(ctx, table, data, where, args, autoCommit, skipZero)
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+	change.Affected = affected
+	if captureErr == nil {
+		var after []map[string]interface{}
+		after, _, captureErr = a.selectAuditRows(ctx, table, where, args, autoCommit)
+		change.Diff = diffAuditRows(before, after)
+		change.Before, change.After, change.Truncated = maskAuditRows(before), maskAuditRows(after), truncated
+	}
+	if captureErr != nil {
+		change.CaptureError = captureErr.Error()
+	}
+	a.record(ctx, recorder, change)
+	return affected, nil
+}
+
+// Delete 删除记录，记录删除前的数据
+func (a *AuditDatabase) Delete(ctx context.Context, table string, where string, args []interface{}, autoCommit bool) (int64, error) {
+	recorder := a.recorder(ctx, table)
+	if recorder == nil {
+		return a.Database.Delete(ctx, table, where, args, autoCommit)
+	}
+
+	before, truncated, captureErr := a.selectAuditRows(ctx, table, where, args, autoCommit)
+	affected, err := a.Database.Delete(ctx, table, where, args, autoCommit)
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+	change := AuditChange{Table: table, Operation: AuditOperationDelete, Affected: affected, Before: maskAuditRows(before), Truncated: truncated}
+	if captureErr != nil {
+		change.CaptureError = captureErr.Error()
+	}
+	a.record(ctx, recorder, change)
+	return affected, nil
+}
+
+// BatchUpdate 批量更新记录，按匹配键逐条记录更新前后的数据
+func (a *AuditDatabase) BatchUpdate(ctx context.Context, table string, dataSlice interface{}, keyFields []string, autoCommit bool) (int64, error) {
+	recorder := a.recorder(ctx, table)
+	if recorder == nil {
+		return a.Database.BatchUpdate(ctx, table, dataSlice, keyFields, autoCommit)
+	}
+
+	conditions, truncated, captureErr := auditKeyConditions(dataSlice, keyFields)
+	var before []map[string]interface{}
+	if captureErr == nil {
+		before, captureErr = a.selectAuditRowsByKeys(ctx, table, conditions, autoCommit)
+	}
+	affected, err := a.Database.BatchUpdate(ctx, table, dataSlice, keyFields, autoCommit)
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+	change := AuditChange{Table: table, Operation: AuditOperationUpdate, Affected: affected, Truncated: truncated}
+	if captureErr == nil {
+		var after []map[string]interface{}
+		after, captureErr = a.selectAuditRowsByKeys(ctx, table, conditions, autoCommit)
+		change.Diff = diffAuditRows(before, after)
+		change.Before, change.After = maskAuditRows(before), maskAuditRows(after)
+	}
+	if captureErr != nil {
+		change.CaptureError = captureErr.Error()
+	}
+	a.record(ctx, recorder, change)
+	return affected, nil
+}
+
+// BatchDelete 批量删除记录，按匹配键记录删除前的数据
+func (a *AuditDatabase) BatchDelete(ctx context.Context, table string, dataSlice interface{}, keyFields []string, autoCommit bool) (int64, error) {
+	recorder := a.recorder(ctx, table)
+	if recorder == nil {
+		return a.Database.BatchDelete(ctx, table, dataSlice, keyFields, autoCommit)
+	}
+
+	conditions, truncated, captureErr := auditKeyConditions(dataSlice, keyFields)
+	var before []map[string]interface{}
+	if captureErr == nil {
+		before, captureErr = a.selectAuditRowsByKeys(ctx, table, conditions, autoCommit)
+	}
+	affected, err := a.Database.BatchDelete(ctx, table, dataSlice, keyFields, autoCommit)
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+	change := AuditChange{Table: table, Operation: AuditOperationDelete, Affected: affected, Before: maskAuditRows(before), Truncated: truncated}
+	if captureErr != nil {
+		change.CaptureError = captureErr.Error()
+	}
+	a.record(ctx, recorder, change)
+	return affected, nil
+}
+
+// BatchDeleteByKeys 按主键批量删除记录，记录删除前的数据
+func (a *AuditDatabase) BatchDeleteByKeys(ctx context.Context, table string, keyField string, keys []interface{}, autoCommit bool) (int64, error) {
+	recorder := a.recorder(ctx, table)
+	if recorder == nil || len(keys) == 0 || keyField == "" {
+		return a.Database.BatchDeleteByKeys(ctx, table, keyField, keys, autoCommit)
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(keys)), ", ")
+	where := fmt.Sprintf("%s IN (%s)", keyField, placeholders)
+	before, truncated, captureErr := a.selectAuditRows(ctx, table, where, keys, autoCommit)
+	affected, err := a.Database.BatchDeleteByKeys(ctx, table, keyField, keys, autoCommit)
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+	change := AuditChange{Table: table, Operation: AuditOperationDelete, Affected: affected, Before: maskAuditRows(before), Truncated: truncated}
+	if captureErr != nil {
+		change.CaptureError = captureErr.Error()
+	}
+	a.record(ctx, recorder, change)
+	return affected, nil
+}
+
+// BeginTx 开始事务，事务中的变更在提交后才记录
+func (a *AuditDatabase) BeginTx(ctx context.Context, options *TxOptions) (context.Context, error) {
+	txCtx, err := a.Database.BeginTx(ctx, options)
+	if err != nil || AuditRecorderFromContext(ctx) == nil {
+		return txCtx, err
+	}
+	parent, _ := ctx.Value(auditTxKey).(*auditTx)
+	return context.WithValue(txCtx, auditTxKey, &auditTx{parent: parent}), nil
+}
+
+// Commit 提交事务，并记录事务中的变更
+func (a *AuditDatabase) Commit(ctx context.Context) error {
+	if err := a.Database.Commit(ctx); err != nil {
+		return err
+	}
+	if tx, ok := ctx.Value(auditTxKey).(*auditTx); ok {
+		if recorder := AuditRecorderFromContext(ctx); recorder != nil {
+			tx.flush(recorder)
+		}
+	}
+	return nil
+}
+
+// Rollback 回滚事务，并丢弃事务中的变更
+func (a *AuditDatabase) Rollback(ctx context.Context) error {
+	if tx, ok := ctx.Value(auditTxKey).(*auditTx); ok {
+		tx.mu.Lock()
+		tx.changes = nil
+		tx.mu.Unlock()
+	}
+	return a.Database.Rollback(ctx)
+}
+
+// InTx 在事务中执行函数，函数成功且事务提交后记录其中的变更
+func (a *AuditDatabase) InTx(ctx context.Context, options *TxOptions, fn func(context.Context) error) error {
+	recorder := AuditRecorderFromContext(ctx)
+	if recorder == nil {
+		return a.Database.InTx(ctx, options, fn)
+	}
+	parent, _ := ctx.Value(auditTxKey).(*auditTx)
+	tx := &auditTx{parent: parent}
+	err := a.Database.InTx(ctx, options, func(txCtx context.Context) error {
+		return fn(context.WithValue(txCtx, auditTxKey, tx))
+	})
+	if err != nil {
+		return err
+	}
+	tx.flush(recorder)
+	return nil
+}
+
+// selectAuditRows 按条件查询审计数据，最多返回 maxAuditRows 行
+// 返回:
+//
+//	[]map[string]interface{}: 数据行，敏感列未脱敏，记录前由调用方脱敏
+//	bool: 是否超过最大行数被截断
+//	error: 查询失败时返回错误
+func (a *AuditDatabase) selectAuditRows(ctx context.Context, table, where string, args []interface{}, autoCommit bool) ([]map[string]interface{}, bool, error) {
+	query := "SELECT * FROM " + table
+	if strings.TrimSpace(where) != "" {
+		query += " WHERE " + where
+	}
+	query += limitClause(a.Database.GetDriver(), maxAuditRows+1)
+
+	var rows []map[string]interface{}
+	if err := a.Database.Query(ctx, &rows, query, args, autoCommit); err != nil && err != ErrRecordNotFound {
+		return nil, false, err
+	}
+	truncated := len(rows) > maxAuditRows
+	if truncated {
+		rows = rows[:maxAuditRows]
+	}
+	return rows, truncated, nil
+}
+
+// selectAuditRowsByKeys 按每条记录的匹配键查询审计数据
+func (a *AuditDatabase) selectAuditRowsByKeys(ctx context.Context, table string, conditions []auditKeyCondition, autoCommit bool) ([]map[string]interface{}, error) {
+	var rows []map[string]interface{}
+	for _, condition := range conditions {
+		matched, _, err := a.selectAuditRows(ctx, table, condition.where, condition.args, autoCommit)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, matched...)
+	}
+	return rows, nil
+}
+
+// auditKeyCondition 按匹配键查询单条记录的条件
+type auditKeyCondition struct {
+	where string
+	args  []interface{}
+}
+
+// auditKeyConditions 根据匹配键生成每条记录的查询条件，最多 maxAuditRows 条
+func auditKeyConditions(dataSlice interface{}, keyFields []string) ([]auditKeyCondition, bool, error) {
+	if len(keyFields) == 0 {
+		return nil, false, fmt.Errorf("keyFields cannot be empty")
+	}
+	slice := reflect.ValueOf(dataSlice)
+	for slice.Kind() == reflect.Ptr {
+		slice = slice.Elem()
+	}
+	if slice.Kind() != reflect.Slice {
+		return nil, false, fmt.Errorf("dataSlice must be a slice")
+	}
+
+	count := slice.Len()
+	truncated := count > maxAuditRows
+	if truncated {
+		count = maxAuditRows
+	}
+	conditions := make([]auditKeyCondition, 0, count)
+	for i := 0; i < count; i++ {
+		item := slice.Index(i)
+		for item.Kind() == reflect.Ptr || item.Kind() == reflect.Interface {
+			item = item.Elem()
+		}
+		if item.Kind() != reflect.Struct {
+			return nil, false, fmt.Errorf("record %d must be a struct", i)
+		}
+		parts := make([]string, 0, len(keyFields))
+		args := make([]interface{}, 0, len(keyFields))
+		for _, key := range keyFields {
+			field, ok := findColumnField(item, key)
+			if !ok {
+				return nil, false, fmt.Errorf("record %d has no key field %s", i, key)
+			}
+			parts = append(parts, key+" = ?")
+			args = append(args, field.Interface())
+		}
+		conditions = append(conditions, auditKeyCondition{where: strings.Join(parts, " AND "), args: args})
+	}
+	return conditions, truncated, nil
+}
+
+// limitClause 按驱动类型生成限制查询行数的子句
+func limitClause(driver string, limit int) string {
+	if driver == DriverOracle {
+		return fmt.Sprintf(" FETCH FIRST %d ROWS ONLY", limit)
+	}
+	return fmt.Sprintf(" LIMIT %d", limit)
+}
+
+// auditRowsFromData 将插入的结构体或结构体切片转换为审计数据行
+func auditRowsFromData(data interface{}) ([]map[string]interface{}, bool) {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		if row := auditRowFromValue(v); row != nil {
+			return []map[string]interface{}{row}, false
+		}
+		return nil, false
+	}
+
+	count := v.Len()
+	truncated := count > maxAuditRows
+	if truncated {
+		count = maxAuditRows
+	}
+	rows := make([]map[string]interface{}, 0, count)
+	for i := 0; i < count; i++ {
+		if row := auditRowFromValue(v.Index(i)); row != nil {
+			rows = append(rows, row)
+		}
+	}
+	return rows, truncated
+}
+
+// auditRowFromValue 将结构体按 db tag 转换为审计数据行，敏感列脱敏
+func auditRowFromValue(v reflect.Value) map[string]interface{} {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	row := make(map[string]interface{})
+	switch v.Kind() {
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			row[fmt.Sprint(iter.Key().Interface())] = auditValue(iter.Value())
+		}
+	case reflect.Struct:
+		for _, sf := range reflect.VisibleFields(v.Type()) {
+			if !sf.IsExported() || sf.Anonymous {
+				continue
+			}
+			name := sf.Tag.Get("db")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+			field, err := v.FieldByIndexErr(sf.Index)
+			if err != nil {
+				continue
+			}
+			row[name] = auditValue(field)
+		}
+	default:
+		return nil
+	}
+	maskAuditRow(row)
+	return row
+}
+
+// auditValue 获取字段值，空指针记为nil，实现 driver.Valuer 的类型使用其数据库值
+func auditValue(field reflect.Value) interface{} {
+	for field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return nil
+		}
+		if valuer, ok := field.Interface().(driver.Valuer); ok {
+			value, err := valuer.Value()
+			if err != nil {
+				return nil
+			}
+			return value
+		}
+		field = field.Elem()
+	}
+	if valuer, ok := field.Interface().(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil {
+			return nil
+		}
+		return value
+	}
+	if b, ok := field.Interface().([]byte); ok {
+		return string(b)
+	}
+	return field.Interface()
+}
+
+// maskAuditRows 将数据行中敏感列的值脱敏，返回原切片
+func maskAuditRows(rows []map[string]interface{}) []map[string]interface{} {
+	for _, row := range rows {
+		maskAuditRow(row)
+	}
+	return rows
+}
+
+// maskAuditRow 将敏感列的非空值替换为脱敏值
+func maskAuditRow(row map[string]interface{}) {
+	for column, value := range row {
+		row[column] = maskAuditValue(column, value)
+	}
+}
+
+// maskAuditValue 敏感列的非空值返回脱敏值，其他值原样返回
+// 敏感列与日志脱敏使用同一套敏感键名，见 mask.IsSensitiveKey
+func maskAuditValue(column string, value interface{}) interface{} {
+	if value == nil || value == "" {
+		return value
+	}
+	if mask.IsSensitiveKey(column) {
+		return mask.Masked
+	}
+	return value
+}
+
+// diffAuditRows 按行序比较更新前后的数据，只返回有变化的字段
+// 使用脱敏前的数据比较，敏感列有变化时记录为脱敏值的差异；
+// 前后行数不一致（如更新修改了查询条件中的列）时不计算差异
+func diffAuditRows(before, after []map[string]interface{}) []map[string]AuditFieldChange {
+	if len(before) == 0 || len(before) != len(after) {
+		return nil
+	}
+	diffs := make([]map[string]AuditFieldChange, 0, len(before))
+	for i := range before {
+		diff := make(map[string]AuditFieldChange)
+		for column, afterValue := range after[i] {
+			beforeValue := before[i][column]
+			if auditValueString(beforeValue) != auditValueString(afterValue) {
+				diff[column] = AuditFieldChange{Before: maskAuditValue(column, beforeValue), After: maskAuditValue(column, afterValue)}
+			}
+		}
+		for column, beforeValue := range before[i] {
+			if _, ok := after[i][column]; !ok {
+				diff[column] = AuditFieldChange{Before: maskAuditValue(column, beforeValue)}
+			}
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+// auditValueString 将值转换为用于比较的字符串
+func auditValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// parseAuditStatement 解析原始SQL写入语句的操作类型和表名
+// 返回:
+//
+//	string: 操作类型，非写入语句时为空
+//	string: 表名，无法解析时为空
+func parseAuditStatement(query string) (string, string) {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "", ""
+	}
+	next := func(keyword string) string {
+		for i := 0; i < len(fields)-1; i++ {
+			if strings.EqualFold(fields[i], keyword) {
+				return strings.Trim(strings.SplitN(fields[i+1], "(", 2)[0], "`\"")
+			}
+		}
+		return ""
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "INSERT", "REPLACE":
+		return AuditOperationCreate, next("INTO")
+	case "UPDATE":
+		if len(fields) > 1 {
+			return AuditOperationUpdate, strings.Trim(fields[1], "`\"")
+		}
+		return AuditOperationUpdate, ""
+	case "DELETE":
+		return AuditOperationDelete, next("FROM")
+	case "MERGE":
+		return AuditOperationUpdate, next("INTO")
+	}
+	return "", ""
+}
@@ -12,6 +12,7 @@ import (
 
 	"gateway/pkg/database"
 	"gateway/pkg/database/dblogger"
+	"gateway/pkg/database/dbtypes"
 	"gateway/pkg/database/sqlutils"
 
 	_ "github.com/ClickHouse/clickhouse-go/v2" // 导入ClickHouse驱动
@@ -130,6 +131,7 @@ func (c *ClickHouse) Connect(config *database.DbConfig) error {
 	}
 
 	c.db = db
+	c.logger.SetExplainer(c.explainQuery)
 	c.logger.LogConnected(context.Background(), database.DriverClickHouse, map[string]any{
 		"maxOpenConns":    maxOpenConns,
 		"maxIdleConns":    maxIdleConns,
@@ -140,6 +142,11 @@ func (c *ClickHouse) Connect(config *database.DbConfig) error {
 	return nil
 }
 
+// explainQuery 获取慢查询的执行计划（EXPLAIN）
+func (c *ClickHouse) explainQuery(ctx context.Context, query string, args []any) (string, error) {
+	return dblogger.ExplainRows(ctx, c.db, "EXPLAIN "+query, args...)
+}
+
 // Close 关闭数据库连接
 // 关闭ClickHouse数据库连接，释放相关资源
 // 注意：使用上下文绑定事务的情况下，Close不会自动回滚事务
@@ -407,6 +414,36 @@ func (c *ClickHouse) getExecutor(ctx context.Context, autoCommit bool) interface
 	return c.db
 }
 
+// insertTable 获取实际写入的表名
+// buffer 写入模式下，配置的目标表改写入对应的Buffer引擎表（目标表名+后缀），
+// Buffer表按阈值合并刷新到目标表，降低高频小批量写入的延迟；其他写入模式返回原表名
+func (c *ClickHouse) insertTable(table string) string {
+	if c.config == nil || c.config.Connection.ClickHouseInsertMode != dbtypes.ClickHouseInsertModeBuffer {
+		return table
+	}
+	conn := c.config.Connection
+	suffix := conn.ClickHouseBufferTableSuffix
+	if suffix == "" {
+		suffix = dbtypes.DefaultClickHouseBufferTableSuffix
+	}
+	if strings.HasSuffix(table, suffix) {
+		return table
+	}
+	if conn.ClickHouseBufferTables != "" {
+		matched := false
+		for _, name := range strings.Split(conn.ClickHouseBufferTables, ",") {
+			if strings.EqualFold(strings.TrimSpace(name), table) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return table
+		}
+	}
+	return table + suffix
+}
+
 // Exec 执行SQL语句
 // 执行INSERT、UPDATE、DELETE等不返回结果集的ClickHouse语句
 // 使用Go底层自动优化，无需手动预编译
@@ -577,7 +614,7 @@ func (c *ClickHouse) QueryOne(ctx context.Context, dest interface{}, query strin
 //	int64: 插入记录的自增ID（如果有）
 //	error: 插入失败时返回错误信息
 func (c *ClickHouse) Insert(ctx context.Context, table string, data interface{}, autoCommit bool) (int64, error) {
-	query, args, err := sqlutils.BuildInsertQuery(table, data)
+	query, args, err := sqlutils.BuildInsertQuery(c.insertTable(table), data)
 	if err != nil {
 		return 0, err
 	}
@@ -783,6 +820,7 @@ func (c *ClickHouse) BatchInsert(ctx context.Context, table string, dataSlice in
 	if totalLen == 0 {
 		return 0, nil
 	}
+	table = c.insertTable(table)
 
 	// ClickHouse优化：智能分批和执行策略选择
 	//
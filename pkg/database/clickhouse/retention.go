@@ -0,0 +1,288 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gateway/pkg/database"
+)
+
+// identifierPattern 表名、列名允许的字符，分区管理语句无法使用参数绑定表名，必须先校验
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// TablePolicy 表的分区和TTL保留策略声明
+// 由应用代码声明日志表应有的保留策略，启动时与 system.tables 中的实际定义比对，
+// 替代在建表脚本之外手工执行 ALTER TABLE 维护保留策略
+type TablePolicy struct {
+	// Table 表名
+	Table string
+	// PartitionBy 期望的分区键表达式，如 toDate(gatewayStartProcessingTime)，为空时不校验
+	// 分区键在建表后无法修改，不一致时只报告不处理
+	PartitionBy string
+	// TTLColumn TTL使用的时间列
+	TTLColumn string
+	// TTLDays 数据保留天数，小于等于0表示表上不应设置TTL
+	TTLDays int
+}
+
+// TTLExpression 返回策略对应的TTL表达式
+// 使用 ClickHouse 保存表定义时的规范形式，便于与 engine_full 中的TTL直接比对
+// 返回:
+//
+//	string: TTL表达式，策略不设置TTL时返回空字符串
+func (p TablePolicy) TTLExpression() string {
+	if p.TTLDays <= 0 || p.TTLColumn == "" {
+		return ""
+	}
+	return fmt.Sprintf("toDateTime(%s) + toIntervalDay(%d)", p.TTLColumn, p.TTLDays)
+}
+
+// Validate 校验策略中的表名和列名
+// 返回:
+//
+//	error: 表名或TTL列名不合法时返回错误信息
+func (p TablePolicy) Validate() error {
+	if err := ValidateIdentifier(p.Table); err != nil {
+		return err
+	}
+	if p.TTLDays > 0 {
+		if err := ValidateIdentifier(p.TTLColumn); err != nil {
+			return fmt.Errorf("表 %s 的TTL列不合法: %w", p.Table, err)
+		}
+	}
+	return nil
+}
+
+// TablePolicyState 表当前的分区和TTL定义及与策略的比对结果
+type TablePolicyState struct {
+	Table            string // 表名
+	Exists           bool   // 表是否存在
+	PartitionKey     string // 当前分区键表达式
+	TTL              string // 当前TTL表达式，未设置时为空
+	PartitionMatched bool   // 分区键是否与策略一致
+	TTLMatched       bool   // TTL是否与策略一致
+	Altered          bool   // 本次是否已执行 ALTER TABLE 修正TTL
+}
+
+// Partition 表的分区信息，按 system.parts 中的活动数据片段汇总
+type Partition struct {
+	Partition   string `json:"partition" db:"partition"`     // 分区值，如 2024-05-01
+	PartitionID string `json:"partitionId" db:"partitionId"` // 分区ID，DROP PARTITION ID 使用
+	Rows        int64  `json:"rows" db:"rows"`               // 行数
+	BytesOnDisk int64  `json:"bytesOnDisk" db:"bytesOnDisk"` // 磁盘占用字节数
+}
+
+// ValidateIdentifier 校验表名或列名只包含字母、数字和下划线
+// 参数:
+//
+//	name: 表名或列名
+//
+// 返回:
+//
+//	error: 名称不合法时返回错误信息
+func ValidateIdentifier(name string) error {
+	if !identifierPattern.MatchString(name) {
+		return fmt.Errorf("名称 %q 不合法，只允许字母、数字和下划线", name)
+	}
+	return nil
+}
+
+// ExtractTTL 从 system.tables 的 engine_full 中提取表级TTL表达式
+// 参数:
+//
+//	engineFull: 表引擎完整定义，如 MergeTree PARTITION BY ... ORDER BY ... TTL ... SETTINGS ...
+//
+// 返回:
+//
+//	string: TTL表达式，未设置TTL时返回空字符串
+func ExtractTTL(engineFull string) string {
+	idx := strings.Index(engineFull, " TTL ")
+	if idx < 0 {
+		return ""
+	}
+	ttl := engineFull[idx+len(" TTL "):]
+	if end := strings.Index(ttl, " SETTINGS "); end >= 0 {
+		ttl = ttl[:end]
+	}
+	return strings.TrimSpace(ttl)
+}
+
+// normalizeExpression 去掉空白并统一大小写，用于比较表达式
+func normalizeExpression(expr string) string {
+	return strings.ToLower(strings.Join(strings.Fields(expr), ""))
+}
+
+// InspectTablePolicy 读取表当前的分区键和TTL并与策略比对
+// 参数:
+//
+//	ctx: 上下文，用于控制请求超时和取消
+//	db: ClickHouse 数据库连接
+//	policy: 表的保留策略
+//
+// 返回:
+//
+//	*TablePolicyState: 表当前定义及比对结果，表不存在时 Exists 为 false
+//	error: 策略不合法或查询失败时返回错误信息
+func InspectTablePolicy(ctx context.Context, db database.Database, policy TablePolicy) (*TablePolicyState, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	var tables []struct {
+		PartitionKey string `db:"partitionKey"`
+		EngineFull   string `db:"engineFull"`
+	}
+	err := db.Query(ctx, &tables, `
+		SELECT partition_key AS partitionKey, engine_full AS engineFull
+		FROM system.tables
+		WHERE database = currentDatabase() AND name = ?`, []interface{}{policy.Table}, true)
+	if err != nil {
+		return nil, fmt.Errorf("查询表 %s 定义失败: %w", policy.Table, err)
+	}
+
+	state := &TablePolicyState{Table: policy.Table}
+	if len(tables) == 0 {
+		return state, nil
+	}
+	state.Exists = true
+	state.PartitionKey = tables[0].PartitionKey
+	state.TTL = ExtractTTL(tables[0].EngineFull)
+	state.PartitionMatched = policy.PartitionBy == "" || normalizeExpression(policy.PartitionBy) == normalizeExpression(state.PartitionKey)
+	state.TTLMatched = normalizeExpression(policy.TTLExpression()) == normalizeExpression(state.TTL)
+	return state, nil
+}
+
+// EnsureTablePolicy 校验表的保留策略，apply 为 true 时修正不一致的TTL
+// TTL不一致时执行 ALTER TABLE MODIFY TTL（策略不设置TTL时执行 REMOVE TTL）；
+// 修改TTL会在后台物化到已有数据片段，大表上可能持续较长时间
+// 参数:
+//
+//	ctx: 上下文，用于控制请求超时和取消
+//	db: ClickHouse 数据库连接
+//	policy: 表的保留策略
+//	apply: 是否执行 ALTER TABLE 修正TTL，false 时只返回比对结果
+//
+// 返回:
+//
+//	*TablePolicyState: 表当前定义及比对结果
+//	error: 查询或修改失败时返回错误信息
+func EnsureTablePolicy(ctx context.Context, db database.Database, policy TablePolicy, apply bool) (*TablePolicyState, error) {
+	state, err := InspectTablePolicy(ctx, db, policy)
+	if err != nil {
+		return nil, err
+	}
+	if !state.Exists || state.TTLMatched || !apply {
+		return state, nil
+	}
+
+	alterSQL := fmt.Sprintf("ALTER TABLE %s REMOVE TTL", policy.Table)
+	if ttl := policy.TTLExpression(); ttl != "" {
+		alterSQL = fmt.Sprintf("ALTER TABLE %s MODIFY TTL %s", policy.Table, ttl)
+	}
+	if _, err := db.Exec(ctx, alterSQL, nil, true); err != nil {
+		return state, fmt.Errorf("修改表 %s 的TTL失败: %w", policy.Table, err)
+	}
+	state.TTL = policy.TTLExpression()
+	state.TTLMatched = true
+	state.Altered = true
+	return state, nil
+}
+
+// ListPartitions 查询表的活动分区
+// 参数:
+//
+//	ctx: 上下文，用于控制请求超时和取消
+//	db: ClickHouse 数据库连接
+//	table: 表名
+//
+// 返回:
+//
+//	[]Partition: 按分区值升序排列的分区列表
+//	error: 表名不合法或查询失败时返回错误信息
+func ListPartitions(ctx context.Context, db database.Database, table string) ([]Partition, error) {
+	if err := ValidateIdentifier(table); err != nil {
+		return nil, err
+	}
+
+	var partitions []Partition
+	err := db.Query(ctx, &partitions, `
+		SELECT partition, partition_id AS partitionId,
+		       toInt64(sum(rows)) AS rows, toInt64(sum(bytes_on_disk)) AS bytesOnDisk
+		FROM system.parts
+		WHERE database = currentDatabase() AND table = ? AND active = 1
+		GROUP BY partition, partition_id
+		ORDER BY partition`, []interface{}{table}, true)
+	if err != nil {
+		return nil, fmt.Errorf("查询表 %s 分区失败: %w", table, err)
+	}
+	return partitions, nil
+}
+
+// DropPartition 按分区ID删除表的一个分区
+// 参数:
+//
+//	ctx: 上下文，用于控制请求超时和取消
+//	db: ClickHouse 数据库连接
+//	table: 表名
+//	partitionID: 分区ID，取自 system.parts.partition_id
+//
+// 返回:
+//
+//	error: 参数不合法或删除失败时返回错误信息
+func DropPartition(ctx context.Context, db database.Database, table, partitionID string) error {
+	if err := ValidateIdentifier(table); err != nil {
+		return err
+	}
+	if partitionID == "" {
+		return fmt.Errorf("分区ID不能为空")
+	}
+
+	escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(partitionID)
+	dropSQL := fmt.Sprintf("ALTER TABLE %s DROP PARTITION ID '%s'", table, escaped)
+	if _, err := db.Exec(ctx, dropSQL, nil, true); err != nil {
+		return fmt.Errorf("删除表 %s 分区 %s 失败: %w", table, partitionID, err)
+	}
+	return nil
+}
+
+// DropPartitionsBefore 删除分区值小于 before 的全部分区
+// 适用于按日期分区的表，分区值与 before 按字符串比较，如 before 为 2024-05-01 时删除该日期之前的分区；
+// 单个分区删除失败时继续删除其余分区，返回已删除的分区和遇到的第一个错误
+// 参数:
+//
+//	ctx: 上下文，用于控制请求超时和取消
+//	db: ClickHouse 数据库连接
+//	table: 表名
+//	before: 分区值上界（不包含）
+//
+// 返回:
+//
+//	[]Partition: 已删除的分区
+//	error: 查询失败或有分区删除失败时返回错误信息
+func DropPartitionsBefore(ctx context.Context, db database.Database, table, before string) ([]Partition, error) {
+	if before == "" {
+		return nil, fmt.Errorf("分区值上界不能为空")
+	}
+	partitions, err := ListPartitions(ctx, db, table)
+	if err != nil {
+		return nil, err
+	}
+
+	dropped := make([]Partition, 0)
+	var firstErr error
+	for _, p := range partitions {
+		if p.Partition >= before {
+			continue
+		}
+		if err := DropPartition(ctx, db, table, p.PartitionID); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		dropped = append(dropped, p)
+	}
+	return dropped, firstErr
+}
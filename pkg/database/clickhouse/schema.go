@@ -0,0 +1,78 @@
+package clickhouse
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gateway/pkg/database"
+)
+
+// Schema 获取当前数据库的结构
+// 查询 system.tables 和 system.columns 中当前库（currentDatabase()）的表和列；
+// ClickHouse 没有传统索引，表的主键（稀疏索引）作为主键索引返回，不包含数据跳数索引
+// 参数:
+//
+//	ctx: 上下文，用于控制请求超时和取消
+//	options: 查询选项，为nil时返回全部表
+//
+// 返回:
+//
+//	*database.SchemaInfo: 数据库结构
+//	error: 查询失败时返回错误信息
+func (c *ClickHouse) Schema(ctx context.Context, options *database.SchemaOptions) (*database.SchemaInfo, error) {
+	var databaseName string
+	if err := c.db.QueryRowContext(ctx, "SELECT currentDatabase()").Scan(&databaseName); err != nil {
+		return nil, fmt.Errorf("查询当前数据库失败: %w", err)
+	}
+	builder := database.NewSchemaBuilder(c.GetDriver(), databaseName, options)
+
+	err := database.QuerySchemaRows(ctx, c.db, `
+		SELECT name, comment, primary_key FROM system.tables
+		WHERE database = currentDatabase() AND is_temporary = 0 AND engine NOT IN ('View', 'MaterializedView')`, nil, func(rows *sql.Rows) error {
+		var name, comment, primaryKey string
+		if err := rows.Scan(&name, &comment, &primaryKey); err != nil {
+			return err
+		}
+		builder.AddTable(name, comment)
+		for _, column := range strings.Split(primaryKey, ",") {
+			if column = strings.TrimSpace(column); column != "" {
+				builder.AddIndexColumn(name, "PRIMARY", true, true, column)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("查询表信息失败: %w", err)
+	}
+
+	err = database.QuerySchemaRows(ctx, c.db, `
+		SELECT table, name, type, default_expression, comment, position FROM system.columns
+		WHERE database = currentDatabase()`, nil, func(rows *sql.Rows) error {
+		var (
+			table, name, dataType, defaultExpression, comment string
+			position                                          uint64
+		)
+		if err := rows.Scan(&table, &name, &dataType, &defaultExpression, &comment, &position); err != nil {
+			return err
+		}
+		column := database.ColumnInfo{
+			Name:     name,
+			DataType: dataType,
+			Nullable: strings.HasPrefix(dataType, "Nullable("),
+			Comment:  comment,
+			Position: int(position),
+		}
+		if defaultExpression != "" {
+			column.Default = &defaultExpression
+		}
+		builder.AddColumn(table, column)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("查询列信息失败: %w", err)
+	}
+
+	return builder.Build(), nil
+}
@@ -2,34 +2,34 @@ package database
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"gateway/pkg/config"
 	"gateway/pkg/database/dbtypes"
 	"gateway/pkg/database/dsn"
 	"gateway/pkg/security"
+	"gateway/pkg/utils/huberrors"
 	"sync"
 )
 
-// 定义通用数据库错误
+// 定义通用数据库错误，带有错误码，可通过 huberrors.Is、huberrors.CodeOf 识别
 var (
 	// ErrRecordNotFound 记录未找到错误
-	ErrRecordNotFound = errors.New("record not found")
+	ErrRecordNotFound = huberrors.Sentinel(huberrors.CodeNotFound, "record not found")
 
 	// ErrDuplicateKey 重复键错误
-	ErrDuplicateKey = errors.New("duplicate key")
+	ErrDuplicateKey = huberrors.Sentinel(huberrors.CodeAlreadyExists, "duplicate key")
 
 	// ErrConnection 连接错误
-	ErrConnection = errors.New("database connection error")
+	ErrConnection = huberrors.Sentinel(huberrors.CodeUnavailable, "database connection error")
 
 	// ErrTransaction 事务错误
-	ErrTransaction = errors.New("transaction error")
+	ErrTransaction = huberrors.Sentinel(huberrors.CodeInternal, "transaction error")
 
 	// ErrInvalidQuery 无效查询错误
-	ErrInvalidQuery = errors.New("invalid query")
+	ErrInvalidQuery = huberrors.Sentinel(huberrors.CodeInvalidArgument, "invalid query")
 
 	// ErrConfigNotFound 配置未找到错误
-	ErrConfigNotFound = errors.New("database config not found")
+	ErrConfigNotFound = huberrors.Sentinel(huberrors.CodeInternal, "database config not found")
 )
 
 // 数据库工厂映射及缓存
@@ -316,6 +316,16 @@ type Database interface {
 	// 返回:
 	//   string: 连接名称
 	GetName() string
+
+	// Schema 获取当前连接的数据库结构
+	// 查询数据库系统表，返回表、列（含类型、可空、默认值）和索引信息，表按名称排序，列按定义顺序排序
+	// 参数:
+	//   ctx: 上下文，用于控制请求超时和取消
+	//   options: 查询选项，为nil时返回全部表
+	// 返回:
+	//   *SchemaInfo: 数据库结构
+	//   error: 查询失败时返回错误信息
+	Schema(ctx context.Context, options *SchemaOptions) (*SchemaInfo, error)
 }
 
 // Model 模型接口
@@ -395,12 +405,10 @@ func Open(config *DbConfig) (Database, error) {
 		return nil, fmt.Errorf("unsupported database driver: %s", config.Driver)
 	}
 
-	// 解密密码（如果需要）
-	decryptedPassword, err := security.DecryptWithDefaultKey(config.Connection.Password)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt database password: %w", err)
+	// 解密密码和连接字符串（如果需要）
+	if err := DecryptConnectionSecrets(config); err != nil {
+		return nil, err
 	}
-	config.Connection.Password = decryptedPassword
 
 	// 生成DSN
 	if config.DSN == "" {
@@ -433,6 +441,45 @@ func Open(config *DbConfig) (Database, error) {
 	return db, nil
 }
 
+// DecryptConnectionSecrets 解密数据库配置中以 "ENCY_" 开头的密码和连接字符串
+// 明文值保持不变，解密后的值直接覆盖配置，重复调用是安全的
+// 参数:
+//
+//	config: 数据库配置
+//
+// 返回:
+//
+//	error: 解密失败时返回错误；未配置解密密钥时错误包装 security.ErrEncryptionKeyMissing 并提示配置方式
+func DecryptConnectionSecrets(config *DbConfig) error {
+	password, err := decryptConnectionSecret(config, "password", config.Connection.Password)
+	if err != nil {
+		return err
+	}
+	dsnStr, err := decryptConnectionSecret(config, "dsn", config.DSN)
+	if err != nil {
+		return err
+	}
+	config.Connection.Password = password
+	config.DSN = dsnStr
+	return nil
+}
+
+// decryptConnectionSecret 解密单个配置值，区分密钥未配置和密文错误
+func decryptConnectionSecret(config *DbConfig, field, value string) (string, error) {
+	if !security.IsEncryptedString(value) {
+		return value, nil
+	}
+	plaintext, err := security.DecryptWithDefaultKey(value)
+	if err == nil {
+		return plaintext, nil
+	}
+	if !security.IsDecryptionKeyConfigured(value) {
+		return "", fmt.Errorf("%w: database connection '%s' has an encrypted %s, set the key via env %s or app.encryption_key (%v)",
+			security.ErrEncryptionKeyMissing, GetConnectionID(config), field, security.EncryptionKeyEnv, err)
+	}
+	return "", fmt.Errorf("failed to decrypt %s of database connection '%s': %w", field, GetConnectionID(config), err)
+}
+
 // openWithoutLock 内部方法：打开数据库连接（不加锁）
 // 此方法假设调用者已经持有connMutex锁
 // 参数:
@@ -457,12 +504,10 @@ func openWithoutLock(config *DbConfig) (Database, error) {
 		return nil, fmt.Errorf("unsupported database driver: %s", config.Driver)
 	}
 
-	// 解密密码（如果需要）
-	decryptedPassword, err := security.DecryptWithDefaultKey(config.Connection.Password)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt database password: %w", err)
+	// 解密密码和连接字符串（如果需要）
+	if err := DecryptConnectionSecrets(config); err != nil {
+		return nil, err
 	}
-	config.Connection.Password = decryptedPassword
 
 	// 生成DSN
 	if config.DSN == "" {
@@ -530,18 +575,18 @@ func CloseAllConnections() error {
 	connMutex.Lock()
 	defer connMutex.Unlock()
 
-	var errs []error
+	errs := huberrors.NewMultiError("errors closing connections")
 	for name, conn := range dbConnections {
 		if err := conn.Close(); err != nil {
-			errs = append(errs, fmt.Errorf("failed to close connection %s: %w", name, err))
+			huberrors.Append(errs, fmt.Errorf("failed to close connection %s: %w", name, err))
 		}
 	}
 
 	// 清空连接缓存
 	dbConnections = make(map[string]Database)
 
-	if len(errs) > 0 {
-		return fmt.Errorf("errors closing connections: %v", errs)
+	if err := errs.ErrorOrNil(); err != nil {
+		return err
 	}
 
 	return nil
@@ -550,6 +595,7 @@ func CloseAllConnections() error {
 // LoadAllConnections 从配置文件加载所有数据库连接
 // 解析配置文件中的所有数据库连接配置，创建并缓存连接实例
 // 只有enabled为true的连接才会被创建
+// 以 "ENCY_" 开头的密码和连接字符串在打开任何连接之前统一解密，未配置解密密钥时返回明确的错误
 // 参数:
 //
 //	configPath: 配置文件路径
@@ -568,6 +614,16 @@ func LoadAllConnections(configPath string) (map[string]Database, error) {
 		return nil, fmt.Errorf("加载数据库配置失败: %w", err)
 	}
 
+	// 打开任何连接之前先解密全部启用连接的密码，密钥缺失时直接失败，避免部分连接已建立
+	for name, config := range configs {
+		if !config.Enabled {
+			continue
+		}
+		if err := DecryptConnectionSecrets(config); err != nil {
+			return nil, fmt.Errorf("解密数据库连接 '%s' 配置失败: %w", name, err)
+		}
+	}
+
 	connections := make(map[string]Database)
 
 	// 遍历所有配置，创建启用的连接
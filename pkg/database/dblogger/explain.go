@@ -0,0 +1,190 @@
+package dblogger
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gateway/pkg/logger"
+)
+
+// DefaultExplainTimeout 获取执行计划的默认超时时间
+const DefaultExplainTimeout = 5 * time.Second
+
+const (
+	// maxConcurrentExplains 同时获取执行计划的最大数量，超过时慢查询日志不附加执行计划
+	maxConcurrentExplains = 2
+	// explainDedupWindow 同一SQL语句在此时间内只获取一次执行计划
+	explainDedupWindow = time.Minute
+	// maxExplainRows 执行计划最多保留的行数
+	maxExplainRows = 200
+)
+
+// SlowQueryExplainer 获取查询语句执行计划的函数，由各数据库驱动按方言实现
+// 参数:
+//   - ctx: 上下文，带获取执行计划的超时时间
+//   - query: 执行的SQL语句（与日志中的语句相同，占位符未替换）
+//   - args: SQL参数
+//
+// 返回:
+//   - string: 文本格式的执行计划
+//   - error: 获取失败时返回错误
+type SlowQueryExplainer func(ctx context.Context, query string, args []any) (string, error)
+
+// explainState 执行计划获取的并发限制和去重状态
+type explainState struct {
+	sem    chan struct{}
+	mu     sync.Mutex
+	recent map[string]time.Time // SQL语句 -> 最近一次获取执行计划的时间
+}
+
+// SetExplainer 设置慢查询执行计划获取函数
+// 只有配置启用 explain_slow 时才会在慢查询时调用
+// 参数:
+//   - explainer: 执行计划获取函数，为nil时不获取执行计划
+func (l *DBLogger) SetExplainer(explainer SlowQueryExplainer) {
+	l.explainer = explainer
+	if l.explain == nil {
+		l.explain = &explainState{
+			sem:    make(chan struct{}, maxConcurrentExplains),
+			recent: make(map[string]time.Time),
+		}
+	}
+}
+
+// IsExplainable 判断SQL语句是否可以获取执行计划
+// 只对查询语句获取执行计划，避免不同方言对写入语句 EXPLAIN 的副作用差异
+func IsExplainable(query string) bool {
+	fields := strings.Fields(strings.TrimLeft(query, "( \t\r\n"))
+	if len(fields) == 0 {
+		return false
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "SELECT", "WITH":
+		return true
+	}
+	return false
+}
+
+// explainSlowQuery 在后台获取慢查询的执行计划，获取完成后记录附带执行计划的慢查询日志
+// 返回:
+//   - bool: 是否已转到后台处理，false 时调用方直接记录慢查询日志
+func (l *DBLogger) explainSlowQuery(ctx context.Context, message, query string, args []any, fields []any) bool {
+	if !l.ExplainSlow || l.explainer == nil || l.explain == nil || !IsExplainable(query) {
+		return false
+	}
+	if !l.explain.markExplained(query, time.Now()) {
+		return false
+	}
+	select {
+	case l.explain.sem <- struct{}{}:
+	default:
+		return false
+	}
+
+	timeout := l.ExplainTimeout
+	if timeout <= 0 {
+		timeout = DefaultExplainTimeout
+	}
+	explainer := l.explainer
+	go func() {
+		defer func() { <-l.explain.sem }()
+		defer func() {
+			if r := recover(); r != nil {
+				logger.WarnWithTrace(ctx, message, append(fields, "explainError", fmt.Sprint(r))...)
+			}
+		}()
+
+		// 原请求结束后仍需获取执行计划，保留上下文中的链路信息但不继承取消
+		explainCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		defer cancel()
+
+		plan, err := explainer(explainCtx, query, args)
+		if err != nil {
+			fields = append(fields, "explainError", err.Error())
+		} else {
+			fields = append(fields, "plan", plan)
+		}
+		logger.WarnWithTrace(ctx, message, fields...)
+	}()
+	return true
+}
+
+// markExplained 记录SQL语句获取执行计划的时间
+// 返回:
+//   - bool: 去重窗口内未获取过执行计划时返回 true
+func (s *explainState) markExplained(query string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if last, ok := s.recent[query]; ok && now.Sub(last) < explainDedupWindow {
+		return false
+	}
+	for q, last := range s.recent {
+		if now.Sub(last) >= explainDedupWindow {
+			delete(s.recent, q)
+		}
+	}
+	s.recent[query] = now
+	return true
+}
+
+// Queryer 执行查询的接口，*sql.DB 和 *sql.Conn 均实现该接口
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// ExplainRows 执行获取执行计划的语句，并将结果格式化为文本
+// 每行结果的各列以 " | " 分隔，超过最大行数时截断
+// 参数:
+//   - ctx: 上下文
+//   - q: 查询执行器
+//   - query: 获取执行计划的语句（如 EXPLAIN SELECT ...）
+//   - args: SQL参数
+//
+// 返回:
+//   - string: 文本格式的执行计划
+//   - error: 执行失败时返回错误
+func ExplainRows(ctx context.Context, q Queryer, query string, args ...any) (string, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	values := make([]sql.NullString, len(columns))
+	targets := make([]any, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+
+	lines := []string{strings.Join(columns, " | ")}
+	for rows.Next() {
+		if len(lines) > maxExplainRows {
+			lines = append(lines, "...")
+			break
+		}
+		if err := rows.Scan(targets...); err != nil {
+			return "", err
+		}
+		cells := make([]string, len(values))
+		for i, v := range values {
+			if v.Valid {
+				cells[i] = v.String
+			} else {
+				cells[i] = "NULL"
+			}
+		}
+		lines = append(lines, strings.Join(cells, " | "))
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -25,6 +25,13 @@ type DBLogger struct {
 	PrintCaller bool
 	// 是否记录事务操作
 	PrintTransaction bool
+	// 是否为慢查询获取执行计划
+	ExplainSlow bool
+	// 获取执行计划的超时时间
+	ExplainTimeout time.Duration
+
+	explainer SlowQueryExplainer // 慢查询执行计划获取函数，由数据库驱动设置
+	explain   *explainState      // 执行计划获取的并发限制和去重状态
 }
 
 // NewDBLogger 创建新的数据库日志记录器
@@ -41,6 +48,8 @@ func NewDBLogger(config *dbtypes.DbConfig) *DBLogger {
 		PrintExecTime:    true, // 默认打印执行时间
 		PrintCaller:      true, // 默认打印调用者信息
 		PrintTransaction: true, // 默认记录事务操作
+		ExplainSlow:      config.Log.ExplainSlow,
+		ExplainTimeout:   time.Duration(config.Log.ExplainTimeout) * time.Millisecond,
 	}
 }
 
@@ -84,8 +93,11 @@ func (l *DBLogger) LogSQL(ctx context.Context, operation string, query string, a
 
 	// 判断是否为慢查询
 	if l.SlowThreshold > 0 && duration.Milliseconds() > int64(l.SlowThreshold) {
-		// 记录慢查询日志
-		logger.WarnWithTrace(ctx, "慢"+operation+" ["+fmt.Sprintf("%d", duration.Milliseconds())+"ms]", fields...)
+		// 记录慢查询日志，启用执行计划获取时在后台获取后再记录
+		message := "慢" + operation + " [" + fmt.Sprintf("%d", duration.Milliseconds()) + "ms]"
+		if !l.explainSlowQuery(ctx, message, query, args, fields) {
+			logger.WarnWithTrace(ctx, message, fields...)
+		}
 	} else {
 		// 记录普通SQL执行日志
 		logger.DebugWithTrace(ctx, operation, fields...)
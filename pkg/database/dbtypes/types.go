@@ -32,6 +32,27 @@ const (
 	DriverMongoDB = "mongodb"
 )
 
+// ClickHouse写入模式
+const (
+	// ClickHouseInsertModeSync 同步写入（默认）
+	ClickHouseInsertModeSync = "sync"
+	// ClickHouseInsertModeAsync 服务端异步写入，由服务端合并小批量写入后刷新到目标表
+	ClickHouseInsertModeAsync = "async"
+	// ClickHouseInsertModeBuffer 写入Buffer引擎表，由Buffer表按阈值刷新到目标表
+	ClickHouseInsertModeBuffer = "buffer"
+)
+
+// ClickHouse异步写入确认方式
+const (
+	// ClickHouseAsyncInsertAckFlush 数据刷新到目标表后确认（wait_for_async_insert=1，默认）
+	ClickHouseAsyncInsertAckFlush = "flush"
+	// ClickHouseAsyncInsertAckEnqueue 数据进入服务端缓冲区即确认（wait_for_async_insert=0），延迟最低，服务端异常时可能丢失未刷新的数据
+	ClickHouseAsyncInsertAckEnqueue = "enqueue"
+)
+
+// DefaultClickHouseBufferTableSuffix buffer模式默认的Buffer引擎表名后缀
+const DefaultClickHouseBufferTableSuffix = "_BUFFER"
+
 // ConnectionConfig 数据库连接配置
 // 描述数据库连接的基本信息，支持多种数据库类型
 type ConnectionConfig struct {
@@ -157,6 +178,22 @@ type ConnectionConfig struct {
 	ClickHouseConnOpenStrategy string `mapstructure:"clickhouse_conn_open_strategy"`
 	// ClickHouseHosts 负载均衡主机列表 (格式: "host1:9000,host2:9000") 官网标准参数
 	ClickHouseHosts string `mapstructure:"clickhouse_hosts"`
+
+	// === ClickHouse写入模式参数 ===
+	// 高并发小批量写入（如访问日志）时，可使用服务端异步写入或Buffer引擎表降低写入延迟
+
+	// ClickHouseInsertMode 写入模式 (sync:同步写入, async:服务端异步写入async_insert, buffer:写入Buffer引擎表) 默认"sync"
+	ClickHouseInsertMode string `mapstructure:"clickhouse_insert_mode"`
+	// ClickHouseAsyncInsertAck async模式的写入确认方式 (flush:刷新到目标表后确认, enqueue:进入服务端缓冲区即确认) 默认"flush"
+	ClickHouseAsyncInsertAck string `mapstructure:"clickhouse_async_insert_ack"`
+	// ClickHouseAsyncInsertBusyTimeoutMs async模式服务端缓冲区最长刷新间隔(毫秒)，0使用服务端默认值
+	ClickHouseAsyncInsertBusyTimeoutMs int `mapstructure:"clickhouse_async_insert_busy_timeout_ms"`
+	// ClickHouseAsyncInsertMaxDataSize async模式服务端缓冲区刷新阈值(字节)，0使用服务端默认值
+	ClickHouseAsyncInsertMaxDataSize int `mapstructure:"clickhouse_async_insert_max_data_size"`
+	// ClickHouseBufferTableSuffix buffer模式Buffer引擎表名后缀，写入目标表时改写入"目标表+后缀" 默认"_BUFFER"
+	ClickHouseBufferTableSuffix string `mapstructure:"clickhouse_buffer_table_suffix"`
+	// ClickHouseBufferTables buffer模式下使用Buffer引擎表的目标表列表 (格式: "table1,table2")，为空表示所有写入
+	ClickHouseBufferTables string `mapstructure:"clickhouse_buffer_tables"`
 }
 
 // PoolConfig 连接池配置
@@ -183,6 +220,13 @@ type LogConfig struct {
 
 	// SlowThreshold 慢查询阈值（毫秒）
 	SlowThreshold int `mapstructure:"slow_threshold"`
+
+	// ExplainSlow 是否为慢查询自动获取执行计划
+	// 启用后查询语句超过慢查询阈值时在后台执行 EXPLAIN，执行计划附加到慢查询日志中
+	ExplainSlow bool `mapstructure:"explain_slow"`
+
+	// ExplainTimeout 获取执行计划的超时时间（毫秒）
+	ExplainTimeout int `mapstructure:"explain_timeout"`
 }
 
 // TransactionConfig 事务配置
@@ -297,6 +341,9 @@ func LoadDatabaseConfigs(configPath string) (map[string]*DbConfig, error) {
 		if cfg.Log.SlowThreshold == 0 {
 			cfg.Log.SlowThreshold = 200 // 200毫秒
 		}
+		if cfg.Log.ExplainTimeout == 0 {
+			cfg.Log.ExplainTimeout = 5000 // 5秒
+		}
 	}
 
 	return dbConfig.Connections, nil
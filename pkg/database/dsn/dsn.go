@@ -585,6 +585,14 @@ func GenerateClickHouse(config *dbtypes.DbConfig) (string, error) {
 		}
 	}
 
+	// 写入模式 - async 模式通过会话设置开启服务端异步写入
+	// 驱动会将未识别的DSN参数作为服务端设置传递；buffer 模式由驱动改写写入表名，不需要DSN参数
+	asyncParams, err := clickHouseAsyncInsertParams(&config.Connection)
+	if err != nil {
+		return "", err
+	}
+	params = append(params, asyncParams...)
+
 	// === ClickHouse集群和高级参数 ===
 	// 注意：多个主机已在地址部分处理，不需要hosts参数
 
@@ -596,6 +604,40 @@ func GenerateClickHouse(config *dbtypes.DbConfig) (string, error) {
 	return dsn, nil
 }
 
+// clickHouseAsyncInsertParams 生成 async 写入模式的服务端设置参数
+// 参数:
+//   - conn: 连接配置
+//
+// 返回:
+//   - []string: DSN参数，非 async 模式返回空
+//   - error: 写入模式或确认方式配置错误
+func clickHouseAsyncInsertParams(conn *dbtypes.ConnectionConfig) ([]string, error) {
+	switch conn.ClickHouseInsertMode {
+	case "", dbtypes.ClickHouseInsertModeSync, dbtypes.ClickHouseInsertModeBuffer:
+		return nil, nil
+	case dbtypes.ClickHouseInsertModeAsync:
+	default:
+		return nil, huberrors.NewError("ClickHouse写入模式不支持: %s (支持sync,async,buffer)", conn.ClickHouseInsertMode)
+	}
+
+	params := []string{"async_insert=1"}
+	switch conn.ClickHouseAsyncInsertAck {
+	case "", dbtypes.ClickHouseAsyncInsertAckFlush:
+		params = append(params, "wait_for_async_insert=1")
+	case dbtypes.ClickHouseAsyncInsertAckEnqueue:
+		params = append(params, "wait_for_async_insert=0")
+	default:
+		return nil, huberrors.NewError("ClickHouse异步写入确认方式不支持: %s (支持flush,enqueue)", conn.ClickHouseAsyncInsertAck)
+	}
+	if conn.ClickHouseAsyncInsertBusyTimeoutMs > 0 {
+		params = append(params, fmt.Sprintf("async_insert_busy_timeout_ms=%d", conn.ClickHouseAsyncInsertBusyTimeoutMs))
+	}
+	if conn.ClickHouseAsyncInsertMaxDataSize > 0 {
+		params = append(params, fmt.Sprintf("async_insert_max_data_size=%d", conn.ClickHouseAsyncInsertMaxDataSize))
+	}
+	return params, nil
+}
+
 // ValidateDSN 验证生成的DSN是否符合格式要求
 // 参数:
 //   - driver: 数据库驱动类型
@@ -126,6 +126,7 @@ func (m *MySQL) Connect(config *database.DbConfig) error {
 	}
 
 	m.db = db
+	m.logger.SetExplainer(m.explainQuery)
 	m.logger.LogConnected(context.Background(), database.DriverMySQL, map[string]any{
 		"maxOpenConns":    maxOpenConns,
 		"maxIdleConns":    maxIdleConns,
@@ -136,6 +137,11 @@ func (m *MySQL) Connect(config *database.DbConfig) error {
 	return nil
 }
 
+// explainQuery 获取慢查询的执行计划（EXPLAIN）
+func (m *MySQL) explainQuery(ctx context.Context, query string, args []any) (string, error) {
+	return dblogger.ExplainRows(ctx, m.db, "EXPLAIN "+query, args...)
+}
+
 // Close 关闭数据库连接
 // 关闭MySQL数据库连接，释放相关资源
 // 注意：使用上下文绑定事务的情况下，Close不会自动回滚事务
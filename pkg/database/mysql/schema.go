@@ -0,0 +1,89 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"gateway/pkg/database"
+)
+
+// Schema 获取当前数据库的结构
+// 查询 information_schema 中当前库（DATABASE()）的表、列和索引，不包含视图
+// 参数:
+//
+//	ctx: 上下文，用于控制请求超时和取消
+//	options: 查询选项，为nil时返回全部表
+//
+// 返回:
+//
+//	*database.SchemaInfo: 数据库结构
+//	error: 查询失败时返回错误信息
+func (m *MySQL) Schema(ctx context.Context, options *database.SchemaOptions) (*database.SchemaInfo, error) {
+	var databaseName string
+	if err := m.db.QueryRowContext(ctx, "SELECT DATABASE()").Scan(&databaseName); err != nil {
+		return nil, fmt.Errorf("查询当前数据库失败: %w", err)
+	}
+	builder := database.NewSchemaBuilder(m.GetDriver(), databaseName, options)
+
+	err := database.QuerySchemaRows(ctx, m.db, `
+		SELECT TABLE_NAME, TABLE_COMMENT FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_TYPE = 'BASE TABLE'`, nil, func(rows *sql.Rows) error {
+		var name, comment sql.NullString
+		if err := rows.Scan(&name, &comment); err != nil {
+			return err
+		}
+		builder.AddTable(name.String, comment.String)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("查询表信息失败: %w", err)
+	}
+
+	err = database.QuerySchemaRows(ctx, m.db, `
+		SELECT TABLE_NAME, COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE, COLUMN_DEFAULT, COLUMN_COMMENT, ORDINAL_POSITION
+		FROM information_schema.COLUMNS
+		WHERE TABLE_SCHEMA = DATABASE()`, nil, func(rows *sql.Rows) error {
+		var (
+			table, name, dataType, nullable, comment sql.NullString
+			defaultValue                             sql.NullString
+			position                                 int
+		)
+		if err := rows.Scan(&table, &name, &dataType, &nullable, &defaultValue, &comment, &position); err != nil {
+			return err
+		}
+		builder.AddColumn(table.String, database.ColumnInfo{
+			Name:     name.String,
+			DataType: dataType.String,
+			Nullable: nullable.String == "YES",
+			Default:  database.NullStringPtr(defaultValue),
+			Comment:  comment.String,
+			Position: position,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("查询列信息失败: %w", err)
+	}
+
+	err = database.QuerySchemaRows(ctx, m.db, `
+		SELECT TABLE_NAME, INDEX_NAME, NON_UNIQUE, COLUMN_NAME
+		FROM information_schema.STATISTICS
+		WHERE TABLE_SCHEMA = DATABASE()
+		ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX`, nil, func(rows *sql.Rows) error {
+		var (
+			table, index, column sql.NullString
+			nonUnique            int
+		)
+		if err := rows.Scan(&table, &index, &nonUnique, &column); err != nil {
+			return err
+		}
+		builder.AddIndexColumn(table.String, index.String, nonUnique == 0, index.String == "PRIMARY", column.String)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("查询索引信息失败: %w", err)
+	}
+
+	return builder.Build(), nil
+}
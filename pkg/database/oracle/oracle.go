@@ -23,6 +23,9 @@ import (
 // 事务上下文键
 const txContextKey = "gateway.oracle.transaction"
 
+// oracleArrayBindBatchSize BatchInsert 每次数组绑定执行的行数，限制单次绑定占用的内存
+const oracleArrayBindBatchSize = 1000
+
 // TxContext 事务上下文信息
 type TxContext struct {
 	tx      *sql.Tx
@@ -159,6 +162,7 @@ func (o *Oracle) Connect(config *database.DbConfig) error {
 	}
 
 	o.db = db
+	o.logger.SetExplainer(o.explainQuery)
 	o.logger.LogConnected(context.Background(), database.DriverOracle, map[string]any{
 		"maxOpenConns":    maxOpenConns,
 		"maxIdleConns":    maxIdleConns,
@@ -170,6 +174,25 @@ func (o *Oracle) Connect(config *database.DbConfig) error {
 	return nil
 }
 
+// explainQuery 获取慢查询的执行计划
+// EXPLAIN PLAN 写入 PLAN_TABLE 后通过 DBMS_XPLAN 读取，需要在同一会话中执行，读取后删除本次的计划记录；
+// EXPLAIN PLAN 不需要绑定变量的值
+func (o *Oracle) explainQuery(ctx context.Context, query string, args []any) (string, error) {
+	conn, err := o.db.Conn(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	statementId := fmt.Sprintf("SLOW_%d", time.Now().UnixNano())
+	if _, err := conn.ExecContext(ctx, "EXPLAIN PLAN SET STATEMENT_ID = '"+statementId+"' FOR "+o.convertPlaceholders(query)); err != nil {
+		return "", err
+	}
+	defer conn.ExecContext(ctx, "DELETE FROM PLAN_TABLE WHERE STATEMENT_ID = :1", statementId)
+
+	return dblogger.ExplainRows(ctx, conn, "SELECT PLAN_TABLE_OUTPUT FROM TABLE(DBMS_XPLAN.DISPLAY('PLAN_TABLE', :1, 'TYPICAL'))", statementId)
+}
+
 // Close 关闭数据库连接
 // 关闭Oracle数据库连接，释放相关资源
 // 如果存在活跃事务，会先回滚事务再关闭连接
@@ -741,14 +764,15 @@ func (o *Oracle) Delete(ctx context.Context, table string, where string, args []
 
 // BatchInsert 批量插入记录
 // 将切片中的多个数据结构体批量插入到Oracle中
-// 使用高效的预编译循环执行模式
+// 使用数组绑定（array DML）模式，每批数据一次往返执行
 //
-// 高效的预编译循环执行模式：
+// 数组绑定执行模式：
 //  1. 预编译一次：使用sql.PrepareContext()预编译单条INSERT语句
 //  2. 事务保证：默认在事务中执行，确保数据一致性
-//  3. 循环执行：在事务中循环执行预编译语句，逐条插入数据
-//  4. 错误处理：任何错误都会触发事务回滚，保证原子性
-//  5. Oracle适配：自动转换占位符格式，支持Oracle特有的序列处理
+//  3. 按列绑定：每 oracleArrayBindBatchSize 行转置为按列的类型化切片，godror 以 ExecMany 一次执行整批
+//  4. 自动回退：某批数据存在无法数组绑定的值（如 bool、混合类型）时，该批回退为逐条执行预编译语句
+//  5. 错误处理：任何错误都会触发事务回滚，保证原子性
+//  6. Oracle适配：自动转换占位符格式，支持Oracle特有的序列处理
 //
 // 参数:
 //
@@ -820,30 +844,36 @@ func (o *Oracle) BatchInsert(ctx context.Context, table string, dataSlice interf
 	}
 	defer stmt.Close()
 
-	// 第五步：循环执行预编译语句，逐条插入数据
+	// 第五步：分批提取数据，按数组绑定执行，无法数组绑定的批次逐条执行
 	var totalRowsAffected int64
-	for i := 0; i < slice.Len(); i++ {
-		item := slice.Index(i).Interface()
-		_, values, err := sqlutils.ExtractColumnsAndValues(item)
-		if err != nil {
-			if needCommit {
-				tx.Rollback()
+	var arrayBatches, loopBatches int
+	rows := make([][]interface{}, 0, min(slice.Len(), oracleArrayBindBatchSize))
+	for batchStart := 0; batchStart < slice.Len(); batchStart += oracleArrayBindBatchSize {
+		batchEnd := min(batchStart+oracleArrayBindBatchSize, slice.Len())
+		rows = rows[:0]
+		for i := batchStart; i < batchEnd; i++ {
+			_, values, err := sqlutils.ExtractColumnsAndValues(slice.Index(i).Interface())
+			if err != nil {
+				if needCommit {
+					tx.Rollback()
+				}
+				return 0, fmt.Errorf("failed to extract values from item %d: %w", i, err)
 			}
-			return 0, fmt.Errorf("failed to extract values from item %d: %w", i, err)
+			rows = append(rows, values)
 		}
 
-		// 执行单条插入
-		result, err := stmt.ExecContext(ctx, values...)
+		rowsAffected, usedArray, err := o.execInsertBatch(ctx, stmt, rows, batchStart)
 		if err != nil {
 			if needCommit {
 				tx.Rollback() // 出现错误时回滚事务
 			}
-			return 0, fmt.Errorf("failed to insert item %d: %w", i, err)
+			return 0, err
 		}
-
-		// 累计影响行数
-		if rowsAffected, err := result.RowsAffected(); err == nil {
-			totalRowsAffected += rowsAffected
+		totalRowsAffected += rowsAffected
+		if usedArray {
+			arrayBatches++
+		} else {
+			loopBatches++
 		}
 	}
 	duration := time.Since(start)
@@ -857,17 +887,66 @@ func (o *Oracle) BatchInsert(ctx context.Context, table string, dataSlice interf
 	}
 
 	// 记录执行日志
+	executionMode := "array_binding"
+	if loopBatches > 0 {
+		executionMode = "array_binding_with_prepared_loop"
+		if arrayBatches == 0 {
+			executionMode = "prepared_loop"
+		}
+	}
 	extra := map[string]interface{}{
 		"rowsAffected":  totalRowsAffected,
 		"batchSize":     slice.Len(),
 		"columnsCount":  len(columns),
-		"executionMode": "prepared_loop",
+		"arrayBatches":  arrayBatches,
+		"loopBatches":   loopBatches,
+		"executionMode": executionMode,
 	}
 	o.logger.LogSQL(ctx, "SQL批量插入", query, []interface{}{"[batch_data]"}, nil, duration, extra)
 
 	return totalRowsAffected, nil
 }
 
+// execInsertBatch 执行一批插入
+// 优先将整批数据转置为按列的类型化切片做数组绑定；存在无法数组绑定的值时逐条执行
+// 参数:
+//
+//	ctx: 上下文
+//	stmt: 预编译的INSERT语句
+//	rows: 本批每行的参数值
+//	offset: 本批第一行在整个切片中的下标，用于错误信息
+//
+// 返回:
+//
+//	int64: 受影响的行数
+//	bool: 是否使用了数组绑定
+//	error: 插入失败时返回错误信息
+func (o *Oracle) execInsertBatch(ctx context.Context, stmt *sql.Stmt, rows [][]interface{}, offset int) (int64, bool, error) {
+	if arrays, err := sqlutils.BuildColumnArrays(rows); err == nil {
+		result, err := stmt.ExecContext(ctx, arrays...)
+		if err != nil {
+			return 0, true, fmt.Errorf("failed to insert items %d-%d: %w", offset, offset+len(rows)-1, err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			rowsAffected = int64(len(rows))
+		}
+		return rowsAffected, true, nil
+	}
+
+	var totalRowsAffected int64
+	for i, values := range rows {
+		result, err := stmt.ExecContext(ctx, values...)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to insert item %d: %w", offset+i, err)
+		}
+		if rowsAffected, err := result.RowsAffected(); err == nil {
+			totalRowsAffected += rowsAffected
+		}
+	}
+	return totalRowsAffected, false, nil
+}
+
 // BatchUpdate 批量更新记录
 // 将切片中的多个数据结构体批量更新到Oracle中
 // 使用预编译循环执行模式，根据指定的关键字段进行匹配更新
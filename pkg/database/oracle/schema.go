@@ -0,0 +1,120 @@
+//go:build !no_oracle
+// +build !no_oracle
+
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gateway/pkg/database"
+)
+
+// Schema 获取当前用户模式的结构
+// 查询 USER_TABLES、USER_TAB_COLUMNS、USER_INDEXES 等数据字典视图；
+// 列默认值为 LONG 类型，各版本驱动读取方式不一致，不返回默认值
+// 参数:
+//
+//	ctx: 上下文，用于控制请求超时和取消
+//	options: 查询选项，为nil时返回全部表
+//
+// 返回:
+//
+//	*database.SchemaInfo: 数据库结构
+//	error: 查询失败时返回错误信息
+func (o *Oracle) Schema(ctx context.Context, options *database.SchemaOptions) (*database.SchemaInfo, error) {
+	var schemaName string
+	if err := o.db.QueryRowContext(ctx, "SELECT SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') FROM DUAL").Scan(&schemaName); err != nil {
+		return nil, fmt.Errorf("查询当前模式失败: %w", err)
+	}
+	builder := database.NewSchemaBuilder(o.GetDriver(), schemaName, options)
+
+	err := database.QuerySchemaRows(ctx, o.db, `
+		SELECT t.TABLE_NAME, c.COMMENTS FROM USER_TABLES t
+		LEFT JOIN USER_TAB_COMMENTS c ON c.TABLE_NAME = t.TABLE_NAME`, nil, func(rows *sql.Rows) error {
+		var name, comment sql.NullString
+		if err := rows.Scan(&name, &comment); err != nil {
+			return err
+		}
+		builder.AddTable(name.String, comment.String)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("查询表信息失败: %w", err)
+	}
+
+	err = database.QuerySchemaRows(ctx, o.db, `
+		SELECT c.TABLE_NAME, c.COLUMN_NAME, c.DATA_TYPE, c.CHAR_LENGTH, c.DATA_PRECISION, c.DATA_SCALE, c.NULLABLE, c.COLUMN_ID, m.COMMENTS
+		FROM USER_TAB_COLUMNS c
+		LEFT JOIN USER_COL_COMMENTS m ON m.TABLE_NAME = c.TABLE_NAME AND m.COLUMN_NAME = c.COLUMN_NAME`, nil, func(rows *sql.Rows) error {
+		var (
+			table, name, dataType, nullable, comment sql.NullString
+			charLength, precision, scale             sql.NullInt64
+			position                                 int
+		)
+		if err := rows.Scan(&table, &name, &dataType, &charLength, &precision, &scale, &nullable, &position, &comment); err != nil {
+			return err
+		}
+		builder.AddColumn(table.String, database.ColumnInfo{
+			Name:     name.String,
+			DataType: oracleColumnType(dataType.String, charLength, precision, scale),
+			Nullable: nullable.String == "Y",
+			Comment:  comment.String,
+			Position: position,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("查询列信息失败: %w", err)
+	}
+
+	primaryIndexes := make(map[string]bool)
+	err = database.QuerySchemaRows(ctx, o.db, `
+		SELECT INDEX_NAME FROM USER_CONSTRAINTS
+		WHERE CONSTRAINT_TYPE = 'P' AND INDEX_NAME IS NOT NULL`, nil, func(rows *sql.Rows) error {
+		var index string
+		if err := rows.Scan(&index); err != nil {
+			return err
+		}
+		primaryIndexes[index] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("查询主键信息失败: %w", err)
+	}
+
+	err = database.QuerySchemaRows(ctx, o.db, `
+		SELECT i.TABLE_NAME, i.INDEX_NAME, i.UNIQUENESS, ic.COLUMN_NAME
+		FROM USER_INDEXES i
+		JOIN USER_IND_COLUMNS ic ON ic.INDEX_NAME = i.INDEX_NAME
+		ORDER BY i.TABLE_NAME, i.INDEX_NAME, ic.COLUMN_POSITION`, nil, func(rows *sql.Rows) error {
+		var table, index, uniqueness, column sql.NullString
+		if err := rows.Scan(&table, &index, &uniqueness, &column); err != nil {
+			return err
+		}
+		builder.AddIndexColumn(table.String, index.String, uniqueness.String == "UNIQUE", primaryIndexes[index.String], column.String)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("查询索引信息失败: %w", err)
+	}
+
+	return builder.Build(), nil
+}
+
+// oracleColumnType 由数据字典字段组装列类型，如 VARCHAR2(32)、NUMBER(10,2)
+func oracleColumnType(dataType string, charLength, precision, scale sql.NullInt64) string {
+	switch {
+	case strings.Contains(dataType, "CHAR") && charLength.Valid && charLength.Int64 > 0:
+		return fmt.Sprintf("%s(%d)", dataType, charLength.Int64)
+	case dataType == "NUMBER" && precision.Valid:
+		if scale.Valid && scale.Int64 > 0 {
+			return fmt.Sprintf("NUMBER(%d,%d)", precision.Int64, scale.Int64)
+		}
+		return fmt.Sprintf("NUMBER(%d)", precision.Int64)
+	default:
+		return dataType
+	}
+}
@@ -0,0 +1,453 @@
+package database
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"gateway/pkg/cache"
+	"gateway/pkg/logger"
+)
+
+// DefaultQueryCacheTTL 查询缓存默认有效期
+const DefaultQueryCacheTTL = 30 * time.Second
+
+// queryCacheKeyPrefix 查询缓存键前缀
+const queryCacheKeyPrefix = "dbquery:"
+
+// errQueryResultNotCacheable 查询结果无法序列化，不能缓存也不能共享给等待的调用方
+var errQueryResultNotCacheable = errors.New("query result is not cacheable")
+
+// 查询缓存上下文键
+const (
+	queryCacheOptionsKey = "gateway.database.queryCache.options"
+	queryCacheTxKey      = "gateway.database.queryCache.tx"
+)
+
+var (
+	// readTablePattern 从查询语句中解析 FROM/JOIN 的表名
+	readTablePattern = regexp.MustCompile("(?i)\\b(?:FROM|JOIN)\\s+([A-Za-z0-9_.`\"\\[\\]]+)")
+	// writeTablePattern 从写入语句中解析目标表名
+	writeTablePattern = regexp.MustCompile("(?i)^\\s*(?:INSERT\\s+(?:IGNORE\\s+)?INTO|REPLACE\\s+INTO|MERGE\\s+INTO|UPDATE|DELETE\\s+FROM|TRUNCATE\\s+(?:TABLE\\s+)?|ALTER\\s+TABLE|DROP\\s+TABLE\\s+(?:IF\\s+EXISTS\\s+)?)\\s*([A-Za-z0-9_.`\"\\[\\]]+)")
+)
+
+// QueryCacheOptions 单次查询的缓存选项
+// 通过 WithQueryCache 设置到上下文中，只有带缓存选项的查询才会使用缓存
+type QueryCacheOptions struct {
+	// Key 缓存键，为空时按SQL语句和参数生成
+	Key string
+
+	// TTL 缓存有效期，<=0 时使用包装器的默认有效期
+	TTL time.Duration
+
+	// Tables 查询涉及的表，为空时从SQL语句中解析
+	// 同一进程内写入这些表时，相关缓存失效
+	Tables []string
+}
+
+// WithQueryCache 为查询设置缓存选项
+// 参数:
+//
+//	ctx: 上下文
+//	options: 缓存选项
+//
+// 返回:
+//
+//	context.Context: 携带缓存选项的上下文
+func WithQueryCache(ctx context.Context, options QueryCacheOptions) context.Context {
+	return context.WithValue(ctx, queryCacheOptionsKey, &options)
+}
+
+// queryCacheTx 事务中写入的表，提交或回滚后再次失效相关缓存，
+// 避免事务未提交期间其他查询把旧数据重新写入缓存
+type queryCacheTx struct {
+	mu     sync.Mutex
+	tables map[string]struct{}
+}
+
+// add 记录事务中写入的表
+func (t *queryCacheTx) add(tables []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, table := range tables {
+		t.tables[table] = struct{}{}
+	}
+}
+
+// list 获取事务中写入的表
+func (t *queryCacheTx) list() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tables := make([]string, 0, len(t.tables))
+	for table := range t.tables {
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+// CachedDatabase 带查询结果缓存的数据库包装器
+// 核心特性:
+// 1. 按需缓存 - 只缓存通过 WithQueryCache 指定了缓存选项的自动提交查询，事务内查询不使用缓存
+// 2. 单次查询配置 - 每次查询可指定缓存键和有效期
+// 3. 写入失效 - 通过包装器写入表时（包括原始SQL写入），失效同一进程内该表相关的查询缓存
+// 4. 缓存后端 - 使用 pkg/cache，查询结果以JSON格式存储，目标结构体需支持JSON序列化
+// 5. 防击穿 - 同一个键同时未命中时只查询一次，过期时间随机抖动，热点键过期前按概率在后台提前刷新
+//
+// 注意：失效只针对经过同一包装器实例的写入，其他进程或绕过包装器的写入只能等待缓存过期
+type CachedDatabase struct {
+	Database
+
+	cache      cache.Cache
+	loader     *cache.Loader
+	defaultTTL time.Duration
+
+	mu          sync.Mutex
+	tableKeys   map[string]map[string]struct{} // 表名 -> 依赖该表的缓存键
+	generations map[string]uint64              // 表名 -> 失效次数，用于丢弃查询期间被失效的结果
+	allGen      uint64                         // 全部失效次数（无法解析写入表时全部失效）
+}
+
+// NewCachedDatabase 创建带查询结果缓存的数据库包装器
+// 参数:
+//
+//	db: 被包装的数据库实例
+//	c: 缓存实例
+//	defaultTTL: 默认缓存有效期，<=0 时使用 DefaultQueryCacheTTL
+//
+// 返回:
+//
+//	*CachedDatabase: 数据库包装器，实现 Database 接口
+func NewCachedDatabase(db Database, c cache.Cache, defaultTTL time.Duration) *CachedDatabase {
+	if defaultTTL <= 0 {
+		defaultTTL = DefaultQueryCacheTTL
+	}
+	return &CachedDatabase{
+		Database:    db,
+		cache:       c,
+		loader:      cache.NewLoader(c, cache.DefaultLoaderOptions),
+		defaultTTL:  defaultTTL,
+		tableKeys:   make(map[string]map[string]struct{}),
+		generations: make(map[string]uint64),
+	}
+}
+
+// Unwrap 获取被包装的数据库实例
+func (c *CachedDatabase) Unwrap() Database {
+	return c.Database
+}
+
+// Query 查询多条记录，带缓存选项时优先从缓存读取
+func (c *CachedDatabase) Query(ctx context.Context, dest interface{}, query string, args []interface{}, autoCommit bool) error {
+	return c.cachedQuery(ctx, dest, query, args, autoCommit, c.Database.Query)
+}
+
+// QueryOne 查询单条记录，带缓存选项时优先从缓存读取
+// 未找到记录时不缓存
+func (c *CachedDatabase) QueryOne(ctx context.Context, dest interface{}, query string, args []interface{}, autoCommit bool) error {
+	return c.cachedQuery(ctx, dest, query, args, autoCommit, c.Database.QueryOne)
+}
+
+// cachedQuery 执行带缓存的查询
+func (c *CachedDatabase) cachedQuery(ctx context.Context, dest interface{}, query string, args []interface{}, autoCommit bool,
+	fn func(ctx context.Context, dest interface{}, query string, args []interface{}, autoCommit bool) error) error {
+	options, ok := ctx.Value(queryCacheOptionsKey).(*QueryCacheOptions)
+	if !ok || !autoCommit || c.cache == nil {
+		return fn(ctx, dest, query, args, autoCommit)
+	}
+	if _, inTx := ctx.Value(queryCacheTxKey).(*queryCacheTx); inTx {
+		return fn(ctx, dest, query, args, autoCommit)
+	}
+
+	key := c.cacheKey(options.Key, query, args)
+	tables := options.Tables
+	if len(tables) == 0 {
+		tables = parseTables(readTablePattern, query)
+	}
+	tables = normalizeTables(tables)
+	ttl := options.TTL
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
+
+	// 同一个键同时未命中时只查询一次，查询结果以JSON共享给等待的调用方
+	data, err := c.loader.Get(ctx, key, ttl, func(ctx context.Context) ([]byte, bool, error) {
+		start := time.Now()
+		generation := c.generation(tables)
+		result := reflect.New(reflect.TypeOf(dest).Elem())
+		if err := fn(ctx, result.Interface(), query, args, autoCommit); err != nil {
+			return nil, false, err
+		}
+
+		data, err := json.Marshal(result.Interface())
+		if err != nil {
+			logger.Warn("查询结果无法序列化，跳过缓存", "key", key, "error", err)
+			return nil, false, errQueryResultNotCacheable
+		}
+
+		// 查询期间相关表被写入时丢弃结果，避免缓存旧数据
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.generationLocked(tables) != generation {
+			return data, false, nil
+		}
+		if err := c.loader.Store(ctx, key, data, ttl, time.Since(start)); err != nil {
+			logger.Warn("写入查询缓存失败", "key", key, "error", err)
+			return data, false, nil
+		}
+		for _, table := range tables {
+			keys, ok := c.tableKeys[table]
+			if !ok {
+				keys = make(map[string]struct{})
+				c.tableKeys[table] = keys
+			}
+			keys[key] = struct{}{}
+		}
+		return data, false, nil
+	})
+	if errors.Is(err, errQueryResultNotCacheable) {
+		return fn(ctx, dest, query, args, autoCommit)
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fn(ctx, dest, query, args, autoCommit)
+	}
+	return nil
+}
+
+// Exec 执行SQL语句，并按语句的目标表失效缓存
+func (c *CachedDatabase) Exec(ctx context.Context, query string, args []interface{}, autoCommit bool) (int64, error) {
+	rows, err := c.Database.Exec(ctx, query, args, autoCommit)
+	if tables := parseTables(writeTablePattern, query); len(tables) > 0 {
+		c.written(ctx, tables...)
+	} else if !isReadStatement(query) {
+		// 无法确定写入的表时全部失效
+		c.InvalidateAll(ctx)
+	}
+	return rows, err
+}
+
+// Insert 插入记录，并失效该表相关的缓存
+func (c *CachedDatabase) Insert(ctx context.Context, table string, data interface{}, autoCommit bool) (int64, error) {
+	defer c.written(ctx, table)
+	return c.Database.Insert(ctx, table, data, autoCommit)
+}
+
+// Update 更新记录，并失效该表相关的缓存
+func (c *CachedDatabase) Update(ctx context.Context, table string, data interface{}, where string, args []interface{}, autoCommit bool, skipZero bool) (int64, error) {
+	defer c.written(ctx, table)
+	return c.Database.Update(ctx, table, data, where, args, autoCommit, skipZero)
+}
+
+// Delete 删除记录，并失效该表相关的缓存
+func (c *CachedDatabase) Delete(ctx context.Context, table string, where string, args []interface{}, autoCommit bool) (int64, error) {
+	defer c.written(ctx, table)
+	return c.Database.Delete(ctx, table, where, args, autoCommit)
+}
+
+// BatchInsert 批量插入记录，并失效该表相关的缓存
+func (c *CachedDatabase) BatchInsert(ctx context.Context, table string, dataSlice interface{}, autoCommit bool) (int64, error) {
+	defer c.written(ctx, table)
+	return c.Database.BatchInsert(ctx, table, dataSlice, autoCommit)
+}
+
+// BatchUpdate 批量更新记录，并失效该表相关的缓存
+func (c *CachedDatabase) BatchUpdate(ctx context.Context, table string, dataSlice interface{}, keyFields []string, autoCommit bool) (int64, error) {
+	defer c.written(ctx, table)
+	return c.Database.BatchUpdate(ctx, table, dataSlice, keyFields, autoCommit)
+}
+
+// BatchDelete 批量删除记录，并失效该表相关的缓存
+func (c *CachedDatabase) BatchDelete(ctx context.Context, table string, dataSlice interface{}, keyFields []string, autoCommit bool) (int64, error) {
+	defer c.written(ctx, table)
+	return c.Database.BatchDelete(ctx, table, dataSlice, keyFields, autoCommit)
+}
+
+// BatchDeleteByKeys 按主键批量删除记录，并失效该表相关的缓存
+func (c *CachedDatabase) BatchDeleteByKeys(ctx context.Context, table string, keyField string, keys []interface{}, autoCommit bool) (int64, error) {
+	defer c.written(ctx, table)
+	return c.Database.BatchDeleteByKeys(ctx, table, keyField, keys, autoCommit)
+}
+
+// BeginTx 开始事务，事务内写入的表在提交或回滚后再次失效
+func (c *CachedDatabase) BeginTx(ctx context.Context, options *TxOptions) (context.Context, error) {
+	txCtx, err := c.Database.BeginTx(ctx, options)
+	if err != nil {
+		return txCtx, err
+	}
+	return context.WithValue(txCtx, queryCacheTxKey, &queryCacheTx{tables: make(map[string]struct{})}), nil
+}
+
+// Commit 提交事务，并失效事务内写入的表相关的缓存
+func (c *CachedDatabase) Commit(ctx context.Context) error {
+	defer c.finishTx(ctx)
+	return c.Database.Commit(ctx)
+}
+
+// Rollback 回滚事务，并失效事务内写入的表相关的缓存
+func (c *CachedDatabase) Rollback(ctx context.Context) error {
+	defer c.finishTx(ctx)
+	return c.Database.Rollback(ctx)
+}
+
+// InTx 在事务中执行函数，事务结束后失效事务内写入的表相关的缓存
+func (c *CachedDatabase) InTx(ctx context.Context, options *TxOptions, fn func(context.Context) error) error {
+	tx := &queryCacheTx{tables: make(map[string]struct{})}
+	defer func() {
+		c.Invalidate(context.Background(), tx.list()...)
+	}()
+	return c.Database.InTx(ctx, options, func(txCtx context.Context) error {
+		return fn(context.WithValue(txCtx, queryCacheTxKey, tx))
+	})
+}
+
+// finishTx 事务结束后失效事务内写入的表
+func (c *CachedDatabase) finishTx(ctx context.Context) {
+	if tx, ok := ctx.Value(queryCacheTxKey).(*queryCacheTx); ok {
+		c.Invalidate(ctx, tx.list()...)
+	}
+}
+
+// written 记录表写入：立即失效相关缓存，事务中写入时同时记录到事务
+func (c *CachedDatabase) written(ctx context.Context, tables ...string) {
+	tables = normalizeTables(tables)
+	if tx, ok := ctx.Value(queryCacheTxKey).(*queryCacheTx); ok {
+		tx.add(tables)
+	}
+	c.Invalidate(ctx, tables...)
+}
+
+// Invalidate 失效指定表相关的查询缓存
+// 参数:
+//
+//	ctx: 上下文
+//	tables: 表名
+func (c *CachedDatabase) Invalidate(ctx context.Context, tables ...string) {
+	tables = normalizeTables(tables)
+	if len(tables) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	var keys []string
+	for _, table := range tables {
+		c.generations[table]++
+		for key := range c.tableKeys[table] {
+			keys = append(keys, key)
+		}
+		delete(c.tableKeys, table)
+	}
+	c.mu.Unlock()
+
+	c.deleteKeys(ctx, keys)
+}
+
+// InvalidateAll 失效全部查询缓存
+func (c *CachedDatabase) InvalidateAll(ctx context.Context) {
+	c.mu.Lock()
+	c.allGen++
+	seen := make(map[string]struct{})
+	var keys []string
+	for _, tableKeys := range c.tableKeys {
+		for key := range tableKeys {
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+	}
+	c.tableKeys = make(map[string]map[string]struct{})
+	c.mu.Unlock()
+
+	c.deleteKeys(ctx, keys)
+}
+
+// deleteKeys 从缓存中删除缓存键
+func (c *CachedDatabase) deleteKeys(ctx context.Context, keys []string) {
+	if len(keys) == 0 || c.cache == nil {
+		return
+	}
+	if err := c.cache.MDelete(ctx, keys); err != nil {
+		logger.Warn("删除查询缓存失败", "keys", len(keys), "error", err)
+	}
+}
+
+// generation 获取表的失效次数之和
+func (c *CachedDatabase) generation(tables []string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generationLocked(tables)
+}
+
+// generationLocked 获取表的失效次数之和，调用方需持有锁
+func (c *CachedDatabase) generationLocked(tables []string) uint64 {
+	total := c.allGen
+	for _, table := range tables {
+		total += c.generations[table]
+	}
+	return total
+}
+
+// cacheKey 生成缓存键，未指定时按SQL语句和参数生成摘要
+func (c *CachedDatabase) cacheKey(key, query string, args []interface{}) string {
+	prefix := queryCacheKeyPrefix + c.GetName() + ":"
+	if key != "" {
+		return prefix + key
+	}
+	sum := sha1.Sum([]byte(query + "|" + fmt.Sprintf("%#v", args)))
+	return prefix + hex.EncodeToString(sum[:])
+}
+
+// parseTables 按正则解析SQL语句中的表名
+func parseTables(pattern *regexp.Regexp, query string) []string {
+	matches := pattern.FindAllStringSubmatch(query, -1)
+	tables := make([]string, 0, len(matches))
+	for _, match := range matches {
+		tables = append(tables, match[1])
+	}
+	return tables
+}
+
+// normalizeTables 规范化表名：去除引号和库名前缀，统一大写并去重
+func normalizeTables(tables []string) []string {
+	result := make([]string, 0, len(tables))
+	seen := make(map[string]struct{}, len(tables))
+	for _, table := range tables {
+		table = strings.Trim(strings.TrimSpace(table), "`\"[]")
+		if idx := strings.LastIndex(table, "."); idx >= 0 {
+			table = strings.Trim(table[idx+1:], "`\"[]")
+		}
+		table = strings.ToUpper(table)
+		if table == "" {
+			continue
+		}
+		if _, ok := seen[table]; ok {
+			continue
+		}
+		seen[table] = struct{}{}
+		result = append(result, table)
+	}
+	return result
+}
+
+// isReadStatement 判断是否为不修改数据的语句
+func isReadStatement(query string) bool {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return true
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "SELECT", "WITH", "SHOW", "DESC", "DESCRIBE", "EXPLAIN", "SET", "PRAGMA", "USE":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"gateway/pkg/config"
+	"gateway/pkg/security"
+)
+
+// reencryptBatchSize 重新加密时每批读取的行数，每处理完一批回调一次进度
+const reencryptBatchSize = 100
+
+// identifierPattern 表名、列名校验规则，防止拼接SQL时注入
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// ReencryptTarget 需要重新加密的表字段
+type ReencryptTarget struct {
+	// Table 表名
+	Table string `mapstructure:"table" json:"table"`
+	// KeyColumn 主键列，用于逐行更新
+	KeyColumn string `mapstructure:"key_column" json:"keyColumn"`
+	// Column 存放密文的列
+	Column string `mapstructure:"column" json:"column"`
+}
+
+// ReencryptProgress 单个表字段的重新加密进度
+type ReencryptProgress struct {
+	Table   string `json:"table"`
+	Column  string `json:"column"`
+	Total   int    `json:"total"`   // 待处理的密文行数
+	Scanned int    `json:"scanned"` // 已处理行数
+	Updated int    `json:"updated"` // 已重新加密行数
+	Skipped int    `json:"skipped"` // 已是新密钥密文而跳过的行数
+	Failed  int    `json:"failed"`  // 失败行数
+}
+
+// ReencryptReport 批量重新加密结果
+type ReencryptReport struct {
+	Targets []ReencryptProgress `json:"targets"`
+	// Errors 失败明细，格式为 表.列[主键]: 错误
+	Errors []string `json:"errors,omitempty"`
+}
+
+// reencryptRow 重新加密时读取的行
+type reencryptRow struct {
+	RowKey   string `db:"rowKey"`
+	RowValue string `db:"rowValue"`
+}
+
+// reencryptCount 待重新加密的行数
+type reencryptCount struct {
+	Total int `db:"total"`
+}
+
+// LoadReencryptTargets 从配置 app.key_rotation.targets 读取需要重新加密的表字段
+func LoadReencryptTargets() ([]ReencryptTarget, error) {
+	var targets []ReencryptTarget
+	if !config.IsExist("app.key_rotation.targets") {
+		return targets, nil
+	}
+	if err := config.GetSection("app.key_rotation.targets", &targets); err != nil {
+		return nil, fmt.Errorf("读取重新加密配置失败: %w", err)
+	}
+	return targets, nil
+}
+
+// ReencryptColumns 批量重新加密数据库字段
+//
+// 逐个扫描表字段中以 "ENCY_" 开头的值，使用旧密钥解密、新密钥重新加密后逐行更新。
+// 按主键分批读取（keyset 分页），大表不会一次性载入内存。
+// 单行失败不会中断处理，失败明细记录在报告中；已是新密钥的密文会被跳过，
+// 因此中断后可以重复执行。
+//
+// 参数:
+//   - ctx: 上下文
+//   - db: 数据库连接
+//   - rotation: 密钥轮换对象，由 security.RotateKey 创建
+//   - targets: 需要处理的表字段
+//   - onProgress: 进度回调，每处理一批行及每个表字段完成时调用，可为nil
+//
+// 返回:
+//   - *ReencryptReport: 处理结果
+//   - error: 参数无效或查询失败时返回错误
+func ReencryptColumns(ctx context.Context, db Database, rotation *security.KeyRotation, targets []ReencryptTarget, onProgress func(ReencryptProgress)) (*ReencryptReport, error) {
+	if db == nil {
+		return nil, fmt.Errorf("数据库连接不能为空")
+	}
+	if rotation == nil {
+		return nil, fmt.Errorf("密钥轮换对象不能为空")
+	}
+
+	report := &ReencryptReport{}
+	for _, target := range targets {
+		if err := target.validate(); err != nil {
+			return report, err
+		}
+
+		progress, errs, err := reencryptTarget(ctx, db, rotation, target, onProgress)
+		report.Targets = append(report.Targets, progress)
+		report.Errors = append(report.Errors, errs...)
+		if err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// validate 校验表名和列名
+func (t ReencryptTarget) validate() error {
+	for _, name := range []string{t.Table, t.KeyColumn, t.Column} {
+		if !identifierPattern.MatchString(name) {
+			return fmt.Errorf("无效的表名或列名: %q", name)
+		}
+	}
+	return nil
+}
+
+// reencryptTarget 处理单个表字段
+// 以上一批最后一行的主键作为下一批的起点，处理期间已更新的行不会被重复读取
+func reencryptTarget(ctx context.Context, db Database, rotation *security.KeyRotation, target ReencryptTarget, onProgress func(ReencryptProgress)) (ReencryptProgress, []string, error) {
+	progress := ReencryptProgress{Table: target.Table, Column: target.Column}
+	pattern := security.EncryptedPrefix + "%"
+
+	// 别名加引号，避免 Oracle 将未加引号的别名转为大写导致无法映射到结构体字段
+	var count reencryptCount
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) AS "total" FROM %s WHERE %s LIKE ?`, target.Table, target.Column)
+	if err := db.QueryOne(ctx, &count, countQuery, []interface{}{pattern}, true); err != nil {
+		return progress, nil, fmt.Errorf("统计 %s.%s 失败: %w", target.Table, target.Column, err)
+	}
+	progress.Total = count.Total
+
+	selectFrom := fmt.Sprintf(`SELECT %s AS "rowKey", %s AS "rowValue" FROM %s WHERE %s LIKE ?`,
+		target.KeyColumn, target.Column, target.Table, target.Column)
+	orderLimit := fmt.Sprintf(" ORDER BY %s%s", target.KeyColumn, limitClause(db.GetDriver(), reencryptBatchSize))
+	update := fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ? AND %s = ?",
+		target.Table, target.Column, target.KeyColumn, target.Column)
+
+	var errs []string
+	var lastKey *string
+	for {
+		query, args := selectFrom, []interface{}{pattern}
+		if lastKey != nil {
+			query += fmt.Sprintf(" AND %s > ?", target.KeyColumn)
+			args = append(args, *lastKey)
+		}
+
+		var rows []reencryptRow
+		if err := db.Query(ctx, &rows, query+orderLimit, args, true); err != nil {
+			return progress, errs, fmt.Errorf("查询 %s.%s 失败: %w", target.Table, target.Column, err)
+		}
+
+		for _, row := range rows {
+			if err := ctx.Err(); err != nil {
+				return progress, errs, err
+			}
+
+			progress.Scanned++
+			newValue, changed, err := rotation.Reencrypt(row.RowValue)
+			switch {
+			case err != nil:
+				progress.Failed++
+				errs = append(errs, fmt.Sprintf("%s.%s[%s]: %v", target.Table, target.Column, row.RowKey, err))
+			case !changed:
+				progress.Skipped++
+			default:
+				// 以原密文作为条件，避免覆盖处理期间被修改的值
+				if _, err := db.Exec(ctx, update, []interface{}{newValue, row.RowKey, row.RowValue}, true); err != nil {
+					progress.Failed++
+					errs = append(errs, fmt.Sprintf("%s.%s[%s]: %v", target.Table, target.Column, row.RowKey, err))
+				} else {
+					progress.Updated++
+				}
+			}
+		}
+
+		if len(rows) < reencryptBatchSize {
+			break
+		}
+		lastKey = &rows[len(rows)-1].RowKey
+		if onProgress != nil {
+			onProgress(progress)
+		}
+	}
+
+	if onProgress != nil {
+		onProgress(progress)
+	}
+	return progress, errs, nil
+}
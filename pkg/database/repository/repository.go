@@ -0,0 +1,170 @@
+// Package repository 类型化仓储的公共支持代码
+//
+// 各表的仓储由 cmd/repogen 根据模型结构体生成（增删改查、列表和分页方法），
+// 生成的代码使用本包构建查询条件并执行统计和分页查询，
+// 避免在各模块 DAO 中重复手写条件拼接和分页代码。
+//
+// 使用示例:
+//
+//	cond := repository.Where().
+//		Eq("tenantId", tenantId).
+//		EqIfNotEmpty("activeFlag", activeFlag).
+//		Like("flagKey", flagKey)
+//	flags, total, err := repo.Page(ctx, cond, "flagKey ASC", page, pageSize)
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"gateway/pkg/database"
+	"gateway/pkg/database/sqlutils"
+	"gateway/pkg/utils/empty"
+	"gateway/pkg/utils/huberrors"
+)
+
+// Condition 查询条件，多个条件之间为 AND 关系
+// 列名由调用方以常量传入，条件值一律使用占位符绑定
+type Condition struct {
+	clauses []string
+	args    []interface{}
+}
+
+// Where 创建空的查询条件
+func Where() *Condition {
+	return &Condition{}
+}
+
+// Eq 添加等值条件
+func (c *Condition) Eq(column string, value interface{}) *Condition {
+	c.clauses = append(c.clauses, column+" = ?")
+	c.args = append(c.args, value)
+	return c
+}
+
+// EqIfNotEmpty 值不为空时添加等值条件，用于可选的筛选参数
+func (c *Condition) EqIfNotEmpty(column string, value string) *Condition {
+	if empty.IsEmpty(value) {
+		return c
+	}
+	return c.Eq(column, value)
+}
+
+// Like 值不为空时添加模糊匹配条件
+func (c *Condition) Like(column string, value string) *Condition {
+	if empty.IsEmpty(value) {
+		return c
+	}
+	c.clauses = append(c.clauses, column+" LIKE ?")
+	c.args = append(c.args, "%"+value+"%")
+	return c
+}
+
+// In 添加 IN 条件，值为空时条件恒为假
+func (c *Condition) In(column string, values ...interface{}) *Condition {
+	if len(values) == 0 {
+		c.clauses = append(c.clauses, "1 = 0")
+		return c
+	}
+	c.clauses = append(c.clauses, column+" IN ("+strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")+")")
+	c.args = append(c.args, values...)
+	return c
+}
+
+// Raw 添加自定义条件片段，片段中的参数使用占位符
+func (c *Condition) Raw(clause string, args ...interface{}) *Condition {
+	c.clauses = append(c.clauses, "("+clause+")")
+	c.args = append(c.args, args...)
+	return c
+}
+
+// SQL 生成 WHERE 子句和参数，没有条件时返回空字符串
+// 参数:
+//   - 无
+//
+// 返回值:
+//   - string: 以 "WHERE " 开头的条件子句
+//   - []interface{}: 占位符参数
+func (c *Condition) SQL() (string, []interface{}) {
+	if c == nil || len(c.clauses) == 0 {
+		return "", nil
+	}
+	args := make([]interface{}, len(c.args))
+	copy(args, c.args)
+	return "WHERE " + strings.Join(c.clauses, " AND "), args
+}
+
+// SelectQuery 构建单表查询语句
+// 参数:
+//   - table: 表名
+//   - cond: 查询条件，可以为 nil
+//   - orderBy: 排序子句（不含 ORDER BY 关键字），为空时不排序
+//
+// 返回值:
+//   - string: 查询语句
+//   - []interface{}: 占位符参数
+func SelectQuery(table string, cond *Condition, orderBy string) (string, []interface{}) {
+	query := "SELECT * FROM " + table
+	where, args := cond.SQL()
+	if where != "" {
+		query += " " + where
+	}
+	if orderBy != "" {
+		query += " ORDER BY " + orderBy
+	}
+	return query, args
+}
+
+// Count 统计查询语句匹配的记录数
+func Count(ctx context.Context, db database.Database, query string, args []interface{}, autoCommit bool) (int, error) {
+	countQuery, err := sqlutils.BuildCountQuery(query)
+	if err != nil {
+		return 0, huberrors.WrapError(err, "构建统计查询失败")
+	}
+
+	var result struct {
+		Count int `db:"COUNT(*)"`
+	}
+	if err := db.QueryOne(ctx, &result, countQuery, args, autoCommit); err != nil {
+		return 0, huberrors.WrapError(err, "查询记录总数失败")
+	}
+	return result.Count, nil
+}
+
+// Page 分页查询
+// 先统计总数，总数为0时不再查询列表
+// 参数:
+//   - ctx: 上下文
+//   - db: 数据库连接
+//   - dest: 接收结果的切片指针
+//   - query: 不带分页的查询语句
+//   - args: 查询参数
+//   - page: 页码（从1开始）
+//   - pageSize: 每页数量
+//   - autoCommit: 是否自动提交，在事务中执行时为 false
+//
+// 返回值:
+//   - int: 记录总数
+//   - error: 查询失败时返回错误
+func Page(ctx context.Context, db database.Database, dest interface{}, query string, args []interface{}, page, pageSize int, autoCommit bool) (int, error) {
+	total, err := Count(ctx, db, query, args, autoCommit)
+	if err != nil {
+		return 0, err
+	}
+	if total == 0 {
+		return 0, nil
+	}
+
+	pagination := sqlutils.NewPaginationInfo(page, pageSize)
+	dbType := sqlutils.GetDatabaseType(db)
+	paginatedQuery, paginationArgs, err := sqlutils.BuildPaginationQuery(dbType, query, pagination)
+	if err != nil {
+		return 0, huberrors.WrapError(err, "构建分页查询失败")
+	}
+
+	allArgs := append(append([]interface{}{}, args...), paginationArgs...)
+	if err := db.Query(ctx, dest, paginatedQuery, allArgs, autoCommit); err != nil {
+		return 0, huberrors.WrapError(err, "分页查询失败")
+	}
+	return total, nil
+}
@@ -0,0 +1,352 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+)
+
+// SchemaOptions 数据库结构查询选项
+type SchemaOptions struct {
+	// Tables 只返回指定的表（表名不区分大小写），为空时返回全部表
+	Tables []string
+}
+
+// SchemaInfo 数据库结构
+type SchemaInfo struct {
+	Driver       string      `json:"driver"`       // 驱动类型
+	DatabaseName string      `json:"databaseName"` // 数据库（或模式）名称
+	Tables       []TableInfo `json:"tables"`       // 表，按名称排序
+}
+
+// TableInfo 表结构
+type TableInfo struct {
+	Name    string       `json:"name"`              // 表名
+	Comment string       `json:"comment,omitempty"` // 表注释
+	Columns []ColumnInfo `json:"columns"`           // 列，按定义顺序排序
+	Indexes []IndexInfo  `json:"indexes"`           // 索引，按名称排序，主键索引在最前
+}
+
+// ColumnInfo 列结构
+type ColumnInfo struct {
+	Name       string  `json:"name"`              // 列名
+	DataType   string  `json:"dataType"`          // 数据库原生类型（含长度和精度，如 varchar(32)、NUMBER(10,2)）
+	Nullable   bool    `json:"nullable"`          // 是否允许为空
+	Default    *string `json:"default,omitempty"` // 默认值表达式，没有默认值时为nil
+	PrimaryKey bool    `json:"primaryKey"`        // 是否为主键列
+	Comment    string  `json:"comment,omitempty"` // 列注释
+	Position   int     `json:"position"`          // 列序号，从1开始
+}
+
+// IndexInfo 索引结构
+type IndexInfo struct {
+	Name    string   `json:"name"`    // 索引名称
+	Unique  bool     `json:"unique"`  // 是否唯一索引
+	Primary bool     `json:"primary"` // 是否主键索引
+	Columns []string `json:"columns"` // 索引列，按索引中的顺序排列
+}
+
+// Table 按名称查找表（不区分大小写），不存在时返回nil
+func (s *SchemaInfo) Table(name string) *TableInfo {
+	for i := range s.Tables {
+		if strings.EqualFold(s.Tables[i].Name, name) {
+			return &s.Tables[i]
+		}
+	}
+	return nil
+}
+
+// Column 按名称查找列（不区分大小写），不存在时返回nil
+func (t *TableInfo) Column(name string) *ColumnInfo {
+	for i := range t.Columns {
+		if strings.EqualFold(t.Columns[i].Name, name) {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+// SchemaBuilder 数据库结构组装器
+// 各驱动查询系统表后按行写入表、列和索引信息，由 Build 排序并生成结果，
+// 不在 SchemaOptions.Tables 中的表直接忽略
+type SchemaBuilder struct {
+	info   *SchemaInfo
+	tables map[string]*TableInfo // 小写表名 -> 表
+	filter map[string]bool       // 小写表名，为空表示不过滤
+}
+
+// NewSchemaBuilder 创建数据库结构组装器
+func NewSchemaBuilder(driver, databaseName string, options *SchemaOptions) *SchemaBuilder {
+	b := &SchemaBuilder{
+		info:   &SchemaInfo{Driver: driver, DatabaseName: databaseName},
+		tables: make(map[string]*TableInfo),
+	}
+	if options != nil && len(options.Tables) > 0 {
+		b.filter = make(map[string]bool, len(options.Tables))
+		for _, table := range options.Tables {
+			b.filter[strings.ToLower(table)] = true
+		}
+	}
+	return b
+}
+
+// Includes 判断表是否需要返回
+func (b *SchemaBuilder) Includes(table string) bool {
+	return b.filter == nil || b.filter[strings.ToLower(table)]
+}
+
+// AddTable 添加表，表已存在时只更新注释
+func (b *SchemaBuilder) AddTable(name, comment string) {
+	if !b.Includes(name) {
+		return
+	}
+	if t, ok := b.tables[strings.ToLower(name)]; ok {
+		t.Comment = comment
+		return
+	}
+	b.tables[strings.ToLower(name)] = &TableInfo{Name: name, Comment: comment, Columns: []ColumnInfo{}, Indexes: []IndexInfo{}}
+}
+
+// AddColumn 添加列，所属表未添加时忽略（如视图的列）
+func (b *SchemaBuilder) AddColumn(table string, column ColumnInfo) {
+	if t, ok := b.tables[strings.ToLower(table)]; ok {
+		t.Columns = append(t.Columns, column)
+	}
+}
+
+// AddIndexColumn 按顺序添加索引的一列，索引不存在时创建
+func (b *SchemaBuilder) AddIndexColumn(table, index string, unique, primary bool, column string) {
+	t, ok := b.tables[strings.ToLower(table)]
+	if !ok {
+		return
+	}
+	for i := range t.Indexes {
+		if t.Indexes[i].Name == index {
+			t.Indexes[i].Columns = append(t.Indexes[i].Columns, column)
+			return
+		}
+	}
+	t.Indexes = append(t.Indexes, IndexInfo{Name: index, Unique: unique || primary, Primary: primary, Columns: []string{column}})
+}
+
+// Build 生成数据库结构
+// 表按名称排序，列按序号排序，主键索引排在最前，主键索引中的列标记为主键列
+func (b *SchemaBuilder) Build() *SchemaInfo {
+	b.info.Tables = make([]TableInfo, 0, len(b.tables))
+	for _, t := range b.tables {
+		sort.SliceStable(t.Columns, func(i, j int) bool { return t.Columns[i].Position < t.Columns[j].Position })
+		sort.SliceStable(t.Indexes, func(i, j int) bool {
+			if t.Indexes[i].Primary != t.Indexes[j].Primary {
+				return t.Indexes[i].Primary
+			}
+			return t.Indexes[i].Name < t.Indexes[j].Name
+		})
+		for _, index := range t.Indexes {
+			if !index.Primary {
+				continue
+			}
+			for _, name := range index.Columns {
+				if column := t.Column(name); column != nil {
+					column.PrimaryKey = true
+				}
+			}
+		}
+		b.info.Tables = append(b.info.Tables, *t)
+	}
+	sort.Slice(b.info.Tables, func(i, j int) bool { return b.info.Tables[i].Name < b.info.Tables[j].Name })
+	return b.info
+}
+
+// QuerySchemaRows 执行系统表查询并逐行处理结果，供各驱动实现 Schema 时使用
+// 参数:
+//
+//	ctx: 上下文
+//	db: 底层数据库连接
+//	query: 查询语句
+//	args: 查询参数
+//	scan: 逐行处理函数
+//
+// 返回:
+//
+//	error: 查询或处理失败时返回错误信息
+func QuerySchemaRows(ctx context.Context, db *sql.DB, query string, args []interface{}, scan func(rows *sql.Rows) error) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// NullStringPtr 将可空字符串转换为指针，NULL 时返回 nil
+func NullStringPtr(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+	s := value.String
+	return &s
+}
+
+// 数据库结构差异类型
+const (
+	SchemaDiffTableMissing  = "TABLE_MISSING"  // 目标库缺少表
+	SchemaDiffTableExtra    = "TABLE_EXTRA"    // 目标库多出表
+	SchemaDiffColumnMissing = "COLUMN_MISSING" // 目标表缺少列
+	SchemaDiffColumnExtra   = "COLUMN_EXTRA"   // 目标表多出列
+	SchemaDiffColumnChanged = "COLUMN_CHANGED" // 列类型、可空或默认值不一致
+	SchemaDiffIndexMissing  = "INDEX_MISSING"  // 目标表缺少索引
+	SchemaDiffIndexExtra    = "INDEX_EXTRA"    // 目标表多出索引
+	SchemaDiffIndexChanged  = "INDEX_CHANGED"  // 索引唯一性或索引列不一致
+)
+
+// SchemaDifference 两个数据库结构之间的一处差异
+type SchemaDifference struct {
+	Kind   string `json:"kind"`             // 差异类型
+	Table  string `json:"table"`            // 表名
+	Name   string `json:"name,omitempty"`   // 列名或索引名，表级差异为空
+	Source string `json:"source,omitempty"` // 源库中的定义
+	Target string `json:"target,omitempty"` // 目标库中的定义
+}
+
+// primaryIndexKey 比对时主键索引使用的名称，不同环境中主键约束名可能不同
+const primaryIndexKey = "<PRIMARY>"
+
+// DiffSchema 比对两个数据库结构，返回目标库相对源库的差异
+// 表名、列名和索引名不区分大小写，列类型比对不区分大小写；
+// 不同驱动的类型名称和默认值表达式不同，只应比对同一驱动的数据库
+// 参数:
+//
+//	source: 源库结构（如预发布环境）
+//	target: 目标库结构（如生产环境）
+//
+// 返回:
+//
+//	[]SchemaDifference: 差异列表，按表名排序，没有差异时为空
+func DiffSchema(source, target *SchemaInfo) []SchemaDifference {
+	diffs := []SchemaDifference{}
+
+	names := make(map[string]string)
+	for _, t := range source.Tables {
+		names[strings.ToLower(t.Name)] = t.Name
+	}
+	for _, t := range target.Tables {
+		if _, ok := names[strings.ToLower(t.Name)]; !ok {
+			names[strings.ToLower(t.Name)] = t.Name
+		}
+	}
+	keys := make([]string, 0, len(names))
+	for key := range names {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := names[key]
+		sourceTable, targetTable := source.Table(name), target.Table(name)
+		switch {
+		case targetTable == nil:
+			diffs = append(diffs, SchemaDifference{Kind: SchemaDiffTableMissing, Table: name})
+		case sourceTable == nil:
+			diffs = append(diffs, SchemaDifference{Kind: SchemaDiffTableExtra, Table: name})
+		default:
+			diffs = append(diffs, diffColumns(name, sourceTable, targetTable)...)
+			diffs = append(diffs, diffIndexes(name, sourceTable, targetTable)...)
+		}
+	}
+	return diffs
+}
+
+// diffColumns 比对表的列
+func diffColumns(table string, source, target *TableInfo) []SchemaDifference {
+	var diffs []SchemaDifference
+	for _, column := range source.Columns {
+		other := target.Column(column.Name)
+		if other == nil {
+			diffs = append(diffs, SchemaDifference{Kind: SchemaDiffColumnMissing, Table: table, Name: column.Name, Source: describeColumn(column)})
+			continue
+		}
+		if describeColumn(column) != describeColumn(*other) {
+			diffs = append(diffs, SchemaDifference{Kind: SchemaDiffColumnChanged, Table: table, Name: column.Name, Source: describeColumn(column), Target: describeColumn(*other)})
+		}
+	}
+	for _, column := range target.Columns {
+		if source.Column(column.Name) == nil {
+			diffs = append(diffs, SchemaDifference{Kind: SchemaDiffColumnExtra, Table: table, Name: column.Name, Target: describeColumn(column)})
+		}
+	}
+	return diffs
+}
+
+// diffIndexes 比对表的索引，主键索引按主键比对，不比对名称
+func diffIndexes(table string, source, target *TableInfo) []SchemaDifference {
+	sourceIndexes, targetIndexes := indexesByKey(source), indexesByKey(target)
+
+	var diffs []SchemaDifference
+	for _, index := range source.Indexes {
+		key := indexKey(index)
+		other, ok := targetIndexes[key]
+		if !ok {
+			diffs = append(diffs, SchemaDifference{Kind: SchemaDiffIndexMissing, Table: table, Name: index.Name, Source: describeIndex(index)})
+			continue
+		}
+		if describeIndex(index) != describeIndex(other) {
+			diffs = append(diffs, SchemaDifference{Kind: SchemaDiffIndexChanged, Table: table, Name: index.Name, Source: describeIndex(index), Target: describeIndex(other)})
+		}
+	}
+	for _, index := range target.Indexes {
+		if _, ok := sourceIndexes[indexKey(index)]; !ok {
+			diffs = append(diffs, SchemaDifference{Kind: SchemaDiffIndexExtra, Table: table, Name: index.Name, Target: describeIndex(index)})
+		}
+	}
+	return diffs
+}
+
+// indexesByKey 按比对键索引表的索引
+func indexesByKey(table *TableInfo) map[string]IndexInfo {
+	indexes := make(map[string]IndexInfo, len(table.Indexes))
+	for _, index := range table.Indexes {
+		indexes[indexKey(index)] = index
+	}
+	return indexes
+}
+
+// indexKey 索引比对键
+func indexKey(index IndexInfo) string {
+	if index.Primary {
+		return primaryIndexKey
+	}
+	return strings.ToLower(index.Name)
+}
+
+// describeColumn 列定义的比对描述
+func describeColumn(column ColumnInfo) string {
+	desc := strings.ToLower(column.DataType)
+	if column.Nullable {
+		desc += " NULL"
+	} else {
+		desc += " NOT NULL"
+	}
+	if column.Default != nil {
+		desc += " DEFAULT " + *column.Default
+	}
+	return desc
+}
+
+// describeIndex 索引定义的比对描述
+func describeIndex(index IndexInfo) string {
+	kind := "INDEX"
+	switch {
+	case index.Primary:
+		kind = "PRIMARY KEY"
+	case index.Unique:
+		kind = "UNIQUE"
+	}
+	return kind + " (" + strings.ToLower(strings.Join(index.Columns, ", ")) + ")"
+}
@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gateway/pkg/database"
+)
+
+// Schema 获取当前数据库的结构
+// 从 sqlite_master 读取表，通过 pragma_table_info、pragma_index_list 和 pragma_index_info 读取列和索引，
+// 不包含 SQLite 内部表；INTEGER PRIMARY KEY 列没有单独的索引，只标记为主键列
+// 参数:
+//
+//	ctx: 上下文，用于控制请求超时和取消
+//	options: 查询选项，为nil时返回全部表
+//
+// 返回:
+//
+//	*database.SchemaInfo: 数据库结构
+//	error: 查询失败时返回错误信息
+func (s *SQLite) Schema(ctx context.Context, options *database.SchemaOptions) (*database.SchemaInfo, error) {
+	builder := database.NewSchemaBuilder(s.GetDriver(), "main", options)
+
+	var tables []string
+	err := database.QuerySchemaRows(ctx, s.db, `
+		SELECT name FROM sqlite_master
+		WHERE type = 'table' AND name NOT LIKE 'sqlite_%'
+		ORDER BY name`, nil, func(rows *sql.Rows) error {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if builder.Includes(name) {
+			tables = append(tables, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("查询表信息失败: %w", err)
+	}
+
+	for _, table := range tables {
+		builder.AddTable(table, "")
+		if err := s.loadTableSchema(ctx, builder, table); err != nil {
+			return nil, fmt.Errorf("查询表 %s 结构失败: %w", table, err)
+		}
+	}
+
+	return builder.Build(), nil
+}
+
+// loadTableSchema 读取单个表的列和索引
+func (s *SQLite) loadTableSchema(ctx context.Context, builder *database.SchemaBuilder, table string) error {
+	var primaryKey []string
+	err := database.QuerySchemaRows(ctx, s.db, `SELECT cid, name, type, "notnull", dflt_value, pk FROM pragma_table_info(?)`, []interface{}{table}, func(rows *sql.Rows) error {
+		var (
+			cid, notNull, pk int
+			name, dataType   string
+			defaultValue     sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &dataType, &notNull, &defaultValue, &pk); err != nil {
+			return err
+		}
+		builder.AddColumn(table, database.ColumnInfo{
+			Name:       name,
+			DataType:   dataType,
+			Nullable:   notNull == 0 && pk == 0,
+			Default:    database.NullStringPtr(defaultValue),
+			PrimaryKey: pk > 0,
+			Position:   cid + 1,
+		})
+		if pk > 0 {
+			primaryKey = append(primaryKey, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	type indexMeta struct {
+		name    string
+		unique  bool
+		primary bool
+	}
+	var indexes []indexMeta
+	err = database.QuerySchemaRows(ctx, s.db, `SELECT name, "unique", origin FROM pragma_index_list(?)`, []interface{}{table}, func(rows *sql.Rows) error {
+		var (
+			name, origin string
+			unique       int
+		)
+		if err := rows.Scan(&name, &unique, &origin); err != nil {
+			return err
+		}
+		indexes = append(indexes, indexMeta{name: name, unique: unique == 1, primary: origin == "pk"})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	hasPrimaryIndex := false
+	for _, index := range indexes {
+		hasPrimaryIndex = hasPrimaryIndex || index.primary
+		err = database.QuerySchemaRows(ctx, s.db, `SELECT name FROM pragma_index_info(?) ORDER BY seqno`, []interface{}{index.name}, func(rows *sql.Rows) error {
+			var column sql.NullString
+			if err := rows.Scan(&column); err != nil {
+				return err
+			}
+			builder.AddIndexColumn(table, index.name, index.unique, index.primary, column.String)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// rowid 表的 INTEGER PRIMARY KEY 没有索引，按主键列补充主键索引，便于与其他环境比对
+	if !hasPrimaryIndex && len(primaryKey) > 0 {
+		name := "pk_" + strings.ToLower(table)
+		for _, column := range primaryKey {
+			builder.AddIndexColumn(table, name, true, true, column)
+		}
+	}
+	return nil
+}
@@ -137,6 +137,7 @@ func (s *SQLite) Connect(config *database.DbConfig) error {
 	}
 
 	s.db = db
+	s.logger.SetExplainer(s.explainQuery)
 	s.logger.LogConnected(context.Background(), database.DriverSQLite, map[string]any{
 		"maxOpenConns":    maxOpenConns,
 		"maxIdleConns":    maxIdleConns,
@@ -179,6 +180,11 @@ func (s *SQLite) configureDatabase(db *sql.DB) error {
 	return nil
 }
 
+// explainQuery 获取慢查询的执行计划（EXPLAIN QUERY PLAN）
+func (s *SQLite) explainQuery(ctx context.Context, query string, args []any) (string, error) {
+	return dblogger.ExplainRows(ctx, s.db, "EXPLAIN QUERY PLAN "+query, args...)
+}
+
 // Close 关闭数据库连接
 // 关闭SQLite数据库连接，释放相关资源
 // 返回:
@@ -503,10 +509,13 @@ func (s *SQLite) Exec(ctx context.Context, query string, args []interface{}, aut
 //
 //	error: 查询失败或扫描失败时返回错误信息
 func (s *SQLite) Query(ctx context.Context, dest interface{}, query string, args []interface{}, autoCommit bool) error {
+	// 与写入时的格式一致，时间条件才能按字符串正确比较
+	convertedArgs := s.convertTimeArgs(args)
+
 	executor := s.getExecutor(ctx, autoCommit)
 
 	start := time.Now()
-	rows, err := executor.QueryContext(ctx, query, args...)
+	rows, err := executor.QueryContext(ctx, query, convertedArgs...)
 	duration := time.Since(start)
 
 	if err != nil {
@@ -555,10 +564,13 @@ func (s *SQLite) Query(ctx context.Context, dest interface{}, query string, args
 //
 //	error: 查询失败、扫描失败或记录不存在时返回错误信息
 func (s *SQLite) QueryOne(ctx context.Context, dest interface{}, query string, args []interface{}, autoCommit bool) error {
+	// 与写入时的格式一致，时间条件才能按字符串正确比较
+	convertedArgs := s.convertTimeArgs(args)
+
 	executor := s.getExecutor(ctx, autoCommit)
 
 	start := time.Now()
-	rows, err := executor.QueryContext(ctx, query, args...)
+	rows, err := executor.QueryContext(ctx, query, convertedArgs...)
 	duration := time.Since(start)
 
 	if err != nil {
@@ -718,7 +730,7 @@ func (s *SQLite) Delete(ctx context.Context, table string, where string, args []
 	executor := s.getExecutor(ctx, autoCommit)
 
 	start := time.Now()
-	result, err := executor.ExecContext(ctx, query, args...)
+	result, err := executor.ExecContext(ctx, query, s.convertTimeArgs(args)...)
 	duration := time.Since(start)
 
 	var rowsAffected int64
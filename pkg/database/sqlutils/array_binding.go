@@ -0,0 +1,176 @@
+package sqlutils
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// arrayColumnKind 数组绑定的列类型
+type arrayColumnKind int
+
+const (
+	arrayKindUnknown arrayColumnKind = iota // 整列都是NULL，按字符串绑定
+	arrayKindString
+	arrayKindInt
+	arrayKindFloat
+	arrayKindTime
+	arrayKindBytes
+)
+
+// BuildColumnArrays 将按行组织的参数转置为按列组织的类型化切片，用于数组绑定（array DML）
+// 支持数组绑定的驱动（如 godror）在参数全部为同长度切片时，一次往返执行多行 DML，
+// 切片元素必须是驱动可识别的具体类型，因此每列按第一个非NULL值确定类型：
+//   - 字符串 -> []string（NULL 绑定为空字符串，Oracle 中空字符串即 NULL）
+//   - 整数 -> []sql.NullInt64
+//   - 浮点数 -> []sql.NullFloat64
+//   - 时间 -> []sql.NullTime
+//   - []byte -> [][]byte
+//
+// 值为指针或实现 driver.Valuer 时先取实际值；同一列出现不同类型或不支持的类型（如 bool）时返回错误，
+// 调用方应回退为逐行执行
+//
+// 参数:
+//
+//	rows: 每行的参数值，所有行的参数个数必须相同
+//
+// 返回:
+//
+//	[]interface{}: 每列一个类型化切片，按列顺序排列
+//	error: 行参数个数不一致或存在无法数组绑定的值时返回错误
+func BuildColumnArrays(rows [][]interface{}) ([]interface{}, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	columnCount := len(rows[0])
+
+	// 先取出每个值的实际值并确定每列的类型
+	normalized := make([][]interface{}, len(rows))
+	kinds := make([]arrayColumnKind, columnCount)
+	for i, row := range rows {
+		if len(row) != columnCount {
+			return nil, fmt.Errorf("row %d has %d values, expected %d", i, len(row), columnCount)
+		}
+		normalized[i] = make([]interface{}, columnCount)
+		for j, value := range row {
+			v, kind, err := normalizeArrayValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("row %d column %d: %w", i, j, err)
+			}
+			normalized[i][j] = v
+			if kind == arrayKindUnknown {
+				continue
+			}
+			if kinds[j] == arrayKindUnknown {
+				kinds[j] = kind
+			} else if kinds[j] != kind {
+				return nil, fmt.Errorf("row %d column %d: mixed value types in one column", i, j)
+			}
+		}
+	}
+
+	arrays := make([]interface{}, columnCount)
+	for j, kind := range kinds {
+		switch kind {
+		case arrayKindInt:
+			values := make([]sql.NullInt64, len(rows))
+			for i := range normalized {
+				if v, ok := normalized[i][j].(int64); ok {
+					values[i] = sql.NullInt64{Int64: v, Valid: true}
+				}
+			}
+			arrays[j] = values
+		case arrayKindFloat:
+			values := make([]sql.NullFloat64, len(rows))
+			for i := range normalized {
+				if v, ok := normalized[i][j].(float64); ok {
+					values[i] = sql.NullFloat64{Float64: v, Valid: true}
+				}
+			}
+			arrays[j] = values
+		case arrayKindTime:
+			values := make([]sql.NullTime, len(rows))
+			for i := range normalized {
+				if v, ok := normalized[i][j].(time.Time); ok {
+					values[i] = sql.NullTime{Time: v, Valid: true}
+				}
+			}
+			arrays[j] = values
+		case arrayKindBytes:
+			values := make([][]byte, len(rows))
+			for i := range normalized {
+				if v, ok := normalized[i][j].([]byte); ok {
+					values[i] = v
+				}
+			}
+			arrays[j] = values
+		default:
+			values := make([]string, len(rows))
+			for i := range normalized {
+				if v, ok := normalized[i][j].(string); ok {
+					values[i] = v
+				}
+			}
+			arrays[j] = values
+		}
+	}
+	return arrays, nil
+}
+
+// normalizeArrayValue 取出参数的实际值并转换为数组绑定使用的基础类型
+// 返回的值为 nil、string、int64、float64、time.Time 或 []byte
+func normalizeArrayValue(value interface{}) (interface{}, arrayColumnKind, error) {
+	if valuer, ok := value.(driver.Valuer); ok {
+		rv := reflect.ValueOf(value)
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil, arrayKindUnknown, nil
+		}
+		v, err := valuer.Value()
+		if err != nil {
+			return nil, arrayKindUnknown, err
+		}
+		value = v
+	}
+	if value == nil {
+		return nil, arrayKindUnknown, nil
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		if v.IsZero() {
+			return nil, arrayKindUnknown, nil
+		}
+		return v, arrayKindTime, nil
+	case []byte:
+		if v == nil {
+			return nil, arrayKindUnknown, nil
+		}
+		return v, arrayKindBytes, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, arrayKindUnknown, nil
+		}
+		return normalizeArrayValue(rv.Elem().Interface())
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), arrayKindString, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), arrayKindInt, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+		if u > 1<<63-1 {
+			return nil, arrayKindUnknown, fmt.Errorf("unsigned value %d overflows int64", u)
+		}
+		return int64(u), arrayKindInt, nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), arrayKindFloat, nil
+	}
+	return nil, arrayKindUnknown, fmt.Errorf("unsupported type %T for array binding", value)
+}
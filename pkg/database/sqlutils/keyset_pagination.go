@@ -0,0 +1,286 @@
+package sqlutils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// KeysetColumn 游标分页的排序列
+type KeysetColumn struct {
+	Name string // 列名
+	Desc bool   // 是否降序
+}
+
+// KeysetPaginationInfo 游标（keyset）分页信息
+// 与 PaginationInfo 的偏移量分页不同，游标分页以上一页最后一条记录的排序列值作为查询条件，
+// 翻页开销与页码无关，适用于访问日志等数据量很大、按时间倒序浏览的表
+type KeysetPaginationInfo struct {
+	Columns  []KeysetColumn // 排序列，最后一列需能唯一确定记录（如主键），保证翻页不重复不遗漏
+	PageSize int            // 每页大小
+	After    []interface{}  // 上一页最后一条记录的排序列值，为空表示第一页
+}
+
+// NewKeysetPaginationInfo 创建游标分页信息
+//
+// 参数:
+//
+//	cursor: 上一页返回的游标，为空表示第一页
+//	pageSize: 每页大小，如果小于1则设为10
+//	columns: 排序列
+//
+// 返回:
+//
+//	*KeysetPaginationInfo: 游标分页信息
+//	error: 游标格式错误或与排序列数量不一致时返回错误
+func NewKeysetPaginationInfo(cursor string, pageSize int, columns ...KeysetColumn) (*KeysetPaginationInfo, error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("keyset pagination requires at least one column")
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	info := &KeysetPaginationInfo{Columns: columns, PageSize: pageSize}
+	if cursor == "" {
+		return info, nil
+	}
+
+	values, err := DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(columns) {
+		return nil, fmt.Errorf("invalid cursor: expected %d values, got %d", len(columns), len(values))
+	}
+	info.After = values
+	return info, nil
+}
+
+// Trim 计算本页实际返回的记录数
+// BuildKeysetQuery 生成的查询多取一条记录用于判断是否还有下一页
+//
+// 参数:
+//
+//	fetched: 查询返回的记录数
+//
+// 返回:
+//
+//	int: 本页应返回的记录数
+//	bool: 是否还有下一页
+func (k *KeysetPaginationInfo) Trim(fetched int) (int, bool) {
+	if fetched > k.PageSize {
+		return k.PageSize, true
+	}
+	return fetched, false
+}
+
+// BuildKeysetQuery 构建游标分页查询SQL语句
+// 在查询条件后追加游标条件和排序，并按数据库类型限制返回记录数（多取一条用于判断是否还有下一页）
+//
+// 游标条件展开为 OR 形式，例如按 (time DESC, id DESC) 排序时生成：
+//
+//	time <= ? AND (time < ? OR (time = ? AND id < ?))
+//
+// 首列的范围条件使数据库可以直接使用排序列上的索引定位起始位置
+//
+// 参数:
+//
+//	dbType: 数据库类型
+//	selectFrom: 查询语句的 SELECT ... FROM ... 部分，不包含 WHERE、ORDER BY 和分页
+//	where: 查询条件，可以以 WHERE 开头，也可以为空
+//	args: 查询条件的参数
+//	keyset: 游标分页信息
+//
+// 返回:
+//
+//	string: 游标分页查询语句
+//	[]interface{}: 全部参数（查询条件参数、游标参数和分页参数）
+//	error: 构建失败时返回错误信息
+//
+// 使用示例:
+//
+//	keyset, _ := NewKeysetPaginationInfo(cursor, 20, KeysetColumn{Name: "addTime", Desc: true}, KeysetColumn{Name: "traceId", Desc: true})
+//	query, args, err := BuildKeysetQuery(DatabaseMySQL, "SELECT * FROM HUB_GW_ACCESS_LOG", "WHERE tenantId = ?", []interface{}{tenantId}, keyset)
+//	// MySQL: "SELECT * FROM HUB_GW_ACCESS_LOG WHERE tenantId = ? ORDER BY addTime DESC, traceId DESC LIMIT ?"
+func BuildKeysetQuery(dbType DatabaseType, selectFrom, where string, args []interface{}, keyset *KeysetPaginationInfo) (string, []interface{}, error) {
+	if keyset == nil || len(keyset.Columns) == 0 {
+		return "", nil, fmt.Errorf("keyset pagination info is required")
+	}
+
+	conditions := strings.TrimSpace(where)
+	if len(conditions) >= 5 && strings.EqualFold(conditions[:5], "WHERE") {
+		conditions = strings.TrimSpace(conditions[5:])
+	}
+	allArgs := append([]interface{}{}, args...)
+
+	if len(keyset.After) > 0 {
+		if len(keyset.After) != len(keyset.Columns) {
+			return "", nil, fmt.Errorf("cursor values do not match keyset columns")
+		}
+		predicate, predicateArgs := buildKeysetPredicate(keyset.Columns, keyset.After)
+		if conditions == "" {
+			conditions = predicate
+		} else {
+			conditions = "(" + conditions + ") AND " + predicate
+		}
+		allArgs = append(allArgs, predicateArgs...)
+	}
+
+	orderParts := make([]string, len(keyset.Columns))
+	for i, col := range keyset.Columns {
+		orderParts[i] = col.Name + " ASC"
+		if col.Desc {
+			orderParts[i] = col.Name + " DESC"
+		}
+	}
+
+	query := strings.TrimSpace(selectFrom)
+	if conditions != "" {
+		query += " WHERE " + conditions
+	}
+	query += " ORDER BY " + strings.Join(orderParts, ", ")
+
+	// 多取一条记录，用于判断是否还有下一页
+	limit := keyset.PageSize + 1
+
+	switch dbType {
+	case DatabaseMySQL, DatabaseMariaDB, DatabaseTiDB, DatabaseSQLite, DatabasePostgreSQL, DatabaseClickHouse:
+		query += " LIMIT ?"
+	case DatabaseSQLServer:
+		query += " OFFSET 0 ROWS FETCH NEXT ? ROWS ONLY"
+	case DatabaseOracle:
+		query += " FETCH FIRST ? ROWS ONLY"
+	case DatabaseOracle11g:
+		// Oracle 11g 不支持 FETCH FIRST，排序后在外层使用 ROWNUM 限制
+		query = fmt.Sprintf("SELECT * FROM (%s) WHERE ROWNUM <= ?", query)
+	case DatabaseMongoDB:
+		return "", nil, fmt.Errorf("MongoDB does not support SQL pagination, use MongoDB-specific methods")
+	default:
+		return "", nil, fmt.Errorf("unsupported database type: %s", dbType)
+	}
+
+	return query, append(allArgs, limit), nil
+}
+
+// buildKeysetPredicate 构建“排在游标记录之后”的条件
+func buildKeysetPredicate(columns []KeysetColumn, values []interface{}) (string, []interface{}) {
+	var args []interface{}
+	branches := make([]string, 0, len(columns))
+	for i, col := range columns {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, columns[j].Name+" = ?")
+			args = append(args, values[j])
+		}
+		parts = append(parts, col.Name+" "+keysetOperator(col, false)+" ?")
+		args = append(args, values[i])
+
+		branch := strings.Join(parts, " AND ")
+		if len(parts) > 1 {
+			branch = "(" + branch + ")"
+		}
+		branches = append(branches, branch)
+	}
+
+	if len(columns) == 1 {
+		return branches[0], args
+	}
+
+	first := columns[0]
+	predicate := first.Name + " " + keysetOperator(first, true) + " ? AND (" + strings.Join(branches, " OR ") + ")"
+	return predicate, append([]interface{}{values[0]}, args...)
+}
+
+// keysetOperator 排序列的比较运算符，inclusive 为 true 时包含等于
+func keysetOperator(col KeysetColumn, inclusive bool) string {
+	op := ">"
+	if col.Desc {
+		op = "<"
+	}
+	if inclusive {
+		op += "="
+	}
+	return op
+}
+
+// cursorValue 游标中单个排序列的值，按类型分别保存，解码后还原为原类型
+type cursorValue struct {
+	Time   *time.Time `json:"t,omitempty"`
+	String *string    `json:"s,omitempty"`
+	Int    *int64     `json:"i,omitempty"`
+	Float  *float64   `json:"f,omitempty"`
+}
+
+// EncodeCursor 将排序列的值编码为游标
+// 支持时间、字符串、整数和浮点数类型，游标为 URL 安全的 Base64 字符串
+func EncodeCursor(values ...interface{}) (string, error) {
+	encoded := make([]cursorValue, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case *time.Time:
+			if v == nil {
+				return "", fmt.Errorf("cursor value %d is nil", i)
+			}
+			t := *v
+			encoded[i].Time = &t
+		case time.Time:
+			encoded[i].Time = &v
+		case string:
+			encoded[i].String = &v
+		case int:
+			n := int64(v)
+			encoded[i].Int = &n
+		case int32:
+			n := int64(v)
+			encoded[i].Int = &n
+		case int64:
+			encoded[i].Int = &v
+		case float32:
+			f := float64(v)
+			encoded[i].Float = &f
+		case float64:
+			encoded[i].Float = &v
+		default:
+			return "", fmt.Errorf("unsupported cursor value type %T", value)
+		}
+	}
+
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor 解码游标，返回排序列的值
+func DecodeCursor(cursor string) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var encoded []cursorValue
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	values := make([]interface{}, len(encoded))
+	for i, v := range encoded {
+		switch {
+		case v.Time != nil:
+			values[i] = *v.Time
+		case v.String != nil:
+			values[i] = *v.String
+		case v.Int != nil:
+			values[i] = *v.Int
+		case v.Float != nil:
+			values[i] = *v.Float
+		default:
+			return nil, fmt.Errorf("invalid cursor: value %d is empty", i)
+		}
+	}
+	return values, nil
+}
@@ -64,32 +64,28 @@ func analyzeStruct(dest interface{}) (*StructInfo, error) {
 		return nil, fmt.Errorf("dest must be a pointer to struct, got pointer to %s", structValue.Kind())
 	}
 
-	structType := structValue.Type()
+	// 嵌入的结构体字段提升到外层，与直接声明的字段一样映射
+	mappedFields := collectStructFields(structValue, true)
 	info := &StructInfo{
-		fields:        make([]FieldInfo, 0, structValue.NumField()),
+		fields:        make([]FieldInfo, 0, len(mappedFields)),
 		fieldMap:      make(map[string]*FieldInfo),
 		fieldMapLower: make(map[string]*FieldInfo),
 		value:         structValue,
 	}
 
-	for i := 0; i < structValue.NumField(); i++ {
-		field := structValue.Field(i)
-		structField := structType.Field(i)
+	for i, mapped := range mappedFields {
+		field := mapped.value
 
 		// 跳过不可设置的字段（私有字段等）
 		if !field.CanSet() {
 			continue
 		}
 
-		dbTag := structField.Tag.Get("db")
-		if dbTag == "-" {
-			continue // 跳过显式忽略的字段
-		}
-
 		// 强制要求db tag，避免字段名匹配的歧义和性能问题
 		// 这确保了精确的列到字段的映射，特别适用于Oracle等数据库
+		dbTag := mapped.dbName
 		if dbTag == "" {
-			return nil, fmt.Errorf("field '%s' missing required 'db' tag for precise column mapping", structField.Name)
+			return nil, fmt.Errorf("field '%s' missing required 'db' tag for precise column mapping", mapped.name)
 		}
 
 		fieldInfo := FieldInfo{
@@ -158,6 +154,11 @@ func (fm *FieldMapper) MapValues(values []interface{}) error {
 
 // setFieldValue 设置字段值
 func (fm *FieldMapper) setFieldValue(fieldInfo *FieldInfo, value interface{}) error {
+	// 字段实现了 sql.Scanner 时由字段自身解析（包括NULL值）
+	if handled, err := scanIntoField(fieldInfo.field, value); handled {
+		return err
+	}
+
 	if value == nil {
 		if fieldInfo.field.Kind() == reflect.Ptr {
 			fieldInfo.field.Set(reflect.Zero(fieldInfo.field.Type()))
@@ -357,25 +358,13 @@ func (fm *FieldMapper) convertValue(field reflect.Value, value interface{}) erro
 		// 这主要用于 SQLite 数据库，因为 SQLite 将日期时间存储为 TEXT，返回字符串
 		if fieldType == reflect.TypeOf(time.Time{}) {
 			if v != "" {
-				// 尝试多种时间格式解析
-				timeFormats := []string{
-					"2006-01-02 15:04:05",
-					"2006-01-02T15:04:05",
-					time.RFC3339,
-					time.RFC3339Nano,
-					"2006-01-02",
-				}
-				var parsedTime time.Time
-				var err error
-				for _, format := range timeFormats {
-					parsedTime, err = time.Parse(format, v)
-					if err == nil {
-						field.Set(reflect.ValueOf(parsedTime))
-						return nil
-					}
+				// 按配置的时间格式解析，所有格式都解析失败时返回错误
+				parsedTime, err := ParseTimeString(v)
+				if err != nil {
+					return err
 				}
-				// 如果所有格式都解析失败，返回错误
-				return fmt.Errorf("cannot parse time string %q: %w", v, err)
+				field.Set(reflect.ValueOf(parsedTime))
+				return nil
 			}
 			// 空字符串设置为零值
 			field.Set(reflect.ValueOf(time.Time{}))
@@ -384,24 +373,12 @@ func (fm *FieldMapper) convertValue(field reflect.Value, value interface{}) erro
 		// 处理指针类型的时间字段
 		if fieldType == reflect.TypeOf(&time.Time{}) {
 			if v != "" {
-				timeFormats := []string{
-					"2006-01-02 15:04:05",
-					"2006-01-02T15:04:05",
-					time.RFC3339,
-					time.RFC3339Nano,
-					"2006-01-02",
-				}
-				var parsedTime time.Time
-				var err error
-				for _, format := range timeFormats {
-					parsedTime, err = time.Parse(format, v)
-					if err == nil {
-						field.Set(reflect.ValueOf(&parsedTime))
-						return nil
-					}
+				parsedTime, err := ParseTimeString(v)
+				if err != nil {
+					return err
 				}
-				// 如果所有格式都解析失败，返回错误
-				return fmt.Errorf("cannot parse time string %q: %w", v, err)
+				field.Set(reflect.ValueOf(&parsedTime))
+				return nil
 			}
 			// 空字符串设置为 nil
 			field.Set(reflect.ValueOf((*time.Time)(nil)))
@@ -417,6 +394,16 @@ func (fm *FieldMapper) convertValue(field reflect.Value, value interface{}) erro
 			field.Set(reflect.ValueOf(&v))
 			return nil
 		}
+		// 字符串字段按配置的时间格式格式化
+		if fieldType.Kind() == reflect.String {
+			field.SetString(FormatTime(v))
+			return nil
+		}
+		if fieldType.Kind() == reflect.Ptr && fieldType.Elem().Kind() == reflect.String {
+			strValue := FormatTime(v)
+			field.Set(reflect.ValueOf(&strValue))
+			return nil
+		}
 	case bool:
 		if fieldType.Kind() == reflect.Bool {
 			field.SetBool(v)
@@ -467,7 +454,7 @@ func ExtractValues(values []interface{}) []interface{} {
 // 参数:
 //
 //	rows: SQL查询返回的行结果集
-//	dest: 目标切片的指针，元素类型应为结构体或结构体指针
+//	dest: 目标切片的指针，元素类型应为结构体、结构体指针或 map[string]interface{}（动态结果）
 //
 // 返回:
 //
@@ -489,6 +476,19 @@ func ScanRows(rows *sql.Rows, dest interface{}) error {
 	}
 
 	elementType := sliceValue.Type().Elem()
+
+	// 动态结果：每行扫描为一个map
+	if elementType == reflect.TypeOf(map[string]interface{}{}) {
+		maps, err := ScanRowsToMaps(rows)
+		if err != nil {
+			return err
+		}
+		for _, m := range maps {
+			sliceValue.Set(reflect.Append(sliceValue, reflect.ValueOf(m)))
+		}
+		return nil
+	}
+
 	isPtr := elementType.Kind() == reflect.Ptr
 	if isPtr {
 		elementType = elementType.Elem()
@@ -635,22 +635,15 @@ func ScanRow(row *sql.Row, dest interface{}) error {
 
 	// 由于sql.Row没有Columns方法，这里使用传统的按字段顺序扫描
 	// 这是QueryOne方法的限制，建议在可能的情况下使用Query方法
-	structType := structValue.Type()
 	var scanTargets []interface{}
 	var fields []reflect.Value
 
-	for i := 0; i < structValue.NumField(); i++ {
-		field := structValue.Field(i)
+	for _, mapped := range collectStructFields(structValue, true) {
+		field := mapped.value
 		if !field.CanSet() {
 			continue
 		}
 
-		structField := structType.Field(i)
-		dbTag := structField.Tag.Get("db")
-		if dbTag == "-" {
-			continue
-		}
-
 		// 创建NULL值安全的扫描目标
 		scanTarget := CreateNullSafeScanTarget(field)
 		scanTargets = append(scanTargets, scanTarget)
@@ -735,20 +728,18 @@ func PrepareScanTargetsWithFields(structValue reflect.Value, columns []string) (
 //	reflect.Value: 找到的字段反射值
 //	bool: 是否找到匹配的字段
 func FindFieldByColumn(structValue reflect.Value, column string) (reflect.Value, bool) {
-	structType := structValue.Type()
 	columnLower := strings.ToLower(column)
 
 	// 用于存储大小写不敏感匹配的结果（优先级较低）
 	var caseInsensitiveMatch reflect.Value
 
-	// 单次遍历，按优先级查找匹配
-	for i := 0; i < structValue.NumField(); i++ {
-		field := structValue.Field(i)
-		structField := structType.Field(i)
+	// 单次遍历，按优先级查找匹配（嵌入结构体的字段已提升到外层）
+	for _, mapped := range collectStructFields(structValue, true) {
+		field := mapped.value
 
-		dbTag := structField.Tag.Get("db")
-		if dbTag == "" || dbTag == "-" {
-			continue // 跳过没有db tag或忽略的字段
+		dbTag := mapped.dbName
+		if dbTag == "" {
+			continue // 跳过没有db tag的字段
 		}
 
 		// 优先级1：db tag精确匹配（立即返回）
@@ -791,9 +782,17 @@ func FindFieldByColumn(structValue reflect.Value, column string) (reflect.Value,
 func CreateNullSafeScanTarget(field reflect.Value) interface{} {
 	fieldType := field.Type()
 
+	// 实现了 sql.Scanner 的字段由字段自身解析
+	if scanner, ok := fieldScanner(field); ok {
+		return scanner
+	}
+	if isScannerPointer(fieldType) {
+		return &pointerScanTarget{elem: reflect.New(fieldType.Elem())}
+	}
+
 	switch fieldType.Kind() {
 	case reflect.String:
-		return &sql.NullString{}
+		return &timeFormattedString{}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return &sql.NullInt64{}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
@@ -807,7 +806,7 @@ func CreateNullSafeScanTarget(field reflect.Value) interface{} {
 		elemType := fieldType.Elem()
 		switch elemType.Kind() {
 		case reflect.String:
-			return &sql.NullString{}
+			return &timeFormattedString{}
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 			return &sql.NullInt64{}
 		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
@@ -869,57 +868,37 @@ func ProcessScannedValues(scanTargets []interface{}, fields []reflect.Value) err
 			continue
 		}
 
+		// 字符串字段的扫描目标按 sql.NullString 处理
+		if target, ok := scanTarget.(*timeFormattedString); ok {
+			scanTarget = &target.NullString
+		}
+
 		// 根据扫描目标类型处理值转换
 		switch v := scanTarget.(type) {
+		case *pointerScanTarget:
+			// 实现了 sql.Scanner 的指针字段：NULL 值设置为nil
+			if v.valid {
+				field.Set(v.elem)
+			} else {
+				field.Set(reflect.Zero(field.Type()))
+			}
 		case *sql.NullString:
-			// 特殊处理：如果目标字段是 time.Time 类型，尝试将字符串解析为时间
+			// 特殊处理：如果目标字段是 time.Time 类型，按配置的时间格式将字符串解析为时间
 			// 这主要用于 SQLite 数据库，因为 SQLite 将日期时间存储为 TEXT
 			if field.Type() == reflect.TypeOf(time.Time{}) {
 				if v.Valid && v.String != "" {
-					// 尝试多种时间格式解析
-					timeFormats := []string{
-						"2006-01-02 15:04:05",
-						"2006-01-02T15:04:05",
-						time.RFC3339,
-						time.RFC3339Nano,
-						"2006-01-02",
-					}
-					var parsedTime time.Time
-					var err error
-					for _, format := range timeFormats {
-						parsedTime, err = time.Parse(format, v.String)
-						if err == nil {
-							field.Set(reflect.ValueOf(parsedTime))
-							break
-						}
-					}
-					if err != nil {
-						// 如果所有格式都解析失败，设置为零值
-						field.Set(reflect.ValueOf(time.Time{}))
-					}
+					// 如果所有格式都解析失败，设置为零值
+					parsedTime, _ := ParseTimeString(v.String)
+					field.Set(reflect.ValueOf(parsedTime))
 				} else {
 					field.Set(reflect.ValueOf(time.Time{}))
 				}
 			} else if field.Type() == reflect.TypeOf(&time.Time{}) {
 				// 处理指针类型的时间字段
 				if v.Valid && v.String != "" {
-					timeFormats := []string{
-						"2006-01-02 15:04:05",
-						"2006-01-02T15:04:05",
-						time.RFC3339,
-						time.RFC3339Nano,
-						"2006-01-02",
-					}
-					var parsedTime time.Time
-					var err error
-					for _, format := range timeFormats {
-						parsedTime, err = time.Parse(format, v.String)
-						if err == nil {
-							field.Set(reflect.ValueOf(&parsedTime))
-							break
-						}
-					}
-					if err != nil {
+					if parsedTime, err := ParseTimeString(v.String); err == nil {
+						field.Set(reflect.ValueOf(&parsedTime))
+					} else {
 						field.Set(reflect.ValueOf((*time.Time)(nil)))
 					}
 				} else {
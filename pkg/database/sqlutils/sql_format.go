@@ -236,28 +236,17 @@ func ExtractColumnsAndValues(data interface{}) ([]string, []interface{}, error)
 		return nil, nil, fmt.Errorf("data must be a struct or pointer to struct")
 	}
 
-	t := v.Type()
 	var columns []string
 	var values []interface{}
 
-	for i := 0; i < v.NumField(); i++ {
-		field := v.Field(i)
-		structField := t.Field(i)
-
-		// 跳过未导出的字段
-		if !field.CanInterface() {
-			continue
-		}
+	// 嵌入结构体的字段提升到外层，忽略的字段（db:"-"）和未导出的字段已跳过
+	for _, mapped := range collectStructFields(v, false) {
+		field := mapped.value
 
 		// 获取数据库字段名
-		dbTag := structField.Tag.Get("db")
+		dbTag := mapped.dbName
 		if dbTag == "" {
-			dbTag = strings.ToLower(structField.Name)
-		}
-
-		// 跳过忽略的字段
-		if dbTag == "-" {
-			continue
+			dbTag = strings.ToLower(mapped.name)
 		}
 
 		// 注意：对于数据库插入操作，不应该跳过零值字段
@@ -320,28 +309,17 @@ func ExtractColumnsAndValuesSkipZero(data interface{}) ([]string, []interface{},
 		return nil, nil, fmt.Errorf("data must be a struct or pointer to struct")
 	}
 
-	t := v.Type()
 	var columns []string
 	var values []interface{}
 
-	for i := 0; i < v.NumField(); i++ {
-		field := v.Field(i)
-		structField := t.Field(i)
-
-		// 跳过未导出的字段
-		if !field.CanInterface() {
-			continue
-		}
+	// 嵌入结构体的字段提升到外层，忽略的字段（db:"-"）和未导出的字段已跳过
+	for _, mapped := range collectStructFields(v, false) {
+		field := mapped.value
 
 		// 获取数据库字段名
-		dbTag := structField.Tag.Get("db")
+		dbTag := mapped.dbName
 		if dbTag == "" {
-			dbTag = strings.ToLower(structField.Name)
-		}
-
-		// 跳过忽略的字段
-		if dbTag == "-" {
-			continue
+			dbTag = strings.ToLower(mapped.name)
 		}
 
 		// 跳过零值字段（UPDATE场景）
@@ -0,0 +1,253 @@
+package sqlutils
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 结构体映射使用的常用类型
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// defaultTimeLayouts 字符串与时间互相转换的默认格式，解析时按顺序尝试
+var defaultTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+	time.RFC3339Nano,
+	"2006-01-02",
+}
+
+// timeLayouts 当前使用的时间格式
+var (
+	timeLayoutsMu sync.RWMutex
+	timeLayouts   = defaultTimeLayouts
+)
+
+// SetTimeLayouts 设置字符串与时间互相转换的格式
+// 解析字符串列到时间字段时按顺序尝试每个格式；时间列写入字符串字段时使用第一个格式
+// 参数:
+//
+//	layouts: 时间格式列表，为空时恢复默认格式
+func SetTimeLayouts(layouts ...string) {
+	timeLayoutsMu.Lock()
+	defer timeLayoutsMu.Unlock()
+	if len(layouts) == 0 {
+		timeLayouts = defaultTimeLayouts
+		return
+	}
+	timeLayouts = append([]string(nil), layouts...)
+}
+
+// GetTimeLayouts 获取字符串与时间互相转换的格式
+func GetTimeLayouts() []string {
+	timeLayoutsMu.RLock()
+	defer timeLayoutsMu.RUnlock()
+	return append([]string(nil), timeLayouts...)
+}
+
+// ParseTimeString 按配置的时间格式解析时间字符串
+// 参数:
+//
+//	value: 时间字符串
+//
+// 返回:
+//
+//	time.Time: 解析后的时间
+//	error: 所有格式都解析失败时返回错误
+func ParseTimeString(value string) (time.Time, error) {
+	timeLayoutsMu.RLock()
+	layouts := timeLayouts
+	timeLayoutsMu.RUnlock()
+
+	var err error
+	for _, layout := range layouts {
+		var parsed time.Time
+		if parsed, err = time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse time string %q: %w", value, err)
+}
+
+// FormatTime 按配置的第一个时间格式格式化时间
+func FormatTime(t time.Time) string {
+	timeLayoutsMu.RLock()
+	layout := timeLayouts[0]
+	timeLayoutsMu.RUnlock()
+	return t.Format(layout)
+}
+
+// mappedField 结构体中映射到数据库列的字段
+type mappedField struct {
+	value  reflect.Value // 字段的反射值
+	name   string        // Go字段名
+	dbName string        // db tag，未设置时为空
+}
+
+// collectStructFields 收集结构体中映射到数据库列的字段
+// 匿名嵌入且未设置db tag的结构体（或结构体指针）会展开，其字段提升到外层，
+// 与外层字段的列名相同时外层字段优先；time.Time 和实现了 sql.Scanner/driver.Valuer 的类型作为单个字段
+//
+// 参数:
+//
+//	structValue: 结构体反射值
+//	alloc: 嵌入的结构体指针为nil时是否分配（扫描结果时为true，提取写入值时为false）
+//
+// 返回:
+//
+//	[]mappedField: 映射字段列表，外层字段在前
+func collectStructFields(structValue reflect.Value, alloc bool) []mappedField {
+	fields := make([]mappedField, 0, structValue.NumField())
+	seen := make(map[string]struct{}, structValue.NumField())
+	collectFields(structValue, alloc, &fields, seen)
+	return fields
+}
+
+// collectFields 递归收集字段，先收集本层字段再展开嵌入的结构体
+func collectFields(structValue reflect.Value, alloc bool, fields *[]mappedField, seen map[string]struct{}) {
+	structType := structValue.Type()
+	var embedded []reflect.Value
+
+	for i := 0; i < structValue.NumField(); i++ {
+		field := structValue.Field(i)
+		structField := structType.Field(i)
+		dbTag := structField.Tag.Get("db")
+		if dbTag == "-" {
+			continue
+		}
+
+		if structField.Anonymous && dbTag == "" && isEmbeddedStructType(field.Type()) {
+			// 值为nil且不分配的嵌入结构体指针没有可映射的字段
+			if inner, ok := embeddedStruct(field, alloc); ok {
+				embedded = append(embedded, inner)
+			}
+			continue
+		}
+
+		// 跳过未导出的字段
+		if !structField.IsExported() {
+			continue
+		}
+
+		key := dbTag
+		if key == "" {
+			key = strings.ToLower(structField.Name)
+		}
+		if _, ok := seen[strings.ToLower(key)]; ok {
+			continue
+		}
+		seen[strings.ToLower(key)] = struct{}{}
+		*fields = append(*fields, mappedField{value: field, name: structField.Name, dbName: dbTag})
+	}
+
+	for _, inner := range embedded {
+		collectFields(inner, alloc, fields, seen)
+	}
+}
+
+// isEmbeddedStructType 判断嵌入字段的类型是否需要展开（结构体或结构体指针，且不作为单个列处理）
+func isEmbeddedStructType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && !isColumnType(t)
+}
+
+// embeddedStruct 获取需要展开的嵌入结构体，结构体指针为nil时按 alloc 分配
+func embeddedStruct(field reflect.Value, alloc bool) (reflect.Value, bool) {
+	if field.Kind() != reflect.Ptr {
+		return field, true
+	}
+	if field.IsNil() {
+		if !alloc || !field.CanSet() {
+			return reflect.Value{}, false
+		}
+		field.Set(reflect.New(field.Type().Elem()))
+	}
+	return field.Elem(), true
+}
+
+// isColumnType 判断结构体类型是否作为单个列处理
+func isColumnType(t reflect.Type) bool {
+	return t == timeType ||
+		t.Implements(scannerType) || reflect.PointerTo(t).Implements(scannerType) ||
+		t.Implements(valuerType) || reflect.PointerTo(t).Implements(valuerType)
+}
+
+// fieldScanner 获取字段自身实现的 sql.Scanner（非指针字段）
+func fieldScanner(field reflect.Value) (sql.Scanner, bool) {
+	if field.Kind() == reflect.Ptr || !field.CanAddr() {
+		return nil, false
+	}
+	scanner, ok := field.Addr().Interface().(sql.Scanner)
+	return scanner, ok
+}
+
+// isScannerPointer 判断字段是否为实现了 sql.Scanner 的指针类型
+func isScannerPointer(fieldType reflect.Type) bool {
+	return fieldType.Kind() == reflect.Ptr && fieldType.Implements(scannerType)
+}
+
+// scanIntoField 字段实现了 sql.Scanner 时由字段自身解析值
+// 返回值:
+//
+//	bool: 字段是否实现了 sql.Scanner
+//	error: 解析失败时返回错误信息
+func scanIntoField(field reflect.Value, value interface{}) (bool, error) {
+	if scanner, ok := fieldScanner(field); ok {
+		return true, scanner.Scan(value)
+	}
+	if isScannerPointer(field.Type()) {
+		if value == nil {
+			field.Set(reflect.Zero(field.Type()))
+			return true, nil
+		}
+		elem := reflect.New(field.Type().Elem())
+		if err := elem.Interface().(sql.Scanner).Scan(value); err != nil {
+			return true, err
+		}
+		field.Set(elem)
+		return true, nil
+	}
+	return false, nil
+}
+
+// pointerScanTarget 实现了 sql.Scanner 的指针字段的扫描目标
+// NULL 值时字段设置为nil，否则由新分配的值解析
+type pointerScanTarget struct {
+	elem  reflect.Value
+	valid bool
+}
+
+// Scan 实现 sql.Scanner 接口
+func (p *pointerScanTarget) Scan(value interface{}) error {
+	if value == nil {
+		p.valid = false
+		return nil
+	}
+	p.valid = true
+	return p.elem.Interface().(sql.Scanner).Scan(value)
+}
+
+// timeFormattedString 字符串字段的扫描目标，时间值按配置的第一个时间格式转换为字符串
+type timeFormattedString struct {
+	sql.NullString
+}
+
+// Scan 实现 sql.Scanner 接口
+func (s *timeFormattedString) Scan(value interface{}) error {
+	if t, ok := value.(time.Time); ok {
+		s.String, s.Valid = FormatTime(t), true
+		return nil
+	}
+	return s.NullString.Scan(value)
+}
@@ -0,0 +1,412 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// DefaultTenantColumn 租户隔离默认使用的列名
+const DefaultTenantColumn = "tenantId"
+
+// 租户隔离上下文键
+const (
+	tenantIdKey          = "gateway.database.tenant.id"
+	tenantScopeBypassKey = "gateway.database.tenant.bypass"
+)
+
+// 定义租户隔离错误
+var (
+	// ErrTenantRequired 访问租户隔离表时上下文中没有租户ID
+	ErrTenantRequired = errors.New("tenant id required for tenant-scoped table")
+
+	// ErrTenantMismatch 写入数据的租户ID与上下文中的租户ID不一致
+	ErrTenantMismatch = errors.New("record tenant does not match context tenant")
+
+	// ErrTenantScopeUnsupported SQL语句无法自动附加租户条件
+	ErrTenantScopeUnsupported = errors.New("statement cannot be tenant-scoped automatically")
+)
+
+// WithTenant 为上下文设置租户ID，租户隔离包装器按此租户过滤和写入数据
+// 参数:
+//
+//	ctx: 上下文
+//	tenantId: 租户ID
+//
+// 返回:
+//
+//	context.Context: 携带租户ID的上下文
+func WithTenant(ctx context.Context, tenantId string) context.Context {
+	return context.WithValue(ctx, tenantIdKey, tenantId)
+}
+
+// TenantFromContext 获取上下文中的租户ID
+// 返回:
+//
+//	string: 租户ID
+//	bool: 上下文中是否设置了非空的租户ID
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenantId, ok := ctx.Value(tenantIdKey).(string)
+	return tenantId, ok && tenantId != ""
+}
+
+// WithoutTenantScope 跳过租户隔离，用于需要跨租户访问数据的系统任务（如初始化、定时清理）
+func WithoutTenantScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantScopeBypassKey, true)
+}
+
+// TenantScopedDatabase 行级租户隔离的数据库包装器
+// 核心特性:
+// 1. 按表启用 - 只有通过 RegisterTable 注册的表才会附加租户条件，其他表直接访问
+// 2. 条件附加 - 查询、更新、删除注册表时自动附加 "租户列 = 上下文租户ID" 条件，
+// 原始SQL按语句结构附加到 WHERE/ON 子句，无法安全改写的语句（子查询、UNION等）返回 ErrTenantScopeUnsupported
+// 3. 写入填充 - 插入和更新时租户列为空则填充上下文租户ID，与上下文租户不一致时返回 ErrTenantMismatch
+// 4. 缺少租户 - 上下文中没有租户ID时访问注册表返回 ErrTenantRequired，系统任务通过 WithoutTenantScope 跳过隔离
+//
+// 注意：原始SQL只支持 ? 占位符；与 CachedDatabase 组合使用时租户隔离包装器应位于外层，使缓存键包含租户条件
+type TenantScopedDatabase struct {
+	Database
+
+	mu     sync.RWMutex
+	tables map[string]string // 规范化表名 -> 租户列名
+}
+
+// NewTenantScopedDatabase 创建行级租户隔离的数据库包装器
+// 参数:
+//
+//	db: 被包装的数据库实例
+//	tables: 需要租户隔离的表，使用默认租户列 DefaultTenantColumn
+//
+// 返回:
+//
+//	*TenantScopedDatabase: 数据库包装器，实现 Database 接口
+func NewTenantScopedDatabase(db Database, tables ...string) *TenantScopedDatabase {
+	t := &TenantScopedDatabase{
+		Database: db,
+		tables:   make(map[string]string),
+	}
+	for _, table := range tables {
+		t.RegisterTable(table, "")
+	}
+	return t
+}
+
+// RegisterTable 注册需要租户隔离的表
+// 参数:
+//
+//	table: 表名
+//	column: 租户列名，为空时使用 DefaultTenantColumn
+func (t *TenantScopedDatabase) RegisterTable(table, column string) {
+	names := normalizeTables([]string{table})
+	if len(names) == 0 {
+		return
+	}
+	if column == "" {
+		column = DefaultTenantColumn
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tables[names[0]] = column
+}
+
+// Unwrap 获取被包装的数据库实例
+func (t *TenantScopedDatabase) Unwrap() Database {
+	return t.Database
+}
+
+// tenantColumn 获取表的租户列名，未注册时返回空
+func (t *TenantScopedDatabase) tenantColumn(table string) string {
+	names := normalizeTables([]string{table})
+	if len(names) == 0 {
+		return ""
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tables[names[0]]
+}
+
+// scope 获取表的租户列和上下文租户ID
+// 返回:
+//
+//	string: 租户列名，表未注册或跳过隔离时为空
+//	string: 租户ID
+//	error: 需要隔离但上下文中没有租户ID时返回 ErrTenantRequired
+func (t *TenantScopedDatabase) scope(ctx context.Context, table string) (string, string, error) {
+	column := t.tenantColumn(table)
+	if column == "" {
+		return "", "", nil
+	}
+	if bypass, _ := ctx.Value(tenantScopeBypassKey).(bool); bypass {
+		return "", "", nil
+	}
+	tenantId, ok := TenantFromContext(ctx)
+	if !ok {
+		return "", "", fmt.Errorf("%w: %s", ErrTenantRequired, table)
+	}
+	return column, tenantId, nil
+}
+
+// scopeSQL 为原始SQL语句附加租户条件
+func (t *TenantScopedDatabase) scopeSQL(ctx context.Context, query string, args []interface{}) (string, []interface{}, error) {
+	if bypass, _ := ctx.Value(tenantScopeBypassKey).(bool); bypass {
+		return query, args, nil
+	}
+	return rewriteTenantSQL(query, args, t.tenantColumn, func(table string) (string, error) {
+		tenantId, ok := TenantFromContext(ctx)
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrTenantRequired, table)
+		}
+		return tenantId, nil
+	})
+}
+
+// Query 查询多条记录，查询注册表时附加租户条件
+func (t *TenantScopedDatabase) Query(ctx context.Context, dest interface{}, query string, args []interface{}, autoCommit bool) error {
+	query, args, err := t.scopeSQL(ctx, query, args)
+	if err != nil {
+		return err
+	}
+	return t.Database.Query(ctx, dest, query, args, autoCommit)
+}
+
+// QueryOne 查询单条记录，查询注册表时附加租户条件
+func (t *TenantScopedDatabase) QueryOne(ctx context.Context, dest interface{}, query string, args []interface{}, autoCommit bool) error {
+	query, args, err := t.scopeSQL(ctx, query, args)
+	if err != nil {
+		return err
+	}
+	return t.Database.QueryOne(ctx, dest, query, args, autoCommit)
+}
+
+// Exec 执行SQL语句，更新/删除注册表时附加租户条件，插入注册表时要求指定租户列
+func (t *TenantScopedDatabase) Exec(ctx context.Context, query string, args []interface{}, autoCommit bool) (int64, error) {
+	query, args, err := t.scopeSQL(ctx, query, args)
+	if err != nil {
+		return 0, err
+	}
+	return t.Database.Exec(ctx, query, args, autoCommit)
+}
+
+// Insert 插入记录，注册表的记录填充上下文租户ID
+func (t *TenantScopedDatabase) Insert(ctx context.Context, table string, data interface{}, autoCommit bool) (int64, error) {
+	column, tenantId, err := t.scope(ctx, table)
+	if err != nil {
+		return 0, err
+	}
+	if column != "" {
+		if err := setTenantField(reflect.ValueOf(data), column, tenantId, true, false); err != nil {
+			return 0, err
+		}
+	}
+	return t.Database.Insert(ctx, table, data, autoCommit)
+}
+
+// Update 更新记录，注册表附加租户条件，并禁止修改记录的租户
+func (t *TenantScopedDatabase) Update(ctx context.Context, table string, data interface{}, where string, args []interface{}, autoCommit bool, skipZero bool) (int64, error) {
+	column, tenantId, err := t.scope(ctx, table)
+	if err != nil {
+		return 0, err
+	}
+	if column != "" {
+		// 更新数据可以不包含租户列；包含时填充或校验，避免全量更新把租户列写为空
+		if err := setTenantField(reflect.ValueOf(data), column, tenantId, false, skipZero); err != nil {
+			return 0, err
+		}
+		where, args = scopeWhere(where, args, column, tenantId)
+	}
+	return t.Database.Update(ctx, table, data, where, args, autoCommit, skipZero)
+}
+
+// Delete 删除记录，注册表附加租户条件
+func (t *TenantScopedDatabase) Delete(ctx context.Context, table string, where string, args []interface{}, autoCommit bool) (int64, error) {
+	column, tenantId, err := t.scope(ctx, table)
+	if err != nil {
+		return 0, err
+	}
+	if column != "" {
+		where, args = scopeWhere(where, args, column, tenantId)
+	}
+	return t.Database.Delete(ctx, table, where, args, autoCommit)
+}
+
+// BatchInsert 批量插入记录，注册表的记录填充上下文租户ID
+func (t *TenantScopedDatabase) BatchInsert(ctx context.Context, table string, dataSlice interface{}, autoCommit bool) (int64, error) {
+	column, tenantId, err := t.scope(ctx, table)
+	if err != nil {
+		return 0, err
+	}
+	if column != "" {
+		if err := setTenantFields(dataSlice, column, tenantId); err != nil {
+			return 0, err
+		}
+	}
+	return t.Database.BatchInsert(ctx, table, dataSlice, autoCommit)
+}
+
+// BatchUpdate 批量更新记录，注册表的租户列作为附加的匹配键
+func (t *TenantScopedDatabase) BatchUpdate(ctx context.Context, table string, dataSlice interface{}, keyFields []string, autoCommit bool) (int64, error) {
+	column, tenantId, err := t.scope(ctx, table)
+	if err != nil {
+		return 0, err
+	}
+	if column != "" {
+		if err := setTenantFields(dataSlice, column, tenantId); err != nil {
+			return 0, err
+		}
+		keyFields = withTenantKey(keyFields, column)
+	}
+	return t.Database.BatchUpdate(ctx, table, dataSlice, keyFields, autoCommit)
+}
+
+// BatchDelete 批量删除记录，注册表的租户列作为附加的匹配键
+func (t *TenantScopedDatabase) BatchDelete(ctx context.Context, table string, dataSlice interface{}, keyFields []string, autoCommit bool) (int64, error) {
+	column, tenantId, err := t.scope(ctx, table)
+	if err != nil {
+		return 0, err
+	}
+	if column != "" {
+		if err := setTenantFields(dataSlice, column, tenantId); err != nil {
+			return 0, err
+		}
+		keyFields = withTenantKey(keyFields, column)
+	}
+	return t.Database.BatchDelete(ctx, table, dataSlice, keyFields, autoCommit)
+}
+
+// BatchDeleteByKeys 按主键批量删除记录，注册表改为带租户条件的 IN 删除
+func (t *TenantScopedDatabase) BatchDeleteByKeys(ctx context.Context, table string, keyField string, keys []interface{}, autoCommit bool) (int64, error) {
+	column, tenantId, err := t.scope(ctx, table)
+	if err != nil {
+		return 0, err
+	}
+	if column == "" {
+		return t.Database.BatchDeleteByKeys(ctx, table, keyField, keys, autoCommit)
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	if keyField == "" {
+		return 0, fmt.Errorf("keyField cannot be empty")
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(keys)), ", ")
+	where := fmt.Sprintf("%s IN (%s) AND %s = ?", keyField, placeholders, column)
+	args := append(append(make([]interface{}, 0, len(keys)+1), keys...), tenantId)
+	return t.Database.Delete(ctx, table, where, args, autoCommit)
+}
+
+// scopeWhere 为 WHERE 条件附加租户条件，原条件加括号避免 OR 优先级问题
+func scopeWhere(where string, args []interface{}, column, tenantId string) (string, []interface{}) {
+	condition := column + " = ?"
+	if strings.TrimSpace(where) != "" {
+		condition = "(" + where + ") AND " + condition
+	}
+	return condition, append(append(make([]interface{}, 0, len(args)+1), args...), tenantId)
+}
+
+// withTenantKey 将租户列加入匹配键
+func withTenantKey(keyFields []string, column string) []string {
+	for _, key := range keyFields {
+		if strings.EqualFold(key, column) {
+			return keyFields
+		}
+	}
+	return append(append(make([]string, 0, len(keyFields)+1), keyFields...), column)
+}
+
+// setTenantFields 为切片中的每条记录填充租户ID
+func setTenantFields(dataSlice interface{}, column, tenantId string) error {
+	slice := reflect.ValueOf(dataSlice)
+	if slice.Kind() == reflect.Ptr {
+		slice = slice.Elem()
+	}
+	if slice.Kind() != reflect.Slice {
+		return fmt.Errorf("dataSlice must be a slice")
+	}
+	for i := 0; i < slice.Len(); i++ {
+		if err := setTenantField(slice.Index(i), column, tenantId, true, false); err != nil {
+			return fmt.Errorf("record %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// setTenantField 填充或校验记录的租户列
+// 参数:
+//
+//	v: 结构体或结构体指针
+//	column: 租户列名，按 db tag（未设置时为字段名）不区分大小写匹配，包括嵌入结构体中的字段
+//	tenantId: 上下文租户ID
+//	required: 结构体没有租户列时是否返回错误
+//	allowEmpty: 租户列为空且无法填充时是否允许（跳过零值的更新不会写入租户列）
+//
+// 返回:
+//
+//	error: 租户ID不一致、结构体没有租户列或租户列无法填充时返回错误
+func setTenantField(v reflect.Value, column, tenantId string, required, allowEmpty bool) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return fmt.Errorf("data cannot be nil")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("data must be a struct or pointer to struct")
+	}
+
+	field, ok := findColumnField(v, column)
+	if !ok {
+		if required {
+			return fmt.Errorf("%w: struct %s has no tenant column %s", ErrTenantScopeUnsupported, v.Type().Name(), column)
+		}
+		return nil
+	}
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("tenant column %s must be a string field", column)
+	}
+
+	switch field.String() {
+	case tenantId:
+		return nil
+	case "":
+		if !field.CanSet() {
+			if allowEmpty {
+				return nil
+			}
+			return fmt.Errorf("tenant column %s is empty and cannot be set, pass a pointer", column)
+		}
+		field.SetString(tenantId)
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrTenantMismatch, field.String())
+	}
+}
+
+// findColumnField 按 db tag（未设置时为字段名）不区分大小写查找列对应的字段，嵌入结构体指针为nil时跳过
+func findColumnField(v reflect.Value, column string) (reflect.Value, bool) {
+	var (
+		found reflect.Value
+		depth = -1
+	)
+	for _, sf := range reflect.VisibleFields(v.Type()) {
+		if !sf.IsExported() || (sf.Anonymous && sf.Tag.Get("db") == "") {
+			continue
+		}
+		name := sf.Tag.Get("db")
+		if name == "" {
+			name = sf.Name
+		}
+		if !strings.EqualFold(name, column) || (depth >= 0 && len(sf.Index) >= depth) {
+			continue
+		}
+		field, err := v.FieldByIndexErr(sf.Index)
+		if err != nil {
+			continue
+		}
+		found, depth = field, len(sf.Index)
+	}
+	return found, depth >= 0
+}
@@ -0,0 +1,467 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sqlToken SQL语句的词法单元，字符串常量和注释不产生词法单元
+type sqlToken struct {
+	kind  byte   // 'w' 标识符/关键字，'(' ')' ',' ';' '?' 为对应符号
+	text  string // 原始文本
+	upper string // 大写文本，用于匹配关键字
+	start int    // 起始位置
+	end   int    // 结束位置
+	depth int    // 括号嵌套深度（括号本身记为外层深度）
+}
+
+// tenantTableRef 语句中引用的租户隔离表
+type tenantTableRef struct {
+	table     string // 原始表名
+	alias     string // 别名
+	column    string // 租户列名
+	index     int    // 表名词法单元下标
+	depth     int    // 括号嵌套深度
+	join      bool   // 是否通过 JOIN 引用
+	outerJoin bool   // 是否为 LEFT/RIGHT/FULL JOIN
+}
+
+// tenantSQLEdit 改写SQL时的一次插入
+type tenantSQLEdit struct {
+	pos      int           // 插入位置
+	text     string        // 插入文本
+	argIndex int           // 参数插入位置（之前的 ? 占位符个数）
+	args     []interface{} // 插入的参数
+}
+
+// sqlNonAliasKeywords 紧跟表名但不是别名的关键字
+var sqlNonAliasKeywords = map[string]struct{}{
+	"WHERE": {}, "JOIN": {}, "LEFT": {}, "RIGHT": {}, "INNER": {}, "OUTER": {}, "CROSS": {}, "FULL": {},
+	"NATURAL": {}, "STRAIGHT_JOIN": {}, "ON": {}, "USING": {}, "GROUP": {}, "ORDER": {}, "HAVING": {},
+	"LIMIT": {}, "OFFSET": {}, "FETCH": {}, "FOR": {}, "UNION": {}, "INTERSECT": {}, "EXCEPT": {}, "MINUS": {},
+	"SET": {}, "VALUES": {}, "VALUE": {}, "SELECT": {}, "WINDOW": {}, "RETURNING": {}, "LOCK": {}, "FORCE": {},
+	"USE": {}, "IGNORE": {}, "PARTITION": {}, "WITH": {},
+}
+
+// sqlClauseEndKeywords WHERE 条件之后的子句关键字
+var sqlClauseEndKeywords = map[string]struct{}{
+	"GROUP": {}, "ORDER": {}, "HAVING": {}, "LIMIT": {}, "OFFSET": {}, "FETCH": {}, "FOR": {},
+	"WINDOW": {}, "RETURNING": {}, "LOCK": {},
+}
+
+// sqlJoinKeywords JOIN 子句的起始关键字，用于确定 ON 条件的结束位置
+var sqlJoinKeywords = map[string]struct{}{
+	"JOIN": {}, "LEFT": {}, "RIGHT": {}, "INNER": {}, "FULL": {}, "CROSS": {}, "NATURAL": {}, "STRAIGHT_JOIN": {},
+}
+
+// sqlSetOperators 集合运算关键字，包含集合运算的语句不自动改写
+var sqlSetOperators = map[string]struct{}{
+	"UNION": {}, "INTERSECT": {}, "EXCEPT": {}, "MINUS": {},
+}
+
+// rewriteTenantSQL 为引用租户隔离表的SQL语句附加租户条件
+// SELECT/UPDATE/DELETE 在 WHERE 中附加条件（JOIN 的表附加到 ON 条件），原条件加括号；
+// INSERT/REPLACE 要求列清单中包含租户列；子查询、集合运算和其他语句中引用租户隔离表时返回 ErrTenantScopeUnsupported
+//
+// 参数:
+//
+//	query: SQL语句，只支持 ? 占位符
+//	args: 参数
+//	columnOf: 获取表的租户列名，未注册的表返回空
+//	tenantOf: 获取租户ID，上下文中没有租户时返回错误
+//
+// 返回:
+//
+//	string: 改写后的SQL语句
+//	[]interface{}: 改写后的参数
+//	error: 无法改写或缺少租户时返回错误
+func rewriteTenantSQL(query string, args []interface{}, columnOf func(string) string, tenantOf func(string) (string, error)) (string, []interface{}, error) {
+	tokens := tokenizeSQL(query)
+	refs := findTenantTables(tokens, columnOf)
+	if len(refs) == 0 {
+		return query, args, nil
+	}
+
+	tenantId, err := tenantOf(refs[0].table)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, ref := range refs {
+		if ref.depth > 0 {
+			return "", nil, fmt.Errorf("%w: table %s referenced in subquery", ErrTenantScopeUnsupported, ref.table)
+		}
+	}
+	for _, tok := range tokens {
+		if _, ok := sqlSetOperators[tok.upper]; ok && tok.kind == 'w' && tok.depth == 0 {
+			return "", nil, fmt.Errorf("%w: %s statement", ErrTenantScopeUnsupported, tok.upper)
+		}
+	}
+
+	switch tokens[0].upper {
+	case "INSERT", "REPLACE":
+		return query, args, checkTenantInsert(tokens, refs)
+	case "SELECT", "UPDATE", "DELETE":
+	default:
+		return "", nil, fmt.Errorf("%w: %s statement", ErrTenantScopeUnsupported, tokens[0].upper)
+	}
+
+	edits, err := tenantConditionEdits(query, tokens, refs, tenantId)
+	if err != nil {
+		return "", nil, err
+	}
+	rewritten, newArgs := applyTenantSQLEdits(query, args, edits)
+	return rewritten, newArgs, nil
+}
+
+// tokenizeSQL 将SQL语句切分为词法单元，跳过字符串常量和注释
+func tokenizeSQL(query string) []sqlToken {
+	var (
+		tokens []sqlToken
+		depth  int
+	)
+	add := func(kind byte, start, end, tokenDepth int) {
+		text := query[start:end]
+		tokens = append(tokens, sqlToken{
+			kind: kind, text: text, upper: strings.ToUpper(text),
+			start: start, end: end, depth: tokenDepth,
+		})
+	}
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				i = len(query)
+			} else {
+				i += end + 4
+			}
+		case c == '\'':
+			i = skipQuoted(query, i, '\'')
+		case c == '(':
+			add('(', i, i+1, depth)
+			depth++
+			i++
+		case c == ')':
+			if depth > 0 {
+				depth--
+			}
+			add(')', i, i+1, depth)
+			i++
+		case c == '?' || c == ',' || c == ';':
+			add(c, i, i+1, depth)
+			i++
+		case isSQLWordChar(c) || c == '`' || c == '"':
+			start := i
+			for i < len(query) && (isSQLWordChar(query[i]) || query[i] == '`' || query[i] == '"') {
+				if query[i] == '`' || query[i] == '"' {
+					i = skipQuoted(query, i, query[i])
+				} else {
+					i++
+				}
+			}
+			add('w', start, i, depth)
+		default:
+			i++
+		}
+	}
+	return tokens
+}
+
+// skipQuoted 跳过引号包围的内容，返回结束引号之后的位置
+func skipQuoted(query string, i int, quote byte) int {
+	for i++; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if quote == '\'' {
+				i++
+			}
+		case quote:
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+// isSQLWordChar 判断是否为标识符字符
+func isSQLWordChar(c byte) bool {
+	return c == '_' || c == '$' || c == '#' || c == '.' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c >= 0x80
+}
+
+// findTenantTables 查找语句中 FROM/JOIN/UPDATE/INTO 之后引用的租户隔离表
+func findTenantTables(tokens []sqlToken, columnOf func(string) string) []tenantTableRef {
+	var refs []tenantTableRef
+	for i, tok := range tokens {
+		if tok.kind != 'w' {
+			continue
+		}
+		switch tok.upper {
+		case "FROM", "JOIN", "INTO", "STRAIGHT_JOIN":
+		case "UPDATE":
+			// 排除 FOR UPDATE 和 ON DUPLICATE KEY UPDATE
+			if i > 0 && tokens[i-1].kind == 'w' {
+				continue
+			}
+		default:
+			continue
+		}
+
+		join := tok.upper == "JOIN" || tok.upper == "STRAIGHT_JOIN"
+		outer := join && isOuterJoin(tokens, i)
+		for j := i + 1; j < len(tokens) && tokens[j].kind == 'w'; {
+			if _, keyword := sqlNonAliasKeywords[tokens[j].upper]; keyword {
+				break
+			}
+			ref := tenantTableRef{table: tokens[j].text, index: j, depth: tokens[j].depth, join: join, outerJoin: outer}
+			j++
+			if j < len(tokens) && tokens[j].upper == "AS" {
+				j++
+			}
+			if j < len(tokens) && tokens[j].kind == 'w' {
+				if _, keyword := sqlNonAliasKeywords[tokens[j].upper]; !keyword {
+					ref.alias = tokens[j].text
+					j++
+				}
+			}
+			if ref.column = columnOf(ref.table); ref.column != "" {
+				refs = append(refs, ref)
+			}
+			// 只有 FROM 支持逗号分隔的多个表
+			if tok.upper != "FROM" || j >= len(tokens) || tokens[j].kind != ',' {
+				break
+			}
+			j++
+		}
+	}
+	return refs
+}
+
+// isOuterJoin 判断 JOIN 是否为外连接
+func isOuterJoin(tokens []sqlToken, joinIndex int) bool {
+	for i := joinIndex - 1; i >= 0 && tokens[i].kind == 'w'; i-- {
+		switch tokens[i].upper {
+		case "LEFT", "RIGHT", "FULL":
+			return true
+		case "OUTER", "INNER", "CROSS", "NATURAL":
+			continue
+		}
+		break
+	}
+	return false
+}
+
+// checkTenantInsert 校验 INSERT 语句的列清单包含租户列
+func checkTenantInsert(tokens []sqlToken, refs []tenantTableRef) error {
+	for _, ref := range refs {
+		if ref.index == 0 || tokens[ref.index-1].upper != "INTO" {
+			return fmt.Errorf("%w: INSERT ... SELECT from table %s", ErrTenantScopeUnsupported, ref.table)
+		}
+		if !insertHasColumn(tokens, ref.index+1, ref.column) {
+			return fmt.Errorf("%w: INSERT into %s must specify column %s", ErrTenantScopeUnsupported, ref.table, ref.column)
+		}
+	}
+	return nil
+}
+
+// insertHasColumn 判断表名之后的列清单是否包含指定列
+func insertHasColumn(tokens []sqlToken, i int, column string) bool {
+	if i < len(tokens) && tokens[i].kind == 'w' && tokens[i].upper != "VALUES" && tokens[i].upper != "SELECT" {
+		i++ // 跳过别名
+	}
+	if i >= len(tokens) || tokens[i].kind != '(' {
+		return false
+	}
+	depth := tokens[i].depth
+	for i++; i < len(tokens) && !(tokens[i].kind == ')' && tokens[i].depth == depth); i++ {
+		if tokens[i].kind == 'w' && strings.EqualFold(strings.Trim(tokens[i].text, "`\"[]"), column) {
+			return true
+		}
+	}
+	return false
+}
+
+// tenantConditionEdits 生成附加租户条件的插入操作
+func tenantConditionEdits(query string, tokens []sqlToken, refs []tenantTableRef, tenantId string) ([]tenantSQLEdit, error) {
+	// 语句中引用多个表时用别名或表名限定租户列
+	qualify := len(refs) > 1 || tableRefCount(tokens) > 1
+	condition := func(ref tenantTableRef) string {
+		if !qualify {
+			return ref.column + " = ?"
+		}
+		if ref.alias != "" {
+			return ref.alias + "." + ref.column + " = ?"
+		}
+		return ref.table + "." + ref.column + " = ?"
+	}
+
+	var (
+		edits      []tenantSQLEdit
+		conditions []string
+		lastRef    int
+	)
+	for _, ref := range refs {
+		if ref.index > lastRef {
+			lastRef = ref.index
+		}
+		if !ref.join {
+			conditions = append(conditions, condition(ref))
+			continue
+		}
+
+		on := nextTopLevel(tokens, ref.index+1, func(tok sqlToken) bool {
+			_, join := sqlJoinKeywords[tok.upper]
+			_, end := sqlClauseEndKeywords[tok.upper]
+			return tok.upper == "ON" || tok.upper == "USING" || tok.upper == "WHERE" || join || end || tok.kind == ','
+		})
+		if on < 0 || tokens[on].upper != "ON" {
+			if ref.outerJoin {
+				return nil, fmt.Errorf("%w: outer join on table %s without ON clause", ErrTenantScopeUnsupported, ref.table)
+			}
+			conditions = append(conditions, condition(ref))
+			continue
+		}
+
+		// ON 条件加括号后附加租户条件
+		end := nextTopLevel(tokens, on+1, func(tok sqlToken) bool {
+			_, join := sqlJoinKeywords[tok.upper]
+			_, end := sqlClauseEndKeywords[tok.upper]
+			return tok.upper == "WHERE" || join || end || tok.kind == ',' || tok.kind == ';'
+		})
+		endPos := statementEnd(query, tokens, end)
+		edits = append(edits,
+			tenantSQLEdit{pos: clauseStart(query, tokens[on]), text: condition(ref) + " AND (", argIndex: argsBefore(tokens, tokens[on].end), args: []interface{}{tenantId}},
+			tenantSQLEdit{pos: endPos, text: ")", argIndex: argsBefore(tokens, endPos)},
+		)
+	}
+	if len(conditions) == 0 {
+		return edits, nil
+	}
+
+	conditionText := strings.Join(conditions, " AND ")
+	conditionArgs := make([]interface{}, len(conditions))
+	for i := range conditionArgs {
+		conditionArgs[i] = tenantId
+	}
+
+	where := nextTopLevel(tokens, lastRef+1, func(tok sqlToken) bool {
+		_, end := sqlClauseEndKeywords[tok.upper]
+		return tok.upper == "WHERE" || end || tok.kind == ';'
+	})
+	if where >= 0 && tokens[where].upper == "WHERE" {
+		end := nextTopLevel(tokens, where+1, func(tok sqlToken) bool {
+			_, end := sqlClauseEndKeywords[tok.upper]
+			return end || tok.kind == ';'
+		})
+		endPos := statementEnd(query, tokens, end)
+		edits = append(edits,
+			tenantSQLEdit{pos: clauseStart(query, tokens[where]), text: conditionText + " AND (", argIndex: argsBefore(tokens, tokens[where].end), args: conditionArgs},
+			tenantSQLEdit{pos: endPos, text: ")", argIndex: argsBefore(tokens, endPos)},
+		)
+		return edits, nil
+	}
+
+	pos := statementEnd(query, tokens, where)
+	edits = append(edits, tenantSQLEdit{pos: pos, text: " WHERE " + conditionText, argIndex: argsBefore(tokens, pos), args: conditionArgs})
+	return edits, nil
+}
+
+// tableRefCount 统计顶层 FROM/JOIN/UPDATE 引用的表数量，用于判断是否需要限定列名
+func tableRefCount(tokens []sqlToken) int {
+	count := 0
+	for i, tok := range tokens {
+		if tok.depth != 0 || tok.kind != 'w' {
+			continue
+		}
+		switch {
+		case tok.upper == "JOIN" || tok.upper == "STRAIGHT_JOIN" || (tok.upper == "UPDATE" && i == 0):
+			count++
+		case tok.upper == "FROM":
+			count++
+			for j := i + 1; j < len(tokens) && tokens[j].depth == 0; j++ {
+				if tokens[j].kind == ',' {
+					count++
+				} else if _, keyword := sqlNonAliasKeywords[tokens[j].upper]; keyword && tokens[j].kind == 'w' {
+					break
+				}
+			}
+		}
+	}
+	return count
+}
+
+// nextTopLevel 从 start 开始查找第一个满足条件的顶层词法单元，未找到时返回 -1
+func nextTopLevel(tokens []sqlToken, start int, match func(sqlToken) bool) int {
+	for i := start; i < len(tokens); i++ {
+		if tokens[i].depth == 0 && match(tokens[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// clauseStart 获取 WHERE/ON 关键字之后条件的起始位置（跳过空白）
+func clauseStart(query string, keyword sqlToken) int {
+	return len(query) - len(strings.TrimLeft(query[keyword.end:], " \t\r\n"))
+}
+
+// statementEnd 获取子句的结束位置：指定词法单元之前（去除空白），未指定时为语句末尾（去除结尾的空白和分号）
+func statementEnd(query string, tokens []sqlToken, index int) int {
+	if index >= 0 {
+		return len(strings.TrimRight(query[:tokens[index].start], " \t\r\n"))
+	}
+	return len(strings.TrimRight(query, " \t\r\n;"))
+}
+
+// argsBefore 统计指定位置之前的 ? 占位符个数
+func argsBefore(tokens []sqlToken, pos int) int {
+	count := 0
+	for _, tok := range tokens {
+		if tok.start >= pos {
+			break
+		}
+		if tok.kind == '?' {
+			count++
+		}
+	}
+	return count
+}
+
+// applyTenantSQLEdits 按位置应用插入操作，并在对应位置插入参数
+func applyTenantSQLEdits(query string, args []interface{}, edits []tenantSQLEdit) (string, []interface{}) {
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].pos < edits[j].pos })
+
+	var (
+		sb      strings.Builder
+		newArgs = make([]interface{}, 0, len(args)+len(edits))
+		pos     int
+		argPos  int
+	)
+	for _, edit := range edits {
+		sb.WriteString(query[pos:edit.pos])
+		sb.WriteString(edit.text)
+		pos = edit.pos
+
+		if edit.argIndex > len(args) {
+			edit.argIndex = len(args)
+		}
+		newArgs = append(newArgs, args[argPos:edit.argIndex]...)
+		newArgs = append(newArgs, edit.args...)
+		argPos = edit.argIndex
+	}
+	sb.WriteString(query[pos:])
+	newArgs = append(newArgs, args[argPos:]...)
+	return sb.String(), newArgs
+}
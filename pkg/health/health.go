@@ -0,0 +1,209 @@
+// Package health 应用健康检查
+//
+// 各子系统（数据库、缓存、注册中心、定时任务、网关实例等）注册检查函数，
+// 存活检查（/healthz）和就绪检查（/readyz）并发执行所有检查并汇总每个组件的状态：
+//   - 存活检查只要进程能够响应就返回200，组件状态仅供参考，避免依赖故障导致进程被反复重启
+//   - 就绪检查在应用启动完成、未进入停止流程且关键组件正常时返回200，否则返回503，负载均衡据此摘除流量
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"gateway/pkg/config"
+)
+
+// Status 组件或整体的健康状态
+type Status string
+
+const (
+	StatusUp       Status = "UP"       // 正常
+	StatusDegraded Status = "DEGRADED" // 部分功能异常，仍可提供服务
+	StatusDown     Status = "DOWN"     // 不可用
+)
+
+// DefaultCheckTimeout 单个检查的默认超时时间
+const DefaultCheckTimeout = 3 * time.Second
+
+// CheckFunc 检查函数
+// 返回组件的状态和详细信息，返回错误时组件状态为 DOWN
+type CheckFunc func(ctx context.Context) (Status, map[string]interface{}, error)
+
+// Check 组件检查
+type Check struct {
+	Name     string        // 组件名称，唯一
+	Critical bool          // 关键组件，状态为 DOWN 时应用未就绪
+	Timeout  time.Duration // 检查超时时间，0使用 app.health.check_timeout_seconds 或默认值
+	Func     CheckFunc
+}
+
+// ComponentStatus 组件检查结果
+type ComponentStatus struct {
+	Name       string                 `json:"name"`
+	Status     Status                 `json:"status"`
+	Critical   bool                   `json:"critical"`
+	DurationMs int64                  `json:"durationMs"`
+	Error      string                 `json:"error,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+}
+
+// Report 健康检查报告
+type Report struct {
+	Status     Status            `json:"status"`
+	Ready      bool              `json:"ready"`
+	Reason     string            `json:"reason,omitempty"` // 未就绪的原因
+	Components []ComponentStatus `json:"components"`
+	CheckedAt  time.Time         `json:"checkedAt"`
+}
+
+var (
+	mu      sync.RWMutex
+	checks  = make(map[string]Check)
+	started bool
+)
+
+// Register 注册组件检查，同名检查会被替换
+func Register(check Check) {
+	mu.Lock()
+	defer mu.Unlock()
+	checks[check.Name] = check
+}
+
+// Unregister 移除组件检查
+func Unregister(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(checks, name)
+}
+
+// SetStarted 设置应用是否已启动完成，启动完成前就绪检查返回未就绪
+func SetStarted(value bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	started = value
+}
+
+// Run 并发执行所有检查并汇总结果
+// 参数:
+//
+//	ctx: 上下文
+//
+// 返回:
+//
+//	*Report: 健康检查报告，组件按名称排序
+func Run(ctx context.Context) *Report {
+	mu.RLock()
+	list := make([]Check, 0, len(checks))
+	for _, check := range checks {
+		list = append(list, check)
+	}
+	isStarted := started
+	mu.RUnlock()
+
+	results := make([]ComponentStatus, len(list))
+	var wg sync.WaitGroup
+	for i, check := range list {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = runCheck(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	report := &Report{Status: StatusUp, Ready: true, Components: results, CheckedAt: time.Now()}
+	var criticalDown []string
+	for _, result := range results {
+		switch {
+		case result.Status == StatusDown && result.Critical:
+			report.Status = StatusDown
+			criticalDown = append(criticalDown, result.Name)
+		case result.Status != StatusUp && report.Status == StatusUp:
+			report.Status = StatusDegraded
+		}
+	}
+
+	switch {
+	case config.IsInstanceStopping():
+		report.Ready, report.Reason = false, "应用正在停止"
+	case !isStarted:
+		report.Ready, report.Reason = false, "应用尚未启动完成"
+	case len(criticalDown) > 0:
+		report.Ready, report.Reason = false, fmt.Sprintf("关键组件不可用: %v", criticalDown)
+	}
+	return report
+}
+
+// runCheck 在超时时间内执行单个检查
+func runCheck(ctx context.Context, check Check) ComponentStatus {
+	result := ComponentStatus{Name: check.Name, Critical: check.Critical}
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = time.Duration(config.GetInt("app.health.check_timeout_seconds", 0)) * time.Second
+	}
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		status  Status
+		details map[string]interface{}
+		err     error
+	}
+	done := make(chan outcome, 1)
+	start := time.Now()
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- outcome{err: fmt.Errorf("panic: %v", r)}
+			}
+		}()
+		status, details, err := check.Func(checkCtx)
+		done <- outcome{status, details, err}
+	}()
+
+	select {
+	case o := <-done:
+		result.Status, result.Details = o.status, o.details
+		if o.err != nil {
+			result.Status, result.Error = StatusDown, o.err.Error()
+		} else if result.Status == "" {
+			result.Status = StatusUp
+		}
+	case <-checkCtx.Done():
+		result.Status, result.Error = StatusDown, fmt.Sprintf("检查超时(%s)", timeout)
+	}
+	result.DurationMs = time.Since(start).Milliseconds()
+	return result
+}
+
+// LivenessHandler 存活检查接口，进程能够响应时始终返回200，组件状态仅供参考
+func LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	writeReport(w, http.StatusOK, Run(r.Context()))
+}
+
+// ReadinessHandler 就绪检查接口，未就绪时返回503
+func ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	report := Run(r.Context())
+	status := http.StatusOK
+	if !report.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	writeReport(w, status, report)
+}
+
+// writeReport 输出JSON格式的检查报告
+func writeReport(w http.ResponseWriter, status int, report *Report) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(report)
+}
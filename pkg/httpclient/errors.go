@@ -7,7 +7,16 @@
 // - 网络相关错误 (1301-1400)
 package httpclient
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+
+	"gateway/pkg/utils/huberrors"
+)
+
+func init() {
+	huberrors.RegisterClassifier(classifyHTTPError)
+}
 
 // === HTTP错误代码常量（仅保留实际使用的，范围：1001-2000） ===
 
@@ -237,3 +246,35 @@ func IsRetryableError(err error) bool {
 	}
 	return false
 }
+
+// classifyHTTPError 为 HTTPError 确定通用错误码，使 huberrors.IsRetryable 等函数可以识别HTTP客户端错误
+func classifyHTTPError(err error) (huberrors.Code, bool) {
+	httpErr, ok := err.(*HTTPError)
+	if !ok {
+		return "", false
+	}
+	switch httpErr.Code {
+	case ErrCodeRequestTimeout:
+		return huberrors.CodeTimeout, true
+	case ErrCodeNetworkError, ErrCodeResponseReadFailed:
+		return huberrors.CodeUnavailable, true
+	}
+	switch {
+	case httpErr.StatusCode == http.StatusTooManyRequests:
+		return huberrors.CodeRateLimited, true
+	case httpErr.StatusCode == http.StatusNotFound:
+		return huberrors.CodeNotFound, true
+	case httpErr.StatusCode == http.StatusUnauthorized:
+		return huberrors.CodeUnauthenticated, true
+	case httpErr.StatusCode == http.StatusForbidden:
+		return huberrors.CodePermissionDenied, true
+	case httpErr.StatusCode >= 500 && httpErr.StatusCode < 600:
+		return huberrors.CodeUnavailable, true
+	case httpErr.StatusCode >= 400 && httpErr.StatusCode < 500:
+		return huberrors.CodeInvalidArgument, true
+	}
+	if httpErr.Code >= ErrCodeInvalidURL && httpErr.Code < ErrCodeRequestFailed {
+		return huberrors.CodeInvalidArgument, true
+	}
+	return "", false
+}
@@ -0,0 +1,434 @@
+// Package lifecycle 应用子系统生命周期管理
+//
+// 各子系统（配置、日志、数据库、缓存、定时任务、网关、Web等）以 Module 注册初始化、启动、停止函数及依赖的模块，
+// 管理器按依赖关系排序后依次初始化并启动，停止时按启动的逆序停止；每个阶段有独立的超时时间，
+// 启动和停止完成后生成报告，记录每个模块的状态、耗时和错误
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gateway/pkg/logger"
+	"gateway/pkg/utils/huberrors"
+)
+
+// 默认超时时间
+const (
+	DefaultStartTimeout = 2 * time.Minute  // 模块初始化和启动的默认超时时间
+	DefaultStopTimeout  = 15 * time.Second // 模块停止的默认超时时间
+)
+
+// Phase 生命周期阶段
+type Phase string
+
+const (
+	PhaseInit  Phase = "INIT"  // 初始化
+	PhaseStart Phase = "START" // 启动
+	PhaseStop  Phase = "STOP"  // 停止
+)
+
+// Status 模块状态
+type Status string
+
+const (
+	StatusPending Status = "PENDING" // 未启动
+	StatusStarted Status = "STARTED" // 已启动
+	StatusFailed  Status = "FAILED"  // 初始化或启动失败
+	StatusSkipped Status = "SKIPPED" // 依赖的模块失败或被跳过，未启动
+	StatusStopped Status = "STOPPED" // 已停止
+)
+
+// Func 生命周期函数，ctx 在阶段超时或管理器上下文取消时结束
+type Func func(ctx context.Context) error
+
+// Module 子系统模块
+type Module struct {
+	Name         string        // 模块名称，唯一
+	DependsOn    []string      // 依赖的模块，这些模块启动后才初始化本模块，本模块停止后才停止
+	Optional     bool          // 可选模块，失败时记录错误并继续启动，依赖它的模块被跳过
+	Init         Func          // 初始化，可为空
+	Start        Func          // 启动，在初始化之后执行，可为空
+	Stop         Func          // 停止，只对已启动的模块执行，可为空
+	StartTimeout time.Duration // 初始化和启动各自的超时时间，0使用管理器默认值
+	StopTimeout  time.Duration // 停止的超时时间，0使用管理器默认值
+}
+
+// ModuleReport 单个模块在一次启动或停止中的结果
+type ModuleReport struct {
+	Name     string        `json:"name"`
+	Status   Status        `json:"status"`
+	Phase    Phase         `json:"phase,omitempty"` // 失败时所在阶段
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+
+	err error // 原始错误，供 Report.Err 汇总
+}
+
+// Report 启动或停止报告，模块按执行顺序排列
+type Report struct {
+	Operation string         `json:"operation"` // startup 或 shutdown
+	Modules   []ModuleReport `json:"modules"`
+	Duration  time.Duration  `json:"duration"`
+}
+
+// String 以表格形式输出报告
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s report (%d modules, %s)\n", r.Operation, len(r.Modules), r.Duration.Round(time.Millisecond))
+	for _, m := range r.Modules {
+		fmt.Fprintf(&b, "  %-18s %-8s %10s", m.Name, m.Status, m.Duration.Round(time.Millisecond))
+		if m.Error != "" {
+			fmt.Fprintf(&b, "  [%s] %s", m.Phase, m.Error)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Failed 失败的模块
+func (r *Report) Failed() []ModuleReport {
+	var failed []ModuleReport
+	for _, m := range r.Modules {
+		if m.Status == StatusFailed {
+			failed = append(failed, m)
+		}
+	}
+	return failed
+}
+
+// Err 汇总所有失败模块的错误，没有模块失败时返回nil
+func (r *Report) Err() error {
+	errs := huberrors.NewMultiError("%s: %d 个模块失败", r.Operation, len(r.Failed()))
+	for _, m := range r.Failed() {
+		err := m.err
+		if err == nil {
+			err = errors.New(m.Error)
+		}
+		huberrors.Append(errs, fmt.Errorf("模块 %s %s失败: %w", m.Name, phaseName(m.Phase), err))
+	}
+	return errs.ErrorOrNil()
+}
+
+// TimeoutResolver 按模块和阶段返回超时时间，返回0时使用模块或管理器的设置
+type TimeoutResolver func(module string, phase Phase) time.Duration
+
+// Manager 生命周期管理器
+type Manager struct {
+	mu           sync.Mutex
+	modules      []*Module
+	byName       map[string]*Module
+	status       map[string]Status
+	started      []*Module // 已启动的模块，按启动顺序
+	startTimeout time.Duration
+	stopTimeout  time.Duration
+	resolver     TimeoutResolver
+}
+
+// NewManager 创建生命周期管理器
+func NewManager() *Manager {
+	return &Manager{
+		byName:       make(map[string]*Module),
+		status:       make(map[string]Status),
+		startTimeout: DefaultStartTimeout,
+		stopTimeout:  DefaultStopTimeout,
+	}
+}
+
+// SetDefaultTimeouts 设置模块未指定超时时间时使用的默认值，小于等于0的参数不修改
+func (m *Manager) SetDefaultTimeouts(start, stop time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if start > 0 {
+		m.startTimeout = start
+	}
+	if stop > 0 {
+		m.stopTimeout = stop
+	}
+}
+
+// SetTimeoutResolver 设置超时时间解析函数，在每个阶段执行前调用，可用于读取启动过程中才加载的配置
+func (m *Manager) SetTimeoutResolver(resolver TimeoutResolver) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resolver = resolver
+}
+
+// Register 注册模块，模块名称不能重复
+func (m *Manager) Register(module Module) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if module.Name == "" {
+		return errors.New("模块名称不能为空")
+	}
+	if _, exists := m.byName[module.Name]; exists {
+		return fmt.Errorf("模块 %s 重复注册", module.Name)
+	}
+	m.modules = append(m.modules, &module)
+	m.byName[module.Name] = &module
+	m.status[module.Name] = StatusPending
+	return nil
+}
+
+// MustRegister 注册模块，注册失败时panic，用于启动代码中的固定模块列表
+func (m *Manager) MustRegister(modules ...Module) {
+	for _, module := range modules {
+		if err := m.Register(module); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Status 获取模块当前状态，未注册的模块返回空字符串
+func (m *Manager) Status(name string) Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status[name]
+}
+
+// order 按依赖关系排序模块，没有依赖关系的模块保持注册顺序
+func (m *Manager) order() ([]*Module, error) {
+	for _, module := range m.modules {
+		for _, dep := range module.DependsOn {
+			if _, ok := m.byName[dep]; !ok {
+				return nil, fmt.Errorf("模块 %s 依赖的模块 %s 未注册", module.Name, dep)
+			}
+		}
+	}
+
+	ordered := make([]*Module, 0, len(m.modules))
+	placed := make(map[string]bool, len(m.modules))
+	for len(ordered) < len(m.modules) {
+		progressed := false
+		// 每轮选择注册顺序最靠前、依赖已全部排好的模块
+		for _, module := range m.modules {
+			if placed[module.Name] {
+				continue
+			}
+			ready := true
+			for _, dep := range module.DependsOn {
+				if !placed[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				ordered = append(ordered, module)
+				placed[module.Name] = true
+				progressed = true
+				break
+			}
+		}
+		if !progressed {
+			var remaining []string
+			for _, module := range m.modules {
+				if !placed[module.Name] {
+					remaining = append(remaining, module.Name)
+				}
+			}
+			return nil, fmt.Errorf("模块存在循环依赖: %s", strings.Join(remaining, ", "))
+		}
+	}
+	return ordered, nil
+}
+
+// timeout 获取模块在指定阶段的超时时间
+func (m *Manager) timeout(module *Module, phase Phase) time.Duration {
+	m.mu.Lock()
+	resolver := m.resolver
+	defaultTimeout, moduleTimeout := m.startTimeout, module.StartTimeout
+	if phase == PhaseStop {
+		defaultTimeout, moduleTimeout = m.stopTimeout, module.StopTimeout
+	}
+	m.mu.Unlock()
+
+	if resolver != nil {
+		if d := resolver(module.Name, phase); d > 0 {
+			return d
+		}
+	}
+	if moduleTimeout > 0 {
+		return moduleTimeout
+	}
+	return defaultTimeout
+}
+
+// Start 按依赖顺序初始化并启动所有模块
+// 必需模块失败时停止已启动的模块并返回错误；可选模块失败时记录错误，依赖它的模块被跳过
+// 参数:
+//
+//	ctx: 管理器上下文，取消后正在执行的阶段提前结束
+//
+// 返回:
+//
+//	*Report: 启动报告，包含所有模块的结果
+//	error: 必需模块失败或依赖关系无效时返回错误
+func (m *Manager) Start(ctx context.Context) (*Report, error) {
+	begin := time.Now()
+	report := &Report{Operation: "startup"}
+	defer func() { report.Duration = time.Since(begin) }()
+
+	m.mu.Lock()
+	ordered, err := m.order()
+	m.mu.Unlock()
+	if err != nil {
+		return report, err
+	}
+
+	unavailable := make(map[string]bool)
+	for _, module := range ordered {
+		item := ModuleReport{Name: module.Name}
+
+		var blocked []string
+		for _, dep := range module.DependsOn {
+			if unavailable[dep] {
+				blocked = append(blocked, dep)
+			}
+		}
+		if len(blocked) > 0 {
+			item.Status = StatusSkipped
+			item.Error = "依赖的模块未启动: " + strings.Join(blocked, ", ")
+			unavailable[module.Name] = true
+			m.setStatus(module, StatusSkipped)
+			report.Modules = append(report.Modules, item)
+			logger.Warn("模块未启动，依赖的模块未启动", "module", module.Name, "dependencies", blocked)
+			if !module.Optional {
+				return report, m.abortStart(fmt.Errorf("模块 %s 依赖的模块未启动: %s", module.Name, strings.Join(blocked, ", ")))
+			}
+			continue
+		}
+
+		start := time.Now()
+		phase, err := m.startModule(ctx, module)
+		item.Duration = time.Since(start)
+		if err != nil {
+			item.Status, item.Phase, item.Error, item.err = StatusFailed, phase, err.Error(), err
+			unavailable[module.Name] = true
+			m.setStatus(module, StatusFailed)
+			report.Modules = append(report.Modules, item)
+			if !module.Optional {
+				logger.Error("模块启动失败", "module", module.Name, "phase", phase, "error", err)
+				return report, m.abortStart(fmt.Errorf("模块 %s %s失败: %w", module.Name, phaseName(phase), err))
+			}
+			logger.Error("可选模块启动失败，继续启动其他模块", "module", module.Name, "phase", phase, "error", err)
+			continue
+		}
+
+		item.Status = StatusStarted
+		m.mu.Lock()
+		m.started = append(m.started, module)
+		m.status[module.Name] = StatusStarted
+		m.mu.Unlock()
+		report.Modules = append(report.Modules, item)
+		logger.Info("模块启动完成", "module", module.Name, "duration", item.Duration.String())
+	}
+	return report, nil
+}
+
+// abortStart 必需模块失败时停止已启动的模块
+func (m *Manager) abortStart(cause error) error {
+	stopReport := m.Stop(context.Background())
+	if failed := stopReport.Failed(); len(failed) > 0 {
+		logger.Warn("启动失败后停止已启动模块时发生错误", "modules", len(failed))
+	}
+	return cause
+}
+
+// startModule 执行模块的初始化和启动，返回失败的阶段
+func (m *Manager) startModule(ctx context.Context, module *Module) (Phase, error) {
+	if err := m.run(ctx, module, PhaseInit, module.Init); err != nil {
+		return PhaseInit, err
+	}
+	if err := m.run(ctx, module, PhaseStart, module.Start); err != nil {
+		return PhaseStart, err
+	}
+	return "", nil
+}
+
+// Stop 按启动的逆序停止已启动的模块，单个模块停止失败或超时不影响其他模块
+// 参数:
+//
+//	ctx: 停止上下文
+//
+// 返回:
+//
+//	*Report: 停止报告
+func (m *Manager) Stop(ctx context.Context) *Report {
+	begin := time.Now()
+	report := &Report{Operation: "shutdown"}
+
+	m.mu.Lock()
+	started := m.started
+	m.started = nil
+	m.mu.Unlock()
+
+	for i := len(started) - 1; i >= 0; i-- {
+		module := started[i]
+		item := ModuleReport{Name: module.Name, Status: StatusStopped}
+		start := time.Now()
+		if err := m.run(ctx, module, PhaseStop, module.Stop); err != nil {
+			item.Status, item.Phase, item.Error, item.err = StatusFailed, PhaseStop, err.Error(), err
+			logger.Error("模块停止失败", "module", module.Name, "error", err)
+		}
+		item.Duration = time.Since(start)
+		m.setStatus(module, StatusStopped)
+		report.Modules = append(report.Modules, item)
+	}
+	report.Duration = time.Since(begin)
+	return report
+}
+
+// run 在超时时间内执行生命周期函数，超时后不再等待函数返回
+func (m *Manager) run(ctx context.Context, module *Module, phase Phase, fn Func) (err error) {
+	if fn == nil {
+		return nil
+	}
+	timeout := m.timeout(module, phase)
+	phaseCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- fn(phaseCtx)
+	}()
+
+	select {
+	case err = <-done:
+		return err
+	case <-phaseCtx.Done():
+		if errors.Is(phaseCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%s超时(%s)", phaseName(phase), timeout)
+		}
+		return phaseCtx.Err()
+	}
+}
+
+// setStatus 设置模块状态
+func (m *Manager) setStatus(module *Module, status Status) {
+	m.mu.Lock()
+	m.status[module.Name] = status
+	m.mu.Unlock()
+}
+
+// phaseName 阶段的中文名称
+func phaseName(phase Phase) string {
+	switch phase {
+	case PhaseInit:
+		return "初始化"
+	case PhaseStart:
+		return "启动"
+	case PhaseStop:
+		return "停止"
+	default:
+		return string(phase)
+	}
+}
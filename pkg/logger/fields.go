@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// LogFieldsKey 请求级日志字段在 gin.Context 中的键名
+// gin.Context 通过 Set(LogFieldsKey, ...) 携带，标准上下文使用未导出的 logFieldsCtxKey，避免与其他包冲突
+const LogFieldsKey = "logFields"
+
+// logFieldsCtxKey 请求级日志字段在标准上下文中的键类型
+type logFieldsCtxKey struct{}
+
+// WithFields 为上下文附加请求级日志字段
+//
+// 在请求入口（中间件、路由匹配后）调用一次，之后使用该上下文的
+// *WithTrace 系列函数会自动带上这些字段，无需在每个调用点重复传参。
+// 同名字段以后附加的为准。
+//
+// 参数:
+//   - ctx: 上下文对象
+//   - args: 字段，格式与 Info 的附加参数相同（键值对、map、zap.Field）
+//
+// 返回:
+//   - context.Context: 携带日志字段的新上下文
+func WithFields(ctx context.Context, args ...any) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	fields := MergeFields(ContextFields(ctx), args...)
+	if len(fields) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, logFieldsCtxKey{}, fields)
+}
+
+// MergeFields 合并已有字段与新字段，返回新的字段切片，不修改原切片
+//
+// 参数:
+//   - existing: 已有字段
+//   - args: 新字段，格式与 Info 的附加参数相同
+//
+// 返回:
+//   - []zap.Field: 合并后的字段，同名字段以新字段为准
+func MergeFields(existing []zap.Field, args ...any) []zap.Field {
+	added := parseArgs(args...)
+	if len(added) == 0 {
+		return existing
+	}
+	merged := make([]zap.Field, 0, len(existing)+len(added))
+	for _, field := range existing {
+		if !containsField(added, field.Key) {
+			merged = append(merged, field)
+		}
+	}
+	return append(merged, added...)
+}
+
+// ContextFields 获取上下文中的请求级日志字段
+// 先查 WithFields 写入的标准上下文字段，再查 gin.Context 中以 LogFieldsKey 存放的字段
+func ContextFields(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	if fields, ok := ctx.Value(logFieldsCtxKey{}).([]zap.Field); ok {
+		return fields
+	}
+	if fields, ok := ctx.Value(LogFieldsKey).([]zap.Field); ok {
+		return fields
+	}
+	return nil
+}
+
+// containsField 判断字段列表中是否存在指定键
+func containsField(fields []zap.Field, key string) bool {
+	for _, field := range fields {
+		if field.Key == key {
+			return true
+		}
+	}
+	return false
+}
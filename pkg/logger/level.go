@@ -0,0 +1,237 @@
+package logger
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// modulePathPrefix 本项目的模块路径前缀，解析调用方模块时去掉该前缀
+const modulePathPrefix = "gateway/"
+
+var (
+	// globalLevel 全局日志级别，可在运行时调整
+	globalLevel = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+
+	// moduleLevels 按模块设置的日志级别
+	moduleLevels = &moduleLevelRegistry{
+		levels: make(map[string]zapcore.Level),
+	}
+)
+
+// moduleLevelRegistry 模块日志级别注册表
+//
+// 模块以包路径标识（去掉 gateway/ 前缀），支持 "/" 或 "." 分隔，
+// 例如 internal/gateway/handler/proxy 或 pkg.database。
+// 匹配时取最长前缀，未匹配的包使用全局级别。
+type moduleLevelRegistry struct {
+	mu     sync.RWMutex
+	levels map[string]zapcore.Level
+	// count 模块级别数量，为0时日志调用走快速路径，不解析调用方
+	count atomic.Int32
+	// callerCache 调用方函数入口到模块路径的缓存
+	callerCache sync.Map
+}
+
+// normalizeModule 规范化模块名称
+func normalizeModule(module string) string {
+	module = strings.TrimSpace(module)
+	module = strings.ReplaceAll(module, ".", "/")
+	module = strings.TrimPrefix(module, modulePathPrefix)
+	return strings.Trim(module, "/")
+}
+
+// set 设置模块日志级别
+func (r *moduleLevelRegistry) set(module string, level zapcore.Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.levels[module] = level
+	r.count.Store(int32(len(r.levels)))
+}
+
+// remove 移除模块日志级别
+func (r *moduleLevelRegistry) remove(module string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.levels, module)
+	r.count.Store(int32(len(r.levels)))
+}
+
+// clear 清空所有模块日志级别
+func (r *moduleLevelRegistry) clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.levels = make(map[string]zapcore.Level)
+	r.count.Store(0)
+}
+
+// lookup 按最长前缀查找包路径对应的日志级别
+func (r *moduleLevelRegistry) lookup(pkgPath string) (zapcore.Level, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := ""
+	var level zapcore.Level
+	for module, lvl := range r.levels {
+		if pkgPath != module && !strings.HasPrefix(pkgPath, module+"/") {
+			continue
+		}
+		if len(module) > len(matched) {
+			matched = module
+			level = lvl
+		}
+	}
+	return level, matched != ""
+}
+
+// minLevel 获取全局与所有模块级别中的最低级别
+func (r *moduleLevelRegistry) minLevel(base zapcore.Level) zapcore.Level {
+	if r.count.Load() == 0 {
+		return base
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	lowest := base
+	for _, lvl := range r.levels {
+		if lvl < lowest {
+			lowest = lvl
+		}
+	}
+	return lowest
+}
+
+// snapshot 获取模块级别副本
+func (r *moduleLevelRegistry) snapshot() map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make(map[string]string, len(r.levels))
+	for module, lvl := range r.levels {
+		result[module] = lvl.String()
+	}
+	return result
+}
+
+// callerModule 解析调用方所在包路径（去掉 gateway/ 前缀）
+func (r *moduleLevelRegistry) callerModule(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+	entry := fn.Entry()
+	if cached, ok := r.callerCache.Load(entry); ok {
+		return cached.(string)
+	}
+	pkgPath := packagePathOf(fn.Name())
+	r.callerCache.Store(entry, pkgPath)
+	return pkgPath
+}
+
+// packagePathOf 从函数全名中提取包路径
+// 例如 gateway/internal/gateway/handler/proxy.(*HTTPProxy).ServeHTTP -> internal/gateway/handler/proxy
+func packagePathOf(funcName string) string {
+	lastSlash := strings.LastIndex(funcName, "/")
+	if dot := strings.Index(funcName[lastSlash+1:], "."); dot >= 0 {
+		funcName = funcName[:lastSlash+1+dot]
+	}
+	return strings.TrimPrefix(funcName, modulePathPrefix)
+}
+
+// coreLevelEnabler 输出核心使用的级别过滤器
+// 放行全局与模块级别中的最低级别，具体模块的过滤在 enabled 中完成
+var coreLevelEnabler = zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+	return lvl >= moduleLevels.minLevel(globalLevel.Level())
+})
+
+// enabled 判断调用方是否需要输出该级别的日志
+//
+// 参数:
+//   - lvl: 日志级别
+//   - skip: 从 enabled 的调用方算起需要跳过的栈帧数，日志函数直接调用时为1
+func enabled(lvl zapcore.Level, skip int) bool {
+	if moduleLevels.count.Load() == 0 {
+		return globalLevel.Enabled(lvl)
+	}
+	if moduleLevel, ok := moduleLevels.lookup(moduleLevels.callerModule(skip + 1)); ok {
+		return lvl >= moduleLevel
+	}
+	return globalLevel.Enabled(lvl)
+}
+
+// parseLevel 解析日志级别字符串
+func parseLevel(level string) (zapcore.Level, error) {
+	lvl, err := zapcore.ParseLevel(strings.ToLower(strings.TrimSpace(level)))
+	if err != nil {
+		return zapcore.InfoLevel, fmt.Errorf("无效的日志级别 %q: %w", level, err)
+	}
+	return lvl, nil
+}
+
+// SetLevel 运行时调整全局日志级别，无需重启进程
+//
+// 参数:
+//   - level: 日志级别，支持 debug, info, warn, error, dpanic, panic, fatal
+//
+// 返回:
+//   - error: 级别无效时返回错误
+func SetLevel(level string) error {
+	lvl, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+	globalLevel.SetLevel(lvl)
+	return nil
+}
+
+// GetLevel 获取当前全局日志级别
+func GetLevel() string {
+	return globalLevel.Level().String()
+}
+
+// SetModuleLevel 运行时调整指定模块的日志级别
+//
+// 模块使用包路径表示，可省略 gateway/ 前缀，"/" 与 "." 均可作为分隔符。
+// 子包继承父包的设置，例如设置 internal/gateway 为 debug 后，
+// internal/gateway/handler/proxy 同样输出调试日志。
+//
+// 参数:
+//   - module: 模块（包路径）
+//   - level: 日志级别
+//
+// 返回:
+//   - error: 模块为空或级别无效时返回错误
+func SetModuleLevel(module, level string) error {
+	module = normalizeModule(module)
+	if module == "" {
+		return fmt.Errorf("模块名称不能为空")
+	}
+	lvl, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+	moduleLevels.set(module, lvl)
+	return nil
+}
+
+// ResetModuleLevel 移除模块日志级别设置，恢复使用全局级别
+func ResetModuleLevel(module string) {
+	moduleLevels.remove(normalizeModule(module))
+}
+
+// ResetAllModuleLevels 移除所有模块日志级别设置
+func ResetAllModuleLevels() {
+	moduleLevels.clear()
+}
+
+// GetModuleLevels 获取所有模块日志级别设置
+func GetModuleLevels() map[string]string {
+	return moduleLevels.snapshot()
+}
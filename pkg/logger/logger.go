@@ -13,7 +13,6 @@ import (
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var (
@@ -36,7 +35,7 @@ const (
 type LoggerConfig struct {
 	// Level 日志级别
 	Level string `mapstructure:"level"`
-	// Encoding 编码格式
+	// Encoding 编码格式: json, console, structured（每行一个JSON对象，含traceId/tenantId/module等字段）
 	Encoding string `mapstructure:"encoding"`
 	// ShowCaller 是否显示调用者信息
 	ShowCaller bool `mapstructure:"show_caller"`
@@ -64,6 +63,23 @@ type LoggerConfig struct {
 	MaxAge int `mapstructure:"max_age"`
 	// Compress 是否压缩旧日志文件
 	Compress bool `mapstructure:"compress"`
+	// RotateInterval 按时间轮转的周期: hourly, daily，为空时只按大小轮转
+	RotateInterval string `mapstructure:"rotate_interval"`
+	// Rotation 按输出单独设置的轮转策略，键为 default/error/warn/info/debug/tenant
+	Rotation map[string]RotationPolicy `mapstructure:"rotation"`
+
+	// Sampling 相同消息的采样配置，用于抑制故障风暴产生的重复日志
+	Sampling *SamplingConfig `mapstructure:"sampling"`
+
+	// ModuleLevels 按模块（包路径）设置的日志级别，如 internal/gateway/handler/proxy: debug
+	ModuleLevels map[string]string `mapstructure:"module_levels"`
+
+	// Masking 开启敏感信息脱敏，开启后密码、令牌、私钥等内容脱敏后输出
+	// 脱敏对每条日志的消息和字符串字段执行多次正则匹配，默认关闭，按需开启
+	Masking bool `mapstructure:"masking"`
+
+	// TenantSegregation 按租户分离日志，带 tenantId 的日志写入租户专属的输出
+	TenantSegregation *TenantSegregationConfig `mapstructure:"tenant_segregation"`
 }
 
 // Setup 设置日志，从配置文件加载
@@ -91,7 +107,7 @@ func Setup() error {
 // 主要功能包括：
 // 1. 解析日志级别和堆栈跟踪级别
 // 2. 创建日志目录
-// 3. 配置编码器（JSON或Console格式）
+// 3. 配置编码器（JSON、结构化JSON或Console格式）
 // 4. 设置多个输出目标（默认、错误、信息、调试）
 // 5. 集成日志轮转功能
 //
@@ -123,6 +139,15 @@ func Init(config *LoggerConfig) error {
 	if err != nil {
 		level = zapcore.InfoLevel // 解析失败时使用信息级别
 	}
+	globalLevel.SetLevel(level)
+
+	// 加载模块日志级别，无效配置忽略
+	ResetAllModuleLevels()
+	for module, moduleLevel := range config.ModuleLevels {
+		if err := SetModuleLevel(module, moduleLevel); err != nil {
+			fmt.Printf("忽略模块 %s 的日志级别配置: %v\n", module, err)
+		}
+	}
 
 	// 解析堆栈跟踪级别
 	// 只有达到此级别的日志才会包含堆栈跟踪信息
@@ -145,9 +170,16 @@ func Init(config *LoggerConfig) error {
 	encoderConfig := zap.NewProductionEncoderConfig()
 	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder // 使用ISO8601时间格式
 
-	if config.Encoding == "json" {
+	structured := config.Encoding == EncodingStructured
+	traceIDFieldKey = TraceIDKey
+	switch {
+	case structured:
+		// 结构化JSON格式，固定字段名，便于日志平台直接采集
+		encoder = zapcore.NewJSONEncoder(newStructuredEncoderConfig())
+		traceIDFieldKey = structuredTraceIDKey
+	case config.Encoding == "json":
 		encoder = zapcore.NewJSONEncoder(encoderConfig) // JSON格式，适合生产环境
-	} else {
+	default:
 		encoder = zapcore.NewConsoleEncoder(encoderConfig) // 控制台格式，适合开发环境
 	}
 
@@ -156,22 +188,22 @@ func Init(config *LoggerConfig) error {
 	var cores []zapcore.Core
 
 	// 默认输出核心
-	// 处理所有达到最低级别的日志
-	if defaultWriter := getWriteSyncer(config.DefaultOutput, config.LogPath, config); defaultWriter != nil {
-		defaultCore := zapcore.NewCore(encoder, defaultWriter, level)
+	// 处理所有达到最低级别的日志，级别支持运行时调整（见 SetLevel/SetModuleLevel）
+	if defaultWriter := getWriteSyncer(config.DefaultOutput, config.LogPath, config, OutputDefault); defaultWriter != nil {
+		defaultCore := zapcore.NewCore(encoder, defaultWriter, coreLevelEnabler)
 		cores = append(cores, defaultCore)
 	}
 
 	// 错误日志输出核心
 	// 只处理错误级别及以上的日志，避免重复输出
 	if config.ErrorOutput != "" && config.ErrorOutput != config.DefaultOutput {
-		errorWriter := getWriteSyncer(config.ErrorOutput, config.LogPath, config)
+		errorWriter := getWriteSyncer(config.ErrorOutput, config.LogPath, config, OutputError)
 		if errorWriter != nil {
 			errorCore := zapcore.NewCore(
 				encoder,
 				errorWriter,
 				zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-					return lvl >= zapcore.ErrorLevel && lvl >= level
+					return lvl >= zapcore.ErrorLevel && coreLevelEnabler(lvl)
 				}),
 			)
 			cores = append(cores, errorCore)
@@ -181,13 +213,13 @@ func Init(config *LoggerConfig) error {
 	// 警告日志输出核心
 	// 只处理警告级别的日志，实现日志分级存储
 	if config.WarnOutput != "" && config.WarnOutput != config.DefaultOutput {
-		warnWriter := getWriteSyncer(config.WarnOutput, config.LogPath, config)
+		warnWriter := getWriteSyncer(config.WarnOutput, config.LogPath, config, OutputWarn)
 		if warnWriter != nil {
 			warnCore := zapcore.NewCore(
 				encoder,
 				warnWriter,
 				zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-					return lvl == zapcore.WarnLevel && lvl >= level
+					return lvl == zapcore.WarnLevel && coreLevelEnabler(lvl)
 				}),
 			)
 			cores = append(cores, warnCore)
@@ -197,13 +229,13 @@ func Init(config *LoggerConfig) error {
 	// 信息日志输出核心
 	// 只处理信息级别的日志，实现日志分级存储
 	if config.InfoOutput != "" && config.InfoOutput != config.DefaultOutput {
-		infoWriter := getWriteSyncer(config.InfoOutput, config.LogPath, config)
+		infoWriter := getWriteSyncer(config.InfoOutput, config.LogPath, config, OutputInfo)
 		if infoWriter != nil {
 			infoCore := zapcore.NewCore(
 				encoder,
 				infoWriter,
 				zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-					return lvl == zapcore.InfoLevel && lvl >= level
+					return lvl == zapcore.InfoLevel && coreLevelEnabler(lvl)
 				}),
 			)
 			cores = append(cores, infoCore)
@@ -213,29 +245,52 @@ func Init(config *LoggerConfig) error {
 	// 调试日志输出核心
 	// 只处理调试级别的日志，用于开发时的详细信息
 	if config.DebugOutput != "" && config.DebugOutput != config.DefaultOutput {
-		debugWriter := getWriteSyncer(config.DebugOutput, config.LogPath, config)
+		debugWriter := getWriteSyncer(config.DebugOutput, config.LogPath, config, OutputDebug)
 		if debugWriter != nil {
 			debugCore := zapcore.NewCore(
 				encoder,
 				debugWriter,
 				zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-					return lvl == zapcore.DebugLevel && lvl >= level
+					return lvl == zapcore.DebugLevel && coreLevelEnabler(lvl)
 				}),
 			)
 			cores = append(cores, debugCore)
 		}
 	}
 
+	if structured {
+		// 结构化模式追加调用方模块字段
+		// 逐个包装输出核心：包装Tee会让本核心接管写入，绕过各输出核心的级别过滤
+		for i := range cores {
+			cores[i] = newModuleCore(cores[i])
+		}
+	}
+
 	// 合并多个核心
 	// 使用Tee将所有核心组合成一个，实现多目标输出
 	core := zapcore.NewTee(cores...)
 
+	// 按租户分离日志，带 tenantId 的日志写入租户专属的输出
+	if router := newTenantRouter(config, encoder); router != nil {
+		core = newTenantCore(core, router)
+	}
+
+	if config.Masking {
+		// 输出前脱敏密码、令牌等敏感信息
+		// 在Tee之前包装一次，每条日志只脱敏一次，默认输出和租户输出共用脱敏结果
+		core = newMaskingCore(core)
+	}
+
+	// 相同消息采样，被丢弃的数量定期汇总输出
+	core = applySampling(core, config.Sampling)
+
 	// 添加日志选项
 	options := []zap.Option{}
 
-	// 添加调用者信息（文件名、行号、函数名）
-	if config.ShowCaller {
-		options = append(options, zap.AddCaller())
+	// 添加调用者信息（文件名、行号、函数名），结构化模式依赖调用者信息解析模块
+	// 跳过本包的日志函数，记录真实调用方
+	if config.ShowCaller || structured {
+		options = append(options, zap.AddCaller(), zap.AddCallerSkip(1))
 	}
 
 	// 添加堆栈跟踪，用于错误诊断
@@ -256,10 +311,11 @@ func Init(config *LoggerConfig) error {
 //   - output: 输出目标路径或特殊值(stdout/stderr)
 //   - logPath: 日志文件根目录，用于相对路径拼接
 //   - logConfig: 日志配置对象，包含轮转参数
+//   - name: 输出名称，用于查找该输出单独配置的轮转策略
 //
 // 返回:
 //   - zapcore.WriteSyncer: 可用于zap的写入器，失败时返回nil
-func getWriteSyncer(output string, logPath string, logConfig *LoggerConfig) zapcore.WriteSyncer {
+func getWriteSyncer(output string, logPath string, logConfig *LoggerConfig, name string) zapcore.WriteSyncer {
 	// 空输出路径检查
 	if output == "" {
 		return nil
@@ -304,19 +360,12 @@ func getWriteSyncer(output string, logPath string, logConfig *LoggerConfig) zapc
 	// lumberjack 提供了基于文件大小、文件数量、文件年龄的自动轮转功能
 	// 轮转规则：
 	// 1. 当文件大小超过 MaxSize 时触发轮转
-	// 2. 保留最多 MaxBackups 个旧文件
-	// 3. 删除超过 MaxAge 天的旧文件
-	// 4. 根据 Compress 配置决定是否压缩旧文件
-	lumberjackLogger := &lumberjack.Logger{
-		Filename:   output,                   // 日志文件路径
-		MaxSize:    getMaxSize(logConfig),    // 单个日志文件最大尺寸(MB)
-		MaxBackups: getMaxBackups(logConfig), // 保留的旧日志文件最大数量
-		MaxAge:     getMaxAge(logConfig),     // 保留的旧日志文件最大天数
-		Compress:   getCompress(logConfig),   // 是否压缩旧日志文件
-		LocalTime:  true,                     // 使用本地时间命名轮转文件
-	}
-
-	return zapcore.AddSync(lumberjackLogger)
+	// 2. 配置了 Interval 时跨越整点/零点触发轮转
+	// 3. 保留最多 MaxBackups 个旧文件
+	// 4. 删除超过 MaxAge 天的旧文件
+	// 5. 根据 Compress 配置决定是否gzip压缩旧文件
+	// 每个输出可通过 Rotation 单独覆盖以上参数
+	return newRotateWriter(output, resolveRotationPolicy(logConfig, name))
 }
 
 // getMaxSize 获取日志文件最大尺寸配置
@@ -418,7 +467,7 @@ func ensureDir(dirPath string) error {
 //   - msg: 日志消息内容
 //   - args: 可变参数，支持多种格式的附加信息
 func Info(msg string, args ...any) {
-	if log == nil {
+	if log == nil || !enabled(zapcore.InfoLevel, 1) {
 		return
 	}
 	log.Info(msg, parseArgs(args...)...)
@@ -434,7 +483,7 @@ func Info(msg string, args ...any) {
 //   - msg: 日志消息内容
 //   - args: 可变参数，支持多种格式的附加信息
 func InfoWithTrace(ctx context.Context, msg string, args ...any) {
-	if log == nil {
+	if log == nil || !enabled(zapcore.InfoLevel, 1) {
 		return
 	}
 	fields := parseArgs(args...)
@@ -453,7 +502,7 @@ func InfoWithTrace(ctx context.Context, msg string, args ...any) {
 //   - msg: 日志消息内容
 //   - args: 可变参数，支持多种格式的附加信息
 func Debug(msg string, args ...any) {
-	if log == nil {
+	if log == nil || !enabled(zapcore.DebugLevel, 1) {
 		return
 	}
 	log.Debug(msg, parseArgs(args...)...)
@@ -468,7 +517,7 @@ func Debug(msg string, args ...any) {
 //   - msg: 日志消息内容
 //   - args: 可变参数，支持多种格式的附加信息
 func DebugWithTrace(ctx context.Context, msg string, args ...any) {
-	if log == nil {
+	if log == nil || !enabled(zapcore.DebugLevel, 1) {
 		return
 	}
 	fields := parseArgs(args...)
@@ -487,7 +536,7 @@ func DebugWithTrace(ctx context.Context, msg string, args ...any) {
 //   - msg: 日志消息内容
 //   - args: 可变参数，支持多种格式的附加信息
 func Warn(msg string, args ...any) {
-	if log == nil {
+	if log == nil || !enabled(zapcore.WarnLevel, 1) {
 		return
 	}
 	log.Warn(msg, parseArgs(args...)...)
@@ -502,7 +551,7 @@ func Warn(msg string, args ...any) {
 //   - msg: 日志消息内容
 //   - args: 可变参数，支持多种格式的附加信息
 func WarnWithTrace(ctx context.Context, msg string, args ...any) {
-	if log == nil {
+	if log == nil || !enabled(zapcore.WarnLevel, 1) {
 		return
 	}
 	fields := parseArgs(args...)
@@ -523,7 +572,7 @@ func WarnWithTrace(ctx context.Context, msg string, args ...any) {
 //   - msg: 日志消息内容
 //   - args: 可变参数，支持多种格式的附加信息
 func Error(msg string, args ...any) {
-	if log == nil {
+	if log == nil || !enabled(zapcore.ErrorLevel, 1) {
 		return
 	}
 
@@ -555,7 +604,7 @@ func Error(msg string, args ...any) {
 //   - msg: 日志消息内容
 //   - args: 可变参数，支持多种格式的附加信息
 func ErrorWithTrace(ctx context.Context, msg string, args ...any) {
-	if log == nil {
+	if log == nil || !enabled(zapcore.ErrorLevel, 1) {
 		return
 	}
 
@@ -856,7 +905,7 @@ func appendTraceID(ctx context.Context, fields []zap.Field) []zap.Field {
 	}
 
 	if traceID := getTraceIDFromContext(ctx); traceID != "" {
-		fields = append(fields, zap.String("trace_id", traceID))
+		fields = append(fields, zap.String(traceIDFieldKey, traceID))
 	}
 
 	// 添加用户ID
@@ -874,6 +923,13 @@ func appendTraceID(ctx context.Context, fields []zap.Field) []zap.Field {
 		fields = append(fields, zap.String("tenantId", tenantID))
 	}
 
+	// 添加请求入口通过 WithFields 附加的字段（路由、客户端IP等），调用点传入的同名字段优先
+	for _, field := range ContextFields(ctx) {
+		if !containsField(fields, field.Key) {
+			fields = append(fields, field)
+		}
+	}
+
 	return fields
 }
 
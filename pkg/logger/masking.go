@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"fmt"
+
+	"gateway/pkg/utils/mask"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// maskingCore 输出前脱敏敏感信息的核心包装
+// 敏感键名（password、token 等）的字段值整体替换为掩码，
+// 消息、字符串字段和错误信息中的密码、令牌、私钥等内容按 mask.Sanitize 规则脱敏。
+// 包装在Tee之外，每条日志只脱敏一次，脱敏后经内层核心的 Check 分发到各输出
+type maskingCore struct {
+	zapcore.Core
+}
+
+// newMaskingCore 包装合并后的核心，写入前脱敏
+func newMaskingCore(core zapcore.Core) zapcore.Core {
+	return &maskingCore{Core: core}
+}
+
+// With 添加字段并保持包装，预置字段同样脱敏
+func (c *maskingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &maskingCore{Core: c.Core.With(maskFields(fields))}
+}
+
+// Check 检查日志级别，通过时由本核心负责写入
+func (c *maskingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write 脱敏消息和字段后写入
+func (c *maskingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = mask.Sanitize(ent.Message)
+	ent.Stack = mask.Sanitize(ent.Stack)
+	return writeThroughCheck(c.Core, ent, maskFields(fields))
+}
+
+// maskFields 脱敏字段列表，返回新切片，不修改调用方传入的字段
+func maskFields(fields []zapcore.Field) []zapcore.Field {
+	if len(fields) == 0 {
+		return fields
+	}
+	masked := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		masked[i] = maskField(f)
+	}
+	return masked
+}
+
+// maskField 脱敏单个字段
+func maskField(f zapcore.Field) zapcore.Field {
+	if f.Type == zapcore.NamespaceType || f.Type == zapcore.SkipType {
+		return f
+	}
+
+	if mask.IsSensitiveKey(f.Key) {
+		if f.Type == zapcore.StringType && f.String == "" {
+			return f
+		}
+		return zap.String(f.Key, mask.Masked)
+	}
+
+	switch f.Type {
+	case zapcore.StringType:
+		f.String = mask.Sanitize(f.String)
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok && err != nil {
+			return zap.String(f.Key, mask.Sanitize(err.Error()))
+		}
+	case zapcore.StringerType:
+		if s, ok := f.Interface.(fmt.Stringer); ok && s != nil {
+			return zap.String(f.Key, mask.Sanitize(s.String()))
+		}
+	case zapcore.ByteStringType:
+		if b, ok := f.Interface.([]byte); ok {
+			return zap.ByteString(f.Key, []byte(mask.Sanitize(string(b))))
+		}
+	case zapcore.ReflectType:
+		switch f.Interface.(type) {
+		case map[string]interface{}, map[string]string, []interface{}, []string:
+			return zap.Any(f.Key, mask.SanitizeValue("", f.Interface))
+		}
+	}
+	return f
+}
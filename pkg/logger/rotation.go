@@ -0,0 +1,192 @@
+package logger
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// 日志输出名称，用于按输出配置轮转策略
+const (
+	OutputDefault = "default"
+	OutputError   = "error"
+	OutputWarn    = "warn"
+	OutputInfo    = "info"
+	OutputDebug   = "debug"
+)
+
+// 按时间轮转的周期
+const (
+	RotateNone   = ""
+	RotateHourly = "hourly"
+	RotateDaily  = "daily"
+)
+
+var (
+	// rotationNow 按时间轮转时获取当前时间的时钟
+	rotationNow = time.Now
+	// rotationNowMu 保护 rotationNow 的读写
+	rotationNowMu sync.RWMutex
+)
+
+// SetRotationClock 替换按时间轮转使用的时钟，用于测试跨越整点或零点的轮转
+// 参数:
+//   - now: 返回当前时间的函数，传入nil恢复为系统时钟
+func SetRotationClock(now func() time.Time) {
+	rotationNowMu.Lock()
+	defer rotationNowMu.Unlock()
+	if now == nil {
+		now = time.Now
+	}
+	rotationNow = now
+}
+
+// currentRotationTime 获取按时间轮转使用的当前时间
+func currentRotationTime() time.Time {
+	rotationNowMu.RLock()
+	defer rotationNowMu.RUnlock()
+	return rotationNow()
+}
+
+// RotationPolicy 单个日志输出文件的轮转策略
+// 未配置的字段继承 LoggerConfig 中的全局设置
+type RotationPolicy struct {
+	// MaxSize 单个日志文件最大尺寸(MB)
+	MaxSize int `mapstructure:"max_size"`
+	// MaxBackups 保留的旧日志文件最大数量
+	MaxBackups int `mapstructure:"max_backups"`
+	// MaxAge 保留的旧日志文件最大天数
+	MaxAge int `mapstructure:"max_age"`
+	// Compress 是否使用gzip压缩旧日志文件
+	Compress *bool `mapstructure:"compress"`
+	// Interval 按时间轮转的周期: hourly, daily，为空时只按大小轮转
+	Interval string `mapstructure:"interval"`
+}
+
+// resolveRotationPolicy 合并全局设置与指定输出的轮转策略
+//
+// 参数:
+//   - config: 日志配置对象
+//   - name: 输出名称（default/error/warn/info/debug/tenant）
+//
+// 返回:
+//   - RotationPolicy: 字段均已填充的轮转策略
+func resolveRotationPolicy(config *LoggerConfig, name string) RotationPolicy {
+	compress := getCompress(config)
+	policy := RotationPolicy{
+		MaxSize:    getMaxSize(config),
+		MaxBackups: getMaxBackups(config),
+		MaxAge:     getMaxAge(config),
+		Compress:   &compress,
+	}
+	if config != nil {
+		policy.Interval = normalizeInterval(config.RotateInterval)
+	}
+
+	if config == nil || config.Rotation == nil {
+		return policy
+	}
+	override, ok := config.Rotation[name]
+	if !ok {
+		return policy
+	}
+	if override.MaxSize > 0 {
+		policy.MaxSize = override.MaxSize
+	}
+	if override.MaxBackups > 0 {
+		policy.MaxBackups = override.MaxBackups
+	}
+	if override.MaxAge > 0 {
+		policy.MaxAge = override.MaxAge
+	}
+	if override.Compress != nil {
+		policy.Compress = override.Compress
+	}
+	if override.Interval != "" {
+		policy.Interval = normalizeInterval(override.Interval)
+	}
+	return policy
+}
+
+// normalizeInterval 规范化轮转周期，无法识别时不按时间轮转
+func normalizeInterval(interval string) string {
+	switch strings.ToLower(strings.TrimSpace(interval)) {
+	case RotateHourly:
+		return RotateHourly
+	case RotateDaily:
+		return RotateDaily
+	default:
+		return RotateNone
+	}
+}
+
+// newRotateWriter 根据轮转策略创建文件写入器
+func newRotateWriter(filename string, policy RotationPolicy) *timedRotateWriter {
+	return &timedRotateWriter{
+		logger: &lumberjack.Logger{
+			Filename:   filename,
+			MaxSize:    policy.MaxSize,
+			MaxBackups: policy.MaxBackups,
+			MaxAge:     policy.MaxAge,
+			Compress:   policy.Compress != nil && *policy.Compress,
+			LocalTime:  true,
+		},
+		interval: policy.Interval,
+	}
+}
+
+// timedRotateWriter 在 lumberjack 按大小轮转的基础上增加按时间轮转
+//
+// 写入时发现跨越了周期边界（整点或零点）则先触发一次轮转，
+// 备份数量、保留天数和压缩仍由 lumberjack 统一处理。
+type timedRotateWriter struct {
+	mu       sync.Mutex
+	logger   *lumberjack.Logger
+	interval string
+	// periodStart 当前文件所属周期的起始时间
+	periodStart time.Time
+}
+
+// Write 写入日志，必要时先按时间轮转
+func (w *timedRotateWriter) Write(p []byte) (int, error) {
+	if w.interval != RotateNone {
+		w.mu.Lock()
+		start := w.truncate(currentRotationTime())
+		if w.periodStart.IsZero() {
+			// 首次写入时以已有文件的修改时间作为周期起点，进程重启后也能按时轮转
+			w.periodStart = start
+			if info, err := os.Stat(w.logger.Filename); err == nil {
+				w.periodStart = w.truncate(info.ModTime())
+			}
+		}
+		if start.After(w.periodStart) {
+			w.periodStart = start
+			if err := w.logger.Rotate(); err != nil {
+				w.mu.Unlock()
+				return 0, err
+			}
+		}
+		w.mu.Unlock()
+	}
+	return w.logger.Write(p)
+}
+
+// Sync 满足 zapcore.WriteSyncer 接口，lumberjack 每次写入直接落盘
+func (w *timedRotateWriter) Sync() error {
+	return nil
+}
+
+// truncate 计算时间所在周期的起始时间
+func (w *timedRotateWriter) truncate(t time.Time) time.Time {
+	switch w.interval {
+	case RotateHourly:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	case RotateDaily:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	default:
+		return time.Time{}
+	}
+}
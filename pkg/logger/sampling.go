@@ -0,0 +1,197 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SamplingConfig 日志采样配置
+//
+// 同一级别、相同消息的日志在每个周期内先输出前 Initial 条，
+// 之后每 Thereafter 条输出1条，被丢弃的数量在周期结束后汇总输出一次，
+// 避免后端宕机、心跳失败等故障风暴写满磁盘。
+type SamplingConfig struct {
+	// Enabled 是否启用采样
+	Enabled bool `mapstructure:"enabled"`
+	// Interval 采样周期(秒)
+	Interval int `mapstructure:"interval"`
+	// Initial 每个周期内相同消息完整输出的条数
+	Initial int `mapstructure:"initial"`
+	// Thereafter 超过 Initial 后每多少条输出1条，0表示全部丢弃
+	Thereafter int `mapstructure:"thereafter"`
+}
+
+// suppressedSummaryMsg 抑制汇总日志的消息内容
+const suppressedSummaryMsg = "suppressed similar log messages"
+
+// suppressionKey 抑制计数的键
+type suppressionKey struct {
+	level   zapcore.Level
+	message string
+}
+
+// suppressionTracker 统计被采样或限流丢弃的日志并定期输出汇总
+type suppressionTracker struct {
+	mu      sync.Mutex
+	dropped map[suppressionKey]int64
+	// limited 按键限流的状态
+	limited map[string]*rateLimitState
+	// output 输出汇总使用的日志实例，不经过采样
+	output *zap.Logger
+	stop   chan struct{}
+}
+
+// rateLimitState 单个限流键的状态
+type rateLimitState struct {
+	last       time.Time
+	suppressed int64
+}
+
+// suppression 全局抑制统计
+var suppression = &suppressionTracker{
+	dropped: make(map[suppressionKey]int64),
+	limited: make(map[string]*rateLimitState),
+}
+
+// start 启动汇总输出，重复调用时替换之前的输出协程
+func (t *suppressionTracker) start(output *zap.Logger, interval time.Duration) {
+	t.mu.Lock()
+	if t.stop != nil {
+		close(t.stop)
+	}
+	t.output = output
+	stop := make(chan struct{})
+	t.stop = stop
+	t.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				t.flush()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// recordDropped 采样钩子，记录被丢弃的日志
+func (t *suppressionTracker) recordDropped(ent zapcore.Entry, dec zapcore.SamplingDecision) {
+	if dec&zapcore.LogDropped == 0 {
+		return
+	}
+	t.mu.Lock()
+	t.dropped[suppressionKey{level: ent.Level, message: ent.Message}]++
+	t.mu.Unlock()
+}
+
+// allow 判断限流键在周期内是否允许输出
+func (t *suppressionTracker) allow(key string, interval time.Duration) bool {
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state, ok := t.limited[key]
+	if !ok {
+		t.limited[key] = &rateLimitState{last: now}
+		return true
+	}
+	if now.Sub(state.last) >= interval {
+		state.last = now
+		return true
+	}
+	state.suppressed++
+	return false
+}
+
+// flush 输出本周期的抑制汇总并清零计数
+func (t *suppressionTracker) flush() {
+	t.mu.Lock()
+	output := t.output
+	dropped := t.dropped
+	t.dropped = make(map[suppressionKey]int64)
+	limited := make(map[string]int64)
+	for key, state := range t.limited {
+		if state.suppressed > 0 {
+			limited[key] = state.suppressed
+			state.suppressed = 0
+		}
+		// 长时间没有日志的限流键不再保留
+		if time.Since(state.last) > time.Hour {
+			delete(t.limited, key)
+		}
+	}
+	t.mu.Unlock()
+
+	if output == nil {
+		return
+	}
+	for key, count := range dropped {
+		output.Warn(suppressedSummaryMsg,
+			zap.String("level", key.level.String()),
+			zap.String("similarMessage", key.message),
+			zap.Int64("suppressed", count))
+	}
+	for key, count := range limited {
+		output.Warn(suppressedSummaryMsg,
+			zap.String("rateLimitKey", key),
+			zap.Int64("suppressed", count))
+	}
+}
+
+// applySampling 按配置为输出核心增加采样
+//
+// 参数:
+//   - core: 原始输出核心
+//   - cfg: 采样配置，为nil或未启用时原样返回
+//
+// 返回:
+//   - zapcore.Core: 采样后的核心
+func applySampling(core zapcore.Core, cfg *SamplingConfig) zapcore.Core {
+	interval := time.Minute
+	if cfg != nil && cfg.Interval > 0 {
+		interval = time.Duration(cfg.Interval) * time.Second
+	}
+	// 汇总直接写入原始核心，不受采样影响；按键限流不依赖采样配置，同样需要汇总
+	suppression.start(zap.New(core), interval)
+
+	if cfg == nil || !cfg.Enabled {
+		return core
+	}
+	initial := cfg.Initial
+	if initial <= 0 {
+		initial = 100
+	}
+	return zapcore.NewSamplerWithOptions(core, interval, initial, cfg.Thereafter,
+		zapcore.SamplerHook(suppression.recordDropped))
+}
+
+// RateLimit 按键限流，同一个键在 interval 内只允许输出一次
+//
+// 用于心跳失败、后端不可用等会持续重复的日志，被限流的次数会在
+// 采样周期结束时以 "suppressed similar log messages" 汇总输出。
+// 用法:
+//
+//	if logger.RateLimit("heartbeat:"+nodeId, time.Minute) {
+//	    logger.Warn("心跳失败", "nodeId", nodeId, "error", err)
+//	}
+//
+// 参数:
+//   - key: 限流键，通常为 场景+对象ID
+//   - interval: 限流周期
+//
+// 返回:
+//   - bool: 是否允许输出
+func RateLimit(key string, interval time.Duration) bool {
+	return suppression.allow(key, interval)
+}
+
+// FlushSuppressed 立即输出当前的抑制汇总，通常在进程退出前调用
+func FlushSuppressed() {
+	suppression.flush()
+}
@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// EncodingStructured 结构化JSON编码模式
+//
+// 每行输出一个JSON对象，字段名固定，便于 ELK/ClickHouse 直接采集：
+// timestamp、level、message、caller、module，以及 *WithTrace 系列函数
+// 从上下文提取的 traceId、tenantId、userId、userName。
+const EncodingStructured = "structured"
+
+// 结构化模式下的字段名
+const (
+	structuredTraceIDKey = "traceId"
+	structuredModuleKey  = "module"
+)
+
+// traceIDFieldKey 跟踪ID的输出字段名，结构化模式下为 traceId
+var traceIDFieldKey = TraceIDKey
+
+// newStructuredEncoderConfig 创建结构化JSON编码器配置
+func newStructuredEncoderConfig() zapcore.EncoderConfig {
+	return zapcore.EncoderConfig{
+		TimeKey:        "timestamp",
+		LevelKey:       "level",
+		NameKey:        "logger",
+		CallerKey:      "caller",
+		MessageKey:     "message",
+		StacktraceKey:  "stacktrace",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.RFC3339NanoTimeEncoder,
+		EncodeDuration: zapcore.MillisDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	}
+}
+
+// moduleCore 为每条日志追加调用方模块字段的核心包装
+// 模块取调用函数所在包路径（去掉 gateway/ 前缀），与 SetModuleLevel 的模块名称一致
+type moduleCore struct {
+	zapcore.Core
+}
+
+// newModuleCore 包装输出核心，追加 module 字段
+func newModuleCore(core zapcore.Core) zapcore.Core {
+	return &moduleCore{Core: core}
+}
+
+// With 添加字段并保持包装
+func (c *moduleCore) With(fields []zapcore.Field) zapcore.Core {
+	return &moduleCore{Core: c.Core.With(fields)}
+}
+
+// Check 检查日志级别，通过时由本核心负责写入
+func (c *moduleCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write 写入日志，追加 module 字段
+func (c *moduleCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ent.Caller.Defined && ent.Caller.Function != "" {
+		fields = append(fields, zap.String(structuredModuleKey, packagePathOf(ent.Caller.Function)))
+	}
+	return c.Core.Write(ent, fields)
+}
@@ -0,0 +1,233 @@
+package logger
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// OutputTenant 租户日志输出名称，用于在 Rotation 中配置租户日志文件的轮转策略
+const OutputTenant = "tenant"
+
+// tenantPlaceholder 租户输出路径模板中的租户ID占位符
+const tenantPlaceholder = "{tenantId}"
+
+// defaultMaxTenantOutputs 按模板创建的租户输出数量上限默认值
+const defaultMaxTenantOutputs = 100
+
+// TenantSegregationConfig 按租户分离日志的配置
+//
+// 携带 tenantId 字段的日志（上下文中的租户ID或调用方传入的 "tenantId" 键值）
+// 写入该租户专属的输出，满足托管部署下租户间日志数据隔离的要求。
+// 未携带 tenantId 的日志以及没有对应输出的租户日志仍写入默认输出。
+type TenantSegregationConfig struct {
+	// Enabled 是否启用按租户分离日志
+	Enabled bool `mapstructure:"enabled"`
+	// Tenants 租户ID到输出的映射，输出可以是 stdout、stderr 或文件路径，相对路径与 log_path 拼接
+	Tenants map[string]string `mapstructure:"tenants"`
+	// DefaultOutput 未在 Tenants 中配置的租户使用的输出路径模板，{tenantId} 替换为租户ID；为空时这些租户的日志写入默认输出
+	DefaultOutput string `mapstructure:"default_output"`
+	// MaxTenants 按模板创建的租户输出数量上限，超过后新租户的日志写入默认输出，默认100
+	MaxTenants int `mapstructure:"max_tenants"`
+	// KeepInDefault 租户日志写入租户输出后是否仍写入默认输出，默认false，即租户日志只写入租户输出
+	KeepInDefault bool `mapstructure:"keep_in_default"`
+}
+
+// tenantRouter 租户输出的创建和缓存
+type tenantRouter struct {
+	mu sync.Mutex
+	// cores 租户ID到输出核心，nil 表示该租户没有单独的输出
+	cores map[string]zapcore.Core
+	// templated 按模板创建的租户输出数量
+	templated int
+	config    TenantSegregationConfig
+	// newCore 按输出路径创建输出核心，返回 nil 表示输出不可用
+	newCore func(output string) zapcore.Core
+}
+
+// newTenantRouter 创建租户输出路由
+// 租户输出与默认输出使用相同的编码器和级别过滤，脱敏在分发前统一完成
+//
+// 参数:
+//   - config: 日志配置对象，TenantSegregation 未启用时返回 nil
+//   - encoder: 日志编码器
+//
+// 返回:
+//   - *tenantRouter: 租户输出路由，未启用按租户分离日志时为 nil
+func newTenantRouter(config *LoggerConfig, encoder zapcore.Encoder) *tenantRouter {
+	if config.TenantSegregation == nil || !config.TenantSegregation.Enabled {
+		return nil
+	}
+	return &tenantRouter{
+		cores:  make(map[string]zapcore.Core),
+		config: *config.TenantSegregation,
+		newCore: func(output string) zapcore.Core {
+			writer := getWriteSyncer(output, config.LogPath, config, OutputTenant)
+			if writer == nil {
+				return nil
+			}
+			var core zapcore.Core = zapcore.NewCore(encoder.Clone(), writer, coreLevelEnabler)
+			if config.Encoding == EncodingStructured {
+				core = newModuleCore(core)
+			}
+			return core
+		},
+	}
+}
+
+// coreFor 返回租户的输出核心，配置了映射的租户和按模板创建的租户在首次写入时创建
+func (r *tenantRouter) coreFor(tenantId string) zapcore.Core {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if core, ok := r.cores[tenantId]; ok {
+		return core
+	}
+
+	var core zapcore.Core
+	if output, ok := r.config.Tenants[tenantId]; ok {
+		core = r.newCore(output)
+	} else if r.config.DefaultOutput != "" {
+		maxTenants := r.config.MaxTenants
+		if maxTenants <= 0 {
+			maxTenants = defaultMaxTenantOutputs
+		}
+		if r.templated >= maxTenants {
+			// 超过上限不再创建，也不缓存，避免异常的租户ID无限占用文件句柄和内存
+			return nil
+		}
+		output := strings.ReplaceAll(r.config.DefaultOutput, tenantPlaceholder, sanitizeTenantId(tenantId))
+		core = r.newCore(output)
+		r.templated++
+	}
+	r.cores[tenantId] = core
+	return core
+}
+
+// sync 刷新全部租户输出
+func (r *tenantRouter) sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var err error
+	for _, core := range r.cores {
+		if core != nil {
+			err = errors.Join(err, core.Sync())
+		}
+	}
+	return err
+}
+
+// tenantCore 按租户分发日志的核心包装
+// 日志字段或 With 预置字段中带有 tenantId 且该租户有单独输出时写入租户输出，否则写入默认核心
+type tenantCore struct {
+	zapcore.Core
+	router *tenantRouter
+	// fields With 预置的字段，租户输出核心不经过 With，写入时补充
+	fields []zapcore.Field
+	// tenantId With 预置字段中的租户ID
+	tenantId string
+}
+
+// newTenantCore 包装默认核心，按租户分发日志
+func newTenantCore(core zapcore.Core, router *tenantRouter) zapcore.Core {
+	return &tenantCore{Core: core, router: router}
+}
+
+// With 添加字段并保持包装，记录预置字段中的租户ID
+func (c *tenantCore) With(fields []zapcore.Field) zapcore.Core {
+	tenantId := c.tenantId
+	if id, ok := tenantIdOf(fields); ok {
+		tenantId = id
+	}
+	merged := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	merged = append(merged, c.fields...)
+	merged = append(merged, fields...)
+	return &tenantCore{Core: c.Core.With(fields), router: c.router, fields: merged, tenantId: tenantId}
+}
+
+// Check 检查日志级别，通过时由本核心负责写入
+func (c *tenantCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write 按租户写入租户输出或默认核心
+func (c *tenantCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	tenantId := c.tenantId
+	if id, ok := tenantIdOf(fields); ok {
+		tenantId = id
+	}
+
+	var tenantOutput zapcore.Core
+	if tenantId != "" {
+		tenantOutput = c.router.coreFor(tenantId)
+	}
+	if tenantOutput == nil {
+		return writeThroughCheck(c.Core, ent, fields)
+	}
+
+	var err error
+	if tenantOutput.Enabled(ent.Level) {
+		all := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+		all = append(all, c.fields...)
+		all = append(all, fields...)
+		err = tenantOutput.Write(ent, all)
+	}
+	if c.router.config.KeepInDefault {
+		err = errors.Join(err, writeThroughCheck(c.Core, ent, fields))
+	}
+	return err
+}
+
+// Sync 刷新默认核心和全部租户输出
+func (c *tenantCore) Sync() error {
+	return errors.Join(c.Core.Sync(), c.router.sync())
+}
+
+// writeThroughCheck 经内层核心的 Check 写入日志
+// 内层为Tee时直接调用 Write 会写入全部输出核心，绕过各核心的级别过滤；
+// 经 Check 只有级别匹配的输出核心参与写入，写入错误与 zap 默认行为一致输出到标准错误
+func writeThroughCheck(core zapcore.Core, ent zapcore.Entry, fields []zapcore.Field) error {
+	ce := core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+	ce.ErrorOutput = zapcore.Lock(os.Stderr)
+	ce.Write(fields...)
+	return nil
+}
+
+// tenantIdOf 从字段中查找租户ID，同名字段以最后一个为准
+func tenantIdOf(fields []zapcore.Field) (string, bool) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		f := fields[i]
+		if f.Key != TenantIdKey {
+			continue
+		}
+		if f.Type == zapcore.StringType {
+			return f.String, f.String != ""
+		}
+		if s, ok := f.Interface.(string); ok {
+			return s, s != ""
+		}
+	}
+	return "", false
+}
+
+// sanitizeTenantId 将租户ID中路径分隔符等不安全字符替换为下划线，避免模板路径越出日志目录
+func sanitizeTenantId(tenantId string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, tenantId)
+}
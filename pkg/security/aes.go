@@ -310,6 +310,9 @@ func DecryptWithAAD(key []byte, encryptedData *EncryptedData, aad []byte) ([]byt
 	switch encryptedData.Version {
 	case AESGCMVersion:
 		return decryptGCM(key, encryptedData, aad)
+	case AESGCMKeyedVersion:
+		// 密钥版本参与认证
+		return decryptGCM(key, encryptedData, keyedAAD(encryptedData.KeyVersion, aad))
 	case AESCBCVersion:
 		if aad != nil && len(aad) > 0 {
 			return nil, errors.New("CBC模式不支持AAD")
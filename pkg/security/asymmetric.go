@@ -0,0 +1,392 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// 非对称密钥类型
+const (
+	// KeyTypeRSA RSA密钥
+	KeyTypeRSA = "RSA"
+	// KeyTypeECDSA ECDSA密钥
+	KeyTypeECDSA = "ECDSA"
+	// KeyTypeSM2 国密SM2密钥，以SM2曲线上的ECDSA密钥表示
+	KeyTypeSM2 = "SM2"
+)
+
+// DefaultRSAKeyBits 默认RSA密钥长度
+const DefaultRSAKeyBits = 2048
+
+// ErrUnsupportedKey 不支持的密钥类型
+var ErrUnsupportedKey = errors.New("不支持的密钥类型")
+
+// GenerateRSAKeyPair 生成RSA密钥对
+//
+// 参数:
+//   - bits: 密钥长度，小于2048时使用2048
+//
+// 返回:
+//   - *rsa.PrivateKey: 私钥，公钥通过 PublicKey 字段获取
+//   - error: 生成失败时返回错误
+func GenerateRSAKeyPair(bits int) (*rsa.PrivateKey, error) {
+	if bits < DefaultRSAKeyBits {
+		bits = DefaultRSAKeyBits
+	}
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return nil, fmt.Errorf("生成RSA密钥失败: %w", err)
+	}
+	return key, nil
+}
+
+// GenerateECDSAKeyPair 生成ECDSA密钥对
+//
+// 参数:
+//   - curve: 曲线名称 P256、P384、P521、SM2，为空时使用 P256；SM2 时生成国密SM2密钥
+//
+// 返回:
+//   - *ecdsa.PrivateKey: 私钥
+//   - error: 曲线不支持或生成失败时返回错误
+func GenerateECDSAKeyPair(curve string) (*ecdsa.PrivateKey, error) {
+	if strings.EqualFold(curve, KeyTypeSM2) {
+		return GenerateSM2KeyPair()
+	}
+	c, err := ellipticCurve(curve)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ecdsa.GenerateKey(c, rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("生成ECDSA密钥失败: %w", err)
+	}
+	return key, nil
+}
+
+// ellipticCurve 根据名称获取椭圆曲线
+func ellipticCurve(name string) (elliptic.Curve, error) {
+	switch strings.ToUpper(strings.ReplaceAll(name, "-", "")) {
+	case "", "P256":
+		return elliptic.P256(), nil
+	case "P384":
+		return elliptic.P384(), nil
+	case "P521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("不支持的椭圆曲线: %s", name)
+	}
+}
+
+// EncodePrivateKeyPEM 将私钥编码为PKCS#8格式的PEM
+//
+// 参数:
+//   - key: RSA、ECDSA或SM2私钥
+//
+// 返回:
+//   - []byte: PEM内容（"PRIVATE KEY"）
+//   - error: 编码失败时返回错误
+func EncodePrivateKeyPEM(key crypto.Signer) ([]byte, error) {
+	var der []byte
+	var err error
+	if k, ok := key.(*ecdsa.PrivateKey); ok && IsSM2Key(&k.PublicKey) {
+		der, err = MarshalSM2PrivateKey(k)
+	} else {
+		der, err = x509.MarshalPKCS8PrivateKey(key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("编码私钥失败: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// EncodePublicKeyPEM 将公钥编码为PKIX格式的PEM
+//
+// 参数:
+//   - pub: RSA、ECDSA或SM2公钥
+//
+// 返回:
+//   - []byte: PEM内容（"PUBLIC KEY"）
+//   - error: 编码失败时返回错误
+func EncodePublicKeyPEM(pub crypto.PublicKey) ([]byte, error) {
+	var der []byte
+	var err error
+	if k, ok := pub.(*ecdsa.PublicKey); ok && IsSM2Key(k) {
+		der, err = MarshalSM2PublicKey(k)
+	} else {
+		der, err = x509.MarshalPKIXPublicKey(pub)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("编码公钥失败: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// ParsePrivateKeyPEM 解析PEM格式的私钥
+// 支持 PKCS#8（PRIVATE KEY）、PKCS#1（RSA PRIVATE KEY）和 SEC 1（EC PRIVATE KEY），包括SM2私钥
+//
+// 参数:
+//   - data: PEM内容
+//
+// 返回:
+//   - crypto.Signer: *rsa.PrivateKey 或 *ecdsa.PrivateKey（SM2私钥的曲线为 SM2P256）
+//   - error: 格式无效或类型不支持时返回错误
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("解析PEM私钥失败: 未找到PEM数据")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("解析RSA私钥失败: %w", err)
+		}
+		return key, nil
+	case "EC PRIVATE KEY":
+		if key, err := ParseSM2PrivateKey(block.Bytes); err == nil {
+			return key, nil
+		}
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("解析EC私钥失败: %w", err)
+		}
+		return key, nil
+	case "PRIVATE KEY":
+		if key, err := ParseSM2PrivateKey(block.Bytes); err == nil {
+			return key, nil
+		}
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("解析PKCS#8私钥失败: %w", err)
+		}
+		switch k := key.(type) {
+		case *rsa.PrivateKey:
+			return k, nil
+		case *ecdsa.PrivateKey:
+			return k, nil
+		}
+		return nil, ErrUnsupportedKey
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKey, block.Type)
+	}
+}
+
+// ParsePublicKeyPEM 解析PEM格式的公钥
+// 支持 PKIX（PUBLIC KEY，包括SM2公钥）、PKCS#1（RSA PUBLIC KEY）以及证书（CERTIFICATE）中的公钥
+//
+// 参数:
+//   - data: PEM内容
+//
+// 返回:
+//   - crypto.PublicKey: *rsa.PublicKey 或 *ecdsa.PublicKey（SM2公钥的曲线为 SM2P256）
+//   - error: 格式无效或类型不支持时返回错误
+func ParsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("解析PEM公钥失败: 未找到PEM数据")
+	}
+
+	var pub interface{}
+	var err error
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		pub, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		if sm2Pub, sm2Err := ParseSM2PublicKey(block.Bytes); sm2Err == nil {
+			return sm2Pub, nil
+		}
+		pub, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+			pub = cert.PublicKey
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKey, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("解析公钥失败: %w", err)
+	}
+
+	switch pub.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return pub, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// LoadPrivateKeyFile 从文件加载PEM私钥
+//
+// 参数:
+//   - path: 文件路径
+//
+// 返回:
+//   - crypto.Signer: 私钥
+//   - error: 读取或解析失败时返回错误
+func LoadPrivateKeyFile(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取私钥文件失败: %w", err)
+	}
+	return ParsePrivateKeyPEM(data)
+}
+
+// LoadPublicKeyFile 从文件加载PEM公钥
+//
+// 参数:
+//   - path: 文件路径
+//
+// 返回:
+//   - crypto.PublicKey: 公钥
+//   - error: 读取或解析失败时返回错误
+func LoadPublicKeyFile(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取公钥文件失败: %w", err)
+	}
+	return ParsePublicKeyPEM(data)
+}
+
+// SavePrivateKeyFile 将私钥以PKCS#8 PEM格式保存到文件，文件权限为0600
+//
+// 参数:
+//   - path: 文件路径
+//   - key: 私钥
+//
+// 返回:
+//   - error: 编码或写入失败时返回错误
+func SavePrivateKeyFile(path string, key crypto.Signer) error {
+	data, err := EncodePrivateKeyPEM(key)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("写入私钥文件失败: %w", err)
+	}
+	return nil
+}
+
+// SavePublicKeyFile 将公钥以PKIX PEM格式保存到文件
+//
+// 参数:
+//   - path: 文件路径
+//   - pub: 公钥
+//
+// 返回:
+//   - error: 编码或写入失败时返回错误
+func SavePublicKeyFile(path string, pub crypto.PublicKey) error {
+	data, err := EncodePublicKeyPEM(pub)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("写入公钥文件失败: %w", err)
+	}
+	return nil
+}
+
+// SignData 使用私钥对数据签名（SHA-256摘要）
+// RSA 使用 PKCS#1 v1.5，ECDSA 使用 ASN.1 DER 编码的签名；
+// SM2私钥按国密标准使用SM3摘要和默认用户身份标识签名，见 SM2Sign
+//
+// 参数:
+//   - key: RSA、ECDSA或SM2私钥
+//   - data: 待签名数据
+//
+// 返回:
+//   - []byte: 签名
+//   - error: 签名失败时返回错误
+func SignData(key crypto.Signer, data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	case *ecdsa.PrivateKey:
+		if IsSM2Key(&k.PublicKey) {
+			return SM2Sign(k, nil, data)
+		}
+		return ecdsa.SignASN1(rand.Reader, k, digest[:])
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// VerifySignature 使用公钥校验 SignData 生成的签名
+//
+// 参数:
+//   - pub: RSA、ECDSA或SM2公钥
+//   - data: 原始数据
+//   - signature: 签名
+//
+// 返回:
+//   - error: 签名无效时返回 ErrSignatureMismatch
+func VerifySignature(pub crypto.PublicKey, data, signature []byte) error {
+	digest := sha256.Sum256(data)
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature); err != nil {
+			return ErrSignatureMismatch
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if IsSM2Key(k) {
+			if !SM2Verify(k, nil, data, signature) {
+				return ErrSignatureMismatch
+			}
+			return nil
+		}
+		if !ecdsa.VerifyASN1(k, digest[:], signature) {
+			return ErrSignatureMismatch
+		}
+		return nil
+	default:
+		return ErrUnsupportedKey
+	}
+}
+
+// RSAEncrypt 使用RSA公钥加密（OAEP，SHA-256）
+// 明文长度受密钥长度限制，大数据应使用AES加密后再加密AES密钥
+//
+// 参数:
+//   - pub: RSA公钥
+//   - plaintext: 明文
+//
+// 返回:
+//   - []byte: 密文
+//   - error: 加密失败时返回错误
+func RSAEncrypt(pub *rsa.PublicKey, plaintext []byte) ([]byte, error) {
+	ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, plaintext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("RSA加密失败: %w", err)
+	}
+	return ciphertext, nil
+}
+
+// RSADecrypt 使用RSA私钥解密（OAEP，SHA-256）
+//
+// 参数:
+//   - key: RSA私钥
+//   - ciphertext: 密文
+//
+// 返回:
+//   - []byte: 明文
+//   - error: 解密失败时返回错误
+func RSADecrypt(key *rsa.PrivateKey, ciphertext []byte) ([]byte, error) {
+	plaintext, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, key, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("RSA解密失败: %w", err)
+	}
+	return plaintext, nil
+}
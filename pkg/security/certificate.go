@@ -0,0 +1,454 @@
+package security
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// 证书用途
+const (
+	// CertUsageServer 服务端证书
+	CertUsageServer = "server"
+	// CertUsageClient 客户端证书（mTLS）
+	CertUsageClient = "client"
+)
+
+// 证书默认有效期
+const (
+	// DefaultCAValidity 默认CA有效期10年
+	DefaultCAValidity = 10 * 365 * 24 * time.Hour
+	// DefaultCertValidity 默认证书有效期1年
+	DefaultCertValidity = 365 * 24 * time.Hour
+)
+
+// CertificateOptions 证书生成选项
+type CertificateOptions struct {
+	// CommonName 通用名称
+	CommonName string
+	// Organization 组织
+	Organization []string
+	// DNSNames 主题备用名称中的域名
+	DNSNames []string
+	// IPAddresses 主题备用名称中的IP
+	IPAddresses []string
+	// ValidFor 有效期，为0时CA使用10年、证书使用1年
+	ValidFor time.Duration
+	// KeyType 密钥类型 RSA 或 ECDSA，默认 ECDSA
+	KeyType string
+	// RSABits RSA密钥长度，默认2048
+	RSABits int
+	// Curve ECDSA曲线，默认 P256
+	Curve string
+}
+
+// CertificateBundle 证书及其私钥
+type CertificateBundle struct {
+	// Certificate 解析后的证书
+	Certificate *x509.Certificate
+	// PrivateKey 私钥，由CSR签发的证书为nil
+	PrivateKey crypto.Signer
+	// CertPEM 证书PEM
+	CertPEM []byte
+	// KeyPEM 私钥PEM（PKCS#8），由CSR签发的证书为nil
+	KeyPEM []byte
+}
+
+// CertificateInfo 证书摘要信息，用于展示和到期提醒
+type CertificateInfo struct {
+	Subject         string    `json:"subject"`
+	Issuer          string    `json:"issuer"`
+	SerialNumber    string    `json:"serialNumber"`
+	DNSNames        []string  `json:"dnsNames"`
+	IPAddresses     []string  `json:"ipAddresses"`
+	NotBefore       time.Time `json:"notBefore"`
+	NotAfter        time.Time `json:"notAfter"`
+	DaysUntilExpiry int       `json:"daysUntilExpiry"` // 距离过期的天数，已过期为负数
+	Expired         bool      `json:"expired"`
+	IsCA            bool      `json:"isCA"`
+	// Fingerprint SHA-256指纹（十六进制，冒号分隔）
+	Fingerprint string `json:"fingerprint"`
+}
+
+// GenerateCA 生成自签名CA证书，用于开发环境和内部mTLS
+//
+// 参数:
+//   - opts: 证书选项，CommonName 为空时使用 "Gateway Local CA"
+//
+// 返回:
+//   - *CertificateBundle: CA证书及私钥
+//   - error: 生成失败时返回错误
+func GenerateCA(opts CertificateOptions) (*CertificateBundle, error) {
+	if opts.CommonName == "" {
+		opts.CommonName = "Gateway Local CA"
+	}
+	if opts.ValidFor == 0 {
+		opts.ValidFor = DefaultCAValidity
+	}
+
+	key, err := generateCertificateKey(opts)
+	if err != nil {
+		return nil, err
+	}
+	template, err := certificateTemplate(opts)
+	if err != nil {
+		return nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+
+	return createCertificate(template, template, key.Public(), key, key)
+}
+
+// IssueCertificate 使用CA签发服务端或客户端证书
+//
+// 参数:
+//   - ca: CA证书及私钥
+//   - usage: 证书用途 CertUsageServer 或 CertUsageClient
+//   - opts: 证书选项，服务端证书的 CommonName 为空时使用第一个域名
+//
+// 返回:
+//   - *CertificateBundle: 证书及私钥
+//   - error: CA无效或生成失败时返回错误
+func IssueCertificate(ca *CertificateBundle, usage string, opts CertificateOptions) (*CertificateBundle, error) {
+	if err := checkCA(ca); err != nil {
+		return nil, err
+	}
+	if opts.CommonName == "" && len(opts.DNSNames) > 0 {
+		opts.CommonName = opts.DNSNames[0]
+	}
+	if opts.ValidFor == 0 {
+		opts.ValidFor = DefaultCertValidity
+	}
+
+	key, err := generateCertificateKey(opts)
+	if err != nil {
+		return nil, err
+	}
+	template, err := certificateTemplate(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyCertUsage(template, usage); err != nil {
+		return nil, err
+	}
+	return createCertificate(template, ca.Certificate, key.Public(), ca.PrivateKey, key)
+}
+
+// CreateCSR 创建证书签名请求
+//
+// 参数:
+//   - key: 私钥
+//   - opts: 证书选项，使用其中的 CommonName、Organization、DNSNames、IPAddresses
+//
+// 返回:
+//   - []byte: CSR的PEM内容（"CERTIFICATE REQUEST"）
+//   - error: 创建失败时返回错误
+func CreateCSR(key crypto.Signer, opts CertificateOptions) ([]byte, error) {
+	ips, err := parseIPAddresses(opts.IPAddresses)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.CertificateRequest{
+		Subject:     pkix.Name{CommonName: opts.CommonName, Organization: opts.Organization},
+		DNSNames:    opts.DNSNames,
+		IPAddresses: ips,
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, fmt.Errorf("创建证书签名请求失败: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), nil
+}
+
+// SignCSR 使用CA签发证书签名请求
+//
+// 参数:
+//   - ca: CA证书及私钥
+//   - csrPEM: CSR的PEM内容
+//   - usage: 证书用途 CertUsageServer 或 CertUsageClient
+//   - validFor: 有效期，为0时使用1年
+//
+// 返回:
+//   - *CertificateBundle: 签发的证书（不含私钥）
+//   - error: CSR无效、签名校验失败或签发失败时返回错误
+func SignCSR(ca *CertificateBundle, csrPEM []byte, usage string, validFor time.Duration) (*CertificateBundle, error) {
+	if err := checkCA(ca); err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(csrPEM)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, errors.New("解析证书签名请求失败: 未找到PEM数据")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("解析证书签名请求失败: %w", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("证书签名请求签名无效: %w", err)
+	}
+
+	if validFor == 0 {
+		validFor = DefaultCertValidity
+	}
+	template, err := certificateTemplate(CertificateOptions{ValidFor: validFor})
+	if err != nil {
+		return nil, err
+	}
+	template.Subject = csr.Subject
+	template.DNSNames = csr.DNSNames
+	template.IPAddresses = csr.IPAddresses
+	if err := applyCertUsage(template, usage); err != nil {
+		return nil, err
+	}
+	return createCertificate(template, ca.Certificate, csr.PublicKey, ca.PrivateKey, nil)
+}
+
+// LoadCertificateBundle 从PEM内容加载证书及私钥，用于加载已有CA
+//
+// 参数:
+//   - certPEM: 证书PEM
+//   - keyPEM: 私钥PEM，可为空
+//
+// 返回:
+//   - *CertificateBundle: 证书及私钥
+//   - error: 解析失败或私钥与证书不匹配时返回错误
+func LoadCertificateBundle(certPEM, keyPEM []byte) (*CertificateBundle, error) {
+	certs, err := ParseCertificatesPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &CertificateBundle{Certificate: certs[0], CertPEM: certPEM}
+	if len(keyPEM) == 0 {
+		return bundle, nil
+	}
+
+	key, err := ParsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	if !publicKeysEqual(certs[0].PublicKey, key.Public()) {
+		return nil, errors.New("私钥与证书不匹配")
+	}
+	bundle.PrivateKey = key
+	bundle.KeyPEM = keyPEM
+	return bundle, nil
+}
+
+// SaveCertificateBundle 保存证书和私钥到文件，私钥文件权限为0600
+//
+// 参数:
+//   - bundle: 证书及私钥
+//   - certFile: 证书文件路径
+//   - keyFile: 私钥文件路径，bundle 不含私钥时忽略
+//
+// 返回:
+//   - error: 写入失败时返回错误
+func SaveCertificateBundle(bundle *CertificateBundle, certFile, keyFile string) error {
+	if err := os.WriteFile(certFile, bundle.CertPEM, 0644); err != nil {
+		return fmt.Errorf("写入证书文件失败: %w", err)
+	}
+	if len(bundle.KeyPEM) > 0 && keyFile != "" {
+		if err := os.WriteFile(keyFile, bundle.KeyPEM, 0600); err != nil {
+			return fmt.Errorf("写入私钥文件失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// ParseCertificatesPEM 解析PEM内容中的全部证书，顺序与文件中一致（通常第一个为叶子证书）
+//
+// 参数:
+//   - data: PEM内容
+//
+// 返回:
+//   - []*x509.Certificate: 证书列表
+//   - error: 没有证书或解析失败时返回错误
+func ParseCertificatesPEM(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("解析证书失败: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("解析证书失败: 未找到证书")
+	}
+	return certs, nil
+}
+
+// DaysUntilExpiry 计算证书距离过期的天数，不足一天按0计，已过期为负数
+//
+// 参数:
+//   - cert: 证书
+//
+// 返回:
+//   - int: 剩余天数
+func DaysUntilExpiry(cert *x509.Certificate) int {
+	return int(math.Floor(time.Until(cert.NotAfter).Hours() / 24))
+}
+
+// GetCertificateInfo 获取证书摘要信息
+//
+// 参数:
+//   - cert: 证书
+//
+// 返回:
+//   - *CertificateInfo: 证书摘要
+func GetCertificateInfo(cert *x509.Certificate) *CertificateInfo {
+	ips := make([]string, 0, len(cert.IPAddresses))
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	sum := sha256.Sum256(cert.Raw)
+	fingerprint := strings.ToUpper(hex.EncodeToString(sum[:]))
+	parts := make([]string, 0, len(sum))
+	for i := 0; i < len(fingerprint); i += 2 {
+		parts = append(parts, fingerprint[i:i+2])
+	}
+
+	return &CertificateInfo{
+		Subject:         cert.Subject.String(),
+		Issuer:          cert.Issuer.String(),
+		SerialNumber:    cert.SerialNumber.Text(16),
+		DNSNames:        cert.DNSNames,
+		IPAddresses:     ips,
+		NotBefore:       cert.NotBefore,
+		NotAfter:        cert.NotAfter,
+		DaysUntilExpiry: DaysUntilExpiry(cert),
+		Expired:         time.Now().After(cert.NotAfter),
+		IsCA:            cert.IsCA,
+		Fingerprint:     strings.Join(parts, ":"),
+	}
+}
+
+// generateCertificateKey 按选项生成证书私钥
+func generateCertificateKey(opts CertificateOptions) (crypto.Signer, error) {
+	switch strings.ToUpper(opts.KeyType) {
+	case "", KeyTypeECDSA:
+		return GenerateECDSAKeyPair(opts.Curve)
+	case KeyTypeRSA:
+		return GenerateRSAKeyPair(opts.RSABits)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKey, opts.KeyType)
+	}
+}
+
+// certificateTemplate 生成证书模板
+func certificateTemplate(opts CertificateOptions) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("生成证书序列号失败: %w", err)
+	}
+	ips, err := parseIPAddresses(opts.IPAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	// 生效时间提前1小时，容忍节点间时钟偏差
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: opts.CommonName, Organization: opts.Organization},
+		DNSNames:     opts.DNSNames,
+		IPAddresses:  ips,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(opts.ValidFor),
+	}, nil
+}
+
+// applyCertUsage 设置证书用途
+func applyCertUsage(template *x509.Certificate, usage string) error {
+	template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	template.BasicConstraintsValid = true
+	switch usage {
+	case CertUsageServer:
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	case CertUsageClient:
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	default:
+		return fmt.Errorf("不支持的证书用途: %s", usage)
+	}
+	return nil
+}
+
+// createCertificate 签发证书并组装结果
+func createCertificate(template, parent *x509.Certificate, pub crypto.PublicKey, signer crypto.Signer, key crypto.Signer) (*CertificateBundle, error) {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, signer)
+	if err != nil {
+		return nil, fmt.Errorf("签发证书失败: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("解析证书失败: %w", err)
+	}
+
+	bundle := &CertificateBundle{
+		Certificate: cert,
+		CertPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+	if key != nil {
+		keyPEM, err := EncodePrivateKeyPEM(key)
+		if err != nil {
+			return nil, err
+		}
+		bundle.PrivateKey = key
+		bundle.KeyPEM = keyPEM
+	}
+	return bundle, nil
+}
+
+// checkCA 检查CA证书及私钥是否可用于签发
+func checkCA(ca *CertificateBundle) error {
+	if ca == nil || ca.Certificate == nil || ca.PrivateKey == nil {
+		return errors.New("CA证书或私钥为空")
+	}
+	if !ca.Certificate.IsCA {
+		return errors.New("证书不是CA证书")
+	}
+	return nil
+}
+
+// parseIPAddresses 解析IP地址列表
+func parseIPAddresses(values []string) ([]net.IP, error) {
+	ips := make([]net.IP, 0, len(values))
+	for _, value := range values {
+		ip := net.ParseIP(strings.TrimSpace(value))
+		if ip == nil {
+			return nil, fmt.Errorf("无效的IP地址: %s", value)
+		}
+		ips = append(ips, ip)
+	}
+	return ips, nil
+}
+
+// publicKeysEqual 比较两个公钥是否相同
+func publicKeysEqual(a, b crypto.PublicKey) bool {
+	if key, ok := a.(interface{ Equal(crypto.PublicKey) bool }); ok {
+		return key.Equal(b)
+	}
+	return false
+}
@@ -23,6 +23,12 @@ const (
 	AESCBCVersion byte = 0x02
 	// DESVersion DES加密版本号
 	DESVersion byte = 0x03
+	// AESGCMKeyedVersion 带密钥版本头的AES-GCM加密版本号
+	// 密文中记录加密所用的密钥版本，解密时据此选择密钥，支持密钥轮换
+	AESGCMKeyedVersion byte = 0x04
+	// SM4GCMKeyedVersion 带密钥版本头的SM4-GCM加密版本号（国密）
+	// 格式与版本4相同，配置 app.encryption_algorithm 为 sm4 时使用
+	SM4GCMKeyedVersion byte = 0x05
 
 	// EncryptedPrefix 加密字符串的前缀标识
 	// 使用 "ENCY_" 格式，Base64编码后的数据不包含下划线，便于区分前缀和数据
@@ -36,9 +42,13 @@ const (
 //   - 0x01: AES-GCM模式
 //   - 0x02: AES-CBC模式
 //   - 0x03: DES-CBC模式
+//   - 0x04: 带密钥版本的AES-GCM模式
+//   - 0x05: 带密钥版本的SM4-GCM模式
 type EncryptedData struct {
-	// Version 加密版本号（1=GCM, 2=AES-CBC, 3=DES-CBC）
+	// Version 加密版本号（1=GCM, 2=AES-CBC, 3=DES-CBC, 4=带密钥版本的GCM, 5=带密钥版本的SM4-GCM）
 	Version byte `json:"version"`
+	// KeyVersion 加密所用的密钥版本（仅版本4、5）
+	KeyVersion uint16 `json:"keyVersion,omitempty"`
 	// Nonce GCM模式的nonce（12字节）、CBC模式的IV（AES为16字节，DES为8字节），Base64编码
 	Nonce string `json:"nonce"`
 	// Ciphertext 加密后的数据（包含GCM标签或纯密文），Base64编码
@@ -49,6 +59,7 @@ type EncryptedData struct {
 
 // ToString 将EncryptedData格式化为字符串密文
 // 使用紧凑格式：版本号(1字节) || nonce长度(2字节) || nonce || 密文长度(4字节) || 密文 || AAD长度(2字节) || AAD
+// 版本4、5在版本号之后紧跟密钥版本(2字节)
 // 所有数据经过Base64编码后，添加前缀标识 "ENCY_"
 // Base64编码不包含下划线，便于区分前缀和数据部分
 //
@@ -84,14 +95,24 @@ func (e *EncryptedData) ToString() (string, error) {
 	nonceLen := len(nonceBytes)
 	ciphertextLen := len(ciphertextBytes)
 	aadLen := len(aadBytes)
+	headerLen := 1
+	if hasKeyVersion(e.Version) {
+		headerLen += keyVersionHeaderSize
+	}
 
-	buf := make([]byte, 1+2+nonceLen+4+ciphertextLen+2+aadLen)
+	buf := make([]byte, headerLen+2+nonceLen+4+ciphertextLen+2+aadLen)
 	pos := 0
 
 	// 版本号
 	buf[pos] = e.Version
 	pos++
 
+	// 密钥版本（大端序，仅版本4、5）
+	if hasKeyVersion(e.Version) {
+		binary.BigEndian.PutUint16(buf[pos:pos+keyVersionHeaderSize], e.KeyVersion)
+		pos += keyVersionHeaderSize
+	}
+
 	// nonce长度和nonce（大端序）
 	binary.BigEndian.PutUint16(buf[pos:pos+2], uint16(nonceLen))
 	pos += 2
@@ -168,6 +189,16 @@ func EncryptedDataFromString(ciphertext string) (*EncryptedData, error) {
 	version := data[pos]
 	pos++
 
+	// 读取密钥版本（大端序，仅版本4、5）
+	var keyVersion uint16
+	if hasKeyVersion(version) {
+		if len(data) < pos+keyVersionHeaderSize {
+			return nil, fmt.Errorf("密文长度不足，无法读取密钥版本")
+		}
+		keyVersion = binary.BigEndian.Uint16(data[pos : pos+keyVersionHeaderSize])
+		pos += keyVersionHeaderSize
+	}
+
 	// 读取nonce长度和nonce（大端序）
 	if len(data) < pos+2 {
 		return nil, fmt.Errorf("密文长度不足，无法读取nonce长度")
@@ -208,6 +239,7 @@ func EncryptedDataFromString(ciphertext string) (*EncryptedData, error) {
 
 	return &EncryptedData{
 		Version:    version,
+		KeyVersion: keyVersion,
 		Nonce:      base64.StdEncoding.EncodeToString(nonce),
 		Ciphertext: base64.StdEncoding.EncodeToString(ciphertextBytes),
 		AAD:        base64.StdEncoding.EncodeToString(aad),
@@ -276,7 +308,7 @@ func SHA512(data string) string {
 // Hash 计算哈希值（支持多种算法）
 // 参数:
 //   - data: 待计算哈希的数据
-//   - algorithm: 哈希算法名称（md5, sha1, sha256, sha512），默认sha256
+//   - algorithm: 哈希算法名称（md5, sha1, sha256, sha512, sm3），默认sha256
 //
 // 返回:
 //   - string: 哈希值的十六进制字符串
@@ -301,8 +333,10 @@ func Hash(data string, algorithm ...string) (string, error) {
 		return SHA256(data), nil
 	case "sha512":
 		return SHA512(data), nil
+	case "sm3":
+		return SM3(data), nil
 	default:
-		return "", fmt.Errorf("不支持的哈希算法: %s，支持: md5, sha1, sha256, sha512", algo)
+		return "", fmt.Errorf("不支持的哈希算法: %s，支持: md5, sha1, sha256, sha512, sm3", algo)
 	}
 }
 
@@ -511,18 +545,32 @@ func GenerateSecretKey() (string, error) {
 	return base64.StdEncoding.EncodeToString(key), nil
 }
 
+// EncryptionKeyEnv 默认加密密钥的环境变量名，优先级高于配置文件
+const EncryptionKeyEnv = "GATEWAY_APP_ENCRYPTION_KEY"
+
+func init() {
+	// 注册配置解密函数，使 pkg/config 在加载时透明解密 ENCY_ 格式的配置
+	config.RegisterDecryptor(DecryptWithDefaultKey)
+}
+
 // GetDefaultEncryptionKey 获取默认加密密钥
-// 从配置文件中读取 app.encryption_key，如果未配置则使用默认值
+// 通过当前密钥提供者获取版本0密钥。默认提供者优先读取环境变量 GATEWAY_APP_ENCRYPTION_KEY，
+// 其次读取配置文件 app.encryption_key，如果都未配置则使用默认值；
+// 配置了外部密钥服务（app.key_provider）时从外部服务获取
 //
 // 返回:
 //   - string: 默认加密密钥字符串
-func GetDefaultEncryptionKey() string {
-	defaultKey := "gateway-default-encryption-key-please-change-in-production"
-	return config.GetString("app.encryption_key", defaultKey)
+//   - error: 密钥提供者获取密钥失败时返回错误
+func GetDefaultEncryptionKey() (string, error) {
+	key, err := GetEncryptionKeyByVersion(LegacyKeyVersion)
+	if err != nil {
+		return "", fmt.Errorf("获取默认加密密钥失败(provider=%s): %w", GetKeyProvider().Name(), err)
+	}
+	return key, nil
 }
 
 // EncryptWithDefaultKey 使用默认密钥加密字符串（便捷方法）
-// 使用配置中的默认密钥进行加密
+// 使用当前密钥版本（app.encryption_key_version）的密钥进行带密钥版本的AES-GCM加密
 //
 // 参数:
 //   - plaintext: 待加密的明文字符串
@@ -536,12 +584,11 @@ func GetDefaultEncryptionKey() string {
 //	ciphertext, err := security.EncryptWithDefaultKey("Hello, World!")
 //	// 使用配置中的默认密钥
 func EncryptWithDefaultKey(plaintext string) (string, error) {
-	key := GetDefaultEncryptionKey()
-	return AESEncryptToString(key, plaintext)
+	return EncryptWithKeyVersion(GetEncryptionKeyVersion(), []byte(plaintext))
 }
 
 // DecryptWithDefaultKey 使用默认密钥解密字符串（便捷方法）
-// 使用配置中的默认密钥进行解密
+// 带密钥版本的密文按版本选择密钥，不带密钥版本的旧密文使用默认密钥解密
 // 如果字符串没有加密前缀，直接返回原始值（兼容明文数据）
 //
 // 参数:
@@ -562,8 +609,15 @@ func DecryptWithDefaultKey(ciphertext string) (string, error) {
 		return ciphertext, nil
 	}
 
-	key := GetDefaultEncryptionKey()
-	return AESDecryptFromString(key, ciphertext)
+	encryptedData, err := EncryptedDataFromString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("解析加密数据失败: %w", err)
+	}
+	plaintext, err := decryptWithKeyring(encryptedData)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
 }
 
 // EncryptBytesWithDefaultKey 使用默认密钥加密字节数组
@@ -574,12 +628,7 @@ func DecryptWithDefaultKey(ciphertext string) (string, error) {
 //   - string: 加密后的字符串密文（带 "ENCY_" 前缀）
 //   - error: 加密过程中的错误
 func EncryptBytesWithDefaultKey(plaintext []byte) (string, error) {
-	key := GetDefaultEncryptionKey()
-	encrypted, err := AESEncryptBytes(key, plaintext)
-	if err != nil {
-		return "", err
-	}
-	return encrypted.ToString()
+	return EncryptWithKeyVersion(GetEncryptionKeyVersion(), plaintext)
 }
 
 // DecryptBytesWithDefaultKey 使用默认密钥解密字节数组
@@ -596,10 +645,9 @@ func DecryptBytesWithDefaultKey(ciphertext string) ([]byte, error) {
 		return []byte(ciphertext), nil
 	}
 
-	key := GetDefaultEncryptionKey()
 	encryptedData, err := EncryptedDataFromString(ciphertext)
 	if err != nil {
 		return nil, fmt.Errorf("解析加密数据失败: %w", err)
 	}
-	return AESDecryptBytes(key, encryptedData)
+	return decryptWithKeyring(encryptedData)
 }
@@ -0,0 +1,278 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// JWTOptions JWT签发与校验选项
+type JWTOptions struct {
+	// Issuer 签发者，签发时写入 iss
+	Issuer string
+	// TTL 有效期，签发时写入 exp，<=0 时不设置过期时间
+	TTL time.Duration
+	// KeyID 密钥ID，签发时写入头部 kid，便于验证方按 kid 选择公钥
+	KeyID string
+	// VerifyIssuer 校验时是否要求 iss 与 Issuer 一致
+	VerifyIssuer bool
+	// SkipClaimsValidation 校验时跳过 exp、nbf、iat 等时间声明的检查
+	SkipClaimsValidation bool
+}
+
+// JWTSigner JWT签发与校验
+// 网关认证过滤器和管理端会话令牌共用该实现，签名算法在创建时固定，
+// 校验时只接受该算法，避免算法混淆攻击
+type JWTSigner struct {
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+	opts      JWTOptions
+}
+
+// JWTSigningMethod 根据算法名称获取签名方法
+// 支持 HS256/384/512、RS256/384/512、PS256/384/512、ES256/384/512
+//
+// 参数:
+//   - algorithm: 算法名称，不区分大小写
+//
+// 返回:
+//   - jwt.SigningMethod: 签名方法
+//   - error: 算法不支持时返回错误
+func JWTSigningMethod(algorithm string) (jwt.SigningMethod, error) {
+	alg := strings.ToUpper(algorithm)
+	if alg == "NONE" {
+		return nil, fmt.Errorf("不支持的JWT算法: %s", algorithm)
+	}
+	method := jwt.GetSigningMethod(alg)
+	if method == nil {
+		return nil, fmt.Errorf("不支持的JWT算法: %s", algorithm)
+	}
+	return method, nil
+}
+
+// IsHMACAlgorithm 判断是否为对称签名算法（HS系列）
+func IsHMACAlgorithm(algorithm string) bool {
+	return strings.HasPrefix(strings.ToUpper(algorithm), "HS")
+}
+
+// NewHMACJWTSigner 创建使用对称密钥的JWT签发器
+//
+// 参数:
+//   - algorithm: HS256、HS384 或 HS512
+//   - secret: 对称密钥
+//   - opts: 签发与校验选项
+//
+// 返回:
+//   - *JWTSigner: JWT签发器，可同时用于签发和校验
+//   - error: 算法不是HS系列或密钥为空时返回错误
+func NewHMACJWTSigner(algorithm, secret string, opts JWTOptions) (*JWTSigner, error) {
+	if !IsHMACAlgorithm(algorithm) {
+		return nil, fmt.Errorf("算法 %s 不是HMAC算法", algorithm)
+	}
+	if secret == "" {
+		return nil, errors.New("JWT密钥不能为空")
+	}
+	method, err := JWTSigningMethod(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return &JWTSigner{method: method, signKey: []byte(secret), verifyKey: []byte(secret), opts: opts}, nil
+}
+
+// NewJWTSigner 创建使用私钥的JWT签发器
+//
+// 参数:
+//   - algorithm: RS*/PS* 需要RSA私钥，ES* 需要对应曲线的ECDSA私钥
+//   - privateKey: 私钥
+//   - opts: 签发与校验选项
+//
+// 返回:
+//   - *JWTSigner: JWT签发器，使用私钥对应的公钥校验
+//   - error: 算法与密钥类型不匹配时返回错误
+func NewJWTSigner(algorithm string, privateKey crypto.Signer, opts JWTOptions) (*JWTSigner, error) {
+	method, err := JWTSigningMethod(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if privateKey == nil {
+		return nil, errors.New("JWT私钥不能为空")
+	}
+	if err := checkJWTKeyType(method, privateKey.Public()); err != nil {
+		return nil, err
+	}
+	return &JWTSigner{method: method, signKey: privateKey, verifyKey: privateKey.Public(), opts: opts}, nil
+}
+
+// NewJWTVerifier 创建只用于校验的JWT校验器
+//
+// 参数:
+//   - algorithm: RS*/PS*/ES* 算法
+//   - publicKey: 公钥
+//   - opts: 校验选项
+//
+// 返回:
+//   - *JWTSigner: 只能校验的JWT签发器，调用签发方法会返回错误
+//   - error: 算法与密钥类型不匹配时返回错误
+func NewJWTVerifier(algorithm string, publicKey crypto.PublicKey, opts JWTOptions) (*JWTSigner, error) {
+	method, err := JWTSigningMethod(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkJWTKeyType(method, publicKey); err != nil {
+		return nil, err
+	}
+	return &JWTSigner{method: method, verifyKey: publicKey, opts: opts}, nil
+}
+
+// checkJWTKeyType 检查算法与公钥类型是否匹配
+func checkJWTKeyType(method jwt.SigningMethod, publicKey crypto.PublicKey) error {
+	alg := method.Alg()
+	switch {
+	case strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS"):
+		if _, ok := publicKey.(*rsa.PublicKey); ok {
+			return nil
+		}
+	case strings.HasPrefix(alg, "ES"):
+		if _, ok := publicKey.(*ecdsa.PublicKey); ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("算法 %s 与密钥类型 %T 不匹配", alg, publicKey)
+}
+
+// Algorithm 签名算法名称
+func (s *JWTSigner) Algorithm() string {
+	return s.method.Alg()
+}
+
+// Sign 签发令牌，声明原样写入，不补充 iss、exp 等字段
+//
+// 参数:
+//   - claims: 声明
+//
+// 返回:
+//   - string: 令牌
+//   - error: 签发失败时返回错误
+func (s *JWTSigner) Sign(claims jwt.Claims) (string, error) {
+	if s.signKey == nil {
+		return "", errors.New("JWT校验器不能签发令牌")
+	}
+	token := jwt.NewWithClaims(s.method, claims)
+	if s.opts.KeyID != "" {
+		token.Header["kid"] = s.opts.KeyID
+	}
+	tokenString, err := token.SignedString(s.signKey)
+	if err != nil {
+		return "", fmt.Errorf("签发JWT失败: %w", err)
+	}
+	return tokenString, nil
+}
+
+// Issue 签发令牌，自动设置 iss、sub、iat、exp
+//
+// 参数:
+//   - subject: 主体，写入 sub，可为空
+//   - extra: 其他声明，与标准声明同名时以标准声明为准
+//
+// 返回:
+//   - string: 令牌
+//   - error: 签发失败时返回错误
+func (s *JWTSigner) Issue(subject string, extra map[string]interface{}) (string, error) {
+	claims := jwt.MapClaims{}
+	for k, v := range extra {
+		claims[k] = v
+	}
+	now := time.Now()
+	claims["iat"] = now.Unix()
+	if s.opts.TTL > 0 {
+		claims["exp"] = now.Add(s.opts.TTL).Unix()
+	}
+	if s.opts.Issuer != "" {
+		claims["iss"] = s.opts.Issuer
+	}
+	if subject != "" {
+		claims["sub"] = subject
+	}
+	return s.Sign(claims)
+}
+
+// IssueRegistered 为嵌入 jwt.RegisteredClaims 的声明补充 iss、iat、exp 后签发
+//
+// 参数:
+//   - claims: 声明，自定义结构体需嵌入 jwt.RegisteredClaims
+//   - registered: 声明中嵌入的 RegisteredClaims 指针
+//
+// 返回:
+//   - string: 令牌
+//   - error: 签发失败时返回错误
+func (s *JWTSigner) IssueRegistered(claims jwt.Claims, registered *jwt.RegisteredClaims) (string, error) {
+	now := time.Now()
+	registered.IssuedAt = jwt.NewNumericDate(now)
+	if s.opts.TTL > 0 {
+		registered.ExpiresAt = jwt.NewNumericDate(now.Add(s.opts.TTL))
+	}
+	if s.opts.Issuer != "" {
+		registered.Issuer = s.opts.Issuer
+	}
+	return s.Sign(claims)
+}
+
+// Parse 校验令牌并解析声明
+//
+// 参数:
+//   - tokenString: 令牌
+//   - claims: 声明接收对象，如 jwt.MapClaims{} 或自定义结构体指针
+//
+// 返回:
+//   - *jwt.Token: 解析后的令牌
+//   - error: 签名无效、算法不符、已过期或签发者不符时返回错误
+func (s *JWTSigner) Parse(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	if tokenString == "" {
+		return nil, errors.New("令牌为空")
+	}
+
+	parserOpts := []jwt.ParserOption{jwt.WithValidMethods([]string{s.method.Alg()})}
+	if s.opts.SkipClaimsValidation {
+		parserOpts = append(parserOpts, jwt.WithoutClaimsValidation())
+	}
+	token, err := jwt.NewParser(parserOpts...).ParseWithClaims(tokenString, claims, func(*jwt.Token) (interface{}, error) {
+		return s.verifyKey, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("无效的令牌: %w", err)
+	}
+	if !token.Valid {
+		return nil, errors.New("无效的令牌")
+	}
+
+	if s.opts.VerifyIssuer {
+		if s.opts.Issuer == "" {
+			return nil, errors.New("已启用签发者校验但未配置签发者")
+		}
+		if !verifyJWTIssuer(claims, s.opts.Issuer) {
+			return nil, errors.New("无效的签发者")
+		}
+	}
+	return token, nil
+}
+
+// verifyJWTIssuer 校验签发者
+func verifyJWTIssuer(claims jwt.Claims, issuer string) bool {
+	switch c := claims.(type) {
+	case jwt.MapClaims:
+		return c.VerifyIssuer(issuer, true)
+	case *jwt.MapClaims:
+		return c.VerifyIssuer(issuer, true)
+	case interface{ VerifyIssuer(string, bool) bool }:
+		return c.VerifyIssuer(issuer, true)
+	default:
+		return false
+	}
+}
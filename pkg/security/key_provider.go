@@ -0,0 +1,296 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"gateway/pkg/config"
+	"gateway/pkg/logger"
+)
+
+// 密钥提供者类型
+const (
+	// KeyProviderStatic 从环境变量和配置文件读取密钥（默认）
+	KeyProviderStatic = "static"
+	// KeyProviderVault 从 HashiCorp Vault 读取密钥
+	KeyProviderVault = "vault"
+	// KeyProviderAWSKMS 使用 AWS KMS 解密数据密钥
+	KeyProviderAWSKMS = "aws_kms"
+	// KeyProviderGCPKMS 使用 GCP Cloud KMS 解密数据密钥
+	KeyProviderGCPKMS = "gcp_kms"
+)
+
+// defaultEncryptionKey 未配置任何密钥时使用的默认密钥
+const defaultEncryptionKey = "gateway-default-encryption-key-please-change-in-production"
+
+// KeyProvider 加密密钥提供者
+// pkg/security 中所有使用默认密钥的加解密都通过当前密钥提供者获取密钥
+type KeyProvider interface {
+	// Name 提供者名称，用于日志
+	Name() string
+	// GetKey 获取指定版本的密钥字符串，版本0为默认密钥
+	GetKey(ctx context.Context, version uint16) (string, error)
+}
+
+// KeyProviderConfig 密钥提供者配置，对应 app.key_provider
+type KeyProviderConfig struct {
+	// Type 提供者类型：static、vault、aws_kms、gcp_kms
+	Type string `mapstructure:"type"`
+	// RefreshInterval 外部密钥的缓存刷新间隔(秒)，默认300
+	RefreshInterval int `mapstructure:"refresh_interval"`
+	// Timeout 访问外部服务的超时时间(秒)，默认10
+	Timeout int `mapstructure:"timeout"`
+	// Vault HashiCorp Vault 配置
+	Vault VaultKeyConfig `mapstructure:"vault"`
+	// AWSKMS AWS KMS 配置
+	AWSKMS AWSKMSKeyConfig `mapstructure:"aws_kms"`
+	// GCPKMS GCP Cloud KMS 配置
+	GCPKMS GCPKMSKeyConfig `mapstructure:"gcp_kms"`
+}
+
+var (
+	keyProviderMu sync.RWMutex
+	// customKeyProvider 通过 SetKeyProvider 设置的提供者，优先于配置
+	customKeyProvider KeyProvider
+	// configuredKeyProvider 按配置创建的提供者及其类型
+	configuredKeyProvider     KeyProvider
+	configuredKeyProviderType string
+)
+
+// SetKeyProvider 设置密钥提供者，传入nil时恢复为按配置创建的提供者
+//
+// 参数:
+//   - provider: 密钥提供者
+func SetKeyProvider(provider KeyProvider) {
+	keyProviderMu.Lock()
+	defer keyProviderMu.Unlock()
+	customKeyProvider = provider
+}
+
+// ResetKeyProvider 丢弃按配置创建的提供者及其缓存，下次使用时重新读取 app.key_provider
+func ResetKeyProvider() {
+	keyProviderMu.Lock()
+	defer keyProviderMu.Unlock()
+	configuredKeyProvider = nil
+	configuredKeyProviderType = ""
+}
+
+// GetKeyProvider 获取当前密钥提供者
+// 未通过 SetKeyProvider 设置时按配置 app.key_provider.type 创建，配置类型变化时自动重建
+//
+// 返回:
+//   - KeyProvider: 当前密钥提供者
+func GetKeyProvider() KeyProvider {
+	providerType := config.GetString("app.key_provider.type", KeyProviderStatic)
+
+	keyProviderMu.RLock()
+	if customKeyProvider != nil {
+		defer keyProviderMu.RUnlock()
+		return customKeyProvider
+	}
+	if configuredKeyProvider != nil && configuredKeyProviderType == providerType {
+		defer keyProviderMu.RUnlock()
+		return configuredKeyProvider
+	}
+	keyProviderMu.RUnlock()
+
+	provider, err := loadKeyProvider()
+	if err != nil {
+		logger.Error("创建密钥提供者失败", "type", providerType, "error", err)
+		provider = &errorKeyProvider{name: providerType, err: err}
+	}
+
+	keyProviderMu.Lock()
+	defer keyProviderMu.Unlock()
+	configuredKeyProvider = provider
+	configuredKeyProviderType = providerType
+	return provider
+}
+
+// loadKeyProvider 按配置创建密钥提供者
+func loadKeyProvider() (KeyProvider, error) {
+	var cfg KeyProviderConfig
+	if config.IsExist("app.key_provider") {
+		if err := config.GetSection("app.key_provider", &cfg); err != nil {
+			return nil, fmt.Errorf("读取密钥提供者配置失败: %w", err)
+		}
+	}
+	return NewKeyProvider(cfg)
+}
+
+// NewKeyProvider 根据配置创建密钥提供者
+// 外部提供者（vault、aws_kms、gcp_kms）会被包装为带缓存的提供者，
+// 缓存到期后重新获取，获取失败时继续使用旧密钥并记录告警
+//
+// 参数:
+//   - cfg: 密钥提供者配置
+//
+// 返回:
+//   - KeyProvider: 密钥提供者
+//   - error: 类型不支持或配置不完整时返回错误
+func NewKeyProvider(cfg KeyProviderConfig) (KeyProvider, error) {
+	timeout := 10 * time.Second
+	if cfg.Timeout > 0 {
+		timeout = time.Duration(cfg.Timeout) * time.Second
+	}
+	client := &http.Client{Timeout: timeout}
+
+	var provider KeyProvider
+	var err error
+	switch cfg.Type {
+	case "", KeyProviderStatic:
+		return staticKeyProvider{}, nil
+	case KeyProviderVault:
+		provider, err = newVaultKeyProvider(cfg.Vault, client)
+	case KeyProviderAWSKMS:
+		provider, err = newAWSKMSKeyProvider(cfg.AWSKMS, client)
+	case KeyProviderGCPKMS:
+		provider, err = newGCPKMSKeyProvider(cfg.GCPKMS, client)
+	default:
+		return nil, fmt.Errorf("不支持的密钥提供者类型: %s", cfg.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	refresh := 5 * time.Minute
+	if cfg.RefreshInterval > 0 {
+		refresh = time.Duration(cfg.RefreshInterval) * time.Second
+	}
+	return NewCachedKeyProvider(provider, refresh), nil
+}
+
+// staticKeyProvider 从环境变量和配置文件读取密钥
+//
+// 查找顺序：
+//   - 版本0：环境变量 GATEWAY_APP_ENCRYPTION_KEY、配置 app.encryption_key、内置默认值
+//   - 其他版本：环境变量 GATEWAY_APP_ENCRYPTION_KEY_V{版本号}、配置 app.encryption_keys
+type staticKeyProvider struct{}
+
+// Name 提供者名称
+func (staticKeyProvider) Name() string {
+	return KeyProviderStatic
+}
+
+// GetKey 获取指定版本的密钥
+func (staticKeyProvider) GetKey(_ context.Context, version uint16) (string, error) {
+	if version == LegacyKeyVersion {
+		if key := os.Getenv(EncryptionKeyEnv); key != "" {
+			return key, nil
+		}
+		return config.GetString("app.encryption_key", defaultEncryptionKey), nil
+	}
+
+	versionStr := strconv.Itoa(int(version))
+	if key := os.Getenv(EncryptionKeyEnv + "_V" + versionStr); key != "" {
+		return key, nil
+	}
+	keys := getConfiguredEncryptionKeys()
+	if key, ok := keys[versionStr]; ok && key != "" {
+		return key, nil
+	}
+	return "", fmt.Errorf("未找到版本 %d 的加密密钥，请检查 app.encryption_keys 配置", version)
+}
+
+// errorKeyProvider 配置错误时使用的提供者，所有获取都返回创建时的错误
+// 避免配置错误时静默退回内置默认密钥
+type errorKeyProvider struct {
+	name string
+	err  error
+}
+
+// Name 提供者名称
+func (p *errorKeyProvider) Name() string {
+	return p.name
+}
+
+// GetKey 返回创建提供者时的错误
+func (p *errorKeyProvider) GetKey(_ context.Context, _ uint16) (string, error) {
+	return "", p.err
+}
+
+// cachedKey 缓存的密钥
+type cachedKey struct {
+	key       string
+	fetchedAt time.Time
+}
+
+// CachedKeyProvider 带缓存的密钥提供者
+// 密钥在刷新间隔内直接使用缓存，到期后重新获取；重新获取失败时继续使用旧密钥，
+// 避免外部服务短暂不可用导致全部加解密失败
+type CachedKeyProvider struct {
+	provider KeyProvider
+	refresh  time.Duration
+	mu       sync.Mutex
+	entries  map[uint16]cachedKey
+}
+
+// NewCachedKeyProvider 创建带缓存的密钥提供者
+//
+// 参数:
+//   - provider: 实际获取密钥的提供者
+//   - refresh: 缓存刷新间隔
+//
+// 返回:
+//   - *CachedKeyProvider: 带缓存的密钥提供者
+func NewCachedKeyProvider(provider KeyProvider, refresh time.Duration) *CachedKeyProvider {
+	return &CachedKeyProvider{
+		provider: provider,
+		refresh:  refresh,
+		entries:  make(map[uint16]cachedKey),
+	}
+}
+
+// Name 提供者名称
+func (p *CachedKeyProvider) Name() string {
+	return p.provider.Name()
+}
+
+// GetKey 获取指定版本的密钥，优先使用未过期的缓存
+func (p *CachedKeyProvider) GetKey(ctx context.Context, version uint16) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.entries[version]
+	if ok && time.Since(entry.fetchedAt) < p.refresh {
+		return entry.key, nil
+	}
+
+	key, err := p.provider.GetKey(ctx, version)
+	if err != nil {
+		if ok {
+			if logger.RateLimit("keyProvider:"+p.provider.Name(), time.Minute) {
+				logger.Warn("刷新加密密钥失败，继续使用缓存的密钥",
+					"provider", p.provider.Name(), "keyVersion", version, "error", err)
+			}
+			return entry.key, nil
+		}
+		return "", fmt.Errorf("从 %s 获取版本 %d 的加密密钥失败: %w", p.provider.Name(), version, err)
+	}
+	if key == "" {
+		return "", fmt.Errorf("%s 返回的版本 %d 加密密钥为空", p.provider.Name(), version)
+	}
+
+	p.entries[version] = cachedKey{key: key, fetchedAt: time.Now()}
+	return key, nil
+}
+
+// Invalidate 清空缓存，下次获取时重新从外部服务读取
+func (p *CachedKeyProvider) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.entries = make(map[uint16]cachedKey)
+}
+
+// versionedKeyName 按版本生成密钥名，版本0使用原名，其他版本追加 _v{版本号}
+func versionedKeyName(name string, version uint16) string {
+	if version == LegacyKeyVersion {
+		return name
+	}
+	return name + "_v" + strconv.Itoa(int(version))
+}
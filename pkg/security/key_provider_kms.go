@@ -0,0 +1,315 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AWSKMSKeyConfig AWS KMS 密钥配置
+//
+// 加密密钥以KMS加密后的数据密钥（CiphertextBlob，Base64）形式保存在配置中，
+// 启动时调用 KMS Decrypt 还原，明文密钥按Base64编码后作为密钥字符串使用。
+type AWSKMSKeyConfig struct {
+	// Region 区域，为空时读取环境变量 AWS_REGION
+	Region string `mapstructure:"region"`
+	// Endpoint 自定义服务地址（VPC终端节点等），为空时使用 https://kms.{region}.amazonaws.com
+	Endpoint string `mapstructure:"endpoint"`
+	// KeyID 加密数据密钥使用的KMS密钥ID，可为空（对称密钥密文中已包含）
+	KeyID string `mapstructure:"key_id"`
+	// Ciphertext 版本0密钥的密文
+	Ciphertext string `mapstructure:"ciphertext"`
+	// Ciphertexts 其他版本密钥的密文，以版本号为键
+	Ciphertexts map[string]string `mapstructure:"ciphertexts"`
+	// AccessKeyID 访问密钥，为空时读取环境变量 AWS_ACCESS_KEY_ID
+	AccessKeyID string `mapstructure:"access_key_id"`
+	// SecretAccessKey 访问密钥，为空时读取环境变量 AWS_SECRET_ACCESS_KEY
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+	// SessionToken 临时凭证令牌，为空时读取环境变量 AWS_SESSION_TOKEN
+	SessionToken string `mapstructure:"session_token"`
+}
+
+// GCPKMSKeyConfig GCP Cloud KMS 密钥配置
+// 与 AWS KMS 相同，配置中保存KMS加密后的数据密钥，启动时解密
+type GCPKMSKeyConfig struct {
+	// KeyName 密钥资源名，如 projects/p/locations/global/keyRings/r/cryptoKeys/k
+	KeyName string `mapstructure:"key_name"`
+	// Endpoint 服务地址，默认 https://cloudkms.googleapis.com
+	Endpoint string `mapstructure:"endpoint"`
+	// Ciphertext 版本0密钥的密文（Base64）
+	Ciphertext string `mapstructure:"ciphertext"`
+	// Ciphertexts 其他版本密钥的密文，以版本号为键
+	Ciphertexts map[string]string `mapstructure:"ciphertexts"`
+	// AccessToken OAuth访问令牌，为空时读取环境变量 GOOGLE_OAUTH_ACCESS_TOKEN，
+	// 仍为空时从GCE元数据服务获取
+	AccessToken string `mapstructure:"access_token"`
+	// MetadataURL 元数据服务地址，默认 http://metadata.google.internal
+	MetadataURL string `mapstructure:"metadata_url"`
+}
+
+// kmsCiphertext 按版本选择数据密钥密文
+func kmsCiphertext(ciphertext string, ciphertexts map[string]string, version uint16) (string, error) {
+	if version == LegacyKeyVersion {
+		if ciphertext == "" {
+			return "", fmt.Errorf("未配置版本0的数据密钥密文")
+		}
+		return ciphertext, nil
+	}
+	if value := ciphertexts[strconv.Itoa(int(version))]; value != "" {
+		return value, nil
+	}
+	return "", fmt.Errorf("未配置版本 %d 的数据密钥密文", version)
+}
+
+// doKMSRequest 发送请求并解析JSON响应
+func doKMSRequest(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求KMS失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("读取KMS响应失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("KMS返回状态码 %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("解析KMS响应失败: %w", err)
+	}
+	return nil
+}
+
+// awsKMSKeyProvider 使用 AWS KMS Decrypt 还原数据密钥
+type awsKMSKeyProvider struct {
+	cfg    AWSKMSKeyConfig
+	client *http.Client
+}
+
+// newAWSKMSKeyProvider 创建 AWS KMS 密钥提供者
+func newAWSKMSKeyProvider(cfg AWSKMSKeyConfig, client *http.Client) (*awsKMSKeyProvider, error) {
+	if cfg.Region == "" {
+		cfg.Region = os.Getenv("AWS_REGION")
+	}
+	if cfg.AccessKeyID == "" {
+		cfg.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+	}
+	if cfg.SecretAccessKey == "" {
+		cfg.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+	if cfg.SessionToken == "" {
+		cfg.SessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	}
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("aws_kms 密钥提供者需要配置 region")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("aws_kms 密钥提供者需要配置访问密钥或环境变量 AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY")
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://kms." + cfg.Region + ".amazonaws.com"
+	}
+	return &awsKMSKeyProvider{cfg: cfg, client: client}, nil
+}
+
+// Name 提供者名称
+func (p *awsKMSKeyProvider) Name() string {
+	return KeyProviderAWSKMS
+}
+
+// GetKey 调用 KMS Decrypt 解密对应版本的数据密钥
+func (p *awsKMSKeyProvider) GetKey(ctx context.Context, version uint16) (string, error) {
+	ciphertext, err := kmsCiphertext(p.cfg.Ciphertext, p.cfg.Ciphertexts, version)
+	if err != nil {
+		return "", err
+	}
+
+	payload := map[string]string{"CiphertextBlob": ciphertext}
+	if p.cfg.KeyID != "" {
+		payload["KeyId"] = p.cfg.KeyID
+	}
+	body, _ := json.Marshal(payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("创建KMS请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService.Decrypt")
+	p.sign(req, body)
+
+	var result struct {
+		Plaintext string `json:"Plaintext"`
+	}
+	if err := doKMSRequest(p.client, req, &result); err != nil {
+		return "", err
+	}
+	if result.Plaintext == "" {
+		return "", fmt.Errorf("KMS返回的明文为空")
+	}
+	return result.Plaintext, nil
+}
+
+// sign 使用 AWS Signature Version 4 为请求签名
+func (p *awsKMSKeyProvider) sign(req *http.Request, body []byte) {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if p.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", p.cfg.SessionToken)
+	}
+
+	headers := map[string]string{
+		"content-type": req.Header.Get("Content-Type"),
+		"host":         req.URL.Host,
+		"x-amz-date":   amzDate,
+		"x-amz-target": req.Header.Get("X-Amz-Target"),
+	}
+	names := []string{"content-type", "host", "x-amz-date", "x-amz-target"}
+	if p.cfg.SessionToken != "" {
+		headers["x-amz-security-token"] = p.cfg.SessionToken
+		names = append(names, "x-amz-security-token")
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	bodyHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	scope := date + "/" + p.cfg.Region + "/kms/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+p.cfg.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, p.cfg.Region)
+	signingKey = hmacSHA256(signingKey, "kms")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		p.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// hmacSHA256 计算HMAC-SHA256
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// gcpKMSKeyProvider 使用 GCP Cloud KMS decrypt 还原数据密钥
+type gcpKMSKeyProvider struct {
+	cfg    GCPKMSKeyConfig
+	client *http.Client
+}
+
+// newGCPKMSKeyProvider 创建 GCP KMS 密钥提供者
+func newGCPKMSKeyProvider(cfg GCPKMSKeyConfig, client *http.Client) (*gcpKMSKeyProvider, error) {
+	if cfg.KeyName == "" {
+		return nil, fmt.Errorf("gcp_kms 密钥提供者需要配置 key_name")
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://cloudkms.googleapis.com"
+	}
+	if cfg.AccessToken == "" {
+		cfg.AccessToken = os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN")
+	}
+	if cfg.MetadataURL == "" {
+		cfg.MetadataURL = "http://metadata.google.internal"
+	}
+	return &gcpKMSKeyProvider{cfg: cfg, client: client}, nil
+}
+
+// Name 提供者名称
+func (p *gcpKMSKeyProvider) Name() string {
+	return KeyProviderGCPKMS
+}
+
+// GetKey 调用 Cloud KMS decrypt 解密对应版本的数据密钥
+func (p *gcpKMSKeyProvider) GetKey(ctx context.Context, version uint16) (string, error) {
+	ciphertext, err := kmsCiphertext(p.cfg.Ciphertext, p.cfg.Ciphertexts, version)
+	if err != nil {
+		return "", err
+	}
+	token, err := p.accessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	body, _ := json.Marshal(map[string]string{"ciphertext": ciphertext})
+	endpoint := strings.TrimRight(p.cfg.Endpoint, "/") + "/v1/" + strings.TrimLeft(p.cfg.KeyName, "/") + ":decrypt"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("创建KMS请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var result struct {
+		Plaintext string `json:"plaintext"`
+	}
+	if err := doKMSRequest(p.client, req, &result); err != nil {
+		return "", err
+	}
+	if result.Plaintext == "" {
+		return "", fmt.Errorf("KMS返回的明文为空")
+	}
+	return result.Plaintext, nil
+}
+
+// accessToken 获取访问令牌，未配置时从GCE元数据服务获取服务账号令牌
+func (p *gcpKMSKeyProvider) accessToken(ctx context.Context) (string, error) {
+	if p.cfg.AccessToken != "" {
+		return p.cfg.AccessToken, nil
+	}
+
+	tokenURL := strings.TrimRight(p.cfg.MetadataURL, "/") +
+		"/computeMetadata/v1/instance/service-accounts/default/token?scopes=" +
+		url.QueryEscape("https://www.googleapis.com/auth/cloudkms")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("创建元数据请求失败: %w", err)
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := doKMSRequest(p.client, req, &result); err != nil {
+		return "", fmt.Errorf("获取GCP访问令牌失败: %w", err)
+	}
+	if result.AccessToken == "" {
+		return "", fmt.Errorf("GCP元数据服务返回的访问令牌为空")
+	}
+	return result.AccessToken, nil
+}
@@ -0,0 +1,106 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// VaultKeyConfig HashiCorp Vault 密钥配置
+//
+// 密钥保存在一个 KV 机密中：版本0读取 Field 字段，其他版本读取 Field_v{版本号} 字段。
+// 同时兼容 KV v1（data.字段）和 KV v2（data.data.字段）的返回格式。
+type VaultKeyConfig struct {
+	// Address Vault地址，为空时读取环境变量 VAULT_ADDR
+	Address string `mapstructure:"address"`
+	// Token 访问令牌，为空时读取环境变量 VAULT_TOKEN
+	Token string `mapstructure:"token"`
+	// Namespace 命名空间（Vault企业版），可为空
+	Namespace string `mapstructure:"namespace"`
+	// Path 机密的API路径（不含 /v1/ 前缀），如 secret/data/gateway
+	Path string `mapstructure:"path"`
+	// Field 密钥字段名，默认 encryption_key
+	Field string `mapstructure:"field"`
+}
+
+// vaultKeyProvider 从 HashiCorp Vault 读取密钥
+type vaultKeyProvider struct {
+	cfg    VaultKeyConfig
+	client *http.Client
+}
+
+// newVaultKeyProvider 创建 Vault 密钥提供者
+func newVaultKeyProvider(cfg VaultKeyConfig, client *http.Client) (*vaultKeyProvider, error) {
+	if cfg.Address == "" {
+		cfg.Address = os.Getenv("VAULT_ADDR")
+	}
+	if cfg.Token == "" {
+		cfg.Token = os.Getenv("VAULT_TOKEN")
+	}
+	if cfg.Field == "" {
+		cfg.Field = "encryption_key"
+	}
+	if cfg.Address == "" || cfg.Path == "" {
+		return nil, fmt.Errorf("vault 密钥提供者需要配置 address 和 path")
+	}
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("vault 密钥提供者需要配置 token 或环境变量 VAULT_TOKEN")
+	}
+	return &vaultKeyProvider{cfg: cfg, client: client}, nil
+}
+
+// Name 提供者名称
+func (p *vaultKeyProvider) Name() string {
+	return KeyProviderVault
+}
+
+// GetKey 读取 Vault 机密中对应版本的密钥字段
+func (p *vaultKeyProvider) GetKey(ctx context.Context, version uint16) (string, error) {
+	url := strings.TrimRight(p.cfg.Address, "/") + "/v1/" + strings.TrimLeft(p.cfg.Path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("创建vault请求失败: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", p.cfg.Token)
+	if p.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.cfg.Namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("请求vault失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("读取vault响应失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault返回状态码 %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("解析vault响应失败: %w", err)
+	}
+
+	// KV v2 的字段位于 data.data 下
+	data := secret.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		data = nested
+	}
+
+	field := versionedKeyName(p.cfg.Field, version)
+	key, ok := data[field].(string)
+	if !ok || key == "" {
+		return "", fmt.Errorf("vault机密 %s 中不存在字段 %s", p.cfg.Path, field)
+	}
+	return key, nil
+}
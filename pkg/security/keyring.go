@@ -0,0 +1,210 @@
+package security
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gateway/pkg/config"
+)
+
+// LegacyKeyVersion 旧版密钥版本号
+// 版本0固定对应 GetDefaultEncryptionKey 返回的默认密钥，不带密钥版本的旧密文也使用该密钥解密
+const LegacyKeyVersion uint16 = 0
+
+// keyVersionHeaderSize 密钥版本头长度（2字节，大端序）
+const keyVersionHeaderSize = 2
+
+// 加密算法，对应配置 app.encryption_algorithm
+const (
+	// EncryptionAlgorithmAES AES-256-GCM（默认）
+	EncryptionAlgorithmAES = "aes"
+	// EncryptionAlgorithmSM4 国密SM4-GCM
+	EncryptionAlgorithmSM4 = "sm4"
+)
+
+// GetEncryptionAlgorithm 获取用于加密的算法
+// 读取配置 app.encryption_algorithm，未配置或无法识别时为 aes。
+// 解密按密文中的版本号选择算法，切换算法后已有的AES密文仍可解密
+//
+// 返回:
+//   - string: EncryptionAlgorithmAES 或 EncryptionAlgorithmSM4
+func GetEncryptionAlgorithm() string {
+	if strings.EqualFold(config.GetString("app.encryption_algorithm", EncryptionAlgorithmAES), EncryptionAlgorithmSM4) {
+		return EncryptionAlgorithmSM4
+	}
+	return EncryptionAlgorithmAES
+}
+
+// hasKeyVersion 判断该版本的密文是否带密钥版本头
+func hasKeyVersion(version byte) bool {
+	return version == AESGCMKeyedVersion || version == SM4GCMKeyedVersion
+}
+
+// GetEncryptionKeyVersion 获取当前用于加密的密钥版本
+// 读取配置 app.encryption_key_version，未配置时为0（默认密钥）
+//
+// 返回:
+//   - uint16: 当前密钥版本
+func GetEncryptionKeyVersion() uint16 {
+	version := config.GetInt("app.encryption_key_version", int(LegacyKeyVersion))
+	if version < 0 || version > 0xFFFF {
+		return LegacyKeyVersion
+	}
+	return uint16(version)
+}
+
+// GetEncryptionKeyByVersion 获取指定版本的密钥
+// 密钥由当前密钥提供者（GetKeyProvider）提供，默认从环境变量和配置文件读取：
+//  1. 版本0：GetDefaultEncryptionKey（环境变量 GATEWAY_APP_ENCRYPTION_KEY 或 app.encryption_key）
+//  2. 环境变量 GATEWAY_APP_ENCRYPTION_KEY_V{版本号}
+//  3. 配置 app.encryption_keys 中以版本号为键的密钥
+//
+// 参数:
+//   - version: 密钥版本
+//
+// 返回:
+//   - string: 密钥字符串
+//   - error: 未找到该版本密钥或外部密钥服务不可用时返回错误
+func GetEncryptionKeyByVersion(version uint16) (string, error) {
+	return GetKeyProvider().GetKey(context.Background(), version)
+}
+
+// ErrEncryptionKeyMissing 解密所需的加密密钥未配置
+var ErrEncryptionKeyMissing = errors.New("解密所需的加密密钥未配置")
+
+// IsDecryptionKeyConfigured 判断解密密文所需的密钥是否已配置
+// 静态密钥提供者的版本0密钥未通过环境变量或 app.encryption_key 设置时（退回内置默认密钥）视为未配置；
+// 其他版本或其他密钥提供者按能否获取到密钥判断。无法解析的密文不涉及密钥，返回true
+//
+// 参数:
+//   - ciphertext: 带 "ENCY_" 前缀的密文
+//
+// 返回:
+//   - bool: 密钥已配置返回true
+func IsDecryptionKeyConfigured(ciphertext string) bool {
+	encryptedData, err := EncryptedDataFromString(ciphertext)
+	if err != nil {
+		return true
+	}
+	version := LegacyKeyVersion
+	if hasKeyVersion(encryptedData.Version) {
+		version = encryptedData.KeyVersion
+	}
+	if version == LegacyKeyVersion && GetKeyProvider().Name() == KeyProviderStatic {
+		return os.Getenv(EncryptionKeyEnv) != "" || config.GetString("app.encryption_key", "") != ""
+	}
+	_, err = GetEncryptionKeyByVersion(version)
+	return err == nil
+}
+
+// getConfiguredEncryptionKeys 读取配置中的版本化密钥
+func getConfiguredEncryptionKeys() map[string]string {
+	keys := make(map[string]string)
+	if !config.IsExist("app.encryption_keys") {
+		return keys
+	}
+	if err := config.GetSection("app.encryption_keys", &keys); err != nil {
+		return map[string]string{}
+	}
+	return keys
+}
+
+// EncryptWithKeyVersion 使用指定版本的密钥加密（带密钥版本头的AES-GCM格式）
+// 配置 app.encryption_algorithm 为 sm4 时使用带密钥版本头的SM4-GCM格式
+//
+// 参数:
+//   - version: 密钥版本
+//   - plaintext: 待加密的明文字节数组
+//
+// 返回:
+//   - string: 加密后的字符串密文（带 "ENCY_" 前缀）
+//   - error: 未找到密钥或加密失败时返回错误
+func EncryptWithKeyVersion(version uint16, plaintext []byte) (string, error) {
+	secretKey, err := GetEncryptionKeyByVersion(version)
+	if err != nil {
+		return "", err
+	}
+	encrypted, err := encryptWithKeyVersion(secretKey, version, plaintext)
+	if err != nil {
+		return "", err
+	}
+	return encrypted.ToString()
+}
+
+// encryptWithKeyVersion 按 app.encryption_algorithm 选择AES-GCM或SM4-GCM，加密并写入密钥版本
+func encryptWithKeyVersion(secretKey string, version uint16, plaintext []byte) (*EncryptedData, error) {
+	if GetEncryptionAlgorithm() == EncryptionAlgorithmSM4 {
+		return SM4EncryptWithKeyVersion(secretKey, version, plaintext)
+	}
+	return encryptGCMWithKeyVersion(DeriveKeyFromString(secretKey), version, plaintext, nil)
+}
+
+// decryptBytesWithKey 按密文版本号选择AES或SM4解密
+func decryptBytesWithKey(secretKey string, encryptedData *EncryptedData) ([]byte, error) {
+	if encryptedData.Version == SM4GCMKeyedVersion {
+		return SM4DecryptBytes(secretKey, encryptedData)
+	}
+	return AESDecryptBytes(secretKey, encryptedData)
+}
+
+// encryptGCMWithKeyVersion 使用AES-GCM加密并写入密钥版本
+// 密钥版本作为附加认证数据参与认证，篡改版本号会导致解密失败
+func encryptGCMWithKeyVersion(key []byte, version uint16, plaintext []byte, aad []byte) (*EncryptedData, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("创建AES cipher失败: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("创建GCM模式失败: %w", err)
+	}
+
+	nonce := make([]byte, GCMNonceSize)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("生成nonce失败: %w", err)
+	}
+
+	ciphertext := gcm.Seal(nil, nonce, plaintext, keyedAAD(version, aad))
+	return &EncryptedData{
+		Version:    AESGCMKeyedVersion,
+		KeyVersion: version,
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+		AAD:        encodeOptionalBase64(aad),
+	}, nil
+}
+
+// keyedAAD 组装带密钥版本的附加认证数据：密钥版本(2字节) || 用户AAD
+func keyedAAD(version uint16, aad []byte) []byte {
+	buf := make([]byte, keyVersionHeaderSize+len(aad))
+	binary.BigEndian.PutUint16(buf, version)
+	copy(buf[keyVersionHeaderSize:], aad)
+	return buf
+}
+
+// decryptWithKeyring 按密文中的密钥版本选择密钥解密
+// 不带密钥版本的旧格式密文使用默认密钥（版本0）解密，SM4密文按版本号识别
+func decryptWithKeyring(encryptedData *EncryptedData) ([]byte, error) {
+	version := LegacyKeyVersion
+	if hasKeyVersion(encryptedData.Version) {
+		version = encryptedData.KeyVersion
+	}
+	secretKey, err := GetEncryptionKeyByVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	return decryptBytesWithKey(secretKey, encryptedData)
+}
@@ -0,0 +1,303 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gateway/pkg/config"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 密码哈希算法
+const (
+	// PasswordAlgorithmBcrypt bcrypt算法
+	PasswordAlgorithmBcrypt = "bcrypt"
+	// PasswordAlgorithmArgon2id argon2id算法（默认）
+	PasswordAlgorithmArgon2id = "argon2id"
+)
+
+// ErrInvalidPasswordHash 密码哈希格式无效
+var ErrInvalidPasswordHash = errors.New("无效的密码哈希格式")
+
+// PasswordHashConfig 密码哈希配置，对应 app.password_hash
+//
+// 用户密码必须使用不可逆的哈希保存，不能使用 EncryptWithDefaultKey 等可逆加密。
+// 调整参数后，已有哈希在下次校验成功时通过 VerifyPasswordAndRehash 自动升级。
+type PasswordHashConfig struct {
+	// Algorithm 算法：argon2id、bcrypt
+	Algorithm string `mapstructure:"algorithm"`
+	// BcryptCost bcrypt计算成本（4-31）
+	BcryptCost int `mapstructure:"bcrypt_cost"`
+	// Argon2Memory argon2id内存开销(KiB)
+	Argon2Memory uint32 `mapstructure:"argon2_memory"`
+	// Argon2Iterations argon2id迭代次数
+	Argon2Iterations uint32 `mapstructure:"argon2_iterations"`
+	// Argon2Parallelism argon2id并行度
+	Argon2Parallelism uint8 `mapstructure:"argon2_parallelism"`
+	// Argon2SaltLength 盐长度(字节)
+	Argon2SaltLength uint32 `mapstructure:"argon2_salt_length"`
+	// Argon2KeyLength 哈希长度(字节)
+	Argon2KeyLength uint32 `mapstructure:"argon2_key_length"`
+}
+
+// DefaultPasswordHashConfig 默认密码哈希配置
+// argon2id 参数参考 OWASP 推荐值（64MiB内存、3次迭代）
+//
+// 返回:
+//   - PasswordHashConfig: 默认配置
+func DefaultPasswordHashConfig() PasswordHashConfig {
+	return PasswordHashConfig{
+		Algorithm:         PasswordAlgorithmArgon2id,
+		BcryptCost:        12,
+		Argon2Memory:      64 * 1024,
+		Argon2Iterations:  3,
+		Argon2Parallelism: 2,
+		Argon2SaltLength:  16,
+		Argon2KeyLength:   32,
+	}
+}
+
+// GetPasswordHashConfig 读取配置 app.password_hash，未配置的字段使用默认值
+//
+// 返回:
+//   - PasswordHashConfig: 密码哈希配置
+func GetPasswordHashConfig() PasswordHashConfig {
+	cfg := DefaultPasswordHashConfig()
+	if config.IsExist("app.password_hash") {
+		// 读取失败时保留默认值
+		_ = config.GetSection("app.password_hash", &cfg)
+	}
+	return cfg.withDefaults()
+}
+
+// withDefaults 补全未设置的参数
+func (c PasswordHashConfig) withDefaults() PasswordHashConfig {
+	defaults := DefaultPasswordHashConfig()
+	if c.Algorithm == "" {
+		c.Algorithm = defaults.Algorithm
+	}
+	if c.BcryptCost == 0 {
+		c.BcryptCost = defaults.BcryptCost
+	}
+	if c.Argon2Memory == 0 {
+		c.Argon2Memory = defaults.Argon2Memory
+	}
+	if c.Argon2Iterations == 0 {
+		c.Argon2Iterations = defaults.Argon2Iterations
+	}
+	if c.Argon2Parallelism == 0 {
+		c.Argon2Parallelism = defaults.Argon2Parallelism
+	}
+	if c.Argon2SaltLength == 0 {
+		c.Argon2SaltLength = defaults.Argon2SaltLength
+	}
+	if c.Argon2KeyLength == 0 {
+		c.Argon2KeyLength = defaults.Argon2KeyLength
+	}
+	return c
+}
+
+// HashPassword 使用配置的算法计算密码哈希
+//
+// 参数:
+//   - password: 明文密码
+//
+// 返回:
+//   - string: 密码哈希，bcrypt 为 "$2a$..." 格式，argon2id 为 PHC 格式 "$argon2id$v=19$m=...,t=...,p=...$盐$哈希"
+//   - error: 计算失败时返回错误
+//
+// 示例:
+//
+//	hash, err := security.HashPassword("P@ssw0rd")
+func HashPassword(password string) (string, error) {
+	return HashPasswordWithConfig(password, GetPasswordHashConfig())
+}
+
+// HashPasswordWithConfig 使用指定配置计算密码哈希
+//
+// 参数:
+//   - password: 明文密码
+//   - cfg: 密码哈希配置
+//
+// 返回:
+//   - string: 密码哈希
+//   - error: 算法不支持或计算失败时返回错误
+func HashPasswordWithConfig(password string, cfg PasswordHashConfig) (string, error) {
+	cfg = cfg.withDefaults()
+	switch cfg.Algorithm {
+	case PasswordAlgorithmBcrypt:
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), cfg.BcryptCost)
+		if err != nil {
+			return "", fmt.Errorf("计算bcrypt哈希失败: %w", err)
+		}
+		return string(hash), nil
+	case PasswordAlgorithmArgon2id:
+		salt := make([]byte, cfg.Argon2SaltLength)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return "", fmt.Errorf("生成盐失败: %w", err)
+		}
+		key := argon2.IDKey([]byte(password), salt, cfg.Argon2Iterations, cfg.Argon2Memory, cfg.Argon2Parallelism, cfg.Argon2KeyLength)
+		return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+			argon2.Version, cfg.Argon2Memory, cfg.Argon2Iterations, cfg.Argon2Parallelism,
+			base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+	default:
+		return "", fmt.Errorf("不支持的密码哈希算法: %s", cfg.Algorithm)
+	}
+}
+
+// VerifyPassword 校验密码与哈希是否匹配，根据哈希格式自动识别算法
+//
+// 参数:
+//   - password: 明文密码
+//   - hash: HashPassword 生成的密码哈希
+//
+// 返回:
+//   - bool: 是否匹配
+//   - error: 哈希格式无效时返回错误，密码不匹配不视为错误
+func VerifyPassword(password, hash string) (bool, error) {
+	switch {
+	case isBcryptHash(hash):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("%w: %v", ErrInvalidPasswordHash, err)
+		}
+		return true, nil
+	case strings.HasPrefix(hash, "$argon2id$"):
+		params, salt, key, err := parseArgon2idHash(hash)
+		if err != nil {
+			return false, err
+		}
+		actual := argon2.IDKey([]byte(password), salt, params.Argon2Iterations, params.Argon2Memory, params.Argon2Parallelism, uint32(len(key)))
+		return subtle.ConstantTimeCompare(actual, key) == 1, nil
+	default:
+		return false, ErrInvalidPasswordHash
+	}
+}
+
+// VerifyPasswordAndRehash 校验密码，匹配且哈希参数低于当前配置时返回新哈希
+//
+// 调用方在返回的新哈希不为空时应将其保存，替换原哈希，
+// 从而在用户登录时逐步完成算法或参数升级。
+//
+// 参数:
+//   - password: 明文密码
+//   - hash: 已保存的密码哈希
+//
+// 返回:
+//   - bool: 是否匹配
+//   - string: 需要升级时的新哈希，无需升级时为空
+//   - error: 哈希格式无效或重新计算失败时返回错误
+func VerifyPasswordAndRehash(password, hash string) (bool, string, error) {
+	ok, err := VerifyPassword(password, hash)
+	if err != nil || !ok {
+		return ok, "", err
+	}
+
+	cfg := GetPasswordHashConfig()
+	if !passwordNeedsRehash(hash, cfg) {
+		return true, "", nil
+	}
+	newHash, err := HashPasswordWithConfig(password, cfg)
+	if err != nil {
+		return true, "", err
+	}
+	return true, newHash, nil
+}
+
+// NeedsRehash 判断哈希的算法或参数是否与当前配置不一致
+//
+// 参数:
+//   - hash: 密码哈希
+//
+// 返回:
+//   - bool: 是否需要重新计算
+func NeedsRehash(hash string) bool {
+	return passwordNeedsRehash(hash, GetPasswordHashConfig())
+}
+
+// IsPasswordHash 判断字符串是否为支持的密码哈希格式
+// 可用于识别历史明文或可逆加密保存的密码，以便迁移
+//
+// 参数:
+//   - value: 待判断的字符串
+//
+// 返回:
+//   - bool: 是否为密码哈希
+func IsPasswordHash(value string) bool {
+	if isBcryptHash(value) {
+		return true
+	}
+	_, _, _, err := parseArgon2idHash(value)
+	return err == nil
+}
+
+// passwordNeedsRehash 按指定配置判断是否需要重新计算
+func passwordNeedsRehash(hash string, cfg PasswordHashConfig) bool {
+	cfg = cfg.withDefaults()
+	switch cfg.Algorithm {
+	case PasswordAlgorithmBcrypt:
+		if !isBcryptHash(hash) {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(hash))
+		return err != nil || cost < cfg.BcryptCost
+	case PasswordAlgorithmArgon2id:
+		params, salt, key, err := parseArgon2idHash(hash)
+		if err != nil {
+			return true
+		}
+		return params.Argon2Memory < cfg.Argon2Memory ||
+			params.Argon2Iterations < cfg.Argon2Iterations ||
+			params.Argon2Parallelism != cfg.Argon2Parallelism ||
+			uint32(len(salt)) < cfg.Argon2SaltLength ||
+			uint32(len(key)) < cfg.Argon2KeyLength
+	default:
+		return false
+	}
+}
+
+// isBcryptHash 判断是否为bcrypt哈希
+func isBcryptHash(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+// parseArgon2idHash 解析PHC格式的argon2id哈希
+func parseArgon2idHash(hash string) (PasswordHashConfig, []byte, []byte, error) {
+	var params PasswordHashConfig
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != PasswordAlgorithmArgon2id {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("%w: 不支持的argon2版本", ErrInvalidPasswordHash)
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Argon2Memory, &params.Argon2Iterations, &params.Argon2Parallelism); err != nil {
+		return params, nil, nil, fmt.Errorf("%w: %v", ErrInvalidPasswordHash, err)
+	}
+	// 参数为0时 argon2.IDKey 会panic，按无效哈希处理
+	if params.Argon2Memory == 0 || params.Argon2Iterations == 0 || params.Argon2Parallelism == 0 {
+		return params, nil, nil, fmt.Errorf("%w: argon2参数必须为正数", ErrInvalidPasswordHash)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("%w: %v", ErrInvalidPasswordHash, err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+	params.Algorithm = PasswordAlgorithmArgon2id
+	return params, salt, key, nil
+}
@@ -0,0 +1,141 @@
+package security
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSameRotationKey 新旧密钥相同
+var ErrSameRotationKey = errors.New("新旧密钥不能相同")
+
+// KeyRotation 密钥轮换
+// 使用旧密钥解密、新密钥重新加密，供配置值和数据库字段的批量迁移使用
+type KeyRotation struct {
+	oldKey string
+	newKey string
+	// newKeyVersion 新密文写入的密钥版本，nil时使用不带密钥版本的AES-GCM格式；
+	// 非nil时按 app.encryption_algorithm 使用AES-GCM或SM4-GCM格式
+	newKeyVersion *uint16
+}
+
+// RotateKey 创建密钥轮换
+//
+// 参数:
+//   - oldKey: 旧密钥字符串
+//   - newKey: 新密钥字符串
+//
+// 返回:
+//   - *KeyRotation: 密钥轮换对象
+//   - error: 密钥为空或新旧密钥相同时返回错误
+//
+// 示例:
+//
+//	rotation, err := security.RotateKey(oldKey, newKey)
+//	newCiphertext, changed, err := rotation.Reencrypt(ciphertext)
+func RotateKey(oldKey, newKey string) (*KeyRotation, error) {
+	if oldKey == "" || newKey == "" {
+		return nil, fmt.Errorf("新旧密钥都不能为空")
+	}
+	if oldKey == newKey {
+		return nil, ErrSameRotationKey
+	}
+	return &KeyRotation{oldKey: oldKey, newKey: newKey}, nil
+}
+
+// RotateToKeyVersion 创建轮换到指定密钥版本的密钥轮换
+// 新密文使用带密钥版本头的格式，DecryptWithDefaultKey 可按版本自动选择密钥；
+// app.encryption_algorithm 为 sm4 时新密文使用SM4-GCM，可用于将已有AES密文迁移为国密算法
+//
+// 参数:
+//   - oldKey: 旧密钥字符串
+//   - version: 目标密钥版本，密钥从 app.encryption_keys 或环境变量读取
+//
+// 返回:
+//   - *KeyRotation: 密钥轮换对象
+//   - error: 未找到目标版本密钥或新旧密钥相同时返回错误
+func RotateToKeyVersion(oldKey string, version uint16) (*KeyRotation, error) {
+	newKey, err := GetEncryptionKeyByVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	rotation, err := RotateKey(oldKey, newKey)
+	if err != nil {
+		return nil, err
+	}
+	rotation.newKeyVersion = &version
+	return rotation, nil
+}
+
+// Reencrypt 使用新密钥重新加密单个密文
+//
+// 处理规则：
+//   - 明文（没有 "ENCY_" 前缀）保持不变
+//   - 旧密钥解密失败但新密钥可以解密的密文视为已轮换，保持不变，便于中断后重复执行
+//
+// 参数:
+//   - ciphertext: 字符串密文
+//
+// 返回:
+//   - string: 新密文，未变更时返回原值
+//   - bool: 是否发生变更
+//   - error: 新旧密钥都无法解密或加密失败时返回错误
+func (r *KeyRotation) Reencrypt(ciphertext string) (string, bool, error) {
+	if !IsEncryptedString(ciphertext) {
+		return ciphertext, false, nil
+	}
+
+	encryptedData, err := EncryptedDataFromString(ciphertext)
+	if err != nil {
+		return "", false, fmt.Errorf("解析加密数据失败: %w", err)
+	}
+
+	plaintext, err := decryptBytesWithKey(r.oldKey, encryptedData)
+	if err != nil {
+		if _, newErr := decryptBytesWithKey(r.newKey, encryptedData); newErr == nil {
+			return ciphertext, false, nil
+		}
+		return "", false, fmt.Errorf("旧密钥解密失败: %w", err)
+	}
+
+	var reencrypted *EncryptedData
+	if r.newKeyVersion != nil {
+		reencrypted, err = encryptWithKeyVersion(r.newKey, *r.newKeyVersion, plaintext)
+	} else {
+		reencrypted, err = AESEncryptBytes(r.newKey, plaintext)
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("新密钥加密失败: %w", err)
+	}
+
+	result, err := reencrypted.ToString()
+	if err != nil {
+		return "", false, err
+	}
+	return result, true, nil
+}
+
+// ReencryptValues 批量重新加密，返回新值列表
+// 任意一个值失败时返回错误及其下标，已处理的结果不返回，调用方应整体放弃本批次
+//
+// 参数:
+//   - values: 密文或明文列表
+//
+// 返回:
+//   - []string: 重新加密后的值，与输入一一对应
+//   - int: 发生变更的数量
+//   - error: 处理失败时返回错误
+func (r *KeyRotation) ReencryptValues(values []string) ([]string, int, error) {
+	result := make([]string, len(values))
+	changed := 0
+	for i, value := range values {
+		newValue, ok, err := r.Reencrypt(value)
+		if err != nil {
+			return nil, 0, fmt.Errorf("第 %d 个值重新加密失败: %w", i, err)
+		}
+		if ok {
+			changed++
+		}
+		result[i] = newValue
+	}
+	return result, changed, nil
+}
@@ -0,0 +1,422 @@
+package security
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 请求签名使用的HTTP头
+const (
+	// SignatureAccessKeyHeader 访问密钥ID，验签时据此查找签名密钥
+	SignatureAccessKeyHeader = "X-Access-Key"
+	// SignatureTimestampHeader 签名时间戳（Unix秒）
+	SignatureTimestampHeader = "X-Timestamp"
+	// SignatureNonceHeader 随机数，防止重放
+	SignatureNonceHeader = "X-Nonce"
+	// SignatureSignedHeadersHeader 参与签名的请求头，小写，分号分隔
+	SignatureSignedHeadersHeader = "X-Signed-Headers"
+	// SignatureHeader 签名值（HMAC-SHA256，十六进制）
+	SignatureHeader = "X-Signature"
+)
+
+// DefaultSignatureMaxSkew 默认允许的签名时间偏差
+const DefaultSignatureMaxSkew = 5 * time.Minute
+
+// 签名校验错误
+var (
+	ErrSignatureMissing  = errors.New("缺少签名信息")
+	ErrSignatureExpired  = errors.New("签名已过期或时间戳无效")
+	ErrSignatureReplay   = errors.New("重复的签名请求")
+	ErrSignatureMismatch = errors.New("签名不匹配")
+)
+
+// SignatureParams 参与签名的请求要素
+type SignatureParams struct {
+	// Method 请求方法
+	Method string
+	// Path 请求路径（未解码的原始路径）
+	Path string
+	// Query 查询参数
+	Query url.Values
+	// Headers 请求头
+	Headers http.Header
+	// SignedHeaders 参与签名的请求头名称
+	SignedHeaders []string
+	// Body 请求体
+	Body []byte
+	// Timestamp 签名时间戳（Unix秒）
+	Timestamp int64
+	// Nonce 随机数
+	Nonce string
+}
+
+// CanonicalString 生成规范化的待签名字符串
+//
+// 格式（各部分以换行分隔）：
+//
+//	方法(大写)
+//	路径
+//	按键排序的查询参数（key=value&...，URL编码）
+//	按名称排序的请求头（name:value，每行一个）
+//	参与签名的请求头（小写，分号分隔）
+//	请求体SHA256（十六进制）
+//	时间戳
+//	随机数
+//
+// 返回:
+//   - string: 待签名字符串
+func (p SignatureParams) CanonicalString() string {
+	path := p.Path
+	if path == "" {
+		path = "/"
+	}
+
+	names := normalizeHeaderNames(p.SignedHeaders)
+	var headers strings.Builder
+	for _, name := range names {
+		values := p.Headers.Values(name)
+		trimmed := make([]string, len(values))
+		for i, value := range values {
+			trimmed[i] = strings.TrimSpace(value)
+		}
+		headers.WriteString(name + ":" + strings.Join(trimmed, ",") + "\n")
+	}
+
+	bodyHash := sha256.Sum256(p.Body)
+	return strings.Join([]string{
+		strings.ToUpper(p.Method),
+		path,
+		canonicalQuery(p.Query),
+		headers.String(),
+		strings.Join(names, ";"),
+		hex.EncodeToString(bodyHash[:]),
+		strconv.FormatInt(p.Timestamp, 10),
+		p.Nonce,
+	}, "\n")
+}
+
+// canonicalQuery 按键和值排序并编码查询参数
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, url.QueryEscape(key)+"="+url.QueryEscape(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// normalizeHeaderNames 请求头名称转小写、去重并排序
+func normalizeHeaderNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// HMACSHA256Hex 计算HMAC-SHA256并返回十六进制字符串
+//
+// 参数:
+//   - secret: 签名密钥
+//   - data: 待签名数据
+//
+// 返回:
+//   - string: 十六进制签名
+func HMACSHA256Hex(secret string, data []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sign 计算请求签名
+//
+// 参数:
+//   - secret: 签名密钥
+//   - params: 请求要素
+//
+// 返回:
+//   - string: 十六进制签名
+func Sign(secret string, params SignatureParams) string {
+	return HMACSHA256Hex(secret, []byte(params.CanonicalString()))
+}
+
+// SignHTTPRequest 为HTTP请求签名，设置时间戳、随机数和签名请求头
+// 请求体会被读取后重新放回，调用后请求仍可正常发送
+//
+// 参数:
+//   - req: HTTP请求
+//   - accessKey: 访问密钥ID
+//   - secret: 签名密钥
+//   - signedHeaders: 额外参与签名的请求头，如 Content-Type
+//
+// 返回:
+//   - error: 读取请求体失败时返回错误
+func SignHTTPRequest(req *http.Request, accessKey, secret string, signedHeaders ...string) error {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return err
+	}
+
+	params := SignatureParams{
+		Method:        req.Method,
+		Path:          req.URL.EscapedPath(),
+		Query:         req.URL.Query(),
+		Headers:       req.Header,
+		SignedHeaders: normalizeHeaderNames(signedHeaders),
+		Body:          body,
+		Timestamp:     time.Now().Unix(),
+		Nonce:         newNonce(),
+	}
+
+	if accessKey != "" {
+		req.Header.Set(SignatureAccessKeyHeader, accessKey)
+	}
+	req.Header.Set(SignatureTimestampHeader, strconv.FormatInt(params.Timestamp, 10))
+	req.Header.Set(SignatureNonceHeader, params.Nonce)
+	req.Header.Set(SignatureSignedHeadersHeader, strings.Join(params.SignedHeaders, ";"))
+	req.Header.Set(SignatureHeader, Sign(secret, params))
+	return nil
+}
+
+// SignatureVerifier 请求签名校验器
+type SignatureVerifier struct {
+	// SecretLookup 根据访问密钥ID查找签名密钥
+	SecretLookup func(accessKey string) (string, error)
+	// MaxSkew 允许的时间偏差，默认5分钟
+	MaxSkew time.Duration
+	// NonceStore 随机数存储，用于拒绝重放请求；为nil时不检查重放
+	NonceStore NonceStore
+}
+
+// VerifyHTTPRequest 校验HTTP请求签名
+// 请求体会被读取后重新放回，校验后仍可继续转发
+//
+// 参数:
+//   - req: HTTP请求
+//
+// 返回:
+//   - string: 通过校验的访问密钥ID
+//   - error: 签名缺失、过期、重放或不匹配时返回对应错误
+func (v *SignatureVerifier) VerifyHTTPRequest(req *http.Request) (string, error) {
+	accessKey := req.Header.Get(SignatureAccessKeyHeader)
+	signature := req.Header.Get(SignatureHeader)
+	nonce := req.Header.Get(SignatureNonceHeader)
+	timestampStr := req.Header.Get(SignatureTimestampHeader)
+	if signature == "" || nonce == "" || timestampStr == "" {
+		return accessKey, ErrSignatureMissing
+	}
+
+	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil || !v.withinSkew(timestamp) {
+		return accessKey, ErrSignatureExpired
+	}
+
+	if v.SecretLookup == nil {
+		return accessKey, fmt.Errorf("未配置签名密钥查找函数")
+	}
+	secret, err := v.SecretLookup(accessKey)
+	if err != nil {
+		return accessKey, err
+	}
+
+	body, err := readRequestBody(req)
+	if err != nil {
+		return accessKey, err
+	}
+
+	var signedHeaders []string
+	if value := req.Header.Get(SignatureSignedHeadersHeader); value != "" {
+		signedHeaders = strings.Split(value, ";")
+	}
+	expected := Sign(secret, SignatureParams{
+		Method:        req.Method,
+		Path:          req.URL.EscapedPath(),
+		Query:         req.URL.Query(),
+		Headers:       req.Header,
+		SignedHeaders: signedHeaders,
+		Body:          body,
+		Timestamp:     timestamp,
+		Nonce:         nonce,
+	})
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return accessKey, ErrSignatureMismatch
+	}
+
+	// 签名通过后再记录随机数，避免伪造请求占用随机数
+	if v.NonceStore != nil && !v.NonceStore.Add(accessKey+":"+nonce, 2*v.maxSkew()) {
+		return accessKey, ErrSignatureReplay
+	}
+	return accessKey, nil
+}
+
+// maxSkew 允许的时间偏差
+func (v *SignatureVerifier) maxSkew() time.Duration {
+	if v.MaxSkew > 0 {
+		return v.MaxSkew
+	}
+	return DefaultSignatureMaxSkew
+}
+
+// withinSkew 判断时间戳是否在允许的偏差内
+func (v *SignatureVerifier) withinSkew(timestamp int64) bool {
+	diff := time.Since(time.Unix(timestamp, 0))
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= v.maxSkew()
+}
+
+// newNonce 生成32位十六进制随机数
+func newNonce() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
+
+// readRequestBody 读取请求体并重新放回
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("读取请求体失败: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// PayloadSignature 消息签名，用于节点间同步消息等非HTTP场景
+type PayloadSignature struct {
+	Timestamp int64  `json:"timestamp"`
+	Nonce     string `json:"nonce"`
+	Signature string `json:"signature"`
+}
+
+// SignPayload 为消息体签名
+//
+// 参数:
+//   - secret: 签名密钥
+//   - payload: 消息体
+//
+// 返回:
+//   - *PayloadSignature: 签名信息，随消息一同发送
+func SignPayload(secret string, payload []byte) *PayloadSignature {
+	sig := &PayloadSignature{
+		Timestamp: time.Now().Unix(),
+		Nonce:     newNonce(),
+	}
+	sig.Signature = HMACSHA256Hex(secret, payloadSigningData(payload, sig.Timestamp, sig.Nonce))
+	return sig
+}
+
+// VerifyPayload 校验消息体签名
+//
+// 参数:
+//   - secret: 签名密钥
+//   - payload: 消息体
+//   - sig: 签名信息
+//   - maxSkew: 允许的时间偏差，<=0时使用默认值
+//   - nonces: 随机数存储，为nil时不检查重放
+//
+// 返回:
+//   - error: 签名缺失、过期、重放或不匹配时返回对应错误
+func VerifyPayload(secret string, payload []byte, sig *PayloadSignature, maxSkew time.Duration, nonces NonceStore) error {
+	if sig == nil || sig.Signature == "" || sig.Nonce == "" {
+		return ErrSignatureMissing
+	}
+	verifier := &SignatureVerifier{MaxSkew: maxSkew}
+	if !verifier.withinSkew(sig.Timestamp) {
+		return ErrSignatureExpired
+	}
+	expected := HMACSHA256Hex(secret, payloadSigningData(payload, sig.Timestamp, sig.Nonce))
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(sig.Signature))) {
+		return ErrSignatureMismatch
+	}
+	if nonces != nil && !nonces.Add(sig.Nonce, 2*verifier.maxSkew()) {
+		return ErrSignatureReplay
+	}
+	return nil
+}
+
+// payloadSigningData 消息签名数据：时间戳\n随机数\n消息体
+func payloadSigningData(payload []byte, timestamp int64, nonce string) []byte {
+	prefix := strconv.FormatInt(timestamp, 10) + "\n" + nonce + "\n"
+	data := make([]byte, 0, len(prefix)+len(payload))
+	data = append(data, prefix...)
+	return append(data, payload...)
+}
+
+// NonceStore 随机数存储，用于拒绝重放
+type NonceStore interface {
+	// Add 记录随机数，已存在时返回false
+	Add(nonce string, ttl time.Duration) bool
+}
+
+// MemoryNonceStore 基于内存的随机数存储，适用于单节点
+// 多节点部署时应使用共享存储（如Redis SETNX）实现 NonceStore
+type MemoryNonceStore struct {
+	mu      sync.Mutex
+	entries map[string]time.Time
+	// lastSweep 上次清理过期随机数的时间
+	lastSweep time.Time
+}
+
+// NewMemoryNonceStore 创建内存随机数存储
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{entries: make(map[string]time.Time), lastSweep: time.Now()}
+}
+
+// Add 记录随机数，已存在且未过期时返回false
+func (s *MemoryNonceStore) Add(nonce string, ttl time.Duration) bool {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) > time.Minute {
+		for key, expireAt := range s.entries {
+			if now.After(expireAt) {
+				delete(s.entries, key)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	if expireAt, ok := s.entries[nonce]; ok && now.Before(expireAt) {
+		return false
+	}
+	s.entries[nonce] = now.Add(ttl)
+	return true
+}
@@ -0,0 +1,381 @@
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// SM2DefaultUID SM2签名的默认用户身份标识，GM/T 0009 规定未约定时使用该值
+const SM2DefaultUID = "1234567812345678"
+
+// sm2CurveName SM2推荐曲线名称
+const sm2CurveName = "SM2P256V1"
+
+// sm2ByteSize SM2曲线坐标和私钥的字节长度
+const sm2ByteSize = 32
+
+var (
+	// oidPublicKeyEC 椭圆曲线公钥算法标识，SM2密钥同样使用该标识，曲线参数区分SM2
+	oidPublicKeyEC = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	// oidNamedCurveSM2 SM2推荐曲线标识
+	oidNamedCurveSM2 = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 301}
+
+	// ErrInvalidSM2Key SM2密钥格式无效
+	ErrInvalidSM2Key = errors.New("无效的SM2密钥")
+
+	sm2Once   sync.Once
+	sm2Params *elliptic.CurveParams
+)
+
+// SM2P256 返回SM2推荐曲线
+// 曲线参数遵循 GB/T 32918.5-2017，a = p - 3，可以使用 elliptic.CurveParams 的通用实现
+//
+// 返回:
+//   - elliptic.Curve: SM2曲线
+func SM2P256() elliptic.Curve {
+	sm2Once.Do(func() {
+		sm2Params = &elliptic.CurveParams{Name: sm2CurveName, BitSize: 256}
+		sm2Params.P, _ = new(big.Int).SetString("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF00000000FFFFFFFFFFFFFFFF", 16)
+		sm2Params.N, _ = new(big.Int).SetString("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFF7203DF6B21C6052B53BBF40939D54123", 16)
+		sm2Params.B, _ = new(big.Int).SetString("28E9FA9E9D9F5E344D5A9E4BCF6509A7F39789F515AB8F92DDBCBD414D940E93", 16)
+		sm2Params.Gx, _ = new(big.Int).SetString("32C4AE2C1F1981195F9904466A39C9948FE30BBFF2660BE1715A4589334C74C7", 16)
+		sm2Params.Gy, _ = new(big.Int).SetString("BC3736A2F4F6779C59BDCEE36B692153D0A9877CC62A474002DF32E52139F0A0", 16)
+	})
+	return sm2Params
+}
+
+// IsSM2Key 判断公钥是否为SM2曲线上的密钥
+// SM2密钥使用 *ecdsa.PublicKey / *ecdsa.PrivateKey 表示，以曲线区分
+//
+// 参数:
+//   - pub: 椭圆曲线公钥
+//
+// 返回:
+//   - bool: 曲线为SM2时返回true
+func IsSM2Key(pub *ecdsa.PublicKey) bool {
+	return pub != nil && pub.Curve != nil && pub.Curve.Params().Name == sm2CurveName
+}
+
+// GenerateSM2KeyPair 生成SM2密钥对
+//
+// 返回:
+//   - *ecdsa.PrivateKey: 曲线为 SM2P256 的私钥，私钥取值范围为 [1, n-2]
+//   - error: 生成失败时返回错误
+func GenerateSM2KeyPair() (*ecdsa.PrivateKey, error) {
+	curve := SM2P256()
+	// d ∈ [1, n-2]，保证签名时 1+d 可逆
+	limit := new(big.Int).Sub(curve.Params().N, big.NewInt(2))
+	d, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return nil, fmt.Errorf("生成SM2密钥失败: %w", err)
+	}
+	d.Add(d, big.NewInt(1))
+	return sm2PrivateKeyFromScalar(d), nil
+}
+
+// sm2PrivateKeyFromScalar 根据私钥标量计算公钥
+func sm2PrivateKeyFromScalar(d *big.Int) *ecdsa.PrivateKey {
+	curve := SM2P256()
+	x, y := curve.ScalarBaseMult(d.FillBytes(make([]byte, sm2ByteSize)))
+	return &ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: curve, X: x, Y: y}, D: d}
+}
+
+// sm2Signature ASN.1 编码的SM2签名（GM/T 0009）
+type sm2Signature struct {
+	R, S *big.Int
+}
+
+// SM2Sign 使用SM2私钥签名
+// 签名前按 GB/T 32918.2 计算 e = SM3(Z_A || msg)，Z_A 由用户身份标识和公钥计算
+//
+// 参数:
+//   - key: SM2私钥
+//   - uid: 用户身份标识，为空时使用 SM2DefaultUID
+//   - msg: 待签名数据
+//
+// 返回:
+//   - []byte: ASN.1 DER 编码的签名
+//   - error: 密钥不是SM2密钥或签名失败时返回错误
+func SM2Sign(key *ecdsa.PrivateKey, uid, msg []byte) ([]byte, error) {
+	if key == nil || !IsSM2Key(&key.PublicKey) || key.D == nil {
+		return nil, ErrInvalidSM2Key
+	}
+	n := key.Curve.Params().N
+	e := sm2MessageDigest(&key.PublicKey, uid, msg)
+
+	// (1 + d)^-1 mod n
+	dPlus1Inv := new(big.Int).ModInverse(new(big.Int).Add(key.D, big.NewInt(1)), n)
+	if dPlus1Inv == nil {
+		return nil, ErrInvalidSM2Key
+	}
+
+	for {
+		k, err := rand.Int(rand.Reader, new(big.Int).Sub(n, big.NewInt(1)))
+		if err != nil {
+			return nil, fmt.Errorf("SM2签名失败: %w", err)
+		}
+		k.Add(k, big.NewInt(1))
+
+		x1, _ := key.Curve.ScalarBaseMult(k.FillBytes(make([]byte, sm2ByteSize)))
+		r := new(big.Int).Add(e, x1)
+		r.Mod(r, n)
+		if r.Sign() == 0 || new(big.Int).Add(r, k).Cmp(n) == 0 {
+			continue
+		}
+
+		// s = (1 + d)^-1 * (k - r*d) mod n
+		s := new(big.Int).Mul(r, key.D)
+		s.Sub(k, s)
+		s.Mul(s, dPlus1Inv)
+		s.Mod(s, n)
+		if s.Sign() == 0 {
+			continue
+		}
+		return asn1.Marshal(sm2Signature{R: r, S: s})
+	}
+}
+
+// SM2Verify 使用SM2公钥校验签名
+//
+// 参数:
+//   - pub: SM2公钥
+//   - uid: 用户身份标识，必须与签名时相同，为空时使用 SM2DefaultUID
+//   - msg: 原始数据
+//   - signature: ASN.1 DER 编码的签名
+//
+// 返回:
+//   - bool: 签名有效返回true
+func SM2Verify(pub *ecdsa.PublicKey, uid, msg, signature []byte) bool {
+	if !IsSM2Key(pub) || pub.X == nil || pub.Y == nil || !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+		return false
+	}
+	var sig sm2Signature
+	rest, err := asn1.Unmarshal(signature, &sig)
+	if err != nil || len(rest) > 0 || sig.R == nil || sig.S == nil {
+		return false
+	}
+
+	n := pub.Curve.Params().N
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.Cmp(n) >= 0 || sig.S.Cmp(n) >= 0 {
+		return false
+	}
+	t := new(big.Int).Add(sig.R, sig.S)
+	t.Mod(t, n)
+	if t.Sign() == 0 {
+		return false
+	}
+
+	// (x1, y1) = s*G + t*P
+	x1, y1 := pub.Curve.ScalarBaseMult(sig.S.FillBytes(make([]byte, sm2ByteSize)))
+	x2, y2 := pub.Curve.ScalarMult(pub.X, pub.Y, t.FillBytes(make([]byte, sm2ByteSize)))
+	x, _ := pub.Curve.Add(x1, y1, x2, y2)
+
+	e := sm2MessageDigest(pub, uid, msg)
+	r := new(big.Int).Add(e, x)
+	r.Mod(r, n)
+	return r.Cmp(sig.R) == 0
+}
+
+// sm2MessageDigest 计算 e = SM3(Z_A || msg)
+// Z_A = SM3(ENTL_A || ID_A || a || b || x_G || y_G || x_A || y_A)
+func sm2MessageDigest(pub *ecdsa.PublicKey, uid, msg []byte) *big.Int {
+	if len(uid) == 0 {
+		uid = []byte(SM2DefaultUID)
+	}
+	params := pub.Curve.Params()
+	a := new(big.Int).Sub(params.P, big.NewInt(3))
+
+	h := NewSM3()
+	entl := len(uid) * 8
+	h.Write([]byte{byte(entl >> 8), byte(entl)})
+	h.Write(uid)
+	for _, v := range []*big.Int{a, params.B, params.Gx, params.Gy, pub.X, pub.Y} {
+		h.Write(v.FillBytes(make([]byte, sm2ByteSize)))
+	}
+	za := h.Sum(nil)
+
+	h.Reset()
+	h.Write(za)
+	h.Write(msg)
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
+
+// ========== SM2 密钥编码 ==========
+
+// sm2PublicKeyInfo PKIX 公钥结构
+type sm2PublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// sm2PKCS8 PKCS#8 私钥结构
+type sm2PKCS8 struct {
+	Version    int
+	Algorithm  pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// sm2ECPrivateKey SEC 1 私钥结构
+type sm2ECPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// sm2AlgorithmIdentifier SM2公钥算法标识：ecPublicKey + SM2曲线
+func sm2AlgorithmIdentifier() (pkix.AlgorithmIdentifier, error) {
+	params, err := asn1.Marshal(oidNamedCurveSM2)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+	return pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyEC, Parameters: asn1.RawValue{FullBytes: params}}, nil
+}
+
+// isSM2Algorithm 判断算法标识是否为SM2
+func isSM2Algorithm(algo pkix.AlgorithmIdentifier) bool {
+	if !algo.Algorithm.Equal(oidPublicKeyEC) {
+		return false
+	}
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(algo.Parameters.FullBytes, &curve); err != nil {
+		return false
+	}
+	return curve.Equal(oidNamedCurveSM2)
+}
+
+// sm2MarshalPoint 将公钥编码为未压缩点格式 0x04 || X || Y
+func sm2MarshalPoint(pub *ecdsa.PublicKey) []byte {
+	point := make([]byte, 1+2*sm2ByteSize)
+	point[0] = 0x04
+	pub.X.FillBytes(point[1 : 1+sm2ByteSize])
+	pub.Y.FillBytes(point[1+sm2ByteSize:])
+	return point
+}
+
+// sm2UnmarshalPoint 解析未压缩点格式的公钥
+func sm2UnmarshalPoint(point []byte) (*ecdsa.PublicKey, error) {
+	if len(point) != 1+2*sm2ByteSize || point[0] != 0x04 {
+		return nil, ErrInvalidSM2Key
+	}
+	curve := SM2P256()
+	x := new(big.Int).SetBytes(point[1 : 1+sm2ByteSize])
+	y := new(big.Int).SetBytes(point[1+sm2ByteSize:])
+	if !curve.IsOnCurve(x, y) {
+		return nil, ErrInvalidSM2Key
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+// MarshalSM2PublicKey 将SM2公钥编码为PKIX（SubjectPublicKeyInfo）DER
+//
+// 参数:
+//   - pub: SM2公钥
+//
+// 返回:
+//   - []byte: DER编码
+//   - error: 不是SM2公钥时返回错误
+func MarshalSM2PublicKey(pub *ecdsa.PublicKey) ([]byte, error) {
+	if !IsSM2Key(pub) {
+		return nil, ErrInvalidSM2Key
+	}
+	algo, err := sm2AlgorithmIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	point := sm2MarshalPoint(pub)
+	return asn1.Marshal(sm2PublicKeyInfo{
+		Algorithm: algo,
+		PublicKey: asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+}
+
+// ParseSM2PublicKey 解析PKIX（SubjectPublicKeyInfo）DER编码的SM2公钥
+//
+// 参数:
+//   - der: DER编码
+//
+// 返回:
+//   - *ecdsa.PublicKey: SM2公钥
+//   - error: 格式无效或不是SM2公钥时返回错误
+func ParseSM2PublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	var info sm2PublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil || len(rest) > 0 || !isSM2Algorithm(info.Algorithm) {
+		return nil, ErrInvalidSM2Key
+	}
+	return sm2UnmarshalPoint(info.PublicKey.RightAlign())
+}
+
+// MarshalSM2PrivateKey 将SM2私钥编码为PKCS#8 DER
+//
+// 参数:
+//   - key: SM2私钥
+//
+// 返回:
+//   - []byte: DER编码
+//   - error: 不是SM2私钥时返回错误
+func MarshalSM2PrivateKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	if key == nil || key.D == nil || !IsSM2Key(&key.PublicKey) {
+		return nil, ErrInvalidSM2Key
+	}
+	point := sm2MarshalPoint(&key.PublicKey)
+	ecKey, err := asn1.Marshal(sm2ECPrivateKey{
+		Version:    1,
+		PrivateKey: key.D.FillBytes(make([]byte, sm2ByteSize)),
+		PublicKey:  asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+	if err != nil {
+		return nil, err
+	}
+	algo, err := sm2AlgorithmIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(sm2PKCS8{Algorithm: algo, PrivateKey: ecKey})
+}
+
+// ParseSM2PrivateKey 解析SM2私钥，支持 PKCS#8 和 SEC 1 格式的DER编码
+//
+// 参数:
+//   - der: DER编码
+//
+// 返回:
+//   - *ecdsa.PrivateKey: SM2私钥
+//   - error: 格式无效或不是SM2私钥时返回错误
+func ParseSM2PrivateKey(der []byte) (*ecdsa.PrivateKey, error) {
+	var pkcs8 sm2PKCS8
+	if rest, err := asn1.Unmarshal(der, &pkcs8); err == nil && len(rest) == 0 {
+		if !isSM2Algorithm(pkcs8.Algorithm) {
+			return nil, ErrInvalidSM2Key
+		}
+		return parseSM2ECPrivateKey(pkcs8.PrivateKey, false)
+	}
+	return parseSM2ECPrivateKey(der, true)
+}
+
+// parseSM2ECPrivateKey 解析SEC 1私钥，独立的SEC 1私钥必须声明SM2曲线
+func parseSM2ECPrivateKey(der []byte, requireCurve bool) (*ecdsa.PrivateKey, error) {
+	var ecKey sm2ECPrivateKey
+	rest, err := asn1.Unmarshal(der, &ecKey)
+	if err != nil || len(rest) > 0 || ecKey.Version != 1 {
+		return nil, ErrInvalidSM2Key
+	}
+	if requireCurve && !ecKey.NamedCurveOID.Equal(oidNamedCurveSM2) {
+		return nil, ErrInvalidSM2Key
+	}
+
+	d := new(big.Int).SetBytes(ecKey.PrivateKey)
+	nMinus1 := new(big.Int).Sub(SM2P256().Params().N, big.NewInt(1))
+	if d.Sign() <= 0 || d.Cmp(nMinus1) >= 0 {
+		return nil, ErrInvalidSM2Key
+	}
+	return sm2PrivateKeyFromScalar(d), nil
+}
@@ -0,0 +1,185 @@
+package security
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"math/bits"
+)
+
+const (
+	// SM3Size SM3摘要长度（256位 = 32字节）
+	SM3Size = 32
+	// SM3BlockSize SM3分组长度（512位 = 64字节）
+	SM3BlockSize = 64
+)
+
+// sm3IV SM3初始值
+var sm3IV = [8]uint32{
+	0x7380166f, 0x4914b2b9, 0x172442d7, 0xda8a0600,
+	0xa96f30bc, 0x163138aa, 0xe38dee4d, 0xb0fb0e4e,
+}
+
+// sm3Digest SM3哈希状态，实现 hash.Hash
+// 算法遵循 GB/T 32905-2016《信息安全技术 SM3密码杂凑算法》
+type sm3Digest struct {
+	h   [8]uint32
+	buf [SM3BlockSize]byte
+	n   int    // buf 中未处理的字节数
+	len uint64 // 已写入的总字节数
+}
+
+// NewSM3 创建SM3哈希
+//
+// 返回:
+//   - hash.Hash: SM3哈希，可用于 hmac.New 等需要 hash.Hash 的场景
+func NewSM3() hash.Hash {
+	d := new(sm3Digest)
+	d.Reset()
+	return d
+}
+
+// SM3Sum 计算SM3摘要
+//
+// 参数:
+//   - data: 待计算摘要的数据
+//
+// 返回:
+//   - [SM3Size]byte: 32字节摘要
+func SM3Sum(data []byte) [SM3Size]byte {
+	d := new(sm3Digest)
+	d.Reset()
+	d.Write(data)
+	var sum [SM3Size]byte
+	d.checkSum(sum[:0])
+	return sum
+}
+
+// SM3 计算SM3哈希值
+// 参数:
+//   - data: 待计算哈希的数据
+//
+// 返回:
+//   - string: SM3哈希值的十六进制字符串（64位）
+func SM3(data string) string {
+	sum := SM3Sum([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// Reset 重置为初始状态
+func (d *sm3Digest) Reset() {
+	d.h = sm3IV
+	d.n = 0
+	d.len = 0
+}
+
+// Size 返回摘要长度
+func (d *sm3Digest) Size() int { return SM3Size }
+
+// BlockSize 返回分组长度
+func (d *sm3Digest) BlockSize() int { return SM3BlockSize }
+
+// Write 写入数据，满整组时压缩
+func (d *sm3Digest) Write(p []byte) (int, error) {
+	written := len(p)
+	d.len += uint64(written)
+	if d.n > 0 {
+		copied := copy(d.buf[d.n:], p)
+		d.n += copied
+		p = p[copied:]
+		if d.n < SM3BlockSize {
+			return written, nil
+		}
+		d.compress(d.buf[:])
+		d.n = 0
+	}
+	for len(p) >= SM3BlockSize {
+		d.compress(p[:SM3BlockSize])
+		p = p[SM3BlockSize:]
+	}
+	d.n = copy(d.buf[:], p)
+	return written, nil
+}
+
+// Sum 将当前摘要追加到 b 后返回，不改变哈希状态
+func (d *sm3Digest) Sum(b []byte) []byte {
+	clone := *d
+	return clone.checkSum(b)
+}
+
+// checkSum 填充并输出摘要，填充规则与SHA-256相同：0x80、补零、64位大端序消息位长度
+func (d *sm3Digest) checkSum(b []byte) []byte {
+	bitLen := d.len << 3
+	var pad [SM3BlockSize + 8]byte
+	pad[0] = 0x80
+	padLen := SM3BlockSize - (d.n+8)%SM3BlockSize
+	binary.BigEndian.PutUint64(pad[padLen:], bitLen)
+	d.Write(pad[:padLen+8])
+
+	var out [SM3Size]byte
+	for i, v := range d.h {
+		binary.BigEndian.PutUint32(out[i*4:], v)
+	}
+	return append(b, out[:]...)
+}
+
+// compress 压缩一个64字节分组
+func (d *sm3Digest) compress(block []byte) {
+	var w [68]uint32
+	for i := 0; i < 16; i++ {
+		w[i] = binary.BigEndian.Uint32(block[i*4:])
+	}
+	for j := 16; j < 68; j++ {
+		w[j] = sm3P1(w[j-16]^w[j-9]^bits.RotateLeft32(w[j-3], 15)) ^ bits.RotateLeft32(w[j-13], 7) ^ w[j-6]
+	}
+
+	a, b, c, dd, e, f, g, h := d.h[0], d.h[1], d.h[2], d.h[3], d.h[4], d.h[5], d.h[6], d.h[7]
+	for j := 0; j < 64; j++ {
+		t := uint32(0x79cc4519)
+		if j >= 16 {
+			t = 0x7a879d8a
+		}
+		a12 := bits.RotateLeft32(a, 12)
+		ss1 := bits.RotateLeft32(a12+e+bits.RotateLeft32(t, j%32), 7)
+		ss2 := ss1 ^ a12
+
+		var ff, gg uint32
+		if j < 16 {
+			ff = a ^ b ^ c
+			gg = e ^ f ^ g
+		} else {
+			ff = (a & b) | (a & c) | (b & c)
+			gg = (e & f) | (^e & g)
+		}
+		tt1 := ff + dd + ss2 + (w[j] ^ w[j+4])
+		tt2 := gg + h + ss1 + w[j]
+
+		dd = c
+		c = bits.RotateLeft32(b, 9)
+		b = a
+		a = tt1
+		h = g
+		g = bits.RotateLeft32(f, 19)
+		f = e
+		e = sm3P0(tt2)
+	}
+
+	d.h[0] ^= a
+	d.h[1] ^= b
+	d.h[2] ^= c
+	d.h[3] ^= dd
+	d.h[4] ^= e
+	d.h[5] ^= f
+	d.h[6] ^= g
+	d.h[7] ^= h
+}
+
+// sm3P0 置换函数P0
+func sm3P0(x uint32) uint32 {
+	return x ^ bits.RotateLeft32(x, 9) ^ bits.RotateLeft32(x, 17)
+}
+
+// sm3P1 置换函数P1
+func sm3P1(x uint32) uint32 {
+	return x ^ bits.RotateLeft32(x, 15) ^ bits.RotateLeft32(x, 23)
+}
@@ -0,0 +1,240 @@
+package security
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+const (
+	// SM4BlockSize SM4分组长度（128位 = 16字节）
+	SM4BlockSize = 16
+	// SM4KeySize SM4密钥长度（128位 = 16字节）
+	SM4KeySize = 16
+)
+
+// ErrInvalidSM4KeyLength SM4密钥长度错误
+var ErrInvalidSM4KeyLength = errors.New("无效的SM4密钥长度，必须为16字节")
+
+// sm4Sbox SM4 S盒
+var sm4Sbox = [256]byte{
+	0xd6, 0x90, 0xe9, 0xfe, 0xcc, 0xe1, 0x3d, 0xb7, 0x16, 0xb6, 0x14, 0xc2, 0x28, 0xfb, 0x2c, 0x05,
+	0x2b, 0x67, 0x9a, 0x76, 0x2a, 0xbe, 0x04, 0xc3, 0xaa, 0x44, 0x13, 0x26, 0x49, 0x86, 0x06, 0x99,
+	0x9c, 0x42, 0x50, 0xf4, 0x91, 0xef, 0x98, 0x7a, 0x33, 0x54, 0x0b, 0x43, 0xed, 0xcf, 0xac, 0x62,
+	0xe4, 0xb3, 0x1c, 0xa9, 0xc9, 0x08, 0xe8, 0x95, 0x80, 0xdf, 0x94, 0xfa, 0x75, 0x8f, 0x3f, 0xa6,
+	0x47, 0x07, 0xa7, 0xfc, 0xf3, 0x73, 0x17, 0xba, 0x83, 0x59, 0x3c, 0x19, 0xe6, 0x85, 0x4f, 0xa8,
+	0x68, 0x6b, 0x81, 0xb2, 0x71, 0x64, 0xda, 0x8b, 0xf8, 0xeb, 0x0f, 0x4b, 0x70, 0x56, 0x9d, 0x35,
+	0x1e, 0x24, 0x0e, 0x5e, 0x63, 0x58, 0xd1, 0xa2, 0x25, 0x22, 0x7c, 0x3b, 0x01, 0x21, 0x78, 0x87,
+	0xd4, 0x00, 0x46, 0x57, 0x9f, 0xd3, 0x27, 0x52, 0x4c, 0x36, 0x02, 0xe7, 0xa0, 0xc4, 0xc8, 0x9e,
+	0xea, 0xbf, 0x8a, 0xd2, 0x40, 0xc7, 0x38, 0xb5, 0xa3, 0xf7, 0xf2, 0xce, 0xf9, 0x61, 0x15, 0xa1,
+	0xe0, 0xae, 0x5d, 0xa4, 0x9b, 0x34, 0x1a, 0x55, 0xad, 0x93, 0x32, 0x30, 0xf5, 0x8c, 0xb1, 0xe3,
+	0x1d, 0xf6, 0xe2, 0x2e, 0x82, 0x66, 0xca, 0x60, 0xc0, 0x29, 0x23, 0xab, 0x0d, 0x53, 0x4e, 0x6f,
+	0xd5, 0xdb, 0x37, 0x45, 0xde, 0xfd, 0x8e, 0x2f, 0x03, 0xff, 0x6a, 0x72, 0x6d, 0x6c, 0x5b, 0x51,
+	0x8d, 0x1b, 0xaf, 0x92, 0xbb, 0xdd, 0xbc, 0x7f, 0x11, 0xd9, 0x5c, 0x41, 0x1f, 0x10, 0x5a, 0xd8,
+	0x0a, 0xc1, 0x31, 0x88, 0xa5, 0xcd, 0x7b, 0xbd, 0x2d, 0x74, 0xd0, 0x12, 0xb8, 0xe5, 0xb4, 0xb0,
+	0x89, 0x69, 0x97, 0x4a, 0x0c, 0x96, 0x77, 0x7e, 0x65, 0xb9, 0xf1, 0x09, 0xc5, 0x6e, 0xc6, 0x84,
+	0x18, 0xf0, 0x7d, 0xec, 0x3a, 0xdc, 0x4d, 0x20, 0x79, 0xee, 0x5f, 0x3e, 0xd7, 0xcb, 0x39, 0x48,
+}
+
+// sm4FK 密钥扩展使用的系统参数
+var sm4FK = [4]uint32{0xa3b1bac6, 0x56aa3350, 0x677d9197, 0xb27022dc}
+
+// sm4Cipher SM4分组密码，实现 cipher.Block
+// 算法遵循 GB/T 32907-2016《信息安全技术 SM4分组密码算法》
+type sm4Cipher struct {
+	enc [32]uint32 // 加密轮密钥
+	dec [32]uint32 // 解密轮密钥，为加密轮密钥的逆序
+}
+
+// NewSM4Cipher 创建SM4分组密码
+// 返回的 cipher.Block 可配合 cipher.NewGCM、cipher.NewCBCEncrypter 等标准库工作模式使用
+//
+// 参数:
+//   - key: 16字节密钥
+//
+// 返回:
+//   - cipher.Block: SM4分组密码
+//   - error: 密钥长度不是16字节时返回 ErrInvalidSM4KeyLength
+func NewSM4Cipher(key []byte) (cipher.Block, error) {
+	if len(key) != SM4KeySize {
+		return nil, ErrInvalidSM4KeyLength
+	}
+
+	c := new(sm4Cipher)
+	var k [4]uint32
+	for i := 0; i < 4; i++ {
+		k[i] = binary.BigEndian.Uint32(key[i*4:]) ^ sm4FK[i]
+	}
+	for i := 0; i < 32; i++ {
+		rk := k[0] ^ sm4KeyTransform(k[1]^k[2]^k[3]^sm4CK(i))
+		c.enc[i] = rk
+		c.dec[31-i] = rk
+		k[0], k[1], k[2], k[3] = k[1], k[2], k[3], rk
+	}
+	return c, nil
+}
+
+// BlockSize 返回分组长度
+func (c *sm4Cipher) BlockSize() int { return SM4BlockSize }
+
+// Encrypt 加密一个分组
+func (c *sm4Cipher) Encrypt(dst, src []byte) {
+	sm4Crypt(&c.enc, dst, src)
+}
+
+// Decrypt 解密一个分组
+func (c *sm4Cipher) Decrypt(dst, src []byte) {
+	sm4Crypt(&c.dec, dst, src)
+}
+
+// sm4Crypt 使用轮密钥执行32轮迭代，加解密结构相同，只是轮密钥顺序相反
+func sm4Crypt(rk *[32]uint32, dst, src []byte) {
+	if len(src) < SM4BlockSize || len(dst) < SM4BlockSize {
+		panic("security: SM4 输入或输出不足一个分组")
+	}
+	x0 := binary.BigEndian.Uint32(src[0:])
+	x1 := binary.BigEndian.Uint32(src[4:])
+	x2 := binary.BigEndian.Uint32(src[8:])
+	x3 := binary.BigEndian.Uint32(src[12:])
+	for i := 0; i < 32; i++ {
+		x0, x1, x2, x3 = x1, x2, x3, x0^sm4RoundTransform(x1^x2^x3^rk[i])
+	}
+	// 反序变换
+	binary.BigEndian.PutUint32(dst[0:], x3)
+	binary.BigEndian.PutUint32(dst[4:], x2)
+	binary.BigEndian.PutUint32(dst[8:], x1)
+	binary.BigEndian.PutUint32(dst[12:], x0)
+}
+
+// sm4CK 密钥扩展使用的固定参数，第i个参数的第j字节为 (4i+j)*7 mod 256
+func sm4CK(i int) uint32 {
+	var ck uint32
+	for j := 0; j < 4; j++ {
+		ck = ck<<8 | uint32(byte((4*i+j)*7))
+	}
+	return ck
+}
+
+// sm4Tau 非线性变换，逐字节查S盒
+func sm4Tau(a uint32) uint32 {
+	return uint32(sm4Sbox[a>>24])<<24 | uint32(sm4Sbox[a>>16&0xff])<<16 |
+		uint32(sm4Sbox[a>>8&0xff])<<8 | uint32(sm4Sbox[a&0xff])
+}
+
+// sm4RoundTransform 轮函数中的合成置换T
+func sm4RoundTransform(a uint32) uint32 {
+	b := sm4Tau(a)
+	return b ^ bits.RotateLeft32(b, 2) ^ bits.RotateLeft32(b, 10) ^ bits.RotateLeft32(b, 18) ^ bits.RotateLeft32(b, 24)
+}
+
+// sm4KeyTransform 密钥扩展中的合成置换T'
+func sm4KeyTransform(a uint32) uint32 {
+	b := sm4Tau(a)
+	return b ^ bits.RotateLeft32(b, 13) ^ bits.RotateLeft32(b, 23)
+}
+
+// DeriveSM4KeyFromString 从字符串派生SM4密钥（使用SM3）
+// 参数:
+//   - secretKey: 原始密钥字符串
+//
+// 返回:
+//   - []byte: SM3摘要的前16字节
+func DeriveSM4KeyFromString(secretKey string) []byte {
+	sum := SM3Sum([]byte(secretKey))
+	return sum[:SM4KeySize]
+}
+
+// SM4EncryptWithKeyVersion 使用SM4-GCM加密并写入密钥版本
+// 密文格式与带密钥版本的AES-GCM相同，仅版本号不同，密钥版本作为附加认证数据参与认证
+//
+// 参数:
+//   - secretKey: 密钥字符串，会通过SM3派生为16字节SM4密钥
+//   - version: 密钥版本
+//   - plaintext: 待加密的明文字节数组
+//
+// 返回:
+//   - *EncryptedData: 加密后的数据结构
+//   - error: 加密过程中的错误
+func SM4EncryptWithKeyVersion(secretKey string, version uint16, plaintext []byte) (*EncryptedData, error) {
+	gcm, err := newSM4GCM(DeriveSM4KeyFromString(secretKey))
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, GCMNonceSize)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("生成nonce失败: %w", err)
+	}
+
+	ciphertext := gcm.Seal(nil, nonce, plaintext, keyedAAD(version, nil))
+	return &EncryptedData{
+		Version:    SM4GCMKeyedVersion,
+		KeyVersion: version,
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	}, nil
+}
+
+// SM4DecryptBytes 解密SM4-GCM密文
+// 参数:
+//   - secretKey: 密钥字符串，会通过SM3派生为16字节SM4密钥
+//   - encryptedData: 加密数据结构，版本必须为 SM4GCMKeyedVersion
+//
+// 返回:
+//   - []byte: 解密后的明文字节数组
+//   - error: 版本不匹配、密钥错误或数据被篡改时返回错误
+func SM4DecryptBytes(secretKey string, encryptedData *EncryptedData) ([]byte, error) {
+	if encryptedData.Version != SM4GCMKeyedVersion {
+		return nil, ErrUnsupportedVersion
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(encryptedData.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("nonce解码失败: %w", err)
+	}
+	if len(nonce) != GCMNonceSize {
+		return nil, fmt.Errorf("nonce长度错误，期望%d字节，实际%d字节", GCMNonceSize, len(nonce))
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encryptedData.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("密文解码失败: %w", err)
+	}
+	if len(ciphertext) < GCMTagSize {
+		return nil, ErrCiphertextTooShort
+	}
+
+	var aad []byte
+	if encryptedData.AAD != "" {
+		if aad, err = base64.StdEncoding.DecodeString(encryptedData.AAD); err != nil {
+			return nil, fmt.Errorf("AAD解码失败: %w", err)
+		}
+	}
+
+	gcm, err := newSM4GCM(DeriveSM4KeyFromString(secretKey))
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, keyedAAD(encryptedData.KeyVersion, aad))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+	return plaintext, nil
+}
+
+// newSM4GCM 创建SM4-GCM认证加密
+func newSM4GCM(key []byte) (cipher.AEAD, error) {
+	block, err := NewSM4Cipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("创建GCM模式失败: %w", err)
+	}
+	return gcm, nil
+}
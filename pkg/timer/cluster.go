@@ -0,0 +1,383 @@
+package timer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"gateway/pkg/cache"
+	"gateway/pkg/config"
+	"gateway/pkg/logger"
+)
+
+// ClusterMode 任务在集群中的执行方式
+type ClusterMode int
+
+const (
+	ClusterModeExclusive ClusterMode = iota // 每次调度在集群中只执行一次（默认），分片任务按节点分配分片
+	ClusterModeLocal                        // 每个节点都执行，适用于采集本节点数据等任务
+)
+
+// 集群协调默认配置
+const (
+	defaultClusterKeyPrefix         = "gateway:timer:"
+	defaultClusterLeaseTTL          = 15 * time.Second
+	defaultClusterHeartbeatInterval = 5 * time.Second
+	clusterOnceClaimTTL             = 24 * time.Hour // 一次性/延迟任务执行标记的保留时间
+	clusterMinClaimTTL              = time.Minute    // 周期任务执行标记的最短保留时间
+)
+
+// ClusterConfig 调度器集群协调配置
+// 多个应用实例加载同一调度器时，通过缓存选出主节点执行普通任务，并为分片任务分配分片；
+// 每次执行前按调度时间在缓存中加执行标记，保证主节点切换期间同一次调度也不会重复执行
+type ClusterConfig struct {
+	Enabled           bool          `json:"enabled"`           // 是否启用集群协调
+	CacheName         string        `json:"cacheName"`         // 缓存连接名称，为空使用默认缓存；跨进程协调需要redis缓存
+	KeyPrefix         string        `json:"keyPrefix"`         // 缓存键前缀
+	NodeId            string        `json:"nodeId"`            // 节点ID，为空使用 config.GetNodeId()
+	LeaseTTL          time.Duration `json:"leaseTTL"`          // 主节点租约时长，主节点失联超过该时长后由其他节点接管
+	HeartbeatInterval time.Duration `json:"heartbeatInterval"` // 心跳间隔，用于续约主节点租约和刷新节点列表
+}
+
+// ShardInfo 分片任务本次执行的分片信息
+type ShardInfo struct {
+	Index int `json:"index"` // 分片序号，从0开始
+	Total int `json:"total"` // 分片总数
+}
+
+// shardContextKey 分片信息在上下文中的键
+type shardContextKey struct{}
+
+// WithShard 将分片信息写入上下文，供执行器按分片处理数据
+func WithShard(ctx context.Context, shard ShardInfo) context.Context {
+	return context.WithValue(ctx, shardContextKey{}, shard)
+}
+
+// ShardFromContext 从执行上下文中获取分片信息
+// 返回:
+//
+//	ShardInfo: 分片信息
+//	bool: 非分片执行时返回false
+func ShardFromContext(ctx context.Context) (ShardInfo, bool) {
+	shard, ok := ctx.Value(shardContextKey{}).(ShardInfo)
+	return shard, ok
+}
+
+// Owns 判断分片是否负责指定的分区键，按键的哈希取模分配，便于执行器过滤数据
+func (s ShardInfo) Owns(key string) bool {
+	if s.Total <= 1 {
+		return true
+	}
+	var hash uint32 = 2166136261
+	for i := 0; i < len(key); i++ {
+		hash ^= uint32(key[i])
+		hash *= 16777619
+	}
+	return int(hash%uint32(s.Total)) == s.Index
+}
+
+// LoadClusterConfig 从应用配置加载定时任务集群协调配置
+// 配置项:
+//   - app.timer.cluster.enabled: 是否启用，默认false
+//   - app.timer.cluster.cache_name: 缓存连接名称，为空使用默认缓存
+//   - app.timer.cluster.key_prefix: 缓存键前缀，默认 gateway:timer:
+//   - app.timer.cluster.lease_ttl: 主节点租约时长，默认15s
+//   - app.timer.cluster.heartbeat_interval: 心跳间隔，默认5s
+func LoadClusterConfig() *ClusterConfig {
+	return &ClusterConfig{
+		Enabled:           config.GetBool("app.timer.cluster.enabled", false),
+		CacheName:         config.GetString("app.timer.cluster.cache_name", ""),
+		KeyPrefix:         config.GetString("app.timer.cluster.key_prefix", defaultClusterKeyPrefix),
+		LeaseTTL:          parseClusterDuration(config.GetString("app.timer.cluster.lease_ttl", ""), defaultClusterLeaseTTL),
+		HeartbeatInterval: parseClusterDuration(config.GetString("app.timer.cluster.heartbeat_interval", ""), defaultClusterHeartbeatInterval),
+	}
+}
+
+// parseClusterDuration 解析时间字符串，为空或解析失败返回默认值
+func parseClusterDuration(s string, defaultValue time.Duration) time.Duration {
+	if s == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return defaultValue
+	}
+	return d
+}
+
+// clusterCoordinator 调度器的集群协调器
+// 节点通过心跳登记到节点列表，并竞争主节点租约；主节点清理心跳超时的节点
+type clusterCoordinator struct {
+	cache  cache.Cache
+	config *ClusterConfig
+	nodeId string
+
+	leaderKey string // 主节点租约键
+	nodesKey  string // 节点心跳哈希键，字段为节点ID，值为最后心跳的Unix秒
+	claimKey  string // 执行标记键前缀
+
+	mu          sync.RWMutex
+	leaderUntil time.Time // 本节点持有主节点租约的截止时间
+	nodes       []string  // 最近一次心跳时的存活节点，按节点ID排序
+}
+
+// newClusterCoordinator 创建集群协调器
+// 参数:
+//
+//	schedulerConfig: 调度器配置，缓存键按租户和调度器ID区分
+//	clusterConfig: 集群协调配置
+//
+// 返回:
+//
+//	*clusterCoordinator: 集群协调器
+//	error: 缓存不可用时返回错误
+func newClusterCoordinator(schedulerConfig *SchedulerConfig, clusterConfig *ClusterConfig) (*clusterCoordinator, error) {
+	var c cache.Cache
+	if clusterConfig.CacheName != "" {
+		c = cache.GetCache(clusterConfig.CacheName)
+	} else {
+		c = cache.GetDefaultCache()
+	}
+	if c == nil {
+		return nil, fmt.Errorf("cache %q not found", clusterConfig.CacheName)
+	}
+	if c.GetCacheType() == "memory" {
+		logger.Warn("定时任务集群协调使用内存缓存，只能在单个进程内生效", "schedulerID", schedulerConfig.ID)
+	}
+
+	nodeId := clusterConfig.NodeId
+	if nodeId == "" {
+		nodeId = config.GetNodeId()
+	}
+	if clusterConfig.KeyPrefix == "" {
+		clusterConfig.KeyPrefix = defaultClusterKeyPrefix
+	}
+	if clusterConfig.LeaseTTL <= 0 {
+		clusterConfig.LeaseTTL = defaultClusterLeaseTTL
+	}
+	if clusterConfig.HeartbeatInterval <= 0 || clusterConfig.HeartbeatInterval >= clusterConfig.LeaseTTL {
+		clusterConfig.HeartbeatInterval = clusterConfig.LeaseTTL / 3
+	}
+
+	base := clusterConfig.KeyPrefix + schedulerConfig.TenantId + ":" + schedulerConfig.ID + ":"
+	return &clusterCoordinator{
+		cache:     c,
+		config:    clusterConfig,
+		nodeId:    nodeId,
+		leaderKey: base + "leader",
+		nodesKey:  base + "nodes",
+		claimKey:  base + "claim:",
+	}, nil
+}
+
+// run 心跳协程，直到上下文取消
+func (c *clusterCoordinator) run(ctx context.Context) {
+	ticker := time.NewTicker(c.config.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.heartbeat(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// heartbeat 登记本节点心跳、竞争或续约主节点租约并刷新存活节点列表
+func (c *clusterCoordinator) heartbeat(ctx context.Context) {
+	now := time.Now()
+	if err := c.cache.HSet(ctx, c.nodesKey, c.nodeId, strconv.FormatInt(now.Unix(), 10)); err != nil {
+		if logger.RateLimit("timerHeartbeat:"+c.nodeId, time.Minute) {
+			logger.Warn("定时任务集群心跳失败", "nodeId", c.nodeId, "error", err)
+		}
+	}
+
+	leader, err := c.acquireLeader(ctx)
+	if err != nil {
+		logger.Warn("定时任务主节点租约续约失败", "nodeId", c.nodeId, "error", err)
+	}
+	c.mu.Lock()
+	wasLeader := now.Before(c.leaderUntil)
+	if leader {
+		c.leaderUntil = now.Add(c.config.LeaseTTL)
+	} else if err == nil {
+		c.leaderUntil = time.Time{}
+	}
+	c.mu.Unlock()
+	if leader && !wasLeader {
+		logger.Info("本节点成为定时任务主节点", "nodeId", c.nodeId, "key", c.leaderKey)
+	} else if !leader && wasLeader && err == nil {
+		logger.Info("本节点不再是定时任务主节点", "nodeId", c.nodeId, "key", c.leaderKey)
+	}
+
+	heartbeats, err := c.cache.HGetAll(ctx, c.nodesKey)
+	if err != nil {
+		logger.Warn("读取定时任务集群节点失败", "error", err)
+		return
+	}
+	nodes := make([]string, 0, len(heartbeats))
+	stale := make([]string, 0)
+	for nodeId, value := range heartbeats {
+		seconds, _ := strconv.ParseInt(value, 10, 64)
+		if now.Sub(time.Unix(seconds, 0)) > c.config.LeaseTTL {
+			stale = append(stale, nodeId)
+			continue
+		}
+		nodes = append(nodes, nodeId)
+	}
+	sort.Strings(nodes)
+	c.mu.Lock()
+	c.nodes = nodes
+	c.mu.Unlock()
+
+	// 由主节点清理心跳超时的节点，避免分片一直分配给已下线的节点
+	if leader && len(stale) > 0 {
+		if _, err := c.cache.HDel(ctx, c.nodesKey, stale...); err != nil {
+			logger.Warn("清理超时的定时任务集群节点失败", "nodes", stale, "error", err)
+		}
+	}
+}
+
+// acquireLeader 竞争主节点租约，已持有时续约
+func (c *clusterCoordinator) acquireLeader(ctx context.Context) (bool, error) {
+	acquired, err := c.cache.SetNXString(ctx, c.leaderKey, c.nodeId, c.config.LeaseTTL)
+	if err != nil || acquired {
+		return acquired, err
+	}
+	holder, err := c.cache.GetString(ctx, c.leaderKey)
+	if err != nil || holder != c.nodeId {
+		return false, err
+	}
+	if _, err := c.cache.Expire(ctx, c.leaderKey, c.config.LeaseTTL); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// release 停止调度器时释放主节点租约并移除本节点心跳，其他节点下次心跳即可接管
+func (c *clusterCoordinator) release(ctx context.Context) {
+	if holder, err := c.cache.GetString(ctx, c.leaderKey); err == nil && holder == c.nodeId {
+		if err := c.cache.Delete(ctx, c.leaderKey); err != nil {
+			logger.Warn("释放定时任务主节点租约失败", "nodeId", c.nodeId, "error", err)
+		}
+	}
+	if _, err := c.cache.HDel(ctx, c.nodesKey, c.nodeId); err != nil {
+		logger.Warn("移除定时任务集群节点失败", "nodeId", c.nodeId, "error", err)
+	}
+
+	c.mu.Lock()
+	c.leaderUntil = time.Time{}
+	c.nodes = nil
+	c.mu.Unlock()
+}
+
+// isLeader 本节点当前是否持有主节点租约
+func (c *clusterCoordinator) isLeader() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return time.Now().Before(c.leaderUntil)
+}
+
+// assignedShards 按存活节点列表计算本节点负责的分片，分片 i 分配给排序后的第 i%n 个节点
+func (c *clusterCoordinator) assignedShards(total int) []int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	index := sort.SearchStrings(c.nodes, c.nodeId)
+	if index >= len(c.nodes) || c.nodes[index] != c.nodeId {
+		return nil // 本节点心跳尚未登记，暂不领取分片
+	}
+	shards := make([]int, 0, total/len(c.nodes)+1)
+	for shard := index; shard < total; shard += len(c.nodes) {
+		shards = append(shards, shard)
+	}
+	return shards
+}
+
+// claim 判断本节点是否执行任务的本次调度，并在缓存中加执行标记
+// 参数:
+//
+//	ctx: 上下文
+//	config: 任务配置
+//	fireTime: 本次调度的计划执行时间
+//
+// 返回:
+//
+//	[]int: 本节点领取的分片，非分片任务为nil
+//	bool: 本节点是否执行本次调度
+//	bool: 执行标记已被其他节点持有，即本次调度已在其他节点执行
+func (c *clusterCoordinator) claim(ctx context.Context, config *TaskConfig, fireTime time.Time) ([]int, bool, bool) {
+	if config.ShardCount <= 1 {
+		if !c.isLeader() {
+			return nil, false, false
+		}
+		acquired, err := c.setClaim(ctx, c.claimKey+config.ID+":"+claimSlot(config, fireTime), claimTTL(config, fireTime))
+		if err != nil {
+			logger.Warn("定时任务加执行标记失败，跳过本次调度", "taskID", config.ID, "error", err)
+			return nil, false, false
+		}
+		return nil, acquired, !acquired
+	}
+
+	claimed := make([]int, 0)
+	ttl := claimTTL(config, fireTime)
+	slot := claimSlot(config, fireTime)
+	for _, shard := range c.assignedShards(config.ShardCount) {
+		acquired, err := c.setClaim(ctx, c.claimKey+config.ID+":"+slot+":"+strconv.Itoa(shard), ttl)
+		if err != nil {
+			logger.Warn("定时任务分片加执行标记失败，跳过该分片", "taskID", config.ID, "shard", shard, "error", err)
+			continue
+		}
+		if acquired {
+			claimed = append(claimed, shard)
+		}
+	}
+	return claimed, len(claimed) > 0, false
+}
+
+// setClaim 加执行标记，值为持有标记的节点ID
+func (c *clusterCoordinator) setClaim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return c.cache.SetNXString(ctx, key, c.nodeId, ttl)
+}
+
+// claimSlot 本次调度的标识，各节点对同一次调度需计算出相同的值
+// Cron任务使用计划执行时间；固定间隔任务按间隔对齐，消除节点间上次执行时间的差异；一次性和延迟任务只执行一次
+func claimSlot(config *TaskConfig, fireTime time.Time) string {
+	switch config.ScheduleType {
+	case ScheduleTypeCron:
+		return strconv.FormatInt(fireTime.Unix(), 10)
+	case ScheduleTypeInterval:
+		return strconv.FormatInt(fireTime.Truncate(config.Interval).Unix(), 10)
+	default:
+		return "once"
+	}
+}
+
+// claimTTL 执行标记的保留时间，至少覆盖到下一次调度，避免时钟偏差较大的节点重复领取
+func claimTTL(config *TaskConfig, fireTime time.Time) time.Duration {
+	ttl := clusterMinClaimTTL
+	switch config.ScheduleType {
+	case ScheduleTypeInterval:
+		ttl = config.Interval
+	case ScheduleTypeCron:
+		if schedule, err := ParseTaskCron(config.CronExpr, config.TimeZone); err == nil {
+			if next := schedule.Next(fireTime); !next.IsZero() {
+				ttl = next.Sub(fireTime)
+			}
+		}
+	default:
+		return clusterOnceClaimTTL
+	}
+	if ttl < clusterMinClaimTTL {
+		ttl = clusterMinClaimTTL
+	}
+	if ttl > clusterOnceClaimTTL {
+		ttl = clusterOnceClaimTTL
+	}
+	return ttl
+}
@@ -11,6 +11,7 @@ import (
 // CronParser Cron表达式解析器接口
 type CronParser interface {
 	Parse(expr string) (CronSchedule, error)
+	ParseInLocation(expr string, loc *time.Location) (CronSchedule, error)
 }
 
 // CronSchedule Cron调度接口
@@ -18,264 +19,407 @@ type CronSchedule interface {
 	Next(t time.Time) time.Time
 }
 
+// 时区前缀，写在表达式最前面，例如 "CRON_TZ=Asia/Shanghai 0 0 9 * * *"
+const (
+	cronTimeZonePrefix = "CRON_TZ="
+	timeZonePrefix     = "TZ="
+)
+
+// maxSearchYears Next 向后搜索的最大年数，超过仍找不到匹配时间（例如 2月30日）返回零值
+const maxSearchYears = 5
+
+// 预定义表达式
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// 月份和星期的英文缩写，不区分大小写
+var (
+	monthNames   = map[string]int{"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6, "JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12}
+	weekdayNames = map[string]int{"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6}
+)
+
 // StandardCronParser 标准Cron解析器
 // 支持6字段格式：秒 分钟 小时 日 月 周
 // 也支持5字段格式：分钟 小时 日 月 周（为了向后兼容）
-// 支持的语法：通配符(*)、问号(?)、范围(1-5)、列表(1,3,5)、步长(*/2)
+// 支持的语法：通配符(*)、问号(?)、范围(1-5)、列表(1,3,5)、步长(*/2、5/15)、月份和星期英文缩写(JAN-DEC、SUN-SAT)、
+// 预定义表达式(@yearly、@monthly、@weekly、@daily、@hourly、@every 1h30m)、时区前缀(CRON_TZ=Asia/Shanghai)
 type StandardCronParser struct{}
 
 // NewStandardCronParser 创建标准Cron解析器实例
 // 支持标准6字段Cron表达式格式：秒 分钟 小时 日 月 周
 // 也支持5字段格式：分钟 小时 日 月 周（为了向后兼容）
 // 返回:
-//   *StandardCronParser: 初始化的Cron解析器实例
+//
+//	*StandardCronParser: 初始化的Cron解析器实例
 func NewStandardCronParser() *StandardCronParser {
 	return &StandardCronParser{}
 }
 
-// Parse 解析Cron表达式字符串
-// 支持6字段Cron表达式（秒 分钟 小时 日 月 周）和5字段格式（分钟 小时 日 月 周）
-// 支持通配符(*)、问号(?)、范围(1-5)、列表(1,3,5)、步长(*/2)等语法
-// 注意: * 和 ? 都表示匹配所有可能的值，? 通常用于日期和星期字段
+// Parse 解析Cron表达式字符串，未指定时区前缀时按传入 Next 的时间所在时区计算
+// 注意: * 和 ? 都表示匹配所有可能的值，? 通常用于日期和星期字段；
+// 日和星期都不是 * 或 ? 时，满足其中之一即可（与标准 cron 一致）
 // 参数:
-//   expr: Cron表达式字符串，格式为"秒 分钟 小时 日 月 周"或"分钟 小时 日 月 周"
+//
+//	expr: Cron表达式字符串，格式为"秒 分钟 小时 日 月 周"或"分钟 小时 日 月 周"
+//
 // 返回:
-//   CronSchedule: 解析后的调度对象，用于计算下次执行时间
-//   error: 解析失败时返回错误信息
+//
+//	CronSchedule: 解析后的调度对象，用于计算下次执行时间
+//	error: 解析失败时返回错误信息
 func (p *StandardCronParser) Parse(expr string) (CronSchedule, error) {
-	fields := strings.Fields(expr)
-	
-	var second, minute, hour, day, month, weekday []int
-	var err error
-	
-	if len(fields) == 6 {
-		// 6字段格式：秒 分钟 小时 日 月 周
-		second, err = parseField(fields[0], 0, 59)
-		if err != nil {
-			return nil, fmt.Errorf("invalid second field: %v", err)
-		}
-		
-		minute, err = parseField(fields[1], 0, 59)
-		if err != nil {
-			return nil, fmt.Errorf("invalid minute field: %v", err)
-		}
-		
-		hour, err = parseField(fields[2], 0, 23)
-		if err != nil {
-			return nil, fmt.Errorf("invalid hour field: %v", err)
-		}
-		
-		day, err = parseField(fields[3], 1, 31)
-		if err != nil {
-			return nil, fmt.Errorf("invalid day field: %v", err)
-		}
-		
-		month, err = parseField(fields[4], 1, 12)
-		if err != nil {
-			return nil, fmt.Errorf("invalid month field: %v", err)
-		}
-		
-		weekday, err = parseField(fields[5], 0, 6)
-		if err != nil {
-			return nil, fmt.Errorf("invalid weekday field: %v", err)
+	return p.ParseInLocation(expr, nil)
+}
+
+// ParseInLocation 按指定时区解析Cron表达式，表达式中的时区前缀优先于 loc
+// 参数:
+//
+//	expr: Cron表达式字符串
+//	loc: 计算执行时间使用的时区，为nil时使用传入 Next 的时间所在时区
+//
+// 返回:
+//
+//	CronSchedule: 解析后的调度对象，Next 返回的时间与传入时间处于同一时区
+//	error: 解析失败时返回错误信息
+func (p *StandardCronParser) ParseInLocation(expr string, loc *time.Location) (CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, cronTimeZonePrefix) || strings.HasPrefix(expr, timeZonePrefix) {
+		end := strings.IndexAny(expr, " \t")
+		if end < 0 {
+			return nil, fmt.Errorf("invalid cron expression: missing fields after time zone")
 		}
-	} else if len(fields) == 5 {
-		// 5字段格式：分钟 小时 日 月 周（为了向后兼容）
-		second = []int{0} // 默认在0秒执行
-		
-		minute, err = parseField(fields[0], 0, 59)
+		name := expr[strings.Index(expr, "=")+1 : end]
+		zone, err := time.LoadLocation(name)
 		if err != nil {
-			return nil, fmt.Errorf("invalid minute field: %v", err)
+			return nil, fmt.Errorf("invalid time zone %s: %v", name, err)
 		}
-		
-		hour, err = parseField(fields[1], 0, 23)
+		loc = zone
+		expr = strings.TrimSpace(expr[end:])
+	}
+
+	if strings.HasPrefix(expr, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(expr[len("@every "):]))
 		if err != nil {
-			return nil, fmt.Errorf("invalid hour field: %v", err)
+			return nil, fmt.Errorf("invalid @every duration: %v", err)
 		}
-		
-		day, err = parseField(fields[2], 1, 31)
-		if err != nil {
-			return nil, fmt.Errorf("invalid day field: %v", err)
+		if interval < time.Second {
+			return nil, fmt.Errorf("@every duration must be at least 1s")
 		}
-		
-		month, err = parseField(fields[3], 1, 12)
+		return &EverySchedule{interval: interval.Truncate(time.Second)}, nil
+	}
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) == 5 {
+		// 5字段格式：分钟 小时 日 月 周（为了向后兼容），默认在0秒执行
+		fields = append([]string{"0"}, fields...)
+	} else if len(fields) != 6 {
+		return nil, fmt.Errorf("invalid cron expression: expected 5 or 6 fields, got %d", len(fields))
+	}
+
+	specs := []struct {
+		name     string
+		min, max int
+		names    map[string]int
+	}{
+		{"second", 0, 59, nil},
+		{"minute", 0, 59, nil},
+		{"hour", 0, 23, nil},
+		{"day", 1, 31, nil},
+		{"month", 1, 12, monthNames},
+		{"weekday", 0, 6, weekdayNames},
+	}
+	bits := make([]uint64, len(specs))
+	for i, spec := range specs {
+		values, err := parseField(fields[i], spec.min, spec.max, spec.names)
 		if err != nil {
-			return nil, fmt.Errorf("invalid month field: %v", err)
+			return nil, fmt.Errorf("invalid %s field: %v", spec.name, err)
 		}
-		
-		weekday, err = parseField(fields[4], 0, 6)
-		if err != nil {
-			return nil, fmt.Errorf("invalid weekday field: %v", err)
+		for _, value := range values {
+			bits[i] |= 1 << uint(value)
 		}
-	} else {
-		return nil, fmt.Errorf("invalid cron expression: expected 5 or 6 fields, got %d", len(fields))
 	}
-	
+
 	return &StandardCronSchedule{
-		second:  second,
-		minute:  minute,
-		hour:    hour,
-		day:     day,
-		month:   month,
-		weekday: weekday,
+		second:   bits[0],
+		minute:   bits[1],
+		hour:     bits[2],
+		day:      bits[3],
+		month:    bits[4],
+		weekday:  bits[5],
+		anyDay:   isWildcard(fields[3]),
+		anyWeek:  isWildcard(fields[5]),
+		location: loc,
 	}, nil
 }
 
-// StandardCronSchedule 标准Cron调度实现
+// StandardCronSchedule 标准Cron调度实现，各字段以位图保存允许的取值
 type StandardCronSchedule struct {
-	second  []int
-	minute  []int
-	hour    []int
-	day     []int
-	month   []int
-	weekday []int
+	second  uint64
+	minute  uint64
+	hour    uint64
+	day     uint64
+	month   uint64
+	weekday uint64
+
+	anyDay   bool           // 日字段为 * 或 ?
+	anyWeek  bool           // 星期字段为 * 或 ?
+	location *time.Location // 计算使用的时区，为nil时使用传入时间的时区
 }
 
 // Next 计算下次执行时间
-// 基于当前时间和Cron规则计算下一次任务应该执行的时间
+// 基于当前时间和Cron规则计算下一次任务应该执行的时间，按月、日、时、分、秒逐级跳过不匹配的取值；
+// 夏令时切换导致不存在的时刻被跳过，重复的时刻只执行第一次
 // 参数:
-//   t: 当前时间，作为计算的起点
+//
+//	t: 当前时间，作为计算的起点
+//
 // 返回:
-//   time.Time: 下次执行时间，如果找不到匹配时间则返回零值
+//
+//	time.Time: 下次执行时间（与 t 同一时区），如果找不到匹配时间则返回零值
 func (s *StandardCronSchedule) Next(t time.Time) time.Time {
+	origin := t.Location()
+	loc := s.location
+	if loc == nil {
+		loc = origin
+	}
+	t = t.In(loc)
+
 	// 从下一秒开始计算
-	next := t.Add(time.Second).Truncate(time.Second)
-	
-	// 最多向前搜索4年（以秒为单位）
-	for i := 0; i < 4*365*24*60*60; i++ {
-		if s.matches(next) {
-			return next
+	t = t.Add(time.Second - time.Duration(t.Nanosecond()))
+	added := false
+	yearLimit := t.Year() + maxSearchYears
+
+wrap:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+
+	for s.month&(1<<uint(t.Month())) == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+		}
+		t = t.AddDate(0, 1, 0)
+		if t.Month() == time.January {
+			goto wrap
 		}
-		next = next.Add(time.Second)
 	}
-	
-	// 如果找不到匹配的时间，返回零值
-	return time.Time{}
-}
 
-// matches 检查时间是否匹配Cron表达式
-func (s *StandardCronSchedule) matches(t time.Time) bool {
-	return s.matchesField(s.second, t.Second()) &&
-		s.matchesField(s.minute, t.Minute()) &&
-		s.matchesField(s.hour, t.Hour()) &&
-		s.matchesField(s.day, t.Day()) &&
-		s.matchesField(s.month, int(t.Month())) &&
-		s.matchesField(s.weekday, int(t.Weekday()))
-}
+	for !s.dayMatches(t) {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		}
+		t = t.AddDate(0, 0, 1)
+		// 夏令时切换日的零点可能不存在，校正回当天零点附近
+		if t.Hour() != 0 {
+			if t.Hour() > 12 {
+				t = t.Add(time.Duration(24-t.Hour()) * time.Hour)
+			} else {
+				t = t.Add(-time.Duration(t.Hour()) * time.Hour)
+			}
+		}
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
 
-// matchesField 检查字段是否匹配
-func (s *StandardCronSchedule) matchesField(field []int, value int) bool {
-	for _, v := range field {
-		if v == value {
-			return true
+	for s.hour&(1<<uint(t.Hour())) == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+		}
+		t = t.Add(time.Hour)
+		if t.Hour() == 0 {
+			goto wrap
 		}
 	}
-	return false
+
+	for s.minute&(1<<uint(t.Minute())) == 0 {
+		if !added {
+			added = true
+			t = t.Truncate(time.Minute)
+		}
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+
+	for s.second&(1<<uint(t.Second())) == 0 {
+		if !added {
+			added = true
+			t = t.Truncate(time.Second)
+		}
+		t = t.Add(time.Second)
+		if t.Second() == 0 {
+			goto wrap
+		}
+	}
+
+	return t.In(origin)
+}
+
+// dayMatches 检查日期是否匹配日和星期字段
+// 两个字段都有限制时满足其一即可，否则两者都需满足
+func (s *StandardCronSchedule) dayMatches(t time.Time) bool {
+	dayMatch := s.day&(1<<uint(t.Day())) != 0
+	weekMatch := s.weekday&(1<<uint(t.Weekday())) != 0
+	if s.anyDay || s.anyWeek {
+		return dayMatch && weekMatch
+	}
+	return dayMatch || weekMatch
+}
+
+// EverySchedule 固定间隔调度，对应 "@every 时长"
+type EverySchedule struct {
+	interval time.Duration
+}
+
+// Next 返回 t 之后一个间隔的时间，精确到秒
+func (s *EverySchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval - time.Duration(t.Nanosecond()))
+}
+
+// isWildcard 字段是否为 * 或 ?
+func isWildcard(field string) bool {
+	return field == "*" || field == "?"
 }
 
 // parseField 解析Cron字段
-func parseField(field string, min, max int) ([]int, error) {
-	if field == "*" || field == "?" {
+func parseField(field string, min, max int, names map[string]int) ([]int, error) {
+	if isWildcard(field) {
 		return makeRange(min, max), nil
 	}
-	
+
 	var result []int
-	
+
 	// 处理逗号分隔的值
 	parts := strings.Split(field, ",")
 	for _, part := range parts {
-		values, err := parseFieldPart(part, min, max)
+		values, err := parseFieldPart(part, min, max, names)
 		if err != nil {
 			return nil, err
 		}
 		result = append(result, values...)
 	}
-	
+
 	return result, nil
 }
 
 // parseFieldPart 解析字段的一部分
-func parseFieldPart(part string, min, max int) ([]int, error) {
-	// 处理步长 (例如: */2, 1-5/2)
+func parseFieldPart(part string, min, max int, names map[string]int) ([]int, error) {
+	// 处理步长 (例如: */2, 1-5/2, 5/15)
 	if strings.Contains(part, "/") {
-		return parseStepValue(part, min, max)
+		return parseStepValue(part, min, max, names)
 	}
-	
-	// 处理范围 (例如: 1-5)
+
+	// 处理范围 (例如: 1-5, MON-FRI)
 	if strings.Contains(part, "-") {
-		return parseRange(part, min, max)
+		return parseRange(part, min, max, names)
 	}
-	
+
 	// 处理单个值
-	value, err := strconv.Atoi(part)
+	value, err := parseValue(part, names)
 	if err != nil {
-		return nil, fmt.Errorf("invalid value: %s", part)
+		return nil, err
 	}
-	
+
 	if value < min || value > max {
 		return nil, fmt.Errorf("value %d out of range [%d, %d]", value, min, max)
 	}
-	
+
 	return []int{value}, nil
 }
 
-// parseStepValue 解析步长值
-func parseStepValue(part string, min, max int) ([]int, error) {
+// parseValue 解析单个取值，支持数字和英文缩写
+func parseValue(value string, names map[string]int) (int, error) {
+	if named, ok := names[strings.ToUpper(value)]; ok {
+		return named, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value: %s", value)
+	}
+	return number, nil
+}
+
+// parseStepValue 解析步长值，起点为单个值时表示从该值到最大值
+func parseStepValue(part string, min, max int, names map[string]int) ([]int, error) {
 	parts := strings.Split(part, "/")
 	if len(parts) != 2 {
 		return nil, fmt.Errorf("invalid step value: %s", part)
 	}
-	
+
 	step, err := strconv.Atoi(parts[1])
 	if err != nil || step <= 0 {
 		return nil, fmt.Errorf("invalid step: %s", parts[1])
 	}
-	
+
 	var baseRange []int
-	if parts[0] == "*" {
+	switch {
+	case parts[0] == "*" || parts[0] == "?":
 		baseRange = makeRange(min, max)
-	} else {
-		baseRange, err = parseFieldPart(parts[0], min, max)
+	case strings.Contains(parts[0], "-"):
+		baseRange, err = parseRange(parts[0], min, max, names)
 		if err != nil {
 			return nil, err
 		}
+	default:
+		start, err := parseValue(parts[0], names)
+		if err != nil {
+			return nil, err
+		}
+		if start < min || start > max {
+			return nil, fmt.Errorf("value %d out of range [%d, %d]", start, min, max)
+		}
+		baseRange = makeRange(start, max)
 	}
-	
+
 	var result []int
 	for i, value := range baseRange {
 		if i%step == 0 {
 			result = append(result, value)
 		}
 	}
-	
+
 	return result, nil
 }
 
 // parseRange 解析范围值
-func parseRange(part string, min, max int) ([]int, error) {
+func parseRange(part string, min, max int, names map[string]int) ([]int, error) {
 	parts := strings.Split(part, "-")
 	if len(parts) != 2 {
 		return nil, fmt.Errorf("invalid range: %s", part)
 	}
-	
-	start, err := strconv.Atoi(parts[0])
+
+	start, err := parseValue(parts[0], names)
 	if err != nil {
 		return nil, fmt.Errorf("invalid range start: %s", parts[0])
 	}
-	
-	end, err := strconv.Atoi(parts[1])
+
+	end, err := parseValue(parts[1], names)
 	if err != nil {
 		return nil, fmt.Errorf("invalid range end: %s", parts[1])
 	}
-	
+
 	if start < min || start > max || end < min || end > max {
 		return nil, fmt.Errorf("range [%d, %d] out of bounds [%d, %d]", start, end, min, max)
 	}
-	
+
 	if start > end {
 		return nil, fmt.Errorf("invalid range: start %d > end %d", start, end)
 	}
-	
+
 	return makeRange(start, end), nil
 }
 
@@ -310,4 +454,36 @@ var (
 func ParseCron(expr string) (CronSchedule, error) {
 	parser := NewStandardCronParser()
 	return parser.Parse(expr)
-} 
\ No newline at end of file
+}
+
+// Preview 计算Cron表达式从 from 之后的若干次执行时间，用于配置时预览
+// 参数:
+//
+//	expr: Cron表达式
+//	loc: 计算使用的时区，为nil时使用 from 所在时区；表达式中的时区前缀优先
+//	from: 起始时间（不含）
+//	count: 计算次数
+//
+// 返回:
+//
+//	[]time.Time: 执行时间，找不到更多匹配时间时提前结束
+//	error: 表达式解析失败时返回错误
+func Preview(expr string, loc *time.Location, from time.Time, count int) ([]time.Time, error) {
+	schedule, err := NewStandardCronParser().ParseInLocation(expr, loc)
+	if err != nil {
+		return nil, err
+	}
+	if loc != nil {
+		from = from.In(loc)
+	}
+	times := make([]time.Time, 0, count)
+	for i := 0; i < count; i++ {
+		next := schedule.Next(from)
+		if next.IsZero() {
+			break
+		}
+		times = append(times, next)
+		from = next
+	}
+	return times, nil
+}
@@ -0,0 +1,319 @@
+package timer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"gateway/pkg/logger"
+)
+
+// DependencyPolicy 上游任务失败时依赖任务的处理方式
+type DependencyPolicy int
+
+const (
+	DependencyPolicySkip     DependencyPolicy = iota // 任一上游失败或被跳过时跳过本任务（默认），跳过会继续向下游传递
+	DependencyPolicyContinue                         // 上游全部结束后继续执行，不论上游是否成功
+)
+
+// String 返回依赖失败策略的字符串表示
+func (p DependencyPolicy) String() string {
+	switch p {
+	case DependencyPolicySkip:
+		return "SKIP"
+	case DependencyPolicyContinue:
+		return "CONTINUE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ParseDependencyPolicy 解析依赖失败策略，空字符串返回默认的 SKIP
+func ParseDependencyPolicy(value string) (DependencyPolicy, error) {
+	switch value {
+	case "", "SKIP":
+		return DependencyPolicySkip, nil
+	case "CONTINUE":
+		return DependencyPolicyContinue, nil
+	default:
+		return DependencyPolicySkip, fmt.Errorf("unsupported dependency policy: %s", value)
+	}
+}
+
+// SplitDependsOn 解析逗号分隔的上游任务ID列表，去除空白和重复项
+func SplitDependsOn(value string) []string {
+	var taskIDs []string
+	seen := make(map[string]bool)
+	for _, taskID := range strings.Split(value, ",") {
+		taskID = strings.TrimSpace(taskID)
+		if taskID == "" || seen[taskID] {
+			continue
+		}
+		seen[taskID] = true
+		taskIDs = append(taskIDs, taskID)
+	}
+	return taskIDs
+}
+
+// HasDependencies 任务是否依赖其他任务
+// 依赖任务不按自身的调度配置执行，只在同一调度器中的上游任务全部执行结束后触发
+func (tc *TaskConfig) HasDependencies() bool {
+	return len(tc.DependsOn) > 0
+}
+
+// FindDependencyCycle 检查任务依赖关系中的循环依赖
+// 参数:
+//
+//	dependencies: 任务ID到其上游任务ID列表的映射，上游不在映射中的视为没有依赖
+//
+// 返回:
+//
+//	[]string: 构成循环的任务ID路径（首尾相同），没有循环时返回nil
+func FindDependencyCycle(dependencies map[string][]string) []string {
+	// 未访问的任务状态为零值
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int, len(dependencies))
+	var path []string
+
+	var visit func(taskID string) []string
+	visit = func(taskID string) []string {
+		switch state[taskID] {
+		case visiting:
+			for i, id := range path {
+				if id == taskID {
+					return append(append([]string{}, path[i:]...), taskID)
+				}
+			}
+		case visited:
+			return nil
+		}
+		state[taskID] = visiting
+		path = append(path, taskID)
+		for _, upstream := range dependencies[taskID] {
+			if cycle := visit(upstream); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[taskID] = visited
+		return nil
+	}
+
+	// 按任务ID排序遍历，保证返回的循环路径稳定
+	taskIDs := make([]string, 0, len(dependencies))
+	for taskID := range dependencies {
+		taskIDs = append(taskIDs, taskID)
+	}
+	sort.Strings(taskIDs)
+	for _, taskID := range taskIDs {
+		if cycle := visit(taskID); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// dependencyOutcome 任务在一次依赖链执行中的结果
+type dependencyOutcome int
+
+const (
+	outcomeSucceeded dependencyOutcome = iota // 执行成功
+	outcomeFailed                             // 执行失败
+	outcomeSkipped                            // 因上游失败被跳过
+)
+
+// dagRun 一次依赖链执行
+// 非依赖任务每次执行都会开启一次依赖链执行，其下游任务在同一次执行中按依赖顺序触发，
+// 没有依赖关系的分支作为独立作业提交到任务队列并行执行
+type dagRun struct {
+	mu       sync.Mutex
+	root     string                       // 开启本次执行的任务ID
+	members  map[string]bool              // 本次执行涉及的任务：根任务及其所有下游任务
+	outcomes map[string]dependencyOutcome // 已结束任务的结果
+	started  map[string]bool              // 已触发（执行或跳过）的任务
+}
+
+// newDagRun 以指定任务为根创建依赖链执行，根任务没有下游任务时返回nil
+func (s *StandardScheduler) newDagRun(root string) *dagRun {
+	members := map[string]bool{root: true}
+	queue := []string{root}
+	for len(queue) > 0 {
+		taskID := queue[0]
+		queue = queue[1:]
+		for _, dependent := range s.dependentsOf(taskID) {
+			if !members[dependent.ID] {
+				members[dependent.ID] = true
+				queue = append(queue, dependent.ID)
+			}
+		}
+	}
+	if len(members) == 1 {
+		return nil
+	}
+	return &dagRun{
+		root:     root,
+		members:  members,
+		outcomes: make(map[string]dependencyOutcome),
+		started:  map[string]bool{root: true},
+	}
+}
+
+// dependentsOf 返回直接依赖指定任务的下游任务，按任务ID排序
+func (s *StandardScheduler) dependentsOf(taskID string) []*TaskConfig {
+	var dependents []*TaskConfig
+	for _, config := range s.config.ListTasks() {
+		for _, upstream := range config.DependsOn {
+			if upstream == taskID {
+				dependents = append(dependents, config)
+				break
+			}
+		}
+	}
+	sort.Slice(dependents, func(i, j int) bool {
+		return dependents[i].ID < dependents[j].ID
+	})
+	return dependents
+}
+
+// finishDependency 记录任务在依赖链中的结果，并触发上游已全部结束的下游任务
+// 参数:
+//
+//	run: 依赖链执行，任务不属于任何依赖链执行时为nil
+//	taskID: 已结束的任务ID
+//	outcome: 任务结果
+func (s *StandardScheduler) finishDependency(run *dagRun, taskID string, outcome dependencyOutcome) {
+	if run == nil {
+		if run = s.newDagRun(taskID); run == nil {
+			return
+		}
+	}
+
+	run.mu.Lock()
+	run.outcomes[taskID] = outcome
+	run.mu.Unlock()
+
+	for _, dependent := range s.dependentsOf(taskID) {
+		upstreamFailed, ready := s.checkUpstreams(run, dependent)
+		if !ready {
+			continue
+		}
+
+		run.mu.Lock()
+		alreadyStarted := run.started[dependent.ID]
+		run.started[dependent.ID] = true
+		run.mu.Unlock()
+		if alreadyStarted {
+			continue
+		}
+
+		// 下游任务被禁用（停止或暂停）时视为跳过
+		if !dependent.Enabled {
+			logger.Info("依赖任务未启用，跳过执行", "taskID", dependent.ID, "upstream", taskID, "root", run.root)
+			s.finishDependency(run, dependent.ID, outcomeSkipped)
+			continue
+		}
+		if upstreamFailed && dependent.DependencyPolicy == DependencyPolicySkip {
+			logger.Warn("上游任务执行失败，跳过依赖任务", "taskID", dependent.ID, "upstream", taskID, "root", run.root)
+			s.finishDependency(run, dependent.ID, outcomeSkipped)
+			continue
+		}
+
+		s.submitDependent(run, dependent)
+	}
+}
+
+// checkUpstreams 检查依赖任务的上游是否已全部结束
+// 属于本次依赖链执行的上游需在本次执行中结束；不属于本次执行的上游按其最近一次执行结果判断，未执行过视为失败
+// 返回:
+//
+//	bool: 是否有上游失败或被跳过
+//	bool: 上游是否已全部结束
+func (s *StandardScheduler) checkUpstreams(run *dagRun, dependent *TaskConfig) (bool, bool) {
+	upstreamFailed := false
+	for _, upstream := range dependent.DependsOn {
+		if run.members[upstream] {
+			run.mu.Lock()
+			outcome, finished := run.outcomes[upstream]
+			run.mu.Unlock()
+			if !finished {
+				return false, false
+			}
+			if outcome != outcomeSucceeded {
+				upstreamFailed = true
+			}
+			continue
+		}
+
+		config, exists := s.config.GetTask(upstream)
+		if !exists {
+			upstreamFailed = true
+			continue
+		}
+		config.mu.RLock()
+		lastResult := config.LastResult
+		config.mu.RUnlock()
+		if lastResult == nil || lastResult.Status != TaskStatusCompleted {
+			upstreamFailed = true
+		}
+	}
+	return upstreamFailed, true
+}
+
+// submitDependent 将依赖任务提交到任务队列
+// 在工作线程中调用，队列已满时由新协程等待入队，避免所有工作线程阻塞在入队上
+func (s *StandardScheduler) submitDependent(run *dagRun, config *TaskConfig) {
+	s.mu.RLock()
+	executor, exists := s.executors[config.ID]
+	s.mu.RUnlock()
+	if !exists {
+		logger.Warn("依赖任务执行器不存在，跳过执行", "taskID", config.ID, "root", run.root)
+		s.finishDependency(run, config.ID, outcomeSkipped)
+		return
+	}
+
+	job := &taskJob{
+		taskID:   config.ID,
+		params:   config.Params,
+		executor: executor,
+		config:   config,
+		shards:   allShards(config.ShardCount),
+		run:      run,
+	}
+	select {
+	case s.taskQueue <- job:
+	default:
+		go func() {
+			select {
+			case s.taskQueue <- job:
+			case <-s.ctx.Done():
+			}
+		}()
+	}
+}
+
+// validateDependencies 校验新增任务的依赖关系：不能依赖自身，不能与已有任务形成循环依赖
+// 上游任务可以晚于依赖任务添加，添加前依赖任务不会被触发
+// 调用方需持有 s.mu
+func (s *StandardScheduler) validateDependencies(config *TaskConfig) error {
+	if !config.HasDependencies() {
+		return nil
+	}
+	dependencies := make(map[string][]string)
+	for _, task := range s.config.ListTasks() {
+		dependencies[task.ID] = task.DependsOn
+	}
+	dependencies[config.ID] = config.DependsOn
+	for _, upstream := range config.DependsOn {
+		if upstream == config.ID {
+			return fmt.Errorf("task %s cannot depend on itself", config.ID)
+		}
+	}
+	if cycle := FindDependencyCycle(dependencies); cycle != nil {
+		return fmt.Errorf("dependency cycle detected: %v", cycle)
+	}
+	return nil
+}
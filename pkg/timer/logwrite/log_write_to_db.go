@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"reflect"
 	"time"
 
@@ -13,32 +14,171 @@ import (
 	"gateway/pkg/utils/random"
 )
 
+// HUB_TIMER_TASK 中与执行结果相关的任务状态，与 timertypes 中的任务状态常量一致
+const (
+	taskStatusRunning    = 2 // 运行中
+	taskStatusDeadLetter = 6 // 死信
+)
+
 // WriteTaskExecutionLog 静态方法：写入任务执行日志
 // 从任务配置和执行结果中提取信息，创建日志记录并写入数据库
 func WriteTaskExecutionLog(ctx context.Context, taskConfig interface{}, taskResult interface{}, maxRetries int, tenantId, schedulerId string) error {
-	// 从配置中获取默认数据库连接
-	defaultDbName := config.GetString("database.default", "default")
-	db := database.GetConnection(defaultDbName)
-	if db == nil {
-		return fmt.Errorf("未找到数据库连接: %s", defaultDbName)
+	return FinishTaskExecutionLog(ctx, "", taskConfig, taskResult, maxRetries, tenantId, schedulerId, false)
+}
+
+// StartTaskExecutionLog 写入任务开始执行的记录
+// 记录状态为运行中，任务结束后由 FinishTaskExecutionLog 按执行ID更新为最终结果，
+// 执行中断（如进程退出）时记录保持运行中状态，便于排查
+// 参数:
+//
+//	ctx: 上下文
+//	taskConfig: 任务配置
+//	tenantId: 租户ID
+//	schedulerId: 调度器ID
+//
+// 返回:
+//
+//	string: 执行ID
+//	error: 写入失败时返回错误
+func StartTaskExecutionLog(ctx context.Context, taskConfig interface{}, tenantId, schedulerId string) (string, error) {
+	db, err := getLogDatabase()
+	if err != nil {
+		return "", err
+	}
+
+	log := createExecutionLog(taskConfig, nil, 0, tenantId, schedulerId)
+	log.ExecutionEndTime = nil
+	log.ExecutionDurationMs = nil
+	log.ExecutionStatus = int(StatusRunning)
+	log.ResultSuccess = "N"
+	logMessage := "任务开始执行"
+	log.LogMessage = &logMessage
+	phase := string(PhaseExecuting)
+	log.ExecutionPhase = &phase
+	setLogDefaults(log)
+
+	if _, err := db.Insert(ctx, log.TableName(), log, true); err != nil {
+		logger.Error("写入执行开始记录失败", "executionId", log.ExecutionId, "error", err)
+		return "", fmt.Errorf("写入执行开始记录失败: %w", err)
+	}
+	return log.ExecutionId, nil
+}
+
+// FinishTaskExecutionLog 写入任务执行结果
+// 执行ID不为空时更新 StartTaskExecutionLog 写入的记录，记录不存在或执行ID为空时插入新记录；
+// 同时更新 HUB_TIMER_TASK 中的执行次数、最后执行结果和死信状态（仅对数据库中配置的任务生效）
+// 参数:
+//
+//	ctx: 上下文
+//	executionId: StartTaskExecutionLog 返回的执行ID，可以为空
+//	taskConfig: 任务配置
+//	taskResult: 任务执行结果
+//	maxRetries: 最大重试次数
+//	tenantId: 租户ID
+//	schedulerId: 调度器ID
+//	deadLetter: 任务是否因连续失败进入死信状态
+//
+// 返回:
+//
+//	error: 写入失败时返回错误
+func FinishTaskExecutionLog(ctx context.Context, executionId string, taskConfig interface{}, taskResult interface{}, maxRetries int, tenantId, schedulerId string, deadLetter bool) error {
+	db, err := getLogDatabase()
+	if err != nil {
+		return err
 	}
 
 	// 创建日志记录
 	log := createExecutionLog(taskConfig, taskResult, maxRetries, tenantId, schedulerId)
+	phase := string(PhaseAfterExecute)
+	log.ExecutionPhase = &phase
+	if deadLetter {
+		logMessage := "任务连续执行失败，已进入死信状态，需手动触发执行"
+		log.LogMessage = &logMessage
+	}
 
 	// 设置默认值
 	setLogDefaults(log)
 
-	// 插入日志记录
-	_, err := db.Insert(ctx, log.TableName(), log, true)
-	if err != nil {
-		logger.Error("写入执行日志失败", "executionId", log.ExecutionId, "error", err)
-		return fmt.Errorf("写入执行日志失败: %w", err)
+	updated := int64(0)
+	if executionId != "" {
+		log.ExecutionId = executionId
+		updated, err = updateExecutionLog(ctx, db, log)
+		if err != nil {
+			logger.Error("更新执行日志失败", "executionId", log.ExecutionId, "error", err)
+			return fmt.Errorf("更新执行日志失败: %w", err)
+		}
+	}
+	if updated == 0 {
+		// 插入日志记录
+		if _, err := db.Insert(ctx, log.TableName(), log, true); err != nil {
+			logger.Error("写入执行日志失败", "executionId", log.ExecutionId, "error", err)
+			return fmt.Errorf("写入执行日志失败: %w", err)
+		}
 	}
 
+	if err := updateTaskRuntime(ctx, db, log, deadLetter); err != nil {
+		logger.Warn("更新任务执行信息失败", "taskId", log.TaskId, "executionId", log.ExecutionId, "error", err)
+	}
 	return nil
 }
 
+// getLogDatabase 获取写入执行日志的数据库连接
+func getLogDatabase() (database.Database, error) {
+	// 从配置中获取默认数据库连接
+	defaultDbName := config.GetString("database.default", "default")
+	db := database.GetConnection(defaultDbName)
+	if db == nil {
+		return nil, fmt.Errorf("未找到数据库连接: %s", defaultDbName)
+	}
+	return db, nil
+}
+
+// updateExecutionLog 按执行ID更新执行记录的结果字段
+func updateExecutionLog(ctx context.Context, db database.Database, log *TimerExecutionLog) (int64, error) {
+	query := "UPDATE " + log.TableName() + " SET executionStartTime = ?, executionEndTime = ?, executionDurationMs = ?, " +
+		"executionStatus = ?, resultSuccess = ?, errorMessage = ?, retryCount = ?, maxRetryCount = ?, executionResult = ?, " +
+		"logLevel = ?, logMessage = ?, logTimestamp = ?, executionPhase = ?, " +
+		"editTime = ?, editWho = ?, oprSeqFlag = ?, currentVersion = currentVersion + 1 " +
+		"WHERE tenantId = ? AND executionId = ?"
+	args := []interface{}{
+		log.ExecutionStartTime, log.ExecutionEndTime, log.ExecutionDurationMs,
+		log.ExecutionStatus, log.ResultSuccess, log.ErrorMessage, log.RetryCount, log.MaxRetryCount, log.ExecutionResult,
+		log.LogLevel, log.LogMessage, log.LogTimestamp, log.ExecutionPhase,
+		log.EditTime, log.EditWho, log.OprSeqFlag,
+		log.TenantId, log.ExecutionId,
+	}
+	return db.Exec(ctx, query, args, true)
+}
+
+// updateTaskRuntime 更新 HUB_TIMER_TASK 中的执行统计和最后执行结果
+// 成功时清零连续失败次数，并将死信任务恢复为运行中；进入死信状态时更新任务状态
+func updateTaskRuntime(ctx context.Context, db database.Database, log *TimerExecutionLog, deadLetter bool) error {
+	success := log.ResultSuccess == "Y"
+	counters := "successCount = successCount + 1, consecutiveFailures = 0, "
+	if !success {
+		counters = "failureCount = failureCount + 1, consecutiveFailures = consecutiveFailures + 1, "
+	}
+	status := ""
+	switch {
+	case deadLetter:
+		status = fmt.Sprintf("taskStatus = %d, ", taskStatusDeadLetter)
+	case success:
+		status = fmt.Sprintf("taskStatus = CASE WHEN taskStatus = %d THEN %d ELSE taskStatus END, ", taskStatusDeadLetter, taskStatusRunning)
+	}
+
+	query := "UPDATE HUB_TIMER_TASK SET runCount = runCount + 1, " + counters + status +
+		"lastRunTime = ?, lastExecutionId = ?, lastExecutionStartTime = ?, lastExecutionEndTime = ?, " +
+		"lastExecutionDurationMs = ?, lastExecutionStatus = ?, lastResultSuccess = ?, lastErrorMessage = ?, lastRetryCount = ? " +
+		"WHERE tenantId = ? AND taskId = ?"
+	args := []interface{}{
+		log.ExecutionStartTime, log.ExecutionId, log.ExecutionStartTime, log.ExecutionEndTime,
+		log.ExecutionDurationMs, log.ExecutionStatus, log.ResultSuccess, log.ErrorMessage, log.RetryCount,
+		log.TenantId, log.TaskId,
+	}
+	_, err := db.Exec(ctx, query, args, true)
+	return err
+}
+
 // createExecutionLog 创建执行日志记录
 func createExecutionLog(taskConfig interface{}, taskResult interface{}, maxRetries int, tenantId, schedulerId string) *TimerExecutionLog {
 	now := time.Now()
@@ -134,6 +274,15 @@ func createExecutionLog(taskConfig interface{}, taskResult interface{}, maxRetri
 	}
 	logTimestamp := now
 
+	// 执行环境
+	var serverNamePtr, serverIpPtr *string
+	if serverName, err := os.Hostname(); err == nil && serverName != "" {
+		serverNamePtr = &serverName
+	}
+	if serverIp := config.GetNodeIP(); serverIp != "" {
+		serverIpPtr = &serverIp
+	}
+
 	return &TimerExecutionLog{
 		ExecutionId:         generateExecutionId(),
 		TenantId:            tenantId,
@@ -150,6 +299,8 @@ func createExecutionLog(taskConfig interface{}, taskResult interface{}, maxRetri
 		MaxRetryCount:       maxRetries,
 		ExecutionParams:     paramsStr,
 		ExecutionResult:     resultStr,
+		ExecutorServerName:  serverNamePtr,
+		ExecutorServerIp:    serverIpPtr,
 		LogLevel:            &logLevel,
 		LogMessage:          &logMessage,
 		LogTimestamp:        &logTimestamp,
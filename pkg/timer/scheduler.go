@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"gateway/pkg/crash"
 	"gateway/pkg/logger"
 	"gateway/pkg/timer/cron"
 	"gateway/pkg/timer/logwrite"
@@ -34,6 +35,9 @@ type StandardScheduler struct {
 	// 调度控制机制
 	schedulerTicker    *time.Ticker       // 调度定时器，控制任务扫描频率
 	scheduleIntervalCh chan time.Duration // 调度间隔调整通道，支持动态修改扫描间隔
+
+	// 集群协调器，未启用集群协调时为nil
+	cluster *clusterCoordinator
 }
 
 // taskJob 任务作业
@@ -43,6 +47,8 @@ type taskJob struct {
 	params   interface{}  // 任务执行参数
 	executor TaskExecutor // 任务执行器，定义具体执行逻辑
 	config   *TaskConfig  // 任务配置，包含调度规则和状态信息
+	shards   []int        // 本次执行的分片，非分片任务为nil
+	run      *dagRun      // 所属的依赖链执行，由上游任务触发时非nil
 }
 
 // NewStandardScheduler 创建标准调度器实例
@@ -101,6 +107,11 @@ func (s *StandardScheduler) AddTask(config *TaskConfig, executor TaskExecutor) e
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	// 校验依赖关系，避免循环依赖导致依赖链无法结束
+	if err := s.validateDependencies(config); err != nil {
+		return fmt.Errorf("invalid task dependencies: %w", err)
+	}
+
 	// 检查任务ID是否已存在
 	if existingExecutor, exists := s.executors[config.ID]; exists {
 		// 任务已存在，先删除旧任务（在当前锁范围内直接处理，避免死锁）
@@ -329,10 +340,35 @@ func (s *StandardScheduler) Start() error {
 	// 创建上下文用于控制调度器生命周期
 	s.ctx, s.cancel = context.WithCancel(context.Background())
 
+	// 启用集群协调时先登记一次心跳，尽快确定主节点和分片分配
+	clusterConfig := s.config.Cluster
+	if clusterConfig == nil {
+		clusterConfig = LoadClusterConfig()
+	}
+	if clusterConfig.Enabled {
+		coordinator, err := newClusterCoordinator(s.config, clusterConfig)
+		if err != nil {
+			s.cancel()
+			return fmt.Errorf("cluster coordination unavailable: %w", err)
+		}
+		coordinator.heartbeat(s.ctx)
+		s.cluster = coordinator
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			coordinator.run(s.ctx)
+		}()
+	}
+
 	// 启动工作线程池，处理任务执行
+	// 工作线程panic后按崩溃重启策略重启，不影响其他工作线程
 	for i := 0; i < s.config.MaxWorkers; i++ {
 		s.wg.Add(1)
-		go s.worker() // 启动工作线程
+		go func() {
+			defer s.wg.Done() // 确保在退出时通知WaitGroup
+			crash.Supervise("timer.worker", s.worker)
+		}()
 	}
 
 	// 启动调度协程，负责扫描和调度任务
@@ -377,6 +413,12 @@ func (s *StandardScheduler) Stop() error {
 	// 等待所有工作线程和调度协程结束
 	s.wg.Wait()
 
+	// 释放主节点租约，其他节点下次心跳即可接管
+	if s.cluster != nil {
+		s.cluster.release(context.Background())
+		s.cluster = nil
+	}
+
 	// 释放所有执行器资源
 	s.closeAllExecutors()
 
@@ -534,12 +576,28 @@ func (s *StandardScheduler) checkAndScheduleTasks() {
 			continue
 		}
 
+		// 集群模式下由主节点执行普通任务，分片任务各节点执行分配到的分片
+		shards := allShards(config.ShardCount)
+		if s.cluster != nil && config.ClusterMode == ClusterModeExclusive {
+			fireTime := now
+			if nextRunTime := config.GetNextRunTime(); nextRunTime != nil {
+				fireTime = *nextRunTime
+			}
+			claimed, ok, executedElsewhere := s.cluster.claim(s.ctx, config, fireTime)
+			if !ok {
+				s.skipClusterRun(config, now, executedElsewhere)
+				continue
+			}
+			shards = claimed
+		}
+
 		// 创建任务作业对象
 		job := &taskJob{
 			taskID:   config.ID,
 			params:   config.Params, // 使用任务配置中的参数
 			executor: executor,
 			config:   config,
+			shards:   shards,
 		}
 
 		// 尝试将任务放入执行队列
@@ -574,16 +632,21 @@ func (s *StandardScheduler) shouldExecuteNow(config *TaskConfig, now time.Time)
 		return false
 	}
 
-	// 检查任务是否正在运行（避免重复执行）
-	if config.GetStatus() == TaskStatusRunning {
+	// 检查任务是否正在运行（避免重复执行）或已进入死信状态（需手动触发）
+	if status := config.GetStatus(); status == TaskStatusRunning || status == TaskStatusDeadLetter {
+		return false
+	}
+
+	// 依赖任务由上游任务执行结束后触发，不按自身调度配置执行
+	if config.HasDependencies() {
 		return false
 	}
 
 	// 根据调度类型进行不同的判断
 	switch config.ScheduleType {
 	case ScheduleTypeOnce:
-		// 一次性任务：检查是否已执行过
-		if config.GetRunCount() > 0 {
+		// 一次性任务：检查是否已执行过（包括集群中已由其他节点执行）
+		if config.GetRunCount() > 0 || config.GetStatus() == TaskStatusCompleted {
 			return false // 已执行过，不再调度
 		}
 
@@ -597,8 +660,8 @@ func (s *StandardScheduler) shouldExecuteNow(config *TaskConfig, now time.Time)
 		return nextRunTime != nil && (now.After(*nextRunTime) || now.Equal(*nextRunTime))
 
 	case ScheduleTypeDelay:
-		// 延迟执行任务：检查是否已执行过
-		if config.GetRunCount() > 0 {
+		// 延迟执行任务：检查是否已执行过（包括集群中已由其他节点执行）
+		if config.GetRunCount() > 0 || config.GetStatus() == TaskStatusCompleted {
 			return false // 已执行过，不再调度
 		}
 
@@ -634,12 +697,53 @@ func (s *StandardScheduler) shouldExecuteNow(config *TaskConfig, now time.Time)
 	}
 }
 
+// skipClusterRun 本节点不执行本次调度时推进任务的下次执行时间
+// 一次性和延迟任务在其他节点已执行时不再调度，否则保持待执行，主节点切换到本节点后接管执行
+// 参数:
+//
+//	config: 任务配置
+//	now: 当前时间
+//	executedElsewhere: 本次调度是否已在其他节点执行
+func (s *StandardScheduler) skipClusterRun(config *TaskConfig, now time.Time, executedElsewhere bool) {
+	switch config.ScheduleType {
+	case ScheduleTypeInterval:
+		nextRunTime := now.Add(config.Interval)
+		config.SetNextRunTime(&nextRunTime)
+	case ScheduleTypeCron:
+		s.updateNextRunTime(config)
+	default:
+		if executedElsewhere {
+			config.SetNextRunTime(nil)
+			config.UpdateStatus(TaskStatusCompleted)
+			logger.Info("任务已在集群其他节点执行", "taskID", config.ID)
+		}
+	}
+}
+
+// allShards 返回全部分片序号，单节点或未启用集群协调时由本节点执行所有分片；非分片任务返回nil
+func allShards(total int) []int {
+	if total <= 1 {
+		return nil
+	}
+	shards := make([]int, total)
+	for i := range shards {
+		shards[i] = i
+	}
+	return shards
+}
+
 // updateNextRunTime 更新任务的下次执行时间
 // 根据任务类型和配置重新计算下次执行时间
 // 参数:
 //
 //	config: 任务配置
 func (s *StandardScheduler) updateNextRunTime(config *TaskConfig) {
+	// 依赖任务没有自身的执行时间
+	if config.HasDependencies() {
+		config.SetNextRunTime(nil)
+		return
+	}
+
 	// 重新计算下次执行时间
 	nextRunTime := s.calculateNextRunTime(config)
 	if nextRunTime.IsZero() {
@@ -664,6 +768,11 @@ func (s *StandardScheduler) updateNextRunTime(config *TaskConfig) {
 func (s *StandardScheduler) calculateNextRunTime(config *TaskConfig) time.Time {
 	now := time.Now() // 获取当前时间作为计算基准
 
+	// 依赖任务由上游任务触发，不计算执行时间
+	if config.HasDependencies() {
+		return time.Time{}
+	}
+
 	// 根据调度类型计算下次执行时间
 	switch config.ScheduleType {
 	case ScheduleTypeOnce:
@@ -704,8 +813,13 @@ func (s *StandardScheduler) calculateNextRunTime(config *TaskConfig) time.Time {
 			return time.Time{} // cron表达式为空，无法调度
 		}
 
-		// 解析cron表达式
-		schedule, err := s.cronParser.Parse(config.CronExpr)
+		// 按任务时区解析cron表达式，未配置时区时使用服务器本地时区
+		loc, err := LoadTaskLocation(config.TimeZone)
+		if err != nil {
+			logger.Warn("加载任务时区失败", "taskID", config.ID, "timeZone", config.TimeZone, "error", err)
+			return time.Time{} // 时区无效，无法调度
+		}
+		schedule, err := s.cronParser.ParseInLocation(config.CronExpr, loc)
 		if err != nil {
 			logger.Warn("解析cron表达式失败", "taskID", config.ID, "cronExpr", config.CronExpr, "error", err)
 			return time.Time{} // 解析失败，无法调度
@@ -722,8 +836,6 @@ func (s *StandardScheduler) calculateNextRunTime(config *TaskConfig) time.Time {
 // worker 工作线程，处理任务队列中的任务
 // 从任务队列中获取任务并执行，直到调度器停止
 func (s *StandardScheduler) worker() {
-	defer s.wg.Done() // 确保在退出时通知WaitGroup
-
 	for {
 		select {
 		case job, ok := <-s.taskQueue:
@@ -755,11 +867,30 @@ func (s *StandardScheduler) runTask(job *taskJob) {
 	ctx, cancel := context.WithTimeout(s.ctx, timeout)
 	defer cancel() // 确保释放上下文资源
 
+	// 日志写入不受任务超时影响，任务超时后仍需记录执行结果
+	logCtx := context.WithoutCancel(ctx)
+
+	// 记录开始执行，失败不影响任务执行
+	executionId, err := logwrite.StartTaskExecutionLog(logCtx, job.config, s.config.TenantId, s.config.ID)
+	if err != nil {
+		logger.Warn("写入执行开始记录失败", "taskID", job.taskID, "error", err)
+	}
+
 	// 创建任务结果对象，记录执行过程
 	result := NewTaskResult(job.taskID)
 
-	// 执行任务（包含重试逻辑）
-	err := s.executeWithRetry(ctx, job, result)
+	// 执行任务（包含重试逻辑），分片任务依次执行本次领取的分片
+	if len(job.shards) == 0 {
+		err = s.executeWithRetry(ctx, job, result)
+	} else {
+		for _, shard := range job.shards {
+			shardCtx := WithShard(ctx, ShardInfo{Index: shard, Total: job.config.ShardCount})
+			if shardErr := s.executeWithRetry(shardCtx, job, result); shardErr != nil {
+				logger.Warn("任务分片执行失败", "taskID", job.taskID, "shard", shard, "error", shardErr)
+				err = fmt.Errorf("shard %d: %w", shard, shardErr)
+			}
+		}
+	}
 	if err != nil {
 		result.Fail(err) // 标记任务执行失败
 		logger.Error("任务执行失败", "taskID", job.taskID, "error", err, "duration", result.Duration)
@@ -769,17 +900,30 @@ func (s *StandardScheduler) runTask(job *taskJob) {
 
 	// 更新任务的运行信息（执行次数、最后执行时间等）
 	job.config.UpdateRunInfo(result)
-
-	// 任务完成后，为重复执行的任务更新下次执行时间
-	s.updateNextRunTime(job.config)
+	deadLetter := job.config.GetStatus() == TaskStatusDeadLetter
+	if deadLetter {
+		job.config.SetNextRunTime(nil)
+		logger.Error("任务连续执行失败，已进入死信状态，需手动触发执行", "taskID", job.taskID,
+			"consecutiveFailures", job.config.ConsecutiveFailures, "threshold", job.config.DeadLetterThreshold)
+	} else {
+		// 任务完成后，为重复执行的任务更新下次执行时间
+		s.updateNextRunTime(job.config)
+	}
 
 	// 写入任务执行日志到数据库
-	if err := s.writeExecutionLog(ctx, job, result); err != nil {
+	if err := s.writeExecutionLog(logCtx, executionId, job, result, deadLetter); err != nil {
 		logger.Error("日志写入失败", "taskID", job.taskID, "error", err)
 	}
 
 	// 记录任务执行完成的日志
 	logger.Info("任务执行完成", "taskID", job.taskID, "status", result.Status.String(), "duration", result.Duration, "retryCount", result.RetryCount)
+
+	// 触发上游已全部结束的下游依赖任务
+	outcome := outcomeSucceeded
+	if result.Status == TaskStatusFailed {
+		outcome = outcomeFailed
+	}
+	s.finishDependency(job.run, job.taskID, outcome)
 }
 
 // executeWithRetry 带重试的任务执行
@@ -804,28 +948,27 @@ func (s *StandardScheduler) executeWithRetry(ctx context.Context, job *taskJob,
 		maxAttempts = 1
 	}
 
-	// 获取重试间隔
-	retryInterval := job.config.RetryInterval
-	if retryInterval <= 0 {
-		retryInterval = time.Second * 5 // 默认重试间隔5秒
-	}
-
 	var lastErr error // 记录最后一次错误
 
 	// 执行重试循环（maxAttempts次总执行次数）
 	for attempt := 0; attempt < maxAttempts; attempt++ {
 		if attempt > 0 {
 			result.RetryCount++ // 增加重试计数
-			// 等待重试间隔，支持上下文取消
+			// 等待重试间隔（按退避倍率递增），支持上下文取消
 			select {
-			case <-time.After(retryInterval):
+			case <-time.After(job.config.RetryDelay(attempt)):
 			case <-ctx.Done():
 				return ctx.Err() // 上下文已取消，返回取消错误
 			}
 		}
 
-		// 调用执行器执行任务
-		executeResult, err := job.executor.Execute(ctx, job.params)
+		// 调用执行器执行任务，执行器panic视为本次执行失败
+		var executeResult *ExecuteResult
+		err := crash.Call("timer.task", func() error {
+			var executeErr error
+			executeResult, executeErr = job.executor.Execute(ctx, job.params)
+			return executeErr
+		})
 		if err != nil {
 			lastErr = err
 			logger.Warn("任务重试失败", "taskID", job.taskID, "attempt", attempt+1, "maxAttempts", maxAttempts, "error", err)
@@ -855,20 +998,24 @@ func (s *StandardScheduler) executeWithRetry(ctx context.Context, job *taskJob,
 // 参数:
 //
 //	ctx: 上下文
+//	executionId: 开始执行时写入的执行ID，为空时插入新记录
 //	job: 任务作业对象
 //	result: 任务执行结果
+//	deadLetter: 任务是否已进入死信状态
 //
 // 返回:
 //
 //	error: 写入失败时返回错误信息
-func (s *StandardScheduler) writeExecutionLog(ctx context.Context, job *taskJob, result *TaskResult) error {
+func (s *StandardScheduler) writeExecutionLog(ctx context.Context, executionId string, job *taskJob, result *TaskResult, deadLetter bool) error {
 	// 直接调用静态方法写入日志，所有逻辑由logwrite包处理
-	return logwrite.WriteTaskExecutionLog(
+	return logwrite.FinishTaskExecutionLog(
 		ctx,
+		executionId,           // 执行ID
 		job.config,            // 任务配置
 		result,                // 任务执行结果
 		job.config.MaxRetries, // 最大重试次数
 		s.config.TenantId,     // 租户ID，由logwrite包设置默认值
 		s.config.ID,           // 调度器ID，可选
+		deadLetter,            // 是否进入死信状态
 	)
 }
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"time"
+
+	"gateway/pkg/timer/cron"
 )
 
 // ValidateTaskConfig 验证任务配置的有效性
@@ -36,6 +39,9 @@ func ValidateTaskConfig(config *TaskConfig) error {
 		if config.CronExpr == "" {
 			return errors.New("cron expression is required for cron schedule type")
 		}
+		if _, err := ParseTaskCron(config.CronExpr, config.TimeZone); err != nil {
+			return fmt.Errorf("invalid cron schedule: %w", err)
+		}
 	case ScheduleTypeInterval:
 		// 间隔调度需要正数间隔时间
 		if config.Interval <= 0 {
@@ -56,10 +62,76 @@ func ValidateTaskConfig(config *TaskConfig) error {
 	if config.MaxRetries < 0 {
 		return errors.New("max retries cannot be negative")
 	}
+	if config.RetryBackoff < 0 {
+		return errors.New("retry backoff cannot be negative")
+	}
+	if config.DeadLetterThreshold < 0 {
+		return errors.New("dead letter threshold cannot be negative")
+	}
 	
 	return nil
 }
 
+// ParseTaskCron 按任务的时区解析Cron表达式
+// 参数:
+//   cronExpr: Cron表达式，支持秒字段和 CRON_TZ= 时区前缀（优先于 timeZone）
+//   timeZone: IANA时区名称，为空使用服务器本地时区
+// 返回:
+//   cron.CronSchedule: 解析后的调度对象
+//   error: 时区或表达式无效时返回错误
+func ParseTaskCron(cronExpr, timeZone string) (cron.CronSchedule, error) {
+	loc, err := LoadTaskLocation(timeZone)
+	if err != nil {
+		return nil, err
+	}
+	return cron.NewStandardCronParser().ParseInLocation(cronExpr, loc)
+}
+
+// LoadTaskLocation 加载任务时区
+// 参数:
+//   timeZone: IANA时区名称，为空返回服务器本地时区
+// 返回:
+//   *time.Location: 时区
+//   error: 时区名称无效时返回错误
+func LoadTaskLocation(timeZone string) (*time.Location, error) {
+	if timeZone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %s: %w", timeZone, err)
+	}
+	return loc, nil
+}
+
+// defaultRetryInterval 未配置重试间隔时的默认值
+const defaultRetryInterval = time.Second * 5
+
+// RetryDelay 计算第 attempt 次重试前的等待时间
+// 以 RetryInterval 为初始间隔，RetryBackoff 大于1时每次重试按倍率递增，不超过 MaxRetryInterval
+// 参数:
+//   attempt: 重试序号，从1开始
+// 返回:
+//   time.Duration: 等待时间
+func (c *TaskConfig) RetryDelay(attempt int) time.Duration {
+	delay := c.RetryInterval
+	if delay <= 0 {
+		delay = defaultRetryInterval
+	}
+	if c.RetryBackoff > 1 && attempt > 1 {
+		scaled := float64(delay) * math.Pow(c.RetryBackoff, float64(attempt-1))
+		if scaled >= float64(math.MaxInt64) {
+			delay = time.Duration(math.MaxInt64)
+		} else {
+			delay = time.Duration(scaled)
+		}
+	}
+	if c.MaxRetryInterval > 0 && delay > c.MaxRetryInterval {
+		delay = c.MaxRetryInterval
+	}
+	return delay
+}
+
 // CopyTaskConfig 深拷贝任务配置
 // 使用JSON序列化/反序列化实现深拷贝，确保配置对象的独立性
 // 参数:
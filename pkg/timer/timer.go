@@ -12,11 +12,12 @@ import (
 type TaskStatus int
 
 const (
-	TaskStatusPending   TaskStatus = 1 // 待执行
-	TaskStatusRunning   TaskStatus = 2 // 运行中
-	TaskStatusCompleted TaskStatus = 3 // 已完成
-	TaskStatusFailed    TaskStatus = 4 // 执行失败
-	TaskStatusCancelled TaskStatus = 5 // 已取消
+	TaskStatusPending    TaskStatus = 1 // 待执行
+	TaskStatusRunning    TaskStatus = 2 // 运行中
+	TaskStatusCompleted  TaskStatus = 3 // 已完成
+	TaskStatusFailed     TaskStatus = 4 // 执行失败
+	TaskStatusCancelled  TaskStatus = 5 // 已取消
+	TaskStatusDeadLetter TaskStatus = 6 // 死信，连续失败达到阈值后停止调度，需手动触发执行成功后恢复
 )
 
 // String 返回任务状态的字符串表示
@@ -32,6 +33,8 @@ func (s TaskStatus) String() string {
 		return "FAILED"
 	case TaskStatusCancelled:
 		return "CANCELLED"
+	case TaskStatusDeadLetter:
+		return "DEAD_LETTER"
 	default:
 		return "UNKNOWN"
 	}
@@ -99,31 +102,44 @@ type TaskConfig struct {
 	// 调度配置
 	ScheduleType ScheduleType  `json:"scheduleType"` // 调度类型
 	CronExpr     string        `json:"cronExpr"`     // Cron表达式
+	TimeZone     string        `json:"timeZone"`     // Cron表达式使用的时区（IANA名称，如 Asia/Shanghai），为空使用服务器本地时区
 	Interval     time.Duration `json:"interval"`     // 执行间隔
 	Delay        time.Duration `json:"delay"`        // 延迟时间
 	StartTime    *time.Time    `json:"startTime"`    // 开始时间
 	EndTime      *time.Time    `json:"endTime"`      // 结束时间
 	
 	// 执行配置
-	MaxRetries    int           `json:"maxRetries"`    // 最大重试次数
-	RetryInterval time.Duration `json:"retryInterval"` // 重试间隔
-	Timeout       time.Duration `json:"timeout"`       // 执行超时时间
+	MaxRetries          int           `json:"maxRetries"`          // 最大重试次数
+	RetryInterval       time.Duration `json:"retryInterval"`       // 重试间隔
+	RetryBackoff        float64       `json:"retryBackoff"`        // 重试间隔退避倍率，每次重试间隔乘以该倍率，小于等于1为固定间隔
+	MaxRetryInterval    time.Duration `json:"maxRetryInterval"`    // 退避后的最大重试间隔，0表示不限制
+	DeadLetterThreshold int           `json:"deadLetterThreshold"` // 连续失败多少次后进入死信状态，0表示不启用
+	Timeout             time.Duration `json:"timeout"`             // 执行超时时间
 	
 	// 任务参数
 	Params interface{} `json:"params"` // 任务参数
+
+	// 集群配置
+	ClusterMode ClusterMode `json:"clusterMode"` // 集群执行方式，仅在调度器启用集群协调时生效
+	ShardCount  int         `json:"shardCount"`  // 分片数，大于1时按分片执行，执行器通过 ShardFromContext 获取分片
+
+	// 依赖配置
+	DependsOn        []string         `json:"dependsOn"`        // 上游任务ID列表，非空时不按自身调度配置执行，由同一调度器中的上游任务执行结束后触发
+	DependencyPolicy DependencyPolicy `json:"dependencyPolicy"` // 上游任务失败时的处理方式
 	
 	// 其他配置
 	Enabled   bool      `json:"enabled"`   // 是否启用
 	CreatedAt time.Time `json:"createdAt"` // 创建时间
 	
 	// 运行时状态信息
-	Status       TaskStatus    `json:"status"`       // 当前状态
-	NextRunTime  *time.Time    `json:"nextRunTime"`  // 下次执行时间
-	LastRunTime  *time.Time    `json:"lastRunTime"`  // 上次执行时间
-	LastResult   *TaskResult   `json:"lastResult"`   // 上次执行结果
-	RunCount     int64         `json:"runCount"`     // 执行次数
-	FailureCount int64         `json:"failureCount"` // 失败次数
-	UpdatedAt    time.Time     `json:"updatedAt"`    // 更新时间
+	Status              TaskStatus  `json:"status"`              // 当前状态
+	NextRunTime         *time.Time  `json:"nextRunTime"`         // 下次执行时间
+	LastRunTime         *time.Time  `json:"lastRunTime"`         // 上次执行时间
+	LastResult          *TaskResult `json:"lastResult"`          // 上次执行结果
+	RunCount            int64       `json:"runCount"`            // 执行次数
+	FailureCount        int64       `json:"failureCount"`        // 失败次数
+	ConsecutiveFailures int64       `json:"consecutiveFailures"` // 连续失败次数，执行成功后清零
+	UpdatedAt           time.Time   `json:"updatedAt"`           // 更新时间
 	
 	// 并发控制
 	mu sync.RWMutex `json:"-"` // 读写锁，用于并发安全
@@ -146,8 +162,17 @@ func (tc *TaskConfig) UpdateRunInfo(result *TaskResult) {
 	tc.RunCount++
 	if result.Status == TaskStatusFailed {
 		tc.FailureCount++
+		tc.ConsecutiveFailures++
+	} else {
+		tc.ConsecutiveFailures = 0
 	}
 	tc.UpdatedAt = time.Now()
+
+	// 连续失败达到阈值后进入死信状态，停止调度，等待手动触发
+	if result.Status == TaskStatusFailed && tc.DeadLetterThreshold > 0 && tc.ConsecutiveFailures >= int64(tc.DeadLetterThreshold) {
+		tc.Status = TaskStatusDeadLetter
+		return
+	}
 	
 	// 关键修复：根据执行结果重置任务状态，确保间隔任务能够继续调度
 	// 对于重复执行的任务（如间隔任务、cron任务），执行完成后应该重置为待执行状态
@@ -314,6 +339,7 @@ type SchedulerConfig struct {
 	DefaultTimeout   time.Duration             `json:"defaultTimeout"`   // 默认超时时间
 	DefaultRetries   int                       `json:"defaultRetries"`   // 默认重试次数
 	ScheduleInterval time.Duration             `json:"scheduleInterval"` // 调度检查间隔
+	Cluster          *ClusterConfig            `json:"cluster"`          // 集群协调配置，为nil时读取 app.timer.cluster 配置
 	
 	// 任务配置映射
 	Tasks map[string]*TaskConfig `json:"tasks"` // 任务ID到任务配置的映射
@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"gateway/pkg/logger"
+	"gateway/pkg/security"
 
 	"github.com/youmark/pkcs8"
 )
@@ -132,16 +133,22 @@ func (loader *CertLoader) decryptPrivateKey(keyPEM []byte) ([]byte, error) {
 		return keyPEM, nil
 	}
 
+	// 私钥密码支持 ENCY_ 加密存储，使用前先解密
+	keyPassword, err := security.DecryptWithDefaultKey(loader.config.KeyPassword)
+	if err != nil {
+		return nil, fmt.Errorf("解密私钥密码失败: %w", err)
+	}
+
 	// 检查是否为加密的 PKCS#8 私钥
 	if block.Type == "ENCRYPTED PRIVATE KEY" {
-		if loader.config.KeyPassword == "" {
+		if keyPassword == "" {
 			return nil, fmt.Errorf("私钥已加密（PKCS#8）但未提供密码")
 		}
 
 		logger.Debug("检测到PKCS#8加密私钥，使用密码解密")
 
 		// 使用 github.com/youmark/pkcs8 包解密 PKCS#8 私钥
-		privateKey, err := pkcs8.ParsePKCS8PrivateKey(block.Bytes, []byte(loader.config.KeyPassword))
+		privateKey, err := pkcs8.ParsePKCS8PrivateKey(block.Bytes, []byte(keyPassword))
 		if err != nil {
 			return nil, fmt.Errorf("解密PKCS#8私钥失败: %w", err)
 		}
@@ -164,14 +171,14 @@ func (loader *CertLoader) decryptPrivateKey(keyPEM []byte) ([]byte, error) {
 
 	// 检查传统加密格式（PEM 加密，如 RSA PRIVATE KEY with DEK-Info）
 	if block.Headers != nil && block.Headers["DEK-Info"] != "" {
-		if loader.config.KeyPassword == "" {
+		if keyPassword == "" {
 			return nil, fmt.Errorf("私钥已加密（传统格式）但未提供密码")
 		}
 
 		logger.Debug("检测到传统格式加密私钥，使用密码解密")
 
 		// 解密传统格式私钥
-		decryptedDER, err := decryptPEMBlock(block, []byte(loader.config.KeyPassword))
+		decryptedDER, err := decryptPEMBlock(block, []byte(keyPassword))
 		if err != nil {
 			return nil, fmt.Errorf("解密传统格式私钥失败: %w", err)
 		}
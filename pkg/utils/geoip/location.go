@@ -0,0 +1,90 @@
+package geoip
+
+import (
+	"net"
+	"strings"
+	"sync/atomic"
+)
+
+// Location 客户端地理位置
+// 名称字段使用数据库中的英文名称，便于日志统计和路由配置
+type Location struct {
+	ContinentCode string `json:"continentCode,omitempty"` // 大洲代码，如 EU、AS、NA
+	CountryCode   string `json:"countryCode,omitempty"`   // 国家ISO代码，如 CN、DE
+	Country       string `json:"country,omitempty"`       // 国家名称
+	RegionCode    string `json:"regionCode,omitempty"`    // 一级行政区代码，如 BJ、BY
+	Region        string `json:"region,omitempty"`        // 一级行政区名称
+	City          string `json:"city,omitempty"`          // 城市名称
+}
+
+// IsEmpty 是否没有任何地理位置信息
+func (l *Location) IsEmpty() bool {
+	return l == nil || (l.ContinentCode == "" && l.CountryCode == "" && l.RegionCode == "" && l.Region == "" && l.City == "")
+}
+
+// newLocation 从数据库记录中提取地理位置
+// 国家缺失时使用注册国家（如卫星、任播地址只有注册国家）
+func newLocation(record map[string]interface{}) *Location {
+	location := &Location{
+		ContinentCode: recordString(record, "continent", "code"),
+		CountryCode:   recordString(record, "country", "iso_code"),
+		Country:       recordString(record, "country", "names", "en"),
+		City:          recordString(record, "city", "names", "en"),
+	}
+	if location.CountryCode == "" {
+		location.CountryCode = recordString(record, "registered_country", "iso_code")
+		location.Country = recordString(record, "registered_country", "names", "en")
+	}
+	if subdivisions, ok := record["subdivisions"].([]interface{}); ok && len(subdivisions) > 0 {
+		if subdivision, ok := subdivisions[0].(map[string]interface{}); ok {
+			location.RegionCode = recordString(subdivision, "iso_code")
+			location.Region = recordString(subdivision, "names", "en")
+		}
+	}
+	return location
+}
+
+// recordString 按路径读取记录中的字符串
+func recordString(record map[string]interface{}, path ...string) string {
+	var current interface{} = record
+	for _, key := range path {
+		values, ok := current.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		current = values[key]
+	}
+	value, _ := current.(string)
+	return value
+}
+
+// defaultReader 全局地理位置库，由应用启动时根据配置加载
+var defaultReader atomic.Pointer[Reader]
+
+// SetDefault 设置全局地理位置库，传入nil表示关闭地理位置查询
+func SetDefault(reader *Reader) {
+	defaultReader.Store(reader)
+}
+
+// Default 获取全局地理位置库，未加载时返回nil
+func Default() *Reader {
+	return defaultReader.Load()
+}
+
+// Lookup 使用全局地理位置库查询IP地址
+// 未加载地理位置库、IP无效、私有地址或库中不存在时返回nil
+func Lookup(ip string) *Location {
+	reader := Default()
+	if reader == nil {
+		return nil
+	}
+	parsed := net.ParseIP(strings.TrimSpace(ip))
+	if parsed == nil || parsed.IsLoopback() || parsed.IsPrivate() || parsed.IsUnspecified() {
+		return nil
+	}
+	location, err := reader.Lookup(parsed)
+	if err != nil || location.IsEmpty() {
+		return nil
+	}
+	return location
+}
@@ -0,0 +1,401 @@
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net"
+	"os"
+)
+
+// metadataStartMarker MaxMind DB 元数据段起始标记
+var metadataStartMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// dataSectionSeparatorSize 搜索树与数据段之间的16字节分隔符
+const dataSectionSeparatorSize = 16
+
+// Reader MaxMind DB（.mmdb）格式的地理位置库读取器
+//
+// 支持 GeoLite2/GeoIP2 的 Country、City 数据库，数据库文件整体加载到内存，
+// 查询只读内存数据，可被多个goroutine并发使用。
+type Reader struct {
+	buffer       []byte  // 数据库文件内容
+	decoder      decoder // 数据段解码器
+	nodeCount    uint    // 搜索树节点数
+	recordSize   uint    // 记录位数（24/28/32）
+	ipVersion    uint    // 数据库IP版本（4或6）
+	ipv4Start    uint    // IPv6数据库中IPv4地址（::/96）的起始节点
+	DatabaseType string  // 数据库类型，如 GeoLite2-City
+}
+
+// Open 从文件加载地理位置库
+// 参数:
+// - path: .mmdb 文件路径
+// 返回值:
+// - *Reader: 读取器
+// - error: 文件读取或格式错误
+func Open(path string) (*Reader, error) {
+	buffer, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取地理位置库文件失败: %w", err)
+	}
+	return FromBytes(buffer)
+}
+
+// FromBytes 从内存数据创建地理位置库读取器
+// 参数:
+// - buffer: .mmdb 文件内容
+// 返回值:
+// - *Reader: 读取器
+// - error: 格式错误
+func FromBytes(buffer []byte) (*Reader, error) {
+	metadataStart := bytes.LastIndex(buffer, metadataStartMarker)
+	if metadataStart < 0 {
+		return nil, fmt.Errorf("无效的地理位置库: 未找到元数据")
+	}
+	metadataStart += len(metadataStartMarker)
+
+	metadataDecoder := decoder{buffer: buffer[metadataStart:]}
+	value, _, err := metadataDecoder.decode(0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("解析地理位置库元数据失败: %w", err)
+	}
+	metadata, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("无效的地理位置库: 元数据不是map")
+	}
+
+	reader := &Reader{
+		buffer:     buffer,
+		nodeCount:  uint(metadataUint(metadata, "node_count")),
+		recordSize: uint(metadataUint(metadata, "record_size")),
+		ipVersion:  uint(metadataUint(metadata, "ip_version")),
+	}
+	reader.DatabaseType, _ = metadata["database_type"].(string)
+
+	switch reader.recordSize {
+	case 24, 28, 32:
+	default:
+		return nil, fmt.Errorf("不支持的地理位置库记录大小: %d", reader.recordSize)
+	}
+	if reader.ipVersion != 4 && reader.ipVersion != 6 {
+		return nil, fmt.Errorf("不支持的地理位置库IP版本: %d", reader.ipVersion)
+	}
+
+	searchTreeSize := int(reader.nodeCount * reader.recordSize / 4)
+	dataStart := searchTreeSize + dataSectionSeparatorSize
+	dataEnd := metadataStart - len(metadataStartMarker)
+	if dataStart > dataEnd {
+		return nil, fmt.Errorf("无效的地理位置库: 搜索树大小超出文件范围")
+	}
+	reader.decoder = decoder{buffer: buffer[dataStart:dataEnd]}
+
+	// IPv6数据库中IPv4地址位于 ::/96 子树，预先计算起始节点
+	if reader.ipVersion == 6 {
+		node := uint(0)
+		for i := 0; i < 96 && node < reader.nodeCount; i++ {
+			node = reader.readRecord(node, 0)
+		}
+		reader.ipv4Start = node
+	}
+	return reader, nil
+}
+
+// metadataUint 读取元数据中的无符号整数
+func metadataUint(metadata map[string]interface{}, key string) uint64 {
+	value, _ := metadata[key].(uint64)
+	return value
+}
+
+// Lookup 查询IP地址的地理位置
+// 参数:
+// - ip: IP地址
+// 返回值:
+// - *Location: 地理位置，库中不存在该地址时返回nil
+// - error: IP无效或数据库损坏
+func (r *Reader) Lookup(ip net.IP) (*Location, error) {
+	offset, found, err := r.lookupOffset(ip)
+	if err != nil || !found {
+		return nil, err
+	}
+	value, _, err := r.decoder.decode(offset, 0)
+	if err != nil {
+		return nil, fmt.Errorf("解析地理位置记录失败: %w", err)
+	}
+	record, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("地理位置记录不是map")
+	}
+	return newLocation(record), nil
+}
+
+// lookupOffset 在搜索树中查找IP对应的数据段偏移
+func (r *Reader) lookupOffset(ip net.IP) (uint, bool, error) {
+	if ip == nil {
+		return 0, false, fmt.Errorf("无效的IP地址")
+	}
+
+	node := uint(0)
+	address := ip.To4()
+	if address != nil {
+		if r.ipVersion == 6 {
+			node = r.ipv4Start
+		}
+	} else {
+		address = ip.To16()
+		if address == nil {
+			return 0, false, fmt.Errorf("无效的IP地址: %s", ip)
+		}
+		if r.ipVersion == 4 {
+			// IPv4数据库中不包含IPv6地址
+			return 0, false, nil
+		}
+	}
+
+	bitCount := len(address) * 8
+	for i := 0; i < bitCount && node < r.nodeCount; i++ {
+		bit := uint(address[i>>3]>>(7-uint(i%8))) & 1
+		node = r.readRecord(node, bit)
+	}
+
+	switch {
+	case node == r.nodeCount:
+		return 0, false, nil
+	case node > r.nodeCount:
+		offset := node - r.nodeCount - dataSectionSeparatorSize
+		if offset >= uint(len(r.decoder.buffer)) {
+			return 0, false, fmt.Errorf("地理位置库搜索树指针越界")
+		}
+		return offset, true, nil
+	default:
+		return 0, false, fmt.Errorf("地理位置库搜索树无效")
+	}
+}
+
+// readRecord 读取搜索树节点的左（bit=0）或右（bit=1）记录
+func (r *Reader) readRecord(node uint, bit uint) uint {
+	switch r.recordSize {
+	case 24:
+		base := node * 6
+		if bit == 1 {
+			base += 3
+		}
+		b := r.buffer[base : base+3]
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+	case 28:
+		base := node * 7
+		b := r.buffer[base : base+7]
+		if bit == 0 {
+			return uint(b[3]>>4)<<24 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0f)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default:
+		base := node * 8
+		if bit == 1 {
+			base += 4
+		}
+		return uint(binary.BigEndian.Uint32(r.buffer[base : base+4]))
+	}
+}
+
+// 数据段字段类型
+const (
+	dataTypeExtended  = 0
+	dataTypePointer   = 1
+	dataTypeString    = 2
+	dataTypeDouble    = 3
+	dataTypeBytes     = 4
+	dataTypeUint16    = 5
+	dataTypeUint32    = 6
+	dataTypeMap       = 7
+	dataTypeInt32     = 8
+	dataTypeUint64    = 9
+	dataTypeUint128   = 10
+	dataTypeArray     = 11
+	dataTypeContainer = 12
+	dataTypeEndMarker = 13
+	dataTypeBool      = 14
+	dataTypeFloat     = 15
+)
+
+// maxDecodeDepth 最大嵌套深度，防止损坏的数据库导致无限递归
+const maxDecodeDepth = 64
+
+// decoder MaxMind DB 数据段解码器
+// 整数统一解码为uint64（int32解码为int64），uint128解码为大端字节切片
+type decoder struct {
+	buffer []byte
+}
+
+// decode 解码offset处的值，返回值和下一个字段的偏移
+func (d *decoder) decode(offset uint, depth int) (interface{}, uint, error) {
+	if depth > maxDecodeDepth {
+		return nil, 0, fmt.Errorf("数据嵌套过深")
+	}
+	typeNum, size, offset, err := d.decodeControl(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if typeNum == dataTypePointer {
+		pointer, next, err := d.decodePointer(size, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		value, _, err := d.decode(pointer, depth+1)
+		return value, next, err
+	}
+
+	switch typeNum {
+	case dataTypeMap:
+		result := make(map[string]interface{}, size)
+		for i := uint(0); i < size; i++ {
+			var key interface{}
+			key, offset, err = d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			name, ok := key.(string)
+			if !ok {
+				return nil, 0, fmt.Errorf("map的键不是字符串")
+			}
+			result[name], offset, err = d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+		return result, offset, nil
+	case dataTypeArray:
+		result := make([]interface{}, size)
+		for i := uint(0); i < size; i++ {
+			result[i], offset, err = d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+		return result, offset, nil
+	case dataTypeBool:
+		return size != 0, offset, nil
+	}
+
+	end := offset + size
+	if end > uint(len(d.buffer)) || end < offset {
+		return nil, 0, fmt.Errorf("数据越界")
+	}
+	data := d.buffer[offset:end]
+
+	switch typeNum {
+	case dataTypeString:
+		return string(data), end, nil
+	case dataTypeBytes:
+		return append([]byte(nil), data...), end, nil
+	case dataTypeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("double长度无效: %d", size)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(data)), end, nil
+	case dataTypeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("float长度无效: %d", size)
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), end, nil
+	case dataTypeUint16, dataTypeUint32, dataTypeUint64:
+		if size > 8 {
+			return nil, 0, fmt.Errorf("整数长度无效: %d", size)
+		}
+		var value uint64
+		for _, b := range data {
+			value = value<<8 | uint64(b)
+		}
+		return value, end, nil
+	case dataTypeInt32:
+		if size > 4 {
+			return nil, 0, fmt.Errorf("int32长度无效: %d", size)
+		}
+		var value uint32
+		for _, b := range data {
+			value = value<<8 | uint32(b)
+		}
+		return int64(int32(value)), end, nil
+	case dataTypeUint128:
+		if size > 16 {
+			return nil, 0, fmt.Errorf("uint128长度无效: %d", size)
+		}
+		return append([]byte(nil), data...), end, nil
+	default:
+		return nil, 0, fmt.Errorf("不支持的数据类型: %d", typeNum)
+	}
+}
+
+// decodeControl 解码控制字节，返回类型、大小和数据起始偏移
+// 指针类型返回的size为控制字节的低5位，由 decodePointer 解释
+func (d *decoder) decodeControl(offset uint) (uint, uint, uint, error) {
+	if offset >= uint(len(d.buffer)) {
+		return 0, 0, 0, fmt.Errorf("数据越界")
+	}
+	control := d.buffer[offset]
+	offset++
+
+	typeNum := uint(control >> 5)
+	if typeNum == dataTypePointer {
+		return typeNum, uint(control & 0x1f), offset, nil
+	}
+	if typeNum == dataTypeExtended {
+		if offset >= uint(len(d.buffer)) {
+			return 0, 0, 0, fmt.Errorf("数据越界")
+		}
+		typeNum = 7 + uint(d.buffer[offset])
+		offset++
+		if typeNum <= dataTypeMap || typeNum == dataTypeContainer || typeNum == dataTypeEndMarker || typeNum > dataTypeFloat {
+			return 0, 0, 0, fmt.Errorf("无效的扩展数据类型: %d", typeNum)
+		}
+	}
+
+	size := uint(control & 0x1f)
+	if size < 29 {
+		return typeNum, size, offset, nil
+	}
+	extraBytes := size - 28
+	if offset+extraBytes > uint(len(d.buffer)) {
+		return 0, 0, 0, fmt.Errorf("数据越界")
+	}
+	var extra uint
+	for _, b := range d.buffer[offset : offset+extraBytes] {
+		extra = extra<<8 | uint(b)
+	}
+	offset += extraBytes
+	switch size {
+	case 29:
+		size = 29 + extra
+	case 30:
+		size = 285 + extra
+	default:
+		size = 65821 + extra
+	}
+	return typeNum, size, offset, nil
+}
+
+// decodePointer 解码指针，返回指向的数据段偏移和指针之后的偏移
+func (d *decoder) decodePointer(control uint, offset uint) (uint, uint, error) {
+	pointerSize := (control >> 3) & 0x3
+	byteCount := pointerSize + 1
+	if offset+byteCount > uint(len(d.buffer)) {
+		return 0, 0, fmt.Errorf("数据越界")
+	}
+
+	var pointer uint
+	if pointerSize != 3 {
+		pointer = control & 0x7
+	}
+	for _, b := range d.buffer[offset : offset+byteCount] {
+		pointer = pointer<<8 | uint(b)
+	}
+	switch pointerSize {
+	case 1:
+		pointer += 2048
+	case 2:
+		pointer += 526336
+	}
+	return pointer, offset + byteCount, nil
+}
@@ -0,0 +1,342 @@
+package huberrors
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime"
+	"sync"
+	"syscall"
+
+	"gateway/pkg/utils/mask"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Code 错误码，描述错误的性质，与具体消息无关
+type Code string
+
+// Category 错误分类
+type Category string
+
+const (
+	CategoryClient    Category = "client"    // 客户端错误，请求本身有问题，重试无意义
+	CategoryServer    Category = "server"    // 服务端错误，需要修复后才能成功
+	CategoryTransient Category = "transient" // 暂时性错误，稍后重试可能成功
+)
+
+// 错误码定义
+const (
+	CodeInvalidArgument    Code = "INVALID_ARGUMENT"    // 参数错误
+	CodeUnauthenticated    Code = "UNAUTHENTICATED"     // 未认证
+	CodePermissionDenied   Code = "PERMISSION_DENIED"   // 无权限
+	CodeNotFound           Code = "NOT_FOUND"           // 资源不存在
+	CodeAlreadyExists      Code = "ALREADY_EXISTS"      // 资源已存在
+	CodeConflict           Code = "CONFLICT"            // 并发修改冲突，如版本号不一致
+	CodeFailedPrecondition Code = "FAILED_PRECONDITION" // 当前状态不允许该操作
+	CodeCanceled           Code = "CANCELED"            // 请求被调用方取消
+	CodeRateLimited        Code = "RATE_LIMITED"        // 请求过多被限流
+	CodeTimeout            Code = "TIMEOUT"             // 处理超时
+	CodeUnavailable        Code = "UNAVAILABLE"         // 依赖服务暂时不可用，如连接失败
+	CodeUnimplemented      Code = "UNIMPLEMENTED"       // 功能未实现
+	CodeInternal           Code = "INTERNAL"            // 内部错误
+	CodeUnknown            Code = "UNKNOWN"             // 无法识别的错误
+)
+
+// codeInfo 错误码对应的分类、HTTP状态码和gRPC状态码
+type codeInfo struct {
+	category   Category
+	httpStatus int
+	grpcCode   codes.Code
+}
+
+// codeTable 错误码映射表
+var codeTable = map[Code]codeInfo{
+	CodeInvalidArgument:    {CategoryClient, http.StatusBadRequest, codes.InvalidArgument},
+	CodeUnauthenticated:    {CategoryClient, http.StatusUnauthorized, codes.Unauthenticated},
+	CodePermissionDenied:   {CategoryClient, http.StatusForbidden, codes.PermissionDenied},
+	CodeNotFound:           {CategoryClient, http.StatusNotFound, codes.NotFound},
+	CodeAlreadyExists:      {CategoryClient, http.StatusConflict, codes.AlreadyExists},
+	CodeConflict:           {CategoryClient, http.StatusConflict, codes.Aborted},
+	CodeFailedPrecondition: {CategoryClient, http.StatusBadRequest, codes.FailedPrecondition},
+	CodeCanceled:           {CategoryClient, 499, codes.Canceled},
+	CodeRateLimited:        {CategoryTransient, http.StatusTooManyRequests, codes.ResourceExhausted},
+	CodeTimeout:            {CategoryTransient, http.StatusGatewayTimeout, codes.DeadlineExceeded},
+	CodeUnavailable:        {CategoryTransient, http.StatusServiceUnavailable, codes.Unavailable},
+	CodeUnimplemented:      {CategoryServer, http.StatusNotImplemented, codes.Unimplemented},
+	CodeInternal:           {CategoryServer, http.StatusInternalServerError, codes.Internal},
+	CodeUnknown:            {CategoryServer, http.StatusInternalServerError, codes.Unknown},
+}
+
+// grpcCodeTable gRPC状态码对应的错误码，用于识别下游gRPC服务返回的错误
+var grpcCodeTable = map[codes.Code]Code{
+	codes.InvalidArgument:    CodeInvalidArgument,
+	codes.OutOfRange:         CodeInvalidArgument,
+	codes.Unauthenticated:    CodeUnauthenticated,
+	codes.PermissionDenied:   CodePermissionDenied,
+	codes.NotFound:           CodeNotFound,
+	codes.AlreadyExists:      CodeAlreadyExists,
+	codes.Aborted:            CodeConflict,
+	codes.FailedPrecondition: CodeFailedPrecondition,
+	codes.Canceled:           CodeCanceled,
+	codes.ResourceExhausted:  CodeRateLimited,
+	codes.DeadlineExceeded:   CodeTimeout,
+	codes.Unavailable:        CodeUnavailable,
+	codes.Unimplemented:      CodeUnimplemented,
+	codes.Internal:           CodeInternal,
+	codes.DataLoss:           CodeInternal,
+}
+
+// info 获取错误码的映射信息，未定义的错误码按 CodeUnknown 处理
+func (c Code) info() codeInfo {
+	if info, ok := codeTable[c]; ok {
+		return info
+	}
+	return codeTable[CodeUnknown]
+}
+
+// Category 错误码的分类
+func (c Code) Category() Category { return c.info().category }
+
+// HTTPStatus 错误码对应的HTTP状态码
+func (c Code) HTTPStatus() int { return c.info().httpStatus }
+
+// GRPCCode 错误码对应的gRPC状态码
+func (c Code) GRPCCode() codes.Code { return c.info().grpcCode }
+
+// 通用错误码哨兵，配合 errors.Is 判断错误是否带有指定错误码，如 errors.Is(err, huberrors.ErrNotFound)
+// 只匹配通过 NewCodeError、WrapCodeError、Sentinel 带上错误码的错误；第三方错误（如 sql.ErrNoRows）请使用 Is
+var (
+	ErrInvalidArgument    = codeSentinel(CodeInvalidArgument)
+	ErrUnauthenticated    = codeSentinel(CodeUnauthenticated)
+	ErrPermissionDenied   = codeSentinel(CodePermissionDenied)
+	ErrNotFound           = codeSentinel(CodeNotFound)
+	ErrAlreadyExists      = codeSentinel(CodeAlreadyExists)
+	ErrConflict           = codeSentinel(CodeConflict)
+	ErrFailedPrecondition = codeSentinel(CodeFailedPrecondition)
+	ErrTimeout            = codeSentinel(CodeTimeout)
+	ErrUnavailable        = codeSentinel(CodeUnavailable)
+	ErrInternal           = codeSentinel(CodeInternal)
+)
+
+// codeSentinel 创建只按错误码匹配的哨兵错误
+func codeSentinel(code Code) *HubError {
+	return &HubError{Message: string(code), Code: code, codeOnly: true}
+}
+
+// Is 支持 errors.Is 按错误码匹配通用错误码哨兵
+func (e *HubError) Is(target error) bool {
+	t, ok := target.(*HubError)
+	return ok && t.codeOnly && e.Code != "" && e.Code == t.Code
+}
+
+// GRPCStatus 返回错误对应的gRPC状态，gRPC服务直接返回 HubError 时自动转换为对应状态码
+func (e *HubError) GRPCStatus() *status.Status {
+	return status.New(CodeOf(e).GRPCCode(), e.Message)
+}
+
+// Sentinel 创建带错误码的包级错误变量，不记录位置和调用栈
+// 用于替代 errors.New 定义的哨兵错误，如 database.ErrRecordNotFound
+func Sentinel(code Code, msg string) error {
+	return &HubError{Message: msg, Code: code}
+}
+
+// NewCodeError 创建带错误码、位置信息和调用栈的错误
+func NewCodeError(code Code, msg string, args ...interface{}) error {
+	return newHubError(code, nil, msg, args...)
+}
+
+// WrapCodeError 包装现有错误并指定错误码，添加位置信息和调用栈
+func WrapCodeError(err error, code Code, msg string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return newHubError(code, err, msg, args...)
+}
+
+// newHubError 创建 HubError，位置信息取 NewCodeError/WrapCodeError 的调用者
+func newHubError(code Code, err error, msg string, args ...interface{}) error {
+	pc, file, line, _ := runtime.Caller(2)
+	fn := runtime.FuncForPC(pc)
+
+	// 参数可能包含配置中的密码等敏感信息，格式化后统一脱敏
+	formattedMsg := mask.Sanitize(fmt.Sprintf(msg, args...))
+
+	return &HubError{
+		Message:  formattedMsg,
+		File:     file,
+		Line:     line,
+		Function: fn.Name(),
+		Err:      err,
+		Code:     code,
+		Stack:    captureStack(3), // 跳过newHubError及其调用的构造函数
+	}
+}
+
+// Classifier 错误识别函数，为第三方库的错误确定错误码
+type Classifier func(err error) (Code, bool)
+
+var (
+	classifierMu sync.RWMutex
+	classifiers  []Classifier
+)
+
+// RegisterClassifier 注册错误识别函数，用于识别驱动等第三方库特有的错误
+// 识别时先使用注册的函数，再使用内置规则
+func RegisterClassifier(classifier Classifier) {
+	classifierMu.Lock()
+	defer classifierMu.Unlock()
+	classifiers = append(classifiers, classifier)
+}
+
+// classify 识别单个错误（不展开原因链）的错误码
+func classify(err error) (Code, bool) {
+	if hubErr, ok := err.(*HubError); ok {
+		return hubErr.Code, hubErr.Code != ""
+	}
+
+	classifierMu.RLock()
+	registered := classifiers
+	classifierMu.RUnlock()
+	for _, classifier := range registered {
+		if code, ok := classifier(err); ok {
+			return code, true
+		}
+	}
+
+	switch err {
+	case sql.ErrNoRows:
+		return CodeNotFound, true
+	case context.DeadlineExceeded:
+		return CodeTimeout, true
+	case context.Canceled:
+		return CodeCanceled, true
+	case syscall.ECONNREFUSED, syscall.ECONNRESET, syscall.ECONNABORTED, syscall.EPIPE, syscall.EHOSTUNREACH, syscall.ENETUNREACH:
+		return CodeUnavailable, true
+	}
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return CodeTimeout, true
+	}
+	if grpcErr, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		if code, ok := grpcCodeTable[grpcErr.GRPCStatus().Code()]; ok {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+// walk 按原因链（包括 errors.Join 等多个原因）依次访问错误，fn 返回true时停止
+func walk(err error, fn func(error) bool) bool {
+	if err == nil {
+		return false
+	}
+	if fn(err) {
+		return true
+	}
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return walk(e.Unwrap(), fn)
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			if walk(inner, fn) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CodeOf 获取错误的错误码
+// 沿原因链查找，最外层带错误码的错误优先；都没有错误码时按注册的识别函数和内置规则识别
+// （sql.ErrNoRows、context超时和取消、网络超时、连接被拒绝、gRPC状态等）
+// 参数:
+//
+//	err: 错误
+//
+// 返回:
+//
+//	Code: 错误码，err为nil时返回空字符串，无法识别时返回 CodeUnknown
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	code := CodeUnknown
+	walk(err, func(e error) bool {
+		if c, ok := classify(e); ok {
+			code = c
+			return true
+		}
+		return false
+	})
+	return code
+}
+
+// Is 判断错误的原因链中是否有指定错误码的错误
+// 与 CodeOf 不同，外层错误码不会掩盖内层错误码，如包装为 CodeInternal 的“记录不存在”仍可判断为 CodeNotFound
+func Is(err error, code Code) bool {
+	return walk(err, func(e error) bool {
+		c, ok := classify(e)
+		return ok && c == code
+	})
+}
+
+// As 获取原因链中最外层的 HubError
+func As(err error) (*HubError, bool) {
+	var hubErr *HubError
+	if errors.As(err, &hubErr) {
+		return hubErr, true
+	}
+	return nil, false
+}
+
+// CategoryOf 获取错误的分类
+func CategoryOf(err error) Category {
+	return CodeOf(err).Category()
+}
+
+// IsRetryable 判断错误是否为暂时性错误，重试可能成功
+func IsRetryable(err error) bool {
+	return err != nil && CategoryOf(err) == CategoryTransient
+}
+
+// IsClientError 判断错误是否由请求本身引起
+func IsClientError(err error) bool {
+	return err != nil && CategoryOf(err) == CategoryClient
+}
+
+// IsNotFound 判断错误是否为资源不存在，包括 sql.ErrNoRows 和 database.ErrRecordNotFound
+func IsNotFound(err error) bool {
+	return Is(err, CodeNotFound)
+}
+
+// HTTPStatus 获取错误对应的HTTP状态码，err为nil时返回200
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	return CodeOf(err).HTTPStatus()
+}
+
+// GRPCStatus 获取错误对应的gRPC状态，err为nil时返回nil
+func GRPCStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+	return status.New(CodeOf(err).GRPCCode(), Message(err))
+}
+
+// Message 获取可展示给调用方的错误消息
+// 返回最外层 HubError 的消息（不含位置信息），其他错误返回脱敏后的错误信息
+func Message(err error) string {
+	if err == nil {
+		return ""
+	}
+	if hubErr, ok := As(err); ok && !hubErr.codeOnly {
+		return hubErr.Message
+	}
+	return mask.Sanitize(err.Error())
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"runtime"
 	"strings"
+
+	"gateway/pkg/utils/mask"
 )
 
 // HubError 带有位置和堆栈信息的错误类型
@@ -15,6 +17,9 @@ type HubError struct {
 	Function string  // 发生错误的函数
 	Err      error   // 原始错误
 	Stack    []Frame // 错误发生时的调用栈
+	Code     Code    // 错误码，为空时按原因链识别，见 CodeOf
+
+	codeOnly bool // 通用错误码哨兵，errors.Is 只比较错误码
 }
 
 // Frame 代表调用栈中的一帧
@@ -37,7 +42,8 @@ func (e *HubError) Error() string {
 
 	// 添加原始错误信息
 	if e.Err != nil {
-		result.WriteString(fmt.Sprintf("\n原因: %v", e.Err))
+		// 原始错误可能来自驱动等第三方库，消息中含DSN等敏感信息，需脱敏
+		result.WriteString("\n原因: " + causeMessage(e.Err))
 	}
 
 	return result.String()
@@ -82,13 +88,22 @@ func (e *HubError) FullError() string {
 			}
 		} else {
 			// 普通错误直接显示
-			result.WriteString("  " + e.Err.Error() + "\n")
+			result.WriteString("  " + causeMessage(e.Err) + "\n")
 		}
 	}
 
 	return result.String()
 }
 
+// causeMessage 获取原因错误的信息，非HubError的错误信息先脱敏
+// HubError 的消息在创建时已脱敏
+func causeMessage(err error) string {
+	if _, ok := err.(*HubError); ok {
+		return err.Error()
+	}
+	return mask.Sanitize(err.Error())
+}
+
 // 收集当前调用栈
 func captureStack(skip int) []Frame {
 	const depth = 32
@@ -122,7 +137,8 @@ func NewError(msg string, args ...interface{}) error {
 	pc, file, line, _ := runtime.Caller(1)
 	fn := runtime.FuncForPC(pc)
 
-	formattedMsg := fmt.Sprintf(msg, args...)
+	// 参数可能包含配置中的密码等敏感信息，格式化后统一脱敏
+	formattedMsg := mask.Sanitize(fmt.Sprintf(msg, args...))
 
 	return &HubError{
 		Message:  formattedMsg,
@@ -143,7 +159,8 @@ func WrapError(err error, msg string, args ...interface{}) error {
 	pc, file, line, _ := runtime.Caller(1)
 	fn := runtime.FuncForPC(pc)
 
-	formattedMsg := fmt.Sprintf(msg, args...)
+	// 参数可能包含配置中的密码等敏感信息，格式化后统一脱敏
+	formattedMsg := mask.Sanitize(fmt.Sprintf(msg, args...))
 
 	return &HubError{
 		Message:  formattedMsg,
@@ -174,7 +191,7 @@ func ErrorStack(err error) string {
 	}
 
 	// 兜底返回
-	return err.Error()
+	return mask.Sanitize(err.Error())
 }
 
 // Location 返回错误发生的位置信息
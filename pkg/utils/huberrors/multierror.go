@@ -0,0 +1,168 @@
+package huberrors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiError 多个错误的集合
+// 用于停止、清理等需要继续处理其余组件、最后汇总报告所有错误的场景，而不是只返回第一个错误
+// 实现 Unwrap() []error，errors.Is/As 和 CodeOf 会依次检查其中的每个错误
+type MultiError struct {
+	Message string  // 汇总说明，如"部分服务中心实例停止失败"，可为空
+	Errors  []error // 收集到的错误，按发生顺序
+}
+
+// NewMultiError 创建带汇总说明的错误集合
+// 参数:
+//
+//	msg: 汇总说明，支持格式化
+//	args: 格式化参数
+//
+// 返回:
+//
+//	*MultiError: 空的错误集合，收集完成后调用 ErrorOrNil 返回
+func NewMultiError(msg string, args ...interface{}) *MultiError {
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	return &MultiError{Message: msg}
+}
+
+// Append 把错误追加到错误集合
+// err 为 *MultiError 时直接追加到该集合并返回它，否则创建新集合并把 err 作为第一个错误；
+// nil 错误被忽略，没有汇总说明的 *MultiError 会被展开，避免多层嵌套
+// 参数:
+//
+//	err: 已有的错误集合或普通错误，可为nil
+//	errs: 要追加的错误
+//
+// 返回:
+//
+//	*MultiError: 追加后的错误集合，不为nil
+func Append(err error, errs ...error) *MultiError {
+	m, ok := err.(*MultiError)
+	if !ok || m == nil {
+		m = &MultiError{}
+		if err != nil {
+			m.add(err)
+		}
+	}
+	for _, e := range errs {
+		m.add(e)
+	}
+	return m
+}
+
+// add 追加单个错误
+func (m *MultiError) add(err error) {
+	if err == nil {
+		return
+	}
+	if inner, ok := err.(*MultiError); ok {
+		if inner == nil {
+			return
+		}
+		if inner.Message == "" {
+			for _, e := range inner.Errors {
+				m.add(e)
+			}
+			return
+		}
+	}
+	m.Errors = append(m.Errors, err)
+}
+
+// Len 错误数量
+func (m *MultiError) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.Errors)
+}
+
+// ErrorOrNil 没有收集到错误时返回nil，否则返回错误集合本身
+// 函数返回 error 时应使用该方法，避免返回非nil接口包装的空集合
+func (m *MultiError) ErrorOrNil() error {
+	if m.Len() == 0 {
+		return nil
+	}
+	return m
+}
+
+// Unwrap 返回所有错误，支持 errors.Is/As
+func (m *MultiError) Unwrap() []error {
+	if m == nil {
+		return nil
+	}
+	return m.Errors
+}
+
+// Error 实现error接口
+// 只有一个错误时返回该错误的信息，多个错误时逐行列出
+func (m *MultiError) Error() string {
+	var result strings.Builder
+	if m.Message != "" {
+		result.WriteString(m.Message)
+		if m.Len() > 0 {
+			result.WriteString(": ")
+		}
+	}
+
+	switch m.Len() {
+	case 0:
+	case 1:
+		result.WriteString(causeMessage(m.Errors[0]))
+	default:
+		result.WriteString(fmt.Sprintf("共 %d 个错误", len(m.Errors)))
+		for _, err := range m.Errors {
+			result.WriteString("\n  * " + indent(causeMessage(err), "    "))
+		}
+	}
+	return result.String()
+}
+
+// Format 实现 fmt.Formatter
+// %v、%s 输出 Error()，%+v 输出每个错误的完整信息（HubError 包含调用栈），%q 输出带引号的 Error()
+func (m *MultiError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			_, _ = fmt.Fprint(s, m.fullError())
+			return
+		}
+		_, _ = fmt.Fprint(s, m.Error())
+	case 's':
+		_, _ = fmt.Fprint(s, m.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", m.Error())
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(*huberrors.MultiError=%s)", verb, m.Error())
+	}
+}
+
+// fullError 逐个输出错误的完整信息
+func (m *MultiError) fullError() string {
+	var result strings.Builder
+	if m.Message != "" {
+		result.WriteString(m.Message + "\n")
+	}
+	for i, err := range m.Errors {
+		var detail string
+		switch e := err.(type) {
+		case *HubError:
+			detail = strings.TrimRight(e.FullError(), "\n")
+		case *MultiError:
+			detail = e.fullError()
+		default:
+			detail = causeMessage(err)
+		}
+		result.WriteString(fmt.Sprintf("[%d/%d] %s\n", i+1, len(m.Errors), indent(detail, "  ")))
+	}
+	return strings.TrimRight(result.String(), "\n")
+}
+
+// indent 缩进多行文本除第一行外的每一行
+func indent(text, prefix string) string {
+	return strings.ReplaceAll(text, "\n", "\n"+prefix)
+}
@@ -107,6 +107,9 @@ SETTINGS
     -- 设置索引颗粒度
     index_granularity = 8192;
 
+-- 限流排队等待时间：使用独立ALTER语句，避免修改历史建表语句，保证已有表可以增量升级
+ALTER TABLE HUB_GW_ACCESS_LOG ADD COLUMN IF NOT EXISTS `queueTimeMs` Nullable(Int32) COMMENT '限流排队等待时间(毫秒，已计入网关处理时间)' AFTER `backendResponseTimeMs`;
+
 -- ============================================================================
 -- 重要说明
 -- ============================================================================
@@ -122,6 +125,17 @@ SETTINGS
 --    - 利用分区特性，查询条件包含时间范围
 -- 4. 实时查询：所有统计都是实时计算，无需预聚合，ClickHouse性能足够支撑
 
+-- ============================================================================
+-- 访问日志 Buffer 表 - HUB_GW_ACCESS_LOG_BUFFER（可选）
+-- 说明：
+--   1. 数据库连接配置 clickhouse_insert_mode: "buffer" 时，写入 HUB_GW_ACCESS_LOG 的数据改写入此表
+--   2. Buffer 表在内存中合并写入，满足任一最大阈值或全部最小阈值时刷新到 HUB_GW_ACCESS_LOG
+--   3. 参数：Buffer(数据库, 目标表, 分片数, 最短/最长刷新秒数, 最少/最多行数, 最少/最多字节数)
+--   4. 服务端异常重启时 Buffer 表中未刷新的数据会丢失，对可靠性要求高时使用 async 写入模式
+-- ============================================================================
+-- CREATE TABLE HUB_GW_ACCESS_LOG_BUFFER AS HUB_GW_ACCESS_LOG
+-- ENGINE = Buffer(currentDatabase(), HUB_GW_ACCESS_LOG, 16, 5, 30, 10000, 1000000, 10000000, 100000000);
+
 -- ============================================================================
 -- 后端追踪日志表 - HUB_GW_BACKEND_TRACE_LOG
 -- 对应MySQL版本：scripts/db/mysql.sql 第1271-1335行
@@ -231,6 +231,19 @@ INSERT INTO `HUB_AUTH_RESOURCE` (
   NOW(), 'system', NOW(), 'system', 'INIT_007', 1, 'Y'
 );
 
+-- 操作审计模块 (hub0009) - 属于 group0001
+INSERT INTO `HUB_AUTH_RESOURCE` (
+  `resourceId`, `tenantId`, `resourceName`, `resourceCode`, `resourceType`,
+  `resourcePath`, `parentResourceId`, `resourceLevel`, `sortOrder`, `iconClass`, `language`,
+  `resourceStatus`, `builtInFlag`,
+  `addTime`, `addWho`, `editTime`, `editWho`, `oprSeqFlag`, `currentVersion`, `activeFlag`
+) VALUES (
+  'hub0009', 'default', '操作审计', 'hub0009', 'MODULE',
+  '/system/auditLogManagement', 'group0001', 2, 6, 'DocumentTextOutline', 'zh-CN',
+  'Y', 'Y',
+  NOW(), 'system', NOW(), 'system', 'INIT_008', 1, 'Y'
+);
+
 -- 网关实例管理模块 (hub0020) - 属于 group0020
 INSERT INTO `HUB_AUTH_RESOURCE` (
   `resourceId`, `tenantId`, `resourceName`, `resourceCode`, `resourceType`,
@@ -3979,3 +3992,115 @@ INSERT INTO `HUB_AUTH_RESOURCE` (
   NOW(), 'system', NOW(), 'system', 'INIT_042_004', 1, 'Y'
 );
 
+-- =====================================================
+-- 接口资源 - 无菜单的管理端接口模块，用于路由权限校验
+-- 管理端接口按 /gateway/{模块编码}/ 校验模块资源，未定义资源的模块拒绝访问
+-- =====================================================
+
+-- 定时任务接口 (hub0003)
+INSERT INTO `HUB_AUTH_RESOURCE` (
+  `resourceId`, `tenantId`, `resourceName`, `resourceCode`, `resourceType`,
+  `resourceLevel`, `sortOrder`, `description`, `language`,
+  `resourceStatus`, `builtInFlag`,
+  `addTime`, `addWho`, `editTime`, `editWho`, `oprSeqFlag`, `currentVersion`, `activeFlag`
+) VALUES (
+  'hub0003', 'default', '定时任务接口', 'hub0003', 'API',
+  1, 1, '定时任务调度器、任务和执行日志接口', 'zh-CN',
+  'Y', 'Y',
+  NOW(), 'system', NOW(), 'system', 'INIT_API_001', 1, 'Y'
+);
+
+-- 公共安全配置接口 (hubcommon002)
+INSERT INTO `HUB_AUTH_RESOURCE` (
+  `resourceId`, `tenantId`, `resourceName`, `resourceCode`, `resourceType`,
+  `resourceLevel`, `sortOrder`, `description`, `language`,
+  `resourceStatus`, `builtInFlag`,
+  `addTime`, `addWho`, `editTime`, `editWho`, `oprSeqFlag`, `currentVersion`, `activeFlag`
+) VALUES (
+  'hubcommon002', 'default', '公共安全配置接口', 'hubcommon002', 'API',
+  1, 2, '网关实例和路由共用的安全配置接口（IP/UA/API/域名访问控制、CORS、认证、限流）', 'zh-CN',
+  'Y', 'Y',
+  NOW(), 'system', NOW(), 'system', 'INIT_API_002', 1, 'Y'
+);
+
+-- 插件接口 (hubplugin)
+INSERT INTO `HUB_AUTH_RESOURCE` (
+  `resourceId`, `tenantId`, `resourceName`, `resourceCode`, `resourceType`,
+  `resourceLevel`, `sortOrder`, `description`, `language`,
+  `resourceStatus`, `builtInFlag`,
+  `addTime`, `addWho`, `editTime`, `editWho`, `oprSeqFlag`, `currentVersion`, `activeFlag`
+) VALUES (
+  'hubplugin', 'default', '插件接口', 'hubplugin', 'API',
+  1, 3, '插件管理接口（HTTP调试等）', 'zh-CN',
+  'Y', 'Y',
+  NOW(), 'system', NOW(), 'system', 'INIT_API_003', 1, 'Y'
+);
+
+-- =====================================================
+-- 路由权限按钮资源 - 接口名无法推导出独立按钮编码的写操作
+-- =====================================================
+
+-- 定时任务接口 - 新增
+INSERT INTO `HUB_AUTH_RESOURCE` (
+  `resourceId`, `tenantId`, `resourceName`, `resourceCode`, `resourceType`,
+  `parentResourceId`, `resourceLevel`, `sortOrder`, `language`,
+  `resourceStatus`, `builtInFlag`,
+  `addTime`, `addWho`, `editTime`, `editWho`, `oprSeqFlag`, `currentVersion`, `activeFlag`
+) VALUES (
+  'hub0003:add', 'default', '新增', 'hub0003:add', 'BUTTON',
+  'hub0003', 2, 1, 'zh-CN',
+  'Y', 'Y',
+  NOW(), 'system', NOW(), 'system', 'INIT_API_001_001', 1, 'Y'
+);
+
+-- 定时任务接口 - 编辑（含启动、停止、暂停、恢复和立即执行）
+INSERT INTO `HUB_AUTH_RESOURCE` (
+  `resourceId`, `tenantId`, `resourceName`, `resourceCode`, `resourceType`,
+  `parentResourceId`, `resourceLevel`, `sortOrder`, `language`,
+  `resourceStatus`, `builtInFlag`,
+  `addTime`, `addWho`, `editTime`, `editWho`, `oprSeqFlag`, `currentVersion`, `activeFlag`
+) VALUES (
+  'hub0003:edit', 'default', '编辑', 'hub0003:edit', 'BUTTON',
+  'hub0003', 2, 2, 'zh-CN',
+  'Y', 'Y',
+  NOW(), 'system', NOW(), 'system', 'INIT_API_001_002', 1, 'Y'
+);
+
+-- 定时任务接口 - 删除
+INSERT INTO `HUB_AUTH_RESOURCE` (
+  `resourceId`, `tenantId`, `resourceName`, `resourceCode`, `resourceType`,
+  `parentResourceId`, `resourceLevel`, `sortOrder`, `language`,
+  `resourceStatus`, `builtInFlag`,
+  `addTime`, `addWho`, `editTime`, `editWho`, `oprSeqFlag`, `currentVersion`, `activeFlag`
+) VALUES (
+  'hub0003:delete', 'default', '删除', 'hub0003:delete', 'BUTTON',
+  'hub0003', 2, 3, 'zh-CN',
+  'Y', 'Y',
+  NOW(), 'system', NOW(), 'system', 'INIT_API_001_003', 1, 'Y'
+);
+
+-- 网关日志管理 - 删除分区按钮
+INSERT INTO `HUB_AUTH_RESOURCE` (
+  `resourceId`, `tenantId`, `resourceName`, `resourceCode`, `resourceType`,
+  `parentResourceId`, `resourceLevel`, `sortOrder`, `language`,
+  `resourceStatus`, `builtInFlag`,
+  `addTime`, `addWho`, `editTime`, `editWho`, `oprSeqFlag`, `currentVersion`, `activeFlag`
+) VALUES (
+  'hub0023:dropPartition', 'default', '删除分区', 'hub0023:dropPartition', 'BUTTON',
+  'hub0023', 3, 7, 'zh-CN',
+  'Y', 'Y',
+  NOW(), 'system', NOW(), 'system', 'INIT_013_007', 1, 'Y'
+);
+
+-- 网关日志管理 - 日志重放按钮（启动和取消重放）
+INSERT INTO `HUB_AUTH_RESOURCE` (
+  `resourceId`, `tenantId`, `resourceName`, `resourceCode`, `resourceType`,
+  `parentResourceId`, `resourceLevel`, `sortOrder`, `language`,
+  `resourceStatus`, `builtInFlag`,
+  `addTime`, `addWho`, `editTime`, `editWho`, `oprSeqFlag`, `currentVersion`, `activeFlag`
+) VALUES (
+  'hub0023:replay', 'default', '日志重放', 'hub0023:replay', 'BUTTON',
+  'hub0023', 3, 8, 'zh-CN',
+  'Y', 'Y',
+  NOW(), 'system', NOW(), 'system', 'INIT_013_008', 1, 'Y'
+);
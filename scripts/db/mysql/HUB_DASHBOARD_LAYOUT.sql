@@ -0,0 +1,30 @@
+CREATE TABLE `HUB_DASHBOARD_LAYOUT` (
+  -- 主键和租户
+  `tenantId` VARCHAR(32) NOT NULL COMMENT '租户ID，主键',
+  `layoutId` VARCHAR(32) NOT NULL COMMENT '布局ID，主键，可通过布局ID分享',
+  
+  -- 布局信息
+  `layoutName` VARCHAR(200) NOT NULL COMMENT '布局名称',
+  `dashboardCode` VARCHAR(50) NOT NULL COMMENT '看板编码，区分不同的监控页面',
+  `layoutConfig` TEXT DEFAULT NULL COMMENT '网格布局，JSON格式，记录各组件的位置和尺寸',
+  `widgetConfig` TEXT DEFAULT NULL COMMENT '组件配置，JSON数组，记录各组件的指标、时间范围和刷新间隔',
+  `timeRangeMinutes` INT NOT NULL DEFAULT 60 COMMENT '默认时间范围（分钟），组件未单独配置时使用',
+  `refreshIntervalSeconds` INT NOT NULL DEFAULT 0 COMMENT '默认刷新间隔（秒），0表示不自动刷新',
+  `ownerUserId` VARCHAR(32) NOT NULL COMMENT '所属用户ID',
+  `shareFlag` VARCHAR(1) NOT NULL DEFAULT 'N' COMMENT '是否共享给租户内其他用户：Y是，N否',
+  `defaultFlag` VARCHAR(1) NOT NULL DEFAULT 'N' COMMENT '是否为所属用户在该看板的默认布局：Y是，N否',
+  
+  -- 通用字段
+  `addTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `addWho` VARCHAR(32) NOT NULL COMMENT '创建人ID',
+  `editTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后修改时间',
+  `editWho` VARCHAR(32) NOT NULL COMMENT '最后修改人ID',
+  `oprSeqFlag` VARCHAR(32) NOT NULL COMMENT '操作序列标识',
+  `currentVersion` INT NOT NULL DEFAULT 1 COMMENT '当前版本号',
+  `activeFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '活动状态标记：N非活动，Y活动',
+  `noteText` VARCHAR(500) DEFAULT NULL COMMENT '备注信息',
+  
+  -- 主键和索引
+  PRIMARY KEY (`tenantId`, `layoutId`),
+  INDEX `IDX_DASHBOARD_LAYOUT_OWNER` (`tenantId`, `ownerUserId`, `dashboardCode`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='看板布局表 - 存储用户的监控看板布局和组件配置';
@@ -99,3 +99,7 @@ CREATE TABLE `HUB_GW_ACCESS_LOG` (
   INDEX `idx_HUB_GW_ACCESS_LOG_status_time` (`gatewayStatusCode`, `gatewayStartProcessingTime`),
   INDEX `idx_HUB_GW_ACCESS_LOG_proxy_type` (`proxyType`, `gatewayStartProcessingTime`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='网关访问日志表 - 记录API网关的请求和响应详细信息,开始时间必填,完成时间可选(支持处理中状态),含冗余字段优化查询性能';
+
+-- 限流排队等待时间：使用独立ALTER语句，避免修改历史建表语句，保证已有表可以增量升级
+ALTER TABLE `HUB_GW_ACCESS_LOG`
+  ADD COLUMN `queueTimeMs` INT DEFAULT NULL COMMENT '限流排队等待时间(毫秒，已计入网关处理时间)' AFTER `backendResponseTimeMs`;
@@ -0,0 +1,21 @@
+CREATE TABLE `HUB_GW_CONFIG_VERSION` (
+  `tenantId` VARCHAR(32) NOT NULL COMMENT '租户ID',
+  `gatewayInstanceId` VARCHAR(32) NOT NULL COMMENT '网关实例ID',
+  `configVersion` VARCHAR(32) NOT NULL COMMENT '配置版本号(配置内容摘要)',
+
+  -- 版本内容
+  `configData` LONGTEXT NOT NULL COMMENT '网关完整配置,JSON格式,使用默认密钥加密存储',
+  `operator` VARCHAR(32) DEFAULT NULL COMMENT '发布人',
+  `appliedTime` DATETIME NOT NULL COMMENT '生效时间,同一版本重复发布时刷新',
+  `appliedSeq` BIGINT NOT NULL DEFAULT 0 COMMENT '生效序号(纳秒时间戳),版本历史按该字段排序',
+
+  `addTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `addWho` VARCHAR(32) NOT NULL COMMENT '创建人ID',
+  `editTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后修改时间',
+  `editWho` VARCHAR(32) NOT NULL COMMENT '最后修改人ID',
+  `oprSeqFlag` VARCHAR(32) NOT NULL COMMENT '操作序列标识',
+  `currentVersion` INT NOT NULL DEFAULT 1 COMMENT '当前版本号',
+  `activeFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '活动状态标记(N非活动,Y活动)',
+  PRIMARY KEY (`tenantId`, `gatewayInstanceId`, `configVersion`),
+  INDEX `IDX_GW_CFGVER_SEQ` (`tenantId`, `gatewayInstanceId`, `appliedSeq`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='网关配置版本表 - 记录实例已生效的配置版本历史,用于回滚';
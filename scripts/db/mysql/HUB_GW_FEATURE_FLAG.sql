@@ -0,0 +1,30 @@
+CREATE TABLE `HUB_GW_FEATURE_FLAG` (
+  `tenantId` VARCHAR(32) NOT NULL COMMENT '租户ID',
+  `featureFlagId` VARCHAR(32) NOT NULL COMMENT '功能开关ID',
+  `gatewayInstanceId` VARCHAR(32) DEFAULT NULL COMMENT '网关实例ID(为空表示租户下全部实例)',
+  `routeConfigId` VARCHAR(32) DEFAULT NULL COMMENT '路由配置ID(为空表示实例下全部路由)',
+  `flagKey` VARCHAR(100) NOT NULL COMMENT '开关标识，过滤器通过该标识查询开关',
+  `flagName` VARCHAR(100) NOT NULL COMMENT '开关名称',
+  `flagDesc` VARCHAR(200) DEFAULT NULL COMMENT '开关描述',
+
+  -- 开关规则
+  `flagEnabled` VARCHAR(1) NOT NULL DEFAULT 'N' COMMENT '是否开启(N关闭,Y开启)',
+  `rolloutPercent` INT NOT NULL DEFAULT 100 COMMENT '放量百分比(0-100)',
+  `bucketBy` VARCHAR(20) NOT NULL DEFAULT 'CLIENT_IP' COMMENT '放量分桶依据(CLIENT_IP,HEADER,QUERY,TRACE_ID)',
+  `bucketKey` VARCHAR(100) DEFAULT NULL COMMENT '分桶键名(bucketBy为HEADER/QUERY时的请求头或查询参数名)',
+  `logEvaluation` VARCHAR(1) NOT NULL DEFAULT 'N' COMMENT '是否将判定结果写入访问日志扩展属性(N否,Y是)',
+
+  `extProperty` TEXT DEFAULT NULL COMMENT '扩展属性,JSON格式',
+  `addTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `addWho` VARCHAR(32) NOT NULL COMMENT '创建人ID',
+  `editTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后修改时间',
+  `editWho` VARCHAR(32) NOT NULL COMMENT '最后修改人ID',
+  `oprSeqFlag` VARCHAR(32) NOT NULL COMMENT '操作序列标识',
+  `currentVersion` INT NOT NULL DEFAULT 1 COMMENT '当前版本号',
+  `activeFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '活动状态标记(N非活动,Y活动)',
+  `noteText` VARCHAR(500) DEFAULT NULL COMMENT '备注信息',
+  PRIMARY KEY (`tenantId`, `featureFlagId`),
+  INDEX `IDX_GW_FLAG_KEY` (`tenantId`, `flagKey`),
+  INDEX `IDX_GW_FLAG_INST` (`gatewayInstanceId`),
+  INDEX `IDX_GW_FLAG_ROUTE` (`routeConfigId`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='网关功能开关表 - 按实例/路由维度控制过滤器等功能按比例放量';
@@ -59,3 +59,6 @@ CREATE TABLE `HUB_GW_SERVICE_DEFINITION` (
   INDEX `IDX_GW_SVC_PROXY` (`proxyConfigId`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='服务定义表 - 根据ServiceConfig结构设计,存储完整的服务配置';
 
+-- 上游TLS配置：使用独立ALTER语句，避免修改历史建表语句，保证已有表可以增量升级
+ALTER TABLE `HUB_GW_SERVICE_DEFINITION`
+  ADD COLUMN `upstreamTlsConfig` TEXT DEFAULT NULL COMMENT '上游TLS配置(CA证书、客户端证书、SNI、最低TLS版本),JSON格式' AFTER `serviceMetadata`;
@@ -0,0 +1,56 @@
+CREATE TABLE `HUB_GW_SLOW_REQ` (
+  `tenantId` VARCHAR(32) NOT NULL COMMENT '租户ID',
+  `slowReqId` VARCHAR(32) NOT NULL COMMENT '慢请求记录ID',
+  `traceId` VARCHAR(64) NOT NULL COMMENT '链路追踪ID，关联主表 HUB_GW_ACCESS_LOG.traceId',
+
+  -- 网关和路由信息
+  `gatewayInstanceId` VARCHAR(32) DEFAULT NULL COMMENT '网关实例ID',
+  `gatewayInstanceName` VARCHAR(300) DEFAULT NULL COMMENT '网关实例名称',
+  `gatewayNodeIp` VARCHAR(50) DEFAULT NULL COMMENT '网关节点IP',
+  `routeConfigId` VARCHAR(32) DEFAULT NULL COMMENT '路由配置ID',
+  `routeName` VARCHAR(300) DEFAULT NULL COMMENT '路由名称',
+  `serviceDefinitionId` VARCHAR(32) DEFAULT NULL COMMENT '服务定义ID',
+  `serviceName` VARCHAR(300) DEFAULT NULL COMMENT '服务名称',
+
+  -- 请求信息
+  `requestMethod` VARCHAR(10) DEFAULT NULL COMMENT '请求方法',
+  `requestPath` VARCHAR(1000) DEFAULT NULL COMMENT '请求路径',
+  `clientIpAddress` VARCHAR(50) DEFAULT NULL COMMENT '客户端IP',
+  `gatewayStatusCode` INT DEFAULT NULL COMMENT '网关状态码',
+  `backendStatusCode` INT DEFAULT NULL COMMENT '后端状态码(0表示未调用后端)',
+
+  -- 耗时信息
+  `thresholdMs` INT NOT NULL COMMENT '触发时的慢请求阈值(毫秒)',
+  `totalProcessingTimeMs` INT NOT NULL COMMENT '总处理时间(毫秒)',
+  `gatewayProcessingTimeMs` INT DEFAULT NULL COMMENT '网关自身处理时间(毫秒)',
+  `backendResponseTimeMs` INT DEFAULT NULL COMMENT '后端响应时间(毫秒)',
+  `requestStartTime` DATETIME(3) NOT NULL COMMENT '网关开始处理时间',
+  `requestFinishTime` DATETIME(3) DEFAULT NULL COMMENT '网关处理完成时间',
+
+  -- 诊断信息
+  `timingBreakdown` TEXT DEFAULT NULL COMMENT '耗时分解(JSON格式)',
+  `upstreamConnState` LONGTEXT DEFAULT NULL COMMENT '上游连接状态(JSON数组，每次转发尝试一条)',
+  `goroutineCount` INT DEFAULT 0 COMMENT '超过阈值时的协程数量(0表示请求结束前未触发看门狗)',
+  `goroutineSnapshotRef` VARCHAR(500) DEFAULT NULL COMMENT '协程快照文件引用',
+  `detectedTime` DATETIME(3) DEFAULT NULL COMMENT '看门狗检测到慢请求的时间',
+  `errorMessage` LONGTEXT DEFAULT NULL COMMENT '错误信息',
+
+  -- 扩展信息
+  `extProperty` TEXT DEFAULT NULL COMMENT '扩展属性(JSON格式)',
+
+  -- 标准数据库字段
+  `addTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
+  `addWho` VARCHAR(32) NOT NULL COMMENT '记录创建者',
+  `editTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '记录修改时间',
+  `editWho` VARCHAR(32) NOT NULL COMMENT '记录修改者',
+  `oprSeqFlag` VARCHAR(32) NOT NULL COMMENT '操作序列标识',
+  `currentVersion` INT NOT NULL DEFAULT 1 COMMENT '当前版本号',
+  `activeFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '活动状态标记(N非活动,Y活动)',
+  `noteText` VARCHAR(500) DEFAULT NULL COMMENT '备注信息',
+
+  PRIMARY KEY (`tenantId`, `slowReqId`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='网关慢请求诊断表 - 记录超过慢请求阈值的请求及其诊断信息';
+
+CREATE INDEX `IDX_GW_SLOWREQ_TRACE` ON `HUB_GW_SLOW_REQ` (`tenantId`, `traceId`);
+CREATE INDEX `IDX_GW_SLOWREQ_ROUTE` ON `HUB_GW_SLOW_REQ` (`tenantId`, `routeConfigId`, `requestStartTime`);
+CREATE INDEX `IDX_GW_SLOWREQ_TIME` ON `HUB_GW_SLOW_REQ` (`tenantId`, `requestStartTime`);
@@ -0,0 +1,48 @@
+CREATE TABLE `HUB_MONITOR_ALERT_EVENT` (
+  -- 主键和租户
+  `tenantId` VARCHAR(32) NOT NULL COMMENT '租户ID，主键',
+  `alertEventId` VARCHAR(32) NOT NULL COMMENT '告警事件ID，主键',
+  
+  -- 规则信息
+  `alertRuleId` VARCHAR(32) NOT NULL COMMENT '告警规则ID',
+  `ruleName` VARCHAR(200) DEFAULT NULL COMMENT '规则名称（冗余字段，便于查询显示）',
+  `metricType` VARCHAR(30) NOT NULL COMMENT '监控指标',
+  `alertLevel` VARCHAR(20) NOT NULL COMMENT '告警级别',
+  
+  -- 实例信息
+  `jvmResourceId` VARCHAR(100) NOT NULL COMMENT 'JVM资源ID',
+  `applicationName` VARCHAR(100) DEFAULT NULL COMMENT '应用名称',
+  `hostName` VARCHAR(100) DEFAULT NULL COMMENT '主机名',
+  `hostIpAddress` VARCHAR(50) DEFAULT NULL COMMENT '主机IP地址',
+  `groupKey` VARCHAR(200) DEFAULT NULL COMMENT '通知分组键',
+  
+  -- 事件状态
+  `eventStatus` VARCHAR(20) NOT NULL COMMENT '事件状态：FIRING告警中/RESOLVED已恢复',
+  `metricValue` DECIMAL(18,4) NOT NULL DEFAULT 0 COMMENT '最近一次评估的指标值',
+  `thresholdValue` DECIMAL(18,4) NOT NULL DEFAULT 0 COMMENT '触发时的阈值',
+  `messageText` VARCHAR(1000) DEFAULT NULL COMMENT '告警描述',
+  `firstTriggerTime` DATETIME NOT NULL COMMENT '首次触发时间',
+  `lastTriggerTime` DATETIME NOT NULL COMMENT '最近一次满足条件的时间',
+  `resolvedTime` DATETIME DEFAULT NULL COMMENT '恢复时间',
+  
+  -- 通知信息
+  `notifyCount` INT NOT NULL DEFAULT 0 COMMENT '通知次数',
+  `lastNotifyTime` DATETIME DEFAULT NULL COMMENT '最近通知时间',
+  `silencedFlag` VARCHAR(1) NOT NULL DEFAULT 'N' COMMENT '是否被静默：Y是，N否',
+  `alertLogId` VARCHAR(64) DEFAULT NULL COMMENT '最近一次通知的告警日志ID（HUB_ALERT_LOG）',
+  
+  -- 通用字段
+  `addTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `addWho` VARCHAR(32) NOT NULL COMMENT '创建人ID',
+  `editTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后修改时间',
+  `editWho` VARCHAR(32) NOT NULL COMMENT '最后修改人ID',
+  `oprSeqFlag` VARCHAR(32) NOT NULL COMMENT '操作序列标识',
+  `currentVersion` INT NOT NULL DEFAULT 1 COMMENT '当前版本号',
+  `activeFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '活动状态标记：N非活动，Y活动',
+  
+  -- 主键和索引
+  PRIMARY KEY (`tenantId`, `alertEventId`),
+  INDEX `IDX_MON_ALERT_EVENT_RULE` (`tenantId`, `alertRuleId`, `eventStatus`),
+  INDEX `IDX_MON_ALERT_EVENT_RES` (`jvmResourceId`),
+  INDEX `IDX_MON_ALERT_EVENT_TIME` (`firstTriggerTime`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='JVM告警事件表 - 记录告警的触发、通知和恢复历史';
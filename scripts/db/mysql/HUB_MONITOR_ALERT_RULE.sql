@@ -0,0 +1,42 @@
+CREATE TABLE `HUB_MONITOR_ALERT_RULE` (
+  -- 主键和租户
+  `tenantId` VARCHAR(32) NOT NULL COMMENT '租户ID，主键',
+  `alertRuleId` VARCHAR(32) NOT NULL COMMENT '告警规则ID，主键',
+  
+  -- 规则定义
+  `ruleName` VARCHAR(200) NOT NULL COMMENT '规则名称',
+  `metricType` VARCHAR(30) NOT NULL COMMENT '监控指标：HEAP_USAGE堆内存使用率/NON_HEAP_USAGE非堆内存使用率/FGC_COUNT Full GC次数/GC_COUNT GC总次数/THREAD_COUNT线程数/DEADLOCK死锁线程数/GATEWAY_ERROR_RATE网关5xx错误率',
+  `evaluateMode` VARCHAR(20) NOT NULL DEFAULT 'THRESHOLD' COMMENT '评估方式：THRESHOLD阈值/RATE每分钟变化量/ANOMALY基线偏离(z-score)',
+  `compareOperator` VARCHAR(10) NOT NULL DEFAULT 'GT' COMMENT '比较运算符：GT大于/GTE大于等于/LT小于/LTE小于等于',
+  `thresholdValue` DECIMAL(18,4) NOT NULL DEFAULT 0 COMMENT '阈值',
+  `durationSeconds` INT NOT NULL DEFAULT 0 COMMENT '持续时长（秒），阈值方式下持续满足条件才触发，0表示最新采集值满足即触发',
+  `windowSeconds` INT NOT NULL DEFAULT 300 COMMENT '速率计算窗口（秒），RATE方式使用',
+  
+  -- 匹配范围
+  `serviceGroupId` VARCHAR(32) DEFAULT NULL COMMENT '服务分组ID，为空匹配所有分组',
+  `applicationName` VARCHAR(100) DEFAULT NULL COMMENT '应用名称，为空匹配所有应用',
+  
+  -- 通知配置
+  `groupBy` VARCHAR(20) NOT NULL DEFAULT 'APPLICATION' COMMENT '通知分组方式：APPLICATION按应用合并/INSTANCE按实例单独通知',
+  `alertLevel` VARCHAR(20) NOT NULL DEFAULT 'WARN' COMMENT '告警级别：INFO/WARN/ERROR/CRITICAL',
+  `channelName` VARCHAR(100) DEFAULT NULL COMMENT '告警渠道名称，为空使用默认渠道',
+  `repeatIntervalSeconds` INT NOT NULL DEFAULT 3600 COMMENT '重复通知间隔（秒），0表示只通知一次',
+  `notifyResolvedFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '恢复时是否通知：Y是，N否',
+  `enabledFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '是否启用：Y启用，N停用',
+  `lastEvalTime` DATETIME DEFAULT NULL COMMENT '最近评估时间',
+  
+  -- 通用字段
+  `addTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `addWho` VARCHAR(32) NOT NULL COMMENT '创建人ID',
+  `editTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后修改时间',
+  `editWho` VARCHAR(32) NOT NULL COMMENT '最后修改人ID',
+  `oprSeqFlag` VARCHAR(32) NOT NULL COMMENT '操作序列标识',
+  `currentVersion` INT NOT NULL DEFAULT 1 COMMENT '当前版本号',
+  `activeFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '活动状态标记：N非活动，Y活动',
+  `noteText` VARCHAR(500) DEFAULT NULL COMMENT '备注信息',
+  
+  -- 主键和索引
+  PRIMARY KEY (`tenantId`, `alertRuleId`),
+  INDEX `IDX_MON_ALERT_RULE_ENABLED` (`enabledFlag`, `activeFlag`),
+  INDEX `IDX_MON_ALERT_RULE_METRIC` (`tenantId`, `metricType`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='JVM告警规则表 - 基于JVM监控数据的阈值和变化速率告警规则';
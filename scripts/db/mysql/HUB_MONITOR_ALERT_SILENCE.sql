@@ -0,0 +1,28 @@
+CREATE TABLE `HUB_MONITOR_ALERT_SILENCE` (
+  -- 主键和租户
+  `tenantId` VARCHAR(32) NOT NULL COMMENT '租户ID，主键',
+  `alertSilenceId` VARCHAR(32) NOT NULL COMMENT '静默ID，主键',
+  
+  -- 匹配条件
+  `alertRuleId` VARCHAR(32) DEFAULT NULL COMMENT '告警规则ID，为空匹配所有规则',
+  `applicationName` VARCHAR(100) DEFAULT NULL COMMENT '应用名称，为空匹配所有应用',
+  `jvmResourceId` VARCHAR(100) DEFAULT NULL COMMENT 'JVM资源ID，为空匹配所有实例',
+  
+  -- 静默时间
+  `startTime` DATETIME NOT NULL COMMENT '静默开始时间',
+  `endTime` DATETIME NOT NULL COMMENT '静默结束时间',
+  `reasonText` VARCHAR(500) DEFAULT NULL COMMENT '静默原因',
+  
+  -- 通用字段
+  `addTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `addWho` VARCHAR(32) NOT NULL COMMENT '创建人ID',
+  `editTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后修改时间',
+  `editWho` VARCHAR(32) NOT NULL COMMENT '最后修改人ID',
+  `oprSeqFlag` VARCHAR(32) NOT NULL COMMENT '操作序列标识',
+  `currentVersion` INT NOT NULL DEFAULT 1 COMMENT '当前版本号',
+  `activeFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '活动状态标记：N非活动，Y活动',
+  
+  -- 主键和索引
+  PRIMARY KEY (`tenantId`, `alertSilenceId`),
+  INDEX `IDX_MON_ALERT_SILENCE_TIME` (`tenantId`, `endTime`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='JVM告警静默表 - 在时间窗口内屏蔽匹配的告警通知';
@@ -0,0 +1,37 @@
+CREATE TABLE `HUB_MONITOR_JVM_DIAG_TASK` (
+  -- 主键
+  `tenantId` VARCHAR(32) NOT NULL COMMENT '租户ID',
+  `diagTaskId` VARCHAR(32) NOT NULL COMMENT '诊断任务ID',
+  
+  -- 采集请求
+  `jvmResourceId` VARCHAR(100) NOT NULL COMMENT 'JVM资源ID',
+  `applicationName` VARCHAR(100) DEFAULT NULL COMMENT '应用名称，创建任务时从资源信息复制',
+  `diagType` VARCHAR(30) NOT NULL COMMENT '诊断类型：THREAD_DUMP线程转储/HEAP_HISTOGRAM类直方图',
+  `taskStatus` VARCHAR(20) NOT NULL DEFAULT 'PENDING' COMMENT '任务状态：PENDING待下发/DISPATCHED已下发/COMPLETED已完成/FAILED失败/EXPIRED已过期',
+  `requestTime` DATETIME NOT NULL COMMENT '请求时间',
+  `expireTime` DATETIME NOT NULL COMMENT '过期时间，待下发或已下发的任务超过该时间未完成则过期',
+  `dispatchTime` DATETIME DEFAULT NULL COMMENT '下发时间，采集端心跳领取任务的时间',
+  `completeTime` DATETIME DEFAULT NULL COMMENT '完成时间',
+  
+  -- 采集结果
+  `resultText` LONGTEXT DEFAULT NULL COMMENT '采集结果原文（jstack/jmap -histo 格式）',
+  `resultSize` INT NOT NULL DEFAULT 0 COMMENT '采集结果字节数',
+  `threadCount` INT NOT NULL DEFAULT 0 COMMENT '线程转储中的线程数',
+  `blockedThreadCount` INT NOT NULL DEFAULT 0 COMMENT '线程转储中BLOCKED状态的线程数',
+  `errorMessage` VARCHAR(1000) DEFAULT NULL COMMENT '采集失败原因',
+  
+  -- 通用字段
+  `addTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `addWho` VARCHAR(32) NOT NULL COMMENT '创建人ID',
+  `editTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后修改时间',
+  `editWho` VARCHAR(32) NOT NULL COMMENT '最后修改人ID',
+  `oprSeqFlag` VARCHAR(32) NOT NULL COMMENT '操作序列标识',
+  `currentVersion` INT NOT NULL DEFAULT 1 COMMENT '当前版本号',
+  `activeFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '活动状态标记：N非活动，Y活动',
+  `noteText` VARCHAR(500) DEFAULT NULL COMMENT '备注信息',
+  
+  -- 主键和索引
+  PRIMARY KEY (`tenantId`, `diagTaskId`),
+  INDEX `IDX_MON_DIAG_RESOURCE` (`tenantId`, `jvmResourceId`, `taskStatus`),
+  INDEX `IDX_MON_DIAG_REQ_TIME` (`requestTime`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='JVM诊断采集任务表 - 线程转储/类直方图的按需采集请求和结果';
@@ -0,0 +1,39 @@
+CREATE TABLE `HUB_MONITOR_JVM_METRIC_1H` (
+  -- 主键
+  `tenantId` VARCHAR(32) NOT NULL COMMENT '租户ID，主键',
+  `jvmResourceId` VARCHAR(100) NOT NULL COMMENT 'JVM资源ID，主键',
+  `bucketTime` DATETIME NOT NULL COMMENT '聚合时间段起点（1小时对齐），主键',
+  
+  -- 内存指标
+  `memorySampleCount` INT NOT NULL DEFAULT 0 COMMENT '参与聚合的堆内存采集点数',
+  `heapUsageAvg` DECIMAL(5,2) NOT NULL DEFAULT 0 COMMENT '堆内存使用率平均值（百分比）',
+  `heapUsageMax` DECIMAL(5,2) NOT NULL DEFAULT 0 COMMENT '堆内存使用率最大值（百分比）',
+  `heapUsedBytesAvg` BIGINT NOT NULL DEFAULT 0 COMMENT '堆内存已使用平均值（字节）',
+  `heapUsedBytesMax` BIGINT NOT NULL DEFAULT 0 COMMENT '堆内存已使用最大值（字节）',
+  `nonHeapSampleCount` INT NOT NULL DEFAULT 0 COMMENT '参与聚合的非堆内存采集点数',
+  `nonHeapUsageAvg` DECIMAL(5,2) NOT NULL DEFAULT 0 COMMENT '非堆内存使用率平均值（百分比）',
+  `nonHeapUsageMax` DECIMAL(5,2) NOT NULL DEFAULT 0 COMMENT '非堆内存使用率最大值（百分比）',
+  
+  -- 线程指标
+  `threadSampleCount` INT NOT NULL DEFAULT 0 COMMENT '参与聚合的线程采集点数',
+  `threadCountAvg` DECIMAL(10,2) NOT NULL DEFAULT 0 COMMENT '线程数平均值',
+  `threadCountMax` INT NOT NULL DEFAULT 0 COMMENT '线程数最大值',
+  
+  -- GC指标
+  `ygcCount` BIGINT NOT NULL DEFAULT 0 COMMENT '时间段内年轻代GC次数',
+  `fgcCount` BIGINT NOT NULL DEFAULT 0 COMMENT '时间段内Full GC次数',
+  `gcTimeSeconds` DECIMAL(12,3) NOT NULL DEFAULT 0 COMMENT '时间段内GC耗时（秒）',
+  
+  -- 通用字段
+  `addTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `addWho` VARCHAR(32) NOT NULL COMMENT '创建人ID',
+  `editTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后修改时间',
+  `editWho` VARCHAR(32) NOT NULL COMMENT '最后修改人ID',
+  `oprSeqFlag` VARCHAR(32) NOT NULL COMMENT '操作序列标识',
+  `currentVersion` INT NOT NULL DEFAULT 1 COMMENT '当前版本号',
+  `activeFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '活动状态标记：N非活动，Y活动',
+  
+  -- 主键和索引
+  PRIMARY KEY (`tenantId`, `jvmResourceId`, `bucketTime`),
+  INDEX `IDX_MON_JVM_1H_TIME` (`bucketTime`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='JVM监控1小时聚合表 - 由5分钟聚合数据按小时汇总';
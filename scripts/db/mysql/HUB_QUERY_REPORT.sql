@@ -0,0 +1,39 @@
+CREATE TABLE `HUB_QUERY_REPORT` (
+  -- 主键和租户
+  `tenantId` VARCHAR(32) NOT NULL COMMENT '租户ID，主键',
+  `reportId` VARCHAR(32) NOT NULL COMMENT '报表ID，主键',
+  
+  -- 关联信息
+  `savedQueryId` VARCHAR(32) NOT NULL COMMENT '保存查询ID',
+  `queryName` VARCHAR(200) DEFAULT NULL COMMENT '查询名称（冗余字段，便于查询显示）',
+  `triggerType` VARCHAR(20) NOT NULL COMMENT '触发方式：SCHEDULE定时/MANUAL手动',
+  
+  -- 生成结果
+  `reportStatus` VARCHAR(20) NOT NULL COMMENT '报表状态：SUCCESS成功/FAILED失败',
+  `rowCount` INT NOT NULL DEFAULT 0 COMMENT '报表行数',
+  `reportFormat` VARCHAR(10) DEFAULT NULL COMMENT '报表格式：csv/xlsx',
+  `fileName` VARCHAR(300) DEFAULT NULL COMMENT '报表文件名',
+  `filePath` VARCHAR(500) DEFAULT NULL COMMENT '报表文件存储路径',
+  `fileSize` BIGINT NOT NULL DEFAULT 0 COMMENT '报表文件大小（字节）',
+  `emailStatus` VARCHAR(20) DEFAULT NULL COMMENT '邮件发送状态：NONE未发送/SUCCESS成功/FAILED失败',
+  `errorMessage` TEXT DEFAULT NULL COMMENT '错误信息',
+  
+  -- 执行时间
+  `startTime` DATETIME NOT NULL COMMENT '开始时间',
+  `endTime` DATETIME DEFAULT NULL COMMENT '结束时间',
+  `durationMs` BIGINT NOT NULL DEFAULT 0 COMMENT '耗时（毫秒）',
+  
+  -- 通用字段
+  `addTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `addWho` VARCHAR(32) NOT NULL COMMENT '创建人ID',
+  `editTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后修改时间',
+  `editWho` VARCHAR(32) NOT NULL COMMENT '最后修改人ID',
+  `oprSeqFlag` VARCHAR(32) NOT NULL COMMENT '操作序列标识',
+  `currentVersion` INT NOT NULL DEFAULT 1 COMMENT '当前版本号',
+  `activeFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '活动状态标记：N非活动，Y活动',
+  
+  -- 主键和索引
+  PRIMARY KEY (`tenantId`, `reportId`),
+  INDEX `IDX_QUERY_REPORT_QUERY` (`tenantId`, `savedQueryId`),
+  INDEX `IDX_QUERY_REPORT_TIME` (`startTime`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='查询报表表 - 记录保存查询生成的报表文件和发送结果';
@@ -0,0 +1,41 @@
+CREATE TABLE `HUB_SAVED_QUERY` (
+  -- 主键和租户
+  `tenantId` VARCHAR(32) NOT NULL COMMENT '租户ID，主键',
+  `savedQueryId` VARCHAR(32) NOT NULL COMMENT '保存查询ID，主键',
+  
+  -- 查询信息
+  `queryName` VARCHAR(200) NOT NULL COMMENT '查询名称',
+  `queryType` VARCHAR(50) NOT NULL COMMENT '查询类型：ACCESS_LOG访问日志/MONITOR_OVERVIEW监控概览/MONITOR_CHART监控图表',
+  `queryParams` TEXT DEFAULT NULL COMMENT '查询过滤条件，JSON格式，与对应查询接口参数一致',
+  `timeRangeMinutes` INT NOT NULL DEFAULT 0 COMMENT '相对时间范围（分钟），大于0时执行时按当前时间计算startTime/endTime',
+  `ownerUserId` VARCHAR(32) NOT NULL COMMENT '所属用户ID，定时执行时以该用户身份查询',
+  `shareFlag` VARCHAR(1) NOT NULL DEFAULT 'N' COMMENT '是否共享给租户内其他用户：Y是，N否',
+  
+  -- 报表调度配置
+  `scheduleEnabled` VARCHAR(1) NOT NULL DEFAULT 'N' COMMENT '是否启用定时报表：Y是，N否',
+  `cronExpression` VARCHAR(100) DEFAULT NULL COMMENT 'Cron表达式',
+  `reportFormat` VARCHAR(10) NOT NULL DEFAULT 'xlsx' COMMENT '报表格式：csv/xlsx',
+  `reportColumns` TEXT DEFAULT NULL COMMENT '报表列，逗号分隔或JSON数组，为空时导出全部字段',
+  `maxRows` INT NOT NULL DEFAULT 0 COMMENT '报表最大行数，0表示使用服务端上限',
+  `emailChannel` VARCHAR(100) DEFAULT NULL COMMENT '邮件告警渠道名称，为空时使用默认渠道',
+  `emailTo` VARCHAR(1000) DEFAULT NULL COMMENT '报表收件人，逗号分隔，为空时仅生成报表记录',
+  
+  -- 最近一次报表
+  `lastReportTime` DATETIME DEFAULT NULL COMMENT '最近报表生成时间',
+  `lastReportStatus` VARCHAR(20) DEFAULT NULL COMMENT '最近报表状态：SUCCESS/FAILED',
+  
+  -- 通用字段
+  `addTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `addWho` VARCHAR(32) NOT NULL COMMENT '创建人ID',
+  `editTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后修改时间',
+  `editWho` VARCHAR(32) NOT NULL COMMENT '最后修改人ID',
+  `oprSeqFlag` VARCHAR(32) NOT NULL COMMENT '操作序列标识',
+  `currentVersion` INT NOT NULL DEFAULT 1 COMMENT '当前版本号',
+  `activeFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '活动状态标记：N非活动，Y活动',
+  `noteText` VARCHAR(500) DEFAULT NULL COMMENT '备注信息',
+  
+  -- 主键和索引
+  PRIMARY KEY (`tenantId`, `savedQueryId`),
+  INDEX `IDX_SAVED_QUERY_OWNER` (`tenantId`, `ownerUserId`),
+  INDEX `IDX_SAVED_QUERY_SCHEDULE` (`scheduleEnabled`, `activeFlag`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='保存查询表 - 存储用户保存的访问日志和监控查询条件及定时报表配置';
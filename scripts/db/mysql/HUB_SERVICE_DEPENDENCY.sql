@@ -0,0 +1,39 @@
+-- 服务依赖关系表 - 记录订阅方与被订阅服务之间的依赖关系，用于依赖拓扑和维护前的影响范围评估
+CREATE TABLE `HUB_SERVICE_DEPENDENCY` (
+  -- 主键和租户信息
+  `dependencyId` VARCHAR(32) NOT NULL COMMENT '依赖关系ID，由订阅方和被订阅服务计算得出，主键',
+  `tenantId` VARCHAR(32) NOT NULL COMMENT '租户ID，用于多租户数据隔离',
+  `namespaceId` VARCHAR(32) NOT NULL COMMENT '被订阅服务的命名空间ID',
+  
+  -- 订阅方（消费者）
+  `consumerType` VARCHAR(20) NOT NULL COMMENT '订阅方类型(SERVICE:已注册的服务,CLIENT:未声明服务身份的客户端)',
+  `consumerNamespaceId` VARCHAR(32) DEFAULT NULL COMMENT '订阅方命名空间ID',
+  `consumerGroupName` VARCHAR(64) DEFAULT NULL COMMENT '订阅方分组名称',
+  `consumerName` VARCHAR(100) NOT NULL COMMENT '订阅方服务名称，CLIENT类型时为客户端ID或IP',
+  
+  -- 被订阅方（提供者）
+  `providerGroupName` VARCHAR(64) DEFAULT NULL COMMENT '被订阅服务分组名称，订阅整个命名空间时为空',
+  `providerServiceName` VARCHAR(100) NOT NULL COMMENT '被订阅服务名称，订阅整个命名空间/分组时为*',
+  
+  -- 依赖信息
+  `subscribeType` VARCHAR(20) NOT NULL COMMENT '订阅方式(SERVICE:订阅服务,NAMESPACE:订阅命名空间/分组)',
+  `firstSeenTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '首次订阅时间',
+  `lastSeenTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '最近一次订阅时间',
+  
+  -- 通用字段
+  `addTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `addWho` VARCHAR(32) NOT NULL COMMENT '创建人ID',
+  `editTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后修改时间',
+  `editWho` VARCHAR(32) NOT NULL COMMENT '最后修改人ID',
+  `oprSeqFlag` VARCHAR(32) NOT NULL COMMENT '操作序列标识',
+  `currentVersion` INT NOT NULL DEFAULT 1 COMMENT '当前版本号',
+  `activeFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '活动状态标记(N非活动,Y活动)',
+  `noteText` VARCHAR(500) DEFAULT NULL COMMENT '备注信息',
+  `extProperty` TEXT DEFAULT NULL COMMENT '扩展属性，JSON格式',
+  
+  -- 主键和索引
+  PRIMARY KEY (`tenantId`, `dependencyId`),
+  KEY `IDX_SVC_DEP_PROVIDER` (`tenantId`, `namespaceId`, `providerGroupName`, `providerServiceName`),
+  KEY `IDX_SVC_DEP_CONSUMER` (`tenantId`, `consumerNamespaceId`, `consumerGroupName`, `consumerName`),
+  KEY `IDX_SVC_DEP_LAST_SEEN` (`lastSeenTime`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='服务依赖关系表 - 记录订阅方与被订阅服务之间的依赖关系，用于依赖拓扑和维护前的影响范围评估';
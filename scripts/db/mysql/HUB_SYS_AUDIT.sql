@@ -0,0 +1,41 @@
+CREATE TABLE `HUB_SYS_AUDIT` (
+  `tenantId` VARCHAR(32) NOT NULL COMMENT '租户ID',
+  `auditId` VARCHAR(32) NOT NULL COMMENT '审计记录ID',
+
+  -- 操作人
+  `userId` VARCHAR(32) DEFAULT NULL COMMENT '操作用户ID',
+  `userName` VARCHAR(50) DEFAULT NULL COMMENT '操作用户名',
+  `clientIp` VARCHAR(128) DEFAULT NULL COMMENT '客户端IP',
+  `userAgent` VARCHAR(500) DEFAULT NULL COMMENT '客户端UserAgent',
+
+  -- 操作入口
+  `moduleCode` VARCHAR(32) DEFAULT NULL COMMENT '模块编码(如hub0002)',
+  `actionName` VARCHAR(100) DEFAULT NULL COMMENT '接口动作(如addUser)',
+  `httpMethod` VARCHAR(10) NOT NULL COMMENT 'HTTP方法',
+  `requestPath` VARCHAR(500) NOT NULL COMMENT '请求路径',
+
+  -- 数据变更
+  `operationType` VARCHAR(20) NOT NULL COMMENT '操作类型(CREATE,UPDATE,DELETE,MIXED)',
+  `tableNames` VARCHAR(500) DEFAULT NULL COMMENT '变更的表，逗号分隔',
+  `changeCount` INT NOT NULL DEFAULT 0 COMMENT '变更记录数',
+  `changeData` LONGTEXT DEFAULT NULL COMMENT '变更前后数据及字段差异,JSON格式',
+
+  -- 操作结果
+  `resultFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '请求是否成功(N失败,Y成功)',
+  `httpStatus` INT NOT NULL DEFAULT 200 COMMENT 'HTTP响应状态码',
+  `durationMs` BIGINT NOT NULL DEFAULT 0 COMMENT '请求耗时(毫秒)',
+  `traceId` VARCHAR(64) DEFAULT NULL COMMENT '链路跟踪ID',
+  `operateTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '操作时间',
+
+  `addTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `addWho` VARCHAR(32) NOT NULL COMMENT '创建人ID',
+  `editTime` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后修改时间',
+  `editWho` VARCHAR(32) NOT NULL COMMENT '最后修改人ID',
+  `oprSeqFlag` VARCHAR(32) NOT NULL COMMENT '操作序列标识',
+  `currentVersion` INT NOT NULL DEFAULT 1 COMMENT '当前版本号',
+  `activeFlag` VARCHAR(1) NOT NULL DEFAULT 'Y' COMMENT '活动状态标记(N非活动,Y活动)',
+  PRIMARY KEY (`tenantId`, `auditId`),
+  INDEX `IDX_SYS_AUDIT_TIME` (`tenantId`, `operateTime`),
+  INDEX `IDX_SYS_AUDIT_USER` (`tenantId`, `userId`),
+  INDEX `IDX_SYS_AUDIT_MODULE` (`moduleCode`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='系统操作审计表 - 记录管理端接口的数据变更';
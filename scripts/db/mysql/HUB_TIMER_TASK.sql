@@ -13,31 +13,38 @@ CREATE TABLE `HUB_TIMER_TASK` (
   -- 调度配置
   `scheduleType` INT NOT NULL COMMENT '调度类型(1一次性执行,2固定间隔,3Cron表达式,4延迟执行,5实时执行)',
   `cronExpression` VARCHAR(100) DEFAULT NULL COMMENT 'Cron表达式，scheduleType=3时必填',
+  `timeZone` VARCHAR(64) DEFAULT NULL COMMENT 'Cron表达式使用的时区（IANA名称，如Asia/Shanghai），为空使用服务器本地时区',
   `intervalSeconds` BIGINT DEFAULT NULL COMMENT '执行间隔秒数，scheduleType=2时必填',
   `delaySeconds` BIGINT DEFAULT NULL COMMENT '延迟秒数，scheduleType=4时必填',
   `startTime` DATETIME DEFAULT NULL COMMENT '任务开始时间',
   `endTime` DATETIME DEFAULT NULL COMMENT '任务结束时间',
+  `dependsOnTaskIds` VARCHAR(1000) DEFAULT NULL COMMENT '上游任务ID列表，逗号分隔；配置后由同一调度器中的上游任务执行结束后触发，不按自身调度类型执行',
+  `dependencyPolicy` VARCHAR(20) NOT NULL DEFAULT 'SKIP' COMMENT '上游任务失败时的处理方式(SKIP跳过本任务及下游,CONTINUE继续执行)',
   
   -- 执行配置
   `maxRetries` INT NOT NULL DEFAULT 0 COMMENT '最大重试次数',
   `retryIntervalSeconds` BIGINT NOT NULL DEFAULT 60 COMMENT '重试间隔秒数',
+  `retryBackoffRate` DECIMAL(5,2) NOT NULL DEFAULT 1.00 COMMENT '重试间隔退避倍率，每次重试间隔乘以该倍率，小于等于1为固定间隔',
+  `maxRetryIntervalSeconds` BIGINT DEFAULT NULL COMMENT '退避后的最大重试间隔秒数，为空不限制',
+  `deadLetterThreshold` INT NOT NULL DEFAULT 0 COMMENT '连续失败多少次后进入死信状态，0不启用；死信任务停止调度，需手动触发执行成功后恢复',
   `timeoutSeconds` BIGINT NOT NULL DEFAULT 1800 COMMENT '执行超时时间秒数',
   `taskParams` TEXT DEFAULT NULL COMMENT '任务参数，JSON格式存储',
   
   -- 任务执行器配置 - 关联到具体工具配置
-  `executorType` VARCHAR(50) DEFAULT NULL COMMENT '执行器类型(BUILTIN内置,SFTP文件传输,SSH远程执行,DATABASE数据库,HTTP接口调用等)',
+  `executorType` VARCHAR(50) DEFAULT NULL COMMENT '执行器类型(BUILTIN内置,SFTP文件传输,DATA_CLEANUP数据清理,SSH远程执行,DATABASE数据库,HTTP接口调用等)',
   `toolConfigId` VARCHAR(32) DEFAULT NULL COMMENT '关联的工具配置ID（如SFTP配置ID、数据库配置ID等）',
   `toolConfigName` VARCHAR(100) DEFAULT NULL COMMENT '工具配置名称（冗余字段，便于显示）',
   `operationType` VARCHAR(100) DEFAULT NULL COMMENT '执行操作类型（如文件上传、下载、SQL执行、接口调用等）',
   `operationConfig` TEXT DEFAULT NULL COMMENT '操作参数配置，JSON格式存储具体操作的参数',
   
   -- 运行时状态
-  `taskStatus` INT NOT NULL DEFAULT 1 COMMENT '任务状态(1待执行,2运行中,3已完成,4执行失败,5已取消)',
+  `taskStatus` INT NOT NULL DEFAULT 1 COMMENT '任务状态(1待执行,2运行中,3已完成,4执行失败,5已取消,6死信,7已暂停)',
   `nextRunTime` DATETIME DEFAULT NULL COMMENT '下次执行时间',
   `lastRunTime` DATETIME DEFAULT NULL COMMENT '上次执行时间',
   `runCount` BIGINT NOT NULL DEFAULT 0 COMMENT '执行总次数',
   `successCount` BIGINT NOT NULL DEFAULT 0 COMMENT '成功次数',
   `failureCount` BIGINT NOT NULL DEFAULT 0 COMMENT '失败次数',
+  `consecutiveFailures` INT NOT NULL DEFAULT 0 COMMENT '连续失败次数，执行成功后清零',
   
   -- 最后执行结果
   `lastExecutionId` VARCHAR(32) DEFAULT NULL COMMENT '最后执行ID',
@@ -7,6 +7,7 @@
 
 source HUB_USER.sql;
 source HUB_LOGIN_LOG.sql;
+source HUB_SYS_AUDIT.sql;
 source HUB_GW_INSTANCE.sql;
 source HUB_GW_ROUTER_CONFIG.sql;
 source HUB_GW_ROUTE_CONFIG.sql;
@@ -27,6 +28,9 @@ source HUB_TOOL_CONFIG_GROUP.sql;
 source HUB_GW_LOG_CONFIG.sql;
 source HUB_GW_ACCESS_LOG.sql;
 source HUB_GW_BACKEND_TRACE_LOG.sql;
+source HUB_GW_SLOW_REQ.sql;
+source HUB_GW_FEATURE_FLAG.sql;
+source HUB_GW_CONFIG_VERSION.sql;
 source HUB_GW_SECURITY_CONFIG.sql;
 source HUB_GW_IP_ACCESS_CONFIG.sql;
 source HUB_GW_UA_ACCESS_CONFIG.sql;
@@ -54,10 +58,19 @@ source HUB_MONITOR_JVM_THR_STATE.sql;
 source HUB_MONITOR_JVM_DEADLOCK.sql;
 source HUB_MONITOR_JVM_CLASS.sql;
 source HUB_MONITOR_APP_DATA.sql;
+source HUB_MONITOR_ALERT_RULE.sql;
+source HUB_MONITOR_ALERT_SILENCE.sql;
+source HUB_MONITOR_ALERT_EVENT.sql;
+source HUB_MONITOR_JVM_METRIC_5M.sql;
+source HUB_MONITOR_JVM_METRIC_1H.sql;
+source HUB_MONITOR_JVM_DIAG_TASK.sql;
 source HUB_TUNNEL_SERVER.sql;
 source HUB_TUNNEL_SERVER_NODE.sql;
 source HUB_TUNNEL_CLIENT.sql;
 source HUB_TUNNEL_SERVICE.sql;
+source HUB_SAVED_QUERY.sql;
+source HUB_DASHBOARD_LAYOUT.sql;
+source HUB_QUERY_REPORT.sql;
 
 -- =====================================================
 -- 字段长度调整：支持多服务定义ID和服务名称（多服务场景）
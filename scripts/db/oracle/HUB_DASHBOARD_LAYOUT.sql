@@ -0,0 +1,36 @@
+CREATE TABLE HUB_DASHBOARD_LAYOUT (
+  -- 主键和租户
+  tenantId VARCHAR2(32) NOT NULL, -- 租户ID，主键
+  layoutId VARCHAR2(32) NOT NULL, -- 布局ID，主键，可通过布局ID分享
+  
+  -- 布局信息
+  layoutName VARCHAR2(200) NOT NULL, -- 布局名称
+  dashboardCode VARCHAR2(50) NOT NULL, -- 看板编码，区分不同的监控页面
+  layoutConfig CLOB, -- 网格布局，JSON格式，记录各组件的位置和尺寸
+  widgetConfig CLOB, -- 组件配置，JSON数组，记录各组件的指标、时间范围和刷新间隔
+  timeRangeMinutes NUMBER(10) DEFAULT 60 NOT NULL, -- 默认时间范围（分钟），组件未单独配置时使用
+  refreshIntervalSeconds NUMBER(10) DEFAULT 0 NOT NULL, -- 默认刷新间隔（秒），0表示不自动刷新
+  ownerUserId VARCHAR2(32) NOT NULL, -- 所属用户ID
+  shareFlag VARCHAR2(1) DEFAULT 'N' NOT NULL, -- 是否共享给租户内其他用户：Y是，N否
+  defaultFlag VARCHAR2(1) DEFAULT 'N' NOT NULL, -- 是否为所属用户在该看板的默认布局：Y是，N否
+  
+  -- 通用字段
+  addTime DATE DEFAULT SYSDATE NOT NULL, -- 创建时间
+  addWho VARCHAR2(32) NOT NULL, -- 创建人ID
+  editTime DATE DEFAULT SYSDATE NOT NULL, -- 最后修改时间
+  editWho VARCHAR2(32) NOT NULL, -- 最后修改人ID
+  oprSeqFlag VARCHAR2(32) NOT NULL, -- 操作序列标识
+  currentVersion NUMBER(10) DEFAULT 1 NOT NULL, -- 当前版本号
+  activeFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL, -- 活动状态标记：N非活动，Y活动
+  noteText VARCHAR2(500), -- 备注信息
+  
+  CONSTRAINT PK_DASHBOARD_LAYOUT PRIMARY KEY (tenantId, layoutId)
+);
+
+COMMENT ON TABLE HUB_DASHBOARD_LAYOUT IS '看板布局表 - 存储用户的监控看板布局和组件配置';
+COMMENT ON COLUMN HUB_DASHBOARD_LAYOUT.layoutConfig IS '网格布局，JSON格式，记录各组件的位置和尺寸';
+COMMENT ON COLUMN HUB_DASHBOARD_LAYOUT.widgetConfig IS '组件配置，JSON数组，记录各组件的指标、时间范围和刷新间隔';
+COMMENT ON COLUMN HUB_DASHBOARD_LAYOUT.refreshIntervalSeconds IS '默认刷新间隔（秒），0表示不自动刷新';
+COMMENT ON COLUMN HUB_DASHBOARD_LAYOUT.defaultFlag IS '是否为所属用户在该看板的默认布局：Y是，N否';
+
+CREATE INDEX IDX_DASHBOARD_LAYOUT_OWNER ON HUB_DASHBOARD_LAYOUT (tenantId, ownerUserId, dashboardCode);
@@ -97,4 +97,5 @@ CREATE INDEX idx_gw_log_client_ip ON HUB_GW_ACCESS_LOG (clientIpAddress, gateway
 CREATE INDEX idx_gw_log_status_time ON HUB_GW_ACCESS_LOG (gatewayStatusCode, gatewayStartProcessingTime);
 CREATE INDEX idx_gw_log_proxy_type ON HUB_GW_ACCESS_LOG (proxyType, gatewayStartProcessingTime);
 
-
\ No newline at end of file
+-- 限流排队等待时间：使用独立ALTER语句，避免修改历史建表语句，保证已有表可以增量升级
+ALTER TABLE HUB_GW_ACCESS_LOG ADD (queueTimeMs NUMBER(10));
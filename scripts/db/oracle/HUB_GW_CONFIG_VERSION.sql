@@ -0,0 +1,23 @@
+CREATE TABLE HUB_GW_CONFIG_VERSION (
+                                      tenantId VARCHAR2(32) NOT NULL, -- 租户ID
+                                      gatewayInstanceId VARCHAR2(32) NOT NULL, -- 网关实例ID
+                                      configVersion VARCHAR2(32) NOT NULL, -- 配置版本号(配置内容摘要)
+
+                                      configData CLOB NOT NULL, -- 网关完整配置,JSON格式,使用默认密钥加密存储
+                                      operator VARCHAR2(32), -- 发布人
+                                      appliedTime DATE NOT NULL, -- 生效时间,同一版本重复发布时刷新
+                                      appliedSeq NUMBER(19) DEFAULT 0 NOT NULL, -- 生效序号(纳秒时间戳),版本历史按该字段排序
+
+                                      addTime DATE DEFAULT SYSDATE NOT NULL, -- 创建时间
+                                      addWho VARCHAR2(32) NOT NULL, -- 创建人ID
+                                      editTime DATE DEFAULT SYSDATE NOT NULL, -- 最后修改时间
+                                      editWho VARCHAR2(32) NOT NULL, -- 最后修改人ID
+                                      oprSeqFlag VARCHAR2(32) NOT NULL, -- 操作序列标识
+                                      currentVersion NUMBER(10) DEFAULT 1 NOT NULL, -- 当前版本号
+                                      activeFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL, -- 活动状态标记(N非活动,Y活动)
+
+                                      CONSTRAINT PK_GW_CONFIG_VERSION PRIMARY KEY (tenantId, gatewayInstanceId, configVersion)
+);
+CREATE INDEX IDX_GW_CFGVER_SEQ ON HUB_GW_CONFIG_VERSION(tenantId, gatewayInstanceId, appliedSeq);
+COMMENT ON TABLE HUB_GW_CONFIG_VERSION IS '网关配置版本表 - 记录实例已生效的配置版本历史,用于回滚';
+COMMENT ON COLUMN HUB_GW_CONFIG_VERSION.configData IS '网关完整配置,JSON格式,使用默认密钥加密存储';
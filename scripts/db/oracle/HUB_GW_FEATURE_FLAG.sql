@@ -0,0 +1,33 @@
+CREATE TABLE HUB_GW_FEATURE_FLAG (
+                                      tenantId VARCHAR2(32) NOT NULL, -- 租户ID
+                                      featureFlagId VARCHAR2(32) NOT NULL, -- 功能开关ID
+                                      gatewayInstanceId VARCHAR2(32), -- 网关实例ID(为空表示租户下全部实例)
+                                      routeConfigId VARCHAR2(32), -- 路由配置ID(为空表示实例下全部路由)
+                                      flagKey VARCHAR2(100) NOT NULL, -- 开关标识，过滤器通过该标识查询开关
+                                      flagName VARCHAR2(100) NOT NULL, -- 开关名称
+                                      flagDesc VARCHAR2(200), -- 开关描述
+
+    -- 开关规则
+                                      flagEnabled VARCHAR2(1) DEFAULT 'N' NOT NULL, -- 是否开启(N关闭,Y开启)
+                                      rolloutPercent NUMBER(10) DEFAULT 100 NOT NULL, -- 放量百分比(0-100)
+                                      bucketBy VARCHAR2(20) DEFAULT 'CLIENT_IP' NOT NULL, -- 放量分桶依据(CLIENT_IP,HEADER,QUERY,TRACE_ID)
+                                      bucketKey VARCHAR2(100), -- 分桶键名(bucketBy为HEADER/QUERY时的请求头或查询参数名)
+                                      logEvaluation VARCHAR2(1) DEFAULT 'N' NOT NULL, -- 是否将判定结果写入访问日志扩展属性(N否,Y是)
+
+                                      extProperty CLOB, -- 扩展属性,JSON格式
+
+                                      addTime DATE DEFAULT SYSDATE NOT NULL, -- 创建时间
+                                      addWho VARCHAR2(32) NOT NULL, -- 创建人ID
+                                      editTime DATE DEFAULT SYSDATE NOT NULL, -- 最后修改时间
+                                      editWho VARCHAR2(32) NOT NULL, -- 最后修改人ID
+                                      oprSeqFlag VARCHAR2(32) NOT NULL, -- 操作序列标识
+                                      currentVersion NUMBER(10) DEFAULT 1 NOT NULL, -- 当前版本号
+                                      activeFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL, -- 活动状态标记(N非活动,Y活动)
+                                      noteText VARCHAR2(500), -- 备注信息
+
+                                      CONSTRAINT PK_GW_FEATURE_FLAG PRIMARY KEY (tenantId, featureFlagId)
+);
+CREATE INDEX IDX_GW_FLAG_KEY ON HUB_GW_FEATURE_FLAG(tenantId, flagKey);
+CREATE INDEX IDX_GW_FLAG_INST ON HUB_GW_FEATURE_FLAG(gatewayInstanceId);
+CREATE INDEX IDX_GW_FLAG_ROUTE ON HUB_GW_FEATURE_FLAG(routeConfigId);
+COMMENT ON TABLE HUB_GW_FEATURE_FLAG IS '网关功能开关表 - 按实例/路由维度控制过滤器等功能按比例放量';
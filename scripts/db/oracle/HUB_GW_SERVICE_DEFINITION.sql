@@ -54,3 +54,6 @@ CREATE INDEX IDX_GW_SVC_STRATEGY ON HUB_GW_SERVICE_DEFINITION(loadBalanceStrateg
 CREATE INDEX IDX_GW_SVC_HEALTH ON HUB_GW_SERVICE_DEFINITION(healthCheckEnabled);
 CREATE INDEX IDX_GW_SVC_PROXY ON HUB_GW_SERVICE_DEFINITION(proxyConfigId);
 COMMENT ON TABLE HUB_GW_SERVICE_DEFINITION IS '服务定义表 - 根据ServiceConfig结构设计,存储完整的服务配置';
+
+-- 上游TLS配置：使用独立ALTER语句，避免修改历史建表语句，保证已有表可以增量升级
+ALTER TABLE HUB_GW_SERVICE_DEFINITION ADD (upstreamTlsConfig CLOB);
@@ -0,0 +1,58 @@
+CREATE TABLE HUB_GW_SLOW_REQ (
+                                   tenantId VARCHAR2(32) NOT NULL,              -- 租户ID
+                                   slowReqId VARCHAR2(32) NOT NULL,             -- 慢请求记录ID
+                                   traceId VARCHAR2(64) NOT NULL,               -- 链路追踪ID，关联主表 HUB_GW_ACCESS_LOG.traceId
+
+                                   -- 网关和路由信息
+                                   gatewayInstanceId VARCHAR2(32),              -- 网关实例ID
+                                   gatewayInstanceName VARCHAR2(300),           -- 网关实例名称
+                                   gatewayNodeIp VARCHAR2(50),                  -- 网关节点IP
+                                   routeConfigId VARCHAR2(32),                  -- 路由配置ID
+                                   routeName VARCHAR2(300),                     -- 路由名称
+                                   serviceDefinitionId VARCHAR2(32),            -- 服务定义ID
+                                   serviceName VARCHAR2(300),                   -- 服务名称
+
+                                   -- 请求信息
+                                   requestMethod VARCHAR2(10),                  -- 请求方法
+                                   requestPath VARCHAR2(1000),                  -- 请求路径
+                                   clientIpAddress VARCHAR2(50),                -- 客户端IP
+                                   gatewayStatusCode NUMBER(10),                -- 网关状态码
+                                   backendStatusCode NUMBER(10),                -- 后端状态码（0表示未调用后端）
+
+                                   -- 耗时信息
+                                   thresholdMs NUMBER(10) NOT NULL,             -- 触发时的慢请求阈值（毫秒）
+                                   totalProcessingTimeMs NUMBER(10) NOT NULL,   -- 总处理时间（毫秒）
+                                   gatewayProcessingTimeMs NUMBER(10),          -- 网关自身处理时间（毫秒）
+                                   backendResponseTimeMs NUMBER(10),            -- 后端响应时间（毫秒）
+                                   requestStartTime TIMESTAMP(3) NOT NULL,      -- 网关开始处理时间
+                                   requestFinishTime TIMESTAMP(3),              -- 网关处理完成时间
+
+                                   -- 诊断信息
+                                   timingBreakdown CLOB,                        -- 耗时分解（JSON格式）
+                                   upstreamConnState CLOB,                      -- 上游连接状态（JSON数组，每次转发尝试一条）
+                                   goroutineCount NUMBER(10) DEFAULT 0,         -- 超过阈值时的协程数量
+                                   goroutineSnapshotRef VARCHAR2(500),          -- 协程快照文件引用
+                                   detectedTime TIMESTAMP(3),                   -- 看门狗检测到慢请求的时间
+                                   errorMessage CLOB,                           -- 错误信息
+
+                                   -- 扩展信息
+                                   extProperty CLOB,                            -- 扩展属性(JSON格式)
+
+                                   -- 标准数据库字段
+                                   addTime TIMESTAMP DEFAULT SYSTIMESTAMP NOT NULL, -- 记录创建时间
+                                   addWho VARCHAR2(32) NOT NULL,                -- 记录创建者
+                                   editTime TIMESTAMP DEFAULT SYSTIMESTAMP NOT NULL, -- 记录修改时间
+                                   editWho VARCHAR2(32) NOT NULL,               -- 记录修改者
+                                   oprSeqFlag VARCHAR2(32) NOT NULL,            -- 操作序列标识
+                                   currentVersion NUMBER(10) DEFAULT 1 NOT NULL,-- 当前版本号
+                                   activeFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL, -- 活动状态标记
+                                   noteText VARCHAR2(500),                      -- 备注信息
+
+                                   CONSTRAINT pk_HUB_GW_SLOW_REQ PRIMARY KEY (tenantId, slowReqId)
+);
+
+COMMENT ON TABLE HUB_GW_SLOW_REQ IS '网关慢请求诊断表 - 记录超过慢请求阈值的请求及其诊断信息';
+
+CREATE INDEX idx_gw_slowreq_trace ON HUB_GW_SLOW_REQ (tenantId, traceId);
+CREATE INDEX idx_gw_slowreq_route ON HUB_GW_SLOW_REQ (tenantId, routeConfigId, requestStartTime);
+CREATE INDEX idx_gw_slowreq_time ON HUB_GW_SLOW_REQ (tenantId, requestStartTime);
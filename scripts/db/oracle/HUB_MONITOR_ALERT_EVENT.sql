@@ -0,0 +1,52 @@
+CREATE TABLE HUB_MONITOR_ALERT_EVENT (
+  -- 主键和租户
+  tenantId VARCHAR2(32) NOT NULL, -- 租户ID，主键
+  alertEventId VARCHAR2(32) NOT NULL, -- 告警事件ID，主键
+  
+  -- 规则信息
+  alertRuleId VARCHAR2(32) NOT NULL, -- 告警规则ID
+  ruleName VARCHAR2(200), -- 规则名称（冗余字段，便于查询显示）
+  metricType VARCHAR2(30) NOT NULL, -- 监控指标
+  alertLevel VARCHAR2(20) NOT NULL, -- 告警级别
+  
+  -- 实例信息
+  jvmResourceId VARCHAR2(100) NOT NULL, -- JVM资源ID
+  applicationName VARCHAR2(100), -- 应用名称
+  hostName VARCHAR2(100), -- 主机名
+  hostIpAddress VARCHAR2(50), -- 主机IP地址
+  groupKey VARCHAR2(200), -- 通知分组键
+  
+  -- 事件状态
+  eventStatus VARCHAR2(20) NOT NULL, -- 事件状态：FIRING告警中/RESOLVED已恢复
+  metricValue NUMBER(18,4) DEFAULT 0 NOT NULL, -- 最近一次评估的指标值
+  thresholdValue NUMBER(18,4) DEFAULT 0 NOT NULL, -- 触发时的阈值
+  messageText VARCHAR2(1000), -- 告警描述
+  firstTriggerTime DATE NOT NULL, -- 首次触发时间
+  lastTriggerTime DATE NOT NULL, -- 最近一次满足条件的时间
+  resolvedTime DATE, -- 恢复时间
+  
+  -- 通知信息
+  notifyCount NUMBER(10) DEFAULT 0 NOT NULL, -- 通知次数
+  lastNotifyTime DATE, -- 最近通知时间
+  silencedFlag VARCHAR2(1) DEFAULT 'N' NOT NULL, -- 是否被静默：Y是，N否
+  alertLogId VARCHAR2(64), -- 最近一次通知的告警日志ID（HUB_ALERT_LOG）
+  
+  -- 通用字段
+  addTime DATE DEFAULT SYSDATE NOT NULL, -- 创建时间
+  addWho VARCHAR2(32) NOT NULL, -- 创建人ID
+  editTime DATE DEFAULT SYSDATE NOT NULL, -- 最后修改时间
+  editWho VARCHAR2(32) NOT NULL, -- 最后修改人ID
+  oprSeqFlag VARCHAR2(32) NOT NULL, -- 操作序列标识
+  currentVersion NUMBER(10) DEFAULT 1 NOT NULL, -- 当前版本号
+  activeFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL, -- 活动状态标记：N非活动，Y活动
+  
+  CONSTRAINT PK_MONITOR_ALERT_EVENT PRIMARY KEY (tenantId, alertEventId)
+);
+
+COMMENT ON TABLE HUB_MONITOR_ALERT_EVENT IS 'JVM告警事件表 - 记录告警的触发、通知和恢复历史';
+COMMENT ON COLUMN HUB_MONITOR_ALERT_EVENT.eventStatus IS '事件状态：FIRING告警中/RESOLVED已恢复';
+COMMENT ON COLUMN HUB_MONITOR_ALERT_EVENT.silencedFlag IS '是否被静默：Y是，N否';
+
+CREATE INDEX IDX_MON_ALERT_EVENT_RULE ON HUB_MONITOR_ALERT_EVENT (tenantId, alertRuleId, eventStatus);
+CREATE INDEX IDX_MON_ALERT_EVENT_RES ON HUB_MONITOR_ALERT_EVENT (jvmResourceId);
+CREATE INDEX IDX_MON_ALERT_EVENT_TIME ON HUB_MONITOR_ALERT_EVENT (firstTriggerTime);
@@ -0,0 +1,51 @@
+CREATE TABLE HUB_MONITOR_ALERT_RULE (
+  -- 主键和租户
+  tenantId VARCHAR2(32) NOT NULL, -- 租户ID，主键
+  alertRuleId VARCHAR2(32) NOT NULL, -- 告警规则ID，主键
+  
+  -- 规则定义
+  ruleName VARCHAR2(200) NOT NULL, -- 规则名称
+  metricType VARCHAR2(30) NOT NULL, -- 监控指标：HEAP_USAGE堆内存使用率/NON_HEAP_USAGE非堆内存使用率/FGC_COUNT Full GC次数/GC_COUNT GC总次数/THREAD_COUNT线程数/DEADLOCK死锁线程数/GATEWAY_ERROR_RATE网关5xx错误率
+  evaluateMode VARCHAR2(20) DEFAULT 'THRESHOLD' NOT NULL, -- 评估方式：THRESHOLD阈值/RATE每分钟变化量/ANOMALY基线偏离(z-score)
+  compareOperator VARCHAR2(10) DEFAULT 'GT' NOT NULL, -- 比较运算符：GT大于/GTE大于等于/LT小于/LTE小于等于
+  thresholdValue NUMBER(18,4) DEFAULT 0 NOT NULL, -- 阈值
+  durationSeconds NUMBER(10) DEFAULT 0 NOT NULL, -- 持续时长（秒），阈值方式下持续满足条件才触发，0表示最新采集值满足即触发
+  windowSeconds NUMBER(10) DEFAULT 300 NOT NULL, -- 速率计算窗口（秒），RATE方式使用
+  
+  -- 匹配范围
+  serviceGroupId VARCHAR2(32), -- 服务分组ID，为空匹配所有分组
+  applicationName VARCHAR2(100), -- 应用名称，为空匹配所有应用
+  
+  -- 通知配置
+  groupBy VARCHAR2(20) DEFAULT 'APPLICATION' NOT NULL, -- 通知分组方式：APPLICATION按应用合并/INSTANCE按实例单独通知
+  alertLevel VARCHAR2(20) DEFAULT 'WARN' NOT NULL, -- 告警级别：INFO/WARN/ERROR/CRITICAL
+  channelName VARCHAR2(100), -- 告警渠道名称，为空使用默认渠道
+  repeatIntervalSeconds NUMBER(10) DEFAULT 3600 NOT NULL, -- 重复通知间隔（秒），0表示只通知一次
+  notifyResolvedFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL, -- 恢复时是否通知：Y是，N否
+  enabledFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL, -- 是否启用：Y启用，N停用
+  lastEvalTime DATE, -- 最近评估时间
+  
+  -- 通用字段
+  addTime DATE DEFAULT SYSDATE NOT NULL, -- 创建时间
+  addWho VARCHAR2(32) NOT NULL, -- 创建人ID
+  editTime DATE DEFAULT SYSDATE NOT NULL, -- 最后修改时间
+  editWho VARCHAR2(32) NOT NULL, -- 最后修改人ID
+  oprSeqFlag VARCHAR2(32) NOT NULL, -- 操作序列标识
+  currentVersion NUMBER(10) DEFAULT 1 NOT NULL, -- 当前版本号
+  activeFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL, -- 活动状态标记：N非活动，Y活动
+  noteText VARCHAR2(500), -- 备注信息
+  
+  CONSTRAINT PK_MONITOR_ALERT_RULE PRIMARY KEY (tenantId, alertRuleId)
+);
+
+COMMENT ON TABLE HUB_MONITOR_ALERT_RULE IS 'JVM告警规则表 - 基于JVM监控数据的阈值和变化速率告警规则';
+COMMENT ON COLUMN HUB_MONITOR_ALERT_RULE.metricType IS '监控指标：HEAP_USAGE堆内存使用率/NON_HEAP_USAGE非堆内存使用率/FGC_COUNT Full GC次数/GC_COUNT GC总次数/THREAD_COUNT线程数/DEADLOCK死锁线程数/GATEWAY_ERROR_RATE网关5xx错误率';
+COMMENT ON COLUMN HUB_MONITOR_ALERT_RULE.evaluateMode IS '评估方式：THRESHOLD阈值/RATE每分钟变化量/ANOMALY基线偏离(z-score)';
+COMMENT ON COLUMN HUB_MONITOR_ALERT_RULE.compareOperator IS '比较运算符：GT大于/GTE大于等于/LT小于/LTE小于等于';
+COMMENT ON COLUMN HUB_MONITOR_ALERT_RULE.groupBy IS '通知分组方式：APPLICATION按应用合并/INSTANCE按实例单独通知';
+COMMENT ON COLUMN HUB_MONITOR_ALERT_RULE.alertLevel IS '告警级别：INFO/WARN/ERROR/CRITICAL';
+COMMENT ON COLUMN HUB_MONITOR_ALERT_RULE.notifyResolvedFlag IS '恢复时是否通知：Y是，N否';
+COMMENT ON COLUMN HUB_MONITOR_ALERT_RULE.enabledFlag IS '是否启用：Y启用，N停用';
+
+CREATE INDEX IDX_MON_ALERT_RULE_ENABLED ON HUB_MONITOR_ALERT_RULE (enabledFlag, activeFlag);
+CREATE INDEX IDX_MON_ALERT_RULE_METRIC ON HUB_MONITOR_ALERT_RULE (tenantId, metricType);
@@ -0,0 +1,30 @@
+CREATE TABLE HUB_MONITOR_ALERT_SILENCE (
+  -- 主键和租户
+  tenantId VARCHAR2(32) NOT NULL, -- 租户ID，主键
+  alertSilenceId VARCHAR2(32) NOT NULL, -- 静默ID，主键
+  
+  -- 匹配条件
+  alertRuleId VARCHAR2(32), -- 告警规则ID，为空匹配所有规则
+  applicationName VARCHAR2(100), -- 应用名称，为空匹配所有应用
+  jvmResourceId VARCHAR2(100), -- JVM资源ID，为空匹配所有实例
+  
+  -- 静默时间
+  startTime DATE NOT NULL, -- 静默开始时间
+  endTime DATE NOT NULL, -- 静默结束时间
+  reasonText VARCHAR2(500), -- 静默原因
+  
+  -- 通用字段
+  addTime DATE DEFAULT SYSDATE NOT NULL, -- 创建时间
+  addWho VARCHAR2(32) NOT NULL, -- 创建人ID
+  editTime DATE DEFAULT SYSDATE NOT NULL, -- 最后修改时间
+  editWho VARCHAR2(32) NOT NULL, -- 最后修改人ID
+  oprSeqFlag VARCHAR2(32) NOT NULL, -- 操作序列标识
+  currentVersion NUMBER(10) DEFAULT 1 NOT NULL, -- 当前版本号
+  activeFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL, -- 活动状态标记：N非活动，Y活动
+  
+  CONSTRAINT PK_MONITOR_ALERT_SILENCE PRIMARY KEY (tenantId, alertSilenceId)
+);
+
+COMMENT ON TABLE HUB_MONITOR_ALERT_SILENCE IS 'JVM告警静默表 - 在时间窗口内屏蔽匹配的告警通知';
+
+CREATE INDEX IDX_MON_ALERT_SILENCE_TIME ON HUB_MONITOR_ALERT_SILENCE (tenantId, endTime);
@@ -0,0 +1,41 @@
+CREATE TABLE HUB_MONITOR_JVM_DIAG_TASK (
+  -- 主键
+  tenantId VARCHAR2(32) NOT NULL, -- 租户ID
+  diagTaskId VARCHAR2(32) NOT NULL, -- 诊断任务ID
+  
+  -- 采集请求
+  jvmResourceId VARCHAR2(100) NOT NULL, -- JVM资源ID
+  applicationName VARCHAR2(100), -- 应用名称，创建任务时从资源信息复制
+  diagType VARCHAR2(30) NOT NULL, -- 诊断类型：THREAD_DUMP线程转储/HEAP_HISTOGRAM类直方图
+  taskStatus VARCHAR2(20) DEFAULT 'PENDING' NOT NULL, -- 任务状态：PENDING待下发/DISPATCHED已下发/COMPLETED已完成/FAILED失败/EXPIRED已过期
+  requestTime DATE NOT NULL, -- 请求时间
+  expireTime DATE NOT NULL, -- 过期时间，待下发或已下发的任务超过该时间未完成则过期
+  dispatchTime DATE, -- 下发时间，采集端心跳领取任务的时间
+  completeTime DATE, -- 完成时间
+  
+  -- 采集结果
+  resultText CLOB, -- 采集结果原文（jstack/jmap -histo 格式）
+  resultSize NUMBER(10) DEFAULT 0 NOT NULL, -- 采集结果字节数
+  threadCount NUMBER(10) DEFAULT 0 NOT NULL, -- 线程转储中的线程数
+  blockedThreadCount NUMBER(10) DEFAULT 0 NOT NULL, -- 线程转储中BLOCKED状态的线程数
+  errorMessage VARCHAR2(1000), -- 采集失败原因
+  
+  -- 通用字段
+  addTime DATE DEFAULT SYSDATE NOT NULL, -- 创建时间
+  addWho VARCHAR2(32) NOT NULL, -- 创建人ID
+  editTime DATE DEFAULT SYSDATE NOT NULL, -- 最后修改时间
+  editWho VARCHAR2(32) NOT NULL, -- 最后修改人ID
+  oprSeqFlag VARCHAR2(32) NOT NULL, -- 操作序列标识
+  currentVersion NUMBER(10) DEFAULT 1 NOT NULL, -- 当前版本号
+  activeFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL, -- 活动状态标记：N非活动，Y活动
+  noteText VARCHAR2(500), -- 备注信息
+  
+  CONSTRAINT PK_MONITOR_JVM_DIAG_TASK PRIMARY KEY (tenantId, diagTaskId)
+);
+
+COMMENT ON TABLE HUB_MONITOR_JVM_DIAG_TASK IS 'JVM诊断采集任务表 - 线程转储/类直方图的按需采集请求和结果';
+COMMENT ON COLUMN HUB_MONITOR_JVM_DIAG_TASK.diagType IS '诊断类型：THREAD_DUMP线程转储/HEAP_HISTOGRAM类直方图';
+COMMENT ON COLUMN HUB_MONITOR_JVM_DIAG_TASK.taskStatus IS '任务状态：PENDING待下发/DISPATCHED已下发/COMPLETED已完成/FAILED失败/EXPIRED已过期';
+
+CREATE INDEX IDX_MON_DIAG_RESOURCE ON HUB_MONITOR_JVM_DIAG_TASK (tenantId, jvmResourceId, taskStatus);
+CREATE INDEX IDX_MON_DIAG_REQ_TIME ON HUB_MONITOR_JVM_DIAG_TASK (requestTime);
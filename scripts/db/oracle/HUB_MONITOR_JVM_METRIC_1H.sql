@@ -0,0 +1,41 @@
+CREATE TABLE HUB_MONITOR_JVM_METRIC_1H (
+  -- 主键
+  tenantId VARCHAR2(32) NOT NULL, -- 租户ID，主键
+  jvmResourceId VARCHAR2(100) NOT NULL, -- JVM资源ID，主键
+  bucketTime DATE NOT NULL, -- 聚合时间段起点（1小时对齐），主键
+  
+  -- 内存指标
+  memorySampleCount NUMBER(10) DEFAULT 0 NOT NULL, -- 参与聚合的堆内存采集点数
+  heapUsageAvg NUMBER(5,2) DEFAULT 0 NOT NULL, -- 堆内存使用率平均值（百分比）
+  heapUsageMax NUMBER(5,2) DEFAULT 0 NOT NULL, -- 堆内存使用率最大值（百分比）
+  heapUsedBytesAvg NUMBER(19) DEFAULT 0 NOT NULL, -- 堆内存已使用平均值（字节）
+  heapUsedBytesMax NUMBER(19) DEFAULT 0 NOT NULL, -- 堆内存已使用最大值（字节）
+  nonHeapSampleCount NUMBER(10) DEFAULT 0 NOT NULL, -- 参与聚合的非堆内存采集点数
+  nonHeapUsageAvg NUMBER(5,2) DEFAULT 0 NOT NULL, -- 非堆内存使用率平均值（百分比）
+  nonHeapUsageMax NUMBER(5,2) DEFAULT 0 NOT NULL, -- 非堆内存使用率最大值（百分比）
+  
+  -- 线程指标
+  threadSampleCount NUMBER(10) DEFAULT 0 NOT NULL, -- 参与聚合的线程采集点数
+  threadCountAvg NUMBER(10,2) DEFAULT 0 NOT NULL, -- 线程数平均值
+  threadCountMax NUMBER(10) DEFAULT 0 NOT NULL, -- 线程数最大值
+  
+  -- GC指标
+  ygcCount NUMBER(19) DEFAULT 0 NOT NULL, -- 时间段内年轻代GC次数
+  fgcCount NUMBER(19) DEFAULT 0 NOT NULL, -- 时间段内Full GC次数
+  gcTimeSeconds NUMBER(12,3) DEFAULT 0 NOT NULL, -- 时间段内GC耗时（秒）
+  
+  -- 通用字段
+  addTime DATE DEFAULT SYSDATE NOT NULL, -- 创建时间
+  addWho VARCHAR2(32) NOT NULL, -- 创建人ID
+  editTime DATE DEFAULT SYSDATE NOT NULL, -- 最后修改时间
+  editWho VARCHAR2(32) NOT NULL, -- 最后修改人ID
+  oprSeqFlag VARCHAR2(32) NOT NULL, -- 操作序列标识
+  currentVersion NUMBER(10) DEFAULT 1 NOT NULL, -- 当前版本号
+  activeFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL, -- 活动状态标记：N非活动，Y活动
+  
+  CONSTRAINT PK_MONITOR_JVM_METRIC_1H PRIMARY KEY (tenantId, jvmResourceId, bucketTime)
+);
+
+COMMENT ON TABLE HUB_MONITOR_JVM_METRIC_1H IS 'JVM监控1小时聚合表 - 由5分钟聚合数据按小时汇总';
+
+CREATE INDEX IDX_MON_JVM_1H_TIME ON HUB_MONITOR_JVM_METRIC_1H (bucketTime);
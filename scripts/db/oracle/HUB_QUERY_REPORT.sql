@@ -0,0 +1,44 @@
+CREATE TABLE HUB_QUERY_REPORT (
+  -- 主键和租户
+  tenantId VARCHAR2(32) NOT NULL, -- 租户ID，主键
+  reportId VARCHAR2(32) NOT NULL, -- 报表ID，主键
+  
+  -- 关联信息
+  savedQueryId VARCHAR2(32) NOT NULL, -- 保存查询ID
+  queryName VARCHAR2(200), -- 查询名称（冗余字段，便于查询显示）
+  triggerType VARCHAR2(20) NOT NULL, -- 触发方式：SCHEDULE定时/MANUAL手动
+  
+  -- 生成结果
+  reportStatus VARCHAR2(20) NOT NULL, -- 报表状态：SUCCESS成功/FAILED失败
+  rowCount NUMBER(10) DEFAULT 0 NOT NULL, -- 报表行数
+  reportFormat VARCHAR2(10), -- 报表格式：csv/xlsx
+  fileName VARCHAR2(300), -- 报表文件名
+  filePath VARCHAR2(500), -- 报表文件存储路径
+  fileSize NUMBER(19) DEFAULT 0 NOT NULL, -- 报表文件大小（字节）
+  emailStatus VARCHAR2(20), -- 邮件发送状态：NONE未发送/SUCCESS成功/FAILED失败
+  errorMessage CLOB, -- 错误信息
+  
+  -- 执行时间
+  startTime DATE NOT NULL, -- 开始时间
+  endTime DATE, -- 结束时间
+  durationMs NUMBER(19) DEFAULT 0 NOT NULL, -- 耗时（毫秒）
+  
+  -- 通用字段
+  addTime DATE DEFAULT SYSDATE NOT NULL, -- 创建时间
+  addWho VARCHAR2(32) NOT NULL, -- 创建人ID
+  editTime DATE DEFAULT SYSDATE NOT NULL, -- 最后修改时间
+  editWho VARCHAR2(32) NOT NULL, -- 最后修改人ID
+  oprSeqFlag VARCHAR2(32) NOT NULL, -- 操作序列标识
+  currentVersion NUMBER(10) DEFAULT 1 NOT NULL, -- 当前版本号
+  activeFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL, -- 活动状态标记：N非活动，Y活动
+  
+  CONSTRAINT PK_QUERY_REPORT PRIMARY KEY (tenantId, reportId)
+);
+
+COMMENT ON TABLE HUB_QUERY_REPORT IS '查询报表表 - 记录保存查询生成的报表文件和发送结果';
+COMMENT ON COLUMN HUB_QUERY_REPORT.triggerType IS '触发方式：SCHEDULE定时/MANUAL手动';
+COMMENT ON COLUMN HUB_QUERY_REPORT.reportStatus IS '报表状态：SUCCESS成功/FAILED失败';
+COMMENT ON COLUMN HUB_QUERY_REPORT.emailStatus IS '邮件发送状态：NONE未发送/SUCCESS成功/FAILED失败';
+
+CREATE INDEX IDX_QUERY_REPORT_QUERY ON HUB_QUERY_REPORT (tenantId, savedQueryId);
+CREATE INDEX IDX_QUERY_REPORT_TIME ON HUB_QUERY_REPORT (startTime);
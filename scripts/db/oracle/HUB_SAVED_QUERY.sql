@@ -0,0 +1,48 @@
+CREATE TABLE HUB_SAVED_QUERY (
+  -- 主键和租户
+  tenantId VARCHAR2(32) NOT NULL, -- 租户ID，主键
+  savedQueryId VARCHAR2(32) NOT NULL, -- 保存查询ID，主键
+  
+  -- 查询信息
+  queryName VARCHAR2(200) NOT NULL, -- 查询名称
+  queryType VARCHAR2(50) NOT NULL, -- 查询类型：ACCESS_LOG访问日志/MONITOR_OVERVIEW监控概览/MONITOR_CHART监控图表
+  queryParams CLOB, -- 查询过滤条件，JSON格式，与对应查询接口参数一致
+  timeRangeMinutes NUMBER(10) DEFAULT 0 NOT NULL, -- 相对时间范围（分钟），大于0时执行时按当前时间计算startTime/endTime
+  ownerUserId VARCHAR2(32) NOT NULL, -- 所属用户ID，定时执行时以该用户身份查询
+  shareFlag VARCHAR2(1) DEFAULT 'N' NOT NULL, -- 是否共享给租户内其他用户：Y是，N否
+  
+  -- 报表调度配置
+  scheduleEnabled VARCHAR2(1) DEFAULT 'N' NOT NULL, -- 是否启用定时报表：Y是，N否
+  cronExpression VARCHAR2(100), -- Cron表达式
+  reportFormat VARCHAR2(10) DEFAULT 'xlsx' NOT NULL, -- 报表格式：csv/xlsx
+  reportColumns CLOB, -- 报表列，逗号分隔或JSON数组，为空时导出全部字段
+  maxRows NUMBER(10) DEFAULT 0 NOT NULL, -- 报表最大行数，0表示使用服务端上限
+  emailChannel VARCHAR2(100), -- 邮件告警渠道名称，为空时使用默认渠道
+  emailTo VARCHAR2(1000), -- 报表收件人，逗号分隔，为空时仅生成报表记录
+  
+  -- 最近一次报表
+  lastReportTime DATE, -- 最近报表生成时间
+  lastReportStatus VARCHAR2(20), -- 最近报表状态：SUCCESS/FAILED
+  
+  -- 通用字段
+  addTime DATE DEFAULT SYSDATE NOT NULL, -- 创建时间
+  addWho VARCHAR2(32) NOT NULL, -- 创建人ID
+  editTime DATE DEFAULT SYSDATE NOT NULL, -- 最后修改时间
+  editWho VARCHAR2(32) NOT NULL, -- 最后修改人ID
+  oprSeqFlag VARCHAR2(32) NOT NULL, -- 操作序列标识
+  currentVersion NUMBER(10) DEFAULT 1 NOT NULL, -- 当前版本号
+  activeFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL, -- 活动状态标记：N非活动，Y活动
+  noteText VARCHAR2(500), -- 备注信息
+  
+  CONSTRAINT PK_SAVED_QUERY PRIMARY KEY (tenantId, savedQueryId)
+);
+
+COMMENT ON TABLE HUB_SAVED_QUERY IS '保存查询表 - 存储用户保存的访问日志和监控查询条件及定时报表配置';
+COMMENT ON COLUMN HUB_SAVED_QUERY.queryType IS '查询类型：ACCESS_LOG访问日志/MONITOR_OVERVIEW监控概览/MONITOR_CHART监控图表';
+COMMENT ON COLUMN HUB_SAVED_QUERY.queryParams IS '查询过滤条件，JSON格式，与对应查询接口参数一致';
+COMMENT ON COLUMN HUB_SAVED_QUERY.timeRangeMinutes IS '相对时间范围（分钟），大于0时执行时按当前时间计算startTime/endTime';
+COMMENT ON COLUMN HUB_SAVED_QUERY.scheduleEnabled IS '是否启用定时报表：Y是，N否';
+COMMENT ON COLUMN HUB_SAVED_QUERY.emailTo IS '报表收件人，逗号分隔，为空时仅生成报表记录';
+
+CREATE INDEX IDX_SAVED_QUERY_OWNER ON HUB_SAVED_QUERY (tenantId, ownerUserId);
+CREATE INDEX IDX_SAVED_QUERY_SCHEDULE ON HUB_SAVED_QUERY (scheduleEnabled, activeFlag);
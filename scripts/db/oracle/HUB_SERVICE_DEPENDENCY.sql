@@ -0,0 +1,41 @@
+-- 服务依赖关系表 - 记录订阅方与被订阅服务之间的依赖关系，用于依赖拓扑和维护前的影响范围评估
+CREATE TABLE HUB_SERVICE_DEPENDENCY (
+  -- 主键和租户信息
+  dependencyId VARCHAR2(32) NOT NULL,
+  tenantId VARCHAR2(32) NOT NULL,
+  namespaceId VARCHAR2(32) NOT NULL,
+  
+  -- 订阅方（消费者）
+  consumerType VARCHAR2(20) NOT NULL,
+  consumerNamespaceId VARCHAR2(32),
+  consumerGroupName VARCHAR2(64),
+  consumerName VARCHAR2(100) NOT NULL,
+  
+  -- 被订阅方（提供者）
+  providerGroupName VARCHAR2(64),
+  providerServiceName VARCHAR2(100) NOT NULL,
+  
+  -- 依赖信息
+  subscribeType VARCHAR2(20) NOT NULL,
+  firstSeenTime DATE DEFAULT SYSDATE NOT NULL,
+  lastSeenTime DATE DEFAULT SYSDATE NOT NULL,
+  
+  -- 通用字段
+  addTime DATE DEFAULT SYSDATE NOT NULL,
+  addWho VARCHAR2(32) NOT NULL,
+  editTime DATE DEFAULT SYSDATE NOT NULL,
+  editWho VARCHAR2(32) NOT NULL,
+  oprSeqFlag VARCHAR2(32) NOT NULL,
+  currentVersion NUMBER(10) DEFAULT 1 NOT NULL,
+  activeFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL,
+  noteText VARCHAR2(500),
+  extProperty CLOB,
+  
+  CONSTRAINT PK_SVC_DEP PRIMARY KEY (tenantId, dependencyId)
+);
+
+CREATE INDEX IDX_SVC_DEP_PROVIDER ON HUB_SERVICE_DEPENDENCY(tenantId, namespaceId, providerGroupName, providerServiceName);
+CREATE INDEX IDX_SVC_DEP_CONSUMER ON HUB_SERVICE_DEPENDENCY(tenantId, consumerNamespaceId, consumerGroupName, consumerName);
+CREATE INDEX IDX_SVC_DEP_LAST_SEEN ON HUB_SERVICE_DEPENDENCY(lastSeenTime);
+
+COMMENT ON TABLE HUB_SERVICE_DEPENDENCY IS '服务依赖关系表 - 记录订阅方与被订阅服务之间的依赖关系，用于依赖拓扑和维护前的影响范围评估';
@@ -0,0 +1,43 @@
+CREATE TABLE HUB_SYS_AUDIT (
+                                tenantId VARCHAR2(32) NOT NULL, -- 租户ID
+                                auditId VARCHAR2(32) NOT NULL, -- 审计记录ID
+
+    -- 操作人
+                                userId VARCHAR2(32), -- 操作用户ID
+                                userName VARCHAR2(50), -- 操作用户名
+                                clientIp VARCHAR2(128), -- 客户端IP
+                                userAgent VARCHAR2(500), -- 客户端UserAgent
+
+    -- 操作入口
+                                moduleCode VARCHAR2(32), -- 模块编码(如hub0002)
+                                actionName VARCHAR2(100), -- 接口动作(如addUser)
+                                httpMethod VARCHAR2(10) NOT NULL, -- HTTP方法
+                                requestPath VARCHAR2(500) NOT NULL, -- 请求路径
+
+    -- 数据变更
+                                operationType VARCHAR2(20) NOT NULL, -- 操作类型(CREATE,UPDATE,DELETE,MIXED)
+                                tableNames VARCHAR2(500), -- 变更的表，逗号分隔
+                                changeCount NUMBER(10) DEFAULT 0 NOT NULL, -- 变更记录数
+                                changeData CLOB, -- 变更前后数据及字段差异,JSON格式
+
+    -- 操作结果
+                                resultFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL, -- 请求是否成功(N失败,Y成功)
+                                httpStatus NUMBER(10) DEFAULT 200 NOT NULL, -- HTTP响应状态码
+                                durationMs NUMBER(19) DEFAULT 0 NOT NULL, -- 请求耗时(毫秒)
+                                traceId VARCHAR2(64), -- 链路跟踪ID
+                                operateTime DATE DEFAULT SYSDATE NOT NULL, -- 操作时间
+
+                                addTime DATE DEFAULT SYSDATE NOT NULL, -- 创建时间
+                                addWho VARCHAR2(32) NOT NULL, -- 创建人ID
+                                editTime DATE DEFAULT SYSDATE NOT NULL, -- 最后修改时间
+                                editWho VARCHAR2(32) NOT NULL, -- 最后修改人ID
+                                oprSeqFlag VARCHAR2(32) NOT NULL, -- 操作序列标识
+                                currentVersion NUMBER(10) DEFAULT 1 NOT NULL, -- 当前版本号
+                                activeFlag VARCHAR2(1) DEFAULT 'Y' NOT NULL, -- 活动状态标记(N非活动,Y活动)
+
+                                CONSTRAINT PK_SYS_AUDIT PRIMARY KEY (tenantId, auditId)
+);
+CREATE INDEX IDX_SYS_AUDIT_TIME ON HUB_SYS_AUDIT(tenantId, operateTime);
+CREATE INDEX IDX_SYS_AUDIT_USER ON HUB_SYS_AUDIT(tenantId, userId);
+CREATE INDEX IDX_SYS_AUDIT_MODULE ON HUB_SYS_AUDIT(moduleCode);
+COMMENT ON TABLE HUB_SYS_AUDIT IS '系统操作审计表 - 记录管理端接口的数据变更';
@@ -7,6 +7,7 @@
 
 @HUB_USER.sql
 @HUB_LOGIN_LOG.sql
+@HUB_SYS_AUDIT.sql
 @HUB_GW_INSTANCE.sql
 @HUB_GW_ROUTER_CONFIG.sql
 @HUB_GW_ROUTE_CONFIG.sql
@@ -26,6 +27,9 @@
 @HUB_GW_LOG_CONFIG.sql
 @HUB_GW_ACCESS_LOG.sql
 @HUB_GW_BACKEND_TRACE_LOG.sql
+@HUB_GW_SLOW_REQ.sql
+@HUB_GW_FEATURE_FLAG.sql
+@HUB_GW_CONFIG_VERSION.sql
 @HUB_GW_CORS_CONFIG.sql
 @HUB_GW_SECURITY_CONFIG.sql
 @HUB_GW_IP_ACCESS_CONFIG.sql
@@ -54,6 +58,12 @@
 @HUB_MONITOR_JVM_DEADLOCK.sql
 @HUB_MONITOR_JVM_CLASS.sql
 @HUB_MONITOR_APP_DATA.sql
+@HUB_MONITOR_ALERT_RULE.sql
+@HUB_MONITOR_ALERT_SILENCE.sql
+@HUB_MONITOR_ALERT_EVENT.sql
+@HUB_MONITOR_JVM_METRIC_5M.sql
+@HUB_MONITOR_JVM_METRIC_1H.sql
+@HUB_MONITOR_JVM_DIAG_TASK.sql
 @HUB_TUNNEL_SERVER.sql
 @HUB_TUNNEL_SERVER_NODE.sql
 @HUB_TUNNEL_CLIENT.sql
@@ -61,6 +71,9 @@
 @HUB_ALERT_CONFIG.sql
 @HUB_ALERT_TEMPLATE.sql
 @HUB_ALERT_LOG.sql
+@HUB_SAVED_QUERY.sql
+@HUB_DASHBOARD_LAYOUT.sql
+@HUB_QUERY_REPORT.sql
 
 -- =====================================================
 -- 字段长度调整：支持多服务定义ID和服务名称（多服务场景）
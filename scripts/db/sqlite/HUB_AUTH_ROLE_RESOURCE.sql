@@ -229,6 +229,14 @@ INSERT INTO HUB_AUTH_ROLE_RESOURCE (
   datetime('now'), 'system', datetime('now'), 'system', 'INIT_005_003', 1, 'Y'
 );
 
+INSERT INTO HUB_AUTH_ROLE_RESOURCE (
+  roleResourceId, tenantId, roleId, resourceId, permissionType, grantedBy, grantedTime,
+  addTime, addWho, editTime, editWho, oprSeqFlag, currentVersion, activeFlag
+) VALUES (
+  'ROLE_RES_SUPER_ADMIN_HUB0009', 'default', 'ROLE_SUPER_ADMIN', 'hub0009', 'ALLOW', 'system', datetime('now'),
+  datetime('now'), 'system', datetime('now'), 'system', 'INIT_008', 1, 'Y'
+);
+
 INSERT INTO HUB_AUTH_ROLE_RESOURCE (
   roleResourceId, tenantId, roleId, resourceId, permissionType, grantedBy, grantedTime,
   addTime, addWho, editTime, editWho, oprSeqFlag, currentVersion, activeFlag
@@ -315,4 +323,70 @@ INSERT INTO HUB_AUTH_ROLE_RESOURCE (
 ) VALUES (
   'ROLE_RES_SUPER_ADMIN_HUB0063', 'default', 'ROLE_SUPER_ADMIN', 'hub0063', 'ALLOW', 'system', datetime('now'),
   datetime('now'), 'system', datetime('now'), 'system', 'INIT_033', 1, 'Y'
-);
\ No newline at end of file
+);
+
+-- 接口资源授权
+INSERT INTO HUB_AUTH_ROLE_RESOURCE (
+  roleResourceId, tenantId, roleId, resourceId, permissionType, grantedBy, grantedTime,
+  addTime, addWho, editTime, editWho, oprSeqFlag, currentVersion, activeFlag
+) VALUES (
+  'ROLE_RES_SUPER_ADMIN_HUB0003', 'default', 'ROLE_SUPER_ADMIN', 'hub0003', 'ALLOW', 'system', datetime('now'),
+  datetime('now'), 'system', datetime('now'), 'system', 'INIT_API_001', 1, 'Y'
+);
+
+INSERT INTO HUB_AUTH_ROLE_RESOURCE (
+  roleResourceId, tenantId, roleId, resourceId, permissionType, grantedBy, grantedTime,
+  addTime, addWho, editTime, editWho, oprSeqFlag, currentVersion, activeFlag
+) VALUES (
+  'ROLE_RES_SUPER_ADMIN_HUBCOMMON002', 'default', 'ROLE_SUPER_ADMIN', 'hubcommon002', 'ALLOW', 'system', datetime('now'),
+  datetime('now'), 'system', datetime('now'), 'system', 'INIT_API_002', 1, 'Y'
+);
+
+INSERT INTO HUB_AUTH_ROLE_RESOURCE (
+  roleResourceId, tenantId, roleId, resourceId, permissionType, grantedBy, grantedTime,
+  addTime, addWho, editTime, editWho, oprSeqFlag, currentVersion, activeFlag
+) VALUES (
+  'ROLE_RES_SUPER_ADMIN_HUBPLUGIN', 'default', 'ROLE_SUPER_ADMIN', 'hubplugin', 'ALLOW', 'system', datetime('now'),
+  datetime('now'), 'system', datetime('now'), 'system', 'INIT_API_003', 1, 'Y'
+);
+
+-- 路由权限按钮资源授权
+INSERT INTO HUB_AUTH_ROLE_RESOURCE (
+  roleResourceId, tenantId, roleId, resourceId, permissionType, grantedBy, grantedTime,
+  addTime, addWho, editTime, editWho, oprSeqFlag, currentVersion, activeFlag
+) VALUES (
+  'ROLE_RES_SUPER_ADMIN_HUB0003_BTN_ADD', 'default', 'ROLE_SUPER_ADMIN', 'hub0003:add', 'ALLOW', 'system', datetime('now'),
+  datetime('now'), 'system', datetime('now'), 'system', 'INIT_API_001_001', 1, 'Y'
+);
+
+INSERT INTO HUB_AUTH_ROLE_RESOURCE (
+  roleResourceId, tenantId, roleId, resourceId, permissionType, grantedBy, grantedTime,
+  addTime, addWho, editTime, editWho, oprSeqFlag, currentVersion, activeFlag
+) VALUES (
+  'ROLE_RES_SUPER_ADMIN_HUB0003_BTN_EDIT', 'default', 'ROLE_SUPER_ADMIN', 'hub0003:edit', 'ALLOW', 'system', datetime('now'),
+  datetime('now'), 'system', datetime('now'), 'system', 'INIT_API_001_002', 1, 'Y'
+);
+
+INSERT INTO HUB_AUTH_ROLE_RESOURCE (
+  roleResourceId, tenantId, roleId, resourceId, permissionType, grantedBy, grantedTime,
+  addTime, addWho, editTime, editWho, oprSeqFlag, currentVersion, activeFlag
+) VALUES (
+  'ROLE_RES_SUPER_ADMIN_HUB0003_BTN_DELETE', 'default', 'ROLE_SUPER_ADMIN', 'hub0003:delete', 'ALLOW', 'system', datetime('now'),
+  datetime('now'), 'system', datetime('now'), 'system', 'INIT_API_001_003', 1, 'Y'
+);
+
+INSERT INTO HUB_AUTH_ROLE_RESOURCE (
+  roleResourceId, tenantId, roleId, resourceId, permissionType, grantedBy, grantedTime,
+  addTime, addWho, editTime, editWho, oprSeqFlag, currentVersion, activeFlag
+) VALUES (
+  'ROLE_RES_SUPER_ADMIN_HUB0023_BTN_DROP_PARTITION', 'default', 'ROLE_SUPER_ADMIN', 'hub0023:dropPartition', 'ALLOW', 'system', datetime('now'),
+  datetime('now'), 'system', datetime('now'), 'system', 'INIT_013_007', 1, 'Y'
+);
+
+INSERT INTO HUB_AUTH_ROLE_RESOURCE (
+  roleResourceId, tenantId, roleId, resourceId, permissionType, grantedBy, grantedTime,
+  addTime, addWho, editTime, editWho, oprSeqFlag, currentVersion, activeFlag
+) VALUES (
+  'ROLE_RES_SUPER_ADMIN_HUB0023_BTN_REPLAY', 'default', 'ROLE_SUPER_ADMIN', 'hub0023:replay', 'ALLOW', 'system', datetime('now'),
+  datetime('now'), 'system', datetime('now'), 'system', 'INIT_013_008', 1, 'Y'
+);
@@ -0,0 +1,32 @@
+-- 看板布局表
+CREATE TABLE IF NOT EXISTS HUB_DASHBOARD_LAYOUT (
+  -- 主键和租户
+  tenantId TEXT NOT NULL,
+  layoutId TEXT NOT NULL,
+  
+  -- 布局信息
+  layoutName TEXT NOT NULL,
+  dashboardCode TEXT NOT NULL,
+  layoutConfig TEXT,
+  widgetConfig TEXT,
+  timeRangeMinutes INTEGER NOT NULL DEFAULT 60,
+  refreshIntervalSeconds INTEGER NOT NULL DEFAULT 0,
+  ownerUserId TEXT NOT NULL,
+  shareFlag TEXT NOT NULL DEFAULT 'N',
+  defaultFlag TEXT NOT NULL DEFAULT 'N',
+  
+  -- 通用字段
+  addTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  addWho TEXT NOT NULL,
+  editTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  editWho TEXT NOT NULL,
+  oprSeqFlag TEXT NOT NULL,
+  currentVersion INTEGER NOT NULL DEFAULT 1,
+  activeFlag TEXT NOT NULL DEFAULT 'Y',
+  noteText TEXT,
+  
+  PRIMARY KEY (tenantId, layoutId)
+);
+
+-- 创建索引
+CREATE INDEX IF NOT EXISTS IDX_DASHBOARD_LAYOUT_OWNER ON HUB_DASHBOARD_LAYOUT(tenantId, ownerUserId, dashboardCode);
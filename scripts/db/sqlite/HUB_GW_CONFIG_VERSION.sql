@@ -0,0 +1,21 @@
+-- 网关配置版本表
+--   1. 记录实例已生效的配置版本历史，用于回滚，每个实例只保留最近的若干个版本
+--   2. configData 为网关完整配置的JSON，使用默认密钥加密存储
+CREATE TABLE IF NOT EXISTS HUB_GW_CONFIG_VERSION (
+    tenantId TEXT NOT NULL,
+    gatewayInstanceId TEXT NOT NULL,
+    configVersion TEXT NOT NULL,
+    configData TEXT NOT NULL,
+    operator TEXT,
+    appliedTime DATETIME NOT NULL,
+    appliedSeq INTEGER NOT NULL DEFAULT 0,
+    addTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    addWho TEXT NOT NULL,
+    editTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    editWho TEXT NOT NULL,
+    oprSeqFlag TEXT NOT NULL,
+    currentVersion INTEGER NOT NULL DEFAULT 1,
+    activeFlag TEXT NOT NULL DEFAULT 'Y',
+    PRIMARY KEY (tenantId, gatewayInstanceId, configVersion)
+);
+CREATE INDEX IF NOT EXISTS IDX_GW_CFGVER_SEQ ON HUB_GW_CONFIG_VERSION(tenantId, gatewayInstanceId, appliedSeq);
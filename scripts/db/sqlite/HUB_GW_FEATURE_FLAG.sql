@@ -0,0 +1,30 @@
+-- 网关功能开关表
+--   1. 按实例/路由维度控制过滤器等功能按比例放量，gatewayInstanceId、routeConfigId 为空表示不限
+--   2. 变更后由管理端刷新缓存中的版本号，网关节点检测到版本变化后重新加载
+CREATE TABLE IF NOT EXISTS HUB_GW_FEATURE_FLAG (
+    tenantId TEXT NOT NULL,
+    featureFlagId TEXT NOT NULL,
+    gatewayInstanceId TEXT,
+    routeConfigId TEXT,
+    flagKey TEXT NOT NULL,
+    flagName TEXT NOT NULL,
+    flagDesc TEXT,
+    flagEnabled TEXT NOT NULL DEFAULT 'N',
+    rolloutPercent INTEGER NOT NULL DEFAULT 100,
+    bucketBy TEXT NOT NULL DEFAULT 'CLIENT_IP',
+    bucketKey TEXT,
+    logEvaluation TEXT NOT NULL DEFAULT 'N',
+    extProperty TEXT,
+    addTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    addWho TEXT NOT NULL,
+    editTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    editWho TEXT NOT NULL,
+    oprSeqFlag TEXT NOT NULL,
+    currentVersion INTEGER NOT NULL DEFAULT 1,
+    activeFlag TEXT NOT NULL DEFAULT 'Y',
+    noteText TEXT,
+    PRIMARY KEY (tenantId, featureFlagId)
+);
+CREATE INDEX IDX_GW_FLAG_KEY ON HUB_GW_FEATURE_FLAG(tenantId, flagKey);
+CREATE INDEX IDX_GW_FLAG_INST ON HUB_GW_FEATURE_FLAG(gatewayInstanceId);
+CREATE INDEX IDX_GW_FLAG_ROUTE ON HUB_GW_FEATURE_FLAG(routeConfigId);
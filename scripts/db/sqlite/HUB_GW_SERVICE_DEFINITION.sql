@@ -46,4 +46,7 @@ CREATE INDEX IDX_GW_SVC_NAME ON HUB_GW_SERVICE_DEFINITION(serviceName);
 CREATE INDEX IDX_GW_SVC_TYPE ON HUB_GW_SERVICE_DEFINITION(serviceType);
 CREATE INDEX IDX_GW_SVC_STRATEGY ON HUB_GW_SERVICE_DEFINITION(loadBalanceStrategy);
 CREATE INDEX IDX_GW_SVC_HEALTH ON HUB_GW_SERVICE_DEFINITION(healthCheckEnabled);
-CREATE INDEX IDX_GW_SVC_PROXY ON HUB_GW_SERVICE_DEFINITION(proxyConfigId);
\ No newline at end of file
+CREATE INDEX IDX_GW_SVC_PROXY ON HUB_GW_SERVICE_DEFINITION(proxyConfigId);
+
+-- 上游TLS配置：使用独立ALTER语句，避免修改历史建表语句，保证已有表可以增量升级
+ALTER TABLE HUB_GW_SERVICE_DEFINITION ADD COLUMN upstreamTlsConfig TEXT;
\ No newline at end of file
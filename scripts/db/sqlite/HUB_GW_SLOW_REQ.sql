@@ -0,0 +1,58 @@
+-- 网关慢请求诊断表
+--   1. 记录总耗时超过慢请求阈值的请求，通过 tenantId + traceId 关联 HUB_GW_ACCESS_LOG
+--   2. 协程快照只保存文件引用，不入库
+CREATE TABLE IF NOT EXISTS HUB_GW_SLOW_REQ (
+    tenantId TEXT NOT NULL,                 -- 租户ID
+    slowReqId TEXT NOT NULL,                -- 慢请求记录ID
+    traceId TEXT NOT NULL,                  -- 链路追踪ID，关联主表 HUB_GW_ACCESS_LOG.traceId
+
+    -- 网关和路由信息
+    gatewayInstanceId TEXT,                 -- 网关实例ID
+    gatewayInstanceName TEXT,               -- 网关实例名称
+    gatewayNodeIp TEXT,                     -- 网关节点IP
+    routeConfigId TEXT,                     -- 路由配置ID
+    routeName TEXT,                         -- 路由名称
+    serviceDefinitionId TEXT,               -- 服务定义ID
+    serviceName TEXT,                       -- 服务名称
+
+    -- 请求信息
+    requestMethod TEXT,                     -- 请求方法
+    requestPath TEXT,                       -- 请求路径
+    clientIpAddress TEXT,                   -- 客户端IP
+    gatewayStatusCode INTEGER,              -- 网关状态码
+    backendStatusCode INTEGER,              -- 后端状态码(0表示未调用后端)
+
+    -- 耗时信息
+    thresholdMs INTEGER NOT NULL,           -- 触发时的慢请求阈值(毫秒)
+    totalProcessingTimeMs INTEGER NOT NULL, -- 总处理时间(毫秒)
+    gatewayProcessingTimeMs INTEGER,        -- 网关自身处理时间(毫秒)
+    backendResponseTimeMs INTEGER,          -- 后端响应时间(毫秒)
+    requestStartTime DATETIME NOT NULL,     -- 网关开始处理时间
+    requestFinishTime DATETIME,             -- 网关处理完成时间
+
+    -- 诊断信息
+    timingBreakdown TEXT,                   -- 耗时分解(JSON格式)
+    upstreamConnState TEXT,                 -- 上游连接状态(JSON数组)
+    goroutineCount INTEGER DEFAULT 0,       -- 超过阈值时的协程数量
+    goroutineSnapshotRef TEXT,              -- 协程快照文件引用
+    detectedTime DATETIME,                  -- 看门狗检测到慢请求的时间
+    errorMessage TEXT,                      -- 错误信息
+
+    -- 扩展信息
+    extProperty TEXT,                       -- 扩展属性(JSON格式)
+
+    -- 标准数据库字段
+    addTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    addWho TEXT NOT NULL,
+    editTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    editWho TEXT NOT NULL,
+    oprSeqFlag TEXT NOT NULL,
+    currentVersion INTEGER NOT NULL DEFAULT 1,
+    activeFlag TEXT NOT NULL DEFAULT 'Y',
+    noteText TEXT,
+
+    PRIMARY KEY (tenantId, slowReqId)
+);
+CREATE INDEX IF NOT EXISTS IDX_GW_SLOWREQ_TRACE ON HUB_GW_SLOW_REQ(tenantId, traceId);
+CREATE INDEX IF NOT EXISTS IDX_GW_SLOWREQ_ROUTE ON HUB_GW_SLOW_REQ(tenantId, routeConfigId, requestStartTime);
+CREATE INDEX IF NOT EXISTS IDX_GW_SLOWREQ_TIME ON HUB_GW_SLOW_REQ(tenantId, requestStartTime);
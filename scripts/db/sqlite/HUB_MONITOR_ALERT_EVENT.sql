@@ -0,0 +1,50 @@
+-- JVM告警事件表
+CREATE TABLE IF NOT EXISTS HUB_MONITOR_ALERT_EVENT (
+  -- 主键和租户
+  tenantId TEXT NOT NULL,
+  alertEventId TEXT NOT NULL,
+  
+  -- 规则信息
+  alertRuleId TEXT NOT NULL,
+  ruleName TEXT,
+  metricType TEXT NOT NULL,
+  alertLevel TEXT NOT NULL,
+  
+  -- 实例信息
+  jvmResourceId TEXT NOT NULL,
+  applicationName TEXT,
+  hostName TEXT,
+  hostIpAddress TEXT,
+  groupKey TEXT,
+  
+  -- 事件状态
+  eventStatus TEXT NOT NULL,
+  metricValue REAL NOT NULL DEFAULT 0,
+  thresholdValue REAL NOT NULL DEFAULT 0,
+  messageText TEXT,
+  firstTriggerTime DATETIME NOT NULL,
+  lastTriggerTime DATETIME NOT NULL,
+  resolvedTime DATETIME,
+  
+  -- 通知信息
+  notifyCount INTEGER NOT NULL DEFAULT 0,
+  lastNotifyTime DATETIME,
+  silencedFlag TEXT NOT NULL DEFAULT 'N',
+  alertLogId TEXT,
+  
+  -- 通用字段
+  addTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  addWho TEXT NOT NULL,
+  editTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  editWho TEXT NOT NULL,
+  oprSeqFlag TEXT NOT NULL,
+  currentVersion INTEGER NOT NULL DEFAULT 1,
+  activeFlag TEXT NOT NULL DEFAULT 'Y',
+  
+  PRIMARY KEY (tenantId, alertEventId)
+);
+
+-- 创建索引
+CREATE INDEX IF NOT EXISTS IDX_MON_ALERT_EVENT_RULE ON HUB_MONITOR_ALERT_EVENT(tenantId, alertRuleId, eventStatus);
+CREATE INDEX IF NOT EXISTS IDX_MON_ALERT_EVENT_RES ON HUB_MONITOR_ALERT_EVENT(jvmResourceId);
+CREATE INDEX IF NOT EXISTS IDX_MON_ALERT_EVENT_TIME ON HUB_MONITOR_ALERT_EVENT(firstTriggerTime);
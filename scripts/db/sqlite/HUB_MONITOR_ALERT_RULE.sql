@@ -0,0 +1,44 @@
+-- JVM告警规则表
+CREATE TABLE IF NOT EXISTS HUB_MONITOR_ALERT_RULE (
+  -- 主键和租户
+  tenantId TEXT NOT NULL,
+  alertRuleId TEXT NOT NULL,
+  
+  -- 规则定义
+  ruleName TEXT NOT NULL,
+  metricType TEXT NOT NULL,
+  evaluateMode TEXT NOT NULL DEFAULT 'THRESHOLD',
+  compareOperator TEXT NOT NULL DEFAULT 'GT',
+  thresholdValue REAL NOT NULL DEFAULT 0,
+  durationSeconds INTEGER NOT NULL DEFAULT 0,
+  windowSeconds INTEGER NOT NULL DEFAULT 300,
+  
+  -- 匹配范围
+  serviceGroupId TEXT,
+  applicationName TEXT,
+  
+  -- 通知配置
+  groupBy TEXT NOT NULL DEFAULT 'APPLICATION',
+  alertLevel TEXT NOT NULL DEFAULT 'WARN',
+  channelName TEXT,
+  repeatIntervalSeconds INTEGER NOT NULL DEFAULT 3600,
+  notifyResolvedFlag TEXT NOT NULL DEFAULT 'Y',
+  enabledFlag TEXT NOT NULL DEFAULT 'Y',
+  lastEvalTime DATETIME,
+  
+  -- 通用字段
+  addTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  addWho TEXT NOT NULL,
+  editTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  editWho TEXT NOT NULL,
+  oprSeqFlag TEXT NOT NULL,
+  currentVersion INTEGER NOT NULL DEFAULT 1,
+  activeFlag TEXT NOT NULL DEFAULT 'Y',
+  noteText TEXT,
+  
+  PRIMARY KEY (tenantId, alertRuleId)
+);
+
+-- 创建索引
+CREATE INDEX IF NOT EXISTS IDX_MON_ALERT_RULE_ENABLED ON HUB_MONITOR_ALERT_RULE(enabledFlag, activeFlag);
+CREATE INDEX IF NOT EXISTS IDX_MON_ALERT_RULE_METRIC ON HUB_MONITOR_ALERT_RULE(tenantId, metricType);
@@ -0,0 +1,30 @@
+-- JVM告警静默表
+CREATE TABLE IF NOT EXISTS HUB_MONITOR_ALERT_SILENCE (
+  -- 主键和租户
+  tenantId TEXT NOT NULL,
+  alertSilenceId TEXT NOT NULL,
+  
+  -- 匹配条件
+  alertRuleId TEXT,
+  applicationName TEXT,
+  jvmResourceId TEXT,
+  
+  -- 静默时间
+  startTime DATETIME NOT NULL,
+  endTime DATETIME NOT NULL,
+  reasonText TEXT,
+  
+  -- 通用字段
+  addTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  addWho TEXT NOT NULL,
+  editTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  editWho TEXT NOT NULL,
+  oprSeqFlag TEXT NOT NULL,
+  currentVersion INTEGER NOT NULL DEFAULT 1,
+  activeFlag TEXT NOT NULL DEFAULT 'Y',
+  
+  PRIMARY KEY (tenantId, alertSilenceId)
+);
+
+-- 创建索引
+CREATE INDEX IF NOT EXISTS IDX_MON_ALERT_SILENCE_TIME ON HUB_MONITOR_ALERT_SILENCE(tenantId, endTime);
@@ -0,0 +1,39 @@
+-- JVM诊断采集任务表
+CREATE TABLE IF NOT EXISTS HUB_MONITOR_JVM_DIAG_TASK (
+  -- 主键
+  tenantId TEXT NOT NULL,
+  diagTaskId TEXT NOT NULL,
+  
+  -- 采集请求
+  jvmResourceId TEXT NOT NULL,
+  applicationName TEXT,
+  diagType TEXT NOT NULL,
+  taskStatus TEXT NOT NULL DEFAULT 'PENDING',
+  requestTime DATETIME NOT NULL,
+  expireTime DATETIME NOT NULL,
+  dispatchTime DATETIME,
+  completeTime DATETIME,
+  
+  -- 采集结果
+  resultText TEXT,
+  resultSize INTEGER NOT NULL DEFAULT 0,
+  threadCount INTEGER NOT NULL DEFAULT 0,
+  blockedThreadCount INTEGER NOT NULL DEFAULT 0,
+  errorMessage TEXT,
+  
+  -- 通用字段
+  addTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  addWho TEXT NOT NULL,
+  editTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  editWho TEXT NOT NULL,
+  oprSeqFlag TEXT NOT NULL,
+  currentVersion INTEGER NOT NULL DEFAULT 1,
+  activeFlag TEXT NOT NULL DEFAULT 'Y',
+  noteText TEXT,
+  
+  PRIMARY KEY (tenantId, diagTaskId)
+);
+
+-- 创建索引
+CREATE INDEX IF NOT EXISTS IDX_MON_DIAG_RESOURCE ON HUB_MONITOR_JVM_DIAG_TASK(tenantId, jvmResourceId, taskStatus);
+CREATE INDEX IF NOT EXISTS IDX_MON_DIAG_REQ_TIME ON HUB_MONITOR_JVM_DIAG_TASK(requestTime);
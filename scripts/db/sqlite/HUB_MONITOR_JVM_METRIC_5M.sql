@@ -0,0 +1,41 @@
+-- JVM监控5分钟聚合表
+CREATE TABLE IF NOT EXISTS HUB_MONITOR_JVM_METRIC_5M (
+  -- 主键
+  tenantId TEXT NOT NULL,
+  jvmResourceId TEXT NOT NULL,
+  bucketTime DATETIME NOT NULL,
+  
+  -- 内存指标
+  memorySampleCount INTEGER NOT NULL DEFAULT 0,
+  heapUsageAvg REAL NOT NULL DEFAULT 0,
+  heapUsageMax REAL NOT NULL DEFAULT 0,
+  heapUsedBytesAvg INTEGER NOT NULL DEFAULT 0,
+  heapUsedBytesMax INTEGER NOT NULL DEFAULT 0,
+  nonHeapSampleCount INTEGER NOT NULL DEFAULT 0,
+  nonHeapUsageAvg REAL NOT NULL DEFAULT 0,
+  nonHeapUsageMax REAL NOT NULL DEFAULT 0,
+  
+  -- 线程指标
+  threadSampleCount INTEGER NOT NULL DEFAULT 0,
+  threadCountAvg REAL NOT NULL DEFAULT 0,
+  threadCountMax INTEGER NOT NULL DEFAULT 0,
+  
+  -- GC指标
+  ygcCount INTEGER NOT NULL DEFAULT 0,
+  fgcCount INTEGER NOT NULL DEFAULT 0,
+  gcTimeSeconds REAL NOT NULL DEFAULT 0,
+  
+  -- 通用字段
+  addTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  addWho TEXT NOT NULL,
+  editTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  editWho TEXT NOT NULL,
+  oprSeqFlag TEXT NOT NULL,
+  currentVersion INTEGER NOT NULL DEFAULT 1,
+  activeFlag TEXT NOT NULL DEFAULT 'Y',
+  
+  PRIMARY KEY (tenantId, jvmResourceId, bucketTime)
+);
+
+-- 创建索引
+CREATE INDEX IF NOT EXISTS IDX_MON_JVM_5M_TIME ON HUB_MONITOR_JVM_METRIC_5M(bucketTime);
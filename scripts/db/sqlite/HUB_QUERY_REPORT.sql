@@ -0,0 +1,41 @@
+-- 查询报表表
+CREATE TABLE IF NOT EXISTS HUB_QUERY_REPORT (
+  -- 主键和租户
+  tenantId TEXT NOT NULL,
+  reportId TEXT NOT NULL,
+  
+  -- 关联信息
+  savedQueryId TEXT NOT NULL,
+  queryName TEXT,
+  triggerType TEXT NOT NULL,
+  
+  -- 生成结果
+  reportStatus TEXT NOT NULL,
+  rowCount INTEGER NOT NULL DEFAULT 0,
+  reportFormat TEXT,
+  fileName TEXT,
+  filePath TEXT,
+  fileSize INTEGER NOT NULL DEFAULT 0,
+  emailStatus TEXT,
+  errorMessage TEXT,
+  
+  -- 执行时间
+  startTime DATETIME NOT NULL,
+  endTime DATETIME,
+  durationMs INTEGER NOT NULL DEFAULT 0,
+  
+  -- 通用字段
+  addTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  addWho TEXT NOT NULL,
+  editTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  editWho TEXT NOT NULL,
+  oprSeqFlag TEXT NOT NULL,
+  currentVersion INTEGER NOT NULL DEFAULT 1,
+  activeFlag TEXT NOT NULL DEFAULT 'Y',
+  
+  PRIMARY KEY (tenantId, reportId)
+);
+
+-- 创建索引
+CREATE INDEX IF NOT EXISTS IDX_QUERY_REPORT_QUERY ON HUB_QUERY_REPORT(tenantId, savedQueryId);
+CREATE INDEX IF NOT EXISTS IDX_QUERY_REPORT_TIME ON HUB_QUERY_REPORT(startTime);
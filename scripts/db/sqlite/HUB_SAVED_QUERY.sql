@@ -0,0 +1,43 @@
+-- 保存查询表
+CREATE TABLE IF NOT EXISTS HUB_SAVED_QUERY (
+  -- 主键和租户
+  tenantId TEXT NOT NULL,
+  savedQueryId TEXT NOT NULL,
+  
+  -- 查询信息
+  queryName TEXT NOT NULL,
+  queryType TEXT NOT NULL,
+  queryParams TEXT,
+  timeRangeMinutes INTEGER NOT NULL DEFAULT 0,
+  ownerUserId TEXT NOT NULL,
+  shareFlag TEXT NOT NULL DEFAULT 'N',
+  
+  -- 报表调度配置
+  scheduleEnabled TEXT NOT NULL DEFAULT 'N',
+  cronExpression TEXT,
+  reportFormat TEXT NOT NULL DEFAULT 'xlsx',
+  reportColumns TEXT,
+  maxRows INTEGER NOT NULL DEFAULT 0,
+  emailChannel TEXT,
+  emailTo TEXT,
+  
+  -- 最近一次报表
+  lastReportTime DATETIME,
+  lastReportStatus TEXT,
+  
+  -- 通用字段
+  addTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  addWho TEXT NOT NULL,
+  editTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  editWho TEXT NOT NULL,
+  oprSeqFlag TEXT NOT NULL,
+  currentVersion INTEGER NOT NULL DEFAULT 1,
+  activeFlag TEXT NOT NULL DEFAULT 'Y',
+  noteText TEXT,
+  
+  PRIMARY KEY (tenantId, savedQueryId)
+);
+
+-- 创建索引
+CREATE INDEX IF NOT EXISTS IDX_SAVED_QUERY_OWNER ON HUB_SAVED_QUERY(tenantId, ownerUserId);
+CREATE INDEX IF NOT EXISTS IDX_SAVED_QUERY_SCHEDULE ON HUB_SAVED_QUERY(scheduleEnabled, activeFlag);
@@ -0,0 +1,39 @@
+-- 服务依赖关系表 - 记录订阅方与被订阅服务之间的依赖关系，用于依赖拓扑和维护前的影响范围评估
+CREATE TABLE IF NOT EXISTS HUB_SERVICE_DEPENDENCY (
+  -- 主键和租户信息
+  dependencyId TEXT NOT NULL,
+  tenantId TEXT NOT NULL,
+  namespaceId TEXT NOT NULL,
+  
+  -- 订阅方（消费者）
+  consumerType TEXT NOT NULL,
+  consumerNamespaceId TEXT,
+  consumerGroupName TEXT,
+  consumerName TEXT NOT NULL,
+  
+  -- 被订阅方（提供者）
+  providerGroupName TEXT,
+  providerServiceName TEXT NOT NULL,
+  
+  -- 依赖信息
+  subscribeType TEXT NOT NULL,
+  firstSeenTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  lastSeenTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  
+  -- 通用字段
+  addTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  addWho TEXT NOT NULL,
+  editTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  editWho TEXT NOT NULL,
+  oprSeqFlag TEXT NOT NULL,
+  currentVersion INTEGER NOT NULL DEFAULT 1,
+  activeFlag TEXT NOT NULL DEFAULT 'Y',
+  noteText TEXT,
+  extProperty TEXT,
+  
+  PRIMARY KEY (tenantId, dependencyId)
+);
+
+CREATE INDEX IDX_SVC_DEP_PROVIDER ON HUB_SERVICE_DEPENDENCY(tenantId, namespaceId, providerGroupName, providerServiceName);
+CREATE INDEX IDX_SVC_DEP_CONSUMER ON HUB_SERVICE_DEPENDENCY(tenantId, consumerNamespaceId, consumerGroupName, consumerName);
+CREATE INDEX IDX_SVC_DEP_LAST_SEEN ON HUB_SERVICE_DEPENDENCY(lastSeenTime);
@@ -0,0 +1,35 @@
+-- 系统操作审计表
+--   1. 记录管理端接口的数据变更：操作人、模块、变更前后数据及字段差异
+--   2. 按 web.audit.retention_days 定期清理
+CREATE TABLE IF NOT EXISTS HUB_SYS_AUDIT (
+    tenantId TEXT NOT NULL,
+    auditId TEXT NOT NULL,
+    userId TEXT,
+    userName TEXT,
+    clientIp TEXT,
+    userAgent TEXT,
+    moduleCode TEXT,
+    actionName TEXT,
+    httpMethod TEXT NOT NULL,
+    requestPath TEXT NOT NULL,
+    operationType TEXT NOT NULL,
+    tableNames TEXT,
+    changeCount INTEGER NOT NULL DEFAULT 0,
+    changeData TEXT,
+    resultFlag TEXT NOT NULL DEFAULT 'Y',
+    httpStatus INTEGER NOT NULL DEFAULT 200,
+    durationMs INTEGER NOT NULL DEFAULT 0,
+    traceId TEXT,
+    operateTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    addTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    addWho TEXT NOT NULL,
+    editTime DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    editWho TEXT NOT NULL,
+    oprSeqFlag TEXT NOT NULL,
+    currentVersion INTEGER NOT NULL DEFAULT 1,
+    activeFlag TEXT NOT NULL DEFAULT 'Y',
+    PRIMARY KEY (tenantId, auditId)
+);
+CREATE INDEX IDX_SYS_AUDIT_TIME ON HUB_SYS_AUDIT(tenantId, operateTime);
+CREATE INDEX IDX_SYS_AUDIT_USER ON HUB_SYS_AUDIT(tenantId, userId);
+CREATE INDEX IDX_SYS_AUDIT_MODULE ON HUB_SYS_AUDIT(moduleCode);
@@ -10,12 +10,18 @@ CREATE TABLE IF NOT EXISTS HUB_TIMER_TASK (
     schedulerName TEXT,
     scheduleType INTEGER NOT NULL,
     cronExpression TEXT,
+    timeZone TEXT,
     intervalSeconds INTEGER,
     delaySeconds INTEGER,
     startTime DATETIME,
     endTime DATETIME,
+    dependsOnTaskIds TEXT,
+    dependencyPolicy TEXT NOT NULL DEFAULT 'SKIP',
     maxRetries INTEGER NOT NULL DEFAULT 0,
     retryIntervalSeconds INTEGER NOT NULL DEFAULT 60,
+    retryBackoffRate REAL NOT NULL DEFAULT 1,
+    maxRetryIntervalSeconds INTEGER,
+    deadLetterThreshold INTEGER NOT NULL DEFAULT 0,
     timeoutSeconds INTEGER NOT NULL DEFAULT 1800,
     taskParams TEXT,
     executorType TEXT,
@@ -29,6 +35,7 @@ CREATE TABLE IF NOT EXISTS HUB_TIMER_TASK (
     runCount INTEGER NOT NULL DEFAULT 0,
     successCount INTEGER NOT NULL DEFAULT 0,
     failureCount INTEGER NOT NULL DEFAULT 0,
+    consecutiveFailures INTEGER NOT NULL DEFAULT 0,
     lastExecutionId TEXT,
     lastExecutionStartTime DATETIME,
     lastExecutionEndTime DATETIME,
@@ -21,6 +21,7 @@ PRAGMA journal_mode = WAL;
 
 .read HUB_USER.sql
 .read HUB_LOGIN_LOG.sql
+.read HUB_SYS_AUDIT.sql
 .read HUB_GW_INSTANCE.sql
 .read HUB_GW_ROUTER_CONFIG.sql
 .read HUB_GW_ROUTE_CONFIG.sql
@@ -41,6 +42,9 @@ PRAGMA journal_mode = WAL;
 .read HUB_GW_LOG_CONFIG.sql
 .read HUB_GW_ACCESS_LOG.sql
 .read HUB_GW_BACKEND_TRACE_LOG.sql
+.read HUB_GW_SLOW_REQ.sql
+.read HUB_GW_FEATURE_FLAG.sql
+.read HUB_GW_CONFIG_VERSION.sql
 .read HUB_GW_SECURITY_CONFIG.sql
 .read HUB_GW_IP_ACCESS_CONFIG.sql
 .read HUB_GW_UA_ACCESS_CONFIG.sql
@@ -68,6 +72,12 @@ PRAGMA journal_mode = WAL;
 .read HUB_MONITOR_JVM_DEADLOCK.sql
 .read HUB_MONITOR_JVM_CLASS.sql
 .read HUB_MONITOR_APP_DATA.sql
+.read HUB_MONITOR_ALERT_RULE.sql
+.read HUB_MONITOR_ALERT_SILENCE.sql
+.read HUB_MONITOR_ALERT_EVENT.sql
+.read HUB_MONITOR_JVM_METRIC_5M.sql
+.read HUB_MONITOR_JVM_METRIC_1H.sql
+.read HUB_MONITOR_JVM_DIAG_TASK.sql
 .read HUB_TUNNEL_SERVER.sql
 .read HUB_TUNNEL_SERVER_NODE.sql
 .read HUB_TUNNEL_CLIENT.sql
@@ -77,6 +87,9 @@ PRAGMA journal_mode = WAL;
 .read HUB_AUTH_ROLE_RESOURCE.sql
 .read HUB_AUTH_USER_ROLE.sql
 .read HUB_AUTH_DATA_PERMISSION.sql
+.read HUB_SAVED_QUERY.sql
+.read HUB_DASHBOARD_LAYOUT.sql
+.read HUB_QUERY_REPORT.sql
 
 -- 索引说明
 -- ==========================================
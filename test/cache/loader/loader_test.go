@@ -0,0 +1,151 @@
+package loader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/cache"
+	"gateway/pkg/cache/memory"
+)
+
+func newMemoryCache(t *testing.T) cache.Cache {
+	c, err := memory.NewMemoryCache(&memory.MemoryConfig{
+		Enabled:           true,
+		MaxSize:           1000,
+		DefaultExpiration: time.Hour,
+		CleanupInterval:   10 * time.Minute,
+		EvictionPolicy:    memory.EvictionTTL,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// TestLoaderSingleflight 测试同一个键同时未命中时只加载一次
+func TestLoaderSingleflight(t *testing.T) {
+	loader := cache.NewLoader(newMemoryCache(t), cache.LoaderOptions{})
+	ctx := context.Background()
+
+	var calls int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) ([]byte, bool, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return []byte("routes"), true, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([][]byte, 20)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			value, err := loader.Get(ctx, "route_table", time.Minute, load)
+			assert.NoError(t, err)
+			results[i] = value
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, value := range results {
+		assert.Equal(t, []byte("routes"), value)
+	}
+
+	// 已写入缓存，再次获取不加载
+	value, err := loader.Get(ctx, "route_table", time.Minute, load)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("routes"), value)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+// TestLoaderNoStoreAndError 测试不写入缓存和加载失败的情况
+func TestLoaderNoStoreAndError(t *testing.T) {
+	c := newMemoryCache(t)
+	loader := cache.NewLoader(c, cache.DefaultLoaderOptions)
+	ctx := context.Background()
+
+	_, err := loader.Get(ctx, "k", time.Minute, func(ctx context.Context) ([]byte, bool, error) {
+		return nil, false, errors.New("db down")
+	})
+	assert.Error(t, err)
+
+	value, err := loader.Get(ctx, "k", time.Minute, func(ctx context.Context) ([]byte, bool, error) {
+		return []byte("v"), false, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v"), value)
+	exists, err := c.Exists(ctx, "k")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// 非加载器写入的数据按原值返回
+	require.NoError(t, c.Set(ctx, "plain", []byte("raw"), time.Minute))
+	value, err = loader.Get(ctx, "plain", time.Minute, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("raw"), value)
+
+	assert.Same(t, cache.SharedLoader(c), cache.SharedLoader(c))
+}
+
+// TestLoaderEarlyRefresh 测试命中时提前在后台刷新
+func TestLoaderEarlyRefresh(t *testing.T) {
+	c := newMemoryCache(t)
+	loader := cache.NewLoader(c, cache.LoaderOptions{EarlyRefreshBeta: 1})
+	ctx := context.Background()
+
+	// 加载耗时远大于有效期，命中时必然提前刷新
+	require.NoError(t, loader.Store(ctx, "ns", []byte("old"), time.Minute, time.Hour))
+
+	refreshed := make(chan struct{})
+	value, err := loader.Get(ctx, "ns", time.Minute, func(ctx context.Context) ([]byte, bool, error) {
+		defer close(refreshed)
+		return []byte("new"), true, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("old"), value)
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("early refresh not triggered")
+	}
+	assert.Eventually(t, func() bool {
+		value, err := loader.Get(ctx, "ns", time.Minute, func(ctx context.Context) ([]byte, bool, error) {
+			return []byte("new"), true, nil
+		})
+		return err == nil && string(value) == "new"
+	}, time.Second, 10*time.Millisecond)
+}
+
+// TestJitterTTL 测试过期时间抖动范围
+func TestJitterTTL(t *testing.T) {
+	assert.Equal(t, time.Minute, cache.JitterTTL(time.Minute, 0, 0.5))
+	assert.Equal(t, time.Minute, cache.JitterTTL(time.Minute, 0.1, 0))
+	assert.Equal(t, 54*time.Second, cache.JitterTTL(time.Minute, 0.1, 1))
+	assert.Equal(t, time.Millisecond, cache.JitterTTL(time.Minute, 2, 1))
+	assert.Equal(t, time.Duration(0), cache.JitterTTL(0, 0.1, 0.5))
+}
+
+// TestShouldRefreshEarly 测试 XFetch 提前刷新判断
+func TestShouldRefreshEarly(t *testing.T) {
+	now := time.Now()
+	expiry := now.Add(10 * time.Second)
+
+	assert.False(t, cache.ShouldRefreshEarly(now, time.Time{}, time.Second, 1, 0.001))
+	assert.False(t, cache.ShouldRefreshEarly(now, expiry, time.Second, 0, 0.001))
+	// -ln(0.5) ≈ 0.69，加载耗时1秒时距过期10秒不刷新
+	assert.False(t, cache.ShouldRefreshEarly(now, expiry, time.Second, 1, 0.5))
+	// -ln(0.0001) ≈ 9.2，加载耗时2秒时提前刷新
+	assert.True(t, cache.ShouldRefreshEarly(now, expiry, 2*time.Second, 1, 0.0001))
+	assert.True(t, cache.ShouldRefreshEarly(expiry, expiry, 0, 1, 1))
+}
@@ -0,0 +1,119 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/cache"
+)
+
+type routeSnapshot struct {
+	Routes []string `json:"routes"`
+}
+
+// TestSnapshotStoreSaveLoad 测试快照写入、读取、列出以及名称转义
+func TestSnapshotStoreSaveLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "snapshot")
+	store := cache.NewSnapshotStore(dir, 0)
+
+	var missing routeSnapshot
+	_, err := store.Load("gateway_config.gw1", &missing)
+	assert.ErrorIs(t, err, cache.ErrSnapshotNotFound)
+	names, err := store.List("")
+	require.NoError(t, err)
+	assert.Empty(t, names)
+
+	before := time.Now()
+	require.NoError(t, store.Save("gateway_config.gw1", routeSnapshot{Routes: []string{"/a"}}))
+	require.NoError(t, store.Save("gateway_config.gw/2", routeSnapshot{Routes: []string{"/b", "/c"}}))
+	require.NoError(t, store.Save("registry", routeSnapshot{}))
+	require.NoError(t, store.Save("gateway_config.gw1", routeSnapshot{Routes: []string{"/a", "/d"}}))
+
+	var loaded routeSnapshot
+	info, err := store.Load("gateway_config.gw1", &loaded)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/a", "/d"}, loaded.Routes)
+	assert.Equal(t, "gateway_config.gw1", info.Name)
+	assert.False(t, info.SavedAt.Before(before.Truncate(time.Second)))
+	assert.True(t, info.RestoredAt.IsZero())
+
+	names, err = store.List("gateway_config.")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gateway_config.gw/2", "gateway_config.gw1"}, names)
+
+	// 目录中只有快照文件，没有残留的临时文件
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
+
+// TestSnapshotStoreExpired 测试超过最大保留时长的快照不再用于恢复
+func TestSnapshotStoreExpired(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, cache.NewSnapshotStore(dir, 0).Save("registry", routeSnapshot{Routes: []string{"/a"}}))
+
+	var loaded routeSnapshot
+	_, err := cache.NewSnapshotStore(dir, time.Hour).Load("registry", &loaded)
+	require.NoError(t, err)
+
+	time.Sleep(20 * time.Millisecond)
+	info, err := cache.NewSnapshotStore(dir, 10*time.Millisecond).Load("registry", &loaded)
+	assert.ErrorIs(t, err, cache.ErrSnapshotExpired)
+	require.NotNil(t, info)
+	assert.Greater(t, info.Age(time.Now()), 10*time.Millisecond)
+}
+
+// TestSnapshotStoreSaveEncrypted 测试加密快照不以明文落盘，读取时自动解密，且与未加密快照共存
+func TestSnapshotStoreSaveEncrypted(t *testing.T) {
+	dir := t.TempDir()
+	store := cache.NewSnapshotStore(dir, 0)
+
+	type secretSnapshot struct {
+		Routes []string `json:"routes"`
+		Secret string   `json:"secret"`
+	}
+	require.NoError(t, store.SaveEncrypted("gateway_config.gw1", secretSnapshot{Routes: []string{"/a"}, Secret: "jwt-secret-value"}))
+	require.NoError(t, store.Save("registry", routeSnapshot{Routes: []string{"/b"}}))
+
+	content, err := os.ReadFile(filepath.Join(dir, "gateway_config.gw1.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "jwt-secret-value")
+	assert.NotContains(t, string(content), "/a")
+	assert.Contains(t, string(content), `"encrypted":true`)
+
+	var loaded secretSnapshot
+	info, err := store.Load("gateway_config.gw1", &loaded)
+	require.NoError(t, err)
+	assert.Equal(t, "gateway_config.gw1", info.Name)
+	assert.Equal(t, secretSnapshot{Routes: []string{"/a"}, Secret: "jwt-secret-value"}, loaded)
+
+	var plain routeSnapshot
+	_, err = store.Load("registry", &plain)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/b"}, plain.Routes)
+}
+
+// TestSnapshotRestoredState 测试降级模式标记
+func TestSnapshotRestoredState(t *testing.T) {
+	savedAt := time.Now().Add(-time.Hour)
+	cache.MarkSnapshotRestored(cache.SnapshotInfo{Name: "test.b", SavedAt: savedAt})
+	cache.MarkSnapshotRestored(cache.SnapshotInfo{Name: "test.a", SavedAt: savedAt})
+	defer cache.ClearSnapshotRestored("test.a")
+
+	assert.True(t, cache.IsSnapshotRestored("test.b"))
+	restored := cache.RestoredSnapshots()
+	require.Len(t, restored, 2)
+	assert.Equal(t, "test.a", restored[0].Name)
+	assert.False(t, restored[0].RestoredAt.IsZero())
+	assert.GreaterOrEqual(t, restored[1].Age(time.Now()), time.Hour)
+
+	assert.True(t, cache.ClearSnapshotRestored("test.b"))
+	assert.False(t, cache.ClearSnapshotRestored("test.b"))
+	assert.False(t, cache.IsSnapshotRestored("test.b"))
+	assert.Len(t, cache.RestoredSnapshots(), 1)
+}
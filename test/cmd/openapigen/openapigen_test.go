@@ -0,0 +1,32 @@
+package openapigen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGeneratedSpecsUpToDate 验证 web/moduleimports/openapi_specs.go 与控制器源码一致
+// 新增或修改接口注释后未执行 go generate ./web/moduleimports 时测试失败
+func TestGeneratedSpecsUpToDate(t *testing.T) {
+	root, err := filepath.Abs(filepath.Join("..", "..", ".."))
+	require.NoError(t, err)
+	out := filepath.Join(t.TempDir(), "openapi_specs.go")
+
+	cmd := exec.Command("go", "run", "gateway/cmd/openapigen",
+		"-views", filepath.Join(root, "web", "views"), "-out", out)
+	cmd.Dir = root
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	generated, err := os.ReadFile(out)
+	require.NoError(t, err)
+	committed, err := os.ReadFile(filepath.Join(root, "web", "moduleimports", "openapi_specs.go"))
+	require.NoError(t, err)
+	assert.True(t, string(generated) == string(committed),
+		"web/moduleimports/openapi_specs.go 已过期，请执行 go generate ./web/moduleimports")
+}
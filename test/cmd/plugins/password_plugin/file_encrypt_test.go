@@ -0,0 +1,181 @@
+package password_plugin
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"gateway/pkg/security"
+	"gateway/pkg/utils/mask"
+)
+
+// pluginBinary 测试前编译的密码加密工具
+var pluginBinary string
+
+// TestMain 编译密码加密工具，测试通过命令行调用验证其行为
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "password_plugin")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	pluginBinary = filepath.Join(dir, "password_plugin")
+	build := exec.Command("go", "build", "-o", pluginBinary, "gateway/cmd/plugins/password_plugin")
+	build.Stderr = os.Stderr
+	if err := build.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "编译密码加密工具失败:", err)
+		os.RemoveAll(dir)
+		os.Exit(1)
+	}
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// runPlugin 运行密码加密工具，返回标准输出、标准错误和退出码
+// 配置目录指向空的临时目录，避免读取仓库中的配置
+func runPlugin(t *testing.T, stdin string, args ...string) (string, string, int) {
+	t.Helper()
+	cmd := exec.Command(pluginBinary, append([]string{"-config", t.TempDir()}, args...)...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return stdout.String(), stderr.String(), exitErr.ExitCode()
+	}
+	require.NoError(t, err)
+	return stdout.String(), stderr.String(), 0
+}
+
+// newSecretKey 生成测试使用的加密密钥
+func newSecretKey(t *testing.T) string {
+	t.Helper()
+	key, err := security.GenerateSecretKey()
+	require.NoError(t, err)
+	return key
+}
+
+// testDatabaseYAML 包含各种写法敏感值的数据库配置
+const testDatabaseYAML = `# 数据库配置
+database:
+  connections:
+    main:
+      user: admin
+      password: "db-pass"   # 主库密码
+      api_secret: 's3cret'
+      access-key: 123456
+      cache_key_enabled: true
+    replica:
+      password: ${REPLICA_PASSWORD}
+      tls_private_key: |
+        line-1
+        line-2
+app:
+  encryption_key: raw-key
+`
+
+// writeConfig 写入待加密的配置文件
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "database.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0600))
+	return file
+}
+
+// readConfigValue 按路径读取配置文件中的字符串值
+func readConfigValue(t *testing.T, file string, path ...string) string {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	var node interface{}
+	require.NoError(t, yaml.Unmarshal(data, &node))
+	for _, key := range path {
+		node = node.(map[string]interface{})[key]
+	}
+	return fmt.Sprint(node)
+}
+
+// TestEncryptConfigFile 验证按键名后缀原地加密明文敏感值，保留注释、引号和非敏感配置，
+// 环境变量引用、多行值和加密密钥配置不加密
+func TestEncryptConfigFile(t *testing.T) {
+	key := newSecretKey(t)
+	file := writeConfig(t, testDatabaseYAML)
+
+	stdout, stderr, code := runPlugin(t, "", "-k", key, "-f", file)
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "共加密 3 项")
+	assert.Contains(t, stdout, "@@ 第6行 database.connections.main.password @@")
+	assert.Contains(t, stdout, `-      password: "`+mask.Masked+`"   # 主库密码`)
+	assert.Contains(t, stdout, "跳过: 第12行 database.connections.replica.tls_private_key: 不支持多行值")
+	assert.NotContains(t, stdout, "db-pass", "变更摘要中的原值需要脱敏")
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	content := string(data)
+	assert.Contains(t, content, "# 数据库配置")
+	assert.Contains(t, content, "   # 主库密码")
+	assert.Contains(t, content, "      user: admin")
+	assert.Contains(t, content, "      password: ${REPLICA_PASSWORD}")
+	assert.Contains(t, content, "  encryption_key: raw-key")
+	assert.Contains(t, content, "      cache_key_enabled: true")
+
+	for path, plaintext := range map[string]string{"password": "db-pass", "api_secret": "s3cret", "access-key": "123456"} {
+		ciphertext := readConfigValue(t, file, "database", "connections", "main", path)
+		require.True(t, strings.HasPrefix(ciphertext, "ENCY_"), path)
+		decrypted, err := security.AESDecryptFromString(key, ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, plaintext, decrypted, path)
+	}
+
+	// 已加密的值不会重复加密
+	stdout, _, code = runPlugin(t, "", "-k", key, "-f", file)
+	require.Equal(t, 0, code)
+	assert.Contains(t, stdout, "共加密 0 项")
+	after, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(after))
+}
+
+// TestEncryptConfigFileDryRunAndKeys 验证预览模式不修改文件，-keys 可以指定匹配的键名
+func TestEncryptConfigFileDryRunAndKeys(t *testing.T) {
+	key := newSecretKey(t)
+	file := writeConfig(t, testDatabaseYAML)
+
+	stdout, stderr, code := runPlugin(t, "", "-k", key, "-f", file, "-dry-run")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "预览模式: 共 3 项待加密，未修改文件")
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, testDatabaseYAML, string(data))
+
+	stdout, stderr, code = runPlugin(t, "", "-k", key, "-f", file, "-keys", "secret")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "共加密 1 项")
+	assert.True(t, strings.HasPrefix(readConfigValue(t, file, "database", "connections", "main", "api_secret"), "ENCY_"))
+	assert.Equal(t, "db-pass", readConfigValue(t, file, "database", "connections", "main", "password"))
+}
+
+// TestEncryptConfigFileErrors 验证文件不存在或YAML格式错误时以非零退出码结束，其余文件继续处理
+func TestEncryptConfigFileErrors(t *testing.T) {
+	key := newSecretKey(t)
+	valid := writeConfig(t, "redis:\n  password: redis-pass\n")
+	invalid := filepath.Join(t.TempDir(), "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("redis: [password\n"), 0600))
+	missing := filepath.Join(t.TempDir(), "missing.yaml")
+
+	stdout, stderr, code := runPlugin(t, "", "-k", key, "-f", strings.Join([]string{missing, invalid, valid}, ","))
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, missing)
+	assert.Contains(t, stderr, invalid+": 解析YAML失败")
+	assert.Contains(t, stdout, "共加密 1 项")
+	assert.True(t, strings.HasPrefix(readConfigValue(t, valid, "redis", "password"), "ENCY_"))
+}
@@ -0,0 +1,141 @@
+package password_plugin
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/security"
+)
+
+// jsonResult 加密、解密和生成密钥的JSON输出
+type jsonResult struct {
+	Success    bool   `json:"success"`
+	Version    string `json:"version"`
+	Ciphertext string `json:"ciphertext"`
+	Plaintext  string `json:"plaintext"`
+	Key        string `json:"key"`
+	KeySource  string `json:"keySource"`
+	Error      string `json:"error"`
+}
+
+// runJSON 以JSON模式运行密码加密工具，标准输出必须是单行JSON
+func runJSON(t *testing.T, stdin string, args ...string) (*jsonResult, int) {
+	t.Helper()
+	stdout, _, code := runPlugin(t, stdin, append([]string{"-json"}, args...)...)
+	require.Equal(t, 1, strings.Count(stdout, "\n"), "标准输出只包含一行JSON: %s", stdout)
+	result := &jsonResult{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), result))
+	return result, code
+}
+
+// TestJSONEncryptDecrypt 验证JSON模式下加密、解密的输出，密码和密文可以从标准输入读取
+func TestJSONEncryptDecrypt(t *testing.T) {
+	key := newSecretKey(t)
+
+	result, code := runJSON(t, "", "-p", "my-password", "-k", key)
+	require.Equal(t, 0, code)
+	assert.True(t, result.Success)
+	assert.Equal(t, "specified", result.KeySource)
+	assert.Empty(t, result.Key, "指定密钥时不输出密钥")
+	plaintext, err := security.AESDecryptFromString(key, result.Ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "my-password", plaintext)
+
+	result, code = runJSON(t, "stdin-password\n", "-k", key)
+	require.Equal(t, 0, code)
+	encrypted := result.Ciphertext
+	plaintext, err = security.AESDecryptFromString(key, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "stdin-password", plaintext)
+
+	result, code = runJSON(t, "", "-d", "-c", encrypted, "-k", key)
+	require.Equal(t, 0, code)
+	assert.Equal(t, jsonResult{Success: true, Plaintext: "stdin-password", KeySource: "specified"}, *result)
+
+	result, code = runJSON(t, encrypted+"\n", "-d", "-k", key)
+	require.Equal(t, 0, code)
+	assert.Equal(t, "stdin-password", result.Plaintext)
+}
+
+// TestJSONRandomKeyAndGenerate 验证随机密钥加密同时输出密钥和密文，生成密钥和版本信息以JSON输出
+func TestJSONRandomKeyAndGenerate(t *testing.T) {
+	result, code := runJSON(t, "", "-p", "my-password", "-r")
+	require.Equal(t, 0, code)
+	assert.Equal(t, "random", result.KeySource)
+	require.NotEmpty(t, result.Key)
+	plaintext, err := security.AESDecryptFromString(result.Key, result.Ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "my-password", plaintext)
+
+	result, code = runJSON(t, "", "-g")
+	require.Equal(t, 0, code)
+	assert.True(t, result.Success)
+	assert.NotEmpty(t, result.Key)
+	assert.Empty(t, result.Ciphertext)
+
+	result, code = runJSON(t, "", "-v")
+	require.Equal(t, 0, code)
+	assert.Equal(t, "1.0.0", result.Version)
+}
+
+// TestJSONErrors 验证JSON模式下错误以 success=false 输出到标准输出，退出码为1
+func TestJSONErrors(t *testing.T) {
+	key := newSecretKey(t)
+
+	result, code := runJSON(t, "", "-d", "-c", "ENCY_invalid", "-k", key)
+	assert.Equal(t, 1, code)
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "解密失败")
+
+	result, code = runJSON(t, "\n", "-k", key)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, jsonResult{Error: "密码不能为空"}, *result)
+}
+
+// TestJSONEncryptConfigFile 验证JSON模式下批量加密输出每个文件的变更项和跳过原因
+func TestJSONEncryptConfigFile(t *testing.T) {
+	key := newSecretKey(t)
+	file := writeConfig(t, testDatabaseYAML)
+	missing := file + ".missing"
+
+	stdout, _, code := runPlugin(t, "", "-json", "-k", key, "-dry-run", "-f", file+","+missing)
+	assert.Equal(t, 1, code)
+	var result struct {
+		Success bool `json:"success"`
+		DryRun  bool `json:"dryRun"`
+		Total   int  `json:"total"`
+		Files   []struct {
+			File    string `json:"file"`
+			Changes []struct {
+				Path       string `json:"path"`
+				Line       int    `json:"line"`
+				Ciphertext string `json:"ciphertext"`
+			} `json:"changes"`
+			Skipped []string `json:"skipped"`
+			Error   string   `json:"error"`
+		} `json:"files"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.False(t, result.Success)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 3, result.Total)
+	require.Len(t, result.Files, 2)
+
+	assert.Equal(t, file, result.Files[0].File)
+	require.Len(t, result.Files[0].Changes, 3)
+	change := result.Files[0].Changes[0]
+	assert.Equal(t, "database.connections.main.password", change.Path)
+	assert.Equal(t, 6, change.Line)
+	plaintext, err := security.AESDecryptFromString(key, change.Ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "db-pass", plaintext)
+	assert.Equal(t, []string{"第12行 database.connections.replica.tls_private_key: 不支持多行值"}, result.Files[0].Skipped)
+
+	assert.Equal(t, missing, result.Files[1].File)
+	assert.NotEmpty(t, result.Files[1].Error)
+	assert.Empty(t, result.Files[1].Changes)
+}
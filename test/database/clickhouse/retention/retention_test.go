@@ -0,0 +1,149 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database"
+	"gateway/pkg/database/clickhouse"
+)
+
+// fakeDB 记录执行的语句，按查询语句中的系统表返回预设结果
+type fakeDB struct {
+	database.Database
+	engineFull   string
+	partitionKey string
+	partitions   []clickhouse.Partition
+	failDrop     string
+	execs        []string
+}
+
+func (f *fakeDB) Query(ctx context.Context, dest interface{}, query string, args []interface{}, autoCommit bool) error {
+	v := reflect.ValueOf(dest).Elem()
+	switch {
+	case strings.Contains(query, "system.tables"):
+		if f.engineFull == "" {
+			return nil
+		}
+		row := reflect.New(v.Type().Elem()).Elem()
+		row.Field(0).SetString(f.partitionKey)
+		row.Field(1).SetString(f.engineFull)
+		v.Set(reflect.Append(v, row))
+	case strings.Contains(query, "system.parts"):
+		v.Set(reflect.ValueOf(f.partitions))
+	}
+	return nil
+}
+
+func (f *fakeDB) Exec(ctx context.Context, query string, args []interface{}, autoCommit bool) (int64, error) {
+	if f.failDrop != "" && strings.Contains(query, f.failDrop) {
+		return 0, errors.New("drop failed")
+	}
+	f.execs = append(f.execs, query)
+	return 0, nil
+}
+
+var accessLogPolicy = clickhouse.TablePolicy{
+	Table:       "HUB_GW_ACCESS_LOG",
+	PartitionBy: "toDate(gatewayStartProcessingTime)",
+	TTLColumn:   "gatewayStartProcessingTime",
+	TTLDays:     30,
+}
+
+// TestExtractTTL 测试从表引擎定义中提取TTL
+func TestExtractTTL(t *testing.T) {
+	assert.Equal(t, "toDateTime(t) + toIntervalDay(7)",
+		clickhouse.ExtractTTL("MergeTree PARTITION BY toDate(t) ORDER BY (a, t) TTL toDateTime(t) + toIntervalDay(7) SETTINGS index_granularity = 8192"))
+	assert.Equal(t, "toDateTime(t) + toIntervalDay(7)", clickhouse.ExtractTTL("MergeTree ORDER BY t TTL toDateTime(t) + toIntervalDay(7)"))
+	assert.Empty(t, clickhouse.ExtractTTL("MergeTree PARTITION BY toDate(t) ORDER BY t SETTINGS index_granularity = 8192"))
+}
+
+// TestEnsureTablePolicy 测试TTL比对和修正
+func TestEnsureTablePolicy(t *testing.T) {
+	db := &fakeDB{
+		partitionKey: "toDate(gatewayStartProcessingTime)",
+		engineFull:   "MergeTree PARTITION BY toDate(gatewayStartProcessingTime) ORDER BY traceId SETTINGS index_granularity = 8192",
+	}
+
+	state, err := clickhouse.EnsureTablePolicy(context.Background(), db, accessLogPolicy, false)
+	require.NoError(t, err)
+	assert.True(t, state.Exists)
+	assert.True(t, state.PartitionMatched)
+	assert.False(t, state.TTLMatched)
+	assert.Empty(t, db.execs)
+
+	state, err = clickhouse.EnsureTablePolicy(context.Background(), db, accessLogPolicy, true)
+	require.NoError(t, err)
+	assert.True(t, state.Altered)
+	assert.Equal(t, []string{"ALTER TABLE HUB_GW_ACCESS_LOG MODIFY TTL toDateTime(gatewayStartProcessingTime) + toIntervalDay(30)"}, db.execs)
+
+	// 已一致时不再修改，分区键不一致只报告
+	db.execs = nil
+	db.partitionKey = "toYYYYMM(gatewayStartProcessingTime)"
+	db.engineFull = "MergeTree ORDER BY traceId TTL toDateTime(gatewayStartProcessingTime) + toIntervalDay(30) SETTINGS index_granularity = 8192"
+	state, err = clickhouse.EnsureTablePolicy(context.Background(), db, accessLogPolicy, true)
+	require.NoError(t, err)
+	assert.True(t, state.TTLMatched)
+	assert.False(t, state.PartitionMatched)
+	assert.False(t, state.Altered)
+	assert.Empty(t, db.execs)
+
+	// 策略不设置TTL时移除已有TTL
+	noTTL := accessLogPolicy
+	noTTL.TTLDays = 0
+	_, err = clickhouse.EnsureTablePolicy(context.Background(), db, noTTL, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ALTER TABLE HUB_GW_ACCESS_LOG REMOVE TTL"}, db.execs)
+
+	// 表不存在
+	state, err = clickhouse.EnsureTablePolicy(context.Background(), &fakeDB{}, accessLogPolicy, true)
+	require.NoError(t, err)
+	assert.False(t, state.Exists)
+
+	invalid := accessLogPolicy
+	invalid.Table = "HUB_GW_ACCESS_LOG; DROP TABLE X"
+	_, err = clickhouse.EnsureTablePolicy(context.Background(), db, invalid, true)
+	assert.Error(t, err)
+}
+
+// TestDropPartitionsBefore 测试按日期删除旧分区
+func TestDropPartitionsBefore(t *testing.T) {
+	db := &fakeDB{
+		partitions: []clickhouse.Partition{
+			{Partition: "2024-04-29", PartitionID: "20240429"},
+			{Partition: "2024-04-30", PartitionID: "20240430"},
+			{Partition: "2024-05-01", PartitionID: "20240501"},
+		},
+	}
+
+	dropped, err := clickhouse.DropPartitionsBefore(context.Background(), db, "HUB_GW_ACCESS_LOG", "2024-05-01")
+	require.NoError(t, err)
+	require.Len(t, dropped, 2)
+	assert.Equal(t, []string{
+		"ALTER TABLE HUB_GW_ACCESS_LOG DROP PARTITION ID '20240429'",
+		"ALTER TABLE HUB_GW_ACCESS_LOG DROP PARTITION ID '20240430'",
+	}, db.execs)
+
+	// 单个分区失败时继续删除其余分区
+	db.execs = nil
+	db.failDrop = "20240429"
+	dropped, err = clickhouse.DropPartitionsBefore(context.Background(), db, "HUB_GW_ACCESS_LOG", "2024-05-01")
+	assert.Error(t, err)
+	require.Len(t, dropped, 1)
+	assert.Equal(t, "20240430", dropped[0].PartitionID)
+
+	_, err = clickhouse.DropPartitionsBefore(context.Background(), db, "HUB_GW_ACCESS_LOG", "")
+	assert.Error(t, err)
+	assert.Error(t, clickhouse.DropPartition(context.Background(), db, "system.parts", "1"))
+
+	db.execs = nil
+	db.failDrop = ""
+	require.NoError(t, clickhouse.DropPartition(context.Background(), db, "T", `a'b`))
+	assert.Equal(t, []string{`ALTER TABLE T DROP PARTITION ID 'a\'b'`}, db.execs)
+}
@@ -0,0 +1,53 @@
+package connsecret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database"
+	"gateway/pkg/database/dbtypes"
+	"gateway/pkg/security"
+)
+
+// TestDecryptConnectionSecrets 测试解密连接配置中的加密密码和DSN
+func TestDecryptConnectionSecrets(t *testing.T) {
+	t.Setenv(security.EncryptionKeyEnv, "connection-secret-test-key")
+	password, err := security.EncryptWithDefaultKey("db-pass")
+	require.NoError(t, err)
+	dsn, err := security.EncryptWithDefaultKey("user:db-pass@tcp(127.0.0.1:3306)/gateway")
+	require.NoError(t, err)
+
+	cfg := &dbtypes.DbConfig{Name: "mysql", Driver: "mysql", DSN: dsn}
+	cfg.Connection.Password = password
+	require.NoError(t, database.DecryptConnectionSecrets(cfg))
+	assert.Equal(t, "db-pass", cfg.Connection.Password)
+	assert.Equal(t, "user:db-pass@tcp(127.0.0.1:3306)/gateway", cfg.DSN)
+
+	// 已解密或明文的值重复调用保持不变
+	require.NoError(t, database.DecryptConnectionSecrets(cfg))
+	assert.Equal(t, "db-pass", cfg.Connection.Password)
+}
+
+// TestDecryptConnectionSecretsKeyMissing 测试未配置解密密钥时返回明确的错误
+func TestDecryptConnectionSecretsKeyMissing(t *testing.T) {
+	t.Setenv(security.EncryptionKeyEnv, "connection-secret-test-key")
+	password, err := security.EncryptWithDefaultKey("db-pass")
+	require.NoError(t, err)
+
+	t.Setenv(security.EncryptionKeyEnv, "")
+	cfg := &dbtypes.DbConfig{Name: "mysql", Driver: "mysql"}
+	cfg.Connection.Password = password
+	err = database.DecryptConnectionSecrets(cfg)
+	require.ErrorIs(t, err, security.ErrEncryptionKeyMissing)
+	assert.Contains(t, err.Error(), "mysql")
+	assert.Contains(t, err.Error(), security.EncryptionKeyEnv)
+	assert.Equal(t, password, cfg.Connection.Password)
+
+	// 密钥已配置但不匹配时不是密钥缺失错误
+	t.Setenv(security.EncryptionKeyEnv, "another-key")
+	err = database.DecryptConnectionSecrets(cfg)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, security.ErrEncryptionKeyMissing)
+}
@@ -0,0 +1,44 @@
+package dblogger
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database/dblogger"
+)
+
+// TestIsExplainable 测试只对查询语句获取执行计划
+func TestIsExplainable(t *testing.T) {
+	assert.True(t, dblogger.IsExplainable("SELECT * FROM HUB_SERVICE"))
+	assert.True(t, dblogger.IsExplainable("  with t AS (SELECT 1) SELECT * FROM t"))
+	assert.True(t, dblogger.IsExplainable("(SELECT 1) UNION (SELECT 2)"))
+	assert.False(t, dblogger.IsExplainable("UPDATE HUB_SERVICE SET name = ?"))
+	assert.False(t, dblogger.IsExplainable("INSERT INTO HUB_SERVICE VALUES (?)"))
+	assert.False(t, dblogger.IsExplainable(""))
+}
+
+// TestExplainRows 测试执行计划结果格式化为文本
+func TestExplainRows(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("CREATE TABLE hub_service (id TEXT PRIMARY KEY, name TEXT)")
+	require.NoError(t, err)
+
+	plan, err := dblogger.ExplainRows(context.Background(), db, "EXPLAIN QUERY PLAN SELECT * FROM hub_service WHERE id = ?", "1")
+	require.NoError(t, err)
+	lines := strings.Split(plan, "\n")
+	require.GreaterOrEqual(t, len(lines), 2)
+	assert.Contains(t, lines[0], "detail")
+	assert.Contains(t, plan, "hub_service")
+
+	_, err = dblogger.ExplainRows(context.Background(), db, "EXPLAIN QUERY PLAN SELECT * FROM missing_table")
+	assert.Error(t, err)
+}
@@ -646,3 +646,45 @@ func TestOracleDSNGeneration(t *testing.T) {
 		t.Errorf("期望SID DSN前缀为 '%s'，实际为 '%s'", expectedSIDPrefix, dsnStr[:len(expectedSIDPrefix)])
 	}
 }
+
+// TestGenerateClickHouseInsertMode 测试ClickHouse写入模式参数生成
+func TestGenerateClickHouseInsertMode(t *testing.T) {
+	newConfig := func(mode, ack string) *dbtypes.DbConfig {
+		return &dbtypes.DbConfig{
+			Driver: dbtypes.DriverClickHouse,
+			Connection: dbtypes.ConnectionConfig{
+				Host:                               "localhost",
+				Username:                           "default",
+				Database:                           "gateway",
+				ClickHouseInsertMode:               mode,
+				ClickHouseAsyncInsertAck:           ack,
+				ClickHouseAsyncInsertBusyTimeoutMs: 200,
+			},
+		}
+	}
+
+	// async 模式默认刷新后确认
+	dsnStr, err := dsn.Generate(newConfig(dbtypes.ClickHouseInsertModeAsync, ""))
+	assert.NoError(t, err)
+	assert.Contains(t, dsnStr, "async_insert=1")
+	assert.Contains(t, dsnStr, "wait_for_async_insert=1")
+	assert.Contains(t, dsnStr, "async_insert_busy_timeout_ms=200")
+
+	// enqueue 确认方式不等待刷新
+	dsnStr, err = dsn.Generate(newConfig(dbtypes.ClickHouseInsertModeAsync, dbtypes.ClickHouseAsyncInsertAckEnqueue))
+	assert.NoError(t, err)
+	assert.Contains(t, dsnStr, "wait_for_async_insert=0")
+
+	// sync 和 buffer 模式不添加异步写入参数
+	for _, mode := range []string{"", dbtypes.ClickHouseInsertModeSync, dbtypes.ClickHouseInsertModeBuffer} {
+		dsnStr, err = dsn.Generate(newConfig(mode, ""))
+		assert.NoError(t, err)
+		assert.NotContains(t, dsnStr, "async_insert")
+	}
+
+	// 不支持的写入模式和确认方式
+	_, err = dsn.Generate(newConfig("batch", ""))
+	assert.Error(t, err)
+	_, err = dsn.Generate(newConfig(dbtypes.ClickHouseInsertModeAsync, "none"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,123 @@
+package querycache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/cache/memory"
+	"gateway/pkg/database"
+)
+
+// cacheTestRow 测试查询结果
+type cacheTestRow struct {
+	ID   string `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+}
+
+// fakeDatabase 记录查询次数的数据库实现，只实现测试用到的方法
+type fakeDatabase struct {
+	database.Database
+	queries int
+	name    string
+}
+
+func (f *fakeDatabase) GetName() string { return "fake" }
+
+func (f *fakeDatabase) Query(ctx context.Context, dest interface{}, query string, args []interface{}, autoCommit bool) error {
+	f.queries++
+	*dest.(*[]cacheTestRow) = []cacheTestRow{{ID: "1", Name: f.name}}
+	return nil
+}
+
+func (f *fakeDatabase) QueryOne(ctx context.Context, dest interface{}, query string, args []interface{}, autoCommit bool) error {
+	f.queries++
+	return database.ErrRecordNotFound
+}
+
+func (f *fakeDatabase) Insert(ctx context.Context, table string, data interface{}, autoCommit bool) (int64, error) {
+	return 1, nil
+}
+
+func (f *fakeDatabase) Exec(ctx context.Context, query string, args []interface{}, autoCommit bool) (int64, error) {
+	return 1, nil
+}
+
+func newCachedDatabase(t *testing.T) (*database.CachedDatabase, *fakeDatabase) {
+	cfg := &memory.MemoryConfig{}
+	cfg.SetDefaults()
+	c, err := memory.NewMemoryCache(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+
+	fake := &fakeDatabase{name: "v1"}
+	return database.NewCachedDatabase(fake, c, time.Minute), fake
+}
+
+// TestCachedDatabaseQuery 测试带缓存选项的查询命中缓存，未带选项的查询不使用缓存
+func TestCachedDatabaseQuery(t *testing.T) {
+	db, fake := newCachedDatabase(t)
+	ctx := database.WithQueryCache(context.Background(), database.QueryCacheOptions{})
+	query := "SELECT * FROM HUB_SERVICE_NAMESPACE WHERE tenantId = ?"
+
+	var rows []cacheTestRow
+	require.NoError(t, db.Query(ctx, &rows, query, []interface{}{"default"}, true))
+	fake.name = "v2"
+	rows = nil
+	require.NoError(t, db.Query(ctx, &rows, query, []interface{}{"default"}, true))
+	assert.Equal(t, 1, fake.queries)
+	assert.Equal(t, "v1", rows[0].Name)
+
+	// 参数不同使用不同的缓存键
+	require.NoError(t, db.Query(ctx, &rows, query, []interface{}{"other"}, true))
+	assert.Equal(t, 2, fake.queries)
+
+	// 未带缓存选项或事务内查询直接查询数据库
+	require.NoError(t, db.Query(context.Background(), &rows, query, []interface{}{"default"}, true))
+	require.NoError(t, db.Query(ctx, &rows, query, []interface{}{"default"}, false))
+	assert.Equal(t, 4, fake.queries)
+}
+
+// TestCachedDatabaseInvalidation 测试写入表后相关缓存失效
+func TestCachedDatabaseInvalidation(t *testing.T) {
+	db, fake := newCachedDatabase(t)
+	ctx := database.WithQueryCache(context.Background(), database.QueryCacheOptions{Key: "namespaces"})
+	query := "SELECT n.* FROM `HUB_SERVICE_NAMESPACE` n JOIN HUB_SERVICE s ON s.namespaceId = n.namespaceId"
+
+	var rows []cacheTestRow
+	require.NoError(t, db.Query(ctx, &rows, query, nil, true))
+
+	// 写入无关的表不影响缓存
+	_, err := db.Insert(context.Background(), "HUB_GW_ROUTE_CONFIG", &cacheTestRow{}, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Query(ctx, &rows, query, nil, true))
+	assert.Equal(t, 1, fake.queries)
+
+	// 写入查询涉及的表后重新查询
+	_, err = db.Insert(context.Background(), "hub_service", &cacheTestRow{}, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Query(ctx, &rows, query, nil, true))
+	assert.Equal(t, 2, fake.queries)
+
+	// 原始SQL写入按语句的目标表失效
+	_, err = db.Exec(context.Background(), "UPDATE HUB_SERVICE_NAMESPACE SET noteText = ?", []interface{}{"x"}, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Query(ctx, &rows, query, nil, true))
+	assert.Equal(t, 3, fake.queries)
+}
+
+// TestCachedDatabaseQueryOneNotFound 测试未找到记录时不缓存
+func TestCachedDatabaseQueryOneNotFound(t *testing.T) {
+	db, fake := newCachedDatabase(t)
+	ctx := database.WithQueryCache(context.Background(), database.QueryCacheOptions{TTL: time.Second})
+
+	var row cacheTestRow
+	for i := 0; i < 2; i++ {
+		err := db.QueryOne(ctx, &row, "SELECT * FROM HUB_SERVICE WHERE serviceName = ?", []interface{}{"missing"}, true)
+		assert.ErrorIs(t, err, database.ErrRecordNotFound)
+	}
+	assert.Equal(t, 2, fake.queries)
+}
@@ -0,0 +1,87 @@
+package reencrypt
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database"
+	"gateway/pkg/database/dbtypes"
+	_ "gateway/pkg/database/sqlite" // 导入SQLite实现
+	"gateway/pkg/security"
+)
+
+// secretRow 测试表记录
+type secretRow struct {
+	ID     int    `db:"id"`
+	Secret string `db:"secret"`
+}
+
+// TestReencryptColumns 验证按主键分批重新加密，跨越多批时不重复、不遗漏
+func TestReencryptColumns(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.Open(&dbtypes.DbConfig{
+		Name:    t.Name(),
+		Enabled: true,
+		Driver:  dbtypes.DriverSQLite,
+		DSN:     filepath.Join(t.TempDir(), "reencrypt.db"),
+		Pool:    dbtypes.PoolConfig{MaxOpenConns: 1, MaxIdleConns: 1},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(ctx, "CREATE TABLE TEST_SECRET (id INTEGER PRIMARY KEY, secret TEXT)", nil, true)
+	require.NoError(t, err)
+
+	// 250 行旧密钥密文跨越三批，另有已是新密钥的密文和明文
+	const legacyRows = 250
+	for i := 1; i <= legacyRows+20; i++ {
+		var value string
+		switch {
+		case i <= legacyRows:
+			value, err = security.AESEncryptToString("old-key", fmt.Sprintf("secret-%d", i))
+		case i <= legacyRows+10:
+			value, err = security.AESEncryptToString("new-key", fmt.Sprintf("secret-%d", i))
+		default:
+			value = fmt.Sprintf("plain-%d", i)
+		}
+		require.NoError(t, err)
+		_, err = db.Exec(ctx, "INSERT INTO TEST_SECRET (id, secret) VALUES (?, ?)", []interface{}{i, value}, true)
+		require.NoError(t, err)
+	}
+
+	rotation, err := security.RotateKey("old-key", "new-key")
+	require.NoError(t, err)
+
+	var calls []database.ReencryptProgress
+	report, err := database.ReencryptColumns(ctx, db, rotation,
+		[]database.ReencryptTarget{{Table: "TEST_SECRET", KeyColumn: "id", Column: "secret"}},
+		func(p database.ReencryptProgress) { calls = append(calls, p) })
+	require.NoError(t, err)
+	require.Len(t, report.Targets, 1)
+	assert.Empty(t, report.Errors)
+
+	progress := report.Targets[0]
+	assert.Equal(t, legacyRows+10, progress.Total)
+	assert.Equal(t, legacyRows+10, progress.Scanned)
+	assert.Equal(t, legacyRows, progress.Updated)
+	assert.Equal(t, 10, progress.Skipped)
+	assert.Zero(t, progress.Failed)
+	require.NotEmpty(t, calls)
+	assert.Equal(t, progress, calls[len(calls)-1])
+	assert.Equal(t, 100, calls[0].Scanned)
+
+	var rows []secretRow
+	require.NoError(t, db.Query(ctx, &rows, "SELECT id, secret FROM TEST_SECRET ORDER BY id", nil, true))
+	require.Len(t, rows, legacyRows+20)
+	for _, row := range rows[:legacyRows+10] {
+		plaintext, err := security.AESDecryptFromString("new-key", row.Secret)
+		require.NoError(t, err, "id=%d", row.ID)
+		assert.Equal(t, fmt.Sprintf("secret-%d", row.ID), plaintext)
+	}
+	assert.Equal(t, fmt.Sprintf("plain-%d", legacyRows+20), rows[len(rows)-1].Secret)
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database"
+	"gateway/pkg/database/dbtypes"
+	"gateway/pkg/database/repository"
+)
+
+// fakeDatabase 记录执行的查询，统计查询返回固定的总数，只实现测试用到的方法
+type fakeDatabase struct {
+	database.Database
+	total   int
+	queries []string
+	args    [][]interface{}
+}
+
+func (f *fakeDatabase) GetDriver() string {
+	return dbtypes.DriverMySQL
+}
+
+func (f *fakeDatabase) QueryOne(ctx context.Context, dest interface{}, query string, args []interface{}, autoCommit bool) error {
+	f.queries, f.args = append(f.queries, query), append(f.args, args)
+	reflect.ValueOf(dest).Elem().Field(0).SetInt(int64(f.total))
+	return nil
+}
+
+func (f *fakeDatabase) Query(ctx context.Context, dest interface{}, query string, args []interface{}, autoCommit bool) error {
+	f.queries, f.args = append(f.queries, query), append(f.args, args)
+	return nil
+}
+
+// TestCondition 测试查询条件构建
+func TestCondition(t *testing.T) {
+	where, args := repository.Where().SQL()
+	assert.Empty(t, where)
+	assert.Nil(t, args)
+
+	where, args = repository.Where().
+		Eq("tenantId", "t1").
+		EqIfNotEmpty("activeFlag", "").
+		EqIfNotEmpty("flagEnabled", "Y").
+		Like("flagKey", "checkout").
+		Like("flagName", "").
+		In("bucketBy", "HEADER", "QUERY").
+		Raw("rolloutPercent > ? OR flagEnabled = ?", 0, "N").
+		SQL()
+	assert.Equal(t, "WHERE tenantId = ? AND flagEnabled = ? AND flagKey LIKE ? AND bucketBy IN (?, ?) AND (rolloutPercent > ? OR flagEnabled = ?)", where)
+	assert.Equal(t, []interface{}{"t1", "Y", "%checkout%", "HEADER", "QUERY", 0, "N"}, args)
+
+	where, args = repository.Where().In("bucketBy").SQL()
+	assert.Equal(t, "WHERE 1 = 0", where)
+	assert.Empty(t, args)
+}
+
+// TestSelectQuery 测试单表查询语句构建
+func TestSelectQuery(t *testing.T) {
+	query, args := repository.SelectQuery("HUB_GW_FEATURE_FLAG", nil, "")
+	assert.Equal(t, "SELECT * FROM HUB_GW_FEATURE_FLAG", query)
+	assert.Nil(t, args)
+
+	query, args = repository.SelectQuery("HUB_GW_FEATURE_FLAG", repository.Where().Eq("tenantId", "t1"), "flagKey ASC")
+	assert.Equal(t, "SELECT * FROM HUB_GW_FEATURE_FLAG WHERE tenantId = ? ORDER BY flagKey ASC", query)
+	assert.Equal(t, []interface{}{"t1"}, args)
+}
+
+// TestPage 测试分页查询：总数为0时不查询列表，否则追加分页参数且不修改调用方的参数切片
+func TestPage(t *testing.T) {
+	ctx := context.Background()
+	query, args := repository.SelectQuery("HUB_GW_FEATURE_FLAG", repository.Where().Eq("tenantId", "t1"), "flagKey ASC")
+
+	empty := &fakeDatabase{}
+	var rows []map[string]interface{}
+	total, err := repository.Page(ctx, empty, &rows, query, args, 1, 10, true)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Len(t, empty.queries, 1)
+	assert.Contains(t, empty.queries[0], "COUNT(*)")
+
+	db := &fakeDatabase{total: 25}
+	total, err = repository.Page(ctx, db, &rows, query, args, 3, 10, true)
+	require.NoError(t, err)
+	assert.Equal(t, 25, total)
+	require.Len(t, db.queries, 2)
+	assert.Contains(t, db.queries[1], "LIMIT")
+	assert.Equal(t, "t1", db.args[1][0])
+	assert.Greater(t, len(db.args[1]), 1)
+	assert.Equal(t, []interface{}{"t1"}, args)
+
+	count, err := repository.Count(ctx, db, query, args, true)
+	require.NoError(t, err)
+	assert.Equal(t, 25, count)
+}
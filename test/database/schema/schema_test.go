@@ -0,0 +1,93 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+// buildRouteSchema 构建测试用的数据库结构，primaryIndexName 为主键索引名称
+func buildRouteSchema(primaryIndexName string, options *database.SchemaOptions) *database.SchemaBuilder {
+	b := database.NewSchemaBuilder("mysql", "gateway", options)
+	b.AddTable("HUB_GW_ROUTE", "路由")
+	b.AddTable("HUB_GW_SERVICE", "服务")
+	b.AddColumn("HUB_GW_ROUTE", database.ColumnInfo{Name: "routeName", DataType: "varchar(100)", Nullable: true, Position: 3})
+	b.AddColumn("HUB_GW_ROUTE", database.ColumnInfo{Name: "tenantId", DataType: "varchar(32)", Position: 1})
+	b.AddColumn("HUB_GW_ROUTE", database.ColumnInfo{Name: "routeId", DataType: "varchar(32)", Position: 2})
+	b.AddColumn("HUB_GW_ROUTE", database.ColumnInfo{Name: "activeFlag", DataType: "varchar(1)", Default: strPtr("Y"), Position: 4})
+	b.AddColumn("VIEW_ROUTE", database.ColumnInfo{Name: "ignored", DataType: "int", Position: 1})
+	b.AddIndexColumn("HUB_GW_ROUTE", "IDX_ROUTE_NAME", false, false, "routeName")
+	b.AddIndexColumn("HUB_GW_ROUTE", primaryIndexName, true, true, "tenantId")
+	b.AddIndexColumn("HUB_GW_ROUTE", primaryIndexName, true, true, "routeId")
+	return b
+}
+
+// TestSchemaBuilder 测试结构组装：排序、主键列标记和表过滤
+func TestSchemaBuilder(t *testing.T) {
+	info := buildRouteSchema("PRIMARY", nil).Build()
+	require.Len(t, info.Tables, 2)
+	assert.Equal(t, "HUB_GW_ROUTE", info.Tables[0].Name)
+	assert.Equal(t, "HUB_GW_SERVICE", info.Tables[1].Name)
+	assert.Empty(t, info.Tables[1].Columns)
+	assert.NotNil(t, info.Tables[1].Columns)
+
+	route := info.Table("hub_gw_route")
+	require.NotNil(t, route)
+	names := make([]string, 0, len(route.Columns))
+	for _, column := range route.Columns {
+		names = append(names, column.Name)
+	}
+	assert.Equal(t, []string{"tenantId", "routeId", "routeName", "activeFlag"}, names)
+	assert.True(t, route.Column("TENANTID").PrimaryKey)
+	assert.True(t, route.Column("routeId").PrimaryKey)
+	assert.False(t, route.Column("routeName").PrimaryKey)
+
+	require.Len(t, route.Indexes, 2)
+	assert.Equal(t, database.IndexInfo{Name: "PRIMARY", Unique: true, Primary: true, Columns: []string{"tenantId", "routeId"}}, route.Indexes[0])
+	assert.Equal(t, "IDX_ROUTE_NAME", route.Indexes[1].Name)
+
+	filtered := buildRouteSchema("PRIMARY", &database.SchemaOptions{Tables: []string{"hub_gw_service"}}).Build()
+	require.Len(t, filtered.Tables, 1)
+	assert.Equal(t, "HUB_GW_SERVICE", filtered.Tables[0].Name)
+	assert.Nil(t, filtered.Table("HUB_GW_ROUTE"))
+}
+
+// TestDiffSchema 测试结构差异比对
+func TestDiffSchema(t *testing.T) {
+	source := buildRouteSchema("PRIMARY", nil).Build()
+
+	// 主键约束名不同不视为差异
+	same := buildRouteSchema("PK_HUB_GW_ROUTE", nil).Build()
+	assert.Empty(t, database.DiffSchema(source, same))
+
+	b := database.NewSchemaBuilder("mysql", "gateway_prod", nil)
+	b.AddTable("hub_gw_route", "")
+	b.AddTable("HUB_GW_LEGACY", "")
+	b.AddColumn("hub_gw_route", database.ColumnInfo{Name: "tenantid", DataType: "VARCHAR(32)", Position: 1})
+	b.AddColumn("hub_gw_route", database.ColumnInfo{Name: "routeId", DataType: "varchar(32)", Position: 2})
+	b.AddColumn("hub_gw_route", database.ColumnInfo{Name: "routeName", DataType: "varchar(50)", Nullable: true, Position: 3})
+	b.AddColumn("hub_gw_route", database.ColumnInfo{Name: "oldField", DataType: "int", Nullable: true, Position: 4})
+	b.AddIndexColumn("hub_gw_route", "PRIMARY", true, true, "tenantId")
+	b.AddIndexColumn("hub_gw_route", "PRIMARY", true, true, "routeId")
+	b.AddIndexColumn("hub_gw_route", "idx_route_name", true, false, "routeName")
+	b.AddIndexColumn("hub_gw_route", "IDX_OLD", false, false, "oldField")
+	target := b.Build()
+
+	diffs := database.DiffSchema(source, target)
+	assert.Equal(t, []database.SchemaDifference{
+		{Kind: database.SchemaDiffTableExtra, Table: "HUB_GW_LEGACY"},
+		{Kind: database.SchemaDiffColumnChanged, Table: "HUB_GW_ROUTE", Name: "routeName", Source: "varchar(100) NULL", Target: "varchar(50) NULL"},
+		{Kind: database.SchemaDiffColumnMissing, Table: "HUB_GW_ROUTE", Name: "activeFlag", Source: "varchar(1) NOT NULL DEFAULT Y"},
+		{Kind: database.SchemaDiffColumnExtra, Table: "HUB_GW_ROUTE", Name: "oldField", Target: "int NULL"},
+		{Kind: database.SchemaDiffIndexChanged, Table: "HUB_GW_ROUTE", Name: "IDX_ROUTE_NAME", Source: "INDEX (routename)", Target: "UNIQUE (routename)"},
+		{Kind: database.SchemaDiffIndexExtra, Table: "HUB_GW_ROUTE", Name: "IDX_OLD", Target: "INDEX (oldfield)"},
+		{Kind: database.SchemaDiffTableMissing, Table: "HUB_GW_SERVICE"},
+	}, diffs)
+}
@@ -0,0 +1,52 @@
+package arraybind
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database/sqlutils"
+)
+
+type status string
+
+// TestBuildColumnArrays 测试按行参数转置为按列的类型化切片
+func TestBuildColumnArrays(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	name := "b"
+	rows := [][]interface{}{
+		{"a", 1, 1.5, at, status("Y"), nil, []byte("x")},
+		{&name, int64(2), nil, nil, status("N"), nil, nil},
+		{sql.NullString{String: "c", Valid: true}, uint8(3), float32(2), &at, "N", nil, []byte("z")},
+	}
+
+	arrays, err := sqlutils.BuildColumnArrays(rows)
+	require.NoError(t, err)
+	require.Len(t, arrays, 7)
+	assert.Equal(t, []string{"a", "b", "c"}, arrays[0])
+	assert.Equal(t, []sql.NullInt64{{Int64: 1, Valid: true}, {Int64: 2, Valid: true}, {Int64: 3, Valid: true}}, arrays[1])
+	assert.Equal(t, []sql.NullFloat64{{Float64: 1.5, Valid: true}, {}, {Float64: 2, Valid: true}}, arrays[2])
+	assert.Equal(t, []sql.NullTime{{Time: at, Valid: true}, {}, {Time: at, Valid: true}}, arrays[3])
+	assert.Equal(t, []string{"Y", "N", "N"}, arrays[4])
+	assert.Equal(t, []string{"", "", ""}, arrays[5])
+	assert.Equal(t, [][]byte{[]byte("x"), nil, []byte("z")}, arrays[6])
+
+	arrays, err = sqlutils.BuildColumnArrays(nil)
+	require.NoError(t, err)
+	assert.Nil(t, arrays)
+}
+
+// TestBuildColumnArraysUnsupported 测试无法数组绑定的情况
+func TestBuildColumnArraysUnsupported(t *testing.T) {
+	_, err := sqlutils.BuildColumnArrays([][]interface{}{{true}})
+	assert.Error(t, err)
+
+	_, err = sqlutils.BuildColumnArrays([][]interface{}{{"a"}, {1}})
+	assert.Error(t, err)
+
+	_, err = sqlutils.BuildColumnArrays([][]interface{}{{"a", "b"}, {"c"}})
+	assert.Error(t, err)
+}
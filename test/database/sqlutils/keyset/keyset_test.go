@@ -0,0 +1,115 @@
+package keyset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database/sqlutils"
+)
+
+var logColumns = []sqlutils.KeysetColumn{
+	{Name: "gatewayStartProcessingTime", Desc: true},
+	{Name: "traceId", Desc: true},
+}
+
+// TestCursorRoundTrip 测试游标编码后还原为原类型
+func TestCursorRoundTrip(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 30, 0, 123456789, time.FixedZone("CST", 8*3600))
+	cursor, err := sqlutils.EncodeCursor(&at, "trace-1", 42, 1.5)
+	require.NoError(t, err)
+
+	values, err := sqlutils.DecodeCursor(cursor)
+	require.NoError(t, err)
+	require.Len(t, values, 4)
+	assert.True(t, at.Equal(values[0].(time.Time)))
+	assert.Equal(t, "trace-1", values[1])
+	assert.Equal(t, int64(42), values[2])
+	assert.Equal(t, 1.5, values[3])
+
+	var nilTime *time.Time
+	_, err = sqlutils.EncodeCursor(nilTime)
+	assert.Error(t, err)
+	_, err = sqlutils.EncodeCursor([]string{"x"})
+	assert.Error(t, err)
+
+	_, err = sqlutils.DecodeCursor("not a cursor!")
+	assert.Error(t, err)
+}
+
+// TestNewKeysetPaginationInfo 测试游标与排序列数量校验
+func TestNewKeysetPaginationInfo(t *testing.T) {
+	info, err := sqlutils.NewKeysetPaginationInfo("", 0, logColumns...)
+	require.NoError(t, err)
+	assert.Equal(t, 10, info.PageSize)
+	assert.Empty(t, info.After)
+
+	cursor, err := sqlutils.EncodeCursor("only-one")
+	require.NoError(t, err)
+	_, err = sqlutils.NewKeysetPaginationInfo(cursor, 20, logColumns...)
+	assert.Error(t, err)
+
+	_, err = sqlutils.NewKeysetPaginationInfo("", 20)
+	assert.Error(t, err)
+}
+
+// TestBuildKeysetQuery 测试各数据库的游标分页语句
+func TestBuildKeysetQuery(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	cursor, err := sqlutils.EncodeCursor(at, "trace-9")
+	require.NoError(t, err)
+
+	first, err := sqlutils.NewKeysetPaginationInfo("", 20, logColumns...)
+	require.NoError(t, err)
+	query, args, err := sqlutils.BuildKeysetQuery(sqlutils.DatabaseMySQL, "SELECT * FROM HUB_GW_ACCESS_LOG", "WHERE tenantId = ?", []interface{}{"t1"}, first)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM HUB_GW_ACCESS_LOG WHERE tenantId = ? ORDER BY gatewayStartProcessingTime DESC, traceId DESC LIMIT ?", query)
+	assert.Equal(t, []interface{}{"t1", 21}, args)
+
+	next, err := sqlutils.NewKeysetPaginationInfo(cursor, 20, logColumns...)
+	require.NoError(t, err)
+	params := []interface{}{"t1"}
+	query, args, err = sqlutils.BuildKeysetQuery(sqlutils.DatabaseClickHouse, "SELECT * FROM HUB_GW_ACCESS_LOG", "WHERE tenantId = ?", params, next)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM HUB_GW_ACCESS_LOG WHERE (tenantId = ?) AND gatewayStartProcessingTime <= ? AND "+
+		"(gatewayStartProcessingTime < ? OR (gatewayStartProcessingTime = ? AND traceId < ?)) "+
+		"ORDER BY gatewayStartProcessingTime DESC, traceId DESC LIMIT ?", query)
+	assert.Equal(t, []interface{}{"t1", at, at, at, "trace-9", 21}, args)
+	assert.Equal(t, []interface{}{"t1"}, params)
+
+	// 单列升序、没有查询条件
+	asc, err := sqlutils.NewKeysetPaginationInfo("", 5, sqlutils.KeysetColumn{Name: "id"})
+	require.NoError(t, err)
+	asc.After = []interface{}{int64(100)}
+	query, args, err = sqlutils.BuildKeysetQuery(sqlutils.DatabaseSQLServer, "SELECT * FROM T", "", nil, asc)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM T WHERE id > ? ORDER BY id ASC OFFSET 0 ROWS FETCH NEXT ? ROWS ONLY", query)
+	assert.Equal(t, []interface{}{int64(100), 6}, args)
+
+	query, _, err = sqlutils.BuildKeysetQuery(sqlutils.DatabaseOracle, "SELECT * FROM T", "", nil, asc)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM T WHERE id > ? ORDER BY id ASC FETCH FIRST ? ROWS ONLY", query)
+
+	query, _, err = sqlutils.BuildKeysetQuery(sqlutils.DatabaseOracle11g, "SELECT * FROM T", "", nil, asc)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM (SELECT * FROM T WHERE id > ? ORDER BY id ASC) WHERE ROWNUM <= ?", query)
+
+	_, _, err = sqlutils.BuildKeysetQuery(sqlutils.DatabaseMongoDB, "SELECT * FROM T", "", nil, asc)
+	assert.Error(t, err)
+}
+
+// TestTrim 测试多取一条记录判断是否还有下一页
+func TestTrim(t *testing.T) {
+	info, err := sqlutils.NewKeysetPaginationInfo("", 20, logColumns...)
+	require.NoError(t, err)
+
+	count, hasMore := info.Trim(21)
+	assert.Equal(t, 20, count)
+	assert.True(t, hasMore)
+
+	count, hasMore = info.Trim(20)
+	assert.Equal(t, 20, count)
+	assert.False(t, hasMore)
+}
@@ -0,0 +1,89 @@
+package structmap
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database/sqlutils"
+)
+
+// BaseAudit 嵌入的公共审计字段
+type BaseAudit struct {
+	AddTime  time.Time `db:"addTime"`
+	EditWho  string    `db:"editWho"`
+	Internal string    `db:"-"`
+}
+
+// TenantScope 嵌入的租户字段（指针嵌入）
+type TenantScope struct {
+	TenantId string `db:"tenantId"`
+}
+
+// embeddedRow 包含嵌入结构体和自定义扫描类型的测试结构体
+type embeddedRow struct {
+	BaseAudit
+	*TenantScope
+	ID      string         `db:"id"`
+	EditWho string         `db:"editWho"` // 与嵌入字段同名，外层优先
+	Note    sql.NullString `db:"note"`
+	Remark  *sql.NullInt64 `db:"remark"`
+	Created string         `db:"created"`
+}
+
+// TestExtractColumnsPromotesEmbeddedFields 测试提取写入列时展开嵌入结构体
+func TestExtractColumnsPromotesEmbeddedFields(t *testing.T) {
+	row := &embeddedRow{
+		BaseAudit: BaseAudit{EditWho: "inner", Internal: "skip"},
+		ID:        "1",
+		EditWho:   "outer",
+	}
+	columns, values, err := sqlutils.ExtractColumnsAndValues(row)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "editWho", "note", "remark", "created", "addTime"}, columns)
+	assert.Equal(t, "outer", values[1])
+	assert.Nil(t, values[5]) // 零值时间写入NULL
+
+	// 嵌入的结构体指针不为nil时同样展开
+	row.TenantScope = &TenantScope{TenantId: "default"}
+	columns, _, err = sqlutils.ExtractColumnsAndValues(row)
+	require.NoError(t, err)
+	assert.Contains(t, columns, "tenantId")
+}
+
+// TestFindFieldByColumnEmbedded 测试按列名查找嵌入结构体中的字段和自定义扫描类型
+func TestFindFieldByColumnEmbedded(t *testing.T) {
+	var row embeddedRow
+	v := reflect.ValueOf(&row).Elem()
+
+	field, ok := sqlutils.FindFieldByColumn(v, "TENANTID")
+	require.True(t, ok)
+	field.SetString("default")
+	require.NotNil(t, row.TenantScope)
+	assert.Equal(t, "default", row.TenantId)
+
+	target := sqlutils.CreateNullSafeScanTarget(v.FieldByName("Note"))
+	require.NoError(t, target.(sql.Scanner).Scan("hello"))
+	assert.Equal(t, sql.NullString{String: "hello", Valid: true}, row.Note)
+}
+
+// TestTimeLayouts 测试自定义时间格式的解析和格式化
+func TestTimeLayouts(t *testing.T) {
+	defer sqlutils.SetTimeLayouts()
+
+	sqlutils.SetTimeLayouts("2006/01/02 15:04", "2006-01-02")
+	parsed, err := sqlutils.ParseTimeString("2024/05/06 07:08")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 6, 7, 8, 0, 0, time.UTC), parsed)
+	assert.Equal(t, "2024/05/06 07:08", sqlutils.FormatTime(parsed))
+
+	_, err = sqlutils.ParseTimeString("2024-05-06 07:08:09")
+	assert.Error(t, err)
+
+	sqlutils.SetTimeLayouts()
+	assert.Equal(t, "2006-01-02 15:04:05", sqlutils.GetTimeLayouts()[0])
+}
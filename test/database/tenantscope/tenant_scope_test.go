@@ -0,0 +1,145 @@
+package tenantscope
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database"
+)
+
+// tenantTestRow 测试记录
+type tenantTestRow struct {
+	TenantId string `db:"tenantId"`
+	ID       string `db:"id"`
+	Name     string `db:"name"`
+}
+
+// fakeDatabase 记录最后一次执行的SQL和参数，只实现测试用到的方法
+type fakeDatabase struct {
+	database.Database
+	query string
+	where string
+	args  []interface{}
+}
+
+func (f *fakeDatabase) Query(ctx context.Context, dest interface{}, query string, args []interface{}, autoCommit bool) error {
+	f.query, f.args = query, args
+	return nil
+}
+
+func (f *fakeDatabase) Exec(ctx context.Context, query string, args []interface{}, autoCommit bool) (int64, error) {
+	f.query, f.args = query, args
+	return 1, nil
+}
+
+func (f *fakeDatabase) Insert(ctx context.Context, table string, data interface{}, autoCommit bool) (int64, error) {
+	return 1, nil
+}
+
+func (f *fakeDatabase) BatchInsert(ctx context.Context, table string, dataSlice interface{}, autoCommit bool) (int64, error) {
+	return 1, nil
+}
+
+func (f *fakeDatabase) Update(ctx context.Context, table string, data interface{}, where string, args []interface{}, autoCommit bool, skipZero bool) (int64, error) {
+	f.where, f.args = where, args
+	return 1, nil
+}
+
+func (f *fakeDatabase) Delete(ctx context.Context, table string, where string, args []interface{}, autoCommit bool) (int64, error) {
+	f.where, f.args = where, args
+	return 1, nil
+}
+
+func newTenantScopedDatabase() (*database.TenantScopedDatabase, *fakeDatabase) {
+	fake := &fakeDatabase{}
+	db := database.NewTenantScopedDatabase(fake, "HUB_SERVICE", "HUB_SERVICE_NAMESPACE")
+	db.RegisterTable("HUB_USER", "tenant_id")
+	return db, fake
+}
+
+// TestTenantScopedQuery 测试查询语句附加租户条件
+func TestTenantScopedQuery(t *testing.T) {
+	db, fake := newTenantScopedDatabase()
+	ctx := database.WithTenant(context.Background(), "t1")
+	var rows []tenantTestRow
+
+	require.NoError(t, db.Query(ctx, &rows, "SELECT * FROM HUB_SERVICE WHERE a = ? OR b = ? ORDER BY id LIMIT ?", []interface{}{1, 2, 10}, true))
+	assert.Equal(t, "SELECT * FROM HUB_SERVICE WHERE tenantId = ? AND (a = ? OR b = ?) ORDER BY id LIMIT ?", fake.query)
+	assert.Equal(t, []interface{}{"t1", 1, 2, 10}, fake.args)
+
+	require.NoError(t, db.Query(ctx, &rows, "SELECT s.* FROM HUB_SERVICE s LEFT JOIN HUB_USER u ON u.id = s.userId WHERE s.name LIKE '%?%';", nil, true))
+	assert.Equal(t, "SELECT s.* FROM HUB_SERVICE s LEFT JOIN HUB_USER u ON u.tenant_id = ? AND (u.id = s.userId) WHERE s.tenantId = ? AND (s.name LIKE '%?%');", fake.query)
+	assert.Equal(t, []interface{}{"t1", "t1"}, fake.args)
+
+	// 未注册的表不改写
+	query := "SELECT * FROM HUB_GW_ROUTE_CONFIG WHERE routeId = ?"
+	require.NoError(t, db.Query(ctx, &rows, query, []interface{}{"r1"}, true))
+	assert.Equal(t, query, fake.query)
+}
+
+// TestTenantScopedExec 测试原始SQL写入附加租户条件
+func TestTenantScopedExec(t *testing.T) {
+	db, fake := newTenantScopedDatabase()
+	ctx := database.WithTenant(context.Background(), "t1")
+
+	_, err := db.Exec(ctx, "UPDATE HUB_SERVICE SET name = ?", []interface{}{"n"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, "UPDATE HUB_SERVICE SET name = ? WHERE tenantId = ?", fake.query)
+	assert.Equal(t, []interface{}{"n", "t1"}, fake.args)
+
+	_, err = db.Exec(ctx, "INSERT INTO HUB_SERVICE (tenantId, id) VALUES (?, ?)", []interface{}{"t1", "1"}, true)
+	require.NoError(t, err)
+
+	_, err = db.Exec(ctx, "INSERT INTO HUB_SERVICE (id) VALUES (?)", []interface{}{"1"}, true)
+	assert.ErrorIs(t, err, database.ErrTenantScopeUnsupported)
+
+	_, err = db.Exec(ctx, "DELETE FROM HUB_GW_ROUTE_CONFIG WHERE serviceId IN (SELECT id FROM HUB_SERVICE)", nil, true)
+	assert.ErrorIs(t, err, database.ErrTenantScopeUnsupported)
+}
+
+// TestTenantScopeRequired 测试缺少租户时拒绝访问，跳过隔离时直接访问
+func TestTenantScopeRequired(t *testing.T) {
+	db, fake := newTenantScopedDatabase()
+	var rows []tenantTestRow
+
+	err := db.Query(context.Background(), &rows, "SELECT * FROM hub_service", nil, true)
+	assert.ErrorIs(t, err, database.ErrTenantRequired)
+	_, err = db.Delete(context.Background(), "HUB_SERVICE", "id = ?", []interface{}{"1"}, true)
+	assert.ErrorIs(t, err, database.ErrTenantRequired)
+
+	ctx := database.WithoutTenantScope(context.Background())
+	require.NoError(t, db.Query(ctx, &rows, "SELECT * FROM hub_service", nil, true))
+	assert.Equal(t, "SELECT * FROM hub_service", fake.query)
+}
+
+// TestTenantScopedWrite 测试结构化写入填充租户ID并附加租户条件
+func TestTenantScopedWrite(t *testing.T) {
+	db, fake := newTenantScopedDatabase()
+	ctx := database.WithTenant(context.Background(), "t1")
+
+	row := &tenantTestRow{ID: "1"}
+	_, err := db.Insert(ctx, "HUB_SERVICE", row, true)
+	require.NoError(t, err)
+	assert.Equal(t, "t1", row.TenantId)
+
+	_, err = db.Insert(ctx, "HUB_SERVICE", &tenantTestRow{TenantId: "t2"}, true)
+	assert.ErrorIs(t, err, database.ErrTenantMismatch)
+
+	rows := []tenantTestRow{{ID: "1"}, {ID: "2"}}
+	_, err = db.BatchInsert(ctx, "HUB_SERVICE", rows, true)
+	require.NoError(t, err)
+	assert.Equal(t, "t1", rows[1].TenantId)
+
+	_, err = db.Update(ctx, "HUB_SERVICE", &tenantTestRow{Name: "n"}, "id = ? OR id = ?", []interface{}{"1", "2"}, true, true)
+	require.NoError(t, err)
+	assert.Equal(t, "(id = ? OR id = ?) AND tenantId = ?", fake.where)
+	assert.Equal(t, []interface{}{"1", "2", "t1"}, fake.args)
+
+	_, err = db.BatchDeleteByKeys(ctx, "HUB_SERVICE", "id", []interface{}{"1", "2"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, "id IN (?, ?) AND tenantId = ?", fake.where)
+	assert.Equal(t, []interface{}{"1", "2", "t1"}, fake.args)
+}
@@ -0,0 +1,86 @@
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/gateway/bootstrap"
+	"gateway/internal/gateway/config"
+	"gateway/internal/gateway/handler/router"
+	"gateway/internal/gateway/handler/service"
+)
+
+func newDiffTestConfig() *config.GatewayConfig {
+	cfg := &config.GatewayConfig{InstanceID: "gw1"}
+	cfg.Base.Listen = ":8080"
+	cfg.Router.Routes = []router.RouteConfig{
+		{ID: "route-a", Name: "A", ServiceID: "svc-1", Path: "/a", MatchType: router.MatchTypePrefix, Enabled: true},
+		{ID: "route-b", Name: "B", ServiceID: "svc-1", Path: "/b", MatchType: router.MatchTypePrefix, Enabled: true},
+	}
+	cfg.Proxy.Service = []*service.ServiceConfig{
+		{ID: "svc-1", Name: "service-1", Strategy: service.RoundRobin},
+	}
+	return cfg
+}
+
+func TestDiffGatewayConfig(t *testing.T) {
+	current := newDiffTestConfig()
+	next := newDiffTestConfig()
+
+	assert.Empty(t, bootstrap.DiffGatewayConfig(current, next))
+
+	next.Base.Listen = ":9090"
+	next.Router.Routes[0].Path = "/a2"
+	next.Router.Routes = append(next.Router.Routes[:1], router.RouteConfig{
+		ID: "route-c", Name: "C", ServiceID: "svc-1", Path: "/c", MatchType: router.MatchTypePrefix, Enabled: true,
+	})
+	next.Proxy.Service[0] = &service.ServiceConfig{ID: "svc-1", Name: "service-1", Strategy: service.Random}
+
+	changes := bootstrap.DiffGatewayConfig(current, next)
+	require.Len(t, changes, 5)
+
+	assert.Equal(t, bootstrap.ConfigChange{
+		Category: bootstrap.ConfigDiffCategorySection, ID: "base", Action: bootstrap.ConfigDiffActionModified, Fields: []string{"listen"},
+	}, changes[0])
+	assert.Equal(t, bootstrap.ConfigChange{
+		Category: bootstrap.ConfigDiffCategoryRoute, ID: "route-a", Name: "A", Action: bootstrap.ConfigDiffActionModified, Fields: []string{"path"},
+	}, changes[1])
+	assert.Equal(t, bootstrap.ConfigDiffActionRemoved, changes[2].Action)
+	assert.Equal(t, "route-b", changes[2].ID)
+	assert.Equal(t, bootstrap.ConfigDiffActionAdded, changes[3].Action)
+	assert.Equal(t, "route-c", changes[3].ID)
+	assert.Equal(t, bootstrap.ConfigChange{
+		Category: bootstrap.ConfigDiffCategoryService, ID: "svc-1", Name: "service-1", Action: bootstrap.ConfigDiffActionModified, Fields: []string{"strategy"},
+	}, changes[4])
+
+	// 实例未运行时所有配置项都视为新增
+	changes = bootstrap.DiffGatewayConfig(nil, current)
+	added := 0
+	for _, change := range changes {
+		if change.Action == bootstrap.ConfigDiffActionAdded {
+			added++
+		}
+	}
+	assert.Equal(t, 3, added)
+}
+
+func TestValidateGatewayConfigRoutes(t *testing.T) {
+	require.NoError(t, bootstrap.ValidateGatewayConfig(newDiffTestConfig()))
+
+	cfg := newDiffTestConfig()
+	cfg.Router.Routes[1].ServiceID = ""
+	assert.Error(t, bootstrap.ValidateGatewayConfig(cfg), "路由未配置服务")
+
+	cfg = newDiffTestConfig()
+	cfg.Router.Routes[1].ServiceID = "svc-missing"
+	err := bootstrap.ValidateGatewayConfig(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "svc-missing")
+
+	cfg = newDiffTestConfig()
+	cfg.Router.Routes[0].MatchType = router.MatchTypeRegex
+	cfg.Router.Routes[0].Path = "/a/(["
+	assert.Error(t, bootstrap.ValidateGatewayConfig(cfg), "正则路径无效")
+}
@@ -0,0 +1,50 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/gateway/bootstrap"
+	"gateway/internal/gateway/config"
+	"gateway/internal/gateway/constants"
+)
+
+// TestGatewayMaintenanceMode 验证维护模式下新请求直接返回503和Retry-After，关闭后恢复正常处理
+func TestGatewayMaintenanceMode(t *testing.T) {
+	cfg := config.DefaultGatewayConfig
+	cfg.InstanceID = "gw-maintenance-test"
+	gateway, err := bootstrap.NewGatewayFactory().CreateGateway(&cfg, "")
+	require.NoError(t, err)
+	assert.False(t, gateway.IsMaintenanceMode(), "新建网关默认不处于维护模式")
+
+	serve := func() *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		gateway.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/orders", nil))
+		return recorder
+	}
+
+	gateway.SetMaintenanceMode(true)
+	assert.True(t, gateway.IsMaintenanceMode())
+	recorder := serve()
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, "60", recorder.Header().Get("Retry-After"))
+	var body struct {
+		Code string `json:"code"`
+		Path string `json:"path"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, constants.ErrorCodeMaintenance, body.Code)
+	assert.Equal(t, "/api/orders", body.Path)
+
+	// 关闭维护模式后请求进入处理器链，未配置路由时返回404
+	gateway.SetMaintenanceMode(false)
+	assert.False(t, gateway.IsMaintenanceMode())
+	recorder = serve()
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Empty(t, recorder.Header().Get("Retry-After"))
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http/httptest"
 	"testing"
@@ -9,7 +10,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"gateway/internal/gateway/constants"
 	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/helper"
 )
 
 func TestNewContext(t *testing.T) {
@@ -241,6 +244,64 @@ func TestContextAbort(t *testing.T) {
 	assert.Contains(t, writer.Body.String(), "unauthorized", "响应体应该包含错误信息")
 }
 
+func TestContextAbortWithErrorResponseConfig(t *testing.T) {
+	errorResponse := &helper.ErrorResponseConfig{
+		Enabled: true,
+		Rules: []helper.ErrorMappingRule{
+			{Code: constants.ErrorCodeTimeout, ResponseStatus: 504, Message: "upstream timeout"},
+		},
+		TenantRules: map[string][]helper.ErrorMappingRule{
+			"tenant-a": {{Code: constants.ErrorCodeRateLimitExceeded, ResponseCode: "TOO_MANY_REQUESTS", Message: "slow down"}},
+		},
+	}
+
+	newContext := func(tenantID string) (*core.Context, *httptest.ResponseRecorder) {
+		writer := httptest.NewRecorder()
+		ctx := core.NewContext(writer, httptest.NewRequest("GET", "/api/orders", nil))
+		ctx.Set(constants.ContextKeyTraceID, "trace-1")
+		ctx.Set(constants.ContextKeyTenantID, tenantID)
+		ctx.SetErrorResponseConfig(errorResponse)
+		return ctx, writer
+	}
+
+	// 处理器指定的错误码和原始消息
+	ctx, writer := newContext("tenant-b")
+	ctx.Abort(429, map[string]string{"error": "Rate limit exceeded", "error_code": constants.ErrorCodeRateLimitExceeded})
+	var envelope helper.ErrorEnvelope
+	assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &envelope))
+	assert.Equal(t, 429, writer.Code)
+	assert.Equal(t, constants.ErrorCodeRateLimitExceeded, envelope.Code)
+	assert.Equal(t, "Rate limit exceeded", envelope.Message)
+	assert.Equal(t, "trace-1", envelope.TraceID)
+	assert.Equal(t, "/api/orders", envelope.Path)
+
+	// 租户规则覆盖错误码和消息
+	ctx, writer = newContext("tenant-a")
+	ctx.Abort(429, map[string]string{"error": "Rate limit exceeded", "error_code": constants.ErrorCodeRateLimitExceeded})
+	envelope = helper.ErrorEnvelope{}
+	assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &envelope))
+	assert.Equal(t, "TOO_MANY_REQUESTS", envelope.Code)
+	assert.Equal(t, "slow down", envelope.Message)
+
+	// 全局规则覆盖状态码，并同步到网关状态码
+	ctx, writer = newContext("tenant-a")
+	ctx.Abort(502, map[string]string{"error": "proxy request timeout", "error_code": constants.ErrorCodeTimeout})
+	envelope = helper.ErrorEnvelope{}
+	assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &envelope))
+	assert.Equal(t, 504, writer.Code)
+	assert.Equal(t, 504, envelope.Status)
+	assert.Equal(t, "upstream timeout", envelope.Message)
+	statusCode, _ := ctx.GetInt(constants.GatewayStatusCode)
+	assert.Equal(t, 504, statusCode)
+
+	// 未指定错误码时根据状态码推断
+	ctx, writer = newContext("tenant-b")
+	ctx.Abort(401, map[string]string{"error": "Unauthorized"})
+	envelope = helper.ErrorEnvelope{}
+	assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &envelope))
+	assert.Equal(t, constants.ErrorCodeAuthenticationFail, envelope.Code)
+}
+
 func TestContextPathParams(t *testing.T) {
 	req := httptest.NewRequest("GET", "/test", nil)
 	writer := httptest.NewRecorder()
@@ -8,8 +8,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"gateway/internal/gateway/constants"
 	"gateway/internal/gateway/core"
 	"gateway/internal/gateway/handler/assertion"
+	"gateway/pkg/utils/geoip"
 )
 
 func TestAssertionConfig(t *testing.T) {
@@ -454,6 +456,73 @@ func TestIPAssertion(t *testing.T) {
 	}
 }
 
+func TestGeoAssertion(t *testing.T) {
+	factory := assertion.NewAssertionFactory()
+	euAssertion, err := factory.CreateAssertion(assertion.AssertionConfig{
+		Type:     "geo",
+		Name:     "continent",
+		Value:    "eu",
+		Operator: "equal",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, assertion.GeoAssertion, euAssertion.GetType())
+
+	countryAssertion, err := factory.CreateAssertion(assertion.AssertionConfig{
+		Type:     "geo",
+		Name:     "country",
+		Value:    "^(DE|FR)$",
+		Operator: "matches",
+	})
+	assert.NoError(t, err)
+
+	_, err = factory.CreateAssertion(assertion.AssertionConfig{Type: "geo", Name: "latitude", Operator: "equal"})
+	assert.Error(t, err, "不支持的地理位置字段应返回错误")
+
+	tests := []struct {
+		name          string
+		location      *geoip.Location
+		expectEU      bool
+		expectCountry bool
+	}{
+		{
+			name:          "Germany",
+			location:      &geoip.Location{ContinentCode: "EU", CountryCode: "DE"},
+			expectEU:      true,
+			expectCountry: true,
+		},
+		{
+			name:          "UnitedKingdom",
+			location:      &geoip.Location{ContinentCode: "EU", CountryCode: "GB"},
+			expectEU:      true,
+			expectCountry: false,
+		},
+		{
+			name:     "China",
+			location: &geoip.Location{ContinentCode: "AS", CountryCode: "CN"},
+		},
+		{
+			name: "Unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := core.NewContext(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/test", nil))
+			if tt.location != nil {
+				ctx.Set(constants.ContextKeyClientGeoLocation, tt.location)
+			}
+
+			result, err := euAssertion.Evaluate(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectEU, result)
+
+			result, err = countryAssertion.Evaluate(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectCountry, result)
+		})
+	}
+}
+
 func TestCookieAssertion(t *testing.T) {
 	config := &assertion.AssertionConfig{
 		ID:       "test-cookie",
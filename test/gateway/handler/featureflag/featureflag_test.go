@@ -0,0 +1,134 @@
+package featureflag
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/featureflag"
+	"gateway/internal/gateway/handler/filter"
+)
+
+// newRequestContext 创建属于指定实例和路由的请求上下文
+func newRequestContext(instanceID, routeID, clientIP string) *core.Context {
+	req := httptest.NewRequest("GET", "/api/test", nil)
+	req.RemoteAddr = clientIP + ":12345"
+	ctx := core.NewContext(httptest.NewRecorder(), req)
+	ctx.Set(constants.ContextKeyGatewayInstanceID, instanceID)
+	ctx.SetRouteID(routeID)
+	return ctx
+}
+
+// registerManager 注册测试用开关管理器，测试结束时注销
+func registerManager(t *testing.T, instanceID string, flags []featureflag.Flag) *featureflag.Manager {
+	manager := featureflag.NewManager("default", instanceID, nil, nil, 0)
+	manager.Replace(flags)
+	featureflag.Register(manager)
+	t.Cleanup(func() { featureflag.Unregister(manager) })
+	return manager
+}
+
+func TestFlagInRollout(t *testing.T) {
+	disabled := featureflag.Flag{Key: "waf", Enabled: false, RolloutPercent: 100}
+	assert.False(t, disabled.InRollout("10.0.0.1"))
+
+	full := featureflag.Flag{Key: "waf", Enabled: true, RolloutPercent: 100}
+	assert.True(t, full.InRollout("10.0.0.1"))
+
+	zero := featureflag.Flag{Key: "waf", Enabled: true, RolloutPercent: 0}
+	assert.False(t, zero.InRollout("10.0.0.1"))
+
+	partial := featureflag.Flag{Key: "waf", Enabled: true, RolloutPercent: 10}
+	hits := 0
+	for i := 0; i < 10000; i++ {
+		value := fmt.Sprintf("10.0.%d.%d", i/256, i%256)
+		result := partial.InRollout(value)
+		// 同一分桶值判定结果稳定
+		assert.Equal(t, result, partial.InRollout(value))
+		if result {
+			hits++
+		}
+	}
+	assert.InDelta(t, 1000, hits, 200, "10%%放量命中数应接近1000，实际为%d", hits)
+}
+
+func TestManagerLookupPrefersMostSpecific(t *testing.T) {
+	manager := featureflag.NewManager("default", "gw-1", nil, nil, 0)
+	manager.Replace([]featureflag.Flag{
+		{FlagID: "global", Key: "waf", Enabled: true, RolloutPercent: 100},
+		{FlagID: "instance", Key: "waf", GatewayInstanceID: "gw-1", Enabled: true, RolloutPercent: 50},
+		{FlagID: "route", Key: "waf", GatewayInstanceID: "gw-1", RouteConfigID: "route-x", Enabled: true, RolloutPercent: 10},
+		{FlagID: "other-instance", Key: "waf", GatewayInstanceID: "gw-2", Enabled: false},
+	})
+
+	assert.Equal(t, 3, manager.Count(), "限定到其他实例的开关不应加载")
+	assert.Equal(t, "route", manager.Lookup("route-x", "waf").FlagID)
+	assert.Equal(t, "instance", manager.Lookup("route-y", "waf").FlagID)
+	assert.Nil(t, manager.Lookup("route-x", "missing"))
+}
+
+func TestEnabledRecordsEvaluation(t *testing.T) {
+	registerManager(t, "gw-eval", []featureflag.Flag{
+		{Key: "new-waf", RouteConfigID: "route-x", Enabled: true, RolloutPercent: 100, LogEvaluation: true},
+		{Key: "quiet", Enabled: true, RolloutPercent: 100},
+	})
+
+	ctx := newRequestContext("gw-eval", "route-x", "10.0.0.1")
+	assert.True(t, featureflag.Enabled(ctx, "new-waf"))
+	assert.True(t, featureflag.Enabled(ctx, "quiet"))
+	assert.False(t, featureflag.Enabled(ctx, "missing"))
+	assert.Equal(t, map[string]bool{"new-waf": true}, featureflag.Evaluations(ctx))
+
+	// 路由级开关不作用于其他路由
+	other := newRequestContext("gw-eval", "route-y", "10.0.0.1")
+	assert.False(t, featureflag.Enabled(other, "new-waf"))
+	assert.Nil(t, featureflag.Evaluations(other))
+
+	// 未注册管理器的实例视为关闭
+	assert.False(t, featureflag.Enabled(newRequestContext("gw-unknown", "route-x", "10.0.0.1"), "new-waf"))
+}
+
+func TestEnabledBucketByHeader(t *testing.T) {
+	registerManager(t, "gw-header", []featureflag.Flag{
+		{Key: "beta", Enabled: true, RolloutPercent: 50, BucketBy: featureflag.BucketByHeader, BucketKey: "X-User-Id"},
+	})
+
+	// 同一用户在不同客户端IP下判定结果一致
+	var first *bool
+	for i := 0; i < 20; i++ {
+		ctx := newRequestContext("gw-header", "route-x", fmt.Sprintf("10.0.0.%d", i))
+		ctx.Request.Header.Set("X-User-Id", "user-42")
+		result := featureflag.Enabled(ctx, "beta")
+		if first == nil {
+			first = &result
+			continue
+		}
+		assert.Equal(t, *first, result)
+	}
+}
+
+func TestFilterShouldApplyConsultsFeatureFlag(t *testing.T) {
+	registerManager(t, "gw-filter", []featureflag.Flag{
+		{Key: "on", Enabled: true, RolloutPercent: 100},
+		{Key: "off", Enabled: false, RolloutPercent: 100},
+	})
+	ctx := newRequestContext("gw-filter", "route-x", "10.0.0.1")
+
+	plain := filter.NewBaseFilter(filter.HeaderFilterType, filter.PreRouting, 10, true, "plain")
+	assert.True(t, filter.ShouldApply(ctx, plain))
+
+	gatedOn := filter.NewBaseFilter(filter.HeaderFilterType, filter.PreRouting, 10, true, "gated-on")
+	gatedOn.GetConfig().Config[filter.FeatureFlagConfigKey] = "on"
+	assert.True(t, filter.ShouldApply(ctx, gatedOn))
+
+	gatedOff := filter.NewBaseFilter(filter.HeaderFilterType, filter.PreRouting, 10, true, "gated-off")
+	gatedOff.GetConfig().Config[filter.FeatureFlagConfigKey] = "off"
+	assert.False(t, filter.ShouldApply(ctx, gatedOff))
+
+	disabled := filter.NewBaseFilter(filter.HeaderFilterType, filter.PreRouting, 10, false, "disabled")
+	assert.False(t, filter.ShouldApply(ctx, disabled))
+}
@@ -0,0 +1,146 @@
+package filter
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/filter"
+)
+
+// newABTestFilter 从配置创建A/B测试过滤器
+func newABTestFilter(t *testing.T, config map[string]interface{}) *filter.ABTestFilter {
+	f, err := filter.NewFilterFactory().CreateFilter(filter.FilterConfig{
+		ID:      "ab-checkout",
+		Type:    string(filter.ABTestFilterType),
+		Enabled: true,
+		Action:  "pre-routing",
+		Config:  config,
+	})
+	require.NoError(t, err)
+	return f.(*filter.ABTestFilter)
+}
+
+// newABTestContext 创建带链路追踪ID的请求上下文
+func newABTestContext(traceID string) (*core.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	ctx := core.NewContext(w, httptest.NewRequest(http.MethodGet, "/checkout", nil))
+	ctx.Set(constants.ContextKeyTraceID, traceID)
+	return ctx, w
+}
+
+// TestABTestFilterConfig 验证配置校验
+func TestABTestFilterConfig(t *testing.T) {
+	factory := filter.NewFilterFactory()
+	invalid := []map[string]interface{}{
+		{"variants": []interface{}{"A", "B"}},
+		{"experiment": "check out", "variants": []interface{}{"A"}},
+		{"experiment": "checkout"},
+		{"experiment": "checkout", "variants": []interface{}{"A", "A"}},
+		{"experiment": "checkout", "variants": []interface{}{map[string]interface{}{"name": "A", "weight": float64(0)}}},
+		{"experiment": "checkout", "variants": []interface{}{"A"}, "bucketBy": "header"},
+		{"experiment": "checkout", "variants": []interface{}{"A"}, "bucketBy": "ip"},
+	}
+	for _, config := range invalid {
+		_, err := factory.CreateFilter(filter.FilterConfig{ID: "ab", Type: "ab-test", Config: config})
+		assert.Error(t, err, "%v", config)
+	}
+
+	f := newABTestFilter(t, map[string]interface{}{
+		"abTestConfig": map[string]interface{}{
+			"experiment": "checkout",
+			"variants": []interface{}{
+				map[string]interface{}{"name": "A", "weight": float64(90)},
+				map[string]interface{}{"name": "B", "weight": 10},
+			},
+		},
+	})
+	assert.Equal(t, "checkout", f.Experiment)
+	assert.Equal(t, filter.ABTestBucketByCookie, f.BucketBy)
+	assert.Equal(t, "gw_ab_checkout", f.CookieName)
+	assert.Equal(t, filter.DefaultABTestVariantHeader, f.VariantHeader)
+	assert.Equal(t, []filter.ABTestVariant{{Name: "A", Weight: 90}, {Name: "B", Weight: 10}}, f.Variants)
+}
+
+// TestABTestFilterPick 验证分组稳定，且按权重比例分配
+func TestABTestFilterPick(t *testing.T) {
+	f := newABTestFilter(t, map[string]interface{}{
+		"experiment": "checkout",
+		"variants": []interface{}{
+			map[string]interface{}{"name": "A", "weight": float64(80)},
+			map[string]interface{}{"name": "B", "weight": float64(20)},
+		},
+	})
+
+	counts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		counts[f.Pick(fmt.Sprintf("client-%d", i))]++
+	}
+	assert.InDelta(t, 8000, counts["A"], 400)
+	assert.InDelta(t, 2000, counts["B"], 400)
+	assert.Equal(t, f.Pick("user-42"), f.Pick("user-42"))
+}
+
+// TestABTestFilterCookieSticky 验证首次访问写入Cookie，后续请求沿用Cookie中的实验组并转发请求头
+func TestABTestFilterCookieSticky(t *testing.T) {
+	f := newABTestFilter(t, map[string]interface{}{
+		"experiment": "checkout",
+		"variants":   []interface{}{"A", "B"},
+	})
+
+	ctx, w := newABTestContext("trace-1")
+	ctx.Request.Header.Set(filter.DefaultABTestVariantHeader, "forged")
+	require.NoError(t, f.Apply(ctx))
+	variant := ctx.Request.Header.Get(filter.DefaultABTestVariantHeader)
+	assert.Contains(t, []string{"A", "B"}, variant)
+
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "gw_ab_checkout", cookies[0].Name)
+	assert.Equal(t, variant, cookies[0].Value)
+	assert.Equal(t, map[string]string{"checkout": variant}, filter.ABTestAssignments(ctx))
+
+	// 携带Cookie的后续请求不论链路追踪ID如何都沿用原实验组，且不再写Cookie
+	for _, traceID := range []string{"trace-2", "trace-3", "trace-4"} {
+		next, nextW := newABTestContext(traceID)
+		next.Request.AddCookie(cookies[0])
+		require.NoError(t, f.Apply(next))
+		assert.Equal(t, variant, next.Request.Header.Get(filter.DefaultABTestVariantHeader))
+		assert.Empty(t, nextW.Result().Cookies())
+	}
+
+	// Cookie中的实验组已不存在时重新分配
+	stale, staleW := newABTestContext("trace-5")
+	stale.Request.AddCookie(&http.Cookie{Name: "gw_ab_checkout", Value: "C"})
+	require.NoError(t, f.Apply(stale))
+	assert.Contains(t, []string{"A", "B"}, stale.Request.Header.Get(filter.DefaultABTestVariantHeader))
+	assert.Len(t, staleW.Result().Cookies(), 1)
+}
+
+// TestABTestFilterHeaderBucket 验证按用户ID请求头分桶，请求头为空时退化为按Cookie分桶
+func TestABTestFilterHeaderBucket(t *testing.T) {
+	f := newABTestFilter(t, map[string]interface{}{
+		"experiment":    "search",
+		"variants":      []interface{}{"control", "treatment"},
+		"bucketBy":      "header",
+		"bucketKey":     "X-User-Id",
+		"variantHeader": "X-Search-Variant",
+	})
+
+	ctx, w := newABTestContext("trace-1")
+	ctx.Request.Header.Set("X-User-Id", "u-1001")
+	require.NoError(t, f.Apply(ctx))
+	assert.Equal(t, f.Pick("u-1001"), ctx.Request.Header.Get("X-Search-Variant"))
+	assert.Empty(t, w.Result().Cookies(), "按用户ID分桶时不写Cookie")
+
+	anonymous, anonymousW := newABTestContext("trace-2")
+	require.NoError(t, f.Apply(anonymous))
+	assert.NotEmpty(t, anonymous.Request.Header.Get("X-Search-Variant"))
+	assert.Len(t, anonymousW.Result().Cookies(), 1)
+}
@@ -0,0 +1,157 @@
+package filter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/filter"
+	"gateway/pkg/config"
+)
+
+// setFaultInjectionEnabled 设置故障注入总开关
+func setFaultInjectionEnabled(t *testing.T, enabled bool) {
+	file := filepath.Join(t.TempDir(), "app.yaml")
+	content := "app:\n  gateway:\n    fault_injection:\n      enabled: false\n"
+	if enabled {
+		content = strings.Replace(content, "false", "true", 1)
+	}
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+	require.NoError(t, config.LoadConfigFile(file, config.LoadOptions{ClearExisting: true}))
+	t.Cleanup(config.Clear)
+}
+
+// newFaultFilter 从配置创建故障注入过滤器，随机数固定为0，比例大于0的故障总是注入
+func newFaultFilter(t *testing.T, faultConfig map[string]interface{}) *filter.FaultInjectionFilter {
+	f, err := filter.NewFilterFactory().CreateFilter(filter.FilterConfig{
+		ID:      "chaos",
+		Type:    string(filter.FaultInjectionFilterType),
+		Enabled: true,
+		Config:  map[string]interface{}{"faultConfig": faultConfig},
+	})
+	require.NoError(t, err)
+	return f.(*filter.FaultInjectionFilter).SetRandom(func() float64 { return 0 })
+}
+
+// TestFaultInjectionConfig 验证配置校验
+func TestFaultInjectionConfig(t *testing.T) {
+	factory := filter.NewFilterFactory()
+	invalid := []map[string]interface{}{
+		{},
+		{"delay": map[string]interface{}{"percent": float64(10)}},
+		{"delay": map[string]interface{}{"percent": float64(120), "durationMs": float64(100)}},
+		{"abort": map[string]interface{}{"statusCode": float64(503)}},
+		{"abort": map[string]interface{}{"percent": float64(10), "statusCode": float64(200)}},
+		{"corrupt": map[string]interface{}{"percent": float64(10), "mode": "shuffle"}},
+	}
+	for _, faultConfig := range invalid {
+		_, err := factory.CreateFilter(filter.FilterConfig{ID: "chaos", Type: "fault-injection", Config: faultConfig})
+		assert.Error(t, err, "%v", faultConfig)
+	}
+}
+
+// TestFaultInjectionDisabled 验证总开关关闭时不注入任何故障
+func TestFaultInjectionDisabled(t *testing.T) {
+	setFaultInjectionEnabled(t, false)
+	f := newFaultFilter(t, map[string]interface{}{
+		"abort": map[string]interface{}{"percent": float64(100)},
+	})
+
+	w := httptest.NewRecorder()
+	ctx := core.NewContext(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	require.NoError(t, f.Apply(ctx))
+	assert.False(t, ctx.IsResponded())
+	_, injected := ctx.Get(constants.ContextKeyFaultInjected)
+	assert.False(t, injected)
+}
+
+// TestFaultInjectionDelayAndAbort 验证注入延迟后中止请求，返回配置的状态码并记录注入的故障
+func TestFaultInjectionDelayAndAbort(t *testing.T) {
+	setFaultInjectionEnabled(t, true)
+	f := newFaultFilter(t, map[string]interface{}{
+		"delay": map[string]interface{}{"percent": float64(100), "durationMs": float64(50)},
+		"abort": map[string]interface{}{"percent": 100, "statusCode": 502, "message": "chaos"},
+	})
+
+	w := httptest.NewRecorder()
+	ctx := core.NewContext(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	start := time.Now()
+	require.Error(t, f.Apply(ctx))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.Contains(t, w.Body.String(), "chaos")
+	faults, _ := ctx.GetString(constants.ContextKeyFaultInjected)
+	assert.Equal(t, "delay=50ms,abort=502", faults)
+
+	// 随机数超出比例时不注入
+	skip := newFaultFilter(t, map[string]interface{}{
+		"abort": map[string]interface{}{"percent": float64(30)},
+	}).SetRandom(func() float64 { return 0.5 })
+	other := core.NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders", nil))
+	require.NoError(t, skip.Apply(other))
+	assert.False(t, other.IsResponded())
+}
+
+// TestFaultInjectionDelayCanceled 验证延迟期间请求取消时立即返回
+func TestFaultInjectionDelayCanceled(t *testing.T) {
+	setFaultInjectionEnabled(t, true)
+	f := newFaultFilter(t, map[string]interface{}{
+		"delay": map[string]interface{}{"percent": float64(100), "durationMs": float64(60000)},
+	})
+
+	requestCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil).WithContext(requestCtx)
+	ctx := core.NewContext(httptest.NewRecorder(), req)
+	start := time.Now()
+	assert.Error(t, f.Apply(ctx))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+// TestFaultInjectionCorrupt 验证响应损坏：篡改保持长度不变，截断只保留前若干字节
+func TestFaultInjectionCorrupt(t *testing.T) {
+	setFaultInjectionEnabled(t, true)
+	body := `{"orderId":"1001","status":"PAID","amount":100}`
+
+	cases := []struct {
+		mode   string
+		verify func(t *testing.T, got string)
+	}{
+		{"garble", func(t *testing.T, got string) {
+			assert.Len(t, got, len(body))
+			assert.NotEqual(t, body, got)
+		}},
+		{"truncate", func(t *testing.T, got string) {
+			assert.Equal(t, body[:10], got)
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.mode, func(t *testing.T) {
+			f := newFaultFilter(t, map[string]interface{}{
+				"corrupt": map[string]interface{}{"percent": float64(100), "mode": tc.mode, "truncateBytes": float64(10)},
+			})
+			w := httptest.NewRecorder()
+			ctx := core.NewContext(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+			require.NoError(t, f.Apply(ctx))
+
+			// 模拟代理分两次写入后端响应
+			n, err := io.WriteString(ctx.Writer, body[:20])
+			require.NoError(t, err)
+			assert.Equal(t, 20, n)
+			_, err = io.WriteString(ctx.Writer, body[20:])
+			require.NoError(t, err)
+			tc.verify(t, w.Body.String())
+		})
+	}
+}
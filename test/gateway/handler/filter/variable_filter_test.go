@@ -0,0 +1,125 @@
+package filter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/filter"
+)
+
+// newVariableContext 创建带租户请求头和查询参数的请求上下文
+func newVariableContext() *core.Context {
+	req := httptest.NewRequest(http.MethodPost, "/orders?version=v2", nil)
+	req.Header.Set("X-Tenant-Id", "t1")
+	return core.NewContext(httptest.NewRecorder(), req)
+}
+
+// TestExpandVars 验证模板变量展开
+func TestExpandVars(t *testing.T) {
+	ctx := newVariableContext()
+	ctx.Set("user_id", "u1")
+	ctx.Set("claims", map[string]interface{}{"sub": "u1"})
+	ctx.SetRouteID("route-orders")
+	ctx.SetVar("attempt", 2)
+
+	cases := map[string]string{
+		"plain":                                "plain",
+		"${ctx.user_id}@${header.X-Tenant-Id}": "u1@t1",
+		"${ctx.attempt}":                       "2",
+		"${ctx.claims}":                        "",
+		"${ctx.missing:-anonymous}":            "anonymous",
+		"${query.version}":                     "v2",
+		"${request.method} ${request.path}":    "POST /orders",
+		"${route.id}":                          "route-orders",
+		"${env.HOME}":                          "${env.HOME}",
+	}
+	for template, want := range cases {
+		assert.Equal(t, want, ctx.ExpandVars(template), template)
+	}
+
+	// SetVar 写入的变量优先于 Set 写入的同名数据
+	ctx.SetVar("user_id", "override")
+	assert.Equal(t, "override", ctx.ExpandVars("${ctx.user_id}"))
+}
+
+// TestContextVarTypedGetters 验证请求变量的类型转换
+func TestContextVarTypedGetters(t *testing.T) {
+	ctx := newVariableContext()
+	ctx.SetVar("count", "42")
+	ctx.SetVar("enabled", "true")
+	ctx.SetVar("ratio", 1.5)
+
+	count, ok := ctx.GetVarInt("count")
+	assert.True(t, ok)
+	assert.Equal(t, 42, count)
+
+	enabled, ok := ctx.GetVarBool("enabled")
+	assert.True(t, ok)
+	assert.True(t, enabled)
+
+	_, ok = ctx.GetVarInt("ratio")
+	assert.False(t, ok)
+	ratio, _ := ctx.GetVarString("ratio")
+	assert.Equal(t, "1.5", ratio)
+
+	ctx.DeleteVar("count")
+	assert.Len(t, ctx.Vars(), 2)
+
+	ctx.Reset()
+	assert.Nil(t, ctx.Vars())
+}
+
+// TestVariableFilterChaining 验证变量过滤器设置的变量可被后续头部和查询参数过滤器读取
+func TestVariableFilterChaining(t *testing.T) {
+	factory := filter.NewFilterFactory()
+	_, err := factory.CreateFilter(filter.FilterConfig{ID: "vars", Type: "variable", Config: map[string]interface{}{}})
+	assert.Error(t, err)
+	_, err = factory.CreateFilter(filter.FilterConfig{ID: "vars", Type: "variable", Config: map[string]interface{}{
+		"variables": map[string]interface{}{"bad name": "x"},
+	}})
+	assert.Error(t, err)
+
+	variableFilter, err := factory.CreateFilter(filter.FilterConfig{
+		ID:     "vars",
+		Type:   "variable",
+		Action: "post-routing",
+		Config: map[string]interface{}{
+			"variableConfig": map[string]interface{}{
+				"variables": map[string]interface{}{
+					"caller": "${ctx.user_id:-anonymous}@${header.X-Tenant-Id}",
+					"tenant": "${header.X-Tenant-Id}",
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	headerFilter, err := factory.CreateFilter(filter.FilterConfig{
+		ID:     "caller-header",
+		Type:   "header",
+		Action: "post-routing",
+		Config: map[string]interface{}{
+			"modifierType":    "set",
+			"headerName":      "X-Caller",
+			"headerValue":     "${ctx.caller}",
+			"isRequestHeader": true,
+		},
+	})
+	require.NoError(t, err)
+	queryFilter := filter.NewQueryParamFilter("tenant-param", filter.PostRouting, 100).ConfigureAdd("tenant", "${ctx.tenant}")
+
+	ctx := newVariableContext()
+	ctx.Set("user_id", "u1")
+	for _, f := range []filter.Filter{variableFilter, headerFilter, queryFilter} {
+		require.NoError(t, f.Apply(ctx))
+	}
+
+	caller, _ := ctx.GetVarString("caller")
+	assert.Equal(t, "u1@t1", caller)
+	assert.Equal(t, "u1@t1", ctx.Request.Header.Get("X-Caller"))
+	assert.Equal(t, "t1", ctx.Request.URL.Query().Get("tenant"))
+}
@@ -0,0 +1,111 @@
+package limiter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/limiter"
+)
+
+// TestParseBandwidthConfig 测试从自定义配置解析带宽限制
+func TestParseBandwidthConfig(t *testing.T) {
+	config := limiter.ParseBandwidthConfig(map[string]interface{}{
+		"uploadBytesPerSecond":   float64(1024),
+		"downloadBytesPerSecond": "2048",
+		"bandwidthBurstBytes":    4096,
+	})
+	require.NotNil(t, config)
+	assert.Equal(t, int64(1024), config.UploadRate)
+	assert.Equal(t, int64(2048), config.DownloadRate)
+	assert.Equal(t, int64(4096), config.Burst)
+
+	assert.Nil(t, limiter.ParseBandwidthConfig(nil), "未配置速率时不应启用带宽限制")
+	assert.Nil(t, limiter.ParseBandwidthConfig(map[string]interface{}{"bandwidthBurstBytes": 4096}), "只配置突发量时不应启用带宽限制")
+	assert.Nil(t, limiter.NewBandwidthLimiter(nil, "ip"))
+}
+
+// TestBandwidthLimiterDownload 测试下载限速：超过突发量的部分按速率写出
+func TestBandwidthLimiterDownload(t *testing.T) {
+	bandwidth := limiter.NewBandwidthLimiter(&limiter.BandwidthConfig{DownloadRate: 10000, Burst: 1000}, "ip")
+	require.NotNil(t, bandwidth)
+
+	recorder := httptest.NewRecorder()
+	ctx := core.NewContext(recorder, newBandwidthRequest("192.168.1.1", nil))
+	bandwidth.Apply(ctx)
+
+	start := time.Now()
+	n, err := ctx.Writer.Write(make([]byte, 3000))
+	elapsed := time.Since(start)
+	require.NoError(t, err)
+	assert.Equal(t, 3000, n)
+	assert.Equal(t, 3000, recorder.Body.Len())
+	// 突发1000字节立即写出，剩余2000字节按10000字节/秒约需200ms
+	assert.GreaterOrEqual(t, elapsed, 150*time.Millisecond)
+	assert.Less(t, elapsed, 2*time.Second)
+
+	// 其他客户端使用独立的字节桶，不受影响
+	otherCtx := core.NewContext(httptest.NewRecorder(), newBandwidthRequest("192.168.1.2", nil))
+	bandwidth.Apply(otherCtx)
+	start = time.Now()
+	_, err = otherCtx.Writer.Write(make([]byte, 1000))
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
+
+// TestBandwidthLimiterUpload 测试上传限速和请求取消
+func TestBandwidthLimiterUpload(t *testing.T) {
+	bandwidth := limiter.NewBandwidthLimiter(&limiter.BandwidthConfig{UploadRate: 1000, Burst: 1000}, "ip")
+	require.NotNil(t, bandwidth)
+
+	requestCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := newBandwidthRequest("192.168.1.3", bytes.NewReader(make([]byte, 10000))).WithContext(requestCtx)
+	ctx := core.NewContext(httptest.NewRecorder(), req)
+	bandwidth.Apply(ctx)
+
+	time.AfterFunc(100*time.Millisecond, cancel)
+	start := time.Now()
+	data, err := io.ReadAll(ctx.Request.Body)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, len(data), 10000, "取消前不应读完全部请求体")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+// TestBandwidthLimiterSkipsWebSocket 测试WebSocket升级请求不做包装
+func TestBandwidthLimiterSkipsWebSocket(t *testing.T) {
+	bandwidth := limiter.NewBandwidthLimiter(&limiter.BandwidthConfig{DownloadRate: 1000}, "ip")
+	recorder := httptest.NewRecorder()
+	req := newBandwidthRequest("192.168.1.4", nil)
+	req.Header.Set("Upgrade", "websocket")
+	ctx := core.NewContext(recorder, req)
+	bandwidth.Apply(ctx)
+	assert.Same(t, recorder, ctx.Writer)
+}
+
+// TestExtractAPIKeyKey 测试按API Key提取限流键
+func TestExtractAPIKeyKey(t *testing.T) {
+	ctx := core.NewContext(httptest.NewRecorder(), newBandwidthRequest("192.168.1.5", nil))
+	assert.Equal(t, "ip:192.168.1.5", limiter.ExtractAPIKeyKey(ctx), "未认证时应退回IP键")
+
+	ctx.Set("api_key", "secret-key")
+	key := limiter.ExtractAPIKeyKey(ctx)
+	assert.True(t, strings.HasPrefix(key, "apikey:"))
+	assert.NotContains(t, key, "secret-key", "限流键不应包含API Key明文")
+	assert.Equal(t, key, limiter.GetKeyExtractor("api_key")(ctx))
+}
+
+func newBandwidthRequest(ip string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/download", body)
+	req.RemoteAddr = ip + ":12345"
+	return req
+}
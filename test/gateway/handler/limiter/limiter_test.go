@@ -107,6 +107,7 @@ func TestLimiterAlgorithms(t *testing.T) {
 	algorithms := []limiter.RateLimitAlgorithm{
 		limiter.AlgorithmTokenBucket,
 		limiter.AlgorithmLeakyBucket,
+		limiter.AlgorithmSpikeArrest,
 		limiter.AlgorithmSlidingWindow,
 		limiter.AlgorithmFixedWindow,
 		limiter.AlgorithmNone,
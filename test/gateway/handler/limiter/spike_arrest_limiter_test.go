@@ -0,0 +1,104 @@
+package limiter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/limiter"
+)
+
+// TestSpikeArrestLimiterQueuesThenRejects 测试削峰：突发请求先排队按速率放行，超出排队上限时拒绝
+func TestSpikeArrestLimiterQueuesThenRejects(t *testing.T) {
+	handler, err := limiter.NewLimiterFactory().CreateLimiter(&limiter.RateLimitConfig{
+		Enabled:      true,
+		Algorithm:    limiter.AlgorithmSpikeArrest,
+		Rate:         10,
+		KeyStrategy:  "ip",
+		CustomConfig: map[string]interface{}{"maxQueueDelayMs": float64(250)},
+	})
+	require.NoError(t, err)
+	require.NoError(t, handler.Validate())
+	assert.Equal(t, limiter.AlgorithmSpikeArrest, handler.GetAlgorithm())
+
+	// 突发容量默认1，第一个请求立即放行
+	ctx := newSpikeArrestContext(context.Background(), "10.0.0.1")
+	assert.True(t, handler.Handle(ctx))
+	_, queued := ctx.Get(constants.ContextKeyRateLimitQueueTime)
+	assert.False(t, queued)
+
+	// 第二个请求按每秒10个的速率排队约100ms后放行
+	ctx = newSpikeArrestContext(context.Background(), "10.0.0.1")
+	start := time.Now()
+	assert.True(t, handler.Handle(ctx))
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+	queueTime, queued := ctx.Get(constants.ContextKeyRateLimitQueueTime)
+	require.True(t, queued)
+	assert.Greater(t, queueTime.(time.Duration), 50*time.Millisecond)
+
+	// 连续突发：预计排队时间超过250ms的请求被立即拒绝
+	results := make(chan bool, 4)
+	for i := 0; i < 4; i++ {
+		go func() {
+			results <- handler.Handle(newSpikeArrestContext(context.Background(), "10.0.0.1"))
+		}()
+	}
+	passed, rejected := 0, 0
+	for i := 0; i < 4; i++ {
+		if <-results {
+			passed++
+		} else {
+			rejected++
+		}
+	}
+	assert.Equal(t, 2, passed, "排队上限内只能容纳2个请求")
+	assert.Equal(t, 2, rejected)
+
+	// 其他客户端使用独立的令牌桶
+	assert.True(t, handler.Handle(newSpikeArrestContext(context.Background(), "10.0.0.2")))
+}
+
+// TestSpikeArrestLimiterCanceledWhileQueued 测试排队期间客户端断开时归还令牌
+func TestSpikeArrestLimiterCanceledWhileQueued(t *testing.T) {
+	handler, err := limiter.NewSpikeArrestLimiter(&limiter.RateLimitConfig{
+		Enabled:      true,
+		Rate:         5,
+		KeyStrategy:  "ip",
+		CustomConfig: map[string]interface{}{"maxQueueDelayMs": 1000},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, handler.Handle(newSpikeArrestContext(context.Background(), "10.0.0.3")))
+
+	cancelCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.False(t, handler.Handle(newSpikeArrestContext(cancelCtx, "10.0.0.3")))
+
+	// 被取消的请求已归还令牌，下一个请求只需等待一个令牌周期（200ms）
+	start := time.Now()
+	assert.True(t, handler.Handle(newSpikeArrestContext(context.Background(), "10.0.0.3")))
+	assert.Less(t, time.Since(start), 300*time.Millisecond)
+}
+
+// TestSpikeArrestLimiterValidate 测试排队上限校验
+func TestSpikeArrestLimiterValidate(t *testing.T) {
+	handler, err := limiter.NewSpikeArrestLimiter(&limiter.RateLimitConfig{
+		Rate:         10,
+		CustomConfig: map[string]interface{}{"maxQueueDelayMs": 60000},
+	})
+	require.NoError(t, err)
+	assert.Error(t, handler.Validate())
+}
+
+func newSpikeArrestContext(parent context.Context, ip string) *core.Context {
+	req := httptest.NewRequest(http.MethodGet, "/legacy", nil).WithContext(parent)
+	req.RemoteAddr = ip + ":12345"
+	return core.NewContext(httptest.NewRecorder(), req)
+}
@@ -0,0 +1,106 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/proxy"
+	"gateway/internal/gateway/handler/router"
+)
+
+// conditionalBackend 按 If-None-Match 返回304的后端，首个请求阻塞到 release 关闭，便于等待的请求加入合并
+func conditionalBackend(calls *int32, arrived chan<- struct{}, release <-chan struct{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) == 1 {
+			close(arrived)
+			<-release
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", "max-age=60")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("X-Backend", "full")
+		_, _ = w.Write([]byte("payload"))
+	}))
+}
+
+// sendCoalesced 按合并策略发送GET请求
+func sendCoalesced(httpProxy *proxy.HTTPProxy, policy *router.CoalescePolicy, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "http://gateway/items", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	recorder := httptest.NewRecorder()
+	ctx := core.NewContext(recorder, req)
+	ctx.SetServiceIDs([]string{"payment-service"})
+	ctx.Set(constants.ContextKeyRouteCoalescePolicy, policy)
+	httpProxy.Handle(ctx)
+	return recorder
+}
+
+// coalescePair 首个请求转发到后端后再发送等待的请求，返回两者的响应
+func coalescePair(t *testing.T, leaderETag, followerETag string) (*httptest.ResponseRecorder, *httptest.ResponseRecorder, int32) {
+	var calls int32
+	arrived := make(chan struct{})
+	release := make(chan struct{})
+	backend := conditionalBackend(&calls, arrived, release)
+	defer backend.Close()
+
+	httpProxy := newIdempotencyProxy(t, backend.URL)
+	policy, err := router.NewCoalescePolicy("items", &router.CoalesceConfig{Enabled: true})
+	require.NoError(t, err)
+
+	var leader, follower *httptest.ResponseRecorder
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		leader = sendCoalesced(httpProxy, policy, leaderETag)
+	}()
+	<-arrived
+	go func() {
+		defer wg.Done()
+		follower = sendCoalesced(httpProxy, policy, followerETag)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	return leader, follower, atomic.LoadInt32(&calls)
+}
+
+// TestCoalescedNotModified 验证验证条件匹配的等待请求由共享的200响应得到304
+func TestCoalescedNotModified(t *testing.T) {
+	leader, follower, calls := coalescePair(t, "", `W/"v1"`)
+
+	assert.Equal(t, int32(1), calls, "等待的请求应复用首个请求的响应")
+	assert.Equal(t, http.StatusOK, leader.Code)
+	assert.Equal(t, "payload", leader.Body.String())
+	assert.Equal(t, `"v1"`, leader.Header().Get("ETag"), "ETag应透传给客户端")
+
+	assert.Equal(t, http.StatusNotModified, follower.Code)
+	assert.Empty(t, follower.Body.String(), "304响应不应包含响应体")
+	assert.Equal(t, `"v1"`, follower.Header().Get("ETag"))
+	assert.Equal(t, "max-age=60", follower.Header().Get("Cache-Control"))
+	assert.Empty(t, follower.Header().Get("X-Backend"), "304响应只保留验证器和缓存相关的响应头")
+}
+
+// TestCoalescedNotModifiedMismatch 验证首个请求得到304时，验证条件不匹配的等待请求各自转发
+func TestCoalescedNotModifiedMismatch(t *testing.T) {
+	leader, follower, calls := coalescePair(t, `"v1"`, "")
+
+	assert.Equal(t, http.StatusNotModified, leader.Code, "If-None-Match应透传给后端")
+	assert.Equal(t, int32(2), calls, "无验证条件的请求不能复用304响应")
+	assert.Equal(t, http.StatusOK, follower.Code)
+	assert.Equal(t, "payload", follower.Body.String())
+}
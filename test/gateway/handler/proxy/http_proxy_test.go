@@ -33,6 +33,7 @@ func (m *MockServiceManager) SelectNode(serviceID string, ctx *core.Context) (*s
 }
 
 // 实现ServiceManager接口的其他方法（测试用简单实现）
+func (m *MockServiceManager) ReleaseNode(serviceID, nodeID string)           {}
 func (m *MockServiceManager) AddService(config *service.ServiceConfig) error { return nil }
 func (m *MockServiceManager) RemoveService(serviceID string) error           { return nil }
 func (m *MockServiceManager) GetService(serviceID string) (*service.ServiceConfig, bool) {
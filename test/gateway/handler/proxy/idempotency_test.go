@@ -0,0 +1,212 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/proxy"
+	"gateway/internal/gateway/handler/router"
+	"gateway/internal/gateway/handler/service"
+)
+
+// newIdempotencyProxy 创建转发到指定后端的HTTP代理
+func newIdempotencyProxy(t *testing.T, backendURL string) *proxy.HTTPProxy {
+	manager := service.NewServiceManager()
+	require.NoError(t, manager.AddService(&service.ServiceConfig{
+		ID:       "payment-service",
+		Name:     "payment-service",
+		Strategy: service.RoundRobin,
+		Nodes: []*service.NodeConfig{{
+			ID: "payment-node", URL: backendURL, Weight: 1, Health: true, Enabled: true,
+		}},
+		LoadBalancer: &service.LoadBalancerConfig{Strategy: service.RoundRobin},
+	}))
+
+	httpProxy, err := proxy.NewHTTPProxy(proxy.ProxyConfig{
+		Type:    proxy.ProxyTypeHTTP,
+		Enabled: true,
+		Name:    "idempotency-proxy",
+		Config:  map[string]interface{}{"retryCount": 0},
+	}, manager)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = httpProxy.Close() })
+	return httpProxy
+}
+
+// sendIdempotent 携带幂等键发送POST请求
+func sendIdempotent(httpProxy *proxy.HTTPProxy, policy *router.IdempotencyPolicy, key string) (*httptest.ResponseRecorder, *core.Context) {
+	return sendIdempotentRequest(httpProxy, policy, key, "", "")
+}
+
+// sendIdempotentRequest 携带幂等键、请求体和API Key认证身份发送POST请求
+func sendIdempotentRequest(httpProxy *proxy.HTTPProxy, policy *router.IdempotencyPolicy, key, body, apiKey string) (*httptest.ResponseRecorder, *core.Context) {
+	req := httptest.NewRequest(http.MethodPost, "http://gateway/payments", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	recorder := httptest.NewRecorder()
+	ctx := core.NewContext(recorder, req)
+	ctx.SetServiceIDs([]string{"payment-service"})
+	ctx.Set(constants.ContextKeyRouteIdempotency, policy)
+	if apiKey != "" {
+		ctx.Set("api_key", apiKey)
+	}
+	httpProxy.Handle(ctx)
+	return recorder, ctx
+}
+
+// TestIdempotentReplay 验证相同幂等键的重复请求返回首个请求的缓存响应
+func TestIdempotentReplay(t *testing.T) {
+	var calls int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		w.Header().Set("X-Payment-Id", fmt.Sprintf("pay-%d", n))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, "charge %d", n)
+	}))
+	defer backend.Close()
+
+	httpProxy := newIdempotencyProxy(t, backend.URL)
+	policy, err := router.NewIdempotencyPolicy("payments", &router.IdempotencyConfig{Enabled: true})
+	require.NoError(t, err)
+
+	first, _ := sendIdempotent(httpProxy, policy, "order-1")
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, "charge 1", first.Body.String())
+	assert.Empty(t, first.Header().Get(proxy.IdempotentReplayedHeader))
+
+	retry, ctx := sendIdempotent(httpProxy, policy, "order-1")
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "charge 1", retry.Body.String(), "重复请求应返回缓存的响应")
+	assert.Equal(t, "pay-1", retry.Header().Get("X-Payment-Id"))
+	assert.Equal(t, "true", retry.Header().Get(proxy.IdempotentReplayedHeader))
+	replayed, _ := ctx.Get(constants.ContextKeyIdempotentReplayed)
+	assert.Equal(t, true, replayed)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls), "重复请求不应到达后端")
+
+	other, _ := sendIdempotent(httpProxy, policy, "order-2")
+	assert.Equal(t, "charge 2", other.Body.String(), "不同幂等键应该各自转发")
+	none, _ := sendIdempotent(httpProxy, policy, "")
+	assert.Equal(t, "charge 3", none.Body.String(), "未携带幂等键的请求应该正常转发")
+}
+
+// TestIdempotentConcurrentDuplicates 验证并发的重复请求只向后端转发一次
+func TestIdempotentConcurrentDuplicates(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		_, _ = w.Write([]byte("charged"))
+	}))
+	defer backend.Close()
+
+	httpProxy := newIdempotencyProxy(t, backend.URL)
+	policy, err := router.NewIdempotencyPolicy("payments", &router.IdempotencyConfig{Enabled: true})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	bodies := make([]string, 5)
+	for i := range bodies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			recorder, _ := sendIdempotent(httpProxy, policy, "order-1")
+			bodies[i] = recorder.Body.String()
+		}(i)
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls), "并发的重复请求只应转发一次")
+	for _, body := range bodies {
+		assert.Equal(t, "charged", body)
+	}
+}
+
+// TestIdempotentSkipsServerErrors 验证5xx响应不缓存，客户端重试会重新转发
+func TestIdempotentSkipsServerErrors(t *testing.T) {
+	var calls int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("charged"))
+	}))
+	defer backend.Close()
+
+	httpProxy := newIdempotencyProxy(t, backend.URL)
+	policy, err := router.NewIdempotencyPolicy("payments", &router.IdempotencyConfig{Enabled: true})
+	require.NoError(t, err)
+
+	failed, _ := sendIdempotent(httpProxy, policy, "order-1")
+	assert.Equal(t, http.StatusServiceUnavailable, failed.Code)
+
+	retry, _ := sendIdempotent(httpProxy, policy, "order-1")
+	assert.Equal(t, http.StatusOK, retry.Code)
+	assert.Equal(t, "charged", retry.Body.String())
+	assert.Empty(t, retry.Header().Get(proxy.IdempotentReplayedHeader))
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+}
+
+// TestIdempotentTTL 验证缓存过期后相同幂等键重新转发
+func TestIdempotentTTL(t *testing.T) {
+	var calls int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "charge %d", atomic.AddInt32(&calls, 1))
+	}))
+	defer backend.Close()
+
+	httpProxy := newIdempotencyProxy(t, backend.URL)
+	policy, err := router.NewIdempotencyPolicy("payments", &router.IdempotencyConfig{
+		Enabled: true,
+		TTL:     50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	first, _ := sendIdempotent(httpProxy, policy, "order-1")
+	assert.Equal(t, "charge 1", first.Body.String())
+	time.Sleep(100 * time.Millisecond)
+	expired, _ := sendIdempotent(httpProxy, policy, "order-1")
+	assert.Equal(t, "charge 2", expired.Body.String(), "缓存过期后应重新转发")
+}
+
+// TestIdempotentKeyReuse 验证复用幂等键发送不同请求体时返回422，不同API Key使用相同幂等键时各自转发
+func TestIdempotentKeyReuse(t *testing.T) {
+	var calls int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "charge %d", atomic.AddInt32(&calls, 1))
+	}))
+	defer backend.Close()
+
+	httpProxy := newIdempotencyProxy(t, backend.URL)
+	policy, err := router.NewIdempotencyPolicy("payments", &router.IdempotencyConfig{Enabled: true})
+	require.NoError(t, err)
+
+	first, _ := sendIdempotentRequest(httpProxy, policy, "order-1", `{"amount":1}`, "key-a")
+	assert.Equal(t, "charge 1", first.Body.String())
+
+	reused, _ := sendIdempotentRequest(httpProxy, policy, "order-1", `{"amount":2}`, "key-a")
+	assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls), "复用幂等键的不同请求不应到达后端")
+
+	retry, _ := sendIdempotentRequest(httpProxy, policy, "order-1", `{"amount":1}`, "key-a")
+	assert.Equal(t, "charge 1", retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get(proxy.IdempotentReplayedHeader))
+
+	other, _ := sendIdempotentRequest(httpProxy, policy, "order-1", `{"amount":1}`, "key-b")
+	assert.Equal(t, "charge 2", other.Body.String(), "不同API Key的相同幂等键不应共享响应")
+}
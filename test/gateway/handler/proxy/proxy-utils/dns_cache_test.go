@@ -0,0 +1,169 @@
+package proxyutils_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	proxyutils "gateway/internal/gateway/handler/proxy/proxy-utils"
+)
+
+// fakeResolver 可控的DNS解析器
+type fakeResolver struct {
+	mu    sync.Mutex
+	addrs []net.IPAddr
+	err   error
+	calls int
+}
+
+func (r *fakeResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return append([]net.IPAddr(nil), r.addrs...), nil
+}
+
+func (r *fakeResolver) set(err error, ips ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
+	r.addrs = nil
+	for _, ip := range ips {
+		r.addrs = append(r.addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+}
+
+func (r *fakeResolver) callCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls
+}
+
+func TestDNSCacheLookup(t *testing.T) {
+	if proxyutils.NewDNSCache(nil, 0) != nil {
+		t.Fatal("缓存时间为0时不应启用DNS缓存")
+	}
+
+	resolver := &fakeResolver{}
+	resolver.set(nil, "10.0.0.1", "10.0.0.2")
+	cache := proxyutils.NewDNSCache(resolver, 50*time.Millisecond)
+	ctx := context.Background()
+
+	ips, err := cache.Lookup(ctx, "backend.local")
+	if err != nil || len(ips) != 2 {
+		t.Fatalf("首次解析失败: %v, %v", ips, err)
+	}
+	if _, err = cache.Lookup(ctx, "backend.local"); err != nil || resolver.callCount() != 1 {
+		t.Fatalf("缓存有效期内不应重新解析, 解析次数: %d", resolver.callCount())
+	}
+
+	// 过期后重新解析
+	time.Sleep(60 * time.Millisecond)
+	resolver.set(nil, "10.0.0.3")
+	ips, err = cache.Lookup(ctx, "backend.local")
+	if err != nil || len(ips) != 1 || !ips[0].Equal(net.ParseIP("10.0.0.3")) {
+		t.Fatalf("过期后应使用新解析结果: %v, %v", ips, err)
+	}
+
+	// 重新解析失败时使用旧结果
+	time.Sleep(60 * time.Millisecond)
+	resolver.set(errors.New("dns unavailable"))
+	ips, err = cache.Lookup(ctx, "backend.local")
+	if err != nil || len(ips) != 1 || !ips[0].Equal(net.ParseIP("10.0.0.3")) {
+		t.Fatalf("解析失败时应使用缓存结果: %v, %v", ips, err)
+	}
+
+	// 从未解析成功的主机返回错误
+	if _, err = cache.Lookup(ctx, "unknown.local"); err == nil {
+		t.Error("首次解析失败时应返回错误")
+	}
+}
+
+func TestDNSCacheRefresh(t *testing.T) {
+	resolver := &fakeResolver{}
+	resolver.set(nil, "10.0.0.1")
+	cache := proxyutils.NewDNSCache(resolver, time.Hour)
+	ctx := context.Background()
+
+	if _, err := cache.Lookup(ctx, "backend.local"); err != nil {
+		t.Fatalf("首次解析失败: %v", err)
+	}
+	resolver.set(nil, "10.0.0.9")
+	cache.Refresh(ctx)
+
+	ips, err := cache.Lookup(ctx, "backend.local")
+	if err != nil || len(ips) != 1 || !ips[0].Equal(net.ParseIP("10.0.0.9")) {
+		t.Fatalf("后台重新解析后应使用新结果: %v, %v", ips, err)
+	}
+	if resolver.callCount() != 2 {
+		t.Errorf("解析次数不正确: %d", resolver.callCount())
+	}
+}
+
+func TestDNSCacheDialRoundRobin(t *testing.T) {
+	// 在两个回环地址上监听同一端口，模拟主机名解析到多条A记录
+	first, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("监听失败: %v", err)
+	}
+	defer first.Close()
+	_, port, _ := net.SplitHostPort(first.Addr().String())
+	second, err := net.Listen("tcp", net.JoinHostPort("127.0.0.2", port))
+	if err != nil {
+		t.Skipf("当前环境不支持 127.0.0.2: %v", err)
+	}
+	defer second.Close()
+
+	accepted := make(chan string, 8)
+	for _, listener := range []net.Listener{first, second} {
+		go func(listener net.Listener) {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				accepted <- listener.Addr().String()
+				conn.Close()
+			}
+		}(listener)
+	}
+
+	resolver := &fakeResolver{}
+	resolver.set(nil, "127.0.0.1", "127.0.0.2")
+	cache := proxyutils.NewDNSCache(resolver, time.Minute)
+	dial := cache.DialContext(&net.Dialer{Timeout: time.Second})
+
+	counts := map[string]int{}
+	for i := 0; i < 4; i++ {
+		conn, err := dial(context.Background(), "tcp", net.JoinHostPort("backend.local", port))
+		if err != nil {
+			t.Fatalf("拨号失败: %v", err)
+		}
+		conn.Close()
+		counts[<-accepted]++
+	}
+	if counts[first.Addr().String()] != 2 || counts[second.Addr().String()] != 2 {
+		t.Errorf("连接未均匀分布到所有地址: %v", counts)
+	}
+
+	// 某个地址不可用时切换到其他地址
+	second.Close()
+	for i := 0; i < 2; i++ {
+		conn, err := dial(context.Background(), "tcp", net.JoinHostPort("backend.local", port))
+		if err != nil {
+			t.Fatalf("地址不可用时应切换到其他地址: %v", err)
+		}
+		conn.Close()
+	}
+
+	// 网络类型与地址版本不匹配
+	if _, err = dial(context.Background(), "tcp6", net.JoinHostPort("backend.local", port)); err == nil {
+		t.Error("没有IPv6地址时tcp6拨号应失败")
+	}
+}
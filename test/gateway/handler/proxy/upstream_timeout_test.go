@@ -0,0 +1,130 @@
+package proxy
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/proxy"
+	"gateway/internal/gateway/handler/service"
+)
+
+// proxyWithTimeouts 通过指定超时配置的HTTP代理转发请求，routeTimeouts 模拟路由写入上下文的超时
+func proxyWithTimeouts(t *testing.T, proxyConfig map[string]interface{}, config *service.ServiceConfig, routeTimeouts map[string]time.Duration) (*httptest.ResponseRecorder, bool, time.Duration) {
+	manager := service.NewServiceManager()
+	require.NoError(t, manager.AddService(config))
+
+	proxyConfig["retryCount"] = 0
+	httpProxy, err := proxy.NewHTTPProxy(proxy.ProxyConfig{
+		Type:    proxy.ProxyTypeHTTP,
+		Enabled: true,
+		Name:    "timeout-proxy",
+		Config:  proxyConfig,
+	}, manager)
+	require.NoError(t, err)
+	defer httpProxy.Close()
+
+	recorder := httptest.NewRecorder()
+	ctx := core.NewContext(recorder, httptest.NewRequest(http.MethodGet, "http://gateway/api", nil))
+	ctx.SetServiceIDs([]string{config.ID})
+	for key, value := range routeTimeouts {
+		ctx.Set(key, value)
+	}
+	start := time.Now()
+	ok := httpProxy.Handle(ctx)
+	return recorder, ok, time.Since(start)
+}
+
+// newTimeoutService 创建只有一个节点的服务
+func newTimeoutService(url string, timeouts *service.UpstreamTimeoutConfig) *service.ServiceConfig {
+	return &service.ServiceConfig{
+		ID:       "timeout-service",
+		Name:     "timeout-service",
+		Strategy: service.RoundRobin,
+		Nodes: []*service.NodeConfig{{
+			ID: "timeout-node", URL: url, Weight: 1, Health: true, Enabled: true,
+		}},
+		LoadBalancer: &service.LoadBalancerConfig{Strategy: service.RoundRobin},
+		Timeouts:     timeouts,
+	}
+}
+
+// TestUpstreamResponseHeaderTimeout 验证等待响应头超时按 路由 > 服务 > 代理 逐级继承
+func TestUpstreamResponseHeaderTimeout(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		_, _ = w.Write([]byte("slow"))
+	}))
+	defer backend.Close()
+
+	t.Run("代理读取超时", func(t *testing.T) {
+		recorder, ok, _ := proxyWithTimeouts(t, map[string]interface{}{"timeout": 30, "readTimeout": "100ms"},
+			newTimeoutService(backend.URL, nil), nil)
+		assert.False(t, ok, "超过代理读取超时应该失败")
+		assert.Equal(t, http.StatusGatewayTimeout, recorder.Code)
+	})
+
+	t.Run("服务覆盖代理", func(t *testing.T) {
+		recorder, ok, _ := proxyWithTimeouts(t, map[string]interface{}{"timeout": 30, "readTimeout": "100ms"},
+			newTimeoutService(backend.URL, &service.UpstreamTimeoutConfig{ResponseHeaderTimeout: 2 * time.Second}), nil)
+		require.True(t, ok, "服务响应头超时大于后端耗时时应该成功")
+		assert.Equal(t, "slow", recorder.Body.String())
+	})
+
+	t.Run("路由覆盖服务", func(t *testing.T) {
+		recorder, ok, _ := proxyWithTimeouts(t, map[string]interface{}{"timeout": 30},
+			newTimeoutService(backend.URL, &service.UpstreamTimeoutConfig{ResponseHeaderTimeout: 2 * time.Second}),
+			map[string]time.Duration{constants.ContextKeyRouteHeaderTimeout: 100 * time.Millisecond})
+		assert.False(t, ok, "路由响应头超时优先于服务配置")
+		assert.Equal(t, http.StatusGatewayTimeout, recorder.Code)
+	})
+
+	t.Run("服务总超时", func(t *testing.T) {
+		recorder, ok, _ := proxyWithTimeouts(t, map[string]interface{}{"timeout": 30},
+			newTimeoutService(backend.URL, &service.UpstreamTimeoutConfig{Timeout: 100 * time.Millisecond}), nil)
+		assert.False(t, ok, "超过服务总超时应该失败")
+		assert.Equal(t, http.StatusGatewayTimeout, recorder.Code)
+	})
+}
+
+// TestUpstreamTLSHandshakeTimeout 验证服务级TLS握手超时，后端接受连接但不响应握手
+func TestUpstreamTLSHandshakeTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		var conns []net.Conn
+		defer func() {
+			for _, conn := range conns {
+				_ = conn.Close()
+			}
+		}()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+
+	recorder, ok, elapsed := proxyWithTimeouts(t, map[string]interface{}{"timeout": 30},
+		newTimeoutService("https://"+listener.Addr().String(), &service.UpstreamTimeoutConfig{TLSHandshakeTimeout: 200 * time.Millisecond}), nil)
+	assert.False(t, ok, "TLS握手超时应该失败")
+	assert.Equal(t, http.StatusGatewayTimeout, recorder.Code)
+	assert.Less(t, elapsed, 5*time.Second, "应按服务TLS握手超时失败，而不是等待代理默认超时")
+}
+
+// TestUpstreamTimeoutValidation 验证服务超时配置在加载服务时校验
+func TestUpstreamTimeoutValidation(t *testing.T) {
+	manager := service.NewServiceManager()
+	err := manager.AddService(newTimeoutService("http://127.0.0.1:1", &service.UpstreamTimeoutConfig{ConnectTimeout: -time.Second}))
+	assert.Error(t, err, "负数超时应该在加载时被拒绝")
+}
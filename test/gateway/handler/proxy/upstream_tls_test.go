@@ -0,0 +1,202 @@
+package proxy
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/proxy"
+	"gateway/internal/gateway/handler/service"
+)
+
+// testPKI 测试用CA及其签发的证书
+type testPKI struct {
+	caCert    *x509.Certificate
+	caKey     *ecdsa.PrivateKey
+	caPEM     string
+	caPool    *x509.CertPool
+	serialNum int64
+}
+
+// newTestPKI 创建自签名CA
+func newTestPKI(t *testing.T) *testPKI {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testPKI{
+		caCert:    cert,
+		caKey:     key,
+		caPEM:     string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		caPool:    pool,
+		serialNum: 1,
+	}
+}
+
+// issue 签发服务端或客户端证书，返回PEM格式的证书和私钥
+func (p *testPKI) issue(t *testing.T, commonName string, usage x509.ExtKeyUsage, dnsNames ...string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p.serialNum++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(p.serialNum),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, p.caCert, &key.PublicKey, p.caKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+// newMTLSBackend 创建要求客户端证书的https后端，证书只对 backend.internal 有效
+func newMTLSBackend(t *testing.T, pki *testPKI) *httptest.Server {
+	serverCert, serverKey := pki.issue(t, "backend", x509.ExtKeyUsageServerAuth, "backend.internal")
+	certificate, err := tls.X509KeyPair([]byte(serverCert), []byte(serverKey))
+	require.NoError(t, err)
+
+	backend := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("hello " + r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	backend.TLS = &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pki.caPool,
+		MinVersion:   tls.VersionTLS12,
+	}
+	backend.StartTLS()
+	return backend
+}
+
+// proxyThroughService 通过HTTP代理把请求转发到指定服务
+func proxyThroughService(t *testing.T, config *service.ServiceConfig) (*httptest.ResponseRecorder, bool) {
+	manager := service.NewServiceManager()
+	require.NoError(t, manager.AddService(config))
+
+	httpProxy, err := proxy.NewHTTPProxy(proxy.ProxyConfig{
+		Type:    proxy.ProxyTypeHTTP,
+		Enabled: true,
+		Name:    "tls-proxy",
+		Config:  map[string]interface{}{"retryCount": 0},
+	}, manager)
+	require.NoError(t, err)
+	defer httpProxy.Close()
+
+	recorder := httptest.NewRecorder()
+	ctx := core.NewContext(recorder, httptest.NewRequest(http.MethodGet, "http://gateway/api", nil))
+	ctx.SetServiceIDs([]string{config.ID})
+	return recorder, httpProxy.Handle(ctx)
+}
+
+// TestUpstreamTLSOrigination 验证使用服务级CA证书、客户端证书和SNI与后端建立双向TLS连接
+func TestUpstreamTLSOrigination(t *testing.T) {
+	pki := newTestPKI(t)
+	backend := newMTLSBackend(t, pki)
+	defer backend.Close()
+	clientCert, clientKey := pki.issue(t, "gateway-client", x509.ExtKeyUsageClientAuth)
+
+	newService := func(tlsConfig *service.UpstreamTLSConfig) *service.ServiceConfig {
+		return &service.ServiceConfig{
+			ID:       "tls-service",
+			Name:     "tls-service",
+			Strategy: service.RoundRobin,
+			Nodes: []*service.NodeConfig{{
+				ID: "tls-node", URL: backend.URL, Weight: 1, Health: true, Enabled: true,
+			}},
+			LoadBalancer: &service.LoadBalancerConfig{Strategy: service.RoundRobin},
+			TLS:          tlsConfig,
+		}
+	}
+
+	t.Run("双向TLS", func(t *testing.T) {
+		recorder, ok := proxyThroughService(t, newService(&service.UpstreamTLSConfig{
+			CACert:     pki.caPEM,
+			ClientCert: clientCert,
+			ClientKey:  clientKey,
+			ServerName: "backend.internal",
+			MinVersion: "TLS1.2",
+		}))
+		require.True(t, ok, "代理请求应该成功")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "hello gateway-client", recorder.Body.String())
+	})
+
+	t.Run("缺少客户端证书", func(t *testing.T) {
+		_, ok := proxyThroughService(t, newService(&service.UpstreamTLSConfig{
+			CACert:     pki.caPEM,
+			ServerName: "backend.internal",
+		}))
+		assert.False(t, ok, "后端要求客户端证书时握手应该失败")
+	})
+
+	t.Run("未覆盖SNI", func(t *testing.T) {
+		_, ok := proxyThroughService(t, newService(&service.UpstreamTLSConfig{
+			CACert:     pki.caPEM,
+			ClientCert: clientCert,
+			ClientKey:  clientKey,
+		}))
+		assert.False(t, ok, "后端证书与节点地址不匹配时校验应该失败")
+	})
+}
+
+// TestUpstreamTLSValidation 验证上游TLS配置在加载服务时校验
+func TestUpstreamTLSValidation(t *testing.T) {
+	pki := newTestPKI(t)
+	clientCert, _ := pki.issue(t, "gateway-client", x509.ExtKeyUsageClientAuth)
+
+	tests := []struct {
+		name      string
+		tlsConfig *service.UpstreamTLSConfig
+	}{
+		{"无效CA证书", &service.UpstreamTLSConfig{CACert: "not a pem"}},
+		{"CA证书文件不存在", &service.UpstreamTLSConfig{CACertFile: "/nonexistent/ca.pem"}},
+		{"只配置客户端证书", &service.UpstreamTLSConfig{ClientCert: clientCert}},
+		{"无效TLS版本", &service.UpstreamTLSConfig{MinVersion: "SSL3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := service.NewServiceManager()
+			err := manager.AddService(&service.ServiceConfig{
+				ID:       "invalid-tls-service",
+				Strategy: service.RoundRobin,
+				TLS:      tt.tlsConfig,
+			})
+			assert.Error(t, err, "无效的上游TLS配置应该在加载时被拒绝")
+		})
+	}
+
+	assert.NoError(t, (&service.UpstreamTLSConfig{CACert: pki.caPEM, MinVersion: "TLS1.3"}).Validate())
+}
@@ -0,0 +1,116 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/router"
+)
+
+func TestCoalescePolicyKey(t *testing.T) {
+	policy, err := router.NewCoalescePolicy("route-1", &router.CoalesceConfig{
+		Enabled:     true,
+		KeyTemplate: "{method} {path} {query.id} {header.X-Tenant} {cookie.lang}",
+	})
+	if err != nil {
+		t.Fatalf("编译合并策略失败: %v", err)
+	}
+	if policy.MaxBodyBytes != router.DefaultCoalesceMaxBodyBytes {
+		t.Fatalf("默认响应体上限不正确: %d", policy.MaxBodyBytes)
+	}
+
+	newRequest := func(target, tenant string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("X-Tenant", tenant)
+		req.AddCookie(&http.Cookie{Name: "lang", Value: "zh"})
+		return req
+	}
+
+	base := policy.Key(newRequest("http://gateway/items?id=1&ts=100", "t1"))
+	if base != "route-1\nGET /items 1 t1 zh" {
+		t.Fatalf("合并键不正确: %q", base)
+	}
+	// 模板未引用的参数不影响合并键
+	if key := policy.Key(newRequest("http://gateway/items?ts=200&id=1", "t1")); key != base {
+		t.Fatalf("模板未引用的参数不应影响合并键: %q != %q", key, base)
+	}
+	if key := policy.Key(newRequest("http://gateway/items?id=1", "t2")); key == base {
+		t.Fatal("不同租户的请求不应合并")
+	}
+
+	other, err := router.NewCoalescePolicy("route-2", &router.CoalesceConfig{
+		Enabled:     true,
+		KeyTemplate: "{method} {path} {query.id} {header.X-Tenant} {cookie.lang}",
+	})
+	if err != nil {
+		t.Fatalf("编译合并策略失败: %v", err)
+	}
+	if other.Key(newRequest("http://gateway/items?id=1", "t1")) == base {
+		t.Fatal("不同路由的请求不应合并")
+	}
+}
+
+func TestCoalescePolicyDefaultTemplate(t *testing.T) {
+	policy, err := router.NewCoalescePolicy("route-1", &router.CoalesceConfig{Enabled: true})
+	if err != nil {
+		t.Fatalf("编译默认合并策略失败: %v", err)
+	}
+
+	first := httptest.NewRequest(http.MethodGet, "http://gateway/items?b=2&a=1", nil)
+	second := httptest.NewRequest(http.MethodGet, "http://gateway/items?a=1&b=2", nil)
+	if policy.Key(first) != policy.Key(second) {
+		t.Fatal("查询参数顺序不同的请求应合并")
+	}
+
+	second.Header.Set("Authorization", "Bearer other")
+	if policy.Key(first) == policy.Key(second) {
+		t.Fatal("默认模板不应合并不同用户的请求")
+	}
+}
+
+func TestCoalescePolicyInvalidTemplate(t *testing.T) {
+	for _, template := range []string{"{method", "{unknown}", "{header.}"} {
+		if _, err := router.NewCoalescePolicy("route-1", &router.CoalesceConfig{Enabled: true, KeyTemplate: template}); err == nil {
+			t.Fatalf("无效模板应返回错误: %q", template)
+		}
+	}
+
+	policy, err := router.NewCoalescePolicy("route-1", &router.CoalesceConfig{Enabled: false, KeyTemplate: "{unknown}"})
+	if err != nil || policy != nil {
+		t.Fatalf("未启用时不应编译合并策略: %v", err)
+	}
+}
+
+func TestRouteHandleSetsCoalescePolicy(t *testing.T) {
+	config := router.RouteConfig{
+		ID:             "coalesce-route",
+		Name:           "coalesce-route",
+		ServiceID:      "svc",
+		Path:           "/items",
+		MatchType:      router.MatchTypePrefix,
+		Enabled:        true,
+		CoalesceConfig: &router.CoalesceConfig{Enabled: true},
+	}
+	route, err := router.NewRoute(config)
+	if err != nil {
+		t.Fatalf("创建路由失败: %v", err)
+	}
+
+	ctx := core.NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://gateway/items", nil))
+	route.Handle(ctx)
+	value, exists := ctx.Get(constants.ContextKeyRouteCoalescePolicy)
+	if !exists {
+		t.Fatal("路由未写入请求合并策略")
+	}
+	if policy, ok := value.(*router.CoalescePolicy); !ok || policy.RouteID != "coalesce-route" {
+		t.Fatalf("请求合并策略不正确: %#v", value)
+	}
+
+	config.CoalesceConfig.KeyTemplate = "{unknown}"
+	if _, err := router.NewRoute(config); err == nil {
+		t.Fatal("无效合并键模板应导致创建路由失败")
+	}
+}
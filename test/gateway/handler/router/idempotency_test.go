@@ -0,0 +1,157 @@
+package router
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gateway/internal/gateway/constants"
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/router"
+)
+
+func TestIdempotencyPolicyKey(t *testing.T) {
+	policy, err := router.NewIdempotencyPolicy("route-1", &router.IdempotencyConfig{Enabled: true})
+	if err != nil {
+		t.Fatalf("编译幂等策略失败: %v", err)
+	}
+	if policy.Header != router.DefaultIdempotencyHeader || policy.TTL != router.DefaultIdempotencyTTL ||
+		policy.MaxBodyBytes != router.DefaultIdempotencyMaxBodyBytes {
+		t.Fatalf("默认值不正确: %#v", policy)
+	}
+
+	newRequest := func(method, key, authorization string) *http.Request {
+		req := httptest.NewRequest(method, "http://gateway/payments", nil)
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		return req
+	}
+
+	base, ok := policy.Key(newRequest(http.MethodPost, "key-1", "Bearer a"), "")
+	if !ok {
+		t.Fatal("携带幂等键的POST请求应参与去重")
+	}
+	if key, _ := policy.Key(newRequest(http.MethodPost, "key-1", "Bearer a"), ""); key != base {
+		t.Fatalf("相同幂等键应生成相同的键: %q != %q", key, base)
+	}
+	if key, _ := policy.Key(newRequest(http.MethodPost, "key-1", "Bearer b"), ""); key == base {
+		t.Fatal("不同用户的相同幂等键不应共享响应")
+	}
+	if _, ok := policy.Key(newRequest(http.MethodPost, "", "Bearer a"), ""); ok {
+		t.Fatal("未携带幂等键的请求不应参与去重")
+	}
+	if _, ok := policy.Key(newRequest(http.MethodGet, "key-1", "Bearer a"), ""); ok {
+		t.Fatal("默认方法不包含GET")
+	}
+
+	withCookie := newRequest(http.MethodPost, "key-1", "Bearer a")
+	withCookie.Header.Set("Cookie", "session=b")
+	if key, _ := policy.Key(withCookie, ""); key == base {
+		t.Fatal("Cookie不同的相同幂等键不应共享响应")
+	}
+	if key, _ := policy.Key(newRequest(http.MethodPost, "key-1", "Bearer a"), "apikey:other"); key == base {
+		t.Fatal("认证身份不同的相同幂等键不应共享响应")
+	}
+	if strings.Contains(base, "Bearer") {
+		t.Fatalf("幂等键不应包含认证信息原文: %q", base)
+	}
+
+	other, err := router.NewIdempotencyPolicy("route-2", &router.IdempotencyConfig{Enabled: true})
+	if err != nil {
+		t.Fatalf("编译幂等策略失败: %v", err)
+	}
+	if key, _ := other.Key(newRequest(http.MethodPost, "key-1", "Bearer a"), ""); key == base {
+		t.Fatal("不同路由的相同幂等键不应共享响应")
+	}
+}
+
+// TestIdempotencyPolicyFingerprint 验证请求指纹区分请求方法和请求体，且读取后请求体仍可转发
+func TestIdempotencyPolicyFingerprint(t *testing.T) {
+	policy, err := router.NewIdempotencyPolicy("route-1", &router.IdempotencyConfig{Enabled: true})
+	if err != nil {
+		t.Fatalf("编译幂等策略失败: %v", err)
+	}
+	fingerprint := func(method, body string) string {
+		req := httptest.NewRequest(method, "http://gateway/payments", strings.NewReader(body))
+		value, err := policy.Fingerprint(req)
+		if err != nil {
+			t.Fatalf("计算请求指纹失败: %v", err)
+		}
+		rest, _ := io.ReadAll(req.Body)
+		if string(rest) != body {
+			t.Fatalf("读取指纹后请求体应保持不变: %q", rest)
+		}
+		return value
+	}
+
+	base := fingerprint(http.MethodPost, `{"amount":1}`)
+	if fingerprint(http.MethodPost, `{"amount":1}`) != base {
+		t.Fatal("相同请求的指纹应一致")
+	}
+	if fingerprint(http.MethodPost, `{"amount":2}`) == base {
+		t.Fatal("请求体不同时指纹应不同")
+	}
+	if fingerprint(http.MethodPut, `{"amount":1}`) == base {
+		t.Fatal("请求方法不同时指纹应不同")
+	}
+}
+
+func TestIdempotencyPolicyConfig(t *testing.T) {
+	policy, err := router.NewIdempotencyPolicy("route-1", &router.IdempotencyConfig{
+		Enabled: true,
+		Header:  "x-request-id",
+		TTL:     time.Minute,
+		Methods: []string{"post"},
+	})
+	if err != nil {
+		t.Fatalf("编译幂等策略失败: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "http://gateway/orders", nil)
+	req.Header.Set("X-Request-Id", "abc")
+	if _, ok := policy.Key(req, ""); !ok || policy.TTL != time.Minute {
+		t.Fatalf("自定义请求头和缓存时间未生效: %#v", policy)
+	}
+	req.Method = http.MethodPut
+	if _, ok := policy.Key(req, ""); ok {
+		t.Fatal("未配置的方法不应参与去重")
+	}
+
+	if _, err := router.NewIdempotencyPolicy("route-1", &router.IdempotencyConfig{Enabled: true, TTL: -time.Second}); err == nil {
+		t.Fatal("负数缓存时间应返回错误")
+	}
+	if policy, err := router.NewIdempotencyPolicy("route-1", &router.IdempotencyConfig{Enabled: false}); err != nil || policy != nil {
+		t.Fatalf("未启用时不应编译幂等策略: %v", err)
+	}
+}
+
+func TestRouteHandleSetsIdempotencyPolicy(t *testing.T) {
+	route, err := router.NewRoute(router.RouteConfig{
+		ID:                "idempotency-route",
+		Name:              "idempotency-route",
+		ServiceID:         "svc",
+		Path:              "/payments",
+		MatchType:         router.MatchTypePrefix,
+		Enabled:           true,
+		IdempotencyConfig: &router.IdempotencyConfig{Enabled: true},
+	})
+	if err != nil {
+		t.Fatalf("创建路由失败: %v", err)
+	}
+
+	ctx := core.NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "http://gateway/payments", nil))
+	route.Handle(ctx)
+	value, exists := ctx.Get(constants.ContextKeyRouteIdempotency)
+	if !exists {
+		t.Fatal("路由未写入幂等策略")
+	}
+	if policy, ok := value.(*router.IdempotencyPolicy); !ok || policy.RouteID != "idempotency-route" {
+		t.Fatalf("幂等策略不正确: %#v", value)
+	}
+}
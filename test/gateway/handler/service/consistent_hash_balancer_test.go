@@ -0,0 +1,132 @@
+package service
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/handler/service"
+)
+
+// newHashTestService 创建包含指定数量节点的一致性哈希测试服务
+func newHashTestService(count int) *service.ServiceConfig {
+	nodes := make([]*service.NodeConfig, 0, count)
+	for i := 0; i < count; i++ {
+		nodes = append(nodes, &service.NodeConfig{
+			ID:      string(rune('a' + i)),
+			URL:     "http://localhost:800" + string(rune('1'+i)),
+			Weight:  1,
+			Health:  true,
+			Enabled: true,
+		})
+	}
+	return &service.ServiceConfig{ID: "hash-service", Strategy: service.ConsistentHash, Nodes: nodes}
+}
+
+// newHashTestContext 创建测试请求上下文
+func newHashTestContext(target string, headers map[string]string) *core.Context {
+	req := httptest.NewRequest("GET", target, nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	return core.NewContext(httptest.NewRecorder(), req)
+}
+
+// TestConsistentHashByHeader 验证按请求头哈希时相同的键路由到相同节点
+func TestConsistentHashByHeader(t *testing.T) {
+	svc := newHashTestService(3)
+	lb := service.NewConsistentHashBalancer(&service.LoadBalancerConfig{
+		Strategy: service.ConsistentHash,
+		HashOn:   service.HashOnHeader,
+		HashKey:  "X-User-Id",
+	})
+
+	first := lb.Select(svc, newHashTestContext("/api/a", map[string]string{"X-User-Id": "user-1"}))
+	require.NotNil(t, first)
+	for i := 0; i < 20; i++ {
+		node := lb.Select(svc, newHashTestContext("/api/other", map[string]string{"X-User-Id": "user-1"}))
+		assert.Equal(t, first.ID, node.ID, "相同用户应该路由到相同节点")
+	}
+
+	selected := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		node := lb.Select(svc, newHashTestContext("/api/a", map[string]string{"X-User-Id": "user-" + string(rune('A'+i%26)) + string(rune('a'+i/26))}))
+		selected[node.ID] = true
+	}
+	assert.Greater(t, len(selected), 1, "不同用户应该分布到多个节点")
+}
+
+// TestConsistentHashByQuery 验证按查询参数哈希
+func TestConsistentHashByQuery(t *testing.T) {
+	svc := newHashTestService(3)
+	lb := service.NewConsistentHashBalancer(&service.LoadBalancerConfig{
+		Strategy: service.ConsistentHash,
+		HashOn:   service.HashOnQuery,
+		HashKey:  "tenant",
+	})
+
+	first := lb.Select(svc, newHashTestContext("/a?tenant=t1", nil))
+	second := lb.Select(svc, newHashTestContext("/b?tenant=t1&x=1", nil))
+	assert.Equal(t, first.ID, second.ID, "相同查询参数应该路由到相同节点")
+}
+
+// TestConsistentHashBoundedLoad 验证有界负载：热点键超过容量上限后顺延到其他节点，释放后恢复
+func TestConsistentHashBoundedLoad(t *testing.T) {
+	svc := newHashTestService(3)
+	lb := service.NewConsistentHashBalancer(&service.LoadBalancerConfig{
+		Strategy:   service.ConsistentHash,
+		HashOn:     service.HashOnHeader,
+		HashKey:    "X-User-Id",
+		LoadFactor: 1.25,
+	})
+	releaser, ok := lb.(service.NodeReleaser)
+	require.True(t, ok, "一致性哈希负载均衡器应该支持释放节点")
+
+	headers := map[string]string{"X-User-Id": "hot-user"}
+	home := lb.Select(svc, newHashTestContext("/", headers))
+	require.NotNil(t, home)
+
+	// 同一个热点键持续占用，在途请求应分散到多个节点，且单个节点不超过容量上限
+	counts := map[string]int{home.ID: 1}
+	for i := 1; i < 30; i++ {
+		node := lb.Select(svc, newHashTestContext("/", headers))
+		counts[node.ID]++
+	}
+	assert.Len(t, counts, 3, "热点键超出容量后应该顺延到其他节点")
+	for nodeID, count := range counts {
+		assert.LessOrEqual(t, count, 13, "节点 %s 的在途请求数超过上限 ceil(1.25*30/3)", nodeID)
+	}
+
+	// 全部释放后热点键重新路由到原节点
+	for nodeID, count := range counts {
+		for i := 0; i < count; i++ {
+			releaser.ReleaseNode(nodeID)
+		}
+	}
+	assert.Empty(t, lb.GetStats()["in_flight"], "释放后不应该有在途请求")
+	assert.Equal(t, home.ID, lb.Select(svc, newHashTestContext("/", headers)).ID)
+}
+
+// TestConsistentHashRingRebuild 验证节点变化时重建哈希环，不会选中已移除的节点
+func TestConsistentHashRingRebuild(t *testing.T) {
+	svc := newHashTestService(3)
+	lb := service.NewConsistentHashBalancer(&service.LoadBalancerConfig{
+		Strategy: service.ConsistentHash,
+		HashOn:   service.HashOnPath,
+	})
+
+	paths := []string{"/a", "/b", "/c", "/d", "/e", "/f", "/g", "/h"}
+	for _, path := range paths {
+		lb.Select(svc, newHashTestContext(path, nil))
+	}
+
+	svc.Nodes = svc.Nodes[:2]
+	for _, path := range paths {
+		node := lb.Select(svc, newHashTestContext(path, nil))
+		require.NotNil(t, node)
+		assert.NotEqual(t, "c", node.ID, "已移除的节点不应该被选中")
+	}
+}
@@ -53,6 +53,7 @@ func TestLoadBalancerAlgorithms(t *testing.T) {
 		service.LeastConn,
 		service.IPHash,
 		service.Random,
+		service.ConsistentHash,
 	}
 
 	for _, algorithm := range algorithms {
@@ -0,0 +1,164 @@
+package loader_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/gateway/loader"
+)
+
+// writeValidateConfig 将配置内容写入临时YAML文件
+func writeValidateConfig(t *testing.T, content string) string {
+	configPath := filepath.Join(t.TempDir(), "gateway.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+	return configPath
+}
+
+// issuePaths 返回指定级别问题的路径列表
+func issuePaths(report *loader.ValidationReport, level loader.IssueLevel) []string {
+	var paths []string
+	for _, issue := range report.Issues {
+		if issue.Level == level {
+			paths = append(paths, issue.Path)
+		}
+	}
+	return paths
+}
+
+const validGatewayYAML = `
+base:
+  listen: ":8080"
+router:
+  id: "test-router"
+  enabled: true
+  routes:
+    - id: "user-route"
+      service_id: "user-service"
+      path: "/api/users"
+      enabled: true
+proxy:
+  id: "test-proxy"
+  enabled: true
+  type: "http"
+  service:
+    - id: "user-service"
+      strategy: "round-robin"
+      nodes:
+        - id: "user-node-1"
+          url: "http://127.0.0.1:8081"
+          weight: 100
+          enabled: true
+`
+
+func TestValidateConfigFileReport(t *testing.T) {
+	t.Run("有效配置校验通过", func(t *testing.T) {
+		report := loader.ValidateConfigFileReport(writeValidateConfig(t, validGatewayYAML))
+
+		assert.False(t, report.HasErrors(), "issues: %v", report.Issues)
+		assert.Equal(t, 1, report.Routes)
+		assert.Equal(t, 1, report.Services)
+		assert.Equal(t, 1, report.Nodes)
+	})
+
+	t.Run("配置文件不存在", func(t *testing.T) {
+		report := loader.ValidateConfigFileReport(filepath.Join(t.TempDir(), "missing.yaml"))
+
+		assert.True(t, report.HasErrors())
+		assert.Equal(t, []string{"file"}, issuePaths(report, loader.IssueLevelError))
+	})
+
+	t.Run("路由引用不存在的服务", func(t *testing.T) {
+		report := loader.ValidateConfigFileReport(writeValidateConfig(t, `
+router:
+  id: "test-router"
+  routes:
+    - id: "order-route"
+      service_id: "order-service"
+      path: "/api/orders"
+      enabled: true
+proxy:
+  id: "test-proxy"
+  service:
+    - id: "user-service"
+      nodes:
+        - id: "user-node-1"
+          url: "http://127.0.0.1:8081"
+          enabled: true
+`))
+
+		assert.Contains(t, issuePaths(report, loader.IssueLevelError), "router.routes[order-route].service_id")
+		assert.Contains(t, issuePaths(report, loader.IssueLevelWarning), "proxy.service[user-service]")
+	})
+
+	t.Run("服务节点配置错误", func(t *testing.T) {
+		report := loader.ValidateConfigFileReport(writeValidateConfig(t, `
+router:
+  id: "test-router"
+  routes:
+    - id: "user-route"
+      service_id: "user-service"
+      path: "/api/users"
+    - id: "order-route"
+      service_id: "order-service"
+      path: "/api/orders"
+proxy:
+  id: "test-proxy"
+  service:
+    - id: "user-service"
+      strategy: "fastest"
+      nodes:
+        - id: "user-node-1"
+          url: "127.0.0.1:8081"
+          enabled: true
+        - id: "user-node-1"
+          url: "http://127.0.0.1:8082"
+          weight: -1
+          enabled: true
+    - id: "order-service"
+`))
+
+		errors := issuePaths(report, loader.IssueLevelError)
+		assert.Contains(t, errors, "proxy.service[user-service].strategy")
+		assert.Contains(t, errors, "proxy.service[user-service].nodes[user-node-1].url")
+		assert.Contains(t, errors, "proxy.service[user-service].nodes[user-node-1]")
+		assert.Contains(t, errors, "proxy.service[user-service].nodes[user-node-1].weight")
+		assert.Contains(t, errors, "proxy.service[order-service].nodes")
+	})
+
+	t.Run("路由器ID为空时路由不生效", func(t *testing.T) {
+		report := loader.ValidateConfigFileReport(writeValidateConfig(t, `
+router:
+  routes:
+    - id: "user-route"
+      service_id: "user-service"
+      path: "/api/users"
+`))
+
+		assert.Contains(t, issuePaths(report, loader.IssueLevelError), "router.id")
+		assert.Equal(t, 0, report.Routes)
+	})
+
+	t.Run("未知配置项报告为警告", func(t *testing.T) {
+		report := loader.ValidateConfigFileReport(writeValidateConfig(t, validGatewayYAML+`
+unknown_section:
+  enabled: true
+`))
+
+		assert.False(t, report.HasErrors(), "issues: %v", report.Issues)
+		assert.Equal(t, []string{"schema"}, issuePaths(report, loader.IssueLevelWarning))
+	})
+}
+
+func TestValidationReport_Print(t *testing.T) {
+	report := loader.ValidateConfigFileReport(filepath.Join(t.TempDir(), "missing.yaml"))
+
+	var buf bytes.Buffer
+	report.Print(&buf)
+	assert.Contains(t, buf.String(), "[ERROR] file:")
+	assert.Contains(t, buf.String(), "校验失败: 1 个错误, 0 个警告")
+}
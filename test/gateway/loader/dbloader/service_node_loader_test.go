@@ -0,0 +1,69 @@
+package dbloader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/gateway/loader/dbloader"
+	"gateway/pkg/database"
+	"gateway/pkg/database/dbtypes"
+	_ "gateway/pkg/database/sqlite" // 导入SQLite实现
+)
+
+// openNodeDB 创建包含服务节点表的临时SQLite数据库
+func openNodeDB(t *testing.T) database.Database {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "gateway.db")
+	db, err := database.Open(&dbtypes.DbConfig{
+		Name:    dsn,
+		Enabled: true,
+		Driver:  dbtypes.DriverSQLite,
+		DSN:     dsn,
+		Pool:    dbtypes.PoolConfig{MaxOpenConns: 1, MaxIdleConns: 1},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	script, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "scripts", "db", "sqlite", "HUB_GW_SERVICE_NODE.sql"))
+	require.NoError(t, err)
+	_, err = db.Exec(context.Background(), string(script), nil, true)
+	require.NoError(t, err)
+	return db
+}
+
+// insertNode 写入服务节点
+func insertNode(t *testing.T, db database.Database, serviceNodeId string, nodeWeight, nodeStatus int) {
+	t.Helper()
+	_, err := db.Exec(context.Background(), `INSERT INTO HUB_GW_SERVICE_NODE
+		(tenantId, serviceNodeId, serviceDefinitionId, nodeId, nodeUrl, nodeHost, nodePort, nodeWeight, nodeStatus, addWho, editWho, oprSeqFlag)
+		VALUES ('default', ?, 'svc-order', ?, ?, '127.0.0.1', 8080, ?, ?, 'admin', 'admin', ?)`,
+		[]interface{}{serviceNodeId, serviceNodeId, "http://127.0.0.1:8080/" + serviceNodeId, nodeWeight, nodeStatus, serviceNodeId}, true)
+	require.NoError(t, err)
+}
+
+// TestLoadServiceNodesMaintenance 验证维护状态的节点加载为禁用节点，在线和下线状态不影响启用标记
+func TestLoadServiceNodesMaintenance(t *testing.T) {
+	db := openNodeDB(t)
+	insertNode(t, db, "node-online", 300, 1)
+	insertNode(t, db, "node-maintenance", 200, dbloader.NodeStatusMaintenance)
+	insertNode(t, db, "node-offline", 100, 0)
+
+	nodes, err := dbloader.NewLimiterServiceLoader(db, "default").LoadServiceNodes(context.Background(), "svc-order")
+	require.NoError(t, err)
+	require.Len(t, nodes, 3)
+
+	enabled := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		enabled[node.ID] = node.Enabled
+	}
+	assert.Equal(t, map[string]bool{
+		"node-online":      true,
+		"node-maintenance": false,
+		"node-offline":     true,
+	}, enabled)
+}
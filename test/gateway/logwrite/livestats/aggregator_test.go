@@ -0,0 +1,136 @@
+package livestats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/gateway/logwrite/livestats"
+	"gateway/internal/gateway/logwrite/types"
+)
+
+// fakeClock 可手动推进的时钟
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newAccessLog(tenantId, instanceId, routeId string, status, latencyMs int) *types.AccessLog {
+	return &types.AccessLog{
+		TenantID:              tenantId,
+		GatewayInstanceID:     instanceId,
+		GatewayInstanceName:   instanceId + "-name",
+		RouteConfigID:         routeId,
+		RouteName:             routeId + "-name",
+		GatewayStatusCode:     status,
+		TotalProcessingTimeMs: latencyMs,
+	}
+}
+
+func TestAggregatorSnapshot(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	agg := livestats.NewAggregatorWithClock(clock.Now)
+
+	// route-a: 100 次请求，其中 10 次 500，延迟 1..100ms
+	for i := 1; i <= 100; i++ {
+		status := 200
+		if i <= 10 {
+			status = 500
+		}
+		agg.Record(newAccessLog("t1", "gw1", "route-a", status, i))
+	}
+	// route-b: 20 次请求，全部 404
+	for i := 0; i < 20; i++ {
+		agg.Record(newAccessLog("t1", "gw1", "route-b", 404, 3))
+	}
+	// 其他租户的数据不应出现在快照中
+	agg.Record(newAccessLog("t2", "gw2", "route-x", 200, 1))
+
+	snapshot := agg.Snapshot(livestats.SnapshotQuery{TenantId: "t1", WindowSeconds: 10})
+	require.NotNil(t, snapshot)
+
+	assert.Equal(t, int64(120), snapshot.Requests)
+	assert.Equal(t, int64(10), snapshot.Errors)
+	assert.Equal(t, int64(20), snapshot.ClientErrors)
+	assert.InDelta(t, 12.0, snapshot.RPS, 0.0001)
+
+	require.Len(t, snapshot.Routes, 2)
+	routeA := snapshot.Routes[0]
+	assert.Equal(t, "route-a", routeA.RouteConfigId)
+	assert.Equal(t, "route-a-name", routeA.RouteName)
+	assert.InDelta(t, 0.1, routeA.ErrorRate, 0.0001)
+	assert.InDelta(t, 50.5, routeA.AvgLatencyMs, 0.0001)
+	// 第95个请求延迟为95ms，落在(50,100]桶，观测最大值为100
+	assert.Equal(t, 100, routeA.P95LatencyMs)
+	// 全部请求落在首个桶，桶上界大于观测最大值时取最大值
+	assert.Equal(t, 3, snapshot.Routes[1].P95LatencyMs)
+
+	require.Len(t, snapshot.Instances, 1)
+	assert.Equal(t, "gw1", snapshot.Instances[0].GatewayInstanceId)
+	assert.Equal(t, "gw1-name", snapshot.Instances[0].GatewayInstanceName)
+	assert.Equal(t, livestats.HealthStatusDegraded, snapshot.Instances[0].HealthStatus)
+}
+
+func TestAggregatorWindowExpiry(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	agg := livestats.NewAggregatorWithClock(clock.Now)
+
+	agg.Record(newAccessLog("t1", "gw1", "route-a", 200, 10))
+	clock.now = clock.now.Add(5 * time.Second)
+	agg.Record(newAccessLog("t1", "gw1", "route-a", 502, 10))
+
+	snapshot := agg.Snapshot(livestats.SnapshotQuery{TenantId: "t1", WindowSeconds: 3})
+	assert.Equal(t, int64(1), snapshot.Requests)
+	assert.Equal(t, livestats.HealthStatusUnhealthy, snapshot.Instances[0].HealthStatus)
+
+	snapshot = agg.Snapshot(livestats.SnapshotQuery{TenantId: "t1", WindowSeconds: 10})
+	assert.Equal(t, int64(2), snapshot.Requests)
+
+	// 超过窗口后实例保留但状态为空闲
+	clock.now = clock.now.Add(time.Minute)
+	snapshot = agg.Snapshot(livestats.SnapshotQuery{TenantId: "t1", WindowSeconds: 10})
+	assert.Equal(t, int64(0), snapshot.Requests)
+	assert.Empty(t, snapshot.Routes)
+	require.Len(t, snapshot.Instances, 1)
+	assert.Equal(t, livestats.HealthStatusIdle, snapshot.Instances[0].HealthStatus)
+
+	// 环形桶被复用后旧数据不再计入
+	clock.now = clock.now.Add(time.Duration(livestats.MaxWindowSeconds-65) * time.Second)
+	agg.Record(newAccessLog("t1", "gw1", "route-a", 200, 10))
+	snapshot = agg.Snapshot(livestats.SnapshotQuery{TenantId: "t1", WindowSeconds: livestats.MaxWindowSeconds})
+	assert.Equal(t, int64(2), snapshot.Requests)
+}
+
+func TestAggregatorFilters(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	agg := livestats.NewAggregatorWithClock(clock.Now)
+
+	for i := 0; i < 3; i++ {
+		agg.Record(newAccessLog("t1", "gw1", "route-a", 200, 10))
+		agg.Record(newAccessLog("t1", "gw2", "route-b", 200, 10))
+	}
+	agg.Record(newAccessLog("t1", "gw2", "route-c", 200, 10))
+
+	snapshot := agg.Snapshot(livestats.SnapshotQuery{TenantId: "t1", GatewayInstanceId: "gw2"})
+	assert.Equal(t, int64(4), snapshot.Requests)
+	require.Len(t, snapshot.Instances, 1)
+	assert.Equal(t, "gw2", snapshot.Instances[0].GatewayInstanceId)
+	assert.Equal(t, livestats.HealthStatusHealthy, snapshot.Instances[0].HealthStatus)
+
+	snapshot = agg.Snapshot(livestats.SnapshotQuery{TenantId: "t1", MaxRoutes: 2})
+	require.Len(t, snapshot.Routes, 2)
+	assert.Equal(t, int64(7), snapshot.Requests)
+	assert.Equal(t, livestats.DefaultWindowSeconds, snapshot.WindowSeconds)
+}
+
+func TestHealthOf(t *testing.T) {
+	assert.Equal(t, livestats.HealthStatusIdle, livestats.HealthOf(livestats.TrafficStats{}))
+	assert.Equal(t, livestats.HealthStatusHealthy, livestats.HealthOf(livestats.TrafficStats{Requests: 100, Errors: 4}))
+	assert.Equal(t, livestats.HealthStatusDegraded, livestats.HealthOf(livestats.TrafficStats{Requests: 100, Errors: 5}))
+	assert.Equal(t, livestats.HealthStatusUnhealthy, livestats.HealthOf(livestats.TrafficStats{Requests: 100, Errors: 50}))
+}
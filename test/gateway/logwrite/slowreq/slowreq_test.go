@@ -0,0 +1,143 @@
+package slowreq_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"gateway/internal/gateway/core"
+	"gateway/internal/gateway/logwrite/slowreq"
+	"gateway/internal/gateway/logwrite/types"
+)
+
+func TestParseSlowRequestConfig(t *testing.T) {
+	cfg := types.ParseSlowRequestConfigFromExtProperty("")
+	if cfg.SlowRequestEnabled || cfg.ThresholdMs != 3000 || cfg.MaxPerMinute != 60 || cfg.SnapshotDir == "" {
+		t.Errorf("默认配置不正确: %+v", cfg)
+	}
+
+	cfg = types.ParseSlowRequestConfigFromExtProperty(`{"slowRequestEnabled":"Y","slowRequestThresholdMs":500,"slowRequestGoroutineDump":"y","slowRequestSnapshotDir":" /tmp/slow ","slowRequestMaxPerMinute":10}`)
+	if !cfg.SlowRequestEnabled || cfg.ThresholdMs != 500 || !cfg.GoroutineDump || cfg.SnapshotDir != "/tmp/slow" || cfg.MaxPerMinute != 10 {
+		t.Errorf("解析配置不正确: %+v", cfg)
+	}
+
+	cfg = types.ParseSlowRequestConfigFromExtProperty(`{"slowRequestEnabled":"Y","slowRequestThresholdMs":0}`)
+	if cfg.SlowRequestEnabled {
+		t.Error("阈值无效时不应启用慢请求检测")
+	}
+}
+
+func TestWatchdogDetect(t *testing.T) {
+	ctx := core.NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+	if slowreq.Start(ctx, &types.SlowRequestConfig{ThresholdMs: 10}) != nil {
+		t.Fatal("未启用时不应启动看门狗")
+	}
+	if slowreq.FromContext(ctx) != nil {
+		t.Fatal("未启用时上下文中不应有看门狗")
+	}
+	// 未启用时停止和追踪均为空操作
+	slowreq.FromContext(ctx).Stop()
+
+	dir := t.TempDir()
+	config := &types.SlowRequestConfig{SlowRequestEnabled: true, ThresholdMs: 20, GoroutineDump: true, SnapshotDir: dir}
+	watchdog := slowreq.Start(ctx, config)
+	if watchdog == nil || slowreq.FromContext(ctx) != watchdog {
+		t.Fatal("看门狗未写入上下文")
+	}
+
+	time.Sleep(80 * time.Millisecond)
+	watchdog.Stop()
+	result := watchdog.Result()
+	if !result.Detected || result.GoroutineCount <= 0 || result.DetectedAt.IsZero() {
+		t.Fatalf("超过阈值后应触发检测: %+v", result)
+	}
+	if !strings.HasPrefix(result.SnapshotRef, dir) {
+		t.Fatalf("协程快照应保存在配置目录: %s", result.SnapshotRef)
+	}
+	content, err := os.ReadFile(result.SnapshotRef)
+	if err != nil || !strings.Contains(string(content), "goroutine") {
+		t.Errorf("协程快照内容不正确: %v", err)
+	}
+
+	// 窗口期内的慢请求共享同一份快照
+	other := slowreq.Start(core.NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil)), config)
+	time.Sleep(80 * time.Millisecond)
+	if other.Result().SnapshotRef != result.SnapshotRef {
+		t.Errorf("窗口期内应复用协程快照: %s != %s", other.Result().SnapshotRef, result.SnapshotRef)
+	}
+
+	// 在阈值前结束的请求不触发检测
+	fast := slowreq.Start(core.NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fast", nil)),
+		&types.SlowRequestConfig{SlowRequestEnabled: true, ThresholdMs: 1000})
+	fast.Stop()
+	if fast.Result().Detected {
+		t.Error("阈值前结束的请求不应触发检测")
+	}
+}
+
+func TestTraceRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := core.NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	watchdog := slowreq.Start(ctx, &types.SlowRequestConfig{SlowRequestEnabled: true, ThresholdMs: 60000})
+	defer watchdog.Stop()
+
+	client := server.Client()
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		resp, err := client.Do(slowreq.TraceRequest(watchdog, req))
+		if err != nil {
+			t.Fatalf("请求失败: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	states := watchdog.Conns().Snapshot()
+	if len(states) != 2 {
+		t.Fatalf("每次转发应记录一条连接状态: %d", len(states))
+	}
+	first, second := states[0], states[1]
+	if !first.GotConn || !first.WroteRequest || !first.GotFirstByte || first.Reused || first.RemoteAddr == "" {
+		t.Errorf("首次连接状态不正确: %+v", first)
+	}
+	if !second.Reused {
+		t.Errorf("第二次请求应复用连接: %+v", second)
+	}
+	if first.FirstByteMs < 10 {
+		t.Errorf("首字节耗时应包含后端处理时间: %d", first.FirstByteMs)
+	}
+
+	timing := slowreq.BuildTiming(100, 20, 80, time.Now().Add(-time.Second), watchdog.Result(), states)
+	if timing.UpstreamAttempts != 2 || timing.FirstByteMs != first.FirstByteMs+second.FirstByteMs || timing.DetectedAfterMs != 0 {
+		t.Errorf("耗时分解不正确: %+v", timing)
+	}
+
+	// 未启用慢请求检测时原样返回请求
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if slowreq.TraceRequest(nil, req) != req {
+		t.Error("未启用时应原样返回请求")
+	}
+}
+
+func TestAllow(t *testing.T) {
+	if !slowreq.Allow(0) {
+		t.Error("上限为0时不限制")
+	}
+	allowed := 0
+	for i := 0; i < 10; i++ {
+		if slowreq.Allow(3) {
+			allowed++
+		}
+	}
+	// 跨分钟边界时计数会重置，最多允许两个窗口的上限
+	if allowed == 0 || allowed > 6 {
+		t.Errorf("每分钟记录上限未生效: %d", allowed)
+	}
+}
@@ -0,0 +1,153 @@
+package spool_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"gateway/internal/gateway/logwrite/spool"
+	"gateway/internal/gateway/logwrite/types"
+)
+
+// fakeRetrier 测试用写入器，记录重写成功的日志
+type fakeRetrier struct {
+	mu      sync.Mutex
+	fail    bool
+	written []*types.AccessLog
+}
+
+func (r *fakeRetrier) RetryAccessLog(_ context.Context, log *types.AccessLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail {
+		return errors.New("database unavailable")
+	}
+	r.written = append(r.written, log)
+	return nil
+}
+
+// newTestSpool 创建后台重试间隔很长的落盘重试器，由测试手动调用 Retry
+func newTestSpool(t *testing.T, dir string, retrier *fakeRetrier, maxRetries int) *spool.Spool {
+	t.Helper()
+	s, err := spool.New("instance-1", &types.LogSpoolConfig{
+		SpoolEnabled:       true,
+		SpoolDir:           dir,
+		MaxRetries:         maxRetries,
+		RetryIntervalMs:    3600000,
+		MaxRetryIntervalMs: 3600000,
+		MaxSpoolSizeMB:     1,
+	}, func() (spool.Retrier, bool) { return retrier, true })
+	if err != nil {
+		t.Fatalf("创建落盘重试器失败: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func TestParseLogSpoolConfig(t *testing.T) {
+	cfg := types.ParseLogSpoolConfigFromExtProperty("")
+	if cfg.SpoolEnabled || cfg.SpoolDir != "./logs/spool" || cfg.MaxRetries != 10 || cfg.RetryIntervalMs != 5000 || cfg.MaxSpoolSizeMB != 512 {
+		t.Errorf("默认配置不正确: %+v", cfg)
+	}
+
+	cfg = types.ParseLogSpoolConfigFromExtProperty(`{"logSpoolEnabled":"Y","logSpoolDir":" /tmp/spool ","logSpoolMaxRetries":3,"logSpoolRetryIntervalMs":1000,"logSpoolMaxRetryIntervalMs":500,"logSpoolMaxSizeMB":0}`)
+	if !cfg.SpoolEnabled || cfg.SpoolDir != "/tmp/spool" || cfg.MaxRetries != 3 || cfg.RetryIntervalMs != 1000 || cfg.MaxSpoolSizeMB != 0 {
+		t.Errorf("解析配置不正确: %+v", cfg)
+	}
+	if cfg.MaxRetryIntervalMs != 1000 {
+		t.Errorf("最大重试间隔小于首次间隔时应修正为首次间隔，实际: %d", cfg.MaxRetryIntervalMs)
+	}
+
+	cfg = types.ParseLogSpoolConfigFromExtProperty(`{"logSpoolMaxRetries":0,"logSpoolRetryIntervalMs":-1}`)
+	if cfg.MaxRetries != 10 || cfg.RetryIntervalMs != 5000 {
+		t.Errorf("无效的重试次数和间隔应使用默认值: %+v", cfg)
+	}
+}
+
+func TestSpoolRetry(t *testing.T) {
+	dir := t.TempDir()
+	retrier := &fakeRetrier{fail: true}
+	s := newTestSpool(t, dir, retrier, 5)
+
+	s.Append([]*types.AccessLog{{TraceID: "trace-1"}, {TraceID: "trace-2"}}, errors.New("queue full"))
+	s.Append([]*types.AccessLog{{TraceID: "trace-3"}}, errors.New("flush failed"))
+	if pending := s.Pending(); pending != 3 {
+		t.Fatalf("待重试日志应为3条，实际: %d", pending)
+	}
+
+	if _, err := s.Retry(context.Background()); err == nil {
+		t.Fatal("写入器不可用时重试应返回错误")
+	}
+	if pending := s.Pending(); pending != 3 {
+		t.Fatalf("重试失败后日志应保留在磁盘上，实际: %d", pending)
+	}
+
+	retrier.fail = false
+	n, err := s.Retry(context.Background())
+	if err != nil || n != 3 {
+		t.Fatalf("重试应全部成功: n=%d err=%v", n, err)
+	}
+	if pending := s.Pending(); pending != 0 {
+		t.Errorf("重试成功后不应有待重试日志，实际: %d", pending)
+	}
+	if got := retrier.written[0]; got.TraceID != "trace-1" || got.RetryCount != 2 {
+		t.Errorf("应按落盘顺序重写并累计重试次数: traceId=%s retryCount=%d", got.TraceID, got.RetryCount)
+	}
+}
+
+func TestSpoolDeadLetter(t *testing.T) {
+	dir := t.TempDir()
+	retrier := &fakeRetrier{fail: true}
+	s := newTestSpool(t, dir, retrier, 2)
+
+	s.Append([]*types.AccessLog{{TraceID: "trace-dead"}}, errors.New("write failed"))
+	for i := 0; i < 2; i++ {
+		_, _ = s.Retry(context.Background())
+	}
+	if pending := s.Pending(); pending != 0 {
+		t.Fatalf("超过最大重试次数后应移出待重试队列，实际: %d", pending)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "instance-1", "dead"))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("死信目录应有1个文件: entries=%d err=%v", len(entries), err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "instance-1", "dead", entries[0].Name()))
+	if err != nil || !strings.Contains(string(data), "trace-dead") {
+		t.Errorf("死信文件应包含超过重试次数的日志: %s", data)
+	}
+}
+
+func TestSpoolSizeLimit(t *testing.T) {
+	s := newTestSpool(t, t.TempDir(), &fakeRetrier{}, 5)
+
+	body := strings.Repeat("x", 600<<10)
+	s.Append([]*types.AccessLog{{TraceID: "trace-1", RequestBody: body}}, errors.New("write failed"))
+	s.Append([]*types.AccessLog{{TraceID: "trace-2", RequestBody: body}}, errors.New("write failed"))
+	if pending := s.Pending(); pending != 1 {
+		t.Errorf("超过落盘大小上限的日志应被拒绝，待重试日志应为1条，实际: %d", pending)
+	}
+}
+
+func TestSpoolReopen(t *testing.T) {
+	dir := t.TempDir()
+	s := newTestSpool(t, dir, &fakeRetrier{}, 5)
+	s.Append([]*types.AccessLog{{TraceID: "trace-1"}}, errors.New("write failed"))
+	_ = s.Close()
+
+	// 关闭后仍可追加，日志保留到下次启动
+	s.Append([]*types.AccessLog{{TraceID: "trace-2"}}, errors.New("writer closed"))
+
+	retrier := &fakeRetrier{}
+	reopened := newTestSpool(t, dir, retrier, 5)
+	if pending := reopened.Pending(); pending != 2 {
+		t.Fatalf("重新创建后应继续重试遗留日志，实际: %d", pending)
+	}
+	if n, err := reopened.Retry(context.Background()); err != nil || n != 2 {
+		t.Errorf("遗留日志应重试成功: n=%d err=%v", n, err)
+	}
+}
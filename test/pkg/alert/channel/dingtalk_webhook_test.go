@@ -0,0 +1,138 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gateway/pkg/alert"
+	alertchannel "gateway/pkg/alert/channel"
+)
+
+// TestDingTalkChannel_Send 测试钉钉渠道发送markdown消息和加签参数
+func TestDingTalkChannel_Send(t *testing.T) {
+	var received map[string]interface{}
+	var query map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &received)
+		_, _ = w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	ch, err := alertchannel.CreateChannel(map[string]interface{}{
+		"type":   "dingtalk",
+		"name":   "dingtalk-test",
+		"server": map[string]interface{}{"webhook_url": server.URL, "secret": "SECtest"},
+		"send":   map[string]interface{}{"at_mobiles": []interface{}{"13800000000"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateChannel() error = %v", err)
+	}
+
+	result := ch.Send(context.Background(), &alert.Message{
+		Title:     "JVM告警",
+		Content:   "堆内存使用率过高",
+		Timestamp: time.Now(),
+	}, &alert.SendOptions{Timeout: 5 * time.Second, Retry: 1})
+	if !result.Success {
+		t.Fatalf("Send() failed: %v", result.Error)
+	}
+
+	if received["msgtype"] != "markdown" {
+		t.Errorf("msgtype = %v, want markdown", received["msgtype"])
+	}
+	at, _ := received["at"].(map[string]interface{})
+	if mobiles, _ := at["atMobiles"].([]interface{}); len(mobiles) != 1 || mobiles[0] != "13800000000" {
+		t.Errorf("atMobiles = %v", at["atMobiles"])
+	}
+	if len(query["timestamp"]) == 0 || len(query["sign"]) == 0 {
+		t.Errorf("加签参数缺失: %v", query)
+	}
+}
+
+// TestDingTalkChannel_Send_ErrCode 测试钉钉返回非0错误码时发送失败
+func TestDingTalkChannel_Send_ErrCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"errcode":310000,"errmsg":"sign not match"}`))
+	}))
+	defer server.Close()
+
+	ch, err := alertchannel.NewDingTalkChannel("dingtalk-test", &alertchannel.DingTalkServerConfig{WebhookURL: server.URL}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewDingTalkChannel() error = %v", err)
+	}
+
+	result := ch.Send(context.Background(), &alert.Message{Title: "t", Content: "c", Timestamp: time.Now()},
+		&alert.SendOptions{Timeout: 5 * time.Second, Retry: 1})
+	if result.Success {
+		t.Fatal("Send() 应返回失败")
+	}
+}
+
+// TestWebhookChannel_Send 测试通用Webhook渠道推送JSON消息和自定义请求头
+func TestWebhookChannel_Send(t *testing.T) {
+	var received map[string]interface{}
+	var method, token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		token = r.Header.Get("X-Token")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &received)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	ch, err := alertchannel.CreateChannel(map[string]interface{}{
+		"type": "webhook",
+		"name": "webhook-test",
+		"server": map[string]interface{}{
+			"url":     server.URL,
+			"method":  "put",
+			"headers": map[string]interface{}{"X-Token": "abc"},
+		},
+		"send": map[string]interface{}{},
+	})
+	if err != nil {
+		t.Fatalf("CreateChannel() error = %v", err)
+	}
+
+	result := ch.Send(context.Background(), &alert.Message{
+		Title:     "JVM告警",
+		Content:   "死锁",
+		Timestamp: time.Now(),
+		Tags:      map[string]string{"metricType": "DEADLOCK"},
+	}, &alert.SendOptions{Timeout: 5 * time.Second, Retry: 1})
+	if !result.Success {
+		t.Fatalf("Send() failed: %v", result.Error)
+	}
+
+	if method != http.MethodPut {
+		t.Errorf("method = %s, want PUT", method)
+	}
+	if token != "abc" {
+		t.Errorf("X-Token = %s, want abc", token)
+	}
+	if received["title"] != "JVM告警" || received["channel"] != "webhook-test" {
+		t.Errorf("payload = %v", received)
+	}
+}
+
+// TestWebhookServerConfig_Validate 测试Webhook服务器配置验证
+func TestWebhookServerConfig_Validate(t *testing.T) {
+	if err := (&alertchannel.WebhookServerConfig{}).Validate(); err == nil {
+		t.Error("URL为空时应返回错误")
+	}
+	if err := (&alertchannel.WebhookServerConfig{URL: "http://localhost", Method: "GET"}).Validate(); err == nil {
+		t.Error("GET方法应返回错误")
+	}
+	cfg := &alertchannel.WebhookServerConfig{URL: "http://localhost"}
+	if err := cfg.Validate(); err != nil || cfg.Method != http.MethodPost {
+		t.Errorf("默认方法应为POST, got %s, err %v", cfg.Method, err)
+	}
+}
@@ -0,0 +1,62 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/config"
+)
+
+// registerTestDecryptor 注册去掉 ENCY_ 前缀即为明文的测试解密函数
+func registerTestDecryptor(t *testing.T) {
+	config.RegisterDecryptor(func(ciphertext string) (string, error) {
+		return strings.TrimPrefix(ciphertext, config.EncryptedValuePrefix), nil
+	})
+	t.Cleanup(func() {
+		config.RegisterDecryptor(nil)
+		config.Clear()
+	})
+}
+
+// TestLoadConfig_EncryptedAppFileDisallowOverride 验证整文件加密的 app.yaml 加载后不允许覆盖
+func TestLoadConfig_EncryptedAppFileDisallowOverride(t *testing.T) {
+	registerTestDecryptor(t)
+	dir := t.TempDir()
+	appFile := filepath.Join(dir, "app.yaml")
+	require.NoError(t, os.WriteFile(appFile, []byte("ENCY_app:\n  name: encrypted-app\n"), 0644))
+
+	require.NoError(t, config.LoadConfig(dir, config.LoadOptions{ClearExisting: true}))
+	assert.Equal(t, "encrypted-app", config.GetString("app.name", ""))
+
+	assert.Error(t, config.LoadConfig(dir, config.LoadOptions{AllowOverride: false}))
+	assert.Error(t, config.LoadConfigFile(appFile, config.LoadOptions{AllowOverride: false}))
+
+	config.Clear()
+	assert.NoError(t, config.LoadConfigFile(appFile, config.LoadOptions{AllowOverride: false}))
+}
+
+// TestLoadConfig_EnvOverridesEncryptedValue 验证解密后的配置项仍可被环境变量覆盖
+func TestLoadConfig_EnvOverridesEncryptedValue(t *testing.T) {
+	registerTestDecryptor(t)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.yaml"), []byte(`
+app:
+  secret: ENCY_file-secret
+  token: ENCY_file-token
+`), 0644))
+	t.Setenv("GATEWAY_APP.TOKEN", "ENCY_env-token")
+
+	require.NoError(t, config.LoadConfig(dir, config.LoadOptions{ClearExisting: true}))
+	assert.Equal(t, "file-secret", config.GetString("app.secret", ""))
+	// 环境变量本身为密文时同样解密
+	assert.Equal(t, "env-token", config.GetString("app.token", ""))
+
+	t.Setenv("GATEWAY_APP.SECRET", "env-secret")
+	assert.Equal(t, "env-secret", config.GetString("app.secret", ""))
+	assert.Equal(t, config.SourceEnv, config.Inspect("app.secret").Source)
+}
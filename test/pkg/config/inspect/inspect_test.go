@@ -0,0 +1,128 @@
+package inspect
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/config"
+	"gateway/pkg/utils/mask"
+)
+
+// loadTestConfig 在临时目录写入 app.yaml 和 database.yaml 并加载
+func loadTestConfig(t *testing.T) string {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.yaml"), []byte(`
+app:
+  name: gateway-test
+  gateway:
+    enabled: true
+cache:
+  snapshot:
+    interval_seconds: 30
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "database.yaml"), []byte(`
+database:
+  connections:
+    mysql:
+      password: secret-value
+      dsn: "root:secret-value@tcp(127.0.0.1:3306)/gateway"
+cache:
+  snapshot:
+    interval_seconds: 60
+`), 0644))
+	require.NoError(t, config.LoadConfig(dir, config.LoadOptions{ClearExisting: true}))
+	t.Cleanup(config.Clear)
+	return dir
+}
+
+// TestInspect 测试配置项生效值来源和脱敏
+func TestInspect(t *testing.T) {
+	dir := loadTestConfig(t)
+	appFile, err := filepath.Abs(filepath.Join(dir, "app.yaml"))
+	require.NoError(t, err)
+	databaseFile, err := filepath.Abs(filepath.Join(dir, "database.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{appFile, databaseFile}, config.LoadedConfigFiles())
+
+	name := config.Inspect("App.Name")
+	assert.Equal(t, "app.name", name.Key)
+	assert.Equal(t, "gateway-test", name.Value)
+	assert.Equal(t, config.SourceFile, name.Source)
+	assert.Equal(t, appFile, name.File)
+	assert.Equal(t, "GATEWAY_APP.NAME", name.EnvVar)
+
+	// 后加载的文件覆盖先加载的文件
+	interval := config.Inspect("cache.snapshot.interval_seconds")
+	assert.Equal(t, config.SourceFile, interval.Source)
+	assert.Equal(t, databaseFile, interval.File)
+	assert.Equal(t, []string{appFile, databaseFile}, interval.Files)
+	assert.Equal(t, 60, interval.Value)
+
+	t.Setenv("GATEWAY_APP.NAME", "from-env")
+	name = config.Inspect("app.name")
+	assert.Equal(t, config.SourceEnv, name.Source)
+	assert.Equal(t, "from-env", name.Value)
+
+	missing := config.Inspect("app.not_configured")
+	assert.Equal(t, config.SourceDefault, missing.Source)
+	assert.Nil(t, missing.Value)
+	assert.Empty(t, missing.Files)
+
+	password := config.Inspect("database.connections.mysql.password")
+	assert.Equal(t, mask.Masked, password.Value)
+	assert.True(t, password.Masked)
+	dsn := config.Inspect("database.connections.mysql.dsn")
+	assert.NotContains(t, dsn.Value, "secret-value")
+	assert.True(t, dsn.Masked)
+
+	section := config.Inspect("database.connections.mysql")
+	require.IsType(t, map[string]interface{}{}, section.Value)
+	assert.Equal(t, mask.Masked, section.Value.(map[string]interface{})["password"])
+	assert.True(t, section.Masked)
+
+	items := config.InspectAll("database")
+	require.Len(t, items, 2)
+	assert.Equal(t, "database.connections.mysql.dsn", items[0].Key)
+}
+
+// TestDiffLoadedFiles 测试配置文件修改后与启动时内容的差异
+func TestDiffLoadedFiles(t *testing.T) {
+	dir := loadTestConfig(t)
+	diffs, err := config.DiffLoadedFiles()
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.yaml"), []byte(`
+app:
+  name: gateway-renamed
+  timezone: Asia/Shanghai
+cache:
+  snapshot:
+    interval_seconds: 30
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "database.yaml"), []byte(`
+database:
+  connections:
+    mysql:
+      password: changed-value
+      dsn: "root:secret-value@tcp(127.0.0.1:3306)/gateway"
+cache:
+  snapshot:
+    interval_seconds: 60
+`), 0644))
+
+	diffs, err = config.DiffLoadedFiles()
+	require.NoError(t, err)
+	require.Len(t, diffs, 4)
+	assert.Equal(t, config.FileDiff{File: diffs[0].File, Key: "app.gateway.enabled", Kind: config.DiffRemoved, Booted: true}, diffs[0])
+	assert.Equal(t, config.FileDiff{File: diffs[1].File, Key: "app.name", Kind: config.DiffChanged, Booted: "gateway-test", Current: "gateway-renamed"}, diffs[1])
+	assert.Equal(t, config.FileDiff{File: diffs[2].File, Key: "app.timezone", Kind: config.DiffAdded, Current: "Asia/Shanghai"}, diffs[2])
+	assert.Equal(t, config.FileDiff{File: diffs[3].File, Key: "database.connections.mysql.password", Kind: config.DiffChanged, Booted: mask.Masked, Current: mask.Masked}, diffs[3])
+
+	// 启动后的配置不受文件修改影响
+	assert.Equal(t, "gateway-test", config.GetString("app.name", ""))
+}
@@ -0,0 +1,111 @@
+package crash
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/config"
+	"gateway/pkg/crash"
+)
+
+// setup 使用临时目录保存崩溃报告，缩短重启等待时间
+func setup(t *testing.T) string {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.yaml")
+	content := fmt.Sprintf(`app:
+  crash:
+    report_dir: %q
+    max_reports: 2
+    restart:
+      enabled: true
+      max_restarts: 2
+      window_seconds: 60
+      delay_ms: 1
+`, filepath.Join(dir, "crash"))
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+	require.NoError(t, config.LoadConfigFile(file, config.LoadOptions{ClearExisting: true}))
+	crash.Reset()
+	t.Cleanup(func() {
+		config.Clear()
+		crash.Reset()
+	})
+	return filepath.Join(dir, "crash")
+}
+
+// TestGoRecoversPanic 验证协程panic被恢复，写入包含堆栈的崩溃报告并累计崩溃次数
+func TestGoRecoversPanic(t *testing.T) {
+	dir := setup(t)
+	done := make(chan struct{})
+	crash.Go("gateway.accesslog", func() {
+		defer close(done)
+		panic("boom")
+	})
+	<-done
+
+	require.Eventually(t, func() bool { return crash.TotalCrashes() == 1 }, time.Second, 10*time.Millisecond)
+	stats := crash.Stats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, "gateway.accesslog", stats[0].Component)
+	assert.Equal(t, "boom", stats[0].LastValue)
+
+	data, err := os.ReadFile(stats[0].LastReport)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(stats[0].LastReport, dir))
+	assert.Contains(t, string(data), "panic: boom")
+	assert.Contains(t, string(data), "TestGoRecoversPanic")
+}
+
+// TestCallConvertsPanic 验证 Call 把panic转换为错误，普通错误原样返回
+func TestCallConvertsPanic(t *testing.T) {
+	setup(t)
+	err := crash.Call("timer.task", func() error { panic("task failed") })
+	assert.True(t, errors.Is(err, crash.ErrPanic))
+	assert.Contains(t, err.Error(), "task failed")
+
+	plain := errors.New("plain")
+	assert.Equal(t, plain, crash.Call("timer.task", func() error { return plain }))
+}
+
+// TestSuperviseRestarts 验证panic后重启，重启次数超过上限后停止并清理多余的崩溃报告
+func TestSuperviseRestarts(t *testing.T) {
+	dir := setup(t)
+
+	runs := 0
+	c := crash.Supervise("gateway.listener", func() {
+		runs++
+		if runs == 1 {
+			panic("first")
+		}
+	})
+	assert.Nil(t, c, "重启后正常返回")
+	assert.Equal(t, 2, runs)
+
+	runs = 0
+	c = crash.Supervise("timer.worker", func() {
+		runs++
+		panic(fmt.Sprintf("run %d", runs))
+	})
+	require.NotNil(t, c)
+	assert.Equal(t, 3, runs, "首次运行加两次重启")
+	assert.Equal(t, "run 3", c.Value)
+
+	for _, s := range crash.Stats() {
+		if s.Component == "timer.worker" {
+			assert.True(t, s.GaveUp)
+			assert.Equal(t, int64(3), s.Crashes)
+			assert.Equal(t, int64(2), s.Restarts)
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "crash-*.log"))
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+}
@@ -0,0 +1,105 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/config"
+	"gateway/pkg/health"
+)
+
+func up(ctx context.Context) (health.Status, map[string]interface{}, error) {
+	return health.StatusUp, map[string]interface{}{"count": 1}, nil
+}
+
+func down(ctx context.Context) (health.Status, map[string]interface{}, error) {
+	return "", nil, errors.New("connection refused")
+}
+
+func slow(ctx context.Context) (health.Status, map[string]interface{}, error) {
+	<-ctx.Done()
+	return health.StatusUp, nil, nil
+}
+
+// reset 清理测试注册的检查和状态
+func reset(t *testing.T, names ...string) {
+	t.Cleanup(func() {
+		for _, name := range names {
+			health.Unregister(name)
+		}
+		health.SetStarted(false)
+		config.SetInstanceStopping(false)
+	})
+}
+
+// TestRunAggregatesStatus 验证组件状态汇总：非关键组件异常只降级，关键组件异常时未就绪
+func TestRunAggregatesStatus(t *testing.T) {
+	reset(t, "database", "timer")
+	health.SetStarted(true)
+	health.Register(health.Check{Name: "database", Critical: true, Func: up})
+	health.Register(health.Check{Name: "timer", Func: down})
+
+	report := health.Run(context.Background())
+	assert.Equal(t, health.StatusDegraded, report.Status)
+	assert.True(t, report.Ready)
+	require.Len(t, report.Components, 2)
+	assert.Equal(t, "database", report.Components[0].Name)
+	assert.Equal(t, health.StatusUp, report.Components[0].Status)
+	assert.Equal(t, health.StatusDown, report.Components[1].Status)
+	assert.Equal(t, "connection refused", report.Components[1].Error)
+
+	health.Register(health.Check{Name: "database", Critical: true, Func: down})
+	report = health.Run(context.Background())
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.False(t, report.Ready)
+	assert.Contains(t, report.Reason, "database")
+}
+
+// TestRunTimeout 验证检查超时视为 DOWN
+func TestRunTimeout(t *testing.T) {
+	reset(t, "registry")
+	health.Register(health.Check{Name: "registry", Critical: true, Timeout: 50 * time.Millisecond, Func: slow})
+
+	report := health.Run(context.Background())
+	require.Len(t, report.Components, 1)
+	assert.Equal(t, health.StatusDown, report.Components[0].Status)
+	assert.Contains(t, report.Components[0].Error, "超时")
+}
+
+// TestHandlers 验证存活检查始终返回200，就绪检查在启动完成前和停止流程中返回503
+func TestHandlers(t *testing.T) {
+	reset(t, "cache")
+	health.Register(health.Check{Name: "cache", Critical: true, Func: up})
+
+	serve := func(handler http.HandlerFunc) (int, health.Report) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		var report health.Report
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		return w.Code, report
+	}
+
+	code, report := serve(health.ReadinessHandler)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, report.Ready)
+
+	health.SetStarted(true)
+	code, report = serve(health.ReadinessHandler)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, health.StatusUp, report.Status)
+	assert.Equal(t, float64(1), report.Components[0].Details["count"])
+
+	config.SetInstanceStopping(true)
+	code, _ = serve(health.ReadinessHandler)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	code, _ = serve(health.LivenessHandler)
+	assert.Equal(t, http.StatusOK, code)
+}
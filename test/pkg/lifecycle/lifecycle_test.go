@@ -0,0 +1,177 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/lifecycle"
+)
+
+// recorder 记录生命周期函数的调用顺序
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) fn(call string, err error) lifecycle.Func {
+	return func(ctx context.Context) error {
+		r.mu.Lock()
+		r.calls = append(r.calls, call)
+		r.mu.Unlock()
+		return err
+	}
+}
+
+func (r *recorder) module(name string, deps ...string) lifecycle.Module {
+	return lifecycle.Module{
+		Name:      name,
+		DependsOn: deps,
+		Init:      r.fn(name+".init", nil),
+		Start:     r.fn(name+".start", nil),
+		Stop:      r.fn(name+".stop", nil),
+	}
+}
+
+// TestStartStopOrder 验证按依赖顺序启动、按启动逆序停止，没有依赖关系时保持注册顺序
+func TestStartStopOrder(t *testing.T) {
+	r := &recorder{}
+	manager := lifecycle.NewManager()
+	manager.MustRegister(
+		r.module("web", "database", "cache"),
+		r.module("config"),
+		r.module("database", "config"),
+		r.module("cache", "config"),
+	)
+
+	report, err := manager.Start(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"config.init", "config.start",
+		"database.init", "database.start",
+		"cache.init", "cache.start",
+		"web.init", "web.start",
+	}, r.calls)
+	assert.Len(t, report.Modules, 4)
+	assert.Equal(t, lifecycle.StatusStarted, manager.Status("web"))
+
+	r.calls = nil
+	stopReport := manager.Stop(context.Background())
+	assert.Equal(t, []string{"web.stop", "cache.stop", "database.stop", "config.stop"}, r.calls)
+	assert.Empty(t, stopReport.Failed())
+	assert.NoError(t, stopReport.Err())
+	assert.Equal(t, lifecycle.StatusStopped, manager.Status("config"))
+}
+
+// TestRequiredFailure 验证必需模块失败时停止已启动的模块并返回错误
+func TestRequiredFailure(t *testing.T) {
+	r := &recorder{}
+	manager := lifecycle.NewManager()
+	failing := r.module("database", "config")
+	failing.Init = r.fn("database.init", errors.New("connection refused"))
+	manager.MustRegister(r.module("config"), failing, r.module("web", "database"))
+
+	report, err := manager.Start(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+	assert.Equal(t, []string{"config.init", "config.start", "database.init", "config.stop"}, r.calls)
+
+	failed := report.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "database", failed[0].Name)
+	assert.Equal(t, lifecycle.PhaseInit, failed[0].Phase)
+}
+
+// TestOptionalFailure 验证可选模块失败时继续启动，依赖它的可选模块被跳过
+func TestOptionalFailure(t *testing.T) {
+	r := &recorder{}
+	manager := lifecycle.NewManager()
+	tunnel := r.module("tunnel")
+	tunnel.Optional = true
+	tunnel.Start = r.fn("tunnel.start", errors.New("port in use"))
+	tunnelClient := r.module("tunnelClient", "tunnel")
+	tunnelClient.Optional = true
+	manager.MustRegister(tunnel, tunnelClient, r.module("web"))
+
+	report, err := manager.Start(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, lifecycle.StatusFailed, manager.Status("tunnel"))
+	assert.Equal(t, lifecycle.StatusSkipped, manager.Status("tunnelClient"))
+	assert.Equal(t, lifecycle.StatusStarted, manager.Status("web"))
+	assert.Equal(t, lifecycle.PhaseStart, report.Modules[0].Phase)
+
+	r.calls = nil
+	manager.Stop(context.Background())
+	assert.Equal(t, []string{"web.stop"}, r.calls, "只停止已启动的模块")
+}
+
+// TestStopCollectsErrors 验证停止失败时继续停止其余模块，Err 汇总所有失败模块的错误
+func TestStopCollectsErrors(t *testing.T) {
+	r := &recorder{}
+	manager := lifecycle.NewManager()
+	errCache := errors.New("flush failed")
+	cache := r.module("cache", "config")
+	cache.Stop = r.fn("cache.stop", errCache)
+	web := r.module("web", "cache")
+	web.Stop = r.fn("web.stop", errors.New("listener busy"))
+	manager.MustRegister(r.module("config"), cache, web)
+
+	_, err := manager.Start(context.Background())
+	require.NoError(t, err)
+
+	r.calls = nil
+	report := manager.Stop(context.Background())
+	assert.Equal(t, []string{"web.stop", "cache.stop", "config.stop"}, r.calls)
+	err = report.Err()
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errCache))
+	assert.Contains(t, err.Error(), "listener busy")
+	assert.Contains(t, err.Error(), "模块 cache 停止失败")
+}
+
+// TestTimeout 验证阶段超时，超时时间可由解析函数按模块覆盖
+func TestTimeout(t *testing.T) {
+	manager := lifecycle.NewManager()
+	manager.SetDefaultTimeouts(time.Hour, 0)
+	manager.SetTimeoutResolver(func(module string, phase lifecycle.Phase) time.Duration {
+		if module == "slow" {
+			return 20 * time.Millisecond
+		}
+		return 0
+	})
+	manager.MustRegister(lifecycle.Module{
+		Name: "slow",
+		Init: func(ctx context.Context) error {
+			<-ctx.Done()
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		},
+	})
+
+	begin := time.Now()
+	report, err := manager.Start(context.Background())
+	require.Error(t, err)
+	assert.Less(t, time.Since(begin), 45*time.Millisecond)
+	assert.Contains(t, report.Modules[0].Error, "超时")
+}
+
+// TestInvalidDependencies 验证未注册的依赖、循环依赖和重复注册
+func TestInvalidDependencies(t *testing.T) {
+	r := &recorder{}
+	manager := lifecycle.NewManager()
+	manager.MustRegister(r.module("web", "database"))
+	_, err := manager.Start(context.Background())
+	assert.ErrorContains(t, err, "未注册")
+
+	manager = lifecycle.NewManager()
+	manager.MustRegister(r.module("a", "b"), r.module("b", "a"), r.module("c"))
+	_, err = manager.Start(context.Background())
+	assert.ErrorContains(t, err, "循环依赖")
+
+	assert.Error(t, manager.Register(r.module("c")))
+}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gateway/pkg/logger"
+)
+
+// TestWithFields 测试请求级日志字段自动附加到 *WithTrace 日志
+func TestWithFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fields.log")
+	if err := logger.Init(&logger.LoggerConfig{Level: "info", Encoding: "json", DefaultOutput: path}); err != nil {
+		t.Fatalf("初始化日志失败: %v", err)
+	}
+
+	ctx := logger.WithFields(context.Background(), "routeId", "route-1", "clientIp", "10.0.0.1")
+	ctx = logger.WithFields(ctx, "routeId", "route-2")
+	logger.InfoWithTrace(ctx, "with-fields", "clientIp", "10.0.0.2")
+	logger.Info("without-trace")
+
+	lines := strings.Split(strings.TrimSpace(readLog(t, path)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("应输出2行日志，实际 %d 行", len(lines))
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("日志不是合法JSON: %v", err)
+	}
+	if entry["routeId"] != "route-2" {
+		t.Errorf("后附加的同名字段应覆盖之前的值，实际 %v", entry["routeId"])
+	}
+	if entry["clientIp"] != "10.0.0.2" {
+		t.Errorf("调用点传入的字段应优先，实际 %v", entry["clientIp"])
+	}
+	if strings.Count(lines[0], `"clientIp"`) != 1 {
+		t.Errorf("同名字段不应重复输出: %s", lines[0])
+	}
+	if strings.Contains(lines[1], "routeId") {
+		t.Errorf("非 WithTrace 日志不应携带上下文字段")
+	}
+}
+
+// TestWithFieldsContextKey 测试日志字段不使用字符串键存入标准上下文
+func TestWithFieldsContextKey(t *testing.T) {
+	ctx := logger.WithFields(context.Background(), "routeId", "route-1")
+	if ctx.Value(logger.LogFieldsKey) != nil {
+		t.Errorf("标准上下文不应使用字符串键存放日志字段")
+	}
+	if fields := logger.ContextFields(ctx); len(fields) != 1 || fields[0].Key != "routeId" {
+		t.Errorf("应能读取附加的日志字段，实际 %v", fields)
+	}
+}
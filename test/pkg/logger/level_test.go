@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gateway/pkg/logger"
+)
+
+// initFileLogger 初始化输出到临时文件的日志，返回日志文件路径
+func initFileLogger(t *testing.T, level string) string {
+	t.Helper()
+	dir := t.TempDir()
+	err := logger.Init(&logger.LoggerConfig{
+		Level:         level,
+		Encoding:      "json",
+		DefaultOutput: filepath.Join(dir, "level.log"),
+	})
+	if err != nil {
+		t.Fatalf("初始化日志失败: %v", err)
+	}
+	t.Cleanup(logger.ResetAllModuleLevels)
+	return filepath.Join(dir, "level.log")
+}
+
+func readLog(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("读取日志文件失败: %v", err)
+	}
+	return string(data)
+}
+
+// TestSetLevel 测试运行时调整全局日志级别
+func TestSetLevel(t *testing.T) {
+	path := initFileLogger(t, "info")
+
+	logger.Debug("debug-before")
+	if err := logger.SetLevel("debug"); err != nil {
+		t.Fatalf("调整日志级别失败: %v", err)
+	}
+	logger.Debug("debug-after")
+
+	content := readLog(t, path)
+	if strings.Contains(content, "debug-before") {
+		t.Errorf("info级别下不应输出调试日志")
+	}
+	if !strings.Contains(content, "debug-after") {
+		t.Errorf("调整为debug后应输出调试日志")
+	}
+	if logger.GetLevel() != "debug" {
+		t.Errorf("全局级别应为debug，实际 %s", logger.GetLevel())
+	}
+
+	if err := logger.SetLevel("verbose"); err == nil {
+		t.Errorf("无效级别应返回错误")
+	}
+}
+
+// TestSetModuleLevel 测试按模块调整日志级别
+func TestSetModuleLevel(t *testing.T) {
+	path := initFileLogger(t, "info")
+
+	// 本测试包路径为 test/pkg/logger
+	if err := logger.SetModuleLevel("test.pkg", "debug"); err != nil {
+		t.Fatalf("调整模块日志级别失败: %v", err)
+	}
+	logger.Debug("module-debug")
+
+	if err := logger.SetModuleLevel("test/pkg/logger", "error"); err != nil {
+		t.Fatalf("调整模块日志级别失败: %v", err)
+	}
+	logger.Warn("module-warn")
+
+	logger.ResetModuleLevel("test/pkg/logger")
+	logger.Debug("parent-debug")
+
+	content := readLog(t, path)
+	if !strings.Contains(content, "module-debug") {
+		t.Errorf("模块级别为debug时应输出调试日志")
+	}
+	if strings.Contains(content, "module-warn") {
+		t.Errorf("更具体的模块级别为error时不应输出警告日志")
+	}
+	if !strings.Contains(content, "parent-debug") {
+		t.Errorf("重置后应继承父模块的debug级别")
+	}
+
+	levels := logger.GetModuleLevels()
+	if levels["test/pkg"] != "debug" || len(levels) != 1 {
+		t.Errorf("模块级别设置不符合预期: %v", levels)
+	}
+	if logger.GetLevel() != "info" {
+		t.Errorf("模块设置不应影响全局级别")
+	}
+}
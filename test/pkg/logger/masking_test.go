@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gateway/pkg/logger"
+)
+
+// TestMasking 测试日志输出前脱敏
+func TestMasking(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "masking.log")
+	err := logger.Init(&logger.LoggerConfig{
+		Level:         "info",
+		Encoding:      "json",
+		DefaultOutput: path,
+		Masking:       true,
+	})
+	if err != nil {
+		t.Fatalf("初始化日志失败: %v", err)
+	}
+
+	logger.Info("connect mysql://root:r00t@db:3306/gw", "password", "r00t", "user", "root")
+	logger.Error("连接数据库失败", errors.New("open root:r00t@tcp(db:3306)/gw failed"))
+	logger.Info("config", map[string]any{"db": map[string]interface{}{"password": "r00t"}})
+	logger.Info("usage", "tokenCount", 42)
+
+	content := readLog(t, path)
+	if strings.Contains(content, "r00t") {
+		t.Errorf("日志中不应出现密码: %s", content)
+	}
+	if !strings.Contains(content, `"user":"root"`) {
+		t.Errorf("非敏感字段应保持原样: %s", content)
+	}
+	if !strings.Contains(content, `"tokenCount":42`) {
+		t.Errorf("仅包含敏感关键字的键名不应脱敏: %s", content)
+	}
+}
+
+// TestMaskingDisabledByDefault 测试未开启脱敏时原样输出
+func TestMaskingDisabledByDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nomask.log")
+	err := logger.Init(&logger.LoggerConfig{
+		Level:         "info",
+		Encoding:      "json",
+		DefaultOutput: path,
+	})
+	if err != nil {
+		t.Fatalf("初始化日志失败: %v", err)
+	}
+
+	logger.Info("login", "password", "r00t")
+
+	if content := readLog(t, path); !strings.Contains(content, "r00t") {
+		t.Errorf("未开启脱敏时应原样输出: %s", content)
+	}
+}
+
+// TestMaskingLevelOutputs 测试脱敏后分级输出仍按级别过滤
+func TestMaskingLevelOutputs(t *testing.T) {
+	dir := t.TempDir()
+	err := logger.Init(&logger.LoggerConfig{
+		Level:         "info",
+		Encoding:      "json",
+		LogPath:       dir,
+		DefaultOutput: "gateway.log",
+		InfoOutput:    "info.log",
+		ErrorOutput:   "error.log",
+		Masking:       true,
+	})
+	if err != nil {
+		t.Fatalf("初始化日志失败: %v", err)
+	}
+
+	logger.Info("info-message", "password", "r00t")
+	logger.Error("error-message", "password", "r00t")
+
+	info := readLog(t, filepath.Join(dir, "info.log"))
+	errorLog := readLog(t, filepath.Join(dir, "error.log"))
+	if !strings.Contains(info, "info-message") || strings.Contains(info, "error-message") {
+		t.Errorf("info输出只应包含信息级别日志: %q", info)
+	}
+	if !strings.Contains(errorLog, "error-message") || strings.Contains(errorLog, "info-message") {
+		t.Errorf("error输出只应包含错误级别日志: %q", errorLog)
+	}
+	if strings.Contains(info+errorLog, "r00t") {
+		t.Errorf("分级输出同样应脱敏: %q %q", info, errorLog)
+	}
+}
@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gateway/pkg/logger"
+)
+
+// restoreLogger 测试结束后恢复为输出到标准输出的全局日志，避免后续日志写入已删除的临时目录
+func restoreLogger(t *testing.T) {
+	t.Cleanup(func() {
+		if err := logger.Init(&logger.LoggerConfig{Level: "info", Encoding: "json", DefaultOutput: "stdout"}); err != nil {
+			t.Errorf("恢复日志失败: %v", err)
+		}
+	})
+}
+
+// TestRotationPolicyPerOutput 测试按输出单独配置的轮转策略
+func TestRotationPolicyPerOutput(t *testing.T) {
+	dir := t.TempDir()
+	restoreLogger(t)
+	noCompress := false
+	err := logger.Init(&logger.LoggerConfig{
+		Level:         "info",
+		Encoding:      "json",
+		DefaultOutput: filepath.Join(dir, "gateway.log"),
+		ErrorOutput:   filepath.Join(dir, "error.log"),
+		MaxSize:       100,
+		Compress:      true,
+		Rotation: map[string]logger.RotationPolicy{
+			// 错误日志单独设置1MB轮转且不压缩
+			logger.OutputError: {MaxSize: 1, MaxBackups: 2, Compress: &noCompress},
+		},
+	})
+	if err != nil {
+		t.Fatalf("初始化日志失败: %v", err)
+	}
+
+	payload := strings.Repeat("x", 2048)
+	for i := 0; i < 700; i++ {
+		logger.Error("rotation-test", "payload", payload)
+	}
+
+	errorBackups, _ := filepath.Glob(filepath.Join(dir, "error-*.log"))
+	if len(errorBackups) == 0 {
+		t.Errorf("错误日志超过1MB后应轮转出未压缩的备份文件")
+	}
+	defaultBackups, _ := filepath.Glob(filepath.Join(dir, "gateway-*"))
+	if len(defaultBackups) != 0 {
+		t.Errorf("默认输出使用全局100MB策略，不应轮转: %v", defaultBackups)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "error.log")); err != nil {
+		t.Errorf("轮转后应继续写入当前错误日志文件: %v", err)
+	}
+}
+
+// TestTimedRotation 测试跨越整点和零点时按时间轮转
+func TestTimedRotation(t *testing.T) {
+	cases := []struct {
+		name     string
+		interval string
+		start    time.Time
+		same     time.Time // 与 start 处于同一周期
+		next     time.Time // 进入下一周期
+	}{
+		{
+			name:     "hourly",
+			interval: logger.RotateHourly,
+			start:    time.Date(2026, 10, 16, 10, 5, 0, 0, time.Local),
+			same:     time.Date(2026, 10, 16, 10, 59, 59, 0, time.Local),
+			next:     time.Date(2026, 10, 16, 11, 0, 0, 0, time.Local),
+		},
+		{
+			name:     "daily",
+			interval: logger.RotateDaily,
+			start:    time.Date(2026, 10, 16, 0, 30, 0, 0, time.Local),
+			same:     time.Date(2026, 10, 16, 23, 59, 0, 0, time.Local),
+			next:     time.Date(2026, 10, 17, 0, 1, 0, 0, time.Local),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := tc.start
+			logger.SetRotationClock(func() time.Time { return now })
+			t.Cleanup(func() { logger.SetRotationClock(nil) })
+			restoreLogger(t)
+
+			dir := t.TempDir()
+			err := logger.Init(&logger.LoggerConfig{
+				Level:          "info",
+				Encoding:       "json",
+				DefaultOutput:  filepath.Join(dir, "gateway.log"),
+				MaxSize:        100,
+				RotateInterval: tc.interval,
+			})
+			if err != nil {
+				t.Fatalf("初始化日志失败: %v", err)
+			}
+
+			logger.Info("timed-rotation", "phase", "start")
+			now = tc.same
+			logger.Info("timed-rotation", "phase", "same")
+			if backups, _ := filepath.Glob(filepath.Join(dir, "gateway-*.log")); len(backups) != 0 {
+				t.Fatalf("同一周期内不应轮转: %v", backups)
+			}
+
+			now = tc.next
+			logger.Info("timed-rotation", "phase", "next")
+			backups, _ := filepath.Glob(filepath.Join(dir, "gateway-*.log"))
+			if len(backups) != 1 {
+				t.Fatalf("进入下一周期应轮转出一个备份文件，实际: %v", backups)
+			}
+
+			current, err := os.ReadFile(filepath.Join(dir, "gateway.log"))
+			if err != nil {
+				t.Fatalf("读取当前日志文件失败: %v", err)
+			}
+			if !strings.Contains(string(current), `"phase":"next"`) || strings.Contains(string(current), `"phase":"start"`) {
+				t.Errorf("轮转后当前文件应只包含新周期的日志: %s", current)
+			}
+			backup, _ := os.ReadFile(backups[0])
+			if !strings.Contains(string(backup), `"phase":"same"`) {
+				t.Errorf("备份文件应包含上一周期的日志: %s", backup)
+			}
+		})
+	}
+}
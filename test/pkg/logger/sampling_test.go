@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gateway/pkg/logger"
+)
+
+// TestSampling 测试相同消息采样及抑制汇总
+func TestSampling(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sampling.log")
+	err := logger.Init(&logger.LoggerConfig{
+		Level:         "info",
+		Encoding:      "json",
+		DefaultOutput: path,
+		Sampling: &logger.SamplingConfig{
+			Enabled:    true,
+			Interval:   60,
+			Initial:    3,
+			Thereafter: 0,
+		},
+	})
+	if err != nil {
+		t.Fatalf("初始化日志失败: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		logger.Warn("backend-down")
+	}
+	logger.FlushSuppressed()
+
+	content := readLog(t, path)
+	if got := strings.Count(content, `"msg":"backend-down"`); got != 3 {
+		t.Errorf("采样后应只输出3条，实际 %d 条", got)
+	}
+	if !strings.Contains(content, `"similarMessage":"backend-down"`) || !strings.Contains(content, `"suppressed":7`) {
+		t.Errorf("应输出抑制汇总: %s", content)
+	}
+}
+
+// TestRateLimit 测试按键限流
+func TestRateLimit(t *testing.T) {
+	if !logger.RateLimit("test:heartbeat", time.Hour) {
+		t.Fatalf("首次调用应允许输出")
+	}
+	if logger.RateLimit("test:heartbeat", time.Hour) {
+		t.Errorf("周期内重复调用应被限流")
+	}
+	if !logger.RateLimit("test:other", time.Hour) {
+		t.Errorf("不同的键互不影响")
+	}
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gateway/pkg/logger"
+)
+
+// TestStructuredEncoding 测试结构化JSON输出包含跟踪字段
+func TestStructuredEncoding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "structured.log")
+	err := logger.Init(&logger.LoggerConfig{
+		Level:         "info",
+		Encoding:      logger.EncodingStructured,
+		DefaultOutput: path,
+	})
+	if err != nil {
+		t.Fatalf("初始化日志失败: %v", err)
+	}
+
+	ctx := logger.WithTraceID(context.Background(), "trace-001")
+	ctx = context.WithValue(ctx, logger.TenantIdKey, "tenant-a")
+	logger.InfoWithTrace(ctx, "structured-message", "routeId", "route-1")
+
+	lines := strings.Split(strings.TrimSpace(readLog(t, path)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("应输出一行日志，实际 %d 行", len(lines))
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("日志不是合法JSON: %v, %s", err, lines[0])
+	}
+
+	expected := map[string]string{
+		"level":    "info",
+		"message":  "structured-message",
+		"traceId":  "trace-001",
+		"tenantId": "tenant-a",
+		"module":   "test/pkg/logger",
+		"routeId":  "route-1",
+	}
+	for key, want := range expected {
+		if got, _ := entry[key].(string); got != want {
+			t.Errorf("字段 %s: 期望 %q，实际 %q", key, want, got)
+		}
+	}
+	for _, key := range []string{"timestamp", "caller"} {
+		if _, ok := entry[key]; !ok {
+			t.Errorf("缺少字段 %s", key)
+		}
+	}
+}
+
+// TestStructuredEncodingLevelOutputs 测试结构化模式下分级输出仍按级别过滤
+func TestStructuredEncodingLevelOutputs(t *testing.T) {
+	dir := t.TempDir()
+	err := logger.Init(&logger.LoggerConfig{
+		Level:         "info",
+		Encoding:      logger.EncodingStructured,
+		LogPath:       dir,
+		DefaultOutput: "gateway.log",
+		InfoOutput:    "info.log",
+		ErrorOutput:   "error.log",
+	})
+	if err != nil {
+		t.Fatalf("初始化日志失败: %v", err)
+	}
+
+	logger.Info("info-message")
+	logger.Error("error-message")
+
+	info := readLog(t, filepath.Join(dir, "info.log"))
+	errorLog := readLog(t, filepath.Join(dir, "error.log"))
+	if !strings.Contains(info, "info-message") || strings.Contains(info, "error-message") {
+		t.Errorf("info输出只应包含信息级别日志: %q", info)
+	}
+	if !strings.Contains(errorLog, "error-message") || strings.Contains(errorLog, "info-message") {
+		t.Errorf("error输出只应包含错误级别日志: %q", errorLog)
+	}
+	if !strings.Contains(errorLog, `"module":"test/pkg/logger"`) {
+		t.Errorf("分级输出同样应包含module字段: %q", errorLog)
+	}
+}
@@ -0,0 +1,124 @@
+package logger
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gateway/pkg/logger"
+)
+
+// TestTenantSegregation 测试带 tenantId 的日志写入租户专属输出
+func TestTenantSegregation(t *testing.T) {
+	dir := t.TempDir()
+	err := logger.Init(&logger.LoggerConfig{
+		Level:         "info",
+		Encoding:      "json",
+		LogPath:       dir,
+		DefaultOutput: "gateway.log",
+		TenantSegregation: &logger.TenantSegregationConfig{
+			Enabled:       true,
+			Tenants:       map[string]string{"tenant-a": "a.log"},
+			DefaultOutput: "tenants/{tenantId}.log",
+			MaxTenants:    1,
+		},
+		Masking: true,
+	})
+	if err != nil {
+		t.Fatalf("初始化日志失败: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), logger.TenantIdKey, "tenant-a")
+	logger.InfoWithTrace(ctx, "from-context", "password", "secret")
+	logger.Info("from-args", "tenantId", "tenant-b")
+	logger.Info("over-limit", "tenantId", "../tenant-c")
+	logger.Info("shared")
+
+	shared := readLog(t, filepath.Join(dir, "gateway.log"))
+	tenantA := readLog(t, filepath.Join(dir, "a.log"))
+	tenantB := readLog(t, filepath.Join(dir, "tenants", "tenant-b.log"))
+
+	if !strings.Contains(tenantA, "from-context") {
+		t.Errorf("上下文中的租户日志应写入租户输出: %q", tenantA)
+	}
+	if strings.Contains(tenantA, "secret") {
+		t.Errorf("租户输出同样应脱敏: %q", tenantA)
+	}
+	if !strings.Contains(tenantB, "from-args") {
+		t.Errorf("未配置映射的租户应按模板写入: %q", tenantB)
+	}
+	if strings.Contains(shared, "from-context") || strings.Contains(shared, "from-args") {
+		t.Errorf("租户日志不应写入默认输出: %q", shared)
+	}
+	if !strings.Contains(shared, "shared") || !strings.Contains(shared, "over-limit") {
+		t.Errorf("无租户和超过模板上限的日志应写入默认输出: %q", shared)
+	}
+}
+
+// TestTenantSegregationKeepInDefault 测试租户日志同时保留在默认输出
+func TestTenantSegregationKeepInDefault(t *testing.T) {
+	dir := t.TempDir()
+	err := logger.Init(&logger.LoggerConfig{
+		Level:         "info",
+		Encoding:      "json",
+		LogPath:       dir,
+		DefaultOutput: "gateway.log",
+		TenantSegregation: &logger.TenantSegregationConfig{
+			Enabled:       true,
+			Tenants:       map[string]string{"tenant-a": "a.log"},
+			KeepInDefault: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("初始化日志失败: %v", err)
+	}
+
+	logger.Info("both", "tenantId", "tenant-a")
+	logger.Info("unmapped", "tenantId", "tenant-b")
+
+	shared := readLog(t, filepath.Join(dir, "gateway.log"))
+	tenantA := readLog(t, filepath.Join(dir, "a.log"))
+	if !strings.Contains(tenantA, "both") || !strings.Contains(shared, "both") {
+		t.Errorf("租户日志应同时写入租户输出和默认输出: tenant=%q shared=%q", tenantA, shared)
+	}
+	if !strings.Contains(shared, "unmapped") {
+		t.Errorf("没有租户输出的租户日志应写入默认输出: %q", shared)
+	}
+}
+
+// TestTenantSegregationLevelOutputs 测试启用租户分离后默认输出仍按级别过滤
+func TestTenantSegregationLevelOutputs(t *testing.T) {
+	dir := t.TempDir()
+	err := logger.Init(&logger.LoggerConfig{
+		Level:         "info",
+		Encoding:      "json",
+		LogPath:       dir,
+		DefaultOutput: "gateway.log",
+		InfoOutput:    "info.log",
+		ErrorOutput:   "error.log",
+		TenantSegregation: &logger.TenantSegregationConfig{
+			Enabled: true,
+			Tenants: map[string]string{"tenant-a": "a.log"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("初始化日志失败: %v", err)
+	}
+
+	logger.Info("untagged-info")
+	logger.Error("untagged-error")
+	logger.Info("tenant-info", "tenantId", "tenant-a")
+
+	info := readLog(t, filepath.Join(dir, "info.log"))
+	errorLog := readLog(t, filepath.Join(dir, "error.log"))
+	if !strings.Contains(info, "untagged-info") || strings.Contains(info, "untagged-error") {
+		t.Errorf("info输出只应包含信息级别日志: %q", info)
+	}
+	if !strings.Contains(errorLog, "untagged-error") || strings.Contains(errorLog, "untagged-info") {
+		t.Errorf("error输出只应包含错误级别日志: %q", errorLog)
+	}
+	if strings.Contains(info, "tenant-info") {
+		t.Errorf("租户日志不应写入默认输出: %q", info)
+	}
+}
@@ -0,0 +1,89 @@
+package security
+
+import (
+	"crypto"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"gateway/pkg/security"
+)
+
+// TestSignData 测试RSA与ECDSA签名校验
+func TestSignData(t *testing.T) {
+	rsaKey, err := security.GenerateRSAKeyPair(2048)
+	if err != nil {
+		t.Fatalf("生成RSA密钥失败: %v", err)
+	}
+	ecKey, err := security.GenerateECDSAKeyPair("P-256")
+	if err != nil {
+		t.Fatalf("生成ECDSA密钥失败: %v", err)
+	}
+
+	for name, key := range map[string]crypto.Signer{"RSA": rsaKey, "ECDSA": ecKey} {
+		t.Run(name, func(t *testing.T) {
+			signature, err := security.SignData(key, []byte("payload"))
+			if err != nil {
+				t.Fatalf("签名失败: %v", err)
+			}
+			if err := security.VerifySignature(key.Public(), []byte("payload"), signature); err != nil {
+				t.Errorf("校验签名失败: %v", err)
+			}
+			if err := security.VerifySignature(key.Public(), []byte("tampered"), signature); !errors.Is(err, security.ErrSignatureMismatch) {
+				t.Errorf("篡改数据应校验失败，实际 %v", err)
+			}
+		})
+	}
+}
+
+// TestKeyPEMFiles 测试PEM保存与加载
+func TestKeyPEMFiles(t *testing.T) {
+	ecKey, _ := security.GenerateECDSAKeyPair("")
+	dir := t.TempDir()
+	privPath := filepath.Join(dir, "ec.key")
+	pubPath := filepath.Join(dir, "ec.pub")
+
+	if err := security.SavePrivateKeyFile(privPath, ecKey); err != nil {
+		t.Fatalf("保存私钥失败: %v", err)
+	}
+	if err := security.SavePublicKeyFile(pubPath, &ecKey.PublicKey); err != nil {
+		t.Fatalf("保存公钥失败: %v", err)
+	}
+
+	loaded, err := security.LoadPrivateKeyFile(privPath)
+	if err != nil {
+		t.Fatalf("加载私钥失败: %v", err)
+	}
+	if !ecKey.Equal(loaded) {
+		t.Errorf("加载的私钥与原私钥不一致")
+	}
+	pub, err := security.LoadPublicKeyFile(pubPath)
+	if err != nil {
+		t.Fatalf("加载公钥失败: %v", err)
+	}
+	if !ecKey.PublicKey.Equal(pub) {
+		t.Errorf("加载的公钥与原公钥不一致")
+	}
+
+	if _, err := security.ParsePrivateKeyPEM([]byte("not pem")); err == nil {
+		t.Errorf("无效PEM应返回错误")
+	}
+}
+
+// TestRSAEncrypt 测试RSA-OAEP加解密
+func TestRSAEncrypt(t *testing.T) {
+	key, _ := security.GenerateRSAKeyPair(2048)
+	ciphertext, err := security.RSAEncrypt(&key.PublicKey, []byte("data-key"))
+	if err != nil {
+		t.Fatalf("加密失败: %v", err)
+	}
+	plaintext, err := security.RSADecrypt(key, ciphertext)
+	if err != nil || string(plaintext) != "data-key" {
+		t.Errorf("解密失败: %v", err)
+	}
+
+	other, _ := security.GenerateRSAKeyPair(2048)
+	if _, err := security.RSADecrypt(other, ciphertext); err == nil {
+		t.Errorf("错误私钥解密应失败")
+	}
+}
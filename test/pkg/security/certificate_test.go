@@ -0,0 +1,114 @@
+package security
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gateway/pkg/security"
+)
+
+// TestIssueCertificate 测试CA生成及服务端、客户端证书签发
+func TestIssueCertificate(t *testing.T) {
+	ca, err := security.GenerateCA(security.CertificateOptions{})
+	if err != nil {
+		t.Fatalf("生成CA失败: %v", err)
+	}
+	if !ca.Certificate.IsCA {
+		t.Fatalf("CA证书应标记为CA")
+	}
+
+	server, err := security.IssueCertificate(ca, security.CertUsageServer, security.CertificateOptions{
+		DNSNames:    []string{"gateway.local"},
+		IPAddresses: []string{"127.0.0.1"},
+		KeyType:     security.KeyTypeRSA,
+	})
+	if err != nil {
+		t.Fatalf("签发服务端证书失败: %v", err)
+	}
+	if server.Certificate.Subject.CommonName != "gateway.local" {
+		t.Errorf("CommonName应默认使用第一个域名: %s", server.Certificate.Subject.CommonName)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate)
+	if _, err := server.Certificate.Verify(x509.VerifyOptions{DNSName: "gateway.local", Roots: roots}); err != nil {
+		t.Errorf("服务端证书校验失败: %v", err)
+	}
+	if _, err := tls.X509KeyPair(server.CertPEM, server.KeyPEM); err != nil {
+		t.Errorf("证书与私钥应可用于TLS: %v", err)
+	}
+
+	client, err := security.IssueCertificate(ca, security.CertUsageClient, security.CertificateOptions{CommonName: "node-1"})
+	if err != nil {
+		t.Fatalf("签发客户端证书失败: %v", err)
+	}
+	if _, err := client.Certificate.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		t.Errorf("客户端证书校验失败: %v", err)
+	}
+
+	if _, err := security.IssueCertificate(server, security.CertUsageServer, security.CertificateOptions{}); err == nil {
+		t.Errorf("非CA证书不能签发证书")
+	}
+}
+
+// TestSignCSR 测试CSR签发
+func TestSignCSR(t *testing.T) {
+	ca, _ := security.GenerateCA(security.CertificateOptions{})
+	key, _ := security.GenerateECDSAKeyPair("P256")
+	csr, err := security.CreateCSR(key, security.CertificateOptions{CommonName: "svc", DNSNames: []string{"svc.internal"}})
+	if err != nil {
+		t.Fatalf("创建CSR失败: %v", err)
+	}
+
+	bundle, err := security.SignCSR(ca, csr, security.CertUsageServer, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("签发CSR失败: %v", err)
+	}
+	if bundle.PrivateKey != nil || bundle.Certificate.DNSNames[0] != "svc.internal" {
+		t.Errorf("CSR签发结果错误")
+	}
+	if !key.PublicKey.Equal(bundle.Certificate.PublicKey) {
+		t.Errorf("证书公钥应与CSR一致")
+	}
+}
+
+// TestCertificateInfo 测试证书解析与到期天数
+func TestCertificateInfo(t *testing.T) {
+	ca, _ := security.GenerateCA(security.CertificateOptions{})
+	cert, _ := security.IssueCertificate(ca, security.CertUsageServer, security.CertificateOptions{
+		DNSNames: []string{"a.local"},
+		ValidFor: 10*24*time.Hour + time.Hour,
+	})
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	if err := security.SaveCertificateBundle(cert, certFile, keyFile); err != nil {
+		t.Fatalf("保存证书失败: %v", err)
+	}
+
+	// 证书链：叶子证书 + CA
+	chain := append(append([]byte{}, cert.CertPEM...), ca.CertPEM...)
+	certs, err := security.ParseCertificatesPEM(chain)
+	if err != nil || len(certs) != 2 {
+		t.Fatalf("解析证书链失败: %v", err)
+	}
+
+	info := security.GetCertificateInfo(certs[0])
+	if info.DaysUntilExpiry != 10 || info.Expired || info.IsCA {
+		t.Errorf("证书信息错误: %+v", info)
+	}
+	if len(info.Fingerprint) != 95 {
+		t.Errorf("指纹格式错误: %s", info.Fingerprint)
+	}
+
+	if _, err := security.LoadCertificateBundle(cert.CertPEM, ca.KeyPEM); err == nil {
+		t.Errorf("私钥与证书不匹配应返回错误")
+	}
+}
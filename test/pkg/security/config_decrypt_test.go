@@ -0,0 +1,59 @@
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gateway/pkg/config"
+	"gateway/pkg/security"
+)
+
+// TestLoadConfigFile_DecryptValue 测试加载配置时自动解密 ENCY_ 配置项
+func TestLoadConfigFile_DecryptValue(t *testing.T) {
+	ciphertext, err := security.EncryptWithDefaultKey("db-secret")
+	if err != nil {
+		t.Fatalf("加密失败: %v", err)
+	}
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "decrypt_value.yaml")
+	content := "decrypt_test:\n  password: \"" + ciphertext + "\"\n  plain: \"plain-value\"\n"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("写入配置文件失败: %v", err)
+	}
+
+	if err := config.LoadConfigFile(filePath); err != nil {
+		t.Fatalf("加载配置文件失败: %v", err)
+	}
+
+	if got := config.GetString("decrypt_test.password", ""); got != "db-secret" {
+		t.Errorf("密码未被解密: 期望 db-secret，实际 %s", got)
+	}
+	if got := config.GetString("decrypt_test.plain", ""); got != "plain-value" {
+		t.Errorf("明文配置被修改: 期望 plain-value，实际 %s", got)
+	}
+}
+
+// TestLoadConfigFile_DecryptWholeFile 测试整文件加密的配置文件
+func TestLoadConfigFile_DecryptWholeFile(t *testing.T) {
+	plain := "decrypt_file_test:\n  name: \"whole-file\"\n"
+	ciphertext, err := security.EncryptWithDefaultKey(plain)
+	if err != nil {
+		t.Fatalf("加密失败: %v", err)
+	}
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "decrypt_file.yaml")
+	if err := os.WriteFile(filePath, []byte(ciphertext), 0644); err != nil {
+		t.Fatalf("写入配置文件失败: %v", err)
+	}
+
+	if err := config.LoadConfigFile(filePath); err != nil {
+		t.Fatalf("加载加密配置文件失败: %v", err)
+	}
+
+	if got := config.GetString("decrypt_file_test.name", ""); got != "whole-file" {
+		t.Errorf("整文件解密失败: 期望 whole-file，实际 %s", got)
+	}
+}
@@ -38,7 +38,10 @@ func TestGenerateSecretKey(t *testing.T) {
 // TestGetDefaultEncryptionKey 测试获取默认密钥
 func TestGetDefaultEncryptionKey(t *testing.T) {
 	// 测试获取默认密钥
-	key := security.GetDefaultEncryptionKey()
+	key, err := security.GetDefaultEncryptionKey()
+	if err != nil {
+		t.Fatalf("获取默认密钥失败: %v", err)
+	}
 	if key == "" {
 		t.Error("默认密钥不能为空")
 	}
@@ -155,7 +158,10 @@ func TestEncryptDecryptBytes_WithDefaultKey(t *testing.T) {
 // TestDefaultKey_ConfigOverride 测试配置覆盖默认密钥
 func TestDefaultKey_ConfigOverride(t *testing.T) {
 	// 测试配置中的密钥会覆盖默认值
-	originalKey := security.GetDefaultEncryptionKey()
+	originalKey, err := security.GetDefaultEncryptionKey()
+	if err != nil {
+		t.Fatalf("获取默认密钥失败: %v", err)
+	}
 
 	// 使用默认密钥加密
 	plaintext := "Test Message"
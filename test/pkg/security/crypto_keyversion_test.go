@@ -0,0 +1,88 @@
+package security
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"gateway/pkg/security"
+)
+
+// TestEncryptWithKeyVersion 测试带密钥版本的加密格式
+func TestEncryptWithKeyVersion(t *testing.T) {
+	t.Setenv(security.EncryptionKeyEnv+"_V2", "key-version-2")
+
+	ciphertext, err := security.EncryptWithKeyVersion(2, []byte("versioned-secret"))
+	if err != nil {
+		t.Fatalf("加密失败: %v", err)
+	}
+
+	data, err := security.EncryptedDataFromString(ciphertext)
+	if err != nil {
+		t.Fatalf("解析密文失败: %v", err)
+	}
+	if data.Version != security.AESGCMKeyedVersion || data.KeyVersion != 2 {
+		t.Errorf("密文头不正确: version=%d keyVersion=%d", data.Version, data.KeyVersion)
+	}
+
+	// 按密文中的密钥版本自动选择密钥
+	plaintext, err := security.DecryptWithDefaultKey(ciphertext)
+	if err != nil {
+		t.Fatalf("解密失败: %v", err)
+	}
+	if plaintext != "versioned-secret" {
+		t.Errorf("解密结果不匹配: %s", plaintext)
+	}
+
+	// 使用对应密钥也可以直接解密
+	plaintext, err = security.AESDecryptFromString("key-version-2", ciphertext)
+	if err != nil || plaintext != "versioned-secret" {
+		t.Errorf("使用版本密钥直接解密失败: %v", err)
+	}
+}
+
+// TestDecryptWithDefaultKey_Legacy 测试不带密钥版本的旧密文仍可解密
+func TestDecryptWithDefaultKey_Legacy(t *testing.T) {
+	defaultKey, err := security.GetDefaultEncryptionKey()
+	if err != nil {
+		t.Fatalf("获取默认密钥失败: %v", err)
+	}
+	legacy, err := security.AESEncryptToString(defaultKey, "legacy-secret")
+	if err != nil {
+		t.Fatalf("加密失败: %v", err)
+	}
+
+	plaintext, err := security.DecryptWithDefaultKey(legacy)
+	if err != nil {
+		t.Fatalf("旧密文解密失败: %v", err)
+	}
+	if plaintext != "legacy-secret" {
+		t.Errorf("解密结果不匹配: %s", plaintext)
+	}
+}
+
+// TestKeyVersion_Tampered 测试篡改密钥版本后无法解密
+func TestKeyVersion_Tampered(t *testing.T) {
+	t.Setenv(security.EncryptionKeyEnv+"_V3", "same-key")
+	t.Setenv(security.EncryptionKeyEnv+"_V4", "same-key")
+
+	ciphertext, err := security.EncryptWithKeyVersion(3, []byte("tamper-test"))
+	if err != nil {
+		t.Fatalf("加密失败: %v", err)
+	}
+
+	raw, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(ciphertext, security.EncryptedPrefix))
+	raw[2] = 4 // 密钥版本低字节 3 -> 4，两个版本密钥相同
+	tampered := security.EncryptedPrefix + base64.StdEncoding.EncodeToString(raw)
+
+	if _, err := security.DecryptWithDefaultKey(tampered); err == nil {
+		t.Errorf("密钥版本参与认证，篡改后应解密失败")
+	}
+}
+
+// TestKeyVersion_Unknown 测试未配置的密钥版本
+func TestKeyVersion_Unknown(t *testing.T) {
+	if _, err := security.EncryptWithKeyVersion(999, []byte("x")); err == nil {
+		t.Errorf("未配置的密钥版本应返回错误")
+	}
+}
@@ -0,0 +1,117 @@
+package security
+
+import (
+	"crypto"
+	"strings"
+	"testing"
+	"time"
+
+	"gateway/pkg/security"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// TestJWTSigner_HMAC 测试HMAC签发与校验
+func TestJWTSigner_HMAC(t *testing.T) {
+	signer, err := security.NewHMACJWTSigner("HS256", "secret", security.JWTOptions{
+		Issuer:       "gateway",
+		TTL:          time.Hour,
+		VerifyIssuer: true,
+	})
+	if err != nil {
+		t.Fatalf("创建签发器失败: %v", err)
+	}
+
+	token, err := signer.Issue("user-1", map[string]interface{}{"tenantId": "t1"})
+	if err != nil {
+		t.Fatalf("签发失败: %v", err)
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := signer.Parse(token, claims); err != nil {
+		t.Fatalf("校验失败: %v", err)
+	}
+	if claims["sub"] != "user-1" || claims["tenantId"] != "t1" || claims["iss"] != "gateway" {
+		t.Errorf("声明错误: %v", claims)
+	}
+
+	other, _ := security.NewHMACJWTSigner("HS256", "secret", security.JWTOptions{Issuer: "other", VerifyIssuer: true})
+	if _, err := other.Parse(token, jwt.MapClaims{}); err == nil {
+		t.Errorf("签发者不符应校验失败")
+	}
+}
+
+// TestJWTSigner_Asymmetric 测试RS256、PS256、ES256签发及公钥校验
+func TestJWTSigner_Asymmetric(t *testing.T) {
+	rsaKey, _ := security.GenerateRSAKeyPair(2048)
+	ecKey, _ := security.GenerateECDSAKeyPair("P256")
+
+	cases := []struct {
+		alg string
+		key crypto.Signer
+	}{
+		{"RS256", rsaKey},
+		{"PS256", rsaKey},
+		{"ES256", ecKey},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.alg, func(t *testing.T) {
+			signer, err := security.NewJWTSigner(tc.alg, tc.key, security.JWTOptions{TTL: time.Minute, KeyID: "k1"})
+			if err != nil {
+				t.Fatalf("创建签发器失败: %v", err)
+			}
+			token, err := signer.Issue("svc", nil)
+			if err != nil {
+				t.Fatalf("签发失败: %v", err)
+			}
+
+			pemData, _ := security.EncodePublicKeyPEM(tc.key.Public())
+			pub, err := security.ParsePublicKeyPEM(pemData)
+			if err != nil {
+				t.Fatalf("解析公钥失败: %v", err)
+			}
+			verifier, err := security.NewJWTVerifier(tc.alg, pub, security.JWTOptions{})
+			if err != nil {
+				t.Fatalf("创建校验器失败: %v", err)
+			}
+			parsed, err := verifier.Parse(token, jwt.MapClaims{})
+			if err != nil {
+				t.Fatalf("校验失败: %v", err)
+			}
+			if parsed.Header["kid"] != "k1" {
+				t.Errorf("kid错误: %v", parsed.Header["kid"])
+			}
+			if _, err := verifier.Issue("x", nil); err == nil {
+				t.Errorf("校验器不应能签发令牌")
+			}
+		})
+	}
+}
+
+// TestJWTSigner_Rejections 测试算法混淆、过期与密钥类型不匹配
+func TestJWTSigner_Rejections(t *testing.T) {
+	rsaKey, _ := security.GenerateRSAKeyPair(2048)
+	if _, err := security.NewJWTSigner("ES256", rsaKey, security.JWTOptions{}); err == nil {
+		t.Errorf("算法与密钥类型不匹配应返回错误")
+	}
+	if _, err := security.JWTSigningMethod("none"); err == nil {
+		t.Errorf("none算法应被拒绝")
+	}
+
+	hs, _ := security.NewHMACJWTSigner("HS256", "secret", security.JWTOptions{})
+	hs512, _ := security.NewHMACJWTSigner("HS512", "secret", security.JWTOptions{})
+	token, _ := hs512.Issue("u", nil)
+	if _, err := hs.Parse(token, jwt.MapClaims{}); err == nil {
+		t.Errorf("非预期算法的令牌应被拒绝")
+	}
+
+	expired, _ := hs.Sign(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()})
+	if _, err := hs.Parse(expired, jwt.MapClaims{}); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("过期令牌应被拒绝，实际 %v", err)
+	}
+	skip, _ := security.NewHMACJWTSigner("HS256", "secret", security.JWTOptions{SkipClaimsValidation: true})
+	if _, err := skip.Parse(expired, jwt.MapClaims{}); err != nil {
+		t.Errorf("跳过时间校验时过期令牌应通过: %v", err)
+	}
+}
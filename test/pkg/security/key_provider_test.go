@@ -0,0 +1,161 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gateway/pkg/security"
+)
+
+// fakeKeyProvider 测试用密钥提供者
+type fakeKeyProvider struct {
+	key   string
+	err   error
+	calls int32
+}
+
+func (p *fakeKeyProvider) Name() string { return "fake" }
+
+func (p *fakeKeyProvider) GetKey(_ context.Context, _ uint16) (string, error) {
+	atomic.AddInt32(&p.calls, 1)
+	return p.key, p.err
+}
+
+// TestSetKeyProvider 测试默认密钥加解密使用自定义密钥提供者
+func TestSetKeyProvider(t *testing.T) {
+	provider := &fakeKeyProvider{key: "provider-key"}
+	security.SetKeyProvider(provider)
+	defer security.SetKeyProvider(nil)
+
+	ciphertext, err := security.EncryptWithDefaultKey("secret")
+	if err != nil {
+		t.Fatalf("加密失败: %v", err)
+	}
+	if plaintext, err := security.AESDecryptFromString("provider-key", ciphertext); err != nil || plaintext != "secret" {
+		t.Errorf("密文应使用提供者的密钥加密: %v", err)
+	}
+
+	provider.err = errors.New("unavailable")
+	if _, err := security.EncryptWithDefaultKey("secret"); err == nil {
+		t.Errorf("提供者不可用时加密应失败")
+	}
+	if key, err := security.GetDefaultEncryptionKey(); !errors.Is(err, provider.err) || key != "" {
+		t.Errorf("提供者不可用时应返回提供者的错误: key=%q, err=%v", key, err)
+	}
+}
+
+// TestCachedKeyProvider 测试缓存与刷新失败时使用旧密钥
+func TestCachedKeyProvider(t *testing.T) {
+	provider := &fakeKeyProvider{key: "cached-key"}
+	cached := security.NewCachedKeyProvider(provider, 50*time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		if key, err := cached.GetKey(context.Background(), 0); err != nil || key != "cached-key" {
+			t.Fatalf("获取密钥失败: %v", err)
+		}
+	}
+	if provider.calls != 1 {
+		t.Errorf("缓存期内应只获取一次，实际 %d 次", provider.calls)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	provider.err = errors.New("unavailable")
+	if key, err := cached.GetKey(context.Background(), 0); err != nil || key != "cached-key" {
+		t.Errorf("刷新失败时应继续使用缓存: key=%s err=%v", key, err)
+	}
+
+	if _, err := cached.GetKey(context.Background(), 1); err == nil {
+		t.Errorf("无缓存且获取失败时应返回错误")
+	}
+}
+
+// TestVaultKeyProvider 测试从Vault KV v2读取密钥
+func TestVaultKeyProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test-token" || r.URL.Path != "/v1/secret/data/gateway" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"data": map[string]interface{}{
+					"encryption_key":    "vault-key",
+					"encryption_key_v2": "vault-key-2",
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	provider, err := security.NewKeyProvider(security.KeyProviderConfig{
+		Type:  security.KeyProviderVault,
+		Vault: security.VaultKeyConfig{Address: server.URL, Token: "test-token", Path: "secret/data/gateway"},
+	})
+	if err != nil {
+		t.Fatalf("创建提供者失败: %v", err)
+	}
+
+	if key, err := provider.GetKey(context.Background(), 0); err != nil || key != "vault-key" {
+		t.Errorf("版本0密钥错误: key=%s err=%v", key, err)
+	}
+	if key, err := provider.GetKey(context.Background(), 2); err != nil || key != "vault-key-2" {
+		t.Errorf("版本2密钥错误: key=%s err=%v", key, err)
+	}
+	if _, err := provider.GetKey(context.Background(), 3); err == nil {
+		t.Errorf("不存在的版本应返回错误")
+	}
+}
+
+// TestAWSKMSKeyProvider 测试调用AWS KMS Decrypt
+func TestAWSKMSKeyProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDTEST/") ||
+			r.Header.Get("X-Amz-Target") != "TrentService.Decrypt" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		if req["CiphertextBlob"] != "Y2lwaGVy" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"Plaintext": "a21zLWtleQ=="})
+	}))
+	defer server.Close()
+
+	provider, err := security.NewKeyProvider(security.KeyProviderConfig{
+		Type: security.KeyProviderAWSKMS,
+		AWSKMS: security.AWSKMSKeyConfig{
+			Region:          "us-east-1",
+			Endpoint:        server.URL,
+			Ciphertext:      "Y2lwaGVy",
+			AccessKeyID:     "AKIDTEST",
+			SecretAccessKey: "secret",
+		},
+	})
+	if err != nil {
+		t.Fatalf("创建提供者失败: %v", err)
+	}
+	if key, err := provider.GetKey(context.Background(), 0); err != nil || key != "a21zLWtleQ==" {
+		t.Errorf("KMS密钥错误: key=%s err=%v", key, err)
+	}
+}
+
+// TestNewKeyProvider_InvalidConfig 测试无效配置
+func TestNewKeyProvider_InvalidConfig(t *testing.T) {
+	if _, err := security.NewKeyProvider(security.KeyProviderConfig{Type: "unknown"}); err == nil {
+		t.Errorf("不支持的类型应返回错误")
+	}
+	if _, err := security.NewKeyProvider(security.KeyProviderConfig{Type: security.KeyProviderGCPKMS}); err == nil {
+		t.Errorf("缺少key_name应返回错误")
+	}
+}
@@ -0,0 +1,83 @@
+package security
+
+import (
+	"testing"
+
+	"gateway/pkg/security"
+)
+
+// TestRotateKey_Reencrypt 测试使用新密钥重新加密
+func TestRotateKey_Reencrypt(t *testing.T) {
+	oldCiphertext, err := security.AESEncryptToString("old-key", "rotate-me")
+	if err != nil {
+		t.Fatalf("加密失败: %v", err)
+	}
+
+	rotation, err := security.RotateKey("old-key", "new-key")
+	if err != nil {
+		t.Fatalf("创建密钥轮换失败: %v", err)
+	}
+
+	newCiphertext, changed, err := rotation.Reencrypt(oldCiphertext)
+	if err != nil || !changed {
+		t.Fatalf("重新加密失败: changed=%v err=%v", changed, err)
+	}
+
+	plaintext, err := security.AESDecryptFromString("new-key", newCiphertext)
+	if err != nil || plaintext != "rotate-me" {
+		t.Errorf("新密钥解密失败: %v", err)
+	}
+
+	// 已轮换的密文再次处理时保持不变
+	again, changed, err := rotation.Reencrypt(newCiphertext)
+	if err != nil || changed || again != newCiphertext {
+		t.Errorf("已轮换的密文应跳过: changed=%v err=%v", changed, err)
+	}
+
+	// 明文保持不变
+	plain, changed, err := rotation.Reencrypt("plain-value")
+	if err != nil || changed || plain != "plain-value" {
+		t.Errorf("明文应保持不变")
+	}
+
+	// 新旧密钥都无法解密时返回错误
+	foreign, _ := security.AESEncryptToString("other-key", "x")
+	if _, _, err := rotation.Reencrypt(foreign); err == nil {
+		t.Errorf("无法解密的密文应返回错误")
+	}
+}
+
+// TestRotateToKeyVersion 测试轮换到版本化密钥
+func TestRotateToKeyVersion(t *testing.T) {
+	t.Setenv(security.EncryptionKeyEnv+"_V7", "key-version-7")
+
+	defaultKey, err := security.GetDefaultEncryptionKey()
+	if err != nil {
+		t.Fatalf("获取默认密钥失败: %v", err)
+	}
+	legacy, _ := security.AESEncryptToString(defaultKey, "versioned")
+	rotation, err := security.RotateToKeyVersion(defaultKey, 7)
+	if err != nil {
+		t.Fatalf("创建密钥轮换失败: %v", err)
+	}
+
+	values, changed, err := rotation.ReencryptValues([]string{legacy, "plain"})
+	if err != nil || changed != 1 {
+		t.Fatalf("批量重新加密失败: changed=%d err=%v", changed, err)
+	}
+
+	data, _ := security.EncryptedDataFromString(values[0])
+	if data.Version != security.AESGCMKeyedVersion || data.KeyVersion != 7 {
+		t.Errorf("新密文应记录密钥版本7: version=%d keyVersion=%d", data.Version, data.KeyVersion)
+	}
+	if plaintext, err := security.DecryptWithDefaultKey(values[0]); err != nil || plaintext != "versioned" {
+		t.Errorf("按密钥版本解密失败: %v", err)
+	}
+}
+
+// TestRotateKey_SameKey 测试新旧密钥相同
+func TestRotateKey_SameKey(t *testing.T) {
+	if _, err := security.RotateKey("same", "same"); err != security.ErrSameRotationKey {
+		t.Errorf("新旧密钥相同应返回 ErrSameRotationKey，实际 %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package security
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gateway/pkg/security"
+)
+
+// TestHashPassword_Argon2id 测试argon2id哈希与校验
+func TestHashPassword_Argon2id(t *testing.T) {
+	hash, err := security.HashPassword("P@ssw0rd")
+	if err != nil {
+		t.Fatalf("计算哈希失败: %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=2$") {
+		t.Errorf("默认应使用argon2id: %s", hash)
+	}
+	if !security.IsPasswordHash(hash) {
+		t.Errorf("应识别为密码哈希")
+	}
+
+	if ok, err := security.VerifyPassword("P@ssw0rd", hash); err != nil || !ok {
+		t.Errorf("正确密码校验失败: %v", err)
+	}
+	if ok, err := security.VerifyPassword("wrong", hash); err != nil || ok {
+		t.Errorf("错误密码不应通过: %v", err)
+	}
+
+	// 相同密码每次生成的哈希不同
+	another, _ := security.HashPassword("P@ssw0rd")
+	if another == hash {
+		t.Errorf("相同密码的哈希应使用不同的盐")
+	}
+}
+
+// TestHashPassword_Bcrypt 测试bcrypt哈希与校验
+func TestHashPassword_Bcrypt(t *testing.T) {
+	cfg := security.PasswordHashConfig{Algorithm: security.PasswordAlgorithmBcrypt, BcryptCost: 4}
+	hash, err := security.HashPasswordWithConfig("P@ssw0rd", cfg)
+	if err != nil {
+		t.Fatalf("计算哈希失败: %v", err)
+	}
+	if ok, err := security.VerifyPassword("P@ssw0rd", hash); err != nil || !ok {
+		t.Errorf("正确密码校验失败: %v", err)
+	}
+	if ok, _ := security.VerifyPassword("wrong", hash); ok {
+		t.Errorf("错误密码不应通过")
+	}
+}
+
+// TestVerifyPasswordAndRehash 测试参数升级后校验时自动重新计算
+func TestVerifyPasswordAndRehash(t *testing.T) {
+	weak := security.PasswordHashConfig{
+		Algorithm:        security.PasswordAlgorithmArgon2id,
+		Argon2Memory:     8 * 1024,
+		Argon2Iterations: 1,
+	}
+	oldHash, _ := security.HashPasswordWithConfig("P@ssw0rd", weak)
+	if !security.NeedsRehash(oldHash) {
+		t.Errorf("低于当前配置的哈希应需要重新计算")
+	}
+
+	ok, newHash, err := security.VerifyPasswordAndRehash("P@ssw0rd", oldHash)
+	if err != nil || !ok || newHash == "" {
+		t.Fatalf("应返回升级后的哈希: ok=%v newHash=%q err=%v", ok, newHash, err)
+	}
+	if security.NeedsRehash(newHash) {
+		t.Errorf("升级后的哈希不应再需要重新计算")
+	}
+
+	// 算法切换：bcrypt 哈希在默认配置下升级为 argon2id
+	bcryptHash, _ := security.HashPasswordWithConfig("P@ssw0rd",
+		security.PasswordHashConfig{Algorithm: security.PasswordAlgorithmBcrypt, BcryptCost: 4})
+	if _, upgraded, _ := security.VerifyPasswordAndRehash("P@ssw0rd", bcryptHash); !strings.HasPrefix(upgraded, "$argon2id$") {
+		t.Errorf("bcrypt哈希应升级为argon2id: %s", upgraded)
+	}
+
+	// 密码错误时不返回新哈希
+	if ok, upgraded, _ := security.VerifyPasswordAndRehash("wrong", oldHash); ok || upgraded != "" {
+		t.Errorf("密码错误时不应升级")
+	}
+}
+
+// TestVerifyPassword_InvalidHash 测试无效哈希
+func TestVerifyPassword_InvalidHash(t *testing.T) {
+	for _, hash := range []string{"", "plaintext", "$argon2id$v=19$m=1$bad", "ENCY_abc",
+		"$argon2id$v=19$m=0,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2g",
+		"$argon2id$v=19$m=65536,t=0,p=1$c2FsdHNhbHQ$aGFzaGhhc2g",
+		"$argon2id$v=19$m=65536,t=1,p=0$c2FsdHNhbHQ$aGFzaGhhc2g",
+		"$argon2id$v=19$m=-1,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2g"} {
+		if _, err := security.VerifyPassword("x", hash); err == nil {
+			t.Errorf("无效哈希 %q 应返回错误", hash)
+		} else if strings.HasPrefix(hash, "$argon2id$v=19$m=") && !errors.Is(err, security.ErrInvalidPasswordHash) {
+			t.Errorf("无效哈希 %q 应返回 ErrInvalidPasswordHash, got %v", hash, err)
+		}
+		if security.IsPasswordHash(hash) {
+			t.Errorf("%q 不应识别为密码哈希", hash)
+		}
+	}
+}
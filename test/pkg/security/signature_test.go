@@ -0,0 +1,128 @@
+package security
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gateway/pkg/security"
+)
+
+// newSignatureVerifier 创建测试用校验器
+func newSignatureVerifier() *security.SignatureVerifier {
+	return &security.SignatureVerifier{
+		SecretLookup: func(accessKey string) (string, error) {
+			if accessKey != "partner-a" {
+				return "", errors.New("unknown access key")
+			}
+			return "partner-secret", nil
+		},
+		NonceStore: security.NewMemoryNonceStore(),
+	}
+}
+
+// TestSignHTTPRequest 测试请求签名与校验
+func TestSignHTTPRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/orders?b=2&a=1", strings.NewReader(`{"id":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	if err := security.SignHTTPRequest(req, "partner-a", "partner-secret", "Content-Type"); err != nil {
+		t.Fatalf("签名失败: %v", err)
+	}
+
+	verifier := newSignatureVerifier()
+	accessKey, err := verifier.VerifyHTTPRequest(req)
+	if err != nil || accessKey != "partner-a" {
+		t.Fatalf("校验失败: accessKey=%s err=%v", accessKey, err)
+	}
+
+	// 请求体放回后仍可读取
+	body := make([]byte, 8)
+	if n, _ := req.Body.Read(body); string(body[:n]) != `{"id":1}` {
+		t.Errorf("校验后请求体应保持不变")
+	}
+
+	// 重放
+	req.Body = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id":1}`)).Body
+	if _, err := verifier.VerifyHTTPRequest(req); !errors.Is(err, security.ErrSignatureReplay) {
+		t.Errorf("重复请求应被拒绝，实际 %v", err)
+	}
+}
+
+// TestVerifyHTTPRequest_Tampered 测试篡改请求
+func TestVerifyHTTPRequest_Tampered(t *testing.T) {
+	newSigned := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/orders?a=1", strings.NewReader(`{"id":1}`))
+		req.Header.Set("Content-Type", "application/json")
+		security.SignHTTPRequest(req, "partner-a", "partner-secret", "Content-Type")
+		return req
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(*http.Request)
+		want   error
+	}{
+		{"修改请求体", func(r *http.Request) {
+			r.Body = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id":2}`)).Body
+		}, security.ErrSignatureMismatch},
+		{"修改查询参数", func(r *http.Request) { r.URL.RawQuery = "a=2" }, security.ErrSignatureMismatch},
+		{"修改签名请求头", func(r *http.Request) { r.Header.Set("Content-Type", "text/plain") }, security.ErrSignatureMismatch},
+		{"缺少签名", func(r *http.Request) { r.Header.Del(security.SignatureHeader) }, security.ErrSignatureMissing},
+		{"时间戳过期", func(r *http.Request) {
+			r.Header.Set(security.SignatureTimestampHeader, strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10))
+		}, security.ErrSignatureExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newSigned()
+			tt.tamper(req)
+			if _, err := newSignatureVerifier().VerifyHTTPRequest(req); !errors.Is(err, tt.want) {
+				t.Errorf("期望 %v，实际 %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestCanonicalString 测试规范化字符串与参数顺序无关
+func TestCanonicalString(t *testing.T) {
+	a := security.SignatureParams{
+		Method:        "get",
+		Path:          "/x",
+		Query:         map[string][]string{"b": {"2"}, "a": {"1"}},
+		Headers:       http.Header{"X-A": {" v "}},
+		SignedHeaders: []string{"X-A"},
+		Timestamp:     1,
+		Nonce:         "n",
+	}
+	b := a
+	b.Query = map[string][]string{"a": {"1"}, "b": {"2"}}
+	b.SignedHeaders = []string{"x-a", "X-A"}
+	if a.CanonicalString() != b.CanonicalString() {
+		t.Errorf("规范化字符串应与参数顺序无关")
+	}
+	if !strings.HasPrefix(a.CanonicalString(), "GET\n/x\na=1&b=2\nx-a:v\n") {
+		t.Errorf("规范化字符串格式错误: %q", a.CanonicalString())
+	}
+}
+
+// TestSignPayload 测试消息签名
+func TestSignPayload(t *testing.T) {
+	payload := []byte(`{"event":"sync"}`)
+	sig := security.SignPayload("node-secret", payload)
+	nonces := security.NewMemoryNonceStore()
+
+	if err := security.VerifyPayload("node-secret", payload, sig, 0, nonces); err != nil {
+		t.Fatalf("校验失败: %v", err)
+	}
+	if err := security.VerifyPayload("node-secret", payload, sig, 0, nonces); !errors.Is(err, security.ErrSignatureReplay) {
+		t.Errorf("重复消息应被拒绝，实际 %v", err)
+	}
+	if err := security.VerifyPayload("other", payload, security.SignPayload("node-secret", payload), 0, nil); !errors.Is(err, security.ErrSignatureMismatch) {
+		t.Errorf("密钥错误应校验失败，实际 %v", err)
+	}
+}
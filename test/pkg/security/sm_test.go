@@ -0,0 +1,178 @@
+package security
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gateway/pkg/config"
+	"gateway/pkg/security"
+)
+
+// TestSM3 测试SM3标准测试向量（GB/T 32905 附录A）
+func TestSM3(t *testing.T) {
+	cases := map[string]string{
+		"abc":                      "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0",
+		strings.Repeat("abcd", 16): "debe9ff92275b8a138604889c18e5a4d6fdb70e5387e5765293dcba39c0c5732",
+	}
+	for input, want := range cases {
+		if got := security.SM3(input); got != want {
+			t.Errorf("SM3(%q) = %s，期望 %s", input, got, want)
+		}
+		if got, err := security.Hash(input, "sm3"); err != nil || got != want {
+			t.Errorf("Hash sm3 结果不一致: %s, %v", got, err)
+		}
+	}
+
+	// 分段写入与一次写入结果一致
+	h := security.NewSM3()
+	h.Write([]byte("ab"))
+	h.Write([]byte("c"))
+	if got := hex.EncodeToString(h.Sum(nil)); got != cases["abc"] {
+		t.Errorf("分段写入结果不一致: %s", got)
+	}
+}
+
+// TestSM4Cipher 测试SM4标准测试向量（GB/T 32907 附录A）
+func TestSM4Cipher(t *testing.T) {
+	key, _ := hex.DecodeString("0123456789abcdeffedcba9876543210")
+	want, _ := hex.DecodeString("681edf34d206965e86b3e94f536e4246")
+
+	block, err := security.NewSM4Cipher(key)
+	if err != nil {
+		t.Fatalf("创建SM4失败: %v", err)
+	}
+	dst := make([]byte, security.SM4BlockSize)
+	block.Encrypt(dst, key)
+	if !bytes.Equal(dst, want) {
+		t.Fatalf("SM4加密结果 %x，期望 %x", dst, want)
+	}
+	block.Decrypt(dst, dst)
+	if !bytes.Equal(dst, key) {
+		t.Errorf("SM4解密结果 %x，期望 %x", dst, key)
+	}
+
+	if _, err := security.NewSM4Cipher(key[:8]); err != security.ErrInvalidSM4KeyLength {
+		t.Errorf("密钥长度错误应返回 ErrInvalidSM4KeyLength，实际 %v", err)
+	}
+}
+
+// TestSM4EncryptionAlgorithm 测试配置 app.encryption_algorithm 为 sm4 时的 ENCY_ 密文
+func TestSM4EncryptionAlgorithm(t *testing.T) {
+	loadEncryptionAlgorithm(t, "sm4")
+	defer loadEncryptionAlgorithm(t, "aes")
+
+	ciphertext, err := security.EncryptWithDefaultKey("gm-secret")
+	if err != nil {
+		t.Fatalf("加密失败: %v", err)
+	}
+	data, err := security.EncryptedDataFromString(ciphertext)
+	if err != nil {
+		t.Fatalf("解析密文失败: %v", err)
+	}
+	if data.Version != security.SM4GCMKeyedVersion {
+		t.Errorf("密文版本应为SM4，实际 %d", data.Version)
+	}
+	if plaintext, err := security.DecryptWithDefaultKey(ciphertext); err != nil || plaintext != "gm-secret" {
+		t.Errorf("解密失败: %s, %v", plaintext, err)
+	}
+
+	// 切回AES后SM4密文仍可按版本号解密
+	loadEncryptionAlgorithm(t, "aes")
+	if plaintext, err := security.DecryptWithDefaultKey(ciphertext); err != nil || plaintext != "gm-secret" {
+		t.Errorf("切换算法后解密失败: %s, %v", plaintext, err)
+	}
+}
+
+// TestSM2SignVerify 测试SM2签名和验签
+func TestSM2SignVerify(t *testing.T) {
+	curve := security.SM2P256()
+	params := curve.Params()
+	if !curve.IsOnCurve(params.Gx, params.Gy) {
+		t.Fatalf("基点不在曲线上")
+	}
+
+	key, err := security.GenerateECDSAKeyPair("SM2")
+	if err != nil {
+		t.Fatalf("生成SM2密钥失败: %v", err)
+	}
+	if !security.IsSM2Key(&key.PublicKey) {
+		t.Fatalf("生成的密钥应为SM2密钥")
+	}
+
+	data := []byte("gm-payload")
+	signature, err := security.SignData(key, data)
+	if err != nil {
+		t.Fatalf("签名失败: %v", err)
+	}
+	if err := security.VerifySignature(&key.PublicKey, data, signature); err != nil {
+		t.Errorf("验签失败: %v", err)
+	}
+	if err := security.VerifySignature(&key.PublicKey, []byte("tampered"), signature); err != security.ErrSignatureMismatch {
+		t.Errorf("篡改数据应验签失败，实际 %v", err)
+	}
+
+	// 用户身份标识参与签名
+	uidSig, err := security.SM2Sign(key, []byte("alice@example.com"), data)
+	if err != nil {
+		t.Fatalf("签名失败: %v", err)
+	}
+	if !security.SM2Verify(&key.PublicKey, []byte("alice@example.com"), data, uidSig) {
+		t.Errorf("相同用户身份标识应验签成功")
+	}
+	if security.SM2Verify(&key.PublicKey, nil, data, uidSig) {
+		t.Errorf("不同用户身份标识应验签失败")
+	}
+}
+
+// TestSM2KeyPEM 测试SM2密钥PEM编码和解析
+func TestSM2KeyPEM(t *testing.T) {
+	key, err := security.GenerateSM2KeyPair()
+	if err != nil {
+		t.Fatalf("生成SM2密钥失败: %v", err)
+	}
+
+	privPEM, err := security.EncodePrivateKeyPEM(key)
+	if err != nil {
+		t.Fatalf("编码私钥失败: %v", err)
+	}
+	pubPEM, err := security.EncodePublicKeyPEM(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("编码公钥失败: %v", err)
+	}
+
+	parsedKey, err := security.ParsePrivateKeyPEM(privPEM)
+	if err != nil {
+		t.Fatalf("解析私钥失败: %v", err)
+	}
+	parsedPub, err := security.ParsePublicKeyPEM(pubPEM)
+	if err != nil {
+		t.Fatalf("解析公钥失败: %v", err)
+	}
+
+	signature, err := security.SignData(parsedKey, []byte("pem"))
+	if err != nil {
+		t.Fatalf("签名失败: %v", err)
+	}
+	if err := security.VerifySignature(parsedPub, []byte("pem"), signature); err != nil {
+		t.Errorf("解析后的密钥验签失败: %v", err)
+	}
+}
+
+// loadEncryptionAlgorithm 通过配置文件设置 app.encryption_algorithm
+func loadEncryptionAlgorithm(t *testing.T, algorithm string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "encryption_algorithm.yaml")
+	if err := os.WriteFile(path, []byte("app:\n  encryption_algorithm: "+algorithm+"\n"), 0644); err != nil {
+		t.Fatalf("写入配置文件失败: %v", err)
+	}
+	if err := config.LoadConfigFile(path); err != nil {
+		t.Fatalf("加载配置文件失败: %v", err)
+	}
+	if got := security.GetEncryptionAlgorithm(); got != algorithm {
+		t.Fatalf("加密算法应为 %s，实际 %s", algorithm, got)
+	}
+}
@@ -0,0 +1,179 @@
+package timer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"gateway/pkg/cache"
+	"gateway/pkg/cache/memory"
+	"gateway/pkg/timer"
+)
+
+// newClusterTestScheduler 创建使用共享内存缓存协调的调度器，模拟集群中的一个节点
+func newClusterTestScheduler(cacheName, nodeId string) *timer.StandardScheduler {
+	return timer.NewStandardScheduler(&timer.SchedulerConfig{
+		ID:               "cluster-test-scheduler",
+		Name:             "ClusterTestScheduler",
+		TenantId:         "default",
+		MaxWorkers:       4,
+		QueueSize:        20,
+		DefaultTimeout:   time.Second * 5,
+		DefaultRetries:   1,
+		ScheduleInterval: time.Millisecond * 100,
+		Cluster: &timer.ClusterConfig{
+			Enabled:           true,
+			CacheName:         cacheName,
+			NodeId:            nodeId,
+			LeaseTTL:          time.Second * 3,
+			HeartbeatInterval: time.Millisecond * 500,
+		},
+	})
+}
+
+// registerClusterTestCache 注册测试用的共享缓存
+func registerClusterTestCache(t *testing.T, name string) {
+	mc, err := memory.NewMemoryCache(nil)
+	if err != nil {
+		t.Fatalf("创建内存缓存失败: %v", err)
+	}
+	if err := cache.AddCache(name, mc); err != nil {
+		t.Fatalf("注册缓存失败: %v", err)
+	}
+	t.Cleanup(func() {
+		cache.GetGlobalManager().RemoveCache(name)
+	})
+}
+
+// TestClusterExclusiveExecution 测试集群模式下普通任务只在主节点执行
+func TestClusterExclusiveExecution(t *testing.T) {
+	registerClusterTestCache(t, "timer_cluster_exclusive")
+
+	var mu sync.Mutex
+	counts := make(map[string]int)
+	nodes := []string{"node-a", "node-b"}
+	schedulers := make([]*timer.StandardScheduler, 0, len(nodes))
+	for _, nodeId := range nodes {
+		nodeId := nodeId
+		scheduler := newClusterTestScheduler("timer_cluster_exclusive", nodeId)
+		config := CreateTestTaskConfig("cluster-interval-task", "集群间隔任务", timer.ScheduleTypeInterval)
+		config.Interval = time.Second
+		executor := NewTestTaskExecutor(nodeId, func(ctx context.Context, params interface{}) error {
+			mu.Lock()
+			counts[nodeId]++
+			mu.Unlock()
+			return nil
+		})
+		if err := scheduler.AddTask(config, executor); err != nil {
+			t.Fatalf("添加任务失败: %v", err)
+		}
+		if err := scheduler.Start(); err != nil {
+			t.Fatalf("启动调度器失败: %v", err)
+		}
+		schedulers = append(schedulers, scheduler)
+	}
+	defer func() {
+		for _, scheduler := range schedulers {
+			scheduler.Stop()
+		}
+	}()
+
+	time.Sleep(time.Millisecond * 2500)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if counts["node-a"] == 0 {
+		t.Error("先启动的节点应成为主节点并执行任务")
+	}
+	if counts["node-b"] != 0 {
+		t.Errorf("非主节点不应执行任务, 执行次数 = %d", counts["node-b"])
+	}
+}
+
+// TestClusterShardedExecution 测试分片任务在节点间分配分片执行
+func TestClusterShardedExecution(t *testing.T) {
+	registerClusterTestCache(t, "timer_cluster_sharded")
+
+	var mu sync.Mutex
+	shardNodes := make(map[int]map[string]bool)
+	var shardTotal int
+	nodes := []string{"node-a", "node-b"}
+	schedulers := make([]*timer.StandardScheduler, 0, len(nodes))
+	for _, nodeId := range nodes {
+		nodeId := nodeId
+		scheduler := newClusterTestScheduler("timer_cluster_sharded", nodeId)
+		config := CreateTestTaskConfig("cluster-sharded-task", "集群分片任务", timer.ScheduleTypeInterval)
+		config.Interval = time.Second
+		config.ShardCount = 4
+		executor := NewTestTaskExecutor(nodeId, func(ctx context.Context, params interface{}) error {
+			shard, ok := timer.ShardFromContext(ctx)
+			if !ok {
+				t.Error("分片任务执行上下文中应包含分片信息")
+				return nil
+			}
+			mu.Lock()
+			if shardNodes[shard.Index] == nil {
+				shardNodes[shard.Index] = make(map[string]bool)
+			}
+			shardNodes[shard.Index][nodeId] = true
+			shardTotal = shard.Total
+			mu.Unlock()
+			return nil
+		})
+		if err := scheduler.AddTask(config, executor); err != nil {
+			t.Fatalf("添加任务失败: %v", err)
+		}
+		if err := scheduler.Start(); err != nil {
+			t.Fatalf("启动调度器失败: %v", err)
+		}
+		schedulers = append(schedulers, scheduler)
+	}
+	defer func() {
+		for _, scheduler := range schedulers {
+			scheduler.Stop()
+		}
+	}()
+
+	time.Sleep(time.Millisecond * 3500)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if shardTotal != 4 {
+		t.Errorf("分片总数 = %d, want 4", shardTotal)
+	}
+	for shard := 0; shard < 4; shard++ {
+		if len(shardNodes[shard]) == 0 {
+			t.Errorf("分片 %d 未被执行", shard)
+		}
+	}
+	executedNodes := make(map[string]bool)
+	for _, nodes := range shardNodes {
+		for nodeId := range nodes {
+			executedNodes[nodeId] = true
+		}
+	}
+	if len(executedNodes) != 2 {
+		t.Errorf("分片应分配到两个节点执行, 实际执行节点 = %v", executedNodes)
+	}
+}
+
+// TestShardInfoOwns 测试分片按分区键分配
+func TestShardInfoOwns(t *testing.T) {
+	keys := []string{"tenant-a", "tenant-b", "tenant-c", "tenant-d", "tenant-e"}
+	for _, key := range keys {
+		owners := 0
+		for index := 0; index < 3; index++ {
+			if (timer.ShardInfo{Index: index, Total: 3}).Owns(key) {
+				owners++
+			}
+		}
+		if owners != 1 {
+			t.Errorf("分区键 %s 应只属于一个分片, 实际 %d 个", key, owners)
+		}
+	}
+
+	if !(timer.ShardInfo{Index: 0, Total: 1}).Owns("any") {
+		t.Error("单分片应负责所有分区键")
+	}
+}
@@ -283,3 +283,101 @@ func TestSpecificCronExpression(t *testing.T) {
 		}
 	})
 }
+
+func TestParseInLocation(t *testing.T) {
+	parser := cron.NewStandardCronParser()
+	shanghai := time.FixedZone("CST", 8*3600)
+
+	schedule, err := parser.ParseInLocation("0 0 9 * * *", shanghai)
+	if err != nil {
+		t.Fatalf("ParseInLocation() error = %v", err)
+	}
+
+	// UTC 2024-01-01 02:00 即北京时间 10:00，下一次为北京时间次日 9:00
+	start := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+	next := schedule.Next(start)
+	expected := time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC)
+	if !next.Equal(expected) {
+		t.Errorf("Next() = %v, want %v", next, expected)
+	}
+	if next.Location() != time.UTC {
+		t.Errorf("Next() 应返回调用方时区, got %v", next.Location())
+	}
+}
+
+func TestCronTimeZonePrefix(t *testing.T) {
+	if _, err := time.LoadLocation("Asia/Shanghai"); err != nil {
+		t.Skip("时区数据不可用")
+	}
+	parser := cron.NewStandardCronParser()
+
+	schedule, err := parser.Parse("CRON_TZ=Asia/Shanghai 0 30 8 * * *")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	next := schedule.Next(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	expected := time.Date(2024, 3, 1, 0, 30, 0, 0, time.UTC)
+	if !next.Equal(expected) {
+		t.Errorf("Next() = %v, want %v", next, expected)
+	}
+
+	if _, err := parser.Parse("CRON_TZ=Invalid/Zone 0 * * * * *"); err == nil {
+		t.Error("无效时区应返回错误")
+	}
+}
+
+func TestCronNamesAndMacros(t *testing.T) {
+	parser := cron.NewStandardCronParser()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // 周一
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected time.Time
+	}{
+		{"星期名称", "0 0 9 * * FRI", time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)},
+		{"月份名称", "0 0 0 1 MAR-MAY *", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"@daily", "@daily", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", "@hourly", time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)},
+		{"@every", "@every 90s", time.Date(2024, 1, 1, 0, 1, 30, 0, time.UTC)},
+		{"起始值步长", "5/20 * * * * *", time.Date(2024, 1, 1, 0, 0, 5, 0, time.UTC)},
+		{"日期与星期取并集", "0 0 0 15 * MON", time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parser.Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if next := schedule.Next(start); !next.Equal(tt.expected) {
+				t.Errorf("Next() = %v, want %v", next, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPreview(t *testing.T) {
+	start := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+
+	times, err := cron.Preview("0 0 0 29 2 *", time.UTC, start, 2)
+	if err != nil {
+		t.Fatalf("Preview() error = %v", err)
+	}
+	expected := []time.Time{
+		time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+	}
+	if len(times) != len(expected) {
+		t.Fatalf("Preview() 返回 %d 个时间, want %d", len(times), len(expected))
+	}
+	for i := range expected {
+		if !times[i].Equal(expected[i]) {
+			t.Errorf("Preview()[%d] = %v, want %v", i, times[i], expected[i])
+		}
+	}
+
+	if _, err := cron.Preview("invalid", time.UTC, start, 3); err == nil {
+		t.Error("无效表达式应返回错误")
+	}
+}
@@ -0,0 +1,160 @@
+package timer
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"gateway/pkg/timer"
+)
+
+// dagRecorder 记录依赖链中任务的执行顺序
+type dagRecorder struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (r *dagRecorder) executor(taskID string, fail bool) *TestTaskExecutor {
+	return NewTestTaskExecutor(taskID, func(ctx context.Context, params interface{}) error {
+		r.mu.Lock()
+		r.order = append(r.order, taskID)
+		r.mu.Unlock()
+		if fail {
+			return errors.New("模拟任务执行失败")
+		}
+		return nil
+	})
+}
+
+func (r *dagRecorder) executed() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.order...)
+}
+
+// newDagTestScheduler 创建并启动依赖链测试用的调度器
+func newDagTestScheduler(t *testing.T) *timer.StandardScheduler {
+	scheduler := timer.NewStandardScheduler(&timer.SchedulerConfig{
+		Name:             "DagTestScheduler",
+		MaxWorkers:       4,
+		QueueSize:        20,
+		DefaultTimeout:   time.Second * 5,
+		DefaultRetries:   1,
+		ScheduleInterval: time.Millisecond * 100,
+	})
+	if err := scheduler.Start(); err != nil {
+		t.Fatalf("启动调度器失败: %v", err)
+	}
+	t.Cleanup(func() { scheduler.Stop() })
+	return scheduler
+}
+
+// addDagTask 添加只能手动触发或由上游触发的任务
+func addDagTask(t *testing.T, scheduler *timer.StandardScheduler, recorder *dagRecorder, taskID string, fail bool,
+	policy timer.DependencyPolicy, dependsOn ...string) {
+	config := CreateTestTaskConfig(taskID, taskID, timer.ScheduleTypeOnce)
+	future := time.Now().Add(time.Hour)
+	config.StartTime = &future
+	config.MaxRetries = 1
+	config.DependsOn = dependsOn
+	config.DependencyPolicy = policy
+	if err := scheduler.AddTask(config, recorder.executor(taskID, fail)); err != nil {
+		t.Fatalf("添加任务 %s 失败: %v", taskID, err)
+	}
+}
+
+// TestDependencyChainOrder 测试依赖链按顺序执行，并行分支都执行后才触发汇合任务
+func TestDependencyChainOrder(t *testing.T) {
+	scheduler := newDagTestScheduler(t)
+	recorder := &dagRecorder{}
+
+	// rollup -> (archive, report) -> notify
+	addDagTask(t, scheduler, recorder, "notify", false, timer.DependencyPolicySkip, "archive", "report")
+	addDagTask(t, scheduler, recorder, "rollup", false, timer.DependencyPolicySkip)
+	addDagTask(t, scheduler, recorder, "archive", false, timer.DependencyPolicySkip, "rollup")
+	addDagTask(t, scheduler, recorder, "report", false, timer.DependencyPolicySkip, "rollup")
+
+	if err := scheduler.TriggerTask("rollup", nil); err != nil {
+		t.Fatalf("触发任务失败: %v", err)
+	}
+	if !WaitForCondition(func() bool { return len(recorder.executed()) == 4 }, time.Second*5, time.Millisecond*50) {
+		t.Fatalf("依赖链未全部执行, 已执行 = %v", recorder.executed())
+	}
+
+	order := recorder.executed()
+	position := make(map[string]int)
+	for i, taskID := range order {
+		position[taskID] = i
+	}
+	if position["rollup"] != 0 || position["notify"] != 3 {
+		t.Errorf("执行顺序 = %v, rollup 应最先执行, notify 应最后执行", order)
+	}
+}
+
+// TestDependencyFailurePolicy 测试上游失败时按策略跳过或继续执行下游任务
+func TestDependencyFailurePolicy(t *testing.T) {
+	scheduler := newDagTestScheduler(t)
+	recorder := &dagRecorder{}
+
+	// rollup 失败: archive 跳过，其下游 purge 也跳过；audit 配置为继续执行
+	addDagTask(t, scheduler, recorder, "rollup", true, timer.DependencyPolicySkip)
+	addDagTask(t, scheduler, recorder, "archive", false, timer.DependencyPolicySkip, "rollup")
+	addDagTask(t, scheduler, recorder, "purge", false, timer.DependencyPolicySkip, "archive")
+	addDagTask(t, scheduler, recorder, "audit", false, timer.DependencyPolicyContinue, "rollup")
+
+	if err := scheduler.TriggerTask("rollup", nil); err != nil {
+		t.Fatalf("触发任务失败: %v", err)
+	}
+	if !WaitForCondition(func() bool { return len(recorder.executed()) >= 2 }, time.Second*5, time.Millisecond*50) {
+		t.Fatalf("依赖任务未执行, 已执行 = %v", recorder.executed())
+	}
+	time.Sleep(time.Millisecond * 300)
+
+	if order := recorder.executed(); !reflect.DeepEqual(order, []string{"rollup", "audit"}) {
+		t.Errorf("执行的任务 = %v, want [rollup audit]", order)
+	}
+}
+
+// TestDependencyCycleRejected 测试添加形成循环依赖的任务被拒绝
+func TestDependencyCycleRejected(t *testing.T) {
+	scheduler := timer.NewStandardScheduler(nil)
+	recorder := &dagRecorder{}
+
+	addDagTask(t, scheduler, recorder, "a", false, timer.DependencyPolicySkip, "c")
+	addDagTask(t, scheduler, recorder, "b", false, timer.DependencyPolicySkip, "a")
+
+	config := CreateTestTaskConfig("c", "c", timer.ScheduleTypeOnce)
+	config.DependsOn = []string{"b"}
+	if err := scheduler.AddTask(config, recorder.executor("c", false)); err == nil {
+		t.Error("形成循环依赖的任务应添加失败")
+	}
+
+	self := CreateTestTaskConfig("d", "d", timer.ScheduleTypeOnce)
+	self.DependsOn = []string{"d"}
+	if err := scheduler.AddTask(self, recorder.executor("d", false)); err == nil {
+		t.Error("依赖自身的任务应添加失败")
+	}
+}
+
+// TestFindDependencyCycle 测试循环依赖检测
+func TestFindDependencyCycle(t *testing.T) {
+	if cycle := timer.FindDependencyCycle(map[string][]string{
+		"archive": {"rollup"},
+		"report":  {"rollup"},
+		"notify":  {"archive", "report"},
+	}); cycle != nil {
+		t.Errorf("无环依赖不应检测到循环, got %v", cycle)
+	}
+
+	cycle := timer.FindDependencyCycle(map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a"},
+	})
+	if !reflect.DeepEqual(cycle, []string{"a", "b", "c", "a"}) {
+		t.Errorf("循环路径 = %v, want [a b c a]", cycle)
+	}
+}
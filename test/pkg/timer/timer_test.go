@@ -41,6 +41,11 @@ func TestTaskStatus(t *testing.T) {
 			status:   timer.TaskStatusCancelled,
 			expected: "CANCELLED",
 		},
+		{
+			name:     "死信状态",
+			status:   timer.TaskStatusDeadLetter,
+			expected: "DEAD_LETTER",
+		},
 		{
 			name:     "未知状态",
 			status:   timer.TaskStatus(999),
@@ -268,6 +273,58 @@ func TestTaskConfigStateMethods(t *testing.T) {
 	}
 }
 
+// TestTaskConfigRetryDelay 测试重试等待时间的退避计算
+func TestTaskConfigRetryDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *timer.TaskConfig
+		attempt  int
+		expected time.Duration
+	}{
+		{"默认间隔", &timer.TaskConfig{}, 1, time.Second * 5},
+		{"固定间隔", &timer.TaskConfig{RetryInterval: time.Second * 2}, 3, time.Second * 2},
+		{"首次重试不放大", &timer.TaskConfig{RetryInterval: time.Second, RetryBackoff: 2}, 1, time.Second},
+		{"指数退避", &timer.TaskConfig{RetryInterval: time.Second, RetryBackoff: 2}, 4, time.Second * 8},
+		{"最大间隔封顶", &timer.TaskConfig{RetryInterval: time.Second, RetryBackoff: 3, MaxRetryInterval: time.Second * 10}, 5, time.Second * 10},
+		{"倍率溢出封顶", &timer.TaskConfig{RetryInterval: time.Hour, RetryBackoff: 10, MaxRetryInterval: time.Hour * 2}, 100, time.Hour * 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.RetryDelay(tt.attempt); got != tt.expected {
+				t.Errorf("RetryDelay(%d) = %v, want %v", tt.attempt, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestTaskConfigDeadLetter 测试连续失败达到阈值后进入死信状态，成功执行后恢复
+func TestTaskConfigDeadLetter(t *testing.T) {
+	config := CreateTestTaskConfig("test-dead-letter-001", "死信测试任务", timer.ScheduleTypeInterval)
+	config.DeadLetterThreshold = 2
+
+	config.UpdateRunInfo(&timer.TaskResult{Status: timer.TaskStatusFailed})
+	if config.GetStatus() != timer.TaskStatusPending {
+		t.Errorf("未达到阈值时状态 = %v, want %v", config.GetStatus(), timer.TaskStatusPending)
+	}
+
+	config.UpdateRunInfo(&timer.TaskResult{Status: timer.TaskStatusFailed})
+	if config.GetStatus() != timer.TaskStatusDeadLetter {
+		t.Errorf("达到阈值后状态 = %v, want %v", config.GetStatus(), timer.TaskStatusDeadLetter)
+	}
+	if config.ConsecutiveFailures != 2 {
+		t.Errorf("ConsecutiveFailures = %d, want 2", config.ConsecutiveFailures)
+	}
+
+	config.UpdateRunInfo(&timer.TaskResult{Status: timer.TaskStatusCompleted})
+	if config.GetStatus() != timer.TaskStatusPending {
+		t.Errorf("成功执行后状态 = %v, want %v", config.GetStatus(), timer.TaskStatusPending)
+	}
+	if config.ConsecutiveFailures != 0 {
+		t.Errorf("成功执行后 ConsecutiveFailures = %d, want 0", config.ConsecutiveFailures)
+	}
+}
+
 // TestTaskResult 测试任务执行结果结构
 // 验证TaskResult结构体的字段设置和访问
 func TestTaskResult(t *testing.T) {
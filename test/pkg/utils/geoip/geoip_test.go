@@ -0,0 +1,215 @@
+package geoip_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"gateway/pkg/utils/geoip"
+)
+
+// testNetwork 测试库中的网段及其数据
+type testNetwork struct {
+	cidr   string
+	record map[string]interface{}
+}
+
+var testNetworks = []testNetwork{
+	{
+		cidr: "81.2.69.0/24",
+		record: map[string]interface{}{
+			"continent": map[string]interface{}{"code": "EU"},
+			"country":   map[string]interface{}{"iso_code": "GB", "names": map[string]interface{}{"en": "United Kingdom"}},
+			"subdivisions": []interface{}{
+				map[string]interface{}{"iso_code": "ENG", "names": map[string]interface{}{"en": "England"}},
+			},
+			"city": map[string]interface{}{"names": map[string]interface{}{"en": "London"}},
+		},
+	},
+	{
+		cidr: "175.16.0.0/16",
+		record: map[string]interface{}{
+			"continent":          map[string]interface{}{"code": "AS"},
+			"registered_country": map[string]interface{}{"iso_code": "CN", "names": map[string]interface{}{"en": "China"}},
+		},
+	},
+}
+
+func TestReaderLookup(t *testing.T) {
+	reader, err := geoip.FromBytes(buildTestDatabase(t, testNetworks))
+	if err != nil {
+		t.Fatalf("加载地理位置库失败: %v", err)
+	}
+	if reader.DatabaseType != "Test-City" {
+		t.Errorf("数据库类型不正确: %s", reader.DatabaseType)
+	}
+
+	location, err := reader.Lookup(net.ParseIP("81.2.69.160"))
+	if err != nil || location == nil {
+		t.Fatalf("查询地理位置失败: %v", err)
+	}
+	expected := geoip.Location{
+		ContinentCode: "EU",
+		CountryCode:   "GB",
+		Country:       "United Kingdom",
+		RegionCode:    "ENG",
+		Region:        "England",
+		City:          "London",
+	}
+	if *location != expected {
+		t.Errorf("地理位置不正确: %+v", *location)
+	}
+
+	// 国家缺失时使用注册国家
+	location, err = reader.Lookup(net.ParseIP("175.16.199.1"))
+	if err != nil || location == nil {
+		t.Fatalf("查询地理位置失败: %v", err)
+	}
+	if location.CountryCode != "CN" || location.ContinentCode != "AS" || location.City != "" {
+		t.Errorf("地理位置不正确: %+v", *location)
+	}
+
+	// IPv4映射的IPv6地址按IPv4查询
+	location, err = reader.Lookup(net.ParseIP("::ffff:81.2.69.1"))
+	if err != nil || location == nil || location.CountryCode != "GB" {
+		t.Errorf("IPv4映射地址查询失败: %+v, %v", location, err)
+	}
+
+	for _, ip := range []string{"81.2.70.1", "8.8.8.8", "2001:db8::1"} {
+		location, err = reader.Lookup(net.ParseIP(ip))
+		if err != nil || location != nil {
+			t.Errorf("库中不存在的地址 %s 应返回nil: %+v, %v", ip, location, err)
+		}
+	}
+	if _, err = reader.Lookup(nil); err == nil {
+		t.Error("无效IP应返回错误")
+	}
+}
+
+func TestOpenAndDefaultLookup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	if err := os.WriteFile(path, buildTestDatabase(t, testNetworks), 0o644); err != nil {
+		t.Fatalf("写入测试库失败: %v", err)
+	}
+	reader, err := geoip.Open(path)
+	if err != nil {
+		t.Fatalf("打开地理位置库失败: %v", err)
+	}
+
+	if geoip.Lookup("81.2.69.1") != nil {
+		t.Error("未设置全局地理位置库时应返回nil")
+	}
+	geoip.SetDefault(reader)
+	defer geoip.SetDefault(nil)
+
+	if location := geoip.Lookup(" 81.2.69.1 "); location == nil || location.City != "London" {
+		t.Errorf("全局查询结果不正确: %+v", location)
+	}
+	for _, ip := range []string{"127.0.0.1", "10.0.0.1", "invalid"} {
+		if geoip.Lookup(ip) != nil {
+			t.Errorf("地址 %s 不应有地理位置", ip)
+		}
+	}
+}
+
+func TestFromBytesInvalid(t *testing.T) {
+	if _, err := geoip.FromBytes([]byte("not a database")); err == nil {
+		t.Error("缺少元数据时应返回错误")
+	}
+	if _, err := geoip.Open(filepath.Join(t.TempDir(), "missing.mmdb")); err == nil {
+		t.Error("文件不存在时应返回错误")
+	}
+}
+
+// buildTestDatabase 构建记录大小为24位的IPv4测试库
+func buildTestDatabase(t *testing.T, networks []testNetwork) []byte {
+	t.Helper()
+
+	type node struct{ records [2]int }
+	const empty, dataRecord = -1, -2
+	nodes := []*node{{records: [2]int{empty, empty}}}
+	dataRecords := map[[2]int]int{} // 节点和方向 -> 数据段偏移
+
+	var data bytes.Buffer
+	for _, network := range networks {
+		_, ipNet, err := net.ParseCIDR(network.cidr)
+		if err != nil {
+			t.Fatalf("无效网段: %v", err)
+		}
+		ones, _ := ipNet.Mask.Size()
+		ip := ipNet.IP.To4()
+		current := 0
+		for i := 0; i < ones; i++ {
+			bit := int(ip[i/8]>>(7-uint(i%8))) & 1
+			if i == ones-1 {
+				nodes[current].records[bit] = dataRecord
+				dataRecords[[2]int{current, bit}] = data.Len()
+				break
+			}
+			if nodes[current].records[bit] == empty {
+				nodes = append(nodes, &node{records: [2]int{empty, empty}})
+				nodes[current].records[bit] = len(nodes) - 1
+			}
+			current = nodes[current].records[bit]
+		}
+		encodeValue(&data, network.record)
+	}
+
+	nodeCount := len(nodes)
+	var buffer bytes.Buffer
+	for index, n := range nodes {
+		for bit, record := range n.records {
+			value := record
+			switch record {
+			case empty:
+				value = nodeCount
+			case dataRecord:
+				value = nodeCount + 16 + dataRecords[[2]int{index, bit}]
+			}
+			buffer.Write([]byte{byte(value >> 16), byte(value >> 8), byte(value)})
+		}
+	}
+	buffer.Write(make([]byte, 16))
+	buffer.Write(data.Bytes())
+	buffer.WriteString("\xAB\xCD\xEFMaxMind.com")
+	encodeValue(&buffer, map[string]interface{}{
+		"node_count":    uint32(nodeCount),
+		"record_size":   uint32(24),
+		"ip_version":    uint32(4),
+		"database_type": "Test-City",
+	})
+	return buffer.Bytes()
+}
+
+// encodeValue 按MaxMind DB格式编码测试数据（仅支持小于29字节/元素的字符串、map、数组和uint32）
+func encodeValue(buffer *bytes.Buffer, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		buffer.WriteByte(2<<5 | byte(len(v)))
+		buffer.WriteString(v)
+	case uint32:
+		buffer.WriteByte(6<<5 | 4)
+		_ = binary.Write(buffer, binary.BigEndian, v)
+	case map[string]interface{}:
+		buffer.WriteByte(7<<5 | byte(len(v)))
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			encodeValue(buffer, key)
+			encodeValue(buffer, v[key])
+		}
+	case []interface{}:
+		buffer.WriteByte(byte(len(v)))
+		buffer.WriteByte(11 - 7)
+		for _, item := range v {
+			encodeValue(buffer, item)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package huberrors
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gateway/pkg/database"
+	"gateway/pkg/utils/huberrors"
+)
+
+// TestCodeOf 验证错误码识别：带错误码的错误、包装的第三方错误和无法识别的错误
+func TestCodeOf(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code huberrors.Code
+	}{
+		{"nil", nil, ""},
+		{"code error", huberrors.NewCodeError(huberrors.CodeInvalidArgument, "名称不能为空"), huberrors.CodeInvalidArgument},
+		{"wrapped sql.ErrNoRows", huberrors.WrapError(sql.ErrNoRows, "查询失败"), huberrors.CodeNotFound},
+		{"record not found", fmt.Errorf("查询: %w", database.ErrRecordNotFound), huberrors.CodeNotFound},
+		{"deadline", huberrors.WrapError(context.DeadlineExceeded, "调用超时"), huberrors.CodeTimeout},
+		{"grpc status", status.Error(codes.Unavailable, "down"), huberrors.CodeUnavailable},
+		{"outer code wins", huberrors.WrapCodeError(sql.ErrNoRows, huberrors.CodeInternal, "加载配置失败"), huberrors.CodeInternal},
+		{"plain", errors.New("boom"), huberrors.CodeUnknown},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.code, huberrors.CodeOf(tc.err))
+		})
+	}
+}
+
+// TestClassification 验证分类、可重试判断以及HTTP/gRPC状态码映射
+func TestClassification(t *testing.T) {
+	timeout := huberrors.WrapError(context.DeadlineExceeded, "调用下游超时")
+	assert.True(t, huberrors.IsRetryable(timeout))
+	assert.Equal(t, http.StatusGatewayTimeout, huberrors.HTTPStatus(timeout))
+
+	notFound := huberrors.WrapCodeError(database.ErrRecordNotFound, huberrors.CodeInternal, "加载失败")
+	assert.True(t, huberrors.IsNotFound(notFound), "外层错误码不掩盖内层的记录不存在")
+	assert.False(t, huberrors.IsRetryable(notFound))
+	assert.Equal(t, huberrors.CategoryServer, huberrors.CategoryOf(notFound))
+
+	invalid := huberrors.NewCodeError(huberrors.CodeInvalidArgument, "端口 %d 无效", 70000)
+	assert.True(t, huberrors.IsClientError(invalid))
+	assert.Equal(t, http.StatusBadRequest, huberrors.HTTPStatus(invalid))
+	assert.Equal(t, "端口 70000 无效", huberrors.Message(invalid))
+	assert.Equal(t, codes.InvalidArgument, status.Code(invalid), "HubError 实现 GRPCStatus")
+	assert.Equal(t, http.StatusOK, huberrors.HTTPStatus(nil))
+}
+
+// TestIsAndAs 验证 errors.Is 通用哨兵匹配和 As 获取 HubError
+func TestIsAndAs(t *testing.T) {
+	err := fmt.Errorf("保存失败: %w", huberrors.NewCodeError(huberrors.CodeAlreadyExists, "名称已存在"))
+	assert.True(t, errors.Is(err, huberrors.ErrAlreadyExists))
+	assert.False(t, errors.Is(err, huberrors.ErrNotFound))
+	assert.True(t, errors.Is(database.ErrRecordNotFound, huberrors.ErrNotFound))
+	assert.False(t, errors.Is(huberrors.ErrNotFound, database.ErrRecordNotFound), "具体哨兵仍按实例匹配")
+
+	hubErr, ok := huberrors.As(err)
+	assert.True(t, ok)
+	assert.Equal(t, huberrors.CodeAlreadyExists, hubErr.Code)
+
+	_, ok = huberrors.As(errors.New("plain"))
+	assert.False(t, ok)
+}
+
+// TestRegisterClassifier 验证注册的识别函数优先于内置规则
+func TestRegisterClassifier(t *testing.T) {
+	errQuota := errors.New("quota exceeded")
+	huberrors.RegisterClassifier(func(err error) (huberrors.Code, bool) {
+		if err == errQuota {
+			return huberrors.CodeRateLimited, true
+		}
+		return "", false
+	})
+	err := huberrors.WrapError(errQuota, "调用失败")
+	assert.Equal(t, huberrors.CodeRateLimited, huberrors.CodeOf(err))
+	assert.True(t, huberrors.IsRetryable(err))
+}
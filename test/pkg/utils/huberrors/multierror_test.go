@@ -0,0 +1,76 @@
+package huberrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database"
+	"gateway/pkg/utils/huberrors"
+)
+
+// TestAppend 验证追加时忽略nil、展开无说明的嵌套集合，没有错误时 ErrorOrNil 返回nil
+func TestAppend(t *testing.T) {
+	var errs *huberrors.MultiError
+	assert.NoError(t, errs.ErrorOrNil())
+
+	errs = huberrors.Append(nil, nil)
+	assert.Equal(t, 0, errs.Len())
+	assert.NoError(t, errs.ErrorOrNil())
+
+	first := errors.New("first")
+	errs = huberrors.Append(first, nil, huberrors.Append(nil, errors.New("second"), errors.New("third")))
+	assert.Equal(t, 3, errs.Len())
+
+	named := huberrors.NewMultiError("网关实例停止失败")
+	huberrors.Append(named, errors.New("gw1: timeout"))
+	errs = huberrors.Append(errs, named)
+	assert.Equal(t, 4, errs.Len(), "带说明的集合不展开")
+	assert.Same(t, errs, huberrors.Append(errs), "追加到已有集合")
+}
+
+// TestMultiErrorMessage 验证错误信息：单个错误直接显示，多个错误逐行列出
+func TestMultiErrorMessage(t *testing.T) {
+	single := huberrors.NewMultiError("部分实例停止失败")
+	huberrors.Append(single, errors.New("sc1: closed"))
+	assert.Equal(t, "部分实例停止失败: sc1: closed", single.Error())
+
+	multi := huberrors.Append(single, errors.New("sc2: line1\nline2"))
+	assert.Equal(t, "部分实例停止失败: 共 2 个错误\n  * sc1: closed\n  * sc2: line1\n    line2", multi.Error())
+	assert.Equal(t, multi.Error(), fmt.Sprintf("%v", multi))
+	assert.Equal(t, fmt.Sprintf("%q", multi.Error()), fmt.Sprintf("%q", multi))
+}
+
+// TestMultiErrorFormat 验证 %+v 输出每个错误的完整信息，HubError 包含调用栈
+func TestMultiErrorFormat(t *testing.T) {
+	errs := huberrors.Append(nil, huberrors.NewError("关闭缓存失败"), errors.New("plain"))
+	full := fmt.Sprintf("%+v", errs)
+	assert.Contains(t, full, "[1/2] 错误: 关闭缓存失败")
+	assert.Contains(t, full, "调用栈:")
+	assert.Contains(t, full, "TestMultiErrorFormat")
+	assert.Contains(t, full, "[2/2] plain")
+}
+
+// TestMultiErrorUnwrap 验证 errors.Is/As 和 CodeOf 检查集合中的每个错误
+func TestMultiErrorUnwrap(t *testing.T) {
+	err := fmt.Errorf("清理资源: %w", huberrors.Append(
+		errors.New("plain"),
+		fmt.Errorf("db1: %w", database.ErrRecordNotFound),
+		huberrors.WrapError(context.DeadlineExceeded, "停止超时"),
+	).ErrorOrNil())
+
+	assert.True(t, errors.Is(err, database.ErrRecordNotFound))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, huberrors.IsNotFound(err))
+
+	var multi *huberrors.MultiError
+	require.True(t, errors.As(err, &multi))
+	assert.Equal(t, 3, multi.Len())
+	hubErr, ok := huberrors.As(err)
+	require.True(t, ok)
+	assert.Same(t, database.ErrRecordNotFound, hubErr, "按顺序返回第一个 HubError")
+}
@@ -0,0 +1,101 @@
+package pprof
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/pprof"
+)
+
+// newConfig 创建启用认证的测试配置
+func newConfig() *pprof.Config {
+	cfg := pprof.DefaultConfig
+	cfg.Enabled = true
+	cfg.EnableAuth = true
+	cfg.AuthToken = "secret"
+	cfg.MaxProfileDuration = time.Second
+	return &cfg
+}
+
+// serve 以指定客户端地址请求pprof处理器
+func serve(handler http.Handler, path, remote, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = remote
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+// TestValidate 验证安全配置：启用认证必须有token，未启用认证只能监听本机地址
+func TestValidate(t *testing.T) {
+	cfg := pprof.DefaultConfig
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Listen = ":6060"
+	assert.Error(t, cfg.Validate(), "未启用认证时不能监听所有地址")
+
+	cfg.EnableAuth = true
+	assert.Error(t, cfg.Validate(), "启用认证时必须配置token")
+
+	cfg.AuthToken = "secret"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.AllowedIPs = []string{"10.0.0.0/8", "bad-ip"}
+	assert.Error(t, cfg.Validate())
+}
+
+// TestAccessControl 验证除 /health 外的接口都需要认证，并按 allowed_ips 限制客户端IP
+func TestAccessControl(t *testing.T) {
+	cfg := newConfig()
+	cfg.AllowedIPs = []string{"10.0.0.0/8"}
+	handler := pprof.NewManager(cfg).Handler()
+
+	assert.Equal(t, http.StatusOK, serve(handler, "/health", "192.168.1.10:1000", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "/debug/pprof/", "10.1.1.1:1000", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "/analyze", "10.1.1.1:1000", "wrong").Code)
+	assert.Equal(t, http.StatusForbidden, serve(handler, "/debug/pprof/", "192.168.1.10:1000", "secret").Code)
+	assert.Equal(t, http.StatusOK, serve(handler, "/debug/pprof/", "10.1.1.1:1000", "secret").Code)
+	assert.Equal(t, http.StatusOK, serve(handler, "/debug/pprof/", "127.0.0.1:1000", "secret").Code, "本机地址始终允许")
+}
+
+// TestRuntimeStats 验证运行时统计包含协程数、GC和分配速率
+func TestRuntimeStats(t *testing.T) {
+	handler := pprof.NewManager(newConfig()).Handler()
+	w := serve(handler, "/debug/runtime", "127.0.0.1:1000", "secret")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var stats pprof.RuntimeStats
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Greater(t, stats.Goroutines, 0)
+	assert.Greater(t, stats.Memory.HeapAlloc, uint64(0))
+	assert.Greater(t, stats.Rates.WindowSeconds, 0.0)
+	assert.LessOrEqual(t, len(stats.GC.RecentPausesMs), 32)
+}
+
+// TestProfileLimit 验证CPU profile采样时间受上限约束，同一时间只允许一个采样
+func TestProfileLimit(t *testing.T) {
+	handler := pprof.NewManager(newConfig()).Handler()
+
+	done := make(chan *httptest.ResponseRecorder)
+	start := time.Now()
+	go func() {
+		done <- serve(handler, "/debug/pprof/profile?seconds=600", "127.0.0.1:1000", "secret")
+	}()
+
+	// 等待第一个采样开始后再发起第二个采样
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, http.StatusTooManyRequests, serve(handler, "/debug/pprof/profile?seconds=1", "127.0.0.1:1000", "secret").Code)
+
+	w := <-done
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Less(t, time.Since(start), 10*time.Second, "采样时间应被限制为 max_profile_duration")
+}
@@ -0,0 +1,201 @@
+package db
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/script/db"
+	"gateway/pkg/config"
+	"gateway/pkg/database"
+	"gateway/pkg/database/dbtypes"
+	_ "gateway/pkg/database/sqlite" // 导入SQLite实现
+)
+
+// writeFile 写入测试文件，自动创建上级目录
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+// setupMigration 准备SQLite脚本目录和临时数据库
+// 01_ORDER.sql 可以完整执行且有回滚脚本；02_AUDIT.sql 的第二条语句依赖尚不存在的表
+func setupMigration(t *testing.T) database.Database {
+	t.Helper()
+	dir := t.TempDir()
+	scriptDir := filepath.Join(dir, "scripts")
+	writeFile(t, filepath.Join(scriptDir, "sqlite", "01_ORDER.sql"), `-- 订单表
+CREATE TABLE T_ORDER (orderId TEXT PRIMARY KEY, amount INTEGER);
+CREATE INDEX IDX_T_ORDER_AMOUNT ON T_ORDER(amount);
+`)
+	writeFile(t, filepath.Join(scriptDir, "sqlite", "down", "01_ORDER.sql"), `DROP INDEX IDX_T_ORDER_AMOUNT;
+DROP TABLE T_ORDER;
+`)
+	writeFile(t, filepath.Join(scriptDir, "sqlite", "02_AUDIT.sql"), `CREATE TABLE T_AUDIT (auditId TEXT PRIMARY KEY);
+INSERT INTO T_AUDIT_SOURCE (auditId) VALUES ('a-1');
+`)
+
+	configFile := filepath.Join(dir, "database.yaml")
+	writeFile(t, configFile, "database:\n  tenant_id: default\n  script_directory: "+scriptDir+"\n")
+	require.NoError(t, config.LoadConfigFile(configFile, config.LoadOptions{ClearExisting: true}))
+	t.Cleanup(config.Clear)
+
+	dsn := filepath.Join(dir, "gateway.db")
+	conn, err := database.Open(&dbtypes.DbConfig{
+		Name:    dsn,
+		Enabled: true,
+		Driver:  dbtypes.DriverSQLite,
+		DSN:     dsn,
+		Pool:    dbtypes.PoolConfig{MaxOpenConns: 1, MaxIdleConns: 1},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// migrationStatus 按脚本名索引迁移状态
+func migrationStatus(t *testing.T, conn database.Database) map[string]db.ScriptMigrationStatus {
+	t.Helper()
+	statuses, err := db.GetMigrationStatus(context.Background(), conn, dbtypes.DriverSQLite)
+	require.NoError(t, err)
+	result := make(map[string]db.ScriptMigrationStatus, len(statuses))
+	for _, status := range statuses {
+		result[status.ScriptName] = status
+	}
+	return result
+}
+
+// tableExists 检查SQLite中是否存在指定表
+func tableExists(t *testing.T, conn database.Database, table string) bool {
+	t.Helper()
+	var result struct {
+		Count int `db:"COUNT(*)"`
+	}
+	require.NoError(t, conn.QueryOne(context.Background(), &result,
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", []interface{}{table}, true))
+	return result.Count > 0
+}
+
+// TestMigrateUpAndStatus 验证升级后按脚本统计成功、失败和未执行的语句，注释不计入语句数
+func TestMigrateUpAndStatus(t *testing.T) {
+	conn := setupMigration(t)
+
+	result, err := db.MigrateUp(context.Background(), conn, conn, "main")
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.StatementsExecuted)
+	assert.Equal(t, 1, result.StatementsFailed)
+	assert.True(t, tableExists(t, conn, "T_ORDER"))
+
+	statuses := migrationStatus(t, conn)
+	require.Len(t, statuses, 2)
+	order := statuses["01_ORDER.sql"]
+	assert.Equal(t, 2, order.TotalStatements)
+	assert.Equal(t, 2, order.SuccessStatements)
+	assert.True(t, order.HasDownScript)
+	audit := statuses["02_AUDIT.sql"]
+	assert.Equal(t, 2, audit.TotalStatements)
+	assert.Equal(t, 1, audit.SuccessStatements)
+	assert.Equal(t, 1, audit.FailedStatements)
+	assert.Equal(t, 0, audit.PendingStatements)
+	assert.False(t, audit.HasDownScript)
+
+	// 再次升级时已成功的语句跳过，失败的语句重新执行
+	result, err = db.MigrateUp(context.Background(), conn, conn, "main")
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.StatementsSkipped)
+	assert.Equal(t, 1, result.StatementsFailed)
+}
+
+// TestRerunStatement 验证按记录ID重新执行失败的语句并更新执行历史，已成功的语句需要强制模式
+func TestRerunStatement(t *testing.T) {
+	conn := setupMigration(t)
+	ctx := context.Background()
+	_, err := db.MigrateUp(ctx, conn, conn, "main")
+	require.NoError(t, err)
+
+	failed, err := db.ListStatementExecutionHistory(ctx, conn, db.StatementHistoryQuery{ExecutionStatus: "FAILED"})
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	assert.Equal(t, "02_AUDIT.sql", failed[0].ScriptName)
+
+	// 依赖的表仍不存在时再次失败
+	_, err = db.RerunStatement(ctx, conn, conn, failed[0].StatementId, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "语句执行失败")
+
+	_, err = conn.Exec(ctx, "CREATE TABLE T_AUDIT_SOURCE (auditId TEXT PRIMARY KEY)", nil, true)
+	require.NoError(t, err)
+	history, err := db.RerunStatement(ctx, conn, conn, failed[0].StatementId, false)
+	require.NoError(t, err)
+	assert.Equal(t, "FAILED", history.ExecutionStatus, "返回重新执行前的记录")
+	assert.Equal(t, 2, migrationStatus(t, conn)["02_AUDIT.sql"].SuccessStatements)
+
+	_, err = db.RerunStatement(ctx, conn, conn, failed[0].StatementId, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "语句已执行成功")
+
+	_, err = db.RerunStatement(ctx, conn, conn, "missing", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "语句执行记录不存在: missing")
+}
+
+// TestListStatementExecutionHistory 验证按脚本、状态过滤和限制返回记录数
+func TestListStatementExecutionHistory(t *testing.T) {
+	conn := setupMigration(t)
+	ctx := context.Background()
+	_, err := db.MigrateUp(ctx, conn, conn, "main")
+	require.NoError(t, err)
+
+	histories, err := db.ListStatementExecutionHistory(ctx, conn, db.StatementHistoryQuery{})
+	require.NoError(t, err)
+	assert.Len(t, histories, 4)
+
+	histories, err = db.ListStatementExecutionHistory(ctx, conn, db.StatementHistoryQuery{ScriptName: "01_ORDER.sql", ExecutionStatus: "SUCCESS"})
+	require.NoError(t, err)
+	require.Len(t, histories, 2)
+	for _, history := range histories {
+		assert.Equal(t, dbtypes.DriverSQLite, history.DatabaseDriver)
+	}
+
+	histories, err = db.ListStatementExecutionHistory(ctx, conn, db.StatementHistoryQuery{Limit: 3})
+	require.NoError(t, err)
+	assert.Len(t, histories, 3)
+}
+
+// TestMigrateDown 验证回滚脚本执行后清除执行历史，下次升级时重新执行；缺少回滚脚本或脚本不存在时报错
+func TestMigrateDown(t *testing.T) {
+	conn := setupMigration(t)
+	ctx := context.Background()
+	_, err := db.MigrateUp(ctx, conn, conn, "main")
+	require.NoError(t, err)
+
+	executed, err := db.MigrateDown(ctx, conn, conn, "01_ORDER.sql")
+	require.NoError(t, err)
+	assert.Equal(t, 2, executed)
+	assert.False(t, tableExists(t, conn, "T_ORDER"))
+	order := migrationStatus(t, conn)["01_ORDER.sql"]
+	assert.Equal(t, 2, order.PendingStatements)
+	assert.Equal(t, 0, order.SuccessStatements)
+
+	histories, err := db.ListStatementExecutionHistory(ctx, conn, db.StatementHistoryQuery{ScriptName: "01_ORDER.sql"})
+	require.NoError(t, err)
+	assert.Empty(t, histories)
+
+	result, err := db.MigrateUp(ctx, conn, conn, "main")
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.StatementsExecuted)
+	assert.True(t, tableExists(t, conn, "T_ORDER"))
+
+	_, err = db.MigrateDown(ctx, conn, conn, "02_AUDIT.sql")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "脚本 02_AUDIT.sql 没有回滚脚本")
+
+	_, err = db.MigrateDown(ctx, conn, conn, "03_MISSING.sql")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "未找到数据库 sqlite 的脚本文件: 03_MISSING.sql")
+}
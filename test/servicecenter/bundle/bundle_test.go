@@ -0,0 +1,96 @@
+package bundle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/servicecenter/bundle"
+	"gateway/internal/servicecenter/types"
+)
+
+func sourceBundle() *bundle.Bundle {
+	namespace := &types.Namespace{NamespaceId: "staging", NamespaceName: "预发布", Environment: "STAGING"}
+	services := []*types.Service{
+		{NamespaceId: "staging", GroupName: "pay", ServiceName: "gateway", ServiceType: types.ServiceTypeInternal, ProtectThreshold: 0.5},
+		{NamespaceId: "staging", GroupName: "DEFAULT_GROUP", ServiceName: "user", ServiceType: types.ServiceTypeInternal, MetadataJson: `{"warmupSeconds":30}`},
+	}
+	configs := []*types.ConfigData{
+		{NamespaceId: "staging", GroupName: "DEFAULT_GROUP", ConfigDataId: "app.yaml", ContentType: "yaml", ConfigContent: "timeout: 3s",
+			ExtProperty: `{"grayRelease":{"configContent":"timeout: 5s","clientIps":["10.0.0.1"]}}`},
+	}
+	return bundle.Build(namespace, services, configs)
+}
+
+// TestBundleRoundTrip 验证导出包按 JSON 和 YAML 序列化后可还原，且分组由服务和配置汇总
+func TestBundleRoundTrip(t *testing.T) {
+	b := sourceBundle()
+	assert.Equal(t, []string{"DEFAULT_GROUP", "pay"}, b.Groups)
+	assert.Equal(t, "user", b.Services[0].ServiceName, "服务按分组和名称排序")
+
+	for _, format := range []string{bundle.FormatJSON, bundle.FormatYAML} {
+		data, err := bundle.Marshal(b, format)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "grayRelease", "配置灰度状态不导出")
+
+		parsed, err := bundle.Parse(data)
+		require.NoError(t, err, format)
+		assert.Equal(t, bundle.Version, parsed.BundleVersion)
+		assert.Equal(t, "staging", parsed.Namespace.NamespaceId)
+		assert.Equal(t, b.Services, parsed.Services, format)
+		assert.Equal(t, b.Configs, parsed.Configs, format)
+	}
+
+	_, err := bundle.Marshal(b, "xml")
+	assert.Error(t, err)
+}
+
+// TestParseBundleValidation 验证导出包版本和服务、配置标识校验
+func TestParseBundleValidation(t *testing.T) {
+	_, err := bundle.Parse([]byte(`{"services":[]}`))
+	assert.ErrorContains(t, err, "bundleVersion")
+
+	_, err = bundle.Parse([]byte(`{"bundleVersion": 99}`))
+	assert.ErrorContains(t, err, "高于当前支持的版本")
+
+	_, err = bundle.Parse([]byte("bundleVersion: 1\nservices:\n  - serviceName: a\n  - serviceName: a\n    groupName: DEFAULT_GROUP\n"))
+	assert.ErrorContains(t, err, "服务重复")
+
+	b, err := bundle.Parse([]byte("bundleVersion: 1\nconfigs:\n  - configDataId: app.yaml\n    configContent: x\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "DEFAULT_GROUP", b.Configs[0].GroupName, "未指定分组时使用默认分组")
+	assert.Equal(t, []string{"DEFAULT_GROUP"}, b.Groups)
+}
+
+// TestDiff 验证导入变更计划：新增、覆盖（列出不同字段）、无变化和只存在于目标命名空间的数据
+func TestDiff(t *testing.T) {
+	b := sourceBundle()
+	services := []*types.Service{
+		{NamespaceId: "prod", GroupName: "DEFAULT_GROUP", ServiceName: "user", ServiceType: types.ServiceTypeInternal, MetadataJson: `{"warmupSeconds":30}`},
+		{NamespaceId: "prod", GroupName: "pay", ServiceName: "gateway", ServiceType: types.ServiceTypeInternal, ProtectThreshold: 0.8},
+		{NamespaceId: "prod", GroupName: "DEFAULT_GROUP", ServiceName: "legacy"},
+	}
+	configs := []*types.ConfigData{
+		{NamespaceId: "prod", GroupName: "DEFAULT_GROUP", ConfigDataId: "app.yaml", ContentType: "yaml", ConfigContent: "timeout: 1s"},
+	}
+
+	plan := bundle.Diff(b, "prod", services, configs)
+	assert.True(t, plan.DryRun)
+	assert.Equal(t, "staging", plan.SourceNamespaceId)
+	assert.Equal(t, "prod", plan.TargetNamespaceId)
+
+	actions := make(map[string]*bundle.Change)
+	for _, change := range plan.Changes {
+		actions[change.Kind+"/"+change.GroupName+"/"+change.Name] = change
+	}
+	assert.Equal(t, bundle.ActionUnchanged, actions["SERVICE/DEFAULT_GROUP/user"].Action)
+	assert.Equal(t, bundle.ActionUpdate, actions["SERVICE/pay/gateway"].Action)
+	assert.Equal(t, []string{"protectThreshold"}, actions["SERVICE/pay/gateway"].Fields)
+	assert.Equal(t, bundle.ActionRetain, actions["SERVICE/DEFAULT_GROUP/legacy"].Action, "只存在于目标命名空间的服务保留")
+	assert.Equal(t, []string{"configContent"}, actions["CONFIG/DEFAULT_GROUP/app.yaml"].Fields)
+	assert.Equal(t, bundle.Summary{Update: 2, Unchanged: 1, Retain: 1}, plan.Summary)
+
+	plan = bundle.Diff(b, "empty", nil, nil)
+	assert.Equal(t, bundle.Summary{Create: 3}, plan.Summary)
+}
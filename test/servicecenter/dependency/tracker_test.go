@@ -0,0 +1,136 @@
+package dependency
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/servicecenter/cache"
+	"gateway/internal/servicecenter/dependency"
+	"gateway/internal/servicecenter/types"
+)
+
+// memoryStore 测试用依赖关系存储
+type memoryStore struct {
+	mu      sync.Mutex
+	saved   map[string]*types.ServiceDependency
+	history []*types.ServiceDependency
+	changed chan struct{}
+}
+
+func newMemoryStore(history ...*types.ServiceDependency) *memoryStore {
+	return &memoryStore{saved: make(map[string]*types.ServiceDependency), history: history, changed: make(chan struct{}, 16)}
+}
+
+func (s *memoryStore) SaveDependency(_ context.Context, dependency *types.ServiceDependency) error {
+	s.mu.Lock()
+	s.saved[dependency.DependencyId] = dependency
+	s.mu.Unlock()
+	s.changed <- struct{}{}
+	return nil
+}
+
+func (s *memoryStore) ListDependencies(_ context.Context, tenantId, namespaceId string) ([]*types.ServiceDependency, error) {
+	return s.history, nil
+}
+
+// waitSaved 等待异步持久化完成
+func (s *memoryStore) waitSaved(t *testing.T, count int) {
+	for i := 0; i < count; i++ {
+		select {
+		case <-s.changed:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("等待依赖关系持久化超时，已完成 %d/%d", i, count)
+		}
+	}
+}
+
+// TestTrackDependencies 验证订阅时记录并持久化依赖关系，取消订阅后依赖关系保留但不再活跃
+func TestTrackDependencies(t *testing.T) {
+	ctx := context.Background()
+	cache.GetGlobalCache().SetService(ctx, &types.Service{
+		TenantId: "default", NamespaceId: "dep-ns", GroupName: "DEFAULT_GROUP", ServiceName: "user",
+		Nodes: []*types.ServiceNode{{NodeId: "dep-n1", HealthyStatus: types.HealthyStatusHealthy}},
+	})
+
+	store := newMemoryStore()
+	tracker := dependency.NewTracker(store)
+	order := dependency.ServiceConsumer("dep-ns", "", "order")
+	tracker.TrackServices("SUB_1", "default", order, "dep-ns", "DEFAULT_GROUP", []string{"user", "pay"})
+	tracker.TrackServices("SUB_2", "default", order, "dep-ns", "DEFAULT_GROUP", []string{"user"})
+	store.waitSaved(t, 2)
+	assert.Len(t, store.saved, 2, "同一订阅方对同一服务只持久化一条依赖关系，且在持久化间隔内不重复写入")
+
+	graph, err := tracker.CollectGraph(ctx, "default", "dep-ns", "", "")
+	require.NoError(t, err)
+	require.Len(t, graph.Edges, 2)
+	userEdge := graph.Edges[1]
+	assert.Equal(t, "dep-ns/DEFAULT_GROUP/order", userEdge.Source)
+	assert.Equal(t, "dep-ns/DEFAULT_GROUP/user", userEdge.Target)
+	assert.Equal(t, 2, userEdge.ActiveSubscribers)
+
+	nodes := make(map[string]*dependency.GraphNode)
+	for _, n := range graph.Nodes {
+		nodes[n.Id] = n
+	}
+	assert.True(t, nodes["dep-ns/DEFAULT_GROUP/user"].Registered)
+	assert.Equal(t, 1, nodes["dep-ns/DEFAULT_GROUP/user"].HealthyNodes)
+	assert.False(t, nodes["dep-ns/DEFAULT_GROUP/pay"].Registered, "未注册的被订阅服务同样出现在拓扑中")
+
+	tracker.Untrack("SUB_1")
+	tracker.Untrack("SUB_2")
+	graph, err = tracker.CollectGraph(ctx, "default", "dep-ns", "", "")
+	require.NoError(t, err)
+	require.Len(t, graph.Edges, 2, "取消订阅后依赖关系保留")
+	assert.Equal(t, 0, graph.Edges[1].ActiveSubscribers)
+}
+
+// TestBlastRadius 验证影响范围包括间接依赖方和命名空间订阅方，并合并持久化的历史依赖关系
+func TestBlastRadius(t *testing.T) {
+	ctx := context.Background()
+	history := &types.ServiceDependency{
+		DependencyId: "history-1", TenantId: "default", NamespaceId: "blast-ns",
+		ConsumerType: types.ConsumerTypeService, ConsumerNamespaceId: "blast-ns", ConsumerGroupName: "DEFAULT_GROUP", ConsumerName: "report",
+		ProviderGroupName: "DEFAULT_GROUP", ProviderServiceName: "order", SubscribeType: types.SubscribeTypeService,
+	}
+	tracker := dependency.NewTracker(newMemoryStore(history))
+
+	tracker.TrackServices("SUB_order", "default", dependency.ServiceConsumer("blast-ns", "", "order"), "blast-ns", "DEFAULT_GROUP", []string{"user"})
+	tracker.TrackServices("SUB_gateway", "default", dependency.ServiceConsumer("blast-ns", "", "gateway"), "blast-ns", "DEFAULT_GROUP", []string{"order"})
+	tracker.TrackNamespace("SUB_monitor", "default", dependency.ClientConsumer("monitor-1"), "blast-ns", "")
+	tracker.TrackServices("SUB_other", "default", dependency.ServiceConsumer("blast-ns", "", "search"), "blast-ns", "DEFAULT_GROUP", []string{"catalog"})
+
+	graph, err := tracker.CollectGraph(ctx, "default", "blast-ns", "", "user")
+	require.NoError(t, err)
+
+	impacted := make(map[string]*dependency.Impact)
+	for _, impact := range graph.Impacted {
+		impacted[impact.NodeId] = impact
+	}
+	require.Len(t, impacted, 4, "影响范围: %+v", graph.Impacted)
+	assert.Equal(t, 1, impacted["blast-ns/DEFAULT_GROUP/order"].Depth)
+	assert.Equal(t, 1, impacted["client:monitor-1"].Depth, "订阅整个命名空间的客户端依赖其中每个服务")
+	assert.Equal(t, 2, impacted["blast-ns/DEFAULT_GROUP/gateway"].Depth)
+	assert.True(t, impacted["blast-ns/DEFAULT_GROUP/gateway"].Active)
+	assert.Equal(t, 2, impacted["blast-ns/DEFAULT_GROUP/report"].Depth)
+	assert.False(t, impacted["blast-ns/DEFAULT_GROUP/report"].Active, "仅有历史记录的依赖关系不活跃")
+}
+
+// TestConsumerFromLabels 验证从客户端标签解析订阅方服务身份
+func TestConsumerFromLabels(t *testing.T) {
+	_, ok := dependency.ConsumerFromLabels(map[string]string{"env": "prod"}, "ns")
+	assert.False(t, ok)
+
+	consumer, ok := dependency.ConsumerFromLabels(map[string]string{dependency.LabelServiceName: " order "}, "ns")
+	require.True(t, ok)
+	assert.Equal(t, dependency.ServiceConsumer("ns", "DEFAULT_GROUP", "order"), consumer)
+
+	consumer, _ = dependency.ConsumerFromLabels(map[string]string{
+		dependency.LabelServiceName: "order", dependency.LabelGroupName: "g1", dependency.LabelNamespaceId: "other",
+	}, "ns")
+	assert.Equal(t, dependency.ServiceConsumer("other", "g1", "order"), consumer)
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/servicecenter/cache"
+	"gateway/internal/servicecenter/server/handler"
+	pb "gateway/internal/servicecenter/server/proto"
+	"gateway/internal/servicecenter/types"
+)
+
+// registerCatalogNode 在 catalog 命名空间下注册节点，测试结束后注销
+func registerCatalogNode(t *testing.T, registry *handler.RegistryHandler, node *pb.Node) {
+	t.Helper()
+	ctx := context.Background()
+	node.NamespaceId = "catalog"
+	node.IpAddress = "10.0.1.1"
+	resp, err := registry.RegisterNode(ctx, node)
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Message)
+	t.Cleanup(func() { registry.UnregisterNode(ctx, &pb.NodeKey{NodeId: node.NodeId}) })
+}
+
+// TestListServices 验证按命名空间和分组列出服务，结果按分组和服务名排序并统计节点数和健康节点数
+func TestListServices(t *testing.T) {
+	ctx := context.Background()
+	cache.GetGlobalCache().SetNamespace(ctx, &types.Namespace{TenantId: "default", NamespaceId: "catalog", ActiveFlag: "Y"})
+	cache.GetGlobalCache().SetNamespace(ctx, &types.Namespace{TenantId: "default", NamespaceId: "catalog-other", ActiveFlag: "Y"})
+	registry := handler.NewRegistryHandler(nil)
+
+	registerCatalogNode(t, registry, &pb.Node{NodeId: "catalog-user-1", GroupName: "B_GROUP", ServiceName: "user", PortNumber: 8080})
+	registerCatalogNode(t, registry, &pb.Node{NodeId: "catalog-order-1", GroupName: "A_GROUP", ServiceName: "order", PortNumber: 8081})
+	registerCatalogNode(t, registry, &pb.Node{NodeId: "catalog-order-2", GroupName: "A_GROUP", ServiceName: "order", PortNumber: 8082,
+		HealthyStatus: types.HealthyStatusUnhealthy})
+	registerCatalogNode(t, registry, &pb.Node{NodeId: "catalog-account-1", GroupName: "B_GROUP", ServiceName: "account", PortNumber: 8083})
+
+	resp, err := registry.ListServices(ctx, &pb.ListServicesRequest{NamespaceId: "catalog"})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Message)
+	require.Len(t, resp.Services, 3)
+	assert.Equal(t, "found 3 services", resp.Message)
+
+	type summary struct {
+		group, name    string
+		nodes, healthy int32
+	}
+	got := make([]summary, 0, len(resp.Services))
+	for _, service := range resp.Services {
+		got = append(got, summary{service.Service.GroupName, service.Service.ServiceName, service.NodeCount, service.HealthyNodeCount})
+	}
+	assert.Equal(t, []summary{
+		{"A_GROUP", "order", 2, 1},
+		{"B_GROUP", "account", 1, 1},
+		{"B_GROUP", "user", 1, 1},
+	}, got)
+
+	// 指定分组时只列出该分组的服务
+	resp, err = registry.ListServices(ctx, &pb.ListServicesRequest{NamespaceId: "catalog", GroupName: "A_GROUP"})
+	require.NoError(t, err)
+	require.Len(t, resp.Services, 1)
+	assert.Equal(t, "order", resp.Services[0].Service.ServiceName)
+
+	// 其他命名空间的服务不在结果中
+	resp, err = registry.ListServices(ctx, &pb.ListServicesRequest{NamespaceId: "catalog-other"})
+	require.NoError(t, err)
+	assert.True(t, resp.Success, resp.Message)
+	assert.Empty(t, resp.Services)
+}
+
+// TestListServicesNamespaceValidation 验证命名空间为空、不存在或已禁用时返回失败
+func TestListServicesNamespaceValidation(t *testing.T) {
+	ctx := context.Background()
+	cache.GetGlobalCache().SetNamespace(ctx, &types.Namespace{TenantId: "default", NamespaceId: "catalog-disabled", ActiveFlag: "N"})
+	registry := handler.NewRegistryHandler(nil)
+
+	cases := map[string]string{
+		"":                 "namespaceId is required",
+		"catalog-missing":  "namespace not found: catalog-missing",
+		"catalog-disabled": "namespace is disabled: catalog-disabled",
+	}
+	for namespaceId, msg := range cases {
+		resp, err := registry.ListServices(ctx, &pb.ListServicesRequest{NamespaceId: namespaceId})
+		require.NoError(t, err)
+		assert.False(t, resp.Success)
+		assert.Contains(t, resp.Message, msg)
+	}
+}
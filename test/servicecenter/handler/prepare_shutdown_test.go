@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/servicecenter/cache"
+	"gateway/internal/servicecenter/server/handler"
+	pb "gateway/internal/servicecenter/server/proto"
+	"gateway/internal/servicecenter/types"
+)
+
+// registerDrainNodes 在 drain 命名空间下为服务注册节点，测试结束后注销
+// 服务缓存为全局单例，各测试使用不同的服务名互不影响
+func registerDrainNodes(t *testing.T, registry *handler.RegistryHandler, serviceName string, nodeIds ...string) {
+	t.Helper()
+	ctx := context.Background()
+	cache.GetGlobalCache().SetNamespace(ctx, &types.Namespace{TenantId: "default", NamespaceId: "drain", ActiveFlag: "Y"})
+	for i, nodeId := range nodeIds {
+		resp, err := registry.RegisterNode(ctx, &pb.Node{
+			NodeId:      nodeId,
+			NamespaceId: "drain",
+			ServiceName: serviceName,
+			IpAddress:   "10.0.0.1",
+			PortNumber:  int32(8080 + i),
+		})
+		require.NoError(t, err)
+		require.True(t, resp.Success, resp.Message)
+		t.Cleanup(func() { registry.UnregisterNode(ctx, &pb.NodeKey{NodeId: nodeId}) })
+	}
+}
+
+// nodeStatus 获取缓存中节点的实例状态，节点不存在时返回空
+func nodeStatus(nodeId string) string {
+	node, found := cache.GetGlobalCache().GetNode(context.Background(), "default", nodeId)
+	if !found || node == nil {
+		return ""
+	}
+	return node.InstanceStatus
+}
+
+// discoveredNodeIds 服务发现返回的节点ID
+func discoveredNodeIds(t *testing.T, registry *handler.RegistryHandler, serviceName string) []string {
+	t.Helper()
+	resp, err := registry.DiscoverNodes(context.Background(), &pb.DiscoverNodesRequest{NamespaceId: "drain", GroupName: "DEFAULT_GROUP", ServiceName: serviceName})
+	require.NoError(t, err)
+	ids := make([]string, 0, len(resp.Nodes))
+	for _, node := range resp.Nodes {
+		ids = append(ids, node.NodeId)
+	}
+	return ids
+}
+
+// nextEvent 读取订阅 channel 中的下一个事件
+func nextEvent(t *testing.T, ch <-chan *pb.ServiceChangeEvent) *pb.ServiceChangeEvent {
+	t.Helper()
+	select {
+	case event := <-ch:
+		return event
+	case <-time.After(3 * time.Second):
+		t.Fatal("no service change event received")
+		return nil
+	}
+}
+
+// TestPrepareShutdownDrainsAndRemovesNode 验证准备下线的节点进入 DRAINING 状态并推送事件，
+// 不再参与服务发现，心跳不能恢复状态，宽限期结束后被移除
+func TestPrepareShutdownDrainsAndRemovesNode(t *testing.T) {
+	ctx := context.Background()
+	registry := handler.NewRegistryHandler(nil)
+	registerDrainNodes(t, registry, "drain-order", "drain-n1", "drain-n2")
+	ch := registry.GetServiceSubscriber().SubscribeMultipleServices(ctx, "default", "drain", "DEFAULT_GROUP", []string{"drain-order"}, "drain-watcher")
+	t.Cleanup(func() { registry.GetServiceSubscriber().UnsubscribeMultipleServices("drain-watcher") })
+
+	resp, err := registry.PrepareShutdown(ctx, &pb.PrepareShutdownRequest{NodeId: "drain-n1", GracePeriodSeconds: 1})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+	assert.Equal(t, int32(1), resp.GracePeriodSeconds)
+	assert.NotEmpty(t, resp.RemoveTime)
+	assert.Equal(t, types.NodeStatusDraining, nodeStatus("drain-n1"))
+
+	event := nextEvent(t, ch)
+	assert.Equal(t, "NODE_DRAINING", event.EventType)
+	assert.Equal(t, "drain-n1", event.ChangedNode.NodeId)
+	assert.Equal(t, types.NodeStatusDraining, event.ChangedNode.InstanceStatus)
+	assert.Len(t, event.Nodes, 2)
+
+	assert.Equal(t, []string{"drain-n2"}, discoveredNodeIds(t, registry, "drain-order"))
+
+	// 排空中的节点心跳上报 UP 不会恢复状态
+	beat, err := registry.Heartbeat(ctx, &pb.HeartbeatRequest{
+		NodeId: "drain-n1",
+		Service: &pb.Service{
+			NamespaceId: "drain",
+			ServiceName: "drain-order",
+			Node:        &pb.Node{NodeId: "drain-n1", IpAddress: "10.0.0.1", PortNumber: 8080, InstanceStatus: types.NodeStatusUp},
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, beat.Success, beat.Message)
+	assert.Equal(t, types.NodeStatusDraining, nodeStatus("drain-n1"))
+
+	// 重复调用不重新计时
+	resp, err = registry.PrepareShutdown(ctx, &pb.PrepareShutdownRequest{NodeId: "drain-n1", GracePeriodSeconds: 60})
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.Equal(t, "node is already draining", resp.Message)
+	assert.Zero(t, resp.GracePeriodSeconds)
+
+	// 宽限期结束后节点被移除并推送 NODE_REMOVED
+	require.Eventually(t, func() bool { return nodeStatus("drain-n1") == "" }, 3*time.Second, 20*time.Millisecond)
+	for event = nextEvent(t, ch); event.EventType != "NODE_REMOVED"; event = nextEvent(t, ch) {
+	}
+	assert.Len(t, event.Nodes, 1)
+	assert.Equal(t, types.NodeStatusUp, nodeStatus("drain-n2"))
+}
+
+// TestPrepareShutdownReregisteredNode 验证宽限期内重新注册的节点恢复服务发现且不会被移除
+func TestPrepareShutdownReregisteredNode(t *testing.T) {
+	ctx := context.Background()
+	registry := handler.NewRegistryHandler(nil)
+	registerDrainNodes(t, registry, "reregister-order", "drain-r1")
+
+	resp, err := registry.PrepareShutdown(ctx, &pb.PrepareShutdownRequest{NodeId: "drain-r1", GracePeriodSeconds: 1})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+	assert.NotContains(t, discoveredNodeIds(t, registry, "reregister-order"), "drain-r1")
+
+	registerDrainNodes(t, registry, "reregister-order", "drain-r1")
+	assert.Equal(t, types.NodeStatusUp, nodeStatus("drain-r1"))
+
+	time.Sleep(1500 * time.Millisecond)
+	assert.Equal(t, types.NodeStatusUp, nodeStatus("drain-r1"))
+	assert.Contains(t, discoveredNodeIds(t, registry, "reregister-order"), "drain-r1")
+}
+
+// TestPrepareShutdownRequest 验证节点ID必填、未知节点幂等返回成功、宽限期取默认值和上限
+func TestPrepareShutdownRequest(t *testing.T) {
+	ctx := context.Background()
+	registry := handler.NewRegistryHandler(nil)
+
+	resp, err := registry.PrepareShutdown(ctx, &pb.PrepareShutdownRequest{})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+
+	resp, err = registry.PrepareShutdown(ctx, &pb.PrepareShutdownRequest{NodeId: "drain-missing"})
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.Zero(t, resp.GracePeriodSeconds)
+
+	registerDrainNodes(t, registry, "grace-order", "drain-d1", "drain-d2")
+	resp, err = registry.PrepareShutdown(ctx, &pb.PrepareShutdownRequest{NodeId: "drain-d1"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(handler.DefaultDrainGracePeriod/time.Second), resp.GracePeriodSeconds)
+
+	resp, err = registry.PrepareShutdown(ctx, &pb.PrepareShutdownRequest{NodeId: "drain-d2", GracePeriodSeconds: 3600})
+	require.NoError(t, err)
+	assert.Equal(t, int32(handler.MaxDrainGracePeriod/time.Second), resp.GracePeriodSeconds)
+	removeTime, err := time.ParseInLocation("2006-01-02 15:04:05", resp.RemoveTime, time.Local)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(handler.MaxDrainGracePeriod), removeTime, 2*time.Second)
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gateway/internal/servicecenter/cache"
+	"gateway/internal/servicecenter/server/handler"
+	pb "gateway/internal/servicecenter/server/proto"
+	"gateway/internal/servicecenter/types"
+)
+
+// registerSelectService 在 select 命名空间下注册服务，测试结束后注销
+// 服务缓存为全局单例，各测试使用不同的服务名互不影响
+func registerSelectService(t *testing.T, registry *handler.RegistryHandler, serviceName string, metadata map[string]string) {
+	t.Helper()
+	ctx := context.Background()
+	cache.GetGlobalCache().SetNamespace(ctx, &types.Namespace{TenantId: "default", NamespaceId: "select", ActiveFlag: "Y"})
+	resp, err := registry.RegisterService(ctx, &pb.Service{NamespaceId: "select", ServiceName: serviceName, Metadata: metadata})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Message)
+	t.Cleanup(func() {
+		registry.UnregisterService(ctx, &pb.ServiceKey{NamespaceId: "select", GroupName: "DEFAULT_GROUP", ServiceName: serviceName})
+	})
+}
+
+// registerSelectNode 为服务注册节点，测试结束后注销
+func registerSelectNode(t *testing.T, registry *handler.RegistryHandler, serviceName string, node *pb.Node) {
+	t.Helper()
+	ctx := context.Background()
+	node.NamespaceId = "select"
+	node.ServiceName = serviceName
+	node.IpAddress = "10.0.2.1"
+	resp, err := registry.RegisterNode(ctx, node)
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Message)
+	t.Cleanup(func() { registry.UnregisterNode(ctx, &pb.NodeKey{NodeId: node.NodeId}) })
+}
+
+// selectCounts 多次选择实例，统计各节点被选中的次数
+func selectCounts(t *testing.T, registry *handler.RegistryHandler, req *pb.SelectInstanceRequest, times int) map[string]int {
+	t.Helper()
+	counts := make(map[string]int)
+	for i := 0; i < times; i++ {
+		resp, err := registry.SelectInstance(context.Background(), req)
+		require.NoError(t, err)
+		require.True(t, resp.Success, resp.Message)
+		counts[resp.Node.NodeId]++
+	}
+	return counts
+}
+
+// TestSelectInstanceWeightedRandom 验证默认按权重随机选择，只选择运行中且健康的节点
+func TestSelectInstanceWeightedRandom(t *testing.T) {
+	registry := handler.NewRegistryHandler(nil)
+	registerSelectService(t, registry, "select-weighted", nil)
+	registerSelectNode(t, registry, "select-weighted", &pb.Node{NodeId: "weighted-heavy", PortNumber: 8080, Weight: 3})
+	registerSelectNode(t, registry, "select-weighted", &pb.Node{NodeId: "weighted-light", PortNumber: 8081, Weight: 1})
+	registerSelectNode(t, registry, "select-weighted", &pb.Node{NodeId: "weighted-unhealthy", PortNumber: 8082, Weight: 100,
+		HealthyStatus: types.HealthyStatusUnhealthy})
+	registerSelectNode(t, registry, "select-weighted", &pb.Node{NodeId: "weighted-down", PortNumber: 8083, Weight: 100,
+		InstanceStatus: types.NodeStatusDown})
+
+	req := &pb.SelectInstanceRequest{NamespaceId: "select", GroupName: "DEFAULT_GROUP", ServiceName: "select-weighted"}
+	resp, err := registry.SelectInstance(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Message)
+	assert.Equal(t, handler.LoadBalanceWeightedRandom, resp.Strategy)
+	assert.Equal(t, "10.0.2.1:"+map[string]string{"weighted-heavy": "8080", "weighted-light": "8081"}[resp.Node.NodeId], resp.Address)
+	assert.False(t, resp.SameZone)
+
+	counts := selectCounts(t, registry, req, 400)
+	assert.Len(t, counts, 2, "不健康和已停止的节点不参与选择")
+	assert.InDelta(t, 300, counts["weighted-heavy"], 80, "按3:1的权重选择")
+	assert.InDelta(t, 100, counts["weighted-light"], 80)
+
+	// 不支持的策略使用加权随机
+	req.Strategy = "ROUND_ROBIN"
+	resp, err = registry.SelectInstance(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, handler.LoadBalanceWeightedRandom, resp.Strategy)
+}
+
+// TestSelectInstanceZoneAffinity 验证服务配置可用区亲和时优先选择调用方同可用区的节点，
+// 同可用区没有可用节点时跨可用区选择，请求指定的策略优先于服务配置
+func TestSelectInstanceZoneAffinity(t *testing.T) {
+	registry := handler.NewRegistryHandler(nil)
+	registerSelectService(t, registry, "select-zone", map[string]string{handler.MetadataKeyLoadBalanceStrategy: "zone_affinity"})
+	registerSelectNode(t, registry, "select-zone", &pb.Node{NodeId: "zone-a-1", PortNumber: 8080,
+		Metadata: map[string]string{handler.MetadataKeyZone: "zone-a"}})
+	registerSelectNode(t, registry, "select-zone", &pb.Node{NodeId: "zone-b-1", PortNumber: 8081,
+		Metadata: map[string]string{handler.MetadataKeyZone: "zone-b"}})
+	registerSelectNode(t, registry, "select-zone", &pb.Node{NodeId: "zone-c-1", PortNumber: 8082, Weight: 10,
+		Metadata: map[string]string{handler.MetadataKeyZone: "zone-c"}, HealthyStatus: types.HealthyStatusUnhealthy})
+
+	req := &pb.SelectInstanceRequest{NamespaceId: "select", GroupName: "DEFAULT_GROUP", ServiceName: "select-zone", Zone: "zone-a"}
+	resp, err := registry.SelectInstance(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Message)
+	assert.Equal(t, handler.LoadBalanceZoneAffinity, resp.Strategy)
+	assert.True(t, resp.SameZone)
+	assert.Equal(t, map[string]int{"zone-a-1": 50}, selectCounts(t, registry, req, 50))
+
+	// 同可用区只有不健康的节点时跨可用区选择
+	req.Zone = "zone-c"
+	resp, err = registry.SelectInstance(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Message)
+	assert.False(t, resp.SameZone)
+	assert.NotEqual(t, "zone-c-1", resp.Node.NodeId)
+
+	// 请求指定加权随机时不考虑可用区，选中的节点在同可用区时仍标记 sameZone
+	req.Zone = "zone-a"
+	req.Strategy = handler.LoadBalanceWeightedRandom
+	counts := make(map[string]int)
+	for i := 0; i < 200; i++ {
+		resp, err = registry.SelectInstance(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, handler.LoadBalanceWeightedRandom, resp.Strategy)
+		assert.Equal(t, resp.Node.NodeId == "zone-a-1", resp.SameZone)
+		counts[resp.Node.NodeId]++
+	}
+	assert.Len(t, counts, 2)
+}
+
+// TestSelectInstanceErrors 验证命名空间、服务名、服务不存在和没有可用节点时的返回
+func TestSelectInstanceErrors(t *testing.T) {
+	ctx := context.Background()
+	registry := handler.NewRegistryHandler(nil)
+	registerSelectService(t, registry, "select-empty", nil)
+	registerSelectNode(t, registry, "select-empty", &pb.Node{NodeId: "empty-down", PortNumber: 8080, InstanceStatus: types.NodeStatusDown})
+
+	resp, err := registry.SelectInstance(ctx, &pb.SelectInstanceRequest{NamespaceId: "select-missing", ServiceName: "select-empty"})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Contains(t, resp.Message, "namespace not found: select-missing")
+
+	_, err = registry.SelectInstance(ctx, &pb.SelectInstanceRequest{NamespaceId: "select"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err = registry.SelectInstance(ctx, &pb.SelectInstanceRequest{NamespaceId: "select", GroupName: "DEFAULT_GROUP", ServiceName: "select-unknown"})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, "service not found: select-unknown", resp.Message)
+
+	resp, err = registry.SelectInstance(ctx, &pb.SelectInstanceRequest{NamespaceId: "select", GroupName: "DEFAULT_GROUP", ServiceName: "select-empty"})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, "no available nodes", resp.Message)
+	assert.Equal(t, handler.LoadBalanceWeightedRandom, resp.Strategy)
+	assert.Nil(t, resp.Node)
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gateway/internal/servicecenter/cache"
+	"gateway/internal/servicecenter/server/handler"
+	pb "gateway/internal/servicecenter/server/proto"
+	"gateway/internal/servicecenter/server/subscriber"
+	"gateway/internal/servicecenter/types"
+)
+
+// fakeSubscribeStream 记录推送事件的订阅流，sendErr 不为空时推送失败
+type fakeSubscribeStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	mu      sync.Mutex
+	events  []*pb.ServiceChangeEvent
+	sendErr error
+}
+
+func (s *fakeSubscribeStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeSubscribeStream) Send(event *pb.ServiceChangeEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+// countEvents 统计指定类型的已推送事件
+func (s *fakeSubscribeStream) countEvents(eventType string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, event := range s.events {
+		if event.EventType == eventType {
+			count++
+		}
+	}
+	return count
+}
+
+// subscribe 在后台发起订阅，返回接收订阅结束错误的 channel
+func subscribe(t *testing.T, registry *handler.RegistryHandler, stream *fakeSubscribeStream) <-chan error {
+	t.Helper()
+	cache.GetGlobalCache().SetNamespace(context.Background(), &types.Namespace{TenantId: "default", NamespaceId: "keepalive", ActiveFlag: "Y"})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- registry.SubscribeServices(&pb.SubscribeServicesRequest{
+			NamespaceId:  "keepalive",
+			ServiceNames: []string{"order"},
+		}, stream)
+	}()
+	return done
+}
+
+// waitDone 等待订阅结束
+func waitDone(t *testing.T, done <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(2 * time.Second):
+		t.Fatal("subscription did not end")
+		return nil
+	}
+}
+
+// subscriberCount 当前订阅 order 服务的订阅者数量
+func subscriberCount(registry *handler.RegistryHandler) int {
+	return registry.GetServiceSubscriber().GetSubscriberCount("default", "keepalive", "DEFAULT_GROUP", "order")
+}
+
+// TestSubscribeServicesPing 验证订阅流按保活间隔推送PING事件，客户端断开后订阅被清理
+func TestSubscribeServicesPing(t *testing.T) {
+	registry := handler.NewRegistryHandler(nil)
+	registry.SetPingInterval(20 * time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &fakeSubscribeStream{ctx: ctx}
+	done := subscribe(t, registry, stream)
+
+	require.Eventually(t, func() bool { return stream.countEvents(subscriber.EventTypePing) >= 3 },
+		2*time.Second, 5*time.Millisecond)
+	// 订阅后首先推送服务当前状态
+	assert.Equal(t, 1, stream.countEvents("SERVICE_NOT_FOUND"))
+	assert.Equal(t, 1, subscriberCount(registry))
+
+	// 持续推送保活事件的订阅不会被当作空闲订阅清理
+	assert.Zero(t, registry.GetServiceSubscriber().ReapIdleSubscribers(200*time.Millisecond))
+
+	cancel()
+	assert.ErrorIs(t, waitDone(t, done), context.Canceled)
+	assert.Zero(t, subscriberCount(registry))
+}
+
+// TestSubscribeServicesSendFailure 验证推送失败时结束订阅流并清理订阅
+func TestSubscribeServicesSendFailure(t *testing.T) {
+	registry := handler.NewRegistryHandler(nil)
+	registry.SetPingInterval(20 * time.Millisecond)
+	sendErr := errors.New("transport is closing")
+	stream := &fakeSubscribeStream{ctx: context.Background(), sendErr: sendErr}
+
+	assert.ErrorIs(t, waitDone(t, subscribe(t, registry, stream)), sendErr)
+	assert.Zero(t, subscriberCount(registry))
+}
+
+// TestSubscribeServicesReaped 验证空闲订阅被清理后订阅流返回 Unavailable，客户端应重新订阅
+func TestSubscribeServicesReaped(t *testing.T) {
+	registry := handler.NewRegistryHandler(nil)
+	registry.SetPingInterval(time.Hour)
+	stream := &fakeSubscribeStream{ctx: context.Background()}
+	done := subscribe(t, registry, stream)
+
+	require.Eventually(t, func() bool { return stream.countEvents("SERVICE_NOT_FOUND") == 1 },
+		2*time.Second, 5*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, 1, registry.GetServiceSubscriber().ReapIdleSubscribers(20*time.Millisecond))
+
+	err := waitDone(t, done)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Zero(t, stream.countEvents(subscriber.EventTypePing))
+	assert.Zero(t, subscriberCount(registry))
+}
@@ -0,0 +1,58 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/servicecenter/cache"
+	"gateway/internal/servicecenter/types"
+)
+
+// TestRegistrySnapshotRoundTrip 测试注册中心缓存快照的收集和恢复，已存在的数据不被快照覆盖
+func TestRegistrySnapshotRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	beat := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	source := &cache.ServiceCache{}
+	source.SetNamespace(ctx, &types.Namespace{TenantId: "default", NamespaceId: "public", NamespaceName: "公共"})
+	source.SetService(ctx, &types.Service{TenantId: "default", NamespaceId: "public", GroupName: "g1", ServiceName: "order"})
+	source.AddNode(ctx, &types.ServiceNode{NodeId: "n1", TenantId: "default", NamespaceId: "public", GroupName: "g1", ServiceName: "order", Ephemeral: "N", LastBeatTime: &beat})
+	source.AddNode(ctx, &types.ServiceNode{NodeId: "n2", TenantId: "default", NamespaceId: "public", GroupName: "g1", ServiceName: "order", Ephemeral: "Y"})
+	source.SetService(ctx, &types.Service{TenantId: "default", NamespaceId: "public", GroupName: "g1", ServiceName: "user", ServiceDescription: "快照中的描述"})
+
+	// 经过JSON序列化，与写入磁盘后恢复一致
+	data, err := json.Marshal(cache.CollectRegistrySnapshot(source))
+	require.NoError(t, err)
+	var snapshot cache.RegistrySnapshot
+	require.NoError(t, json.Unmarshal(data, &snapshot))
+	require.Len(t, snapshot.Namespaces, 1)
+	require.Len(t, snapshot.Services, 2)
+
+	target := &cache.ServiceCache{}
+	target.SetService(ctx, &types.Service{TenantId: "default", NamespaceId: "public", GroupName: "g1", ServiceName: "user", ServiceDescription: "数据库中的描述"})
+
+	namespaceCount, serviceCount := cache.RestoreRegistrySnapshot(ctx, target, &snapshot)
+	assert.Equal(t, 1, namespaceCount)
+	assert.Equal(t, 1, serviceCount)
+
+	namespace, ok := target.GetNamespace(ctx, "default", "public")
+	require.True(t, ok)
+	assert.Equal(t, "公共", namespace.NamespaceName)
+
+	nodes, ok := target.GetNodes(ctx, "default", "public", "g1", "order")
+	require.True(t, ok)
+	require.Len(t, nodes, 2)
+	node, ok := target.GetNode(ctx, "default", "n1")
+	require.True(t, ok, "恢复的节点应建立节点索引")
+	require.NotNil(t, node.LastBeatTime)
+	assert.True(t, beat.Equal(*node.LastBeatTime))
+
+	user, ok := target.GetService(ctx, "default", "public", "g1", "user")
+	require.True(t, ok)
+	assert.Equal(t, "数据库中的描述", user.ServiceDescription)
+}
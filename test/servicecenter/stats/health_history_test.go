@@ -0,0 +1,74 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/servicecenter/stats"
+	"gateway/internal/servicecenter/types"
+)
+
+// TestHealthTrackerFlapping 测试窗口内健康状态切换次数达到阈值时判定抖动并隔离，隔离到期后重新计数
+func TestHealthTrackerFlapping(t *testing.T) {
+	tracker := stats.NewHealthTracker(stats.FlapPolicy{Threshold: 3, Window: time.Minute, Quarantine: 5 * time.Minute})
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	healthy, unhealthy := types.HealthyStatusHealthy, types.HealthyStatusUnhealthy
+
+	assert.False(t, tracker.Observe("t1", "n1", healthy, "节点注册", start), "首次记录不计为切换")
+	assert.False(t, tracker.Observe("t1", "n1", healthy, "心跳", start.Add(time.Second)), "状态未变化不计为切换")
+	assert.False(t, tracker.Observe("t1", "n1", unhealthy, "心跳超时", start.Add(10*time.Second)))
+	assert.False(t, tracker.Observe("t1", "n1", healthy, "心跳", start.Add(20*time.Second)))
+	assert.True(t, tracker.Observe("t1", "n1", unhealthy, "心跳超时", start.Add(30*time.Second)), "窗口内第3次切换判定为抖动")
+
+	now := start.Add(time.Minute)
+	assert.True(t, tracker.InQuarantine("t1", "n1", now))
+	assert.False(t, tracker.InQuarantine("t1", "n2", now))
+	assert.False(t, tracker.Observe("t1", "n1", healthy, "心跳", now), "隔离期内不重复判定")
+
+	history := tracker.History("t1", "n1", now)
+	require.NotNil(t, history)
+	assert.True(t, history.Flapping)
+	assert.Equal(t, 1, history.FlapCount)
+	assert.Equal(t, healthy, history.CurrentStatus)
+	require.Len(t, history.Transitions, 4)
+	assert.Equal(t, "心跳超时", history.Transitions[0].Reason)
+	assert.Equal(t, start.Add(30*time.Second+5*time.Minute), *history.QuarantineUntil)
+
+	assert.Empty(t, tracker.Sweep(start.Add(4*time.Minute)), "未到期不解除隔离")
+	released := tracker.Sweep(start.Add(6 * time.Minute))
+	assert.Equal(t, []stats.NodeRef{{TenantId: "t1", NodeId: "n1"}}, released)
+	assert.False(t, tracker.InQuarantine("t1", "n1", start.Add(6*time.Minute)))
+
+	// 隔离解除前的切换不再计入，解除后需重新累计到阈值
+	after := start.Add(6*time.Minute + time.Second)
+	assert.False(t, tracker.Observe("t1", "n1", unhealthy, "心跳超时", after))
+	assert.False(t, tracker.Observe("t1", "n1", healthy, "心跳", after.Add(time.Second)))
+	assert.True(t, tracker.Observe("t1", "n1", unhealthy, "心跳超时", after.Add(2*time.Second)))
+	assert.Equal(t, 2, tracker.History("t1", "n1", after.Add(2*time.Second)).FlapCount)
+}
+
+// TestHealthTrackerWindow 测试统计窗口外的切换不计入抖动判定，以及长时间无变化的记录被清理
+func TestHealthTrackerWindow(t *testing.T) {
+	tracker := stats.NewHealthTracker(stats.FlapPolicy{Threshold: 3, Window: time.Minute})
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tracker.Observe("t1", "n1", types.HealthyStatusHealthy, "节点注册", start)
+	for i := 1; i <= 6; i++ {
+		status := types.HealthyStatusUnhealthy
+		if i%2 == 0 {
+			status = types.HealthyStatusHealthy
+		}
+		assert.False(t, tracker.Observe("t1", "n1", status, "", start.Add(time.Duration(i)*40*time.Second)), "每分钟最多两次切换不判定为抖动")
+	}
+	assert.Len(t, tracker.History("t1", "n1", start).Transitions, 6)
+
+	tracker.Forget("t1", "n1")
+	assert.Nil(t, tracker.History("t1", "n1", start))
+
+	tracker.Observe("t1", "n2", types.HealthyStatusHealthy, "节点注册", start)
+	assert.Empty(t, tracker.Sweep(start.Add(2*time.Hour)))
+	assert.Nil(t, tracker.History("t1", "n2", start.Add(2*time.Hour)), "长时间无变化的记录被清理")
+}
@@ -0,0 +1,66 @@
+package stats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/servicecenter/cache"
+	"gateway/internal/servicecenter/stats"
+	"gateway/internal/servicecenter/types"
+)
+
+// TestCollectNamespaceStats 测试按分组统计服务、节点健康状态、变动和心跳延迟
+func TestCollectNamespaceStats(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	recent := now.Add(-2 * time.Second)
+	stale := now.Add(-20 * time.Second)
+
+	cache.GetGlobalCache().SetService(ctx, &types.Service{
+		TenantId: "default", NamespaceId: "stats-ns", GroupName: "g1", ServiceName: "order",
+		Nodes: []*types.ServiceNode{
+			{NodeId: "n1", HealthyStatus: types.HealthyStatusHealthy, LastBeatTime: &recent},
+			{NodeId: "n2", HealthyStatus: types.HealthyStatusUnhealthy, LastBeatTime: &stale},
+		},
+	})
+	cache.GetGlobalCache().SetService(ctx, &types.Service{
+		TenantId: "default", NamespaceId: "stats-ns", GroupName: "g2", ServiceName: "user",
+		Nodes: []*types.ServiceNode{
+			{NodeId: "n3", HealthyStatus: types.HealthyStatusUnknown},
+		},
+	})
+	cache.GetGlobalCache().SetService(ctx, &types.Service{
+		TenantId: "default", NamespaceId: "other-ns", GroupName: "g1", ServiceName: "order",
+	})
+	stats.RecordRegister("default", "stats-ns", "g1")
+	stats.RecordRegister("default", "stats-ns", "g1")
+	stats.RecordDeregister("default", "stats-ns", "g2")
+
+	result := stats.CollectNamespaceStats("default", "stats-ns", "")
+	require.Len(t, result.Groups, 2)
+	assert.Equal(t, 2, result.Summary.ServiceCount)
+	assert.Equal(t, 3, result.Summary.NodeCount)
+	assert.Equal(t, 1, result.Summary.HealthyNodes)
+	assert.Equal(t, 1, result.Summary.UnhealthyNodes)
+	assert.Equal(t, 1, result.Summary.UnknownNodes)
+	assert.Equal(t, 2, result.Summary.Registrations)
+	assert.Equal(t, 1, result.Summary.Deregistrations)
+	assert.InDelta(t, 3.0/60, result.Summary.ChurnPerMinute, 1e-9)
+
+	g1 := result.Groups[0]
+	assert.Equal(t, "g1", g1.GroupName)
+	assert.Equal(t, 1, g1.HeartbeatLatency["<5s"])
+	assert.Equal(t, 1, g1.HeartbeatLatency["15s-30s"])
+	assert.Equal(t, 1, result.Groups[1].HeartbeatLatency[stats.HeartbeatBucketNone])
+
+	filtered := stats.CollectNamespaceStats("default", "stats-ns", "g2")
+	require.Len(t, filtered.Groups, 1)
+	assert.Equal(t, "g2", filtered.Summary.GroupName)
+	assert.Equal(t, 1, filtered.Summary.ServiceCount)
+	assert.Equal(t, 0, filtered.Summary.Registrations)
+	assert.Equal(t, 1, filtered.Summary.Deregistrations)
+}
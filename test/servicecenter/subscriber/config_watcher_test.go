@@ -0,0 +1,44 @@
+package subscriber
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "gateway/internal/servicecenter/server/proto"
+	"gateway/internal/servicecenter/server/subscriber"
+	"gateway/internal/servicecenter/types"
+)
+
+// TestNotifyMatchingWatchers 验证灰度推送只发送给命中规则的监听者，并能列出监听实例
+func TestNotifyMatchingWatchers(t *testing.T) {
+	watcher := subscriber.NewConfigWatcher()
+	ctx := context.Background()
+
+	grayCh := watcher.Watch(ctx, "default", "ns", "DEFAULT_GROUP", []string{"app.yaml"}, "w1")
+	watcher.SetWatcherClient("w1", types.ConfigClient{ClientIp: "10.0.0.1"})
+	officialCh := watcher.Watch(ctx, "default", "ns", "DEFAULT_GROUP", []string{"app.yaml"}, "w2")
+	watcher.SetWatcherClient("w2", types.ConfigClient{ClientIp: "10.0.0.2", Labels: map[string]string{"env": "prod"}})
+	otherCh := watcher.Watch(ctx, "default", "ns", "DEFAULT_GROUP", []string{"other.yaml"}, "w3")
+	watcher.SetWatcherClient("w3", types.ConfigClient{ClientIp: "10.0.0.1"})
+
+	clients := watcher.ListWatcherClients("default", "ns", "DEFAULT_GROUP", "app.yaml")
+	require.Len(t, clients, 2)
+	assert.Equal(t, "w1", clients[0].WatcherId)
+	assert.Equal(t, "10.0.0.1", clients[0].ClientIp)
+	assert.Equal(t, "prod", clients[1].Labels["env"])
+
+	gray := &types.ConfigGrayRelease{ConfigContent: "gray", ClientIps: []string{"10.0.0.1"}}
+	event := &pb.ConfigChangeEvent{EventType: "CONFIG_UPDATED", ConfigDataId: "app.yaml"}
+	notified := watcher.NotifyMatchingWatchers("default", "ns", "DEFAULT_GROUP", "app.yaml", event, gray.Matches)
+	assert.Equal(t, 1, notified)
+
+	assert.Same(t, event, <-grayCh)
+	assert.Empty(t, officialCh, "未命中灰度规则的监听者不接收灰度推送")
+	assert.Empty(t, otherCh, "未监听该配置的监听者不接收推送")
+
+	watcher.Unwatch("w1")
+	assert.Len(t, watcher.ListWatcherClients("default", "ns", "DEFAULT_GROUP", "app.yaml"), 1)
+}
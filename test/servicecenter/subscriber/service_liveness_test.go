@@ -0,0 +1,91 @@
+package subscriber
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "gateway/internal/servicecenter/server/proto"
+	"gateway/internal/servicecenter/server/subscriber"
+)
+
+// assertClosed 校验订阅 channel 已被关闭
+func assertClosed(t *testing.T, ch <-chan *pb.ServiceChangeEvent) {
+	t.Helper()
+	select {
+	case _, ok := <-ch:
+		assert.False(t, ok, "channel should be closed")
+	case <-time.After(time.Second):
+		t.Fatal("channel was not closed")
+	}
+}
+
+// TestReapIdleSubscribers 验证超过空闲超时未刷新的批量订阅和命名空间订阅被清理，刷新过的订阅保留
+func TestReapIdleSubscribers(t *testing.T) {
+	subs := subscriber.NewServiceSubscriber()
+	ctx := context.Background()
+
+	idleCh := subs.SubscribeMultipleServices(ctx, "default", "ns", "DEFAULT_GROUP", []string{"order", "user"}, "idle")
+	activeCh := subs.SubscribeMultipleServices(ctx, "default", "ns", "DEFAULT_GROUP", []string{"order"}, "active")
+	idleNsCh := subs.SubscribeNamespace(ctx, "default", "ns", "DEFAULT_GROUP", "idle-ns")
+	require.Equal(t, 2, subs.GetSubscriberCount("default", "ns", "DEFAULT_GROUP", "order"))
+
+	time.Sleep(60 * time.Millisecond)
+	subs.Touch("active")
+	subs.Touch("unknown") // 未登记的订阅者不受影响
+
+	assert.Equal(t, 2, subs.ReapIdleSubscribers(50*time.Millisecond))
+	assertClosed(t, idleCh)
+	assertClosed(t, idleNsCh)
+	assert.Equal(t, 1, subs.GetSubscriberCount("default", "ns", "DEFAULT_GROUP", "order"))
+	assert.Zero(t, subs.GetSubscriberCount("default", "ns", "DEFAULT_GROUP", "user"))
+
+	// 保留的订阅仍能收到推送
+	event := &pb.ServiceChangeEvent{EventType: "SERVICE_UPDATED", ServiceName: "order"}
+	subs.NotifyServiceChange("default", "ns", "DEFAULT_GROUP", "order", event)
+	select {
+	case received := <-activeCh:
+		assert.Same(t, event, received)
+	case <-time.After(time.Second):
+		t.Fatal("active subscriber did not receive the event")
+	}
+
+	// 已清理的订阅者再次注销不会重复关闭 channel
+	subs.UnsubscribeMultipleServices("idle")
+	subs.UnsubscribeNamespace("default", "ns", "DEFAULT_GROUP", "idle-ns")
+	assert.Zero(t, subs.ReapIdleSubscribers(50*time.Millisecond))
+}
+
+// TestStartIdleReaper 验证后台清理按空闲超时关闭僵尸订阅，停止信号关闭后不再清理
+func TestStartIdleReaper(t *testing.T) {
+	subs := subscriber.NewServiceSubscriber()
+	ctx := context.Background()
+	stopCh := make(chan struct{})
+
+	idleCh := subs.SubscribeMultipleServices(ctx, "default", "ns", "DEFAULT_GROUP", []string{"order"}, "idle")
+	subs.StartIdleReaper(stopCh, 30*time.Millisecond)
+	assertClosed(t, idleCh)
+	assert.Zero(t, subs.GetSubscriberCount("default", "ns", "DEFAULT_GROUP", "order"))
+
+	close(stopCh)
+	time.Sleep(20 * time.Millisecond)
+	laterCh := subs.SubscribeMultipleServices(ctx, "default", "ns", "DEFAULT_GROUP", []string{"order"}, "later")
+	time.Sleep(100 * time.Millisecond)
+	select {
+	case _, ok := <-laterCh:
+		assert.True(t, ok, "reaper should stop after stopCh is closed")
+	default:
+	}
+	assert.Equal(t, 1, subs.GetSubscriberCount("default", "ns", "DEFAULT_GROUP", "order"))
+}
+
+// TestNewPingEvent 验证保活事件类型
+func TestNewPingEvent(t *testing.T) {
+	event := subscriber.NewPingEvent()
+	assert.Equal(t, subscriber.EventTypePing, event.EventType)
+	assert.NotEmpty(t, event.Timestamp)
+	assert.Equal(t, 3*subscriber.DefaultPingInterval, subscriber.DefaultIdleTimeout)
+}
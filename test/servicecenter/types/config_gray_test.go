@@ -0,0 +1,52 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/servicecenter/types"
+)
+
+// TestConfigGrayRelease 验证灰度状态写入扩展属性后可解析，清除时保留扩展属性中的其他键
+func TestConfigGrayRelease(t *testing.T) {
+	config := &types.ConfigData{ConfigContent: "timeout=1", Md5Value: "official", ExtProperty: `{"owner":"team-a"}`}
+	assert.Nil(t, config.GrayRelease())
+
+	gray := &types.ConfigGrayRelease{ConfigContent: "timeout=2", ClientIps: []string{" 10.0.0.1 ", ""}}
+	require.NoError(t, gray.Validate())
+	assert.Equal(t, []string{"10.0.0.1"}, gray.ClientIps)
+	assert.NotEmpty(t, gray.Md5Value)
+	require.NoError(t, config.SetGrayRelease(gray))
+
+	parsed := config.GrayRelease()
+	require.NotNil(t, parsed)
+	assert.Equal(t, "timeout=2", parsed.ConfigContent)
+
+	grayClient := types.ConfigClient{ClientIp: "10.0.0.1"}
+	assert.Equal(t, "timeout=2", config.ForClient(grayClient).ConfigContent)
+	assert.Equal(t, gray.Md5Value, config.ForClient(grayClient).Md5Value)
+	assert.Same(t, config, config.ForClient(types.ConfigClient{ClientIp: "10.0.0.2"}), "未命中灰度规则的客户端获取正式配置")
+	assert.Equal(t, "timeout=1", config.ConfigContent, "灰度期间正式配置不变")
+
+	require.NoError(t, config.SetGrayRelease(nil))
+	assert.Nil(t, config.GrayRelease())
+	assert.JSONEq(t, `{"owner":"team-a"}`, config.ExtProperty)
+}
+
+// TestConfigGrayReleaseMatches 验证灰度规则：命中任意IP或包含全部标签
+func TestConfigGrayReleaseMatches(t *testing.T) {
+	assert.Error(t, (&types.ConfigGrayRelease{ConfigContent: "a"}).Validate(), "灰度规则不能为空")
+	assert.Error(t, (&types.ConfigGrayRelease{ClientIps: []string{"10.0.0.1"}}).Validate(), "灰度内容不能为空")
+
+	gray := &types.ConfigGrayRelease{
+		ConfigContent: "a",
+		ClientIps:     []string{"10.0.0.1"},
+		Labels:        map[string]string{"env": "gray", "zone": "az1"},
+	}
+	assert.True(t, gray.Matches(types.ConfigClient{ClientIp: "10.0.0.1"}))
+	assert.True(t, gray.Matches(types.ConfigClient{ClientIp: "10.0.0.9", Labels: map[string]string{"env": "gray", "zone": "az1", "app": "order"}}))
+	assert.False(t, gray.Matches(types.ConfigClient{ClientIp: "10.0.0.9", Labels: map[string]string{"env": "gray"}}))
+	assert.False(t, gray.Matches(types.ConfigClient{}))
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/servicecenter/types"
+)
+
+const testExtProperty = `{"alertEnabled": "Y", "metadataSchema": {
+	"service": [{"key": "version", "required": true, "format": "SEMVER"}],
+	"node": [
+		{"key": "zone", "required": true, "format": "ENUM", "values": ["az1", "az2"]},
+		{"key": "shard", "format": "NUMBER"},
+		{"key": "owner", "format": "REGEX", "pattern": "^team-[a-z]+$"}
+	]
+}}`
+
+// TestParseMetadataSchemaNotConfigured 测试未配置元数据规范
+func TestParseMetadataSchemaNotConfigured(t *testing.T) {
+	for _, extProperty := range []string{"", "not json", `{"alertEnabled": "Y"}`, `{"metadataSchema": {}}`} {
+		schema, err := types.ParseMetadataSchemaFromExtProperty(extProperty)
+		assert.NoError(t, err, extProperty)
+		assert.Nil(t, schema, extProperty)
+		// 未配置规范时不做校验
+		assert.NoError(t, schema.ValidateNode(nil))
+	}
+}
+
+// TestParseMetadataSchemaInvalid 测试错误的规范配置
+func TestParseMetadataSchemaInvalid(t *testing.T) {
+	cases := []string{
+		`{"metadataSchema": {"node": [{"required": true}]}}`,
+		`{"metadataSchema": {"node": [{"key": "zone", "format": "ENUM"}]}}`,
+		`{"metadataSchema": {"node": [{"key": "owner", "format": "REGEX", "pattern": "("}]}}`,
+		`{"metadataSchema": {"node": [{"key": "zone", "format": "IPV4"}]}}`,
+		`{"metadataSchema": []}`,
+	}
+	for _, extProperty := range cases {
+		_, err := types.ParseMetadataSchemaFromExtProperty(extProperty)
+		assert.Error(t, err, extProperty)
+	}
+}
+
+// TestMetadataSchemaValidate 测试按规范校验服务和节点元数据
+func TestMetadataSchemaValidate(t *testing.T) {
+	schema, err := types.ParseMetadataSchemaFromExtProperty(testExtProperty)
+	require.NoError(t, err)
+	require.NotNil(t, schema)
+
+	assert.NoError(t, schema.ValidateService(map[string]string{"version": "1.2.3"}))
+	assert.NoError(t, schema.ValidateService(map[string]string{"version": "v2.0.0-beta.1"}))
+	assert.ErrorContains(t, schema.ValidateService(nil), `service metadata "version" is required`)
+	assert.ErrorContains(t, schema.ValidateService(map[string]string{"version": "1.2"}), "semantic version")
+
+	assert.NoError(t, schema.ValidateNode(map[string]string{"zone": "az1", "shard": "3", "owner": "team-order"}))
+	// 可选键未设置时不校验格式
+	assert.NoError(t, schema.ValidateNode(map[string]string{"zone": "az2"}))
+
+	err = schema.ValidateNode(map[string]string{"zone": "az9", "shard": "x", "owner": "order"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `node metadata "zone" must be one of [az1, az2], got "az9"`)
+	assert.Contains(t, err.Error(), `"shard" must be a number`)
+	assert.Contains(t, err.Error(), `"owner" must match pattern`)
+}
+
+// TestNamespaceGetMetadataSchema 测试从命名空间扩展属性解析规范
+func TestNamespaceGetMetadataSchema(t *testing.T) {
+	namespace := &types.Namespace{NamespaceId: "public", ExtProperty: testExtProperty}
+	schema, err := namespace.GetMetadataSchema()
+	require.NoError(t, err)
+	assert.Len(t, schema.Node, 3)
+}
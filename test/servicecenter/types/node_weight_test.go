@@ -0,0 +1,51 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/servicecenter/types"
+)
+
+// TestNodeWeightOverride 测试手动调整的权重与注册权重分开保存，并优先用于服务发现
+func TestNodeWeightOverride(t *testing.T) {
+	node := &types.ServiceNode{Weight: 2, ExtProperty: `{"owner":"ops"}`}
+	_, ok := node.WeightOverride()
+	assert.False(t, ok)
+	assert.Equal(t, 2.0, node.DiscoveryWeight())
+
+	weight := 5.5
+	require.NoError(t, node.SetWeightOverride(&weight))
+	override, ok := node.WeightOverride()
+	assert.True(t, ok)
+	assert.Equal(t, 5.5, override)
+	assert.Equal(t, 5.5, node.DiscoveryWeight())
+	assert.Equal(t, 2.0, node.Weight, "注册权重保持不变")
+	assert.Contains(t, node.ExtProperty, `"owner":"ops"`, "扩展属性中的其他键保持不变")
+	assert.False(t, node.IsWeightDrained())
+
+	// 预热期内按手动调整的权重计算有效权重
+	node.RegisterTime = time.Now().Add(-30 * time.Second)
+	assert.InDelta(t, 2.75, node.EffectiveWeight(time.Minute, node.RegisterTime.Add(30*time.Second)), 0.001)
+
+	drained := 0.0
+	require.NoError(t, node.SetWeightOverride(&drained))
+	assert.True(t, node.IsWeightDrained(), "手动调整为0表示摘除流量")
+	assert.Equal(t, 0.0, node.DiscoveryWeight())
+
+	require.NoError(t, node.SetWeightOverride(nil))
+	_, ok = node.WeightOverride()
+	assert.False(t, ok)
+	assert.Equal(t, `{"owner":"ops"}`, node.ExtProperty)
+
+	node.ExtProperty = ""
+	require.NoError(t, node.SetWeightOverride(nil))
+	assert.Empty(t, node.ExtProperty)
+
+	node.ExtProperty = "not json"
+	assert.Error(t, node.SetWeightOverride(&weight))
+	assert.Equal(t, 2.0, node.DiscoveryWeight(), "扩展属性无法解析时使用注册权重")
+}
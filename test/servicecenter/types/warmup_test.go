@@ -0,0 +1,51 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gateway/internal/servicecenter/types"
+)
+
+// TestServiceWarmupDuration 测试解析服务元数据中的预热时长
+func TestServiceWarmupDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":                         0,
+		`{"version": "1.0.0"}`:     0,
+		`{"warmupSeconds": 60}`:    time.Minute,
+		`{"warmupSeconds": "90"}`:  90 * time.Second,
+		`{"warmupSeconds": "abc"}`: 0,
+		`{"warmupSeconds": -10}`:   0,
+		`{"warmupSeconds": "0.5"}`: 500 * time.Millisecond,
+		`not json`:                 0,
+	}
+	for metadataJson, expected := range cases {
+		service := &types.Service{MetadataJson: metadataJson}
+		assert.Equal(t, expected, service.WarmupDuration(), metadataJson)
+	}
+
+	var nilService *types.Service
+	assert.Zero(t, nilService.WarmupDuration())
+}
+
+// TestNodeEffectiveWeight 测试预热期内节点有效权重线性递增
+func TestNodeEffectiveWeight(t *testing.T) {
+	now := time.Now()
+	warmup := 100 * time.Second
+	node := &types.ServiceNode{Weight: 10, RegisterTime: now.Add(-25 * time.Second)}
+
+	assert.InDelta(t, 2.5, node.EffectiveWeight(warmup, now), 0.001)
+	// 未配置预热或预热结束时使用配置权重
+	assert.Equal(t, 10.0, node.EffectiveWeight(0, now))
+	assert.Equal(t, 10.0, node.EffectiveWeight(warmup, now.Add(2*time.Minute)))
+
+	// 刚注册时有效权重不低于配置权重的1%
+	node.RegisterTime = now
+	assert.InDelta(t, 0.1, node.EffectiveWeight(warmup, now), 0.001)
+
+	// 注册时间未知时不预热
+	node.RegisterTime = time.Time{}
+	assert.Equal(t, 10.0, node.EffectiveWeight(warmup, now))
+}
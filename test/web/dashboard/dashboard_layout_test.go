@@ -0,0 +1,32 @@
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gateway/web/views/hub0000/models"
+)
+
+// TestValidateDashboardWidgets 验证组件配置校验
+func TestValidateDashboardWidgets(t *testing.T) {
+	assert.NoError(t, models.ValidateDashboardWidgets(""))
+	assert.NoError(t, models.ValidateDashboardWidgets(`[
+		{"widgetId":"cpu","widgetType":"line","metrics":["cpuUsage"],"timeRangeMinutes":30,"refreshIntervalSeconds":10},
+		{"widgetId":"mem","widgetType":"gauge","metrics":["memoryUsage"]}
+	]`))
+
+	assert.Error(t, models.ValidateDashboardWidgets(`{"widgetId":"cpu"}`), "非数组")
+	assert.Error(t, models.ValidateDashboardWidgets(`[{"widgetType":"line"}]`), "缺少组件ID")
+	assert.Error(t, models.ValidateDashboardWidgets(`[{"widgetId":"cpu"},{"widgetId":"cpu"}]`), "组件ID重复")
+	assert.Error(t, models.ValidateDashboardWidgets(`[{"widgetId":"cpu","refreshIntervalSeconds":1}]`), "刷新间隔过短")
+	assert.Error(t, models.ValidateDashboardWidgets(`[{"widgetId":"cpu","timeRangeMinutes":-1}]`), "时间范围为负数")
+}
+
+// TestValidateDashboardTiming 验证时间范围和刷新间隔边界
+func TestValidateDashboardTiming(t *testing.T) {
+	assert.NoError(t, models.ValidateDashboardTiming(0, 0))
+	assert.NoError(t, models.ValidateDashboardTiming(models.DashboardMaxTimeRangeMinutes, models.DashboardMinRefreshSeconds))
+	assert.Error(t, models.ValidateDashboardTiming(models.DashboardMaxTimeRangeMinutes+1, 0))
+	assert.Error(t, models.ValidateDashboardTiming(60, models.DashboardMinRefreshSeconds-1))
+}
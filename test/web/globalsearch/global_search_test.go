@@ -0,0 +1,20 @@
+package globalsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gateway/web/views/hub0000/controllers"
+	"gateway/web/views/hub0000/models"
+)
+
+// TestResolveSearchTypes 验证命中类型解析：为空返回全部类型，忽略大小写、去重并丢弃不支持的类型
+func TestResolveSearchTypes(t *testing.T) {
+	assert.Equal(t, models.SearchTypes, controllers.ResolveSearchTypes(nil))
+
+	resolved := controllers.ResolveSearchTypes([]string{"route", " ACCESS_LOG ", "ROUTE", "UNKNOWN"})
+	assert.Equal(t, []string{models.SearchTypeAccessLog, models.SearchTypeRoute}, resolved)
+
+	assert.Empty(t, controllers.ResolveSearchTypes([]string{"UNKNOWN"}))
+}
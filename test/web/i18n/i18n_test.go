@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/web/utils/constants"
+	"gateway/web/utils/i18n"
+	"gateway/web/utils/response"
+	"gateway/web/utils/validation"
+)
+
+// TestResolveLanguage 验证按 Accept-Language 权重选择语言，主语言匹配时选择该语言，无匹配时使用默认语言
+func TestResolveLanguage(t *testing.T) {
+	assert.Equal(t, i18n.LanguageEnUS, i18n.ResolveLanguage("en-US,en;q=0.9"))
+	assert.Equal(t, i18n.LanguageEnUS, i18n.ResolveLanguage("en-GB"))
+	assert.Equal(t, i18n.LanguageZhCN, i18n.ResolveLanguage("fr-FR;q=0.9,zh;q=0.8,en;q=0.5"))
+	assert.Equal(t, i18n.LanguageEnUS, i18n.ResolveLanguage("zh-CN;q=0.3,en-us;q=0.7"))
+	assert.Equal(t, i18n.DefaultLanguage(), i18n.ResolveLanguage(""))
+	assert.Equal(t, i18n.DefaultLanguage(), i18n.ResolveLanguage("ja-JP"))
+}
+
+// TestTranslateError 验证错误消息翻译：前缀匹配保留后续原因，无译文时使用消息代码的通用消息并保留原文
+func TestTranslateError(t *testing.T) {
+	msg, original := i18n.TranslateError(i18n.LanguageEnUS, "参数错误: name不能为空", constants.ED00006)
+	assert.Equal(t, "Invalid parameter: name不能为空", msg)
+	assert.Empty(t, original)
+
+	msg, original = i18n.TranslateError(i18n.LanguageEnUS, "某个未翻译的错误", constants.ED00008)
+	assert.Equal(t, "Data not found", msg)
+	assert.Equal(t, "某个未翻译的错误", original)
+
+	msg, original = i18n.TranslateError(i18n.LanguageZhCN, "参数错误: x", constants.ED00006)
+	assert.Equal(t, "参数错误: x", msg)
+	assert.Empty(t, original)
+}
+
+// TestLabels 验证枚举显示名
+func TestLabels(t *testing.T) {
+	assert.Equal(t, "Excellent", i18n.Label(i18n.LanguageEnUS, "healthGrade", "EXCELLENT"))
+	assert.Equal(t, "严重", i18n.Label(i18n.LanguageZhCN, "alertLevel", "CRITICAL"))
+	assert.Equal(t, "UNKNOWN", i18n.Label(i18n.LanguageEnUS, "alertLevel", "UNKNOWN"))
+
+	labels := i18n.Labels(i18n.LanguageEnUS, "alertLevel", "notExists")
+	assert.Equal(t, "Warning", labels["alertLevel"]["WARN"])
+	assert.NotContains(t, labels, "notExists")
+	assert.Contains(t, i18n.Labels(i18n.LanguageEnUS), "healthGrade")
+}
+
+// TestErrorJSON 验证错误响应和参数校验响应按请求语言返回
+func TestErrorJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+	c.Request.Header.Set("Accept-Language", "en-US")
+	response.ErrorJSON(c, "无法获取租户信息", constants.ED00011)
+
+	var data response.JsonData
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &data))
+	assert.Equal(t, "Unable to get tenant information", data.ErrMsg)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+	c.Request.Header.Set("Accept-Language", "en")
+	response.ValidationErrorJSON(c, &validation.Error{Fields: []validation.FieldError{
+		{Field: "pageSize", Rule: "max", Param: "100", Message: "pageSize 不能大于 100"},
+	}}, constants.ED00006)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &data))
+	assert.Equal(t, "Parameter validation failed: pageSize must be at most 100", data.ErrMsg)
+}
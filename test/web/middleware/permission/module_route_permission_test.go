@@ -0,0 +1,134 @@
+package permission
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database"
+	"gateway/pkg/database/dbtypes"
+	_ "gateway/pkg/database/sqlite" // 导入SQLite实现
+	"gateway/web/middleware/permission"
+	hub0002routes "gateway/web/views/hub0002/routes"
+	hub0003routes "gateway/web/views/hub0003/routes"
+	hub0023routes "gateway/web/views/hub0023/routes"
+	hub0042routes "gateway/web/views/hub0042/routes"
+	hub0043routes "gateway/web/views/hub0043/routes"
+)
+
+// newModuleRouter 按模块路由注册接口，同时登记各模块显式指定的路由权限
+func newModuleRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	// 连接名使用临时文件路径，重复运行同一测试时不会复用已关闭的连接
+	dsn := filepath.Join(t.TempDir(), "module.db")
+	db, err := database.Open(&dbtypes.DbConfig{
+		Name:    dsn,
+		Enabled: true,
+		Driver:  dbtypes.DriverSQLite,
+		DSN:     dsn,
+		Pool:    dbtypes.PoolConfig{MaxOpenConns: 1, MaxIdleConns: 1},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	hub0002routes.Init(router, db)
+	hub0003routes.Init(router, db)
+	hub0023routes.Init(router, db)
+	hub0042routes.Init(router, db)
+	hub0043routes.Init(router, db)
+	return router
+}
+
+// TestModuleWriteRoutesRequireButton 验证接口名不以增删改前缀开头的写操作同样要求按钮权限，只有模块权限的角色被拒绝
+func TestModuleWriteRoutesRequireButton(t *testing.T) {
+	router := newModuleRouter(t)
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+
+	cases := []struct {
+		path string
+		code string
+	}{
+		{"/gateway/hub0002/assignUserRoles", "hub0002:roleAuth"},
+		{"/gateway/hub0002/changePassword", "hub0002:resetPassword"},
+		{"/gateway/hub0002/revokeUserSession", "hub0002:edit"},
+		{"/gateway/hub0002/revokeUserAllSessions", "hub0002:edit"},
+		{"/gateway/hub0003/task/trigger", "hub0003:edit"},
+		{"/gateway/hub0003/task/pause", "hub0003:edit"},
+		{"/gateway/hub0003/task/resume", "hub0003:edit"},
+		{"/gateway/hub0023/gateway-log/reset", "hub0023:reset"},
+		{"/gateway/hub0023/gateway-log/replay/start", "hub0023:replay"},
+		{"/gateway/hub0023/gateway-log/replay/cancel", "hub0023:replay"},
+		{"/gateway/hub0042/offlineNode", "hub0042:node:offline"},
+		{"/gateway/hub0042/adjustNodeWeight", "hub0042:node:edit"},
+		{"/gateway/hub0042/resetNodeWeight", "hub0042:node:edit"},
+		{"/gateway/hub0043/promoteGray", "hub0043:edit"},
+		{"/gateway/hub0043/cancelGray", "hub0043:edit"},
+	}
+	store := &stubResourceStore{
+		codes: []string{"hub0002", "hub0003", "hub0023", "hub0042", "hub0043"},
+		granted: map[string]bool{
+			"hub0002": true, "hub0003": true, "hub0023": true, "hub0042": true, "hub0043": true,
+		},
+	}
+	for _, c := range cases {
+		store.codes = append(store.codes, c.code)
+	}
+	ps := newRouteService(store)
+
+	ctx := context.Background()
+	for _, c := range cases {
+		require.True(t, registered["POST "+c.path], "路由未注册: %s", c.path)
+
+		resp, err := ps.CheckRoutePermission(ctx, "u1", "default", "POST", c.path)
+		require.NoError(t, err)
+		assert.False(t, resp.HasPermission, c.path)
+		assert.Contains(t, resp.Message, c.code, c.path)
+
+		store.granted[c.code] = true
+		resp, err = ps.CheckRoutePermission(ctx, "u1", "default", "POST", c.path)
+		require.NoError(t, err)
+		assert.True(t, resp.HasPermission, c.path)
+		store.granted[c.code] = false
+	}
+
+	// 删除分区只在 ClickHouse 就绪时注册，按显式登记的按钮编码校验
+	route := permission.ResolveRoutePermission("POST", "/gateway/hub0023/gateway-log/partition/drop")
+	assert.Equal(t, "hub0023:dropPartition", route.ButtonCode)
+	assert.True(t, route.Explicit)
+}
+
+// TestModuleQueryRoutesRequireModuleOnly 验证查询类接口只要求模块权限，包括接口名无法推导而显式登记的接口
+func TestModuleQueryRoutesRequireModuleOnly(t *testing.T) {
+	newModuleRouter(t)
+	store := &stubResourceStore{
+		codes:   []string{"hub0002", "hub0002:edit", "hub0003", "hub0003:edit", "hub0023", "hub0023:edit", "hub0042", "hub0042:edit"},
+		granted: map[string]bool{"hub0002": true, "hub0003": true, "hub0023": true, "hub0042": true},
+	}
+	ps := newRouteService(store)
+
+	for _, path := range []string{
+		"/gateway/hub0002/queryUserSessions",
+		"/gateway/hub0003/task/executor-types",
+		"/gateway/hub0003/task/preview-cron",
+		"/gateway/hub0003/log/task-logs",
+		"/gateway/hub0023/gateway-log/count",
+		"/gateway/hub0023/gateway-log/access-detail",
+		"/gateway/hub0023/gateway-log/analytics/slow-routes",
+		"/gateway/hub0023/gateway-log/monitoring/live-snapshot",
+		"/gateway/hub0042/compareJvmMetric",
+	} {
+		resp, err := ps.CheckRoutePermission(context.Background(), "u1", "default", "POST", path)
+		require.NoError(t, err)
+		assert.True(t, resp.HasPermission, path)
+	}
+
+}
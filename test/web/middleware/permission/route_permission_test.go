@@ -0,0 +1,203 @@
+package permission
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/web/middleware/permission"
+)
+
+// stubResourceStore 内存资源查询，codes 为租户已定义的资源编码，granted 为用户拥有的资源编码
+type stubResourceStore struct {
+	codes   []string
+	granted map[string]bool
+	err     error
+	checked []string
+}
+
+func (s *stubResourceStore) ListResourceCodes(ctx context.Context, tenantId string) ([]string, error) {
+	return s.codes, s.err
+}
+
+func (s *stubResourceStore) CheckUserPermission(ctx context.Context, userId, tenantId, resourceCode string) (bool, error) {
+	s.checked = append(s.checked, resourceCode)
+	return s.granted[resourceCode], nil
+}
+
+// newRouteService 创建使用内存资源查询的权限服务
+func newRouteService(store *stubResourceStore) *permission.PermissionService {
+	ps := permission.NewPermissionService(nil)
+	ps.SetRouteResourceStore(store)
+	return ps
+}
+
+// TestResolveRoutePermission 验证按路由推导模块编码、动作和按钮编码
+func TestResolveRoutePermission(t *testing.T) {
+	cases := []struct {
+		method string
+		path   string
+		want   permission.RoutePermission
+	}{
+		{"GET", "/health", permission.RoutePermission{}},
+		{"POST", "/gateway/hub0002", permission.RoutePermission{ModuleCode: "hub0002", Action: permission.ActionView}},
+		{"POST", "/gateway/hub0002/queryUsers", permission.RoutePermission{ModuleCode: "hub0002", Action: permission.ActionView}},
+		{"POST", "/gateway/hub0002/addUser", permission.RoutePermission{ModuleCode: "hub0002", Action: permission.ActionAdd, ButtonCode: "hub0002:add"}},
+		{"POST", "/gateway/hub0002/batchDeleteUsers", permission.RoutePermission{ModuleCode: "hub0002", Action: permission.ActionDelete, ButtonCode: "hub0002:delete"}},
+		{"POST", "/gateway/hub0005/updateRole", permission.RoutePermission{ModuleCode: "hub0005", Action: permission.ActionEdit, ButtonCode: "hub0005:edit"}},
+		{"POST", "/gateway/hub0023/exportReport", permission.RoutePermission{ModuleCode: "hub0023", Action: permission.ActionExport, ButtonCode: "hub0023:export"}},
+		{"POST", "/gateway/hubcommon002/ip-access/add", permission.RoutePermission{ModuleCode: "hubcommon002", Action: permission.ActionAdd, ButtonCode: "hubcommon002:add"}},
+		{"DELETE", "/gateway/hub0002/users/:id", permission.RoutePermission{ModuleCode: "hub0002", Action: permission.ActionDelete, ButtonCode: "hub0002:delete"}},
+		{"PUT", "/gateway/hub0002/users/:id", permission.RoutePermission{ModuleCode: "hub0002", Action: permission.ActionEdit, ButtonCode: "hub0002:edit"}},
+		{"GET", "/gateway/hub0002/users/:id", permission.RoutePermission{ModuleCode: "hub0002", Action: permission.ActionView}},
+		// 查询类前缀只校验模块权限，其余写方法默认推导为编辑
+		{"POST", "/gateway/hub0042/getNodeHealthHistory", permission.RoutePermission{ModuleCode: "hub0042", Action: permission.ActionView}},
+		{"POST", "/gateway/hub0061/checkServerPortConflict", permission.RoutePermission{ModuleCode: "hub0061", Action: permission.ActionView}},
+		{"POST", "/gateway/hub0043/promoteGray", permission.RoutePermission{ModuleCode: "hub0043", Action: permission.ActionEdit, ButtonCode: "hub0043:edit"}},
+		{"POST", "/gateway/hub0003/task/trigger", permission.RoutePermission{ModuleCode: "hub0003", Action: permission.ActionEdit, ButtonCode: "hub0003:edit"}},
+		{"POST", "/gateway/hub0002/users/:id", permission.RoutePermission{ModuleCode: "hub0002", Action: permission.ActionEdit, ButtonCode: "hub0002:edit"}},
+		{"HEAD", "/gateway/hub0002/users/:id", permission.RoutePermission{ModuleCode: "hub0002", Action: permission.ActionView}},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, permission.ResolveRoutePermission(c.method, c.path), c.method+" "+c.path)
+	}
+}
+
+// TestResolveRoutePermissionExplicit 验证显式注册的按钮编码优先于推导结果，且按HTTP方法区分
+func TestResolveRoutePermissionExplicit(t *testing.T) {
+	permission.RegisterRoutePermission("post", "/gateway/hub0099/startInstance", "hub0099:start")
+
+	assert.Equal(t, permission.RoutePermission{
+		ModuleCode: "hub0099",
+		Action:     "start",
+		ButtonCode: "hub0099:start",
+		Explicit:   true,
+	}, permission.ResolveRoutePermission("POST", "/gateway/hub0099/startInstance"))
+
+	route := permission.ResolveRoutePermission("GET", "/gateway/hub0099/startInstance")
+	assert.False(t, route.Explicit)
+	assert.Equal(t, "hub0099:edit", route.ButtonCode)
+
+	// 按钮编码为空的显式登记只校验模块权限
+	permission.RegisterRoutePermission("POST", "/gateway/hub0099/metrics/cpu", "")
+	assert.Equal(t, permission.RoutePermission{
+		ModuleCode: "hub0099",
+		Action:     permission.ActionView,
+		Explicit:   true,
+	}, permission.ResolveRoutePermission("POST", "/gateway/hub0099/metrics/cpu"))
+	assert.Equal(t, "hub0099:edit", permission.ResolveRoutePermission("POST", "/gateway/hub0099/metrics/memory").ButtonCode)
+}
+
+// TestCheckRoutePermission 验证模块和按钮权限校验
+func TestCheckRoutePermission(t *testing.T) {
+	ctx := context.Background()
+	store := &stubResourceStore{
+		codes:   []string{"hub0002", "hub0002:add", "hub0005"},
+		granted: map[string]bool{"hub0002": true, "hub0005": true},
+	}
+	ps := newRouteService(store)
+
+	// 非管理端接口和公开模块只要求登录，不查询资源
+	resp, err := ps.CheckRoutePermission(ctx, "u1", "default", "GET", "/health")
+	require.NoError(t, err)
+	assert.True(t, resp.HasPermission)
+	resp, err = ps.CheckRoutePermission(ctx, "u1", "default", "POST", "/gateway/hub0001/logout")
+	require.NoError(t, err)
+	assert.True(t, resp.HasPermission)
+	resp, err = ps.CheckRoutePermission(ctx, "u1", "default", "POST", "/gateway/user/logout")
+	require.NoError(t, err)
+	assert.True(t, resp.HasPermission, "登录模块的接口路径为 /gateway/user")
+	assert.Empty(t, store.checked)
+
+	// 查询接口只要求模块资源
+	resp, err = ps.CheckRoutePermission(ctx, "u1", "default", "POST", "/gateway/hub0002/queryUsers")
+	require.NoError(t, err)
+	assert.True(t, resp.HasPermission)
+	assert.Equal(t, []string{"hub0002"}, resp.Permissions)
+
+	// 按钮编码已定义时要求拥有按钮资源
+	resp, err = ps.CheckRoutePermission(ctx, "u1", "default", "POST", "/gateway/hub0002/addUser")
+	require.NoError(t, err)
+	assert.False(t, resp.HasPermission)
+	assert.Contains(t, resp.Message, "hub0002:add")
+
+	// 推导出的按钮编码未定义时只校验模块资源
+	resp, err = ps.CheckRoutePermission(ctx, "u1", "default", "POST", "/gateway/hub0005/deleteRole")
+	require.NoError(t, err)
+	assert.True(t, resp.HasPermission)
+	assert.Equal(t, []string{"hub0005"}, resp.Permissions)
+
+	// 没有模块资源时拒绝访问
+	store.granted["hub0005"] = false
+	resp, err = ps.CheckRoutePermission(ctx, "u1", "default", "POST", "/gateway/hub0005/queryRoles")
+	require.NoError(t, err)
+	assert.False(t, resp.HasPermission)
+	assert.Contains(t, resp.Message, "hub0005")
+}
+
+// TestCheckRoutePermissionUndefinedModule 验证资源表未定义的模块默认拒绝访问
+func TestCheckRoutePermissionUndefinedModule(t *testing.T) {
+	store := &stubResourceStore{
+		codes:   []string{"hub0002"},
+		granted: map[string]bool{"hub0002": true, "hub0098": true},
+	}
+	ps := newRouteService(store)
+
+	resp, err := ps.CheckRoutePermission(context.Background(), "u1", "default", "POST", "/gateway/hub0098/queryItems")
+	require.NoError(t, err)
+	assert.False(t, resp.HasPermission)
+	assert.Contains(t, resp.Message, "hub0098")
+	assert.Empty(t, store.checked)
+}
+
+// TestCheckRoutePermissionExplicitButton 验证显式注册的按钮编码未在资源表定义时同样要求拥有
+func TestCheckRoutePermissionExplicitButton(t *testing.T) {
+	permission.RegisterRoutePermission("POST", "/gateway/hub0097/stopInstance", "hub0097:stop")
+	store := &stubResourceStore{
+		codes:   []string{"hub0097"},
+		granted: map[string]bool{"hub0097": true},
+	}
+	ps := newRouteService(store)
+
+	resp, err := ps.CheckRoutePermission(context.Background(), "u1", "default", "POST", "/gateway/hub0097/stopInstance")
+	require.NoError(t, err)
+	assert.False(t, resp.HasPermission)
+	assert.Equal(t, []string{"hub0097", "hub0097:stop"}, store.checked)
+
+	store.granted["hub0097:stop"] = true
+	resp, err = ps.CheckRoutePermission(context.Background(), "u1", "default", "POST", "/gateway/hub0097/stopInstance")
+	require.NoError(t, err)
+	assert.True(t, resp.HasPermission)
+	assert.Equal(t, []string{"hub0097", "hub0097:stop"}, resp.Permissions)
+}
+
+// TestCheckRoutePermissionResourceCodeCache 验证资源编码按租户缓存，清除缓存后重新加载
+func TestCheckRoutePermissionResourceCodeCache(t *testing.T) {
+	ctx := context.Background()
+	store := &stubResourceStore{granted: map[string]bool{"hub0096": true}}
+	ps := newRouteService(store)
+
+	resp, err := ps.CheckRoutePermission(ctx, "u1", "default", "POST", "/gateway/hub0096/queryItems")
+	require.NoError(t, err)
+	assert.False(t, resp.HasPermission)
+
+	// 缓存有效期内新增的资源不生效
+	store.codes = []string{"hub0096"}
+	resp, err = ps.CheckRoutePermission(ctx, "u1", "default", "POST", "/gateway/hub0096/queryItems")
+	require.NoError(t, err)
+	assert.False(t, resp.HasPermission)
+
+	ps.InvalidateResourceCodes()
+	resp, err = ps.CheckRoutePermission(ctx, "u1", "default", "POST", "/gateway/hub0096/queryItems")
+	require.NoError(t, err)
+	assert.True(t, resp.HasPermission)
+
+	// 加载资源编码失败时返回错误
+	store.err = errors.New("db down")
+	ps.InvalidateResourceCodes()
+	_, err = ps.CheckRoutePermission(ctx, "u1", "default", "POST", "/gateway/hub0096/queryItems")
+	assert.Error(t, err)
+}
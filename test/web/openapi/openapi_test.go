@@ -0,0 +1,97 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/web/utils/openapi"
+)
+
+type sampleBase struct {
+	TenantId string `json:"tenantId"`
+}
+
+type sampleRequest struct {
+	sampleBase
+	Name   string   `json:"name" binding:"required"`
+	Tags   []string `json:"tags"`
+	Secret string   `json:"-"`
+}
+
+type sampleController struct{}
+
+func (c *sampleController) Add(ctx *gin.Context)   {}
+func (c *sampleController) Query(ctx *gin.Context) {}
+
+// TestBuild 验证根据路由生成文档：只包含管理端接口、路径参数转换、注册的请求模型生成组件
+func TestBuild(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller := &sampleController{}
+	router := gin.New()
+	router.POST("/gateway/hub9999/sample/add", controller.Add)
+	router.GET("/gateway/hub9999/sample/:id", controller.Query)
+	router.GET("/health", func(ctx *gin.Context) {})
+
+	openapi.RegisterHandler(runtime.FuncForPC(reflect.ValueOf(controller.Add).Pointer()).Name(), openapi.HandlerSpec{
+		Summary: "新增示例",
+		Request: sampleRequest{},
+	})
+	openapi.RegisterHandler(runtime.FuncForPC(reflect.ValueOf(controller.Query).Pointer()).Name(), openapi.HandlerSpec{
+		Params: []string{"keyword"},
+		Paged:  true,
+	})
+
+	doc := openapi.Build(router.Routes(), openapi.Info{Title: "test", Version: "1.0.0"})
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+	assert.Len(t, doc.Paths, 2)
+	assert.Equal(t, []openapi.Tag{{Name: "hub9999"}}, doc.Tags)
+
+	add := doc.Paths["/gateway/hub9999/sample/add"]
+	require.NotNil(t, add)
+	require.NotNil(t, add.Post)
+	assert.Equal(t, "新增示例", add.Post.Summary)
+	body := add.Post.RequestBody.Content["application/json"].Schema
+	require.NotEmpty(t, body.Ref)
+
+	component := doc.Components.Schemas[body.Ref[len("#/components/schemas/"):]]
+	require.NotNil(t, component)
+	assert.Contains(t, component.Properties, "tenantId")
+	assert.Contains(t, component.Properties, "name")
+	assert.NotContains(t, component.Properties, "Secret")
+	assert.Equal(t, "array", component.Properties["tags"].Type)
+	assert.Equal(t, []string{"name"}, component.Required)
+
+	query := doc.Paths["/gateway/hub9999/sample/{id}"]
+	require.NotNil(t, query)
+	require.NotNil(t, query.Get)
+	assert.Equal(t, "id", query.Get.Parameters[0].Name)
+	assert.Equal(t, "path", query.Get.Parameters[0].In)
+	names := make([]string, 0)
+	for _, p := range query.Get.Parameters[1:] {
+		assert.Equal(t, "query", p.In)
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"keyword", "pageIndex", "pageSize"}, names)
+}
+
+// TestUIHandler 验证Swagger UI页面从配置的静态资源地址加载脚本，不引用公网CDN
+func TestUIHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/docs", openapi.UIHandler("Gateway API", "/api/openapi.json", "https://assets.internal/swagger-ui/"))
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	body := recorder.Body.String()
+	assert.Contains(t, body, `src="https://assets.internal/swagger-ui/swagger-ui-bundle.js"`)
+	assert.Contains(t, body, `url: "\/api\/openapi.json"`)
+	assert.NotContains(t, body, "unpkg.com")
+}
@@ -0,0 +1,176 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgcache "gateway/pkg/cache"
+	"gateway/pkg/cache/memory"
+	"gateway/web/utils/session"
+)
+
+// TestMain 使用内存缓存作为默认缓存运行测试
+func TestMain(m *testing.M) {
+	memCache, err := memory.NewMemoryCache(nil)
+	if err != nil {
+		panic(err)
+	}
+	if err := pkgcache.AddCache("default", memCache); err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	pkgcache.CloseAllCaches()
+	os.Exit(code)
+}
+
+func newSessionManager(maxSessions int, limitPolicy string) *session.SessionManager {
+	sm := session.NewSessionManager()
+	sm.SetPolicy(session.SessionPolicy{
+		AccessExpire:          30 * time.Minute,
+		RefreshExpire:         24 * time.Hour,
+		MaxConcurrentSessions: maxSessions,
+		LimitPolicy:           limitPolicy,
+	})
+	return sm
+}
+
+func login(t *testing.T, sm *session.SessionManager, tenantId, userId string) *session.SessionToken {
+	token, err := sm.CreateSession(context.Background(), userId, userId, "", tenantId, "", "", "", "", "127.0.0.1", "test")
+	require.NoError(t, err)
+	require.NotEmpty(t, token.RefreshToken)
+	return token
+}
+
+// TestRefreshTokenRotation 测试刷新令牌换取新令牌后原令牌失效
+func TestRefreshTokenRotation(t *testing.T) {
+	ctx := context.Background()
+	sm := newSessionManager(0, "")
+	token := login(t, sm, "t1", "rotate")
+
+	refreshed, err := sm.RefreshToken(ctx, token.RefreshToken)
+	require.NoError(t, err)
+	assert.NotEqual(t, token.SessionId, refreshed.SessionId)
+	assert.NotEqual(t, token.RefreshToken, refreshed.RefreshToken)
+	assert.Equal(t, "rotate", refreshed.UserId)
+
+	_, err = sm.ValidateSession(ctx, token.SessionId)
+	assert.Error(t, err)
+	_, err = sm.RefreshToken(ctx, token.RefreshToken)
+	assert.ErrorIs(t, err, session.ErrRefreshTokenInvalid)
+
+	userContext, err := sm.ValidateSession(ctx, refreshed.SessionId)
+	require.NoError(t, err)
+	assert.Equal(t, "t1", userContext.TenantId)
+
+	sessions, err := sm.ListUserSessions(ctx, "t1", "rotate")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, refreshed.SessionId, sessions[0].SessionId)
+	assert.True(t, sessions[0].Active)
+}
+
+// TestSessionLimit 测试超过最大并发会话数时的处理策略
+func TestSessionLimit(t *testing.T) {
+	ctx := context.Background()
+
+	sm := newSessionManager(2, session.SessionLimitEvictOldest)
+	first := login(t, sm, "t1", "evict")
+	time.Sleep(time.Millisecond)
+	login(t, sm, "t1", "evict")
+	time.Sleep(time.Millisecond)
+	login(t, sm, "t1", "evict")
+
+	sessions, err := sm.ListUserSessions(ctx, "t1", "evict")
+	require.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	_, err = sm.ValidateSession(ctx, first.SessionId)
+	assert.Error(t, err)
+	_, err = sm.RefreshToken(ctx, first.RefreshToken)
+	assert.ErrorIs(t, err, session.ErrRefreshTokenInvalid)
+
+	sm = newSessionManager(1, session.SessionLimitReject)
+	login(t, sm, "t1", "reject")
+	_, err = sm.CreateSession(ctx, "reject", "reject", "", "t1", "", "", "", "", "127.0.0.1", "test")
+	assert.ErrorIs(t, err, session.ErrSessionLimitExceeded)
+
+	// 其他租户的同名用户不受影响
+	login(t, sm, "t2", "reject")
+}
+
+// TestRevokeSessions 测试撤销单个会话和全部会话
+func TestRevokeSessions(t *testing.T) {
+	ctx := context.Background()
+	sm := newSessionManager(0, "")
+	first := login(t, sm, "t1", "revoke")
+	second := login(t, sm, "t1", "revoke")
+
+	assert.ErrorIs(t, sm.RevokeSession(ctx, "t2", "revoke", first.SessionId), session.ErrSessionNotFound)
+	require.NoError(t, sm.RevokeSession(ctx, "t1", "revoke", first.SessionId))
+	_, err := sm.ValidateSession(ctx, first.SessionId)
+	assert.Error(t, err)
+
+	tenantSessions, err := sm.ListTenantSessions(ctx, "t1")
+	require.NoError(t, err)
+	var found bool
+	for _, info := range tenantSessions {
+		found = found || info.SessionId == second.SessionId
+	}
+	assert.True(t, found)
+
+	require.NoError(t, sm.DeleteUserSessions(ctx, "t1", "revoke"))
+	_, err = sm.RefreshToken(ctx, second.RefreshToken)
+	assert.ErrorIs(t, err, session.ErrRefreshTokenInvalid)
+	sessions, err := sm.ListUserSessions(ctx, "t1", "revoke")
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
+
+// TestRefreshTokenUserValidator 测试用户被禁用后刷新令牌失败且会话被撤销，校验出错时不撤销会话
+func TestRefreshTokenUserValidator(t *testing.T) {
+	ctx := context.Background()
+	sm := newSessionManager(0, "")
+	disabled := map[string]bool{}
+	var validateErr error
+	sm.SetUserValidator(func(ctx context.Context, tenantId, userId string) error {
+		if validateErr != nil {
+			return validateErr
+		}
+		if disabled[userId] {
+			return fmt.Errorf("用户 %s 已被禁用: %w", userId, session.ErrUserUnavailable)
+		}
+		return nil
+	})
+
+	token := login(t, sm, "t1", "disabled")
+	refreshed, err := sm.RefreshToken(ctx, token.RefreshToken)
+	require.NoError(t, err)
+
+	// 校验本身出错时返回错误，刷新令牌仍可使用
+	validateErr = errors.New("数据库不可用")
+	_, err = sm.RefreshToken(ctx, refreshed.RefreshToken)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, session.ErrRefreshTokenInvalid)
+	validateErr = nil
+	_, err = sm.RefreshToken(ctx, refreshed.RefreshToken)
+	require.NoError(t, err)
+
+	// 用户禁用后刷新失败，会话被撤销
+	disabled["disabled"] = true
+	token = login(t, sm, "t1", "disabled")
+	_, err = sm.RefreshToken(ctx, token.RefreshToken)
+	assert.ErrorIs(t, err, session.ErrRefreshTokenInvalid)
+	_, err = sm.ValidateSession(ctx, token.SessionId)
+	assert.Error(t, err)
+	sessions, err := sm.ListUserSessions(ctx, "t1", "disabled")
+	require.NoError(t, err)
+	for _, info := range sessions {
+		assert.NotEqual(t, token.SessionId, info.SessionId)
+	}
+}
@@ -0,0 +1,267 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/excel"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/export"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+)
+
+// serviceItem 模拟查询结果记录，字段顺序与JSON输出一致
+type serviceItem struct {
+	ServiceName string   `json:"serviceName"`
+	ServiceId   string   `json:"serviceId"`
+	Keyword     string   `json:"keyword"`
+	Tags        []string `json:"tags"`
+}
+
+// fakeQuery 模拟分页查询处理函数，共 total 条记录，记录每次调用的页码
+func fakeQuery(total int, pages *[]int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, pageSize := request.GetPaginationParams(c)
+		*pages = append(*pages, page)
+		keyword := request.GetParam(c, "keyword")
+
+		items := make([]serviceItem, 0, pageSize)
+		for i := (page - 1) * pageSize; i < total && i < page*pageSize; i++ {
+			items = append(items, serviceItem{
+				ServiceName: fmt.Sprintf("svc-%03d", i),
+				ServiceId:   fmt.Sprintf("id-%03d", i),
+				Keyword:     keyword,
+				Tags:        []string{"a", "b"},
+			})
+		}
+		response.PageJSON(c, items, response.NewPageInfo(page, pageSize, total), constants.SD00002)
+	}
+}
+
+// performExport 以JSON请求体调用导出处理函数
+func performExport(handler gin.HandlerFunc, rawQuery, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/export?"+rawQuery, strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	handler(ctx)
+	return recorder
+}
+
+// readCSV 解析CSV响应，校验并去掉UTF-8 BOM
+func readCSV(t *testing.T, data []byte) [][]string {
+	t.Helper()
+	require.True(t, bytes.HasPrefix(data, []byte("\xEF\xBB\xBF")), "CSV should start with UTF-8 BOM")
+	rows, err := csv.NewReader(bytes.NewReader(data[3:])).ReadAll()
+	require.NoError(t, err)
+	return rows
+}
+
+// TestNewOptions 验证导出格式、导出列和行数上限的解析
+func TestNewOptions(t *testing.T) {
+	opts, err := export.NewOptions("", "", "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, export.FormatXLSX, opts.Format)
+	assert.Nil(t, opts.Columns)
+	assert.Equal(t, export.DefaultMaxRows, opts.MaxRows)
+
+	opts, err = export.NewOptions(" CSV ", "serviceName, serviceId,,", `["名称","ID"]`, 50)
+	require.NoError(t, err)
+	assert.Equal(t, export.FormatCSV, opts.Format)
+	assert.Equal(t, []string{"serviceName", "serviceId"}, opts.Columns)
+	assert.Equal(t, []string{"名称", "ID"}, opts.Titles)
+	assert.Equal(t, 50, opts.MaxRows)
+
+	// 超过服务端上限时使用服务端上限
+	opts, err = export.NewOptions("xlsx", `["serviceName"]`, "", export.DefaultMaxRows+1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"serviceName"}, opts.Columns)
+	assert.Equal(t, export.DefaultMaxRows, opts.MaxRows)
+
+	_, err = export.NewOptions("pdf", "", "", 0)
+	assert.Error(t, err)
+}
+
+// TestHandlerCSVColumnsAndRowCap 验证CSV导出按指定列和标题输出，并在达到行数上限后停止翻页
+func TestHandlerCSVColumnsAndRowCap(t *testing.T) {
+	var pages []int
+	handler := export.Handler("RegistryService", fakeQuery(250, &pages))
+
+	recorder := performExport(handler, "format=csv&columns=serviceId,keyword,missing&columnTitles=ID&maxRows=130", `{"keyword":"order"}`)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Header().Get("Content-Disposition"), "RegistryService_")
+	assert.Contains(t, recorder.Header().Get("Content-Disposition"), ".csv")
+
+	rows := readCSV(t, recorder.Body.Bytes())
+	require.Len(t, rows, 131)
+	assert.Equal(t, []string{"ID", "keyword", "missing"}, rows[0])
+	assert.Equal(t, []string{"id-000", "order", ""}, rows[1])
+	assert.Equal(t, []string{"id-129", "order", ""}, rows[130])
+	// 每页100条，取满130条后不再查询第3页
+	assert.Equal(t, []int{1, 2}, pages)
+}
+
+// TestHandlerCSVAllRows 验证未指定列时按记录字段顺序导出全部记录，数组字段输出为JSON
+func TestHandlerCSVAllRows(t *testing.T) {
+	var pages []int
+	handler := export.Handler("RegistryService", fakeQuery(250, &pages))
+
+	recorder := performExport(handler, "format=csv", `{}`)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	rows := readCSV(t, recorder.Body.Bytes())
+	require.Len(t, rows, 251)
+	assert.Equal(t, []string{"serviceName", "serviceId", "keyword", "tags"}, rows[0])
+	assert.Equal(t, []string{"svc-249", "id-249", "", `["a","b"]`}, rows[250])
+	assert.Equal(t, []int{1, 2, 3}, pages)
+}
+
+// TestHandlerXLSX 验证默认导出Excel文件
+func TestHandlerXLSX(t *testing.T) {
+	var pages []int
+	handler := export.Handler("RegistryService", fakeQuery(3, &pages))
+
+	recorder := performExport(handler, "columns=serviceName,tags&columnTitles=名称,标签", `{}`)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, export.ContentType(export.FormatXLSX), recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Header().Get("Content-Disposition"), ".xlsx")
+	assert.Equal(t, []int{1}, pages)
+
+	sheets, err := excel.Parse(bytes.NewReader(recorder.Body.Bytes()))
+	require.NoError(t, err)
+	rows, ok := sheets["RegistryService"]
+	require.True(t, ok, "sheet should be named after the export name")
+	require.Len(t, rows, 4)
+	assert.Equal(t, []string{"名称", "标签"}, rows[0])
+	assert.Equal(t, []string{"svc-000", `["a","b"]`}, rows[1])
+	assert.Equal(t, "svc-002", rows[3][0])
+}
+
+// TestHandlerErrors 验证格式不支持和查询失败时返回错误响应
+func TestHandlerErrors(t *testing.T) {
+	var pages []int
+	recorder := performExport(export.Handler("RegistryService", fakeQuery(3, &pages)), "format=pdf", `{}`)
+	assertErrorResponse(t, recorder)
+	assert.Empty(t, pages, "query should not run for unsupported formats")
+
+	failing := func(c *gin.Context) {
+		response.ErrorJSON(c, "数据库不可用", constants.ED00009)
+	}
+	recorder = performExport(export.Handler("RegistryService", failing), "format=csv", `{}`)
+	assertErrorResponse(t, recorder)
+	assert.NotEqual(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+}
+
+// TestHandlerSingleObject 验证查询返回单个对象时导出为一条记录
+func TestHandlerSingleObject(t *testing.T) {
+	overview := func(c *gin.Context) {
+		response.SuccessJSON(c, serviceItem{ServiceName: "overview", ServiceId: "all"}, constants.SD00002)
+	}
+	recorder := performExport(export.Handler("Overview", overview), "format=csv&columns=serviceName,serviceId", `{}`)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, [][]string{{"serviceName", "serviceId"}, {"overview", "all"}}, readCSV(t, recorder.Body.Bytes()))
+}
+
+// TestHandlerCSVFormulaInjection 验证以公式起始字符开头的文本导出时加单引号前缀，数字不受影响
+func TestHandlerCSVFormulaInjection(t *testing.T) {
+	values := []string{"=HYPERLINK(\"http://evil\")", "+1+1", "-2+3", "@SUM(A1)", "\tcmd", "\rcmd", "normal", ""}
+	query := func(c *gin.Context) {
+		items := make([]map[string]interface{}, 0, len(values))
+		for _, value := range values {
+			items = append(items, map[string]interface{}{"value": value, "amount": -5})
+		}
+		response.PageJSON(c, items, response.NewPageInfo(1, constants.MaxPageSize, len(items)), constants.SD00002)
+	}
+
+	recorder := performExport(export.Handler("Injection", query), "format=csv&columns=value,amount", `{}`)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	rows := readCSV(t, recorder.Body.Bytes())
+	require.Len(t, rows, len(values)+1)
+	expected := []string{"'=HYPERLINK(\"http://evil\")", "'+1+1", "'-2+3", "'@SUM(A1)", "'\tcmd", "'\rcmd", "normal", ""}
+	for i, want := range expected {
+		assert.Equal(t, []string{want, "-5"}, rows[i+1])
+	}
+}
+
+// TestCollectPaging 验证 Collect 按最大页大小逐页查询，改写JSON请求体中的分页参数并保留过滤条件，
+// 取满行数上限或到达最后一页后停止，结束后恢复原响应写入器和查询参数
+func TestCollectPaging(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newContext := func(body string) (*gin.Context, *httptest.ResponseRecorder) {
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/query?keyword=q", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		return ctx, recorder
+	}
+
+	var pages []int
+	var bodies []map[string]interface{}
+	query := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		var params map[string]interface{}
+		_ = json.Unmarshal(body, &params)
+		bodies = append(bodies, params)
+		fakeQuery(250, &pages)(c)
+	}
+
+	ctx, recorder := newContext(`{"status":"Y","pageIndex":9,"pageSize":5}`)
+	// 调用前已读取过Query参数，gin的Query缓存不应影响分页
+	assert.Equal(t, "q", ctx.Query("keyword"))
+	originalWriter := ctx.Writer
+	records, err := export.Collect(ctx, query, 1000)
+	require.NoError(t, err)
+	require.Len(t, records, 250)
+	assert.Equal(t, []int{1, 2, 3}, pages)
+	assert.Equal(t, []string{"serviceName", "serviceId", "keyword", "tags"}, records[0].Keys)
+	assert.Equal(t, "svc-249", records[249].Values["serviceName"])
+	assert.Equal(t, "q", records[0].Values["keyword"])
+	for i, params := range bodies {
+		assert.Equal(t, "Y", params["status"], "过滤条件应保留")
+		assert.EqualValues(t, i+1, params["pageIndex"])
+		assert.EqualValues(t, constants.MaxPageSize, params["pageSize"])
+	}
+	assert.Equal(t, originalWriter, ctx.Writer)
+	assert.Equal(t, "keyword=q", ctx.Request.URL.RawQuery)
+	restored, _ := io.ReadAll(ctx.Request.Body)
+	assert.JSONEq(t, `{"status":"Y","pageIndex":9,"pageSize":5}`, string(restored), "原请求体应保持不变")
+	assert.Zero(t, recorder.Body.Len(), "查询响应不应写入原响应")
+
+	// 行数上限在页中间时截断，不再查询下一页
+	pages = nil
+	ctx, _ = newContext(`{}`)
+	records, err = export.Collect(ctx, query, 150)
+	require.NoError(t, err)
+	assert.Len(t, records, 150)
+	assert.Equal(t, []int{1, 2}, pages)
+
+	// 查询失败时返回错误
+	ctx, _ = newContext(`{}`)
+	_, err = export.Collect(ctx, func(c *gin.Context) {
+		response.ErrorJSON(c, "数据库不可用", constants.ED00009)
+	}, 10)
+	assert.EqualError(t, err, "数据库不可用")
+}
+
+// assertErrorResponse 校验响应为标准错误JSON
+func assertErrorResponse(t *testing.T, recorder *httptest.ResponseRecorder) {
+	t.Helper()
+	var result response.JsonData
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+	assert.False(t, result.OK)
+}
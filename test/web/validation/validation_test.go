@@ -0,0 +1,119 @@
+package validation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/web/middleware"
+	"gateway/web/utils/openapi"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/utils/validation"
+)
+
+type resetItem struct {
+	TraceId string `json:"traceId" binding:"required"`
+}
+
+type sampleRequest struct {
+	Name     string      `json:"name" form:"name" binding:"required"`
+	PageSize int         `json:"pageSize" form:"pageSize" binding:"omitempty,min=1,max=100"`
+	Mode     string      `json:"mode" form:"mode" binding:"omitempty,oneof=Y N"`
+	Items    []resetItem `json:"items" binding:"dive"`
+}
+
+type sampleController struct {
+	called bool
+	bound  sampleRequest
+}
+
+func (c *sampleController) Add(ctx *gin.Context) {
+	c.called = true
+	if err := request.BindSafely(ctx, &c.bound); err != nil {
+		response.ErrorJSON(ctx, err.Error(), "ED00006")
+		return
+	}
+	response.SuccessJSON(ctx, nil, "SD00001")
+}
+
+// TestValidate 验证字段错误明细：字段名取 json 标签，嵌套字段包含下标
+func TestValidate(t *testing.T) {
+	err := validation.Validate(&sampleRequest{PageSize: 200, Mode: "X", Items: []resetItem{{}}})
+	validationErr, ok := err.(*validation.Error)
+	require.True(t, ok, "应返回 *validation.Error: %v", err)
+
+	fields := make(map[string]validation.FieldError)
+	for _, field := range validationErr.Fields {
+		fields[field.Field] = field
+	}
+	assert.Equal(t, "required", fields["name"].Rule)
+	assert.Equal(t, "name 不能为空", fields["name"].Message)
+	assert.Equal(t, "max", fields["pageSize"].Rule)
+	assert.Equal(t, "100", fields["pageSize"].Param)
+	assert.Equal(t, "pageSize 不能大于 100", fields["pageSize"].Message)
+	assert.Equal(t, "mode 必须是以下值之一: Y, N", fields["mode"].Message)
+	assert.Equal(t, "required", fields["items[0].traceId"].Rule)
+
+	assert.NoError(t, validation.Validate(&sampleRequest{Name: "a", PageSize: 10}))
+}
+
+// TestBindSafely 验证宽松绑定在类型转换回退后仍校验 binding 标签
+func TestBindSafely(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("pageSize=abc"))
+	ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var req sampleRequest
+	err := request.BindSafely(ctx, &req)
+	_, ok := err.(*validation.Error)
+	assert.True(t, ok, "缺少必填字段时应返回 *validation.Error: %v", err)
+}
+
+// TestRequestValidation 验证中间件按注册的请求模型校验，失败返回400且不进入处理函数，成功时处理函数仍可读取请求体
+func TestRequestValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller := &sampleController{}
+	openapi.RegisterHandler(runtime.FuncForPC(reflect.ValueOf(controller.Add).Pointer()).Name(), openapi.HandlerSpec{
+		Request: sampleRequest{},
+	})
+
+	router := gin.New()
+	router.Use(middleware.RequestValidation())
+	router.POST("/gateway/hub9999/add", controller.Add)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/gateway/hub9999/add", strings.NewReader(`{"pageSize":0,"mode":"X"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.False(t, controller.called)
+	var body struct {
+		OK     bool              `json:"oK"`
+		ExtObj *validation.Error `json:"extObj"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.False(t, body.OK)
+	require.NotNil(t, body.ExtObj)
+	assert.Len(t, body.ExtObj.Fields, 2)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/gateway/hub9999/add", strings.NewReader(`{"name":"demo","pageSize":20}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, controller.called)
+	assert.Equal(t, "demo", controller.bound.Name)
+	assert.Equal(t, 20, controller.bound.PageSize)
+}
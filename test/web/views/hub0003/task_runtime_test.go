@@ -0,0 +1,297 @@
+package hub0003
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/internal/timerinit/common/dao"
+	"gateway/internal/timerinit/common/taskinit"
+	"gateway/internal/types/timertypes"
+	"gateway/pkg/database"
+	"gateway/pkg/database/dbtypes"
+	_ "gateway/pkg/database/sqlite" // 导入SQLite实现
+	"gateway/pkg/timer"
+	"gateway/web/globalmodels"
+	"gateway/web/middleware"
+	"gateway/web/views/hub0003/controllers"
+)
+
+const (
+	// testExecutorType 测试注册的执行器类型
+	testExecutorType = "TEST_RUNTIME"
+	// disabledExecutorType 未启用的执行器类型
+	disabledExecutorType = "TEST_DISABLED"
+)
+
+// countingExecutor 记录执行和关闭次数的执行器
+type countingExecutor struct {
+	executed *int32
+	closed   *int32
+}
+
+func (e *countingExecutor) Execute(ctx context.Context, params interface{}) (*timer.ExecuteResult, error) {
+	atomic.AddInt32(e.executed, 1)
+	return &timer.ExecuteResult{Success: true}, nil
+}
+
+func (e *countingExecutor) GetName() string {
+	return "countingExecutor"
+}
+
+func (e *countingExecutor) Close() error {
+	atomic.AddInt32(e.closed, 1)
+	return nil
+}
+
+// countingFactory 创建 countingExecutor 的执行器工厂，记录创建次数
+type countingFactory struct {
+	executorType string
+	created      int32
+	executed     int32
+	closed       int32
+}
+
+func (f *countingFactory) CreateExecutor(ctx context.Context, task *timertypes.TimerTask) (timer.TaskExecutor, error) {
+	atomic.AddInt32(&f.created, 1)
+	return &countingExecutor{executed: &f.executed, closed: &f.closed}, nil
+}
+
+func (f *countingFactory) GetExecutorType() string {
+	return f.executorType
+}
+
+// registerTestFactories 注册测试执行器工厂，返回启用的工厂
+func registerTestFactories() *countingFactory {
+	factory := &countingFactory{executorType: testExecutorType}
+	taskinit.RegisterExecutorFactory(&taskinit.ExecutorFactoryDefinition{
+		ExecutorType: testExecutorType,
+		Description:  "测试执行器",
+		NewFactory:   func(daoManager *dao.DAOManager) taskinit.TaskExecutorFactory { return factory },
+	})
+	taskinit.RegisterExecutorFactory(&taskinit.ExecutorFactoryDefinition{
+		ExecutorType:     disabledExecutorType,
+		Description:      "未启用的测试执行器",
+		EnabledConfigKey: "app.timer.test_disabled.enabled",
+		EnabledByDefault: false,
+		NewFactory: func(daoManager *dao.DAOManager) taskinit.TaskExecutorFactory {
+			return &countingFactory{executorType: disabledExecutorType}
+		},
+	})
+	return factory
+}
+
+// openTimerDB 创建包含定时任务和调度器表的临时SQLite数据库
+func openTimerDB(t *testing.T) database.Database {
+	t.Helper()
+	// 连接名使用临时文件路径，重复运行同一测试时不会复用已关闭的连接
+	dsn := filepath.Join(t.TempDir(), "timer.db")
+	db, err := database.Open(&dbtypes.DbConfig{
+		Name:    dsn,
+		Enabled: true,
+		Driver:  dbtypes.DriverSQLite,
+		DSN:     dsn,
+		Pool:    dbtypes.PoolConfig{MaxOpenConns: 1, MaxIdleConns: 1},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	for _, name := range []string{"HUB_TIMER_SCHEDULER.sql", "HUB_TIMER_TASK.sql"} {
+		script, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "scripts", "db", "sqlite", name))
+		require.NoError(t, err)
+		_, err = db.Exec(context.Background(), string(script), nil, true)
+		require.NoError(t, err)
+	}
+	return db
+}
+
+// insertTask 写入一条每小时执行一次的任务，测试期间不会被调度执行
+// 任务使用以测试名命名的独立调度器，测试结束后从全局定时器池移除
+func insertTask(t *testing.T, db database.Database, taskId, executorType string, status int) string {
+	t.Helper()
+	schedulerId := "sched-" + strings.ReplaceAll(t.Name(), "/", "-")
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO HUB_TIMER_TASK (
+			taskId, tenantId, taskName, taskPriority, schedulerId, scheduleType, intervalSeconds,
+			executorType, taskStatus, consecutiveFailures, addWho, editWho, oprSeqFlag
+		) VALUES (?, 'default', ?, 2, ?, ?, 3600, ?, ?, 3, 'admin', 'admin', ?)
+	`, []interface{}{taskId, taskId + "-name", schedulerId, timertypes.ScheduleTypeInterval, executorType, status, taskId}, true)
+	require.NoError(t, err)
+	t.Cleanup(func() { timer.GetTimerPool().RemoveScheduler(schedulerId) })
+	return schedulerId
+}
+
+// scheduledTask 获取调度器中的任务配置
+func scheduledTask(t *testing.T, schedulerId, taskId string) *timer.TaskConfig {
+	t.Helper()
+	scheduler, err := timer.GetTimerPool().GetScheduler(schedulerId)
+	require.NoError(t, err)
+	task, err := scheduler.GetTask(taskId)
+	require.NoError(t, err)
+	return task
+}
+
+// taskState 查询数据库中的任务状态、连续失败次数和活动标记
+func taskState(t *testing.T, db database.Database, taskId string) (int, int, string) {
+	t.Helper()
+	var state struct {
+		TaskStatus          int    `db:"taskStatus"`
+		ConsecutiveFailures int    `db:"consecutiveFailures"`
+		ActiveFlag          string `db:"activeFlag"`
+	}
+	require.NoError(t, db.QueryOne(context.Background(), &state,
+		"SELECT taskStatus, consecutiveFailures, activeFlag FROM HUB_TIMER_TASK WHERE tenantId = 'default' AND taskId = ?",
+		[]interface{}{taskId}, true))
+	return state.TaskStatus, state.ConsecutiveFailures, state.ActiveFlag
+}
+
+// performTask 以管理员身份调用任务管理接口，返回响应是否成功
+func performTask(t *testing.T, handler gin.HandlerFunc, body string) bool {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/gateway/hub0003/task", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Set(middleware.UserContextKey, &globalmodels.UserContext{UserId: "admin", TenantId: "default"})
+	handler(ctx)
+
+	var resp struct {
+		OK bool `json:"oK"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	return resp.OK
+}
+
+// TestRegisterTaskById 验证按执行器类型注册任务，重复注册时替换调度器中的旧任务
+func TestRegisterTaskById(t *testing.T) {
+	ctx := context.Background()
+	factory := registerTestFactories()
+	db := openTimerDB(t)
+	schedulerId := insertTask(t, db, "task-1", testExecutorType, timertypes.TaskStatusPending)
+
+	require.NoError(t, taskinit.RegisterTaskById(ctx, db, "default", "task-1"))
+	task := scheduledTask(t, schedulerId, "task-1")
+	assert.Equal(t, "task-1-name", task.Name)
+	assert.True(t, task.Enabled)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&factory.created))
+
+	// 修改配置后重新注册，旧执行器被关闭并替换
+	_, err := db.Exec(ctx, "UPDATE HUB_TIMER_TASK SET taskName = 'renamed' WHERE taskId = 'task-1'", nil, true)
+	require.NoError(t, err)
+	require.NoError(t, taskinit.RegisterTaskById(ctx, db, "default", "task-1"))
+	assert.Equal(t, "renamed", scheduledTask(t, schedulerId, "task-1").Name)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&factory.created))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&factory.closed))
+	assert.Zero(t, atomic.LoadInt32(&factory.executed))
+
+	// 未注册、未启用的执行器类型和不存在的任务不能注册
+	insertTask(t, db, "task-unknown", "NOT_REGISTERED", timertypes.TaskStatusPending)
+	assert.ErrorContains(t, taskinit.RegisterTaskById(ctx, db, "default", "task-unknown"), "不支持的执行器类型")
+	insertTask(t, db, "task-disabled", disabledExecutorType, timertypes.TaskStatusPending)
+	assert.ErrorContains(t, taskinit.RegisterTaskById(ctx, db, "default", "task-disabled"), "未启用")
+	assert.Error(t, taskinit.RegisterTaskById(ctx, db, "default", "missing"))
+	assert.Error(t, taskinit.RegisterTaskById(ctx, db, "", "task-1"))
+}
+
+// TestRegisterAllTasks 验证启动注册跳过未启用的执行器类型，暂停的任务注册后不参与调度
+func TestRegisterAllTasks(t *testing.T) {
+	registerTestFactories()
+	db := openTimerDB(t)
+	schedulerId := insertTask(t, db, "task-active", testExecutorType, timertypes.TaskStatusPending)
+	insertTask(t, db, "task-paused", testExecutorType, timertypes.TaskStatusPaused)
+	insertTask(t, db, "task-disabled", disabledExecutorType, timertypes.TaskStatusPending)
+
+	require.NoError(t, taskinit.RegisterAllTasks(context.Background(), db, "default"))
+	assert.True(t, scheduledTask(t, schedulerId, "task-active").Enabled)
+	assert.False(t, scheduledTask(t, schedulerId, "task-paused").Enabled)
+
+	scheduler, err := timer.GetTimerPool().GetScheduler(schedulerId)
+	require.NoError(t, err)
+	_, err = scheduler.GetTask("task-disabled")
+	assert.Error(t, err, "tasks of disabled executor types should not be registered")
+
+	var types []string
+	for _, definition := range taskinit.ListExecutorFactories() {
+		types = append(types, definition.ExecutorType)
+	}
+	assert.Contains(t, types, testExecutorType)
+	assert.Contains(t, types, disabledExecutorType)
+}
+
+// TestPauseResumeTask 验证暂停任务停止调度并持久化状态，恢复后重置连续失败次数并重新注册
+func TestPauseResumeTask(t *testing.T) {
+	factory := registerTestFactories()
+	db := openTimerDB(t)
+	schedulerId := insertTask(t, db, "task-1", testExecutorType, timertypes.TaskStatusPending)
+	require.NoError(t, taskinit.RegisterTaskById(context.Background(), db, "default", "task-1"))
+	controller := controllers.NewTaskConfigController(db)
+
+	require.True(t, performTask(t, controller.PauseTask, `{"taskId":"task-1"}`))
+	assert.False(t, scheduledTask(t, schedulerId, "task-1").Enabled)
+	status, failures, _ := taskState(t, db, "task-1")
+	assert.Equal(t, timertypes.TaskStatusPaused, status)
+	assert.Equal(t, 3, failures)
+
+	// 重复暂停、恢复未暂停的任务返回错误
+	assert.False(t, performTask(t, controller.PauseTask, `{"taskId":"task-1"}`))
+	assert.False(t, performTask(t, controller.PauseTask, `{"taskId":"missing"}`))
+
+	require.True(t, performTask(t, controller.ResumeTask, `{"taskId":"task-1"}`))
+	assert.True(t, scheduledTask(t, schedulerId, "task-1").Enabled)
+	status, failures, _ = taskState(t, db, "task-1")
+	assert.Equal(t, timertypes.TaskStatusPending, status)
+	assert.Zero(t, failures)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&factory.created))
+
+	assert.False(t, performTask(t, controller.ResumeTask, `{"taskId":"task-1"}`))
+}
+
+// TestResumeDeadLetterTask 验证死信任务可以恢复，未注册到调度器的任务暂停时只持久化状态
+func TestResumeDeadLetterTask(t *testing.T) {
+	registerTestFactories()
+	db := openTimerDB(t)
+	schedulerId := insertTask(t, db, "task-dead", testExecutorType, timertypes.TaskStatusDeadLetter)
+	controller := controllers.NewTaskConfigController(db)
+
+	require.True(t, performTask(t, controller.ResumeTask, `{"taskId":"task-dead"}`))
+	assert.True(t, scheduledTask(t, schedulerId, "task-dead").Enabled)
+	status, failures, _ := taskState(t, db, "task-dead")
+	assert.Equal(t, timertypes.TaskStatusPending, status)
+	assert.Zero(t, failures)
+
+	insertTask(t, db, "task-idle", testExecutorType, timertypes.TaskStatusPending)
+	require.True(t, performTask(t, controller.PauseTask, `{"taskId":"task-idle"}`))
+	status, _, _ = taskState(t, db, "task-idle")
+	assert.Equal(t, timertypes.TaskStatusPaused, status)
+}
+
+// TestStopTaskUnregisters 验证停止任务后不再调度，任务被标记为不可用，不能再暂停、恢复或重新注册
+func TestStopTaskUnregisters(t *testing.T) {
+	registerTestFactories()
+	db := openTimerDB(t)
+	schedulerId := insertTask(t, db, "task-1", testExecutorType, timertypes.TaskStatusPending)
+	require.NoError(t, taskinit.RegisterTaskById(context.Background(), db, "default", "task-1"))
+	controller := controllers.NewTaskConfigController(db)
+
+	require.True(t, performTask(t, controller.StopTask, `{"taskId":"task-1","schedulerId":"`+schedulerId+`"}`))
+	task := scheduledTask(t, schedulerId, "task-1")
+	assert.False(t, task.Enabled)
+	assert.Nil(t, task.NextRunTime)
+	_, _, activeFlag := taskState(t, db, "task-1")
+	assert.Equal(t, "N", activeFlag)
+
+	assert.False(t, performTask(t, controller.PauseTask, `{"taskId":"task-1"}`))
+	assert.False(t, performTask(t, controller.ResumeTask, `{"taskId":"task-1"}`))
+	assert.ErrorContains(t, taskinit.RegisterTaskById(context.Background(), db, "default", "task-1"), "未找到")
+}
@@ -0,0 +1,130 @@
+package hub0022
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database"
+	"gateway/pkg/database/dbtypes"
+	_ "gateway/pkg/database/sqlite" // 导入SQLite实现
+	"gateway/web/globalmodels"
+	"gateway/web/middleware"
+	"gateway/web/views/hub0022/controllers"
+	"gateway/web/views/hub0022/dao"
+)
+
+// openNodeDB 创建包含服务节点表的临时SQLite数据库，写入一个在线节点
+func openNodeDB(t *testing.T) database.Database {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "gateway.db")
+	db, err := database.Open(&dbtypes.DbConfig{
+		Name:    dsn,
+		Enabled: true,
+		Driver:  dbtypes.DriverSQLite,
+		DSN:     dsn,
+		Pool:    dbtypes.PoolConfig{MaxOpenConns: 1, MaxIdleConns: 1},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	script, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "scripts", "db", "sqlite", "HUB_GW_SERVICE_NODE.sql"))
+	require.NoError(t, err)
+	_, err = db.Exec(context.Background(), string(script), nil, true)
+	require.NoError(t, err)
+
+	_, err = db.Exec(context.Background(), `INSERT INTO HUB_GW_SERVICE_NODE
+		(tenantId, serviceNodeId, serviceDefinitionId, nodeId, nodeUrl, nodeHost, nodePort, nodeStatus, addWho, editWho, oprSeqFlag)
+		VALUES ('default', 'node-1', 'svc-order', 'node-1', 'http://127.0.0.1:8080', '127.0.0.1', 8080, 1, 'admin', 'admin', 'seq-1')`,
+		nil, true)
+	require.NoError(t, err)
+	return db
+}
+
+// apiResponse 接口响应
+type apiResponse struct {
+	OK      bool   `json:"oK"`
+	BizData string `json:"bizData"`
+	ErrMsg  string `json:"errMsg"`
+	ExtMsg  string `json:"extMsg"`
+}
+
+// message 错误原文，未加载语言包时原文保存在 errMsg 中
+func (r *apiResponse) message() string {
+	if r.ExtMsg != "" {
+		return r.ExtMsg
+	}
+	return r.ErrMsg
+}
+
+// updateNodeStatus 以管理员身份调用节点运行状态更新接口
+func updateNodeStatus(t *testing.T, db database.Database, body string) *apiResponse {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/gateway/hub0022/updateNodeStatus", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Set(middleware.UserContextKey, &globalmodels.UserContext{UserId: "admin", TenantId: "default"})
+	controllers.NewServiceNodeController(db).UpdateNodeStatus(ctx)
+
+	resp := &apiResponse{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), resp))
+	return resp
+}
+
+// TestUpdateNodeStatus 验证节点切换为维护状态后版本号递增、记录操作人，再次切换回在线状态
+func TestUpdateNodeStatus(t *testing.T) {
+	db := openNodeDB(t)
+
+	resp := updateNodeStatus(t, db, `{"serviceNodeId":"node-1","nodeStatus":2}`)
+	require.True(t, resp.OK, resp.message())
+	var node map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(resp.BizData), &node))
+	assert.EqualValues(t, 2, node["nodeStatus"])
+	assert.EqualValues(t, 2, node["currentVersion"])
+	assert.Equal(t, "admin", node["editWho"])
+
+	resp = updateNodeStatus(t, db, `{"serviceNodeId":"node-1","nodeStatus":1}`)
+	require.True(t, resp.OK, resp.message())
+	current, err := dao.NewServiceNodeDAO(db).GetServiceNodeById(context.Background(), "node-1", "default")
+	require.NoError(t, err)
+	assert.Equal(t, 1, current.NodeStatus)
+	assert.Equal(t, 3, current.CurrentVersion)
+}
+
+// TestUpdateNodeStatusValidation 验证节点ID、节点状态取值和节点存在性校验
+func TestUpdateNodeStatusValidation(t *testing.T) {
+	db := openNodeDB(t)
+
+	cases := []struct {
+		name string
+		body string
+		msg  string
+	}{
+		{"缺少节点状态", `{"serviceNodeId":"node-1"}`, "节点状态不能为空"},
+		{"状态超出范围", `{"serviceNodeId":"node-1","nodeStatus":3}`, "节点状态只能为0(下线)、1(在线)或2(维护)"},
+		{"节点不存在", `{"serviceNodeId":"node-missing","nodeStatus":2}`, "服务节点不存在"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := updateNodeStatus(t, db, tc.body)
+			assert.False(t, resp.OK)
+			assert.Contains(t, resp.message(), tc.msg)
+		})
+	}
+
+	current, err := dao.NewServiceNodeDAO(db).GetServiceNodeById(context.Background(), "node-1", "default")
+	require.NoError(t, err)
+	assert.Equal(t, 1, current.NodeStatus)
+	assert.Equal(t, 1, current.CurrentVersion)
+}
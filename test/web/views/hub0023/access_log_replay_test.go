@@ -0,0 +1,316 @@
+package hub0023
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/web/globalmodels"
+	"gateway/web/middleware"
+	"gateway/web/views/hub0023/controllers"
+	"gateway/web/views/hub0023/models"
+)
+
+// replayedRequest 目标环境收到的重放请求
+type replayedRequest struct {
+	method  string
+	uri     string
+	headers http.Header
+	body    string
+}
+
+// replayTarget 记录重放请求的目标环境，/api/users 返回404，其余返回200
+type replayTarget struct {
+	mu       sync.Mutex
+	requests map[string]replayedRequest
+}
+
+// newReplayTarget 启动记录重放请求的目标环境
+func newReplayTarget(t *testing.T) (*replayTarget, *httptest.Server) {
+	t.Helper()
+	target := &replayTarget{requests: make(map[string]replayedRequest)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		target.mu.Lock()
+		target.requests[r.Header.Get("X-Replay-Source-Trace-Id")] = replayedRequest{
+			method:  r.Method,
+			uri:     r.RequestURI,
+			headers: r.Header.Clone(),
+			body:    string(body),
+		}
+		target.mu.Unlock()
+		if r.URL.Path == "/api/users" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return target, server
+}
+
+// request 获取指定链路的重放请求
+func (r *replayTarget) request(traceId string) (replayedRequest, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	req, ok := r.requests[traceId]
+	return req, ok
+}
+
+// replayLogs 重放测试使用的访问日志，按租户和链路追踪ID索引
+var replayLogs = map[string]*models.GatewayAccessLog{
+	"default/trace-post": {
+		TenantId:      "default",
+		TraceId:       "trace-post",
+		RequestMethod: "post",
+		RequestPath:   "/api/orders",
+		RequestQuery:  "?id=1&source=app",
+		RequestHeaders: `{"Authorization":"Bearer token","Content-Type":"application/json","Host":"prod-gw",
+			"Content-Length":"13","X-Gateway-Replay":"true","X-Client":"app"}`,
+		RequestBody:           `{"amount":10}`,
+		GatewayStatusCode:     200,
+		TotalProcessingTimeMs: 35,
+	},
+	"default/trace-get": {
+		TenantId:          "default",
+		TraceId:           "trace-get",
+		RequestMethod:     "GET",
+		RequestPath:       "api/users",
+		GatewayStatusCode: 200,
+	},
+	"default/trace-bad-headers": {
+		TenantId:          "default",
+		TraceId:           "trace-bad-headers",
+		RequestPath:       "/api/orders",
+		RequestHeaders:    "not-json",
+		GatewayStatusCode: 200,
+	},
+}
+
+// newReplayController 创建从内存加载访问日志的重放控制器，记录解析加载函数时的网关实例ID
+func newReplayController(resolvedInstances *[]string) *controllers.AccessLogReplayController {
+	var mu sync.Mutex
+	return controllers.NewAccessLogReplayController(func(ctx context.Context, tenantId, gatewayInstanceId string) controllers.AccessLogLoader {
+		mu.Lock()
+		*resolvedInstances = append(*resolvedInstances, gatewayInstanceId)
+		mu.Unlock()
+		return func(ctx context.Context, tenantId, traceId string) (*models.GatewayAccessLog, error) {
+			if traceId == "trace-error" {
+				return nil, fmt.Errorf("connection refused")
+			}
+			return replayLogs[tenantId+"/"+traceId], nil
+		}
+	})
+}
+
+// replayResponse 重放接口响应
+type replayResponse struct {
+	OK      bool   `json:"oK"`
+	BizData string `json:"bizData"`
+	ErrMsg  string `json:"errMsg"`
+	ExtMsg  string `json:"extMsg"`
+}
+
+// message 错误原文，未加载语言包时原文保存在 errMsg 中
+func (r *replayResponse) message() string {
+	if r.ExtMsg != "" {
+		return r.ExtMsg
+	}
+	return r.ErrMsg
+}
+
+// performReplay 以指定租户调用重放接口
+func performReplay(t *testing.T, handler gin.HandlerFunc, tenantId, body string) *replayResponse {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/gateway/hub0023/gateway-log/replay", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Set(middleware.UserContextKey, &globalmodels.UserContext{UserId: "admin", TenantId: tenantId})
+	handler(ctx)
+
+	resp := &replayResponse{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), resp))
+	return resp
+}
+
+// startReplay 启动重放任务，返回任务快照
+func startReplay(t *testing.T, controller *controllers.AccessLogReplayController, body string) *models.GatewayAccessLogReplayTask {
+	t.Helper()
+	resp := performReplay(t, controller.StartReplay, "default", body)
+	require.True(t, resp.OK, resp.message())
+	task := &models.GatewayAccessLogReplayTask{}
+	require.NoError(t, json.Unmarshal([]byte(resp.BizData), task))
+	return task
+}
+
+// getReplayTask 查询重放任务
+func getReplayTask(t *testing.T, controller *controllers.AccessLogReplayController, taskId string) *models.GatewayAccessLogReplayTask {
+	t.Helper()
+	resp := performReplay(t, controller.GetReplayTask, "default", `{"taskId":"`+taskId+`"}`)
+	require.True(t, resp.OK, resp.message())
+	task := &models.GatewayAccessLogReplayTask{}
+	require.NoError(t, json.Unmarshal([]byte(resp.BizData), task))
+	return task
+}
+
+// waitReplayTask 等待重放任务结束
+func waitReplayTask(t *testing.T, controller *controllers.AccessLogReplayController, taskId string) *models.GatewayAccessLogReplayTask {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		task := getReplayTask(t, controller, taskId)
+		if task.Status != models.ReplayStatusRunning {
+			return task
+		}
+		require.True(t, time.Now().Before(deadline), "重放任务未在5秒内结束")
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// TestAccessLogReplay 验证按访问日志重建请求并发送到目标环境，移除信令头和指定请求头，
+// 按状态码是否与原始日志一致统计结果，日志不存在或无法重建的请求记录失败原因
+func TestAccessLogReplay(t *testing.T) {
+	target, server := newReplayTarget(t)
+	var resolvedInstances []string
+	controller := newReplayController(&resolvedInstances)
+
+	task := startReplay(t, controller, `{"gatewayInstanceId":"gw-1",
+		"traceIds":["trace-post"," trace-get","trace-post","","trace-missing","trace-error","trace-bad-headers"],
+		"targetBaseUrl":"`+server.URL+`/","ratePerSecond":100,"concurrency":2,
+		"stripHeaders":["Authorization"],"setHeaders":{"X-Env":"shadow"}}`)
+	assert.Equal(t, models.ReplayStatusRunning, task.Status)
+	assert.Equal(t, 5, task.Total, "去除空白和重复的链路追踪ID")
+	assert.Equal(t, server.URL, task.TargetBaseUrl)
+	assert.Equal(t, "admin", task.AddWho)
+	assert.Equal(t, []string{"gw-1"}, resolvedInstances)
+
+	task = waitReplayTask(t, controller, task.TaskId)
+	assert.Equal(t, models.ReplayStatusCompleted, task.Status)
+	assert.NotNil(t, task.EndTime)
+	assert.Equal(t, 5, task.Completed)
+	assert.Equal(t, 1, task.Succeeded)
+	assert.Equal(t, 4, task.Failed)
+
+	results := make(map[string]models.GatewayAccessLogReplayResult, len(task.Results))
+	for _, result := range task.Results {
+		results[result.TraceId] = result
+	}
+	post := results["trace-post"]
+	assert.True(t, post.StatusMatched)
+	assert.Equal(t, http.MethodPost, post.Method)
+	assert.Equal(t, server.URL+"/api/orders?id=1&source=app", post.Url)
+	assert.Equal(t, 200, post.StatusCode)
+	assert.Equal(t, 35, post.OriginalDurationMs)
+	assert.EqualValues(t, 2, post.ResponseSize)
+
+	get := results["trace-get"]
+	assert.False(t, get.StatusMatched)
+	assert.Equal(t, 200, get.OriginalStatusCode)
+	assert.Equal(t, http.StatusNotFound, get.StatusCode)
+	assert.Empty(t, get.ErrorMessage)
+
+	assert.Equal(t, "访问日志不存在", results["trace-missing"].ErrorMessage)
+	assert.Equal(t, "加载访问日志失败: connection refused", results["trace-error"].ErrorMessage)
+	assert.Contains(t, results["trace-bad-headers"].ErrorMessage, "解析原始请求头失败")
+
+	req, ok := target.request("trace-post")
+	require.True(t, ok)
+	assert.Equal(t, http.MethodPost, req.method)
+	assert.Equal(t, "/api/orders?id=1&source=app", req.uri)
+	assert.Equal(t, `{"amount":10}`, req.body)
+	assert.Equal(t, "app", req.headers.Get("X-Client"))
+	assert.Equal(t, "shadow", req.headers.Get("X-Env"))
+	assert.Empty(t, req.headers.Get("Authorization"))
+	assert.Empty(t, req.headers.Get("X-Gateway-Replay"))
+	assert.NotEqual(t, "prod-gw", req.headers.Get("Host"))
+
+	req, ok = target.request("trace-get")
+	require.True(t, ok)
+	assert.Equal(t, "/api/users", req.uri)
+	_, ok = target.request("trace-bad-headers")
+	assert.False(t, ok, "无法重建的请求不发送")
+}
+
+// TestAccessLogReplayValidation 验证重放参数校验
+func TestAccessLogReplayValidation(t *testing.T) {
+	var resolvedInstances []string
+	controller := newReplayController(&resolvedInstances)
+	tooMany := make([]string, models.ReplayMaxTraceIds+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("%q", fmt.Sprintf("trace-%d", i))
+	}
+
+	cases := []struct {
+		name string
+		body string
+		msg  string
+	}{
+		{"没有日志", `{"traceIds":[" "],"targetBaseUrl":"http://127.0.0.1"}`, "请选择要重放的访问日志"},
+		{"日志过多", `{"traceIds":[` + strings.Join(tooMany, ",") + `],"targetBaseUrl":"http://127.0.0.1"}`, "单次最多重放1000条访问日志"},
+		{"地址协议无效", `{"traceIds":["trace-post"],"targetBaseUrl":"ftp://127.0.0.1"}`, "目标环境地址必须是有效的 http/https 地址"},
+		{"地址包含查询参数", `{"traceIds":["trace-post"],"targetBaseUrl":"http://127.0.0.1?env=staging"}`, "目标环境地址不能包含查询参数"},
+		{"速率过高", `{"traceIds":["trace-post"],"targetBaseUrl":"http://127.0.0.1","ratePerSecond":101}`, "每秒请求数不能超过100"},
+		{"并发过高", `{"traceIds":["trace-post"],"targetBaseUrl":"http://127.0.0.1","concurrency":21}`, "并发数不能超过20"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := performReplay(t, controller.StartReplay, "default", tc.body)
+			assert.False(t, resp.OK)
+			assert.Contains(t, resp.message(), tc.msg)
+		})
+	}
+	assert.Empty(t, resolvedInstances, "校验未通过时不加载日志")
+}
+
+// TestAccessLogReplayCancel 验证取消执行中的任务后不再分发剩余请求，任务只对所属租户可见
+func TestAccessLogReplayCancel(t *testing.T) {
+	_, server := newReplayTarget(t)
+	var resolvedInstances []string
+	controller := newReplayController(&resolvedInstances)
+
+	// 每秒1条，第一条之后的请求在取消前不会发出
+	task := startReplay(t, controller, `{"traceIds":["trace-post","trace-get","trace-missing"],
+		"targetBaseUrl":"`+server.URL+`","ratePerSecond":1}`)
+	assert.Equal(t, models.ReplayDefaultConcurrency, task.Concurrency)
+
+	// 其他租户不能查看或取消
+	body := `{"taskId":"` + task.TaskId + `"}`
+	resp := performReplay(t, controller.GetReplayTask, "tenant-b", body)
+	assert.False(t, resp.OK)
+	assert.Contains(t, resp.message(), "重放任务不存在或已过期")
+	resp = performReplay(t, controller.CancelReplayTask, "tenant-b", body)
+	assert.False(t, resp.OK)
+	resp = performReplay(t, controller.QueryReplayTasks, "tenant-b", `{}`)
+	require.True(t, resp.OK, resp.message())
+	assert.Equal(t, "[]", resp.BizData)
+
+	resp = performReplay(t, controller.CancelReplayTask, "default", body)
+	require.True(t, resp.OK, resp.message())
+	task = waitReplayTask(t, controller, task.TaskId)
+	assert.Equal(t, models.ReplayStatusCancelled, task.Status)
+	assert.Less(t, task.Completed, task.Total)
+
+	resp = performReplay(t, controller.CancelReplayTask, "default", body)
+	assert.False(t, resp.OK)
+	assert.Contains(t, resp.message(), "重放任务已结束")
+
+	resp = performReplay(t, controller.QueryReplayTasks, "default", `{}`)
+	require.True(t, resp.OK, resp.message())
+	var tasks []models.GatewayAccessLogReplayTask
+	require.NoError(t, json.Unmarshal([]byte(resp.BizData), &tasks))
+	require.Len(t, tasks, 1)
+	assert.Equal(t, task.TaskId, tasks[0].TaskId)
+	assert.Empty(t, tasks[0].Results, "任务列表不含明细结果")
+}
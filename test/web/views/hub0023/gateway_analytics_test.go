@@ -0,0 +1,569 @@
+package hub0023
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgcache "gateway/pkg/cache"
+	"gateway/pkg/cache/memory"
+	"gateway/pkg/database"
+	"gateway/web/globalmodels"
+	"gateway/web/middleware"
+	"gateway/web/views/hub0023/controllers"
+	"gateway/web/views/hub0023/dao"
+	"gateway/web/views/hub0023/models"
+)
+
+// accessLogRow 模拟 HUB_GW_ACCESS_LOG 中参与分析的字段
+type accessLogRow struct {
+	tenantId     string
+	instanceId   string
+	routeId      string
+	routeName    string
+	serviceName  string
+	clientIp     string
+	statusCode   int
+	errorCode    string
+	errorMessage string
+	durationMs   int64
+	startTime    time.Time
+}
+
+// fakeClickHouse 在内存中执行访问日志分析查询
+// 按SQL中的过滤条件筛选日志，再按 GROUP BY 字段和 ClickHouse 聚合函数的语义计算结果，
+// 用于校验分析DAO生成的分组、过滤和排行条件以及结果换算
+type fakeClickHouse struct {
+	database.Database
+	rows    []accessLogRow
+	queries []string
+}
+
+var (
+	havingPattern = regexp.MustCompile(`HAVING requestCount >= (\d+)`)
+	limitPattern  = regexp.MustCompile(`LIMIT (\d+)`)
+)
+
+func (f *fakeClickHouse) Query(ctx context.Context, dest interface{}, query string, args []interface{}, autoCommit bool) error {
+	f.queries = append(f.queries, query)
+	rows, err := f.filter(query, args)
+	if err != nil {
+		return err
+	}
+
+	var results []map[string]interface{}
+	switch {
+	case strings.Contains(query, "GROUP BY timestamp"):
+		results = aggregateTrend(query, rows)
+	case strings.Contains(query, "GROUP BY routeConfigId"):
+		results = aggregateSlowRoutes(query, rows)
+	case strings.Contains(query, "GROUP BY errorCodeGroup"):
+		results = aggregateErrorCodes(rows)
+	case strings.Contains(query, "GROUP BY clientIpAddress"):
+		results = aggregateClientIPs(rows)
+	default:
+		return fmt.Errorf("unexpected query: %s", query)
+	}
+	if match := limitPattern.FindStringSubmatch(query); match != nil {
+		limit, _ := strconv.Atoi(match[1])
+		if len(results) > limit {
+			results = results[:limit]
+		}
+	}
+	return fillRows(dest, results)
+}
+
+// filter 按SQL中的过滤条件和参数筛选日志，参数顺序与条件在SQL中出现的顺序一致
+func (f *fakeClickHouse) filter(query string, args []interface{}) ([]accessLogRow, error) {
+	conditions := []struct {
+		clause string
+		match  func(row accessLogRow, arg interface{}) bool
+	}{
+		{"gatewayStartProcessingTime >= ?", func(row accessLogRow, arg interface{}) bool { return !row.startTime.Before(arg.(time.Time)) }},
+		{"gatewayStartProcessingTime <= ?", func(row accessLogRow, arg interface{}) bool { return !row.startTime.After(arg.(time.Time)) }},
+		{"tenantId = ?", func(row accessLogRow, arg interface{}) bool { return row.tenantId == arg }},
+		{"gatewayInstanceId = ?", func(row accessLogRow, arg interface{}) bool { return row.instanceId == arg }},
+		{"routeConfigId = ?", func(row accessLogRow, arg interface{}) bool { return row.routeId == arg }},
+	}
+
+	type boundCondition struct {
+		pos   int
+		match func(row accessLogRow, arg interface{}) bool
+	}
+	var bound []boundCondition
+	for _, condition := range conditions {
+		if pos := strings.Index(query, condition.clause); pos >= 0 {
+			bound = append(bound, boundCondition{pos: pos, match: condition.match})
+		}
+	}
+	if len(bound) != len(args) || strings.Count(query, "?") != len(args) {
+		return nil, fmt.Errorf("unsupported filter with %d args: %s", len(args), query)
+	}
+	sort.Slice(bound, func(i, j int) bool { return bound[i].pos < bound[j].pos })
+
+	errorsOnly := strings.Contains(query, "errorCode != '' OR gatewayStatusCode >= 400")
+	var result []accessLogRow
+	for _, row := range f.rows {
+		matched := true
+		for i, condition := range bound {
+			matched = matched && condition.match(row, args[i])
+		}
+		if errorsOnly && row.errorCode == "" && row.statusCode < 400 {
+			matched = false
+		}
+		if matched {
+			result = append(result, row)
+		}
+	}
+	return result, nil
+}
+
+// groupRows 按键分组，保持首次出现的顺序
+func groupRows(rows []accessLogRow, key func(row accessLogRow) string) ([]string, map[string][]accessLogRow) {
+	var keys []string
+	groups := make(map[string][]accessLogRow)
+	for _, row := range rows {
+		k := key(row)
+		if _, exists := groups[k]; !exists {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], row)
+	}
+	return keys, groups
+}
+
+// durations 返回大于0的响应时间，对应 avgIf/quantileIf 的条件
+func durations(rows []accessLogRow) []float64 {
+	var values []float64
+	for _, row := range rows {
+		if row.durationMs > 0 {
+			values = append(values, float64(row.durationMs))
+		}
+	}
+	sort.Float64s(values)
+	return values
+}
+
+func average(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// quantile 线性插值分位数，保留6位小数避免浮点误差影响取整
+func quantile(values []float64, level float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	pos := level * float64(len(values)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	value := values[lower] + (values[upper]-values[lower])*(pos-float64(lower))
+	return math.Round(value*1e6) / 1e6
+}
+
+func countRows(rows []accessLogRow, match func(row accessLogRow) bool) int64 {
+	var count int64
+	for _, row := range rows {
+		if match(row) {
+			count++
+		}
+	}
+	return count
+}
+
+func distinctRoutes(rows []accessLogRow) int64 {
+	routes := make(map[string]struct{})
+	for _, row := range rows {
+		routes[row.routeId] = struct{}{}
+	}
+	return int64(len(routes))
+}
+
+func latestMillis(rows []accessLogRow) int64 {
+	var latest time.Time
+	for _, row := range rows {
+		if row.startTime.After(latest) {
+			latest = row.startTime
+		}
+	}
+	return latest.Unix() * 1000
+}
+
+func aggregateTrend(query string, rows []accessLogRow) []map[string]interface{} {
+	bucket := time.Minute
+	if strings.Contains(query, "toStartOfHour(") {
+		bucket = time.Hour
+	}
+	keys, groups := groupRows(rows, func(row accessLogRow) string {
+		return strconv.FormatInt(row.startTime.Truncate(bucket).Unix()*1000, 10)
+	})
+	sort.Strings(keys)
+
+	results := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		timestamp, _ := strconv.ParseInt(key, 10, 64)
+		values := durations(group)
+		results = append(results, map[string]interface{}{
+			"timestamp":       timestamp,
+			"totalRequests":   int64(len(group)),
+			"clientErrors":    countRows(group, func(row accessLogRow) bool { return row.statusCode >= 400 && row.statusCode < 500 }),
+			"serverErrors":    countRows(group, func(row accessLogRow) bool { return row.statusCode >= 500 }),
+			"avgResponseTime": average(values),
+			"p95ResponseTime": quantile(values, 0.95),
+		})
+	}
+	return results
+}
+
+func aggregateSlowRoutes(query string, rows []accessLogRow) []map[string]interface{} {
+	minRequests := int64(1)
+	if match := havingPattern.FindStringSubmatch(query); match != nil {
+		minRequests, _ = strconv.ParseInt(match[1], 10, 64)
+	}
+	keys, groups := groupRows(rows, func(row accessLogRow) string { return row.routeId })
+
+	var results []map[string]interface{}
+	for _, key := range keys {
+		group := groups[key]
+		if int64(len(group)) < minRequests {
+			continue
+		}
+		values := durations(group)
+		results = append(results, map[string]interface{}{
+			"routeConfigId":   key,
+			"routeName":       group[0].routeName,
+			"serviceName":     group[0].serviceName,
+			"requestCount":    int64(len(group)),
+			"errorCount":      countRows(group, func(row accessLogRow) bool { return row.statusCode >= 400 || row.statusCode < 200 }),
+			"avgResponseTime": average(values),
+			"p95ResponseTime": quantile(values, 0.95),
+			"p99ResponseTime": quantile(values, 0.99),
+			"maxResponseTime": quantile(values, 1),
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i]["p95ResponseTime"].(float64) > results[j]["p95ResponseTime"].(float64)
+	})
+	return results
+}
+
+func aggregateErrorCodes(rows []accessLogRow) []map[string]interface{} {
+	keys, groups := groupRows(rows, func(row accessLogRow) string {
+		if row.errorCode == "" {
+			return "HTTP_" + strconv.Itoa(row.statusCode)
+		}
+		return row.errorCode
+	})
+
+	results := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		results = append(results, map[string]interface{}{
+			"errorCodeGroup": key,
+			"count":          int64(len(group)),
+			"affectedRoutes": distinctRoutes(group),
+			"sampleMessage":  group[len(group)-1].errorMessage,
+			"lastOccurTime":  latestMillis(group),
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i]["count"].(int64) > results[j]["count"].(int64)
+	})
+	return results
+}
+
+func aggregateClientIPs(rows []accessLogRow) []map[string]interface{} {
+	keys, groups := groupRows(rows, func(row accessLogRow) string { return row.clientIp })
+
+	results := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		results = append(results, map[string]interface{}{
+			"clientIpAddress": key,
+			"requestCount":    int64(len(group)),
+			"errorCount":      countRows(group, func(row accessLogRow) bool { return row.statusCode >= 400 }),
+			"routeCount":      distinctRoutes(group),
+			"lastRequestTime": latestMillis(group),
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i]["requestCount"].(int64) > results[j]["requestCount"].(int64)
+	})
+	return results
+}
+
+// fillRows 按 db 标签把结果写入目标切片
+func fillRows(dest interface{}, results []map[string]interface{}) error {
+	slice := reflect.ValueOf(dest).Elem()
+	elemType := slice.Type().Elem()
+	for _, result := range results {
+		elem := reflect.New(elemType).Elem()
+		for i := 0; i < elemType.NumField(); i++ {
+			tag := elemType.Field(i).Tag.Get("db")
+			value, ok := result[tag]
+			if !ok {
+				return fmt.Errorf("column %s is not produced by the fake", tag)
+			}
+			elem.Field(i).Set(reflect.ValueOf(value).Convert(elemType.Field(i).Type))
+		}
+		slice.Set(reflect.Append(slice, elem))
+	}
+	return nil
+}
+
+// analyticsBase 测试数据的起始时间，位于历史窗口，分析结果使用较长的缓存时间
+var analyticsBase = time.Date(2026, 10, 15, 10, 0, 0, 0, time.Local)
+
+// at 返回起始时间之后的时刻
+func at(minute, second int) time.Time {
+	return analyticsBase.Add(time.Duration(minute)*time.Minute + time.Duration(second)*time.Second)
+}
+
+// newAnalyticsFixture 创建包含三个路由、三个客户端IP的访问日志
+// 10:00 三个请求，10:01 两个错误请求，10:03 三个请求；另有其他租户、其他实例和时间范围外的干扰数据
+func newAnalyticsFixture() *fakeClickHouse {
+	row := func(routeId, clientIp string, status int, errorCode, errorMessage string, durationMs int64, startTime time.Time) accessLogRow {
+		return accessLogRow{
+			tenantId:     "default",
+			instanceId:   "gw-1",
+			routeId:      routeId,
+			routeName:    routeId + "-name",
+			serviceName:  routeId + "-service",
+			clientIp:     clientIp,
+			statusCode:   status,
+			errorCode:    errorCode,
+			errorMessage: errorMessage,
+			durationMs:   durationMs,
+			startTime:    startTime,
+		}
+	}
+	rows := []accessLogRow{
+		row("r-fast", "10.0.0.1", 200, "", "", 20, at(0, 1)),
+		row("r-fast", "10.0.0.1", 200, "", "", 20, at(0, 30)),
+		row("r-slow", "10.0.0.2", 200, "", "", 800, at(0, 40)),
+		row("r-fast", "10.0.0.2", 404, "", "", 20, at(1, 10)),
+		row("r-slow", "10.0.0.2", 504, "UPSTREAM_TIMEOUT", "upstream timeout", 800, at(1, 20)),
+		row("r-fast", "10.0.0.1", 200, "", "", 20, at(3, 0)),
+		row("r-slow", "10.0.0.1", 200, "", "", 800, at(3, 5)),
+		row("r-single", "10.0.0.3", 500, "UPSTREAM_TIMEOUT", "connection reset", 2000, at(3, 59)),
+	}
+
+	otherTenant := row("r-fast", "10.0.0.9", 500, "", "", 20, at(0, 10))
+	otherTenant.tenantId = "other"
+	otherInstance := row("r-fast", "10.0.0.9", 500, "", "", 20, at(1, 0))
+	otherInstance.instanceId = "gw-2"
+	outOfRange := row("r-fast", "10.0.0.9", 500, "", "", 20, at(-1, 0))
+	rows = append(rows, otherTenant, otherInstance, outOfRange)
+
+	return &fakeClickHouse{rows: rows}
+}
+
+// analyticsRequest 创建 10:00-10:05 的分析请求
+func analyticsRequest() *models.GatewayAnalyticsQueryRequest {
+	return &models.GatewayAnalyticsQueryRequest{
+		StartTime:         analyticsBase.Format("2006-01-02 15:04:05"),
+		EndTime:           at(5, 0).Format("2006-01-02 15:04:05"),
+		GatewayInstanceId: "gw-1",
+		TenantId:          "default",
+	}
+}
+
+// TestAnalyticsRequestTrend 验证请求量按时间粒度分桶，并换算每秒请求数和平均响应时间
+func TestAnalyticsRequestTrend(t *testing.T) {
+	ctx := context.Background()
+	fake := newAnalyticsFixture()
+	monitoringDAO := dao.NewClickHouseMonitoringDAO(fake)
+
+	req := analyticsRequest()
+	req.TimeGranularity = models.TimeGranularityMinute
+	trend, err := monitoringDAO.GetAnalyticsRequestTrend(ctx, req)
+	require.NoError(t, err)
+	assert.Contains(t, fake.queries[0], "toStartOfMinute(gatewayStartProcessingTime)")
+
+	assert.Equal(t, []models.GatewayAnalyticsRequestTrend{
+		{Timestamp: at(0, 0).UnixMilli(), TotalRequests: 3, RequestsPerSecond: 0.05, AvgResponseTimeMs: 280, P95ResponseTimeMs: 722},
+		{Timestamp: at(1, 0).UnixMilli(), TotalRequests: 2, ClientErrors: 1, ServerErrors: 1, RequestsPerSecond: 0.03, AvgResponseTimeMs: 410, P95ResponseTimeMs: 761},
+		{Timestamp: at(3, 0).UnixMilli(), TotalRequests: 3, ServerErrors: 1, RequestsPerSecond: 0.05, AvgResponseTimeMs: 940, P95ResponseTimeMs: 1880},
+	}, trend)
+
+	// 按小时分桶时合并为一个时间点
+	req.TimeGranularity = models.TimeGranularityHour
+	trend, err = monitoringDAO.GetAnalyticsRequestTrend(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, trend, 1)
+	assert.Equal(t, at(0, 0).UnixMilli(), trend[0].Timestamp)
+	assert.Equal(t, int64(8), trend[0].TotalRequests)
+	assert.Equal(t, int64(1), trend[0].ClientErrors)
+	assert.Equal(t, int64(2), trend[0].ServerErrors)
+	assert.Equal(t, 560.0, trend[0].AvgResponseTimeMs)
+	assert.Equal(t, 0.0, trend[0].RequestsPerSecond)
+}
+
+// TestAnalyticsSlowRoutes 验证慢路由按P95倒序排行，并按最小请求数和返回数量过滤
+func TestAnalyticsSlowRoutes(t *testing.T) {
+	ctx := context.Background()
+	monitoringDAO := dao.NewClickHouseMonitoringDAO(newAnalyticsFixture())
+
+	req := analyticsRequest()
+	req.MinRequests = 2
+	routes, err := monitoringDAO.GetAnalyticsSlowRoutes(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []models.GatewayAnalyticsSlowRoute{
+		{
+			RouteConfigId: "r-slow", RouteName: "r-slow-name", ServiceName: "r-slow-service",
+			RequestCount: 3, ErrorRate: 33.33, AvgResponseTimeMs: 800,
+			P95ResponseTimeMs: 800, P99ResponseTimeMs: 800, MaxResponseTimeMs: 800,
+		},
+		{
+			RouteConfigId: "r-fast", RouteName: "r-fast-name", ServiceName: "r-fast-service",
+			RequestCount: 4, ErrorRate: 25, AvgResponseTimeMs: 20,
+			P95ResponseTimeMs: 20, P99ResponseTimeMs: 20, MaxResponseTimeMs: 20,
+		},
+	}, routes)
+
+	// 默认最小请求数为1，只有一个请求的路由也参与排行
+	req.MinRequests = 0
+	req.TopN = 1
+	routes, err = monitoringDAO.GetAnalyticsSlowRoutes(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+	assert.Equal(t, "r-single", routes[0].RouteConfigId)
+	assert.Equal(t, 100.0, routes[0].ErrorRate)
+	assert.Equal(t, 2000, routes[0].P95ResponseTimeMs)
+}
+
+// TestAnalyticsErrorBreakdown 验证错误按错误码分组，未设置错误码的按HTTP状态码归类
+func TestAnalyticsErrorBreakdown(t *testing.T) {
+	fake := newAnalyticsFixture()
+	breakdown, err := dao.NewClickHouseMonitoringDAO(fake).GetAnalyticsErrorBreakdown(context.Background(), analyticsRequest())
+	require.NoError(t, err)
+	assert.Contains(t, fake.queries[0], "LIMIT 10")
+
+	require.Len(t, breakdown, 2)
+	assert.Equal(t, "UPSTREAM_TIMEOUT", breakdown[0].ErrorCode)
+	assert.Equal(t, int64(2), breakdown[0].Count)
+	assert.Equal(t, 66.67, breakdown[0].Percentage)
+	assert.Equal(t, int64(2), breakdown[0].AffectedRoutes)
+	assert.Equal(t, at(3, 59).UnixMilli(), breakdown[0].LastOccurTime)
+	assert.NotEmpty(t, breakdown[0].SampleMessage)
+
+	assert.Equal(t, models.GatewayAnalyticsErrorCode{
+		ErrorCode:      "HTTP_404",
+		Count:          1,
+		Percentage:     33.33,
+		AffectedRoutes: 1,
+		LastOccurTime:  at(1, 10).UnixMilli(),
+	}, breakdown[1])
+}
+
+// TestAnalyticsTopClientIPs 验证客户端IP按请求数排行并计算错误率和访问路由数
+func TestAnalyticsTopClientIPs(t *testing.T) {
+	fake := newAnalyticsFixture()
+	req := analyticsRequest()
+	req.TopN = 500
+	clients, err := dao.NewClickHouseMonitoringDAO(fake).GetAnalyticsTopClientIPs(context.Background(), req)
+	require.NoError(t, err)
+	// 返回数量超过上限时按上限查询
+	assert.Contains(t, fake.queries[0], "LIMIT 100")
+
+	assert.Equal(t, []models.GatewayAnalyticsClientIP{
+		{ClientIpAddress: "10.0.0.1", RequestCount: 4, RouteCount: 2, LastRequestTime: at(3, 5).UnixMilli()},
+		{ClientIpAddress: "10.0.0.2", RequestCount: 3, ErrorCount: 2, ErrorRate: 66.67, RouteCount: 2, LastRequestTime: at(1, 20).UnixMilli()},
+		{ClientIpAddress: "10.0.0.3", RequestCount: 1, ErrorCount: 1, ErrorRate: 100, RouteCount: 1, LastRequestTime: at(3, 59).UnixMilli()},
+	}, clients)
+}
+
+// performAnalytics 以默认租户调用分析接口
+func performAnalytics(handler gin.HandlerFunc, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/gateway/hub0023/gateway-log/analytics", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Set(middleware.UserContextKey, &globalmodels.UserContext{UserId: "admin", TenantId: "default"})
+	handler(ctx)
+	return recorder
+}
+
+// decodeAnalytics 解析分析接口响应
+func decodeAnalytics(t *testing.T, recorder *httptest.ResponseRecorder, data interface{}) bool {
+	t.Helper()
+	var resp struct {
+		OK      bool   `json:"oK"`
+		BizData string `json:"bizData"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	if resp.OK && data != nil {
+		require.NoError(t, json.Unmarshal([]byte(resp.BizData), data))
+	}
+	return resp.OK
+}
+
+// TestAnalyticsControllers 验证分析接口使用上下文租户查询，校验实例和时间范围，并缓存历史窗口的结果
+func TestAnalyticsControllers(t *testing.T) {
+	memCache, err := memory.NewMemoryCache(nil)
+	require.NoError(t, err)
+	require.NoError(t, pkgcache.AddCache("default", memCache))
+	t.Cleanup(func() { pkgcache.GetGlobalManager().RemoveCache("default") })
+
+	fake := newAnalyticsFixture()
+	controller := controllers.NewClickHouseQueryController(fake, nil)
+	body := fmt.Sprintf(`{"startTime":%q,"endTime":%q,"gatewayInstanceId":"gw-1","tenantId":"other","timeGranularity":"minute"}`,
+		analyticsBase.Format("2006-01-02 15:04:05"), at(5, 0).Format("2006-01-02 15:04:05"))
+
+	var trend []models.GatewayAnalyticsRequestTrend
+	require.True(t, decodeAnalytics(t, performAnalytics(controller.GetAnalyticsRequestTrend, body), &trend))
+	require.Len(t, trend, 3)
+	assert.Equal(t, int64(3), trend[0].TotalRequests)
+	assert.Len(t, fake.queries, 1)
+
+	// 相同条件命中缓存，不同分析类型分别缓存
+	require.True(t, decodeAnalytics(t, performAnalytics(controller.GetAnalyticsRequestTrend, body), &trend))
+	assert.Len(t, trend, 3)
+	assert.Len(t, fake.queries, 1)
+
+	var clients []models.GatewayAnalyticsClientIP
+	require.True(t, decodeAnalytics(t, performAnalytics(controller.GetAnalyticsTopClientIPs, body), &clients))
+	require.Len(t, clients, 3)
+	assert.Equal(t, "10.0.0.1", clients[0].ClientIpAddress)
+	assert.Len(t, fake.queries, 2)
+
+	var routes []models.GatewayAnalyticsSlowRoute
+	require.True(t, decodeAnalytics(t, performAnalytics(controller.GetAnalyticsSlowRoutes, body), &routes))
+	assert.Equal(t, "r-single", routes[0].RouteConfigId)
+
+	var breakdown []models.GatewayAnalyticsErrorCode
+	require.True(t, decodeAnalytics(t, performAnalytics(controller.GetAnalyticsErrorBreakdown, body), &breakdown))
+	assert.Equal(t, "UPSTREAM_TIMEOUT", breakdown[0].ErrorCode)
+
+	// 缺少实例ID或时间范围超过24小时时不查询
+	queries := len(fake.queries)
+	noInstance := fmt.Sprintf(`{"startTime":%q,"endTime":%q}`,
+		analyticsBase.Format("2006-01-02 15:04:05"), at(5, 0).Format("2006-01-02 15:04:05"))
+	assert.False(t, decodeAnalytics(t, performAnalytics(controller.GetAnalyticsRequestTrend, noInstance), nil))
+	tooLong := fmt.Sprintf(`{"startTime":%q,"endTime":%q,"gatewayInstanceId":"gw-1"}`,
+		analyticsBase.Format("2006-01-02 15:04:05"), analyticsBase.Add(25*time.Hour).Format("2006-01-02 15:04:05"))
+	assert.False(t, decodeAnalytics(t, performAnalytics(controller.GetAnalyticsRequestTrend, tooLong), nil))
+	assert.Len(t, fake.queries, queries)
+}
@@ -0,0 +1,206 @@
+package hub0023
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database"
+	"gateway/pkg/database/dbtypes"
+	_ "gateway/pkg/database/sqlite" // 导入SQLite实现
+	"gateway/web/globalmodels"
+	"gateway/web/middleware"
+	"gateway/web/views/hub0023/controllers"
+	"gateway/web/views/hub0023/dao"
+	"gateway/web/views/hub0023/models"
+)
+
+// openSavedQueryDB 创建包含保存查询和报表表的临时SQLite数据库
+// 表结构直接读取 scripts/db/sqlite 下的建表脚本
+func openSavedQueryDB(t *testing.T) database.Database {
+	t.Helper()
+	db, err := database.Open(&dbtypes.DbConfig{
+		Name:    t.Name(),
+		Enabled: true,
+		Driver:  dbtypes.DriverSQLite,
+		DSN:     filepath.Join(t.TempDir(), "saved_query.db"),
+		Pool:    dbtypes.PoolConfig{MaxOpenConns: 1, MaxIdleConns: 1},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	for _, name := range []string{"HUB_SAVED_QUERY.sql", "HUB_QUERY_REPORT.sql"} {
+		script, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "scripts", "db", "sqlite", name))
+		require.NoError(t, err)
+		_, err = db.Exec(context.Background(), string(script), nil, true)
+		require.NoError(t, err)
+	}
+	return db
+}
+
+// seedSavedQuery 写入保存查询及其一条成功的报表记录，报表文件内容为 traceId 表头加保存查询ID
+func seedSavedQuery(t *testing.T, savedQueryDAO *dao.SavedQueryDAO, savedQueryId, owner, shareFlag string, startTime time.Time) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now()
+	require.NoError(t, savedQueryDAO.CreateSavedQuery(ctx, &models.SavedQuery{
+		TenantId:        "default",
+		SavedQueryId:    savedQueryId,
+		QueryName:       savedQueryId,
+		QueryType:       models.SavedQueryTypeAccessLog,
+		OwnerUserId:     owner,
+		ShareFlag:       shareFlag,
+		ScheduleEnabled: "N",
+		ReportFormat:    "csv",
+		AddTime:         now,
+		AddWho:          owner,
+		EditTime:        now,
+		EditWho:         owner,
+		OprSeqFlag:      savedQueryId,
+		CurrentVersion:  1,
+		ActiveFlag:      "Y",
+	}))
+
+	filePath := filepath.Join(t.TempDir(), savedQueryId+".csv")
+	require.NoError(t, os.WriteFile(filePath, []byte("traceId\n"+savedQueryId+"\n"), 0o644))
+	require.NoError(t, savedQueryDAO.CreateReport(ctx, &models.QueryReport{
+		TenantId:       "default",
+		ReportId:       "report-" + savedQueryId,
+		SavedQueryId:   savedQueryId,
+		QueryName:      savedQueryId,
+		TriggerType:    models.ReportTriggerManual,
+		ReportStatus:   models.ReportStatusSuccess,
+		RowCount:       1,
+		ReportFormat:   "csv",
+		FileName:       savedQueryId + ".csv",
+		FilePath:       filePath,
+		EmailStatus:    models.ReportEmailNone,
+		StartTime:      startTime,
+		AddTime:        now,
+		AddWho:         owner,
+		EditTime:       now,
+		EditWho:        owner,
+		OprSeqFlag:     savedQueryId,
+		CurrentVersion: 1,
+		ActiveFlag:     "Y",
+	}))
+}
+
+// seedVisibilityFixture 写入三条保存查询：alice 私有、alice 共享、bob 私有
+func seedVisibilityFixture(t *testing.T, db database.Database) *dao.SavedQueryDAO {
+	savedQueryDAO := dao.NewSavedQueryDAO(db)
+	now := time.Now()
+	seedSavedQuery(t, savedQueryDAO, "alice-private", "alice", "N", now.Add(-3*time.Minute))
+	seedSavedQuery(t, savedQueryDAO, "alice-shared", "alice", "Y", now.Add(-2*time.Minute))
+	seedSavedQuery(t, savedQueryDAO, "bob-private", "bob", "N", now.Add(-time.Minute))
+	return savedQueryDAO
+}
+
+// reportIds 提取报表ID
+func reportIds(reports []*models.QueryReport) []string {
+	ids := make([]string, 0, len(reports))
+	for _, report := range reports {
+		ids = append(ids, report.ReportId)
+	}
+	return ids
+}
+
+// TestSavedQueryDAOReportVisibility 验证报表记录只对保存查询的创建人和共享范围可见
+func TestSavedQueryDAOReportVisibility(t *testing.T) {
+	ctx := context.Background()
+	savedQueryDAO := seedVisibilityFixture(t, openSavedQueryDB(t))
+
+	rows, total, err := savedQueryDAO.QueryReports(ctx, "default", "bob", nil, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []string{"report-bob-private", "report-alice-shared"}, reportIds(rows))
+
+	rows, total, err = savedQueryDAO.QueryReports(ctx, "default", "alice", nil, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []string{"report-alice-shared", "report-alice-private"}, reportIds(rows))
+
+	// 按保存查询筛选时同样不能越过可见范围
+	rows, total, err = savedQueryDAO.QueryReports(ctx, "default", "bob", &models.QueryReportListRequest{SavedQueryId: "alice-private"}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, rows)
+
+	// 其他租户看不到
+	_, total, err = savedQueryDAO.QueryReports(ctx, "other", "alice", nil, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+
+	report, err := savedQueryDAO.GetReport(ctx, "default", "bob", "report-alice-private")
+	require.NoError(t, err)
+	assert.Nil(t, report)
+	report, err = savedQueryDAO.GetReport(ctx, "default", "bob", "report-alice-shared")
+	require.NoError(t, err)
+	require.NotNil(t, report)
+	assert.Equal(t, "alice-shared", report.SavedQueryId)
+
+	// 保存查询删除后，创建人仍能看到已生成的报表
+	require.NoError(t, savedQueryDAO.DeleteSavedQuery(ctx, "default", "alice-private", "alice"))
+	report, err = savedQueryDAO.GetReport(ctx, "default", "alice", "report-alice-private")
+	require.NoError(t, err)
+	assert.NotNil(t, report)
+}
+
+// performAs 以指定用户身份调用控制器方法
+func performAs(handler gin.HandlerFunc, userId, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/gateway/hub0023/query-report", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Set(middleware.UserContextKey, &globalmodels.UserContext{UserId: userId, TenantId: "default"})
+	handler(ctx)
+	return recorder
+}
+
+// TestSavedQueryControllerReports 验证报表列表和下载接口按保存查询的可见范围过滤
+func TestSavedQueryControllerReports(t *testing.T) {
+	db := openSavedQueryDB(t)
+	seedVisibilityFixture(t, db)
+	controller := controllers.NewSavedQueryController(db, nil)
+
+	recorder := performAs(controller.QueryReports, "bob", `{}`)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var page struct {
+		OK      bool   `json:"oK"`
+		BizData string `json:"bizData"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &page))
+	assert.True(t, page.OK)
+	var reports []*models.QueryReport
+	require.NoError(t, json.Unmarshal([]byte(page.BizData), &reports))
+	assert.Equal(t, []string{"report-bob-private", "report-alice-shared"}, reportIds(reports))
+
+	// 共享的报表可以下载
+	recorder = performAs(controller.DownloadReport, "bob", `{"reportId":"report-alice-shared"}`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "traceId\nalice-shared\n", recorder.Body.String())
+	assert.Contains(t, recorder.Header().Get("Content-Disposition"), "alice-shared.csv")
+
+	// 其他用户的私有报表不能下载
+	recorder = performAs(controller.DownloadReport, "bob", `{"reportId":"report-alice-private"}`)
+	assert.NotContains(t, recorder.Body.String(), "traceId")
+	var errResp struct {
+		OK bool `json:"oK"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &errResp))
+	assert.False(t, errResp.OK)
+
+	recorder = performAs(controller.DownloadReport, "alice", `{"reportId":"report-alice-private"}`)
+	assert.Equal(t, "traceId\nalice-private\n", recorder.Body.String())
+}
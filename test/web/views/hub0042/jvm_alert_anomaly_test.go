@@ -0,0 +1,191 @@
+package hub0042
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database"
+	"gateway/web/views/hub0042/controllers"
+	"gateway/web/views/hub0042/models"
+)
+
+// openAlertDB 创建包含告警规则、事件、静默和JVM采集表的临时数据库
+func openAlertDB(t *testing.T) database.Database {
+	t.Helper()
+	return openMonitorDB(t, "HUB_MONITOR_ALERT_RULE.sql", "HUB_MONITOR_ALERT_EVENT.sql", "HUB_MONITOR_ALERT_SILENCE.sql",
+		"HUB_MONITOR_JVM_RESOURCE.sql", "HUB_MONITOR_JVM_THREAD.sql", "HUB_MONITOR_JVM_GC.sql")
+}
+
+// addAlertRule 通过管理接口新增告警规则，返回保存后的规则
+func addAlertRule(t *testing.T, db database.Database, body string) *models.AlertRule {
+	t.Helper()
+	resp := callController(t, controllers.NewJvmAlertController(db).AddAlertRule, body)
+	require.True(t, resp.OK, resp.message())
+	rule := &models.AlertRule{}
+	require.NoError(t, json.Unmarshal([]byte(resp.BizData), rule))
+	return rule
+}
+
+// insertThreadSamples 写入每分钟一个的线程数采集点，最后一个采集点为30秒前
+func insertThreadSamples(t *testing.T, db database.Database, resourceId string, values ...float64) {
+	t.Helper()
+	latest := time.Now().Add(-30 * time.Second)
+	for i, value := range values {
+		collectionTime := latest.Add(-time.Duration(len(values)-1-i) * time.Minute)
+		_, err := db.Exec(context.Background(), `INSERT INTO HUB_MONITOR_JVM_THREAD
+			(jvmThreadId, tenantId, jvmResourceId, currentThreadCount, collectionTime) VALUES (?, 'default', ?, ?, ?)`,
+			[]interface{}{fmt.Sprintf("%s-%d", resourceId, i), resourceId, value, collectionTime}, true)
+		require.NoError(t, err)
+	}
+}
+
+// insertGcSamples 写入每分钟一个的GC累积次数采集点，最后一个采集点为30秒前
+func insertGcSamples(t *testing.T, db database.Database, resourceId string, counts ...int64) {
+	t.Helper()
+	latest := time.Now().Add(-30 * time.Second)
+	for i, count := range counts {
+		collectionTime := latest.Add(-time.Duration(len(counts)-1-i) * time.Minute)
+		_, err := db.Exec(context.Background(), `INSERT INTO HUB_MONITOR_JVM_GC
+			(gcSnapshotId, tenantId, jvmResourceId, ygc, fgc, collectionTime) VALUES (?, 'default', ?, ?, 0, ?)`,
+			[]interface{}{fmt.Sprintf("%s-%d", resourceId, i), resourceId, count, collectionTime}, true)
+		require.NoError(t, err)
+	}
+}
+
+// steadySeries 在基准值附近小幅交替波动的序列
+func steadySeries(base float64, count int) []float64 {
+	values := make([]float64, 0, count)
+	for i := 0; i < count; i++ {
+		values = append(values, base+float64(i%3-1))
+	}
+	return values
+}
+
+// firingResourceIds 查询规则告警中的实例
+func firingResourceIds(t *testing.T, db database.Database, alertRuleId string) []string {
+	t.Helper()
+	var rows []struct {
+		JvmResourceId string `db:"jvmResourceId"`
+	}
+	require.NoError(t, db.Query(context.Background(), &rows,
+		"SELECT jvmResourceId FROM HUB_MONITOR_ALERT_EVENT WHERE alertRuleId = ? AND eventStatus = ? ORDER BY jvmResourceId",
+		[]interface{}{alertRuleId, models.AlertEventFiring}, true))
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.JvmResourceId)
+	}
+	return ids
+}
+
+// requiresAttention 查询实例是否被标记为需要立即关注
+func requiresAttention(t *testing.T, db database.Database, resourceId string) string {
+	t.Helper()
+	var row struct {
+		RequiresAttentionFlag string `db:"requiresAttentionFlag"`
+	}
+	require.NoError(t, db.QueryOne(context.Background(), &row,
+		"SELECT requiresAttentionFlag FROM HUB_MONITOR_JVM_RESOURCE WHERE jvmResourceId = ?", []interface{}{resourceId}, true))
+	return row.RequiresAttentionFlag
+}
+
+// TestAnomalyAlertRuleDefaults 验证异常检测规则默认使用3倍标准差和1小时基线窗口，标准差倍数不能为负数
+func TestAnomalyAlertRuleDefaults(t *testing.T) {
+	db := openAlertDB(t)
+
+	rule := addAlertRule(t, db, `{"ruleName":"线程数异常","metricType":"THREAD_COUNT","evaluateMode":"ANOMALY"}`)
+	assert.Equal(t, models.AlertEvaluateAnomaly, rule.EvaluateMode)
+	assert.Equal(t, 3.0, rule.ThresholdValue)
+	assert.Equal(t, 3600, rule.WindowSeconds)
+	assert.Equal(t, models.AlertOperatorGT, rule.CompareOperator)
+
+	rule = addAlertRule(t, db, `{"ruleName":"GC频率异常","metricType":"GC_COUNT","evaluateMode":"ANOMALY",
+		"thresholdValue":2.5,"windowSeconds":600}`)
+	assert.Equal(t, 2.5, rule.ThresholdValue)
+	assert.Equal(t, 600, rule.WindowSeconds)
+
+	resp := callController(t, controllers.NewJvmAlertController(db).AddAlertRule,
+		`{"ruleName":"无效规则","metricType":"THREAD_COUNT","evaluateMode":"ANOMALY","thresholdValue":-1}`)
+	assert.False(t, resp.OK)
+	assert.Contains(t, resp.message(), "异常检测的标准差倍数不能为负数")
+}
+
+// TestEvaluateAnomalyThreadCount 验证最新采集值向上偏离基线超过阈值倍数的实例触发告警并标记为需要关注，
+// 平稳实例和基线数据点不足的实例不告警
+func TestEvaluateAnomalyThreadCount(t *testing.T) {
+	db := openAlertDB(t)
+	for _, id := range []string{"jvm-spike", "jvm-steady", "jvm-new"} {
+		insertJvmResource(t, db, id, "sg-order", "order-"+id, "Y")
+	}
+	insertThreadSamples(t, db, "jvm-spike", append(steadySeries(100, 20), 160)...)
+	insertThreadSamples(t, db, "jvm-steady", append(steadySeries(100, 20), 101)...)
+	// 基线窗口内只有5个数据点，结果待定
+	insertThreadSamples(t, db, "jvm-new", 100, 100, 100, 100, 100, 500)
+
+	rule := addAlertRule(t, db, `{"ruleName":"线程数异常","metricType":"THREAD_COUNT","evaluateMode":"ANOMALY",
+		"groupBy":"INSTANCE"}`)
+	count, err := controllers.NewJvmAlertEngine(db).EvaluateAll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	assert.Equal(t, []string{"jvm-spike"}, firingResourceIds(t, db, rule.AlertRuleId))
+	assert.Equal(t, "Y", requiresAttention(t, db, "jvm-spike"))
+	assert.Equal(t, "N", requiresAttention(t, db, "jvm-steady"))
+	assert.Equal(t, "N", requiresAttention(t, db, "jvm-new"))
+
+	var event models.AlertEvent
+	require.NoError(t, db.QueryOne(context.Background(), &event,
+		"SELECT * FROM HUB_MONITOR_ALERT_EVENT WHERE alertRuleId = ?", []interface{}{rule.AlertRuleId}, true))
+	assert.Equal(t, 160.0, event.MetricValue)
+	assert.Equal(t, "线程数 向上偏离基线超过3倍标准差（基线窗口3600秒）", event.MessageText)
+}
+
+// TestEvaluateAnomalyDownward 验证 LT 运算符检测向下偏离，上升不触发
+func TestEvaluateAnomalyDownward(t *testing.T) {
+	db := openAlertDB(t)
+	insertJvmResource(t, db, "jvm-drop", "sg-order", "order", "Y")
+	insertJvmResource(t, db, "jvm-rise", "sg-order", "order", "Y")
+	insertThreadSamples(t, db, "jvm-drop", append(steadySeries(100, 20), 40)...)
+	insertThreadSamples(t, db, "jvm-rise", append(steadySeries(100, 20), 160)...)
+
+	rule := addAlertRule(t, db, `{"ruleName":"线程数骤降","metricType":"THREAD_COUNT","evaluateMode":"ANOMALY",
+		"compareOperator":"LT","groupBy":"INSTANCE"}`)
+	_, err := controllers.NewJvmAlertEngine(db).EvaluateAll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jvm-drop"}, firingResourceIds(t, db, rule.AlertRuleId))
+}
+
+// TestEvaluateAnomalyGcFrequency 验证GC次数按每分钟增量建立基线，频率突增时告警，
+// 累积值回退（JVM重启）时结果待定，已有告警保持不变
+func TestEvaluateAnomalyGcFrequency(t *testing.T) {
+	db := openAlertDB(t)
+	insertJvmResource(t, db, "jvm-burst", "sg-order", "order", "Y")
+	insertJvmResource(t, db, "jvm-restart", "sg-order", "order", "Y")
+
+	// 每分钟稳定增加2次，最后一分钟增加30次
+	counts := make([]int64, 0, 22)
+	for i := int64(0); i < 21; i++ {
+		counts = append(counts, i*2)
+	}
+	insertGcSamples(t, db, "jvm-burst", append(counts, counts[len(counts)-1]+30)...)
+	insertGcSamples(t, db, "jvm-restart", append(counts, 1)...)
+
+	rule := addAlertRule(t, db, `{"ruleName":"GC频率异常","metricType":"GC_COUNT","evaluateMode":"ANOMALY",
+		"groupBy":"INSTANCE"}`)
+	engine := controllers.NewJvmAlertEngine(db)
+	_, err := engine.EvaluateAll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jvm-burst"}, firingResourceIds(t, db, rule.AlertRuleId))
+	assert.Equal(t, "N", requiresAttention(t, db, "jvm-restart"))
+
+	var event models.AlertEvent
+	require.NoError(t, db.QueryOne(context.Background(), &event,
+		"SELECT * FROM HUB_MONITOR_ALERT_EVENT WHERE alertRuleId = ?", []interface{}{rule.AlertRuleId}, true))
+	assert.InDelta(t, 30, event.MetricValue, 0.5)
+	assert.Contains(t, event.MessageText, "GC次数 每分钟变化量 向上偏离基线")
+}
@@ -0,0 +1,216 @@
+package hub0042
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database"
+	"gateway/web/views/hub0042/controllers"
+	"gateway/web/views/hub0042/models"
+)
+
+// testThreadDump jstack 格式的线程转储：worker-1 等待 worker-2 持有的锁
+const testThreadDump = `2026-10-15 10:00:00
+Full thread dump OpenJDK 64-Bit Server VM (17.0.8+7 mixed mode):
+
+"main" #1 prio=5 os_prio=0 tid=0x1 nid=0x1 runnable
+   java.lang.Thread.State: RUNNABLE
+	at com.example.Main.main(Main.java:10)
+
+"worker-2" #12 daemon prio=5 os_prio=0 tid=0x2 nid=0x2 waiting on condition
+   java.lang.Thread.State: TIMED_WAITING (sleeping)
+	at java.lang.Thread.sleep(Native Method)
+	- locked <0x000000076ab62208> (a java.lang.Object)
+
+"worker-1" #11 prio=5 os_prio=0 tid=0x3 nid=0x3 waiting for monitor entry
+   java.lang.Thread.State: BLOCKED (on object monitor)
+	at com.example.Worker.run(Worker.java:20)
+	- waiting to lock <0x000000076ab62208> (a java.lang.Object)
+
+"GC Thread#0" os_prio=0 tid=0x4 nid=0x4 runnable
+`
+
+// testHeapHistogram jmap -histo 格式的类直方图
+const testHeapHistogram = ` num     #instances         #bytes  class name (module)
+-------------------------------------------------------
+   1:          1000          48000  java.lang.String (java.base@17)
+   2:           200         960000  [B (java.base@17)
+   3:            10            320  java.lang.Object (java.base@17)
+Total          1210        1008320
+`
+
+// newDiagFixture 启用采集上报并通过上报快照登记 jvm-1 实例
+func newDiagFixture(t *testing.T) (database.Database, *gin.Engine) {
+	t.Helper()
+	enableJvmIngest(t)
+	db := openJvmDB(t)
+	router := newJvmIngestRouter(db)
+	result := postSigned(t, router, marshalSnapshots(t, jvmSnapshot("jvm-1", time.Now())), testAccessKey, testSecret, nil).result(t)
+	require.Equal(t, 1, result.Accepted)
+	return db, router
+}
+
+// addDiagTask 通过管理接口创建诊断任务
+func addDiagTask(t *testing.T, db database.Database, body string) *apiResponse {
+	t.Helper()
+	return callController(t, controllers.NewJvmDiagController(db).AddJvmDiagTask, body)
+}
+
+// dispatchedTasks 上报一次快照，返回随响应下发的诊断任务
+func dispatchedTasks(t *testing.T, router http.Handler) []*models.JvmDiagCommand {
+	t.Helper()
+	body := marshalSnapshots(t, jvmSnapshot("jvm-1", time.Now()))
+	return postSigned(t, router, body, testAccessKey, testSecret, nil).result(t).DiagTasks
+}
+
+// submitDiagResult 采集端签名提交诊断结果
+func submitDiagResult(t *testing.T, router http.Handler, result *models.JvmDiagResultRequest) *apiResponse {
+	t.Helper()
+	body, err := json.Marshal(result)
+	require.NoError(t, err)
+	return postSignedPath(t, router, "/submitJvmDiagResult", body, testAccessKey, testSecret, nil)
+}
+
+// getDiagTask 通过管理接口获取诊断任务详情
+func getDiagTask(t *testing.T, db database.Database, diagTaskId string) *models.JvmDiagTaskDetail {
+	t.Helper()
+	resp := callController(t, controllers.NewJvmDiagController(db).GetJvmDiagTask, `{"diagTaskId":"`+diagTaskId+`"}`)
+	require.True(t, resp.OK, resp.message())
+	detail := &models.JvmDiagTaskDetail{}
+	require.NoError(t, json.Unmarshal([]byte(resp.BizData), detail))
+	return detail
+}
+
+// TestJvmThreadDumpTask 验证线程转储任务随快照上报下发一次，提交结果后解析线程并将BLOCKED线程和持锁线程排在前面
+func TestJvmThreadDumpTask(t *testing.T) {
+	db, router := newDiagFixture(t)
+
+	resp := addDiagTask(t, db, `{"jvmResourceId":"jvm-1","diagType":"THREAD_DUMP","noteText":"排查卡顿"}`)
+	require.True(t, resp.OK, resp.message())
+	task := &models.JvmDiagTask{}
+	require.NoError(t, json.Unmarshal([]byte(resp.BizData), task))
+	assert.Equal(t, models.JvmDiagPending, task.TaskStatus)
+	assert.Equal(t, "order-service", task.ApplicationName)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), task.ExpireTime, 5*time.Second)
+
+	commands := dispatchedTasks(t, router)
+	require.Len(t, commands, 1)
+	assert.Equal(t, task.DiagTaskId, commands[0].DiagTaskId)
+	assert.Equal(t, "jvm-1", commands[0].JvmResourceId)
+	assert.Equal(t, models.JvmDiagThreadDump, commands[0].DiagType)
+	// 已下发的任务不会重复下发
+	assert.Empty(t, dispatchedTasks(t, router))
+
+	// 实例不一致或成功但没有内容的结果不接收
+	resp = submitDiagResult(t, router, &models.JvmDiagResultRequest{DiagTaskId: task.DiagTaskId, JvmResourceId: "jvm-2", Success: true, Content: testThreadDump})
+	assert.False(t, resp.OK)
+	assert.Contains(t, resp.message(), "诊断任务不存在")
+	resp = submitDiagResult(t, router, &models.JvmDiagResultRequest{DiagTaskId: task.DiagTaskId, JvmResourceId: "jvm-1", Success: true})
+	assert.False(t, resp.OK)
+
+	resp = submitDiagResult(t, router, &models.JvmDiagResultRequest{DiagTaskId: task.DiagTaskId, JvmResourceId: "jvm-1", Success: true, Content: testThreadDump})
+	require.True(t, resp.OK, resp.message())
+	completed := &models.JvmDiagTask{}
+	require.NoError(t, json.Unmarshal([]byte(resp.BizData), completed))
+	assert.Equal(t, models.JvmDiagCompleted, completed.TaskStatus)
+	assert.Equal(t, 4, completed.ThreadCount)
+	assert.Equal(t, 1, completed.BlockedThreadCount)
+	assert.Nil(t, completed.ResultText)
+
+	// 已完成的任务不能再次提交
+	resp = submitDiagResult(t, router, &models.JvmDiagResultRequest{DiagTaskId: task.DiagTaskId, JvmResourceId: "jvm-1", Success: true, Content: testThreadDump})
+	assert.False(t, resp.OK)
+	assert.Contains(t, resp.message(), "诊断任务不是待提交状态: COMPLETED")
+
+	detail := getDiagTask(t, db, task.DiagTaskId)
+	require.NotNil(t, detail.Task.ResultText)
+	assert.Equal(t, len(testThreadDump), detail.Task.ResultSize)
+	require.Len(t, detail.Threads, 4)
+	assert.Equal(t, "worker-1", detail.Threads[0].Name)
+	assert.True(t, detail.Threads[0].Blocked)
+	assert.Equal(t, "0x000000076ab62208", detail.Threads[0].WaitingToLock)
+	assert.Equal(t, "worker-2", detail.Threads[1].Name)
+	assert.True(t, detail.Threads[1].BlockingOthers)
+	assert.True(t, detail.Threads[1].Daemon)
+	assert.Equal(t, "main", detail.Threads[2].Name)
+	assert.Equal(t, "GC Thread#0", detail.Threads[3].Name)
+	assert.Equal(t, map[string]int{"RUNNABLE": 1, "TIMED_WAITING": 1, "BLOCKED": 1, "UNKNOWN": 1}, detail.StateCounts)
+}
+
+// TestJvmHeapHistogramTask 验证类直方图结果按占用字节数排序，采集失败时记录失败原因
+func TestJvmHeapHistogramTask(t *testing.T) {
+	db, router := newDiagFixture(t)
+	require.True(t, addDiagTask(t, db, `{"jvmResourceId":"jvm-1","diagType":"HEAP_HISTOGRAM"}`).OK)
+	require.True(t, addDiagTask(t, db, `{"jvmResourceId":"jvm-1","diagType":"THREAD_DUMP"}`).OK)
+	commands := dispatchedTasks(t, router)
+	require.Len(t, commands, 2)
+	assert.Equal(t, models.JvmDiagHeapHistogram, commands[0].DiagType)
+
+	resp := submitDiagResult(t, router, &models.JvmDiagResultRequest{DiagTaskId: commands[0].DiagTaskId, JvmResourceId: "jvm-1", Success: true, Content: testHeapHistogram})
+	require.True(t, resp.OK, resp.message())
+	detail := getDiagTask(t, db, commands[0].DiagTaskId)
+	require.Len(t, detail.Histogram, 3)
+	assert.Equal(t, "[B (java.base@17)", detail.Histogram[0].ClassName)
+	assert.Equal(t, int64(960000), detail.Histogram[0].Bytes)
+	assert.Equal(t, 2, detail.Histogram[0].Rank)
+	assert.Equal(t, "java.lang.String (java.base@17)", detail.Histogram[1].ClassName)
+	assert.Empty(t, detail.Threads)
+
+	// 失败原因超长时截断
+	resp = submitDiagResult(t, router, &models.JvmDiagResultRequest{DiagTaskId: commands[1].DiagTaskId, JvmResourceId: "jvm-1",
+		ErrorMessage: strings.Repeat("无法连接", 200)})
+	require.True(t, resp.OK, resp.message())
+	detail = getDiagTask(t, db, commands[1].DiagTaskId)
+	assert.Equal(t, models.JvmDiagFailed, detail.Task.TaskStatus)
+	require.NotNil(t, detail.Task.ErrorMessage)
+	assert.LessOrEqual(t, len(*detail.Task.ErrorMessage), 1000)
+	assert.True(t, strings.HasPrefix(*detail.Task.ErrorMessage, "无法连接"))
+	assert.Empty(t, detail.Threads)
+}
+
+// TestAddJvmDiagTaskValidation 验证诊断类型、实例存在性和单实例未完成任务上限，过期任务不计入上限
+func TestAddJvmDiagTaskValidation(t *testing.T) {
+	db, router := newDiagFixture(t)
+
+	resp := addDiagTask(t, db, `{"diagType":"THREAD_DUMP"}`)
+	assert.False(t, resp.OK)
+	assert.Contains(t, resp.message(), "jvmResourceId不能为空")
+	resp = addDiagTask(t, db, `{"jvmResourceId":"jvm-1","diagType":"HEAP_DUMP"}`)
+	assert.False(t, resp.OK)
+	assert.Contains(t, resp.message(), "不支持的诊断类型: HEAP_DUMP")
+	resp = addDiagTask(t, db, `{"jvmResourceId":"jvm-missing","diagType":"THREAD_DUMP"}`)
+	assert.False(t, resp.OK)
+	assert.Contains(t, resp.message(), "JVM实例不存在")
+
+	for i := 0; i < 3; i++ {
+		require.True(t, addDiagTask(t, db, `{"jvmResourceId":"jvm-1","diagType":"THREAD_DUMP"}`).OK)
+	}
+	resp = addDiagTask(t, db, `{"jvmResourceId":"jvm-1","diagType":"THREAD_DUMP"}`)
+	assert.False(t, resp.OK)
+	assert.Contains(t, resp.message(), "未完成的诊断任务过多")
+
+	// 过期的任务置为已过期，不再下发，也不占用上限
+	_, err := db.Exec(context.Background(), "UPDATE HUB_MONITOR_JVM_DIAG_TASK SET expireTime = ?",
+		[]interface{}{time.Now().Add(-time.Minute)}, true)
+	require.NoError(t, err)
+	assert.Empty(t, dispatchedTasks(t, router))
+	require.True(t, addDiagTask(t, db, `{"jvmResourceId":"jvm-1","diagType":"THREAD_DUMP"}`).OK)
+
+	resp = callController(t, controllers.NewJvmDiagController(db).QueryJvmDiagTasks, `{"taskStatus":"EXPIRED"}`)
+	require.True(t, resp.OK, resp.message())
+	var expired []*models.JvmDiagTask
+	require.NoError(t, json.Unmarshal([]byte(resp.BizData), &expired))
+	require.Len(t, expired, 3)
+	for _, task := range expired {
+		assert.Equal(t, models.JvmDiagExpired, task.TaskStatus)
+		assert.Nil(t, task.ResultText)
+	}
+}
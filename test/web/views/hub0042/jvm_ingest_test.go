@@ -0,0 +1,390 @@
+package hub0042
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"gateway/pkg/config"
+	"gateway/pkg/database"
+	"gateway/pkg/database/dbtypes"
+	_ "gateway/pkg/database/sqlite" // 导入SQLite实现
+	"gateway/pkg/security"
+	"gateway/web/views/hub0042/controllers"
+	"gateway/web/views/hub0042/models"
+)
+
+const (
+	// testAccessKey 测试访问密钥
+	testAccessKey = "agent-a"
+	// testSecret 测试签名密钥
+	testSecret = "secret-a"
+	// testTenantId 测试访问密钥绑定的租户，与管理接口的登录租户一致
+	testTenantId = "default"
+)
+
+// loadIngestConfig 写入采集上报配置并加载，测试结束后清空配置
+func loadIngestConfig(t *testing.T, content string) {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "web.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+	require.NoError(t, config.LoadConfigFile(file, config.LoadOptions{ClearExisting: true}))
+	t.Cleanup(config.Clear)
+}
+
+// enableJvmIngest 启用JVM采集上报，单次最多上报两条快照
+func enableJvmIngest(t *testing.T) {
+	t.Helper()
+	loadIngestConfig(t, fmt.Sprintf(`web:
+  jvm_ingest:
+    enabled: true
+    max_batch_size: 2
+    access_keys:
+      - access_key: %s
+        secret: %s
+        tenant_id: %s
+      - access_key: incomplete
+        secret: ""
+        tenant_id: %s
+`, testAccessKey, testSecret, testTenantId, testTenantId))
+}
+
+// openMonitorDB 创建包含指定监控表的临时SQLite数据库
+func openMonitorDB(t *testing.T, scripts ...string) database.Database {
+	t.Helper()
+	// 连接名使用临时文件路径，重复运行同一测试时不会复用已关闭的连接
+	dsn := filepath.Join(t.TempDir(), "monitor.db")
+	db, err := database.Open(&dbtypes.DbConfig{
+		Name:    dsn,
+		Enabled: true,
+		Driver:  dbtypes.DriverSQLite,
+		DSN:     dsn,
+		Pool:    dbtypes.PoolConfig{MaxOpenConns: 1, MaxIdleConns: 1},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	for _, name := range scripts {
+		script, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "scripts", "db", "sqlite", name))
+		require.NoError(t, err)
+		_, err = db.Exec(context.Background(), string(script), nil, true)
+		require.NoError(t, err)
+	}
+	return db
+}
+
+// openJvmDB 创建包含JVM采集和诊断任务表的临时数据库
+func openJvmDB(t *testing.T) database.Database {
+	return openMonitorDB(t, "HUB_MONITOR_JVM_RESOURCE.sql", "HUB_MONITOR_JVM_MEMORY.sql",
+		"HUB_MONITOR_JVM_GC.sql", "HUB_MONITOR_JVM_THREAD.sql", "HUB_MONITOR_JVM_DIAG_TASK.sql")
+}
+
+// newJvmIngestRouter 按模块路由注册验签中间件和上报接口
+func newJvmIngestRouter(db database.Database) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	controller := controllers.NewJvmIngestController(db)
+	router := gin.New()
+	router.POST("/ingestJvmSnapshots", controller.VerifySignature(), controller.IngestBatch)
+	router.POST("/submitJvmDiagResult", controller.VerifySignature(), controller.SubmitDiagResult)
+	return router
+}
+
+// apiResponse 接口响应
+type apiResponse struct {
+	status  int
+	OK      bool   `json:"oK"`
+	BizData string `json:"bizData"`
+	ErrMsg  string `json:"errMsg"`
+	ExtMsg  string `json:"extMsg"`
+}
+
+// message 错误原文，未加载语言包时原文保存在 errMsg 中
+func (r *apiResponse) message() string {
+	if r.ExtMsg != "" {
+		return r.ExtMsg
+	}
+	return r.ErrMsg
+}
+
+// result 解析上报结果
+func (r *apiResponse) result(t *testing.T) *models.JvmIngestResult {
+	t.Helper()
+	require.True(t, r.OK, r.message())
+	result := &models.JvmIngestResult{}
+	require.NoError(t, json.Unmarshal([]byte(r.BizData), result))
+	return result
+}
+
+// postSigned 使用访问密钥签名后发送快照上报请求，headers 在签名前设置
+func postSigned(t *testing.T, router http.Handler, body []byte, accessKey, secret string, headers map[string]string) *apiResponse {
+	t.Helper()
+	return postSignedPath(t, router, "/ingestJvmSnapshots", body, accessKey, secret, headers)
+}
+
+// postSignedPath 使用访问密钥签名后发送请求
+func postSignedPath(t *testing.T, router http.Handler, path string, body []byte, accessKey, secret string, headers map[string]string) *apiResponse {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	require.NoError(t, security.SignHTTPRequest(req, accessKey, secret, "Content-Type"))
+	return send(t, router, req)
+}
+
+// send 发送请求并解析响应
+func send(t *testing.T, router http.Handler, req *http.Request) *apiResponse {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	resp := &apiResponse{status: recorder.Code}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), resp))
+	return resp
+}
+
+// jvmSnapshot 构造一条完整的采集快照
+func jvmSnapshot(resourceId string, collectionTime time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"resource": map[string]interface{}{
+			"tenantId":        "other-tenant",
+			"serviceGroupId":  "sg-order",
+			"jvmResourceId":   resourceId,
+			"applicationName": "order-service",
+			"groupName":       "DEFAULT",
+			"collectionTime":  collectionTime.Format(time.RFC3339),
+			"jvmStartTime":    collectionTime.Add(-time.Hour).Format(time.RFC3339),
+			"jvmUptimeMs":     3600000,
+		},
+		"memory": []interface{}{
+			map[string]interface{}{"memoryType": "heap", "usedMemoryBytes": 256, "committedMemoryBytes": 512, "maxMemoryBytes": 1024},
+			map[string]interface{}{"memoryType": "NON_HEAP", "usedMemoryBytes": 64, "maxMemoryBytes": -1},
+		},
+		"gc":     map[string]interface{}{"collectionCount": 12, "ygc": 10, "fgc": 2, "gct": 0.5},
+		"thread": map[string]interface{}{"currentThreadCount": 40, "daemonThreadCount": 30, "peakThreadCount": 45, "cpuTimeSupported": "y"},
+	}
+}
+
+// marshalSnapshots 序列化上报请求体
+func marshalSnapshots(t *testing.T, snapshots ...map[string]interface{}) []byte {
+	t.Helper()
+	list := make([]interface{}, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		list = append(list, snapshot)
+	}
+	body, err := json.Marshal(map[string]interface{}{"snapshots": list})
+	require.NoError(t, err)
+	return body
+}
+
+// countRows 统计表中指定租户的记录数
+func countRows(t *testing.T, db database.Database, table, tenantId string) int {
+	t.Helper()
+	var result struct {
+		Count int `db:"COUNT(*)"`
+	}
+	require.NoError(t, db.QueryOne(context.Background(), &result,
+		"SELECT COUNT(*) FROM "+table+" WHERE tenantId = ?", []interface{}{tenantId}, true))
+	return result.Count
+}
+
+// TestIngestJvmSnapshots 验证快照写入访问密钥绑定的租户，校验未通过的快照被跳过并返回原因
+func TestIngestJvmSnapshots(t *testing.T) {
+	enableJvmIngest(t)
+	db := openJvmDB(t)
+	router := newJvmIngestRouter(db)
+	now := time.Now().Add(-time.Minute)
+
+	invalid := jvmSnapshot("jvm-2", now)
+	invalid["memory"] = []interface{}{map[string]interface{}{"memoryType": "PERM"}}
+	resp := postSigned(t, router, marshalSnapshots(t, jvmSnapshot("jvm-1", now), invalid), testAccessKey, testSecret, nil)
+	require.Equal(t, http.StatusOK, resp.status)
+	result := resp.result(t)
+	assert.Equal(t, 1, result.Accepted)
+	require.Len(t, result.Rejected, 1)
+	assert.Equal(t, 1, result.Rejected[0].Index)
+	assert.Equal(t, "jvm-2", result.Rejected[0].JvmResourceId)
+	assert.Contains(t, result.Rejected[0].Reason, "memory[0].memoryType无效")
+	assert.Empty(t, result.DiagTasks)
+
+	assert.Equal(t, 1, countRows(t, db, "HUB_MONITOR_JVM_RESOURCE", testTenantId))
+	assert.Equal(t, 2, countRows(t, db, "HUB_MONITOR_JVM_MEMORY", testTenantId))
+	assert.Equal(t, 1, countRows(t, db, "HUB_MONITOR_JVM_GC", testTenantId))
+	assert.Equal(t, 1, countRows(t, db, "HUB_MONITOR_JVM_THREAD", testTenantId))
+	assert.Zero(t, countRows(t, db, "HUB_MONITOR_JVM_RESOURCE", "other-tenant"))
+
+	// 内存类型规范化为大写，未上报使用率时按已用/最大计算
+	var memory struct {
+		MemoryType   string  `db:"memoryType"`
+		UsagePercent float64 `db:"usagePercent"`
+		AddWho       string  `db:"addWho"`
+	}
+	require.NoError(t, db.QueryOne(context.Background(), &memory,
+		"SELECT memoryType, usagePercent, addWho FROM HUB_MONITOR_JVM_MEMORY WHERE maxMemoryBytes = 1024", nil, true))
+	assert.Equal(t, models.JvmMemoryTypeHeap, memory.MemoryType)
+	assert.Equal(t, 25.0, memory.UsagePercent)
+	assert.Equal(t, testAccessKey, memory.AddWho)
+
+	var thread struct {
+		CpuTimeSupported string `db:"cpuTimeSupported"`
+		HealthyFlag      string `db:"healthyFlag"`
+	}
+	require.NoError(t, db.QueryOne(context.Background(), &thread,
+		"SELECT cpuTimeSupported, healthyFlag FROM HUB_MONITOR_JVM_THREAD", nil, true))
+	assert.Equal(t, "Y", thread.CpuTimeSupported)
+	assert.Equal(t, "Y", thread.HealthyFlag)
+
+	// 再次上报同一实例时更新资源，快照追加，创建人保持不变
+	resp = postSigned(t, router, marshalSnapshots(t, jvmSnapshot("jvm-1", now.Add(30*time.Second))), testAccessKey, testSecret, nil)
+	assert.Equal(t, 1, resp.result(t).Accepted)
+	assert.Equal(t, 1, countRows(t, db, "HUB_MONITOR_JVM_RESOURCE", testTenantId))
+	assert.Equal(t, 4, countRows(t, db, "HUB_MONITOR_JVM_MEMORY", testTenantId))
+	var resource struct {
+		AddWho         string    `db:"addWho"`
+		CollectionTime time.Time `db:"collectionTime"`
+	}
+	require.NoError(t, db.QueryOne(context.Background(), &resource,
+		"SELECT addWho, collectionTime FROM HUB_MONITOR_JVM_RESOURCE WHERE jvmResourceId = 'jvm-1'", nil, true))
+	assert.Equal(t, testAccessKey, resource.AddWho)
+	assert.WithinDuration(t, now.Add(30*time.Second), resource.CollectionTime, time.Second)
+}
+
+// TestIngestJvmSnapshotsValidation 验证必填字段、采集时间超前和Y/N标记的校验
+func TestIngestJvmSnapshotsValidation(t *testing.T) {
+	enableJvmIngest(t)
+	router := newJvmIngestRouter(openJvmDB(t))
+	now := time.Now()
+
+	cases := []struct {
+		name   string
+		modify func(snapshot map[string]interface{})
+		reason string
+	}{
+		{"缺少应用名", func(s map[string]interface{}) {
+			delete(s["resource"].(map[string]interface{}), "applicationName")
+		}, "resource.applicationName不能为空"},
+		{"采集时间超前", func(s map[string]interface{}) {
+			s["resource"].(map[string]interface{})["collectionTime"] = now.Add(time.Hour).Format(time.RFC3339)
+		}, "resource.collectionTime晚于服务端时间"},
+		{"标记无效", func(s map[string]interface{}) {
+			s["resource"].(map[string]interface{})["healthyFlag"] = "X"
+		}, "resource.healthyFlag只能为Y或N"},
+		{"GC次数为负", func(s map[string]interface{}) {
+			s["gc"].(map[string]interface{})["ygc"] = -1
+		}, "gc回收次数和耗时不能为负数"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			snapshot := jvmSnapshot("jvm-invalid", now)
+			tc.modify(snapshot)
+			result := postSigned(t, router, marshalSnapshots(t, snapshot), testAccessKey, testSecret, nil).result(t)
+			assert.Zero(t, result.Accepted)
+			require.Len(t, result.Rejected, 1)
+			assert.Equal(t, tc.reason, result.Rejected[0].Reason)
+		})
+	}
+
+	// 空批次和超过单次上报上限的批次整体拒绝
+	resp := postSigned(t, router, marshalSnapshots(t), testAccessKey, testSecret, nil)
+	assert.False(t, resp.OK)
+	snapshot := jvmSnapshot("jvm-1", now)
+	resp = postSigned(t, router, marshalSnapshots(t, snapshot, snapshot, snapshot), testAccessKey, testSecret, nil)
+	assert.False(t, resp.OK)
+	assert.Contains(t, resp.message(), "单次上报不能超过2条快照")
+}
+
+// TestIngestJvmSnapshotsEncoding 验证gzip压缩和protobuf请求体
+func TestIngestJvmSnapshotsEncoding(t *testing.T) {
+	enableJvmIngest(t)
+	db := openJvmDB(t)
+	router := newJvmIngestRouter(db)
+	body := marshalSnapshots(t, jvmSnapshot("jvm-1", time.Now()))
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write(body)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	resp := postSigned(t, router, compressed.Bytes(), testAccessKey, testSecret, map[string]string{"Content-Encoding": "gzip"})
+	assert.Equal(t, 1, resp.result(t).Accepted)
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	message, err := structpb.NewStruct(payload)
+	require.NoError(t, err)
+	encoded, err := proto.Marshal(message)
+	require.NoError(t, err)
+	resp = postSigned(t, router, encoded, testAccessKey, testSecret, map[string]string{"Content-Type": "application/x-protobuf"})
+	assert.Equal(t, 1, resp.result(t).Accepted)
+	assert.Equal(t, 2, countRows(t, db, "HUB_MONITOR_JVM_GC", testTenantId))
+
+	// 声明gzip但内容不是gzip时拒绝
+	resp = postSigned(t, router, body, testAccessKey, testSecret, map[string]string{"Content-Encoding": "gzip"})
+	assert.Equal(t, http.StatusBadRequest, resp.status)
+	assert.Contains(t, resp.message(), "gzip解压失败")
+}
+
+// TestIngestJvmSnapshotsSignature 验证未签名、签名错误、未知或配置不完整的访问密钥和重放请求被拒绝
+func TestIngestJvmSnapshotsSignature(t *testing.T) {
+	enableJvmIngest(t)
+	db := openJvmDB(t)
+	router := newJvmIngestRouter(db)
+	body := marshalSnapshots(t, jvmSnapshot("jvm-1", time.Now()))
+
+	unsigned := httptest.NewRequest(http.MethodPost, "/ingestJvmSnapshots", bytes.NewReader(body))
+	assert.Equal(t, http.StatusUnauthorized, send(t, router, unsigned).status)
+	assert.Equal(t, http.StatusUnauthorized, postSigned(t, router, body, testAccessKey, "wrong", nil).status)
+	assert.Equal(t, http.StatusUnauthorized, postSigned(t, router, body, "unknown", testSecret, nil).status)
+	assert.Equal(t, http.StatusUnauthorized, postSigned(t, router, body, "incomplete", "", nil).status)
+
+	// 签名后篡改请求体
+	req := httptest.NewRequest(http.MethodPost, "/ingestJvmSnapshots", bytes.NewReader(body))
+	require.NoError(t, security.SignHTTPRequest(req, testAccessKey, testSecret))
+	req.Body = http.NoBody
+	assert.Equal(t, http.StatusUnauthorized, send(t, router, req).status)
+
+	// 相同随机数的请求只接受一次
+	req = httptest.NewRequest(http.MethodPost, "/ingestJvmSnapshots", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	require.NoError(t, security.SignHTTPRequest(req, testAccessKey, testSecret))
+	replay := req.Clone(context.Background())
+	replay.Body = io.NopCloser(bytes.NewReader(body))
+	assert.True(t, send(t, router, req).OK)
+	assert.Equal(t, http.StatusUnauthorized, send(t, router, replay).status)
+	assert.Equal(t, 1, countRows(t, db, "HUB_MONITOR_JVM_RESOURCE", testTenantId))
+}
+
+// TestIngestJvmSnapshotsDisabled 验证未启用或未配置访问密钥时上报接口返回403
+func TestIngestJvmSnapshotsDisabled(t *testing.T) {
+	body := []byte(`{"snapshots":[]}`)
+
+	loadIngestConfig(t, fmt.Sprintf(`web:
+  jvm_ingest:
+    enabled: false
+    access_keys:
+      - access_key: %s
+        secret: %s
+        tenant_id: %s
+`, testAccessKey, testSecret, testTenantId))
+	router := newJvmIngestRouter(openJvmDB(t))
+	assert.Equal(t, http.StatusForbidden, postSigned(t, router, body, testAccessKey, testSecret, nil).status)
+
+	loadIngestConfig(t, "web:\n  jvm_ingest:\n    enabled: true\n")
+	router = newJvmIngestRouter(openJvmDB(t))
+	assert.Equal(t, http.StatusForbidden, postSigned(t, router, body, testAccessKey, testSecret, nil).status)
+}
@@ -0,0 +1,227 @@
+package hub0042
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gateway/pkg/database"
+	"gateway/web/globalmodels"
+	"gateway/web/middleware"
+	"gateway/web/views/hub0042/controllers"
+	"gateway/web/views/hub0042/models"
+)
+
+// compareBase 对比测试数据的起始时间
+var compareBase = time.Date(2026, 10, 15, 10, 0, 0, 0, time.Local)
+
+// openCompareDB 创建包含JVM资源和聚合表的临时数据库，写入 sg-order 分组下的三个实例
+func openCompareDB(t *testing.T) database.Database {
+	t.Helper()
+	db := openMonitorDB(t, "HUB_MONITOR_JVM_RESOURCE.sql", "HUB_MONITOR_JVM_METRIC_5M.sql", "HUB_MONITOR_JVM_METRIC_1H.sql")
+	insertJvmResource(t, db, "jvm-b", "sg-order", "order-b", "Y")
+	insertJvmResource(t, db, "jvm-a", "sg-order", "order-a", "Y")
+	insertJvmResource(t, db, "jvm-c", "sg-order", "order-c", "N")
+	insertJvmResource(t, db, "jvm-x", "sg-user", "user", "Y")
+	return db
+}
+
+// insertJvmResource 写入默认租户下的JVM实例
+func insertJvmResource(t *testing.T, db database.Database, resourceId, serviceGroupId, applicationName, activeFlag string) {
+	t.Helper()
+	_, err := db.Insert(context.Background(), "HUB_MONITOR_JVM_RESOURCE", &models.JvmResource{
+		TenantId:              "default",
+		ServiceGroupId:        serviceGroupId,
+		JvmResourceId:         resourceId,
+		ApplicationName:       applicationName,
+		GroupName:             "DEFAULT",
+		CollectionTime:        compareBase,
+		JvmStartTime:          compareBase.Add(-time.Hour),
+		HealthyFlag:           "Y",
+		RequiresAttentionFlag: "N",
+		AddTime:               compareBase,
+		AddWho:                "agent",
+		EditTime:              compareBase,
+		EditWho:               "agent",
+		OprSeqFlag:            resourceId,
+		CurrentVersion:        1,
+		ActiveFlag:            activeFlag,
+	}, true)
+	require.NoError(t, err)
+}
+
+// insertRollup 写入一条聚合数据
+func insertRollup(t *testing.T, db database.Database, table, resourceId string, bucket time.Time, fill func(row *models.JvmMetricRollup)) {
+	t.Helper()
+	row := &models.JvmMetricRollup{
+		TenantId:       "default",
+		JvmResourceId:  resourceId,
+		BucketTime:     bucket,
+		AddTime:        bucket,
+		AddWho:         "system",
+		EditTime:       bucket,
+		EditWho:        "system",
+		OprSeqFlag:     resourceId + bucket.Format("150405"),
+		CurrentVersion: 1,
+		ActiveFlag:     "Y",
+	}
+	fill(row)
+	_, err := db.Insert(context.Background(), table, row, true)
+	require.NoError(t, err)
+}
+
+// callController 以管理员身份调用管理接口
+func callController(t *testing.T, handler gin.HandlerFunc, body string) *apiResponse {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/gateway/hub0042", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Set(middleware.UserContextKey, &globalmodels.UserContext{UserId: "admin", TenantId: "default"})
+	handler(ctx)
+
+	resp := &apiResponse{status: recorder.Code}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), resp))
+	return resp
+}
+
+// compare 调用实例对比接口，返回是否成功和对比结果
+func compare(t *testing.T, db database.Database, body string) (bool, *models.JvmMetricCompareResult, string) {
+	t.Helper()
+	resp := callController(t, controllers.NewJvmMetricController(db).CompareJvmMetric, body)
+	if !resp.OK {
+		return false, nil, resp.message()
+	}
+	result := &models.JvmMetricCompareResult{}
+	require.NoError(t, json.Unmarshal([]byte(resp.BizData), result))
+	return true, result, ""
+}
+
+// seriesValues 将序列值转换为便于比较的切片，null 用 -1 表示
+func seriesValues(series *models.JvmCompareSeries) []float64 {
+	values := make([]float64, 0, len(series.Values))
+	for _, value := range series.Values {
+		if value == nil {
+			values = append(values, -1)
+			continue
+		}
+		values = append(values, *value)
+	}
+	return values
+}
+
+// TestCompareJvmMetricAlignedSeries 验证按请求顺序返回实例序列，各序列对齐到同一时间轴，缺失的时间段为null
+func TestCompareJvmMetricAlignedSeries(t *testing.T) {
+	db := openCompareDB(t)
+	for i, usage := range []float64{40, 50, 60} {
+		insertRollup(t, db, "HUB_MONITOR_JVM_METRIC_5M", "jvm-a", compareBase.Add(time.Duration(i)*5*time.Minute), func(row *models.JvmMetricRollup) {
+			row.MemorySampleCount, row.HeapUsageAvg = 5, usage
+		})
+	}
+	insertRollup(t, db, "HUB_MONITOR_JVM_METRIC_5M", "jvm-b", compareBase.Add(5*time.Minute), func(row *models.JvmMetricRollup) {
+		row.MemorySampleCount, row.HeapUsageAvg = 5, 70
+	})
+	// 没有堆内存采集点的时间段视为缺失
+	insertRollup(t, db, "HUB_MONITOR_JVM_METRIC_5M", "jvm-b", compareBase.Add(10*time.Minute), func(row *models.JvmMetricRollup) {
+		row.ThreadSampleCount, row.ThreadCountAvg = 5, 30
+	})
+	// 时间范围外的数据不在结果中
+	insertRollup(t, db, "HUB_MONITOR_JVM_METRIC_5M", "jvm-a", compareBase.Add(15*time.Minute), func(row *models.JvmMetricRollup) {
+		row.MemorySampleCount, row.HeapUsageAvg = 5, 99
+	})
+
+	ok, result, msg := compare(t, db, `{"jvmResourceIds":["jvm-b"," jvm-a","jvm-b","jvm-missing",""],"metricType":"heap_usage",
+		"startTime":"2026-10-15 10:02:00","endTime":"2026-10-15 10:15:00"}`)
+	require.True(t, ok, msg)
+	assert.Equal(t, models.JvmCompareHeapUsage, result.MetricType)
+	assert.Equal(t, models.JvmRollup5M, result.Granularity)
+	// 开始时间向下对齐到聚合周期
+	assert.Equal(t, []string{"2026-10-15 10:00:00", "2026-10-15 10:05:00", "2026-10-15 10:10:00"}, result.Timestamps)
+	require.Len(t, result.Series, 2)
+	assert.Equal(t, "jvm-b", result.Series[0].JvmResourceId)
+	assert.Equal(t, "order-b", result.Series[0].ApplicationName)
+	assert.Equal(t, []float64{-1, 70, -1}, seriesValues(result.Series[0]))
+	assert.Equal(t, "jvm-a", result.Series[1].JvmResourceId)
+	assert.Equal(t, []float64{40, 50, 60}, seriesValues(result.Series[1]))
+
+	ok, result, msg = compare(t, db, `{"jvmResourceIds":["jvm-b"],"metricType":"THREAD_COUNT",
+		"startTime":"2026-10-15 10:00:00","endTime":"2026-10-15 10:15:00"}`)
+	require.True(t, ok, msg)
+	assert.Equal(t, []float64{-1, -1, 30}, seriesValues(result.Series[0]))
+
+	// GC增量指标在有聚合数据的时间段取实际值
+	ok, result, msg = compare(t, db, `{"jvmResourceIds":["jvm-b"],"metricType":"YGC_COUNT",
+		"startTime":"2026-10-15 10:00:00","endTime":"2026-10-15 10:15:00"}`)
+	require.True(t, ok, msg)
+	assert.Equal(t, []float64{-1, 0, 0}, seriesValues(result.Series[0]))
+}
+
+// TestCompareJvmMetricServiceGroup 验证未指定实例时对比服务分组下的活动实例，按应用名称排列
+func TestCompareJvmMetricServiceGroup(t *testing.T) {
+	db := openCompareDB(t)
+	insertRollup(t, db, "HUB_MONITOR_JVM_METRIC_1H", "jvm-a", compareBase, func(row *models.JvmMetricRollup) {
+		row.FgcCount = 2
+	})
+	insertRollup(t, db, "HUB_MONITOR_JVM_METRIC_1H", "jvm-b", compareBase.Add(time.Hour), func(row *models.JvmMetricRollup) {
+		row.FgcCount = 1
+	})
+
+	ok, result, msg := compare(t, db, `{"serviceGroupId":"sg-order","metricType":"FGC_COUNT","granularity":"1h",
+		"startTime":"2026-10-15 10:00:00","endTime":"2026-10-15 12:00:00"}`)
+	require.True(t, ok, msg)
+	assert.Equal(t, models.JvmRollup1H, result.Granularity)
+	assert.Equal(t, []string{"2026-10-15 10:00:00", "2026-10-15 11:00:00"}, result.Timestamps)
+	require.Len(t, result.Series, 2)
+	assert.Equal(t, "jvm-a", result.Series[0].JvmResourceId)
+	assert.Equal(t, []float64{2, -1}, seriesValues(result.Series[0]))
+	assert.Equal(t, "jvm-b", result.Series[1].JvmResourceId)
+	assert.Equal(t, []float64{-1, 1}, seriesValues(result.Series[1]))
+
+	// 时间范围超过两天时自动使用1小时粒度
+	ok, result, msg = compare(t, db, `{"serviceGroupId":"sg-order","metricType":"GC_TIME",
+		"startTime":"2026-10-12 10:00:00","endTime":"2026-10-15 10:00:00"}`)
+	require.True(t, ok, msg)
+	assert.Equal(t, models.JvmRollup1H, result.Granularity)
+	assert.Len(t, result.Timestamps, 72)
+}
+
+// TestCompareJvmMetricValidation 验证对比指标、实例范围、时间范围和数据点数的校验
+func TestCompareJvmMetricValidation(t *testing.T) {
+	db := openCompareDB(t)
+	ids := make([]string, 21)
+	for i := range ids {
+		ids[i] = "\"jvm-" + string(rune('a'+i)) + "\""
+	}
+
+	cases := []struct {
+		name string
+		body string
+		msg  string
+	}{
+		{"不支持的指标", `{"jvmResourceIds":["jvm-a"],"metricType":"CPU"}`, "不支持的对比指标: CPU"},
+		{"缺少实例范围", `{"metricType":"HEAP_USAGE"}`, "jvmResourceIds和serviceGroupId不能同时为空"},
+		{"实例过多", `{"jvmResourceIds":[` + strings.Join(ids, ",") + `],"metricType":"HEAP_USAGE"}`, "单次最多对比20个实例"},
+		{"时间格式错误", `{"jvmResourceIds":["jvm-a"],"metricType":"HEAP_USAGE","startTime":"2026/10/15"}`, "startTime格式错误"},
+		{"时间范围颠倒", `{"jvmResourceIds":["jvm-a"],"metricType":"HEAP_USAGE",
+			"startTime":"2026-10-15 11:00:00","endTime":"2026-10-15 10:00:00"}`, "startTime必须早于endTime"},
+		{"粒度无效", `{"jvmResourceIds":["jvm-a"],"metricType":"HEAP_USAGE","granularity":"1D"}`, "granularity只能为5M或1H"},
+		{"数据点过多", `{"jvmResourceIds":["jvm-a"],"metricType":"HEAP_USAGE","granularity":"5M",
+			"startTime":"2026-10-01 00:00:00","endTime":"2026-10-15 00:00:00"}`, "时间范围内的数据点过多"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, _, msg := compare(t, db, tc.body)
+			assert.False(t, ok)
+			assert.Contains(t, msg, tc.msg)
+		})
+	}
+}
@@ -222,6 +222,7 @@ export default {
     DOWN: 'Down',
     STARTING: 'Starting',
     OUT_OF_SERVICE: 'Out of Service',
+    DRAINING: 'Draining',
 
     // Health status
     HEALTHY: 'Healthy',
@@ -275,6 +275,7 @@ export default {
     DOWN: '已停止',
     STARTING: '启动中', 
     OUT_OF_SERVICE: '停止服务',
+    DRAINING: '排空中',
 
     // 健康状态
     HEALTHY: '健康',
@@ -27,6 +27,8 @@ export interface ApiResponse<T = any> {
  * @property timeTypeFieldNames - 时间类型字段名列表
  * @property totalCount - 记录总数
  * @property totalPageIndex - 总页数
+ * @property nextCursor - 下一页游标，仅游标分页时返回
+ * @property hasMore - 是否还有下一页，仅游标分页时返回
  */
 export interface PageInfoObj {
   /** 基础数据，通常用于存储额外信息 */
@@ -53,6 +55,10 @@ export interface PageInfoObj {
   totalCount: number
   /** 总页数 */
   totalPageIndex: number
+  /** 下一页游标，仅游标分页时返回，为空表示没有下一页 */
+  nextCursor?: string
+  /** 是否还有下一页，仅游标分页时返回 */
+  hasMore?: boolean
 }
 
 /**
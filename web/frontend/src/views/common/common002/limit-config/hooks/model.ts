@@ -25,12 +25,14 @@ export function useRateLimitConfigModel(moduleId: string) {
     { label: '路径策略', value: 'path' },
     { label: '服务策略', value: 'service' },
     { label: '路由策略', value: 'route' },
+    { label: 'API Key策略', value: 'api_key' },
   ]
 
   // 限流算法选项
   const algorithmOptions = [
     { label: '令牌桶算法', value: 'token-bucket' },
     { label: '漏桶算法', value: 'leaky-bucket' },
+    { label: '削峰算法', value: 'spike-arrest' },
     { label: '滑动窗口算法', value: 'sliding-window' },
     { label: '固定窗口算法', value: 'fixed-window' },
     { label: '无限流', value: 'none' },
@@ -67,6 +69,21 @@ export function useRateLimitConfigModel(moduleId: string) {
             ],
           }),
         })
+      } else if (algorithm === 'spike-arrest') {
+        return h(NAlert, { type: 'info' }, {
+          default: () => h(NText, null, {
+            default: () => [
+              h('strong', null, '削峰算法：'),
+              '超出稳定速率的突发请求先短暂排队，按稳定速率依次放行，预计排队时间超过上限时才拒绝。适合保护无法承受突发流量的老旧后端。',
+              h('br'),
+              '• 限流速率：每秒放行的请求数（稳定速率）',
+              h('br'),
+              '• 突发容量：允许立即放行的突发请求数，设为1表示严格按速率放行',
+              h('br'),
+              '• 最大排队时间：请求最多排队等待的时间，排队时间记录在访问日志中',
+            ],
+          }),
+        })
       } else if (algorithm === 'sliding-window') {
         return h(NAlert, { type: 'info' }, {
           default: () => h(NText, null, {
@@ -194,7 +211,7 @@ export function useRateLimitConfigModel(moduleId: string) {
             options: keyStrategyOptions,
             placeholder: '选择限流键策略',
           },
-          tips: 'IP策略：按IP地址限流；用户策略：按用户ID限流；路径策略：按请求路径限流；服务策略：按服务名限流；路由策略：按路由配置限流',
+          tips: 'IP策略：按IP地址限流；用户策略：按用户ID限流；路径策略：按请求路径限流；服务策略：按服务名限流；路由策略：按路由配置限流；API Key策略：按API Key认证的Key限流，未认证时按IP',
         },
         {
           field: 'algorithm',
@@ -228,6 +245,8 @@ export function useRateLimitConfigModel(moduleId: string) {
               return '填充速率(令牌/秒)'
             } else if (algorithm === 'leaky-bucket') {
               return '漏出速率(请求/秒)'
+            } else if (algorithm === 'spike-arrest') {
+              return '放行速率(请求/秒)'
             } else if (algorithm === 'sliding-window' || algorithm === 'fixed-window') {
               return '窗口内最大请求数'
             }
@@ -249,6 +268,8 @@ export function useRateLimitConfigModel(moduleId: string) {
               return '每秒向桶中填充的令牌数（令牌/秒）。每个请求需要消耗一个令牌才能通过。'
             } else if (algorithm === 'leaky-bucket') {
               return '每秒从桶中漏出的请求数（处理速率）。无论输入如何，输出速率都严格限制为此值。'
+            } else if (algorithm === 'spike-arrest') {
+              return '每秒放行的请求数。超出此速率的请求先排队等待，按此速率依次放行。'
             } else if (algorithm === 'sliding-window' || algorithm === 'fixed-window') {
               return '时间窗口内允许的最大请求数。超过此数量的请求将被拒绝。'
             }
@@ -263,6 +284,8 @@ export function useRateLimitConfigModel(moduleId: string) {
               return '桶容量(令牌)'
             } else if (algorithm === 'leaky-bucket') {
               return '桶容量(请求)'
+            } else if (algorithm === 'spike-arrest') {
+              return '突发容量(请求)'
             }
             return '突发容量'
           },
@@ -274,7 +297,7 @@ export function useRateLimitConfigModel(moduleId: string) {
           tabKey: 'basic',
           show: (formData: Record<string, any>) => {
             const algorithm = formData?.algorithm
-            return algorithm === 'token-bucket' || algorithm === 'leaky-bucket'
+            return algorithm === 'token-bucket' || algorithm === 'leaky-bucket' || algorithm === 'spike-arrest'
           },
           props: {
             min: 0,
@@ -286,10 +309,28 @@ export function useRateLimitConfigModel(moduleId: string) {
               return '桶的最大容量（最大令牌数）。桶满时可以处理此数量的突发请求。'
             } else if (algorithm === 'leaky-bucket') {
               return '桶的最大容量（最大可容纳的请求数）。超出此容量时，新请求会被拒绝。'
+            } else if (algorithm === 'spike-arrest') {
+              return '空闲后允许立即放行的请求数，超出后开始排队。设为1表示严格按速率放行。'
             }
             return '允许的突发请求数量，用于令牌桶和漏桶算法'
           },
         },
+        {
+          field: 'customConfig.maxQueueDelayMs',
+          label: '最大排队时间(毫秒)',
+          type: 'number',
+          placeholder: '突发请求最多排队等待的时间',
+          span: 12,
+          defaultValue: 500,
+          tabKey: 'basic',
+          show: (formData: Record<string, any>) => formData?.algorithm === 'spike-arrest',
+          props: {
+            min: 0,
+            max: 30000,
+            precision: 0,
+          },
+          tips: '预计排队时间超过此值的请求会被拒绝，0表示不排队直接拒绝',
+        },
         {
           field: 'timeWindowSeconds',
           label: '时间窗口(秒)',
@@ -345,6 +386,58 @@ export function useRateLimitConfigModel(moduleId: string) {
         },
       ],
     },
+    // ============= 带宽限制分组 =============
+    {
+      field: 'bandwidthConfig',
+      label: '',
+      type: 'fieldset',
+      span: 24,
+      tabKey: 'basic',
+      children: [
+        {
+          field: 'customConfig.uploadBytesPerSecond',
+          label: '上传带宽(字节/秒)',
+          type: 'number',
+          placeholder: '0表示不限制',
+          span: 8,
+          defaultValue: 0,
+          tabKey: 'basic',
+          props: {
+            min: 0,
+            precision: 0,
+          },
+          tips: '按限流键策略限制每个客户端的请求体上传速率，超出时等待而不拒绝请求。例如 1048576 表示 1MB/秒',
+        },
+        {
+          field: 'customConfig.downloadBytesPerSecond',
+          label: '下载带宽(字节/秒)',
+          type: 'number',
+          placeholder: '0表示不限制',
+          span: 8,
+          defaultValue: 0,
+          tabKey: 'basic',
+          props: {
+            min: 0,
+            precision: 0,
+          },
+          tips: '按限流键策略限制每个客户端的响应体下载速率，避免单个客户端下载大文件占满网关出口带宽',
+        },
+        {
+          field: 'customConfig.bandwidthBurstBytes',
+          label: '带宽突发(字节)',
+          type: 'number',
+          placeholder: '0表示等于1秒的速率',
+          span: 8,
+          defaultValue: 0,
+          tabKey: 'basic',
+          props: {
+            min: 0,
+            precision: 0,
+          },
+          tips: '空闲后允许立即传输的字节数，0表示等于1秒的带宽速率',
+        },
+      ],
+    },
     // ============= 算法说明分组 =============
     {
       field: 'algorithmDescription',
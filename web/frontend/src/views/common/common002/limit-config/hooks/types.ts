@@ -15,8 +15,8 @@ export interface RateLimitConfig {
   gatewayInstanceId?: string // 网关实例ID(实例级限流)
   routeConfigId?: string // 路由配置ID(路由级限流)
   limitName: string // 限流规则名称
-  algorithm: 'token-bucket' | 'leaky-bucket' | 'sliding-window' | 'fixed-window' | 'none' // 限流算法
-  keyStrategy: 'ip' | 'user' | 'path' | 'service' | 'route' // 限流键策略
+  algorithm: 'token-bucket' | 'leaky-bucket' | 'spike-arrest' | 'sliding-window' | 'fixed-window' | 'none' // 限流算法
+  keyStrategy: 'ip' | 'user' | 'path' | 'service' | 'route' | 'api_key' // 限流键策略
   limitRate: number // 限流速率(次/秒)
   burstCapacity: number // 突发容量
   timeWindowSeconds: number // 时间窗口(秒)
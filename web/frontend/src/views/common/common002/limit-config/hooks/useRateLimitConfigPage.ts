@@ -10,6 +10,12 @@ import { ref, watch } from 'vue'
 import type { RateLimitConfig } from './types'
 import { useRateLimitConfigService } from './useRateLimitConfigService'
 
+/** 存储在 customConfig 中的带宽限制字段 */
+const bandwidthConfigFields = ['uploadBytesPerSecond', 'downloadBytesPerSecond', 'bandwidthBurstBytes']
+
+/** 存储在 customConfig 中的削峰算法字段 */
+const spikeArrestConfigFields = ['maxQueueDelayMs']
+
 /**
  * 限流配置页面级 Hook
  * @param props 组件 props（包含 gatewayInstanceId、routeConfigId、moduleId 的响应式 ref）
@@ -45,24 +51,60 @@ export function useRateLimitConfigPage(props: {
       return value
     }
 
-    return {
+    const customConfig = parseJson(config.customConfig) || {}
+    const formData: Record<string, any> = {
       ...config,
       // customConfig 在后端是 JSON 字符串，前端表单需要对象格式
-      customConfig: parseJson(config.customConfig) || {},
+      customConfig,
     }
+    // 带宽限制字段展开为点号分隔字段，提交时再合并回 customConfig
+    bandwidthConfigFields.forEach((key) => {
+      formData[`customConfig.${key}`] = Number(customConfig[key]) || 0
+    })
+    // 削峰字段未配置时使用后端默认值500毫秒
+    spikeArrestConfigFields.forEach((key) => {
+      formData[`customConfig.${key}`] = customConfig[key] != null ? Number(customConfig[key]) || 0 : 500
+    })
+    return formData
   }
 
   /**
    * 将表单数据转换为API数据格式（对象转换为后端需要的格式）
    */
   const convertToApiData = (formData: Record<string, any>): any => {
-    return {
-      ...formData,
-      // customConfig 前端是对象，后端需要 JSON 字符串
-      customConfig: typeof formData.customConfig === 'object'
-        ? JSON.stringify(formData.customConfig)
-        : formData.customConfig || '{}',
+    const apiData: Record<string, any> = { ...formData }
+    let customConfig: Record<string, any> = {}
+    if (typeof formData.customConfig === 'object' && formData.customConfig) {
+      customConfig = { ...formData.customConfig }
+    } else if (typeof formData.customConfig === 'string' && formData.customConfig) {
+      try {
+        customConfig = JSON.parse(formData.customConfig)
+      } catch {
+        customConfig = {}
+      }
     }
+    // 合并带宽限制字段，0表示不限制，不写入 customConfig
+    bandwidthConfigFields.forEach((key) => {
+      const value = Number(formData[`customConfig.${key}`]) || 0
+      if (value > 0) {
+        customConfig[key] = value
+      } else {
+        delete customConfig[key]
+      }
+      delete apiData[`customConfig.${key}`]
+    })
+    // 合并削峰字段，0表示不排队，只在削峰算法下写入 customConfig
+    spikeArrestConfigFields.forEach((key) => {
+      if (formData.algorithm === 'spike-arrest') {
+        customConfig[key] = Number(formData[`customConfig.${key}`]) || 0
+      } else {
+        delete customConfig[key]
+      }
+      delete apiData[`customConfig.${key}`]
+    })
+    // customConfig 前端是对象，后端需要 JSON 字符串
+    apiData.customConfig = JSON.stringify(customConfig)
+    return apiData
   }
 
   /**
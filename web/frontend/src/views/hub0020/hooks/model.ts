@@ -770,6 +770,87 @@ export function useGatewayInstanceModel() {
         ],
       },
 
+      // ============= 失败日志重试 =============
+      {
+        field: 'log-spool-group',
+        label: '失败日志重试',
+        type: 'fieldset',
+        tabKey: 'basic',
+        props: {
+          titleSize: 300,
+        },
+        children: [
+          {
+            field: 'extProperty.logSpoolEnabled',
+            label: '开启失败日志重试',
+            type: 'switch',
+            span: 8,
+            defaultValue: 'N',
+            tips: '开启后写入数据库、ClickHouse或MongoDB失败的访问日志先保存到本地磁盘，后台按退避间隔重新写入，已落库的记录只更新重置次数',
+            props: {
+              checkedValue: 'Y',
+              uncheckedValue: 'N',
+            },
+          },
+          {
+            field: 'extProperty.logSpoolDir',
+            label: '落盘目录',
+            type: 'input',
+            span: 16,
+            defaultValue: './logs/spool',
+            placeholder: '请输入失败日志落盘目录',
+            tips: '按网关实例分子目录保存，超过最大重试次数的日志保存在 dead 子目录中',
+          },
+          {
+            field: 'extProperty.logSpoolMaxRetries',
+            label: '最大重试次数',
+            type: 'number',
+            span: 8,
+            defaultValue: 10,
+            tips: '单条日志重试超过该次数后转入死信目录并输出错误日志',
+            props: {
+              min: 1,
+              precision: 0,
+            },
+          },
+          {
+            field: 'extProperty.logSpoolRetryIntervalMs',
+            label: '重试间隔(ms)',
+            type: 'number',
+            span: 8,
+            defaultValue: 5000,
+            tips: '首次重试间隔，连续失败时按2倍退避，成功后恢复',
+            props: {
+              min: 100,
+              precision: 0,
+            },
+          },
+          {
+            field: 'extProperty.logSpoolMaxRetryIntervalMs',
+            label: '最大重试间隔(ms)',
+            type: 'number',
+            span: 8,
+            defaultValue: 300000,
+            props: {
+              min: 100,
+              precision: 0,
+            },
+          },
+          {
+            field: 'extProperty.logSpoolMaxSizeMB',
+            label: '落盘大小上限(MB)',
+            type: 'number',
+            span: 8,
+            defaultValue: 512,
+            tips: '落盘文件总大小超过上限时不再接收失败日志并输出错误日志，0表示不限制',
+            props: {
+              min: 0,
+              precision: 0,
+            },
+          },
+        ],
+      },
+
       // ============= 敏感数据 =============
       {
         field: 'sensitive-data-group',
@@ -987,6 +1068,76 @@ export function useGatewayInstanceModel() {
         ],
       },
 
+      // ============= 慢请求诊断（预警 Tab） =============
+      {
+        field: 'slow-request-config-group',
+        label: '慢请求诊断',
+        type: 'fieldset',
+        tabKey: 'alert',
+        props: {
+          titleSize: 300,
+        },
+        children: [
+          {
+            field: 'extProperty.slowRequestEnabled',
+            label: '开启慢请求检测',
+            type: 'switch',
+            span: 8,
+            defaultValue: 'N',
+            tips: '开启后总耗时超过阈值的请求会记录耗时分解和上游连接状态到慢请求诊断表',
+            props: {
+              checkedValue: 'Y',
+              uncheckedValue: 'N',
+            },
+          },
+          {
+            field: 'extProperty.slowRequestThresholdMs',
+            label: '慢请求阈值(ms)',
+            type: 'number',
+            span: 8,
+            defaultValue: 3000,
+            tips: '请求处理超过该时长（毫秒）即判定为慢请求',
+            props: {
+              min: 1,
+              precision: 0,
+            },
+          },
+          {
+            field: 'extProperty.slowRequestMaxPerMinute',
+            label: '每分钟记录上限',
+            type: 'number',
+            span: 8,
+            defaultValue: 60,
+            tips: '每分钟最多记录的慢请求数，避免上游整体变慢时大量写入，0表示不限制',
+            props: {
+              min: 0,
+              precision: 0,
+            },
+          },
+          {
+            field: 'extProperty.slowRequestGoroutineDump',
+            label: '采集协程快照',
+            type: 'switch',
+            span: 8,
+            defaultValue: 'N',
+            tips: '请求超过阈值仍未完成时导出协程堆栈到快照目录，10秒内的慢请求共享同一份快照',
+            props: {
+              checkedValue: 'Y',
+              uncheckedValue: 'N',
+            },
+          },
+          {
+            field: 'extProperty.slowRequestSnapshotDir',
+            label: '快照目录',
+            type: 'input',
+            span: 16,
+            defaultValue: './logs/slow_request',
+            placeholder: '请输入协程快照保存目录',
+            tips: '协程快照文件保存在网关节点本地，慢请求记录中保存文件路径',
+          },
+        ],
+      },
+
       // ============= 其它 Tab =============
       {
         field: 'addTime',
@@ -349,3 +349,58 @@ export async function deleteFilterConfig(
     filterConfigId,
   })
 }
+
+/**
+ * 分页查询功能开关列表
+ * @param params 查询参数（支持按实例、路由、开关标识筛选）
+ * @returns 功能开关列表和分页信息
+ */
+export async function queryFeatureFlags(params: {
+  gatewayInstanceId?: string
+  routeConfigId?: string
+  flagKey?: string
+  flagEnabled?: string
+  activeFlag?: string
+  pageIndex?: number
+  pageSize?: number
+}): Promise<JsonDataObj> {
+  return routeApi.post('/queryFeatureFlags', params)
+}
+
+/**
+ * 获取功能开关详情
+ * @param featureFlagId 功能开关ID
+ * @returns 功能开关详情
+ */
+export async function getFeatureFlag(featureFlagId: string): Promise<JsonDataObj> {
+  return routeApi.post('/getFeatureFlag', { featureFlagId })
+}
+
+/**
+ * 添加功能开关
+ * @param featureFlag 功能开关数据
+ * @returns 操作结果
+ */
+export async function addFeatureFlag(featureFlag: any): Promise<JsonDataObj> {
+  return routeApi.post('/addFeatureFlag', featureFlag)
+}
+
+/**
+ * 编辑功能开关
+ * @param featureFlag 功能开关数据
+ * @returns 操作结果
+ */
+export async function editFeatureFlag(featureFlag: any): Promise<JsonDataObj> {
+  return routeApi.post('/editFeatureFlag', featureFlag)
+}
+
+/**
+ * 删除功能开关
+ * @param featureFlagId 功能开关ID
+ * @returns 操作结果
+ */
+export async function deleteFeatureFlag(featureFlagId: string): Promise<JsonDataObj> {
+  return routeApi.post('/deleteFeatureFlag', {
+    featureFlagId,
+  })
+}
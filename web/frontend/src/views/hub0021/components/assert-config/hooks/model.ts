@@ -104,6 +104,7 @@ export function useAssertConfigModel() {
       'COOKIE': 'warning',
       'IP': 'error',
       'BODY_CONTENT': 'success',
+      'GEO': 'warning',
     }
     return typeColorMap[assertionType] || 'default'
   }
@@ -464,15 +465,15 @@ export function useAssertConfigModel() {
           field: 'fieldName',
           label: '字段名称',
           type: 'input' as const,
-          placeholder: '请输入字段名称（HEADER/QUERY/COOKIE类型必填）',
+          placeholder: '请输入字段名称（HEADER/QUERY/COOKIE类型必填，GEO类型填写continent/country/region/city）',
           span: 12,
           show: (formData: Record<string, any>) => {
-            return ['HEADER', 'QUERY', 'COOKIE'].includes(formData.assertionType)
+            return ['HEADER', 'QUERY', 'COOKIE', 'GEO'].includes(formData.assertionType)
           },
           rules: [
             {
               validator: (_rule: any, value: any, formData: Record<string, any>) => {
-                const needsField = ['HEADER', 'QUERY', 'COOKIE'].includes(formData.assertionType)
+                const needsField = ['HEADER', 'QUERY', 'COOKIE', 'GEO'].includes(formData.assertionType)
                 if (needsField && (!value || !value.trim())) {
                   return new Error('请输入字段名称')
                 }
@@ -527,7 +528,7 @@ export function useAssertConfigModel() {
           span: 12,
           defaultValue: 'Y',
           show: (formData: Record<string, any>) => {
-            return formData.assertionType !== 'IP'
+            return !['IP', 'GEO'].includes(formData.assertionType)
           },
           options: [
             { label: '区分大小写', value: 'Y' },
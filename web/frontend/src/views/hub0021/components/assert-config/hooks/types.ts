@@ -14,6 +14,7 @@ export enum AssertionType {
   COOKIE = 'COOKIE',
   IP = 'IP',
   BODY_CONTENT = 'BODY_CONTENT',
+  GEO = 'GEO',
 }
 
 // 断言操作符枚举（根据后端 RouteAssertion.AssertionOperator 字段）
@@ -41,9 +42,9 @@ export interface RouteAssertion {
   routeAssertionId: string // 路由断言ID，联合主键
   routeConfigId: string // 关联的路由配置ID
   assertionName: string // 断言名称
-  assertionType: AssertionType | string // 断言类型(PATH,HEADER,QUERY,COOKIE,IP,BODY_CONTENT)
+  assertionType: AssertionType | string // 断言类型(PATH,HEADER,QUERY,COOKIE,IP,BODY_CONTENT,GEO)
   assertionOperator: AssertionOperator | string // 断言操作符(EQUAL,NOT_EQUAL,CONTAINS,MATCHES等)
-  fieldName: string // 字段名称(HEADER/QUERY/COOKIE类型时使用，可能为空字符串)
+  fieldName: string // 字段名称(HEADER/QUERY/COOKIE/GEO类型时使用，可能为空字符串)
   expectedValue: string // 期望值(EQUAL/NOT_EQUAL等操作符时使用，可能为空字符串)
   patternValue: string // 匹配模式(MATCHES/NOT_MATCHES操作符时使用,支持正则表达式，可能为空字符串)
   caseSensitive: 'Y' | 'N' // 是否区分大小写(N否,Y是)
@@ -83,6 +84,7 @@ export const ASSERTION_TYPE_OPTIONS = [
   { label: 'Cookie', value: 'COOKIE' as AssertionType, description: 'Cookie断言，检查特定Cookie的值' },
   { label: 'IP地址', value: 'IP' as AssertionType, description: 'IP地址断言，基于客户端IP进行匹配' },
   { label: '请求体内容', value: 'BODY_CONTENT' as AssertionType, description: '请求体内容断言，检查HTTP请求体的内容' },
+  { label: '地理位置', value: 'GEO' as AssertionType, description: '地理位置断言，基于客户端IP解析的大洲/国家/地区/城市匹配（需启用地理位置库）' },
 ]
 
 // 断言操作符选项
@@ -13,9 +13,11 @@ import { NSwitch } from 'naive-ui'
 import { h, ref } from 'vue'
 import type { FilterConfig } from './types'
 import {
+  AB_TEST_BUCKET_BY_OPTIONS,
   BODY_MODIFIER_OPTIONS,
   CONTENT_TYPES,
   COOKIE_OPERATION_OPTIONS,
+  FAULT_CORRUPT_MODE_OPTIONS,
   FILTER_ACTION_OPTIONS,
   FILTER_TYPE_OPTIONS,
   HEADER_MODIFIER_OPTIONS,
@@ -130,7 +132,10 @@ export function useFilterConfigModel(moduleId: string) {
       'rewrite': 'success',
       'method': 'error',
       'cookie': 'default',
-      'response': 'info'
+      'response': 'info',
+      'ab-test': 'warning',
+      'fault-injection': 'error',
+      'variable': 'success'
     }
     return typeColorMap[filterType] || 'default'
   }
@@ -928,6 +933,172 @@ export function useFilterConfigModel(moduleId: string) {
         rows: 3,
       },
     },
+
+    // A/B测试过滤器配置
+    {
+      field: 'config.abTestConfig.experiment',
+      label: '实验标识',
+      type: 'input' as const,
+      placeholder: '字母、数字、下划线或中划线，如 checkout-v2',
+      span: 12,
+      show: (formData: Record<string, any>) => formData.filterType === 'ab-test',
+    },
+    {
+      field: 'config.abTestConfig.bucketBy',
+      label: '分桶依据',
+      type: 'select' as const,
+      placeholder: '请选择分桶依据',
+      span: 12,
+      show: (formData: Record<string, any>) => formData.filterType === 'ab-test',
+      defaultValue: 'cookie',
+      options: AB_TEST_BUCKET_BY_OPTIONS.map(opt => ({ label: opt.label, value: opt.value })),
+    },
+    {
+      field: 'config.abTestConfig.bucketKey',
+      label: '用户ID请求头',
+      type: 'input' as const,
+      placeholder: '如 X-User-Id',
+      span: 12,
+      show: (formData: Record<string, any>) =>
+        formData.filterType === 'ab-test' && formData['config.abTestConfig.bucketBy'] === 'header',
+    },
+    {
+      field: 'config.abTestConfig.variantHeader',
+      label: '实验组请求头',
+      type: 'input' as const,
+      placeholder: '默认 X-AB-Variant',
+      span: 12,
+      show: (formData: Record<string, any>) => formData.filterType === 'ab-test',
+    },
+    {
+      field: 'config.abTestConfig.cookieMaxAge',
+      label: 'Cookie有效期(秒)',
+      type: 'number' as const,
+      placeholder: '默认2592000(30天)',
+      span: 12,
+      show: (formData: Record<string, any>) => formData.filterType === 'ab-test',
+      props: {
+        min: 1,
+      },
+    },
+    {
+      field: 'config.abTestConfig.variantsJson',
+      label: '实验组(JSON)',
+      type: 'input' as const,
+      placeholder: '[{"name":"A","weight":50},{"name":"B","weight":50}]',
+      span: 24,
+      show: (formData: Record<string, any>) => formData.filterType === 'ab-test',
+      props: {
+        type: 'textarea',
+        rows: 4,
+      },
+    },
+
+    // 故障注入过滤器配置
+    {
+      field: 'config.faultConfig.delay.percent',
+      label: '延迟比例(%)',
+      type: 'number' as const,
+      placeholder: '0-100，不注入延迟留空',
+      span: 12,
+      show: (formData: Record<string, any>) => formData.filterType === 'fault-injection',
+      props: {
+        min: 0,
+        max: 100,
+      },
+    },
+    {
+      field: 'config.faultConfig.delay.durationMs',
+      label: '延迟时间(毫秒)',
+      type: 'number' as const,
+      placeholder: '如 500',
+      span: 12,
+      show: (formData: Record<string, any>) => formData.filterType === 'fault-injection',
+      props: {
+        min: 1,
+        max: 300000,
+      },
+    },
+    {
+      field: 'config.faultConfig.abort.percent',
+      label: '中止比例(%)',
+      type: 'number' as const,
+      placeholder: '0-100，不中止请求留空',
+      span: 12,
+      show: (formData: Record<string, any>) => formData.filterType === 'fault-injection',
+      props: {
+        min: 0,
+        max: 100,
+      },
+    },
+    {
+      field: 'config.faultConfig.abort.statusCode',
+      label: '中止状态码',
+      type: 'number' as const,
+      placeholder: '默认503',
+      span: 12,
+      show: (formData: Record<string, any>) => formData.filterType === 'fault-injection',
+      props: {
+        min: 400,
+        max: 599,
+      },
+    },
+    {
+      field: 'config.faultConfig.abort.message',
+      label: '中止消息',
+      type: 'input' as const,
+      placeholder: '默认 Fault injected',
+      span: 24,
+      show: (formData: Record<string, any>) => formData.filterType === 'fault-injection',
+    },
+    {
+      field: 'config.faultConfig.corrupt.percent',
+      label: '响应损坏比例(%)',
+      type: 'number' as const,
+      placeholder: '0-100，不损坏响应留空',
+      span: 12,
+      show: (formData: Record<string, any>) => formData.filterType === 'fault-injection',
+      props: {
+        min: 0,
+        max: 100,
+      },
+    },
+    {
+      field: 'config.faultConfig.corrupt.mode',
+      label: '损坏方式',
+      type: 'select' as const,
+      placeholder: '请选择损坏方式',
+      span: 12,
+      show: (formData: Record<string, any>) => formData.filterType === 'fault-injection',
+      defaultValue: 'garble',
+      options: FAULT_CORRUPT_MODE_OPTIONS.map(opt => ({ label: opt.label, value: opt.value })),
+    },
+
+    // 请求变量过滤器配置
+    {
+      field: 'config.variableConfig.variablesJson',
+      label: '变量定义(JSON)',
+      type: 'input' as const,
+      placeholder: '{"caller":"${ctx.user_id:-anonymous}@${header.X-Tenant-Id}"}',
+      span: 24,
+      show: (formData: Record<string, any>) => formData.filterType === 'variable',
+      props: {
+        type: 'textarea',
+        rows: 4,
+      },
+    },
+    {
+      field: 'config.variableConfig.keepExisting',
+      label: '保留已有变量',
+      type: 'switch' as const,
+      span: 12,
+      show: (formData: Record<string, any>) => formData.filterType === 'variable',
+      defaultValue: false,
+      props: {
+        checkedValue: true,
+        uncheckedValue: false,
+      },
+    },
       ],
     },
     // ============= 其他信息（备注和时间） =============
@@ -286,6 +286,50 @@ export function useFilterConfigPage(
             : {},
         }
         break
+      case 'ab-test':
+        config.abTestConfig = {
+          experiment: formData['config.abTestConfig.experiment'],
+          bucketBy: formData['config.abTestConfig.bucketBy'] ?? 'cookie',
+          bucketKey: formData['config.abTestConfig.bucketKey'],
+          variantHeader: formData['config.abTestConfig.variantHeader'],
+          cookieMaxAge: formData['config.abTestConfig.cookieMaxAge'],
+          variants: formData['config.abTestConfig.variantsJson']
+            ? JSON.parse(formData['config.abTestConfig.variantsJson'])
+            : [],
+        }
+        break
+      case 'fault-injection': {
+        const faultConfig: Record<string, any> = {}
+        if (formData['config.faultConfig.delay.percent'] != null) {
+          faultConfig.delay = {
+            percent: formData['config.faultConfig.delay.percent'],
+            durationMs: formData['config.faultConfig.delay.durationMs'],
+          }
+        }
+        if (formData['config.faultConfig.abort.percent'] != null) {
+          faultConfig.abort = {
+            percent: formData['config.faultConfig.abort.percent'],
+            statusCode: formData['config.faultConfig.abort.statusCode'] ?? 503,
+            message: formData['config.faultConfig.abort.message'],
+          }
+        }
+        if (formData['config.faultConfig.corrupt.percent'] != null) {
+          faultConfig.corrupt = {
+            percent: formData['config.faultConfig.corrupt.percent'],
+            mode: formData['config.faultConfig.corrupt.mode'] ?? 'garble',
+          }
+        }
+        config.faultConfig = faultConfig
+        break
+      }
+      case 'variable':
+        config.variableConfig = {
+          variables: formData['config.variableConfig.variablesJson']
+            ? JSON.parse(formData['config.variableConfig.variablesJson'])
+            : {},
+          keepExisting: formData['config.variableConfig.keepExisting'] ?? false,
+        }
+        break
     }
 
     return JSON.stringify(config)
@@ -377,6 +421,34 @@ export function useFilterConfigPage(
             result['config.responseConfig.conditionsJson'] = JSON.stringify(config.responseConfig.conditions || {}, null, 2)
           }
           break
+        case 'ab-test':
+          if (config.abTestConfig) {
+            result['config.abTestConfig.experiment'] = config.abTestConfig.experiment
+            result['config.abTestConfig.bucketBy'] = config.abTestConfig.bucketBy ?? 'cookie'
+            result['config.abTestConfig.bucketKey'] = config.abTestConfig.bucketKey
+            result['config.abTestConfig.variantHeader'] = config.abTestConfig.variantHeader
+            result['config.abTestConfig.cookieMaxAge'] = config.abTestConfig.cookieMaxAge
+            result['config.abTestConfig.variantsJson'] = JSON.stringify(config.abTestConfig.variants || [], null, 2)
+          }
+          break
+        case 'fault-injection':
+          if (config.faultConfig) {
+            const { delay, abort, corrupt } = config.faultConfig
+            result['config.faultConfig.delay.percent'] = delay?.percent
+            result['config.faultConfig.delay.durationMs'] = delay?.durationMs
+            result['config.faultConfig.abort.percent'] = abort?.percent
+            result['config.faultConfig.abort.statusCode'] = abort?.statusCode
+            result['config.faultConfig.abort.message'] = abort?.message
+            result['config.faultConfig.corrupt.percent'] = corrupt?.percent
+            result['config.faultConfig.corrupt.mode'] = corrupt?.mode ?? 'garble'
+          }
+          break
+        case 'variable':
+          if (config.variableConfig) {
+            result['config.variableConfig.variablesJson'] = JSON.stringify(config.variableConfig.variables || {}, null, 2)
+            result['config.variableConfig.keepExisting'] = config.variableConfig.keepExisting ?? false
+          }
+          break
       }
     } catch (error) {
       console.error('解析过滤器配置失败:', error)
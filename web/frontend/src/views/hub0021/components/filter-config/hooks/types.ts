@@ -15,6 +15,9 @@ export type FilterType =
   | 'method'
   | 'cookie'
   | 'response'
+  | 'ab-test'
+  | 'fault-injection'
+  | 'variable'
 
 // 过滤器执行时机枚举
 export type FilterAction = 'pre-routing' | 'post-routing' | 'pre-response'
@@ -97,6 +100,17 @@ export const FILTER_TYPE_OPTIONS = [
   { label: 'HTTP方法控制', value: 'method' as FilterType, description: '控制允许的HTTP方法' },
   { label: 'Cookie处理', value: 'cookie' as FilterType, description: '处理HTTP Cookie' },
   { label: '响应处理', value: 'response' as FilterType, description: '处理后端响应' },
+  { label: 'A/B测试分组', value: 'ab-test' as FilterType, description: '把客户端分配到实验组并转发给后端' },
+  {
+    label: '故障注入',
+    value: 'fault-injection' as FilterType,
+    description: '按比例注入延迟、中止请求或损坏响应，需开启 app.gateway.fault_injection.enabled',
+  },
+  {
+    label: '请求变量',
+    value: 'variable' as FilterType,
+    description: '按模板设置请求变量，后续过滤器和访问日志可通过 ${ctx.变量名} 引用',
+  },
 ]
 
 // 过滤器执行时机选项
@@ -190,6 +204,18 @@ export const RESPONSE_OPERATION_OPTIONS = [
   },
 ]
 
+// A/B测试分桶依据选项
+export const AB_TEST_BUCKET_BY_OPTIONS = [
+  { label: 'Cookie', value: 'cookie', description: '首次访问随机分配实验组并写入Cookie' },
+  { label: '请求头(用户ID)', value: 'header', description: '按请求头中的用户ID哈希分配实验组' },
+]
+
+// 故障注入响应损坏方式选项
+export const FAULT_CORRUPT_MODE_OPTIONS = [
+  { label: '篡改内容', value: 'garble', description: '保持长度不变，篡改响应体内容' },
+  { label: '截断', value: 'truncate', description: '只返回响应体的前若干字节' },
+]
+
 // HTTP方法选项
 export const HTTP_METHODS = ['GET', 'POST', 'PUT', 'DELETE', 'PATCH', 'HEAD', 'OPTIONS']
 
@@ -754,6 +754,54 @@ export function useRouteConfigModel() {
           },
         ],
       },
+      {
+        field: 'routeMetadata.connectTimeoutMs',
+        label: '连接超时（毫秒）',
+        type: 'number' as const,
+        placeholder: '0表示沿用服务/代理连接超时',
+        span: 12,
+        tabKey: 'forward',
+        defaultValue: 0,
+        show: (formData: Record<string, any>) => formData['routeMetadata.overrideProxyTimeout'] === 'Y',
+        tips: '仅在开启覆盖后生效。建立TCP连接的超时；大于0时覆盖服务和代理配置，0表示沿用',
+        props: {
+          min: 0,
+          max: 600000,
+          style: { width: '100%' },
+        },
+      },
+      {
+        field: 'routeMetadata.tlsHandshakeTimeoutMs',
+        label: 'TLS握手超时（毫秒）',
+        type: 'number' as const,
+        placeholder: '0表示沿用服务/代理TLS握手超时',
+        span: 12,
+        tabKey: 'forward',
+        defaultValue: 0,
+        show: (formData: Record<string, any>) => formData['routeMetadata.overrideProxyTimeout'] === 'Y',
+        tips: '仅在开启覆盖后生效。与https后端完成TLS握手的超时；大于0时覆盖服务和代理配置，0表示沿用',
+        props: {
+          min: 0,
+          max: 600000,
+          style: { width: '100%' },
+        },
+      },
+      {
+        field: 'routeMetadata.responseHeaderTimeoutMs',
+        label: '响应头超时（毫秒）',
+        type: 'number' as const,
+        placeholder: '0表示沿用服务/代理读取超时',
+        span: 12,
+        tabKey: 'forward',
+        defaultValue: 0,
+        show: (formData: Record<string, any>) => formData['routeMetadata.overrideProxyTimeout'] === 'Y',
+        tips: '仅在开启覆盖后生效。请求发送完成后等待后端响应头的超时；大于0时覆盖服务和代理配置，0表示沿用',
+        props: {
+          min: 0,
+          max: 3600000,
+          style: { width: '100%' },
+        },
+      },
       {
         field: 'retryCount',
         label: '重试次数',
@@ -786,6 +834,100 @@ export function useRouteConfigModel() {
           style: { width: '100%' },
         },
       },
+      {
+        field: 'routeMetadata.requestCoalescing',
+        label: '合并相同请求',
+        type: 'switch' as const,
+        span: 24,
+        tabKey: 'forward',
+        defaultValue: 'N',
+        tips: '仅对单服务GET请求生效。Y：合并键相同的并发请求只向后端转发一次，响应分发给所有等待的请求；SSE或超过共享上限的响应不合并',
+        props: {
+          checkedValue: 'Y',
+          uncheckedValue: 'N',
+        },
+      },
+      {
+        field: 'routeMetadata.coalesceKeyTemplate',
+        label: '合并键模板',
+        type: 'input' as const,
+        placeholder: '{method} {host}{path}?{query} {header.Authorization} {header.Cookie}',
+        span: 12,
+        tabKey: 'forward',
+        show: (formData: Record<string, any>) => formData['routeMetadata.requestCoalescing'] === 'Y',
+        tips: '为空时使用默认模板（含认证头和Cookie，不同用户不会合并）。支持 {method} {host} {path} {query} {query.参数名} {header.请求头} {cookie.名称}',
+      },
+      {
+        field: 'routeMetadata.coalesceMaxBodyBytes',
+        label: '共享响应上限（字节）',
+        type: 'number' as const,
+        placeholder: '0表示默认4MB',
+        span: 12,
+        tabKey: 'forward',
+        defaultValue: 0,
+        show: (formData: Record<string, any>) => formData['routeMetadata.requestCoalescing'] === 'Y',
+        tips: '响应体超过该大小时不共享，等待的请求各自转发；0表示使用默认值4MB',
+        props: {
+          min: 0,
+          precision: 0,
+          style: { width: '100%' },
+        },
+      },
+      {
+        field: 'routeMetadata.idempotency',
+        label: '幂等键去重',
+        type: 'switch' as const,
+        span: 24,
+        tabKey: 'forward',
+        defaultValue: 'N',
+        tips: '仅对携带幂等键的POST/PUT/PATCH/DELETE请求生效。Y：首个成功响应按缓存时间保存，相同幂等键的重试直接返回缓存响应（带 Idempotent-Replayed 响应头）；5xx和转发失败不缓存',
+        props: {
+          checkedValue: 'Y',
+          uncheckedValue: 'N',
+        },
+      },
+      {
+        field: 'routeMetadata.idempotencyHeader',
+        label: '幂等键请求头',
+        type: 'input' as const,
+        placeholder: 'Idempotency-Key',
+        span: 8,
+        tabKey: 'forward',
+        show: (formData: Record<string, any>) => formData['routeMetadata.idempotency'] === 'Y',
+        tips: '为空时使用 Idempotency-Key。幂等键按路由和认证头隔离，不同用户使用相同幂等键不会共享响应',
+      },
+      {
+        field: 'routeMetadata.idempotencyTtlSeconds',
+        label: '缓存时间（秒）',
+        type: 'number' as const,
+        placeholder: '0表示默认24小时',
+        span: 8,
+        tabKey: 'forward',
+        defaultValue: 0,
+        show: (formData: Record<string, any>) => formData['routeMetadata.idempotency'] === 'Y',
+        tips: '幂等响应的缓存时间，过期后相同幂等键的请求会重新转发；0表示使用默认值24小时',
+        props: {
+          min: 0,
+          precision: 0,
+          style: { width: '100%' },
+        },
+      },
+      {
+        field: 'routeMetadata.idempotencyMaxBodyBytes',
+        label: '缓存响应上限（字节）',
+        type: 'number' as const,
+        placeholder: '0表示默认1MB',
+        span: 8,
+        tabKey: 'forward',
+        defaultValue: 0,
+        show: (formData: Record<string, any>) => formData['routeMetadata.idempotency'] === 'Y',
+        tips: '响应体超过该大小时不缓存；0表示使用默认值1MB',
+        props: {
+          min: 0,
+          precision: 0,
+          style: { width: '100%' },
+        },
+      },
       {
         field: 'stripPathPrefix',
         label: '剥离路径前缀',
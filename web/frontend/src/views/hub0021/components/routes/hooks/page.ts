@@ -202,6 +202,13 @@ export function useRouteConfigPage(
       'maxConcurrentRequests',
       'requireAllSuccess',
       'overrideProxyTimeout',
+      'requestCoalescing',
+      'coalesceKeyTemplate',
+      'coalesceMaxBodyBytes',
+      'idempotency',
+      'idempotencyHeader',
+      'idempotencyTtlSeconds',
+      'idempotencyMaxBodyBytes',
     ]
     multiServiceConfigFields.forEach((key) => {
       if (routeMetadataObj && typeof routeMetadataObj === 'object' && routeMetadataObj[key] !== undefined) {
@@ -211,6 +218,8 @@ export function useRouteConfigPage(
     // 历史路由未配置或非 Y 时默认关闭；仅 "Y" 开启覆盖
     const overrideFlag = formData['routeMetadata.overrideProxyTimeout']
     formData['routeMetadata.overrideProxyTimeout'] = overrideFlag === 'Y' ? 'Y' : 'N'
+    formData['routeMetadata.requestCoalescing'] = formData['routeMetadata.requestCoalescing'] === 'Y' ? 'Y' : 'N'
+    formData['routeMetadata.idempotency'] = formData['routeMetadata.idempotency'] === 'Y' ? 'Y' : 'N'
 
     return formData
   }
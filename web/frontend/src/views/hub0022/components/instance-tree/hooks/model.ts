@@ -328,6 +328,20 @@ export function useGatewayInstanceTreeModel() {
           min: 1,
         },
       },
+      {
+        field: 'proxyConfig.tlsHandshakeTimeout',
+        label: 'TLS握手超时（秒）',
+        type: 'number' as const,
+        placeholder: '请输入TLS握手超时',
+        span: 12,
+        tabKey: 'http',
+        show: (formData: Record<string, any>) => formData.proxyType === ProxyTypeEnum.HTTP,
+        defaultValue: 10,
+        tips: '与https后端完成TLS握手的超时时间；连接、TLS握手、读取超时为实例默认值，服务和路由可逐项覆盖',
+        props: {
+          min: 1,
+        },
+      },
       {
         field: 'proxyConfig.keepAlive',
         label: '保持连接',
@@ -382,6 +396,36 @@ export function useGatewayInstanceTreeModel() {
           },
         ],
       },
+      {
+        field: 'proxyConfig.dnsCacheTTL',
+        label: 'DNS缓存时间（秒）',
+        type: 'number' as const,
+        placeholder: '0表示不缓存',
+        span: 12,
+        tabKey: 'http',
+        show: (formData: Record<string, any>) => formData.proxyType === ProxyTypeEnum.HTTP,
+        defaultValue: 0,
+        tips: '后端以主机名配置时缓存DNS解析结果，连接在全部A/AAAA记录之间轮询；解析失败时继续使用上次结果。0表示每次由系统解析器解析',
+        props: {
+          min: 0,
+          max: 86400,
+        },
+      },
+      {
+        field: 'proxyConfig.dnsRefreshInterval',
+        label: 'DNS重新解析间隔（秒）',
+        type: 'number' as const,
+        placeholder: '0表示缓存时间的一半',
+        span: 12,
+        tabKey: 'http',
+        show: (formData: Record<string, any>) => formData.proxyType === ProxyTypeEnum.HTTP,
+        defaultValue: 0,
+        tips: '启用DNS缓存后，后台按此间隔重新解析正在使用的主机名。0表示使用DNS缓存时间的一半',
+        props: {
+          min: 0,
+          max: 86400,
+        },
+      },
       // 3. 超时相关配置
       {
         field: 'proxyConfig.timeout',
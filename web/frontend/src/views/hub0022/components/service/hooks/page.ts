@@ -65,7 +65,7 @@ export function useServiceDefinitionPage(
 
     // 将 JSON 字符串字段解析为对象，然后展开为点号分隔字段
     // serviceMetadata 保持为 JSON 字符串格式，不展开
-    const jsonFieldsToExpand = ['discoveryConfig', 'healthCheckHeaders', 'loadBalancerConfig']
+    const jsonFieldsToExpand = ['discoveryConfig', 'healthCheckHeaders', 'loadBalancerConfig', 'upstreamTlsConfig']
     
     jsonFieldsToExpand.forEach((fieldName) => {
       const jsonValue = parseJson((service as any)[fieldName])
@@ -114,7 +114,7 @@ export function useServiceDefinitionPage(
    */
   const convertToApiData = (formData: Record<string, any>): any => {
     // JSON 字段列表（需要展开的字段）
-    const jsonFieldsToExpand = ['discoveryConfig', 'healthCheckHeaders', 'loadBalancerConfig']
+    const jsonFieldsToExpand = ['discoveryConfig', 'healthCheckHeaders', 'loadBalancerConfig', 'upstreamTlsConfig']
 
     // 构建 API 数据对象
     const apiData: Record<string, any> = {}
@@ -58,6 +58,8 @@ export interface ServiceDefinition {
   loadBalancerConfig?: string
   /** 服务元数据，JSON格式 */
   serviceMetadata?: string
+  /** 上游TLS配置，JSON格式 */
+  upstreamTlsConfig?: string
   /** 活动状态标记 */
   activeFlag: 'Y' | 'N'
   /** 备注信息 */
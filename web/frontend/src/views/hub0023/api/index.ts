@@ -8,6 +8,7 @@ import type { GatewayMonitoringQueryParams } from '../components/monitor/hooks/t
 import type {
   GatewayLogGetParams,
   GatewayLogQueryParams,
+  GatewayLogReplayParams,
   GatewayLogResetParams,
 } from '../types'
 
@@ -142,3 +143,38 @@ export const getGatewayRealtimeMonitoringData = async (
   )
   return gatewayLogApi.post('/gateway-log/monitoring/realtime', { ...params, gatewayInstanceId })
 }
+
+/**
+ * 启动访问日志重放任务API - 服务端按限速将选中日志重放到目标环境，立即返回任务ID
+ * @param params 重放参数
+ * @returns 重放任务
+ */
+export const startGatewayLogReplay = async (params: GatewayLogReplayParams): Promise<JsonDataObj> => {
+  const gatewayInstanceId = requireGatewayInstanceIdForQuery(params.gatewayInstanceId, '访问日志重放')
+  return gatewayLogApi.post('/gateway-log/replay/start', { ...params, gatewayInstanceId })
+}
+
+/**
+ * 获取访问日志重放任务进度和结果API
+ * @param taskId 重放任务ID
+ * @returns 重放任务（含明细结果）
+ */
+export const getGatewayLogReplayTask = async (taskId: string): Promise<JsonDataObj> => {
+  return gatewayLogApi.post('/gateway-log/replay/get', { taskId })
+}
+
+/**
+ * 取消访问日志重放任务API
+ * @param taskId 重放任务ID
+ */
+export const cancelGatewayLogReplayTask = async (taskId: string): Promise<JsonDataObj> => {
+  return gatewayLogApi.post('/gateway-log/replay/cancel', { taskId })
+}
+
+/**
+ * 查询访问日志重放任务列表API
+ * @returns 当前租户的重放任务列表（不含明细结果）
+ */
+export const queryGatewayLogReplayTasks = async (): Promise<JsonDataObj> => {
+  return gatewayLogApi.post('/gateway-log/replay/query', {})
+}
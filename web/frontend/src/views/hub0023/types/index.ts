@@ -76,6 +76,8 @@ export interface GatewayLogInfo {
   gatewayProcessingTimeMs?: number
   /** 后端服务响应时间(毫秒，可选) */
   backendResponseTimeMs?: number
+  /** 限流排队等待时间(毫秒，已计入网关处理时间) */
+  queueTimeMs?: number
 
   // 响应信息
   /** 网关响应状态码 */
@@ -349,3 +351,65 @@ export interface GatewayLogResetParams {
   operatorId: string
 }
 
+
+/**
+ * 访问日志重放参数接口
+ */
+export interface GatewayLogReplayParams {
+  /** 网关实例ID，用于按实例解析日志存储 */
+  gatewayInstanceId: string
+  /** 要重放的链路追踪ID列表 */
+  traceIds: string[]
+  /** 目标环境地址，如 http://staging-gw:8080 */
+  targetBaseUrl: string
+  /** 每秒请求数，默认5，最大100 */
+  ratePerSecond?: number
+  /** 并发数，默认1，最大20 */
+  concurrency?: number
+  /** 单请求超时(毫秒)，默认30000 */
+  timeoutMs?: number
+  /** 重放时移除的请求头 */
+  stripHeaders?: string[]
+  /** 重放时覆盖或追加的请求头 */
+  setHeaders?: Record<string, string>
+}
+
+/**
+ * 单条访问日志重放结果
+ */
+export interface GatewayLogReplayResult {
+  traceId: string
+  method: string
+  url: string
+  /** 原始网关响应状态码 */
+  originalStatusCode: number
+  /** 原始总处理时间(毫秒) */
+  originalDurationMs: number
+  /** 重放响应状态码，请求失败时为0 */
+  statusCode: number
+  durationMs: number
+  responseSize: number
+  /** 状态码是否与原始一致 */
+  statusMatched: boolean
+  errorMessage?: string
+}
+
+/**
+ * 访问日志重放任务
+ */
+export interface GatewayLogReplayTask {
+  taskId: string
+  /** 任务状态：RUNNING / COMPLETED / CANCELLED */
+  status: 'RUNNING' | 'COMPLETED' | 'CANCELLED'
+  targetBaseUrl: string
+  ratePerSecond: number
+  concurrency: number
+  total: number
+  completed: number
+  succeeded: number
+  failed: number
+  startTime: string
+  endTime?: string
+  addWho?: string
+  results?: GatewayLogReplayResult[]
+}
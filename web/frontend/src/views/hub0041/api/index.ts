@@ -22,6 +22,27 @@ export async function getNamespace(namespaceId: string): Promise<JsonDataObj> {
   return namespaceApi.post('/getNamespace', { namespaceId })
 }
 
+/**
+ * 查询命名空间服务统计
+ * @param namespaceId 命名空间ID
+ * @param groupName 分组名称，为空时统计全部分组
+ * @returns 服务数、节点健康状态、注册变动率和心跳延迟分布
+ */
+export async function getNamespaceStats(namespaceId: string, groupName?: string): Promise<JsonDataObj> {
+  return namespaceApi.post('/getNamespaceStats', { namespaceId, groupName })
+}
+
+/**
+ * 查询命名空间服务依赖拓扑
+ * @param namespaceId 命名空间ID
+ * @param serviceName 评估影响范围的服务名称，为空时不计算影响范围
+ * @param groupName 评估影响范围的服务分组，默认 DEFAULT_GROUP
+ * @returns 依赖拓扑节点、依赖关系和影响范围
+ */
+export async function getDependencyGraph(namespaceId: string, serviceName?: string, groupName?: string): Promise<JsonDataObj> {
+  return namespaceApi.post('/getDependencyGraph', { namespaceId, serviceName, groupName })
+}
+
 /**
  * 添加命名空间
  * @param data 命名空间创建数据
@@ -120,6 +120,7 @@ export function useNamespaceModel(customModuleId?: string) {
     tabs: [
       { key: 'basic', label: '基本信息' },
       { key: 'quota', label: '配额配置' },
+      { key: 'metadata', label: '元数据规范' },
       { key: 'other', label: '其它' },
     ],
     fields: [
@@ -253,6 +254,20 @@ export function useNamespaceModel(customModuleId?: string) {
           min: 0,
         },
       },
+      // ============= 元数据规范 Tab =============
+      {
+        field: 'extProperty',
+        label: '扩展属性',
+        type: 'textarea',
+        placeholder:
+          '{"metadataSchema": {"service": [{"key": "version", "required": true, "format": "SEMVER"}], "node": [{"key": "zone", "format": "ENUM", "values": ["az1", "az2"]}]}}',
+        span: 24,
+        tabKey: 'metadata',
+        tips: 'JSON格式，metadataSchema 定义服务/节点注册时必须满足的元数据规则，format 支持 SEMVER、ENUM、REGEX、NUMBER',
+        props: {
+          rows: 8,
+        },
+      },
       // ============= 其它 Tab =============
       {
         field: 'noteText',
@@ -28,3 +28,94 @@ export interface Namespace {
   extProperty?: string // 扩展属性，JSON格式
 }
 
+
+// 分组服务统计（命名空间汇总使用相同结构）
+export interface NamespaceGroupStats {
+  groupName: string // 分组名称，汇总时为查询的分组名称
+  serviceCount: number // 服务总数
+  nodeCount: number // 节点总数
+  healthyNodes: number // 健康节点数
+  unhealthyNodes: number // 不健康节点数
+  unknownNodes: number // 健康状态未知的节点数
+  registrations: number // 统计窗口内的节点注册次数
+  deregistrations: number // 统计窗口内的节点注销/驱逐次数
+  churnPerMinute: number // 统计窗口内平均每分钟的注册+注销次数
+  windowMinutes: number // 统计窗口（分钟）
+  heartbeatLatency: Record<string, number> // 心跳延迟分布：区间（<5s、5s-15s、15s-30s、30s-60s、>60s、none）-> 节点数
+}
+
+// 命名空间服务统计
+export interface NamespaceStats {
+  tenantId: string // 租户ID
+  namespaceId: string // 命名空间ID
+  summary: NamespaceGroupStats // 命名空间汇总
+  groups: NamespaceGroupStats[] // 按分组统计
+  statTime: string // 统计时间
+}
+
+// 服务依赖拓扑节点
+export interface DependencyGraphNode {
+  id: string // 节点ID：服务为 namespaceId/groupName/serviceName，客户端为 client:客户端标识
+  type: 'SERVICE' | 'CLIENT' // 节点类型
+  namespaceId: string // 命名空间ID
+  groupName: string // 分组名称，订阅整个命名空间时为空
+  serviceName: string // 服务名称，订阅整个命名空间/分组时为 *，客户端为客户端标识
+  registered: boolean // 服务是否在注册中心中存在
+  nodeCount: number // 服务节点总数
+  healthyNodes: number // 健康节点数
+}
+
+// 服务依赖关系：source（订阅方）依赖 target（被订阅方）
+export interface DependencyGraphEdge {
+  id: string // 依赖关系ID
+  source: string // 订阅方节点ID
+  target: string // 被订阅方节点ID
+  subscribeType: 'SERVICE' | 'NAMESPACE' // 订阅方式
+  activeSubscribers: number // 当前仍在订阅的订阅者数，0表示仅有历史记录
+  firstSeenTime: string // 首次订阅时间
+  lastSeenTime: string // 最近一次订阅时间
+}
+
+// 影响范围中的订阅方
+export interface DependencyImpact {
+  nodeId: string // 订阅方节点ID
+  depth: number // 依赖深度，1表示直接依赖
+  active: boolean // 依赖路径上是否存在仍在订阅的订阅者
+}
+
+// 命名空间服务依赖拓扑
+export interface DependencyGraph {
+  tenantId: string // 租户ID
+  namespaceId: string // 命名空间ID
+  nodes: DependencyGraphNode[] // 拓扑节点
+  edges: DependencyGraphEdge[] // 依赖关系
+  impacted?: DependencyImpact[] // 指定服务时的影响范围
+  statTime: string // 统计时间
+}
+
+// 命名空间导入变更项
+export interface NamespaceImportChange {
+  kind: 'SERVICE' | 'CONFIG' // 对象类型
+  groupName: string // 分组名称
+  name: string // 服务名称或配置ID
+  action: 'CREATE' | 'UPDATE' | 'UNCHANGED' | 'RETAIN' // 变更动作，RETAIN 表示只存在于目标命名空间，导入时保留
+  fields?: string[] // UPDATE 时内容不同的字段
+  applied: boolean // 是否已执行
+  error?: string // 执行失败原因
+}
+
+// 命名空间导入变更计划（importNamespace 接口返回，dryRun 时只预览）
+export interface NamespaceImportPlan {
+  sourceNamespaceId: string // 导出包来源命名空间ID
+  targetNamespaceId: string // 导入目标命名空间ID
+  bundleVersion: number // 导出包格式版本
+  dryRun: boolean // 是否只预览不执行
+  changes: NamespaceImportChange[] // 变更列表
+  summary: {
+    create: number // 新增数
+    update: number // 覆盖数
+    unchanged: number // 无变化数
+    retain: number // 只存在于目标命名空间的数量
+    failed: number // 执行失败数
+  }
+}
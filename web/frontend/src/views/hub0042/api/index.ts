@@ -81,6 +81,36 @@ export async function offlineNode(nodeId: string): Promise<JsonDataObj> {
   return serviceApi.post('/offlineNode', { nodeId })
 }
 
+/**
+ * 手动调整节点权重，0表示摘除节点流量
+ * @param nodeId 节点ID
+ * @param weight 手动调整的权重，范围0-10000
+ * @param reason 调整原因
+ * @returns 更新后的节点
+ */
+export async function adjustNodeWeight(nodeId: string, weight: number, reason?: string): Promise<JsonDataObj> {
+  return serviceApi.post('/adjustNodeWeight', { nodeId, weight, reason })
+}
+
+/**
+ * 清除手动调整的权重，恢复使用节点注册的权重
+ * @param nodeId 节点ID
+ * @param reason 调整原因
+ * @returns 更新后的节点
+ */
+export async function resetNodeWeight(nodeId: string, reason?: string): Promise<JsonDataObj> {
+  return serviceApi.post('/resetNodeWeight', { nodeId, reason })
+}
+
+/**
+ * 获取节点健康状态切换历史和抖动隔离状态
+ * @param nodeId 节点ID
+ * @returns 节点健康状态切换历史
+ */
+export async function getNodeHealthHistory(nodeId: string): Promise<JsonDataObj> {
+  return serviceApi.post('/getNodeHealthHistory', { nodeId })
+}
+
 /**
  * 上线节点（通过 editNode 实现）
  * @param nodeId 节点ID
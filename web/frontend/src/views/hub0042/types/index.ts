@@ -47,7 +47,7 @@ export interface ServiceNode {
   nodeId: string // 节点ID
   ipAddress: string // IP地址
   portNumber: number // 端口号
-  instanceStatus: 'UP' | 'DOWN' | 'STARTING' | 'OUT_OF_SERVICE' // 节点状态
+  instanceStatus: 'UP' | 'DOWN' | 'STARTING' | 'OUT_OF_SERVICE' | 'DRAINING' // 节点状态
   healthyStatus: 'HEALTHY' | 'UNHEALTHY' | 'UNKNOWN' // 健康状态
   ephemeral: 'Y' | 'N' // 是否临时节点
   weight: number // 权重值
@@ -56,5 +56,26 @@ export interface ServiceNode {
   lastBeatTime?: string // 最后心跳时间
   lastCheckTime?: string // 最后健康检查时间
   activeFlag: 'Y' | 'N' // 活动状态标记
+  extProperty?: string // 扩展属性，JSON格式（weightOverride 为手动调整的权重）
+}
+
+// 节点健康状态切换
+export interface HealthTransition {
+  fromStatus: string // 切换前健康状态
+  toStatus: string // 切换后健康状态
+  reason?: string // 切换原因
+  time: string // 切换时间
+}
+
+// 节点健康状态切换历史
+export interface NodeHealthHistory {
+  tenantId: string // 租户ID
+  nodeId: string // 节点ID
+  currentStatus?: string // 最近一次记录的健康状态
+  transitions: HealthTransition[] // 最近的健康状态切换，按时间升序
+  flapping: boolean // 是否处于抖动隔离期
+  quarantineUntil?: string // 隔离结束时间
+  flapCount: number // 累计判定为抖动的次数
+  lastFlapTime?: string // 最近一次判定为抖动的时间
 }
 
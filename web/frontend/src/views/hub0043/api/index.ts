@@ -1,6 +1,6 @@
 import { createApi } from '@/api/request';
 import type { JsonDataObj } from '@/types/api';
-import type { Config, ConfigQuery, RollbackRequest } from '../types';
+import type { Config, ConfigQuery, GrayKeyRequest, GrayPublishRequest, RollbackRequest } from '../types';
 
 const configApi = createApi('/gateway/hub0043')
 
@@ -92,3 +92,38 @@ export async function rollbackConfig(data: RollbackRequest): Promise<JsonDataObj
   return configApi.post('/rollbackConfig', data)
 }
 
+/**
+ * 灰度发布配置（只推送给命中灰度规则的监听实例）
+ * @param data 灰度发布请求数据
+ * @returns 灰度状态和通知的实例数量
+ */
+export async function publishGray(data: GrayPublishRequest): Promise<JsonDataObj> {
+  return configApi.post('/publishGray', data)
+}
+
+/**
+ * 获取配置灰度发布状态和监听实例的灰度命中情况
+ * @param data 配置标识
+ * @returns 灰度发布状态
+ */
+export async function getGrayRelease(data: GrayKeyRequest): Promise<JsonDataObj> {
+  return configApi.post('/getGrayRelease', data)
+}
+
+/**
+ * 灰度配置全量发布
+ * @param data 配置标识和变更原因
+ * @returns 全量发布后的配置
+ */
+export async function promoteGray(data: GrayKeyRequest): Promise<JsonDataObj> {
+  return configApi.post('/promoteGray', data)
+}
+
+/**
+ * 回滚灰度发布（灰度实例恢复正式配置）
+ * @param data 配置标识
+ * @returns 操作结果
+ */
+export async function cancelGray(data: GrayKeyRequest): Promise<JsonDataObj> {
+  return configApi.post('/cancelGray', data)
+}
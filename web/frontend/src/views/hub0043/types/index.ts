@@ -106,3 +106,47 @@ export interface RollbackRequest {
   changeReason?: string // 变更原因
 }
 
+// 配置灰度发布操作请求（查询、全量发布、回滚）
+export interface GrayKeyRequest {
+  namespaceId: string // 命名空间ID（必填）
+  groupName: string // 分组名称（必填）
+  configDataId: string // 配置数据ID（必填）
+  changeReason?: string // 变更原因（全量发布时记录到配置历史）
+}
+
+// 配置灰度发布请求
+export interface GrayPublishRequest extends GrayKeyRequest {
+  configContent: string // 灰度配置内容（必填）
+  clientIps?: string[] // 灰度客户端IP列表
+  labels?: Record<string, string> // 灰度客户端标签，客户端需包含全部标签
+}
+
+// 配置灰度发布状态
+export interface GrayRelease {
+  configContent: string // 灰度配置内容
+  md5Value: string // 灰度配置内容的MD5值
+  clientIps?: string[] // 灰度客户端IP列表
+  labels?: Record<string, string> // 灰度客户端标签
+  baseVersion: number // 发起灰度时的正式配置版本号
+  changeReason?: string // 变更原因
+  startTime: string // 灰度开始时间
+  operator: string // 发起人
+}
+
+// 监听配置的客户端实例及其灰度命中情况
+export interface GrayWatcher {
+  watcherId: string // 监听者ID
+  clientIp: string // 客户端IP
+  labels?: Record<string, string> // 客户端标签
+  gray: boolean // 是否命中灰度规则
+}
+
+// 配置灰度发布状态查询结果
+export interface GrayReleaseStatus {
+  version: number // 正式配置版本号
+  md5Value: string // 正式配置MD5值
+  grayRelease: GrayRelease | null // 灰度发布状态，未处于灰度时为 null
+  watchers: GrayWatcher[] // 当前监听该配置的实例
+  grayCount: number // 命中灰度规则的实例数
+  totalCount: number // 监听实例总数
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/web/middleware/permission"
+	"gateway/web/utils/audit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditTrail 操作审计中间件
+// 功能：
+// 1. 为请求设置数据变更收集器，经审计包装的数据库记录请求中已提交的数据变更
+// 2. 请求结束后有数据变更时，记录操作人、模块、接口、变更前后数据及请求结果
+//
+// 只读请求不产生数据变更，不会写入审计记录；可通过 web.audit.enabled 关闭
+func AuditTrail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		recorder := audit.NewRecorder()
+		if recorder == nil {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		// 同时写入Gin上下文和请求的标准上下文，控制器使用任一上下文访问数据库都会记录变更
+		c.Set(database.AuditRecorderKey, recorder)
+		c.Request = c.Request.WithContext(database.WithAuditRecorder(c.Request.Context(), recorder))
+
+		c.Next()
+
+		changes := recorder.Changes()
+		if len(changes) == 0 {
+			return
+		}
+
+		routePath := c.FullPath()
+		if routePath == "" {
+			routePath = c.Request.URL.Path
+		}
+		status := c.Writer.Status()
+		record := &audit.AuditRecord{
+			ClientIp:    c.ClientIP(),
+			UserAgent:   c.GetHeader("User-Agent"),
+			ModuleCode:  permission.ResolveRoutePermission(c.Request.Method, routePath).ModuleCode,
+			ActionName:  routePath[strings.LastIndex(routePath, "/")+1:],
+			HttpMethod:  c.Request.Method,
+			RequestPath: c.Request.URL.Path,
+			ResultFlag:  "Y",
+			HttpStatus:  status,
+			DurationMs:  time.Since(start).Milliseconds(),
+			TraceId:     c.GetString(TraceIDKey),
+			OperateTime: start,
+		}
+		if status >= http.StatusBadRequest {
+			record.ResultFlag = "N"
+		}
+		if userContext := GetUserContext(c); userContext != nil {
+			record.TenantId = userContext.TenantId
+			record.UserId = userContext.UserId
+			record.UserName = userContext.UserName
+		}
+		if record.TenantId == "" {
+			record.TenantId = "default"
+		}
+
+		if err := audit.FillChanges(record, changes); err != nil {
+			logger.ErrorWithTrace(c, "序列化操作审计数据失败", "error", err, "path", record.RequestPath)
+			return
+		}
+		audit.Save(c.Request.Context(), record)
+	}
+}
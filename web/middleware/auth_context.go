@@ -3,7 +3,9 @@ package middleware
 import (
 	"errors"
 	"gateway/pkg/config"
+	"gateway/pkg/database"
 	"gateway/pkg/logger"
+	"gateway/pkg/security"
 	"net/http"
 	"strings"
 	"time"
@@ -74,49 +76,49 @@ func JWTAuth() gin.HandlerFunc {
 			LoginTime: &now,
 		}
 
-		// 将用户上下文保存到请求中
+		// 将用户上下文保存到请求中，租户ID同时写入请求上下文供租户隔离的数据库访问使用
 		c.Set(UserContextKey, userContext)
+		c.Request = c.Request.WithContext(database.WithTenant(c.Request.Context(), claims.TenantId))
 		c.Next()
 	}
 }
 
-// ParseToken 解析JWT令牌
-func ParseToken(tokenString string) (*TokenClaims, error) {
-	// 获取密钥
+// sessionTokenSigner 根据配置创建会话令牌签发器
+func sessionTokenSigner() (*security.JWTSigner, error) {
 	secretKey := config.GetString("app.jwt_secret", "")
 	if secretKey == "" {
 		return nil, errors.New("未配置JWT密钥")
 	}
 
-	// 解析令牌
-	token, err := jwt.ParseWithClaims(tokenString, &TokenClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secretKey), nil
+	// 设置过期时间，默认24小时
+	expiration := config.GetInt("app.jwt_expiration", 24)
+	return security.NewHMACJWTSigner("HS256", secretKey, security.JWTOptions{
+		Issuer: "gateway",
+		TTL:    time.Duration(expiration) * time.Hour,
 	})
+}
 
+// ParseToken 解析JWT令牌
+func ParseToken(tokenString string) (*TokenClaims, error) {
+	signer, err := sessionTokenSigner()
 	if err != nil {
 		return nil, err
 	}
 
-	// 类型断言
-	if claims, ok := token.Claims.(*TokenClaims); ok && token.Valid {
-		return claims, nil
+	claims := &TokenClaims{}
+	if _, err := signer.Parse(tokenString, claims); err != nil {
+		return nil, err
 	}
-
-	return nil, errors.New("无效的令牌声明")
+	return claims, nil
 }
 
 // GenerateToken 生成JWT令牌
 func GenerateToken(userId, tenantId, userName, realName, deptId string) (string, error) {
-	// 获取密钥
-	secretKey := config.GetString("app.jwt_secret", "")
-	if secretKey == "" {
-		return "", errors.New("未配置JWT密钥")
+	signer, err := sessionTokenSigner()
+	if err != nil {
+		return "", err
 	}
 
-	// 设置过期时间，默认24小时
-	expiration := config.GetInt("app.jwt_expiration", 24)
-	expirationTime := time.Now().Add(time.Duration(expiration) * time.Hour)
-
 	// 创建声明
 	claims := &TokenClaims{
 		UserId:   userId,
@@ -124,16 +126,10 @@ func GenerateToken(userId, tenantId, userName, realName, deptId string) (string,
 		UserName: userName,
 		RealName: realName,
 		DeptId:   deptId,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "gateway",
-		},
 	}
 
 	// 生成令牌
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(secretKey))
+	tokenString, err := signer.IssueRegistered(claims, &claims.RegisteredClaims)
 	if err != nil {
 		logger.Error("生成JWT令牌失败", err)
 		return "", err
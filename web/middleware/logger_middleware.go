@@ -41,11 +41,13 @@ func LoggerMiddleware() gin.HandlerFunc {
 		clientIP := c.ClientIP()
 		userAgent := c.GetHeader("User-Agent")
 
+		// 附加请求级日志字段，后续 *WithTrace 日志自动携带
+		AddLogFields(c, "client_ip", clientIP)
+
 		// 4. 记录请求开始日志
 		logger.InfoWithTrace(c.Request.Context(), "请求开始",
 			"method", method,
 			"path", path,
-			"client_ip", clientIP,
 			"user_agent", userAgent)
 
 		// 5. 处理请求
@@ -67,24 +69,21 @@ func LoggerMiddleware() gin.HandlerFunc {
 				"path", path,
 				"status", status,
 				"duration", duration,
-				"response_size", responseSize,
-				"client_ip", clientIP)
+				"response_size", responseSize)
 		case "warn":
 			logger.WarnWithTrace(c.Request.Context(), logMessage,
 				"method", method,
 				"path", path,
 				"status", status,
 				"duration", duration,
-				"response_size", responseSize,
-				"client_ip", clientIP)
+				"response_size", responseSize)
 		default:
 			logger.InfoWithTrace(c.Request.Context(), logMessage,
 				"method", method,
 				"path", path,
 				"status", status,
 				"duration", duration,
-				"response_size", responseSize,
-				"client_ip", clientIP)
+				"response_size", responseSize)
 		}
 	}
 }
@@ -120,6 +119,14 @@ func setTraceIDToContext(c *gin.Context, traceID string) {
 	c.Header(RequestIDHeader, traceID)
 }
 
+// AddLogFields 为当前请求附加日志字段
+// 同时写入Gin上下文和请求的标准上下文，控制器及下游使用任一上下文调用 *WithTrace 时都会带上这些字段
+func AddLogFields(c *gin.Context, args ...any) {
+	fields := logger.MergeFields(logger.ContextFields(c), args...)
+	c.Set(logger.LogFieldsKey, fields)
+	c.Request = c.Request.WithContext(logger.WithFields(c.Request.Context(), args...))
+}
+
 // getLogLevel 根据HTTP状态码确定日志级别
 func getLogLevel(status int) string {
 	if status >= 500 {
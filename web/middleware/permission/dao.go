@@ -347,3 +347,30 @@ func (dao *PermissionDAO) GetUserDataPermissionsByResource(ctx context.Context,
 
 	return permissions, nil
 }
+
+// ListResourceCodes 获取租户下所有启用的资源编码
+func (dao *PermissionDAO) ListResourceCodes(ctx context.Context, tenantId string) ([]string, error) {
+	query := `
+		SELECT resourceCode
+		FROM HUB_AUTH_RESOURCE
+		WHERE tenantId = ?
+			AND activeFlag = 'Y'
+			AND resourceStatus = 'Y'
+	`
+
+	var result []struct {
+		ResourceCode string `db:"resourceCode"`
+	}
+
+	err := dao.db.Query(ctx, &result, query, []interface{}{tenantId}, true)
+	if err != nil {
+		logger.Error("查询资源编码失败", "error", err, "tenantId", tenantId)
+		return nil, fmt.Errorf("查询资源编码失败: %w", err)
+	}
+
+	codes := make([]string, 0, len(result))
+	for _, r := range result {
+		codes = append(codes, r.ResourceCode)
+	}
+	return codes, nil
+}
@@ -0,0 +1,155 @@
+package permission
+
+import (
+	"strings"
+	"sync"
+)
+
+// 路由动作，与 HUB_AUTH_RESOURCE 中按钮编码的后缀一致，如 hub0002:add
+const (
+	ActionView   = "view"
+	ActionAdd    = "add"
+	ActionEdit   = "edit"
+	ActionDelete = "delete"
+	ActionImport = "import"
+	ActionExport = "export"
+)
+
+// routeAPIPrefix 管理端接口路径前缀，其后第一段为模块编码
+const routeAPIPrefix = "/gateway/"
+
+// RoutePermission 路由对应的权限编码
+type RoutePermission struct {
+	ModuleCode string // 模块编码，如 hub0002
+	Action     string // 动作，如 add、edit、delete
+	ButtonCode string // 按钮编码，如 hub0002:add，为空表示只校验模块权限
+	Explicit   bool   // 是否为显式注册的权限编码，显式注册的按钮编码未在资源表定义时同样拒绝访问
+}
+
+// actionPrefixes 接口名前缀与动作的对应关系，按顺序匹配
+// 未匹配的接口按HTTP方法推导：GET、HEAD、OPTIONS 只校验模块权限，DELETE 为删除，其余写方法默认为编辑，
+// 接口名无法推导的查询类 POST 接口需通过 RegisterRoutePermission 显式登记
+var actionPrefixes = []struct {
+	prefix string
+	action string
+}{
+	{"query", ActionView},
+	{"get", ActionView},
+	{"list", ActionView},
+	{"search", ActionView},
+	{"count", ActionView},
+	{"check", ActionView},
+	{"preview", ActionView},
+	{"compare", ActionView},
+	{"diff", ActionView},
+	{"inspect", ActionView},
+	{"detail", ActionView},
+	{"batchdelete", ActionDelete},
+	{"delete", ActionDelete},
+	{"remove", ActionDelete},
+	{"add", ActionAdd},
+	{"create", ActionAdd},
+	{"insert", ActionAdd},
+	{"batchupdate", ActionEdit},
+	{"update", ActionEdit},
+	{"edit", ActionEdit},
+	{"modify", ActionEdit},
+	{"save", ActionEdit},
+	{"enable", ActionEdit},
+	{"disable", ActionEdit},
+	{"start", ActionEdit},
+	{"stop", ActionEdit},
+	{"restart", ActionEdit},
+	{"reload", ActionEdit},
+	{"publish", ActionEdit},
+	{"rollback", ActionEdit},
+	{"import", ActionImport},
+	{"export", ActionExport},
+}
+
+var (
+	routePermissionMu sync.RWMutex
+	// routePermissions 显式注册的路由权限，键为 "METHOD 路由模板"
+	routePermissions = make(map[string]string)
+)
+
+// RegisterRoutePermission 为路由显式指定按钮权限编码
+// 用于接口名无法推导出正确编码的场景，如 hub0020 下的 startGatewayInstance 对应 hub0020:start，
+// 在模块路由注册时调用。按钮编码为空表示查询类接口，只校验模块权限，如 hub0007 下的 /metrics/cpu
+//
+// 参数:
+//
+//	method: HTTP方法
+//	path: 路由模板，与 gin 的 FullPath 一致，如 /gateway/hub0020/addIpAccessConfig
+//	buttonCode: 按钮权限编码，为空时只校验模块权限
+func RegisterRoutePermission(method, path, buttonCode string) {
+	routePermissionMu.Lock()
+	defer routePermissionMu.Unlock()
+	routePermissions[strings.ToUpper(method)+" "+path] = buttonCode
+}
+
+// ResolveRoutePermission 根据请求方法和路由模板解析所需权限
+// 模块编码取 /gateway/ 之后的第一段路径，动作根据最后一段接口名的前缀推导，
+// 显式注册的路由以注册的按钮编码为准
+//
+// 参数:
+//
+//	method: HTTP方法
+//	path: 路由模板
+//
+// 返回:
+//
+//	RoutePermission: 路由权限，非管理端接口返回空的模块编码
+func ResolveRoutePermission(method, path string) RoutePermission {
+	var result RoutePermission
+	if !strings.HasPrefix(path, routeAPIPrefix) {
+		return result
+	}
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(path, routeAPIPrefix), "/"), "/")
+	result.ModuleCode = segments[0]
+
+	routePermissionMu.RLock()
+	buttonCode, explicit := routePermissions[strings.ToUpper(method)+" "+path]
+	routePermissionMu.RUnlock()
+	if explicit {
+		result.ButtonCode = buttonCode
+		result.Explicit = true
+		result.Action = ActionView
+		if idx := strings.LastIndex(buttonCode, ":"); idx >= 0 {
+			result.Action = buttonCode[idx+1:]
+		}
+		return result
+	}
+
+	if len(segments) < 2 {
+		result.Action = ActionView
+		return result
+	}
+	result.Action = actionOf(method, segments[len(segments)-1])
+	if result.Action != ActionView {
+		result.ButtonCode = result.ModuleCode + ":" + result.Action
+	}
+	return result
+}
+
+// actionOf 根据接口名和HTTP方法推导动作，如 addUser -> add、deleteRole -> delete、assignUserRoles -> edit
+func actionOf(method, name string) string {
+	if !strings.HasPrefix(name, ":") && !strings.HasPrefix(name, "*") {
+		lower := strings.ToLower(name)
+		for _, p := range actionPrefixes {
+			if strings.HasPrefix(lower, p.prefix) {
+				return p.action
+			}
+		}
+	}
+
+	switch strings.ToUpper(method) {
+	case "GET", "HEAD", "OPTIONS":
+		return ActionView
+	case "DELETE":
+		return ActionDelete
+	default:
+		return ActionEdit
+	}
+}
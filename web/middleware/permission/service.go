@@ -4,11 +4,40 @@ import (
 	"context"
 	"fmt"
 	"gateway/pkg/database"
+	"sync"
+	"time"
 )
 
+// resourceCodeTTL 资源编码缓存有效期，资源管理中新增或停用的编码在此时间后生效
+const resourceCodeTTL = time.Minute
+
+// RouteResourceStore 路由权限校验使用的资源查询接口，默认由 PermissionDAO 实现
+type RouteResourceStore interface {
+	// ListResourceCodes 获取租户下所有启用的资源编码
+	ListResourceCodes(ctx context.Context, tenantId string) ([]string, error)
+	// CheckUserPermission 检查用户角色是否拥有指定资源
+	CheckUserPermission(ctx context.Context, userId, tenantId, resourceCode string) (bool, error)
+}
+
 // PermissionService 权限服务
 type PermissionService struct {
 	dao *PermissionDAOExtended
+
+	// store 路由权限校验的资源查询接口
+	store RouteResourceStore
+
+	// publicModules 只需登录即可访问的模块，如登录模块 hub0001（接口路径为 /gateway/user）
+	publicModules map[string]bool
+
+	codeMu sync.Mutex
+	// resourceCodes 按租户缓存的资源编码，用于判断路由推导出的编码是否已定义
+	resourceCodes map[string]*resourceCodeSet
+}
+
+// resourceCodeSet 租户资源编码缓存
+type resourceCodeSet struct {
+	codes    map[string]bool
+	loadedAt time.Time
 }
 
 // NewPermissionService 创建权限服务
@@ -20,9 +49,127 @@ type PermissionService struct {
 //
 //	*PermissionService: 权限服务实例
 func NewPermissionService(db database.Database) *PermissionService {
+	dao := NewPermissionDAOExtended(db)
 	return &PermissionService{
-		dao: NewPermissionDAOExtended(db),
+		dao:           dao,
+		store:         dao,
+		publicModules: map[string]bool{"hub0001": true, "user": true},
+		resourceCodes: make(map[string]*resourceCodeSet),
+	}
+}
+
+// SetRouteResourceStore 替换路由权限校验的资源查询接口，并清除资源编码缓存
+//
+// 参数:
+//
+//	store: 资源查询接口
+func (ps *PermissionService) SetRouteResourceStore(store RouteResourceStore) {
+	ps.store = store
+	ps.InvalidateResourceCodes()
+}
+
+// SetPublicModules 设置只需登录即可访问的模块，覆盖默认值
+//
+// 参数:
+//
+//	modules: 模块编码列表
+func (ps *PermissionService) SetPublicModules(modules []string) {
+	publicModules := make(map[string]bool, len(modules))
+	for _, m := range modules {
+		publicModules[m] = true
 	}
+	ps.publicModules = publicModules
+}
+
+// CheckRoutePermission 根据路由检查用户权限
+// 模块编码和按钮编码由服务端根据路由推导（见 ResolveRoutePermission），不信任客户端传入的权限参数：
+// 1. 非管理端接口、公开模块只要求登录
+// 2. 资源表未定义的模块拒绝访问，要求用户角色拥有模块资源
+// 3. 推导出的按钮编码已在资源表定义（或为显式注册）时，要求用户角色拥有该按钮资源
+//
+// 参数:
+//
+//	ctx: 上下文对象
+//	userId: 用户ID
+//	tenantId: 租户ID
+//	method: HTTP方法
+//	path: 路由模板
+//
+// 返回:
+//
+//	*PermissionCheckResponse: 权限检查响应
+//	error: 错误信息，成功时为nil
+func (ps *PermissionService) CheckRoutePermission(ctx context.Context, userId, tenantId, method, path string) (*PermissionCheckResponse, error) {
+	route := ResolveRoutePermission(method, path)
+	response := &PermissionCheckResponse{
+		HasPermission: true,
+		Details: map[string]interface{}{
+			"moduleCode": route.ModuleCode,
+			"buttonCode": route.ButtonCode,
+		},
+	}
+	if route.ModuleCode == "" || ps.publicModules[route.ModuleCode] {
+		response.Message = "无需权限校验"
+		return response, nil
+	}
+
+	codes, err := ps.getResourceCodes(ctx, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	if !codes[route.ModuleCode] {
+		response.HasPermission = false
+		response.Message = fmt.Sprintf("模块 %s 未定义权限资源", route.ModuleCode)
+		return response, nil
+	}
+
+	required := []string{route.ModuleCode}
+	if route.ButtonCode != "" && (route.Explicit || codes[route.ButtonCode]) {
+		required = append(required, route.ButtonCode)
+	}
+	for _, code := range required {
+		hasPermission, err := ps.store.CheckUserPermission(ctx, userId, tenantId, code)
+		if err != nil {
+			return nil, err
+		}
+		if !hasPermission {
+			response.HasPermission = false
+			response.Message = fmt.Sprintf("用户无访问资源 %s 的权限", code)
+			return response, nil
+		}
+	}
+
+	response.Permissions = required
+	response.Message = "权限检查通过"
+	return response, nil
+}
+
+// getResourceCodes 获取租户资源编码，缓存过期后重新加载
+func (ps *PermissionService) getResourceCodes(ctx context.Context, tenantId string) (map[string]bool, error) {
+	ps.codeMu.Lock()
+	defer ps.codeMu.Unlock()
+
+	if set, ok := ps.resourceCodes[tenantId]; ok && time.Since(set.loadedAt) < resourceCodeTTL {
+		return set.codes, nil
+	}
+
+	list, err := ps.store.ListResourceCodes(ctx, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	codes := make(map[string]bool, len(list))
+	for _, code := range list {
+		codes[code] = true
+	}
+	ps.resourceCodes[tenantId] = &resourceCodeSet{codes: codes, loadedAt: time.Now()}
+	return codes, nil
+}
+
+// InvalidateResourceCodes 清除资源编码缓存，资源新增、删除或停用后调用使其立即生效
+func (ps *PermissionService) InvalidateResourceCodes() {
+	ps.codeMu.Lock()
+	defer ps.codeMu.Unlock()
+	ps.resourceCodes = make(map[string]*resourceCodeSet)
 }
 
 // CheckPermission 检查用户权限，这是唯一的权限校验方法，默认必须进行用户权限校验
@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"fmt"
+	"gateway/pkg/config"
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
 	"gateway/web/middleware/permission"
@@ -17,11 +18,16 @@ import (
 var globalPermissionService *permission.PermissionService
 
 // InitPermissionService 初始化权限服务
+// 公开模块从 web.permission.public_modules 读取，默认只有登录模块 hub0001 及其接口路径 /gateway/user
 // 参数:
 //
 //	db: 数据库连接实例
 func InitPermissionService(db database.Database) {
-	globalPermissionService = permission.NewPermissionService(db)
+	service := permission.NewPermissionService(db)
+	if modules := config.GetStringSlice("web.permission.public_modules", nil); len(modules) > 0 {
+		service.SetPublicModules(modules)
+	}
+	globalPermissionService = service
 }
 
 // GetPermissionService 获取权限服务实例
@@ -30,9 +36,15 @@ func GetPermissionService() *permission.PermissionService {
 }
 
 // PermissionRequired 权限验证中间件
-// 验证请求中的权限参数是否有效，参数从请求中获取
+// 根据路由推导模块编码和按钮编码并校验当前用户的角色权限，不使用客户端传入的权限参数，
+// 可通过 web.permission.enabled 关闭（仅校验登录）
 func PermissionRequired() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if !config.GetBool("web.permission.enabled", true) {
+			c.Next()
+			return
+		}
+
 		// 检查权限服务是否已初始化
 		if globalPermissionService == nil {
 			logger.ErrorWithTrace(c, "权限服务未初始化")
@@ -50,33 +62,14 @@ func PermissionRequired() gin.HandlerFunc {
 			return
 		}
 
-		// 从请求中获取权限参数
-		moduleCode := getPermissionParam(c, "moduleCode")
-		resourceCode := getPermissionParam(c, "resourceCode")
-		buttonCode := getPermissionParam(c, "buttonCode")
-		resourcePath := getPermissionParam(c, "resourcePath")
-		method := getPermissionParam(c, "method")
-
-		// 如果没有提供任何权限参数，使用请求路径和方法作为默认权限检查
-		if moduleCode == "" && resourceCode == "" && buttonCode == "" && resourcePath == "" && method == "" {
-			resourcePath = c.Request.URL.Path
-			method = c.Request.Method
+		// 使用路由模板而非实际路径，路径参数不影响权限编码
+		routePath := c.FullPath()
+		if routePath == "" {
+			routePath = c.Request.URL.Path
 		}
+		method := c.Request.Method
 
-		// 构建权限检查请求
-		req := &permission.PermissionCheckRequest{
-			UserId:       userContext.UserId,
-			TenantId:     userContext.TenantId,
-			ModuleCode:   moduleCode,
-			ResourceCode: resourceCode,
-			ButtonCode:   buttonCode,
-			ResourcePath: resourcePath,
-			Method:       method,
-		}
-
-		// 执行权限检查
-		ctx := context.Background()
-		permissionResponse, err := globalPermissionService.CheckPermission(ctx, req)
+		permissionResponse, err := globalPermissionService.CheckRoutePermission(c.Request.Context(), userContext.UserId, userContext.TenantId, method, routePath)
 		if err != nil {
 			logger.ErrorWithTrace(c, "权限检查失败", "error", err, "userId", userContext.UserId, "tenantId", userContext.TenantId)
 			response.ErrorJSON(c, "权限检查失败", constants.ED00001, http.StatusForbidden)
@@ -89,10 +82,7 @@ func PermissionRequired() gin.HandlerFunc {
 			logger.WarnWithTrace(c, "用户权限不足",
 				"userId", userContext.UserId,
 				"tenantId", userContext.TenantId,
-				"moduleCode", moduleCode,
-				"resourceCode", resourceCode,
-				"buttonCode", buttonCode,
-				"resourcePath", resourcePath,
+				"resourcePath", routePath,
 				"method", method,
 				"message", permissionResponse.Message,
 			)
@@ -103,43 +93,18 @@ func PermissionRequired() gin.HandlerFunc {
 
 		// 权限验证通过，将权限信息设置到上下文中
 		c.Set("permissionResponse", permissionResponse)
-		c.Set("dataScope", permissionResponse.DataScope)
 
 		logger.Debug("权限验证通过",
 			"userId", userContext.UserId,
 			"tenantId", userContext.TenantId,
-			"dataScope", permissionResponse.DataScope,
+			"resourcePath", routePath,
+			"permissions", permissionResponse.Permissions,
 		)
 
 		c.Next()
 	}
 }
 
-// getPermissionParam 从请求中获取权限参数
-// 支持多种方式：header、query、form，按优先级顺序获取
-func getPermissionParam(c *gin.Context, paramName string) string {
-	// 1. 从header中获取 (推荐方式)
-	headerName := "X-Permission-" + paramName
-	value := c.GetHeader(headerName)
-	if value != "" {
-		return value
-	}
-
-	// 2. 从query参数中获取
-	value = c.Query(paramName)
-	if value != "" {
-		return value
-	}
-
-	// 3. 从form参数中获取
-	value = c.PostForm(paramName)
-	if value != "" {
-		return value
-	}
-
-	return ""
-}
-
 // HasPermission 检查当前用户是否拥有指定权限
 // 这是一个辅助函数，用于在控制器中进行权限检查
 // 参数:
@@ -51,6 +51,7 @@ func SessionRequired() gin.HandlerFunc {
 		ctx.Set("tenantId", userContext.TenantId)
 		ctx.Set("userName", userContext.UserName)
 		ctx.Set("realName", userContext.RealName)
+		AddLogFields(ctx, "userId", userContext.UserId, "tenantId", userContext.TenantId, "userName", userContext.UserName)
 
 		logger.Debug("Session验证成功", "sessionId", sessionId, "userId", userContext.UserId)
 		ctx.Next()
@@ -86,6 +87,7 @@ func OptionalSession() gin.HandlerFunc {
 		ctx.Set("tenantId", userContext.TenantId)
 		ctx.Set("userName", userContext.UserName)
 		ctx.Set("realName", userContext.RealName)
+		AddLogFields(ctx, "userId", userContext.UserId, "tenantId", userContext.TenantId, "userName", userContext.UserName)
 
 		logger.Debug("可选session验证成功", "sessionId", sessionId, "userId", userContext.UserId)
 		ctx.Next()
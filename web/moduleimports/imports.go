@@ -4,6 +4,8 @@
 
 package moduleimports
 
+//go:generate go run ../../cmd/openapigen -views ../views -out openapi_specs.go
+
 import (
 	// 导入所有模块的routes包，这样它们的init函数会被自动执行
 	// 每个模块在导入时会通过init函数自动注册自己的路由
@@ -30,6 +32,8 @@ import (
 	_ "gateway/web/views/hub0007/routes"
 	// 导入集群事件管理模块
 	_ "gateway/web/views/hub0008/routes"
+	// 导入操作审计模块
+	_ "gateway/web/views/hub0009/routes"
 	// 导入网关管理模块
 	_ "gateway/web/views/hub0020/routes"
 	// 导入路由管理模块
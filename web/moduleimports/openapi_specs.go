@@ -0,0 +1,434 @@
+// Code generated by cmd/openapigen; DO NOT EDIT.
+
+package moduleimports
+
+import (
+	alerttypes "gateway/internal/alert/types"
+	servicecentertypes "gateway/internal/servicecenter/types"
+	tunneltypes "gateway/internal/tunnel/types"
+	"gateway/web/utils/openapi"
+	hub0000models "gateway/web/views/hub0000/models"
+	hub0001models "gateway/web/views/hub0001/models"
+	hub0002models "gateway/web/views/hub0002/models"
+	hub0003models "gateway/web/views/hub0003/models"
+	hub0005models "gateway/web/views/hub0005/models"
+	hub0006models "gateway/web/views/hub0006/models"
+	hub0007models "gateway/web/views/hub0007/models"
+	hub0008models "gateway/web/views/hub0008/models"
+	hub0009models "gateway/web/views/hub0009/models"
+	hub0020models "gateway/web/views/hub0020/models"
+	hub0021controllers "gateway/web/views/hub0021/controllers"
+	hub0021models "gateway/web/views/hub0021/models"
+	hub0022controllers "gateway/web/views/hub0022/controllers"
+	hub0022dao "gateway/web/views/hub0022/dao"
+	hub0022models "gateway/web/views/hub0022/models"
+	hub0023models "gateway/web/views/hub0023/models"
+	hub0040models "gateway/web/views/hub0040/models"
+	hub0041models "gateway/web/views/hub0041/models"
+	hub0042models "gateway/web/views/hub0042/models"
+	hub0043models "gateway/web/views/hub0043/models"
+	hub0060models "gateway/web/views/hub0060/models"
+	hub0061models "gateway/web/views/hub0061/models"
+	hub0062models "gateway/web/views/hub0062/models"
+	hub0080models "gateway/web/views/hub0080/models"
+	hub0081models "gateway/web/views/hub0081/models"
+	hub0082models "gateway/web/views/hub0082/models"
+	hubcommon002models "gateway/web/views/hubcommon002/models"
+	commonmodels "gateway/web/views/hubplugin/common/models"
+	httpmodels "gateway/web/views/hubplugin/http/models"
+)
+
+func init() {
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*ConfigInspectController).DiffConfigFiles", openapi.HandlerSpec{Summary: "配置文件差异", Description: "配置文件修改后需重启才会生效，返回启动后文件中新增、删除和修改的配置项，敏感配置项的值已脱敏"})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*ConfigInspectController).InspectConfig", openapi.HandlerSpec{Summary: "查询配置生效值", Description: "指定key时返回该配置项的生效值、来源（env/file/runtime/default）、生效的配置文件和可覆盖的环境变量；未指定key时按prefix返回配置项列表。敏感配置项的值已脱敏", Params: []string{"key", "prefix"}})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*DashboardLayoutController).AddLayout", openapi.HandlerSpec{Summary: "保存看板布局", Request: hub0000models.DashboardLayout{}})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*DashboardLayoutController).CopyLayout", openapi.HandlerSpec{Summary: "将本人创建的或共享的布局复制为本人的新布局，复制的布局不共享、不作为默认布局", Params: []string{"layoutName"}})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*DashboardLayoutController).DeleteLayout", openapi.HandlerSpec{Summary: "删除看板布局，仅创建人可删除", Params: []string{"layoutId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*DashboardLayoutController).GetDefaultLayout", openapi.HandlerSpec{Summary: "获取当前用户在指定看板的默认布局", Params: []string{"dashboardCode"}})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*DashboardLayoutController).GetLayout", openapi.HandlerSpec{Summary: "根据布局ID获取看板布局，可获取本人创建的或共享的布局"})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*DashboardLayoutController).QueryLayouts", openapi.HandlerSpec{Summary: "分页查询当前用户可见的看板布局（本人创建的和共享的）", Request: hub0000models.DashboardLayoutListRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*DashboardLayoutController).UpdateLayout", openapi.HandlerSpec{Summary: "更新看板布局，仅创建人可修改", Request: hub0000models.DashboardLayout{}})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*EnumLabelController).QueryEnumLabels", openapi.HandlerSpec{Summary: "查询枚举显示名", Description: "按 Accept-Language 返回枚举值的显示名，enumTypes 为逗号分隔的枚举类型，为空时返回全部", Params: []string{"enumTypes"}})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*GlobalSearchController).GlobalSearch", openapi.HandlerSpec{Summary: "全局搜索", Description: "按关键字搜索服务、路由、网关实例和JVM资源，关键字为链路追踪ID时同时返回对应的访问日志", Request: hub0000models.GlobalSearchRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*LogLevelController).QueryLogLevel", openapi.HandlerSpec{Summary: "查询日志级别", Description: "返回全局日志级别和按模块设置的日志级别"})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*LogLevelController).ResetLogLevel", openapi.HandlerSpec{Summary: "重置模块日志级别", Description: "指定module时移除该模块的设置，未指定时移除所有模块设置，恢复使用全局级别", Params: []string{"module"}})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*LogLevelController).UpdateLogLevel", openapi.HandlerSpec{Summary: "调整日志级别", Description: "未指定module时调整全局级别，指定module时只调整该模块（包路径，如 internal/gateway/handler/proxy）", Params: []string{"level", "module"}})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*MetricQueryController).GetServerInfoDetail", openapi.HandlerSpec{Summary: "获取服务器信息详情"})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*MetricQueryController).QueryCpuLogList", openapi.HandlerSpec{Summary: "查询CPU性能日志列表", Request: hub0000models.CpuLogQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*MetricQueryController).QueryDiskIoLogList", openapi.HandlerSpec{Summary: "查询磁盘IO日志列表", Request: hub0000models.DiskIoLogQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*MetricQueryController).QueryDiskPartitionLogList", openapi.HandlerSpec{Summary: "查询磁盘分区日志列表", Request: hub0000models.DiskPartitionLogQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*MetricQueryController).QueryMemoryLogList", openapi.HandlerSpec{Summary: "查询内存性能日志列表", Request: hub0000models.MemoryLogQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*MetricQueryController).QueryNetworkLogList", openapi.HandlerSpec{Summary: "查询网络日志列表", Request: hub0000models.NetworkLogQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*MetricQueryController).QueryProcessLogList", openapi.HandlerSpec{Summary: "查询进程日志列表", Request: hub0000models.ProcessLogQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*MetricQueryController).QueryProcessStatsLogList", openapi.HandlerSpec{Summary: "查询进程统计日志列表", Request: hub0000models.ProcessStatsLogQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*MetricQueryController).QueryServerInfoList", openapi.HandlerSpec{Summary: "查询服务器信息列表", Request: hub0000models.ServerInfoQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0000/controllers.(*MetricQueryController).QueryTemperatureLogList", openapi.HandlerSpec{Summary: "查询温度日志列表", Request: hub0000models.TemperatureLogQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0001/controllers.(*AuthController).ChangePassword", openapi.HandlerSpec{Summary: "修改密码", Description: "修改用户密码", Request: hub0001models.PasswordChangeRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0001/controllers.(*AuthController).GetCaptcha", openapi.HandlerSpec{Summary: "获取验证码", Description: "获取验证码，支持随机数验证码和短信验证码（扩展）", Request: hub0001models.CaptchaRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0001/controllers.(*AuthController).GetVersion", openapi.HandlerSpec{Summary: "获取系统版本", Description: "获取系统版本号"})
+	openapi.RegisterHandler("gateway/web/views/hub0001/controllers.(*AuthController).Login", openapi.HandlerSpec{Summary: "用户登录", Description: "用户登录并创建Session会话", Request: hub0001models.LoginRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0001/controllers.(*AuthController).Logout", openapi.HandlerSpec{Summary: "用户登出", Description: "用户登出，清除Session会话"})
+	openapi.RegisterHandler("gateway/web/views/hub0001/controllers.(*AuthController).LogoutAll", openapi.HandlerSpec{Summary: "退出所有设备", Description: "撤销当前用户的所有Session和刷新令牌"})
+	openapi.RegisterHandler("gateway/web/views/hub0001/controllers.(*AuthController).RefreshSession", openapi.HandlerSpec{Summary: "刷新Session会话", Description: "延长当前Session的有效期"})
+	openapi.RegisterHandler("gateway/web/views/hub0001/controllers.(*AuthController).RefreshToken", openapi.HandlerSpec{Summary: "刷新访问令牌", Description: "使用登录时签发的刷新令牌换取新的Session ID和刷新令牌，原令牌立即失效", Request: hub0001models.RefreshTokenRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0001/controllers.(*AuthController).UserInfo", openapi.HandlerSpec{Summary: "获取当前登录用户信息", Description: "根据Session获取当前登录用户的详细信息"})
+	openapi.RegisterHandler("gateway/web/views/hub0002/controllers.(*UserController).AddUser", openapi.HandlerSpec{Summary: "创建用户", Description: "创建新用户", Request: hub0002models.User{}})
+	openapi.RegisterHandler("gateway/web/views/hub0002/controllers.(*UserController).AssignUserRoles", openapi.HandlerSpec{Summary: "为用户分配角色", Description: "为用户批量分配角色", Request: hub0002models.UserRoleRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0002/controllers.(*UserController).ChangePassword", openapi.HandlerSpec{Summary: "修改用户密码", Description: "用户修改自己的密码，需要验证旧密码", Params: []string{"newPassword", "oldPassword", "tenantId", "userId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0002/controllers.(*UserController).Delete", openapi.HandlerSpec{Summary: "删除用户"})
+	openapi.RegisterHandler("gateway/web/views/hub0002/controllers.(*UserController).EditUser", openapi.HandlerSpec{Summary: "更新用户", Description: "更新用户信息", Request: hub0002models.User{}})
+	openapi.RegisterHandler("gateway/web/views/hub0002/controllers.(*UserController).GetUser", openapi.HandlerSpec{Summary: "获取用户详情", Description: "根据用户ID获取用户详细信息", Params: []string{"userId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0002/controllers.(*UserController).GetUserRoles", openapi.HandlerSpec{Summary: "获取用户角色列表", Description: "根据用户ID获取所有角色列表，并标记哪些角色已被该用户分配（checked字段）", Params: []string{"userId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0002/controllers.(*UserController).QueryUsers", openapi.HandlerSpec{Summary: "获取用户列表", Description: "分页获取用户列表", Request: hub0002models.UserQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0002/controllers.(*UserSessionController).QueryUserSessions", openapi.HandlerSpec{Summary: "查询用户会话", Description: "分页查询当前租户的在线会话，可按用户ID过滤", Params: []string{"userId"}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0002/controllers.(*UserSessionController).RevokeUserAllSessions", openapi.HandlerSpec{Summary: "撤销用户所有会话", Description: "强制用户在所有设备上下线", Params: []string{"userId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0002/controllers.(*UserSessionController).RevokeUserSession", openapi.HandlerSpec{Summary: "撤销用户会话", Description: "强制用户的指定会话下线，访问令牌和刷新令牌同时失效", Params: []string{"sessionId", "userId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*SchedulerConfigController).AddSchedulerConfig", openapi.HandlerSpec{Summary: "添加调度器配置", Description: "添加新的调度器配置", Request: hub0003models.TimerScheduler{}})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*SchedulerConfigController).DeleteSchedulerConfig", openapi.HandlerSpec{Summary: "删除调度器配置", Params: []string{"schedulerId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*SchedulerConfigController).GetSchedulerConfig", openapi.HandlerSpec{Summary: "获取调度器配置", Description: "根据ID获取调度器配置详情"})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*SchedulerConfigController).QuerySchedulerConfigs", openapi.HandlerSpec{Summary: "查询调度器配置列表", Description: "根据条件查询调度器配置列表", Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*SchedulerConfigController).UpdateSchedulerConfig", openapi.HandlerSpec{Summary: "更新调度器配置", Description: "更新调度器配置信息", Request: hub0003models.TimerScheduler{}})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*SchedulerConfigController).UpdateSchedulerStatus", openapi.HandlerSpec{Summary: "更新调度器状态", Description: "更新调度器运行状态"})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskConfigController).AddTaskConfig", openapi.HandlerSpec{Summary: "添加任务配置", Description: "添加新的任务配置", Request: hub0003models.TimerTask{}})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskConfigController).DeleteTaskConfig", openapi.HandlerSpec{Summary: "删除任务配置"})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskConfigController).GetTaskConfig", openapi.HandlerSpec{Summary: "获取任务配置", Description: "根据ID获取任务配置详情"})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskConfigController).ListExecutorTypes", openapi.HandlerSpec{Summary: "查询执行器类型", Description: "查询当前网关已注册的任务执行器类型及启用状态，新增任务时 executorType 取其中之一"})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskConfigController).PauseTask", openapi.HandlerSpec{Summary: "暂停任务", Description: "暂停任务调度，任务保留在调度器中，暂停状态持久化到数据库，重启后仍保持暂停"})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskConfigController).PreviewCronSchedule", openapi.HandlerSpec{Summary: "预览Cron执行时间", Description: "按指定时区计算Cron表达式从当前时间开始的后续执行时间，用于保存任务前确认表达式"})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskConfigController).QueryTaskConfigs", openapi.HandlerSpec{Summary: "查询任务配置列表", Description: "根据条件查询任务配置列表", Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskConfigController).ResumeTask", openapi.HandlerSpec{Summary: "恢复任务", Description: "恢复已暂停或已进入死信状态的任务，按数据库中的最新配置重新注册并继续调度"})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskConfigController).StartTask", openapi.HandlerSpec{Summary: "启动任务", Description: "启动指定的任务"})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskConfigController).StopTask", openapi.HandlerSpec{Summary: "停止任务", Description: "停止指定的任务"})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskConfigController).TriggerTask", openapi.HandlerSpec{Summary: "立即执行任务", Description: "立即执行指定的任务（不需要等待定时调度）"})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskConfigController).UpdateTaskConfig", openapi.HandlerSpec{Summary: "更新任务配置", Description: "更新任务配置信息", Request: hub0003models.TimerTask{}})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskConfigController).UpdateTaskStatus", openapi.HandlerSpec{Summary: "更新任务状态", Description: "更新任务运行状态"})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskLogController).GetTaskLog", openapi.HandlerSpec{Summary: "获取任务执行日志", Description: "根据ID获取任务执行日志详情"})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskLogController).GetTaskLogsByTaskId", openapi.HandlerSpec{Summary: "根据任务ID查询执行日志", Description: "根据任务ID查询最近的执行日志", Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0003/controllers.(*TaskLogController).QueryTaskLogs", openapi.HandlerSpec{Summary: "查询任务执行日志列表", Description: "根据条件查询任务执行日志列表，支持按执行开始时间范围搜索", Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0005/controllers.(*RoleController).AddRole", openapi.HandlerSpec{Summary: "创建角色", Description: "创建新角色", Request: hub0005models.Role{}})
+	openapi.RegisterHandler("gateway/web/views/hub0005/controllers.(*RoleController).DeleteRole", openapi.HandlerSpec{Summary: "删除角色", Description: "删除角色（逻辑删除）"})
+	openapi.RegisterHandler("gateway/web/views/hub0005/controllers.(*RoleController).EditRole", openapi.HandlerSpec{Summary: "更新角色", Description: "更新角色信息", Request: hub0005models.Role{}})
+	openapi.RegisterHandler("gateway/web/views/hub0005/controllers.(*RoleController).GetRole", openapi.HandlerSpec{Summary: "获取角色详情", Description: "根据角色ID获取角色详细信息", Params: []string{"roleId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0005/controllers.(*RoleController).GetRoleResources", openapi.HandlerSpec{Summary: "获取角色授权的资源列表", Description: "根据角色ID获取所有资源列表（树形结构），并标记哪些资源已被该角色授权", Params: []string{"roleId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0005/controllers.(*RoleController).QueryRoles", openapi.HandlerSpec{Summary: "获取角色列表", Description: "分页获取角色列表", Request: hub0005models.RoleQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0005/controllers.(*RoleController).SaveRoleResources", openapi.HandlerSpec{Summary: "保存角色授权", Description: "保存角色的资源授权信息到 HUB_AUTH_ROLE_RESOURCE 表"})
+	openapi.RegisterHandler("gateway/web/views/hub0005/controllers.(*RoleController).UpdateRoleStatus", openapi.HandlerSpec{Summary: "更新角色状态", Description: "启用或禁用角色", Params: []string{"roleId", "status"}})
+	openapi.RegisterHandler("gateway/web/views/hub0006/controllers.(*ResourceController).AddResource", openapi.HandlerSpec{Summary: "创建资源", Description: "创建新资源", Request: hub0006models.Resource{}})
+	openapi.RegisterHandler("gateway/web/views/hub0006/controllers.(*ResourceController).DeleteResource", openapi.HandlerSpec{Summary: "删除资源", Description: "删除资源（逻辑删除）"})
+	openapi.RegisterHandler("gateway/web/views/hub0006/controllers.(*ResourceController).EditResource", openapi.HandlerSpec{Summary: "更新资源", Description: "更新资源信息", Request: hub0006models.Resource{}})
+	openapi.RegisterHandler("gateway/web/views/hub0006/controllers.(*ResourceController).GetResource", openapi.HandlerSpec{Summary: "获取资源详情", Description: "根据资源ID获取资源详细信息", Params: []string{"resourceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0006/controllers.(*ResourceController).QueryResources", openapi.HandlerSpec{Summary: "获取资源列表", Description: "获取资源列表，返回树形结构数据（包含children字段）", Request: hub0006models.ResourceQuery{}})
+	openapi.RegisterHandler("gateway/web/views/hub0006/controllers.(*ResourceController).UpdateResourceStatus", openapi.HandlerSpec{Summary: "更新资源状态", Description: "启用或禁用资源", Params: []string{"resourceId", "status"}})
+	openapi.RegisterHandler("gateway/web/views/hub0007/controllers.(*ServerInfoController).GetServerInfo", openapi.HandlerSpec{Summary: "获取系统节点信息详情", Description: "根据ID获取系统节点详细信息", Params: []string{"metricServerId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0007/controllers.(*ServerInfoController).QueryCPUMetrics", openapi.HandlerSpec{Summary: "查询CPU监控数据", Description: "根据服务器ID和时间范围查询CPU监控数据", Params: []string{"endTime", "metricServerId", "startTime"}})
+	openapi.RegisterHandler("gateway/web/views/hub0007/controllers.(*ServerInfoController).QueryDiskIOMetrics", openapi.HandlerSpec{Summary: "查询磁盘IO监控数据", Description: "根据服务器ID和时间范围查询磁盘IO监控数据", Params: []string{"endTime", "metricServerId", "startTime"}})
+	openapi.RegisterHandler("gateway/web/views/hub0007/controllers.(*ServerInfoController).QueryDiskMetrics", openapi.HandlerSpec{Summary: "查询磁盘监控数据", Description: "根据服务器ID和时间范围查询磁盘监控数据", Params: []string{"endTime", "metricServerId", "startTime"}})
+	openapi.RegisterHandler("gateway/web/views/hub0007/controllers.(*ServerInfoController).QueryMemoryMetrics", openapi.HandlerSpec{Summary: "查询内存监控数据", Description: "根据服务器ID和时间范围查询内存监控数据", Params: []string{"endTime", "metricServerId", "startTime"}})
+	openapi.RegisterHandler("gateway/web/views/hub0007/controllers.(*ServerInfoController).QueryNetworkMetrics", openapi.HandlerSpec{Summary: "查询网络监控数据", Description: "根据服务器ID和时间范围查询网络监控数据", Params: []string{"endTime", "metricServerId", "startTime"}})
+	openapi.RegisterHandler("gateway/web/views/hub0007/controllers.(*ServerInfoController).QueryProcessMetrics", openapi.HandlerSpec{Summary: "查询进程监控数据", Description: "根据服务器ID和时间范围查询进程监控数据", Params: []string{"endTime", "metricServerId", "startTime"}})
+	openapi.RegisterHandler("gateway/web/views/hub0007/controllers.(*ServerInfoController).QueryServerInfos", openapi.HandlerSpec{Summary: "获取系统节点信息列表", Description: "分页获取系统节点信息列表，支持条件查询", Request: hub0007models.ServerInfoQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0008/controllers.(*ClusterEventController).GetClusterEventAckDetail", openapi.HandlerSpec{Summary: "获取集群事件确认详情", Description: "根据确认ID获取集群事件确认详细信息", Params: []string{"ackId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0008/controllers.(*ClusterEventController).GetClusterEventDetail", openapi.HandlerSpec{Summary: "获取集群事件详情", Description: "根据事件ID获取集群事件详细信息", Params: []string{"eventId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0008/controllers.(*ClusterEventController).QueryClusterEventAcks", openapi.HandlerSpec{Summary: "查询集群事件处理节点列表", Description: "分页查询集群事件处理节点列表，支持条件筛选", Request: hub0008models.ClusterEventAckQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0008/controllers.(*ClusterEventController).QueryClusterEvents", openapi.HandlerSpec{Summary: "查询集群事件列表", Description: "分页查询集群事件列表，支持条件筛选", Request: hub0008models.ClusterEventQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0009/controllers.(*AuditLogController).GetAuditLogDetail", openapi.HandlerSpec{Summary: "获取操作审计详情", Description: "根据审计记录ID获取操作审计详情，包含每张表变更前后的数据和字段差异", Params: []string{"auditId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0009/controllers.(*AuditLogController).QueryAuditLogs", openapi.HandlerSpec{Summary: "查询操作审计列表", Description: "分页查询当前租户的操作审计记录，支持按操作人、模块、操作类型、变更表和时间范围筛选", Request: hub0009models.AuditLogQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).AddGatewayInstance", openapi.HandlerSpec{Summary: "创建网关实例", Description: "创建新的网关实例，支持同时创建关联的日志配置", Request: hub0020models.GatewayInstance{}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).DeleteGatewayInstance", openapi.HandlerSpec{Summary: "删除网关实例", Description: "删除网关实例，删除前会先停止实例", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).EditGatewayInstance", openapi.HandlerSpec{Summary: "更新网关实例", Description: "更新网关实例信息", Request: hub0020models.GatewayInstance{}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).EditLogConfig", openapi.HandlerSpec{Summary: "更新日志配置", Description: "更新日志配置信息", Request: hub0020models.LogConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).ExportGatewayInstance", openapi.HandlerSpec{Summary: "导出完整网关实例配置为 Excel 文件", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).GetGatewayInstance", openapi.HandlerSpec{Summary: "获取网关实例详情", Description: "根据ID获取网关实例详细信息（包含完整数据，用于编辑）", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).GetGatewayRuntimeConfig", openapi.HandlerSpec{Summary: "获取网关生效配置", Description: "返回本节点运行中网关实例实际生效的配置、配置版本和维护模式状态，用于排查数据库配置与运行状态不一致的问题", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).GetLogConfig", openapi.HandlerSpec{Summary: "获取日志配置详情", Description: "根据ID获取日志配置详细信息", Params: []string{"logConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).ImportGatewayInstance", openapi.HandlerSpec{Summary: "导入网关实例", Description: "从 Excel 文件（exportGatewayInstance 生成的格式）批量导入网关实例及其关联配置"})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).PreviewGatewayConfig", openapi.HandlerSpec{Summary: "预览网关配置变更", Description: "从数据库组装网关配置并执行发布前校验，返回与本节点当前生效配置的差异，不会应用配置", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).PublishGatewayConfig", openapi.HandlerSpec{Summary: "发布网关配置版本", Description: "从数据库组装网关配置快照，校验后在本节点生效并下发到集群其他节点；校验或重载失败时保持原配置", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).QueryCertificateExpiry", openapi.HandlerSpec{Summary: "查询证书到期情况", Description: "检查当前租户下启用TLS的网关实例证书，返回剩余天数及到期状态（VALID/EXPIRING/EXPIRED/ERROR）", Params: []string{"warningDays"}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).QueryGatewayConfigVersions", openapi.HandlerSpec{Summary: "查询网关配置版本历史", Description: "返回实例已生效的配置版本列表（不含配置内容），最新版本在末尾", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).QueryGatewayInstances", openapi.HandlerSpec{Summary: "获取网关实例列表", Description: "分页获取网关实例列表，支持条件查询", Request: hub0020models.GatewayInstanceQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).ReloadGatewayInstance", openapi.HandlerSpec{Summary: "重载网关实例配置", Description: "触发网关实例重新加载配置", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).RollbackGatewayConfig", openapi.HandlerSpec{Summary: "回滚网关配置", Description: "将本节点及集群其他节点的网关配置回滚到上一个已生效版本", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).StartGatewayInstance", openapi.HandlerSpec{Summary: "启动网关实例", Description: "启动网关实例并更新健康状态为Y", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).StopGatewayInstance", openapi.HandlerSpec{Summary: "停止网关实例", Description: "停止网关实例并更新健康状态为N", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0020/controllers.(*GatewayInstanceController).UpdateGatewayMaintenance", openapi.HandlerSpec{Summary: "切换网关维护模式", Description: "维护模式下本节点网关实例保持监听，新请求直接返回503，用于发布或故障处理期间摘除流量；该状态仅保存在内存中，网关重启后恢复为关闭", Params: []string{"gatewayInstanceId", "maintenanceMode"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FeatureFlagController).AddFeatureFlag", openapi.HandlerSpec{Summary: "创建功能开关", Description: "添加实例级或路由级功能开关，保存后通知网关节点重新加载", Request: hub0021models.FeatureFlag{}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FeatureFlagController).DeleteFeatureFlag", openapi.HandlerSpec{Summary: "删除功能开关", Description: "删除功能开关，删除后通知网关节点重新加载", Params: []string{"featureFlagId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FeatureFlagController).EditFeatureFlag", openapi.HandlerSpec{Summary: "编辑功能开关", Description: "修改功能开关的开启状态、放量比例等，保存后通知网关节点重新加载", Request: hub0021models.FeatureFlag{}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FeatureFlagController).GetFeatureFlag", openapi.HandlerSpec{Summary: "获取功能开关详情", Description: "根据功能开关ID获取功能开关详情", Params: []string{"featureFlagId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FeatureFlagController).QueryFeatureFlags", openapi.HandlerSpec{Summary: "分页查询功能开关列表", Description: "支持按网关实例、路由、开关标识筛选", Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FilterConfigController).AddFilterConfig", openapi.HandlerSpec{Summary: "创建过滤器配置", Request: hub0021models.FilterConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FilterConfigController).BatchDeleteFilterConfigs", openapi.HandlerSpec{Summary: "批量删除过滤器配置"})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FilterConfigController).BatchUpdateFilterConfigs", openapi.HandlerSpec{Summary: "批量更新过滤器配置"})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FilterConfigController).BatchUpdateFilterOrder", openapi.HandlerSpec{Summary: "批量更新过滤器配置执行顺序"})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FilterConfigController).DeleteFilterConfig", openapi.HandlerSpec{Summary: "删除过滤器配置", Request: hub0021controllers.DeleteFilterConfigRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FilterConfigController).EditFilterConfig", openapi.HandlerSpec{Summary: "更新过滤器配置", Request: hub0021models.FilterConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FilterConfigController).ExportFilterConfigs", openapi.HandlerSpec{Summary: "导出过滤器配置", Params: []string{"activeFlag", "gatewayInstanceId", "routeConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FilterConfigController).GetFilterConfig", openapi.HandlerSpec{Summary: "获取过滤器配置详情", Params: []string{"filterConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FilterConfigController).GetFilterConfigStats", openapi.HandlerSpec{Summary: "获取过滤器配置统计信息", Params: []string{"activeFlag", "gatewayInstanceId", "routeConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FilterConfigController).GetFilterConfigUsage", openapi.HandlerSpec{Summary: "获取过滤器配置使用情况", Params: []string{"filterConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FilterConfigController).ImportFilterConfigs", openapi.HandlerSpec{Summary: "导入过滤器配置", Request: hub0021controllers.ImportFilterConfigsRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FilterConfigController).QueryFilterConfigs", openapi.HandlerSpec{Summary: "获取过滤器配置列表（支持多参数查询）", Params: []string{"activeFlag", "filterAction", "filterName", "filterType", "gatewayInstanceId", "routeConfigId"}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*FilterConfigController).UpdateFilterOrder", openapi.HandlerSpec{Summary: "调整过滤器执行顺序", Request: hub0021controllers.UpdateFilterOrderRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*GatewayInstanceController).QueryAllGatewayInstances", openapi.HandlerSpec{Summary: "获取所有网关实例列表", Description: "分页获取所有网关实例列表（跨租户查询，仅限管理员使用），支持按名称筛选", Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouteAssertionController).AddRouteAssertion", openapi.HandlerSpec{Summary: "创建路由断言", Description: "为路由配置添加断言规则", Request: hub0021models.RouteAssertion{}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouteAssertionController).DeleteRouteAssertion", openapi.HandlerSpec{Summary: "删除路由断言", Description: "删除路由断言规则（软删除）", Params: []string{"routeAssertionId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouteAssertionController).EditRouteAssertion", openapi.HandlerSpec{Summary: "编辑路由断言", Description: "修改现有的路由断言规则", Request: hub0021models.RouteAssertion{}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouteAssertionController).GetRouteAssertionById", openapi.HandlerSpec{Summary: "根据断言ID获取断言配置", Description: "根据路由断言ID获取单个断言配置详情", Params: []string{"routeAssertionId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouteAssertionController).QueryRouteAssertions", openapi.HandlerSpec{Summary: "分页查询路由断言列表", Description: "支持多条件筛选的分页查询路由断言列表", Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouteConfigController).AddRouteConfig", openapi.HandlerSpec{Summary: "创建路由配置", Description: "创建新的路由配置", Request: hub0021models.RouteConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouteConfigController).DeleteRouteConfig", openapi.HandlerSpec{Summary: "删除路由配置", Params: []string{"routeConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouteConfigController).EditRouteConfig", openapi.HandlerSpec{Summary: "更新路由配置", Description: "更新路由配置信息", Request: hub0021models.RouteConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouteConfigController).GetRouteConfig", openapi.HandlerSpec{Summary: "获取路由配置详情", Description: "根据路由配置ID获取详细信息", Params: []string{"routeConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouteConfigController).GetRouteConfigsByInstance", openapi.HandlerSpec{Summary: "根据网关实例获取路由配置列表", Description: "获取指定网关实例下的所有路由配置", Params: []string{"activeFlag", "gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouteConfigController).GetRouteStatistics", openapi.HandlerSpec{Summary: "获取路由统计信息", Description: "获取指定租户和网关实例的路由统计信息", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouteConfigController).QueryRouteConfigs", openapi.HandlerSpec{Summary: "获取路由配置列表", Description: "分页获取路由配置列表", Params: []string{"activeFlag", "gatewayInstanceId", "matchType", "routeName", "routePath"}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouterConfigController).AddRouterConfig", openapi.HandlerSpec{Summary: "创建Router配置", Description: "创建新的Router配置", Request: hub0021models.RouterConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouterConfigController).DeleteRouterConfig", openapi.HandlerSpec{Summary: "删除Router配置", Params: []string{"routerConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouterConfigController).EditRouterConfig", openapi.HandlerSpec{Summary: "更新Router配置", Description: "更新Router配置信息", Request: hub0021models.RouterConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouterConfigController).GetRouterConfig", openapi.HandlerSpec{Summary: "获取Router配置详情", Description: "根据ID获取Router配置详细信息", Params: []string{"routerConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouterConfigController).GetRouterConfigsByInstance", openapi.HandlerSpec{Summary: "根据网关实例获取Router配置", Description: "根据网关实例ID获取Router配置（返回单条数据）", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*RouterConfigController).QueryRouterConfigs", openapi.HandlerSpec{Summary: "获取Router配置列表", Description: "分页获取Router配置列表", Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*ServiceDefinitionController).GetServiceDefinitionById", openapi.HandlerSpec{Summary: "根据ID获取服务定义详情", Description: "获取指定服务定义的详细信息", Params: []string{"activeFlag", "serviceDefinitionId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*ServiceDefinitionController).GetServiceDefinitionsByInstance", openapi.HandlerSpec{Summary: "根据网关实例ID获取服务定义列表", Description: "获取指定网关实例关联的所有服务定义，包含代理配置信息", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*ServiceDefinitionController).QueryAllServiceDefinitions", openapi.HandlerSpec{Summary: "查询所有服务定义（不依赖代理配置）", Description: "分页获取所有服务定义列表，支持筛选，不强制要求代理配置ID，用于日志查询等场景", Params: []string{"activeFlag", "loadBalanceStrategy", "serviceDefinitionId", "serviceName", "serviceType"}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0021/controllers.(*ServiceDefinitionController).QueryServiceDefinitions", openapi.HandlerSpec{Summary: "分页查询服务定义列表", Description: "分页获取服务定义列表，支持筛选", Params: []string{"activeFlag", "gatewayInstanceId", "loadBalanceStrategy", "proxyConfigId", "serviceDefinitionId", "serviceName", "serviceType"}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*GatewayInstanceController).GetGatewayInstance", openapi.HandlerSpec{Summary: "获取网关实例详情", Description: "根据网关实例ID获取网关实例详情"})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*GatewayInstanceController).QueryAllGatewayInstances", openapi.HandlerSpec{Summary: "获取所有网关实例列表", Description: "分页获取所有网关实例列表（跨租户查询，仅限管理员使用）", Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*GatewayInstanceController).QueryGatewayInstances", openapi.HandlerSpec{Summary: "获取租户下的网关实例列表", Description: "分页获取当前租户下的网关实例列表，支持按名称和状态筛选", Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ProxyConfigController).CreateProxyConfig", openapi.HandlerSpec{Summary: "创建代理配置", Description: "创建新的代理配置", Request: hub0022models.ProxyConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ProxyConfigController).DeleteProxyConfig", openapi.HandlerSpec{Summary: "删除代理配置", Params: []string{"proxyConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ProxyConfigController).EditProxyConfig", openapi.HandlerSpec{Summary: "更新代理配置", Description: "更新代理配置信息", Request: hub0022models.ProxyConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ProxyConfigController).GetProxyConfig", openapi.HandlerSpec{Summary: "获取代理配置详情", Description: "根据ID获取代理配置详情", Params: []string{"proxyConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ProxyConfigController).GetProxyConfigsByInstance", openapi.HandlerSpec{Summary: "根据网关实例获取代理配置", Description: "根据网关实例ID获取代理配置（返回单条数据）", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ProxyConfigController).QueryProxyConfigs", openapi.HandlerSpec{Summary: "获取代理配置列表", Description: "分页获取代理配置列表", Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ServiceDefinitionController).CreateServiceDefinition", openapi.HandlerSpec{Summary: "创建服务定义", Description: "创建新的服务定义", Request: hub0022models.ServiceDefinition{}})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ServiceDefinitionController).DeleteServiceDefinition", openapi.HandlerSpec{Summary: "删除服务定义", Request: hub0022controllers.DeleteServiceDefinitionRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ServiceDefinitionController).EditServiceDefinition", openapi.HandlerSpec{Summary: "更新服务定义", Description: "更新服务定义信息", Request: hub0022models.ServiceDefinition{}})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ServiceDefinitionController).GetServiceDefinition", openapi.HandlerSpec{Summary: "获取服务定义详情", Description: "根据ID获取服务定义详情", Request: hub0022controllers.GetServiceDefinitionRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ServiceDefinitionController).QueryServiceDefinitions", openapi.HandlerSpec{Summary: "获取服务定义列表", Description: "分页获取服务定义列表，支持多种过滤条件", Request: hub0022dao.ServiceDefinitionQueryFilter{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ServiceNodeController).AddServiceNode", openapi.HandlerSpec{Summary: "创建服务节点", Description: "创建新的服务节点", Request: hub0022models.ServiceNodeModel{}})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ServiceNodeController).DeleteServiceNode", openapi.HandlerSpec{Summary: "删除服务节点", Request: hub0022controllers.DeleteServiceNodeRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ServiceNodeController).EditServiceNode", openapi.HandlerSpec{Summary: "更新服务节点", Description: "更新服务节点信息", Request: hub0022models.ServiceNodeModel{}})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ServiceNodeController).GetServiceNode", openapi.HandlerSpec{Summary: "获取服务节点详情", Description: "根据ID获取服务节点详情", Request: hub0022controllers.GetServiceNodeRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ServiceNodeController).QueryServiceNodes", openapi.HandlerSpec{Summary: "获取服务节点列表", Description: "分页获取服务节点列表", Request: hub0022controllers.QueryServiceNodesRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ServiceNodeController).UpdateNodeHealth", openapi.HandlerSpec{Summary: "更新节点健康状态", Description: "更新节点的健康状态", Request: hub0022controllers.UpdateNodeHealthRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0022/controllers.(*ServiceNodeController).UpdateNodeStatus", openapi.HandlerSpec{Summary: "更新节点运行状态", Description: "更新节点的运行状态(0下线,1在线,2维护)，维护状态的节点在网关重新加载配置后不再接收流量", Request: hub0022controllers.UpdateNodeStatusRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*AccessLogReplayController).CancelReplayTask", openapi.HandlerSpec{Summary: "取消重放任务", Request: hub0023models.GatewayAccessLogReplayTaskRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*AccessLogReplayController).GetReplayTask", openapi.HandlerSpec{Summary: "获取重放任务", Request: hub0023models.GatewayAccessLogReplayTaskRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*AccessLogReplayController).QueryReplayTasks", openapi.HandlerSpec{Summary: "查询重放任务列表"})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*AccessLogReplayController).StartReplay", openapi.HandlerSpec{Summary: "重放访问日志", Description: "将选中的访问日志按限速重放到指定环境，立即返回任务ID，通过任务详情查看进度和结果", Request: hub0023models.GatewayAccessLogReplayRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*ClickHouseQueryController).CountGatewayLogs", openapi.HandlerSpec{Summary: "统计网关日志数量（ClickHouse版本）", Description: "根据查询条件统计ClickHouse网关日志数量", Request: hub0023models.GatewayAccessLogQueryRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*ClickHouseQueryController).DropLogPartitions", openapi.HandlerSpec{Summary: "删除 ClickHouse 日志表旧分区", Request: hub0023models.LogPartitionDropRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*ClickHouseQueryController).GetAnalyticsErrorBreakdown", openapi.HandlerSpec{Summary: "访问日志错误分布（按错误码统计）"})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*ClickHouseQueryController).GetAnalyticsRequestTrend", openapi.HandlerSpec{Summary: "访问日志请求量分析（按分钟/小时/天统计请求数、错误数和P95）"})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*ClickHouseQueryController).GetAnalyticsSlowRoutes", openapi.HandlerSpec{Summary: "访问日志慢路由排行（按P95响应时间倒序）"})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*ClickHouseQueryController).GetAnalyticsTopClientIPs", openapi.HandlerSpec{Summary: "访问日志客户端IP排行（按请求数倒序）"})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*ClickHouseQueryController).GetGatewayLog", openapi.HandlerSpec{Summary: "获取网关日志详情（ClickHouse版本）", Description: "通过租户ID和链路追踪ID组合主键获取ClickHouse网关日志详情", Request: hub0023models.GatewayAccessLogGetRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*ClickHouseQueryController).GetGatewayLogAccessDetail", openapi.HandlerSpec{Summary: "获取ClickHouse网关日志主表详情（不含后端追踪）", Description: "通过租户ID与链路追踪ID获取主表完整字段，不附带 backendTraces", Request: hub0023models.GatewayAccessLogGetRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*ClickHouseQueryController).GetGatewayMonitoringChartData", openapi.HandlerSpec{Summary: "获取网关监控图表数据（ClickHouse版本）", Description: "获取网关监控图表数据，包括请求趋势、响应时间趋势、状态码分布、热点路由等", Request: hub0023models.GatewayMonitoringQueryRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*ClickHouseQueryController).GetGatewayMonitoringOverview", openapi.HandlerSpec{Summary: "获取网关监控概览数据（ClickHouse版本）", Description: "获取网关监控概览数据，包括总请求数、成功失败数、平均响应时间等", Request: hub0023models.GatewayMonitoringQueryRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*ClickHouseQueryController).QueryGatewayLogs", openapi.HandlerSpec{Summary: "查询网关日志列表（ClickHouse版本）", Description: "支持分页查询和多条件过滤的ClickHouse网关日志列表，不返回大字段以提高查询性能；cursorPaging 为 true 时按游标分页（不统计总数，使用返回的 nextCursor 翻页）", Request: hub0023models.GatewayAccessLogQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*ClickHouseQueryController).QueryLogPartitions", openapi.HandlerSpec{Summary: "查询 ClickHouse 日志表分区", Request: hub0023models.LogPartitionQueryRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*GatewayLogController).Get", openapi.HandlerSpec{Summary: "获取网关日志详情", Description: "通过租户ID和链路追踪ID组合主键获取网关日志详情", Request: hub0023models.GatewayAccessLogGetRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*GatewayLogController).GetAccessDetail", openapi.HandlerSpec{Summary: "获取网关日志主表详情（不含后端追踪）", Description: "通过租户ID与链路追踪ID获取主表完整字段，不附带 backendTraces", Request: hub0023models.GatewayAccessLogGetRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*GatewayLogController).GetMonitoringChartData", openapi.HandlerSpec{Summary: "获取网关监控图表数据（关系数据库版本）", Description: "获取网关监控图表数据，包括请求趋势、响应时间趋势、状态码分布、热点路由等", Request: hub0023models.GatewayMonitoringQueryRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*GatewayLogController).GetMonitoringOverview", openapi.HandlerSpec{Summary: "获取网关监控概览数据（关系数据库版本）", Description: "获取网关监控概览数据，包括总请求数、成功失败数、平均响应时间等", Request: hub0023models.GatewayMonitoringQueryRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*GatewayLogController).Query", openapi.HandlerSpec{Summary: "查询网关日志列表", Description: "支持分页查询和多条件过滤的网关日志列表，cursorPaging 为 true 时按游标分页（不统计总数，使用返回的 nextCursor 翻页）。为了提高查询性能，列表查询不返回大字段（如请求头、请求体、响应头、响应体、错误堆栈等），这些详细信息可通过详情接口获取。", Request: hub0023models.GatewayAccessLogQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*GatewayLogController).Reset", openapi.HandlerSpec{Summary: "重置网关日志（支持批量重置）", Description: "通过租户ID和链路追踪ID组合主键重置指定的网关日志记录", Request: hub0023models.GatewayAccessLogResetRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*LiveMetricsController).GetSnapshot", openapi.HandlerSpec{Summary: "获取一次实时指标快照，供不支持WebSocket的客户端使用", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*LiveMetricsController).Stream", openapi.HandlerSpec{Summary: "建立WebSocket连接并按间隔推送当前租户的实时指标快照", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*MongoQueryController).CountGatewayLogs", openapi.HandlerSpec{Summary: "统计网关日志数量（MongoDB版本）", Description: "根据查询条件统计MongoDB网关日志数量", Request: hub0023models.GatewayAccessLogQueryRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*MongoQueryController).GetGatewayLog", openapi.HandlerSpec{Summary: "获取网关日志详情（MongoDB版本）", Description: "通过租户ID和链路追踪ID组合主键获取MongoDB网关日志详情", Request: hub0023models.GatewayAccessLogGetRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*MongoQueryController).GetGatewayLogAccessDetail", openapi.HandlerSpec{Summary: "获取MongoDB网关日志主表详情（不含后端追踪）", Description: "通过租户ID与链路追踪ID获取主表完整字段，不附带 backendTraces", Request: hub0023models.GatewayAccessLogGetRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*MongoQueryController).GetGatewayMonitoringChartData", openapi.HandlerSpec{Summary: "获取网关监控图表数据", Description: "获取网关监控图表数据，包括请求趋势、响应时间趋势、状态码分布、热点路由等", Request: hub0023models.GatewayMonitoringQueryRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*MongoQueryController).GetGatewayMonitoringOverview", openapi.HandlerSpec{Summary: "获取网关监控概览数据", Description: "获取网关监控概览数据，包括总请求数、成功失败数、平均响应时间等", Request: hub0023models.GatewayMonitoringQueryRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*MongoQueryController).QueryGatewayLogs", openapi.HandlerSpec{Summary: "查询网关日志列表（MongoDB版本）", Description: "支持分页查询和多条件过滤的MongoDB网关日志列表，不返回大字段以提高查询性能", Request: hub0023models.GatewayAccessLogQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*SavedQueryController).AddSavedQuery", openapi.HandlerSpec{Summary: "保存查询条件", Request: hub0023models.SavedQuery{}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*SavedQueryController).DeleteSavedQuery", openapi.HandlerSpec{Summary: "删除保存的查询并移除定时报表，仅创建人可删除", Params: []string{"savedQueryId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*SavedQueryController).DownloadReport", openapi.HandlerSpec{Summary: "下载报表文件，只能下载当前用户可见的保存查询生成的报表", Params: []string{"reportId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*SavedQueryController).ExportSavedQueryReport", openapi.HandlerSpec{Summary: "立即执行保存的查询并生成报表"})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*SavedQueryController).GetSavedQuery", openapi.HandlerSpec{Summary: "获取保存的查询，前端使用 queryParams 回填查询条件"})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*SavedQueryController).QueryReports", openapi.HandlerSpec{Summary: "分页查询当前用户可见的报表记录（本人创建的和共享的保存查询生成的报表）", Request: hub0023models.QueryReportListRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*SavedQueryController).QuerySavedQueries", openapi.HandlerSpec{Summary: "分页查询当前用户可见的保存查询（本人创建的和共享的）", Request: hub0023models.SavedQueryListRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0023/controllers.(*SavedQueryController).UpdateSavedQuery", openapi.HandlerSpec{Summary: "更新保存的查询，仅创建人可修改", Request: hub0023models.SavedQuery{}})
+	openapi.RegisterHandler("gateway/web/views/hub0040/controllers.(*ServiceCenterInstanceController).AddServiceCenterInstance", openapi.HandlerSpec{Summary: "创建服务中心实例", Description: "创建新的服务中心实例", Request: hub0040models.ServiceCenterInstance{}})
+	openapi.RegisterHandler("gateway/web/views/hub0040/controllers.(*ServiceCenterInstanceController).DeleteServiceCenterInstance", openapi.HandlerSpec{Summary: "删除服务中心实例", Description: "删除服务中心实例，删除前会先停止实例", Params: []string{"environment", "instanceName"}})
+	openapi.RegisterHandler("gateway/web/views/hub0040/controllers.(*ServiceCenterInstanceController).EditServiceCenterInstance", openapi.HandlerSpec{Summary: "更新服务中心实例", Description: "更新服务中心实例信息", Request: hub0040models.ServiceCenterInstance{}})
+	openapi.RegisterHandler("gateway/web/views/hub0040/controllers.(*ServiceCenterInstanceController).GetServiceCenterInstance", openapi.HandlerSpec{Summary: "获取服务中心实例详情", Description: "根据主键获取服务中心实例详细信息（包含完整数据，用于编辑）", Params: []string{"environment", "instanceName"}})
+	openapi.RegisterHandler("gateway/web/views/hub0040/controllers.(*ServiceCenterInstanceController).QueryServiceCenterInstances", openapi.HandlerSpec{Summary: "获取服务中心实例列表", Description: "分页获取服务中心实例列表，支持条件查询", Request: hub0040models.ServiceCenterInstanceQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0040/controllers.(*ServiceCenterInstanceController).ReloadServiceCenterInstance", openapi.HandlerSpec{Summary: "重载服务中心实例配置", Description: "触发服务中心实例重新加载配置", Params: []string{"environment", "instanceName"}})
+	openapi.RegisterHandler("gateway/web/views/hub0040/controllers.(*ServiceCenterInstanceController).StartServiceCenterInstance", openapi.HandlerSpec{Summary: "启动服务中心实例", Description: "启动服务中心实例并更新状态为RUNNING", Params: []string{"environment", "instanceName"}})
+	openapi.RegisterHandler("gateway/web/views/hub0040/controllers.(*ServiceCenterInstanceController).StopServiceCenterInstance", openapi.HandlerSpec{Summary: "停止服务中心实例", Description: "停止服务中心实例并更新状态为STOPPED", Params: []string{"environment", "instanceName"}})
+	openapi.RegisterHandler("gateway/web/views/hub0041/controllers.(*NamespaceController).AddNamespace", openapi.HandlerSpec{Summary: "创建命名空间", Description: "创建新的命名空间", Request: hub0041models.Namespace{}})
+	openapi.RegisterHandler("gateway/web/views/hub0041/controllers.(*NamespaceController).DeleteNamespace", openapi.HandlerSpec{Summary: "删除命名空间", Params: []string{"namespaceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0041/controllers.(*NamespaceController).EditNamespace", openapi.HandlerSpec{Summary: "更新命名空间", Description: "更新命名空间信息", Request: hub0041models.Namespace{}})
+	openapi.RegisterHandler("gateway/web/views/hub0041/controllers.(*NamespaceController).ExportNamespace", openapi.HandlerSpec{Summary: "导出命名空间", Description: "将命名空间下的服务、分组和配置导出为带版本号的 JSON/YAML 文件，用于环境间提升", Params: []string{"format", "namespaceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0041/controllers.(*NamespaceController).GetDependencyGraph", openapi.HandlerSpec{Summary: "获取命名空间服务依赖拓扑", Description: "返回订阅方与被订阅服务之间的依赖关系，指定服务时同时返回直接或间接依赖该服务的订阅方（影响范围）", Params: []string{"groupName", "namespaceId", "serviceName"}})
+	openapi.RegisterHandler("gateway/web/views/hub0041/controllers.(*NamespaceController).GetNamespace", openapi.HandlerSpec{Summary: "获取命名空间详情", Description: "根据命名空间ID获取命名空间详细信息", Params: []string{"namespaceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0041/controllers.(*NamespaceController).GetNamespaceStats", openapi.HandlerSpec{Summary: "获取命名空间服务统计", Description: "按分组统计命名空间下的服务总数、节点健康状态、最近一小时注册/注销变动率和心跳延迟分布", Params: []string{"groupName", "namespaceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0041/controllers.(*NamespaceController).ImportNamespace", openapi.HandlerSpec{Summary: "导入命名空间", Description: "导入命名空间导出文件：与目标命名空间比对生成变更计划，dryRun 为 true（默认）时只返回计划，为 false 时新增和覆盖服务、配置（只存在于目标命名空间的数据保留不删除）", Params: []string{"dryRun", "targetNamespaceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0041/controllers.(*NamespaceController).QueryNamespaces", openapi.HandlerSpec{Summary: "获取命名空间列表", Description: "分页获取命名空间列表，支持条件查询", Request: hub0041models.NamespaceQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*HostIngestController).IngestBatch", openapi.HandlerSpec{Summary: "批量上报主机指标"})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmAlertController).AddAlertRule", openapi.HandlerSpec{Summary: "新增告警规则", Request: hub0042models.AlertRule{}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmAlertController).AddAlertSilence", openapi.HandlerSpec{Summary: "新增告警静默", Request: hub0042models.AlertSilenceRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmAlertController).DeleteAlertRule", openapi.HandlerSpec{Summary: "删除告警规则，告警中的事件置为已恢复，历史事件保留", Params: []string{"alertRuleId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmAlertController).DeleteAlertSilence", openapi.HandlerSpec{Summary: "删除告警静默，下一轮评估起恢复通知", Params: []string{"alertSilenceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmAlertController).EditAlertRule", openapi.HandlerSpec{Summary: "编辑告警规则", Request: hub0042models.AlertRule{}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmAlertController).GetAlertRule", openapi.HandlerSpec{Summary: "获取告警规则详情", Params: []string{"alertRuleId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmAlertController).QueryAlertEvents", openapi.HandlerSpec{Summary: "分页查询告警历史", Request: hub0042models.AlertEventListRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmAlertController).QueryAlertRules", openapi.HandlerSpec{Summary: "分页查询告警规则", Request: hub0042models.AlertRuleListRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmAlertController).QueryAlertSilences", openapi.HandlerSpec{Summary: "分页查询告警静默", Request: hub0042models.AlertSilenceListRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmDiagController).AddJvmDiagTask", openapi.HandlerSpec{Summary: "创建诊断采集任务", Request: hub0042models.JvmDiagTaskRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmDiagController).GetJvmDiagTask", openapi.HandlerSpec{Summary: "获取诊断任务详情", Params: []string{"diagTaskId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmDiagController).QueryJvmDiagTasks", openapi.HandlerSpec{Summary: "分页查询诊断任务，不返回结果原文", Request: hub0042models.JvmDiagTaskListRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmIngestController).IngestBatch", openapi.HandlerSpec{Summary: "批量上报JVM采集数据"})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmIngestController).SubmitDiagResult", openapi.HandlerSpec{Summary: "采集端提交诊断任务结果"})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmMetricController).CompareJvmMetric", openapi.HandlerSpec{Summary: "对比多个JVM实例的同一指标", Request: hub0042models.JvmMetricCompareRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*JvmMetricController).QueryJvmMetricTrend", openapi.HandlerSpec{Summary: "查询JVM实例的监控趋势", Request: hub0042models.JvmMetricTrendRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*MonitorOverviewController).QueryMonitorOverview", openapi.HandlerSpec{Summary: "查询监控概览"})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*ServiceController).AddService", openapi.HandlerSpec{Summary: "创建服务", Description: "创建新的服务", Request: servicecentertypes.Service{}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*ServiceController).AdjustNodeWeight", openapi.HandlerSpec{Summary: "手动调整节点权重", Description: "运行期覆盖节点的服务发现权重（0表示摘除节点流量），与节点注册的权重分开保存，调整立即推送给订阅者并记录操作审计", Request: hub0042models.NodeWeightRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*ServiceController).DeleteService", openapi.HandlerSpec{Summary: "删除服务", Params: []string{"groupName", "namespaceId", "serviceName"}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*ServiceController).EditNode", openapi.HandlerSpec{Summary: "编辑节点", Description: "更新服务节点信息（如IP、端口、权重、元数据等），直接操作缓存，不操作数据库", Request: servicecentertypes.ServiceNode{}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*ServiceController).EditService", openapi.HandlerSpec{Summary: "更新服务", Description: "更新服务信息", Request: servicecentertypes.Service{}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*ServiceController).GetNodeHealthHistory", openapi.HandlerSpec{Summary: "获取节点健康状态切换历史", Description: "返回注册中心运行期间记录的节点最近健康状态切换，以及是否因健康状态抖动处于隔离期", Params: []string{"nodeId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*ServiceController).GetService", openapi.HandlerSpec{Summary: "获取服务详情", Description: "根据服务主键获取服务详细信息，包括节点列表", Params: []string{"groupName", "namespaceId", "serviceName"}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*ServiceController).OfflineNode", openapi.HandlerSpec{Summary: "下线节点", Description: "将服务节点下线（设置状态为DOWN），直接操作缓存，不操作数据库", Params: []string{"nodeId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*ServiceController).QueryServices", openapi.HandlerSpec{Summary: "获取服务列表", Description: "分页获取服务列表，支持条件查询", Request: hub0042models.ServiceQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0042/controllers.(*ServiceController).ResetNodeWeight", openapi.HandlerSpec{Summary: "恢复节点注册权重", Description: "清除手动调整的节点权重，节点恢复使用注册时上报的权重，调整立即推送给订阅者并记录操作审计", Request: hub0042models.NodeWeightRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0043/controllers.(*ConfigController).AddConfig", openapi.HandlerSpec{Summary: "创建配置", Description: "创建新的配置", Request: servicecentertypes.ConfigData{}})
+	openapi.RegisterHandler("gateway/web/views/hub0043/controllers.(*ConfigController).CancelGray", openapi.HandlerSpec{Summary: "回滚灰度发布", Description: "清除灰度状态，并向命中灰度规则的监听实例重新推送正式配置", Request: hub0043models.GrayKeyRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0043/controllers.(*ConfigController).DeleteConfig", openapi.HandlerSpec{Summary: "删除配置", Params: []string{"configDataId", "groupName", "namespaceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0043/controllers.(*ConfigController).EditConfig", openapi.HandlerSpec{Summary: "更新配置", Description: "更新配置信息", Request: servicecentertypes.ConfigData{}, Params: []string{"changeReason"}})
+	openapi.RegisterHandler("gateway/web/views/hub0043/controllers.(*ConfigController).GetConfig", openapi.HandlerSpec{Summary: "获取配置详情", Description: "根据配置主键获取配置详细信息", Params: []string{"configDataId", "groupName", "namespaceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0043/controllers.(*ConfigController).GetGrayRelease", openapi.HandlerSpec{Summary: "获取配置灰度发布状态", Description: "获取灰度内容、灰度规则以及当前监听该配置的实例和灰度命中情况", Request: hub0043models.GrayKeyRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0043/controllers.(*ConfigController).PromoteGray", openapi.HandlerSpec{Summary: "灰度配置全量发布", Description: "灰度内容成为正式配置（版本号递增并记录配置历史），并推送给全部监听实例", Request: hub0043models.GrayKeyRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0043/controllers.(*ConfigController).PublishGray", openapi.HandlerSpec{Summary: "灰度发布配置", Description: "向命中灰度规则（客户端IP或标签）的监听实例推送新配置，正式配置保持不变", Request: hub0043models.GrayPublishRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0043/controllers.(*ConfigController).QueryConfigs", openapi.HandlerSpec{Summary: "获取配置列表", Description: "分页获取配置列表，支持条件查询", Request: hub0043models.ConfigQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0043/controllers.(*ConfigHistoryController).GetConfigHistory", openapi.HandlerSpec{Summary: "获取配置历史", Description: "获取配置的变更历史记录", Request: hub0043models.ConfigHistoryRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0043/controllers.(*ConfigHistoryController).GetHistoryById", openapi.HandlerSpec{Summary: "根据历史配置ID获取配置历史详情", Description: "根据历史配置ID获取完整的配置历史记录，包含变更前后的完整内容", Params: []string{"configHistoryId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0043/controllers.(*ConfigHistoryController).RollbackConfig", openapi.HandlerSpec{Summary: "回滚配置", Description: "根据历史配置ID将配置回滚到指定版本", Request: hub0043models.RollbackRequest{}})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).CreateTunnelServer", openapi.HandlerSpec{Summary: "创建隧道服务器", Request: tunneltypes.TunnelServer{}})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).DeleteTunnelServer", openapi.HandlerSpec{Summary: "删除隧道服务器", Params: []string{"tunnelServerId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).GenerateAuthToken", openapi.HandlerSpec{Summary: "生成新的认证令牌"})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).GetRegisteredClients", openapi.HandlerSpec{Summary: "获取指定服务器上已注册的客户端列表", Params: []string{"tunnelServerId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).GetRegisteredServices", openapi.HandlerSpec{Summary: "获取指定服务器上已注册的服务列表", Params: []string{"tunnelServerId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).GetServerStatusOptions", openapi.HandlerSpec{Summary: "获取服务器状态选项"})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).GetTunnelServer", openapi.HandlerSpec{Summary: "获取隧道服务器详情", Params: []string{"tunnelServerId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).GetTunnelServerList", openapi.HandlerSpec{Summary: "获取隧道服务器列表（用于下拉选择）"})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).GetTunnelServerStats", openapi.HandlerSpec{Summary: "获取隧道服务器统计信息"})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).QueryTunnelServers", openapi.HandlerSpec{Summary: "查询隧道服务器列表", Request: hub0060models.TunnelServerQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).ReloadTunnelServerConfig", openapi.HandlerSpec{Summary: "重新加载隧道服务器配置", Params: []string{"tunnelServerId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).RestartTunnelServer", openapi.HandlerSpec{Summary: "重启隧道服务器", Params: []string{"tunnelServerId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).StartTunnelServer", openapi.HandlerSpec{Summary: "启动隧道服务器", Params: []string{"tunnelServerId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).StopTunnelServer", openapi.HandlerSpec{Summary: "停止隧道服务器", Params: []string{"tunnelServerId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).UpdateTunnelServer", openapi.HandlerSpec{Summary: "更新隧道服务器", Request: tunneltypes.TunnelServer{}})
+	openapi.RegisterHandler("gateway/web/views/hub0060/controllers.(*TunnelServerController).UpdateTunnelServerStatus", openapi.HandlerSpec{Summary: "更新隧道服务器状态"})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticNodeController).CreateStaticNode", openapi.HandlerSpec{Summary: "创建静态节点", Description: "创建新的静态节点", Request: tunneltypes.TunnelStaticNode{}})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticNodeController).DeleteStaticNode", openapi.HandlerSpec{Summary: "删除静态节点"})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticNodeController).GetStaticNode", openapi.HandlerSpec{Summary: "获取静态节点详情", Description: "根据节点ID获取静态节点详细信息", Params: []string{"tunnelStaticNodeId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticNodeController).GetStaticNodeStats", openapi.HandlerSpec{Summary: "获取节点统计信息", Description: "获取静态节点统计信息", Params: []string{"tunnelStaticServerId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticNodeController).QueryStaticNodes", openapi.HandlerSpec{Summary: "查询静态节点列表", Description: "分页查询静态节点列表", Request: hub0061models.StaticNodeQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticNodeController).UpdateStaticNode", openapi.HandlerSpec{Summary: "更新静态节点", Description: "更新静态节点信息", Request: tunneltypes.TunnelStaticNode{}})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticServerController).CheckPortConflict", openapi.HandlerSpec{Summary: "检查端口冲突", Description: "检查监听端口是否冲突", Params: []string{"excludeId", "listenAddress", "listenPort", "serverType"}})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticServerController).CreateStaticServer", openapi.HandlerSpec{Summary: "创建静态服务器", Description: "创建新的静态服务器", Request: tunneltypes.TunnelStaticServer{}})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticServerController).DeleteStaticServer", openapi.HandlerSpec{Summary: "删除静态服务器"})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticServerController).GetStaticServer", openapi.HandlerSpec{Summary: "获取静态服务器详情", Description: "根据服务器ID获取静态服务器详细信息", Params: []string{"tunnelStaticServerId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticServerController).GetStaticServerStats", openapi.HandlerSpec{Summary: "获取服务器统计信息", Description: "获取静态服务器统计信息"})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticServerController).QueryStaticServers", openapi.HandlerSpec{Summary: "查询静态服务器列表", Description: "分页查询静态服务器列表", Request: hub0061models.StaticServerQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticServerController).ReloadStaticServer", openapi.HandlerSpec{Summary: "重载静态服务器配置", Description: "重载指定静态服务器的配置", Params: []string{"tunnelStaticServerId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticServerController).StartStaticServer", openapi.HandlerSpec{Summary: "启动静态服务器", Description: "启动指定的静态服务器", Params: []string{"tunnelStaticServerId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticServerController).StopStaticServer", openapi.HandlerSpec{Summary: "停止静态服务器", Description: "停止指定的静态服务器", Params: []string{"tunnelStaticServerId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0061/controllers.(*StaticServerController).UpdateStaticServer", openapi.HandlerSpec{Summary: "更新静态服务器", Description: "更新静态服务器信息", Request: tunneltypes.TunnelStaticServer{}})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelClientController).CreateTunnelClient", openapi.HandlerSpec{Summary: "创建客户端", Request: tunneltypes.TunnelClient{}})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelClientController).DeleteTunnelClient", openapi.HandlerSpec{Summary: "删除客户端", Params: []string{"tunnelClientId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelClientController).GetClientServices", openapi.HandlerSpec{Summary: "获取客户端注册的服务列表", Params: []string{"tunnelClientId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelClientController).GetClientStats", openapi.HandlerSpec{Summary: "获取客户端统计信息"})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelClientController).GetTunnelClient", openapi.HandlerSpec{Summary: "获取客户端详情", Params: []string{"tunnelClientId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelClientController).QueryTunnelClients", openapi.HandlerSpec{Summary: "查询客户端列表", Request: hub0062models.TunnelClientQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelClientController).RestartClient", openapi.HandlerSpec{Summary: "重启客户端（重新连接）", Params: []string{"tunnelClientId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelClientController).StartClient", openapi.HandlerSpec{Summary: "启动客户端（连接到服务器）", Params: []string{"tunnelClientId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelClientController).StopClient", openapi.HandlerSpec{Summary: "停止客户端（断开连接）", Params: []string{"tunnelClientId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelClientController).UpdateTunnelClient", openapi.HandlerSpec{Summary: "更新客户端", Request: tunneltypes.TunnelClient{}})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelServiceController).CreateTunnelService", openapi.HandlerSpec{Summary: "创建服务", Request: tunneltypes.TunnelService{}})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelServiceController).DeleteTunnelService", openapi.HandlerSpec{Summary: "删除服务", Params: []string{"tunnelServiceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelServiceController).GetServiceStats", openapi.HandlerSpec{Summary: "获取服务统计信息"})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelServiceController).GetTunnelService", openapi.HandlerSpec{Summary: "获取服务详情", Params: []string{"tunnelServiceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelServiceController).QueryTunnelServices", openapi.HandlerSpec{Summary: "查询服务列表", Request: hub0062models.TunnelServiceQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelServiceController).RegisterService", openapi.HandlerSpec{Summary: "注册服务到隧道管理器", Params: []string{"tunnelServiceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelServiceController).UnregisterService", openapi.HandlerSpec{Summary: "从隧道管理器注销服务", Params: []string{"tunnelServiceId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0062/controllers.(*TunnelServiceController).UpdateTunnelService", openapi.HandlerSpec{Summary: "更新服务", Request: tunneltypes.TunnelService{}})
+	openapi.RegisterHandler("gateway/web/views/hub0080/controllers.(*AlertConfigController).CreateAlertConfig", openapi.HandlerSpec{Summary: "创建告警渠道配置", Request: alerttypes.AlertConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hub0080/controllers.(*AlertConfigController).GetAlertConfig", openapi.HandlerSpec{Summary: "获取单个告警渠道配置", Params: []string{"channelName"}})
+	openapi.RegisterHandler("gateway/web/views/hub0080/controllers.(*AlertConfigController).QueryAlertConfigs", openapi.HandlerSpec{Summary: "分页查询告警渠道配置", Request: hub0080models.AlertConfigQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0080/controllers.(*AlertConfigController).ReloadAlertChannel", openapi.HandlerSpec{Summary: "重新加载告警渠道配置", Description: "重新从数据库读取指定渠道配置，并在告警管理器中重新注册/更新该渠道（用于配置变更后即时生效）", Params: []string{"channelName"}})
+	openapi.RegisterHandler("gateway/web/views/hub0080/controllers.(*AlertConfigController).SetDefaultChannel", openapi.HandlerSpec{Summary: "设置默认渠道", Params: []string{"channelName"}})
+	openapi.RegisterHandler("gateway/web/views/hub0080/controllers.(*AlertConfigController).TestAlertChannel", openapi.HandlerSpec{Summary: "测试告警渠道", Description: "向指定告警渠道发送一条测试告警消息，验证渠道配置是否正确和可用", Params: []string{"channelName", "content", "title"}})
+	openapi.RegisterHandler("gateway/web/views/hub0080/controllers.(*AlertConfigController).UpdateAlertConfig", openapi.HandlerSpec{Summary: "更新告警渠道配置", Request: alerttypes.AlertConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hub0081/controllers.(*AlertTemplateController).CreateAlertTemplate", openapi.HandlerSpec{Summary: "创建预警模板", Request: alerttypes.AlertTemplate{}})
+	openapi.RegisterHandler("gateway/web/views/hub0081/controllers.(*AlertTemplateController).DeleteAlertTemplate", openapi.HandlerSpec{Summary: "删除预警模板", Params: []string{"templateName"}})
+	openapi.RegisterHandler("gateway/web/views/hub0081/controllers.(*AlertTemplateController).GetAlertTemplate", openapi.HandlerSpec{Summary: "获取单个预警模板", Params: []string{"templateName"}})
+	openapi.RegisterHandler("gateway/web/views/hub0081/controllers.(*AlertTemplateController).QueryAlertTemplates", openapi.HandlerSpec{Summary: "分页查询预警模板", Request: hub0081models.AlertTemplateQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0081/controllers.(*AlertTemplateController).UpdateAlertTemplate", openapi.HandlerSpec{Summary: "更新预警模板", Request: alerttypes.AlertTemplate{}})
+	openapi.RegisterHandler("gateway/web/views/hub0082/controllers.(*AlertLogController).BatchDeleteAlertLogs", openapi.HandlerSpec{Summary: "批量删除预警日志"})
+	openapi.RegisterHandler("gateway/web/views/hub0082/controllers.(*AlertLogController).DeleteAlertLog", openapi.HandlerSpec{Summary: "删除预警日志", Params: []string{"alertLogId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0082/controllers.(*AlertLogController).GetAlertLog", openapi.HandlerSpec{Summary: "获取单个预警日志", Params: []string{"alertLogId"}})
+	openapi.RegisterHandler("gateway/web/views/hub0082/controllers.(*AlertLogController).GetAlertLogStatistics", openapi.HandlerSpec{Summary: "获取预警日志统计信息"})
+	openapi.RegisterHandler("gateway/web/views/hub0082/controllers.(*AlertLogController).QueryAlertLogs", openapi.HandlerSpec{Summary: "分页查询预警日志", Request: hub0082models.AlertLogQueryRequest{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hub0082/controllers.(*AlertLogController).UpdateAlertLog", openapi.HandlerSpec{Summary: "更新预警日志（主要用于更新发送状态和结果）", Request: alerttypes.AlertLog{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*ApiAccessConfigController).AddApiAccessConfig", openapi.HandlerSpec{Summary: "添加API访问控制配置", Request: hubcommon002models.ApiAccessConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*ApiAccessConfigController).DeleteApiAccessConfig", openapi.HandlerSpec{Summary: "删除API访问控制配置", Params: []string{"apiAccessConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*ApiAccessConfigController).GetApiAccessConfig", openapi.HandlerSpec{Summary: "获取API访问控制配置", Params: []string{"apiAccessConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*ApiAccessConfigController).QueryApiAccessConfigs", openapi.HandlerSpec{Summary: "获取API访问控制配置列表", Description: "分页获取API访问控制配置列表，支持条件查询，必须携带securityConfigId条件", Request: hubcommon002models.ApiAccessConfigQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*ApiAccessConfigController).UpdateApiAccessConfig", openapi.HandlerSpec{Summary: "更新API访问控制配置", Request: hubcommon002models.ApiAccessConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*AuthConfigController).AddAuthConfig", openapi.HandlerSpec{Summary: "添加认证配置", Request: hubcommon002models.AuthConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*AuthConfigController).DeleteAuthConfig", openapi.HandlerSpec{Summary: "删除认证配置", Params: []string{"authConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*AuthConfigController).GetAuthConfig", openapi.HandlerSpec{Summary: "获取认证配置详情（使用主键 authConfigId）", Params: []string{"authConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*AuthConfigController).QueryAuthConfigs", openapi.HandlerSpec{Summary: "获取认证配置", Description: "根据gatewayInstanceId或routeConfigId查询单个认证配置，不需要分页", Request: hubcommon002models.AuthConfigQuery{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*AuthConfigController).UpdateAuthConfig", openapi.HandlerSpec{Summary: "更新认证配置", Request: hubcommon002models.AuthConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*CorsConfigController).AddCorsConfig", openapi.HandlerSpec{Summary: "添加CORS配置", Request: hubcommon002models.CorsConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*CorsConfigController).DeleteCorsConfig", openapi.HandlerSpec{Summary: "删除CORS配置", Params: []string{"corsConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*CorsConfigController).GetCorsConfig", openapi.HandlerSpec{Summary: "获取CORS配置详情（使用主键 corsConfigId）", Params: []string{"corsConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*CorsConfigController).QueryCorsConfigs", openapi.HandlerSpec{Summary: "获取CORS配置", Description: "根据gatewayInstanceId或routeConfigId查询单个CORS配置，不需要分页", Request: hubcommon002models.CorsConfigQuery{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*CorsConfigController).UpdateCorsConfig", openapi.HandlerSpec{Summary: "更新CORS配置", Request: hubcommon002models.CorsConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*DomainAccessConfigController).AddDomainAccessConfig", openapi.HandlerSpec{Summary: "添加域名访问控制配置", Request: hubcommon002models.DomainAccessConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*DomainAccessConfigController).DeleteDomainAccessConfig", openapi.HandlerSpec{Summary: "删除域名访问控制配置", Params: []string{"domainAccessConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*DomainAccessConfigController).GetDomainAccessConfig", openapi.HandlerSpec{Summary: "获取域名访问控制配置", Params: []string{"domainAccessConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*DomainAccessConfigController).QueryDomainAccessConfigs", openapi.HandlerSpec{Summary: "获取域名访问控制配置列表", Description: "分页获取域名访问控制配置列表，支持条件查询，必须携带securityConfigId条件", Request: hubcommon002models.DomainAccessConfigQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*DomainAccessConfigController).UpdateDomainAccessConfig", openapi.HandlerSpec{Summary: "更新域名访问控制配置", Request: hubcommon002models.DomainAccessConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*IpAccessConfigController).AddIpAccessConfig", openapi.HandlerSpec{Summary: "添加IP访问控制配置", Request: hubcommon002models.IpAccessConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*IpAccessConfigController).DeleteIpAccessConfig", openapi.HandlerSpec{Summary: "删除IP访问控制配置", Params: []string{"ipAccessConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*IpAccessConfigController).GetIpAccessConfig", openapi.HandlerSpec{Summary: "获取IP访问控制配置", Params: []string{"ipAccessConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*IpAccessConfigController).QueryIpAccessConfigs", openapi.HandlerSpec{Summary: "获取IP访问控制配置列表", Description: "分页获取IP访问控制配置列表，支持条件查询，必须携带securityConfigId条件", Request: hubcommon002models.IpAccessConfigQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*IpAccessConfigController).UpdateIpAccessConfig", openapi.HandlerSpec{Summary: "更新IP访问控制配置", Request: hubcommon002models.IpAccessConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*RateLimitConfigController).AddRateLimitConfig", openapi.HandlerSpec{Summary: "添加限流配置", Request: hubcommon002models.RateLimitConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*RateLimitConfigController).DeleteRateLimitConfig", openapi.HandlerSpec{Summary: "删除限流配置", Params: []string{"rateLimitConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*RateLimitConfigController).GetRateLimitConfig", openapi.HandlerSpec{Summary: "获取限流配置详情（使用主键 rateLimitConfigId）", Params: []string{"rateLimitConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*RateLimitConfigController).QueryRateLimitConfigs", openapi.HandlerSpec{Summary: "获取限流配置", Description: "根据gatewayInstanceId或routeConfigId查询单个限流配置，不需要分页", Request: hubcommon002models.RateLimitConfigQuery{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*RateLimitConfigController).UpdateRateLimitConfig", openapi.HandlerSpec{Summary: "更新限流配置", Request: hubcommon002models.RateLimitConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*SecurityConfigController).AddSecurityConfig", openapi.HandlerSpec{Summary: "添加安全配置", Request: hubcommon002models.SecurityConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*SecurityConfigController).DeleteSecurityConfig", openapi.HandlerSpec{Summary: "删除安全配置", Params: []string{"securityConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*SecurityConfigController).EditSecurityConfig", openapi.HandlerSpec{Summary: "编辑安全配置", Request: hubcommon002models.SecurityConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*SecurityConfigController).GetSecurityConfig", openapi.HandlerSpec{Summary: "获取安全配置详情", Params: []string{"securityConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*SecurityConfigController).QuerySecurityConfigs", openapi.HandlerSpec{Summary: "获取安全配置列表", Description: "分页获取安全配置列表，支持条件查询", Request: hubcommon002models.SecurityConfigQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*SecurityConfigController).QuerySecurityConfigsByGatewayInstance", openapi.HandlerSpec{Summary: "根据网关实例查询安全配置列表", Params: []string{"gatewayInstanceId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*SecurityConfigController).QuerySecurityConfigsByRouteConfig", openapi.HandlerSpec{Summary: "根据路由配置查询安全配置列表", Params: []string{"routeConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*UseragentAccessConfigController).AddUseragentAccessConfig", openapi.HandlerSpec{Summary: "添加User-Agent访问控制配置", Request: hubcommon002models.UseragentAccessConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*UseragentAccessConfigController).DeleteUseragentAccessConfig", openapi.HandlerSpec{Summary: "删除User-Agent访问控制配置", Params: []string{"useragentAccessConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*UseragentAccessConfigController).GetUseragentAccessConfig", openapi.HandlerSpec{Summary: "获取User-Agent访问控制配置", Params: []string{"useragentAccessConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*UseragentAccessConfigController).QueryUseragentAccessConfigs", openapi.HandlerSpec{Summary: "获取User-Agent访问控制配置列表", Description: "分页获取User-Agent访问控制配置列表，支持条件查询，必须携带securityConfigId条件", Request: hubcommon002models.UseragentAccessConfigQuery{}, Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hubcommon002/controllers.(*UseragentAccessConfigController).UpdateUseragentAccessConfig", openapi.HandlerSpec{Summary: "更新User-Agent访问控制配置", Request: hubcommon002models.UseragentAccessConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubplugin/common/controllers.(*ConfigGroupController).AddConfigGroup", openapi.HandlerSpec{Summary: "添加工具配置分组", Description: "添加新的工具配置分组", Request: commonmodels.ToolConfigGroup{}})
+	openapi.RegisterHandler("gateway/web/views/hubplugin/common/controllers.(*ConfigGroupController).DeleteConfigGroup", openapi.HandlerSpec{Summary: "删除工具配置分组", Params: []string{"configGroupId"}})
+	openapi.RegisterHandler("gateway/web/views/hubplugin/common/controllers.(*ConfigGroupController).GetConfigGroup", openapi.HandlerSpec{Summary: "获取工具配置分组", Description: "根据ID获取工具配置分组详情"})
+	openapi.RegisterHandler("gateway/web/views/hubplugin/common/controllers.(*ConfigGroupController).QueryConfigGroups", openapi.HandlerSpec{Summary: "查询工具配置分组列表", Description: "根据条件查询工具配置分组列表", Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hubplugin/common/controllers.(*ConfigGroupController).UpdateConfigGroup", openapi.HandlerSpec{Summary: "更新工具配置分组", Description: "更新工具配置分组信息", Request: commonmodels.ToolConfigGroup{}})
+	openapi.RegisterHandler("gateway/web/views/hubplugin/common/controllers.(*ToolConfigController).AddToolConfig", openapi.HandlerSpec{Summary: "添加工具配置", Description: "添加新的工具配置", Request: commonmodels.ToolConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubplugin/common/controllers.(*ToolConfigController).DeleteToolConfig", openapi.HandlerSpec{Summary: "删除工具配置", Params: []string{"toolConfigId"}})
+	openapi.RegisterHandler("gateway/web/views/hubplugin/common/controllers.(*ToolConfigController).GetToolConfig", openapi.HandlerSpec{Summary: "获取工具配置", Description: "根据ID获取工具配置详情"})
+	openapi.RegisterHandler("gateway/web/views/hubplugin/common/controllers.(*ToolConfigController).QueryToolConfigs", openapi.HandlerSpec{Summary: "查询工具配置列表", Description: "根据条件查询工具配置列表", Paged: true})
+	openapi.RegisterHandler("gateway/web/views/hubplugin/common/controllers.(*ToolConfigController).UpdateToolConfig", openapi.HandlerSpec{Summary: "更新工具配置", Description: "更新工具配置信息", Request: commonmodels.ToolConfig{}})
+	openapi.RegisterHandler("gateway/web/views/hubplugin/common/controllers.(*ToolExecuteController).TestToolExecution", openapi.HandlerSpec{Summary: "测试工具执行", Description: "根据工具配置创建执行器并进行测试"})
+	openapi.RegisterHandler("gateway/web/views/hubplugin/http/controllers.(*HttpRequestController).Execute", openapi.HandlerSpec{Summary: "接收 method/url/headers/body，由后端发起 HTTP 请求并返回下游状态、头与正文。", Request: httpmodels.HttpExecuteRequest{}})
+}
@@ -26,7 +26,8 @@ func AuthRequired() gin.HandlerFunc {
 
 // PermissionRequired 验证用户权限的中间件组合
 // 返回认证和权限校验的中间件数组，第一个是认证，第二个是权限校验
-// 权限参数从请求中获取（header、query、form）
+// 权限编码由服务端根据路由推导：/gateway/{模块编码}/{接口名}，
+// 接口名以 add/edit/update/delete 等开头时还需拥有对应按钮权限（如 hub0002:delete）
 //
 // 返回:
 //
@@ -37,14 +38,12 @@ func AuthRequired() gin.HandlerFunc {
 //	// 基本使用
 //	router.GET("/users", PermissionRequired()..., handler)
 //
-//	// 前端需要在请求中传递权限参数：
-//	// Header: X-Permission-moduleCode: hub0002
-//	// Header: X-Permission-buttonCode: hub0002:user:create
-//	// 或 Query: ?moduleCode=hub0002&buttonCode=hub0002:user:create
+//	// 接口名无法推导出正确编码时显式注册
+//	permission.RegisterRoutePermission("POST", "/gateway/hub0020/startGatewayInstance", "hub0020:start")
 func PermissionRequired() []gin.HandlerFunc {
 	return []gin.HandlerFunc{
-		AuthRequired(), // 认证中间件
-		//middleware.PermissionRequired(), // 权限校验中间件
+		AuthRequired(),                  // 认证中间件
+		middleware.PermissionRequired(), // 权限校验中间件
 	}
 }
 
@@ -66,6 +65,12 @@ func ApplyGlobalMiddleware(router *gin.Engine) {
 	// 应用加密中间件 - 在响应返回时加密数据
 	router.Use(EncryptResponse())
 
+	// 应用操作审计中间件 - 记录请求中的数据变更，未启用审计时直接放行
+	router.Use(middleware.AuditTrail())
+
+	// 应用请求参数校验中间件 - 在解密之后按接口请求模型的 binding 标签校验参数，失败时统一返回400
+	router.Use(middleware.RequestValidation())
+
 	// 可以在这里添加其他全局中间件
 	// 例如：CORS、限流等
 }
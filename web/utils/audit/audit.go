@@ -0,0 +1,195 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"gateway/pkg/config"
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/pkg/utils/random"
+)
+
+// AuditTable 操作审计表
+const AuditTable = "HUB_SYS_AUDIT"
+
+// OperationMixed 一次请求包含多种数据变更类型
+const OperationMixed = "MIXED"
+
+// DefaultRetentionDays 审计记录默认保留天数
+const DefaultRetentionDays = 180
+
+// saveTimeout 写入审计记录的超时时间
+const saveTimeout = 10 * time.Second
+
+// AuditRecord 操作审计记录，对应数据库 HUB_SYS_AUDIT 表
+type AuditRecord struct {
+	// 主键和租户信息
+	TenantId string `json:"tenantId" db:"tenantId"` // 租户ID
+	AuditId  string `json:"auditId" db:"auditId"`   // 审计记录ID
+
+	// 操作人
+	UserId    string `json:"userId" db:"userId"`       // 操作用户ID
+	UserName  string `json:"userName" db:"userName"`   // 操作用户名
+	ClientIp  string `json:"clientIp" db:"clientIp"`   // 客户端IP
+	UserAgent string `json:"userAgent" db:"userAgent"` // 客户端UserAgent
+
+	// 操作入口
+	ModuleCode  string `json:"moduleCode" db:"moduleCode"`   // 模块编码(如hub0002)
+	ActionName  string `json:"actionName" db:"actionName"`   // 接口动作(如addUser)
+	HttpMethod  string `json:"httpMethod" db:"httpMethod"`   // HTTP方法
+	RequestPath string `json:"requestPath" db:"requestPath"` // 请求路径
+
+	// 数据变更
+	OperationType string `json:"operationType" db:"operationType"` // 操作类型(CREATE,UPDATE,DELETE,MIXED)
+	TableNames    string `json:"tableNames" db:"tableNames"`       // 变更的表，逗号分隔
+	ChangeCount   int    `json:"changeCount" db:"changeCount"`     // 变更记录数
+	ChangeData    string `json:"changeData" db:"changeData"`       // 变更前后数据及字段差异,JSON格式
+
+	// 操作结果
+	ResultFlag  string    `json:"resultFlag" db:"resultFlag"`   // 请求是否成功(N失败,Y成功)
+	HttpStatus  int       `json:"httpStatus" db:"httpStatus"`   // HTTP响应状态码
+	DurationMs  int64     `json:"durationMs" db:"durationMs"`   // 请求耗时(毫秒)
+	TraceId     string    `json:"traceId" db:"traceId"`         // 链路跟踪ID
+	OperateTime time.Time `json:"operateTime" db:"operateTime"` // 操作时间
+
+	// 通用字段
+	AddTime        time.Time `json:"addTime" db:"addTime"`               // 创建时间
+	AddWho         string    `json:"addWho" db:"addWho"`                 // 创建人ID
+	EditTime       time.Time `json:"editTime" db:"editTime"`             // 最后修改时间
+	EditWho        string    `json:"editWho" db:"editWho"`               // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" db:"oprSeqFlag"`         // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" db:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" db:"activeFlag"`         // 活动状态标记
+}
+
+// TableName 返回表名
+func (AuditRecord) TableName() string {
+	return AuditTable
+}
+
+// auditor 审计记录写入器
+type auditor struct {
+	db            database.Database
+	excludeTables []string
+}
+
+var globalAuditor *auditor
+
+// Init 初始化操作审计
+// 从 web.audit 读取配置，web.audit.enabled 为 false 时不记录；审计表本身始终不记录变更
+// 参数:
+//
+//	db: 写入审计记录的数据库实例，应为未经审计包装的实例
+func Init(db database.Database) {
+	if !config.GetBool("web.audit.enabled", true) {
+		globalAuditor = nil
+		return
+	}
+	excludeTables := config.GetStringSlice("web.audit.exclude_tables", []string{"HUB_LOGIN_LOG"})
+	globalAuditor = &auditor{
+		db:            db,
+		excludeTables: append(append([]string(nil), excludeTables...), AuditTable),
+	}
+}
+
+// Enabled 判断操作审计是否已启用
+func Enabled() bool {
+	return globalAuditor != nil
+}
+
+// NewRecorder 创建一次请求的数据变更收集器，未启用审计时返回nil
+func NewRecorder() *database.AuditRecorder {
+	if globalAuditor == nil {
+		return nil
+	}
+	return database.NewAuditRecorder(globalAuditor.excludeTables...)
+}
+
+// FillChanges 根据数据变更填充审计记录的操作类型、变更表、变更记录数和变更数据
+// 参数:
+//
+//	record: 审计记录
+//	changes: 一次请求中已提交的数据变更
+//
+// 返回:
+//
+//	error: 变更数据序列化失败时返回错误
+func FillChanges(record *AuditRecord, changes []database.AuditChange) error {
+	tables := make([]string, 0, len(changes))
+	seenTables := make(map[string]bool)
+	operations := make(map[string]bool)
+	count := 0
+	for _, change := range changes {
+		operations[change.Operation] = true
+		count += int(change.Affected)
+		if change.Table != "" && !seenTables[strings.ToUpper(change.Table)] {
+			seenTables[strings.ToUpper(change.Table)] = true
+			tables = append(tables, change.Table)
+		}
+	}
+	sort.Strings(tables)
+
+	record.OperationType = OperationMixed
+	if len(operations) == 1 {
+		for operation := range operations {
+			record.OperationType = operation
+		}
+	}
+	record.TableNames = strings.Join(tables, ",")
+	record.ChangeCount = count
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	record.ChangeData = string(data)
+	return nil
+}
+
+// Save 在后台写入审计记录，写入失败只记录日志，不影响请求
+// 参数:
+//
+//	ctx: 请求上下文，请求结束后仍会写入，保留链路信息但不继承取消
+//	record: 审计记录，审计ID和通用字段为空时自动填充
+func Save(ctx context.Context, record *AuditRecord) {
+	a := globalAuditor
+	if a == nil || record == nil {
+		return
+	}
+
+	now := time.Now()
+	if record.AuditId == "" {
+		record.AuditId = random.Generate32BitRandomString()
+	}
+	if record.OperateTime.IsZero() {
+		record.OperateTime = now
+	}
+	operator := record.UserId
+	if operator == "" {
+		operator = "system"
+	}
+	record.AddTime, record.EditTime = now, now
+	record.AddWho, record.EditWho = operator, operator
+	record.OprSeqFlag = record.AuditId
+	record.CurrentVersion = 1
+	record.ActiveFlag = "Y"
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.ErrorWithTrace(ctx, "写入操作审计记录异常", "error", r, "path", record.RequestPath)
+			}
+		}()
+
+		saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), saveTimeout)
+		defer cancel()
+		if _, err := a.db.Insert(saveCtx, AuditTable, record, true); err != nil {
+			logger.ErrorWithTrace(ctx, "写入操作审计记录失败", "error", err,
+				"path", record.RequestPath, "userId", record.UserId, "tables", record.TableNames)
+		}
+	}()
+}
@@ -42,6 +42,8 @@ const (
 	ED00113 = "ED00113" // 短信发送失败
 	ED00114 = "ED00114" // Session不存在或已过期
 	ED00115 = "ED00115" // Session已过期
+	ED00116 = "ED00116" // 已达到最大同时登录会话数
+	ED00117 = "ED00117" // 刷新令牌无效或已过期
 )
 
 // 通用成功代码
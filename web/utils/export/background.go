@@ -0,0 +1,69 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"gateway/pkg/database"
+	"gateway/web/globalmodels"
+	"gateway/web/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewBackgroundContext 创建脱离HTTP请求的Gin上下文，用于定时任务等后台场景复用查询处理函数
+// 请求参数以JSON请求体传入，用户上下文决定查询的租户和数据权限
+//
+// 参数:
+//   - ctx: 上下文对象，查询处理函数通过 c.Request.Context() 获取
+//   - userContext: 执行查询的用户上下文
+//   - params: 查询参数
+//
+// 返回:
+//   - *gin.Context: Gin上下文，响应内容不会输出到任何客户端
+//   - error: 参数序列化失败时返回错误
+func NewBackgroundContext(ctx context.Context, userContext *globalmodels.UserContext, params map[string]interface{}) (*gin.Context, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("序列化查询参数失败: %w", err)
+	}
+	if userContext != nil {
+		ctx = database.WithTenant(ctx, userContext.TenantId)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	c, _ := gin.CreateTestContext(&discardWriter{header: make(http.Header)})
+	c.Request = req
+	if userContext != nil {
+		c.Set(middleware.UserContextKey, userContext)
+	}
+	return c, nil
+}
+
+// discardWriter 丢弃写入内容的响应写入器
+type discardWriter struct {
+	header http.Header
+}
+
+// Header 响应头
+func (w *discardWriter) Header() http.Header {
+	return w.header
+}
+
+// Write 丢弃响应内容
+func (w *discardWriter) Write(data []byte) (int, error) {
+	return len(data), nil
+}
+
+// WriteHeader 丢弃状态码
+func (w *discardWriter) WriteHeader(int) {}
@@ -0,0 +1,529 @@
+// Package export 列表查询接口的通用导出能力
+// 复用已有分页查询处理函数：使用与查询相同的过滤参数，逐页调用查询处理函数并截获其标准分页响应，
+// 按 format 参数生成 CSV 或 Excel 文件下载，支持选择导出列，导出行数受服务端上限约束。
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gateway/pkg/config"
+	"gateway/pkg/excel"
+	"gateway/pkg/logger"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 导出格式
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// DefaultMaxRows 默认导出行数上限，可通过 web.export.max_rows 配置
+const DefaultMaxRows = 10000
+
+// Options 导出选项，从请求参数解析
+type Options struct {
+	// Format 导出格式 csv 或 xlsx
+	Format string
+	// Columns 导出列（查询结果中的字段名），为空时导出全部字段
+	Columns []string
+	// Titles 列标题，与 Columns 一一对应，缺省时使用字段名
+	Titles []string
+	// MaxRows 最大导出行数
+	MaxRows int
+}
+
+// ParseOptions 解析导出参数
+// 支持的参数：
+// - format: csv 或 xlsx，默认 xlsx
+// - columns: 导出列，逗号分隔或JSON数组
+// - columnTitles: 列标题，逗号分隔或JSON数组，与 columns 顺序一致
+// - maxRows: 最大导出行数，不能超过服务端上限
+//
+// 参数:
+//   - c: Gin上下文
+//
+// 返回:
+//   - Options: 导出选项
+//   - error: 格式不支持时返回错误
+func ParseOptions(c *gin.Context) (Options, error) {
+	maxRows, _ := strconv.Atoi(request.GetParam(c, "maxRows"))
+	return NewOptions(request.GetParam(c, "format", FormatXLSX), request.GetParam(c, "columns"),
+		request.GetParam(c, "columnTitles"), maxRows)
+}
+
+// NewOptions 根据参数值创建导出选项
+//
+// 参数:
+//   - format: csv 或 xlsx，为空时默认 xlsx
+//   - columns: 导出列，逗号分隔或JSON数组
+//   - titles: 列标题，逗号分隔或JSON数组
+//   - maxRows: 最大导出行数，小于等于0或超过服务端上限时使用服务端上限
+//
+// 返回:
+//   - Options: 导出选项
+//   - error: 格式不支持时返回错误
+func NewOptions(format, columns, titles string, maxRows int) (Options, error) {
+	opts := Options{
+		Format:  strings.ToLower(strings.TrimSpace(format)),
+		Columns: parseList(columns),
+		Titles:  parseList(titles),
+		MaxRows: maxRowsLimit(),
+	}
+	if opts.Format == "" {
+		opts.Format = FormatXLSX
+	}
+	if opts.Format != FormatCSV && opts.Format != FormatXLSX {
+		return opts, fmt.Errorf("不支持的导出格式: %s", opts.Format)
+	}
+	if maxRows > 0 && maxRows < opts.MaxRows {
+		opts.MaxRows = maxRows
+	}
+	return opts, nil
+}
+
+// maxRowsLimit 服务端导出行数上限
+func maxRowsLimit() int {
+	limit := config.GetInt("web.export.max_rows", DefaultMaxRows)
+	if limit <= 0 {
+		limit = DefaultMaxRows
+	}
+	return limit
+}
+
+// parseList 解析逗号分隔或JSON数组形式的列表参数
+func parseList(value string) []string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	var list []string
+	if strings.HasPrefix(value, "[") && json.Unmarshal([]byte(value), &list) == nil {
+		return list
+	}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// Handler 将分页查询处理函数包装为导出处理函数
+// 查询处理函数需通过 request.GetPaginationParams 读取分页参数并使用 response.PageJSON 返回结果
+//
+// 参数:
+//   - name: 导出文件名前缀，如 GatewayAccessLog
+//   - query: 分页查询处理函数
+//
+// 返回:
+//   - gin.HandlerFunc: 导出处理函数
+func Handler(name string, query gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		opts, err := ParseOptions(c)
+		if err != nil {
+			response.ErrorJSON(c, err.Error(), constants.ED00006)
+			return
+		}
+
+		records, err := Collect(c, query, opts.MaxRows)
+		if err != nil {
+			logger.ErrorWithTrace(c, "导出数据查询失败", "name", name, "error", err)
+			response.ErrorJSON(c, "导出失败: "+err.Error(), constants.ED00009)
+			return
+		}
+
+		if err := Write(c, name, opts, records); err != nil {
+			logger.ErrorWithTrace(c, "生成导出文件失败", "name", name, "error", err)
+			response.ErrorJSON(c, "生成导出文件失败: "+err.Error(), constants.ED00009)
+		}
+	}
+}
+
+// Record 导出记录，Keys 保持查询结果中字段的原始顺序
+type Record struct {
+	Keys   []string
+	Values map[string]interface{}
+}
+
+// Collect 逐页调用查询处理函数，收集不超过 maxRows 条记录
+// 查询处理函数返回单个对象（如监控概览）时作为一条记录返回
+//
+// 参数:
+//   - c: Gin上下文，请求参数即查询过滤条件
+//   - query: 查询处理函数
+//   - maxRows: 最大记录数
+//
+// 返回:
+//   - []Record: 查询结果记录
+//   - error: 查询失败时返回错误
+func Collect(c *gin.Context, query gin.HandlerFunc, maxRows int) ([]Record, error) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取请求参数失败: %w", err)
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	pageSize := constants.MaxPageSize
+	records := make([]Record, 0)
+	for page := 1; len(records) < maxRows; page++ {
+		// 每页使用独立的上下文副本，gin会缓存已解析的Query参数，复用原上下文时改写分页参数不生效
+		pageCtx := c.Copy()
+		capture := &captureWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		pageCtx.Writer = capture
+		pageCtx.Request = c.Request.Clone(c.Request.Context())
+		setPageParams(pageCtx, body, page, pageSize)
+
+		query(pageCtx)
+		if capture.body.Len() == 0 {
+			return nil, errors.New("查询被中止")
+		}
+
+		pageRecords, total, err := parsePageResponse(capture.body.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range pageRecords {
+			if len(records) >= maxRows {
+				break
+			}
+			records = append(records, r)
+		}
+		if len(pageRecords) < pageSize || (total > 0 && page*pageSize >= total) {
+			break
+		}
+	}
+	return records, nil
+}
+
+// setPageParams 设置本次调用的分页参数
+// Query参数优先级最高，同时改写JSON请求体中的分页字段，兼容直接绑定请求体的处理函数
+func setPageParams(c *gin.Context, body []byte, page, pageSize int) {
+	values := c.Request.URL.Query()
+	values.Set("pageIndex", strconv.Itoa(page))
+	values.Set("pageSize", strconv.Itoa(pageSize))
+	c.Request.URL.RawQuery = values.Encode()
+
+	if strings.Contains(c.GetHeader("Content-Type"), "application/json") && len(bytes.TrimSpace(body)) > 0 {
+		var params map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if decoder.Decode(&params) == nil && params != nil {
+			params["pageIndex"] = page
+			params["pageSize"] = pageSize
+			if data, err := json.Marshal(params); err == nil {
+				body = data
+			}
+		}
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	c.Request.ContentLength = int64(len(body))
+	// 清除 request.GetParam 缓存的JSON参数和gin的表单缓存
+	delete(c.Keys, "_json_params")
+	c.Request.PostForm = nil
+	c.Request.Form = nil
+}
+
+// parsePageResponse 解析标准分页响应，返回记录和总数
+func parsePageResponse(data []byte) ([]Record, int, error) {
+	var result response.JsonData
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, 0, fmt.Errorf("解析查询结果失败: %w", err)
+	}
+	if !result.OK {
+		if result.ErrMsg != "" {
+			return nil, 0, errors.New(result.ErrMsg)
+		}
+		return nil, 0, errors.New("查询失败")
+	}
+
+	total := 0
+	if result.PageQueryData != "" {
+		var pageInfo response.PageInfo
+		if err := json.Unmarshal([]byte(result.PageQueryData), &pageInfo); err == nil {
+			total = pageInfo.TotalCount
+		}
+	}
+
+	bizData := strings.TrimSpace(result.BizData)
+	if bizData == "" {
+		return nil, total, nil
+	}
+	if strings.HasPrefix(bizData, "{") {
+		record, err := parseRecord(json.RawMessage(bizData))
+		if err != nil {
+			return nil, 0, err
+		}
+		return []Record{record}, 1, nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(result.BizData), &items); err != nil {
+		return nil, 0, fmt.Errorf("查询结果不是列表: %w", err)
+	}
+
+	records := make([]Record, 0, len(items))
+	for _, item := range items {
+		record, err := parseRecord(item)
+		if err != nil {
+			return nil, 0, err
+		}
+		records = append(records, record)
+	}
+	return records, total, nil
+}
+
+// parseRecord 解析单条记录，保留字段顺序
+func parseRecord(data json.RawMessage) (Record, error) {
+	record := Record{Values: make(map[string]interface{})}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if tok, err := decoder.Token(); err != nil || tok != json.Delim('{') {
+		return record, errors.New("查询结果记录不是对象")
+	}
+	for decoder.More() {
+		tok, err := decoder.Token()
+		if err != nil {
+			return record, fmt.Errorf("解析查询结果失败: %w", err)
+		}
+		key, _ := tok.(string)
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return record, fmt.Errorf("解析查询结果失败: %w", err)
+		}
+		record.Keys = append(record.Keys, key)
+		record.Values[key] = value
+	}
+	return record, nil
+}
+
+// Write 将记录写为CSV或Excel文件响应
+//
+// 参数:
+//   - c: Gin上下文
+//   - name: 文件名前缀
+//   - opts: 导出选项
+//   - records: 导出记录
+//
+// 返回:
+//   - error: 生成文件失败时返回错误，此时尚未写入响应
+func Write(c *gin.Context, name string, opts Options, records []Record) error {
+	filename := FileName(name, opts.Format)
+
+	if opts.Format == FormatCSV {
+		setAttachmentHeaders(c, filename, ContentType(FormatCSV))
+		c.Writer.WriteHeader(http.StatusOK)
+		return writeCSV(c.Writer, opts, records)
+	}
+
+	tmpPath := filepath.Join(os.TempDir(), fmt.Sprintf("export_%d_%s", time.Now().UnixNano(), filename))
+	defer os.Remove(tmpPath)
+	size, err := SaveFile(tmpPath, name, opts, records)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	setAttachmentHeaders(c, filename, ContentType(FormatXLSX))
+	c.Writer.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	c.Writer.WriteHeader(http.StatusOK)
+	io.Copy(c.Writer, file) //nolint:errcheck
+	return nil
+}
+
+// SaveFile 将记录保存为CSV或Excel文件，用于定时报表等非下载场景
+//
+// 参数:
+//   - path: 文件路径，所在目录需已存在
+//   - name: 工作表名称
+//   - opts: 导出选项
+//   - records: 导出记录
+//
+// 返回:
+//   - int64: 文件大小（字节）
+//   - error: 生成文件失败时返回错误
+func SaveFile(path, name string, opts Options, records []Record) (int64, error) {
+	if opts.Format == FormatCSV {
+		file, err := os.Create(path)
+		if err != nil {
+			return 0, err
+		}
+		if err := writeCSV(file, opts, records); err != nil {
+			file.Close()
+			return 0, err
+		}
+		if err := file.Close(); err != nil {
+			return 0, err
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return 0, err
+		}
+		return info.Size(), nil
+	}
+
+	columns, titles := resolveColumns(opts, records)
+	rows := make([][]any, 0, len(records))
+	for _, r := range records {
+		row := make([]any, len(columns))
+		for i, col := range columns {
+			row[i] = formatValue(r.Values[col])
+		}
+		rows = append(rows, row)
+	}
+	result, err := excel.Build(path, excel.Sheet{Name: name, Headers: titles, Rows: rows})
+	if err != nil {
+		return 0, err
+	}
+	return result.Size, nil
+}
+
+// FileName 生成带时间戳的导出文件名，如 GatewayAccessLog_20240101120000.xlsx
+func FileName(name, format string) string {
+	return fmt.Sprintf("%s_%s.%s", name, time.Now().Format("20060102150405"), format)
+}
+
+// ContentType 导出格式对应的MIME类型
+func ContentType(format string) string {
+	if format == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+// writeCSV 写入CSV内容
+func writeCSV(out io.Writer, opts Options, records []Record) error {
+	columns, titles := resolveColumns(opts, records)
+	// 写入UTF-8 BOM，Excel打开时正确识别中文
+	if _, err := out.Write([]byte("\xEF\xBB\xBF")); err != nil {
+		return err
+	}
+	w := csv.NewWriter(out)
+	w.Write(titles) //nolint:errcheck
+	for _, r := range records {
+		row := make([]string, len(columns))
+		for i, col := range columns {
+			row[i] = formatValue(r.Values[col])
+		}
+		w.Write(row) //nolint:errcheck
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// resolveColumns 确定导出列和列标题
+// 未指定列时使用第一条记录的字段顺序，指定列时按指定顺序导出，记录中不存在的字段导出为空
+func resolveColumns(opts Options, records []Record) ([]string, []string) {
+	columns := opts.Columns
+	if len(columns) == 0 && len(records) > 0 {
+		columns = records[0].Keys
+	}
+	titles := make([]string, len(columns))
+	for i, col := range columns {
+		titles[i] = col
+		if i < len(opts.Titles) && opts.Titles[i] != "" {
+			titles[i] = opts.Titles[i]
+		}
+	}
+	return columns, titles
+}
+
+// formatValue 将字段值格式化为单元格文本，对象和数组输出为JSON
+// 文本以公式起始字符开头时加单引号前缀，防止表格软件打开导出文件时将其作为公式执行
+func formatValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return escapeFormula(val)
+	case json.Number:
+		return val.String()
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(data)
+	}
+}
+
+// escapeFormula 以 = + - @ 制表符或回车开头的文本加单引号前缀，避免CSV/公式注入
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// setAttachmentHeaders 设置文件下载响应头
+func setAttachmentHeaders(c *gin.Context, filename, contentType string) {
+	c.Writer.Header().Set("Content-Type", contentType)
+	c.Writer.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`, filename, url.PathEscape(filename)))
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+}
+
+// captureWriter 截获查询处理函数的响应内容，不写入客户端
+type captureWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+	size   int
+}
+
+// WriteHeader 记录状态码
+func (w *captureWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+// WriteHeaderNow 不向客户端写入响应头
+func (w *captureWriter) WriteHeaderNow() {}
+
+// Write 写入缓冲区
+func (w *captureWriter) Write(data []byte) (int, error) {
+	n, err := w.body.Write(data)
+	w.size += n
+	return n, err
+}
+
+// WriteString 写入缓冲区
+func (w *captureWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Status 截获的状态码
+func (w *captureWriter) Status() int {
+	return w.status
+}
+
+// Size 截获的字节数
+func (w *captureWriter) Size() int {
+	return w.size
+}
+
+// Written 是否已写入内容
+func (w *captureWriter) Written() bool {
+	return w.size > 0
+}
@@ -0,0 +1,385 @@
+// Package i18n 接口错误消息和枚举显示名的多语言支持
+//
+// 接口错误消息原文为中文，请求语言为其他语言时按消息目录翻译：
+// 错误文本完全匹配或前缀匹配 texts 时替换为译文，否则使用 messageId 对应的通用消息，原文放在 extMsg 中。
+// 枚举显示名（健康等级、告警级别等）按 enums 提供，前端通过 hub0000 的 queryEnumLabels 获取。
+//
+// 内置 zh-CN、en-US 两个消息目录，可通过 web.i18n.catalog_dir 目录下的 <语言>.yaml 覆盖或新增
+package i18n
+
+import (
+	"embed"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gateway/pkg/config"
+	"gateway/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+)
+
+// 语言代码
+const (
+	LanguageZhCN = "zh-CN" // 简体中文，接口错误消息的原文语言
+	LanguageEnUS = "en-US" // 英文
+)
+
+// languageContextKey 请求语言在Gin上下文中的键
+const languageContextKey = "i18n_language"
+
+//go:embed catalogs/*.yaml
+var builtinCatalogs embed.FS
+
+// Catalog 单个语言的消息目录
+type Catalog struct {
+	Messages map[string]string            `yaml:"messages"` // 消息代码对应的通用消息，如 ED00006
+	Texts    map[string]string            `yaml:"texts"`    // 中文错误文本对应的译文，完全匹配或前缀匹配
+	Enums    map[string]map[string]string `yaml:"enums"`    // 枚举类型 -> 枚举值 -> 显示名
+}
+
+var (
+	loadOnce sync.Once
+	mu       sync.RWMutex
+	catalogs map[string]*Catalog
+	// defaultLanguage 请求未指定语言或语言不支持时使用的语言
+	defaultLanguage = LanguageZhCN
+)
+
+// ensureLoaded 首次使用时加载内置消息目录和配置目录下的消息目录
+func ensureLoaded() {
+	loadOnce.Do(func() {
+		if err := Reload(); err != nil {
+			logger.Warn("加载多语言消息目录失败，仅使用内置消息目录", "error", err)
+		}
+	})
+}
+
+// Reload 重新加载消息目录
+// 先加载内置目录，再按 web.i18n.catalog_dir 下的 <语言>.yaml 合并，同名条目以配置目录为准
+//
+// 返回:
+//
+//	error: 配置目录下的文件读取或解析失败时返回错误，已成功加载的目录仍然生效
+func Reload() error {
+	loaded := make(map[string]*Catalog)
+	entries, err := builtinCatalogs.ReadDir("catalogs")
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		data, err := builtinCatalogs.ReadFile("catalogs/" + entry.Name())
+		if err != nil {
+			return err
+		}
+		if err := mergeCatalog(loaded, strings.TrimSuffix(entry.Name(), ".yaml"), data); err != nil {
+			return err
+		}
+	}
+
+	var loadErr error
+	if dir := config.GetString("web.i18n.catalog_dir", ""); dir != "" {
+		files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+		if err != nil {
+			loadErr = err
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err == nil {
+				err = mergeCatalog(loaded, strings.TrimSuffix(filepath.Base(file), ".yaml"), data)
+			}
+			if err != nil {
+				logger.Warn("加载多语言消息目录文件失败", "file", file, "error", err)
+				loadErr = err
+			}
+		}
+	}
+
+	mu.Lock()
+	catalogs = loaded
+	defaultLanguage = normalizeLanguage(config.GetString("web.i18n.default_language", LanguageZhCN))
+	if defaultLanguage == "" || loaded[defaultLanguage] == nil {
+		defaultLanguage = LanguageZhCN
+	}
+	mu.Unlock()
+	return loadErr
+}
+
+// mergeCatalog 解析消息目录并合并到已加载的同语言目录
+func mergeCatalog(loaded map[string]*Catalog, language string, data []byte) error {
+	var catalog Catalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return err
+	}
+	language = normalizeLanguage(language)
+	target := loaded[language]
+	if target == nil {
+		target = &Catalog{Messages: map[string]string{}, Texts: map[string]string{}, Enums: map[string]map[string]string{}}
+		loaded[language] = target
+	}
+	for key, value := range catalog.Messages {
+		target.Messages[key] = value
+	}
+	for key, value := range catalog.Texts {
+		target.Texts[key] = value
+	}
+	for enumType, labels := range catalog.Enums {
+		if target.Enums[enumType] == nil {
+			target.Enums[enumType] = map[string]string{}
+		}
+		for code, label := range labels {
+			target.Enums[enumType][code] = label
+		}
+	}
+	return nil
+}
+
+// catalogOf 获取语言的消息目录
+func catalogOf(language string) *Catalog {
+	ensureLoaded()
+	mu.RLock()
+	defer mu.RUnlock()
+	return catalogs[language]
+}
+
+// DefaultLanguage 默认语言，由 web.i18n.default_language 配置
+func DefaultLanguage() string {
+	ensureLoaded()
+	mu.RLock()
+	defer mu.RUnlock()
+	return defaultLanguage
+}
+
+// Supported 已加载消息目录的语言列表
+func Supported() []string {
+	ensureLoaded()
+	mu.RLock()
+	defer mu.RUnlock()
+	languages := make([]string, 0, len(catalogs))
+	for language := range catalogs {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// normalizeLanguage 规范语言代码大小写，如 en-us 转为 en-US
+func normalizeLanguage(language string) string {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(language), "_", "-"), "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.ToUpper(parts[i])
+	}
+	return strings.Join(parts, "-")
+}
+
+// ResolveLanguage 按 Accept-Language 请求头选择语言
+// 按权重从高到低选择第一个支持的语言，只有主语言匹配时（如 en、en-GB）选择该主语言的第一个支持的语言
+// 参数:
+//
+//	acceptLanguage: Accept-Language 请求头，如 "en-US,en;q=0.9,zh-CN;q=0.8"
+//
+// 返回:
+//
+//	string: 支持的语言代码，无匹配时返回默认语言
+func ResolveLanguage(acceptLanguage string) string {
+	type candidate struct {
+		language string
+		weight   float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		if fields[0] == "" || fields[0] == "*" {
+			continue
+		}
+		weight := 1.0
+		for _, field := range fields[1:] {
+			if q, ok := strings.CutPrefix(strings.TrimSpace(field), "q="); ok {
+				if value, err := strconv.ParseFloat(q, 64); err == nil {
+					weight = value
+				}
+			}
+		}
+		if weight > 0 {
+			candidates = append(candidates, candidate{normalizeLanguage(fields[0]), weight})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].weight > candidates[j].weight })
+
+	supported := Supported()
+	for _, c := range candidates {
+		for _, language := range supported {
+			if language == c.language {
+				return language
+			}
+		}
+		primary := strings.Split(c.language, "-")[0]
+		for _, language := range supported {
+			if strings.Split(language, "-")[0] == primary {
+				return language
+			}
+		}
+	}
+	return DefaultLanguage()
+}
+
+// Language 获取请求的语言，结果缓存在Gin上下文中
+// 参数:
+//
+//	c: Gin上下文，为nil时返回默认语言
+//
+// 返回:
+//
+//	string: 语言代码
+func Language(c *gin.Context) string {
+	if c == nil || c.Request == nil {
+		return DefaultLanguage()
+	}
+	if language := c.GetString(languageContextKey); language != "" {
+		return language
+	}
+	language := ResolveLanguage(c.GetHeader("Accept-Language"))
+	c.Set(languageContextKey, language)
+	return language
+}
+
+// Message 获取消息代码或消息键对应的消息
+// 参数:
+//
+//	language: 语言代码
+//	key: 消息代码（如 ED00006）或消息键（如 validation.required）
+//
+// 返回:
+//
+//	string: 消息，未配置时返回空字符串
+func Message(language, key string) string {
+	if catalog := catalogOf(language); catalog != nil {
+		return catalog.Messages[key]
+	}
+	return ""
+}
+
+// TranslateText 翻译中文错误文本
+// 先完全匹配，再匹配最长的前缀，前缀之后的内容（如 "参数错误: " 后的具体原因）原样保留
+// 参数:
+//
+//	language: 目标语言
+//	text: 中文原文
+//
+// 返回:
+//
+//	string: 译文，目标语言为中文时返回原文
+//	bool: 是否翻译成功
+func TranslateText(language, text string) (string, bool) {
+	if language == LanguageZhCN {
+		return text, true
+	}
+	catalog := catalogOf(language)
+	if catalog == nil || text == "" {
+		return text, false
+	}
+	if translated, ok := catalog.Texts[text]; ok {
+		return translated, true
+	}
+
+	prefix := ""
+	for source := range catalog.Texts {
+		if len(source) > len(prefix) && strings.HasPrefix(text, source) {
+			prefix = source
+		}
+	}
+	if prefix == "" {
+		return text, false
+	}
+	return catalog.Texts[prefix] + text[len(prefix):], true
+}
+
+// TranslateError 翻译接口错误消息
+// 参数:
+//
+//	language: 目标语言
+//	errMsg: 中文错误消息
+//	messageId: 消息代码
+//
+// 返回:
+//
+//	string: 翻译后的错误消息；错误文本无译文时为消息代码对应的通用消息
+//	string: 未能翻译时保留的原文，用于排查问题；已翻译或无需翻译时为空
+func TranslateError(language, errMsg, messageId string) (string, string) {
+	if translated, ok := TranslateText(language, errMsg); ok {
+		return translated, ""
+	}
+	if generic := Message(language, messageId); generic != "" {
+		return generic, errMsg
+	}
+	return errMsg, ""
+}
+
+// Label 获取枚举值的显示名
+// 参数:
+//
+//	language: 语言代码
+//	enumType: 枚举类型，如 healthGrade、alertLevel
+//	code: 枚举值
+//
+// 返回:
+//
+//	string: 显示名，未配置时返回枚举值本身
+func Label(language, enumType, code string) string {
+	if catalog := catalogOf(language); catalog != nil {
+		if label, ok := catalog.Enums[enumType][code]; ok {
+			return label
+		}
+	}
+	return code
+}
+
+// Labels 获取枚举类型的全部显示名
+// 参数:
+//
+//	language: 语言代码
+//	enumTypes: 枚举类型，为空时返回全部枚举类型
+//
+// 返回:
+//
+//	map[string]map[string]string: 枚举类型 -> 枚举值 -> 显示名，目标语言未配置的枚举值回退默认语言
+func Labels(language string, enumTypes ...string) map[string]map[string]string {
+	catalog := catalogOf(language)
+	fallback := catalogOf(DefaultLanguage())
+	if len(enumTypes) == 0 {
+		seen := make(map[string]bool)
+		for _, c := range []*Catalog{catalog, fallback} {
+			if c == nil {
+				continue
+			}
+			for enumType := range c.Enums {
+				if !seen[enumType] {
+					seen[enumType] = true
+					enumTypes = append(enumTypes, enumType)
+				}
+			}
+		}
+	}
+
+	result := make(map[string]map[string]string, len(enumTypes))
+	for _, enumType := range enumTypes {
+		labels := make(map[string]string)
+		for _, c := range []*Catalog{fallback, catalog} {
+			if c == nil {
+				continue
+			}
+			for code, label := range c.Enums[enumType] {
+				labels[code] = label
+			}
+		}
+		if len(labels) > 0 {
+			result[enumType] = labels
+		}
+	}
+	return result
+}
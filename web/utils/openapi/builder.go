@@ -0,0 +1,177 @@
+package openapi
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"gateway/web/utils/constants"
+	"gateway/web/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIPathPrefix 生成文档的管理端接口路径前缀
+const APIPathPrefix = "/gateway/"
+
+// Build 根据已注册的路由生成 OpenAPI 文档
+// 只包含管理端接口（/gateway/ 开头），按模块编码分组
+// 参数:
+//
+//	routes: gin 引擎已注册的路由
+//	info: 文档基本信息
+//
+// 返回:
+//
+//	*Document: OpenAPI 文档
+func Build(routes gin.RoutesInfo, info Info) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+			SecuritySchemes: map[string]*SecurityScheme{
+				"sessionCookie": {Type: "apiKey", In: "cookie", Name: constants.HUB_SESSION_COOKIE, Description: "登录后写入的会话Cookie"},
+				"sessionHeader": {Type: "apiKey", In: "header", Name: "X-Session-Id", Description: "会话ID请求头"},
+				"bearer":        {Type: "http", Scheme: "bearer", Description: "Authorization: Bearer <会话ID>"},
+			},
+		},
+		Security: []map[string][]string{{"sessionCookie": {}}, {"sessionHeader": {}}, {"bearer": {}}},
+	}
+	generator := newSchemaGenerator(doc.Components.Schemas)
+	envelope := generator.schemaOf(reflect.TypeOf(response.JsonData{}))
+	generator.schemaOf(reflect.TypeOf(response.PageInfo{}))
+
+	sorted := make(gin.RoutesInfo, len(routes))
+	copy(sorted, routes)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	tags := make(map[string]bool)
+	for _, route := range sorted {
+		if !strings.HasPrefix(route.Path, APIPathPrefix) {
+			continue
+		}
+		moduleCode := strings.SplitN(strings.TrimPrefix(route.Path, APIPathPrefix), "/", 2)[0]
+		path, pathParams := convertPath(route.Path)
+
+		spec, _ := LookupHandler(route.Handler)
+		op := &Operation{
+			Tags:        []string{moduleCode},
+			Summary:     spec.Summary,
+			Description: spec.Description,
+			OperationID: operationID(route.Method, route.Path),
+			Responses: map[string]*Response{
+				"200": {
+					Description: responseDescription(spec.Paged),
+					Content:     map[string]MediaType{"application/json": {Schema: envelope}},
+				},
+			},
+		}
+		if op.Summary == "" {
+			op.Summary = route.Path[strings.LastIndex(route.Path, "/")+1:]
+		}
+		for _, name := range pathParams {
+			op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+		applyRequest(generator, op, route.Method, spec)
+
+		item := doc.Paths[path]
+		if item == nil {
+			item = &PathItem{}
+		}
+		if item.setOperation(route.Method, op) {
+			doc.Paths[path] = item
+			tags[moduleCode] = true
+		}
+	}
+
+	for tag := range tags {
+		doc.Tags = append(doc.Tags, Tag{Name: tag})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+	return doc
+}
+
+// applyRequest 设置请求参数
+// GET 请求的参数作为查询参数，其他请求的模型和参数合并为JSON请求体
+func applyRequest(generator *schemaGenerator, op *Operation, method string, spec HandlerSpec) {
+	params := make(map[string]*Schema)
+	for _, name := range spec.Params {
+		params[name] = &Schema{Type: "string"}
+	}
+	if spec.Paged {
+		params["pageIndex"] = &Schema{Type: "integer", Format: "int32", Description: "页码，从1开始"}
+		params["pageSize"] = &Schema{Type: "integer", Format: "int32", Description: "每页记录数"}
+	}
+
+	if method == "GET" {
+		names := make([]string, 0, len(params))
+		for name := range params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			op.Parameters = append(op.Parameters, Parameter{Name: name, In: "query", Schema: params[name]})
+		}
+		return
+	}
+
+	var parts []*Schema
+	if spec.Request != nil {
+		parts = append(parts, generator.schemaOf(reflect.TypeOf(spec.Request)))
+	}
+	if len(params) > 0 {
+		parts = append(parts, &Schema{Type: "object", Properties: params})
+	}
+
+	var body *Schema
+	switch len(parts) {
+	case 0:
+		body = &Schema{Type: "object"}
+	case 1:
+		body = parts[0]
+	default:
+		body = &Schema{AllOf: parts}
+	}
+	op.RequestBody = &RequestBody{Content: map[string]MediaType{"application/json": {Schema: body}}}
+}
+
+// convertPath 将 gin 路由模板转换为 OpenAPI 路径，返回路径参数名
+// 如 /gateway/hub0001/user/:id 转换为 /gateway/hub0001/user/{id}
+func convertPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var params []string
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			name := segment[1:]
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID 根据方法和路径生成唯一的接口ID，如 post_gateway_hub0000_globalSearch
+func operationID(method, path string) string {
+	id := strings.Map(func(r rune) rune {
+		if r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '_'
+	}, strings.Trim(path, "/"))
+	return strings.ToLower(method) + "_" + id
+}
+
+// responseDescription 响应说明
+func responseDescription(paged bool) string {
+	if paged {
+		return "统一响应格式，业务数据列表序列化为JSON字符串放在 bizData 中，分页信息(PageInfo)序列化后放在 pageQueryData 中"
+	}
+	return "统一响应格式，业务数据序列化为JSON字符串放在 bizData 中，失败时 oK 为 false，错误信息在 errMsg 中"
+}
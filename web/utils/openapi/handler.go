@@ -0,0 +1,60 @@
+package openapi
+
+import (
+	_ "embed"
+	"html/template"
+	"net/http"
+	"strings"
+	"sync"
+
+	"gateway/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed swagger_ui.html
+var swaggerUIPage string
+
+var swaggerUITemplate = template.Must(template.New("swagger-ui").Parse(swaggerUIPage))
+
+// SpecHandler 返回 OpenAPI 文档的处理函数
+// 文档在首次请求时根据路由生成并缓存，此时所有模块路由均已注册
+// 参数:
+//
+//	router: gin 路由引擎
+//	info: 文档基本信息
+func SpecHandler(router *gin.Engine, info Info) gin.HandlerFunc {
+	var (
+		once sync.Once
+		doc  *Document
+	)
+	return func(c *gin.Context) {
+		once.Do(func() {
+			doc = Build(router.Routes(), info)
+			logger.Info("OpenAPI文档生成完成", "paths", len(doc.Paths), "schemas", len(doc.Components.Schemas))
+		})
+		c.JSON(http.StatusOK, doc)
+	}
+}
+
+// UIHandler 返回 Swagger UI 页面的处理函数
+// 参数:
+//
+//	title: 页面标题
+//	specURL: OpenAPI 文档地址
+//	assetURL: Swagger UI 静态资源地址（swagger-ui-dist 目录），不提供默认的公网地址
+func UIHandler(title, specURL, assetURL string) gin.HandlerFunc {
+	data := struct {
+		Title    string
+		SpecURL  string
+		AssetURL string
+	}{title, specURL, strings.TrimSuffix(assetURL, "/")}
+
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "text/html; charset=utf-8")
+		c.Status(http.StatusOK)
+		if err := swaggerUITemplate.Execute(c.Writer, data); err != nil {
+			logger.ErrorWithTrace(c, "渲染Swagger UI页面失败", "error", err)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package openapi
+
+import (
+	"strings"
+	"sync"
+)
+
+// HandlerSpec 接口处理函数的文档说明
+type HandlerSpec struct {
+	Summary     string      // 接口摘要
+	Description string      // 接口描述
+	Request     interface{} // 请求模型，使用零值，如 models.GlobalSearchRequest{}；请求校验中间件按其 binding 标签校验请求参数
+	Params      []string    // 通过 request.GetParam 读取的请求参数
+	Paged       bool        // 是否为分页查询，分页参数为 pageIndex、pageSize，响应包含 pageQueryData
+}
+
+var (
+	handlerSpecsMu sync.RWMutex
+	// handlerSpecs 处理函数说明，键为处理函数全名，如 gateway/web/views/hub0000/controllers.(*GlobalSearchController).GlobalSearch
+	handlerSpecs = make(map[string]HandlerSpec)
+)
+
+// RegisterHandler 注册接口处理函数的文档说明
+// 参数:
+//
+//	name: 处理函数全名，与 runtime.FuncForPC 返回的名称一致，方法值的 -fm 后缀可省略
+//	spec: 接口说明
+func RegisterHandler(name string, spec HandlerSpec) {
+	handlerSpecsMu.Lock()
+	defer handlerSpecsMu.Unlock()
+	handlerSpecs[strings.TrimSuffix(name, "-fm")] = spec
+}
+
+// LookupHandler 查找处理函数的文档说明，请求校验中间件据此获取接口的请求模型
+// 参数:
+//
+//	name: 处理函数全名，如 gin.Context.HandlerName() 的返回值
+//
+// 返回:
+//
+//	HandlerSpec: 接口说明
+//	bool: 是否已注册
+func LookupHandler(name string) (HandlerSpec, bool) {
+	handlerSpecsMu.RLock()
+	defer handlerSpecsMu.RUnlock()
+	spec, ok := handlerSpecs[strings.TrimSuffix(name, "-fm")]
+	return spec, ok
+}
@@ -0,0 +1,181 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// componentRefPrefix 组件引用前缀
+const componentRefPrefix = "#/components/schemas/"
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaGenerator 根据Go类型生成Schema，结构体生成为组件并以引用返回
+type schemaGenerator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+// newSchemaGenerator 创建Schema生成器
+func newSchemaGenerator(schemas map[string]*Schema) *schemaGenerator {
+	return &schemaGenerator{
+		schemas: schemas,
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// schemaOf 生成类型的Schema
+func (g *schemaGenerator) schemaOf(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time", Nullable: nullable}
+	case t == rawMessageType:
+		return &Schema{Description: "任意JSON"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean", Nullable: nullable}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32", Nullable: nullable}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64", Nullable: nullable}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float", Nullable: nullable}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double", Nullable: nullable}
+	case reflect.String:
+		return &Schema{Type: "string", Nullable: nullable}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte", Nullable: nullable}
+		}
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem()), Nullable: nullable}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem()), Nullable: nullable}
+	case reflect.Struct:
+		if t.Name() == "" {
+			// 匿名结构体直接内联
+			schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+			g.fillStruct(schema, t)
+			return schema
+		}
+		return &Schema{Ref: componentRefPrefix + g.component(t)}
+	default:
+		// interface{} 等任意类型
+		return &Schema{}
+	}
+}
+
+// component 生成结构体组件并返回组件名，同一类型只生成一次
+func (g *schemaGenerator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := componentName(t)
+	// 不同包下的同名类型追加序号区分
+	for i := 2; ; i++ {
+		if _, exists := g.schemas[name]; !exists {
+			break
+		}
+		name = componentName(t) + "_" + strconv.Itoa(i)
+	}
+	g.names[t] = name
+
+	// 先占位，支持自引用的结构体
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.schemas[name] = schema
+	g.fillStruct(schema, t)
+	return name
+}
+
+// fillStruct 填充结构体属性，匿名嵌入的结构体字段展开到当前结构体
+func (g *schemaGenerator) fillStruct(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, skip := fieldName(field)
+		if skip {
+			continue
+		}
+
+		fieldType := field.Type
+		if field.Anonymous && !hasNameTag(field) {
+			for fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct && fieldType != timeType {
+				g.fillStruct(schema, fieldType)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		schema.Properties[name] = g.schemaOf(field.Type)
+		if strings.Contains(field.Tag.Get("binding"), "required") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// fieldName 按 json 标签、form 标签、字段名的顺序取属性名，json 标签为 "-" 时跳过
+func fieldName(field reflect.StructField) (string, bool) {
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			return "", true
+		}
+		if name != "" {
+			return name, false
+		}
+	}
+	if tag := strings.Split(field.Tag.Get("form"), ",")[0]; tag != "" && tag != "-" {
+		return tag, false
+	}
+	return field.Name, false
+}
+
+// hasNameTag 字段是否通过 json 标签指定了属性名
+func hasNameTag(field reflect.StructField) bool {
+	return strings.Split(field.Tag.Get("json"), ",")[0] != ""
+}
+
+// componentName 组件名，模块下的类型以模块编码为前缀，如 hub0020.GatewayInstance
+func componentName(t reflect.Type) string {
+	// 泛型实例化的类型名包含方括号等字符，组件名只保留字母、数字和下划线
+	name := strings.Map(func(r rune) rune {
+		if r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '_'
+	}, t.Name())
+
+	pkgPath := t.PkgPath()
+	if pkgPath == "" {
+		return name
+	}
+	segments := strings.Split(pkgPath, "/")
+	prefix := segments[len(segments)-1]
+	for i, segment := range segments {
+		if segment == "views" && i+1 < len(segments) {
+			prefix = segments[i+1]
+			break
+		}
+	}
+	return prefix + "." + name
+}
@@ -7,6 +7,7 @@ import (
 	"gateway/web/globalmodels"
 	"gateway/web/middleware"
 	"gateway/web/utils/constants"
+	"gateway/web/utils/validation"
 	"io"
 	"mime/multipart"
 	"reflect"
@@ -91,27 +92,27 @@ func GetParamID(c *gin.Context, key string) string {
 
 // BindJSON 绑定JSON请求体并处理错误
 func BindJSON(c *gin.Context, obj interface{}) error {
-	return c.ShouldBindJSON(obj)
+	return bindError(c.ShouldBindJSON(obj))
 }
 
 // BindForm 绑定表单数据(multipart/form-data)到结构体
 func BindForm(c *gin.Context, obj interface{}) error {
-	return c.ShouldBindWith(obj, binding.Form)
+	return bindError(c.ShouldBindWith(obj, binding.Form))
 }
 
 // BindQuery 绑定Query参数到结构体
 func BindQuery(c *gin.Context, obj interface{}) error {
-	return c.ShouldBindQuery(obj)
+	return bindError(c.ShouldBindQuery(obj))
 }
 
 // BindFormPost 绑定表单提交数据(application/x-www-form-urlencoded)到结构体
 func BindFormPost(c *gin.Context, obj interface{}) error {
-	return c.ShouldBindWith(obj, binding.FormPost)
+	return bindError(c.ShouldBindWith(obj, binding.FormPost))
 }
 
 // BindUri 绑定URI参数到结构体
 func BindUri(c *gin.Context, obj interface{}) error {
-	return c.ShouldBindUri(obj)
+	return bindError(c.ShouldBindUri(obj))
 }
 
 // Bind 根据Content-Type自动选择绑定方法
@@ -127,18 +128,27 @@ func Bind(c *gin.Context, obj interface{}) error {
 
 	// 根据Content-Type选择不同的绑定方法
 	if strings.Contains(contentType, "application/json") {
-		return c.ShouldBindJSON(obj)
+		return bindError(c.ShouldBindJSON(obj))
 	} else if strings.Contains(contentType, "application/x-www-form-urlencoded") {
-		return c.ShouldBindWith(obj, binding.FormPost)
+		return bindError(c.ShouldBindWith(obj, binding.FormPost))
 	} else if strings.Contains(contentType, "multipart/form-data") {
-		return c.ShouldBindWith(obj, binding.Form)
+		return bindError(c.ShouldBindWith(obj, binding.Form))
 	} else {
 		// 默认尝试所有绑定方法
-		return c.ShouldBind(obj)
+		return bindError(c.ShouldBind(obj))
 	}
 }
 
+// bindError 将 binding 标签校验失败的错误转换为 *validation.Error，其他错误原样返回
+func bindError(err error) error {
+	if validationErr, ok := validation.FromError(err); ok {
+		return validationErr
+	}
+	return err
+}
+
 // BindSafely 安全绑定函数，处理常见的类型转换错误
+// 类型转换失败时按宽松规则重新赋值，但 binding 标签校验失败时始终返回 *validation.Error
 func BindSafely(c *gin.Context, obj interface{}) error {
 	contentType := c.GetHeader("Content-Type")
 
@@ -147,6 +157,9 @@ func BindSafely(c *gin.Context, obj interface{}) error {
 
 	// 先尝试标准绑定
 	err := Bind(c, obj)
+	if _, ok := err.(*validation.Error); ok {
+		return err
+	}
 	if err != nil {
 		logger.WarnWithTrace(c, "绑定出错，尝试自定义处理", "error", err.Error())
 
@@ -155,9 +168,9 @@ func BindSafely(c *gin.Context, obj interface{}) error {
 		if contentType == "" || strings.Contains(contentType, "application/json") {
 			bindErr := c.ShouldBindJSON(&rawData)
 			if bindErr == nil {
-				// 使用反射处理转换
+				// 使用反射处理转换，转换后的值同样需要满足 binding 标签
 				cleanAndSetValue(obj, rawData)
-				return nil
+				return validation.Validate(obj)
 			} else {
 				logger.WarnWithTrace(c, "JSON数据绑定到map失败", "error", bindErr.Error())
 			}
@@ -184,7 +197,7 @@ func BindSafely(c *gin.Context, obj interface{}) error {
 
 			// 处理转换
 			cleanAndSetValue(obj, rawData)
-			return nil
+			return validation.Validate(obj)
 		}
 
 		// 如果自定义处理失败，返回原始错误
@@ -3,7 +3,11 @@ package response
 import (
 	"encoding/json"
 	"gateway/pkg/logger"
+	"gateway/pkg/utils/huberrors"
+	"gateway/web/utils/i18n"
+	"gateway/web/utils/validation"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -34,6 +38,10 @@ type PageInfo struct {
 	TotalCount int `json:"totalCount"`
 	// 总页数
 	TotalPageIndex int `json:"totalPageIndex"`
+	// 下一页游标，仅游标分页时返回，为空表示没有下一页
+	NextCursor string `json:"nextCursor,omitempty"`
+	// 是否还有下一页，仅游标分页时返回
+	HasMore bool `json:"hasMore,omitempty"`
 }
 
 // JsonData 定义了与后端交互的标准响应格式
@@ -184,12 +192,28 @@ func SuccessJSON(c *gin.Context, data interface{}, messageId string) {
 }
 
 // 返回错误响应
+// 错误消息按请求的 Accept-Language 翻译，无译文时使用消息代码对应的通用消息，原文放在 extMsg 中
 func ErrorJSON(c *gin.Context, errMsg string, messageId string, status ...int) {
 	httpStatus := http.StatusOK
 	if len(status) > 0 {
 		httpStatus = status[0]
 	}
-	c.JSON(httpStatus, Error(errMsg, messageId))
+	translated, original := i18n.TranslateError(i18n.Language(c), errMsg, messageId)
+	data := Error(translated, messageId)
+	data.ExtMsg = original
+	c.JSON(httpStatus, data)
+}
+
+// 返回按错误码映射HTTP状态码的错误响应
+// HTTP状态码由 huberrors.HTTPStatus 确定（如记录不存在为404、依赖不可用为503），
+// 错误消息为最外层错误的消息（不含位置信息），extObj 中包含错误码和分类
+func CodeErrorJSON(c *gin.Context, err error, messageId string) {
+	code := huberrors.CodeOf(err)
+	translated, original := i18n.TranslateError(i18n.Language(c), huberrors.Message(err), messageId)
+	data := Error(translated, messageId)
+	data.ExtMsg = original
+	data.ExtObj = gin.H{"code": code, "category": code.Category()}
+	c.JSON(code.HTTPStatus(), data)
 }
 
 // 返回带分页的成功响应
@@ -197,6 +221,36 @@ func PageJSON(c *gin.Context, data interface{}, pageInfo PageInfo, messageId str
 	c.JSON(http.StatusOK, Page(data, pageInfo, messageId))
 }
 
+// 返回参数校验失败响应，HTTP状态码为400，字段错误明细放在 extObj 中，错误描述按请求语言生成
+func ValidationErrorJSON(c *gin.Context, err *validation.Error, messageId string) {
+	language := i18n.Language(c)
+	if language != i18n.LanguageZhCN {
+		err = localizeValidationError(language, err)
+	}
+	prefix, _ := i18n.TranslateText(language, "参数校验失败")
+	data := Error(prefix+": "+err.Error(), messageId)
+	data.ExtObj = err
+	c.JSON(http.StatusBadRequest, data)
+}
+
+// localizeValidationError 按消息目录中的 validation.<规则> 重新生成字段错误描述，{param}、{rule} 替换为规则参数和规则名
+func localizeValidationError(language string, err *validation.Error) *validation.Error {
+	localized := &validation.Error{Fields: make([]validation.FieldError, len(err.Fields))}
+	for i, field := range err.Fields {
+		localized.Fields[i] = field
+		message := i18n.Message(language, "validation."+field.Rule)
+		if message == "" {
+			message = i18n.Message(language, "validation.default")
+		}
+		if message == "" {
+			continue
+		}
+		message = strings.NewReplacer("{param}", strings.Join(strings.Fields(field.Param), ", "), "{rule}", field.Rule).Replace(message)
+		localized.Fields[i].Message = field.Field + " " + message
+	}
+	return localized
+}
+
 // 创建新的分页信息对象
 func NewPageInfo(pageIndex, pageSize, total int) PageInfo {
 	return PageInfo{
@@ -208,6 +262,17 @@ func NewPageInfo(pageIndex, pageSize, total int) PageInfo {
 	}
 }
 
+// 创建游标分页信息对象，游标分页不统计总数，TotalCount 和 TotalPageIndex 为0
+func NewCursorPageInfo(pageSize, curPageCount int, nextCursor string) PageInfo {
+	return PageInfo{
+		PageIndex:    1,
+		PageSize:     pageSize,
+		CurPageCount: curPageCount,
+		NextCursor:   nextCursor,
+		HasMore:      nextCursor != "",
+	}
+}
+
 // min 返回两个整数中的较小值
 func min(a, b int) int {
 	if a < b {
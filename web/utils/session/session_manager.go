@@ -4,7 +4,8 @@
 //   - 基于Redis的分布式session存储
 //   - 支持session的创建、验证、刷新和删除
 //   - 自动过期清理和活动时间更新
-//   - 支持单用户多设备登录
+//   - 支持单用户多设备登录，可配置每个用户的最大同时登录会话数
+//   - 短期访问令牌（session ID）配合刷新令牌续期
 //   - 提供全局单例和自定义实例两种使用方式
 //   - 加密级别的session ID生成
 //
@@ -22,6 +23,9 @@
 //	// 验证session
 //	userContext, err := sessionMgr.ValidateSession(ctx, sessionId)
 //
+//	// 使用刷新令牌换取新的访问令牌
+//	token, err := sessionMgr.RefreshToken(ctx, refreshToken)
+//
 //	// 删除session
 //	err := sessionMgr.DeleteSession(ctx, sessionId)
 //
@@ -40,7 +44,6 @@ import (
 	"gateway/pkg/cache"
 	"gateway/pkg/logger"
 	"gateway/web/globalmodels"
-	"gateway/web/utils/constants"
 	"time"
 )
 
@@ -55,6 +58,8 @@ import (
 // 字段说明:
 //   - cacheManager: Redis缓存管理器，用于实际的数据存储操作
 //   - prefix: Redis key前缀，用于区分不同类型的缓存数据
+//   - policy: session策略，包括访问令牌和刷新令牌有效期、最大并发会话数
+//   - userValidator: 刷新令牌时的用户校验，为空时不校验
 //
 // 设计原则:
 //   - 线程安全: 所有操作都是原子的，支持并发访问
@@ -62,22 +67,23 @@ import (
 //   - 性能优化: 使用合理的缓存策略和数据结构
 //   - 简化存储: 只存储UserContext，所有信息集中管理
 type SessionManager struct {
-	cacheManager *cache.Manager // Redis缓存管理器 - 负责与Redis的交互
-	prefix       string         // session存储key前缀 - Redis中存储session的前缀
+	cacheManager  *cache.Manager // Redis缓存管理器 - 负责与Redis的交互
+	prefix        string         // session存储key前缀 - Redis中存储session的前缀
+	policy        SessionPolicy  // session策略 - 令牌有效期和并发登录限制
+	userValidator UserValidator  // 用户校验 - 刷新令牌时确认用户仍然可用
 }
 
 // NewSessionManager 创建session管理器
 //
 // 方法功能:
 //
-//	创建一个新的session管理器实例，使用全局配置的超时时间
-//	session过期时间从constants包的全局变量中获取
+//	创建一个新的session管理器实例，session策略从 web.session 配置加载
 //
 // 返回值:
 //   - *SessionManager: 新创建的session管理器实例
 //
 // 默认配置:
-//   - 过期时间: 从constants.HUB_SESSION_EXPIRE_HOURS获取
+//   - 访问令牌过期时间: web.session.access_token_expire_minutes，默认constants.HUB_SESSION_EXPIRE_HOURS
 //   - Redis key前缀: "session:"
 //   - 缓存管理器: 使用全局缓存管理器
 //
@@ -87,11 +93,12 @@ type SessionManager struct {
 //
 // 注意事项:
 //   - 依赖全局缓存管理器，确保缓存已正确初始化
-//   - 超时时间统一在constants包中管理
+//   - 配置需在创建前加载，之后修改配置需调用SetPolicy
 func NewSessionManager() *SessionManager {
 	return &SessionManager{
 		cacheManager: cache.GetGlobalManager(),
 		prefix:       "session:",
+		policy:       LoadSessionPolicy(),
 	}
 }
 
@@ -100,7 +107,7 @@ func NewSessionManager() *SessionManager {
 // 方法功能:
 //
 //	为用户创建一个新的session会话，生成唯一的sessionId并创建UserContext
-//	将包含所有session信息的UserContext存储到Redis缓存中
+//	将包含所有session信息的UserContext存储到Redis缓存中，同时签发刷新令牌
 //
 // 参数说明:
 //   - ctx: 上下文对象，用于控制请求的生命周期、超时和取消操作
@@ -116,8 +123,8 @@ func NewSessionManager() *SessionManager {
 //   - userAgent: 客户端用户代理字符串，包含浏览器和操作系统信息
 //
 // 返回值:
-//   - *globalmodels.UserContext: 创建成功的用户上下文对象
-//   - error: 创建失败时返回具体的错误信息
+//   - *SessionToken: 创建成功的用户上下文对象和刷新令牌
+//   - error: 创建失败时返回具体的错误信息，会话数已达上限且策略为拒绝时返回ErrSessionLimitExceeded
 //
 // 使用场景:
 //   - 用户登录成功后创建session
@@ -125,10 +132,16 @@ func NewSessionManager() *SessionManager {
 //   - 替代或配合JWT令牌使用的会话管理
 //
 // 注意事项:
-//   - session过期时间从constants.HUB_SESSION_EXPIRE_HOURS获取
+//   - session过期时间为策略中的访问令牌有效期
+//   - 超过最大并发会话数且策略为踢出时，会撤销该用户最早登录的会话
 //   - 生成的sessionId为64字符的十六进制字符串，具有高度的唯一性
 //   - 所有session信息都存储在UserContext中，简化了数据结构
-func (sm *SessionManager) CreateSession(ctx context.Context, userId, userName, realName, tenantId, deptId, email, mobile, avatar, clientIP, userAgent string) (*globalmodels.UserContext, error) {
+func (sm *SessionManager) CreateSession(ctx context.Context, userId, userName, realName, tenantId, deptId, email, mobile, avatar, clientIP, userAgent string) (*SessionToken, error) {
+	// 检查并发会话数
+	if err := sm.enforceSessionLimit(ctx, tenantId, userId); err != nil {
+		return nil, err
+	}
+
 	// 生成session ID
 	sessionId, err := sm.generateSessionId()
 	if err != nil {
@@ -137,7 +150,7 @@ func (sm *SessionManager) CreateSession(ctx context.Context, userId, userName, r
 	}
 
 	now := time.Now()
-	expireDuration := time.Duration(constants.HUB_SESSION_EXPIRE_HOURS) * time.Hour
+	expireDuration := sm.policy.AccessExpire
 	expireAt := now.Add(expireDuration)
 
 	// 创建用户上下文，包含所有session信息
@@ -165,8 +178,16 @@ func (sm *SessionManager) CreateSession(ctx context.Context, userId, userName, r
 		return nil, fmt.Errorf("存储用户上下文失败: %w", err)
 	}
 
+	// 签发刷新令牌
+	token, err := sm.issueRefreshToken(ctx, userContext)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "签发刷新令牌失败", "error", err, "sessionId", sessionId)
+		sm.DeleteSession(ctx, sessionId)
+		return nil, err
+	}
+
 	logger.Info("Session创建成功", "sessionId", sessionId, "userId", userId, "tenantId", tenantId)
-	return userContext, nil
+	return token, nil
 }
 
 // ValidateSession 验证session并获取用户上下文
@@ -211,10 +232,13 @@ func (sm *SessionManager) ValidateSession(ctx context.Context, sessionId string)
 		return nil, fmt.Errorf("session已过期")
 	}
 
-	// 更新最后活动时间
+	// 更新最后活动时间，缓存过期时间不超过session过期时间
 	now := time.Now()
 	userContext.LastActivity = &now
-	expireDuration := time.Duration(constants.HUB_SESSION_EXPIRE_HOURS) * time.Hour
+	expireDuration := sm.policy.AccessExpire
+	if userContext.ExpireAt != nil {
+		expireDuration = userContext.ExpireAt.Sub(now)
+	}
 	err = sm.storeUserContext(ctx, sessionId, userContext, expireDuration)
 	if err != nil {
 		logger.ErrorWithTrace(ctx, "更新session活动时间失败", "error", err, "sessionId", sessionId)
@@ -244,7 +268,7 @@ func (sm *SessionManager) ValidateSession(ctx context.Context, sessionId string)
 //   - 防止用户在活跃使用时突然掉线
 //
 // 注意事项:
-//   - 会重新设置ExpireAt为当前时间+全局配置的过期时长
+//   - 会重新设置ExpireAt为当前时间+访问令牌有效期
 //   - 同时更新LastActivity为当前时间
 //   - 如果原session不存在或已过期，刷新会失败
 //   - 刷新成功后session的有效期会从当前时间重新计算
@@ -256,7 +280,7 @@ func (sm *SessionManager) RefreshSession(ctx context.Context, sessionId string)
 
 	// 更新过期时间
 	now := time.Now()
-	expireDuration := time.Duration(constants.HUB_SESSION_EXPIRE_HOURS) * time.Hour
+	expireDuration := sm.policy.AccessExpire
 	expireAt := now.Add(expireDuration)
 
 	userContext.ExpireAt = &expireAt
@@ -277,7 +301,7 @@ func (sm *SessionManager) RefreshSession(ctx context.Context, sessionId string)
 // 方法功能:
 //
 //	从Redis缓存中删除指定的session，用于用户登出或强制下线
-//	删除后该session将立即失效，无法再用于身份验证
+//	删除后该session及其刷新令牌将立即失效，无法再用于身份验证
 //
 // 参数说明:
 //   - ctx: 上下文对象，用于控制请求的生命周期和超时
@@ -305,6 +329,16 @@ func (sm *SessionManager) DeleteSession(ctx context.Context, sessionId string) e
 		return fmt.Errorf("Redis缓存未初始化")
 	}
 
+	// 同时撤销刷新令牌
+	if userContext, err := sm.getUserContext(ctx, sessionId); err == nil {
+		indexKey := userSessionIndexKey(userContext.TenantId, userContext.UserId)
+		if entry, err := sm.getIndexEntry(ctx, indexKey, sessionId); err == nil && entry != nil {
+			if err := sm.revokeIndexEntry(ctx, indexKey, entry); err != nil {
+				logger.ErrorWithTrace(ctx, "撤销刷新令牌失败", "error", err, "sessionId", sessionId)
+			}
+		}
+	}
+
 	// 删除session
 	sessionKey := sm.prefix + sessionId
 	err := redisCache.Delete(ctx, sessionKey)
@@ -321,11 +355,12 @@ func (sm *SessionManager) DeleteSession(ctx context.Context, sessionId string) e
 //
 // 方法功能:
 //
-//	删除指定用户的所有session，实现用户在所有设备上的强制登出
-//	会遍历所有session找到属于该用户的session并逐个删除
+//	删除指定用户的所有session和刷新令牌，实现用户在所有设备上的强制登出
+//	先按用户会话索引撤销，再遍历所有session删除未建立索引的session
 //
 // 参数说明:
 //   - ctx: 上下文对象，用于控制请求的生命周期和超时
+//   - tenantId: 租户ID
 //   - userId: 用户唯一标识符，删除该用户的所有session
 //
 // 返回值:
@@ -346,7 +381,22 @@ func (sm *SessionManager) DeleteSession(ctx context.Context, sessionId string) e
 // 性能考虑:
 //   - 在Redis中使用KEYS命令可能影响性能，建议在非高峰期使用
 //   - 对于大型系统，建议考虑使用Redis的SCAN命令替代KEYS命令
-func (sm *SessionManager) DeleteUserSessions(ctx context.Context, userId string) error {
+func (sm *SessionManager) DeleteUserSessions(ctx context.Context, tenantId, userId string) error {
+	// 按索引撤销会话和刷新令牌
+	var deletedCount int
+	indexKey := userSessionIndexKey(tenantId, userId)
+	entries, err := sm.loadIndex(ctx, indexKey)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取用户会话索引失败", "error", err, "userId", userId)
+	}
+	for _, entry := range entries {
+		if err := sm.revokeIndexEntry(ctx, indexKey, entry); err != nil {
+			logger.ErrorWithTrace(ctx, "撤销用户会话失败", "error", err, "sessionId", entry.SessionId, "userId", userId)
+		} else {
+			deletedCount++
+		}
+	}
+
 	// 获取所有session key
 	redisCache := sm.cacheManager.GetCache("default")
 	if redisCache == nil {
@@ -362,7 +412,6 @@ func (sm *SessionManager) DeleteUserSessions(ctx context.Context, userId string)
 	}
 
 	// 遍历所有session，删除属于该用户的
-	var deletedCount int
 	for _, key := range keys {
 		sessionId := key[len(sm.prefix):]
 
@@ -372,7 +421,7 @@ func (sm *SessionManager) DeleteUserSessions(ctx context.Context, userId string)
 			continue // 跳过无效的session
 		}
 
-		if userContext.UserId == userId {
+		if userContext.UserId == userId && userContext.TenantId == tenantId {
 			err = sm.DeleteSession(ctx, sessionId)
 			if err != nil {
 				logger.ErrorWithTrace(ctx, "删除用户session失败", "error", err, "sessionId", sessionId, "userId", userId)
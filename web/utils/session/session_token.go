@@ -0,0 +1,549 @@
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gateway/pkg/config"
+	"gateway/pkg/logger"
+	"gateway/web/globalmodels"
+	"gateway/web/utils/constants"
+)
+
+// 超过最大并发会话数时的处理策略
+const (
+	SessionLimitEvictOldest = "evict_oldest" // 踢出最早登录的会话
+	SessionLimitReject      = "reject"       // 拒绝新的登录
+)
+
+// DefaultRefreshTokenExpire 刷新令牌默认有效期
+const DefaultRefreshTokenExpire = 7 * 24 * time.Hour
+
+const (
+	// refreshTokenPrefix 刷新令牌存储key前缀，key中使用令牌的SHA-256摘要，不存储令牌原文
+	refreshTokenPrefix = "session_refresh:"
+	// refreshTokenUsedPrefix 刷新令牌使用标记key前缀，防止同一令牌被并发重复使用
+	refreshTokenUsedPrefix = "session_refresh_used:"
+	// userSessionIndexPrefix 用户会话索引key前缀，哈希结构，字段为session ID
+	userSessionIndexPrefix = "session_user:"
+)
+
+var (
+	// ErrSessionLimitExceeded 用户会话数已达上限且策略为拒绝新登录
+	ErrSessionLimitExceeded = errors.New("已达到最大同时登录会话数")
+	// ErrRefreshTokenInvalid 刷新令牌不存在、已过期、已使用或已被撤销
+	ErrRefreshTokenInvalid = errors.New("刷新令牌无效或已过期")
+	// ErrSessionNotFound 用户会话不存在
+	ErrSessionNotFound = errors.New("会话不存在")
+	// ErrUserUnavailable 用户不存在或已被禁用，UserValidator 返回该错误时撤销会话
+	ErrUserUnavailable = errors.New("用户不存在或已被禁用")
+)
+
+// UserValidator 刷新令牌前校验用户仍然存在且处于可用状态
+// 用户不可用时返回 ErrUserUnavailable（可包装），其他错误视为校验失败，不撤销会话
+type UserValidator func(ctx context.Context, tenantId, userId string) error
+
+// SessionPolicy session策略配置
+type SessionPolicy struct {
+	AccessExpire          time.Duration // 访问令牌（session ID）有效期
+	RefreshExpire         time.Duration // 刷新令牌有效期，不小于访问令牌有效期
+	MaxConcurrentSessions int           // 每个用户最大同时登录会话数，0表示不限制
+	LimitPolicy           string        // 超过最大会话数时的处理策略
+}
+
+// LoadSessionPolicy 从 web.session 配置加载session策略
+// 未配置的项使用默认值：访问令牌有效期与原session过期时间一致，刷新令牌7天，不限制会话数
+func LoadSessionPolicy() SessionPolicy {
+	policy := SessionPolicy{
+		AccessExpire:          time.Duration(config.GetInt("web.session.access_token_expire_minutes", constants.HUB_SESSION_EXPIRE_HOURS*60)) * time.Minute,
+		RefreshExpire:         time.Duration(config.GetInt("web.session.refresh_token_expire_hours", int(DefaultRefreshTokenExpire/time.Hour))) * time.Hour,
+		MaxConcurrentSessions: config.GetInt("web.session.max_concurrent_sessions", 0),
+		LimitPolicy:           config.GetString("web.session.limit_policy", SessionLimitEvictOldest),
+	}
+	return policy.normalize()
+}
+
+// normalize 修正非法的策略配置
+func (p SessionPolicy) normalize() SessionPolicy {
+	if p.AccessExpire <= 0 {
+		p.AccessExpire = time.Duration(constants.HUB_SESSION_EXPIRE_HOURS) * time.Hour
+	}
+	if p.RefreshExpire < p.AccessExpire {
+		p.RefreshExpire = p.AccessExpire
+	}
+	if p.MaxConcurrentSessions < 0 {
+		p.MaxConcurrentSessions = 0
+	}
+	if p.LimitPolicy != SessionLimitReject {
+		p.LimitPolicy = SessionLimitEvictOldest
+	}
+	return p
+}
+
+// SessionToken 登录或刷新令牌后签发的令牌
+// 内嵌的用户上下文中 SessionId 为访问令牌，ExpireAt 为访问令牌过期时间
+type SessionToken struct {
+	*globalmodels.UserContext
+	RefreshToken    string    // 刷新令牌，只在签发时返回原文
+	RefreshExpireAt time.Time // 刷新令牌过期时间
+}
+
+// SessionInfo 用户会话信息，用于会话列表展示
+type SessionInfo struct {
+	SessionId       string     `json:"sessionId"`       // Session ID
+	UserId          string     `json:"userId"`          // 用户ID
+	TenantId        string     `json:"tenantId"`        // 租户ID
+	UserName        string     `json:"userName"`        // 用户名
+	RealName        string     `json:"realName"`        // 真实姓名
+	LoginTime       *time.Time `json:"loginTime"`       // 登录时间
+	LastActivity    *time.Time `json:"lastActivity"`    // 最后活动时间，访问令牌已过期时为空
+	ExpireAt        *time.Time `json:"expireAt"`        // 访问令牌过期时间，访问令牌已过期时为空
+	RefreshExpireAt time.Time  `json:"refreshExpireAt"` // 刷新令牌过期时间
+	ClientIP        string     `json:"clientIP"`        // 客户端IP
+	UserAgent       string     `json:"userAgent"`       // 用户代理
+	Active          bool       `json:"active"`          // 访问令牌是否有效，false时需使用刷新令牌换取新的访问令牌
+}
+
+// sessionIndexEntry 用户会话索引项
+type sessionIndexEntry struct {
+	SessionId       string     `json:"sessionId"`
+	RefreshHash     string     `json:"refreshHash"`
+	UserName        string     `json:"userName"`
+	RealName        string     `json:"realName"`
+	LoginTime       *time.Time `json:"loginTime"`
+	RefreshExpireAt time.Time  `json:"refreshExpireAt"`
+	ClientIP        string     `json:"clientIP"`
+	UserAgent       string     `json:"userAgent"`
+}
+
+// refreshTokenData 刷新令牌存储数据
+// 保存签发时的用户上下文，访问令牌过期被清除后仍可换取新的访问令牌
+type refreshTokenData struct {
+	SessionId   string                    `json:"sessionId"`
+	UserContext *globalmodels.UserContext `json:"userContext"`
+	ExpireAt    time.Time                 `json:"expireAt"`
+}
+
+// SetPolicy 设置session策略
+func (sm *SessionManager) SetPolicy(policy SessionPolicy) {
+	sm.policy = policy.normalize()
+}
+
+// GetPolicy 获取session策略
+func (sm *SessionManager) GetPolicy() SessionPolicy {
+	return sm.policy
+}
+
+// SetUserValidator 设置刷新令牌时的用户校验，传入nil时不校验
+func (sm *SessionManager) SetUserValidator(validator UserValidator) {
+	sm.userValidator = validator
+}
+
+// RefreshToken 使用刷新令牌换取新的访问令牌
+//
+// 方法功能:
+//
+//	校验刷新令牌后签发新的session ID和刷新令牌，原session ID和刷新令牌立即失效
+//	访问令牌已过期时也可以刷新，只要刷新令牌仍然有效且会话未被撤销
+//	设置了 UserValidator 时，签发前重新校验用户，用户已删除或禁用时撤销该会话
+//
+// 参数说明:
+//   - ctx: 上下文对象
+//   - refreshToken: 登录或上次刷新时签发的刷新令牌
+//
+// 返回值:
+//   - *SessionToken: 新签发的令牌
+//   - error: 刷新令牌无效或用户不可用时返回 ErrRefreshTokenInvalid
+//
+// 注意事项:
+//   - 刷新令牌只能使用一次，并发使用同一令牌时只有一个请求成功
+func (sm *SessionManager) RefreshToken(ctx context.Context, refreshToken string) (*SessionToken, error) {
+	if refreshToken == "" {
+		return nil, ErrRefreshTokenInvalid
+	}
+	redisCache := sm.cacheManager.GetCache("default")
+	if redisCache == nil {
+		return nil, fmt.Errorf("Redis缓存未初始化")
+	}
+
+	refreshHash := hashRefreshToken(refreshToken)
+	jsonData, err := redisCache.GetString(ctx, refreshTokenPrefix+refreshHash)
+	if err != nil {
+		return nil, fmt.Errorf("获取刷新令牌失败: %w", err)
+	}
+	if jsonData == "" {
+		return nil, ErrRefreshTokenInvalid
+	}
+	var data refreshTokenData
+	if err := json.Unmarshal([]byte(jsonData), &data); err != nil || data.UserContext == nil {
+		return nil, ErrRefreshTokenInvalid
+	}
+	if time.Now().After(data.ExpireAt) {
+		_ = redisCache.Delete(ctx, refreshTokenPrefix+refreshHash)
+		return nil, ErrRefreshTokenInvalid
+	}
+
+	// 用户已删除或禁用时不再续期，撤销该会话；校验本身出错时令牌保持可用
+	userContext := data.UserContext
+	indexKey := userSessionIndexKey(userContext.TenantId, userContext.UserId)
+	if sm.userValidator != nil {
+		if err := sm.userValidator(ctx, userContext.TenantId, userContext.UserId); err != nil {
+			if !errors.Is(err, ErrUserUnavailable) {
+				return nil, fmt.Errorf("校验用户状态失败: %w", err)
+			}
+			if err := sm.RevokeSession(ctx, userContext.TenantId, userContext.UserId, data.SessionId); err != nil {
+				_ = redisCache.Delete(ctx, refreshTokenPrefix+refreshHash)
+			}
+			return nil, ErrRefreshTokenInvalid
+		}
+	}
+
+	// 标记令牌已使用，并发请求中只有第一个可以继续
+	ok, err := redisCache.SetNXString(ctx, refreshTokenUsedPrefix+refreshHash, data.SessionId, time.Minute)
+	if err != nil {
+		return nil, fmt.Errorf("标记刷新令牌失败: %w", err)
+	}
+	if !ok {
+		return nil, ErrRefreshTokenInvalid
+	}
+
+	// 会话被撤销时索引项已删除
+	entry, err := sm.getIndexEntry(ctx, indexKey, data.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil || entry.RefreshHash != refreshHash {
+		_ = redisCache.Delete(ctx, refreshTokenPrefix+refreshHash)
+		return nil, ErrRefreshTokenInvalid
+	}
+
+	sessionId, err := sm.generateSessionId()
+	if err != nil {
+		return nil, fmt.Errorf("生成session ID失败: %w", err)
+	}
+	now := time.Now()
+	expireAt := now.Add(sm.policy.AccessExpire)
+	userContext.SessionId = sessionId
+	userContext.LastActivity = &now
+	userContext.ExpireAt = &expireAt
+
+	if err := sm.storeUserContext(ctx, sessionId, userContext, sm.policy.AccessExpire); err != nil {
+		return nil, fmt.Errorf("存储用户上下文失败: %w", err)
+	}
+	token, err := sm.issueRefreshToken(ctx, userContext)
+	if err != nil {
+		_ = redisCache.Delete(ctx, sm.prefix+sessionId)
+		return nil, err
+	}
+
+	// 撤销原访问令牌和刷新令牌
+	_ = redisCache.Delete(ctx, sm.prefix+data.SessionId)
+	_ = redisCache.Delete(ctx, refreshTokenPrefix+refreshHash)
+	if _, err := redisCache.HDel(ctx, indexKey, data.SessionId); err != nil {
+		logger.WarnWithTrace(ctx, "删除会话索引失败", "error", err, "sessionId", data.SessionId)
+	}
+
+	logger.Info("刷新令牌成功", "userId", userContext.UserId, "tenantId", userContext.TenantId, "sessionId", sessionId)
+	return token, nil
+}
+
+// ListUserSessions 获取用户的所有会话
+//
+// 参数说明:
+//   - ctx: 上下文对象
+//   - tenantId: 租户ID
+//   - userId: 用户ID
+//
+// 返回值:
+//   - []*SessionInfo: 会话列表，按登录时间倒序
+//   - error: 获取失败时返回错误
+func (sm *SessionManager) ListUserSessions(ctx context.Context, tenantId, userId string) ([]*SessionInfo, error) {
+	entries, err := sm.loadIndex(ctx, userSessionIndexKey(tenantId, userId))
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]*SessionInfo, 0, len(entries))
+	for _, entry := range entries {
+		sessions = append(sessions, sm.sessionInfo(ctx, tenantId, userId, entry))
+	}
+	sortSessionInfos(sessions)
+	return sessions, nil
+}
+
+// ListTenantSessions 获取租户下所有用户的会话
+//
+// 参数说明:
+//   - ctx: 上下文对象
+//   - tenantId: 租户ID
+//
+// 返回值:
+//   - []*SessionInfo: 会话列表，按登录时间倒序
+//   - error: 获取失败时返回错误
+//
+// 注意事项:
+//   - 使用KEYS命令查找用户会话索引，在用户量很大时可能影响性能
+func (sm *SessionManager) ListTenantSessions(ctx context.Context, tenantId string) ([]*SessionInfo, error) {
+	redisCache := sm.cacheManager.GetCache("default")
+	if redisCache == nil {
+		return nil, fmt.Errorf("Redis缓存未初始化")
+	}
+
+	prefix := userSessionIndexKey(tenantId, "")
+	keys, err := redisCache.Keys(ctx, prefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("获取会话索引失败: %w", err)
+	}
+
+	var sessions []*SessionInfo
+	for _, key := range keys {
+		userId := key[len(prefix):]
+		userSessions, err := sm.ListUserSessions(ctx, tenantId, userId)
+		if err != nil {
+			logger.WarnWithTrace(ctx, "获取用户会话失败", "error", err, "tenantId", tenantId, "userId", userId)
+			continue
+		}
+		sessions = append(sessions, userSessions...)
+	}
+	sortSessionInfos(sessions)
+	return sessions, nil
+}
+
+// RevokeSession 撤销用户的指定会话，访问令牌和刷新令牌同时失效
+//
+// 参数说明:
+//   - ctx: 上下文对象
+//   - tenantId: 租户ID
+//   - userId: 用户ID
+//   - sessionId: 要撤销的session ID
+//
+// 返回值:
+//   - error: 会话不属于该用户或不存在时返回 ErrSessionNotFound
+func (sm *SessionManager) RevokeSession(ctx context.Context, tenantId, userId, sessionId string) error {
+	indexKey := userSessionIndexKey(tenantId, userId)
+	entry, err := sm.getIndexEntry(ctx, indexKey, sessionId)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return ErrSessionNotFound
+	}
+	if err := sm.revokeIndexEntry(ctx, indexKey, entry); err != nil {
+		return err
+	}
+	logger.Info("会话已撤销", "sessionId", sessionId, "userId", userId, "tenantId", tenantId)
+	return nil
+}
+
+// enforceSessionLimit 创建会话前检查用户会话数
+// 已达上限时按策略拒绝登录或撤销最早登录的会话
+func (sm *SessionManager) enforceSessionLimit(ctx context.Context, tenantId, userId string) error {
+	limit := sm.policy.MaxConcurrentSessions
+	if limit <= 0 {
+		return nil
+	}
+	indexKey := userSessionIndexKey(tenantId, userId)
+	entries, err := sm.loadIndex(ctx, indexKey)
+	if err != nil {
+		return err
+	}
+	if len(entries) < limit {
+		return nil
+	}
+	if sm.policy.LimitPolicy == SessionLimitReject {
+		return ErrSessionLimitExceeded
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return timeValue(entries[i].LoginTime).Before(timeValue(entries[j].LoginTime))
+	})
+	for _, entry := range entries[:len(entries)-limit+1] {
+		if err := sm.revokeIndexEntry(ctx, indexKey, entry); err != nil {
+			return err
+		}
+		logger.Info("超过最大会话数，踢出最早登录的会话", "sessionId", entry.SessionId, "userId", userId, "tenantId", tenantId)
+	}
+	return nil
+}
+
+// issueRefreshToken 为会话签发刷新令牌并写入用户会话索引
+func (sm *SessionManager) issueRefreshToken(ctx context.Context, userContext *globalmodels.UserContext) (*SessionToken, error) {
+	redisCache := sm.cacheManager.GetCache("default")
+	if redisCache == nil {
+		return nil, fmt.Errorf("Redis缓存未初始化")
+	}
+
+	refreshToken, err := sm.generateSessionId()
+	if err != nil {
+		return nil, fmt.Errorf("生成刷新令牌失败: %w", err)
+	}
+	refreshHash := hashRefreshToken(refreshToken)
+	refreshExpireAt := time.Now().Add(sm.policy.RefreshExpire)
+
+	data, err := json.Marshal(&refreshTokenData{
+		SessionId:   userContext.SessionId,
+		UserContext: userContext,
+		ExpireAt:    refreshExpireAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("序列化刷新令牌失败: %w", err)
+	}
+	if err := redisCache.SetString(ctx, refreshTokenPrefix+refreshHash, string(data), sm.policy.RefreshExpire); err != nil {
+		return nil, fmt.Errorf("存储刷新令牌失败: %w", err)
+	}
+
+	entry, err := json.Marshal(&sessionIndexEntry{
+		SessionId:       userContext.SessionId,
+		RefreshHash:     refreshHash,
+		UserName:        userContext.UserName,
+		RealName:        userContext.RealName,
+		LoginTime:       userContext.LoginTime,
+		RefreshExpireAt: refreshExpireAt,
+		ClientIP:        userContext.ClientIP,
+		UserAgent:       userContext.UserAgent,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("序列化会话索引失败: %w", err)
+	}
+	indexKey := userSessionIndexKey(userContext.TenantId, userContext.UserId)
+	if err := redisCache.HSet(ctx, indexKey, userContext.SessionId, string(entry)); err != nil {
+		_ = redisCache.Delete(ctx, refreshTokenPrefix+refreshHash)
+		return nil, fmt.Errorf("存储会话索引失败: %w", err)
+	}
+	if _, err := redisCache.Expire(ctx, indexKey, sm.policy.RefreshExpire); err != nil {
+		logger.WarnWithTrace(ctx, "设置会话索引过期时间失败", "error", err, "key", indexKey)
+	}
+
+	return &SessionToken{
+		UserContext:     userContext,
+		RefreshToken:    refreshToken,
+		RefreshExpireAt: refreshExpireAt,
+	}, nil
+}
+
+// loadIndex 获取用户会话索引，同时清理刷新令牌已过期的索引项
+func (sm *SessionManager) loadIndex(ctx context.Context, indexKey string) ([]*sessionIndexEntry, error) {
+	redisCache := sm.cacheManager.GetCache("default")
+	if redisCache == nil {
+		return nil, fmt.Errorf("Redis缓存未初始化")
+	}
+
+	fields, err := redisCache.HGetAll(ctx, indexKey)
+	if err != nil {
+		return nil, fmt.Errorf("获取会话索引失败: %w", err)
+	}
+
+	now := time.Now()
+	entries := make([]*sessionIndexEntry, 0, len(fields))
+	var stale []string
+	for sessionId, value := range fields {
+		var entry sessionIndexEntry
+		if err := json.Unmarshal([]byte(value), &entry); err != nil || now.After(entry.RefreshExpireAt) {
+			stale = append(stale, sessionId)
+			continue
+		}
+		entry.SessionId = sessionId
+		entries = append(entries, &entry)
+	}
+	if len(stale) > 0 {
+		if _, err := redisCache.HDel(ctx, indexKey, stale...); err != nil {
+			logger.WarnWithTrace(ctx, "清理过期会话索引失败", "error", err, "key", indexKey)
+		}
+	}
+	return entries, nil
+}
+
+// getIndexEntry 获取用户会话索引中的指定会话，不存在或刷新令牌已过期时返回nil
+func (sm *SessionManager) getIndexEntry(ctx context.Context, indexKey, sessionId string) (*sessionIndexEntry, error) {
+	redisCache := sm.cacheManager.GetCache("default")
+	if redisCache == nil {
+		return nil, fmt.Errorf("Redis缓存未初始化")
+	}
+
+	value, err := redisCache.HGet(ctx, indexKey, sessionId)
+	if err != nil {
+		return nil, fmt.Errorf("获取会话索引失败: %w", err)
+	}
+	if value == "" {
+		return nil, nil
+	}
+	var entry sessionIndexEntry
+	if err := json.Unmarshal([]byte(value), &entry); err != nil || time.Now().After(entry.RefreshExpireAt) {
+		return nil, nil
+	}
+	entry.SessionId = sessionId
+	return &entry, nil
+}
+
+// revokeIndexEntry 删除会话的访问令牌、刷新令牌和索引项
+func (sm *SessionManager) revokeIndexEntry(ctx context.Context, indexKey string, entry *sessionIndexEntry) error {
+	redisCache := sm.cacheManager.GetCache("default")
+	if redisCache == nil {
+		return fmt.Errorf("Redis缓存未初始化")
+	}
+
+	keys := []string{sm.prefix + entry.SessionId}
+	if entry.RefreshHash != "" {
+		keys = append(keys, refreshTokenPrefix+entry.RefreshHash)
+	}
+	if err := redisCache.MDelete(ctx, keys); err != nil {
+		return fmt.Errorf("撤销会话失败: %w", err)
+	}
+	if _, err := redisCache.HDel(ctx, indexKey, entry.SessionId); err != nil {
+		return fmt.Errorf("删除会话索引失败: %w", err)
+	}
+	return nil
+}
+
+// sessionInfo 组装会话信息，访问令牌有效时补充最后活动时间和过期时间
+func (sm *SessionManager) sessionInfo(ctx context.Context, tenantId, userId string, entry *sessionIndexEntry) *SessionInfo {
+	info := &SessionInfo{
+		SessionId:       entry.SessionId,
+		UserId:          userId,
+		TenantId:        tenantId,
+		UserName:        entry.UserName,
+		RealName:        entry.RealName,
+		LoginTime:       entry.LoginTime,
+		RefreshExpireAt: entry.RefreshExpireAt,
+		ClientIP:        entry.ClientIP,
+		UserAgent:       entry.UserAgent,
+	}
+	userContext, err := sm.getUserContext(ctx, entry.SessionId)
+	if err == nil && (userContext.ExpireAt == nil || time.Now().Before(*userContext.ExpireAt)) {
+		info.LastActivity = userContext.LastActivity
+		info.ExpireAt = userContext.ExpireAt
+		info.Active = true
+	}
+	return info
+}
+
+// userSessionIndexKey 用户会话索引key
+func userSessionIndexKey(tenantId, userId string) string {
+	return userSessionIndexPrefix + tenantId + ":" + userId
+}
+
+// hashRefreshToken 计算刷新令牌摘要
+func hashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// sortSessionInfos 按登录时间倒序排列会话
+func sortSessionInfos(sessions []*SessionInfo) {
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return timeValue(sessions[i].LoginTime).After(timeValue(sessions[j].LoginTime))
+	})
+}
+
+// timeValue 获取时间指针的值，nil时返回零值
+func timeValue(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
@@ -0,0 +1,172 @@
+// Package validation 根据模型的 binding 标签校验请求参数
+//
+// 校验规则沿用 gin 绑定使用的 validator 标签（required、min、max、oneof 等），
+// 校验失败时返回 *Error，包含每个字段的错误明细，字段名取 json 标签（没有时取 form 标签）
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	Field   string `json:"field"`           // 字段路径，如 pageSize、logItems[0].traceId
+	Rule    string `json:"rule"`            // 校验规则，如 required、min
+	Param   string `json:"param,omitempty"` // 规则参数，如 min=1 中的 1
+	Message string `json:"message"`         // 错误描述
+}
+
+// Error 请求参数校验错误
+type Error struct {
+	Fields []FieldError `json:"fields"`
+}
+
+// Error 实现 error 接口，多个字段的错误描述以分号连接
+func (e *Error) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+func init() {
+	// 错误中的字段名使用请求中的参数名，而不是Go字段名
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		engine.RegisterTagNameFunc(paramName)
+	}
+}
+
+// Validate 按 binding 标签校验结构体
+// 参数:
+//
+//	obj: 结构体或结构体指针
+//
+// 返回:
+//
+//	error: 校验失败时为 *Error，其他错误原样返回
+func Validate(obj interface{}) error {
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		if validationErr, ok := FromError(err); ok {
+			return validationErr
+		}
+		return err
+	}
+	return nil
+}
+
+// FromError 将绑定或校验返回的错误转换为 *Error
+// 参数:
+//
+//	err: gin 绑定或 validator 返回的错误
+//
+// 返回:
+//
+//	*Error: 校验错误
+//	bool: 是否为校验错误，JSON解析失败等其他错误返回false
+func FromError(err error) (*Error, bool) {
+	var validationErr *Error
+	if errors.As(err, &validationErr) {
+		return validationErr, true
+	}
+
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		// 切片类型的模型，gin 返回 SliceValidationError
+		var sliceErrors binding.SliceValidationError
+		if !errors.As(err, &sliceErrors) {
+			return nil, false
+		}
+		result := &Error{}
+		for _, item := range sliceErrors {
+			if itemErr, ok := FromError(item); ok {
+				result.Fields = append(result.Fields, itemErr.Fields...)
+			}
+		}
+		return result, len(result.Fields) > 0
+	}
+
+	result := &Error{Fields: make([]FieldError, 0, len(fieldErrors))}
+	for _, fieldErr := range fieldErrors {
+		field := fieldPath(fieldErr.Namespace())
+		result.Fields = append(result.Fields, FieldError{
+			Field:   field,
+			Rule:    fieldErr.Tag(),
+			Param:   fieldErr.Param(),
+			Message: field + " " + ruleMessage(fieldErr),
+		})
+	}
+	return result, true
+}
+
+// paramName 字段的参数名，按 json 标签、form 标签、字段名的顺序选取
+func paramName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		name := strings.Split(field.Tag.Get(key), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// fieldPath 去掉命名空间中的顶层结构体名，如 GatewayAccessLogResetRequest.logItems[0].traceId 转为 logItems[0].traceId
+func fieldPath(namespace string) string {
+	if idx := strings.Index(namespace, "."); idx >= 0 {
+		return namespace[idx+1:]
+	}
+	return namespace
+}
+
+// ruleMessage 校验规则的错误描述
+func ruleMessage(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	// 字符串、切片、映射的 min/max/len 校验的是长度
+	sized := false
+	switch fieldErr.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		sized = true
+	}
+
+	switch fieldErr.Tag() {
+	case "required":
+		return "不能为空"
+	case "min", "gte":
+		if sized {
+			return "长度不能小于 " + param
+		}
+		return "不能小于 " + param
+	case "max", "lte":
+		if sized {
+			return "长度不能大于 " + param
+		}
+		return "不能大于 " + param
+	case "gt":
+		return "必须大于 " + param
+	case "lt":
+		return "必须小于 " + param
+	case "len":
+		return "长度必须为 " + param
+	case "oneof":
+		return "必须是以下值之一: " + strings.Join(strings.Fields(param), ", ")
+	case "email":
+		return "不是有效的邮箱地址"
+	case "url":
+		return "不是有效的URL"
+	case "ip":
+		return "不是有效的IP地址"
+	default:
+		if param != "" {
+			return "不满足校验规则 " + fieldErr.Tag() + "=" + param
+		}
+		return "不满足校验规则 " + fieldErr.Tag()
+	}
+}
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"gateway/pkg/config"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConfigInspectController 配置查看控制器
+// 查询当前进程配置项的生效值及来源，比较配置文件当前内容与启动时加载的内容，用于排查配置问题
+type ConfigInspectController struct{}
+
+// NewConfigInspectController 创建配置查看控制器
+func NewConfigInspectController() *ConfigInspectController {
+	return &ConfigInspectController{}
+}
+
+// InspectConfig 查询配置项的生效值及来源
+// @Summary 查询配置生效值
+// @Description 指定key时返回该配置项的生效值、来源（env/file/runtime/default）、生效的配置文件和可覆盖的环境变量；未指定key时按prefix返回配置项列表。敏感配置项的值已脱敏
+// @Tags 配置查看
+// @Accept json
+// @Produce json
+// @Param key query string false "配置键，如 app.gateway.enabled"
+// @Param prefix query string false "配置键前缀，如 database.connections，key为空时生效"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0000/config/inspect [post]
+func (c *ConfigInspectController) InspectConfig(ctx *gin.Context) {
+	if key := request.GetParam(ctx, "key"); key != "" {
+		response.SuccessJSON(ctx, config.Inspect(key), constants.SD00002)
+		return
+	}
+
+	items := config.InspectAll(request.GetParam(ctx, "prefix"))
+	response.SuccessJSON(ctx, gin.H{
+		"files": config.LoadedConfigFiles(),
+		"items": items,
+		"total": len(items),
+	}, constants.SD00002)
+}
+
+// DiffConfigFiles 比较配置文件当前内容与启动时加载的内容
+// @Summary 配置文件差异
+// @Description 配置文件修改后需重启才会生效，返回启动后文件中新增、删除和修改的配置项，敏感配置项的值已脱敏
+// @Tags 配置查看
+// @Produce json
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0000/config/diff [post]
+func (c *ConfigInspectController) DiffConfigFiles(ctx *gin.Context) {
+	diffs, err := config.DiffLoadedFiles()
+	if err != nil {
+		response.ErrorJSON(ctx, "比较配置文件失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	response.SuccessJSON(ctx, gin.H{
+		"files":   config.LoadedConfigFiles(),
+		"changed": len(diffs) > 0,
+		"diffs":   diffs,
+	}, constants.SD00002)
+}
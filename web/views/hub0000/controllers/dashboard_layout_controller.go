@@ -0,0 +1,273 @@
+package controllers
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/pkg/utils/random"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0000/dao"
+	"gateway/web/views/hub0000/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DashboardLayoutController 看板布局控制器
+// 保存用户的监控看板布局和组件配置，共享的布局可通过布局ID查看和复制
+type DashboardLayoutController struct {
+	dao *dao.DashboardLayoutDAO
+}
+
+// NewDashboardLayoutController 创建看板布局控制器
+func NewDashboardLayoutController(db database.Database) *DashboardLayoutController {
+	return &DashboardLayoutController{
+		dao: dao.NewDashboardLayoutDAO(db),
+	}
+}
+
+// QueryLayouts 分页查询当前用户可见的看板布局（本人创建的和共享的）
+func (c *DashboardLayoutController) QueryLayouts(ctx *gin.Context) {
+	page, pageSize := request.GetPaginationParams(ctx)
+	tenantId := request.GetTenantID(ctx)
+	userId := request.GetUserID(ctx)
+
+	var q models.DashboardLayoutListRequest
+	if err := request.BindSafely(ctx, &q); err != nil {
+		logger.WarnWithTrace(ctx, "绑定看板布局筛选条件失败，使用默认条件", "error", err.Error())
+	}
+
+	rows, total, err := c.dao.QueryLayouts(ctx, tenantId, userId, &q, page, pageSize)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询看板布局失败", "error", err)
+		response.ErrorJSON(ctx, "查询看板布局失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	pageInfo := response.NewPageInfo(page, pageSize, total)
+	pageInfo.MainKey = "layoutId"
+	response.PageJSON(ctx, rows, pageInfo, constants.SD00002)
+}
+
+// GetLayout 根据布局ID获取看板布局，可获取本人创建的或共享的布局
+func (c *DashboardLayoutController) GetLayout(ctx *gin.Context) {
+	layout, ok := c.loadVisible(ctx)
+	if !ok {
+		return
+	}
+	response.SuccessJSON(ctx, layout, constants.SD00001)
+}
+
+// GetDefaultLayout 获取当前用户在指定看板的默认布局
+// 未设置默认布局时返回本人最近修改的布局，本人没有布局时返回空数据，前端使用内置布局
+func (c *DashboardLayoutController) GetDefaultLayout(ctx *gin.Context) {
+	dashboardCode := strings.TrimSpace(request.GetParam(ctx, "dashboardCode"))
+	if dashboardCode == "" {
+		response.ErrorJSON(ctx, "dashboardCode不能为空", constants.ED00007)
+		return
+	}
+
+	layout, err := c.dao.GetDefaultLayout(ctx, request.GetTenantID(ctx), request.GetUserID(ctx), dashboardCode)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取默认看板布局失败", "error", err)
+		response.ErrorJSON(ctx, "获取默认看板布局失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	response.SuccessJSON(ctx, layout, constants.SD00001)
+}
+
+// AddLayout 保存看板布局
+func (c *DashboardLayoutController) AddLayout(ctx *gin.Context) {
+	var req models.DashboardLayout
+	if err := request.BindSafely(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	req.TenantId = request.GetTenantID(ctx)
+	req.LayoutId = random.Generate32BitRandomString()
+	c.fillCreateFields(&req, request.GetOperatorID(ctx))
+	if msg, code := c.normalize(&req); msg != "" {
+		response.ErrorJSON(ctx, msg, code)
+		return
+	}
+
+	if err := c.dao.CreateLayout(ctx, &req); err != nil {
+		logger.ErrorWithTrace(ctx, "创建看板布局失败", "error", err)
+		response.ErrorJSON(ctx, "创建看板布局失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	response.SuccessJSON(ctx, req, constants.SD00003)
+}
+
+// UpdateLayout 更新看板布局，仅创建人可修改
+func (c *DashboardLayoutController) UpdateLayout(ctx *gin.Context) {
+	var req models.DashboardLayout
+	if err := request.BindSafely(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+	if strings.TrimSpace(req.LayoutId) == "" {
+		response.ErrorJSON(ctx, "layoutId不能为空", constants.ED00007)
+		return
+	}
+
+	current, ok := c.loadOwned(ctx, req.LayoutId)
+	if !ok {
+		return
+	}
+
+	// 保留创建信息（避免被覆盖）
+	req.TenantId = current.TenantId
+	req.OwnerUserId = current.OwnerUserId
+	req.AddTime = current.AddTime
+	req.AddWho = current.AddWho
+	req.OprSeqFlag = random.Generate32BitRandomString()
+	req.CurrentVersion = current.CurrentVersion + 1
+	req.ActiveFlag = "Y"
+	req.EditTime = time.Now()
+	req.EditWho = request.GetOperatorID(ctx)
+	if msg, code := c.normalize(&req); msg != "" {
+		response.ErrorJSON(ctx, msg, code)
+		return
+	}
+
+	if err := c.dao.UpdateLayout(ctx, &req); err != nil {
+		logger.ErrorWithTrace(ctx, "更新看板布局失败", "error", err)
+		response.ErrorJSON(ctx, "更新看板布局失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	response.SuccessJSON(ctx, req, constants.SD00004)
+}
+
+// DeleteLayout 删除看板布局，仅创建人可删除
+func (c *DashboardLayoutController) DeleteLayout(ctx *gin.Context) {
+	layoutId := request.GetParam(ctx, "layoutId")
+	if strings.TrimSpace(layoutId) == "" {
+		response.ErrorJSON(ctx, "layoutId不能为空", constants.ED00007)
+		return
+	}
+	if _, ok := c.loadOwned(ctx, layoutId); !ok {
+		return
+	}
+
+	if err := c.dao.DeleteLayout(ctx, request.GetTenantID(ctx), layoutId, request.GetOperatorID(ctx)); err != nil {
+		logger.ErrorWithTrace(ctx, "删除看板布局失败", "error", err)
+		response.ErrorJSON(ctx, "删除看板布局失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	response.SuccessJSON(ctx, gin.H{"layoutId": layoutId}, constants.SD00005)
+}
+
+// CopyLayout 将本人创建的或共享的布局复制为本人的新布局，复制的布局不共享、不作为默认布局
+func (c *DashboardLayoutController) CopyLayout(ctx *gin.Context) {
+	source, ok := c.loadVisible(ctx)
+	if !ok {
+		return
+	}
+
+	layout := *source
+	layout.LayoutId = random.Generate32BitRandomString()
+	if layoutName := strings.TrimSpace(request.GetParam(ctx, "layoutName")); layoutName != "" {
+		layout.LayoutName = layoutName
+	}
+	layout.ShareFlag = "N"
+	layout.DefaultFlag = "N"
+	layout.NoteText = ""
+	c.fillCreateFields(&layout, request.GetOperatorID(ctx))
+
+	if err := c.dao.CreateLayout(ctx, &layout); err != nil {
+		logger.ErrorWithTrace(ctx, "复制看板布局失败", "error", err)
+		response.ErrorJSON(ctx, "复制看板布局失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	response.SuccessJSON(ctx, layout, constants.SD00003)
+}
+
+// fillCreateFields 填充新建布局的所属用户和通用字段
+func (c *DashboardLayoutController) fillCreateFields(layout *models.DashboardLayout, operatorId string) {
+	now := time.Now()
+	layout.OwnerUserId = operatorId
+	layout.AddTime = now
+	layout.AddWho = operatorId
+	layout.EditTime = now
+	layout.EditWho = operatorId
+	layout.OprSeqFlag = random.Generate32BitRandomString()
+	layout.CurrentVersion = 1
+	layout.ActiveFlag = "Y"
+}
+
+// loadVisible 根据请求中的 layoutId 加载当前用户可见的看板布局，失败时已写入错误响应
+func (c *DashboardLayoutController) loadVisible(ctx *gin.Context) (*models.DashboardLayout, bool) {
+	layoutId := request.GetParam(ctx, "layoutId")
+	if strings.TrimSpace(layoutId) == "" {
+		response.ErrorJSON(ctx, "layoutId不能为空", constants.ED00007)
+		return nil, false
+	}
+
+	layout, err := c.dao.GetLayout(ctx, request.GetTenantID(ctx), layoutId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取看板布局失败", "error", err)
+		response.ErrorJSON(ctx, "获取看板布局失败: "+err.Error(), constants.ED00009)
+		return nil, false
+	}
+	if layout == nil || (layout.OwnerUserId != request.GetUserID(ctx) && layout.ShareFlag != "Y") {
+		response.ErrorJSON(ctx, "看板布局不存在", constants.ED00008)
+		return nil, false
+	}
+	return layout, true
+}
+
+// loadOwned 加载当前用户创建的看板布局，失败时已写入错误响应
+func (c *DashboardLayoutController) loadOwned(ctx *gin.Context, layoutId string) (*models.DashboardLayout, bool) {
+	layout, err := c.dao.GetLayout(ctx, request.GetTenantID(ctx), layoutId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取看板布局失败", "error", err)
+		response.ErrorJSON(ctx, "获取看板布局失败: "+err.Error(), constants.ED00009)
+		return nil, false
+	}
+	if layout == nil {
+		response.ErrorJSON(ctx, "看板布局不存在", constants.ED00008)
+		return nil, false
+	}
+	if layout.OwnerUserId != request.GetUserID(ctx) {
+		response.ErrorJSON(ctx, "只能修改本人创建的看板布局", constants.ED00010)
+		return nil, false
+	}
+	return layout, true
+}
+
+// normalize 校验并补全看板布局的字段，返回错误信息和错误码，校验通过时错误信息为空
+func (c *DashboardLayoutController) normalize(layout *models.DashboardLayout) (string, string) {
+	layout.LayoutName = strings.TrimSpace(layout.LayoutName)
+	if layout.LayoutName == "" {
+		return "layoutName不能为空", constants.ED00007
+	}
+	layout.DashboardCode = strings.TrimSpace(layout.DashboardCode)
+	if layout.DashboardCode == "" {
+		return "dashboardCode不能为空", constants.ED00007
+	}
+	if strings.TrimSpace(layout.LayoutConfig) != "" && !json.Valid([]byte(layout.LayoutConfig)) {
+		return "layoutConfig必须是JSON格式", constants.ED00006
+	}
+	if err := models.ValidateDashboardWidgets(layout.WidgetConfig); err != nil {
+		return err.Error(), constants.ED00006
+	}
+	if layout.TimeRangeMinutes == 0 {
+		layout.TimeRangeMinutes = models.DashboardDefaultTimeRangeMinutes
+	}
+	if err := models.ValidateDashboardTiming(layout.TimeRangeMinutes, layout.RefreshIntervalSeconds); err != nil {
+		return err.Error(), constants.ED00006
+	}
+	if layout.ShareFlag != "Y" {
+		layout.ShareFlag = "N"
+	}
+	if layout.DefaultFlag != "Y" {
+		layout.DefaultFlag = "N"
+	}
+	return "", ""
+}
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"strings"
+
+	"gateway/web/utils/constants"
+	"gateway/web/utils/i18n"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EnumLabelController 枚举显示名控制器
+// 按请求语言返回健康等级、告警级别等枚举值的显示名，供前端展示
+type EnumLabelController struct{}
+
+// NewEnumLabelController 创建枚举显示名控制器
+func NewEnumLabelController() *EnumLabelController {
+	return &EnumLabelController{}
+}
+
+// QueryEnumLabels 查询枚举显示名
+// @Summary 查询枚举显示名
+// @Description 按 Accept-Language 返回枚举值的显示名，enumTypes 为逗号分隔的枚举类型，为空时返回全部
+// @Tags 多语言
+// @Produce json
+// @Param enumTypes query string false "枚举类型，如 healthGrade,alertLevel"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0000/queryEnumLabels [post]
+func (c *EnumLabelController) QueryEnumLabels(ctx *gin.Context) {
+	var enumTypes []string
+	for _, enumType := range strings.Split(request.GetParam(ctx, "enumTypes"), ",") {
+		if enumType = strings.TrimSpace(enumType); enumType != "" {
+			enumTypes = append(enumTypes, enumType)
+		}
+	}
+
+	language := i18n.Language(ctx)
+	response.SuccessJSON(ctx, gin.H{
+		"language": language,
+		"labels":   i18n.Labels(language, enumTypes...),
+	}, constants.SD00002)
+}
@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0000/dao"
+	"gateway/web/views/hub0000/models"
+	hub0023models "gateway/web/views/hub0023/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessLogLookup 按链路追踪ID查找访问日志，gatewayInstanceId 用于解析日志存储查询方式
+type AccessLogLookup func(ctx context.Context, tenantId, gatewayInstanceId, traceId string) (*hub0023models.GatewayAccessLog, error)
+
+// GlobalSearchController 全局搜索控制器
+// 按关键字同时搜索服务、路由、网关实例、JVM资源和访问日志，便于从链路追踪ID跳转到完整上下文
+type GlobalSearchController struct {
+	dao             *dao.GlobalSearchDAO
+	accessLogLookup AccessLogLookup
+}
+
+// NewGlobalSearchController 创建全局搜索控制器
+// 参数:
+//
+//	db: 数据库实例
+//	accessLogLookup: 访问日志查找函数，为nil时不搜索访问日志
+func NewGlobalSearchController(db database.Database, accessLogLookup AccessLogLookup) *GlobalSearchController {
+	return &GlobalSearchController{
+		dao:             dao.NewGlobalSearchDAO(db),
+		accessLogLookup: accessLogLookup,
+	}
+}
+
+// GlobalSearch 全局搜索
+// @Summary 全局搜索
+// @Description 按关键字搜索服务、路由、网关实例和JVM资源，关键字为链路追踪ID时同时返回对应的访问日志
+// @Tags 全局搜索
+// @Accept json
+// @Produce json
+// @Param request body models.GlobalSearchRequest true "搜索条件"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0000/globalSearch [post]
+func (c *GlobalSearchController) GlobalSearch(ctx *gin.Context) {
+	var req models.GlobalSearchRequest
+	if err := request.BindSafely(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	keyword := strings.TrimSpace(req.Keyword)
+	if keyword == "" {
+		response.ErrorJSON(ctx, "搜索关键字不能为空", constants.ED00006)
+		return
+	}
+
+	// 强制从上下文获取租户ID
+	tenantId := request.GetTenantID(ctx)
+	if tenantId == "" {
+		response.ErrorJSON(ctx, "无法获取租户信息", constants.ED00007)
+		return
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = models.SearchDefaultLimit
+	}
+	if limit > models.SearchMaxLimit {
+		limit = models.SearchMaxLimit
+	}
+
+	hits := make([]models.GlobalSearchHit, 0)
+	for _, searchType := range ResolveSearchTypes(req.Types) {
+		typeHits, err := c.search(ctx, searchType, tenantId, req.GatewayInstanceId, keyword, limit)
+		if err != nil {
+			// 单个类型搜索失败不影响其他类型的结果
+			logger.ErrorWithTrace(ctx, "全局搜索失败", "type", searchType, "error", err)
+			continue
+		}
+		hits = append(hits, typeHits...)
+	}
+
+	response.SuccessJSON(ctx, gin.H{
+		"keyword": keyword,
+		"total":   len(hits),
+		"hits":    hits,
+	}, constants.SD00002)
+}
+
+// search 按命中类型执行搜索
+func (c *GlobalSearchController) search(ctx *gin.Context, searchType, tenantId, gatewayInstanceId, keyword string, limit int) ([]models.GlobalSearchHit, error) {
+	switch searchType {
+	case models.SearchTypeService:
+		return c.dao.SearchServices(ctx, tenantId, keyword, limit)
+	case models.SearchTypeRoute:
+		return c.dao.SearchRoutes(ctx, tenantId, keyword, limit)
+	case models.SearchTypeGatewayInstance:
+		return c.dao.SearchGatewayInstances(ctx, tenantId, keyword, limit)
+	case models.SearchTypeJvmResource:
+		return c.dao.SearchJvmResources(ctx, tenantId, keyword, limit)
+	case models.SearchTypeAccessLog:
+		return c.searchAccessLog(ctx, tenantId, gatewayInstanceId, keyword), nil
+	}
+	return nil, nil
+}
+
+// searchAccessLog 按链路追踪ID精确查找访问日志
+// 链路追踪ID不包含空白字符，关键字含空白时不查找；日志不存在时各存储均返回错误，按未命中处理
+func (c *GlobalSearchController) searchAccessLog(ctx *gin.Context, tenantId, gatewayInstanceId, traceId string) []models.GlobalSearchHit {
+	if c.accessLogLookup == nil || strings.ContainsAny(traceId, " \t\r\n") {
+		return nil
+	}
+
+	accessLog, err := c.accessLogLookup(ctx, tenantId, strings.TrimSpace(gatewayInstanceId), traceId)
+	if err != nil || accessLog == nil {
+		logger.DebugWithTrace(ctx, "全局搜索未找到访问日志", "traceId", traceId, "error", err)
+		return nil
+	}
+
+	return []models.GlobalSearchHit{{
+		Type:       models.SearchTypeAccessLog,
+		Id:         accessLog.TraceId,
+		Title:      accessLog.RequestMethod + " " + accessLog.RequestPath,
+		Subtitle:   strings.TrimSpace(accessLog.GatewayInstanceName + " " + accessLog.RouteName),
+		ModuleCode: "hub0023",
+		Keys: map[string]string{
+			"traceId":           accessLog.TraceId,
+			"gatewayInstanceId": accessLog.GatewayInstanceId,
+			"routeConfigId":     accessLog.RouteConfigId,
+		},
+	}}
+}
+
+// ResolveSearchTypes 解析请求的命中类型，忽略不支持的类型并去重，为空时返回全部类型
+// 返回的类型按 models.SearchTypes 的顺序排列
+func ResolveSearchTypes(types []string) []string {
+	if len(types) == 0 {
+		return models.SearchTypes
+	}
+
+	requested := make(map[string]bool, len(types))
+	for _, t := range types {
+		requested[strings.ToUpper(strings.TrimSpace(t))] = true
+	}
+
+	resolved := make([]string, 0, len(models.SearchTypes))
+	for _, t := range models.SearchTypes {
+		if requested[t] {
+			resolved = append(resolved, t)
+		}
+	}
+	return resolved
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"gateway/pkg/logger"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LogLevelController 日志级别控制器
+// 运行时调整当前进程的全局或模块日志级别，无需重启
+type LogLevelController struct{}
+
+// NewLogLevelController 创建日志级别控制器
+func NewLogLevelController() *LogLevelController {
+	return &LogLevelController{}
+}
+
+// QueryLogLevel 查询当前日志级别
+// @Summary 查询日志级别
+// @Description 返回全局日志级别和按模块设置的日志级别
+// @Tags 日志级别管理
+// @Produce json
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0000/log/level/query [post]
+func (c *LogLevelController) QueryLogLevel(ctx *gin.Context) {
+	response.SuccessJSON(ctx, gin.H{
+		"level":        logger.GetLevel(),
+		"moduleLevels": logger.GetModuleLevels(),
+	}, constants.SD00002)
+}
+
+// UpdateLogLevel 调整日志级别
+// @Summary 调整日志级别
+// @Description 未指定module时调整全局级别，指定module时只调整该模块（包路径，如 internal/gateway/handler/proxy）
+// @Tags 日志级别管理
+// @Accept json
+// @Produce json
+// @Param level query string true "日志级别: debug, info, warn, error"
+// @Param module query string false "模块（包路径）"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0000/log/level/update [post]
+func (c *LogLevelController) UpdateLogLevel(ctx *gin.Context) {
+	level := request.GetParam(ctx, "level")
+	if level == "" {
+		response.ErrorJSON(ctx, "日志级别不能为空", constants.ED00007)
+		return
+	}
+	module := request.GetParam(ctx, "module")
+
+	var err error
+	if module == "" {
+		err = logger.SetLevel(level)
+	} else {
+		err = logger.SetModuleLevel(module, level)
+	}
+	if err != nil {
+		response.ErrorJSON(ctx, "调整日志级别失败: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	// 使用Warn记录，保证调高级别后该操作仍有记录
+	logger.WarnWithTrace(ctx, "日志级别已调整",
+		"module", module,
+		"level", level,
+		"operator", request.GetOperatorID(ctx))
+
+	response.SuccessJSON(ctx, gin.H{
+		"level":        logger.GetLevel(),
+		"moduleLevels": logger.GetModuleLevels(),
+	}, constants.SD00004)
+}
+
+// ResetLogLevel 重置模块日志级别
+// @Summary 重置模块日志级别
+// @Description 指定module时移除该模块的设置，未指定时移除所有模块设置，恢复使用全局级别
+// @Tags 日志级别管理
+// @Accept json
+// @Produce json
+// @Param module query string false "模块（包路径）"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0000/log/level/reset [post]
+func (c *LogLevelController) ResetLogLevel(ctx *gin.Context) {
+	module := request.GetParam(ctx, "module")
+	if module == "" {
+		logger.ResetAllModuleLevels()
+	} else {
+		logger.ResetModuleLevel(module)
+	}
+
+	logger.WarnWithTrace(ctx, "模块日志级别已重置",
+		"module", module,
+		"operator", request.GetOperatorID(ctx))
+
+	response.SuccessJSON(ctx, gin.H{
+		"level":        logger.GetLevel(),
+		"moduleLevels": logger.GetModuleLevels(),
+	}, constants.SD00001)
+}
@@ -0,0 +1,193 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gateway/pkg/database"
+	"gateway/pkg/database/sqlutils"
+	"gateway/pkg/utils/empty"
+	"gateway/pkg/utils/huberrors"
+	"gateway/web/views/hub0000/models"
+)
+
+// DashboardLayoutDAO 看板布局DAO，对应表 HUB_DASHBOARD_LAYOUT
+type DashboardLayoutDAO struct {
+	db database.Database
+}
+
+// NewDashboardLayoutDAO 创建看板布局DAO
+func NewDashboardLayoutDAO(db database.Database) *DashboardLayoutDAO {
+	return &DashboardLayoutDAO{db: db}
+}
+
+// GetLayout 根据主键获取看板布局，不存在时返回nil
+func (dao *DashboardLayoutDAO) GetLayout(ctx context.Context, tenantId, layoutId string) (*models.DashboardLayout, error) {
+	if layoutId == "" {
+		return nil, errors.New("layoutId不能为空")
+	}
+
+	query := `SELECT * FROM HUB_DASHBOARD_LAYOUT WHERE tenantId = ? AND layoutId = ? AND activeFlag = 'Y'`
+	args := []interface{}{tenantId, layoutId}
+
+	var layout models.DashboardLayout
+	err := dao.db.QueryOne(ctx, &layout, query, args, true)
+	if err != nil {
+		if err == database.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, huberrors.WrapError(err, "查询看板布局失败")
+	}
+	return &layout, nil
+}
+
+// GetDefaultLayout 获取用户在指定看板的默认布局
+// 未设置默认布局时返回本人最近修改的布局，本人没有布局时返回nil
+func (dao *DashboardLayoutDAO) GetDefaultLayout(ctx context.Context, tenantId, ownerUserId, dashboardCode string) (*models.DashboardLayout, error) {
+	baseQuery := `
+		SELECT * FROM HUB_DASHBOARD_LAYOUT
+		WHERE tenantId = ? AND ownerUserId = ? AND dashboardCode = ? AND activeFlag = 'Y'
+		ORDER BY defaultFlag DESC, editTime DESC
+	`
+	args := []interface{}{tenantId, ownerUserId, dashboardCode}
+
+	paginatedQuery, paginationArgs, err := sqlutils.BuildPaginationQuery(sqlutils.GetDatabaseType(dao.db), baseQuery, sqlutils.NewPaginationInfo(1, 1))
+	if err != nil {
+		return nil, huberrors.WrapError(err, "构建分页查询失败")
+	}
+
+	var rows []*models.DashboardLayout
+	if err := dao.db.Query(ctx, &rows, paginatedQuery, append(args, paginationArgs...), true); err != nil {
+		return nil, huberrors.WrapError(err, "查询默认看板布局失败")
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return rows[0], nil
+}
+
+// QueryLayouts 分页查询用户可见的看板布局：本人创建的和租户内共享的
+func (dao *DashboardLayoutDAO) QueryLayouts(ctx context.Context, tenantId, userId string, q *models.DashboardLayoutListRequest, page, pageSize int) ([]*models.DashboardLayout, int, error) {
+	pagination := sqlutils.NewPaginationInfo(page, pageSize)
+	dbType := sqlutils.GetDatabaseType(dao.db)
+
+	whereClause := "WHERE tenantId = ? AND activeFlag = 'Y'"
+	params := []interface{}{tenantId}
+	if q != nil && q.OnlyMine == "Y" {
+		whereClause += " AND ownerUserId = ?"
+		params = append(params, userId)
+	} else {
+		whereClause += " AND (ownerUserId = ? OR shareFlag = 'Y')"
+		params = append(params, userId)
+	}
+
+	if q != nil {
+		if !empty.IsEmpty(q.LayoutName) {
+			whereClause += " AND layoutName LIKE ?"
+			params = append(params, "%"+q.LayoutName+"%")
+		}
+		if !empty.IsEmpty(q.DashboardCode) {
+			whereClause += " AND dashboardCode = ?"
+			params = append(params, q.DashboardCode)
+		}
+	}
+
+	baseQuery := fmt.Sprintf(`
+		SELECT * FROM HUB_DASHBOARD_LAYOUT
+		%s
+		ORDER BY editTime DESC
+	`, whereClause)
+
+	countQuery, err := sqlutils.BuildCountQuery(baseQuery)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "构建计数查询失败")
+	}
+
+	var countResult struct {
+		Count int `db:"COUNT(*)"`
+	}
+	if err := dao.db.QueryOne(ctx, &countResult, countQuery, params, true); err != nil {
+		return nil, 0, huberrors.WrapError(err, "查询看板布局总数失败")
+	}
+	if countResult.Count == 0 {
+		return []*models.DashboardLayout{}, 0, nil
+	}
+
+	paginatedQuery, paginationArgs, err := sqlutils.BuildPaginationQuery(dbType, baseQuery, pagination)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "构建分页查询失败")
+	}
+
+	allArgs := append(params, paginationArgs...)
+	var rows []*models.DashboardLayout
+	if err := dao.db.Query(ctx, &rows, paginatedQuery, allArgs, true); err != nil {
+		return nil, 0, huberrors.WrapError(err, "查询看板布局失败")
+	}
+	return rows, countResult.Count, nil
+}
+
+// CreateLayout 创建看板布局，布局为默认布局时同时取消本人在该看板的其他默认布局
+func (dao *DashboardLayoutDAO) CreateLayout(ctx context.Context, layout *models.DashboardLayout) error {
+	if layout == nil {
+		return errors.New("layout不能为空")
+	}
+	return dao.db.InTx(ctx, nil, func(txCtx context.Context) error {
+		if layout.DefaultFlag == "Y" {
+			if err := dao.clearDefault(txCtx, layout); err != nil {
+				return err
+			}
+		}
+		if _, err := dao.db.Insert(txCtx, "HUB_DASHBOARD_LAYOUT", layout, false); err != nil {
+			return huberrors.WrapError(err, "创建看板布局失败")
+		}
+		return nil
+	})
+}
+
+// UpdateLayout 更新看板布局，布局为默认布局时同时取消本人在该看板的其他默认布局
+func (dao *DashboardLayoutDAO) UpdateLayout(ctx context.Context, layout *models.DashboardLayout) error {
+	if layout == nil {
+		return errors.New("layout不能为空")
+	}
+	return dao.db.InTx(ctx, nil, func(txCtx context.Context) error {
+		if layout.DefaultFlag == "Y" {
+			if err := dao.clearDefault(txCtx, layout); err != nil {
+				return err
+			}
+		}
+		where := "tenantId = ? AND layoutId = ?"
+		args := []interface{}{layout.TenantId, layout.LayoutId}
+		if _, err := dao.db.Update(txCtx, "HUB_DASHBOARD_LAYOUT", layout, where, args, false, false); err != nil {
+			return huberrors.WrapError(err, "更新看板布局失败")
+		}
+		return nil
+	})
+}
+
+// clearDefault 取消布局所属用户在同一看板的其他默认布局
+func (dao *DashboardLayoutDAO) clearDefault(ctx context.Context, layout *models.DashboardLayout) error {
+	_, err := dao.db.Exec(ctx, `UPDATE HUB_DASHBOARD_LAYOUT SET defaultFlag = 'N'
+		WHERE tenantId = ? AND ownerUserId = ? AND dashboardCode = ? AND layoutId <> ? AND defaultFlag = 'Y'`,
+		[]interface{}{layout.TenantId, layout.OwnerUserId, layout.DashboardCode, layout.LayoutId}, false)
+	if err != nil {
+		return huberrors.WrapError(err, "取消默认看板布局失败")
+	}
+	return nil
+}
+
+// DeleteLayout 删除看板布局（逻辑删除）
+func (dao *DashboardLayoutDAO) DeleteLayout(ctx context.Context, tenantId, layoutId, operatorId string) error {
+	if layoutId == "" {
+		return errors.New("layoutId不能为空")
+	}
+	// 使用参数化的时间值，兼容所有数据库类型
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, "UPDATE HUB_DASHBOARD_LAYOUT SET activeFlag = 'N', defaultFlag = 'N', editWho = ?, editTime = ? WHERE tenantId = ? AND layoutId = ?",
+		[]interface{}{operatorId, now, tenantId, layoutId}, true)
+	if err != nil {
+		return huberrors.WrapError(err, "删除看板布局失败")
+	}
+	return nil
+}
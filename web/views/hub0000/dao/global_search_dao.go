@@ -0,0 +1,192 @@
+package dao
+
+import (
+	"context"
+	"gateway/pkg/database"
+	"gateway/pkg/database/sqlutils"
+	"gateway/pkg/utils/huberrors"
+	"gateway/web/views/hub0000/models"
+	"strconv"
+	"strings"
+)
+
+// GlobalSearchDAO 全局搜索数据访问对象
+// 按关键字模糊匹配服务、路由、网关实例和JVM资源，每种类型只返回前 limit 条
+type GlobalSearchDAO struct {
+	db database.Database
+}
+
+// NewGlobalSearchDAO 创建全局搜索DAO
+func NewGlobalSearchDAO(db database.Database) *GlobalSearchDAO {
+	return &GlobalSearchDAO{
+		db: db,
+	}
+}
+
+// likeKeyword 将关键字转换为LIKE匹配参数
+func likeKeyword(keyword string) string {
+	return "%" + strings.TrimSpace(keyword) + "%"
+}
+
+// queryTop 执行查询并只返回前 limit 条记录
+func (dao *GlobalSearchDAO) queryTop(ctx context.Context, dest interface{}, baseQuery string, params []interface{}, limit int) error {
+	pagination := sqlutils.NewPaginationInfo(1, limit)
+	dbType := sqlutils.GetDatabaseType(dao.db)
+	paginatedQuery, paginationArgs, err := sqlutils.BuildPaginationQuery(dbType, baseQuery, pagination)
+	if err != nil {
+		return huberrors.WrapError(err, "构建分页查询失败")
+	}
+	return dao.db.Query(ctx, dest, paginatedQuery, append(params, paginationArgs...), true)
+}
+
+// SearchServices 按服务名、分组名和服务描述搜索注册中心服务
+func (dao *GlobalSearchDAO) SearchServices(ctx context.Context, tenantId, keyword string, limit int) ([]models.GlobalSearchHit, error) {
+	baseQuery := `
+		SELECT namespaceId, groupName, serviceName, serviceType, serviceDescription
+		FROM HUB_SERVICE
+		WHERE tenantId = ? AND activeFlag = 'Y'
+		  AND (serviceName LIKE ? OR groupName LIKE ? OR serviceDescription LIKE ?)
+		ORDER BY serviceName
+	`
+	like := likeKeyword(keyword)
+	var rows []struct {
+		NamespaceId        string `db:"namespaceId"`
+		GroupName          string `db:"groupName"`
+		ServiceName        string `db:"serviceName"`
+		ServiceType        string `db:"serviceType"`
+		ServiceDescription string `db:"serviceDescription"`
+	}
+	if err := dao.queryTop(ctx, &rows, baseQuery, []interface{}{tenantId, like, like, like}, limit); err != nil {
+		return nil, huberrors.WrapError(err, "搜索服务失败")
+	}
+
+	hits := make([]models.GlobalSearchHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, models.GlobalSearchHit{
+			Type:       models.SearchTypeService,
+			Id:         row.NamespaceId + "/" + row.GroupName + "/" + row.ServiceName,
+			Title:      row.ServiceName,
+			Subtitle:   row.GroupName + " " + row.ServiceType,
+			ModuleCode: "hub0042",
+			Keys: map[string]string{
+				"namespaceId": row.NamespaceId,
+				"groupName":   row.GroupName,
+				"serviceName": row.ServiceName,
+			},
+		})
+	}
+	return hits, nil
+}
+
+// SearchRoutes 按路由名称和路由路径搜索网关路由
+func (dao *GlobalSearchDAO) SearchRoutes(ctx context.Context, tenantId, keyword string, limit int) ([]models.GlobalSearchHit, error) {
+	baseQuery := `
+		SELECT routeConfigId, gatewayInstanceId, routeName, routePath
+		FROM HUB_GW_ROUTE_CONFIG
+		WHERE tenantId = ? AND activeFlag = 'Y'
+		  AND (routeName LIKE ? OR routePath LIKE ? OR routeConfigId = ?)
+		ORDER BY routeName
+	`
+	like := likeKeyword(keyword)
+	var rows []struct {
+		RouteConfigId     string `db:"routeConfigId"`
+		GatewayInstanceId string `db:"gatewayInstanceId"`
+		RouteName         string `db:"routeName"`
+		RoutePath         string `db:"routePath"`
+	}
+	if err := dao.queryTop(ctx, &rows, baseQuery, []interface{}{tenantId, like, like, strings.TrimSpace(keyword)}, limit); err != nil {
+		return nil, huberrors.WrapError(err, "搜索路由失败")
+	}
+
+	hits := make([]models.GlobalSearchHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, models.GlobalSearchHit{
+			Type:       models.SearchTypeRoute,
+			Id:         row.RouteConfigId,
+			Title:      row.RouteName,
+			Subtitle:   row.RoutePath,
+			ModuleCode: "hub0021",
+			Keys: map[string]string{
+				"routeConfigId":     row.RouteConfigId,
+				"gatewayInstanceId": row.GatewayInstanceId,
+			},
+		})
+	}
+	return hits, nil
+}
+
+// SearchGatewayInstances 按实例名称和绑定地址搜索网关实例
+func (dao *GlobalSearchDAO) SearchGatewayInstances(ctx context.Context, tenantId, keyword string, limit int) ([]models.GlobalSearchHit, error) {
+	baseQuery := `
+		SELECT gatewayInstanceId, instanceName, bindAddress, httpPort
+		FROM HUB_GW_INSTANCE
+		WHERE tenantId = ? AND activeFlag = 'Y'
+		  AND (instanceName LIKE ? OR bindAddress LIKE ? OR gatewayInstanceId = ?)
+		ORDER BY instanceName
+	`
+	like := likeKeyword(keyword)
+	var rows []struct {
+		GatewayInstanceId string `db:"gatewayInstanceId"`
+		InstanceName      string `db:"instanceName"`
+		BindAddress       string `db:"bindAddress"`
+		HttpPort          *int   `db:"httpPort"`
+	}
+	if err := dao.queryTop(ctx, &rows, baseQuery, []interface{}{tenantId, like, like, strings.TrimSpace(keyword)}, limit); err != nil {
+		return nil, huberrors.WrapError(err, "搜索网关实例失败")
+	}
+
+	hits := make([]models.GlobalSearchHit, 0, len(rows))
+	for _, row := range rows {
+		subtitle := row.BindAddress
+		if row.HttpPort != nil {
+			subtitle += ":" + strconv.Itoa(*row.HttpPort)
+		}
+		hits = append(hits, models.GlobalSearchHit{
+			Type:       models.SearchTypeGatewayInstance,
+			Id:         row.GatewayInstanceId,
+			Title:      row.InstanceName,
+			Subtitle:   subtitle,
+			ModuleCode: "hub0020",
+			Keys: map[string]string{
+				"gatewayInstanceId": row.GatewayInstanceId,
+			},
+		})
+	}
+	return hits, nil
+}
+
+// SearchJvmResources 按应用名称、主机名和主机IP搜索JVM资源，按最近采集时间倒序
+func (dao *GlobalSearchDAO) SearchJvmResources(ctx context.Context, tenantId, keyword string, limit int) ([]models.GlobalSearchHit, error) {
+	baseQuery := `
+		SELECT jvmResourceId, applicationName, hostName, hostIpAddress
+		FROM HUB_MONITOR_JVM_RESOURCE
+		WHERE tenantId = ? AND activeFlag = 'Y'
+		  AND (applicationName LIKE ? OR hostName LIKE ? OR hostIpAddress LIKE ?)
+		ORDER BY collectionTime DESC
+	`
+	like := likeKeyword(keyword)
+	var rows []struct {
+		JvmResourceId   string `db:"jvmResourceId"`
+		ApplicationName string `db:"applicationName"`
+		HostName        string `db:"hostName"`
+		HostIpAddress   string `db:"hostIpAddress"`
+	}
+	if err := dao.queryTop(ctx, &rows, baseQuery, []interface{}{tenantId, like, like, like}, limit); err != nil {
+		return nil, huberrors.WrapError(err, "搜索JVM资源失败")
+	}
+
+	hits := make([]models.GlobalSearchHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, models.GlobalSearchHit{
+			Type:       models.SearchTypeJvmResource,
+			Id:         row.JvmResourceId,
+			Title:      row.ApplicationName,
+			Subtitle:   row.HostName + " " + row.HostIpAddress,
+			ModuleCode: "hub0042",
+			Keys: map[string]string{
+				"jvmResourceId": row.JvmResourceId,
+			},
+		})
+	}
+	return hits, nil
+}
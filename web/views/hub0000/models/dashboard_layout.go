@@ -0,0 +1,119 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 看板布局参数限制
+const (
+	DashboardMaxWidgets              = 50    // 单个布局的组件数上限
+	DashboardMinRefreshSeconds       = 5     // 自动刷新间隔下限(秒)，0表示不自动刷新
+	DashboardMaxTimeRangeMinutes     = 43200 // 时间范围上限(分钟)，30天
+	DashboardDefaultTimeRangeMinutes = 60    // 默认时间范围(分钟)
+)
+
+// DashboardLayout 看板布局，对应表 HUB_DASHBOARD_LAYOUT
+// 保存用户的监控看板布局和组件配置，更换浏览器后可恢复；共享的布局可通过布局ID查看和复制
+type DashboardLayout struct {
+	// 主键字段
+	TenantId string `json:"tenantId" form:"tenantId" db:"tenantId"` // 租户ID
+	LayoutId string `json:"layoutId" form:"layoutId" db:"layoutId"` // 布局ID
+
+	// 布局信息
+	LayoutName             string `json:"layoutName" form:"layoutName" db:"layoutName"`                                     // 布局名称
+	DashboardCode          string `json:"dashboardCode" form:"dashboardCode" db:"dashboardCode"`                            // 看板编码，区分不同的监控页面
+	LayoutConfig           string `json:"layoutConfig" form:"layoutConfig" db:"layoutConfig"`                               // 网格布局，JSON格式，记录各组件的位置和尺寸
+	WidgetConfig           string `json:"widgetConfig" form:"widgetConfig" db:"widgetConfig"`                               // 组件配置，JSON数组，元素结构见 DashboardWidget
+	TimeRangeMinutes       int    `json:"timeRangeMinutes" form:"timeRangeMinutes" db:"timeRangeMinutes"`                   // 默认时间范围(分钟)，组件未单独配置时使用
+	RefreshIntervalSeconds int    `json:"refreshIntervalSeconds" form:"refreshIntervalSeconds" db:"refreshIntervalSeconds"` // 默认刷新间隔(秒)，0表示不自动刷新
+	OwnerUserId            string `json:"ownerUserId" form:"ownerUserId" db:"ownerUserId"`                                  // 所属用户ID
+	ShareFlag              string `json:"shareFlag" form:"shareFlag" db:"shareFlag"`                                        // 是否共享给租户内其他用户(Y是,N否)
+	DefaultFlag            string `json:"defaultFlag" form:"defaultFlag" db:"defaultFlag"`                                  // 是否为所属用户在该看板的默认布局(Y是,N否)
+
+	// 通用字段
+	AddTime        time.Time `json:"addTime" form:"addTime" db:"addTime"`                      // 创建时间
+	AddWho         string    `json:"addWho" form:"addWho" db:"addWho"`                         // 创建人ID
+	EditTime       time.Time `json:"editTime" form:"editTime" db:"editTime"`                   // 最后修改时间
+	EditWho        string    `json:"editWho" form:"editWho" db:"editWho"`                      // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" form:"oprSeqFlag" db:"oprSeqFlag"`             // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" form:"currentVersion" db:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" form:"activeFlag" db:"activeFlag"`             // 活动状态标记(N非活动,Y活动)
+	NoteText       string    `json:"noteText" form:"noteText" db:"noteText"`                   // 备注信息
+}
+
+// TableName 返回表名
+func (DashboardLayout) TableName() string {
+	return "HUB_DASHBOARD_LAYOUT"
+}
+
+// DashboardWidget 看板组件配置，widgetConfig 中的数组元素
+// 时间范围和刷新间隔为0时使用布局的默认值
+type DashboardWidget struct {
+	WidgetId               string                 `json:"widgetId"`               // 组件ID，布局内唯一，与 layoutConfig 中的位置对应
+	WidgetType             string                 `json:"widgetType"`             // 组件类型，如 line、bar、gauge、table
+	Title                  string                 `json:"title"`                  // 组件标题
+	Metrics                []string               `json:"metrics"`                // 展示的指标
+	TimeRangeMinutes       int                    `json:"timeRangeMinutes"`       // 时间范围(分钟)
+	RefreshIntervalSeconds int                    `json:"refreshIntervalSeconds"` // 刷新间隔(秒)
+	Options                map[string]interface{} `json:"options,omitempty"`      // 组件的其他展示选项
+}
+
+// DashboardLayoutListRequest 看板布局列表请求
+// 说明：分页参数通过 request.GetPaginationParams 读取，这里仅放筛选条件
+type DashboardLayoutListRequest struct {
+	LayoutName    string `json:"layoutName" form:"layoutName"`       // 布局名称（模糊）
+	DashboardCode string `json:"dashboardCode" form:"dashboardCode"` // 看板编码（精确）
+	OnlyMine      string `json:"onlyMine" form:"onlyMine"`           // 为Y时只查询本人创建的布局
+}
+
+// ValidateDashboardWidgets 校验组件配置，为空时视为没有组件
+// 参数:
+//
+//	widgetConfig: 组件配置JSON数组
+//
+// 返回:
+//
+//	error: 不是JSON数组、组件数超过上限、组件ID为空或重复、时间范围或刷新间隔越界时返回错误
+func ValidateDashboardWidgets(widgetConfig string) error {
+	if strings.TrimSpace(widgetConfig) == "" {
+		return nil
+	}
+
+	var widgets []DashboardWidget
+	if err := json.Unmarshal([]byte(widgetConfig), &widgets); err != nil {
+		return fmt.Errorf("widgetConfig必须是组件配置JSON数组: %w", err)
+	}
+	if len(widgets) > DashboardMaxWidgets {
+		return fmt.Errorf("组件数不能超过%d个", DashboardMaxWidgets)
+	}
+
+	seen := make(map[string]bool, len(widgets))
+	for i, widget := range widgets {
+		widgetId := strings.TrimSpace(widget.WidgetId)
+		if widgetId == "" {
+			return fmt.Errorf("第%d个组件的widgetId不能为空", i+1)
+		}
+		if seen[widgetId] {
+			return fmt.Errorf("组件ID重复: %s", widgetId)
+		}
+		seen[widgetId] = true
+		if err := ValidateDashboardTiming(widget.TimeRangeMinutes, widget.RefreshIntervalSeconds); err != nil {
+			return fmt.Errorf("组件%s: %w", widgetId, err)
+		}
+	}
+	return nil
+}
+
+// ValidateDashboardTiming 校验时间范围和刷新间隔，两者为0表示使用默认值或不自动刷新
+func ValidateDashboardTiming(timeRangeMinutes, refreshIntervalSeconds int) error {
+	if timeRangeMinutes < 0 || timeRangeMinutes > DashboardMaxTimeRangeMinutes {
+		return fmt.Errorf("timeRangeMinutes必须在0到%d之间", DashboardMaxTimeRangeMinutes)
+	}
+	if refreshIntervalSeconds < 0 || (refreshIntervalSeconds > 0 && refreshIntervalSeconds < DashboardMinRefreshSeconds) {
+		return fmt.Errorf("refreshIntervalSeconds必须为0或不小于%d秒", DashboardMinRefreshSeconds)
+	}
+	return nil
+}
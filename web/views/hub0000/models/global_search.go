@@ -0,0 +1,44 @@
+package models
+
+// 全局搜索命中类型
+const (
+	SearchTypeService         = "SERVICE"          // 服务注册中心服务
+	SearchTypeRoute           = "ROUTE"            // 网关路由
+	SearchTypeGatewayInstance = "GATEWAY_INSTANCE" // 网关实例
+	SearchTypeJvmResource     = "JVM_RESOURCE"     // JVM资源
+	SearchTypeAccessLog       = "ACCESS_LOG"       // 网关访问日志
+)
+
+// 全局搜索参数限制
+const (
+	SearchDefaultLimit = 10 // 每种类型默认返回的命中数
+	SearchMaxLimit     = 50 // 每种类型返回的命中数上限
+)
+
+// SearchTypes 全局搜索支持的全部命中类型，按返回顺序排列
+var SearchTypes = []string{
+	SearchTypeAccessLog,
+	SearchTypeService,
+	SearchTypeRoute,
+	SearchTypeGatewayInstance,
+	SearchTypeJvmResource,
+}
+
+// GlobalSearchRequest 全局搜索请求
+type GlobalSearchRequest struct {
+	Keyword           string   `json:"keyword" form:"keyword"`                     // 搜索关键字，按名称、路径、地址模糊匹配，按链路追踪ID精确匹配访问日志
+	Types             []string `json:"types" form:"types"`                         // 搜索的命中类型，为空时搜索全部类型
+	Limit             int      `json:"limit" form:"limit"`                         // 每种类型返回的命中数，默认10，最大50
+	GatewayInstanceId string   `json:"gatewayInstanceId" form:"gatewayInstanceId"` // 网关实例ID，用于解析访问日志存储查询方式
+}
+
+// GlobalSearchHit 全局搜索命中结果
+// 前端按 moduleCode 跳转到对应模块，按 keys 中的主键定位记录
+type GlobalSearchHit struct {
+	Type       string            `json:"type"`       // 命中类型
+	Id         string            `json:"id"`         // 记录标识
+	Title      string            `json:"title"`      // 显示标题
+	Subtitle   string            `json:"subtitle"`   // 显示副标题
+	ModuleCode string            `json:"moduleCode"` // 记录所属模块编码
+	Keys       map[string]string `json:"keys"`       // 定位记录的主键字段
+}
@@ -1,10 +1,17 @@
 package routes
 
 import (
+	"context"
+	"strings"
+
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
+	"gateway/pkg/mongo/factory"
+	"gateway/web/middleware/permission"
 	"gateway/web/routes"
 	"gateway/web/views/hub0000/controllers"
+	hub0023dao "gateway/web/views/hub0023/dao"
+	hub0023models "gateway/web/views/hub0023/models"
 
 	"github.com/gin-gonic/gin"
 )
@@ -85,6 +92,45 @@ func Init(router *gin.Engine, db database.Database) {
 			temperatureGroup.POST("/query", controller.QueryTemperatureLogList) // 查询温度日志列表
 		}
 
+		// 日志级别运行时调整路由
+		logLevelController := controllers.NewLogLevelController()
+		logLevelGroup := protectedGroup.Group("/log/level")
+		{
+			logLevelGroup.POST("/query", logLevelController.QueryLogLevel)   // 查询日志级别
+			logLevelGroup.POST("/update", logLevelController.UpdateLogLevel) // 调整全局或模块日志级别
+			logLevelGroup.POST("/reset", logLevelController.ResetLogLevel)   // 重置模块日志级别
+		}
+
+		// 配置查看路由：查询配置项的生效值及来源，比较配置文件与启动时加载的内容
+		configInspectController := controllers.NewConfigInspectController()
+		configGroup := protectedGroup.Group("/config")
+		{
+			configGroup.POST("/inspect", configInspectController.InspectConfig) // 查询配置项生效值及来源
+			configGroup.POST("/diff", configInspectController.DiffConfigFiles)  // 比较配置文件与启动时加载的内容
+		}
+
+		// 看板布局路由：保存用户的看板布局和组件配置，共享的布局可通过布局ID查看和复制
+		dashboardLayoutController := controllers.NewDashboardLayoutController(db)
+		dashboardGroup := protectedGroup.Group("/dashboard/layout")
+		{
+			dashboardGroup.POST("/query", dashboardLayoutController.QueryLayouts)          // 查询可见的看板布局列表
+			dashboardGroup.POST("/get", dashboardLayoutController.GetLayout)               // 按布局ID获取看板布局
+			dashboardGroup.POST("/getDefault", dashboardLayoutController.GetDefaultLayout) // 获取本人在指定看板的默认布局
+			dashboardGroup.POST("/add", dashboardLayoutController.AddLayout)               // 保存看板布局
+			dashboardGroup.POST("/update", dashboardLayoutController.UpdateLayout)         // 更新看板布局
+			dashboardGroup.POST("/delete", dashboardLayoutController.DeleteLayout)         // 删除看板布局
+			dashboardGroup.POST("/copy", dashboardLayoutController.CopyLayout)             // 复制看板布局为本人的布局
+		}
+
+		// 全局搜索路由：按关键字搜索服务、路由、网关实例、JVM资源，按链路追踪ID查找访问日志
+		globalSearchController := controllers.NewGlobalSearchController(db, newAccessLogLookup(db))
+		protectedGroup.POST("/globalSearch", globalSearchController.GlobalSearch)
+		permission.RegisterRoutePermission("POST", APIPrefix+"/globalSearch", "") // 查询类接口只校验模块权限
+
+		// 枚举显示名路由：按请求语言返回健康等级、告警级别等枚举值的显示名
+		enumLabelController := controllers.NewEnumLabelController()
+		protectedGroup.POST("/queryEnumLabels", enumLabelController.QueryEnumLabels)
+
 		// 公开API (如果需要的话)
 		// publicGroup := metricGroup.Group("")
 		// publicGroup.Use(routes.PublicAPI())
@@ -97,6 +143,36 @@ func Init(router *gin.Engine, db database.Database) {
 	logger.Info("指标查询模块路由注册完成", "module", ModuleName)
 }
 
+// newAccessLogLookup 创建访问日志查找函数
+// 与网关日志模块一致，按网关实例关联的日志配置选择 MongoDB、ClickHouse 或关系数据库，对应连接不可用时回退关系数据库
+func newAccessLogLookup(db database.Database) controllers.AccessLogLookup {
+	gatewayLogDAO := hub0023dao.NewGatewayLogDAO(db)
+
+	var mongoQueryDAO *hub0023dao.MongoQueryDAO
+	if mongoClient, err := factory.GetDefaultConnection(); err == nil {
+		mongoQueryDAO = hub0023dao.NewMongoQueryDAO(mongoClient)
+	}
+
+	var clickhouseQueryDAO *hub0023dao.ClickHouseQueryDAO
+	if clickhouseDB := database.GetConnection("clickhouse_main"); clickhouseDB != nil {
+		clickhouseQueryDAO = hub0023dao.NewClickHouseQueryDAO(clickhouseDB)
+	}
+
+	return func(ctx context.Context, tenantId, gatewayInstanceId, traceId string) (*hub0023models.GatewayAccessLog, error) {
+		switch hub0023dao.ResolveGatewayLogQueryType(ctx, db, tenantId, strings.TrimSpace(gatewayInstanceId)) {
+		case "mongo":
+			if mongoQueryDAO != nil {
+				return mongoQueryDAO.GetGatewayLogByKey(ctx, tenantId, traceId)
+			}
+		case "clickhouse":
+			if clickhouseQueryDAO != nil {
+				return clickhouseQueryDAO.GetGatewayLogByKey(ctx, tenantId, traceId)
+			}
+		}
+		return gatewayLogDAO.GetByKey(ctx, tenantId, traceId)
+	}
+}
+
 // RegisterRoutesFunc 返回路由注册函数
 func RegisterRoutesFunc() func(router *gin.Engine, db database.Database) {
 	return Init
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"gateway/pkg/config"
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
@@ -32,14 +33,18 @@ type AuthController struct {
 func NewAuthController(db database.Database) *AuthController {
 	userDAO := hubdao.NewUserDAO(db)
 	authDAO := authdao.NewAuthDAO(db)
+	authService := NewAuthService(authDAO, userDAO)
+
+	sessionManager := session.GetGlobalSessionManager()
+	sessionManager.SetUserValidator(authService.ValidateSessionUser)
 
 	return &AuthController{
 		db:             db,
-		authService:    NewAuthService(authDAO, userDAO),
+		authService:    authService,
 		authDAO:        authDAO,
 		userDAO:        userDAO,
 		captchaService: NewCaptchaService(),
-		sessionManager: session.GetGlobalSessionManager(),
+		sessionManager: sessionManager,
 	}
 }
 
@@ -137,6 +142,11 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		clientIP,
 		userAgent,
 	)
+	if errors.Is(err, session.ErrSessionLimitExceeded) {
+		logger.WarnWithTrace(ctx, "登录会话数已达上限", "userId", user.UserId, "tenantId", user.TenantId)
+		response.ErrorJSON(ctx, "已达到最大同时登录会话数，请先退出其他设备的登录", constants.ED00116, http.StatusForbidden)
+		return
+	}
 	if err != nil {
 		logger.ErrorWithTrace(ctx, "创建session失败", "error", err, "userId", user.UserId)
 		response.ErrorJSON(ctx, "创建会话失败", constants.ED00001, http.StatusInternalServerError)
@@ -171,6 +181,8 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		"sessionId":       sessionData.SessionId,
 		"loginTime":       sessionData.LoginTime,
 		"expireAt":        sessionData.ExpireAt.Unix(),
+		"refreshToken":    sessionData.RefreshToken,
+		"refreshExpireAt": sessionData.RefreshExpireAt.Unix(),
 		"clientIP":        clientIP,
 		"userAgent":       userAgent,
 		// 返回web.yaml中的read_timeout配置，单位为秒，转换为毫秒
@@ -272,6 +284,52 @@ func (c *AuthController) RefreshSession(ctx *gin.Context) {
 	}, constants.SD00103)
 }
 
+// RefreshToken 使用刷新令牌换取新的访问令牌
+// @Summary 刷新访问令牌
+// @Description 使用登录时签发的刷新令牌换取新的Session ID和刷新令牌，原令牌立即失效
+// @Tags 认证
+// @Accept json
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param refreshToken body models.RefreshTokenRequest true "刷新令牌"
+// @Success 200 {object} response.JsonData
+// @Router /api/auth/refresh-token [post]
+func (c *AuthController) RefreshToken(ctx *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := request.BindSafely(ctx, &req); err != nil {
+		logger.DebugWithTrace(ctx, "刷新令牌请求参数解析失败", "error", err)
+	}
+	if req.RefreshToken == "" {
+		req.RefreshToken = ctx.GetHeader("X-Refresh-Token")
+	}
+	if req.RefreshToken == "" {
+		response.ErrorJSON(ctx, "刷新令牌不能为空", constants.ED00007)
+		return
+	}
+
+	token, err := c.sessionManager.RefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, session.ErrRefreshTokenInvalid) {
+			logger.WarnWithTrace(ctx, "刷新令牌无效", "clientIP", ctx.ClientIP())
+			response.ErrorJSON(ctx, "登录已过期，请重新登录", constants.ED00117, http.StatusUnauthorized)
+			return
+		}
+		logger.ErrorWithTrace(ctx, "刷新令牌失败", "error", err)
+		response.ErrorJSON(ctx, "刷新令牌失败", constants.ED00108, http.StatusInternalServerError)
+		return
+	}
+
+	// 更新Session Cookie
+	c.setSessionCookie(ctx, token.SessionId, *token.ExpireAt)
+
+	response.SuccessJSON(ctx, gin.H{
+		"sessionId":       token.SessionId,
+		"expireAt":        token.ExpireAt.Unix(),
+		"refreshToken":    token.RefreshToken,
+		"refreshExpireAt": token.RefreshExpireAt.Unix(),
+	}, constants.SD00103)
+}
+
 // Logout 用户登出
 // @Summary 用户登出
 // @Description 用户登出，清除Session会话
@@ -321,7 +379,7 @@ func (c *AuthController) Logout(ctx *gin.Context) {
 		}
 	} else if userId != "" {
 		// 如果没有sessionId但有userId，删除该用户的所有session
-		err := c.sessionManager.DeleteUserSessions(ctx, userId)
+		err := c.sessionManager.DeleteUserSessions(ctx, userContext.TenantId, userId)
 		if err != nil {
 			logger.ErrorWithTrace(ctx, "删除用户所有session失败", "error", err, "userId", userId)
 		} else {
@@ -337,6 +395,37 @@ func (c *AuthController) Logout(ctx *gin.Context) {
 	}, constants.SD00104)
 }
 
+// LogoutAll 退出所有设备的登录
+// @Summary 退出所有设备
+// @Description 撤销当前用户的所有Session和刷新令牌
+// @Tags 认证
+// @Produce json
+// @Security SessionAuth
+// @Success 200 {object} response.JsonData
+// @Router /api/auth/logout-all [post]
+func (c *AuthController) LogoutAll(ctx *gin.Context) {
+	userContext := middleware.GetUserContext(ctx)
+	if userContext == nil {
+		response.ErrorJSON(ctx, "未获取到用户信息，请重新登录", constants.ED00011, http.StatusUnauthorized)
+		return
+	}
+
+	err := c.sessionManager.DeleteUserSessions(ctx, userContext.TenantId, userContext.UserId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "删除用户所有session失败", "error", err, "userId", userContext.UserId)
+		response.ErrorJSON(ctx, "退出所有设备失败", constants.ED00009, http.StatusInternalServerError)
+		return
+	}
+	logger.InfoWithTrace(ctx, "用户已退出所有设备", "userId", userContext.UserId, "tenantId", userContext.TenantId)
+
+	// 清除Session Cookie
+	c.clearSessionCookie(ctx)
+
+	response.SuccessJSON(ctx, gin.H{
+		"message": "已退出所有设备",
+	}, constants.SD00104)
+}
+
 // ChangePassword 修改密码
 // @Summary 修改密码
 // @Description 修改用户密码
@@ -406,7 +495,7 @@ func (c *AuthController) ChangePassword(ctx *gin.Context) {
 	}
 
 	// 密码修改成功后，强制用户重新登录（删除所有session）
-	err = c.sessionManager.DeleteUserSessions(ctx, userId)
+	err = c.sessionManager.DeleteUserSessions(ctx, userContext.TenantId, userId)
 	if err != nil {
 		logger.ErrorWithTrace(ctx, "密码修改后清除用户session失败", "error", err, "userId", userId)
 	} else {
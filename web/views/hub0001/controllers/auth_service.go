@@ -3,8 +3,10 @@ package controllers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"gateway/pkg/logger"
 	"gateway/web/middleware"
+	"gateway/web/utils/session"
 	authdao "gateway/web/views/hub0001/dao"
 	"gateway/web/views/hub0001/models"
 	hubdao "gateway/web/views/hub0002/dao"
@@ -110,6 +112,25 @@ func (s *AuthService) ValidateLogin(ctx context.Context, req *models.LoginReques
 	return user, nil
 }
 
+// ValidateSessionUser 刷新会话前校验用户仍然存在、未被禁用且账号未过期
+// 用户不可用时返回包装 session.ErrUserUnavailable 的错误，会话管理器据此撤销会话
+func (s *AuthService) ValidateSessionUser(ctx context.Context, tenantId, userId string) error {
+	user, err := s.userDAO.GetUserById(ctx, userId, tenantId)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return fmt.Errorf("用户 %s 不存在: %w", userId, session.ErrUserUnavailable)
+	}
+	if user.StatusFlag != "Y" {
+		return fmt.Errorf("用户 %s 已被禁用: %w", userId, session.ErrUserUnavailable)
+	}
+	if user.UserExpireDate.Before(time.Now()) {
+		return fmt.Errorf("用户 %s 账号已过期: %w", userId, session.ErrUserUnavailable)
+	}
+	return nil
+}
+
 // GetUserInfo 获取用户信息
 func (s *AuthService) GetUserInfo(ctx context.Context, userId, tenantId string) (*hubmodels.User, error) {
 	if userId == "" || tenantId == "" {
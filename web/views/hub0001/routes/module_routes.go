@@ -38,6 +38,7 @@ func Init(router *gin.Engine, db database.Database) {
 		authGroup.POST("/login", routes.PublicAPI(), authController.Login)
 		authGroup.POST("/captcha", routes.PublicAPI(), authController.GetCaptcha)
 		authGroup.GET("/version", routes.PublicAPI(), authController.GetVersion)
+		authGroup.POST("/refresh-token", routes.PublicAPI(), authController.RefreshToken)
 
 		// 受保护API - 需要Session认证的路由
 		sessionGroup := authGroup.Group("")
@@ -46,6 +47,7 @@ func Init(router *gin.Engine, db database.Database) {
 			sessionGroup.GET("/userinfo", authController.UserInfo)
 			sessionGroup.POST("/refresh-session", authController.RefreshSession)
 			sessionGroup.POST("/logout", authController.Logout)
+			sessionGroup.POST("/logout-all", authController.LogoutAll)
 			sessionGroup.PUT("/password", authController.ChangePassword)
 		}
 
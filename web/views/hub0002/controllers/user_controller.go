@@ -6,6 +6,7 @@ import (
 	"gateway/web/utils/constants"
 	"gateway/web/utils/request"
 	"gateway/web/utils/response"
+	"gateway/web/utils/session"
 	"gateway/web/views/hub0002/dao"
 	"gateway/web/views/hub0002/models"
 	"strings"
@@ -208,6 +209,13 @@ func (c *UserController) EditUser(ctx *gin.Context) {
 		return
 	}
 
+	// 禁用用户后撤销该用户的所有会话
+	if currentUser.StatusFlag == "Y" && updateData.StatusFlag != "Y" {
+		if err := session.GetGlobalSessionManager().DeleteUserSessions(ctx, tenantId, userId); err != nil {
+			logger.ErrorWithTrace(ctx, "禁用用户后清除用户session失败", "error", err, "userId", userId)
+		}
+	}
+
 	// 查询更新后的用户信息
 	updatedUser, err := c.userDAO.GetUserById(ctx, updateData.UserId, tenantId)
 	if err != nil {
@@ -312,6 +320,11 @@ func (c *UserController) ChangePassword(ctx *gin.Context) {
 		return
 	}
 
+	// 密码修改后撤销该用户的所有会话
+	if err := session.GetGlobalSessionManager().DeleteUserSessions(ctx, tenantId, userId); err != nil {
+		logger.ErrorWithTrace(ctx, "密码修改后清除用户session失败", "error", err, "userId", userId)
+	}
+
 	response.SuccessJSON(ctx, nil, constants.SD00003)
 }
 
@@ -353,6 +366,11 @@ func (c *UserController) Delete(ctx *gin.Context) {
 		return
 	}
 
+	// 删除用户后撤销该用户的所有会话
+	if err := session.GetGlobalSessionManager().DeleteUserSessions(ctx, tenantId, userId); err != nil {
+		logger.ErrorWithTrace(ctx, "删除用户后清除用户session失败", "error", err, "userId", userId)
+	}
+
 	response.SuccessJSON(ctx, gin.H{
 		"userId": userId,
 	}, constants.SD00005)
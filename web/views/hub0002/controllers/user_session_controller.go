@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"errors"
+	"gateway/pkg/logger"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/utils/session"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserSessionController 用户会话管理控制器
+type UserSessionController struct {
+	sessionManager *session.SessionManager
+}
+
+// NewUserSessionController 创建用户会话管理控制器
+func NewUserSessionController() *UserSessionController {
+	return &UserSessionController{
+		sessionManager: session.GetGlobalSessionManager(),
+	}
+}
+
+// QueryUserSessions 查询当前租户的用户会话
+// @Summary 查询用户会话
+// @Description 分页查询当前租户的在线会话，可按用户ID过滤
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param request body object{userId=string} false "查询条件"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0002/queryUserSessions [post]
+func (c *UserSessionController) QueryUserSessions(ctx *gin.Context) {
+	page, pageSize := request.GetPaginationParams(ctx)
+	tenantId := request.GetTenantID(ctx)
+	userId := strings.TrimSpace(request.GetParam(ctx, "userId"))
+
+	var (
+		sessions []*session.SessionInfo
+		err      error
+	)
+	if userId != "" {
+		sessions, err = c.sessionManager.ListUserSessions(ctx, tenantId, userId)
+	} else {
+		sessions, err = c.sessionManager.ListTenantSessions(ctx, tenantId)
+	}
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询用户会话失败", "error", err, "userId", userId)
+		response.ErrorJSON(ctx, "查询用户会话失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	total := len(sessions)
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+
+	pageInfo := response.NewPageInfo(page, pageSize, total)
+	pageInfo.MainKey = "sessionId"
+	response.PageJSON(ctx, sessions[start:end], pageInfo, constants.SD00002)
+}
+
+// RevokeUserSession 撤销用户的指定会话
+// @Summary 撤销用户会话
+// @Description 强制用户的指定会话下线，访问令牌和刷新令牌同时失效
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param request body object{userId=string,sessionId=string} true "会话信息"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0002/revokeUserSession [post]
+func (c *UserSessionController) RevokeUserSession(ctx *gin.Context) {
+	userId := strings.TrimSpace(request.GetParam(ctx, "userId"))
+	sessionId := strings.TrimSpace(request.GetParam(ctx, "sessionId"))
+	if userId == "" || sessionId == "" {
+		response.ErrorJSON(ctx, "用户ID和会话ID不能为空", constants.ED00007)
+		return
+	}
+	tenantId := request.GetTenantID(ctx)
+
+	err := c.sessionManager.RevokeSession(ctx, tenantId, userId, sessionId)
+	if errors.Is(err, session.ErrSessionNotFound) {
+		response.ErrorJSON(ctx, "会话不存在或已失效", constants.ED00008)
+		return
+	}
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "撤销用户会话失败", "error", err, "userId", userId, "sessionId", sessionId)
+		response.ErrorJSON(ctx, "撤销用户会话失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	logger.InfoWithTrace(ctx, "管理员撤销用户会话", "userId", userId, "sessionId", sessionId, "operatorId", request.GetOperatorID(ctx))
+	response.SuccessJSON(ctx, gin.H{
+		"userId":    userId,
+		"sessionId": sessionId,
+	}, constants.SD00001)
+}
+
+// RevokeUserAllSessions 撤销用户的所有会话
+// @Summary 撤销用户所有会话
+// @Description 强制用户在所有设备上下线
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param request body object{userId=string} true "用户ID"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0002/revokeUserAllSessions [post]
+func (c *UserSessionController) RevokeUserAllSessions(ctx *gin.Context) {
+	userId := strings.TrimSpace(request.GetParam(ctx, "userId"))
+	if userId == "" {
+		response.ErrorJSON(ctx, "用户ID不能为空", constants.ED00007)
+		return
+	}
+	tenantId := request.GetTenantID(ctx)
+
+	if err := c.sessionManager.DeleteUserSessions(ctx, tenantId, userId); err != nil {
+		logger.ErrorWithTrace(ctx, "撤销用户所有会话失败", "error", err, "userId", userId)
+		response.ErrorJSON(ctx, "撤销用户所有会话失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	logger.InfoWithTrace(ctx, "管理员撤销用户所有会话", "userId", userId, "operatorId", request.GetOperatorID(ctx))
+	response.SuccessJSON(ctx, gin.H{
+		"userId": userId,
+	}, constants.SD00001)
+}
@@ -3,6 +3,7 @@ package hub0002routes
 import (
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
+	"gateway/web/middleware/permission"
 	"gateway/web/routes"
 	"gateway/web/views/hub0002/controllers"
 
@@ -82,6 +83,16 @@ func initUserRoutes(router *gin.RouterGroup, db database.Database) {
 		// 用户角色授权相关路由
 		userGroup.POST("/getUserRoles", userController.GetUserRoles)
 		userGroup.POST("/assignUserRoles", userController.AssignUserRoles)
+
+		// 用户会话管理相关路由
+		userSessionController := controllers.NewUserSessionController()
+		userGroup.POST("/queryUserSessions", userSessionController.QueryUserSessions)
+		userGroup.POST("/revokeUserSession", userSessionController.RevokeUserSession)
+		userGroup.POST("/revokeUserAllSessions", userSessionController.RevokeUserAllSessions)
+
+		// 修改密码和用户授权有独立的按钮编码，按接口名只能推导出 hub0002:edit
+		permission.RegisterRoutePermission("POST", APIPrefix+"/changePassword", ModuleName+":resetPassword")
+		permission.RegisterRoutePermission("POST", APIPrefix+"/assignUserRoles", ModuleName+":roleAuth")
 	}
 }
 
@@ -3,10 +3,11 @@ package controllers
 import (
 	"context"
 	"fmt"
-	"gateway/internal/timerinit/sftp"
+	"gateway/internal/timerinit/common/taskinit"
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
 	"gateway/pkg/timer"
+	"gateway/pkg/timer/cron"
 	"gateway/pkg/utils/random"
 	"gateway/web/utils/constants"
 	"gateway/web/utils/request"
@@ -18,6 +19,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+
+	// 导入各任务模块，模块在包初始化时注册自己的执行器工厂
+	_ "gateway/internal/timerinit/cleanup"
+	_ "gateway/internal/timerinit/sftp"
 )
 
 // TaskConfigController 任务配置控制器
@@ -98,8 +103,21 @@ func (c *TaskConfigController) AddTaskConfig(ctx *gin.Context) {
 		response.ErrorJSON(ctx, "任务名称不能为空", constants.ED00007)
 		return
 	}
-	if (task.CronExpression == nil || *task.CronExpression == "") && (task.IntervalSeconds == nil || *task.IntervalSeconds == 0) {
-		response.ErrorJSON(ctx, "必须指定Cron表达式或固定频率", constants.ED00007)
+	if (task.CronExpression == nil || *task.CronExpression == "") && (task.IntervalSeconds == nil || *task.IntervalSeconds == 0) &&
+		(task.DependsOnTaskIds == nil || strings.TrimSpace(*task.DependsOnTaskIds) == "") {
+		response.ErrorJSON(ctx, "必须指定Cron表达式、固定频率或上游依赖任务", constants.ED00007)
+		return
+	}
+	if err := validateTaskCron(&task); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
+		return
+	}
+	if err := validateTaskRetry(&task); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
+		return
+	}
+	if err := c.validateTaskDependencies(ctx, tenantId, &task); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
 		return
 	}
 
@@ -240,6 +258,19 @@ func (c *TaskConfigController) UpdateTaskConfig(ctx *gin.Context) {
 		return
 	}
 
+	if err := validateTaskCron(&task); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
+		return
+	}
+	if err := validateTaskRetry(&task); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
+		return
+	}
+	if err := c.validateTaskDependencies(ctx, tenantId, &task); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
+		return
+	}
+
 	// 查询原记录
 	currentTask, err := c.dao.GetById(ctx, tenantId, task.TaskId)
 	if err != nil {
@@ -289,6 +320,12 @@ func (c *TaskConfigController) UpdateTaskConfig(ctx *gin.Context) {
 		return
 	}
 
+	// 已注册到调度器的任务按新配置重新注册，修改立即生效
+	if err := c.reloadRegisteredTask(ctx, &task); err != nil {
+		response.ErrorJSON(ctx, "任务配置已更新，但重新注册任务失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
 	// 查询最新数据
 	updatedTask, err := c.dao.GetById(ctx, tenantId, task.TaskId)
 	if err != nil {
@@ -646,24 +683,16 @@ func (c *TaskConfigController) StartTask(ctx *gin.Context) {
 		return
 	}
 
-	// 根据任务的执行器类型进行任务注册
-	switch *task.ExecutorType {
-	case "SFTP_TRANSFER":
-		// 注册单个SFTP任务（包括调度器创建、任务注册、启动等完整流程）
-		if err := sftp.RegisterSFTPTaskById(ctx, c.db, tenantId, task.TaskId); err != nil {
-			response.ErrorJSON(ctx, "注册SFTP任务失败: "+err.Error(), constants.ED00009)
-			return
-		}
-		logger.Info("SFTP任务注册成功", "taskId", task.TaskId, "tenantId", tenantId)
-	case "HTTP_REQUEST":
-		// 这里可以添加HTTP请求任务的注册逻辑
-		// TODO: 实现HTTP请求任务注册
-		response.ErrorJSON(ctx, "HTTP请求任务注册功能尚未实现", constants.ED00009)
-		return
-	default:
+	// 按任务的执行器类型查找已注册的执行器工厂进行任务注册（包括调度器创建、任务注册、启动等完整流程）
+	if _, ok := taskinit.GetExecutorFactory(*task.ExecutorType); !ok {
 		response.ErrorJSON(ctx, fmt.Sprintf("不支持的执行器类型: %s", *task.ExecutorType), constants.ED00007)
 		return
 	}
+	if err := taskinit.RegisterTaskById(ctx, c.db, tenantId, task.TaskId); err != nil {
+		response.ErrorJSON(ctx, "注册任务失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	logger.Info("任务注册成功", "taskId", task.TaskId, "tenantId", tenantId, "executorType", *task.ExecutorType)
 
 	// 获取全局定时器池
 	timerPool := timer.GetTimerPool()
@@ -898,3 +927,317 @@ func (c *TaskConfigController) TriggerTask(ctx *gin.Context) {
 
 	response.SuccessJSON(ctx, updatedTask, constants.SD00004)
 }
+
+// PauseTask 暂停任务
+// @Summary 暂停任务
+// @Description 暂停任务调度，任务保留在调度器中，暂停状态持久化到数据库，重启后仍保持暂停
+// @Tags 任务管理
+// @Accept json
+// @Produce json
+// @Param data body object true "暂停任务参数"
+// @Success 200 {object} response.Response
+// @Router /gateway/hub0003/task/pause [post]
+func (c *TaskConfigController) PauseTask(ctx *gin.Context) {
+	task, operatorId, ok := c.loadActiveTask(ctx)
+	if !ok {
+		return
+	}
+	if task.TaskStatus == 7 {
+		response.ErrorJSON(ctx, "任务已处于暂停状态", constants.ED00006)
+		return
+	}
+
+	// 停止调度器中的任务调度；任务尚未注册到调度器时只需持久化暂停状态
+	if scheduler, err := timer.GetTimerPool().GetScheduler(taskSchedulerId(task)); err == nil {
+		if _, err := scheduler.GetTask(task.TaskId); err == nil {
+			if err := scheduler.StopTask(task.TaskId); err != nil {
+				response.ErrorJSON(ctx, "暂停任务失败: "+err.Error(), constants.ED00009)
+				return
+			}
+		}
+	}
+
+	c.saveRunStatus(ctx, task, 7, operatorId, "暂停") // 7-已暂停
+}
+
+// ResumeTask 恢复任务
+// @Summary 恢复任务
+// @Description 恢复已暂停或已进入死信状态的任务，按数据库中的最新配置重新注册并继续调度
+// @Tags 任务管理
+// @Accept json
+// @Produce json
+// @Param data body object true "恢复任务参数"
+// @Success 200 {object} response.Response
+// @Router /gateway/hub0003/task/resume [post]
+func (c *TaskConfigController) ResumeTask(ctx *gin.Context) {
+	task, operatorId, ok := c.loadActiveTask(ctx)
+	if !ok {
+		return
+	}
+	if task.TaskStatus != 7 && task.TaskStatus != 6 {
+		response.ErrorJSON(ctx, "只有暂停或死信状态的任务可以恢复", constants.ED00006)
+		return
+	}
+
+	c.saveRunStatus(ctx, task, 1, operatorId, "恢复") // 1-待执行
+}
+
+// loadActiveTask 解析任务ID并加载活动状态的任务，失败时已写入错误响应
+// 返回:
+//
+//	*hub0003models.TimerTask: 任务配置
+//	string: 操作人ID
+//	bool: 是否加载成功
+func (c *TaskConfigController) loadActiveTask(ctx *gin.Context) (*hub0003models.TimerTask, string, bool) {
+	var params struct {
+		TaskId string `json:"taskId" form:"taskId" query:"taskId"`
+	}
+	if err := request.BindSafely(ctx, &params); err != nil {
+		response.ErrorJSON(ctx, "参数解析失败: "+err.Error(), constants.ED00006)
+		return nil, "", false
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	operatorId := request.GetOperatorID(ctx)
+	if tenantId == "" {
+		response.ErrorJSON(ctx, "无法获取租户信息", constants.ED00007)
+		return nil, "", false
+	}
+	if operatorId == "" {
+		response.ErrorJSON(ctx, "无法获取操作人信息", constants.ED00007)
+		return nil, "", false
+	}
+	if params.TaskId == "" {
+		response.ErrorJSON(ctx, "任务ID不能为空", constants.ED00007)
+		return nil, "", false
+	}
+
+	task, err := c.dao.GetById(ctx, tenantId, params.TaskId)
+	if err != nil {
+		response.ErrorJSON(ctx, "获取任务配置失败: "+err.Error(), constants.ED00009)
+		return nil, "", false
+	}
+	if task == nil {
+		response.ErrorJSON(ctx, "任务不存在", constants.ED00008)
+		return nil, "", false
+	}
+	if task.ActiveFlag != "Y" {
+		response.ErrorJSON(ctx, "任务已被删除或不可用", constants.ED00008)
+		return nil, "", false
+	}
+	return task, operatorId, true
+}
+
+// saveRunStatus 保存任务调度状态并返回最新任务配置，恢复为待执行时重置连续失败次数并按最新配置重新注册任务
+func (c *TaskConfigController) saveRunStatus(ctx *gin.Context, task *hub0003models.TimerTask, status int, operatorId, action string) {
+	resume := status == 1
+	if _, err := c.dao.UpdateRunStatus(ctx, task.TenantId, task.TaskId, status, resume, operatorId); err != nil {
+		response.ErrorJSON(ctx, action+"任务失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if resume {
+		if err := c.reloadTask(ctx, task); err != nil {
+			response.ErrorJSON(ctx, action+"任务失败: "+err.Error(), constants.ED00009)
+			return
+		}
+	}
+	logger.Info(action+"任务成功", "taskId", task.TaskId, "operatorId", operatorId)
+
+	updatedTask, err := c.dao.GetById(ctx, task.TenantId, task.TaskId)
+	if err != nil {
+		response.ErrorJSON(ctx, "获取更新后的任务配置失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	response.SuccessJSON(ctx, updatedTask, constants.SD00004)
+}
+
+// ListExecutorTypes 查询已注册的执行器类型
+// @Summary 查询执行器类型
+// @Description 查询当前网关已注册的任务执行器类型及启用状态，新增任务时 executorType 取其中之一
+// @Tags 任务管理
+// @Produce json
+// @Success 200 {object} response.Response
+// @Router /gateway/hub0003/task/executor-types [post]
+func (c *TaskConfigController) ListExecutorTypes(ctx *gin.Context) {
+	definitions := taskinit.ListExecutorFactories()
+	types := make([]gin.H, 0, len(definitions))
+	for _, definition := range definitions {
+		types = append(types, gin.H{
+			"executorType": definition.ExecutorType,
+			"description":  definition.Description,
+			"enabled":      definition.IsEnabled(),
+		})
+	}
+	response.SuccessJSON(ctx, types, constants.SD00001)
+}
+
+// reloadRegisteredTask 任务已注册到调度器时按数据库中的最新配置重新注册，未注册的任务在启动时注册
+func (c *TaskConfigController) reloadRegisteredTask(ctx context.Context, task *hub0003models.TimerTask) error {
+	if task.ActiveFlag != "Y" {
+		return nil
+	}
+	scheduler, err := timer.GetTimerPool().GetScheduler(taskSchedulerId(task))
+	if err != nil {
+		return nil
+	}
+	if _, err := scheduler.GetTask(task.TaskId); err != nil {
+		return nil
+	}
+	return c.reloadTask(ctx, task)
+}
+
+// reloadTask 按数据库中的最新配置注册任务，已注册的任务会被替换
+func (c *TaskConfigController) reloadTask(ctx context.Context, task *hub0003models.TimerTask) error {
+	if task.ExecutorType == nil || *task.ExecutorType == "" {
+		return fmt.Errorf("任务执行器类型不能为空")
+	}
+	return taskinit.RegisterTaskById(ctx, c.db, task.TenantId, task.TaskId)
+}
+
+// taskSchedulerId 任务所属调度器ID
+func taskSchedulerId(task *hub0003models.TimerTask) string {
+	if task.SchedulerId == nil {
+		return ""
+	}
+	return *task.SchedulerId
+}
+
+// cronPreviewMaxCount 预览Cron执行时间的最大次数
+const cronPreviewMaxCount = 20
+
+// PreviewCronSchedule 预览Cron表达式的后续执行时间
+// @Summary 预览Cron执行时间
+// @Description 按指定时区计算Cron表达式从当前时间开始的后续执行时间，用于保存任务前确认表达式
+// @Tags 任务管理
+// @Accept json
+// @Produce json
+// @Param data body object true "Cron预览参数"
+// @Success 200 {object} response.Response
+// @Router /gateway/hub0003/task/preview-cron [post]
+func (c *TaskConfigController) PreviewCronSchedule(ctx *gin.Context) {
+	var params struct {
+		CronExpression string `json:"cronExpression" form:"cronExpression" query:"cronExpression"`
+		TimeZone       string `json:"timeZone" form:"timeZone" query:"timeZone"`
+		Count          int    `json:"count" form:"count" query:"count"`
+	}
+	if err := request.BindSafely(ctx, &params); err != nil {
+		response.ErrorJSON(ctx, "参数解析失败: "+err.Error(), constants.ED00006)
+		return
+	}
+	if strings.TrimSpace(params.CronExpression) == "" {
+		response.ErrorJSON(ctx, "Cron表达式不能为空", constants.ED00007)
+		return
+	}
+	if params.Count <= 0 {
+		params.Count = 5
+	}
+	if params.Count > cronPreviewMaxCount {
+		params.Count = cronPreviewMaxCount
+	}
+
+	loc, err := timer.LoadTaskLocation(params.TimeZone)
+	if err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
+		return
+	}
+	times, err := cron.Preview(params.CronExpression, loc, time.Now(), params.Count)
+	if err != nil {
+		response.ErrorJSON(ctx, "Cron表达式无效: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	nextRunTimes := make([]string, 0, len(times))
+	for _, t := range times {
+		nextRunTimes = append(nextRunTimes, t.Format(time.RFC3339))
+	}
+	response.SuccessJSON(ctx, gin.H{
+		"cronExpression": params.CronExpression,
+		"timeZone":       loc.String(),
+		"nextRunTimes":   nextRunTimes,
+	}, constants.SD00001)
+}
+
+// validateTaskDependencies 校验并规范化任务依赖配置
+// 上游任务必须是同一调度器中的活动任务（依赖只在调度器内部触发），且不能形成循环依赖
+func (c *TaskConfigController) validateTaskDependencies(ctx context.Context, tenantId string, task *hub0003models.TimerTask) error {
+	policy, err := timer.ParseDependencyPolicy(strings.ToUpper(strings.TrimSpace(task.DependencyPolicy)))
+	if err != nil {
+		return fmt.Errorf("不支持的依赖失败策略: %s", task.DependencyPolicy)
+	}
+	task.DependencyPolicy = policy.String()
+
+	var dependsOn []string
+	if task.DependsOnTaskIds != nil {
+		dependsOn = timer.SplitDependsOn(*task.DependsOnTaskIds)
+	}
+	if len(dependsOn) == 0 {
+		task.DependsOnTaskIds = nil
+		return nil
+	}
+	joined := strings.Join(dependsOn, ",")
+	task.DependsOnTaskIds = &joined
+
+	siblings, err := c.dao.ListBySchedulerId(ctx, tenantId, taskSchedulerId(task))
+	if err != nil {
+		return fmt.Errorf("查询调度器任务失败: %w", err)
+	}
+	dependencies := make(map[string][]string, len(siblings)+1)
+	for _, sibling := range siblings {
+		if sibling.TaskId == task.TaskId {
+			continue
+		}
+		var upstreams []string
+		if sibling.DependsOnTaskIds != nil {
+			upstreams = timer.SplitDependsOn(*sibling.DependsOnTaskIds)
+		}
+		dependencies[sibling.TaskId] = upstreams
+	}
+	for _, upstream := range dependsOn {
+		if upstream == task.TaskId {
+			return fmt.Errorf("任务不能依赖自身")
+		}
+		if _, exists := dependencies[upstream]; !exists {
+			return fmt.Errorf("上游任务 %s 不存在或不属于同一调度器", upstream)
+		}
+	}
+	if task.TaskId != "" {
+		dependencies[task.TaskId] = dependsOn
+		if cycle := timer.FindDependencyCycle(dependencies); cycle != nil {
+			return fmt.Errorf("任务依赖存在循环: %s", strings.Join(cycle, " -> "))
+		}
+	}
+	return nil
+}
+
+// validateTaskRetry 校验重试退避和死信配置
+func validateTaskRetry(task *hub0003models.TimerTask) error {
+	if task.RetryBackoffRate < 0 {
+		return fmt.Errorf("重试退避倍率不能为负数")
+	}
+	if task.MaxRetryIntervalSeconds != nil && *task.MaxRetryIntervalSeconds < 0 {
+		return fmt.Errorf("最大重试间隔不能为负数")
+	}
+	if task.DeadLetterThreshold < 0 {
+		return fmt.Errorf("死信阈值不能为负数")
+	}
+	return nil
+}
+
+// validateTaskCron 校验Cron调度任务的表达式和时区，非Cron调度且未填写表达式时不校验
+func validateTaskCron(task *hub0003models.TimerTask) error {
+	if task.ScheduleType != int(timer.ScheduleTypeCron) && (task.CronExpression == nil || *task.CronExpression == "") {
+		return nil
+	}
+	if task.CronExpression == nil || *task.CronExpression == "" {
+		return fmt.Errorf("Cron调度类型必须提供Cron表达式")
+	}
+	timeZone := ""
+	if task.TimeZone != nil {
+		timeZone = strings.TrimSpace(*task.TimeZone)
+		task.TimeZone = &timeZone
+	}
+	if _, err := timer.ParseTaskCron(*task.CronExpression, timeZone); err != nil {
+		return fmt.Errorf("Cron表达式无效: %w", err)
+	}
+	return nil
+}
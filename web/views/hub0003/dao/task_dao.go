@@ -4,6 +4,7 @@ import (
 	"context"
 	"gateway/pkg/database"
 	"gateway/pkg/database/sqlutils"
+	"gateway/pkg/utils/random"
 	hub0003models "gateway/web/views/hub0003/models"
 	"time"
 )
@@ -37,9 +38,10 @@ func (dao *TaskDao) GetById(ctx context.Context, tenantId, taskId string) (*hub0
 // Update 更新任务配置
 func (dao *TaskDao) Update(ctx context.Context, task *hub0003models.TimerTask) (int64, error) {
 	query := "UPDATE " + task.TableName() + " SET taskName = ?, taskDescription = ?, taskPriority = ?, " +
-		"schedulerId = ?, schedulerName = ?, scheduleType = ?, cronExpression = ?, " +
-		"intervalSeconds = ?, delaySeconds = ?, startTime = ?, endTime = ?, " +
-		"maxRetries = ?, retryIntervalSeconds = ?, timeoutSeconds = ?, taskParams = ?, " +
+		"schedulerId = ?, schedulerName = ?, scheduleType = ?, cronExpression = ?, timeZone = ?, " +
+		"intervalSeconds = ?, delaySeconds = ?, startTime = ?, endTime = ?, dependsOnTaskIds = ?, dependencyPolicy = ?, " +
+		"maxRetries = ?, retryIntervalSeconds = ?, retryBackoffRate = ?, maxRetryIntervalSeconds = ?, deadLetterThreshold = ?, " +
+		"timeoutSeconds = ?, taskParams = ?, " +
 		"executorType = ?, toolConfigId = ?, toolConfigName = ?, operationType = ?, operationConfig = ?, " +
 		"taskStatus = ?, activeFlag = ?, " +
 		"editTime = ?, editWho = ?, oprSeqFlag = ?, currentVersion = currentVersion + 1, noteText = ? " +
@@ -47,9 +49,10 @@ func (dao *TaskDao) Update(ctx context.Context, task *hub0003models.TimerTask) (
 
 	args := []interface{}{
 		task.TaskName, task.TaskDescription, task.TaskPriority,
-		task.SchedulerId, task.SchedulerName, task.ScheduleType, task.CronExpression,
-		task.IntervalSeconds, task.DelaySeconds, task.StartTime, task.EndTime,
-		task.MaxRetries, task.RetryIntervalSeconds, task.TimeoutSeconds, task.TaskParams,
+		task.SchedulerId, task.SchedulerName, task.ScheduleType, task.CronExpression, task.TimeZone,
+		task.IntervalSeconds, task.DelaySeconds, task.StartTime, task.EndTime, task.DependsOnTaskIds, task.DependencyPolicy,
+		task.MaxRetries, task.RetryIntervalSeconds, task.RetryBackoffRate, task.MaxRetryIntervalSeconds, task.DeadLetterThreshold,
+		task.TimeoutSeconds, task.TaskParams,
 		task.ExecutorType, task.ToolConfigId, task.ToolConfigName, task.OperationType, task.OperationConfig,
 		task.TaskStatus, task.ActiveFlag,
 		task.EditTime, task.EditWho, task.OprSeqFlag, task.NoteText,
@@ -166,6 +169,17 @@ func (dao *TaskDao) Query(ctx context.Context, params map[string]interface{}, pa
 	return tasks, total, nil
 }
 
+// ListBySchedulerId 查询调度器下的活动任务，用于校验任务依赖关系
+func (dao *TaskDao) ListBySchedulerId(ctx context.Context, tenantId, schedulerId string) ([]*hub0003models.TimerTask, error) {
+	var tasks []*hub0003models.TimerTask
+	query := "SELECT * FROM " + (&hub0003models.TimerTask{}).TableName() +
+		" WHERE tenantId = ? AND schedulerId = ? AND activeFlag = 'Y'"
+	if err := dao.db.Query(ctx, &tasks, query, []interface{}{tenantId, schedulerId}, true); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
 // UpdateTaskStatus 更新任务状态
 func (dao *TaskDao) UpdateTaskStatus(ctx context.Context, tenantId, taskId string, status int, editWho string) (int64, error) {
 	query := "UPDATE " + (&hub0003models.TimerTask{}).TableName() +
@@ -175,6 +189,21 @@ func (dao *TaskDao) UpdateTaskStatus(ctx context.Context, tenantId, taskId strin
 	return dao.db.Exec(ctx, query, []interface{}{status, editWho, tenantId, taskId}, true)
 }
 
+// UpdateRunStatus 更新任务的调度状态，用于暂停和恢复
+// 恢复任务时重置连续失败次数，避免恢复后一次失败就再次进入死信状态
+func (dao *TaskDao) UpdateRunStatus(ctx context.Context, tenantId, taskId string, status int, resetFailures bool, editWho string) (int64, error) {
+	query := "UPDATE " + (&hub0003models.TimerTask{}).TableName() +
+		" SET taskStatus = ?, editWho = ?, editTime = ?, oprSeqFlag = ?, currentVersion = currentVersion + 1"
+	args := []interface{}{status, editWho, time.Now(), random.Generate32BitRandomString()}
+	if resetFailures {
+		query += ", consecutiveFailures = 0"
+	}
+	query += " WHERE tenantId = ? AND taskId = ?"
+	args = append(args, tenantId, taskId)
+
+	return dao.db.Exec(ctx, query, args, true)
+}
+
 // UpdateNextRunTime 更新任务下次执行时间
 func (dao *TaskDao) UpdateNextRunTime(ctx context.Context, tenantId, taskId string, nextRunTime time.Time) (int64, error) {
 	query := "UPDATE " + (&hub0003models.TimerTask{}).TableName() +
@@ -20,14 +20,20 @@ type TimerTask struct {
 	// 调度配置
 	ScheduleType      int     `json:"scheduleType" form:"scheduleType" query:"scheduleType" db:"scheduleType"`
 	CronExpression    *string `json:"cronExpression" form:"cronExpression" query:"cronExpression" db:"cronExpression"`
+	TimeZone          *string `json:"timeZone" form:"timeZone" query:"timeZone" db:"timeZone"`
 	IntervalSeconds   *int64  `json:"intervalSeconds" form:"intervalSeconds" query:"intervalSeconds" db:"intervalSeconds"`
 	DelaySeconds      *int64  `json:"delaySeconds" form:"delaySeconds" query:"delaySeconds" db:"delaySeconds"`
 	StartTime         *time.Time `json:"startTime" form:"startTime" query:"startTime" db:"startTime"`
 	EndTime           *time.Time `json:"endTime" form:"endTime" query:"endTime" db:"endTime"`
+	DependsOnTaskIds  *string `json:"dependsOnTaskIds" form:"dependsOnTaskIds" query:"dependsOnTaskIds" db:"dependsOnTaskIds"`
+	DependencyPolicy  string  `json:"dependencyPolicy" form:"dependencyPolicy" query:"dependencyPolicy" db:"dependencyPolicy"`
 	
 	// 执行配置
 	MaxRetries        int     `json:"maxRetries" form:"maxRetries" query:"maxRetries" db:"maxRetries"`
 	RetryIntervalSeconds int64 `json:"retryIntervalSeconds" form:"retryIntervalSeconds" query:"retryIntervalSeconds" db:"retryIntervalSeconds"`
+	RetryBackoffRate  float64 `json:"retryBackoffRate" form:"retryBackoffRate" query:"retryBackoffRate" db:"retryBackoffRate"`
+	MaxRetryIntervalSeconds *int64 `json:"maxRetryIntervalSeconds" form:"maxRetryIntervalSeconds" query:"maxRetryIntervalSeconds" db:"maxRetryIntervalSeconds"`
+	DeadLetterThreshold int   `json:"deadLetterThreshold" form:"deadLetterThreshold" query:"deadLetterThreshold" db:"deadLetterThreshold"`
 	TimeoutSeconds    int64   `json:"timeoutSeconds" form:"timeoutSeconds" query:"timeoutSeconds" db:"timeoutSeconds"`
 	TaskParams        *string `json:"taskParams" form:"taskParams" query:"taskParams" db:"taskParams"`
 	
@@ -45,6 +51,7 @@ type TimerTask struct {
 	RunCount          int64   `json:"runCount" form:"runCount" query:"runCount" db:"runCount"`
 	SuccessCount      int64   `json:"successCount" form:"successCount" query:"successCount" db:"successCount"`
 	FailureCount      int64   `json:"failureCount" form:"failureCount" query:"failureCount" db:"failureCount"`
+	ConsecutiveFailures int64 `json:"consecutiveFailures" form:"consecutiveFailures" query:"consecutiveFailures" db:"consecutiveFailures"`
 	
 	// 最后执行结果
 	LastExecutionId   *string `json:"lastExecutionId" form:"lastExecutionId" query:"lastExecutionId" db:"lastExecutionId"`
@@ -3,6 +3,7 @@ package hub0003routes
 import (
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
+	"gateway/web/middleware/permission"
 	"gateway/web/routes"
 	"gateway/web/views/hub0003/controllers"
 
@@ -73,13 +74,20 @@ func initTaskRoutes(router *gin.RouterGroup, db database.Database) {
 		taskGroup.POST("/delete", taskController.DeleteTaskConfig)
 		taskGroup.POST("/query", taskController.QueryTaskConfigs)
 		taskGroup.POST("/update-status", taskController.UpdateTaskStatus)
+		taskGroup.POST("/preview-cron", taskController.PreviewCronSchedule) // 预览Cron执行时间
 
 		// 任务控制操作
 		taskGroup.POST("/start", taskController.StartTask)
 		taskGroup.POST("/stop", taskController.StopTask)
+		taskGroup.POST("/pause", taskController.PauseTask)                  // 暂停调度，状态持久化
+		taskGroup.POST("/resume", taskController.ResumeTask)                // 恢复暂停或死信任务
+		taskGroup.POST("/executor-types", taskController.ListExecutorTypes) // 已注册的执行器类型
 
 		// 任务执行操作
 		taskGroup.POST("/trigger", taskController.TriggerTask) // 立即执行任务
+
+		// 查询类接口只校验模块权限，暂停、恢复和立即执行按接口名推导为 hub0003:edit
+		permission.RegisterRoutePermission("POST", APIPrefix+"/task/executor-types", "")
 	}
 }
 
@@ -95,5 +103,8 @@ func initExecutionLogRoutes(router *gin.RouterGroup, db database.Database) {
 		logGroup.POST("/get", executionLogController.GetTaskLog)
 		logGroup.POST("/query", executionLogController.QueryTaskLogs)
 		logGroup.POST("/task-logs", executionLogController.GetTaskLogsByTaskId)
+
+		// 查询类接口只校验模块权限
+		permission.RegisterRoutePermission("POST", APIPrefix+"/log/task-logs", "")
 	}
 }
@@ -3,6 +3,7 @@ package controllers
 import (
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
+	"gateway/web/middleware"
 	"gateway/web/utils/constants"
 	"gateway/web/utils/request"
 	"gateway/web/utils/response"
@@ -134,6 +135,7 @@ func (c *ResourceController) AddResource(ctx *gin.Context) {
 	// 返回完整的资源信息
 	resourceInfo := resourceToMap(newResource)
 
+	invalidatePermissionResources()
 	response.SuccessJSON(ctx, resourceInfo, constants.SD00003)
 }
 
@@ -214,6 +216,7 @@ func (c *ResourceController) EditResource(ctx *gin.Context) {
 	// 返回完整的资源信息
 	resourceInfo := resourceToMap(updatedResource)
 
+	invalidatePermissionResources()
 	response.SuccessJSON(ctx, resourceInfo, constants.SD00004)
 }
 
@@ -294,6 +297,7 @@ func (c *ResourceController) DeleteResource(ctx *gin.Context) {
 		return
 	}
 
+	invalidatePermissionResources()
 	response.SuccessJSON(ctx, gin.H{
 		"resourceId": resourceId,
 	}, constants.SD00005)
@@ -341,6 +345,7 @@ func (c *ResourceController) UpdateResourceStatus(ctx *gin.Context) {
 		return
 	}
 
+	invalidatePermissionResources()
 	response.SuccessJSON(ctx, gin.H{
 		"resourceId": resourceId,
 		"status":     status,
@@ -425,3 +430,10 @@ func buildResourceTree(items []map[string]interface{}, idField, parentField, roo
 
 	return rootNodes
 }
+
+// invalidatePermissionResources 资源变更后清除权限中间件的资源编码缓存，使新增或停用的权限编码立即生效
+func invalidatePermissionResources() {
+	if service := middleware.GetPermissionService(); service != nil {
+		service.InvalidateResourceCodes()
+	}
+}
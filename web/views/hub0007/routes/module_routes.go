@@ -3,6 +3,7 @@ package hub0007routes
 import (
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
+	"gateway/web/middleware/permission"
 	"gateway/web/routes"
 	"gateway/web/views/hub0007/controllers"
 
@@ -92,6 +93,11 @@ func initServerInfoRoutes(router *gin.RouterGroup, db database.Database) {
 
 		// 进程监控数据
 		metricsGroup.POST("/process", serverInfoController.QueryProcessMetrics)
+
+		// 监控数据查询接口按接口名无法推导，显式登记为只校验模块权限
+		for _, name := range []string{"cpu", "memory", "disk", "diskio", "network", "process"} {
+			permission.RegisterRoutePermission("POST", APIPrefix+"/metrics/"+name, "")
+		}
 	}
 }
 
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"encoding/json"
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/web/utils/audit"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0009/dao"
+	"gateway/web/views/hub0009/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditLogController 操作审计控制器
+type AuditLogController struct {
+	db          database.Database
+	auditLogDAO *dao.AuditLogDAO
+}
+
+// NewAuditLogController 创建操作审计控制器
+func NewAuditLogController(db database.Database) *AuditLogController {
+	return &AuditLogController{
+		db:          db,
+		auditLogDAO: dao.NewAuditLogDAO(db),
+	}
+}
+
+// QueryAuditLogs 查询操作审计列表
+// @Summary 查询操作审计列表
+// @Description 分页查询当前租户的操作审计记录，支持按操作人、模块、操作类型、变更表和时间范围筛选
+// @Tags 操作审计
+// @Accept json
+// @Produce json
+// @Param request body object{page=int,pageSize=int,userId=string,userName=string,moduleCode=string,actionName=string,operationType=string,tableName=string,resultFlag=string,startTime=string,endTime=string} false "查询条件"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0009/queryAuditLogs [post]
+func (c *AuditLogController) QueryAuditLogs(ctx *gin.Context) {
+	// 使用工具类获取分页参数
+	page, pageSize := request.GetPaginationParams(ctx)
+	// 使用工具类获取租户ID
+	tenantId := request.GetTenantID(ctx)
+
+	// 绑定查询条件（支持 Query / JSON Body / Form 等多种来源）
+	var query models.AuditLogQuery
+	if err := request.BindSafely(ctx, &query); err != nil {
+		logger.WarnWithTrace(ctx, "绑定操作审计查询条件失败，使用默认条件", "error", err.Error())
+	}
+
+	// 调用DAO获取操作审计列表
+	records, total, err := c.auditLogDAO.ListAuditLogs(ctx, tenantId, &query, page, pageSize)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取操作审计列表失败", err)
+		response.ErrorJSON(ctx, "获取操作审计列表失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	// 转换为响应格式
+	recordList := make([]map[string]interface{}, 0, len(records))
+	for _, record := range records {
+		recordList = append(recordList, auditLogToMap(record))
+	}
+
+	// 创建分页信息并返回
+	pageInfo := response.NewPageInfo(page, pageSize, total)
+	pageInfo.MainKey = "auditId"
+
+	// 使用统一的分页响应
+	response.PageJSON(ctx, recordList, pageInfo, constants.SD00002)
+}
+
+// GetAuditLogDetail 获取操作审计详情
+// @Summary 获取操作审计详情
+// @Description 根据审计记录ID获取操作审计详情，包含每张表变更前后的数据和字段差异
+// @Tags 操作审计
+// @Accept json
+// @Produce json
+// @Param request body object{auditId=string} true "审计记录ID"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0009/getAuditLogDetail [post]
+func (c *AuditLogController) GetAuditLogDetail(ctx *gin.Context) {
+	// 从请求体中获取审计记录ID
+	auditId := request.GetParam(ctx, "auditId")
+	if auditId == "" {
+		response.ErrorJSON(ctx, "审计记录ID不能为空", constants.ED00006)
+		return
+	}
+
+	// 使用工具类获取租户ID
+	tenantId := request.GetTenantID(ctx)
+
+	// 调用DAO获取审计记录详情
+	record, err := c.auditLogDAO.GetAuditLogById(ctx, auditId, tenantId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取操作审计详情失败", err)
+		response.ErrorJSON(ctx, "获取操作审计详情失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	if record == nil {
+		response.ErrorJSON(ctx, "操作审计记录不存在", constants.ED00008)
+		return
+	}
+
+	// 转换为响应格式，变更数据以JSON对象返回，解析失败时返回原始文本
+	recordInfo := auditLogToMap(record)
+	recordInfo["changeData"] = record.ChangeData
+	if json.Valid([]byte(record.ChangeData)) {
+		recordInfo["changeData"] = json.RawMessage(record.ChangeData)
+	}
+
+	response.SuccessJSON(ctx, recordInfo, constants.SD00002)
+}
+
+// auditLogToMap 将审计记录转换为响应格式，不包含变更数据
+func auditLogToMap(record *audit.AuditRecord) map[string]interface{} {
+	if record == nil {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"auditId":        record.AuditId,
+		"tenantId":       record.TenantId,
+		"userId":         record.UserId,
+		"userName":       record.UserName,
+		"clientIp":       record.ClientIp,
+		"userAgent":      record.UserAgent,
+		"moduleCode":     record.ModuleCode,
+		"actionName":     record.ActionName,
+		"httpMethod":     record.HttpMethod,
+		"requestPath":    record.RequestPath,
+		"operationType":  record.OperationType,
+		"tableNames":     record.TableNames,
+		"changeCount":    record.ChangeCount,
+		"resultFlag":     record.ResultFlag,
+		"httpStatus":     record.HttpStatus,
+		"durationMs":     record.DurationMs,
+		"traceId":        record.TraceId,
+		"operateTime":    record.OperateTime,
+		"addTime":        record.AddTime,
+		"addWho":         record.AddWho,
+		"activeFlag":     record.ActiveFlag,
+		"currentVersion": record.CurrentVersion,
+	}
+}
@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gateway/pkg/config"
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/pkg/timer"
+	"gateway/web/utils/audit"
+	"gateway/web/views/hub0009/dao"
+)
+
+// auditRetentionSchedulerId 操作审计清理调度器ID
+const auditRetentionSchedulerId = "AUDIT_RETENTION_scheduler"
+
+// auditRetentionTaskId 操作审计清理任务ID
+const auditRetentionTaskId = "SYS_AUDIT_RETENTION"
+
+// AuditRetentionJob 操作审计清理任务
+// 周期删除操作时间超过 web.audit.retention_days 的审计记录，保留天数不大于0时不清理
+type AuditRetentionJob struct {
+	dao *dao.AuditLogDAO
+}
+
+// NewAuditRetentionJob 创建操作审计清理任务
+func NewAuditRetentionJob(db database.Database) *AuditRetentionJob {
+	return &AuditRetentionJob{dao: dao.NewAuditLogDAO(db)}
+}
+
+// Start 注册周期清理任务，未启用操作审计时不注册
+// 返回:
+//
+//	error: 创建调度器或注册任务失败时返回错误
+func (j *AuditRetentionJob) Start() error {
+	if !audit.Enabled() {
+		logger.Info("操作审计未启用，跳过注册审计清理任务")
+		return nil
+	}
+
+	interval := time.Duration(config.GetInt("web.audit.purge_interval_hours", 24)) * time.Hour
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+
+	pool := timer.GetTimerPool()
+	scheduler, err := pool.GetScheduler(auditRetentionSchedulerId)
+	if err != nil {
+		scheduler, err = pool.CreateScheduler(&timer.SchedulerConfig{
+			ID:               auditRetentionSchedulerId,
+			Name:             "操作审计清理调度器",
+			MaxWorkers:       1,
+			QueueSize:        10,
+			DefaultTimeout:   time.Hour,
+			DefaultRetries:   0,
+			ScheduleInterval: time.Second,
+			Tasks:            make(map[string]*timer.TaskConfig),
+		})
+		if err != nil {
+			return fmt.Errorf("创建操作审计清理调度器失败: %w", err)
+		}
+	}
+
+	taskConfig := timer.NewTaskConfig(auditRetentionTaskId, "操作审计清理", timer.ScheduleTypeInterval)
+	taskConfig.Description = "清理超过保留天数的操作审计记录"
+	taskConfig.Interval = interval
+	taskConfig.Timeout = time.Hour
+	if err := scheduler.AddTask(taskConfig, &auditRetentionTaskExecutor{job: j}); err != nil {
+		return fmt.Errorf("注册操作审计清理任务失败: %w", err)
+	}
+	if !scheduler.IsRunning() {
+		if err := scheduler.Start(); err != nil {
+			return fmt.Errorf("启动操作审计清理调度器失败: %w", err)
+		}
+	}
+	logger.Info("操作审计清理任务注册完成", "interval", interval.String())
+	return nil
+}
+
+// RunOnce 执行一次清理
+// 参数:
+//
+//	ctx: 上下文对象
+//
+// 返回:
+//
+//	string: 本次清理摘要
+//	error: 清理失败时返回错误
+func (j *AuditRetentionJob) RunOnce(ctx context.Context) (string, error) {
+	retentionDays := config.GetInt("web.audit.retention_days", audit.DefaultRetentionDays)
+	if retentionDays <= 0 {
+		return "未配置保留天数，不清理", nil
+	}
+	affected, err := j.dao.PurgeAuditLogs(ctx, time.Now().AddDate(0, 0, -retentionDays))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("清理 %d 条超过 %d 天的操作审计记录", affected, retentionDays), nil
+}
+
+// auditRetentionTaskExecutor 操作审计清理任务执行器，实现 timer.TaskExecutor 接口
+type auditRetentionTaskExecutor struct {
+	job *AuditRetentionJob
+}
+
+// Execute 执行一次清理
+func (e *auditRetentionTaskExecutor) Execute(ctx context.Context, params interface{}) (*timer.ExecuteResult, error) {
+	summary, err := e.job.RunOnce(ctx)
+	if err != nil {
+		return &timer.ExecuteResult{Success: false, Message: err.Error()}, err
+	}
+	return &timer.ExecuteResult{Success: true, Message: summary}, nil
+}
+
+// GetName 执行器名称
+func (e *auditRetentionTaskExecutor) GetName() string {
+	return "SysAuditRetention"
+}
+
+// Close 无需释放资源
+func (e *auditRetentionTaskExecutor) Close() error {
+	return nil
+}
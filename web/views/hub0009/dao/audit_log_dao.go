@@ -0,0 +1,169 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"gateway/pkg/database"
+	"gateway/pkg/database/sqlutils"
+	"gateway/pkg/utils/empty"
+	"gateway/pkg/utils/huberrors"
+	"gateway/web/utils/audit"
+	"gateway/web/views/hub0009/models"
+	"time"
+)
+
+// auditLogListColumns 列表查询的列，不包含变更数据，变更数据只在详情中返回
+const auditLogListColumns = `tenantId, auditId, userId, userName, clientIp, userAgent,
+		moduleCode, actionName, httpMethod, requestPath,
+		operationType, tableNames, changeCount,
+		resultFlag, httpStatus, durationMs, traceId, operateTime,
+		addTime, addWho, editTime, editWho, oprSeqFlag, currentVersion, activeFlag`
+
+// AuditLogDAO 操作审计数据访问对象
+type AuditLogDAO struct {
+	db database.Database
+}
+
+// NewAuditLogDAO 创建操作审计DAO
+func NewAuditLogDAO(db database.Database) *AuditLogDAO {
+	return &AuditLogDAO{
+		db: db,
+	}
+}
+
+// GetAuditLogById 根据审计记录ID获取审计记录详情（包含变更数据）
+func (dao *AuditLogDAO) GetAuditLogById(ctx context.Context, auditId, tenantId string) (*audit.AuditRecord, error) {
+	if auditId == "" || tenantId == "" {
+		return nil, errors.New("auditId和tenantId不能为空")
+	}
+
+	query := `
+		SELECT * FROM HUB_SYS_AUDIT
+		WHERE auditId = ? AND tenantId = ?
+	`
+
+	var record audit.AuditRecord
+	err := dao.db.QueryOne(ctx, &record, query, []interface{}{auditId, tenantId}, true)
+
+	if err != nil {
+		if err == database.ErrRecordNotFound {
+			return nil, nil // 没有找到记录，返回nil而不是错误
+		}
+		return nil, huberrors.WrapError(err, "查询操作审计记录失败")
+	}
+
+	return &record, nil
+}
+
+// ListAuditLogs 获取操作审计列表（支持条件查询），按操作时间倒序
+func (dao *AuditLogDAO) ListAuditLogs(ctx context.Context, tenantId string, query *models.AuditLogQuery, page, pageSize int) ([]*audit.AuditRecord, int, error) {
+	// 创建分页信息
+	pagination := sqlutils.NewPaginationInfo(page, pageSize)
+
+	// 获取数据库类型
+	dbType := sqlutils.GetDatabaseType(dao.db)
+
+	// 构建查询条件
+	whereClause := "WHERE tenantId = ?"
+	var params []interface{}
+	params = append(params, tenantId)
+
+	// 构建查询条件，只有当字段不为空时才添加对应条件
+	if query != nil {
+		if !empty.IsEmpty(query.UserId) {
+			whereClause += " AND userId = ?"
+			params = append(params, query.UserId)
+		}
+		if !empty.IsEmpty(query.UserName) {
+			whereClause += " AND userName LIKE ?"
+			params = append(params, "%"+query.UserName+"%")
+		}
+		if !empty.IsEmpty(query.ModuleCode) {
+			whereClause += " AND moduleCode = ?"
+			params = append(params, query.ModuleCode)
+		}
+		if !empty.IsEmpty(query.ActionName) {
+			whereClause += " AND actionName = ?"
+			params = append(params, query.ActionName)
+		}
+		if !empty.IsEmpty(query.OperationType) {
+			whereClause += " AND operationType = ?"
+			params = append(params, query.OperationType)
+		}
+		if !empty.IsEmpty(query.TableName) {
+			whereClause += " AND tableNames LIKE ?"
+			params = append(params, "%"+query.TableName+"%")
+		}
+		if !empty.IsEmpty(query.ResultFlag) {
+			whereClause += " AND resultFlag = ?"
+			params = append(params, query.ResultFlag)
+		}
+		if !empty.IsEmpty(query.StartTime) {
+			whereClause += " AND operateTime >= ?"
+			params = append(params, query.StartTime)
+		}
+		if !empty.IsEmpty(query.EndTime) {
+			whereClause += " AND operateTime <= ?"
+			params = append(params, query.EndTime)
+		}
+	}
+
+	// 基础查询语句
+	baseQuery := `
+		SELECT ` + auditLogListColumns + ` FROM HUB_SYS_AUDIT
+	` + whereClause + `
+		ORDER BY operateTime DESC
+	`
+
+	// 构建计数查询
+	countQuery, err := sqlutils.BuildCountQuery(baseQuery)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "构建计数查询失败")
+	}
+
+	// 执行计数查询
+	var result struct {
+		Count int `db:"COUNT(*)"`
+	}
+	err = dao.db.QueryOne(ctx, &result, countQuery, params, true)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "查询操作审计总数失败")
+	}
+	total := result.Count
+
+	// 如果没有记录，直接返回空列表
+	if total == 0 {
+		return []*audit.AuditRecord{}, 0, nil
+	}
+
+	// 构建分页查询
+	paginatedQuery, paginationArgs, err := sqlutils.BuildPaginationQuery(dbType, baseQuery, pagination)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "构建分页查询失败")
+	}
+
+	// 合并查询参数：基础查询参数 + 分页参数
+	queryArgs := append(params, paginationArgs...)
+
+	// 执行分页查询
+	var records []*audit.AuditRecord
+	err = dao.db.Query(ctx, &records, paginatedQuery, queryArgs, true)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "查询操作审计列表失败")
+	}
+
+	return records, total, nil
+}
+
+// PurgeAuditLogs 删除操作时间早于 before 的审计记录
+// 返回:
+//
+//	int64: 删除的行数
+//	error: 删除失败时返回错误
+func (dao *AuditLogDAO) PurgeAuditLogs(ctx context.Context, before time.Time) (int64, error) {
+	affected, err := dao.db.Delete(ctx, audit.AuditTable, "operateTime < ?", []interface{}{before}, true)
+	if err != nil {
+		return 0, huberrors.WrapError(err, "清理操作审计记录失败")
+	}
+	return affected, nil
+}
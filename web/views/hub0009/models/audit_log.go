@@ -0,0 +1,14 @@
+package models
+
+// AuditLogQuery 操作审计查询条件
+type AuditLogQuery struct {
+	UserId        string `json:"userId" form:"userId" query:"userId"`                      // 操作用户ID（精确查询）
+	UserName      string `json:"userName" form:"userName" query:"userName"`                // 操作用户名（模糊查询）
+	ModuleCode    string `json:"moduleCode" form:"moduleCode" query:"moduleCode"`          // 模块编码（精确查询）
+	ActionName    string `json:"actionName" form:"actionName" query:"actionName"`          // 接口动作（精确查询）
+	OperationType string `json:"operationType" form:"operationType" query:"operationType"` // 操作类型（精确查询）
+	TableName     string `json:"tableName" form:"tableName" query:"tableName"`             // 变更的表（模糊查询）
+	ResultFlag    string `json:"resultFlag" form:"resultFlag" query:"resultFlag"`          // 请求是否成功：Y/N，空表示全部
+	StartTime     string `json:"startTime" form:"startTime" query:"startTime"`             // 操作开始时间
+	EndTime       string `json:"endTime" form:"endTime" query:"endTime"`                   // 操作结束时间
+}
@@ -0,0 +1,71 @@
+package routes
+
+import (
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/web/routes"
+	"gateway/web/views/hub0009/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 模块配置
+// 这些变量定义了模块的基本信息，用于路由注册和API路径设置
+var (
+	// ModuleName 模块名称，必须与目录名称一致，用于模块识别和查找
+	ModuleName = "hub0009"
+
+	// APIPrefix API路径前缀，所有该模块的API都将以此为基础路径
+	// 实际路由时将根据RouteDiscovery的设置可能会使用"/api/hub0009"
+	APIPrefix = "/gateway/hub0009"
+)
+
+// init 包初始化函数
+// 当包被导入时会自动执行
+// 在这里注册模块的路由初始化函数，这样就不需要手动注册了
+func init() {
+	// 自动注册路由初始化函数
+	routes.RegisterModuleRoutes(ModuleName, Init)
+	logger.Info("模块路由自动注册", "module", ModuleName)
+}
+
+// Init 初始化模块路由
+// 此函数会在路由发现过程中被自动发现和调用，发现机制如下：
+//  1. RouteDiscovery.DiscoverModules() 扫描views目录下以"hub"开头的所有子目录
+//  2. 对于每个发现的子目录，创建一个StandardModule对象
+//  3. StandardModule.RegisterRoutes() 通过以下两种方式查找路由注册函数：
+//     a. 首先尝试通过getRouteInitFunc()从预定义映射中查找（如果已手动注册）
+//     b. 否则，如果存在controllers目录，则通过约定式路由自动生成
+//  4. 当此函数被调用时，会收到全局的gin.Engine和数据库连接实例
+//
+// 参数:
+//   - router: Gin路由引擎实例
+//   - db: 数据库连接实例
+func Init(router *gin.Engine, db database.Database) {
+	// 创建模块路由组
+	group := router.Group(APIPrefix, routes.PermissionRequired()...)
+
+	// 操作审计相关路由
+	initAuditLogRoutes(group, db)
+}
+
+// initAuditLogRoutes 初始化操作审计相关路由，并注册审计记录清理任务
+// 审计记录只提供查询，不提供修改和删除接口
+//
+// 参数:
+//   - router: Gin路由组
+//   - db: 数据库连接实例
+func initAuditLogRoutes(router *gin.RouterGroup, db database.Database) {
+	job := controllers.NewAuditRetentionJob(db)
+	if err := job.Start(); err != nil {
+		logger.Error("注册操作审计清理任务失败", "error", err)
+	}
+
+	auditLogController := controllers.NewAuditLogController(db)
+	{
+		// 查询操作审计列表
+		router.POST("/queryAuditLogs", auditLogController.QueryAuditLogs)
+		// 获取操作审计详情
+		router.POST("/getAuditLogDetail", auditLogController.GetAuditLogDetail)
+	}
+}
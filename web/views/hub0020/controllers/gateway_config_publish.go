@@ -0,0 +1,276 @@
+package controllers
+
+import (
+	"gateway/internal/gateway/bootstrap"
+	"gateway/internal/gateway/config"
+	"gateway/internal/gateway/loader"
+	"gateway/internal/gateway/loader/dbloader"
+	"gateway/pkg/logger"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreviewGatewayConfig 预览待发布的网关配置
+// @Summary 预览网关配置变更
+// @Description 从数据库组装网关配置并执行发布前校验，返回与本节点当前生效配置的差异，不会应用配置
+// @Tags 网关实例管理
+// @Accept json
+// @Produce json
+// @Param gatewayInstanceId query string true "网关实例ID"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0020/previewGatewayConfig [post]
+func (c *GatewayInstanceController) PreviewGatewayConfig(ctx *gin.Context) {
+	gatewayInstanceId := request.GetParam(ctx, "gatewayInstanceId")
+	if gatewayInstanceId == "" {
+		response.ErrorJSON(ctx, "网关实例ID不能为空", constants.ED00007)
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+
+	instance, err := c.gatewayInstanceDAO.GetGatewayInstanceById(ctx, gatewayInstanceId, tenantId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取网关实例信息失败", err)
+		response.ErrorJSON(ctx, "获取网关实例信息失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if instance == nil {
+		response.ErrorJSON(ctx, "网关实例不存在", constants.ED00008)
+		return
+	}
+
+	configLoader := loader.NewDatabaseConfigLoader(c.db, tenantId)
+	newConfig, err := configLoader.LoadGatewayConfig(gatewayInstanceId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "从数据库加载网关配置失败", err)
+		response.ErrorJSON(ctx, "加载网关配置失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	newVersion, err := bootstrap.ComputeConfigVersion(newConfig)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "计算网关配置版本失败", err)
+		response.ErrorJSON(ctx, "计算配置版本失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	// 校验失败时仍返回差异，便于定位问题配置
+	validationError := ""
+	if err := bootstrap.ValidateGatewayConfig(newConfig); err != nil {
+		validationError = err.Error()
+	}
+
+	// 以本节点运行中的配置为比较基准，实例未运行时所有配置项都视为新增
+	var currentConfig *config.GatewayConfig
+	gatewayPool := bootstrap.GetGlobalPool()
+	if gatewayPool.Exists(gatewayInstanceId) {
+		if gateway, err := gatewayPool.Get(gatewayInstanceId); err == nil && gateway.IsRunning() {
+			currentConfig = gateway.GetConfig()
+		}
+	}
+
+	changes := bootstrap.DiffGatewayConfig(currentConfig, newConfig)
+
+	response.SuccessJSON(ctx, gin.H{
+		"gatewayInstanceId": gatewayInstanceId,
+		"running":           currentConfig != nil,
+		"currentVersion":    bootstrap.GetCurrentConfigVersion(tenantId, gatewayInstanceId),
+		"newVersion":        newVersion,
+		"valid":             validationError == "",
+		"validationError":   validationError,
+		"changed":           len(changes) > 0,
+		"changes":           changes,
+	}, constants.SD00002)
+}
+
+// PublishGatewayConfig 发布网关配置版本
+// @Summary 发布网关配置版本
+// @Description 从数据库组装网关配置快照，校验后在本节点生效并下发到集群其他节点；校验或重载失败时保持原配置
+// @Tags 网关实例管理
+// @Accept json
+// @Produce json
+// @Param gatewayInstanceId query string true "网关实例ID"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0020/publishGatewayConfig [post]
+func (c *GatewayInstanceController) PublishGatewayConfig(ctx *gin.Context) {
+	gatewayInstanceId := request.GetParam(ctx, "gatewayInstanceId")
+	if gatewayInstanceId == "" {
+		response.ErrorJSON(ctx, "网关实例ID不能为空", constants.ED00007)
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	operatorId := request.GetOperatorID(ctx)
+
+	instance, err := c.gatewayInstanceDAO.GetGatewayInstanceById(ctx, gatewayInstanceId, tenantId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取网关实例信息失败", err)
+		response.ErrorJSON(ctx, "获取网关实例信息失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if instance == nil {
+		response.ErrorJSON(ctx, "网关实例不存在", constants.ED00008)
+		return
+	}
+
+	// 1. 从数据库组装配置快照
+	configLoader := loader.NewDatabaseConfigLoader(c.db, tenantId)
+	newConfig, err := configLoader.LoadGatewayConfig(gatewayInstanceId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "从数据库加载网关配置失败", err)
+		response.ErrorJSON(ctx, "加载网关配置失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	snapshot, err := bootstrap.NewConfigSnapshot(newConfig, operatorId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "生成网关配置快照失败", err)
+		response.ErrorJSON(ctx, "生成配置快照失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	// 2. 发布前校验，校验失败不下发
+	if err := bootstrap.ValidateGatewayConfig(snapshot.Config); err != nil {
+		logger.WarnWithTrace(ctx, "网关配置校验失败", "gatewayInstanceId", gatewayInstanceId, "error", err)
+		response.ErrorJSON(ctx, "网关配置校验失败: "+err.Error(), constants.ED00014)
+		return
+	}
+
+	// 3. 本节点生效（集群事件不会回送给发布节点）
+	gatewayPool := bootstrap.GetGlobalPool()
+	if gatewayPool.Exists(gatewayInstanceId) {
+		gateway, err := gatewayPool.Get(gatewayInstanceId)
+		if err == nil && gateway.IsRunning() {
+			if err := gateway.ApplyConfigSnapshot(snapshot); err != nil {
+				logger.ErrorWithTrace(ctx, "应用网关配置版本失败", err)
+				dbloader.TouchGatewayInstanceLifecycle(tenantId, gatewayInstanceId, "配置发布失败: "+err.Error())
+				response.ErrorJSON(ctx, "应用网关配置版本失败: "+err.Error(), constants.ED00009)
+				return
+			}
+			dbloader.TouchGatewayInstanceLifecycle(tenantId, gatewayInstanceId, "")
+		}
+	}
+
+	// 4. 写入加密存储的配置版本历史，集群其他节点按版本号读取配置
+	if err := bootstrap.SaveConfigVersion(tenantId, gatewayInstanceId, snapshot); err != nil {
+		logger.ErrorWithTrace(ctx, "保存网关配置版本失败", err)
+		response.ErrorJSON(ctx, "保存网关配置版本失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	// 5. 下发到集群其他节点，事件只携带版本号
+	if err := c.eventPublisher.PublishConfigEvent(
+		ctx,
+		gatewayInstanceId,
+		tenantId,
+		instance.InstanceName,
+		snapshot.Version,
+		operatorId,
+	); err != nil {
+		// 事件发布失败不影响本节点结果，仅记录警告
+		logger.WarnWithTrace(ctx, "发布网关配置下发事件失败", "error", err)
+	}
+
+	logger.InfoWithTrace(ctx, "网关配置版本发布成功",
+		"gatewayInstanceId", gatewayInstanceId,
+		"configVersion", snapshot.Version,
+		"operator", operatorId)
+
+	response.SuccessJSON(ctx, gin.H{
+		"gatewayInstanceId": gatewayInstanceId,
+		"configVersion":     snapshot.Version,
+		"message":           "网关配置版本发布成功",
+	}, constants.SD00001)
+}
+
+// RollbackGatewayConfig 回滚网关配置到上一个版本
+// @Summary 回滚网关配置
+// @Description 将本节点及集群其他节点的网关配置回滚到上一个已生效版本
+// @Tags 网关实例管理
+// @Accept json
+// @Produce json
+// @Param gatewayInstanceId query string true "网关实例ID"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0020/rollbackGatewayConfig [post]
+func (c *GatewayInstanceController) RollbackGatewayConfig(ctx *gin.Context) {
+	gatewayInstanceId := request.GetParam(ctx, "gatewayInstanceId")
+	if gatewayInstanceId == "" {
+		response.ErrorJSON(ctx, "网关实例ID不能为空", constants.ED00007)
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	operatorId := request.GetOperatorID(ctx)
+
+	gatewayPool := bootstrap.GetGlobalPool()
+	if !gatewayPool.Exists(gatewayInstanceId) {
+		response.ErrorJSON(ctx, "网关实例未运行，无法回滚配置", constants.ED00009)
+		return
+	}
+
+	gateway, err := gatewayPool.Get(gatewayInstanceId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取网关实例失败", err)
+		response.ErrorJSON(ctx, "获取网关实例失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	version, err := gateway.RollbackConfig()
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "回滚网关配置失败", err)
+		response.ErrorJSON(ctx, "回滚网关配置失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	if err := c.eventPublisher.PublishRollbackEvent(ctx, gatewayInstanceId, tenantId, "", version, operatorId); err != nil {
+		logger.WarnWithTrace(ctx, "发布网关配置回滚事件失败", "error", err)
+	}
+
+	response.SuccessJSON(ctx, gin.H{
+		"gatewayInstanceId": gatewayInstanceId,
+		"configVersion":     version,
+		"message":           "网关配置回滚成功",
+	}, constants.SD00001)
+}
+
+// QueryGatewayConfigVersions 查询网关配置版本历史
+// @Summary 查询网关配置版本历史
+// @Description 返回实例已生效的配置版本列表（不含配置内容），最新版本在末尾
+// @Tags 网关实例管理
+// @Accept json
+// @Produce json
+// @Param gatewayInstanceId query string true "网关实例ID"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0020/queryGatewayConfigVersions [post]
+func (c *GatewayInstanceController) QueryGatewayConfigVersions(ctx *gin.Context) {
+	gatewayInstanceId := request.GetParam(ctx, "gatewayInstanceId")
+	if gatewayInstanceId == "" {
+		response.ErrorJSON(ctx, "网关实例ID不能为空", constants.ED00007)
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	versions, err := bootstrap.GetConfigVersions(tenantId, gatewayInstanceId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询网关配置版本历史失败", err)
+		response.ErrorJSON(ctx, "查询网关配置版本历史失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	items := make([]gin.H, 0, len(versions))
+	for _, v := range versions {
+		items = append(items, gin.H{
+			"version":   v.Version,
+			"operator":  v.Operator,
+			"appliedAt": v.AppliedAt,
+		})
+	}
+
+	response.SuccessJSON(ctx, gin.H{
+		"gatewayInstanceId": gatewayInstanceId,
+		"currentVersion":    bootstrap.GetCurrentConfigVersion(tenantId, gatewayInstanceId),
+		"versions":          items,
+	}, constants.SD00002)
+}
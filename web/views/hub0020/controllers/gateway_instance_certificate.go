@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"errors"
+	"os"
+	"strconv"
+
+	"gateway/pkg/config"
+	"gateway/pkg/logger"
+	"gateway/pkg/security"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0020/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 证书到期状态
+const (
+	// CertStatusValid 有效
+	CertStatusValid = "VALID"
+	// CertStatusExpiring 即将过期
+	CertStatusExpiring = "EXPIRING"
+	// CertStatusExpired 已过期
+	CertStatusExpired = "EXPIRED"
+	// CertStatusError 证书无法读取或解析
+	CertStatusError = "ERROR"
+)
+
+// defaultCertExpiryWarningDays 默认提前提醒天数
+const defaultCertExpiryWarningDays = 30
+
+// GatewayCertificateStatus 网关实例证书到期状态
+type GatewayCertificateStatus struct {
+	GatewayInstanceId string                    `json:"gatewayInstanceId"`
+	InstanceName      string                    `json:"instanceName"`
+	CertStorageType   string                    `json:"certStorageType"`
+	Status            string                    `json:"status"`
+	Message           string                    `json:"message,omitempty"`
+	Certificate       *security.CertificateInfo `json:"certificate,omitempty"`
+}
+
+// QueryCertificateExpiry 查询网关实例证书到期情况
+// @Summary 查询证书到期情况
+// @Description 检查当前租户下启用TLS的网关实例证书，返回剩余天数及到期状态（VALID/EXPIRING/EXPIRED/ERROR）
+// @Tags 网关实例管理
+// @Accept json
+// @Produce json
+// @Param warningDays query int false "提前提醒天数，默认读取 app.cert_expiry_warning_days（30）"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0020/queryCertificateExpiry [post]
+func (c *GatewayInstanceController) QueryCertificateExpiry(ctx *gin.Context) {
+	warningDays := config.GetInt("app.cert_expiry_warning_days", defaultCertExpiryWarningDays)
+	if value := request.GetParam(ctx, "warningDays"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 0 {
+			response.ErrorJSON(ctx, "提前提醒天数必须为非负整数", constants.ED00006)
+			return
+		}
+		warningDays = days
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	instances, err := c.gatewayInstanceDAO.ListTLSGatewayInstances(ctx, tenantId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询TLS网关实例失败", err)
+		response.ErrorJSON(ctx, "查询网关实例失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	statuses := make([]*GatewayCertificateStatus, 0, len(instances))
+	expiring := 0
+	for _, instance := range instances {
+		status := checkInstanceCertificate(instance, warningDays)
+		if status.Status != CertStatusValid {
+			expiring++
+		}
+		statuses = append(statuses, status)
+	}
+
+	response.SuccessJSON(ctx, gin.H{
+		"certificates":  statuses,
+		"warningDays":   warningDays,
+		"warningCount":  expiring,
+		"instanceCount": len(statuses),
+	}, constants.SD00002)
+}
+
+// checkInstanceCertificate 读取并检查单个网关实例的证书
+func checkInstanceCertificate(instance *models.GatewayInstance, warningDays int) *GatewayCertificateStatus {
+	status := &GatewayCertificateStatus{
+		GatewayInstanceId: instance.GatewayInstanceId,
+		InstanceName:      instance.InstanceName,
+		CertStorageType:   instance.CertStorageType,
+	}
+
+	certPEM, err := loadInstanceCertificate(instance)
+	if err != nil {
+		status.Status = CertStatusError
+		status.Message = err.Error()
+		return status
+	}
+	certs, err := security.ParseCertificatesPEM(certPEM)
+	if err != nil {
+		status.Status = CertStatusError
+		status.Message = err.Error()
+		return status
+	}
+
+	status.Certificate = security.GetCertificateInfo(certs[0])
+	switch {
+	case status.Certificate.Expired:
+		status.Status = CertStatusExpired
+	case status.Certificate.DaysUntilExpiry <= warningDays:
+		status.Status = CertStatusExpiring
+	default:
+		status.Status = CertStatusValid
+	}
+	return status
+}
+
+// loadInstanceCertificate 按存储类型读取证书PEM
+func loadInstanceCertificate(instance *models.GatewayInstance) ([]byte, error) {
+	if instance.CertStorageType == "FILE" {
+		if instance.CertFilePath == "" {
+			return nil, errors.New("未配置证书文件路径")
+		}
+		return os.ReadFile(instance.CertFilePath)
+	}
+	if instance.CertContent == "" {
+		return nil, errors.New("未配置证书内容")
+	}
+	return []byte(instance.CertContent), nil
+}
@@ -308,7 +308,7 @@ func (c *GatewayInstanceController) buildSheets(
 			"healthCheckEnabled", "healthCheckPath", "healthCheckMethod",
 			"healthCheckIntervalSeconds", "healthCheckTimeoutMs",
 			"healthyThreshold", "unhealthyThreshold", "expectedStatusCodes", "healthCheckHeaders",
-			"loadBalancerConfig", "serviceMetadata",
+			"loadBalancerConfig", "serviceMetadata", "upstreamTlsConfig",
 			"reserved1", "reserved2", "reserved3", "reserved4", "reserved5",
 			"extProperty",
 			"oprSeqFlag", "currentVersion", "activeFlag", "noteText",
@@ -346,7 +346,7 @@ func (c *GatewayInstanceController) buildSheets(
 			sd.HealthCheckEnabled, sd.HealthCheckPath, sd.HealthCheckMethod,
 			sd.HealthCheckIntervalSeconds, sd.HealthCheckTimeoutMs,
 			sd.HealthyThreshold, sd.UnhealthyThreshold, sd.ExpectedStatusCodes, sd.HealthCheckHeaders,
-			sd.LoadBalancerConfig, sd.ServiceMetadata,
+			sd.LoadBalancerConfig, sd.ServiceMetadata, sd.UpstreamTlsConfig,
 			sd.Reserved1, sd.Reserved2, sd.Reserved3, sd.Reserved4, sd.Reserved5,
 			sd.ExtProperty,
 			sd.OprSeqFlag, sd.CurrentVersion, sd.ActiveFlag, sd.NoteText,
@@ -846,6 +846,7 @@ func parseServiceDefinitionRow(row []string, idx map[string]int, tenantId string
 		HealthCheckHeaders:   excel.GetCell(row, idx, "healthCheckHeaders"),
 		LoadBalancerConfig:   excel.GetCell(row, idx, "loadBalancerConfig"),
 		ServiceMetadata:      excel.GetCell(row, idx, "serviceMetadata"),
+		UpstreamTlsConfig:    excel.GetCell(row, idx, "upstreamTlsConfig"),
 		ActiveFlag:           strOrDefault(excel.GetCell(row, idx, "activeFlag"), "Y"),
 		NoteText:             excel.GetCell(row, idx, "noteText"),
 	}
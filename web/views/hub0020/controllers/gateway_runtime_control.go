@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"gateway/internal/gateway/bootstrap"
+	"gateway/pkg/logger"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateGatewayMaintenance 开启或关闭网关维护模式
+// @Summary 切换网关维护模式
+// @Description 维护模式下本节点网关实例保持监听，新请求直接返回503，用于发布或故障处理期间摘除流量；该状态仅保存在内存中，网关重启后恢复为关闭
+// @Tags 网关实例管理
+// @Accept json
+// @Produce json
+// @Param gatewayInstanceId query string true "网关实例ID"
+// @Param maintenanceMode query string true "维护模式(Y开启,N关闭)"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0020/updateGatewayMaintenance [post]
+func (c *GatewayInstanceController) UpdateGatewayMaintenance(ctx *gin.Context) {
+	gatewayInstanceId := request.GetParam(ctx, "gatewayInstanceId")
+	if gatewayInstanceId == "" {
+		response.ErrorJSON(ctx, "网关实例ID不能为空", constants.ED00007)
+		return
+	}
+	maintenanceMode := request.GetParam(ctx, "maintenanceMode")
+	if maintenanceMode != "Y" && maintenanceMode != "N" {
+		response.ErrorJSON(ctx, "维护模式只能为Y或N", constants.ED00006)
+		return
+	}
+
+	gateway, ok := c.getRunningGateway(ctx, gatewayInstanceId)
+	if !ok {
+		return
+	}
+
+	gateway.SetMaintenanceMode(maintenanceMode == "Y")
+
+	logger.InfoWithTrace(ctx, "网关维护模式已变更",
+		"gatewayInstanceId", gatewayInstanceId,
+		"maintenanceMode", maintenanceMode,
+		"operatorId", request.GetOperatorID(ctx))
+
+	response.SuccessJSON(ctx, gin.H{
+		"gatewayInstanceId": gatewayInstanceId,
+		"maintenanceMode":   maintenanceMode,
+	}, constants.SD00004)
+}
+
+// GetGatewayRuntimeConfig 获取网关实例当前生效的配置
+// @Summary 获取网关生效配置
+// @Description 返回本节点运行中网关实例实际生效的配置、配置版本和维护模式状态，用于排查数据库配置与运行状态不一致的问题
+// @Tags 网关实例管理
+// @Accept json
+// @Produce json
+// @Param gatewayInstanceId query string true "网关实例ID"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0020/getGatewayRuntimeConfig [post]
+func (c *GatewayInstanceController) GetGatewayRuntimeConfig(ctx *gin.Context) {
+	gatewayInstanceId := request.GetParam(ctx, "gatewayInstanceId")
+	if gatewayInstanceId == "" {
+		response.ErrorJSON(ctx, "网关实例ID不能为空", constants.ED00007)
+		return
+	}
+
+	gateway, ok := c.getRunningGateway(ctx, gatewayInstanceId)
+	if !ok {
+		return
+	}
+
+	maintenanceMode := "N"
+	if gateway.IsMaintenanceMode() {
+		maintenanceMode = "Y"
+	}
+
+	response.SuccessJSON(ctx, gin.H{
+		"gatewayInstanceId": gatewayInstanceId,
+		"configVersion":     bootstrap.GetCurrentConfigVersion(request.GetTenantID(ctx), gatewayInstanceId),
+		"maintenanceMode":   maintenanceMode,
+		"config":            gateway.GetConfig(),
+	}, constants.SD00002)
+}
+
+// getRunningGateway 校验网关实例归属当前租户并获取本节点运行中的网关
+// 校验失败时已写入错误响应
+func (c *GatewayInstanceController) getRunningGateway(ctx *gin.Context, gatewayInstanceId string) (*bootstrap.Gateway, bool) {
+	tenantId := request.GetTenantID(ctx)
+
+	instance, err := c.gatewayInstanceDAO.GetGatewayInstanceById(ctx, gatewayInstanceId, tenantId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取网关实例信息失败", err)
+		response.ErrorJSON(ctx, "获取网关实例信息失败: "+err.Error(), constants.ED00009)
+		return nil, false
+	}
+	if instance == nil {
+		response.ErrorJSON(ctx, "网关实例不存在", constants.ED00008)
+		return nil, false
+	}
+
+	gatewayPool := bootstrap.GetGlobalPool()
+	if !gatewayPool.Exists(gatewayInstanceId) {
+		response.ErrorJSON(ctx, "网关实例未运行", constants.ED00009)
+		return nil, false
+	}
+	gateway, err := gatewayPool.Get(gatewayInstanceId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取网关实例失败", err)
+		response.ErrorJSON(ctx, "获取网关实例失败: "+err.Error(), constants.ED00009)
+		return nil, false
+	}
+	if !gateway.IsRunning() {
+		response.ErrorJSON(ctx, "网关实例未运行", constants.ED00009)
+		return nil, false
+	}
+	return gateway, true
+}
@@ -464,6 +464,25 @@ func (dao *GatewayInstanceDAO) FindGatewayInstanceByName(ctx context.Context, in
 	return &instance, nil
 }
 
+// ListTLSGatewayInstances 查询租户下启用TLS的有效网关实例，用于证书到期检查
+func (dao *GatewayInstanceDAO) ListTLSGatewayInstances(ctx context.Context, tenantId string) ([]*models.GatewayInstance, error) {
+	if tenantId == "" {
+		return nil, errors.New("tenantId不能为空")
+	}
+
+	query := `
+		SELECT * FROM HUB_GW_INSTANCE
+		WHERE tenantId = ? AND tlsEnabled = 'Y' AND activeFlag = 'Y'
+		ORDER BY instanceName
+	`
+
+	var instances []*models.GatewayInstance
+	if err := dao.db.Query(ctx, &instances, query, []interface{}{tenantId}, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询TLS网关实例失败")
+	}
+	return instances, nil
+}
+
 // UpdateHealthStatus 更新健康状态
 func (dao *GatewayInstanceDAO) UpdateHealthStatus(ctx context.Context, gatewayInstanceId, tenantId, healthStatus, operatorId string) error {
 	if gatewayInstanceId == "" {
@@ -3,6 +3,7 @@ package hub0020routes
 import (
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
+	"gateway/web/middleware/permission"
 	"gateway/web/routes"
 	"gateway/web/views/hub0020/controllers"
 
@@ -91,6 +92,25 @@ func initGatewayInstanceRoutes(router *gin.RouterGroup, db database.Database) {
 		// 网关实例配置重载
 		instanceGroup.POST("/reloadGatewayInstance", gatewayInstanceController.ReloadGatewayInstance)
 
+		// 启动、停止、重载和日志配置有独立的按钮编码，按接口名只能推导出 hub0020:edit
+		permission.RegisterRoutePermission("POST", APIPrefix+"/startGatewayInstance", ModuleName+":start")
+		permission.RegisterRoutePermission("POST", APIPrefix+"/stopGatewayInstance", ModuleName+":stop")
+		permission.RegisterRoutePermission("POST", APIPrefix+"/reloadGatewayInstance", ModuleName+":reload")
+		permission.RegisterRoutePermission("POST", APIPrefix+"/editLogConfig", ModuleName+":logConfig")
+
+		// 网关运行时控制：维护模式切换与生效配置查看
+		instanceGroup.POST("/updateGatewayMaintenance", gatewayInstanceController.UpdateGatewayMaintenance)
+		instanceGroup.POST("/getGatewayRuntimeConfig", gatewayInstanceController.GetGatewayRuntimeConfig)
+
+		// 网关配置版本发布与回滚
+		instanceGroup.POST("/previewGatewayConfig", gatewayInstanceController.PreviewGatewayConfig)
+		instanceGroup.POST("/publishGatewayConfig", gatewayInstanceController.PublishGatewayConfig)
+		instanceGroup.POST("/rollbackGatewayConfig", gatewayInstanceController.RollbackGatewayConfig)
+		instanceGroup.POST("/queryGatewayConfigVersions", gatewayInstanceController.QueryGatewayConfigVersions)
+
+		// 证书到期检查
+		instanceGroup.POST("/queryCertificateExpiry", gatewayInstanceController.QueryCertificateExpiry)
+
 		// 日志配置管理
 		instanceGroup.POST("/getLogConfig", gatewayInstanceController.GetLogConfig)
 		instanceGroup.POST("/editLogConfig", gatewayInstanceController.EditLogConfig)
@@ -0,0 +1,244 @@
+package controllers
+
+import (
+	"context"
+	"gateway/internal/gateway/handler/featureflag"
+	"gateway/pkg/cache"
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0021/dao"
+	"gateway/web/views/hub0021/models"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeatureFlagController 功能开关控制器
+type FeatureFlagController struct {
+	db             database.Database
+	featureFlagDAO *dao.FeatureFlagDAO
+}
+
+// NewFeatureFlagController 创建功能开关控制器
+func NewFeatureFlagController(db database.Database) *FeatureFlagController {
+	return &FeatureFlagController{
+		db:             db,
+		featureFlagDAO: dao.NewFeatureFlagDAO(db),
+	}
+}
+
+// AddFeatureFlag 创建功能开关
+// @Summary 创建功能开关
+// @Description 添加实例级或路由级功能开关，保存后通知网关节点重新加载
+// @Tags 功能开关管理
+// @Accept json
+// @Produce json
+// @Param featureFlag body models.FeatureFlag true "功能开关信息"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0021/addFeatureFlag [post]
+func (c *FeatureFlagController) AddFeatureFlag(ctx *gin.Context) {
+	var req models.FeatureFlag
+	if err := request.BindSafely(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	operatorId := request.GetOperatorID(ctx)
+	req.TenantId = tenantId
+	// 清空功能开关ID，让DAO自动生成
+	req.FeatureFlagId = ""
+
+	featureFlagId, err := c.featureFlagDAO.AddFeatureFlag(ctx, &req, operatorId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "创建功能开关失败", err)
+		response.ErrorJSON(ctx, "创建功能开关失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	c.notifyFeatureFlagChanged(ctx, tenantId)
+
+	logger.InfoWithTrace(ctx, "功能开关创建成功",
+		"featureFlagId", featureFlagId,
+		"flagKey", req.FlagKey,
+		"tenantId", tenantId,
+		"operatorId", operatorId)
+
+	newFlag, err := c.featureFlagDAO.GetFeatureFlagById(ctx, featureFlagId, tenantId)
+	if err != nil || newFlag == nil {
+		response.SuccessJSON(ctx, gin.H{
+			"featureFlagId": featureFlagId,
+		}, constants.SD00003)
+		return
+	}
+	response.SuccessJSON(ctx, newFlag, constants.SD00003)
+}
+
+// EditFeatureFlag 编辑功能开关
+// @Summary 编辑功能开关
+// @Description 修改功能开关的开启状态、放量比例等，保存后通知网关节点重新加载
+// @Tags 功能开关管理
+// @Accept json
+// @Produce json
+// @Param featureFlag body models.FeatureFlag true "功能开关信息"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0021/editFeatureFlag [post]
+func (c *FeatureFlagController) EditFeatureFlag(ctx *gin.Context) {
+	var updateData models.FeatureFlag
+	if err := request.BindSafely(ctx, &updateData); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+	if updateData.FeatureFlagId == "" {
+		response.ErrorJSON(ctx, "功能开关ID不能为空", constants.ED00007)
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	operatorId := request.GetOperatorID(ctx)
+	updateData.TenantId = tenantId
+
+	if err := c.featureFlagDAO.UpdateFeatureFlag(ctx, &updateData, operatorId); err != nil {
+		logger.ErrorWithTrace(ctx, "更新功能开关失败", err)
+		response.ErrorJSON(ctx, "更新功能开关失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	c.notifyFeatureFlagChanged(ctx, tenantId)
+
+	logger.InfoWithTrace(ctx, "功能开关更新成功",
+		"featureFlagId", updateData.FeatureFlagId,
+		"flagKey", updateData.FlagKey,
+		"flagEnabled", updateData.FlagEnabled,
+		"rolloutPercent", updateData.RolloutPercent,
+		"tenantId", tenantId,
+		"operatorId", operatorId)
+
+	updatedFlag, err := c.featureFlagDAO.GetFeatureFlagById(ctx, updateData.FeatureFlagId, tenantId)
+	if err != nil || updatedFlag == nil {
+		response.SuccessJSON(ctx, gin.H{
+			"featureFlagId": updateData.FeatureFlagId,
+		}, constants.SD00004)
+		return
+	}
+	response.SuccessJSON(ctx, updatedFlag, constants.SD00004)
+}
+
+// GetFeatureFlag 获取功能开关详情
+// @Summary 获取功能开关详情
+// @Description 根据功能开关ID获取功能开关详情
+// @Tags 功能开关管理
+// @Produce json
+// @Param featureFlagId query string true "功能开关ID"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0021/getFeatureFlag [post]
+func (c *FeatureFlagController) GetFeatureFlag(ctx *gin.Context) {
+	featureFlagId := request.GetParam(ctx, "featureFlagId")
+	if featureFlagId == "" {
+		response.ErrorJSON(ctx, "功能开关ID不能为空", constants.ED00007)
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	flag, err := c.featureFlagDAO.GetFeatureFlagById(ctx, featureFlagId, tenantId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取功能开关失败", err)
+		response.ErrorJSON(ctx, "获取功能开关失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if flag == nil {
+		response.ErrorJSON(ctx, "功能开关不存在", constants.ED00008)
+		return
+	}
+
+	response.SuccessJSON(ctx, flag, constants.SD00002)
+}
+
+// QueryFeatureFlags 分页查询功能开关列表
+// @Summary 分页查询功能开关列表
+// @Description 支持按网关实例、路由、开关标识筛选
+// @Tags 功能开关管理
+// @Accept json
+// @Produce json
+// @Param request body object true "查询参数"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0021/queryFeatureFlags [post]
+func (c *FeatureFlagController) QueryFeatureFlags(ctx *gin.Context) {
+	var req struct {
+		GatewayInstanceId string `json:"gatewayInstanceId" form:"gatewayInstanceId" query:"gatewayInstanceId"`
+		RouteConfigId     string `json:"routeConfigId" form:"routeConfigId" query:"routeConfigId"`
+		FlagKey           string `json:"flagKey" form:"flagKey" query:"flagKey"`
+		FlagEnabled       string `json:"flagEnabled" form:"flagEnabled" query:"flagEnabled"`
+		ActiveFlag        string `json:"activeFlag" form:"activeFlag" query:"activeFlag"`
+	}
+	if err := request.BindSafely(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	page, pageSize := request.GetPaginationParams(ctx)
+	tenantId := request.GetTenantID(ctx)
+
+	filters := map[string]interface{}{
+		"gatewayInstanceId": req.GatewayInstanceId,
+		"routeConfigId":     req.RouteConfigId,
+		"flagKey":           req.FlagKey,
+		"flagEnabled":       req.FlagEnabled,
+		"activeFlag":        req.ActiveFlag,
+	}
+
+	flags, total, err := c.featureFlagDAO.QueryFeatureFlags(ctx, page, pageSize, filters, tenantId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询功能开关列表失败", err)
+		response.ErrorJSON(ctx, "查询功能开关列表失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	pageInfo := response.NewPageInfo(page, pageSize, total)
+	pageInfo.MainKey = "featureFlagId"
+	response.PageJSON(ctx, flags, pageInfo, constants.SD00002)
+}
+
+// DeleteFeatureFlag 删除功能开关
+// @Summary 删除功能开关
+// @Description 删除功能开关，删除后通知网关节点重新加载
+// @Tags 功能开关管理
+// @Accept json
+// @Produce json
+// @Param featureFlagId query string true "功能开关ID"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0021/deleteFeatureFlag [post]
+func (c *FeatureFlagController) DeleteFeatureFlag(ctx *gin.Context) {
+	featureFlagId := request.GetParam(ctx, "featureFlagId")
+	if featureFlagId == "" {
+		response.ErrorJSON(ctx, "功能开关ID不能为空", constants.ED00007)
+		return
+	}
+	tenantId := request.GetTenantID(ctx)
+
+	if err := c.featureFlagDAO.DeleteFeatureFlag(ctx, featureFlagId, tenantId); err != nil {
+		logger.ErrorWithTrace(ctx, "删除功能开关失败", err)
+		response.ErrorJSON(ctx, "删除功能开关失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	c.notifyFeatureFlagChanged(ctx, tenantId)
+
+	response.SuccessJSON(ctx, gin.H{
+		"featureFlagId": featureFlagId,
+	}, constants.SD00005)
+}
+
+// notifyFeatureFlagChanged 刷新缓存中的租户开关版本号，网关节点检测到版本变化后重新加载开关
+// 未配置缓存时网关节点按间隔直接从数据库加载，无需通知
+func (c *FeatureFlagController) notifyFeatureFlagChanged(ctx context.Context, tenantId string) {
+	store := cache.GetDefaultCache()
+	if store == nil {
+		return
+	}
+	version := strconv.FormatInt(time.Now().UnixNano(), 10)
+	if err := store.SetString(ctx, featureflag.VersionCacheKey(tenantId), version, 0); err != nil {
+		logger.WarnWithTrace(ctx, "刷新功能开关版本失败，网关节点将在下次数据库加载时生效", "tenantId", tenantId, "error", err)
+	}
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"gateway/internal/gateway/loader/dbloader"
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
 	"gateway/web/utils/constants"
@@ -76,6 +77,12 @@ func (c *FilterConfigController) AddFilterConfig(ctx *gin.Context) {
 	req.TenantId = tenantId
 	req.FilterConfigId = ""
 
+	// 按网关加载器的规则校验过滤器配置，避免保存后网关加载失败
+	if err := validateFilterConfigSchema(&req); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
+		return
+	}
+
 	// 调用DAO添加过滤器配置
 	filterConfigId, err := c.filterConfigDAO.AddFilterConfig(ctx, &req, operatorId)
 	if err != nil {
@@ -146,6 +153,12 @@ func (c *FilterConfigController) EditFilterConfig(ctx *gin.Context) {
 	// 设置租户ID
 	updateData.TenantId = tenantId
 
+	// 按网关加载器的规则校验过滤器配置，避免保存后网关加载失败
+	if err := validateFilterConfigSchema(&updateData); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
+		return
+	}
+
 	// 调用DAO更新过滤器配置
 	err := c.filterConfigDAO.UpdateFilterConfig(ctx, &updateData, operatorId)
 	if err != nil {
@@ -499,3 +512,21 @@ func (c *FilterConfigController) GetFilterConfigUsage(ctx *gin.Context) {
 
 	response.SuccessJSON(ctx, usageInfo, constants.SD00002)
 }
+
+// validateFilterConfigSchema 使用网关加载器的转换逻辑和过滤器工厂校验过滤器配置，禁用的过滤器不会被加载，不做校验
+func validateFilterConfigSchema(filterConfig *models.FilterConfig) error {
+	if filterConfig.ActiveFlag == "N" {
+		return nil
+	}
+	return dbloader.ValidateFilterRecord(dbloader.FilterConfigRecord{
+		TenantId:       filterConfig.TenantId,
+		FilterConfigId: filterConfig.FilterConfigId,
+		FilterName:     filterConfig.FilterName,
+		FilterType:     filterConfig.FilterType,
+		FilterAction:   filterConfig.FilterAction,
+		FilterOrder:    filterConfig.FilterOrder,
+		FilterConfig:   filterConfig.FilterConfig,
+		ConfigId:       optionalString(filterConfig.ConfigId),
+		ActiveFlag:     "Y",
+	})
+}
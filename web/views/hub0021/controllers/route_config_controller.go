@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"gateway/internal/gateway/loader/dbloader"
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
 	"gateway/web/utils/constants"
@@ -101,6 +102,12 @@ func (c *RouteConfigController) AddRouteConfig(ctx *gin.Context) {
 	req.TenantId = tenantId
 	req.RouteConfigId = ""
 
+	// 按网关加载器的规则校验路由配置，避免保存后网关加载失败
+	if err := validateRouteConfigSchema(&req); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
+		return
+	}
+
 	// 调用DAO添加路由配置
 	routeConfigId, err := c.routeConfigDAO.AddRouteConfig(ctx, &req, operatorId)
 	if err != nil {
@@ -146,6 +153,12 @@ func (c *RouteConfigController) EditRouteConfig(ctx *gin.Context) {
 	// 设置租户ID
 	updateData.TenantId = tenantId
 
+	// 按网关加载器的规则校验路由配置，避免保存后网关加载失败
+	if err := validateRouteConfigSchema(&updateData); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
+		return
+	}
+
 	// 调用DAO更新路由配置
 	err := c.routeConfigDAO.UpdateRouteConfig(ctx, &updateData, operatorId)
 	if err != nil {
@@ -274,3 +287,39 @@ func (c *RouteConfigController) GetRouteStatistics(ctx *gin.Context) {
 
 	response.SuccessJSON(ctx, statistics, constants.SD00002)
 }
+
+// validateRouteConfigSchema 使用网关加载器的转换和校验逻辑校验路由配置，禁用的路由不会被加载，不做校验
+func validateRouteConfigSchema(routeConfig *models.RouteConfig) error {
+	if routeConfig.ActiveFlag == "N" {
+		return nil
+	}
+	return dbloader.ValidateRouteRecord(dbloader.RouteConfigRecord{
+		TenantId:            routeConfig.TenantId,
+		RouteConfigId:       routeConfig.RouteConfigId,
+		GatewayInstanceId:   routeConfig.GatewayInstanceId,
+		RouteName:           routeConfig.RouteName,
+		RoutePath:           routeConfig.RoutePath,
+		AllowedMethods:      optionalString(routeConfig.AllowedMethods),
+		AllowedHosts:        optionalString(routeConfig.AllowedHosts),
+		MatchType:           routeConfig.MatchType,
+		RoutePriority:       routeConfig.RoutePriority,
+		StripPathPrefix:     routeConfig.StripPathPrefix,
+		RewritePath:         optionalString(routeConfig.RewritePath),
+		EnableWebsocket:     routeConfig.EnableWebsocket,
+		TimeoutMs:           routeConfig.TimeoutMs,
+		RetryCount:          routeConfig.RetryCount,
+		RetryIntervalMs:     routeConfig.RetryIntervalMs,
+		ServiceDefinitionId: optionalString(routeConfig.ServiceDefinitionId),
+		LogConfigId:         optionalString(routeConfig.LogConfigId),
+		RouteMetadata:       optionalString(routeConfig.RouteMetadata),
+		ActiveFlag:          "Y",
+	})
+}
+
+// optionalString 空字符串转换为nil，与数据库中的NULL字段保持一致
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
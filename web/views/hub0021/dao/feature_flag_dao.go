@@ -0,0 +1,213 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"gateway/pkg/database"
+	"gateway/pkg/database/repository"
+	"gateway/pkg/utils/huberrors"
+	"gateway/pkg/utils/random"
+	"gateway/web/views/hub0021/models"
+	"strings"
+	"time"
+)
+
+// 功能开关分桶依据，与网关 featureflag 包保持一致
+var validFeatureFlagBucketBy = map[string]bool{
+	"CLIENT_IP": true,
+	"HEADER":    true,
+	"QUERY":     true,
+	"TRACE_ID":  true,
+}
+
+//go:generate go run ../../../../cmd/repogen -model ../models -type FeatureFlag -keys tenantId,featureFlagId -out feature_flag_repo_gen.go
+
+// FeatureFlagDAO 功能开关数据访问对象
+type FeatureFlagDAO struct {
+	db   database.Database
+	repo *FeatureFlagRepository
+}
+
+// NewFeatureFlagDAO 创建功能开关DAO
+func NewFeatureFlagDAO(db database.Database) *FeatureFlagDAO {
+	return &FeatureFlagDAO{
+		db:   db,
+		repo: NewFeatureFlagRepository(db),
+	}
+}
+
+// normalizeFeatureFlag 校验必填字段并填充默认值
+func normalizeFeatureFlag(flag *models.FeatureFlag) error {
+	flag.FlagKey = strings.TrimSpace(flag.FlagKey)
+	if flag.FlagKey == "" {
+		return errors.New("开关标识不能为空")
+	}
+	if flag.FlagName == "" {
+		return errors.New("开关名称不能为空")
+	}
+	if flag.RolloutPercent < 0 || flag.RolloutPercent > 100 {
+		return errors.New("放量百分比必须在0-100之间")
+	}
+
+	flag.BucketBy = strings.ToUpper(strings.TrimSpace(flag.BucketBy))
+	if flag.BucketBy == "" {
+		flag.BucketBy = "CLIENT_IP"
+	}
+	if !validFeatureFlagBucketBy[flag.BucketBy] {
+		return errors.New("不支持的放量分桶依据: " + flag.BucketBy)
+	}
+	if (flag.BucketBy == "HEADER" || flag.BucketBy == "QUERY") && flag.BucketKey == "" {
+		return errors.New("按请求头或查询参数分桶时分桶键名不能为空")
+	}
+	if flag.FlagEnabled == "" {
+		flag.FlagEnabled = "N"
+	}
+	if flag.LogEvaluation == "" {
+		flag.LogEvaluation = "N"
+	}
+	return nil
+}
+
+// AddFeatureFlag 添加功能开关
+func (dao *FeatureFlagDAO) AddFeatureFlag(ctx context.Context, flag *models.FeatureFlag, operatorId string) (string, error) {
+	if err := normalizeFeatureFlag(flag); err != nil {
+		return "", err
+	}
+
+	// 自动生成功能开关ID（如果为空）
+	if flag.FeatureFlagId == "" {
+		flag.FeatureFlagId = random.GenerateUniqueStringWithPrefix("FF", 32)
+	}
+
+	// 设置一些自动填充的字段
+	now := time.Now()
+	flag.AddTime = now
+	flag.AddWho = operatorId
+	flag.EditTime = now
+	flag.EditWho = operatorId
+	flag.OprSeqFlag = flag.FeatureFlagId
+	flag.CurrentVersion = 1
+	flag.ActiveFlag = "Y"
+
+	if err := dao.repo.Insert(ctx, flag); err != nil {
+		return "", huberrors.WrapError(err, "添加功能开关失败")
+	}
+
+	return flag.FeatureFlagId, nil
+}
+
+// GetFeatureFlagById 根据ID获取功能开关
+func (dao *FeatureFlagDAO) GetFeatureFlagById(ctx context.Context, featureFlagId, tenantId string) (*models.FeatureFlag, error) {
+	if featureFlagId == "" {
+		return nil, errors.New("featureFlagId不能为空")
+	}
+
+	flag, err := dao.repo.Get(ctx, tenantId, featureFlagId)
+	if err != nil {
+		return nil, huberrors.WrapError(err, "查询功能开关失败")
+	}
+
+	return flag, nil
+}
+
+// UpdateFeatureFlag 更新功能开关
+func (dao *FeatureFlagDAO) UpdateFeatureFlag(ctx context.Context, flag *models.FeatureFlag, operatorId string) error {
+	if flag.FeatureFlagId == "" {
+		return errors.New("featureFlagId不能为空")
+	}
+	if err := normalizeFeatureFlag(flag); err != nil {
+		return err
+	}
+
+	// 首先获取当前版本信息
+	currentFlag, err := dao.GetFeatureFlagById(ctx, flag.FeatureFlagId, flag.TenantId)
+	if err != nil {
+		return huberrors.WrapError(err, "获取现有功能开关失败")
+	}
+	if currentFlag == nil {
+		return errors.New("功能开关不存在")
+	}
+
+	// 保留不可修改的字段
+	flag.AddTime = currentFlag.AddTime
+	flag.AddWho = currentFlag.AddWho
+	flag.OprSeqFlag = currentFlag.OprSeqFlag
+	flag.CurrentVersion = currentFlag.CurrentVersion + 1
+	if flag.ActiveFlag == "" {
+		flag.ActiveFlag = currentFlag.ActiveFlag
+	}
+
+	// 更新修改信息
+	flag.EditTime = time.Now()
+	flag.EditWho = operatorId
+
+	sql := `
+		UPDATE HUB_GW_FEATURE_FLAG SET
+			gatewayInstanceId = ?, routeConfigId = ?, flagKey = ?, flagName = ?, flagDesc = ?,
+			flagEnabled = ?, rolloutPercent = ?, bucketBy = ?, bucketKey = ?, logEvaluation = ?,
+			extProperty = ?, noteText = ?, editTime = ?, editWho = ?, currentVersion = ?, activeFlag = ?
+		WHERE featureFlagId = ? AND tenantId = ? AND currentVersion = ?
+	`
+
+	result, err := dao.db.Exec(ctx, sql, []interface{}{
+		flag.GatewayInstanceId, flag.RouteConfigId, flag.FlagKey, flag.FlagName, flag.FlagDesc,
+		flag.FlagEnabled, flag.RolloutPercent, flag.BucketBy, flag.BucketKey, flag.LogEvaluation,
+		flag.ExtProperty, flag.NoteText, flag.EditTime, flag.EditWho, flag.CurrentVersion, flag.ActiveFlag,
+		flag.FeatureFlagId, flag.TenantId, currentFlag.CurrentVersion,
+	}, true)
+	if err != nil {
+		return huberrors.WrapError(err, "更新功能开关失败")
+	}
+
+	// 检查是否有记录被更新
+	if result == 0 {
+		return errors.New("功能开关更新失败，可能是版本冲突或记录不存在")
+	}
+
+	return nil
+}
+
+// DeleteFeatureFlag 删除功能开关
+func (dao *FeatureFlagDAO) DeleteFeatureFlag(ctx context.Context, featureFlagId, tenantId string) error {
+	if featureFlagId == "" {
+		return errors.New("featureFlagId不能为空")
+	}
+
+	result, err := dao.repo.Delete(ctx, tenantId, featureFlagId)
+	if err != nil {
+		return huberrors.WrapError(err, "删除功能开关失败")
+	}
+
+	// 检查是否有记录被删除
+	if result == 0 {
+		return errors.New("功能开关不存在或已被删除")
+	}
+
+	return nil
+}
+
+// QueryFeatureFlags 分页查询功能开关列表（支持多条件筛选）
+func (dao *FeatureFlagDAO) QueryFeatureFlags(ctx context.Context, page, pageSize int, filters map[string]interface{}, tenantId string) ([]*models.FeatureFlag, int, error) {
+	cond := repository.Where().Eq(FeatureFlagColTenantId, tenantId)
+
+	// 添加筛选条件
+	if filters != nil {
+		gatewayInstanceId, _ := filters["gatewayInstanceId"].(string)
+		routeConfigId, _ := filters["routeConfigId"].(string)
+		flagKey, _ := filters["flagKey"].(string)
+		flagEnabled, _ := filters["flagEnabled"].(string)
+		activeFlag, _ := filters["activeFlag"].(string)
+		cond.EqIfNotEmpty(FeatureFlagColGatewayInstanceId, gatewayInstanceId).
+			EqIfNotEmpty(FeatureFlagColRouteConfigId, routeConfigId).
+			Like(FeatureFlagColFlagKey, flagKey).
+			EqIfNotEmpty(FeatureFlagColFlagEnabled, flagEnabled).
+			EqIfNotEmpty(FeatureFlagColActiveFlag, activeFlag)
+	}
+
+	flags, total, err := dao.repo.Page(ctx, cond, "flagKey ASC, addTime DESC", page, pageSize)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "查询功能开关列表失败")
+	}
+
+	return flags, total, nil
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+)
+
+// FeatureFlag 功能开关模型，对应数据库HUB_GW_FEATURE_FLAG表
+type FeatureFlag struct {
+	TenantId          string `json:"tenantId" form:"tenantId" query:"tenantId" db:"tenantId"`                                     // 租户ID，联合主键
+	FeatureFlagId     string `json:"featureFlagId" form:"featureFlagId" query:"featureFlagId" db:"featureFlagId"`                 // 功能开关ID，联合主键
+	GatewayInstanceId string `json:"gatewayInstanceId" form:"gatewayInstanceId" query:"gatewayInstanceId" db:"gatewayInstanceId"` // 网关实例ID(为空表示租户下全部实例)
+	RouteConfigId     string `json:"routeConfigId" form:"routeConfigId" query:"routeConfigId" db:"routeConfigId"`                 // 路由配置ID(为空表示实例下全部路由)
+	FlagKey           string `json:"flagKey" form:"flagKey" query:"flagKey" db:"flagKey"`                                         // 开关标识，过滤器通过该标识查询开关
+	FlagName          string `json:"flagName" form:"flagName" query:"flagName" db:"flagName"`                                     // 开关名称
+	FlagDesc          string `json:"flagDesc" form:"flagDesc" query:"flagDesc" db:"flagDesc"`                                     // 开关描述
+
+	// 开关规则
+	FlagEnabled    string `json:"flagEnabled" form:"flagEnabled" query:"flagEnabled" db:"flagEnabled"`             // 是否开启(N关闭,Y开启)
+	RolloutPercent int    `json:"rolloutPercent" form:"rolloutPercent" query:"rolloutPercent" db:"rolloutPercent"` // 放量百分比(0-100)
+	BucketBy       string `json:"bucketBy" form:"bucketBy" query:"bucketBy" db:"bucketBy"`                         // 放量分桶依据(CLIENT_IP,HEADER,QUERY,TRACE_ID)
+	BucketKey      string `json:"bucketKey" form:"bucketKey" query:"bucketKey" db:"bucketKey"`                     // 分桶键名(bucketBy为HEADER/QUERY时的请求头或查询参数名)
+	LogEvaluation  string `json:"logEvaluation" form:"logEvaluation" query:"logEvaluation" db:"logEvaluation"`     // 是否将判定结果写入访问日志扩展属性(N否,Y是)
+
+	// 扩展属性
+	ExtProperty string `json:"extProperty" form:"extProperty" query:"extProperty" db:"extProperty"` // 扩展属性,JSON格式
+
+	// 标准字段
+	AddTime        time.Time `json:"addTime" form:"addTime" query:"addTime" db:"addTime"`                             // 创建时间
+	AddWho         string    `json:"addWho" form:"addWho" query:"addWho" db:"addWho"`                                 // 创建人ID
+	EditTime       time.Time `json:"editTime" form:"editTime" query:"editTime" db:"editTime"`                         // 最后修改时间
+	EditWho        string    `json:"editWho" form:"editWho" query:"editWho" db:"editWho"`                             // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" form:"oprSeqFlag" query:"oprSeqFlag" db:"oprSeqFlag"`                 // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" form:"currentVersion" query:"currentVersion" db:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" form:"activeFlag" query:"activeFlag" db:"activeFlag"`                 // 活动状态标记(N非活动,Y活动)
+	NoteText       string    `json:"noteText" form:"noteText" query:"noteText" db:"noteText"`                         // 备注信息
+}
+
+// TableName 返回表名
+func (FeatureFlag) TableName() string {
+	return "HUB_GW_FEATURE_FLAG"
+}
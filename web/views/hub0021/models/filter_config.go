@@ -18,9 +18,9 @@ type FilterConfig struct {
 	// 根据FilterAction枚举值设计 - 支持3种执行时机
 	FilterAction string `json:"filterAction" form:"filterAction" query:"filterAction" db:"filterAction"` // 过滤器执行时机(pre-routing,post-routing,pre-response)
 
-	FilterOrder  int    `json:"filterOrder" form:"filterOrder" query:"filterOrder" db:"filterOrder"`       // 过滤器执行顺序(Priority)
-	FilterConfig string `json:"filterConfig" form:"filterConfig" query:"filterConfig" db:"filterConfig"`   // 过滤器具体配置,JSON格式
-	FilterDesc   string `json:"filterDesc" form:"filterDesc" query:"filterDesc" db:"filterDesc"`           // 过滤器描述
+	FilterOrder  int    `json:"filterOrder" form:"filterOrder" query:"filterOrder" db:"filterOrder"`     // 过滤器执行顺序(Priority)
+	FilterConfig string `json:"filterConfig" form:"filterConfig" query:"filterConfig" db:"filterConfig"` // 过滤器具体配置,JSON格式
+	FilterDesc   string `json:"filterDesc" form:"filterDesc" query:"filterDesc" db:"filterDesc"`         // 过滤器描述
 
 	// 根据FilterConfig结构设计的附属字段
 	ConfigId string `json:"configId" form:"configId" query:"configId" db:"configId"` // 过滤器配置ID(来自FilterConfig.ID)
@@ -53,14 +53,17 @@ func (FilterConfig) TableName() string {
 
 // FilterType 过滤器类型常量
 const (
-	FilterTypeHeader     = "header"      // 请求头过滤器
-	FilterTypeQueryParam = "query-param" // 查询参数过滤器
-	FilterTypeBody       = "body"        // 请求体过滤器
-	FilterTypeStrip      = "strip"       // 前缀剥离过滤器
-	FilterTypeRewrite    = "rewrite"     // 路径重写过滤器
-	FilterTypeMethod     = "method"      // HTTP方法过滤器
-	FilterTypeCookie     = "cookie"      // Cookie过滤器
-	FilterTypeResponse   = "response"    // 响应过滤器
+	FilterTypeHeader     = "header"          // 请求头过滤器
+	FilterTypeQueryParam = "query-param"     // 查询参数过滤器
+	FilterTypeBody       = "body"            // 请求体过滤器
+	FilterTypeStrip      = "strip"           // 前缀剥离过滤器
+	FilterTypeRewrite    = "rewrite"         // 路径重写过滤器
+	FilterTypeMethod     = "method"          // HTTP方法过滤器
+	FilterTypeCookie     = "cookie"          // Cookie过滤器
+	FilterTypeResponse   = "response"        // 响应过滤器
+	FilterTypeABTest     = "ab-test"         // A/B测试分组过滤器
+	FilterTypeFault      = "fault-injection" // 故障注入过滤器
+	FilterTypeVariable   = "variable"        // 请求变量过滤器
 )
 
 // FilterAction 过滤器执行时机常量
@@ -81,6 +84,9 @@ func GetValidFilterTypes() []string {
 		FilterTypeMethod,
 		FilterTypeCookie,
 		FilterTypeResponse,
+		FilterTypeABTest,
+		FilterTypeFault,
+		FilterTypeVariable,
 	}
 }
 
@@ -191,4 +197,4 @@ func GetFilterConfigTemplates() []FilterConfigTemplate {
 			},
 		},
 	}
-} 
\ No newline at end of file
+}
@@ -3,6 +3,7 @@ package hub0021routes
 import (
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
+	"gateway/web/middleware/permission"
 	"gateway/web/routes"
 	"gateway/web/views/hub0021/controllers"
 
@@ -63,6 +64,9 @@ func Init(router *gin.Engine, db database.Database) {
 	// 服务定义相关路由
 	initServiceDefinitionRoutes(group, db)
 
+	// 功能开关相关路由
+	initFeatureFlagRoutes(group, db)
+
 	// 可以添加更多子路由组
 	// initRateLimitConfigRoutes(group, db)  // 限流配置
 	// initCorsConfigRoutes(group, db)       // CORS配置
@@ -107,6 +111,10 @@ func initRouteConfigRoutes(router *gin.RouterGroup, db database.Database) {
 
 		// 路由统计信息
 		configGroup.POST("/routeStatistics", routeConfigController.GetRouteStatistics)
+
+		// 查询类接口按接口名无法推导，显式登记为只校验模块权限
+		permission.RegisterRoutePermission("POST", APIPrefix+"/routeConfigs/byInstance", "")
+		permission.RegisterRoutePermission("POST", APIPrefix+"/routeStatistics", "")
 	}
 }
 
@@ -177,6 +185,8 @@ func initRouterConfigRoutes(router *gin.RouterGroup, db database.Database) {
 
 		// 根据网关实例获取Router配置列表
 		configGroup.POST("/routerConfigs/byInstance", routerConfigController.GetRouterConfigsByInstance)
+		permission.RegisterRoutePermission("POST", APIPrefix+"/routerConfig", "")
+		permission.RegisterRoutePermission("POST", APIPrefix+"/routerConfigs/byInstance", "")
 
 		// Router配置增删改
 		configGroup.POST("/addRouterConfig", routerConfigController.AddRouterConfig)
@@ -253,6 +263,8 @@ func initFilterConfigRoutes(router *gin.RouterGroup, db database.Database) {
 		// 过滤器配置统计信息
 		filterGroup.POST("/filterConfigStats", filterConfigController.GetFilterConfigStats)
 		filterGroup.POST("/filterConfigUsage", filterConfigController.GetFilterConfigUsage)
+		permission.RegisterRoutePermission("POST", APIPrefix+"/filterConfigStats", "")
+		permission.RegisterRoutePermission("POST", APIPrefix+"/filterConfigUsage", "")
 	}
 }
 
@@ -290,6 +302,7 @@ func initServiceDefinitionRoutes(router *gin.RouterGroup, db database.Database)
 	{
 		// 根据网关实例ID获取服务定义列表（核心功能 - 关联查询）
 		serviceGroup.POST("/serviceDefinitions/byInstance", serviceDefinitionController.GetServiceDefinitionsByInstance)
+		permission.RegisterRoutePermission("POST", APIPrefix+"/serviceDefinitions/byInstance", "")
 
 		// 服务定义列表查询（分页查询，支持筛选）
 		serviceGroup.POST("/queryServiceDefinitions", serviceDefinitionController.QueryServiceDefinitions)
@@ -313,3 +326,31 @@ func initServiceDefinitionRoutes(router *gin.RouterGroup, db database.Database)
 func RegisterRoutesFunc() func(router *gin.Engine, db database.Database) {
 	return Init
 }
+
+// initFeatureFlagRoutes 初始化功能开关相关路由
+// 功能开关可限定到网关实例和路由并按比例放量，过滤器配置中通过 featureFlag 引用开关标识
+//
+// 参数:
+//   - router: Gin路由组
+//   - db: 数据库连接实例
+func initFeatureFlagRoutes(router *gin.RouterGroup, db database.Database) {
+	// 创建控制器
+	featureFlagController := controllers.NewFeatureFlagController(db)
+
+	// 功能开关路由组
+	flagGroup := router
+
+	// 注册路由 - 所有功能开关管理相关的路由都需要认证
+	{
+		// 功能开关列表查询
+		flagGroup.POST("/queryFeatureFlags", featureFlagController.QueryFeatureFlags)
+
+		// 功能开关详情查询
+		flagGroup.POST("/getFeatureFlag", featureFlagController.GetFeatureFlag)
+
+		// 功能开关增删改
+		flagGroup.POST("/addFeatureFlag", featureFlagController.AddFeatureFlag)
+		flagGroup.POST("/editFeatureFlag", featureFlagController.EditFeatureFlag)
+		flagGroup.POST("/deleteFeatureFlag", featureFlagController.DeleteFeatureFlag)
+	}
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"gateway/internal/gateway/loader/dbloader"
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
 	"gateway/web/utils/constants"
@@ -127,6 +128,12 @@ func (c *ServiceDefinitionController) CreateServiceDefinition(ctx *gin.Context)
 	// 清空服务定义ID，让DAO自动生成
 	req.ServiceDefinitionId = ""
 
+	// 按网关加载器的规则校验服务定义，避免保存后网关加载时静默使用默认值
+	if err := validateServiceDefinitionSchema(&req); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
+		return
+	}
+
 	// 调用DAO添加服务定义
 	serviceDefinitionId, err := c.serviceDefinitionDAO.CreateServiceDefinition(ctx, &req, operatorId)
 	if err != nil {
@@ -209,6 +216,12 @@ func (c *ServiceDefinitionController) EditServiceDefinition(ctx *gin.Context) {
 	// 设置租户ID和操作人信息
 	updateData.TenantId = tenantId
 
+	// 按网关加载器的规则校验服务定义，避免保存后网关加载时静默使用默认值
+	if err := validateServiceDefinitionSchema(&updateData); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
+		return
+	}
+
 	// 调用DAO更新服务定义
 	err = c.serviceDefinitionDAO.UpdateServiceDefinition(ctx, &updateData, operatorId)
 	if err != nil {
@@ -360,3 +373,41 @@ type DeleteServiceDefinitionRequest struct {
 type GetServiceDefinitionRequest struct {
 	ServiceDefinitionId string `json:"serviceDefinitionId" form:"serviceDefinitionId" binding:"required"` // 服务定义ID
 }
+
+// validateServiceDefinitionSchema 使用网关加载器的规则校验服务定义，禁用的服务定义不会被加载，不做校验
+func validateServiceDefinitionSchema(serviceDefinition *models.ServiceDefinition) error {
+	if serviceDefinition.ActiveFlag == "N" {
+		return nil
+	}
+	return dbloader.ValidateServiceRecord(dbloader.ServiceConfigRecord{
+		TenantId:             serviceDefinition.TenantId,
+		ServiceDefinitionId:  serviceDefinition.ServiceDefinitionId,
+		ServiceName:          serviceDefinition.ServiceName,
+		ServiceType:          serviceDefinition.ServiceType,
+		LoadBalanceStrategy:  serviceDefinition.LoadBalanceStrategy,
+		DiscoveryType:        optionalString(serviceDefinition.DiscoveryType),
+		DiscoveryConfig:      optionalString(serviceDefinition.DiscoveryConfig),
+		SessionAffinity:      serviceDefinition.SessionAffinity,
+		StickySession:        serviceDefinition.StickySession,
+		MaxRetries:           serviceDefinition.MaxRetries,
+		RetryTimeoutMs:       serviceDefinition.RetryTimeoutMs,
+		EnableCircuitBreaker: serviceDefinition.EnableCircuitBreaker,
+		HealthCheckEnabled:   serviceDefinition.HealthCheckEnabled,
+		HealthCheckPath:      serviceDefinition.HealthCheckPath,
+		HealthCheckMethod:    serviceDefinition.HealthCheckMethod,
+		ExpectedStatusCodes:  serviceDefinition.ExpectedStatusCodes,
+		HealthCheckHeaders:   optionalString(serviceDefinition.HealthCheckHeaders),
+		LoadBalancerConfig:   optionalString(serviceDefinition.LoadBalancerConfig),
+		ServiceMetadata:      optionalString(serviceDefinition.ServiceMetadata),
+		UpstreamTlsConfig:    optionalString(serviceDefinition.UpstreamTlsConfig),
+		ActiveFlag:           "Y",
+	})
+}
+
+// optionalString 空字符串转换为nil，与数据库中的NULL字段保持一致
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
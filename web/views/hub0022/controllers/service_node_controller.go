@@ -399,6 +399,67 @@ func (c *ServiceNodeController) UpdateNodeHealth(ctx *gin.Context) {
 	response.SuccessJSON(ctx, nodeInfo, constants.SD00004)
 }
 
+// UpdateNodeStatus 更新节点运行状态
+// @Summary 更新节点运行状态
+// @Description 更新节点的运行状态(0下线,1在线,2维护)，维护状态的节点在网关重新加载配置后不再接收流量
+// @Tags 服务节点管理
+// @Accept json
+// @Produce json
+// @Param request body UpdateNodeStatusRequest true "更新请求"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0022/updateNodeStatus [post]
+func (c *ServiceNodeController) UpdateNodeStatus(ctx *gin.Context) {
+	var req UpdateNodeStatusRequest
+	if err := request.BindSafely(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	// 验证必填字段
+	if req.ServiceNodeId == "" {
+		response.ErrorJSON(ctx, "服务节点ID不能为空", constants.ED00007)
+		return
+	}
+	if req.NodeStatus == nil {
+		response.ErrorJSON(ctx, "节点状态不能为空", constants.ED00007)
+		return
+	}
+	if *req.NodeStatus < 0 || *req.NodeStatus > 2 {
+		response.ErrorJSON(ctx, "节点状态只能为0(下线)、1(在线)或2(维护)", constants.ED00006)
+		return
+	}
+
+	// 获取租户ID和操作人ID
+	tenantId := request.GetTenantID(ctx)
+	operatorId := request.GetOperatorID(ctx)
+
+	err := c.serviceNodeDAO.UpdateNodeStatus(ctx, req.ServiceNodeId, tenantId, *req.NodeStatus, operatorId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "更新节点运行状态失败", err)
+		response.ErrorJSON(ctx, "更新节点运行状态失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	// 获取更新后的节点信息
+	updatedNode, err := c.serviceNodeDAO.GetServiceNodeById(ctx, req.ServiceNodeId, tenantId)
+	if err != nil || updatedNode == nil {
+		logger.ErrorWithTrace(ctx, "获取更新后的节点信息失败", err)
+		response.SuccessJSON(ctx, gin.H{
+			"serviceNodeId": req.ServiceNodeId,
+			"message":       "节点运行状态更新成功，但获取详细信息失败",
+		}, constants.SD00004)
+		return
+	}
+
+	logger.InfoWithTrace(ctx, "节点运行状态更新成功",
+		"serviceNodeId", req.ServiceNodeId,
+		"tenantId", tenantId,
+		"operatorId", operatorId,
+		"nodeStatus", *req.NodeStatus)
+
+	response.SuccessJSON(ctx, serviceNodeToMap(updatedNode), constants.SD00004)
+}
+
 // 请求结构体定义
 
 // QueryServiceNodesRequest 查询服务节点列表请求
@@ -425,6 +486,12 @@ type UpdateNodeHealthRequest struct {
 	HealthCheckResult string `json:"healthCheckResult" form:"healthCheckResult" query:"healthCheckResult"`        // 健康检查结果详情
 }
 
+// UpdateNodeStatusRequest 更新节点运行状态请求
+type UpdateNodeStatusRequest struct {
+	ServiceNodeId string `json:"serviceNodeId" form:"serviceNodeId" query:"serviceNodeId" binding:"required"` // 服务节点ID
+	NodeStatus    *int   `json:"nodeStatus" form:"nodeStatus" query:"nodeStatus"`                             // 节点运行状态(0下线,1在线,2维护)
+}
+
 // serviceNodeToMap 将服务节点转换为Map格式
 func serviceNodeToMap(serviceNode *models.ServiceNodeModel) map[string]interface{} {
 	return map[string]interface{}{
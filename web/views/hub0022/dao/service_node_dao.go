@@ -413,3 +413,57 @@ func (dao *ServiceNodeDAO) UpdateNodeHealth(ctx context.Context, serviceNodeId,
 
 	return nil
 }
+
+// UpdateNodeStatus 更新节点运行状态
+// 节点状态为2（维护）时网关加载配置会将该节点视为禁用，用于在不删除节点的情况下排空流量
+func (dao *ServiceNodeDAO) UpdateNodeStatus(ctx context.Context, serviceNodeId, tenantId string, nodeStatus int, operatorId string) error {
+	if serviceNodeId == "" {
+		return errors.New("serviceNodeId不能为空")
+	}
+
+	// 获取当前节点信息
+	currentNode, err := dao.GetServiceNodeById(ctx, serviceNodeId, tenantId)
+	if err != nil {
+		return huberrors.WrapError(err, "获取服务节点信息失败")
+	}
+	if currentNode == nil {
+		return errors.New("服务节点不存在")
+	}
+
+	// 更新审计字段
+	now := time.Now()
+	newVersion := currentNode.CurrentVersion + 1
+	oprSeqFlag := random.GenerateUniqueStringWithPrefix("", 32)
+
+	sql := `
+		UPDATE HUB_GW_SERVICE_NODE SET
+			nodeStatus = ?,
+			editTime = ?,
+			editWho = ?,
+			oprSeqFlag = ?,
+			currentVersion = ?
+		WHERE serviceNodeId = ? AND tenantId = ? AND currentVersion = ?
+	`
+
+	result, err := dao.db.Exec(ctx, sql, []interface{}{
+		nodeStatus,
+		now,
+		operatorId,
+		oprSeqFlag,
+		newVersion,
+		serviceNodeId,
+		tenantId,
+		currentNode.CurrentVersion,
+	}, true)
+
+	if err != nil {
+		return huberrors.WrapError(err, "更新节点运行状态失败")
+	}
+
+	// 检查是否有记录被更新
+	if result == 0 {
+		return errors.New("更新失败，可能是版本冲突或服务节点不存在")
+	}
+
+	return nil
+}
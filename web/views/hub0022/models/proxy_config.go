@@ -85,6 +85,7 @@ type ServiceDefinition struct {
 	// 负载均衡器配置(JSON格式存储完整的LoadBalancerConfig)
 	LoadBalancerConfig string `json:"loadBalancerConfig" form:"loadBalancerConfig" query:"loadBalancerConfig" db:"loadBalancerConfig"` // 负载均衡器完整配置,JSON格式
 	ServiceMetadata    string `json:"serviceMetadata" form:"serviceMetadata" query:"serviceMetadata" db:"serviceMetadata"`             // 服务元数据,JSON格式
+	UpstreamTlsConfig  string `json:"upstreamTlsConfig" form:"upstreamTlsConfig" query:"upstreamTlsConfig" db:"upstreamTlsConfig"`     // 上游TLS配置,JSON格式
 
 	// 预留字段
 	Reserved1 string     `json:"reserved1" form:"reserved1" query:"reserved1" db:"reserved1"` // 预留字段1
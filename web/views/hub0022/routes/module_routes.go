@@ -91,6 +91,9 @@ func RegisterHub0022Routes(router *gin.Engine, db database.Database) {
 
 		// 更新节点健康状态
 		apiGroup.POST("/updateNodeHealth", serviceNodeController.UpdateNodeHealth)
+
+		// 更新节点运行状态（维护状态用于节点排空）
+		apiGroup.POST("/updateNodeStatus", serviceNodeController.UpdateNodeStatus)
 	}
 
 	// 服务注册路由（转发到hub0041模块）
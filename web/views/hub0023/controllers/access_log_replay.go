@@ -0,0 +1,482 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gateway/pkg/logger"
+	"gateway/pkg/utils/random"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0023/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxReplayTasks 内存中保留的重放任务数，超出时淘汰最早结束的任务
+const maxReplayTasks = 50
+
+// replaySourceTraceHeader 重放请求携带的原始链路追踪ID请求头
+// 不使用 X-Gateway-Replay 信令头，避免目标网关将重放结果回写到原始访问日志
+const replaySourceTraceHeader = "X-Replay-Source-Trace-Id"
+
+// replayOmitHeaders 重放时始终移除的请求头（小写）：hop-by-hop 头由HTTP客户端重新生成，重发信令头会改写原始日志
+var replayOmitHeaders = map[string]bool{
+	"connection":                true,
+	"content-length":            true,
+	"transfer-encoding":         true,
+	"keep-alive":                true,
+	"proxy-connection":          true,
+	"te":                        true,
+	"trailer":                   true,
+	"upgrade":                   true,
+	"host":                      true,
+	"x-gateway-replay":          true,
+	"x-gateway-replay-trace-id": true,
+}
+
+// AccessLogLoader 按链路追踪ID加载访问日志
+type AccessLogLoader func(ctx context.Context, tenantId, traceId string) (*models.GatewayAccessLog, error)
+
+// AccessLogLoaderResolver 按网关实例的日志配置选择访问日志加载函数
+type AccessLogLoaderResolver func(ctx context.Context, tenantId, gatewayInstanceId string) AccessLogLoader
+
+// replayTask 执行中的重放任务及其取消函数
+type replayTask struct {
+	task   models.GatewayAccessLogReplayTask
+	cancel context.CancelFunc
+}
+
+// AccessLogReplayController 访问日志重放控制器
+// 按已记录的访问日志重建请求（方法、路径、查询参数、请求头、请求体），按限速和并发发送到指定环境或影子后端，
+// 用于复现问题和使用真实流量做压测；任务在内存中异步执行，重放结果不写入访问日志表
+type AccessLogReplayController struct {
+	resolve AccessLogLoaderResolver
+
+	mu    sync.Mutex
+	tasks map[string]*replayTask
+}
+
+// NewAccessLogReplayController 创建访问日志重放控制器
+// 参数:
+//
+//	resolve: 访问日志加载函数选择器，与日志详情查询使用相同的存储分发逻辑
+//
+// 返回:
+//
+//	*AccessLogReplayController: 重放控制器实例
+func NewAccessLogReplayController(resolve AccessLogLoaderResolver) *AccessLogReplayController {
+	return &AccessLogReplayController{
+		resolve: resolve,
+		tasks:   make(map[string]*replayTask),
+	}
+}
+
+// StartReplay 创建并启动访问日志重放任务
+// @Summary 重放访问日志
+// @Description 将选中的访问日志按限速重放到指定环境，立即返回任务ID，通过任务详情查看进度和结果
+// @Tags 网关日志
+// @Accept json
+// @Produce json
+// @Param replay body models.GatewayAccessLogReplayRequest true "重放参数"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0023/gateway-log/replay/start [post]
+func (c *AccessLogReplayController) StartReplay(ctx *gin.Context) {
+	var req models.GatewayAccessLogReplayRequest
+	if err := request.Bind(ctx, &req); err != nil {
+		logger.ErrorWithTrace(ctx, "访问日志重放参数解析失败", "error", err)
+		response.ErrorJSON(ctx, "参数解析错误: "+err.Error(), constants.ED00006)
+		return
+	}
+	if err := normalizeReplayRequest(&req); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	loader := c.resolve(ctx.Request.Context(), tenantId, req.GatewayInstanceId)
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	rt := &replayTask{
+		task: models.GatewayAccessLogReplayTask{
+			TaskId:        random.Generate32BitRandomString(),
+			TenantId:      tenantId,
+			Status:        models.ReplayStatusRunning,
+			TargetBaseUrl: req.TargetBaseUrl,
+			RatePerSecond: req.RatePerSecond,
+			Concurrency:   req.Concurrency,
+			Total:         len(req.TraceIds),
+			StartTime:     time.Now(),
+			AddWho:        request.GetOperatorID(ctx),
+			Results:       make([]models.GatewayAccessLogReplayResult, 0, len(req.TraceIds)),
+		},
+		cancel: cancel,
+	}
+
+	c.mu.Lock()
+	c.evictTasks()
+	c.tasks[rt.task.TaskId] = rt
+	snapshot := rt.task
+	c.mu.Unlock()
+
+	logger.Info("访问日志重放任务启动",
+		"taskId", rt.task.TaskId,
+		"tenantId", tenantId,
+		"targetBaseUrl", req.TargetBaseUrl,
+		"total", len(req.TraceIds),
+		"ratePerSecond", req.RatePerSecond,
+		"concurrency", req.Concurrency)
+
+	go c.run(runCtx, rt, req, loader)
+
+	snapshot.Results = nil
+	response.SuccessJSON(ctx, snapshot, constants.SD00001)
+}
+
+// GetReplayTask 获取重放任务进度和结果
+// @Summary 获取重放任务
+// @Tags 网关日志
+// @Accept json
+// @Produce json
+// @Param task body models.GatewayAccessLogReplayTaskRequest true "任务参数"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0023/gateway-log/replay/get [post]
+func (c *AccessLogReplayController) GetReplayTask(ctx *gin.Context) {
+	var req models.GatewayAccessLogReplayTaskRequest
+	if err := request.Bind(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数解析错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	c.mu.Lock()
+	rt, ok := c.tasks[req.TaskId]
+	var task models.GatewayAccessLogReplayTask
+	if ok && rt.task.TenantId == request.GetTenantID(ctx) {
+		task = rt.task
+		task.Results = append([]models.GatewayAccessLogReplayResult(nil), rt.task.Results...)
+	} else {
+		ok = false
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		response.ErrorJSON(ctx, "重放任务不存在或已过期", constants.ED00008)
+		return
+	}
+	response.SuccessJSON(ctx, task, constants.SD00002)
+}
+
+// CancelReplayTask 取消执行中的重放任务，已发出的请求不受影响
+// @Summary 取消重放任务
+// @Tags 网关日志
+// @Accept json
+// @Produce json
+// @Param task body models.GatewayAccessLogReplayTaskRequest true "任务参数"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0023/gateway-log/replay/cancel [post]
+func (c *AccessLogReplayController) CancelReplayTask(ctx *gin.Context) {
+	var req models.GatewayAccessLogReplayTaskRequest
+	if err := request.Bind(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数解析错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	c.mu.Lock()
+	rt, ok := c.tasks[req.TaskId]
+	ok = ok && rt.task.TenantId == request.GetTenantID(ctx)
+	running := ok && rt.task.Status == models.ReplayStatusRunning
+	c.mu.Unlock()
+
+	if !ok {
+		response.ErrorJSON(ctx, "重放任务不存在或已过期", constants.ED00008)
+		return
+	}
+	if !running {
+		response.ErrorJSON(ctx, "重放任务已结束", constants.ED00009)
+		return
+	}
+	rt.cancel()
+	logger.Info("访问日志重放任务取消", "taskId", req.TaskId, "operatorId", request.GetOperatorID(ctx))
+	response.SuccessJSON(ctx, gin.H{"taskId": req.TaskId}, constants.SD00001)
+}
+
+// QueryReplayTasks 查询当前租户的重放任务列表（不含明细结果），按开始时间倒序
+// @Summary 查询重放任务列表
+// @Tags 网关日志
+// @Produce json
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0023/gateway-log/replay/query [post]
+func (c *AccessLogReplayController) QueryReplayTasks(ctx *gin.Context) {
+	tenantId := request.GetTenantID(ctx)
+
+	c.mu.Lock()
+	tasks := make([]models.GatewayAccessLogReplayTask, 0, len(c.tasks))
+	for _, rt := range c.tasks {
+		if rt.task.TenantId != tenantId {
+			continue
+		}
+		task := rt.task
+		task.Results = nil
+		tasks = append(tasks, task)
+	}
+	c.mu.Unlock()
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].StartTime.After(tasks[j].StartTime)
+	})
+	response.SuccessJSON(ctx, tasks, constants.SD00002)
+}
+
+// run 按限速分发重放请求，由并发的工作协程执行，全部完成或取消后结束任务
+func (c *AccessLogReplayController) run(ctx context.Context, rt *replayTask, req models.GatewayAccessLogReplayRequest, loader AccessLogLoader) {
+	defer rt.cancel()
+
+	client := &http.Client{
+		Timeout: time.Duration(req.TimeoutMs) * time.Millisecond,
+		// 不跟随重定向，保留目标环境的原始响应状态码便于与原始日志对比
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	defer client.CloseIdleConnections()
+
+	traceIds := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < req.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for traceId := range traceIds {
+				result := replayAccessLog(ctx, client, loader, rt.task.TenantId, traceId, &req)
+				c.addResult(rt, result)
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(req.RatePerSecond))
+	cancelled := false
+dispatch:
+	for i, traceId := range req.TraceIds {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				cancelled = true
+				break dispatch
+			case <-ticker.C:
+			}
+		}
+		select {
+		case <-ctx.Done():
+			cancelled = true
+			break dispatch
+		case traceIds <- traceId:
+		}
+	}
+	ticker.Stop()
+	close(traceIds)
+	wg.Wait()
+
+	c.mu.Lock()
+	endTime := time.Now()
+	rt.task.EndTime = &endTime
+	rt.task.Status = models.ReplayStatusCompleted
+	if cancelled {
+		rt.task.Status = models.ReplayStatusCancelled
+	}
+	task := rt.task
+	c.mu.Unlock()
+
+	logger.Info("访问日志重放任务结束",
+		"taskId", task.TaskId,
+		"status", task.Status,
+		"total", task.Total,
+		"completed", task.Completed,
+		"succeeded", task.Succeeded,
+		"failed", task.Failed,
+		"durationMs", endTime.Sub(task.StartTime).Milliseconds())
+}
+
+// addResult 记录单条重放结果并更新任务统计
+func (c *AccessLogReplayController) addResult(rt *replayTask, result models.GatewayAccessLogReplayResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rt.task.Results = append(rt.task.Results, result)
+	rt.task.Completed++
+	if result.StatusMatched {
+		rt.task.Succeeded++
+	} else {
+		rt.task.Failed++
+	}
+}
+
+// evictTasks 任务数达到上限时淘汰最早结束的任务，调用方需持有锁
+func (c *AccessLogReplayController) evictTasks() {
+	for len(c.tasks) >= maxReplayTasks {
+		var oldestId string
+		var oldest time.Time
+		for id, rt := range c.tasks {
+			if rt.task.EndTime == nil {
+				continue
+			}
+			if oldestId == "" || rt.task.EndTime.Before(oldest) {
+				oldestId, oldest = id, *rt.task.EndTime
+			}
+		}
+		if oldestId == "" {
+			// 全部任务仍在执行，不淘汰
+			return
+		}
+		delete(c.tasks, oldestId)
+	}
+}
+
+// replayAccessLog 加载单条访问日志并重放到目标环境
+func replayAccessLog(ctx context.Context, client *http.Client, loader AccessLogLoader, tenantId, traceId string, req *models.GatewayAccessLogReplayRequest) models.GatewayAccessLogReplayResult {
+	result := models.GatewayAccessLogReplayResult{TraceId: traceId}
+
+	accessLog, err := loader(ctx, tenantId, traceId)
+	if err != nil {
+		result.ErrorMessage = "加载访问日志失败: " + err.Error()
+		return result
+	}
+	if accessLog == nil {
+		result.ErrorMessage = "访问日志不存在"
+		return result
+	}
+	result.Method = accessLog.RequestMethod
+	result.OriginalStatusCode = accessLog.GatewayStatusCode
+	result.OriginalDurationMs = accessLog.TotalProcessingTimeMs
+
+	httpReq, err := buildReplayRequest(ctx, accessLog, req)
+	if err != nil {
+		result.ErrorMessage = err.Error()
+		return result
+	}
+	result.Method = httpReq.Method
+	result.Url = httpReq.URL.String()
+
+	start := time.Now()
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		result.DurationMs = time.Since(start).Milliseconds()
+		result.ErrorMessage = "重放请求失败: " + err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+
+	size, err := io.Copy(io.Discard, resp.Body)
+	result.DurationMs = time.Since(start).Milliseconds()
+	result.StatusCode = resp.StatusCode
+	result.ResponseSize = size
+	result.StatusMatched = resp.StatusCode == accessLog.GatewayStatusCode
+	if err != nil {
+		result.ErrorMessage = "读取响应失败: " + err.Error()
+	}
+	return result
+}
+
+// buildReplayRequest 按访问日志重建重放请求
+func buildReplayRequest(ctx context.Context, accessLog *models.GatewayAccessLog, req *models.GatewayAccessLogReplayRequest) (*http.Request, error) {
+	method := strings.ToUpper(strings.TrimSpace(accessLog.RequestMethod))
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	path := accessLog.RequestPath
+	if path == "" {
+		path = "/"
+	} else if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	target := req.TargetBaseUrl + path
+	if query := strings.TrimPrefix(strings.TrimSpace(accessLog.RequestQuery), "?"); query != "" {
+		target += "?" + query
+	}
+
+	var body io.Reader
+	if accessLog.RequestBody != "" {
+		body = strings.NewReader(accessLog.RequestBody)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("构建重放请求失败: %w", err)
+	}
+
+	if raw := strings.TrimSpace(accessLog.RequestHeaders); raw != "" {
+		var headers map[string]string
+		if err := json.Unmarshal([]byte(raw), &headers); err != nil {
+			return nil, fmt.Errorf("解析原始请求头失败: %w", err)
+		}
+		for key, value := range headers {
+			if replayOmitHeaders[strings.ToLower(key)] {
+				continue
+			}
+			httpReq.Header.Set(key, value)
+		}
+	}
+	for _, key := range req.StripHeaders {
+		httpReq.Header.Del(key)
+	}
+	for key, value := range req.SetHeaders {
+		httpReq.Header.Set(key, value)
+	}
+	httpReq.Header.Set(replaySourceTraceHeader, accessLog.TraceId)
+	return httpReq, nil
+}
+
+// normalizeReplayRequest 校验重放参数并填充默认值
+func normalizeReplayRequest(req *models.GatewayAccessLogReplayRequest) error {
+	traceIds := make([]string, 0, len(req.TraceIds))
+	seen := make(map[string]bool, len(req.TraceIds))
+	for _, traceId := range req.TraceIds {
+		traceId = strings.TrimSpace(traceId)
+		if traceId == "" || seen[traceId] {
+			continue
+		}
+		seen[traceId] = true
+		traceIds = append(traceIds, traceId)
+	}
+	if len(traceIds) == 0 {
+		return fmt.Errorf("请选择要重放的访问日志")
+	}
+	if len(traceIds) > models.ReplayMaxTraceIds {
+		return fmt.Errorf("单次最多重放%d条访问日志", models.ReplayMaxTraceIds)
+	}
+	req.TraceIds = traceIds
+
+	req.TargetBaseUrl = strings.TrimRight(strings.TrimSpace(req.TargetBaseUrl), "/")
+	target, err := url.Parse(req.TargetBaseUrl)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("目标环境地址必须是有效的 http/https 地址")
+	}
+	if target.RawQuery != "" || target.Fragment != "" {
+		return fmt.Errorf("目标环境地址不能包含查询参数")
+	}
+
+	if req.RatePerSecond <= 0 {
+		req.RatePerSecond = models.ReplayDefaultRatePerSecond
+	}
+	if req.RatePerSecond > models.ReplayMaxRatePerSecond {
+		return fmt.Errorf("每秒请求数不能超过%d", models.ReplayMaxRatePerSecond)
+	}
+	if req.Concurrency <= 0 {
+		req.Concurrency = models.ReplayDefaultConcurrency
+	}
+	if req.Concurrency > models.ReplayMaxConcurrency {
+		return fmt.Errorf("并发数不能超过%d", models.ReplayMaxConcurrency)
+	}
+	if req.TimeoutMs <= 0 {
+		req.TimeoutMs = models.ReplayDefaultTimeoutMs
+	}
+	return nil
+}
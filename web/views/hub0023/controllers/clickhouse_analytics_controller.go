@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gateway/pkg/cache"
+	"gateway/pkg/config"
+	"gateway/pkg/logger"
+	"gateway/pkg/utils/ctime"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0023/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// analyticsCacheKeyPrefix 访问日志分析结果的缓存键前缀
+const analyticsCacheKeyPrefix = "gateway_analytics:"
+
+// GetAnalyticsRequestTrend 访问日志请求量分析（按分钟/小时/天统计请求数、错误数和P95）
+// @Router /gateway/hub0023/gateway-log/analytics/request-trend [post]
+func (c *ClickHouseQueryController) GetAnalyticsRequestTrend(ctx *gin.Context) {
+	c.handleAnalytics(ctx, "request-trend", "请求量分析", func(ctx context.Context, req *models.GatewayAnalyticsQueryRequest) (interface{}, error) {
+		return c.clickhouseMonitoringDAO.GetAnalyticsRequestTrend(ctx, req)
+	})
+}
+
+// GetAnalyticsSlowRoutes 访问日志慢路由排行（按P95响应时间倒序）
+// @Router /gateway/hub0023/gateway-log/analytics/slow-routes [post]
+func (c *ClickHouseQueryController) GetAnalyticsSlowRoutes(ctx *gin.Context) {
+	c.handleAnalytics(ctx, "slow-routes", "慢路由分析", func(ctx context.Context, req *models.GatewayAnalyticsQueryRequest) (interface{}, error) {
+		return c.clickhouseMonitoringDAO.GetAnalyticsSlowRoutes(ctx, req)
+	})
+}
+
+// GetAnalyticsErrorBreakdown 访问日志错误分布（按错误码统计）
+// @Router /gateway/hub0023/gateway-log/analytics/error-breakdown [post]
+func (c *ClickHouseQueryController) GetAnalyticsErrorBreakdown(ctx *gin.Context) {
+	c.handleAnalytics(ctx, "error-breakdown", "错误分布分析", func(ctx context.Context, req *models.GatewayAnalyticsQueryRequest) (interface{}, error) {
+		return c.clickhouseMonitoringDAO.GetAnalyticsErrorBreakdown(ctx, req)
+	})
+}
+
+// GetAnalyticsTopClientIPs 访问日志客户端IP排行（按请求数倒序）
+// @Router /gateway/hub0023/gateway-log/analytics/client-ips [post]
+func (c *ClickHouseQueryController) GetAnalyticsTopClientIPs(ctx *gin.Context) {
+	c.handleAnalytics(ctx, "client-ips", "客户端IP排行", func(ctx context.Context, req *models.GatewayAnalyticsQueryRequest) (interface{}, error) {
+		return c.clickhouseMonitoringDAO.GetAnalyticsTopClientIPs(ctx, req)
+	})
+}
+
+// handleAnalytics 解析分析请求、校验时间范围并执行查询，结果按时间窗口缓存
+// 结束时间接近当前时间的窗口数据仍在写入，使用较短的缓存时间；历史窗口数据不再变化，使用较长的缓存时间
+func (c *ClickHouseQueryController) handleAnalytics(
+	ctx *gin.Context,
+	kind, title string,
+	load func(ctx context.Context, req *models.GatewayAnalyticsQueryRequest) (interface{}, error),
+) {
+	var req models.GatewayAnalyticsQueryRequest
+	if err := request.Bind(ctx, &req); err != nil {
+		logger.ErrorWithTrace(ctx, title+"参数解析失败", "error", err)
+		response.ErrorJSON(ctx, "参数解析错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	// 从上下文获取租户ID，不使用前端传递的值
+	req.TenantId = request.GetTenantID(ctx)
+
+	monitoringReq := req.MonitoringFilter()
+	if err := validateGatewayMonitoringInstanceRequired(monitoringReq); err != nil {
+		logger.ErrorWithTrace(ctx, title+"实例参数校验失败", "error", err)
+		response.ErrorJSON(ctx, err.Error(), constants.ED00007)
+		return
+	}
+	if err := c.validateTimeRange(monitoringReq); err != nil {
+		logger.ErrorWithTrace(ctx, title+"时间范围校验失败", "error", err)
+		response.ErrorJSON(ctx, err.Error(), constants.ED00007)
+		return
+	}
+
+	result, err := loadAnalytics(ctx, analyticsCacheKey(kind, &req), analyticsCacheTTL(req.EndTime), func(reqCtx context.Context) (interface{}, error) {
+		return load(reqCtx, &req)
+	})
+	if err != nil {
+		logger.ErrorWithTrace(ctx, title+"查询失败", "error", err)
+		response.ErrorJSON(ctx, "查询失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	response.SuccessJSON(ctx, result, constants.SD00002)
+}
+
+// analyticsCacheKey 根据分析类型和查询条件生成缓存键
+func analyticsCacheKey(kind string, req *models.GatewayAnalyticsQueryRequest) string {
+	data, _ := json.Marshal(req)
+	sum := sha1.Sum(data)
+	return fmt.Sprintf("%s%s:%s:%s", analyticsCacheKeyPrefix, req.TenantId, kind, hex.EncodeToString(sum[:]))
+}
+
+// analyticsCacheTTL 根据查询结束时间计算缓存时间，返回0表示不缓存
+func analyticsCacheTTL(endTimeStr string) time.Duration {
+	endTime, err := ctime.ParseTimeString(endTimeStr)
+	if err != nil {
+		return 0
+	}
+	recentWindow := time.Duration(config.GetInt("web.analytics.recent_window_seconds", 300)) * time.Second
+	if time.Since(endTime) < recentWindow {
+		return time.Duration(config.GetInt("web.analytics.recent_cache_seconds", 15)) * time.Second
+	}
+	return time.Duration(config.GetInt("web.analytics.history_cache_seconds", 600)) * time.Second
+}
+
+// loadAnalytics 读取缓存的分析结果，未命中时查询并缓存
+// 同一查询条件同时未命中时只查询一次，热点查询过期前按概率在后台提前刷新；缓存未初始化或未开启时直接查询
+func loadAnalytics(ctx *gin.Context, key string, ttl time.Duration, load func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	store := cache.GetDefaultCache()
+	if store == nil || !config.GetBool("web.analytics.cache_enabled", true) {
+		return load(ctx)
+	}
+
+	// 后台提前刷新会在请求结束后执行，不能使用会被复用的 gin.Context
+	data, err := cache.SharedLoader(store).Get(ctx.Request.Context(), key, ttl, func(reqCtx context.Context) ([]byte, bool, error) {
+		result, err := load(reqCtx)
+		if err != nil {
+			return nil, false, err
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return nil, false, err
+		}
+		return data, ttl > 0, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(data), nil
+}
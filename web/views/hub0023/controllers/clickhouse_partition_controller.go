@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	"gateway/internal/script/ck"
+	"gateway/pkg/database/clickhouse"
+	"gateway/pkg/logger"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0023/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueryLogPartitions 查询 ClickHouse 日志表的分区
+// @Summary 查询 ClickHouse 日志表分区
+// @Tags ClickHouse日志保留
+// @Accept json
+// @Produce json
+// @Param query body models.LogPartitionQueryRequest true "查询参数"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0023/gateway-log/partition/query [post]
+func (c *ClickHouseQueryController) QueryLogPartitions(ctx *gin.Context) {
+	var req models.LogPartitionQueryRequest
+	if err := request.Bind(ctx, &req); err != nil {
+		logger.ErrorWithTrace(ctx, "日志表分区查询参数解析失败", "error", err)
+		response.ErrorJSON(ctx, "参数解析错误: "+err.Error(), constants.ED00006)
+		return
+	}
+	if !ck.IsLogTable(req.TableName) {
+		response.ErrorJSON(ctx, fmt.Sprintf("不支持的日志表: %s", req.TableName), constants.ED00006)
+		return
+	}
+
+	partitions, err := clickhouse.ListPartitions(ctx, c.clickhouseDB, req.TableName)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询日志表分区失败", "table", req.TableName, "error", err)
+		response.ErrorJSON(ctx, "查询失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	response.SuccessJSON(ctx, partitions, constants.SD00002)
+}
+
+// DropLogPartitions 强制删除 ClickHouse 日志表中指定日期之前的分区
+// 不等待日志清理器的定时任务，立即释放磁盘空间；分区包含所有租户的数据
+// @Summary 删除 ClickHouse 日志表旧分区
+// @Tags ClickHouse日志保留
+// @Accept json
+// @Produce json
+// @Param body body models.LogPartitionDropRequest true "删除参数"
+// @Success 200 {object} response.JsonData
+// @Router /gateway/hub0023/gateway-log/partition/drop [post]
+func (c *ClickHouseQueryController) DropLogPartitions(ctx *gin.Context) {
+	var req models.LogPartitionDropRequest
+	if err := request.Bind(ctx, &req); err != nil {
+		logger.ErrorWithTrace(ctx, "日志表分区删除参数解析失败", "error", err)
+		response.ErrorJSON(ctx, "参数解析错误: "+err.Error(), constants.ED00006)
+		return
+	}
+	if !ck.IsLogTable(req.TableName) {
+		response.ErrorJSON(ctx, fmt.Sprintf("不支持的日志表: %s", req.TableName), constants.ED00006)
+		return
+	}
+	beforeDate, err := time.ParseInLocation("2006-01-02", req.BeforeDate, time.Local)
+	if err != nil {
+		response.ErrorJSON(ctx, "分区日期格式错误，应为 yyyy-MM-dd", constants.ED00006)
+		return
+	}
+	if beforeDate.After(time.Now()) {
+		response.ErrorJSON(ctx, "分区日期不能晚于当天", constants.ED00006)
+		return
+	}
+
+	dropped, err := clickhouse.DropPartitionsBefore(ctx, c.clickhouseDB, req.TableName, req.BeforeDate)
+	logger.InfoWithTrace(ctx, "强制删除日志表分区",
+		"table", req.TableName,
+		"beforeDate", req.BeforeDate,
+		"droppedCount", len(dropped),
+		"operatorId", request.GetOperatorID(ctx))
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "删除日志表分区失败", "table", req.TableName, "error", err)
+		response.ErrorJSON(ctx, fmt.Sprintf("已删除 %d 个分区，其余分区删除失败: %s", len(dropped), err.Error()), constants.ED00009)
+		return
+	}
+
+	response.SuccessJSON(ctx, gin.H{
+		"droppedPartitions": dropped,
+		"message":           fmt.Sprintf("已删除 %d 个分区", len(dropped)),
+	}, constants.SD00005)
+}
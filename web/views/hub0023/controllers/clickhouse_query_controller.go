@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -20,6 +21,8 @@ import (
 type ClickHouseQueryController struct {
 	clickhouseQueryDAO      *dao.ClickHouseQueryDAO
 	clickhouseMonitoringDAO *dao.ClickHouseMonitoringDAO
+	// clickhouseDB ClickHouse 连接，用于日志表分区管理
+	clickhouseDB database.Database
 	// instanceLookupDB 关系库，用于按 gatewayInstanceId 查 HUB_GW_INSTANCE（如拼装 resetUrl）
 	instanceLookupDB database.Database
 }
@@ -30,6 +33,7 @@ func NewClickHouseQueryController(clickhouseDB, instanceLookupDB database.Databa
 	return &ClickHouseQueryController{
 		clickhouseQueryDAO:      dao.NewClickHouseQueryDAO(clickhouseDB),
 		clickhouseMonitoringDAO: dao.NewClickHouseMonitoringDAO(clickhouseDB),
+		clickhouseDB:            clickhouseDB,
 		instanceLookupDB:        instanceLookupDB,
 	}
 }
@@ -162,7 +166,7 @@ func (c *ClickHouseQueryController) GetGatewayMonitoringChartData(ctx *gin.Conte
 
 // QueryGatewayLogs 查询网关日志列表（ClickHouse版本）
 // @Summary 查询网关日志列表（ClickHouse版本）
-// @Description 支持分页查询和多条件过滤的ClickHouse网关日志列表，不返回大字段以提高查询性能
+// @Description 支持分页查询和多条件过滤的ClickHouse网关日志列表，不返回大字段以提高查询性能；cursorPaging 为 true 时按游标分页（不统计总数，使用返回的 nextCursor 翻页）
 // @Tags ClickHouse网关日志
 // @Accept json
 // @Accept x-www-form-urlencoded
@@ -187,6 +191,11 @@ func (c *ClickHouseQueryController) QueryGatewayLogs(ctx *gin.Context) {
 	// 从上下文获取租户ID，不使用前端传递的值
 	req.TenantId = request.GetTenantID(ctx)
 
+	if req.CursorPaging {
+		c.queryGatewayLogsByCursor(ctx, &req)
+		return
+	}
+
 	// 调用DAO查询
 	logs, total, err := c.clickhouseQueryDAO.QueryGatewayLogs(ctx, &req)
 	if err != nil {
@@ -202,6 +211,19 @@ func (c *ClickHouseQueryController) QueryGatewayLogs(ctx *gin.Context) {
 	response.PageJSON(ctx, logs, pageInfo, constants.SD00002)
 }
 
+// queryGatewayLogsByCursor 按游标分页查询网关日志列表（ClickHouse版本）
+func (c *ClickHouseQueryController) queryGatewayLogsByCursor(ctx *gin.Context, req *models.GatewayAccessLogQueryRequest) {
+	logs, nextCursor, err := c.clickhouseQueryDAO.QueryGatewayLogsByCursor(ctx, req)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "ClickHouse网关日志查询失败", "error", err)
+		response.ErrorJSON(ctx, "查询失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	pageInfo := response.NewCursorPageInfo(req.PageSize, len(logs), nextCursor)
+	response.PageJSON(ctx, logs, pageInfo, constants.SD00002)
+}
+
 // GetGatewayLog 获取网关日志详情（ClickHouse版本）
 // @Summary 获取网关日志详情（ClickHouse版本）
 // @Description 通过租户ID和链路追踪ID组合主键获取ClickHouse网关日志详情
@@ -291,6 +313,11 @@ func (c *ClickHouseQueryController) GetGatewayLogAccessDetail(ctx *gin.Context)
 	response.SuccessJSON(ctx, log, constants.SD00002)
 }
 
+// GetAccessLogByKey 按组合主键加载ClickHouse中的网关访问日志，供访问日志重放使用
+func (c *ClickHouseQueryController) GetAccessLogByKey(ctx context.Context, tenantId, traceId string) (*models.GatewayAccessLog, error) {
+	return c.clickhouseQueryDAO.GetGatewayLogByKey(ctx, tenantId, traceId)
+}
+
 // CountGatewayLogs 统计网关日志数量（ClickHouse版本）
 // @Summary 统计网关日志数量（ClickHouse版本）
 // @Description 根据查询条件统计ClickHouse网关日志数量
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -38,7 +39,7 @@ func NewGatewayLogController(db database.Database) *GatewayLogController {
 
 // Query 查询网关日志列表
 // @Summary 查询网关日志列表
-// @Description 支持分页查询和多条件过滤的网关日志列表。为了提高查询性能，列表查询不返回大字段（如请求头、请求体、响应头、响应体、错误堆栈等），这些详细信息可通过详情接口获取。
+// @Description 支持分页查询和多条件过滤的网关日志列表，cursorPaging 为 true 时按游标分页（不统计总数，使用返回的 nextCursor 翻页）。为了提高查询性能，列表查询不返回大字段（如请求头、请求体、响应头、响应体、错误堆栈等），这些详细信息可通过详情接口获取。
 // @Tags 网关日志
 // @Accept json
 // @Accept x-www-form-urlencoded
@@ -63,6 +64,11 @@ func (c *GatewayLogController) Query(ctx *gin.Context) {
 	// 从上下文获取租户ID，不使用前端传递的值
 	req.TenantId = request.GetTenantID(ctx)
 
+	if req.CursorPaging {
+		c.queryByCursor(ctx, &req)
+		return
+	}
+
 	// 调用DAO查询
 	gatewayLogs, total, err := c.gatewayLogDAO.Query(ctx, &req)
 	if err != nil {
@@ -85,6 +91,25 @@ func (c *GatewayLogController) Query(ctx *gin.Context) {
 	response.PageJSON(ctx, gatewayLogList, pageInfo, constants.SD00002)
 }
 
+// queryByCursor 按游标分页查询网关日志列表，不统计总数，分页信息中返回下一页游标
+func (c *GatewayLogController) queryByCursor(ctx *gin.Context, req *models.GatewayAccessLogQueryRequest) {
+	gatewayLogs, nextCursor, err := c.gatewayLogDAO.QueryByCursor(ctx, req)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询网关日志失败", "error", err)
+		response.ErrorJSON(ctx, "查询失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	gatewayLogList := make([]map[string]interface{}, 0, len(gatewayLogs))
+	for _, gatewayLog := range gatewayLogs {
+		gatewayLogList = append(gatewayLogList, gatewayLogSummaryToMap(&gatewayLog))
+	}
+
+	pageInfo := response.NewCursorPageInfo(req.PageSize, len(gatewayLogList), nextCursor)
+	pageInfo.MainKey = "traceId"
+	response.PageJSON(ctx, gatewayLogList, pageInfo, constants.SD00002)
+}
+
 // Get 获取网关日志详情
 // @Summary 获取网关日志详情
 // @Description 通过租户ID和链路追踪ID组合主键获取网关日志详情
@@ -176,6 +201,11 @@ func (c *GatewayLogController) GetAccessDetail(ctx *gin.Context) {
 	response.SuccessJSON(ctx, log, constants.SD00002)
 }
 
+// GetAccessLogByKey 按组合主键加载网关访问日志主表记录，供访问日志重放使用
+func (c *GatewayLogController) GetAccessLogByKey(ctx context.Context, tenantId, traceId string) (*models.GatewayAccessLog, error) {
+	return c.gatewayLogDAO.GetByKey(ctx, tenantId, traceId)
+}
+
 // Reset 重置网关日志（支持批量重置）
 // @Summary 重置网关日志（支持批量重置）
 // @Description 通过租户ID和链路追踪ID组合主键重置指定的网关日志记录
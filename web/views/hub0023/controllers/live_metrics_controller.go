@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"gateway/internal/gateway/logwrite/livestats"
+	"gateway/pkg/config"
+	"gateway/pkg/logger"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// liveMetricsWriteTimeout 单次推送写超时
+	liveMetricsWriteTimeout = 10 * time.Second
+	// liveMetricsPongTimeout 超过该时长未收到客户端消息或Pong时断开连接
+	liveMetricsPongTimeout = 60 * time.Second
+	// liveMetricsPingInterval Ping间隔，需小于Pong超时
+	liveMetricsPingInterval = 25 * time.Second
+)
+
+// LiveMetricsMessage 实时指标推送消息
+type LiveMetricsMessage struct {
+	Type string              `json:"type"` // 消息类型，固定为 snapshot
+	Data *livestats.Snapshot `json:"data"` // 统计快照
+}
+
+// LiveMetricsController 网关实时指标控制器
+// 通过WebSocket周期推送由访问日志写入流程聚合的RPS、错误率、路由P95延迟和实例健康状态，
+// 监控大屏无需轮询日志存储
+type LiveMetricsController struct {
+	connections int64
+}
+
+// NewLiveMetricsController 创建实时指标控制器
+func NewLiveMetricsController() *LiveMetricsController {
+	return &LiveMetricsController{}
+}
+
+// Stream 建立WebSocket连接并按间隔推送当前租户的实时指标快照
+// 查询参数：gatewayInstanceId 实例过滤，windowSeconds 统计窗口，intervalSeconds 推送间隔，maxRoutes 路由数上限
+func (c *LiveMetricsController) Stream(ctx *gin.Context) {
+	if !config.GetBool("web.live_metrics.enabled", true) {
+		response.ErrorJSON(ctx, "实时指标推送未启用", constants.ED00009)
+		return
+	}
+
+	maxConnections := int64(config.GetInt("web.live_metrics.max_connections", 100))
+	if maxConnections > 0 && atomic.AddInt64(&c.connections, 1) > maxConnections {
+		atomic.AddInt64(&c.connections, -1)
+		response.ErrorJSON(ctx, "实时指标连接数已达上限", constants.ED00009)
+		return
+	}
+	defer atomic.AddInt64(&c.connections, -1)
+
+	query := livestats.SnapshotQuery{
+		TenantId:          request.GetTenantID(ctx),
+		GatewayInstanceId: request.GetParam(ctx, "gatewayInstanceId"),
+		WindowSeconds:     intParam(ctx, "windowSeconds", config.GetInt("web.live_metrics.window_seconds", livestats.DefaultWindowSeconds)),
+		MaxRoutes:         intParam(ctx, "maxRoutes", config.GetInt("web.live_metrics.max_routes", 50)),
+	}
+	interval := intParam(ctx, "intervalSeconds", config.GetInt("web.live_metrics.push_interval_seconds", 2))
+	if minInterval := config.GetInt("web.live_metrics.min_interval_seconds", 1); interval < minInterval {
+		interval = minInterval
+	}
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 4096,
+		CheckOrigin:     checkLiveMetricsOrigin,
+	}
+	conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		// Upgrade 失败时已向客户端写入错误响应
+		logger.WarnWithTrace(ctx, "实时指标WebSocket握手失败", "error", err.Error())
+		return
+	}
+	defer conn.Close()
+
+	// 读循环只处理Ping/Pong和关闭帧，客户端断开后通知推送循环退出
+	closed := make(chan struct{})
+	conn.SetReadLimit(1024)
+	_ = conn.SetReadDeadline(time.Now().Add(liveMetricsPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(liveMetricsPongTimeout))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+			_ = conn.SetReadDeadline(time.Now().Add(liveMetricsPongTimeout))
+		}
+	}()
+
+	pushTicker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer pushTicker.Stop()
+	pingTicker := time.NewTicker(liveMetricsPingInterval)
+	defer pingTicker.Stop()
+
+	// 连接建立后立即推送一次，避免页面等待首个推送间隔
+	if err := writeLiveSnapshot(conn, query); err != nil {
+		return
+	}
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ctx.Request.Context().Done():
+			return
+		case <-pushTicker.C:
+			if err := writeLiveSnapshot(conn, query); err != nil {
+				logger.Debug("实时指标推送失败，关闭连接", "tenantId", query.TenantId, "error", err.Error())
+				return
+			}
+		case <-pingTicker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(liveMetricsWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// GetSnapshot 获取一次实时指标快照，供不支持WebSocket的客户端使用
+func (c *LiveMetricsController) GetSnapshot(ctx *gin.Context) {
+	query := livestats.SnapshotQuery{
+		TenantId:          request.GetTenantID(ctx),
+		GatewayInstanceId: request.GetParam(ctx, "gatewayInstanceId"),
+		WindowSeconds:     intParam(ctx, "windowSeconds", config.GetInt("web.live_metrics.window_seconds", livestats.DefaultWindowSeconds)),
+		MaxRoutes:         intParam(ctx, "maxRoutes", config.GetInt("web.live_metrics.max_routes", 50)),
+	}
+	response.SuccessJSON(ctx, livestats.Default().Snapshot(query), constants.SD00002)
+}
+
+// writeLiveSnapshot 生成快照并推送
+func writeLiveSnapshot(conn *websocket.Conn, query livestats.SnapshotQuery) error {
+	message := LiveMetricsMessage{
+		Type: "snapshot",
+		Data: livestats.Default().Snapshot(query),
+	}
+	_ = conn.SetWriteDeadline(time.Now().Add(liveMetricsWriteTimeout))
+	return conn.WriteJSON(message)
+}
+
+// checkLiveMetricsOrigin 校验WebSocket握手的Origin
+// 连接依赖会话Cookie认证，需防止跨站WebSocket劫持：允许同源请求和 web.cors.allowed_origins 中的来源
+func checkLiveMetricsOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	allowedOrigins := config.GetString("web.cors.allowed_origins", "*")
+	if allowedOrigins == "*" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, allowed := range strings.Split(allowedOrigins, ",") {
+		if strings.EqualFold(strings.TrimSpace(allowed), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// intParam 读取整数参数，缺省或非法时返回默认值
+func intParam(ctx *gin.Context, key string, defaultValue int) int {
+	value := request.GetParam(ctx, key)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return defaultValue
+	}
+	return n
+}
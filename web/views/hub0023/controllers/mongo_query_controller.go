@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -358,6 +359,11 @@ func (c *MongoQueryController) GetGatewayLogAccessDetail(ctx *gin.Context) {
 	response.SuccessJSON(ctx, log, constants.SD00002)
 }
 
+// GetAccessLogByKey 按组合主键加载MongoDB中的网关访问日志，供访问日志重放使用
+func (c *MongoQueryController) GetAccessLogByKey(ctx context.Context, tenantId, traceId string) (*models.GatewayAccessLog, error) {
+	return c.mongoQueryDAO.GetGatewayLogByKey(ctx, tenantId, traceId)
+}
+
 // CountGatewayLogs 统计网关日志数量（MongoDB版本）
 // @Summary 统计网关日志数量（MongoDB版本）
 // @Description 根据查询条件统计MongoDB网关日志数量
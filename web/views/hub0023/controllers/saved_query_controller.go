@@ -0,0 +1,315 @@
+package controllers
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/pkg/utils/random"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/export"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0023/dao"
+	"gateway/web/views/hub0023/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SavedQueryController 保存查询和报表控制器
+// 用户可保存访问日志、监控查询的过滤条件，按需或定时生成报表
+type SavedQueryController struct {
+	dao      *dao.SavedQueryDAO
+	reporter *SavedQueryReporter
+}
+
+// NewSavedQueryController 创建保存查询控制器
+func NewSavedQueryController(db database.Database, reporter *SavedQueryReporter) *SavedQueryController {
+	return &SavedQueryController{
+		dao:      dao.NewSavedQueryDAO(db),
+		reporter: reporter,
+	}
+}
+
+// QuerySavedQueries 分页查询当前用户可见的保存查询（本人创建的和共享的）
+func (c *SavedQueryController) QuerySavedQueries(ctx *gin.Context) {
+	page, pageSize := request.GetPaginationParams(ctx)
+	tenantId := request.GetTenantID(ctx)
+	userId := request.GetUserID(ctx)
+
+	var q models.SavedQueryListRequest
+	if err := request.BindSafely(ctx, &q); err != nil {
+		logger.WarnWithTrace(ctx, "绑定保存查询筛选条件失败，使用默认条件", "error", err.Error())
+	}
+
+	rows, total, err := c.dao.QuerySavedQueries(ctx, tenantId, userId, &q, page, pageSize)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询保存的查询失败", "error", err)
+		response.ErrorJSON(ctx, "查询保存的查询失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	pageInfo := response.NewPageInfo(page, pageSize, total)
+	pageInfo.MainKey = "savedQueryId"
+	response.PageJSON(ctx, rows, pageInfo, constants.SD00002)
+}
+
+// GetSavedQuery 获取保存的查询，前端使用 queryParams 回填查询条件
+func (c *SavedQueryController) GetSavedQuery(ctx *gin.Context) {
+	savedQuery, ok := c.loadVisible(ctx)
+	if !ok {
+		return
+	}
+	response.SuccessJSON(ctx, savedQuery, constants.SD00001)
+}
+
+// AddSavedQuery 保存查询条件
+func (c *SavedQueryController) AddSavedQuery(ctx *gin.Context) {
+	var req models.SavedQuery
+	if err := request.BindSafely(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	operatorId := request.GetOperatorID(ctx)
+	now := time.Now()
+	req.TenantId = request.GetTenantID(ctx)
+	req.SavedQueryId = random.Generate32BitRandomString()
+	req.OwnerUserId = operatorId
+	req.LastReportTime = nil
+	req.LastReportStatus = ""
+	req.AddTime = now
+	req.AddWho = operatorId
+	req.EditTime = now
+	req.EditWho = operatorId
+	req.OprSeqFlag = random.Generate32BitRandomString()
+	req.CurrentVersion = 1
+	req.ActiveFlag = "Y"
+	if msg, code := c.normalize(&req); msg != "" {
+		response.ErrorJSON(ctx, msg, code)
+		return
+	}
+
+	if err := c.dao.CreateSavedQuery(ctx, &req); err != nil {
+		logger.ErrorWithTrace(ctx, "创建保存的查询失败", "error", err)
+		response.ErrorJSON(ctx, "创建保存的查询失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	if err := c.reporter.Schedule(&req); err != nil {
+		logger.WarnWithTrace(ctx, "注册定时报表失败", "savedQueryId", req.SavedQueryId, "error", err.Error())
+	}
+	response.SuccessJSON(ctx, req, constants.SD00003)
+}
+
+// UpdateSavedQuery 更新保存的查询，仅创建人可修改
+func (c *SavedQueryController) UpdateSavedQuery(ctx *gin.Context) {
+	var req models.SavedQuery
+	if err := request.BindSafely(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+	if strings.TrimSpace(req.SavedQueryId) == "" {
+		response.ErrorJSON(ctx, "savedQueryId不能为空", constants.ED00007)
+		return
+	}
+
+	current, ok := c.loadOwned(ctx, req.SavedQueryId)
+	if !ok {
+		return
+	}
+
+	// 保留创建信息和最近报表状态（避免被覆盖）
+	req.TenantId = current.TenantId
+	req.OwnerUserId = current.OwnerUserId
+	req.LastReportTime = current.LastReportTime
+	req.LastReportStatus = current.LastReportStatus
+	req.AddTime = current.AddTime
+	req.AddWho = current.AddWho
+	req.OprSeqFlag = random.Generate32BitRandomString()
+	req.CurrentVersion = current.CurrentVersion + 1
+	req.ActiveFlag = "Y"
+	req.EditTime = time.Now()
+	req.EditWho = request.GetOperatorID(ctx)
+	if msg, code := c.normalize(&req); msg != "" {
+		response.ErrorJSON(ctx, msg, code)
+		return
+	}
+
+	if err := c.dao.UpdateSavedQuery(ctx, &req); err != nil {
+		logger.ErrorWithTrace(ctx, "更新保存的查询失败", "error", err)
+		response.ErrorJSON(ctx, "更新保存的查询失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	if err := c.reporter.Schedule(&req); err != nil {
+		logger.WarnWithTrace(ctx, "更新定时报表失败", "savedQueryId", req.SavedQueryId, "error", err.Error())
+	}
+	response.SuccessJSON(ctx, req, constants.SD00004)
+}
+
+// DeleteSavedQuery 删除保存的查询并移除定时报表，仅创建人可删除
+func (c *SavedQueryController) DeleteSavedQuery(ctx *gin.Context) {
+	savedQueryId := request.GetParam(ctx, "savedQueryId")
+	if strings.TrimSpace(savedQueryId) == "" {
+		response.ErrorJSON(ctx, "savedQueryId不能为空", constants.ED00007)
+		return
+	}
+	if _, ok := c.loadOwned(ctx, savedQueryId); !ok {
+		return
+	}
+
+	if err := c.dao.DeleteSavedQuery(ctx, request.GetTenantID(ctx), savedQueryId, request.GetOperatorID(ctx)); err != nil {
+		logger.ErrorWithTrace(ctx, "删除保存的查询失败", "error", err)
+		response.ErrorJSON(ctx, "删除保存的查询失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	c.reporter.Unschedule(savedQueryId)
+	response.SuccessJSON(ctx, gin.H{"savedQueryId": savedQueryId}, constants.SD00005)
+}
+
+// ExportSavedQueryReport 立即执行保存的查询并生成报表
+// 与定时报表相同，生成报表记录，配置了收件人时发送邮件
+func (c *SavedQueryController) ExportSavedQueryReport(ctx *gin.Context) {
+	savedQuery, ok := c.loadVisible(ctx)
+	if !ok {
+		return
+	}
+
+	report, err := c.reporter.Generate(ctx.Request.Context(), savedQuery, models.ReportTriggerManual, request.GetOperatorID(ctx))
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "生成报表失败", "savedQueryId", savedQuery.SavedQueryId, "error", err)
+		response.ErrorJSON(ctx, "生成报表失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	response.SuccessJSON(ctx, report, constants.SD00001)
+}
+
+// QueryReports 分页查询当前用户可见的报表记录（本人创建的和共享的保存查询生成的报表）
+func (c *SavedQueryController) QueryReports(ctx *gin.Context) {
+	page, pageSize := request.GetPaginationParams(ctx)
+	tenantId := request.GetTenantID(ctx)
+	userId := request.GetUserID(ctx)
+
+	var q models.QueryReportListRequest
+	if err := request.BindSafely(ctx, &q); err != nil {
+		logger.WarnWithTrace(ctx, "绑定报表记录筛选条件失败，使用默认条件", "error", err.Error())
+	}
+
+	rows, total, err := c.dao.QueryReports(ctx, tenantId, userId, &q, page, pageSize)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询报表记录失败", "error", err)
+		response.ErrorJSON(ctx, "查询报表记录失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	pageInfo := response.NewPageInfo(page, pageSize, total)
+	pageInfo.MainKey = "reportId"
+	response.PageJSON(ctx, rows, pageInfo, constants.SD00002)
+}
+
+// DownloadReport 下载报表文件，只能下载当前用户可见的保存查询生成的报表
+func (c *SavedQueryController) DownloadReport(ctx *gin.Context) {
+	reportId := request.GetParam(ctx, "reportId")
+	if strings.TrimSpace(reportId) == "" {
+		response.ErrorJSON(ctx, "reportId不能为空", constants.ED00007)
+		return
+	}
+
+	report, err := c.dao.GetReport(ctx, request.GetTenantID(ctx), request.GetUserID(ctx), reportId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询报表记录失败", "error", err)
+		response.ErrorJSON(ctx, "查询报表记录失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if report == nil || report.ReportStatus != models.ReportStatusSuccess || report.FilePath == "" {
+		response.ErrorJSON(ctx, "报表文件不存在", constants.ED00008)
+		return
+	}
+
+	ctx.Header("Content-Type", export.ContentType(report.ReportFormat))
+	ctx.FileAttachment(report.FilePath, report.FileName)
+}
+
+// loadVisible 根据请求中的 savedQueryId 加载当前用户可见的保存查询，失败时已写入错误响应
+func (c *SavedQueryController) loadVisible(ctx *gin.Context) (*models.SavedQuery, bool) {
+	savedQueryId := request.GetParam(ctx, "savedQueryId")
+	if strings.TrimSpace(savedQueryId) == "" {
+		response.ErrorJSON(ctx, "savedQueryId不能为空", constants.ED00007)
+		return nil, false
+	}
+
+	savedQuery, err := c.dao.GetSavedQuery(ctx, request.GetTenantID(ctx), savedQueryId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取保存的查询失败", "error", err)
+		response.ErrorJSON(ctx, "获取保存的查询失败: "+err.Error(), constants.ED00009)
+		return nil, false
+	}
+	if savedQuery == nil || (savedQuery.OwnerUserId != request.GetUserID(ctx) && savedQuery.ShareFlag != "Y") {
+		response.ErrorJSON(ctx, "保存的查询不存在", constants.ED00008)
+		return nil, false
+	}
+	return savedQuery, true
+}
+
+// loadOwned 加载当前用户创建的保存查询，失败时已写入错误响应
+func (c *SavedQueryController) loadOwned(ctx *gin.Context, savedQueryId string) (*models.SavedQuery, bool) {
+	savedQuery, err := c.dao.GetSavedQuery(ctx, request.GetTenantID(ctx), savedQueryId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取保存的查询失败", "error", err)
+		response.ErrorJSON(ctx, "获取保存的查询失败: "+err.Error(), constants.ED00009)
+		return nil, false
+	}
+	if savedQuery == nil {
+		response.ErrorJSON(ctx, "保存的查询不存在", constants.ED00008)
+		return nil, false
+	}
+	if savedQuery.OwnerUserId != request.GetUserID(ctx) {
+		response.ErrorJSON(ctx, "只能修改本人创建的查询", constants.ED00010)
+		return nil, false
+	}
+	return savedQuery, true
+}
+
+// normalize 校验并补全保存查询的字段，返回错误信息和错误码，校验通过时错误信息为空
+func (c *SavedQueryController) normalize(savedQuery *models.SavedQuery) (string, string) {
+	savedQuery.QueryName = strings.TrimSpace(savedQuery.QueryName)
+	if savedQuery.QueryName == "" {
+		return "queryName不能为空", constants.ED00007
+	}
+	if !c.reporter.SupportsQueryType(savedQuery.QueryType) {
+		return "不支持的查询类型: " + savedQuery.QueryType, constants.ED00006
+	}
+	if strings.TrimSpace(savedQuery.QueryParams) != "" {
+		var params map[string]interface{}
+		if err := json.Unmarshal([]byte(savedQuery.QueryParams), &params); err != nil {
+			return "queryParams必须是JSON对象: " + err.Error(), constants.ED00006
+		}
+	}
+	if savedQuery.TimeRangeMinutes < 0 {
+		return "timeRangeMinutes不能为负数", constants.ED00006
+	}
+	if savedQuery.MaxRows < 0 {
+		return "maxRows不能为负数", constants.ED00006
+	}
+	if savedQuery.ShareFlag != "Y" {
+		savedQuery.ShareFlag = "N"
+	}
+	if savedQuery.ScheduleEnabled != "Y" {
+		savedQuery.ScheduleEnabled = "N"
+	}
+	if _, err := export.NewOptions(savedQuery.ReportFormat, savedQuery.ReportColumns, "", savedQuery.MaxRows); err != nil {
+		return err.Error(), constants.ED00006
+	}
+	if savedQuery.ReportFormat == "" {
+		savedQuery.ReportFormat = export.FormatXLSX
+	}
+	if savedQuery.ScheduleEnabled == "Y" {
+		if err := ValidateReportCron(savedQuery.CronExpression); err != nil {
+			return err.Error(), constants.ED00006
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,476 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"gateway/pkg/alert"
+	"gateway/pkg/alert/channel"
+	"gateway/pkg/config"
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/pkg/timer"
+	"gateway/pkg/timer/cron"
+	"gateway/pkg/utils/random"
+	"gateway/web/globalmodels"
+	"gateway/web/utils/export"
+	"gateway/web/views/hub0023/dao"
+	"gateway/web/views/hub0023/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// reportSchedulerType 定时报表调度器类型，调度器ID格式与定时任务模块一致：类型_scheduler_租户ID
+const reportSchedulerType = "SAVED_QUERY_REPORT"
+
+// reportTimeLayout 相对时间范围计算出的 startTime/endTime 格式
+const reportTimeLayout = "2006-01-02 15:04:05"
+
+// unsafeFileNameChars 文件名中不允许出现的字符
+var unsafeFileNameChars = regexp.MustCompile(`[\\/:*?"<>|\s]+`)
+
+// SavedQueryReporter 保存查询的报表生成器
+// 使用与页面查询相同的查询处理函数执行保存的过滤条件，结果生成CSV或Excel报表文件并记录到 HUB_QUERY_REPORT，
+// 配置了收件人时通过邮件告警渠道以附件发送；启用定时报表的查询按Cron表达式注册到定时器池周期执行
+type SavedQueryReporter struct {
+	dao     *dao.SavedQueryDAO
+	queries map[string]gin.HandlerFunc // 查询类型 -> 查询处理函数
+
+	mu        sync.Mutex
+	scheduled map[string]string // 已注册的定时报表：保存查询ID -> 调度器ID
+}
+
+// NewSavedQueryReporter 创建报表生成器
+// 参数:
+//
+//	db: 数据库连接
+//	queries: 查询类型与查询处理函数的映射，处理函数需返回标准的分页或对象响应
+//
+// 返回:
+//
+//	*SavedQueryReporter: 报表生成器实例
+func NewSavedQueryReporter(db database.Database, queries map[string]gin.HandlerFunc) *SavedQueryReporter {
+	return &SavedQueryReporter{
+		dao:       dao.NewSavedQueryDAO(db),
+		queries:   queries,
+		scheduled: make(map[string]string),
+	}
+}
+
+// SupportsQueryType 是否支持指定查询类型
+func (r *SavedQueryReporter) SupportsQueryType(queryType string) bool {
+	_, ok := r.queries[queryType]
+	return ok
+}
+
+// Generate 执行保存的查询并生成报表
+// 查询失败同样写入报表记录（状态为FAILED），便于在报表列表中查看失败原因
+// 参数:
+//
+//	ctx: 上下文对象
+//	savedQuery: 保存的查询
+//	triggerType: 触发方式，SCHEDULE 或 MANUAL
+//	operatorId: 操作人ID，定时触发时为查询所属用户
+//
+// 返回:
+//
+//	*models.QueryReport: 报表记录
+//	error: 报表生成失败时返回错误
+func (r *SavedQueryReporter) Generate(ctx context.Context, savedQuery *models.SavedQuery, triggerType, operatorId string) (*models.QueryReport, error) {
+	now := time.Now()
+	report := &models.QueryReport{
+		TenantId:       savedQuery.TenantId,
+		ReportId:       random.Generate32BitRandomString(),
+		SavedQueryId:   savedQuery.SavedQueryId,
+		QueryName:      savedQuery.QueryName,
+		TriggerType:    triggerType,
+		ReportFormat:   savedQuery.ReportFormat,
+		EmailStatus:    models.ReportEmailNone,
+		StartTime:      now,
+		AddTime:        now,
+		AddWho:         operatorId,
+		EditTime:       now,
+		EditWho:        operatorId,
+		OprSeqFlag:     random.Generate32BitRandomString(),
+		CurrentVersion: 1,
+		ActiveFlag:     "Y",
+	}
+
+	genErr := r.render(ctx, savedQuery, report)
+	if genErr != nil {
+		report.ReportStatus = models.ReportStatusFailed
+		report.ErrorMessage = genErr.Error()
+	} else {
+		report.ReportStatus = models.ReportStatusSuccess
+		if emailErr := r.sendEmail(ctx, savedQuery, report); emailErr != nil {
+			report.EmailStatus = models.ReportEmailFailed
+			report.ErrorMessage = emailErr.Error()
+		}
+	}
+
+	endTime := time.Now()
+	report.EndTime = &endTime
+	report.DurationMs = endTime.Sub(now).Milliseconds()
+
+	if err := r.dao.CreateReport(ctx, report); err != nil {
+		return report, err
+	}
+	if err := r.dao.UpdateLastReport(ctx, savedQuery.TenantId, savedQuery.SavedQueryId, report.ReportStatus, now); err != nil {
+		logger.Warn("更新最近报表状态失败", "savedQueryId", savedQuery.SavedQueryId, "error", err)
+	}
+	r.cleanupExpiredReports(ctx, savedQuery.TenantId)
+
+	logger.Info("保存查询报表生成完成",
+		"tenantId", savedQuery.TenantId,
+		"savedQueryId", savedQuery.SavedQueryId,
+		"reportId", report.ReportId,
+		"triggerType", triggerType,
+		"status", report.ReportStatus,
+		"rowCount", report.RowCount,
+		"emailStatus", report.EmailStatus)
+	return report, genErr
+}
+
+// render 执行查询并写入报表文件
+func (r *SavedQueryReporter) render(ctx context.Context, savedQuery *models.SavedQuery, report *models.QueryReport) error {
+	query, ok := r.queries[savedQuery.QueryType]
+	if !ok {
+		return fmt.Errorf("不支持的查询类型: %s", savedQuery.QueryType)
+	}
+
+	opts, err := export.NewOptions(savedQuery.ReportFormat, savedQuery.ReportColumns, "", savedQuery.MaxRows)
+	if err != nil {
+		return err
+	}
+	report.ReportFormat = opts.Format
+
+	params, err := reportQueryParams(savedQuery, time.Now())
+	if err != nil {
+		return err
+	}
+
+	userContext := &globalmodels.UserContext{
+		UserId:   savedQuery.OwnerUserId,
+		TenantId: savedQuery.TenantId,
+	}
+	c, err := export.NewBackgroundContext(ctx, userContext, params)
+	if err != nil {
+		return err
+	}
+	records, err := export.Collect(c, query, opts.MaxRows)
+	if err != nil {
+		return fmt.Errorf("执行查询失败: %w", err)
+	}
+	report.RowCount = len(records)
+
+	dir := filepath.Join(reportDir(), savedQuery.TenantId)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("创建报表目录失败: %w", err)
+	}
+	report.FileName = export.FileName(reportFileBaseName(savedQuery), opts.Format)
+	report.FilePath = filepath.Join(dir, report.ReportId+"."+opts.Format)
+
+	size, err := export.SaveFile(report.FilePath, reportFileBaseName(savedQuery), opts, records)
+	if err != nil {
+		os.Remove(report.FilePath)
+		return fmt.Errorf("生成报表文件失败: %w", err)
+	}
+	report.FileSize = size
+	return nil
+}
+
+// sendEmail 配置了收件人时以附件发送报表
+func (r *SavedQueryReporter) sendEmail(ctx context.Context, savedQuery *models.SavedQuery, report *models.QueryReport) error {
+	recipients := splitRecipients(savedQuery.EmailTo)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	data, err := os.ReadFile(report.FilePath)
+	if err != nil {
+		return fmt.Errorf("读取报表文件失败: %w", err)
+	}
+
+	message := alert.NewMessage().
+		WithTitle(fmt.Sprintf("查询报表：%s", savedQuery.QueryName)).
+		WithContent(fmt.Sprintf("报表 %s 已生成，详见附件。", report.FileName)).
+		WithTableData(map[string]interface{}{
+			"查询名称": savedQuery.QueryName,
+			"记录数":  report.RowCount,
+			"生成时间": report.StartTime.Format(reportTimeLayout),
+		}).
+		WithAttachment(report.FileName, export.ContentType(report.ReportFormat), data).
+		WithExtra("send_config", &channel.EmailSendConfig{To: recipients})
+
+	var result *alert.SendResult
+	if savedQuery.EmailChannel != "" {
+		result = alert.GetGlobalManager().Send(ctx, savedQuery.EmailChannel, message, nil)
+	} else {
+		result = alert.GetGlobalManager().SendToDefault(ctx, message, nil)
+	}
+	if result == nil || !result.Success {
+		if result != nil && result.Error != nil {
+			return fmt.Errorf("发送报表邮件失败: %w", result.Error)
+		}
+		return fmt.Errorf("发送报表邮件失败")
+	}
+	report.EmailStatus = models.ReportEmailSuccess
+	return nil
+}
+
+// cleanupExpiredReports 清理超过保留天数的报表文件和记录，保留天数小于等于0时不清理
+func (r *SavedQueryReporter) cleanupExpiredReports(ctx context.Context, tenantId string) {
+	retentionDays := config.GetInt("web.report.retention_days", 30)
+	if retentionDays <= 0 {
+		return
+	}
+	expired, err := r.dao.ListExpiredReports(ctx, tenantId, time.Now().AddDate(0, 0, -retentionDays))
+	if err != nil {
+		logger.Warn("查询过期报表失败", "tenantId", tenantId, "error", err)
+		return
+	}
+	for _, report := range expired {
+		if report.FilePath != "" {
+			if err := os.Remove(report.FilePath); err != nil && !os.IsNotExist(err) {
+				logger.Warn("删除过期报表文件失败", "reportId", report.ReportId, "error", err)
+				continue
+			}
+		}
+		if err := r.dao.DeleteReport(ctx, tenantId, report.ReportId); err != nil {
+			logger.Warn("删除过期报表记录失败", "reportId", report.ReportId, "error", err)
+		}
+	}
+}
+
+// LoadSchedules 加载所有启用定时报表的保存查询并注册到定时器池
+// 单个查询注册失败只记录日志，不影响其他查询
+// 参数:
+//
+//	ctx: 上下文对象
+//
+// 返回:
+//
+//	error: 查询配置失败时返回错误
+func (r *SavedQueryReporter) LoadSchedules(ctx context.Context) error {
+	if !config.GetBool("web.report.schedule_enabled", true) {
+		logger.Info("定时报表未启用，跳过注册")
+		return nil
+	}
+
+	savedQueries, err := r.dao.ListScheduledQueries(ctx)
+	if err != nil {
+		return err
+	}
+	for _, savedQuery := range savedQueries {
+		if err := r.Schedule(savedQuery); err != nil {
+			logger.Error("注册定时报表失败", "tenantId", savedQuery.TenantId, "savedQueryId", savedQuery.SavedQueryId, "error", err)
+		}
+	}
+	logger.Info("定时报表注册完成", "count", len(savedQueries))
+	return nil
+}
+
+// Schedule 按保存查询的配置注册或更新定时报表，未启用定时报表时移除已注册的任务
+// 参数:
+//
+//	savedQuery: 保存的查询
+//
+// 返回:
+//
+//	error: Cron表达式无效或注册失败时返回错误
+func (r *SavedQueryReporter) Schedule(savedQuery *models.SavedQuery) error {
+	if savedQuery.ScheduleEnabled != "Y" || savedQuery.ActiveFlag != "Y" {
+		r.Unschedule(savedQuery.SavedQueryId)
+		return nil
+	}
+	if !config.GetBool("web.report.schedule_enabled", true) {
+		return nil
+	}
+	if err := ValidateReportCron(savedQuery.CronExpression); err != nil {
+		return err
+	}
+
+	scheduler, schedulerId, err := reportScheduler(savedQuery.TenantId)
+	if err != nil {
+		return err
+	}
+
+	taskConfig := timer.NewTaskConfig(savedQuery.SavedQueryId, savedQuery.QueryName, timer.ScheduleTypeCron)
+	taskConfig.Description = "保存查询定时报表"
+	taskConfig.CronExpr = savedQuery.CronExpression
+	taskConfig.Timeout = time.Duration(config.GetInt("web.report.timeout_seconds", 300)) * time.Second
+
+	taskExecutor := &reportTaskExecutor{reporter: r, tenantId: savedQuery.TenantId, savedQueryId: savedQuery.SavedQueryId}
+	if err := scheduler.AddTask(taskConfig, taskExecutor); err != nil {
+		return fmt.Errorf("注册定时报表任务失败: %w", err)
+	}
+	if !scheduler.IsRunning() {
+		if err := scheduler.Start(); err != nil {
+			return fmt.Errorf("启动定时报表调度器失败: %w", err)
+		}
+	}
+
+	r.mu.Lock()
+	r.scheduled[savedQuery.SavedQueryId] = schedulerId
+	r.mu.Unlock()
+	return nil
+}
+
+// Unschedule 移除已注册的定时报表
+// 参数:
+//
+//	savedQueryId: 保存查询ID
+func (r *SavedQueryReporter) Unschedule(savedQueryId string) {
+	r.mu.Lock()
+	schedulerId, ok := r.scheduled[savedQueryId]
+	delete(r.scheduled, savedQueryId)
+	r.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	scheduler, err := timer.GetTimerPool().GetScheduler(schedulerId)
+	if err != nil {
+		return
+	}
+	if err := scheduler.RemoveTask(savedQueryId); err != nil {
+		logger.Warn("移除定时报表任务失败", "savedQueryId", savedQueryId, "error", err)
+	}
+}
+
+// reportTaskExecutor 定时报表任务执行器，实现 timer.TaskExecutor 接口
+// 每次执行重新读取保存的查询，编辑后的过滤条件无需重新注册即可生效，已删除或停用时跳过
+type reportTaskExecutor struct {
+	reporter     *SavedQueryReporter
+	tenantId     string
+	savedQueryId string
+}
+
+// Execute 生成报表
+func (e *reportTaskExecutor) Execute(ctx context.Context, params interface{}) (*timer.ExecuteResult, error) {
+	savedQuery, err := e.reporter.dao.GetSavedQuery(ctx, e.tenantId, e.savedQueryId)
+	if err != nil {
+		return &timer.ExecuteResult{Success: false, Message: err.Error()}, err
+	}
+	if savedQuery == nil || savedQuery.ScheduleEnabled != "Y" {
+		logger.Info("保存查询已删除或停用定时报表，跳过执行", "tenantId", e.tenantId, "savedQueryId", e.savedQueryId)
+		return &timer.ExecuteResult{Success: true, Message: "定时报表已停用，跳过执行"}, nil
+	}
+
+	report, err := e.reporter.Generate(ctx, savedQuery, models.ReportTriggerSchedule, savedQuery.OwnerUserId)
+	if err != nil {
+		return &timer.ExecuteResult{Success: false, Data: report, Message: err.Error()}, err
+	}
+	return &timer.ExecuteResult{
+		Success: true,
+		Data:    report,
+		Message: fmt.Sprintf("报表生成成功，共 %d 条记录", report.RowCount),
+	}, nil
+}
+
+// GetName 执行器名称
+func (e *reportTaskExecutor) GetName() string {
+	return "SavedQueryReport-" + e.savedQueryId
+}
+
+// Close 无需释放资源
+func (e *reportTaskExecutor) Close() error {
+	return nil
+}
+
+// reportScheduler 获取或创建租户的定时报表调度器
+func reportScheduler(tenantId string) (timer.TaskScheduler, string, error) {
+	schedulerId := fmt.Sprintf("%s_scheduler_%s", reportSchedulerType, tenantId)
+	pool := timer.GetTimerPool()
+	if scheduler, err := pool.GetScheduler(schedulerId); err == nil {
+		return scheduler, schedulerId, nil
+	}
+
+	scheduler, err := pool.CreateScheduler(&timer.SchedulerConfig{
+		ID:               schedulerId,
+		Name:             fmt.Sprintf("定时报表调度器_%s", tenantId),
+		TenantId:         tenantId,
+		MaxWorkers:       2,
+		QueueSize:        100,
+		DefaultTimeout:   30 * time.Minute,
+		DefaultRetries:   0,
+		ScheduleInterval: 10 * time.Second,
+		Tasks:            make(map[string]*timer.TaskConfig),
+	})
+	if err != nil {
+		// 并发创建时可能已由其他请求创建
+		if existing, getErr := pool.GetScheduler(schedulerId); getErr == nil {
+			return existing, schedulerId, nil
+		}
+		return nil, "", fmt.Errorf("创建定时报表调度器失败: %w", err)
+	}
+	return scheduler, schedulerId, nil
+}
+
+// ValidateReportCron 校验定时报表的Cron表达式
+// 参数:
+//
+//	expr: Cron表达式，支持5字段或6字段格式
+//
+// 返回:
+//
+//	error: 表达式为空或无效时返回错误
+func ValidateReportCron(expr string) error {
+	if strings.TrimSpace(expr) == "" {
+		return fmt.Errorf("启用定时报表时Cron表达式不能为空")
+	}
+	if _, err := cron.NewStandardCronParser().Parse(expr); err != nil {
+		return fmt.Errorf("Cron表达式无效: %w", err)
+	}
+	return nil
+}
+
+// reportQueryParams 解析保存的查询参数，配置了相对时间范围时按执行时间重新计算 startTime/endTime
+func reportQueryParams(savedQuery *models.SavedQuery, now time.Time) (map[string]interface{}, error) {
+	params := make(map[string]interface{})
+	if strings.TrimSpace(savedQuery.QueryParams) != "" {
+		decoder := json.NewDecoder(bytes.NewReader([]byte(savedQuery.QueryParams)))
+		decoder.UseNumber()
+		if err := decoder.Decode(&params); err != nil {
+			return nil, fmt.Errorf("查询参数格式错误: %w", err)
+		}
+	}
+	if savedQuery.TimeRangeMinutes > 0 {
+		params["startTime"] = now.Add(-time.Duration(savedQuery.TimeRangeMinutes) * time.Minute).Format(reportTimeLayout)
+		params["endTime"] = now.Format(reportTimeLayout)
+	}
+	return params, nil
+}
+
+// reportDir 报表文件存储目录
+func reportDir() string {
+	return config.GetString("web.report.dir", "./data/reports")
+}
+
+// reportFileBaseName 报表文件名前缀，使用查询名称并替换不适合作为文件名的字符
+func reportFileBaseName(savedQuery *models.SavedQuery) string {
+	name := strings.Trim(unsafeFileNameChars.ReplaceAllString(savedQuery.QueryName, "_"), "_")
+	if name == "" {
+		return "Report"
+	}
+	return name
+}
+
+// splitRecipients 解析逗号或分号分隔的收件人列表
+func splitRecipients(value string) []string {
+	var recipients []string
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			recipients = append(recipients, item)
+		}
+	}
+	return recipients
+}
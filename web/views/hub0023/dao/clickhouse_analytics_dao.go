@@ -0,0 +1,264 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"gateway/pkg/logger"
+	"gateway/pkg/utils/huberrors"
+	"gateway/web/views/hub0023/models"
+)
+
+// 排行类分析的返回数量限制
+const (
+	defaultAnalyticsTopN = 10
+	maxAnalyticsTopN     = 100
+)
+
+// GetAnalyticsRequestTrend 按时间粒度统计请求量、错误数和响应时间
+func (dao *ClickHouseMonitoringDAO) GetAnalyticsRequestTrend(ctx context.Context, req *models.GatewayAnalyticsQueryRequest) ([]models.GatewayAnalyticsRequestTrend, error) {
+	whereClause, params, err := dao.buildMonitoringFilter(req.MonitoringFilter())
+	if err != nil {
+		return nil, huberrors.WrapError(err, "构建查询条件失败")
+	}
+
+	timeGroupFunc := dao.getTimeGroupFunction(req.TimeGranularity)
+	granularitySeconds := dao.getTimeGranularitySeconds(req.TimeGranularity)
+
+	sql := fmt.Sprintf(`
+		SELECT
+			toUnixTimestamp(%s(gatewayStartProcessingTime)) * 1000 as timestamp,
+			COUNT(*) as totalRequests,
+			countIf(gatewayStatusCode >= 400 AND gatewayStatusCode < 500) as clientErrors,
+			countIf(gatewayStatusCode >= 500) as serverErrors,
+			avgIf(totalProcessingTimeMs, totalProcessingTimeMs IS NOT NULL AND totalProcessingTimeMs > 0) as avgResponseTime,
+			quantileIf(0.95)(totalProcessingTimeMs, totalProcessingTimeMs IS NOT NULL AND totalProcessingTimeMs > 0) as p95ResponseTime
+		FROM HUB_GW_ACCESS_LOG
+		%s
+		GROUP BY timestamp
+		ORDER BY timestamp
+	`, timeGroupFunc, whereClause)
+
+	var results []struct {
+		Timestamp       int64   `db:"timestamp"`
+		TotalRequests   int64   `db:"totalRequests"`
+		ClientErrors    int64   `db:"clientErrors"`
+		ServerErrors    int64   `db:"serverErrors"`
+		AvgResponseTime float64 `db:"avgResponseTime"`
+		P95ResponseTime float64 `db:"p95ResponseTime"`
+	}
+
+	err = dao.db.Query(ctx, &results, sql, params, true)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "ClickHouse请求量分析查询失败", "error", err)
+		return nil, huberrors.WrapError(err, "ClickHouse请求量分析查询失败")
+	}
+
+	trend := make([]models.GatewayAnalyticsRequestTrend, 0, len(results))
+	for _, result := range results {
+		trend = append(trend, models.GatewayAnalyticsRequestTrend{
+			Timestamp:         result.Timestamp,
+			TotalRequests:     result.TotalRequests,
+			ClientErrors:      result.ClientErrors,
+			ServerErrors:      result.ServerErrors,
+			RequestsPerSecond: roundToTwoDecimalPlaces(float64(result.TotalRequests) / float64(granularitySeconds)),
+			AvgResponseTimeMs: roundToTwoDecimalPlaces(result.AvgResponseTime),
+			P95ResponseTimeMs: int(result.P95ResponseTime),
+		})
+	}
+
+	return trend, nil
+}
+
+// GetAnalyticsSlowRoutes 按P95响应时间倒序统计慢路由
+func (dao *ClickHouseMonitoringDAO) GetAnalyticsSlowRoutes(ctx context.Context, req *models.GatewayAnalyticsQueryRequest) ([]models.GatewayAnalyticsSlowRoute, error) {
+	whereClause, params, err := dao.buildMonitoringFilter(req.MonitoringFilter())
+	if err != nil {
+		return nil, huberrors.WrapError(err, "构建查询条件失败")
+	}
+
+	minRequests := req.MinRequests
+	if minRequests <= 0 {
+		minRequests = 1
+	}
+
+	sql := fmt.Sprintf(`
+		SELECT
+			routeConfigId,
+			any(routeName) as routeName,
+			any(serviceName) as serviceName,
+			COUNT(*) as requestCount,
+			countIf(gatewayStatusCode >= 400 OR gatewayStatusCode < 200) as errorCount,
+			avgIf(totalProcessingTimeMs, totalProcessingTimeMs IS NOT NULL AND totalProcessingTimeMs > 0) as avgResponseTime,
+			quantileIf(0.95)(totalProcessingTimeMs, totalProcessingTimeMs IS NOT NULL AND totalProcessingTimeMs > 0) as p95ResponseTime,
+			quantileIf(0.99)(totalProcessingTimeMs, totalProcessingTimeMs IS NOT NULL AND totalProcessingTimeMs > 0) as p99ResponseTime,
+			maxIf(totalProcessingTimeMs, totalProcessingTimeMs IS NOT NULL AND totalProcessingTimeMs > 0) as maxResponseTime
+		FROM HUB_GW_ACCESS_LOG
+		%s
+		GROUP BY routeConfigId
+		HAVING requestCount >= %d
+		ORDER BY p95ResponseTime DESC
+		LIMIT %d
+	`, whereClause, minRequests, analyticsTopN(req.TopN))
+
+	var results []struct {
+		RouteConfigId   string  `db:"routeConfigId"`
+		RouteName       string  `db:"routeName"`
+		ServiceName     string  `db:"serviceName"`
+		RequestCount    int64   `db:"requestCount"`
+		ErrorCount      int64   `db:"errorCount"`
+		AvgResponseTime float64 `db:"avgResponseTime"`
+		P95ResponseTime float64 `db:"p95ResponseTime"`
+		P99ResponseTime float64 `db:"p99ResponseTime"`
+		MaxResponseTime float64 `db:"maxResponseTime"`
+	}
+
+	err = dao.db.Query(ctx, &results, sql, params, true)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "ClickHouse慢路由分析查询失败", "error", err)
+		return nil, huberrors.WrapError(err, "ClickHouse慢路由分析查询失败")
+	}
+
+	routes := make([]models.GatewayAnalyticsSlowRoute, 0, len(results))
+	for _, result := range results {
+		routes = append(routes, models.GatewayAnalyticsSlowRoute{
+			RouteConfigId:     result.RouteConfigId,
+			RouteName:         result.RouteName,
+			ServiceName:       result.ServiceName,
+			RequestCount:      result.RequestCount,
+			ErrorRate:         percentOf(result.ErrorCount, result.RequestCount),
+			AvgResponseTimeMs: roundToTwoDecimalPlaces(result.AvgResponseTime),
+			P95ResponseTimeMs: int(result.P95ResponseTime),
+			P99ResponseTimeMs: int(result.P99ResponseTime),
+			MaxResponseTimeMs: int(result.MaxResponseTime),
+		})
+	}
+
+	return routes, nil
+}
+
+// GetAnalyticsErrorBreakdown 按错误码统计错误分布
+// 未设置 errorCode 的 4xx/5xx 请求按 HTTP_状态码 归类
+func (dao *ClickHouseMonitoringDAO) GetAnalyticsErrorBreakdown(ctx context.Context, req *models.GatewayAnalyticsQueryRequest) ([]models.GatewayAnalyticsErrorCode, error) {
+	whereClause, params, err := dao.buildMonitoringFilter(req.MonitoringFilter())
+	if err != nil {
+		return nil, huberrors.WrapError(err, "构建查询条件失败")
+	}
+	whereClause += " AND (errorCode != '' OR gatewayStatusCode >= 400)"
+
+	sql := fmt.Sprintf(`
+		SELECT
+			if(errorCode = '', concat('HTTP_', toString(gatewayStatusCode)), errorCode) as errorCodeGroup,
+			COUNT(*) as count,
+			uniqExact(routeConfigId) as affectedRoutes,
+			anyLast(errorMessage) as sampleMessage,
+			toUnixTimestamp(max(gatewayStartProcessingTime)) * 1000 as lastOccurTime
+		FROM HUB_GW_ACCESS_LOG
+		%s
+		GROUP BY errorCodeGroup
+		ORDER BY count DESC
+		LIMIT %d
+	`, whereClause, analyticsTopN(req.TopN))
+
+	var results []struct {
+		ErrorCode      string `db:"errorCodeGroup"`
+		Count          int64  `db:"count"`
+		AffectedRoutes int64  `db:"affectedRoutes"`
+		SampleMessage  string `db:"sampleMessage"`
+		LastOccurTime  int64  `db:"lastOccurTime"`
+	}
+
+	err = dao.db.Query(ctx, &results, sql, params, true)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "ClickHouse错误分布分析查询失败", "error", err)
+		return nil, huberrors.WrapError(err, "ClickHouse错误分布分析查询失败")
+	}
+
+	var total int64
+	for _, result := range results {
+		total += result.Count
+	}
+
+	breakdown := make([]models.GatewayAnalyticsErrorCode, 0, len(results))
+	for _, result := range results {
+		breakdown = append(breakdown, models.GatewayAnalyticsErrorCode{
+			ErrorCode:      result.ErrorCode,
+			Count:          result.Count,
+			Percentage:     percentOf(result.Count, total),
+			AffectedRoutes: result.AffectedRoutes,
+			SampleMessage:  result.SampleMessage,
+			LastOccurTime:  result.LastOccurTime,
+		})
+	}
+
+	return breakdown, nil
+}
+
+// GetAnalyticsTopClientIPs 按请求数统计客户端IP排行
+func (dao *ClickHouseMonitoringDAO) GetAnalyticsTopClientIPs(ctx context.Context, req *models.GatewayAnalyticsQueryRequest) ([]models.GatewayAnalyticsClientIP, error) {
+	whereClause, params, err := dao.buildMonitoringFilter(req.MonitoringFilter())
+	if err != nil {
+		return nil, huberrors.WrapError(err, "构建查询条件失败")
+	}
+
+	sql := fmt.Sprintf(`
+		SELECT
+			clientIpAddress,
+			COUNT(*) as requestCount,
+			countIf(gatewayStatusCode >= 400) as errorCount,
+			uniqExact(routeConfigId) as routeCount,
+			toUnixTimestamp(max(gatewayStartProcessingTime)) * 1000 as lastRequestTime
+		FROM HUB_GW_ACCESS_LOG
+		%s
+		GROUP BY clientIpAddress
+		ORDER BY requestCount DESC
+		LIMIT %d
+	`, whereClause, analyticsTopN(req.TopN))
+
+	var results []struct {
+		ClientIpAddress string `db:"clientIpAddress"`
+		RequestCount    int64  `db:"requestCount"`
+		ErrorCount      int64  `db:"errorCount"`
+		RouteCount      int64  `db:"routeCount"`
+		LastRequestTime int64  `db:"lastRequestTime"`
+	}
+
+	err = dao.db.Query(ctx, &results, sql, params, true)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "ClickHouse客户端IP排行查询失败", "error", err)
+		return nil, huberrors.WrapError(err, "ClickHouse客户端IP排行查询失败")
+	}
+
+	clients := make([]models.GatewayAnalyticsClientIP, 0, len(results))
+	for _, result := range results {
+		clients = append(clients, models.GatewayAnalyticsClientIP{
+			ClientIpAddress: result.ClientIpAddress,
+			RequestCount:    result.RequestCount,
+			ErrorCount:      result.ErrorCount,
+			ErrorRate:       percentOf(result.ErrorCount, result.RequestCount),
+			RouteCount:      result.RouteCount,
+			LastRequestTime: result.LastRequestTime,
+		})
+	}
+
+	return clients, nil
+}
+
+// analyticsTopN 规范排行类分析的返回数量
+func analyticsTopN(topN int) int {
+	if topN <= 0 {
+		return defaultAnalyticsTopN
+	}
+	if topN > maxAnalyticsTopN {
+		return maxAnalyticsTopN
+	}
+	return topN
+}
+
+// percentOf 计算百分比，保留两位小数
+func percentOf(part, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return roundToTwoDecimalPlaces(float64(part) / float64(total) * 100)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"gateway/pkg/database"
+	"gateway/pkg/database/sqlutils"
 	"gateway/pkg/logger"
 	"gateway/pkg/utils/ctime"
 	"gateway/pkg/utils/huberrors"
@@ -93,6 +94,33 @@ func (dao *ClickHouseQueryDAO) QueryGatewayLogs(ctx context.Context, req *models
 	return logs, int(countResult.Count), nil
 }
 
+// QueryGatewayLogsByCursor 按游标分页查询网关日志列表（ClickHouse版本）
+// 不统计总数，按网关开始处理时间和链路追踪ID倒序翻页，避免 ClickHouse 深度 OFFSET 读取并丢弃大量数据
+func (dao *ClickHouseQueryDAO) QueryGatewayLogsByCursor(ctx context.Context, req *models.GatewayAccessLogQueryRequest) ([]models.GatewayAccessLogSummary, string, error) {
+	keyset, err := sqlutils.NewKeysetPaginationInfo(req.Cursor, req.PageSize, gatewayLogKeysetColumns...)
+	if err != nil {
+		return nil, "", huberrors.WrapError(err, "分页游标无效")
+	}
+
+	whereClause, params, err := dao.buildGatewayLogFilter(req)
+	if err != nil {
+		return nil, "", huberrors.WrapError(err, "构建查询条件失败")
+	}
+
+	query, args, err := sqlutils.BuildKeysetQuery(sqlutils.DatabaseClickHouse, gatewayLogSummarySelect, whereClause, params, keyset)
+	if err != nil {
+		return nil, "", huberrors.WrapError(err, "构建游标分页查询失败")
+	}
+
+	var logs []models.GatewayAccessLogSummary
+	if err := dao.db.Query(ctx, &logs, query, args, true); err != nil {
+		logger.ErrorWithTrace(ctx, "ClickHouse网关日志查询失败", "error", err)
+		return nil, "", huberrors.WrapError(err, "ClickHouse网关日志查询失败")
+	}
+
+	return trimGatewayLogPage(logs, keyset)
+}
+
 // GetGatewayLogByKey 根据主键获取网关日志详情（ClickHouse版本）
 func (dao *ClickHouseQueryDAO) GetGatewayLogByKey(ctx context.Context, tenantId, traceId string) (*models.GatewayAccessLog, error) {
 	// 验证参数
@@ -111,7 +139,7 @@ func (dao *ClickHouseQueryDAO) GetGatewayLogByKey(ctx context.Context, tenantId,
 			   clientIpAddress, clientPort, userAgent, referer, userIdentifier,
 			   gatewayStartProcessingTime, backendRequestStartTime, backendResponseReceivedTime,
 			   gatewayFinishedProcessingTime, totalProcessingTimeMs, gatewayProcessingTimeMs,
-			   backendResponseTimeMs, gatewayStatusCode, backendStatusCode, responseSize,
+			   backendResponseTimeMs, queueTimeMs, gatewayStatusCode, backendStatusCode, responseSize,
 			   responseHeaders, responseBody, matchedRoute, forwardAddress, forwardMethod,
 			   forwardParams, forwardHeaders, forwardBody, loadBalancerDecision, errorMessage,
 			   errorCode, parentTraceId, resetFlag, retryCount, resetCount, logLevel, logType,
@@ -13,6 +13,43 @@ import (
 	"time"
 )
 
+// gatewayLogSummarySelect 网关日志列表查询语句，不返回大字段
+const gatewayLogSummarySelect = `
+		SELECT tenantId, traceId, gatewayInstanceId, gatewayInstanceName, gatewayNodeIp,
+			   routeConfigId, routeName, serviceDefinitionId, serviceName, proxyType,
+			   requestMethod, requestPath, requestQuery, requestSize, clientIpAddress,
+			   clientPort, userAgent, userIdentifier, gatewayStartProcessingTime,
+			   gatewayFinishedProcessingTime, totalProcessingTimeMs, gatewayProcessingTimeMs,
+			   backendResponseTimeMs, gatewayStatusCode, backendStatusCode, responseSize,
+			   matchedRoute, forwardAddress, forwardMethod, loadBalancerDecision, errorMessage,
+			   errorCode, resetFlag, retryCount, resetCount, logLevel, logType,
+			   addTime, addWho, editTime, editWho, oprSeqFlag, currentVersion, activeFlag, noteText
+		FROM HUB_GW_ACCESS_LOG`
+
+// gatewayLogKeysetColumns 网关日志游标分页排序列，链路追踪ID保证同一时间的日志翻页不重复不遗漏
+var gatewayLogKeysetColumns = []sqlutils.KeysetColumn{
+	{Name: "gatewayStartProcessingTime", Desc: true},
+	{Name: "traceId", Desc: true},
+}
+
+// trimGatewayLogPage 截取游标分页查询多取的记录，并由本页最后一条日志生成下一页游标
+func trimGatewayLogPage(logs []models.GatewayAccessLogSummary, keyset *sqlutils.KeysetPaginationInfo) ([]models.GatewayAccessLogSummary, string, error) {
+	count, hasMore := keyset.Trim(len(logs))
+	if count == 0 {
+		return []models.GatewayAccessLogSummary{}, "", nil
+	}
+	logs = logs[:count]
+	if !hasMore {
+		return logs, "", nil
+	}
+	last := logs[count-1]
+	cursor, err := sqlutils.EncodeCursor(last.GatewayStartProcessingTime, last.TraceId)
+	if err != nil {
+		return nil, "", huberrors.WrapError(err, "生成分页游标失败")
+	}
+	return logs, cursor, nil
+}
+
 // GatewayLogDAO 网关日志数据访问对象
 type GatewayLogDAO struct {
 	db database.Database
@@ -35,7 +72,7 @@ func (dao *GatewayLogDAO) GetByKey(ctx context.Context, tenantId, traceId string
 			   clientIpAddress, clientPort, userAgent, referer, userIdentifier,
 			   gatewayStartProcessingTime, backendRequestStartTime, backendResponseReceivedTime,
 			   gatewayFinishedProcessingTime, totalProcessingTimeMs, gatewayProcessingTimeMs,
-			   backendResponseTimeMs, gatewayStatusCode, backendStatusCode, responseSize,
+			   backendResponseTimeMs, queueTimeMs, gatewayStatusCode, backendStatusCode, responseSize,
 			   responseHeaders, responseBody, matchedRoute, forwardAddress, forwardMethod,
 			   forwardParams, forwardHeaders, forwardBody, loadBalancerDecision, errorMessage,
 			   errorCode, parentTraceId, resetFlag, retryCount, resetCount, logLevel, logType,
@@ -66,6 +103,94 @@ func (dao *GatewayLogDAO) GetByKey(ctx context.Context, tenantId, traceId string
 // 这些字段可能包含大量数据，在列表展示时不需要，只在详情查询时获取
 // 这样可以显著提高查询性能，减少网络传输量和内存使用
 func (dao *GatewayLogDAO) Query(ctx context.Context, req *models.GatewayAccessLogQueryRequest) ([]models.GatewayAccessLogSummary, int, error) {
+	whereClause, params, err := dao.buildQueryFilter(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 构建基础查询语句 - 列表查询不返回大字段
+	baseQuery := gatewayLogSummarySelect + " " + whereClause + " ORDER BY gatewayStartProcessingTime DESC"
+
+	// 构建统计查询
+	countQuery, err := sqlutils.BuildCountQuery(baseQuery)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "构建统计查询失败")
+	}
+
+	// 执行统计查询
+	var countResult struct {
+		Count int `db:"COUNT(*)"`
+	}
+	err = dao.db.QueryOne(ctx, &countResult, countQuery, params, true)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询网关日志总数失败", "error", err)
+		return nil, 0, huberrors.WrapError(err, "查询网关日志总数失败")
+	}
+
+	// 如果没有记录，直接返回空列表
+	if countResult.Count == 0 {
+		return []models.GatewayAccessLogSummary{}, 0, nil
+	}
+
+	// 创建分页信息
+	pagination := sqlutils.NewPaginationInfo(req.PageIndex, req.PageSize)
+
+	// 获取数据库类型
+	dbType := sqlutils.GetDatabaseType(dao.db)
+
+	// 构建分页查询
+	paginatedQuery, paginationArgs, err := sqlutils.BuildPaginationQuery(dbType, baseQuery, pagination)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "构建分页查询失败")
+	}
+
+	// 合并查询参数
+	allArgs := append(params, paginationArgs...)
+
+	// 执行分页查询
+	var logs []models.GatewayAccessLogSummary
+	err = dao.db.Query(ctx, &logs, paginatedQuery, allArgs, true)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询网关日志数据失败", "error", err)
+		return nil, 0, huberrors.WrapError(err, "查询网关日志数据失败")
+	}
+
+	return logs, countResult.Count, nil
+}
+
+// QueryByCursor 按游标分页查询网关日志列表
+// 按网关开始处理时间和链路追踪ID倒序翻页，不统计总数，深度翻页时避免 OFFSET 扫描大量记录
+// 返回值:
+//   - []models.GatewayAccessLogSummary: 本页日志
+//   - string: 下一页游标，为空表示没有下一页
+//   - error: 查询失败或游标无效时返回错误
+func (dao *GatewayLogDAO) QueryByCursor(ctx context.Context, req *models.GatewayAccessLogQueryRequest) ([]models.GatewayAccessLogSummary, string, error) {
+	keyset, err := sqlutils.NewKeysetPaginationInfo(req.Cursor, req.PageSize, gatewayLogKeysetColumns...)
+	if err != nil {
+		return nil, "", huberrors.WrapError(err, "分页游标无效")
+	}
+
+	whereClause, params, err := dao.buildQueryFilter(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	query, args, err := sqlutils.BuildKeysetQuery(sqlutils.GetDatabaseType(dao.db), gatewayLogSummarySelect, whereClause, params, keyset)
+	if err != nil {
+		return nil, "", huberrors.WrapError(err, "构建游标分页查询失败")
+	}
+
+	var logs []models.GatewayAccessLogSummary
+	if err := dao.db.Query(ctx, &logs, query, args, true); err != nil {
+		logger.ErrorWithTrace(ctx, "查询网关日志数据失败", "error", err)
+		return nil, "", huberrors.WrapError(err, "查询网关日志数据失败")
+	}
+
+	return trimGatewayLogPage(logs, keyset)
+}
+
+// buildQueryFilter 构建网关日志查询条件
+func (dao *GatewayLogDAO) buildQueryFilter(ctx context.Context, req *models.GatewayAccessLogQueryRequest) (string, []interface{}, error) {
 	// 构建查询条件
 	whereClause := "WHERE activeFlag = 'Y'"
 	var params []interface{}
@@ -176,7 +301,7 @@ func (dao *GatewayLogDAO) Query(ctx context.Context, req *models.GatewayAccessLo
 		startTime, err := ctime.ParseTimeString(req.StartTime)
 		if err != nil {
 			logger.ErrorWithTrace(ctx, "开始时间格式不正确", "startTime", req.StartTime, "error", err)
-			return nil, 0, huberrors.WrapError(err, "开始时间格式不正确: %s", req.StartTime)
+			return "", nil, huberrors.WrapError(err, "开始时间格式不正确: %s", req.StartTime)
 		}
 		whereClause += " AND gatewayStartProcessingTime >= ?"
 		params = append(params, startTime)
@@ -186,7 +311,7 @@ func (dao *GatewayLogDAO) Query(ctx context.Context, req *models.GatewayAccessLo
 		endTime, err := ctime.ParseTimeString(req.EndTime)
 		if err != nil {
 			logger.ErrorWithTrace(ctx, "结束时间格式不正确", "endTime", req.EndTime, "error", err)
-			return nil, 0, huberrors.WrapError(err, "结束时间格式不正确: %s", req.EndTime)
+			return "", nil, huberrors.WrapError(err, "结束时间格式不正确: %s", req.EndTime)
 		}
 		whereClause += " AND gatewayStartProcessingTime <= ?"
 		params = append(params, endTime)
@@ -227,66 +352,7 @@ func (dao *GatewayLogDAO) Query(ctx context.Context, req *models.GatewayAccessLo
 		params = append(params, keyword, keyword, keyword, keyword)
 	}
 
-	// 构建基础查询语句 - 列表查询不返回大字段
-	baseQuery := fmt.Sprintf(`
-		SELECT tenantId, traceId, gatewayInstanceId, gatewayInstanceName, gatewayNodeIp,
-			   routeConfigId, routeName, serviceDefinitionId, serviceName, proxyType,
-			   requestMethod, requestPath, requestQuery, requestSize, clientIpAddress,
-			   clientPort, userAgent, userIdentifier, gatewayStartProcessingTime,
-			   gatewayFinishedProcessingTime, totalProcessingTimeMs, gatewayProcessingTimeMs,
-			   backendResponseTimeMs, gatewayStatusCode, backendStatusCode, responseSize,
-			   matchedRoute, forwardAddress, forwardMethod, loadBalancerDecision, errorMessage,
-			   errorCode, resetFlag, retryCount, resetCount, logLevel, logType,
-			   addTime, addWho, editTime, editWho, oprSeqFlag, currentVersion, activeFlag, noteText
-		FROM HUB_GW_ACCESS_LOG %s
-		ORDER BY gatewayStartProcessingTime DESC
-	`, whereClause)
-
-	// 构建统计查询
-	countQuery, err := sqlutils.BuildCountQuery(baseQuery)
-	if err != nil {
-		return nil, 0, huberrors.WrapError(err, "构建统计查询失败")
-	}
-
-	// 执行统计查询
-	var countResult struct {
-		Count int `db:"COUNT(*)"`
-	}
-	err = dao.db.QueryOne(ctx, &countResult, countQuery, params, true)
-	if err != nil {
-		logger.ErrorWithTrace(ctx, "查询网关日志总数失败", "error", err)
-		return nil, 0, huberrors.WrapError(err, "查询网关日志总数失败")
-	}
-
-	// 如果没有记录，直接返回空列表
-	if countResult.Count == 0 {
-		return []models.GatewayAccessLogSummary{}, 0, nil
-	}
-
-	// 创建分页信息
-	pagination := sqlutils.NewPaginationInfo(req.PageIndex, req.PageSize)
-
-	// 获取数据库类型
-	dbType := sqlutils.GetDatabaseType(dao.db)
-
-	// 构建分页查询
-	paginatedQuery, paginationArgs, err := sqlutils.BuildPaginationQuery(dbType, baseQuery, pagination)
-	if err != nil {
-		return nil, 0, huberrors.WrapError(err, "构建分页查询失败")
-	}
-
-	// 合并查询参数
-	allArgs := append(params, paginationArgs...)
-
-	// 执行分页查询
-	var logs []models.GatewayAccessLogSummary
-	err = dao.db.Query(ctx, &logs, paginatedQuery, allArgs, true)
-	if err != nil {
-		logger.ErrorWithTrace(ctx, "查询网关日志数据失败", "error", err)
-		return nil, 0, huberrors.WrapError(err, "查询网关日志数据失败")
-	}
-
-	return logs, countResult.Count, nil
+	return whereClause, params, nil
 }
 
 // Reset 重置网关日志（支持批量重置）
@@ -0,0 +1,275 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gateway/pkg/database"
+	"gateway/pkg/database/sqlutils"
+	"gateway/pkg/utils/empty"
+	"gateway/pkg/utils/huberrors"
+	"gateway/web/views/hub0023/models"
+)
+
+// visibleReportCondition 报表可见条件：所属保存查询由当前用户创建或已共享，与保存查询的可见范围一致；
+// 保存查询删除后报表记录保留，因此不过滤保存查询的 activeFlag
+const visibleReportCondition = "savedQueryId IN (SELECT savedQueryId FROM HUB_SAVED_QUERY WHERE tenantId = ? AND (ownerUserId = ? OR shareFlag = 'Y'))"
+
+// SavedQueryDAO 保存查询和报表记录DAO，对应表 HUB_SAVED_QUERY、HUB_QUERY_REPORT
+type SavedQueryDAO struct {
+	db database.Database
+}
+
+// NewSavedQueryDAO 创建保存查询DAO
+func NewSavedQueryDAO(db database.Database) *SavedQueryDAO {
+	return &SavedQueryDAO{db: db}
+}
+
+// GetSavedQuery 根据主键获取保存的查询，不存在时返回nil
+func (dao *SavedQueryDAO) GetSavedQuery(ctx context.Context, tenantId, savedQueryId string) (*models.SavedQuery, error) {
+	if savedQueryId == "" {
+		return nil, errors.New("savedQueryId不能为空")
+	}
+
+	query := `SELECT * FROM HUB_SAVED_QUERY WHERE tenantId = ? AND savedQueryId = ? AND activeFlag = 'Y'`
+	args := []interface{}{tenantId, savedQueryId}
+
+	var savedQuery models.SavedQuery
+	err := dao.db.QueryOne(ctx, &savedQuery, query, args, true)
+	if err != nil {
+		if err == database.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, huberrors.WrapError(err, "查询保存的查询失败")
+	}
+	return &savedQuery, nil
+}
+
+// QuerySavedQueries 分页查询用户可见的保存查询：本人创建的和租户内共享的
+func (dao *SavedQueryDAO) QuerySavedQueries(ctx context.Context, tenantId, userId string, q *models.SavedQueryListRequest, page, pageSize int) ([]*models.SavedQuery, int, error) {
+	pagination := sqlutils.NewPaginationInfo(page, pageSize)
+	dbType := sqlutils.GetDatabaseType(dao.db)
+
+	whereClause := "WHERE tenantId = ? AND activeFlag = 'Y' AND (ownerUserId = ? OR shareFlag = 'Y')"
+	params := []interface{}{tenantId, userId}
+
+	if q != nil {
+		if !empty.IsEmpty(q.QueryName) {
+			whereClause += " AND queryName LIKE ?"
+			params = append(params, "%"+q.QueryName+"%")
+		}
+		if !empty.IsEmpty(q.QueryType) {
+			whereClause += " AND queryType = ?"
+			params = append(params, q.QueryType)
+		}
+		if !empty.IsEmpty(q.ScheduleEnabled) {
+			whereClause += " AND scheduleEnabled = ?"
+			params = append(params, q.ScheduleEnabled)
+		}
+	}
+
+	baseQuery := fmt.Sprintf(`
+		SELECT * FROM HUB_SAVED_QUERY
+		%s
+		ORDER BY editTime DESC
+	`, whereClause)
+
+	countQuery, err := sqlutils.BuildCountQuery(baseQuery)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "构建计数查询失败")
+	}
+
+	var countResult struct {
+		Count int `db:"COUNT(*)"`
+	}
+	if err := dao.db.QueryOne(ctx, &countResult, countQuery, params, true); err != nil {
+		return nil, 0, huberrors.WrapError(err, "查询保存的查询总数失败")
+	}
+	if countResult.Count == 0 {
+		return []*models.SavedQuery{}, 0, nil
+	}
+
+	paginatedQuery, paginationArgs, err := sqlutils.BuildPaginationQuery(dbType, baseQuery, pagination)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "构建分页查询失败")
+	}
+
+	allArgs := append(params, paginationArgs...)
+	var rows []*models.SavedQuery
+	if err := dao.db.Query(ctx, &rows, paginatedQuery, allArgs, true); err != nil {
+		return nil, 0, huberrors.WrapError(err, "查询保存的查询失败")
+	}
+	return rows, countResult.Count, nil
+}
+
+// ListScheduledQueries 查询所有启用定时报表的保存查询，用于启动时注册定时任务
+func (dao *SavedQueryDAO) ListScheduledQueries(ctx context.Context) ([]*models.SavedQuery, error) {
+	query := `SELECT * FROM HUB_SAVED_QUERY WHERE activeFlag = 'Y' AND scheduleEnabled = 'Y'`
+
+	var rows []*models.SavedQuery
+	if err := dao.db.Query(ctx, &rows, query, []interface{}{}, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询定时报表配置失败")
+	}
+	return rows, nil
+}
+
+// CreateSavedQuery 创建保存的查询
+func (dao *SavedQueryDAO) CreateSavedQuery(ctx context.Context, savedQuery *models.SavedQuery) error {
+	if savedQuery == nil {
+		return errors.New("savedQuery不能为空")
+	}
+	_, err := dao.db.Insert(ctx, "HUB_SAVED_QUERY", savedQuery, true)
+	if err != nil {
+		return huberrors.WrapError(err, "创建保存的查询失败")
+	}
+	return nil
+}
+
+// UpdateSavedQuery 更新保存的查询
+func (dao *SavedQueryDAO) UpdateSavedQuery(ctx context.Context, savedQuery *models.SavedQuery) error {
+	if savedQuery == nil {
+		return errors.New("savedQuery不能为空")
+	}
+	where := "tenantId = ? AND savedQueryId = ?"
+	args := []interface{}{savedQuery.TenantId, savedQuery.SavedQueryId}
+	_, err := dao.db.Update(ctx, "HUB_SAVED_QUERY", savedQuery, where, args, true, false)
+	if err != nil {
+		return huberrors.WrapError(err, "更新保存的查询失败")
+	}
+	return nil
+}
+
+// DeleteSavedQuery 删除保存的查询（逻辑删除），已生成的报表记录保留
+func (dao *SavedQueryDAO) DeleteSavedQuery(ctx context.Context, tenantId, savedQueryId, operatorId string) error {
+	if savedQueryId == "" {
+		return errors.New("savedQueryId不能为空")
+	}
+	// 使用参数化的时间值，兼容所有数据库类型
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, "UPDATE HUB_SAVED_QUERY SET activeFlag = 'N', scheduleEnabled = 'N', editWho = ?, editTime = ? WHERE tenantId = ? AND savedQueryId = ?",
+		[]interface{}{operatorId, now, tenantId, savedQueryId}, true)
+	if err != nil {
+		return huberrors.WrapError(err, "删除保存的查询失败")
+	}
+	return nil
+}
+
+// UpdateLastReport 更新最近一次报表生成结果
+func (dao *SavedQueryDAO) UpdateLastReport(ctx context.Context, tenantId, savedQueryId, status string, reportTime time.Time) error {
+	_, err := dao.db.Exec(ctx, "UPDATE HUB_SAVED_QUERY SET lastReportTime = ?, lastReportStatus = ? WHERE tenantId = ? AND savedQueryId = ?",
+		[]interface{}{reportTime, status, tenantId, savedQueryId}, true)
+	if err != nil {
+		return huberrors.WrapError(err, "更新最近报表状态失败")
+	}
+	return nil
+}
+
+// CreateReport 写入报表记录
+func (dao *SavedQueryDAO) CreateReport(ctx context.Context, report *models.QueryReport) error {
+	if report == nil {
+		return errors.New("report不能为空")
+	}
+	_, err := dao.db.Insert(ctx, "HUB_QUERY_REPORT", report, true)
+	if err != nil {
+		return huberrors.WrapError(err, "写入报表记录失败")
+	}
+	return nil
+}
+
+// GetReport 根据主键获取用户可见的报表记录，不存在或不可见时返回nil
+func (dao *SavedQueryDAO) GetReport(ctx context.Context, tenantId, userId, reportId string) (*models.QueryReport, error) {
+	if reportId == "" {
+		return nil, errors.New("reportId不能为空")
+	}
+
+	query := `SELECT * FROM HUB_QUERY_REPORT WHERE tenantId = ? AND reportId = ? AND activeFlag = 'Y' AND ` + visibleReportCondition
+	args := []interface{}{tenantId, reportId, tenantId, userId}
+
+	var report models.QueryReport
+	err := dao.db.QueryOne(ctx, &report, query, args, true)
+	if err != nil {
+		if err == database.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, huberrors.WrapError(err, "查询报表记录失败")
+	}
+	return &report, nil
+}
+
+// QueryReports 分页查询用户可见的报表记录，按开始时间倒序
+func (dao *SavedQueryDAO) QueryReports(ctx context.Context, tenantId, userId string, q *models.QueryReportListRequest, page, pageSize int) ([]*models.QueryReport, int, error) {
+	pagination := sqlutils.NewPaginationInfo(page, pageSize)
+	dbType := sqlutils.GetDatabaseType(dao.db)
+
+	whereClause := "WHERE tenantId = ? AND activeFlag = 'Y' AND " + visibleReportCondition
+	params := []interface{}{tenantId, tenantId, userId}
+
+	if q != nil {
+		if !empty.IsEmpty(q.SavedQueryId) {
+			whereClause += " AND savedQueryId = ?"
+			params = append(params, q.SavedQueryId)
+		}
+		if !empty.IsEmpty(q.ReportStatus) {
+			whereClause += " AND reportStatus = ?"
+			params = append(params, q.ReportStatus)
+		}
+	}
+
+	baseQuery := fmt.Sprintf(`
+		SELECT * FROM HUB_QUERY_REPORT
+		%s
+		ORDER BY startTime DESC
+	`, whereClause)
+
+	countQuery, err := sqlutils.BuildCountQuery(baseQuery)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "构建计数查询失败")
+	}
+
+	var countResult struct {
+		Count int `db:"COUNT(*)"`
+	}
+	if err := dao.db.QueryOne(ctx, &countResult, countQuery, params, true); err != nil {
+		return nil, 0, huberrors.WrapError(err, "查询报表记录总数失败")
+	}
+	if countResult.Count == 0 {
+		return []*models.QueryReport{}, 0, nil
+	}
+
+	paginatedQuery, paginationArgs, err := sqlutils.BuildPaginationQuery(dbType, baseQuery, pagination)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "构建分页查询失败")
+	}
+
+	allArgs := append(params, paginationArgs...)
+	var rows []*models.QueryReport
+	if err := dao.db.Query(ctx, &rows, paginatedQuery, allArgs, true); err != nil {
+		return nil, 0, huberrors.WrapError(err, "查询报表记录失败")
+	}
+	return rows, countResult.Count, nil
+}
+
+// ListExpiredReports 查询生成时间早于 before 的报表记录，用于清理过期报表文件
+func (dao *SavedQueryDAO) ListExpiredReports(ctx context.Context, tenantId string, before time.Time) ([]*models.QueryReport, error) {
+	query := `SELECT * FROM HUB_QUERY_REPORT WHERE tenantId = ? AND activeFlag = 'Y' AND startTime < ?`
+
+	var rows []*models.QueryReport
+	if err := dao.db.Query(ctx, &rows, query, []interface{}{tenantId, before}, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询过期报表记录失败")
+	}
+	return rows, nil
+}
+
+// DeleteReport 删除报表记录
+func (dao *SavedQueryDAO) DeleteReport(ctx context.Context, tenantId, reportId string) error {
+	if reportId == "" {
+		return errors.New("reportId不能为空")
+	}
+	_, err := dao.db.Exec(ctx, "DELETE FROM HUB_QUERY_REPORT WHERE tenantId = ? AND reportId = ?", []interface{}{tenantId, reportId}, true)
+	if err != nil {
+		return huberrors.WrapError(err, "删除报表记录失败")
+	}
+	return nil
+}
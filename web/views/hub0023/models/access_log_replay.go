@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+)
+
+// 访问日志重放任务状态
+const (
+	ReplayStatusRunning   = "RUNNING"   // 执行中
+	ReplayStatusCompleted = "COMPLETED" // 已完成
+	ReplayStatusCancelled = "CANCELLED" // 已取消
+)
+
+// 访问日志重放参数限制
+const (
+	ReplayDefaultRatePerSecond = 5     // 默认每秒请求数
+	ReplayMaxRatePerSecond     = 100   // 每秒请求数上限
+	ReplayDefaultConcurrency   = 1     // 默认并发数
+	ReplayMaxConcurrency       = 20    // 并发数上限
+	ReplayDefaultTimeoutMs     = 30000 // 默认单请求超时(毫秒)
+	ReplayMaxTraceIds          = 1000  // 单个任务最多重放的日志条数
+)
+
+// GatewayAccessLogReplayRequest 访问日志重放请求
+// 按链路追踪ID加载已记录的访问日志，将请求方法、路径、请求头和请求体按限速发送到指定环境或影子后端
+type GatewayAccessLogReplayRequest struct {
+	GatewayInstanceId string            `json:"gatewayInstanceId" form:"gatewayInstanceId"`  // 网关实例ID，用于解析日志存储查询方式
+	TraceIds          []string          `json:"traceIds" form:"traceIds" binding:"required"` // 要重放的链路追踪ID列表，按列表顺序重放
+	TargetBaseUrl     string            `json:"targetBaseUrl" form:"targetBaseUrl"`          // 目标环境地址，如 http://staging-gw:8080，请求路径和查询参数拼接在其后
+	RatePerSecond     int               `json:"ratePerSecond" form:"ratePerSecond"`          // 每秒请求数，默认5，最大100
+	Concurrency       int               `json:"concurrency" form:"concurrency"`              // 并发数，默认1，最大20
+	TimeoutMs         int               `json:"timeoutMs" form:"timeoutMs"`                  // 单请求超时(毫秒)，默认30000
+	StripHeaders      []string          `json:"stripHeaders" form:"stripHeaders"`            // 重放时移除的请求头，如 Authorization、Cookie
+	SetHeaders        map[string]string `json:"setHeaders" form:"setHeaders"`                // 重放时覆盖或追加的请求头
+}
+
+// GatewayAccessLogReplayTaskRequest 重放任务查询/取消请求
+type GatewayAccessLogReplayTaskRequest struct {
+	TaskId string `json:"taskId" form:"taskId" binding:"required"` // 重放任务ID
+}
+
+// GatewayAccessLogReplayTask 访问日志重放任务
+// 任务在服务端内存中异步执行，仅保留最近的任务用于查看进度和结果
+type GatewayAccessLogReplayTask struct {
+	TaskId        string     `json:"taskId"`        // 任务ID
+	TenantId      string     `json:"tenantId"`      // 租户ID
+	Status        string     `json:"status"`        // 任务状态(RUNNING,COMPLETED,CANCELLED)
+	TargetBaseUrl string     `json:"targetBaseUrl"` // 目标环境地址
+	RatePerSecond int        `json:"ratePerSecond"` // 每秒请求数
+	Concurrency   int        `json:"concurrency"`   // 并发数
+	Total         int        `json:"total"`         // 重放总数
+	Completed     int        `json:"completed"`     // 已完成数
+	Succeeded     int        `json:"succeeded"`     // 与原始状态码一致的数量
+	Failed        int        `json:"failed"`        // 请求失败或状态码不一致的数量
+	StartTime     time.Time  `json:"startTime"`     // 开始时间
+	EndTime       *time.Time `json:"endTime"`       // 结束时间
+	AddWho        string     `json:"addWho"`        // 发起人ID
+
+	Results []GatewayAccessLogReplayResult `json:"results,omitempty"` // 各条日志的重放结果，按完成顺序排列
+}
+
+// GatewayAccessLogReplayResult 单条访问日志的重放结果
+type GatewayAccessLogReplayResult struct {
+	TraceId            string `json:"traceId"`                // 原始链路追踪ID
+	Method             string `json:"method"`                 // 请求方法
+	Url                string `json:"url"`                    // 重放请求地址
+	OriginalStatusCode int    `json:"originalStatusCode"`     // 原始网关响应状态码
+	OriginalDurationMs int    `json:"originalDurationMs"`     // 原始总处理时间(毫秒)
+	StatusCode         int    `json:"statusCode"`             // 重放响应状态码，请求失败时为0
+	DurationMs         int64  `json:"durationMs"`             // 重放耗时(毫秒)
+	ResponseSize       int64  `json:"responseSize"`           // 重放响应大小(字节)
+	StatusMatched      bool   `json:"statusMatched"`          // 状态码是否与原始一致
+	ErrorMessage       string `json:"errorMessage,omitempty"` // 加载日志或请求失败原因
+}
@@ -0,0 +1,96 @@
+package models
+
+// GatewayAnalyticsQueryRequest 访问日志分析查询请求
+// 分析接口直接在 HUB_GW_ACCESS_LOG 上做 GROUP BY 聚合，前端无需拉取原始日志
+type GatewayAnalyticsQueryRequest struct {
+	// 必填字段
+	StartTime string `json:"startTime" form:"startTime" binding:"required"` // 开始时间（必填）
+	EndTime   string `json:"endTime" form:"endTime" binding:"required"`     // 结束时间（必填）
+
+	// 可选过滤条件
+	GatewayInstanceId   string `json:"gatewayInstanceId" form:"gatewayInstanceId"`     // 网关实例ID
+	RouteConfigId       string `json:"routeConfigId" form:"routeConfigId"`             // 路由配置ID
+	ServiceDefinitionId string `json:"serviceDefinitionId" form:"serviceDefinitionId"` // 服务定义ID
+	RequestPath         string `json:"requestPath" form:"requestPath"`                 // 请求路径（支持模糊匹配）
+
+	// 聚合参数
+	TimeGranularity TimeGranularity `json:"timeGranularity" form:"timeGranularity"` // 请求趋势的时间粒度，默认按分钟
+	TopN            int             `json:"topN" form:"topN"`                       // 排行类分析返回数量，默认10，最大100
+	MinRequests     int             `json:"minRequests" form:"minRequests"`         // 慢路由排行的最小请求数，过滤样本过少的路由，默认1
+
+	// 内部使用字段
+	TenantId string `json:"tenantId" form:"tenantId"` // 租户ID（从上下文获取）
+}
+
+// MonitoringFilter 转换为监控查询条件，复用监控查询的过滤条件构建逻辑
+func (r *GatewayAnalyticsQueryRequest) MonitoringFilter() *GatewayMonitoringQueryRequest {
+	return &GatewayMonitoringQueryRequest{
+		StartTime:           r.StartTime,
+		EndTime:             r.EndTime,
+		TimeGranularity:     r.TimeGranularity,
+		GatewayInstanceId:   r.GatewayInstanceId,
+		RouteConfigId:       r.RouteConfigId,
+		ServiceDefinitionId: r.ServiceDefinitionId,
+		RequestPath:         r.RequestPath,
+		TenantId:            r.TenantId,
+	}
+}
+
+// GatewayAnalyticsRequestTrend 按时间分组的请求量
+type GatewayAnalyticsRequestTrend struct {
+	// 时间戳（Unix毫秒时间戳）
+	// 抽取逻辑：toStartOfMinute/Hour/Day(gatewayStartProcessingTime)
+	Timestamp int64 `json:"timestamp" form:"timestamp"`
+
+	// 请求数
+	TotalRequests int64 `json:"totalRequests" form:"totalRequests"`
+
+	// 客户端错误数，gatewayStatusCode 4xx
+	ClientErrors int64 `json:"clientErrors" form:"clientErrors"`
+
+	// 服务端错误数，gatewayStatusCode >= 500
+	ServerErrors int64 `json:"serverErrors" form:"serverErrors"`
+
+	// 每秒请求数，请求数 / 时间粒度秒数
+	RequestsPerSecond float64 `json:"requestsPerSecond" form:"requestsPerSecond"`
+
+	// 平均响应时间(毫秒)
+	AvgResponseTimeMs float64 `json:"avgResponseTimeMs" form:"avgResponseTimeMs"`
+
+	// 95%响应时间(毫秒)
+	P95ResponseTimeMs int `json:"p95ResponseTimeMs" form:"p95ResponseTimeMs"`
+}
+
+// GatewayAnalyticsSlowRoute 慢路由排行
+type GatewayAnalyticsSlowRoute struct {
+	RouteConfigId     string  `json:"routeConfigId" form:"routeConfigId"`         // 路由配置ID
+	RouteName         string  `json:"routeName" form:"routeName"`                 // 路由名称
+	ServiceName       string  `json:"serviceName" form:"serviceName"`             // 服务名称
+	RequestCount      int64   `json:"requestCount" form:"requestCount"`           // 请求数
+	ErrorRate         float64 `json:"errorRate" form:"errorRate"`                 // 错误率(%)，gatewayStatusCode >= 400 或 < 200
+	AvgResponseTimeMs float64 `json:"avgResponseTimeMs" form:"avgResponseTimeMs"` // 平均响应时间(毫秒)
+	P95ResponseTimeMs int     `json:"p95ResponseTimeMs" form:"p95ResponseTimeMs"` // 95%响应时间(毫秒)，排序依据
+	P99ResponseTimeMs int     `json:"p99ResponseTimeMs" form:"p99ResponseTimeMs"` // 99%响应时间(毫秒)
+	MaxResponseTimeMs int     `json:"maxResponseTimeMs" form:"maxResponseTimeMs"` // 最大响应时间(毫秒)
+}
+
+// GatewayAnalyticsErrorCode 按错误码的错误分布
+// 未设置 errorCode 的 4xx/5xx 请求按 HTTP_状态码 归类
+type GatewayAnalyticsErrorCode struct {
+	ErrorCode      string  `json:"errorCode" form:"errorCode"`           // 错误码
+	Count          int64   `json:"count" form:"count"`                   // 出现次数
+	Percentage     float64 `json:"percentage" form:"percentage"`         // 占全部错误的百分比
+	AffectedRoutes int64   `json:"affectedRoutes" form:"affectedRoutes"` // 涉及的路由数
+	SampleMessage  string  `json:"sampleMessage" form:"sampleMessage"`   // 示例错误信息
+	LastOccurTime  int64   `json:"lastOccurTime" form:"lastOccurTime"`   // 最近出现时间（Unix毫秒时间戳）
+}
+
+// GatewayAnalyticsClientIP 客户端IP请求排行
+type GatewayAnalyticsClientIP struct {
+	ClientIpAddress string  `json:"clientIpAddress" form:"clientIpAddress"` // 客户端IP
+	RequestCount    int64   `json:"requestCount" form:"requestCount"`       // 请求数
+	ErrorCount      int64   `json:"errorCount" form:"errorCount"`           // 错误数，gatewayStatusCode >= 400
+	ErrorRate       float64 `json:"errorRate" form:"errorRate"`             // 错误率(%)
+	RouteCount      int64   `json:"routeCount" form:"routeCount"`           // 访问的路由数
+	LastRequestTime int64   `json:"lastRequestTime" form:"lastRequestTime"` // 最近请求时间（Unix毫秒时间戳）
+}
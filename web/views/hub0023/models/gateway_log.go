@@ -49,6 +49,7 @@ type GatewayAccessLog struct {
 	TotalProcessingTimeMs   int `json:"totalProcessingTimeMs" form:"totalProcessingTimeMs" query:"totalProcessingTimeMs" db:"totalProcessingTimeMs"`         // 总处理时间(毫秒，当gatewayFinishedProcessingTime为空时为NULL)
 	GatewayProcessingTimeMs int `json:"gatewayProcessingTimeMs" form:"gatewayProcessingTimeMs" query:"gatewayProcessingTimeMs" db:"gatewayProcessingTimeMs"` // 网关处理时间(毫秒，当gatewayFinishedProcessingTime为空时为NULL)
 	BackendResponseTimeMs   int `json:"backendResponseTimeMs" form:"backendResponseTimeMs" query:"backendResponseTimeMs" db:"backendResponseTimeMs"`         // 后端服务响应时间(毫秒，可选)
+	QueueTimeMs             int `json:"queueTimeMs" form:"queueTimeMs" query:"queueTimeMs" db:"queueTimeMs"`                                                 // 限流排队等待时间(毫秒，已计入网关处理时间)
 
 	// 响应信息
 	GatewayStatusCode int    `json:"gatewayStatusCode" form:"gatewayStatusCode" query:"gatewayStatusCode" db:"gatewayStatusCode"` // 网关响应状态码
@@ -204,6 +205,10 @@ type GatewayAccessLogQueryRequest struct {
 	PageIndex int `json:"pageIndex" form:"pageIndex" binding:"min=1"`       // 页码
 	PageSize  int `json:"pageSize" form:"pageSize" binding:"min=1,max=100"` // 每页数量
 
+	// 游标分页：按网关开始处理时间倒序翻页，不统计总数，深度翻页时性能与页码无关
+	CursorPaging bool   `json:"cursorPaging" form:"cursorPaging"` // 是否使用游标分页
+	Cursor       string `json:"cursor" form:"cursor"`             // 上一页返回的游标，为空表示第一页
+
 	// 基础查询条件
 	TenantId            string `json:"tenantId" form:"tenantId"`                       // 租户ID
 	TraceId             string `json:"traceId" form:"traceId"`                         // 链路追踪ID
@@ -0,0 +1,13 @@
+package models
+
+// LogPartitionQueryRequest ClickHouse 日志表分区查询请求
+type LogPartitionQueryRequest struct {
+	TableName string `json:"tableName" form:"tableName" binding:"required"` // 日志表名，HUB_GW_ACCESS_LOG 或 HUB_GW_BACKEND_TRACE_LOG
+}
+
+// LogPartitionDropRequest ClickHouse 日志表分区强制删除请求
+// 删除分区日期早于 BeforeDate 的全部分区，分区删除作用于表中所有租户的数据
+type LogPartitionDropRequest struct {
+	TableName  string `json:"tableName" form:"tableName" binding:"required"`   // 日志表名，HUB_GW_ACCESS_LOG 或 HUB_GW_BACKEND_TRACE_LOG
+	BeforeDate string `json:"beforeDate" form:"beforeDate" binding:"required"` // 分区日期上界（不包含），格式 2006-01-02，不能晚于当天
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"time"
+)
+
+// 保存查询的查询类型
+const (
+	SavedQueryTypeAccessLog       = "ACCESS_LOG"       // 网关访问日志列表
+	SavedQueryTypeMonitorOverview = "MONITOR_OVERVIEW" // 网关监控概览
+	SavedQueryTypeMonitorChart    = "MONITOR_CHART"    // 网关监控图表数据
+)
+
+// 报表触发方式
+const (
+	ReportTriggerSchedule = "SCHEDULE" // 定时触发
+	ReportTriggerManual   = "MANUAL"   // 手动触发
+)
+
+// 报表生成状态
+const (
+	ReportStatusSuccess = "SUCCESS" // 成功
+	ReportStatusFailed  = "FAILED"  // 失败
+)
+
+// 报表邮件发送状态
+const (
+	ReportEmailNone    = "NONE"    // 未配置收件人，不发送
+	ReportEmailSuccess = "SUCCESS" // 发送成功
+	ReportEmailFailed  = "FAILED"  // 发送失败
+)
+
+// SavedQuery 保存的查询条件，对应表 HUB_SAVED_QUERY
+// 用户将访问日志或监控查询的过滤条件保存后可直接复用，配置Cron表达式后由定时器周期生成报表
+type SavedQuery struct {
+	// 主键字段
+	TenantId     string `json:"tenantId" form:"tenantId" db:"tenantId"`             // 租户ID
+	SavedQueryId string `json:"savedQueryId" form:"savedQueryId" db:"savedQueryId"` // 保存查询ID
+
+	// 查询信息
+	QueryName        string `json:"queryName" form:"queryName" db:"queryName"`                      // 查询名称
+	QueryType        string `json:"queryType" form:"queryType" db:"queryType"`                      // 查询类型(ACCESS_LOG,MONITOR_OVERVIEW,MONITOR_CHART)
+	QueryParams      string `json:"queryParams" form:"queryParams" db:"queryParams"`                // 查询过滤条件，JSON格式，与对应查询接口的参数一致
+	TimeRangeMinutes int    `json:"timeRangeMinutes" form:"timeRangeMinutes" db:"timeRangeMinutes"` // 相对时间范围(分钟)，大于0时执行时按当前时间重新计算startTime/endTime
+	OwnerUserId      string `json:"ownerUserId" form:"ownerUserId" db:"ownerUserId"`                // 所属用户ID，定时执行时以该用户身份查询
+	ShareFlag        string `json:"shareFlag" form:"shareFlag" db:"shareFlag"`                      // 是否共享给租户内其他用户(Y是,N否)
+
+	// 报表调度配置
+	ScheduleEnabled string `json:"scheduleEnabled" form:"scheduleEnabled" db:"scheduleEnabled"` // 是否启用定时报表(Y是,N否)
+	CronExpression  string `json:"cronExpression" form:"cronExpression" db:"cronExpression"`    // Cron表达式
+	ReportFormat    string `json:"reportFormat" form:"reportFormat" db:"reportFormat"`          // 报表格式(csv,xlsx)
+	ReportColumns   string `json:"reportColumns" form:"reportColumns" db:"reportColumns"`       // 报表列，逗号分隔或JSON数组，为空时导出全部字段
+	MaxRows         int    `json:"maxRows" form:"maxRows" db:"maxRows"`                         // 报表最大行数，0表示使用服务端上限
+	EmailChannel    string `json:"emailChannel" form:"emailChannel" db:"emailChannel"`          // 邮件告警渠道名称，为空时使用默认渠道
+	EmailTo         string `json:"emailTo" form:"emailTo" db:"emailTo"`                         // 报表收件人，逗号分隔，为空时仅生成报表记录
+
+	// 最近一次报表
+	LastReportTime   *time.Time `json:"lastReportTime" form:"lastReportTime" db:"lastReportTime"`       // 最近报表生成时间
+	LastReportStatus string     `json:"lastReportStatus" form:"lastReportStatus" db:"lastReportStatus"` // 最近报表状态(SUCCESS,FAILED)
+
+	// 通用字段
+	AddTime        time.Time `json:"addTime" form:"addTime" db:"addTime"`                      // 创建时间
+	AddWho         string    `json:"addWho" form:"addWho" db:"addWho"`                         // 创建人ID
+	EditTime       time.Time `json:"editTime" form:"editTime" db:"editTime"`                   // 最后修改时间
+	EditWho        string    `json:"editWho" form:"editWho" db:"editWho"`                      // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" form:"oprSeqFlag" db:"oprSeqFlag"`             // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" form:"currentVersion" db:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" form:"activeFlag" db:"activeFlag"`             // 活动状态标记(N非活动,Y活动)
+	NoteText       string    `json:"noteText" form:"noteText" db:"noteText"`                   // 备注信息
+}
+
+// TableName 返回表名
+func (SavedQuery) TableName() string {
+	return "HUB_SAVED_QUERY"
+}
+
+// QueryReport 保存查询生成的报表记录，对应表 HUB_QUERY_REPORT
+type QueryReport struct {
+	// 主键字段
+	TenantId string `json:"tenantId" form:"tenantId" db:"tenantId"` // 租户ID
+	ReportId string `json:"reportId" form:"reportId" db:"reportId"` // 报表ID
+
+	// 关联信息
+	SavedQueryId string `json:"savedQueryId" form:"savedQueryId" db:"savedQueryId"` // 保存查询ID
+	QueryName    string `json:"queryName" form:"queryName" db:"queryName"`          // 查询名称(冗余字段,便于查询显示)
+	TriggerType  string `json:"triggerType" form:"triggerType" db:"triggerType"`    // 触发方式(SCHEDULE,MANUAL)
+
+	// 生成结果
+	ReportStatus string `json:"reportStatus" form:"reportStatus" db:"reportStatus"` // 报表状态(SUCCESS,FAILED)
+	RowCount     int    `json:"rowCount" form:"rowCount" db:"rowCount"`             // 报表行数
+	ReportFormat string `json:"reportFormat" form:"reportFormat" db:"reportFormat"` // 报表格式(csv,xlsx)
+	FileName     string `json:"fileName" form:"fileName" db:"fileName"`             // 报表文件名
+	FilePath     string `json:"-" db:"filePath"`                                    // 报表文件存储路径，不返回给前端
+	FileSize     int64  `json:"fileSize" form:"fileSize" db:"fileSize"`             // 报表文件大小(字节)
+	EmailStatus  string `json:"emailStatus" form:"emailStatus" db:"emailStatus"`    // 邮件发送状态(NONE,SUCCESS,FAILED)
+	ErrorMessage string `json:"errorMessage" form:"errorMessage" db:"errorMessage"` // 错误信息
+
+	// 执行时间
+	StartTime  time.Time  `json:"startTime" form:"startTime" db:"startTime"`    // 开始时间
+	EndTime    *time.Time `json:"endTime" form:"endTime" db:"endTime"`          // 结束时间
+	DurationMs int64      `json:"durationMs" form:"durationMs" db:"durationMs"` // 耗时(毫秒)
+
+	// 通用字段
+	AddTime        time.Time `json:"addTime" form:"addTime" db:"addTime"`                      // 创建时间
+	AddWho         string    `json:"addWho" form:"addWho" db:"addWho"`                         // 创建人ID
+	EditTime       time.Time `json:"editTime" form:"editTime" db:"editTime"`                   // 最后修改时间
+	EditWho        string    `json:"editWho" form:"editWho" db:"editWho"`                      // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" form:"oprSeqFlag" db:"oprSeqFlag"`             // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" form:"currentVersion" db:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" form:"activeFlag" db:"activeFlag"`             // 活动状态标记(N非活动,Y活动)
+}
+
+// TableName 返回表名
+func (QueryReport) TableName() string {
+	return "HUB_QUERY_REPORT"
+}
+
+// SavedQueryListRequest 保存查询列表请求
+// 说明：分页参数通过 request.GetPaginationParams 读取，这里仅放筛选条件
+type SavedQueryListRequest struct {
+	QueryName       string `json:"queryName" form:"queryName"`             // 查询名称（模糊）
+	QueryType       string `json:"queryType" form:"queryType"`             // 查询类型（精确）
+	ScheduleEnabled string `json:"scheduleEnabled" form:"scheduleEnabled"` // 是否启用定时报表
+}
+
+// QueryReportListRequest 报表记录列表请求
+type QueryReportListRequest struct {
+	SavedQueryId string `json:"savedQueryId" form:"savedQueryId"` // 保存查询ID
+	ReportStatus string `json:"reportStatus" form:"reportStatus"` // 报表状态
+}
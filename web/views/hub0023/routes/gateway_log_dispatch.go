@@ -1,6 +1,7 @@
 package gatewaylogroutes
 
 import (
+	"context"
 	"strings"
 
 	"gateway/pkg/database"
@@ -193,3 +194,43 @@ func dispatchGatewayMonitoringChartData(
 		}
 	}
 }
+
+// dispatchGatewayAnalytics 按实例日志配置分发访问日志分析查询。
+// 分析依赖 ClickHouse 的聚合能力，实例日志未写入 ClickHouse 时返回错误。
+func dispatchGatewayAnalytics(
+	db database.Database,
+	mongoCtl *controllers.MongoQueryController,
+	chCtl *controllers.ClickHouseQueryController,
+	handler func(*controllers.ClickHouseQueryController, *gin.Context),
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		gid := strings.TrimSpace(request.GetParam(c, "gatewayInstanceId"))
+		tenantID := request.GetTenantID(c)
+		resolved := dao.ResolveGatewayLogQueryType(c.Request.Context(), db, tenantID, gid)
+		if pickEffectiveGatewayLogQueryType(resolved, mongoCtl, chCtl) != "clickhouse" {
+			response.ErrorJSON(c, "访问日志分析仅支持日志输出到ClickHouse的网关实例", constants.ED00009)
+			return
+		}
+		handler(chCtl, c)
+	}
+}
+
+// resolveAccessLogLoader 按实例日志配置选择访问日志重放使用的日志加载函数。
+func resolveAccessLogLoader(
+	db database.Database,
+	mongoCtl *controllers.MongoQueryController,
+	chCtl *controllers.ClickHouseQueryController,
+	dbCtl *controllers.GatewayLogController,
+) controllers.AccessLogLoaderResolver {
+	return func(ctx context.Context, tenantID, gatewayInstanceID string) controllers.AccessLogLoader {
+		resolved := dao.ResolveGatewayLogQueryType(ctx, db, tenantID, strings.TrimSpace(gatewayInstanceID))
+		switch pickEffectiveGatewayLogQueryType(resolved, mongoCtl, chCtl) {
+		case "mongo":
+			return mongoCtl.GetAccessLogByKey
+		case "clickhouse":
+			return chCtl.GetAccessLogByKey
+		default:
+			return dbCtl.GetAccessLogByKey
+		}
+	}
+}
@@ -1,11 +1,16 @@
 package gatewaylogroutes
 
 import (
+	"context"
+
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
 	"gateway/pkg/mongo/factory"
+	"gateway/web/middleware/permission"
 	"gateway/web/routes"
+	"gateway/web/utils/export"
 	"gateway/web/views/hub0023/controllers"
+	"gateway/web/views/hub0023/models"
 
 	"github.com/gin-gonic/gin"
 )
@@ -57,14 +62,78 @@ func Init(router *gin.Engine, db database.Database) {
 
 		// 按请求中的网关实例（缺省时取租户下实例列表第一条）关联的日志配置 outputTargets 选择查询后端
 		protectedGroup.POST("/gateway-log/query", dispatchGatewayLogQuery(db, mongoController, clickhouseController, gatewayLogController))
+		// 导出使用与查询相同的过滤参数，format=xlsx/csv
+		protectedGroup.POST("/gateway-log/export", export.Handler("GatewayAccessLog", dispatchGatewayLogQuery(db, mongoController, clickhouseController, gatewayLogController)))
 		protectedGroup.POST("/gateway-log/get", dispatchGatewayLogGet(db, mongoController, clickhouseController, gatewayLogController))
 		protectedGroup.POST("/gateway-log/access-detail", dispatchGatewayLogAccessDetail(db, mongoController, clickhouseController, gatewayLogController))
 		protectedGroup.POST("/gateway-log/count", dispatchGatewayLogCount(db, mongoController, clickhouseController))
 		protectedGroup.POST("/gateway-log/monitoring/overview", dispatchGatewayMonitoringOverview(db, mongoController, clickhouseController, gatewayLogController))
 		protectedGroup.POST("/gateway-log/monitoring/chart-data", dispatchGatewayMonitoringChartData(db, mongoController, clickhouseController, gatewayLogController))
 
+		// 访问日志分析：在 ClickHouse 上按时间、路由、错误码、客户端IP聚合，结果按时间窗口缓存
+		protectedGroup.POST("/gateway-log/analytics/request-trend", dispatchGatewayAnalytics(db, mongoController, clickhouseController, (*controllers.ClickHouseQueryController).GetAnalyticsRequestTrend))
+		protectedGroup.POST("/gateway-log/analytics/slow-routes", dispatchGatewayAnalytics(db, mongoController, clickhouseController, (*controllers.ClickHouseQueryController).GetAnalyticsSlowRoutes))
+		protectedGroup.POST("/gateway-log/analytics/error-breakdown", dispatchGatewayAnalytics(db, mongoController, clickhouseController, (*controllers.ClickHouseQueryController).GetAnalyticsErrorBreakdown))
+		protectedGroup.POST("/gateway-log/analytics/client-ips", dispatchGatewayAnalytics(db, mongoController, clickhouseController, (*controllers.ClickHouseQueryController).GetAnalyticsTopClientIPs))
+
+		// 实时指标：由访问日志写入流程在内存中聚合，WebSocket周期推送，无需轮询日志存储
+		liveMetricsController := controllers.NewLiveMetricsController()
+		protectedGroup.GET("/gateway-log/monitoring/live", liveMetricsController.Stream)
+		protectedGroup.POST("/gateway-log/monitoring/live-snapshot", liveMetricsController.GetSnapshot)
+
 		protectedGroup.POST("/gateway-log/reset", gatewayLogController.Reset)
 
+		// 查询和分析类接口按接口名无法推导，显式登记为只校验模块权限
+		for _, path := range []string{
+			"/gateway-log/access-detail",
+			"/gateway-log/monitoring/overview",
+			"/gateway-log/monitoring/chart-data",
+			"/gateway-log/monitoring/live-snapshot",
+			"/gateway-log/analytics/request-trend",
+			"/gateway-log/analytics/slow-routes",
+			"/gateway-log/analytics/error-breakdown",
+			"/gateway-log/analytics/client-ips",
+		} {
+			permission.RegisterRoutePermission("POST", APIPrefix+path, "")
+		}
+		// 重发、删除分区和访问日志重放有独立的按钮编码
+		permission.RegisterRoutePermission("POST", APIPrefix+"/gateway-log/reset", ModuleName+":reset")
+		permission.RegisterRoutePermission("POST", APIPrefix+"/gateway-log/partition/drop", ModuleName+":dropPartition")
+		permission.RegisterRoutePermission("POST", APIPrefix+"/gateway-log/replay/start", ModuleName+":replay")
+		permission.RegisterRoutePermission("POST", APIPrefix+"/gateway-log/replay/cancel", ModuleName+":replay")
+
+		// ClickHouse 日志表分区管理：查看分区并强制删除旧分区，仅在 clickhouse_main 就绪时注册
+		if clickhouseController != nil {
+			protectedGroup.POST("/gateway-log/partition/query", clickhouseController.QueryLogPartitions)
+			protectedGroup.POST("/gateway-log/partition/drop", clickhouseController.DropLogPartitions)
+		}
+
+		// 访问日志重放：按日志存储分发加载选中的访问日志，限速重放到指定环境或影子后端
+		replayController := controllers.NewAccessLogReplayController(resolveAccessLogLoader(db, mongoController, clickhouseController, gatewayLogController))
+		protectedGroup.POST("/gateway-log/replay/start", replayController.StartReplay)
+		protectedGroup.POST("/gateway-log/replay/get", replayController.GetReplayTask)
+		protectedGroup.POST("/gateway-log/replay/cancel", replayController.CancelReplayTask)
+		protectedGroup.POST("/gateway-log/replay/query", replayController.QueryReplayTasks)
+
+		// 保存的查询条件和报表：报表使用与页面相同的查询分发逻辑，支持手动生成和按Cron定时生成
+		reporter := controllers.NewSavedQueryReporter(db, map[string]gin.HandlerFunc{
+			models.SavedQueryTypeAccessLog:       dispatchGatewayLogQuery(db, mongoController, clickhouseController, gatewayLogController),
+			models.SavedQueryTypeMonitorOverview: dispatchGatewayMonitoringOverview(db, mongoController, clickhouseController, gatewayLogController),
+			models.SavedQueryTypeMonitorChart:    dispatchGatewayMonitoringChartData(db, mongoController, clickhouseController, gatewayLogController),
+		})
+		if err := reporter.LoadSchedules(context.Background()); err != nil {
+			logger.Error("加载定时报表失败", "error", err)
+		}
+		savedQueryController := controllers.NewSavedQueryController(db, reporter)
+		protectedGroup.POST("/saved-query/query", savedQueryController.QuerySavedQueries)
+		protectedGroup.POST("/saved-query/get", savedQueryController.GetSavedQuery)
+		protectedGroup.POST("/saved-query/add", savedQueryController.AddSavedQuery)
+		protectedGroup.POST("/saved-query/update", savedQueryController.UpdateSavedQuery)
+		protectedGroup.POST("/saved-query/delete", savedQueryController.DeleteSavedQuery)
+		protectedGroup.POST("/saved-query/export-report", savedQueryController.ExportSavedQueryReport)
+		protectedGroup.POST("/query-report/query", savedQueryController.QueryReports)
+		protectedGroup.POST("/query-report/export", savedQueryController.DownloadReport)
+
 		// 公开API (如果需要网关直接写入日志的话，可以考虑公开部分API)
 		// 但为了安全考虑，建议通过内部服务调用或消息队列来写入日志
 		// publicGroup := gatewayLogGroup.Group("")
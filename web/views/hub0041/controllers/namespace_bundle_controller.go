@@ -0,0 +1,364 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"gateway/internal/servicecenter"
+	"gateway/internal/servicecenter/bundle"
+	internaldao "gateway/internal/servicecenter/dao"
+	pb "gateway/internal/servicecenter/server/proto"
+	"gateway/internal/servicecenter/types"
+	"gateway/pkg/logger"
+	"gateway/pkg/utils/random"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0041/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBundleSize 导入文件大小上限
+const maxBundleSize = 32 << 20
+
+// ExportNamespace 导出命名空间
+// @Summary 导出命名空间
+// @Description 将命名空间下的服务、分组和配置导出为带版本号的 JSON/YAML 文件，用于环境间提升
+// @Tags 命名空间管理
+// @Produce octet-stream
+// @Param namespaceId query string true "命名空间ID"
+// @Param format query string false "导出格式(json/yaml)" default(json)
+// @Success 200 {file} file
+// @Router /api/hub0041/exportNamespace [post]
+func (c *NamespaceController) ExportNamespace(ctx *gin.Context) {
+	namespaceId := request.GetParam(ctx, "namespaceId")
+	if namespaceId == "" {
+		response.ErrorJSON(ctx, "命名空间ID不能为空", constants.ED00007)
+		return
+	}
+	format := strings.ToLower(request.GetParam(ctx, "format"))
+	if format == "" {
+		format = bundle.FormatJSON
+	}
+	if format != bundle.FormatJSON && format != bundle.FormatYAML {
+		response.ErrorJSON(ctx, "不支持的导出格式: "+format, constants.ED00006)
+		return
+	}
+	tenantId := request.GetTenantID(ctx)
+
+	namespace, err := c.namespaceDAO.GetNamespaceById(ctx, tenantId, namespaceId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取命名空间详情失败", err)
+		response.ErrorJSON(ctx, "获取命名空间详情失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if namespace == nil {
+		response.ErrorJSON(ctx, "命名空间不存在", constants.ED00008)
+		return
+	}
+
+	services, configs, err := c.loadNamespaceContent(ctx, tenantId, namespaceId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询命名空间服务和配置失败", err)
+		response.ErrorJSON(ctx, err.Error(), constants.ED00009)
+		return
+	}
+
+	b := bundle.Build(namespace, services, configs)
+	b.ExportBy = request.GetOperatorID(ctx)
+	data, err := bundle.Marshal(b, format)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "生成导出文件失败", err)
+		response.ErrorJSON(ctx, "生成导出文件失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	logger.InfoWithTrace(ctx, "命名空间导出成功",
+		"namespaceId", namespaceId,
+		"format", format,
+		"services", len(b.Services),
+		"configs", len(b.Configs))
+
+	filename := fmt.Sprintf("Namespace_%s_%s.%s", namespaceId, time.Now().Format("20060102150405"), format)
+	contentType := "application/json"
+	if format == bundle.FormatYAML {
+		contentType = "application/x-yaml"
+	}
+	ctx.Writer.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`, filename, url.PathEscape(filename)))
+	ctx.Writer.Header().Set("Cache-Control", "no-cache")
+	ctx.Data(200, contentType, data)
+}
+
+// ImportNamespace 导入命名空间
+// @Summary 导入命名空间
+// @Description 导入命名空间导出文件：与目标命名空间比对生成变更计划，dryRun 为 true（默认）时只返回计划，为 false 时新增和覆盖服务、配置（只存在于目标命名空间的数据保留不删除）
+// @Tags 命名空间管理
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "命名空间导出文件(JSON/YAML)"
+// @Param targetNamespaceId formData string false "目标命名空间ID，默认与导出包来源相同"
+// @Param dryRun formData string false "是否只预览变更(true/false)" default(true)
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0041/importNamespace [post]
+func (c *NamespaceController) ImportNamespace(ctx *gin.Context) {
+	tenantId := request.GetTenantID(ctx)
+	operatorId := request.GetOperatorID(ctx)
+
+	file, _, err := ctx.Request.FormFile("file")
+	if err != nil {
+		response.ErrorJSON(ctx, "读取上传文件失败: "+err.Error(), constants.ED00006)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxBundleSize+1))
+	if err != nil {
+		response.ErrorJSON(ctx, "读取上传文件失败: "+err.Error(), constants.ED00006)
+		return
+	}
+	if len(data) > maxBundleSize {
+		response.ErrorJSON(ctx, "导入文件过大", constants.ED00006)
+		return
+	}
+
+	b, err := bundle.Parse(data)
+	if err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
+		return
+	}
+
+	targetNamespaceId := request.GetParam(ctx, "targetNamespaceId")
+	if targetNamespaceId == "" {
+		targetNamespaceId = b.Namespace.NamespaceId
+	}
+	if targetNamespaceId == "" {
+		response.ErrorJSON(ctx, "目标命名空间ID不能为空", constants.ED00007)
+		return
+	}
+	// 默认只预览，显式传入 dryRun=false 才执行导入
+	dryRun := !strings.EqualFold(request.GetParam(ctx, "dryRun"), "false")
+
+	namespace, err := c.namespaceDAO.GetNamespaceById(ctx, tenantId, targetNamespaceId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取命名空间详情失败", err)
+		response.ErrorJSON(ctx, "获取命名空间详情失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if namespace == nil {
+		response.ErrorJSON(ctx, "目标命名空间不存在，请先创建命名空间: "+targetNamespaceId, constants.ED00008)
+		return
+	}
+
+	services, configs, err := c.loadNamespaceContent(ctx, tenantId, targetNamespaceId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询命名空间服务和配置失败", err)
+		response.ErrorJSON(ctx, err.Error(), constants.ED00009)
+		return
+	}
+
+	plan := bundle.Diff(b, targetNamespaceId, services, configs)
+	if dryRun {
+		response.SuccessJSON(ctx, plan, constants.SD00002)
+		return
+	}
+
+	c.applyBundle(ctx.Request.Context(), namespace, b, plan, services, configs, operatorId)
+
+	logger.InfoWithTrace(ctx, "命名空间导入完成",
+		"sourceNamespaceId", plan.SourceNamespaceId,
+		"targetNamespaceId", targetNamespaceId,
+		"create", plan.Summary.Create,
+		"update", plan.Summary.Update,
+		"failed", plan.Summary.Failed)
+
+	response.SuccessJSON(ctx, plan, constants.SD00001)
+}
+
+// loadNamespaceContent 查询命名空间下的服务和配置
+func (c *NamespaceController) loadNamespaceContent(ctx context.Context, tenantId, namespaceId string) ([]*types.Service, []*types.ConfigData, error) {
+	services, err := internaldao.NewServiceDAO(c.db).ListServices(ctx, tenantId, namespaceId)
+	if err != nil {
+		return nil, nil, err
+	}
+	configs, err := internaldao.NewConfigDAO(c.db).ListConfigs(ctx, tenantId, namespaceId, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	return services, configs, nil
+}
+
+// applyBundle 按变更计划执行导入，逐项记录执行结果，单项失败不影响其他项
+func (c *NamespaceController) applyBundle(ctx context.Context, namespace *models.Namespace, b *bundle.Bundle, plan *bundle.Plan,
+	services []*types.Service, configs []*types.ConfigData, operatorId string) {
+	plan.DryRun = false
+
+	serviceSpecs := make(map[string]*bundle.ServiceSpec, len(b.Services))
+	for _, spec := range b.Services {
+		serviceSpecs[spec.GroupName+"/"+spec.ServiceName] = spec
+	}
+	configSpecs := make(map[string]*bundle.ConfigSpec, len(b.Configs))
+	for _, spec := range b.Configs {
+		configSpecs[spec.GroupName+"/"+spec.ConfigDataId] = spec
+	}
+	existingServices := make(map[string]*types.Service, len(services))
+	for _, service := range services {
+		existingServices[service.GroupName+"/"+service.ServiceName] = service
+	}
+	existingConfigs := make(map[string]*types.ConfigData, len(configs))
+	for _, config := range configs {
+		existingConfigs[config.GroupName+"/"+config.ConfigDataId] = config
+	}
+
+	for _, change := range plan.Changes {
+		if change.Action != bundle.ActionCreate && change.Action != bundle.ActionUpdate {
+			continue
+		}
+		key := change.GroupName + "/" + change.Name
+		var err error
+		if change.Kind == bundle.KindService {
+			err = c.importService(ctx, namespace, serviceSpecs[key], existingServices[key], operatorId)
+		} else {
+			err = c.importConfig(ctx, namespace, configSpecs[key], existingConfigs[key], operatorId)
+		}
+		if err != nil {
+			change.Error = err.Error()
+			logger.Warn("导入命名空间数据失败", "error", err,
+				"namespaceId", namespace.NamespaceId,
+				"kind", change.Kind,
+				"groupName", change.GroupName,
+				"name", change.Name)
+			continue
+		}
+		change.Applied = true
+	}
+	plan.Summarize()
+}
+
+// importService 新增或覆盖服务，并同步到注册中心缓存
+func (c *NamespaceController) importService(ctx context.Context, namespace *models.Namespace, spec *bundle.ServiceSpec, existing *types.Service, operatorId string) error {
+	serviceDAO := internaldao.NewServiceDAO(c.db)
+	manager := servicecenter.GetManager()
+
+	if existing == nil {
+		service := &types.Service{TenantId: namespace.TenantId, NamespaceId: namespace.NamespaceId, AddWho: operatorId, EditWho: operatorId}
+		spec.ApplyTo(service)
+		if service.ServiceType == "" {
+			service.ServiceType = types.ServiceTypeInternal
+		}
+		if err := serviceDAO.CreateService(ctx, service); err != nil {
+			return err
+		}
+		if manager != nil {
+			if err := manager.AddServiceToCache(ctx, service); err != nil {
+				logger.Warn("添加服务到缓存失败", "error", err, "serviceName", service.ServiceName)
+			}
+		}
+		return nil
+	}
+
+	service := *existing
+	spec.ApplyTo(&service)
+	service.CurrentVersion = existing.CurrentVersion + 1
+	service.EditTime = time.Now()
+	service.EditWho = operatorId
+	if err := serviceDAO.ReplaceService(ctx, &service); err != nil {
+		return err
+	}
+	if manager != nil {
+		if err := manager.UpdateServiceInCache(ctx, &service); err != nil {
+			logger.Warn("更新服务缓存失败", "error", err, "serviceName", service.ServiceName)
+		}
+	}
+	return nil
+}
+
+// importConfig 新增或覆盖配置，记录配置历史并通知监听者
+// 处于灰度发布中的配置不覆盖，需先全量发布或回滚灰度
+func (c *NamespaceController) importConfig(ctx context.Context, namespace *models.Namespace, spec *bundle.ConfigSpec, existing *types.ConfigData, operatorId string) error {
+	if existing.GrayRelease() != nil {
+		return fmt.Errorf("配置正在灰度发布中，请先全量发布或回滚灰度")
+	}
+
+	configDAO := internaldao.NewConfigDAO(c.db)
+	if existing == nil {
+		config := &types.ConfigData{TenantId: namespace.TenantId, NamespaceId: namespace.NamespaceId, AddWho: operatorId, EditWho: operatorId}
+		spec.ApplyTo(config)
+		if err := configDAO.SaveConfig(ctx, config); err != nil {
+			return err
+		}
+		c.recordImportedConfig(ctx, namespace, config, nil, operatorId)
+		return nil
+	}
+
+	config := *existing
+	config.EditWho = operatorId
+	spec.ApplyTo(&config)
+	if err := configDAO.ReplaceConfig(ctx, &config); err != nil {
+		return err
+	}
+	c.recordImportedConfig(ctx, namespace, &config, existing, operatorId)
+	return nil
+}
+
+// recordImportedConfig 记录导入配置的历史并通知监听者
+func (c *NamespaceController) recordImportedConfig(ctx context.Context, namespace *models.Namespace, config, existing *types.ConfigData, operatorId string) {
+
+	now := time.Now()
+	history := &types.ConfigHistory{
+		ConfigHistoryId: random.Generate32BitRandomString(),
+		TenantId:        config.TenantId,
+		NamespaceId:     config.NamespaceId,
+		GroupName:       config.GroupName,
+		ConfigDataId:    config.ConfigDataId,
+		ChangeType:      types.ChangeTypeCreate,
+		NewContent:      config.ConfigContent,
+		NewVersion:      config.Version,
+		NewMd5Value:     config.Md5Value,
+		ChangeReason:    "命名空间导入",
+		ChangedBy:       operatorId,
+		ChangedAt:       now,
+		AddTime:         now,
+		AddWho:          operatorId,
+		EditTime:        now,
+		EditWho:         operatorId,
+	}
+	if existing != nil {
+		history.ChangeType = types.ChangeTypeUpdate
+		history.OldContent = existing.ConfigContent
+		history.OldVersion = existing.Version
+		history.OldMd5Value = existing.Md5Value
+	}
+	if err := internaldao.NewHistoryDAO(c.db).CreateHistory(ctx, history); err != nil {
+		// 历史记录失败不影响主流程，只记录日志
+		logger.Warn("保存配置历史记录失败", "error", err, "configDataId", config.ConfigDataId)
+	}
+
+	if manager := servicecenter.GetManager(); manager != nil && namespace.InstanceName != "" {
+		event := &pb.ConfigChangeEvent{
+			EventType:    "CONFIG_UPDATED",
+			NamespaceId:  config.NamespaceId,
+			GroupName:    config.GroupName,
+			ConfigDataId: config.ConfigDataId,
+			ContentMd5:   config.Md5Value,
+			Config: &pb.ConfigData{
+				NamespaceId:   config.NamespaceId,
+				GroupName:     config.GroupName,
+				ConfigDataId:  config.ConfigDataId,
+				ContentType:   config.ContentType,
+				ConfigContent: config.ConfigContent,
+				ContentMd5:    config.Md5Value,
+				ConfigDesc:    config.ConfigDescription,
+				ConfigVersion: config.Version,
+			},
+		}
+		if err := manager.NotifyConfigChange(ctx, namespace.InstanceName, config.TenantId, config.NamespaceId, config.GroupName, config.ConfigDataId, event); err != nil {
+			logger.Warn("发送配置变更事件通知失败", "error", err, "configDataId", config.ConfigDataId)
+		}
+	}
+}
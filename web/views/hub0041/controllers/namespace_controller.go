@@ -2,6 +2,8 @@ package controllers
 
 import (
 	"gateway/internal/servicecenter"
+	"gateway/internal/servicecenter/dependency"
+	"gateway/internal/servicecenter/stats"
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
 	"gateway/pkg/utils/random"
@@ -102,6 +104,10 @@ func (c *NamespaceController) AddNamespace(ctx *gin.Context) {
 		response.ErrorJSON(ctx, "部署环境不能为空", constants.ED00006)
 		return
 	}
+	if _, err := req.GetMetadataSchema(); err != nil {
+		response.ErrorJSON(ctx, "元数据规范配置错误: "+err.Error(), constants.ED00006)
+		return
+	}
 
 	// 生成命名空间ID（如果未提供）
 	if req.NamespaceId == "" {
@@ -196,6 +202,10 @@ func (c *NamespaceController) EditNamespace(ctx *gin.Context) {
 		response.ErrorJSON(ctx, "命名空间ID不能为空", constants.ED00006)
 		return
 	}
+	if _, err := req.GetMetadataSchema(); err != nil {
+		response.ErrorJSON(ctx, "元数据规范配置错误: "+err.Error(), constants.ED00006)
+		return
+	}
 
 	// 获取现有命名空间信息进行校验
 	currentNamespace, err := c.namespaceDAO.GetNamespaceById(ctx, tenantId, req.NamespaceId)
@@ -318,3 +328,82 @@ func (c *NamespaceController) GetNamespace(ctx *gin.Context) {
 	// 直接返回命名空间对象
 	response.SuccessJSON(ctx, namespace, constants.SD00001)
 }
+
+// GetNamespaceStats 获取命名空间服务统计
+// @Summary 获取命名空间服务统计
+// @Description 按分组统计命名空间下的服务总数、节点健康状态、最近一小时注册/注销变动率和心跳延迟分布
+// @Tags 命名空间管理
+// @Accept json
+// @Produce json
+// @Param namespaceId query string true "命名空间ID"
+// @Param groupName query string false "分组名称，为空时统计全部分组"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0041/namespaces/stats [get]
+func (c *NamespaceController) GetNamespaceStats(ctx *gin.Context) {
+	namespaceId := request.GetParam(ctx, "namespaceId")
+	groupName := request.GetParam(ctx, "groupName")
+	if namespaceId == "" {
+		response.ErrorJSON(ctx, "命名空间ID不能为空", constants.ED00007)
+		return
+	}
+
+	// 使用工具类获取租户ID
+	tenantId := request.GetTenantID(ctx)
+
+	namespace, err := c.namespaceDAO.GetNamespaceById(ctx, tenantId, namespaceId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取命名空间详情失败", err)
+		response.ErrorJSON(ctx, "获取命名空间详情失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if namespace == nil {
+		response.ErrorJSON(ctx, "命名空间不存在", constants.ED00008)
+		return
+	}
+
+	// 统计数据来自服务中心运行时缓存
+	response.SuccessJSON(ctx, stats.CollectNamespaceStats(tenantId, namespaceId, groupName), constants.SD00002)
+}
+
+// GetDependencyGraph 获取命名空间服务依赖拓扑
+// @Summary 获取命名空间服务依赖拓扑
+// @Description 返回订阅方与被订阅服务之间的依赖关系，指定服务时同时返回直接或间接依赖该服务的订阅方（影响范围）
+// @Tags 命名空间管理
+// @Accept json
+// @Produce json
+// @Param namespaceId query string true "命名空间ID"
+// @Param groupName query string false "评估影响范围的服务分组，默认 DEFAULT_GROUP"
+// @Param serviceName query string false "评估影响范围的服务名称，为空时不计算影响范围"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0041/namespaces/dependencyGraph [get]
+func (c *NamespaceController) GetDependencyGraph(ctx *gin.Context) {
+	namespaceId := request.GetParam(ctx, "namespaceId")
+	groupName := request.GetParam(ctx, "groupName")
+	serviceName := request.GetParam(ctx, "serviceName")
+	if namespaceId == "" {
+		response.ErrorJSON(ctx, "命名空间ID不能为空", constants.ED00007)
+		return
+	}
+
+	// 使用工具类获取租户ID
+	tenantId := request.GetTenantID(ctx)
+
+	namespace, err := c.namespaceDAO.GetNamespaceById(ctx, tenantId, namespaceId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取命名空间详情失败", err)
+		response.ErrorJSON(ctx, "获取命名空间详情失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if namespace == nil {
+		response.ErrorJSON(ctx, "命名空间不存在", constants.ED00008)
+		return
+	}
+
+	graph, err := dependency.CollectGraph(ctx, tenantId, namespaceId, groupName, serviceName)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取服务依赖拓扑失败", err)
+		response.ErrorJSON(ctx, "获取服务依赖拓扑失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	response.SuccessJSON(ctx, graph, constants.SD00002)
+}
@@ -64,6 +64,16 @@ func initNamespaceRoutes(router *gin.RouterGroup, db database.Database) {
 		// 命名空间详情查询
 		namespaceGroup.POST("/getNamespace", namespaceController.GetNamespace)
 
+		// 命名空间服务统计
+		namespaceGroup.POST("/getNamespaceStats", namespaceController.GetNamespaceStats)
+
+		// 服务依赖拓扑和影响范围
+		namespaceGroup.POST("/getDependencyGraph", namespaceController.GetDependencyGraph)
+
+		// 命名空间导入导出（环境间提升）
+		namespaceGroup.POST("/exportNamespace", namespaceController.ExportNamespace)
+		namespaceGroup.POST("/importNamespace", namespaceController.ImportNamespace)
+
 		// 命名空间增删改
 		namespaceGroup.POST("/addNamespace", namespaceController.AddNamespace)
 		namespaceGroup.POST("/editNamespace", namespaceController.EditNamespace)
@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"gateway/pkg/config"
+	"gateway/pkg/logger"
+	"gateway/pkg/security"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// 采集上报默认限制
+const (
+	defaultIngestMaxBodyBytes = 10 * 1024 * 1024 // 请求体（解压后）最大字节数
+	defaultIngestMaxBatchSize = 500              // 单次上报最大条数
+	ingestFutureTolerance     = 5 * time.Minute  // 采集时间允许超前服务端时间的范围
+)
+
+// ingestAccessKeyContextKey 验签通过后保存访问密钥的上下文键
+const ingestAccessKeyContextKey = "agentIngestAccessKey"
+
+// agentIngestConfig 采集上报配置，对应 web.jvm_ingest、web.host_ingest 等配置节
+type agentIngestConfig struct {
+	Enabled      bool                   `mapstructure:"enabled"`
+	MaxBodyBytes int64                  `mapstructure:"max_body_bytes"`
+	MaxBatchSize int                    `mapstructure:"max_batch_size"`
+	AccessKeys   []agentIngestAccessKey `mapstructure:"access_keys"`
+}
+
+// agentIngestAccessKey 采集端访问密钥，上报数据写入密钥绑定的租户
+type agentIngestAccessKey struct {
+	AccessKey string `mapstructure:"access_key"`
+	Secret    string `mapstructure:"secret"`
+	TenantId  string `mapstructure:"tenant_id"`
+}
+
+// loadAgentIngestConfig 读取采集上报配置，未配置的限制使用默认值
+// 参数:
+//
+//	section: 配置节，如 web.jvm_ingest
+//	name: 上报类型名称，用于日志
+func loadAgentIngestConfig(section, name string) *agentIngestConfig {
+	cfg := &agentIngestConfig{}
+	if config.IsExist(section) {
+		if err := config.GetSection(section, cfg); err != nil {
+			logger.Warn("读取"+name+"上报配置失败，上报接口不可用", "error", err)
+			cfg = &agentIngestConfig{}
+		}
+	}
+	if cfg.MaxBodyBytes <= 0 {
+		cfg.MaxBodyBytes = defaultIngestMaxBodyBytes
+	}
+	if cfg.MaxBatchSize <= 0 {
+		cfg.MaxBatchSize = defaultIngestMaxBatchSize
+	}
+	return cfg
+}
+
+// agentIngestAuth 采集上报的验签和请求体解析，各类采集数据上报接口共用
+// 采集端使用访问密钥对请求签名，请求体支持JSON和protobuf（google.protobuf.Struct，结构与JSON一致），可使用gzip压缩
+type agentIngestAuth struct {
+	name     string
+	cfg      *agentIngestConfig
+	keys     map[string]*agentIngestAccessKey
+	verifier *security.SignatureVerifier
+}
+
+// newAgentIngestAuth 根据配置节创建采集上报验签器，配置不完整的访问密钥被忽略
+func newAgentIngestAuth(section, name string) *agentIngestAuth {
+	cfg := loadAgentIngestConfig(section, name)
+	a := &agentIngestAuth{
+		name: name,
+		cfg:  cfg,
+		keys: make(map[string]*agentIngestAccessKey, len(cfg.AccessKeys)),
+	}
+	for i := range cfg.AccessKeys {
+		key := &cfg.AccessKeys[i]
+		if key.AccessKey == "" || key.Secret == "" || key.TenantId == "" {
+			logger.Warn(name+"访问密钥配置不完整，已忽略", "accessKey", key.AccessKey)
+			continue
+		}
+		a.keys[key.AccessKey] = key
+	}
+	a.verifier = &security.SignatureVerifier{
+		SecretLookup: func(accessKey string) (string, error) {
+			key, ok := a.keys[accessKey]
+			if !ok {
+				return "", fmt.Errorf("未知的访问密钥: %s", accessKey)
+			}
+			return key.Secret, nil
+		},
+		NonceStore: security.NewMemoryNonceStore(),
+	}
+	return a
+}
+
+// verifySignature 采集上报验签中间件
+// 限制请求体大小后校验请求签名，通过后将访问密钥保存到上下文
+func (a *agentIngestAuth) verifySignature() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !a.cfg.Enabled || len(a.keys) == 0 {
+			response.ErrorJSON(ctx, a.name+"上报接口未启用", constants.ED00010, http.StatusForbidden)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, a.cfg.MaxBodyBytes)
+		accessKey, err := a.verifier.VerifyHTTPRequest(ctx.Request)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				response.ErrorJSON(ctx, "请求体过大", constants.ED00006, http.StatusRequestEntityTooLarge)
+				ctx.Abort()
+				return
+			}
+			logger.WarnWithTrace(ctx, a.name+"上报验签失败", "accessKey", ctx.GetHeader(security.SignatureAccessKeyHeader), "error", err)
+			response.ErrorJSON(ctx, "签名校验失败: "+err.Error(), constants.ED00010, http.StatusUnauthorized)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set(ingestAccessKeyContextKey, accessKey)
+		ctx.Next()
+	}
+}
+
+// accessKey 获取验签通过的访问密钥，未经过验签中间件时返回nil
+func (a *agentIngestAuth) accessKey(ctx *gin.Context) *agentIngestAccessKey {
+	return a.keys[ctx.GetString(ingestAccessKeyContextKey)]
+}
+
+// decodeBody 解压并解析上报请求体
+// Content-Encoding 为 gzip 时先解压，解压后大小同样受 max_body_bytes 限制；
+// Content-Type 为 application/x-protobuf 或 application/protobuf 时按 google.protobuf.Struct 解析
+func (a *agentIngestAuth) decodeBody(r *http.Request, v interface{}) error {
+	var reader io.Reader = r.Body
+	if strings.EqualFold(strings.TrimSpace(r.Header.Get("Content-Encoding")), "gzip") {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return fmt.Errorf("gzip解压失败: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	body, err := io.ReadAll(io.LimitReader(reader, a.cfg.MaxBodyBytes+1))
+	if err != nil {
+		return fmt.Errorf("读取请求体失败: %w", err)
+	}
+	if int64(len(body)) > a.cfg.MaxBodyBytes {
+		return fmt.Errorf("请求体解压后超过%d字节", a.cfg.MaxBodyBytes)
+	}
+
+	contentType := strings.ToLower(r.Header.Get("Content-Type"))
+	if strings.Contains(contentType, "application/x-protobuf") || strings.Contains(contentType, "application/protobuf") {
+		var message structpb.Struct
+		if err := proto.Unmarshal(body, &message); err != nil {
+			return fmt.Errorf("protobuf解析失败: %w", err)
+		}
+		if body, err = message.MarshalJSON(); err != nil {
+			return fmt.Errorf("protobuf转换失败: %w", err)
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("JSON解析失败: %w", err)
+	}
+	return nil
+}
+
+// checkIngestText 校验文本字段必填和长度
+func checkIngestText(field, value string, maxLen int) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("%s不能为空", field)
+	}
+	if len(value) > maxLen {
+		return fmt.Errorf("%s长度不能超过%d", field, maxLen)
+	}
+	return nil
+}
+
+// checkIngestTime 校验采集时间不为空且没有明显超前服务端时间
+func checkIngestTime(field string, value, now time.Time) error {
+	if value.IsZero() {
+		return fmt.Errorf("%s不能为空", field)
+	}
+	if value.After(now.Add(ingestFutureTolerance)) {
+		return fmt.Errorf("%s晚于服务端时间", field)
+	}
+	return nil
+}
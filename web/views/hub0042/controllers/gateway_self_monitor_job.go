@@ -0,0 +1,390 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"gateway/internal/gateway/logwrite/livestats"
+	"gateway/pkg/config"
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/pkg/timer"
+	"gateway/pkg/utils/net"
+	"gateway/pkg/utils/random"
+	"gateway/web/views/hub0042/dao"
+	"gateway/web/views/hub0042/models"
+)
+
+// gatewaySelfMonitorSchedulerId 网关自监控调度器ID
+const gatewaySelfMonitorSchedulerId = "GATEWAY_SELF_MONITOR_scheduler"
+
+// gatewaySelfMonitorTaskId 网关自监控上报任务ID
+const gatewaySelfMonitorTaskId = "GATEWAY_SELF_MONITOR"
+
+// gatewaySelfMonitorOperator 自监控数据写入时使用的操作人
+const gatewaySelfMonitorOperator = "system"
+
+// 网关自监控数据名称和分类
+const (
+	selfMonitorRuntimeName     = "go_runtime" // 运行时指标：协程数、内存、GC
+	selfMonitorCategoryRuntime = "RUNTIME"
+	selfMonitorCategoryTraffic = "TRAFFIC"
+	selfMonitorCategoryRoute   = "ROUTE"
+)
+
+// gatewayProcessStartTime 进程启动时间，用作资源的启动时间
+var gatewayProcessStartTime = time.Now()
+
+// selfMonitorHealthGrades 实时统计健康状态对应的健康等级
+var selfMonitorHealthGrades = map[string]string{
+	livestats.HealthStatusHealthy:   "EXCELLENT",
+	livestats.HealthStatusIdle:      "GOOD",
+	livestats.HealthStatusDegraded:  "FAIR",
+	livestats.HealthStatusUnhealthy: "POOR",
+}
+
+// gatewaySelfMonitorSettings 网关自监控配置，对应 web.gateway_self_monitor
+type gatewaySelfMonitorSettings struct {
+	tenantId        string // 写入数据的租户ID，同时用于读取该租户的实时路由统计
+	serviceGroupId  string // 资源所属的服务分组ID
+	applicationName string // 资源的应用名称
+	maxRoutes       int    // 每轮最多上报的路由数，按请求数倒序截取
+}
+
+// loadGatewaySelfMonitorSettings 读取网关自监控配置
+func loadGatewaySelfMonitorSettings() *gatewaySelfMonitorSettings {
+	s := &gatewaySelfMonitorSettings{
+		tenantId:        config.GetString("web.gateway_self_monitor.tenant_id", "default"),
+		serviceGroupId:  config.GetString("web.gateway_self_monitor.service_group_id", "GATEWAY"),
+		applicationName: config.GetString("web.gateway_self_monitor.application_name", "gateway"),
+		maxRoutes:       config.GetInt("web.gateway_self_monitor.max_routes", 50),
+	}
+	if s.maxRoutes <= 0 {
+		s.maxRoutes = 50
+	}
+	return s
+}
+
+// GatewaySelfMonitorJob 网关自监控上报任务
+// 周期将本节点的运行状态写入监控表，使网关与其代理的JVM应用出现在同一监控界面：
+// 节点作为一个资源写入 HUB_MONITOR_JVM_RESOURCE（jvmResourceId 为 GATEWAY_ 加节点ID），
+// 运行时指标、节点流量和各路由的QPS/延迟作为 CUSTOM_METRIC 写入 HUB_MONITOR_APP_DATA；
+// 节点流量以5xx错误率为主值，供 GATEWAY_ERROR_RATE 告警规则使用；
+// 路由指标来自访问日志的实时统计，窗口与上报周期一致
+type GatewaySelfMonitorJob struct {
+	dao      *dao.JvmIngestDAO
+	interval time.Duration
+	mu       sync.Mutex // 保证同一时刻只有一轮上报
+}
+
+// NewGatewaySelfMonitorJob 创建网关自监控上报任务
+func NewGatewaySelfMonitorJob(db database.Database) *GatewaySelfMonitorJob {
+	interval := time.Duration(config.GetInt("web.gateway_self_monitor.interval_seconds", 60)) * time.Second
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &GatewaySelfMonitorJob{dao: dao.NewJvmIngestDAO(db), interval: interval}
+}
+
+// Start 注册周期上报任务
+// 返回:
+//
+//	error: 创建调度器或注册任务失败时返回错误
+func (j *GatewaySelfMonitorJob) Start() error {
+	if !config.GetBool("web.gateway_self_monitor.enabled", true) {
+		logger.Info("网关自监控上报未启用，跳过注册")
+		return nil
+	}
+
+	pool := timer.GetTimerPool()
+	scheduler, err := pool.GetScheduler(gatewaySelfMonitorSchedulerId)
+	if err != nil {
+		scheduler, err = pool.CreateScheduler(&timer.SchedulerConfig{
+			ID:               gatewaySelfMonitorSchedulerId,
+			Name:             "网关自监控调度器",
+			MaxWorkers:       1,
+			QueueSize:        10,
+			DefaultTimeout:   j.interval,
+			DefaultRetries:   0,
+			ScheduleInterval: time.Second,
+			Tasks:            make(map[string]*timer.TaskConfig),
+		})
+		if err != nil {
+			return fmt.Errorf("创建网关自监控调度器失败: %w", err)
+		}
+	}
+
+	taskConfig := timer.NewTaskConfig(gatewaySelfMonitorTaskId, "网关自监控上报", timer.ScheduleTypeInterval)
+	taskConfig.Description = "上报网关节点的运行时指标和路由QPS/延迟"
+	taskConfig.Interval = j.interval
+	taskConfig.Timeout = j.interval
+	taskConfig.ClusterMode = timer.ClusterModeLocal // 每个网关节点上报自身指标
+	if err := scheduler.AddTask(taskConfig, &gatewaySelfMonitorTaskExecutor{job: j}); err != nil {
+		return fmt.Errorf("注册网关自监控上报任务失败: %w", err)
+	}
+	if !scheduler.IsRunning() {
+		if err := scheduler.Start(); err != nil {
+			return fmt.Errorf("启动网关自监控调度器失败: %w", err)
+		}
+	}
+	logger.Info("网关自监控上报任务注册完成", "interval", j.interval.String())
+	return nil
+}
+
+// RunOnce 采集并写入一轮自监控数据，上一轮未完成时跳过本轮
+// 参数:
+//
+//	ctx: 上下文对象
+//
+// 返回:
+//
+//	string: 本轮处理摘要
+//	error: 写入失败时返回错误
+func (j *GatewaySelfMonitorJob) RunOnce(ctx context.Context) (string, error) {
+	if !j.mu.TryLock() {
+		logger.Warn("上一轮网关自监控上报尚未完成，跳过本轮")
+		return "上一轮尚未完成", nil
+	}
+	defer j.mu.Unlock()
+
+	settings := loadGatewaySelfMonitorSettings()
+	now := time.Now()
+	window := int(j.interval / time.Second)
+	if window > livestats.MaxWindowSeconds {
+		window = livestats.MaxWindowSeconds
+	}
+	snapshot := livestats.Default().Snapshot(livestats.SnapshotQuery{
+		TenantId:      settings.tenantId,
+		WindowSeconds: window,
+		MaxRoutes:     settings.maxRoutes,
+	})
+
+	resource, appData, err := buildGatewaySelfReport(settings, snapshot, readGatewayRuntime(now), now)
+	if err != nil {
+		return "", err
+	}
+	if err := j.dao.SaveAppData(ctx, resource, appData); err != nil {
+		return "", fmt.Errorf("写入网关自监控数据失败: %w", err)
+	}
+	return fmt.Sprintf("上报%d条监控数据，其中路由%d条", len(appData), len(snapshot.Routes)), nil
+}
+
+// gatewayRuntimeStats 网关进程运行时指标
+type gatewayRuntimeStats struct {
+	UptimeSeconds   int64  `json:"uptimeSeconds"`   // 运行时长（秒）
+	Goroutines      int    `json:"goroutines"`      // 协程数
+	HeapAllocBytes  uint64 `json:"heapAllocBytes"`  // 堆上已分配且未释放的字节数
+	HeapSysBytes    uint64 `json:"heapSysBytes"`    // 从操作系统申请的堆内存字节数
+	HeapObjects     uint64 `json:"heapObjects"`     // 堆上对象数
+	SysBytes        uint64 `json:"sysBytes"`        // 从操作系统申请的总内存字节数
+	NumGC           uint32 `json:"numGC"`           // 累计GC次数
+	GcPauseTotalMs  uint64 `json:"gcPauseTotalMs"`  // 累计GC暂停时长（毫秒）
+	LastGcPauseUs   uint64 `json:"lastGcPauseUs"`   // 最近一次GC暂停时长（微秒）
+	GoMaxProcs      int    `json:"goMaxProcs"`      // GOMAXPROCS
+	GoVersion       string `json:"goVersion"`       // Go版本
+	StartTimeMillis int64  `json:"startTimeMillis"` // 进程启动时间（毫秒时间戳）
+}
+
+// readGatewayRuntime 读取当前进程的运行时指标
+func readGatewayRuntime(now time.Time) *gatewayRuntimeStats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	stats := &gatewayRuntimeStats{
+		UptimeSeconds:   int64(now.Sub(gatewayProcessStartTime) / time.Second),
+		Goroutines:      runtime.NumGoroutine(),
+		HeapAllocBytes:  mem.HeapAlloc,
+		HeapSysBytes:    mem.HeapSys,
+		HeapObjects:     mem.HeapObjects,
+		SysBytes:        mem.Sys,
+		NumGC:           mem.NumGC,
+		GcPauseTotalMs:  mem.PauseTotalNs / uint64(time.Millisecond),
+		GoMaxProcs:      runtime.GOMAXPROCS(0),
+		GoVersion:       runtime.Version(),
+		StartTimeMillis: gatewayProcessStartTime.UnixMilli(),
+	}
+	if mem.NumGC > 0 {
+		stats.LastGcPauseUs = mem.PauseNs[(mem.NumGC+255)%256] / uint64(time.Microsecond)
+	}
+	return stats
+}
+
+// buildGatewaySelfReport 根据运行时指标和实时路由统计生成资源信息和应用监控数据
+// 参数:
+//
+//	settings: 自监控配置
+//	snapshot: 本节点访问日志的实时统计快照
+//	runtimeStats: 运行时指标
+//	now: 采集时间
+//
+// 返回:
+//
+//	*models.JvmResource: 代表本节点的资源信息
+//	[]*models.JvmAppData: 运行时、节点流量和各路由的监控数据
+//	error: 序列化失败时返回错误
+func buildGatewaySelfReport(settings *gatewaySelfMonitorSettings, snapshot *livestats.Snapshot, runtimeStats *gatewayRuntimeStats,
+	now time.Time) (*models.JvmResource, []*models.JvmAppData, error) {
+	nodeId := config.GetNodeId()
+	resourceId := "GATEWAY_" + nodeId
+	if len(resourceId) > 100 {
+		resourceId = resourceId[:100]
+	}
+	status := healthOfSnapshot(snapshot)
+	healthyFlag, attentionFlag := "Y", "N"
+	if status == livestats.HealthStatusUnhealthy {
+		healthyFlag, attentionFlag = "N", "Y"
+	}
+	grade := selfMonitorHealthGrades[status]
+	summary := fmt.Sprintf("协程%d，堆内存%dMB，近%d秒请求%d，错误率%.2f%%", runtimeStats.Goroutines, runtimeStats.HeapAllocBytes/1024/1024,
+		snapshot.WindowSeconds, snapshot.Requests, snapshot.ErrorRate*100)
+	hostName, hostIp := net.GetHostname(), net.GetFirstIPv4Address()
+
+	resource := &models.JvmResource{
+		TenantId:              settings.tenantId,
+		ServiceGroupId:        settings.serviceGroupId,
+		JvmResourceId:         resourceId,
+		ApplicationName:       settings.applicationName,
+		GroupName:             settings.serviceGroupId,
+		CollectionTime:        now,
+		JvmStartTime:          gatewayProcessStartTime,
+		JvmUptimeMs:           now.Sub(gatewayProcessStartTime).Milliseconds(),
+		HealthyFlag:           healthyFlag,
+		HealthGrade:           &grade,
+		RequiresAttentionFlag: attentionFlag,
+		SummaryText:           &summary,
+		AddTime:               now,
+		AddWho:                gatewaySelfMonitorOperator,
+		EditTime:              now,
+		EditWho:               gatewaySelfMonitorOperator,
+		OprSeqFlag:            random.Generate32BitRandomString(),
+		CurrentVersion:        1,
+		ActiveFlag:            "Y",
+	}
+	if hostName != "" {
+		resource.HostName = &hostName
+	}
+	if hostIp != "" {
+		resource.HostIpAddress = &hostIp
+	}
+
+	newAppData := func(name, category string, data interface{}, tags map[string]string, primary, secondary float64, status string) (*models.JvmAppData, error) {
+		dataJson, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("序列化%s失败: %w", name, err)
+		}
+		row := &models.JvmAppData{
+			TenantId:              settings.tenantId,
+			AppDataId:             random.Generate32BitRandomString(),
+			JvmResourceId:         resourceId,
+			DataType:              models.AppDataTypeCustomMetric,
+			DataName:              name,
+			DataCategory:          &category,
+			DataJson:              string(dataJson),
+			PrimaryValue:          &primary,
+			SecondaryValue:        &secondary,
+			HealthyFlag:           "Y",
+			RequiresAttentionFlag: "N",
+			CollectionTime:        now,
+			AddTime:               now,
+			AddWho:                gatewaySelfMonitorOperator,
+			EditTime:              now,
+			EditWho:               gatewaySelfMonitorOperator,
+			CurrentVersion:        1,
+			ActiveFlag:            "Y",
+		}
+		row.OprSeqFlag = row.AppDataId
+		if status != "" {
+			statusGrade := selfMonitorHealthGrades[status]
+			row.StatusValue, row.HealthGrade = &status, &statusGrade
+			if status == livestats.HealthStatusUnhealthy {
+				row.HealthyFlag, row.RequiresAttentionFlag = "N", "Y"
+			}
+		}
+		if len(tags) > 0 {
+			tagsJson, err := json.Marshal(tags)
+			if err != nil {
+				return nil, fmt.Errorf("序列化%s标签失败: %w", name, err)
+			}
+			text := string(tagsJson)
+			row.TagsJson = &text
+		}
+		return row, nil
+	}
+
+	appData := make([]*models.JvmAppData, 0, len(snapshot.Routes)+2)
+	runtimeRow, err := newAppData(selfMonitorRuntimeName, selfMonitorCategoryRuntime, runtimeStats,
+		map[string]string{"nodeId": nodeId}, float64(runtimeStats.Goroutines), float64(runtimeStats.HeapAllocBytes), "")
+	if err != nil {
+		return nil, nil, err
+	}
+	appData = append(appData, runtimeRow)
+
+	trafficRow, err := newAppData(models.GatewayTrafficDataName, selfMonitorCategoryTraffic, snapshot.TrafficStats,
+		map[string]string{"nodeId": nodeId, "windowSeconds": fmt.Sprint(snapshot.WindowSeconds)},
+		snapshot.ErrorRate*100, snapshot.RPS, status)
+	if err != nil {
+		return nil, nil, err
+	}
+	appData = append(appData, trafficRow)
+
+	for i := range snapshot.Routes {
+		route := &snapshot.Routes[i]
+		name := route.RouteConfigId
+		if len(name) > 100 {
+			name = name[:100]
+		}
+		row, err := newAppData(name, selfMonitorCategoryRoute, route, map[string]string{
+			"gatewayInstanceId": route.GatewayInstanceId,
+			"routeName":         route.RouteName,
+		}, route.RPS, route.AvgLatencyMs, livestats.HealthOf(route.TrafficStats))
+		if err != nil {
+			return nil, nil, err
+		}
+		appData = append(appData, row)
+	}
+	return resource, appData, nil
+}
+
+// healthOfSnapshot 汇总快照中各网关实例的健康状态，取最差的状态
+func healthOfSnapshot(snapshot *livestats.Snapshot) string {
+	rank := map[string]int{
+		livestats.HealthStatusIdle:      0,
+		livestats.HealthStatusHealthy:   1,
+		livestats.HealthStatusDegraded:  2,
+		livestats.HealthStatusUnhealthy: 3,
+	}
+	status := livestats.HealthStatusIdle
+	for _, instance := range snapshot.Instances {
+		if rank[instance.HealthStatus] > rank[status] {
+			status = instance.HealthStatus
+		}
+	}
+	return status
+}
+
+// gatewaySelfMonitorTaskExecutor 网关自监控任务执行器，实现 timer.TaskExecutor 接口
+type gatewaySelfMonitorTaskExecutor struct {
+	job *GatewaySelfMonitorJob
+}
+
+// Execute 执行一轮自监控上报
+func (e *gatewaySelfMonitorTaskExecutor) Execute(ctx context.Context, params interface{}) (*timer.ExecuteResult, error) {
+	summary, err := e.job.RunOnce(ctx)
+	if err != nil {
+		return &timer.ExecuteResult{Success: false, Message: err.Error()}, err
+	}
+	return &timer.ExecuteResult{Success: true, Message: summary}, nil
+}
+
+// GetName 执行器名称
+func (e *gatewaySelfMonitorTaskExecutor) GetName() string {
+	return "GatewaySelfMonitor"
+}
+
+// Close 无需释放资源
+func (e *gatewaySelfMonitorTaskExecutor) Close() error {
+	return nil
+}
@@ -0,0 +1,192 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"gateway/internal/metric_collect/types"
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0042/dao"
+	"gateway/web/views/hub0042/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HostIngestController 主机指标上报控制器（采集端模式）
+// 独立部署的采集端使用与网关内置采集器相同的 metric.CollectAll 采集 CPU/内存/磁盘/网络/进程指标，
+// 签名后批量上报，数据写入 HUB_METRIC_* 表；网关节点自身的指标仍由内置采集器直接写入
+type HostIngestController struct {
+	dao  *dao.HostMetricDAO
+	auth *agentIngestAuth
+}
+
+// NewHostIngestController 创建主机指标上报控制器，访问密钥和限制读取 web.host_ingest
+func NewHostIngestController(db database.Database) *HostIngestController {
+	return &HostIngestController{
+		dao:  dao.NewHostMetricDAO(db),
+		auth: newAgentIngestAuth("web.host_ingest", "主机指标"),
+	}
+}
+
+// VerifySignature 采集上报验签中间件
+func (c *HostIngestController) VerifySignature() gin.HandlerFunc {
+	return c.auth.verifySignature()
+}
+
+// IngestBatch 批量上报主机指标
+// 校验未通过的记录被跳过并在结果中返回原因，其余记录在一个事务中写入
+func (c *HostIngestController) IngestBatch(ctx *gin.Context) {
+	key := c.auth.accessKey(ctx)
+	if key == nil {
+		response.ErrorJSON(ctx, "缺少访问密钥", constants.ED00010, http.StatusUnauthorized)
+		return
+	}
+
+	req := &models.HostIngestRequest{}
+	if err := c.auth.decodeBody(ctx.Request, req); err != nil {
+		logger.WarnWithTrace(ctx, "解析主机指标失败", "accessKey", key.AccessKey, "error", err)
+		response.ErrorJSON(ctx, "解析采集数据失败: "+err.Error(), constants.ED00006, http.StatusBadRequest)
+		return
+	}
+	if len(req.Reports) == 0 {
+		response.ErrorJSON(ctx, "采集数据不能为空", constants.ED00007)
+		return
+	}
+	if len(req.Reports) > c.auth.cfg.MaxBatchSize {
+		response.ErrorJSON(ctx, fmt.Sprintf("单次上报不能超过%d条记录", c.auth.cfg.MaxBatchSize), constants.ED00006)
+		return
+	}
+
+	now := time.Now()
+	result := &models.HostIngestResult{Rejected: []*models.HostIngestRejected{}}
+	rows := &models.HostMetricRows{}
+	for i, report := range req.Reports {
+		if err := appendHostReport(rows, report, key.TenantId, key.AccessKey, now); err != nil {
+			rejected := &models.HostIngestRejected{Index: i, Reason: err.Error()}
+			if report != nil {
+				rejected.MetricServerId = report.MetricServerId
+			}
+			result.Rejected = append(result.Rejected, rejected)
+			continue
+		}
+		result.Accepted++
+	}
+
+	if result.Accepted > 0 {
+		if err := c.dao.SaveReports(ctx, rows); err != nil {
+			logger.ErrorWithTrace(ctx, "写入主机指标失败", "accessKey", key.AccessKey, "error", err)
+			response.ErrorJSON(ctx, "写入采集数据失败: "+err.Error(), constants.ED00009)
+			return
+		}
+	}
+
+	if len(result.Rejected) > 0 {
+		logger.WarnWithTrace(ctx, "部分主机指标校验未通过", "accessKey", key.AccessKey,
+			"accepted", result.Accepted, "rejected", len(result.Rejected))
+	}
+	response.SuccessJSON(ctx, result, constants.SD00003)
+}
+
+// appendHostReport 校验上报记录并转换为 HUB_METRIC_* 表数据追加到 rows
+// 转换逻辑与内置采集器一致，服务器信息由 system 指标生成
+// 参数:
+//
+//	rows: 待写入数据
+//	report: 上报记录
+//	tenantId: 访问密钥绑定的租户ID
+//	operatorId: 写入 addWho/editWho 的操作人，使用访问密钥
+//	now: 服务端接收时间
+//
+// 返回:
+//
+//	error: 校验未通过的原因，此时 rows 不变
+func appendHostReport(rows *models.HostMetricRows, report *models.HostMetricsReport, tenantId, operatorId string, now time.Time) error {
+	if report == nil {
+		return errors.New("上报记录不能为空")
+	}
+	if err := checkIngestText("metricServerId", report.MetricServerId, 64); err != nil {
+		return err
+	}
+	metrics := report.Metrics
+	if metrics == nil || metrics.System == nil {
+		return errors.New("metrics.system不能为空")
+	}
+	system := metrics.System
+	if err := checkIngestText("metrics.system.hostname", system.Hostname, 255); err != nil {
+		return err
+	}
+	if err := checkIngestTime("metrics.collect_time", metrics.CollectTime, now); err != nil {
+		return err
+	}
+	if metrics.CPU != nil && (metrics.CPU.UsagePercent < 0 || metrics.CPU.UsagePercent > 100) {
+		return errors.New("metrics.cpu.usage_percent超出0-100范围")
+	}
+	if metrics.Memory != nil && (metrics.Memory.UsagePercent < 0 || metrics.Memory.UsagePercent > 100) {
+		return errors.New("metrics.memory.usage_percent超出0-100范围")
+	}
+
+	serverId, collectTime := report.MetricServerId, metrics.CollectTime
+	oprSeqFlag := fmt.Sprintf("INGEST_%d", collectTime.Unix())
+
+	server := &types.ServerInfo{
+		MetricServerId: serverId,
+		TenantId:       tenantId,
+		Hostname:       system.Hostname,
+		OsType:         system.OS,
+		OsVersion:      system.OSVersion,
+		Architecture:   system.Architecture,
+		BootTime:       system.BootTime,
+		ServerLocation: report.ServerLocation,
+		LastUpdateTime: now,
+		AddTime:        now,
+		AddWho:         operatorId,
+		EditTime:       now,
+		EditWho:        operatorId,
+		OprSeqFlag:     oprSeqFlag,
+		CurrentVersion: 1,
+		ActiveFlag:     types.ActiveFlagYes,
+	}
+	if system.KernelVersion != "" {
+		server.KernelVersion = &system.KernelVersion
+	}
+	if system.ServerType != "" {
+		server.ServerType = &system.ServerType
+	}
+	if network := system.NetworkInfo; network != nil {
+		if network.PrimaryIP != "" {
+			server.IpAddress = &network.PrimaryIP
+		}
+		if network.PrimaryMAC != "" {
+			server.MacAddress = &network.PrimaryMAC
+		}
+	}
+	rows.Servers = append(rows.Servers, server)
+
+	if metrics.CPU != nil {
+		rows.Cpus = append(rows.Cpus, types.NewCpuLogFromMetrics(metrics.CPU, tenantId, serverId, operatorId, collectTime, oprSeqFlag))
+	}
+	if metrics.Memory != nil {
+		rows.Memories = append(rows.Memories, types.NewMemoryLogFromMetrics(metrics.Memory, tenantId, serverId, operatorId, collectTime, oprSeqFlag))
+	}
+	if metrics.Disk != nil {
+		rows.DiskPartitions = append(rows.DiskPartitions, types.NewDiskPartitionLogsFromMetrics(metrics.Disk, tenantId, serverId, operatorId, collectTime, oprSeqFlag)...)
+		rows.DiskIos = append(rows.DiskIos, types.NewDiskIoLogsFromMetrics(metrics.Disk, tenantId, serverId, operatorId, collectTime, oprSeqFlag)...)
+	}
+	if metrics.Network != nil {
+		rows.Networks = append(rows.Networks, types.NewNetworkLogsFromMetrics(metrics.Network, tenantId, serverId, operatorId, collectTime, oprSeqFlag)...)
+	}
+	if metrics.Process != nil {
+		if metrics.Process.SystemProcesses != nil {
+			rows.ProcessStats = append(rows.ProcessStats, types.NewProcessStatsLogFromMetrics(metrics.Process.SystemProcesses, tenantId, serverId, operatorId, collectTime, oprSeqFlag))
+		}
+		if metrics.Process.CurrentProcess != nil {
+			rows.Processes = append(rows.Processes, types.NewProcessLogFromMetrics(metrics.Process.CurrentProcess, tenantId, serverId, operatorId, collectTime, oprSeqFlag))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,369 @@
+package controllers
+
+import (
+	"strings"
+	"time"
+
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/pkg/utils/random"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0042/dao"
+	"gateway/web/views/hub0042/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// alertTimeLayout 告警接口的时间参数格式
+const alertTimeLayout = "2006-01-02 15:04:05"
+
+// JvmAlertController JVM告警控制器
+// 提供告警规则维护、告警静默和告警历史查询，规则由 JvmAlertEngine 周期评估
+type JvmAlertController struct {
+	dao *dao.JvmAlertDAO
+}
+
+// NewJvmAlertController 创建JVM告警控制器
+func NewJvmAlertController(db database.Database) *JvmAlertController {
+	return &JvmAlertController{dao: dao.NewJvmAlertDAO(db)}
+}
+
+// QueryAlertRules 分页查询告警规则
+func (c *JvmAlertController) QueryAlertRules(ctx *gin.Context) {
+	page, pageSize := request.GetPaginationParams(ctx)
+	tenantId := request.GetTenantID(ctx)
+
+	var q models.AlertRuleListRequest
+	if err := request.BindSafely(ctx, &q); err != nil {
+		logger.WarnWithTrace(ctx, "绑定告警规则筛选条件失败，使用默认条件", "error", err.Error())
+	}
+
+	rows, total, err := c.dao.QueryAlertRules(ctx, tenantId, &q, page, pageSize)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询告警规则失败", "error", err)
+		response.ErrorJSON(ctx, "查询告警规则失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	pageInfo := response.NewPageInfo(page, pageSize, total)
+	pageInfo.MainKey = "alertRuleId"
+	response.PageJSON(ctx, rows, pageInfo, constants.SD00002)
+}
+
+// GetAlertRule 获取告警规则详情
+func (c *JvmAlertController) GetAlertRule(ctx *gin.Context) {
+	rule, ok := c.loadRule(ctx, request.GetParam(ctx, "alertRuleId"))
+	if !ok {
+		return
+	}
+	response.SuccessJSON(ctx, rule, constants.SD00001)
+}
+
+// AddAlertRule 新增告警规则
+func (c *JvmAlertController) AddAlertRule(ctx *gin.Context) {
+	var req models.AlertRule
+	if err := request.BindSafely(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	operatorId := request.GetOperatorID(ctx)
+	now := time.Now()
+	req.TenantId = request.GetTenantID(ctx)
+	req.AlertRuleId = random.Generate32BitRandomString()
+	req.LastEvalTime = nil
+	req.AddTime = now
+	req.AddWho = operatorId
+	req.EditTime = now
+	req.EditWho = operatorId
+	req.OprSeqFlag = random.Generate32BitRandomString()
+	req.CurrentVersion = 1
+	req.ActiveFlag = "Y"
+	if msg, code := normalizeAlertRule(&req); msg != "" {
+		response.ErrorJSON(ctx, msg, code)
+		return
+	}
+
+	if err := c.dao.CreateAlertRule(ctx, &req); err != nil {
+		logger.ErrorWithTrace(ctx, "创建告警规则失败", "error", err)
+		response.ErrorJSON(ctx, "创建告警规则失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	response.SuccessJSON(ctx, req, constants.SD00003)
+}
+
+// EditAlertRule 编辑告警规则
+// 规则停用时将其告警中的事件置为已恢复（不发送恢复通知），重新启用后按新条件重新评估
+func (c *JvmAlertController) EditAlertRule(ctx *gin.Context) {
+	var req models.AlertRule
+	if err := request.BindSafely(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+	current, ok := c.loadRule(ctx, req.AlertRuleId)
+	if !ok {
+		return
+	}
+
+	req.TenantId = current.TenantId
+	req.LastEvalTime = current.LastEvalTime
+	req.AddTime = current.AddTime
+	req.AddWho = current.AddWho
+	req.OprSeqFlag = random.Generate32BitRandomString()
+	req.CurrentVersion = current.CurrentVersion + 1
+	req.ActiveFlag = "Y"
+	req.EditTime = time.Now()
+	req.EditWho = request.GetOperatorID(ctx)
+	if msg, code := normalizeAlertRule(&req); msg != "" {
+		response.ErrorJSON(ctx, msg, code)
+		return
+	}
+
+	if err := c.dao.UpdateAlertRule(ctx, &req); err != nil {
+		logger.ErrorWithTrace(ctx, "更新告警规则失败", "error", err)
+		response.ErrorJSON(ctx, "更新告警规则失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if req.EnabledFlag != "Y" {
+		if err := c.dao.ResolveRuleEvents(ctx, req.TenantId, req.AlertRuleId, req.EditWho); err != nil {
+			logger.WarnWithTrace(ctx, "恢复停用规则的告警事件失败", "alertRuleId", req.AlertRuleId, "error", err.Error())
+		}
+	}
+	response.SuccessJSON(ctx, req, constants.SD00004)
+}
+
+// DeleteAlertRule 删除告警规则，告警中的事件置为已恢复，历史事件保留
+func (c *JvmAlertController) DeleteAlertRule(ctx *gin.Context) {
+	alertRuleId := request.GetParam(ctx, "alertRuleId")
+	if _, ok := c.loadRule(ctx, alertRuleId); !ok {
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	operatorId := request.GetOperatorID(ctx)
+	if err := c.dao.DeleteAlertRule(ctx, tenantId, alertRuleId, operatorId); err != nil {
+		logger.ErrorWithTrace(ctx, "删除告警规则失败", "error", err)
+		response.ErrorJSON(ctx, "删除告警规则失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if err := c.dao.ResolveRuleEvents(ctx, tenantId, alertRuleId, operatorId); err != nil {
+		logger.WarnWithTrace(ctx, "恢复已删除规则的告警事件失败", "alertRuleId", alertRuleId, "error", err.Error())
+	}
+	response.SuccessJSON(ctx, gin.H{"alertRuleId": alertRuleId}, constants.SD00005)
+}
+
+// QueryAlertSilences 分页查询告警静默
+func (c *JvmAlertController) QueryAlertSilences(ctx *gin.Context) {
+	page, pageSize := request.GetPaginationParams(ctx)
+	tenantId := request.GetTenantID(ctx)
+
+	var q models.AlertSilenceListRequest
+	if err := request.BindSafely(ctx, &q); err != nil {
+		logger.WarnWithTrace(ctx, "绑定告警静默筛选条件失败，使用默认条件", "error", err.Error())
+	}
+
+	rows, total, err := c.dao.QueryAlertSilences(ctx, tenantId, &q, page, pageSize)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询告警静默失败", "error", err)
+		response.ErrorJSON(ctx, "查询告警静默失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	pageInfo := response.NewPageInfo(page, pageSize, total)
+	pageInfo.MainKey = "alertSilenceId"
+	response.PageJSON(ctx, rows, pageInfo, constants.SD00002)
+}
+
+// AddAlertSilence 新增告警静默
+func (c *JvmAlertController) AddAlertSilence(ctx *gin.Context) {
+	var req models.AlertSilenceRequest
+	if err := request.BindSafely(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	now := time.Now()
+	startTime := now
+	if strings.TrimSpace(req.StartTime) != "" {
+		parsed, err := time.ParseInLocation(alertTimeLayout, req.StartTime, time.Local)
+		if err != nil {
+			response.ErrorJSON(ctx, "startTime格式错误，应为 yyyy-MM-dd HH:mm:ss", constants.ED00006)
+			return
+		}
+		startTime = parsed
+	}
+	var endTime time.Time
+	switch {
+	case strings.TrimSpace(req.EndTime) != "":
+		parsed, err := time.ParseInLocation(alertTimeLayout, req.EndTime, time.Local)
+		if err != nil {
+			response.ErrorJSON(ctx, "endTime格式错误，应为 yyyy-MM-dd HH:mm:ss", constants.ED00006)
+			return
+		}
+		endTime = parsed
+	case req.DurationMinutes > 0:
+		endTime = startTime.Add(time.Duration(req.DurationMinutes) * time.Minute)
+	default:
+		response.ErrorJSON(ctx, "endTime和durationMinutes不能同时为空", constants.ED00007)
+		return
+	}
+	if !endTime.After(startTime) {
+		response.ErrorJSON(ctx, "静默结束时间必须晚于开始时间", constants.ED00006)
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	if req.AlertRuleId != "" {
+		if _, ok := c.loadRule(ctx, req.AlertRuleId); !ok {
+			return
+		}
+	}
+
+	operatorId := request.GetOperatorID(ctx)
+	silence := &models.AlertSilence{
+		TenantId:        tenantId,
+		AlertSilenceId:  random.Generate32BitRandomString(),
+		AlertRuleId:     strings.TrimSpace(req.AlertRuleId),
+		ApplicationName: strings.TrimSpace(req.ApplicationName),
+		JvmResourceId:   strings.TrimSpace(req.JvmResourceId),
+		StartTime:       startTime,
+		EndTime:         endTime,
+		ReasonText:      req.ReasonText,
+		AddTime:         now,
+		AddWho:          operatorId,
+		EditTime:        now,
+		EditWho:         operatorId,
+		OprSeqFlag:      random.Generate32BitRandomString(),
+		CurrentVersion:  1,
+		ActiveFlag:      "Y",
+	}
+	if err := c.dao.CreateAlertSilence(ctx, silence); err != nil {
+		logger.ErrorWithTrace(ctx, "创建告警静默失败", "error", err)
+		response.ErrorJSON(ctx, "创建告警静默失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	response.SuccessJSON(ctx, silence, constants.SD00003)
+}
+
+// DeleteAlertSilence 删除告警静默，下一轮评估起恢复通知
+func (c *JvmAlertController) DeleteAlertSilence(ctx *gin.Context) {
+	alertSilenceId := request.GetParam(ctx, "alertSilenceId")
+	if strings.TrimSpace(alertSilenceId) == "" {
+		response.ErrorJSON(ctx, "alertSilenceId不能为空", constants.ED00007)
+		return
+	}
+
+	if err := c.dao.DeleteAlertSilence(ctx, request.GetTenantID(ctx), alertSilenceId, request.GetOperatorID(ctx)); err != nil {
+		logger.ErrorWithTrace(ctx, "删除告警静默失败", "error", err)
+		response.ErrorJSON(ctx, "删除告警静默失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	response.SuccessJSON(ctx, gin.H{"alertSilenceId": alertSilenceId}, constants.SD00005)
+}
+
+// QueryAlertEvents 分页查询告警历史
+func (c *JvmAlertController) QueryAlertEvents(ctx *gin.Context) {
+	page, pageSize := request.GetPaginationParams(ctx)
+	tenantId := request.GetTenantID(ctx)
+
+	var q models.AlertEventListRequest
+	if err := request.BindSafely(ctx, &q); err != nil {
+		logger.WarnWithTrace(ctx, "绑定告警历史筛选条件失败，使用默认条件", "error", err.Error())
+	}
+
+	rows, total, err := c.dao.QueryAlertEvents(ctx, tenantId, &q, page, pageSize)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询告警历史失败", "error", err)
+		response.ErrorJSON(ctx, "查询告警历史失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	pageInfo := response.NewPageInfo(page, pageSize, total)
+	pageInfo.MainKey = "alertEventId"
+	response.PageJSON(ctx, rows, pageInfo, constants.SD00002)
+}
+
+// loadRule 加载告警规则，失败时已写入错误响应
+func (c *JvmAlertController) loadRule(ctx *gin.Context, alertRuleId string) (*models.AlertRule, bool) {
+	if strings.TrimSpace(alertRuleId) == "" {
+		response.ErrorJSON(ctx, "alertRuleId不能为空", constants.ED00007)
+		return nil, false
+	}
+
+	rule, err := c.dao.GetAlertRule(ctx, request.GetTenantID(ctx), alertRuleId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取告警规则失败", "error", err)
+		response.ErrorJSON(ctx, "获取告警规则失败: "+err.Error(), constants.ED00009)
+		return nil, false
+	}
+	if rule == nil {
+		response.ErrorJSON(ctx, "告警规则不存在", constants.ED00008)
+		return nil, false
+	}
+	return rule, true
+}
+
+// normalizeAlertRule 校验并补全告警规则的字段，返回错误信息和错误码，校验通过时错误信息为空
+func normalizeAlertRule(rule *models.AlertRule) (string, string) {
+	rule.RuleName = strings.TrimSpace(rule.RuleName)
+	if rule.RuleName == "" {
+		return "ruleName不能为空", constants.ED00007
+	}
+	if !dao.IsSupportedAlertMetric(rule.MetricType) {
+		return "不支持的监控指标: " + rule.MetricType, constants.ED00006
+	}
+
+	if rule.EvaluateMode == "" {
+		rule.EvaluateMode = models.AlertEvaluateThreshold
+	}
+	if rule.EvaluateMode != models.AlertEvaluateThreshold && rule.EvaluateMode != models.AlertEvaluateRate &&
+		rule.EvaluateMode != models.AlertEvaluateAnomaly {
+		return "不支持的评估方式: " + rule.EvaluateMode, constants.ED00006
+	}
+	if rule.CompareOperator == "" {
+		rule.CompareOperator = models.AlertOperatorGT
+	}
+	if _, ok := alertOperatorSymbols[rule.CompareOperator]; !ok {
+		return "不支持的比较运算符: " + rule.CompareOperator, constants.ED00006
+	}
+	if rule.DurationSeconds < 0 || rule.WindowSeconds < 0 || rule.RepeatIntervalSeconds < 0 {
+		return "持续时长、速率窗口和重复通知间隔不能为负数", constants.ED00006
+	}
+	if rule.EvaluateMode == models.AlertEvaluateRate && rule.WindowSeconds == 0 {
+		rule.WindowSeconds = defaultRateWindowSeconds
+	}
+	if rule.EvaluateMode == models.AlertEvaluateAnomaly {
+		if rule.ThresholdValue < 0 {
+			return "异常检测的标准差倍数不能为负数", constants.ED00006
+		}
+		if rule.ThresholdValue == 0 {
+			rule.ThresholdValue = defaultAnomalySigma
+		}
+		if rule.WindowSeconds == 0 {
+			rule.WindowSeconds = defaultAnomalyWindowSeconds
+		}
+	}
+
+	if rule.GroupBy == "" {
+		rule.GroupBy = models.AlertGroupByApplication
+	}
+	if rule.GroupBy != models.AlertGroupByApplication && rule.GroupBy != models.AlertGroupByInstance {
+		return "不支持的通知分组方式: " + rule.GroupBy, constants.ED00006
+	}
+	switch rule.AlertLevel {
+	case "":
+		rule.AlertLevel = "WARN"
+	case "INFO", "WARN", "ERROR", "CRITICAL":
+	default:
+		return "不支持的告警级别: " + rule.AlertLevel, constants.ED00006
+	}
+	if rule.NotifyResolvedFlag != "N" {
+		rule.NotifyResolvedFlag = "Y"
+	}
+	if rule.EnabledFlag != "N" {
+		rule.EnabledFlag = "Y"
+	}
+	return "", ""
+}
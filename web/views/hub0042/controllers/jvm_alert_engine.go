@@ -0,0 +1,465 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	alertInit "gateway/internal/alert/init"
+	"gateway/pkg/config"
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/pkg/timer"
+	"gateway/pkg/utils/random"
+	"gateway/web/views/hub0042/dao"
+	"gateway/web/views/hub0042/models"
+)
+
+// jvmAlertSchedulerId JVM告警评估调度器ID，所有租户的规则在同一个周期任务中评估
+const jvmAlertSchedulerId = "JVM_ALERT_scheduler"
+
+// jvmAlertTaskId JVM告警评估任务ID
+const jvmAlertTaskId = "JVM_ALERT_EVALUATE"
+
+// jvmAlertType 发送到告警服务的告警类型
+const jvmAlertType = "JVM_ALERT"
+
+// jvmAlertOperator 告警引擎写入事件时使用的操作人
+const jvmAlertOperator = "system"
+
+// JvmAlertEngine JVM告警规则引擎
+// 周期读取启用的告警规则，按规则查询 HUB_MONITOR_JVM_* 表的采集数据逐实例评估，
+// 告警状态持久化在 HUB_MONITOR_ALERT_EVENT（同一规则同一实例同时只有一条 FIRING 事件），
+// 同一规则同一分组（应用或实例）的触发和恢复合并为一条通知，通过告警服务发送到规则配置的渠道；
+// 匹配静默的事件照常记录但不通知
+type JvmAlertEngine struct {
+	dao *dao.JvmAlertDAO
+
+	mu sync.Mutex // 保证同一时刻只有一轮评估
+}
+
+// alertNotice 一轮评估中需要通知的事件
+type alertNotice struct {
+	event  *models.AlertEvent
+	status string // FIRING 或 RESOLVED
+}
+
+// NewJvmAlertEngine 创建JVM告警规则引擎
+func NewJvmAlertEngine(db database.Database) *JvmAlertEngine {
+	return &JvmAlertEngine{dao: dao.NewJvmAlertDAO(db)}
+}
+
+// Start 注册周期评估任务
+// 多节点部署时启用 app.timer.cluster 后每轮只在一个节点执行，未启用时通过 web.jvm_alert.enabled 只在一个节点开启，避免重复通知
+// 返回:
+//
+//	error: 创建调度器或注册任务失败时返回错误
+func (e *JvmAlertEngine) Start() error {
+	if !config.GetBool("web.jvm_alert.enabled", true) {
+		logger.Info("JVM告警评估未启用，跳过注册")
+		return nil
+	}
+
+	interval := time.Duration(config.GetInt("web.jvm_alert.interval_seconds", 60)) * time.Second
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	pool := timer.GetTimerPool()
+	scheduler, err := pool.GetScheduler(jvmAlertSchedulerId)
+	if err != nil {
+		scheduler, err = pool.CreateScheduler(&timer.SchedulerConfig{
+			ID:               jvmAlertSchedulerId,
+			Name:             "JVM告警评估调度器",
+			MaxWorkers:       1,
+			QueueSize:        10,
+			DefaultTimeout:   interval,
+			DefaultRetries:   0,
+			ScheduleInterval: time.Second,
+			Tasks:            make(map[string]*timer.TaskConfig),
+		})
+		if err != nil {
+			return fmt.Errorf("创建JVM告警评估调度器失败: %w", err)
+		}
+	}
+
+	taskConfig := timer.NewTaskConfig(jvmAlertTaskId, "JVM告警评估", timer.ScheduleTypeInterval)
+	taskConfig.Description = "按告警规则评估JVM监控数据并发送通知"
+	taskConfig.Interval = interval
+	taskConfig.Timeout = interval
+	if err := scheduler.AddTask(taskConfig, &jvmAlertTaskExecutor{engine: e}); err != nil {
+		return fmt.Errorf("注册JVM告警评估任务失败: %w", err)
+	}
+	if !scheduler.IsRunning() {
+		if err := scheduler.Start(); err != nil {
+			return fmt.Errorf("启动JVM告警评估调度器失败: %w", err)
+		}
+	}
+	logger.Info("JVM告警评估任务注册完成", "interval", interval.String())
+	return nil
+}
+
+// EvaluateAll 评估所有启用的告警规则
+// 上一轮评估未完成时跳过本轮；单条规则评估失败只记录日志，不影响其他规则
+// 参数:
+//
+//	ctx: 上下文对象
+//
+// 返回:
+//
+//	int: 本轮评估的规则数
+//	error: 查询规则失败时返回错误
+func (e *JvmAlertEngine) EvaluateAll(ctx context.Context) (int, error) {
+	if !e.mu.TryLock() {
+		logger.Warn("上一轮JVM告警评估尚未完成，跳过本轮")
+		return 0, nil
+	}
+	defer e.mu.Unlock()
+
+	rules, err := e.dao.ListEnabledAlertRules(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	interval := time.Duration(config.GetInt("web.jvm_alert.interval_seconds", 60)) * time.Second
+	silencesByTenant := make(map[string][]*models.AlertSilence)
+	for _, rule := range rules {
+		silences, loaded := silencesByTenant[rule.TenantId]
+		if !loaded {
+			silences, err = e.dao.ListActiveSilences(ctx, rule.TenantId, now)
+			if err != nil {
+				logger.Error("查询告警静默失败，本轮按无静默处理", "tenantId", rule.TenantId, "error", err)
+			}
+			silencesByTenant[rule.TenantId] = silences
+		}
+
+		if err := e.evaluateRule(ctx, rule, silences, now, interval); err != nil {
+			logger.Error("JVM告警规则评估失败", "tenantId", rule.TenantId, "alertRuleId", rule.AlertRuleId, "error", err)
+		}
+	}
+	return len(rules), nil
+}
+
+// evaluateRule 评估单条规则并更新告警事件，需要通知的事件按分组发送
+func (e *JvmAlertEngine) evaluateRule(ctx context.Context, rule *models.AlertRule, silences []*models.AlertSilence, now time.Time, interval time.Duration) error {
+	samples, err := e.dao.ListMetricSamples(ctx, rule, now.Add(-alertLookback(rule, interval)))
+	if err != nil {
+		return err
+	}
+	firingEvents, err := e.dao.ListFiringEvents(ctx, rule.TenantId, rule.AlertRuleId)
+	if err != nil {
+		return err
+	}
+	firing := make(map[string]*models.AlertEvent, len(firingEvents))
+	for _, event := range firingEvents {
+		firing[event.JvmResourceId] = event
+	}
+
+	evaluations := evaluateAlertRule(rule, samples)
+	notices := make([]*alertNotice, 0)
+
+	// 触发或持续告警
+	resourceIds := make([]string, 0, len(evaluations))
+	for resourceId := range evaluations {
+		resourceIds = append(resourceIds, resourceId)
+	}
+	sort.Strings(resourceIds)
+	anomalous := make([]string, 0)
+	for _, resourceId := range resourceIds {
+		evaluation := evaluations[resourceId]
+		if !evaluation.ok || !evaluation.breached {
+			continue
+		}
+		if rule.EvaluateMode == models.AlertEvaluateAnomaly {
+			anomalous = append(anomalous, resourceId)
+		}
+		silenced := isAlertSilenced(silences, rule, evaluation.sample, now)
+
+		event, exists := firing[resourceId]
+		if !exists {
+			event = newAlertEvent(rule, evaluation, now)
+			event.SilencedFlag = boolFlag(silenced)
+			if err := e.dao.CreateAlertEvent(ctx, event); err != nil {
+				return err
+			}
+			if !silenced {
+				notices = append(notices, &alertNotice{event: event, status: models.AlertEventFiring})
+			}
+			continue
+		}
+
+		event.MetricValue = evaluation.value
+		event.LastTriggerTime = now
+		event.SilencedFlag = boolFlag(silenced)
+		event.MessageText = describeAlertCondition(rule)
+		event.EditTime = now
+		event.EditWho = jvmAlertOperator
+		if err := e.dao.UpdateAlertEvent(ctx, event); err != nil {
+			return err
+		}
+		if !silenced && shouldRepeatNotify(rule, event, now) {
+			notices = append(notices, &alertNotice{event: event, status: models.AlertEventFiring})
+		}
+	}
+
+	// 检测到异常的实例标记为需要立即关注，在监控概览中统计
+	if err := e.dao.MarkRequiresAttention(ctx, rule.TenantId, anomalous, now); err != nil {
+		logger.Warn("标记异常实例失败", "alertRuleId", rule.AlertRuleId, "error", err)
+	}
+
+	// 恢复：条件不再满足，或实例在回溯时间内没有采集数据
+	for resourceId, event := range firing {
+		if evaluation, exists := evaluations[resourceId]; exists && (!evaluation.ok || evaluation.breached) {
+			continue
+		}
+		resolvedTime := now
+		event.EventStatus = models.AlertEventResolved
+		event.ResolvedTime = &resolvedTime
+		event.EditTime = now
+		event.EditWho = jvmAlertOperator
+		if evaluation, exists := evaluations[resourceId]; exists {
+			event.MetricValue = evaluation.value
+		} else {
+			event.MessageText = describeAlertCondition(rule) + "（实例无最新采集数据）"
+		}
+		if err := e.dao.UpdateAlertEvent(ctx, event); err != nil {
+			return err
+		}
+		silenced := isAlertSilenced(silences, rule, &models.JvmMetricSample{JvmResourceId: event.JvmResourceId, ApplicationName: event.ApplicationName}, now)
+		// 未通知过触发的事件（例如一直被静默）也不通知恢复
+		if rule.NotifyResolvedFlag == "Y" && !silenced && event.NotifyCount > 0 {
+			notices = append(notices, &alertNotice{event: event, status: models.AlertEventResolved})
+		}
+	}
+
+	e.notify(ctx, rule, notices, now)
+
+	if err := e.dao.UpdateLastEvalTime(ctx, rule.TenantId, rule.AlertRuleId, now); err != nil {
+		logger.Warn("更新规则评估时间失败", "alertRuleId", rule.AlertRuleId, "error", err)
+	}
+	return nil
+}
+
+// notify 按 状态+分组键 合并通知并发送，发送后回写事件的通知信息
+func (e *JvmAlertEngine) notify(ctx context.Context, rule *models.AlertRule, notices []*alertNotice, now time.Time) {
+	if len(notices) == 0 {
+		return
+	}
+	svc := alertInit.GetAlertService()
+	if svc == nil {
+		logger.Warn("告警服务未初始化，JVM告警仅记录事件", "alertRuleId", rule.AlertRuleId, "count", len(notices))
+		return
+	}
+
+	groups := groupAlertNotices(notices)
+	for _, group := range groups {
+		title, content, tableData := buildAlertMessage(rule, group, now)
+		level := rule.AlertLevel
+		if group[0].status == models.AlertEventResolved {
+			level = "INFO"
+		}
+		tags := map[string]string{
+			"alertRuleId": rule.AlertRuleId,
+			"metricType":  rule.MetricType,
+			"status":      group[0].status,
+			"groupKey":    group[0].event.GroupKey,
+		}
+
+		alertLogId, err := svc.SendAlert(ctx, level, jvmAlertType, title, content, rule.ChannelName, tags, nil, tableData)
+		if err != nil {
+			logger.Error("发送JVM告警通知失败", "alertRuleId", rule.AlertRuleId, "groupKey", group[0].event.GroupKey, "error", err)
+			continue
+		}
+
+		for _, notice := range group {
+			notifyTime := now
+			notice.event.NotifyCount++
+			notice.event.LastNotifyTime = &notifyTime
+			notice.event.AlertLogId = alertLogId
+			if err := e.dao.UpdateAlertEvent(ctx, notice.event); err != nil {
+				logger.Warn("回写告警事件通知信息失败", "alertEventId", notice.event.AlertEventId, "error", err)
+			}
+		}
+	}
+}
+
+// groupAlertNotices 按 状态+分组键 合并通知，分组和组内事件按键排序保证通知内容稳定
+func groupAlertNotices(notices []*alertNotice) [][]*alertNotice {
+	grouped := make(map[string][]*alertNotice)
+	keys := make([]string, 0)
+	for _, notice := range notices {
+		key := notice.status + "|" + notice.event.GroupKey
+		if _, exists := grouped[key]; !exists {
+			keys = append(keys, key)
+		}
+		grouped[key] = append(grouped[key], notice)
+	}
+	sort.Strings(keys)
+
+	groups := make([][]*alertNotice, 0, len(keys))
+	for _, key := range keys {
+		group := grouped[key]
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].event.JvmResourceId < group[j].event.JvmResourceId
+		})
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// buildAlertMessage 构建一组通知的标题、内容和表格数据
+func buildAlertMessage(rule *models.AlertRule, group []*alertNotice, now time.Time) (string, string, map[string]interface{}) {
+	first := group[0].event
+	statusText := "告警"
+	if group[0].status == models.AlertEventResolved {
+		statusText = "恢复"
+	}
+
+	target := first.ApplicationName
+	if rule.GroupBy == models.AlertGroupByInstance {
+		target = fmt.Sprintf("%s(%s)", first.ApplicationName, first.HostIpAddress)
+	}
+	title := fmt.Sprintf("JVM%s - %s - %s", statusText, rule.RuleName, target)
+
+	lines := make([]string, 0, len(group))
+	for _, notice := range group {
+		event := notice.event
+		lines = append(lines, fmt.Sprintf("%s %s(%s) 当前值: %.2f",
+			event.ApplicationName, event.HostName, event.HostIpAddress, event.MetricValue))
+	}
+	content := fmt.Sprintf("告警条件: %s\n%s", describeAlertCondition(rule), strings.Join(lines, "\n"))
+
+	tableData := map[string]interface{}{
+		"规则名称": rule.RuleName,
+		"告警条件": describeAlertCondition(rule),
+		"应用名称": first.ApplicationName,
+		"实例数":  len(group),
+		"状态":   statusText,
+		"首次触发": first.FirstTriggerTime.Format("2006-01-02 15:04:05"),
+		"通知时间": now.Format("2006-01-02 15:04:05"),
+	}
+	return title, content, tableData
+}
+
+// newAlertEvent 根据评估结果创建 FIRING 事件
+func newAlertEvent(rule *models.AlertRule, evaluation *alertEvaluation, now time.Time) *models.AlertEvent {
+	sample := evaluation.sample
+	return &models.AlertEvent{
+		TenantId:         rule.TenantId,
+		AlertEventId:     random.Generate32BitRandomString(),
+		AlertRuleId:      rule.AlertRuleId,
+		RuleName:         rule.RuleName,
+		MetricType:       rule.MetricType,
+		AlertLevel:       rule.AlertLevel,
+		JvmResourceId:    sample.JvmResourceId,
+		ApplicationName:  sample.ApplicationName,
+		HostName:         sample.HostName,
+		HostIpAddress:    sample.HostIpAddress,
+		GroupKey:         alertGroupKey(rule, sample),
+		EventStatus:      models.AlertEventFiring,
+		MetricValue:      evaluation.value,
+		ThresholdValue:   rule.ThresholdValue,
+		MessageText:      describeAlertCondition(rule),
+		FirstTriggerTime: now,
+		LastTriggerTime:  now,
+		SilencedFlag:     "N",
+		AddTime:          now,
+		AddWho:           jvmAlertOperator,
+		EditTime:         now,
+		EditWho:          jvmAlertOperator,
+		OprSeqFlag:       random.Generate32BitRandomString(),
+		CurrentVersion:   1,
+		ActiveFlag:       "Y",
+	}
+}
+
+// alertGroupKey 通知分组键：按实例分组时为JVM资源ID，否则为应用名称
+func alertGroupKey(rule *models.AlertRule, sample *models.JvmMetricSample) string {
+	if rule.GroupBy == models.AlertGroupByInstance {
+		return sample.JvmResourceId
+	}
+	return sample.ApplicationName
+}
+
+// alertLookback 每轮评估回溯的采集数据时长：持续时长、速率窗口或异常检测基线窗口再加两个评估周期，保证能取到窗口起点前的采集点
+func alertLookback(rule *models.AlertRule, interval time.Duration) time.Duration {
+	span := time.Duration(rule.DurationSeconds) * time.Second
+	if rule.EvaluateMode == models.AlertEvaluateRate {
+		windowSeconds := rule.WindowSeconds
+		if windowSeconds <= 0 {
+			windowSeconds = defaultRateWindowSeconds
+		}
+		span = time.Duration(windowSeconds) * time.Second
+	}
+	if rule.EvaluateMode == models.AlertEvaluateAnomaly {
+		windowSeconds := rule.WindowSeconds
+		if windowSeconds <= 0 {
+			windowSeconds = defaultAnomalyWindowSeconds
+		}
+		span = time.Duration(windowSeconds) * time.Second
+	}
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return span + 2*interval
+}
+
+// shouldRepeatNotify 持续告警是否到了重复通知时间，从未通知过（例如静默结束）时立即通知
+func shouldRepeatNotify(rule *models.AlertRule, event *models.AlertEvent, now time.Time) bool {
+	if event.LastNotifyTime == nil {
+		return true
+	}
+	if rule.RepeatIntervalSeconds <= 0 {
+		return false
+	}
+	return !now.Before(event.LastNotifyTime.Add(time.Duration(rule.RepeatIntervalSeconds) * time.Second))
+}
+
+// isAlertSilenced 是否有生效的静默覆盖该规则和实例
+func isAlertSilenced(silences []*models.AlertSilence, rule *models.AlertRule, sample *models.JvmMetricSample, now time.Time) bool {
+	for _, silence := range silences {
+		if silence.Matches(rule.AlertRuleId, sample.ApplicationName, sample.JvmResourceId, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// boolFlag 布尔值转换为 Y/N 标记
+func boolFlag(value bool) string {
+	if value {
+		return "Y"
+	}
+	return "N"
+}
+
+// jvmAlertTaskExecutor JVM告警评估任务执行器，实现 timer.TaskExecutor 接口
+type jvmAlertTaskExecutor struct {
+	engine *JvmAlertEngine
+}
+
+// Execute 评估所有启用的告警规则
+func (e *jvmAlertTaskExecutor) Execute(ctx context.Context, params interface{}) (*timer.ExecuteResult, error) {
+	count, err := e.engine.EvaluateAll(ctx)
+	if err != nil {
+		return &timer.ExecuteResult{Success: false, Message: err.Error()}, err
+	}
+	return &timer.ExecuteResult{
+		Success: true,
+		Message: fmt.Sprintf("评估告警规则 %d 条", count),
+	}, nil
+}
+
+// GetName 执行器名称
+func (e *jvmAlertTaskExecutor) GetName() string {
+	return "JvmAlertEvaluate"
+}
+
+// Close 无需释放资源
+func (e *jvmAlertTaskExecutor) Close() error {
+	return nil
+}
@@ -0,0 +1,293 @@
+package controllers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"gateway/web/views/hub0042/models"
+)
+
+// defaultRateWindowSeconds 速率方式未配置窗口时使用的默认窗口（秒）
+const defaultRateWindowSeconds = 300
+
+// 异常检测参数
+const (
+	defaultAnomalyWindowSeconds = 3600 // 未配置基线窗口时使用的默认窗口（秒）
+	defaultAnomalySigma         = 3.0  // 未配置阈值时使用的标准差倍数
+	anomalyMinBaselinePoints    = 10   // 基线窗口内至少需要的数据点数
+	anomalyMinStdRatio          = 0.05 // 标准差下限占基线均值绝对值的比例，避免平稳序列的微小波动被判为异常
+)
+
+// alertMetricLabels 监控指标的显示名称
+var alertMetricLabels = map[string]string{
+	models.AlertMetricHeapUsage:    "堆内存使用率(%)",
+	models.AlertMetricNonHeapUsage: "非堆内存使用率(%)",
+	models.AlertMetricFGCCount:     "Full GC次数",
+	models.AlertMetricThreadCount:  "线程数",
+	models.AlertMetricDeadlock:     "死锁线程数",
+
+	models.AlertMetricGCCount:          "GC次数",
+	models.AlertMetricGatewayErrorRate: "网关5xx错误率(%)",
+}
+
+// anomalyDeltaMetrics 异常检测时按相邻采集点的每分钟变化量建立基线的指标：
+// 累积的GC次数即GC频率，内存使用率即增长速度；其余指标直接使用采集值
+var anomalyDeltaMetrics = map[string]bool{
+	models.AlertMetricFGCCount:     true,
+	models.AlertMetricGCCount:      true,
+	models.AlertMetricHeapUsage:    true,
+	models.AlertMetricNonHeapUsage: true,
+}
+
+// isCumulativeAlertMetric 指标是否为累积值，累积值回退说明JVM已重启
+func isCumulativeAlertMetric(metricType string) bool {
+	return metricType == models.AlertMetricFGCCount || metricType == models.AlertMetricGCCount
+}
+
+// alertOperatorSymbols 比较运算符的显示符号
+var alertOperatorSymbols = map[string]string{
+	models.AlertOperatorGT:  ">",
+	models.AlertOperatorGTE: ">=",
+	models.AlertOperatorLT:  "<",
+	models.AlertOperatorLTE: "<=",
+}
+
+// alertEvaluation 单个JVM实例的规则评估结果
+type alertEvaluation struct {
+	sample   *models.JvmMetricSample // 最新采集点，提供实例信息和采集时间
+	value    float64                 // 参与比较的值：阈值方式为最新采集值，速率方式为每分钟变化量，异常检测方式为最新序列值
+	ok       bool                    // 数据是否足够得出结论，false时保持实例原有告警状态
+	breached bool                    // 是否满足告警条件
+}
+
+// evaluateAlertRule 按实例评估规则
+// 阈值方式：durationSeconds 为0时比较最新采集值；否则要求从 (最新采集时间 - durationSeconds) 时刻起的所有采集点都满足条件，
+// 采集历史不足持续时长且都满足条件时结果为待定（ok=false）
+// 速率方式：取窗口起点前最近一个采集点（没有时取最早的采集点）到最新采集点的每分钟变化量，
+// 累积指标（GC次数）出现回退说明JVM已重启，结果为待定
+// 异常检测方式：见 evaluateAnomaly
+// 参数:
+//
+//	rule: 告警规则
+//	samples: 规则匹配范围内的采集数据，顺序不限
+//
+// 返回:
+//
+//	map[string]*alertEvaluation: JVM资源ID -> 评估结果，没有采集数据的实例不在结果中
+func evaluateAlertRule(rule *models.AlertRule, samples []*models.JvmMetricSample) map[string]*alertEvaluation {
+	byResource := make(map[string][]*models.JvmMetricSample)
+	for _, sample := range samples {
+		if sample == nil {
+			continue
+		}
+		byResource[sample.JvmResourceId] = append(byResource[sample.JvmResourceId], sample)
+	}
+
+	results := make(map[string]*alertEvaluation, len(byResource))
+	for resourceId, resourceSamples := range byResource {
+		sort.Slice(resourceSamples, func(i, j int) bool {
+			return resourceSamples[i].CollectionTime.Before(resourceSamples[j].CollectionTime)
+		})
+		switch rule.EvaluateMode {
+		case models.AlertEvaluateRate:
+			results[resourceId] = evaluateRate(rule, resourceSamples)
+		case models.AlertEvaluateAnomaly:
+			results[resourceId] = evaluateAnomaly(rule, resourceSamples)
+		default:
+			results[resourceId] = evaluateThreshold(rule, resourceSamples)
+		}
+	}
+	return results
+}
+
+// evaluateThreshold 阈值方式评估，samples 已按采集时间升序
+func evaluateThreshold(rule *models.AlertRule, samples []*models.JvmMetricSample) *alertEvaluation {
+	latest := samples[len(samples)-1]
+	result := &alertEvaluation{sample: latest, value: latest.MetricValue, ok: true}
+	if !compareAlertValue(rule.CompareOperator, latest.MetricValue, rule.ThresholdValue) {
+		return result
+	}
+	if rule.DurationSeconds <= 0 {
+		result.breached = true
+		return result
+	}
+
+	since := latest.CollectionTime.Add(-time.Duration(rule.DurationSeconds) * time.Second)
+	for i := len(samples) - 1; i >= 0; i-- {
+		if !compareAlertValue(rule.CompareOperator, samples[i].MetricValue, rule.ThresholdValue) {
+			return result
+		}
+		if !samples[i].CollectionTime.After(since) {
+			// 持续时长起点前的采集点也满足条件，说明整段时间都满足
+			result.breached = true
+			return result
+		}
+	}
+	// 采集历史不足持续时长
+	result.ok = false
+	return result
+}
+
+// evaluateRate 速率方式评估，samples 已按采集时间升序
+func evaluateRate(rule *models.AlertRule, samples []*models.JvmMetricSample) *alertEvaluation {
+	latest := samples[len(samples)-1]
+	result := &alertEvaluation{sample: latest}
+
+	windowSeconds := rule.WindowSeconds
+	if windowSeconds <= 0 {
+		windowSeconds = defaultRateWindowSeconds
+	}
+	windowStart := latest.CollectionTime.Add(-time.Duration(windowSeconds) * time.Second)
+
+	base := samples[0]
+	for i := len(samples) - 2; i >= 0; i-- {
+		if !samples[i].CollectionTime.After(windowStart) {
+			base = samples[i]
+			break
+		}
+	}
+
+	elapsed := latest.CollectionTime.Sub(base.CollectionTime)
+	if elapsed <= 0 {
+		return result
+	}
+	delta := latest.MetricValue - base.MetricValue
+	if delta < 0 && isCumulativeAlertMetric(rule.MetricType) {
+		return result
+	}
+
+	result.value = delta / elapsed.Minutes()
+	result.ok = true
+	result.breached = compareAlertValue(rule.CompareOperator, result.value, rule.ThresholdValue)
+	return result
+}
+
+// evaluateAnomaly 异常检测方式评估，samples 已按采集时间升序
+// 序列为采集值，或按 anomalyDeltaMetrics 取相邻采集点的每分钟变化量（累积值回退的区间跳过）；
+// 以最新点之前基线窗口内的序列计算均值和标准差，最新点的 z-score 超过阈值倍数时告警，
+// GT/GTE 检测向上偏离，LT/LTE 检测向下偏离；基线数据点不足时结果为待定
+func evaluateAnomaly(rule *models.AlertRule, samples []*models.JvmMetricSample) *alertEvaluation {
+	latest := samples[len(samples)-1]
+	result := &alertEvaluation{sample: latest}
+
+	type point struct {
+		time  time.Time
+		value float64
+	}
+	series := make([]point, 0, len(samples))
+	if anomalyDeltaMetrics[rule.MetricType] {
+		for i := 1; i < len(samples); i++ {
+			elapsed := samples[i].CollectionTime.Sub(samples[i-1].CollectionTime)
+			delta := samples[i].MetricValue - samples[i-1].MetricValue
+			if elapsed <= 0 || (delta < 0 && isCumulativeAlertMetric(rule.MetricType)) {
+				continue
+			}
+			series = append(series, point{time: samples[i].CollectionTime, value: delta / elapsed.Minutes()})
+		}
+	} else {
+		for _, sample := range samples {
+			series = append(series, point{time: sample.CollectionTime, value: sample.MetricValue})
+		}
+	}
+	if len(series) == 0 || !series[len(series)-1].time.Equal(latest.CollectionTime) {
+		// 最新采集点没有可用的序列值（例如JVM刚重启）
+		return result
+	}
+
+	current := series[len(series)-1]
+	windowSeconds := rule.WindowSeconds
+	if windowSeconds <= 0 {
+		windowSeconds = defaultAnomalyWindowSeconds
+	}
+	windowStart := current.time.Add(-time.Duration(windowSeconds) * time.Second)
+	var sum, sumSquares float64
+	count := 0
+	for _, p := range series[:len(series)-1] {
+		if p.time.Before(windowStart) {
+			continue
+		}
+		sum += p.value
+		sumSquares += p.value * p.value
+		count++
+	}
+	if count < anomalyMinBaselinePoints {
+		return result
+	}
+
+	mean := sum / float64(count)
+	std := math.Sqrt(math.Max(sumSquares/float64(count)-mean*mean, 0))
+	std = math.Max(std, math.Max(anomalyMinStdRatio*math.Abs(mean), 1e-6))
+	sigma := rule.ThresholdValue
+	if sigma <= 0 {
+		sigma = defaultAnomalySigma
+	}
+
+	z := (current.value - mean) / std
+	result.value = current.value
+	result.ok = true
+	switch rule.CompareOperator {
+	case models.AlertOperatorLT, models.AlertOperatorLTE:
+		result.breached = compareAlertValue(rule.CompareOperator, z, -sigma)
+	default:
+		result.breached = compareAlertValue(rule.CompareOperator, z, sigma)
+	}
+	return result
+}
+
+// compareAlertValue 按运算符比较指标值和阈值，未知运算符按大于处理
+func compareAlertValue(operator string, value, threshold float64) bool {
+	switch operator {
+	case models.AlertOperatorGTE:
+		return value >= threshold
+	case models.AlertOperatorLT:
+		return value < threshold
+	case models.AlertOperatorLTE:
+		return value <= threshold
+	default:
+		return value > threshold
+	}
+}
+
+// describeAlertCondition 生成规则条件的描述，例如 "堆内存使用率(%) > 85，持续300秒"
+func describeAlertCondition(rule *models.AlertRule) string {
+	label := alertMetricLabels[rule.MetricType]
+	if label == "" {
+		label = rule.MetricType
+	}
+	symbol := alertOperatorSymbols[rule.CompareOperator]
+	if symbol == "" {
+		symbol = ">"
+	}
+
+	if rule.EvaluateMode == models.AlertEvaluateRate {
+		windowSeconds := rule.WindowSeconds
+		if windowSeconds <= 0 {
+			windowSeconds = defaultRateWindowSeconds
+		}
+		return fmt.Sprintf("%s 每分钟变化量 %s %g（窗口%d秒）", label, symbol, rule.ThresholdValue, windowSeconds)
+	}
+	if rule.EvaluateMode == models.AlertEvaluateAnomaly {
+		windowSeconds := rule.WindowSeconds
+		if windowSeconds <= 0 {
+			windowSeconds = defaultAnomalyWindowSeconds
+		}
+		sigma := rule.ThresholdValue
+		if sigma <= 0 {
+			sigma = defaultAnomalySigma
+		}
+		if anomalyDeltaMetrics[rule.MetricType] {
+			label += " 每分钟变化量"
+		}
+		direction := "向上"
+		if rule.CompareOperator == models.AlertOperatorLT || rule.CompareOperator == models.AlertOperatorLTE {
+			direction = "向下"
+		}
+		return fmt.Sprintf("%s %s偏离基线超过%g倍标准差（基线窗口%d秒）", label, direction, sigma, windowSeconds)
+	}
+	if rule.DurationSeconds > 0 {
+		return fmt.Sprintf("%s %s %g，持续%d秒", label, symbol, rule.ThresholdValue, rule.DurationSeconds)
+	}
+	return fmt.Sprintf("%s %s %g", label, symbol, rule.ThresholdValue)
+}
@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"strings"
+	"time"
+
+	"gateway/pkg/config"
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/pkg/utils/random"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0042/dao"
+	"gateway/web/views/hub0042/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jvmDiagMaxOpenTasks 单个JVM实例同时未完成的诊断任务上限，避免重复点击堆积采集请求
+const jvmDiagMaxOpenTasks = 3
+
+// jvmDiagTimeout 诊断任务从创建到完成的最长时间，读取 web.jvm_ingest.diag_timeout_seconds
+func jvmDiagTimeout() time.Duration {
+	seconds := config.GetInt("web.jvm_ingest.diag_timeout_seconds", 600)
+	if seconds <= 0 {
+		seconds = 600
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// JvmDiagController JVM诊断采集控制器
+// 运维人员为JVM实例创建线程转储/类直方图采集任务，任务在采集端下次上报快照时随响应下发，
+// 采集端执行后通过 submitJvmDiagResult 提交结果，详情接口返回解析后的线程和类直方图
+type JvmDiagController struct {
+	dao *dao.JvmDiagDAO
+}
+
+// NewJvmDiagController 创建JVM诊断采集控制器
+func NewJvmDiagController(db database.Database) *JvmDiagController {
+	return &JvmDiagController{dao: dao.NewJvmDiagDAO(db)}
+}
+
+// AddJvmDiagTask 创建诊断采集任务
+func (c *JvmDiagController) AddJvmDiagTask(ctx *gin.Context) {
+	var req models.JvmDiagTaskRequest
+	if err := request.BindSafely(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+	req.JvmResourceId = strings.TrimSpace(req.JvmResourceId)
+	if req.JvmResourceId == "" {
+		response.ErrorJSON(ctx, "jvmResourceId不能为空", constants.ED00007)
+		return
+	}
+	if req.DiagType != models.JvmDiagThreadDump && req.DiagType != models.JvmDiagHeapHistogram {
+		response.ErrorJSON(ctx, "不支持的诊断类型: "+req.DiagType, constants.ED00006)
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	applicationName, found, err := c.dao.GetResourceApplication(ctx, tenantId, req.JvmResourceId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询JVM资源失败", "error", err)
+		response.ErrorJSON(ctx, "创建诊断任务失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if !found {
+		response.ErrorJSON(ctx, "JVM实例不存在", constants.ED00008)
+		return
+	}
+
+	now := time.Now()
+	if err := c.dao.ExpireTasks(ctx, tenantId, now); err != nil {
+		logger.WarnWithTrace(ctx, "更新过期诊断任务失败", "error", err)
+	}
+	open, err := c.dao.CountOpenTasks(ctx, tenantId, req.JvmResourceId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "统计未完成的诊断任务失败", "error", err)
+		response.ErrorJSON(ctx, "创建诊断任务失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if open >= jvmDiagMaxOpenTasks {
+		response.ErrorJSON(ctx, "该实例未完成的诊断任务过多，请等待采集端执行", constants.ED00006)
+		return
+	}
+
+	operatorId := request.GetOperatorID(ctx)
+	task := &models.JvmDiagTask{
+		TenantId:        tenantId,
+		DiagTaskId:      random.Generate32BitRandomString(),
+		JvmResourceId:   req.JvmResourceId,
+		ApplicationName: applicationName,
+		DiagType:        req.DiagType,
+		TaskStatus:      models.JvmDiagPending,
+		RequestTime:     now,
+		ExpireTime:      now.Add(jvmDiagTimeout()),
+		AddTime:         now,
+		AddWho:          operatorId,
+		EditTime:        now,
+		EditWho:         operatorId,
+		CurrentVersion:  1,
+		ActiveFlag:      "Y",
+	}
+	task.OprSeqFlag = task.DiagTaskId
+	if note := strings.TrimSpace(req.NoteText); note != "" {
+		task.NoteText = &note
+	}
+	if err := c.dao.CreateTask(ctx, task); err != nil {
+		logger.ErrorWithTrace(ctx, "创建诊断任务失败", "error", err)
+		response.ErrorJSON(ctx, "创建诊断任务失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	logger.InfoWithTrace(ctx, "创建JVM诊断任务", "diagTaskId", task.DiagTaskId, "jvmResourceId", task.JvmResourceId,
+		"diagType", task.DiagType, "operatorId", operatorId)
+	response.SuccessJSON(ctx, task, constants.SD00003)
+}
+
+// QueryJvmDiagTasks 分页查询诊断任务，不返回结果原文
+func (c *JvmDiagController) QueryJvmDiagTasks(ctx *gin.Context) {
+	page, pageSize := request.GetPaginationParams(ctx)
+	tenantId := request.GetTenantID(ctx)
+
+	var q models.JvmDiagTaskListRequest
+	if err := request.BindSafely(ctx, &q); err != nil {
+		logger.WarnWithTrace(ctx, "绑定诊断任务筛选条件失败，使用默认条件", "error", err.Error())
+	}
+
+	if err := c.dao.ExpireTasks(ctx, tenantId, time.Now()); err != nil {
+		logger.WarnWithTrace(ctx, "更新过期诊断任务失败", "error", err)
+	}
+	rows, total, err := c.dao.QueryTasks(ctx, tenantId, &q, page, pageSize)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询诊断任务失败", "error", err)
+		response.ErrorJSON(ctx, "查询诊断任务失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	pageInfo := response.NewPageInfo(page, pageSize, total)
+	pageInfo.MainKey = "diagTaskId"
+	response.PageJSON(ctx, rows, pageInfo, constants.SD00002)
+}
+
+// GetJvmDiagTask 获取诊断任务详情
+// 已完成的线程转储解析出线程列表和状态统计，BLOCKED 线程及持有其等待锁的线程排在前面并标记高亮；
+// 已完成的类直方图按占用字节数排序返回
+func (c *JvmDiagController) GetJvmDiagTask(ctx *gin.Context) {
+	diagTaskId := strings.TrimSpace(request.GetParam(ctx, "diagTaskId"))
+	if diagTaskId == "" {
+		response.ErrorJSON(ctx, "diagTaskId不能为空", constants.ED00007)
+		return
+	}
+
+	task, err := c.dao.GetTask(ctx, request.GetTenantID(ctx), diagTaskId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取诊断任务失败", "error", err)
+		response.ErrorJSON(ctx, "获取诊断任务失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if task == nil {
+		response.ErrorJSON(ctx, "诊断任务不存在", constants.ED00008)
+		return
+	}
+
+	detail := &models.JvmDiagTaskDetail{Task: task}
+	if task.TaskStatus == models.JvmDiagCompleted && task.ResultText != nil {
+		switch task.DiagType {
+		case models.JvmDiagThreadDump:
+			detail.Threads, detail.StateCounts = parseThreadDump(*task.ResultText)
+		case models.JvmDiagHeapHistogram:
+			detail.Histogram = parseHeapHistogram(*task.ResultText)
+		}
+	}
+	response.SuccessJSON(ctx, detail, constants.SD00001)
+}
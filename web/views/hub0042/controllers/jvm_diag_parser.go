@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"bufio"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gateway/web/views/hub0042/models"
+)
+
+// histogramMaxEntries 类直方图详情最多返回的类数
+const histogramMaxEntries = 500
+
+var (
+	// threadStatePattern 线程状态行，例如 "   java.lang.Thread.State: BLOCKED (on object monitor)"
+	threadStatePattern = regexp.MustCompile(`^\s*java\.lang\.Thread\.State:\s*([A-Z_]+)`)
+	// monitorAddressPattern 锁信息行中的监视器地址，例如 "- waiting to lock <0x000000076ab62208>"
+	monitorAddressPattern = regexp.MustCompile(`<(0x[0-9a-fA-F]+)>`)
+	// histogramLinePattern 类直方图数据行，例如 "   1:         12345        1234567  [B (java.base@11)"
+	histogramLinePattern = regexp.MustCompile(`^\s*(\d+):\s+(\d+)\s+(\d+)\s+(.+?)\s*$`)
+)
+
+// parseThreadDump 解析 jstack 格式的线程转储
+// 线程以 `"线程名" ...` 开头的行分隔，状态取 java.lang.Thread.State 行；没有状态行的线程（例如 GC 线程）状态为空。
+// BLOCKED 线程和持有其等待的锁的线程排在前面，其余线程保持原顺序
+//
+// 返回:
+//
+//	[]*models.JvmThreadDumpEntry: 线程列表
+//	map[string]int: 各状态的线程数，没有状态的线程计入 UNKNOWN
+func parseThreadDump(text string) ([]*models.JvmThreadDumpEntry, map[string]int) {
+	threads := make([]*models.JvmThreadDumpEntry, 0)
+	var current *models.JvmThreadDumpEntry
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, `"`) {
+			current = newThreadDumpEntry(line)
+			threads = append(threads, current)
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if match := threadStatePattern.FindStringSubmatch(line); match != nil {
+			current.State = match[1]
+			continue
+		}
+		current.StackTrace = append(current.StackTrace, trimmed)
+		if address := monitorAddressPattern.FindStringSubmatch(trimmed); address != nil {
+			switch {
+			case strings.HasPrefix(trimmed, "- waiting to lock"):
+				current.WaitingToLock = address[1]
+			case strings.HasPrefix(trimmed, "- locked"):
+				current.LockedMonitors = append(current.LockedMonitors, address[1])
+			}
+		}
+	}
+
+	contended := make(map[string]bool)
+	counts := make(map[string]int)
+	for _, thread := range threads {
+		thread.Blocked = thread.State == "BLOCKED"
+		if thread.Blocked && thread.WaitingToLock != "" {
+			contended[thread.WaitingToLock] = true
+		}
+		state := thread.State
+		if state == "" {
+			state = "UNKNOWN"
+		}
+		counts[state]++
+	}
+	for _, thread := range threads {
+		for _, address := range thread.LockedMonitors {
+			if contended[address] {
+				thread.BlockingOthers = true
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(threads, func(i, j int) bool {
+		return threadDumpPriority(threads[i]) < threadDumpPriority(threads[j])
+	})
+	return threads, counts
+}
+
+// newThreadDumpEntry 根据线程头信息行创建线程，线程名取第一对引号之间的内容
+func newThreadDumpEntry(header string) *models.JvmThreadDumpEntry {
+	entry := &models.JvmThreadDumpEntry{Header: header, StackTrace: []string{}, LockedMonitors: []string{}}
+	if end := strings.Index(header[1:], `"`); end >= 0 {
+		entry.Name = header[1 : end+1]
+		entry.Daemon = strings.Contains(" "+header[end+2:]+" ", " daemon ")
+	} else {
+		entry.Name = strings.Trim(header, `"`)
+	}
+	return entry
+}
+
+// threadDumpPriority 线程在详情中的排序优先级：BLOCKED 线程、阻塞其他线程的持锁线程、其他线程
+func threadDumpPriority(thread *models.JvmThreadDumpEntry) int {
+	switch {
+	case thread.Blocked:
+		return 0
+	case thread.BlockingOthers:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// parseHeapHistogram 解析 jmap -histo 格式的类直方图，按占用字节数倒序，最多返回 histogramMaxEntries 个类
+func parseHeapHistogram(text string) []*models.JvmHistogramEntry {
+	entries := make([]*models.JvmHistogramEntry, 0)
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		match := histogramLinePattern.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		rank, _ := strconv.Atoi(match[1])
+		instances, err := strconv.ParseInt(match[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		bytes, err := strconv.ParseInt(match[3], 10, 64)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, &models.JvmHistogramEntry{Rank: rank, Instances: instances, Bytes: bytes, ClassName: match[4]})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Bytes > entries[j].Bytes
+	})
+	if len(entries) > histogramMaxEntries {
+		entries = entries[:histogramMaxEntries]
+	}
+	return entries
+}
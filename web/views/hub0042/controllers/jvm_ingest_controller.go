@@ -0,0 +1,375 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/pkg/utils/random"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0042/dao"
+	"gateway/web/views/hub0042/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JvmIngestController JVM采集数据上报控制器
+// 采集端使用访问密钥对请求签名后批量上报资源/内存/GC/线程快照，不再直接写数据库
+// 请求体支持JSON和protobuf（google.protobuf.Struct，结构与JSON一致），可使用gzip压缩
+type JvmIngestController struct {
+	dao     *dao.JvmIngestDAO
+	diagDao *dao.JvmDiagDAO
+	auth    *agentIngestAuth
+}
+
+// NewJvmIngestController 创建JVM采集数据上报控制器，访问密钥和限制读取 web.jvm_ingest
+func NewJvmIngestController(db database.Database) *JvmIngestController {
+	return &JvmIngestController{
+		dao:     dao.NewJvmIngestDAO(db),
+		diagDao: dao.NewJvmDiagDAO(db),
+		auth:    newAgentIngestAuth("web.jvm_ingest", "JVM采集"),
+	}
+}
+
+// VerifySignature 采集上报验签中间件
+func (c *JvmIngestController) VerifySignature() gin.HandlerFunc {
+	return c.auth.verifySignature()
+}
+
+// IngestBatch 批量上报JVM采集数据
+// 校验未通过的快照被跳过并在结果中返回原因，其余快照在一个事务中写入；
+// 上报即视为采集端心跳，写入成功的实例待下发的诊断任务随结果返回
+func (c *JvmIngestController) IngestBatch(ctx *gin.Context) {
+	key := c.auth.accessKey(ctx)
+	if key == nil {
+		response.ErrorJSON(ctx, "缺少访问密钥", constants.ED00010, http.StatusUnauthorized)
+		return
+	}
+
+	req := &models.JvmIngestRequest{}
+	if err := c.auth.decodeBody(ctx.Request, req); err != nil {
+		logger.WarnWithTrace(ctx, "解析JVM采集数据失败", "accessKey", key.AccessKey, "error", err)
+		response.ErrorJSON(ctx, "解析采集数据失败: "+err.Error(), constants.ED00006, http.StatusBadRequest)
+		return
+	}
+	if len(req.Snapshots) == 0 {
+		response.ErrorJSON(ctx, "采集数据不能为空", constants.ED00007)
+		return
+	}
+	if len(req.Snapshots) > c.auth.cfg.MaxBatchSize {
+		response.ErrorJSON(ctx, fmt.Sprintf("单次上报不能超过%d条快照", c.auth.cfg.MaxBatchSize), constants.ED00006)
+		return
+	}
+
+	now := time.Now()
+	result := &models.JvmIngestResult{Rejected: []*models.JvmIngestRejected{}, DiagTasks: []*models.JvmDiagCommand{}}
+	var (
+		resources []*models.JvmResource
+		memories  []*models.JvmMemory
+		gcs       []*models.JvmGc
+		threads   []*models.JvmThread
+	)
+	for i, snapshot := range req.Snapshots {
+		if err := prepareJvmSnapshot(snapshot, key.TenantId, key.AccessKey, now); err != nil {
+			rejected := &models.JvmIngestRejected{Index: i, Reason: err.Error()}
+			if snapshot != nil {
+				rejected.JvmResourceId = snapshot.Resource.JvmResourceId
+			}
+			result.Rejected = append(result.Rejected, rejected)
+			continue
+		}
+		resources = append(resources, &snapshot.Resource)
+		memories = append(memories, snapshot.Memory...)
+		if snapshot.Gc != nil {
+			gcs = append(gcs, snapshot.Gc)
+		}
+		if snapshot.Thread != nil {
+			threads = append(threads, snapshot.Thread)
+		}
+	}
+
+	if len(resources) > 0 {
+		if err := c.dao.SaveSnapshots(ctx, resources, memories, gcs, threads); err != nil {
+			logger.ErrorWithTrace(ctx, "写入JVM采集数据失败", "accessKey", key.AccessKey, "error", err)
+			response.ErrorJSON(ctx, "写入采集数据失败: "+err.Error(), constants.ED00009)
+			return
+		}
+	}
+	result.Accepted = len(resources)
+	result.DiagTasks = c.dispatchDiagTasks(ctx, key, resources, now)
+
+	if len(result.Rejected) > 0 {
+		logger.WarnWithTrace(ctx, "部分JVM采集数据校验未通过", "accessKey", key.AccessKey,
+			"accepted", result.Accepted, "rejected", len(result.Rejected))
+	}
+	response.SuccessJSON(ctx, result, constants.SD00003)
+}
+
+// dispatchDiagTasks 领取本批次实例待下发的诊断任务，失败时只记录日志，不影响快照写入结果
+func (c *JvmIngestController) dispatchDiagTasks(ctx *gin.Context, key *agentIngestAccessKey, resources []*models.JvmResource,
+	now time.Time) []*models.JvmDiagCommand {
+	commands := []*models.JvmDiagCommand{}
+	if len(resources) == 0 {
+		return commands
+	}
+	seen := make(map[string]bool, len(resources))
+	resourceIds := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		if !seen[resource.JvmResourceId] {
+			seen[resource.JvmResourceId] = true
+			resourceIds = append(resourceIds, resource.JvmResourceId)
+		}
+	}
+
+	if err := c.diagDao.ExpireTasks(ctx, key.TenantId, now); err != nil {
+		logger.WarnWithTrace(ctx, "更新过期诊断任务失败", "error", err)
+	}
+	tasks, err := c.diagDao.DispatchTasks(ctx, key.TenantId, resourceIds, key.AccessKey, now)
+	if err != nil {
+		logger.WarnWithTrace(ctx, "下发诊断任务失败", "accessKey", key.AccessKey, "error", err)
+	}
+	for _, task := range tasks {
+		commands = append(commands, &models.JvmDiagCommand{
+			DiagTaskId:    task.DiagTaskId,
+			JvmResourceId: task.JvmResourceId,
+			DiagType:      task.DiagType,
+			ExpireTime:    task.ExpireTime,
+		})
+	}
+	return commands
+}
+
+// SubmitDiagResult 采集端提交诊断任务结果
+// 只接收已下发且未过期的任务，线程转储在写入时统计线程数和BLOCKED线程数
+func (c *JvmIngestController) SubmitDiagResult(ctx *gin.Context) {
+	key := c.auth.accessKey(ctx)
+	if key == nil {
+		response.ErrorJSON(ctx, "缺少访问密钥", constants.ED00010, http.StatusUnauthorized)
+		return
+	}
+
+	req := &models.JvmDiagResultRequest{}
+	if err := c.auth.decodeBody(ctx.Request, req); err != nil {
+		logger.WarnWithTrace(ctx, "解析诊断结果失败", "accessKey", key.AccessKey, "error", err)
+		response.ErrorJSON(ctx, "解析诊断结果失败: "+err.Error(), constants.ED00006, http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.DiagTaskId) == "" {
+		response.ErrorJSON(ctx, "diagTaskId不能为空", constants.ED00007)
+		return
+	}
+	if req.Success && strings.TrimSpace(req.Content) == "" {
+		response.ErrorJSON(ctx, "采集成功时content不能为空", constants.ED00007)
+		return
+	}
+
+	task, err := c.diagDao.GetTask(ctx, key.TenantId, req.DiagTaskId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "获取诊断任务失败", "diagTaskId", req.DiagTaskId, "error", err)
+		response.ErrorJSON(ctx, "获取诊断任务失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if task == nil || task.JvmResourceId != req.JvmResourceId {
+		response.ErrorJSON(ctx, "诊断任务不存在", constants.ED00008)
+		return
+	}
+	now := time.Now()
+	if task.TaskStatus != models.JvmDiagDispatched || now.After(task.ExpireTime) {
+		response.ErrorJSON(ctx, "诊断任务不是待提交状态: "+task.TaskStatus, constants.ED00006)
+		return
+	}
+
+	completeTime := now
+	task.CompleteTime, task.EditTime, task.EditWho = &completeTime, now, key.AccessKey
+	if req.Success {
+		task.TaskStatus = models.JvmDiagCompleted
+		task.ResultText, task.ResultSize = &req.Content, len(req.Content)
+		if task.DiagType == models.JvmDiagThreadDump {
+			threads, counts := parseThreadDump(req.Content)
+			task.ThreadCount, task.BlockedThreadCount = len(threads), counts["BLOCKED"]
+		}
+	} else {
+		message := strings.TrimSpace(req.ErrorMessage)
+		if message == "" {
+			message = "采集端未返回失败原因"
+		}
+		if len(message) > 1000 {
+			// 按字符边界截断到字段长度
+			cut := 1000
+			for cut > 0 && !utf8.RuneStart(message[cut]) {
+				cut--
+			}
+			message = message[:cut]
+		}
+		task.TaskStatus, task.ErrorMessage = models.JvmDiagFailed, &message
+	}
+
+	updated, err := c.diagDao.CompleteTask(ctx, task)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "写入诊断结果失败", "diagTaskId", task.DiagTaskId, "error", err)
+		response.ErrorJSON(ctx, "写入诊断结果失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if !updated {
+		response.ErrorJSON(ctx, "诊断任务已完成或已过期", constants.ED00006)
+		return
+	}
+	task.ResultText = nil
+	response.SuccessJSON(ctx, task, constants.SD00004)
+}
+
+// prepareJvmSnapshot 校验快照并填充租户、主键和通用字段
+// 参数:
+//
+//	snapshot: 采集快照
+//	tenantId: 访问密钥绑定的租户ID，覆盖上报值
+//	operatorId: 写入 addWho/editWho 的操作人，使用访问密钥
+//	now: 服务端接收时间
+//
+// 返回:
+//
+//	error: 校验未通过的原因
+func prepareJvmSnapshot(snapshot *models.JvmSnapshot, tenantId, operatorId string, now time.Time) error {
+	if snapshot == nil {
+		return errors.New("快照不能为空")
+	}
+
+	resource := &snapshot.Resource
+	if err := checkIngestText("resource.jvmResourceId", resource.JvmResourceId, 100); err != nil {
+		return err
+	}
+	if err := checkIngestText("resource.serviceGroupId", resource.ServiceGroupId, 32); err != nil {
+		return err
+	}
+	if err := checkIngestText("resource.applicationName", resource.ApplicationName, 100); err != nil {
+		return err
+	}
+	if err := checkIngestText("resource.groupName", resource.GroupName, 100); err != nil {
+		return err
+	}
+	if err := checkIngestTime("resource.collectionTime", resource.CollectionTime, now); err != nil {
+		return err
+	}
+	if resource.JvmStartTime.IsZero() {
+		return errors.New("resource.jvmStartTime不能为空")
+	}
+	if resource.JvmUptimeMs < 0 {
+		return errors.New("resource.jvmUptimeMs不能为负数")
+	}
+	if err := normalizeIngestFlag("resource.healthyFlag", &resource.HealthyFlag, "Y"); err != nil {
+		return err
+	}
+	if err := normalizeIngestFlag("resource.requiresAttentionFlag", &resource.RequiresAttentionFlag, "N"); err != nil {
+		return err
+	}
+	resource.TenantId = tenantId
+	resource.AddTime, resource.AddWho, resource.EditTime, resource.EditWho = now, operatorId, now, operatorId
+	resource.OprSeqFlag = random.Generate32BitRandomString()
+	resource.CurrentVersion, resource.ActiveFlag = 1, "Y"
+
+	for i, memory := range snapshot.Memory {
+		field := fmt.Sprintf("memory[%d]", i)
+		if memory == nil {
+			return fmt.Errorf("%s不能为空", field)
+		}
+		memory.MemoryType = strings.ToUpper(strings.TrimSpace(memory.MemoryType))
+		if memory.MemoryType != models.JvmMemoryTypeHeap && memory.MemoryType != models.JvmMemoryTypeNonHeap {
+			return fmt.Errorf("%s.memoryType无效: %s", field, memory.MemoryType)
+		}
+		if memory.InitMemoryBytes < 0 || memory.UsedMemoryBytes < 0 || memory.CommittedMemoryBytes < 0 || memory.MaxMemoryBytes < -1 {
+			return fmt.Errorf("%s内存大小无效", field)
+		}
+		if memory.UsagePercent == 0 && memory.MaxMemoryBytes > 0 {
+			memory.UsagePercent = float64(memory.UsedMemoryBytes) * 100 / float64(memory.MaxMemoryBytes)
+		}
+		if memory.UsagePercent < 0 || memory.UsagePercent > 100 {
+			return fmt.Errorf("%s.usagePercent超出0-100范围", field)
+		}
+		if err := normalizeIngestFlag(field+".healthyFlag", &memory.HealthyFlag, "Y"); err != nil {
+			return err
+		}
+		if err := fillIngestCollectionTime(field, &memory.CollectionTime, resource.CollectionTime, now); err != nil {
+			return err
+		}
+		memory.TenantId, memory.JvmResourceId = tenantId, resource.JvmResourceId
+		memory.JvmMemoryId = random.Generate32BitRandomString()
+		memory.AddTime, memory.AddWho, memory.EditTime, memory.EditWho = now, operatorId, now, operatorId
+		memory.OprSeqFlag, memory.CurrentVersion, memory.ActiveFlag = memory.JvmMemoryId, 1, "Y"
+	}
+
+	if gc := snapshot.Gc; gc != nil {
+		if gc.CollectionCount < 0 || gc.CollectionTimeMs < 0 || gc.Ygc < 0 || gc.Fgc < 0 ||
+			gc.Ygct < 0 || gc.Fgct < 0 || gc.Gct < 0 {
+			return errors.New("gc回收次数和耗时不能为负数")
+		}
+		if err := fillIngestCollectionTime("gc", &gc.CollectionTime, resource.CollectionTime, now); err != nil {
+			return err
+		}
+		gc.TenantId, gc.JvmResourceId = tenantId, resource.JvmResourceId
+		gc.GcSnapshotId = random.Generate32BitRandomString()
+		gc.AddTime, gc.AddWho, gc.EditTime, gc.EditWho = now, operatorId, now, operatorId
+		gc.OprSeqFlag, gc.CurrentVersion, gc.ActiveFlag = gc.GcSnapshotId, 1, "Y"
+	}
+
+	if thread := snapshot.Thread; thread != nil {
+		if thread.CurrentThreadCount < 0 || thread.DaemonThreadCount < 0 || thread.UserThreadCount < 0 ||
+			thread.PeakThreadCount < 0 || thread.TotalStartedThreadCount < 0 {
+			return errors.New("thread线程数不能为负数")
+		}
+		flags := []struct {
+			name  string
+			value *string
+			def   string
+		}{
+			{"thread.cpuTimeSupported", &thread.CpuTimeSupported, "N"},
+			{"thread.cpuTimeEnabled", &thread.CpuTimeEnabled, "N"},
+			{"thread.memoryAllocSupported", &thread.MemoryAllocSupported, "N"},
+			{"thread.memoryAllocEnabled", &thread.MemoryAllocEnabled, "N"},
+			{"thread.contentionSupported", &thread.ContentionSupported, "N"},
+			{"thread.contentionEnabled", &thread.ContentionEnabled, "N"},
+			{"thread.healthyFlag", &thread.HealthyFlag, "Y"},
+			{"thread.requiresAttentionFlag", &thread.RequiresAttentionFlag, "N"},
+		}
+		for _, flag := range flags {
+			if err := normalizeIngestFlag(flag.name, flag.value, flag.def); err != nil {
+				return err
+			}
+		}
+		if err := fillIngestCollectionTime("thread", &thread.CollectionTime, resource.CollectionTime, now); err != nil {
+			return err
+		}
+		thread.TenantId, thread.JvmResourceId = tenantId, resource.JvmResourceId
+		thread.JvmThreadId = random.Generate32BitRandomString()
+		thread.AddTime, thread.AddWho, thread.EditTime, thread.EditWho = now, operatorId, now, operatorId
+		thread.OprSeqFlag, thread.CurrentVersion, thread.ActiveFlag = thread.JvmThreadId, 1, "Y"
+	}
+	return nil
+}
+
+// fillIngestCollectionTime 子表快照未上报采集时间时使用资源的采集时间
+func fillIngestCollectionTime(field string, value *time.Time, fallback, now time.Time) error {
+	if value.IsZero() {
+		*value = fallback
+		return nil
+	}
+	return checkIngestTime(field+".collectionTime", *value, now)
+}
+
+// normalizeIngestFlag 规范化Y/N标记，为空时使用默认值
+func normalizeIngestFlag(field string, value *string, defaultValue string) error {
+	flag := strings.ToUpper(strings.TrimSpace(*value))
+	if flag == "" {
+		flag = defaultValue
+	}
+	if flag != "Y" && flag != "N" {
+		return fmt.Errorf("%s只能为Y或N", field)
+	}
+	*value = flag
+	return nil
+}
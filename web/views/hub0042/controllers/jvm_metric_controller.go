@@ -0,0 +1,267 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0042/dao"
+	"gateway/web/views/hub0042/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// trend5MMaxRange 未指定粒度时，时间范围不超过该值使用5分钟聚合数据，否则使用1小时聚合数据
+const trend5MMaxRange = 2 * 24 * time.Hour
+
+// 实例对比查询限制
+const (
+	compareMaxInstances = 20   // 单次对比的最大实例数
+	compareMaxBuckets   = 2000 // 时间轴的最大点数，超过时需要选择更大的粒度或缩小时间范围
+)
+
+// compareMetricSources 对比指标取值方式，ok=false 表示该时间段没有对应的采集数据
+var compareMetricSources = map[string]func(row *models.JvmMetricRollup) (float64, bool){
+	models.JvmCompareHeapUsage: func(row *models.JvmMetricRollup) (float64, bool) {
+		return row.HeapUsageAvg, row.MemorySampleCount > 0
+	},
+	models.JvmCompareNonHeapUsage: func(row *models.JvmMetricRollup) (float64, bool) {
+		return row.NonHeapUsageAvg, row.NonHeapSampleCount > 0
+	},
+	models.JvmCompareGcTime: func(row *models.JvmMetricRollup) (float64, bool) {
+		return row.GcTimeSeconds, true
+	},
+	models.JvmCompareYgcCount: func(row *models.JvmMetricRollup) (float64, bool) {
+		return float64(row.YgcCount), true
+	},
+	models.JvmCompareFgcCount: func(row *models.JvmMetricRollup) (float64, bool) {
+		return float64(row.FgcCount), true
+	},
+	models.JvmCompareThreadCount: func(row *models.JvmMetricRollup) (float64, bool) {
+		return row.ThreadCountAvg, row.ThreadSampleCount > 0
+	},
+}
+
+// JvmMetricController JVM监控趋势控制器
+// 趋势查询读取 JvmRollupJob 生成的聚合表，避免在原始采集数据上做大范围扫描
+type JvmMetricController struct {
+	dao *dao.JvmRollupDAO
+}
+
+// NewJvmMetricController 创建JVM监控趋势控制器
+func NewJvmMetricController(db database.Database) *JvmMetricController {
+	return &JvmMetricController{dao: dao.NewJvmRollupDAO(db)}
+}
+
+// QueryJvmMetricTrend 查询JVM实例的监控趋势
+// 未指定粒度时按时间范围自动选择，最近一个聚合周期内的数据尚未聚合，不在结果中
+func (c *JvmMetricController) QueryJvmMetricTrend(ctx *gin.Context) {
+	var req models.JvmMetricTrendRequest
+	if err := request.BindSafely(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+	if req.JvmResourceId == "" {
+		response.ErrorJSON(ctx, "jvmResourceId不能为空", constants.ED00007)
+		return
+	}
+
+	start, end, granularity, errMsg := parseTrendRange(req.StartTime, req.EndTime, req.Granularity)
+	if errMsg != "" {
+		response.ErrorJSON(ctx, errMsg, constants.ED00006)
+		return
+	}
+
+	rows, err := c.dao.QueryMetricTrend(ctx, request.GetTenantID(ctx), req.JvmResourceId, granularity, start, end)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询JVM监控趋势失败", "jvmResourceId", req.JvmResourceId, "error", err)
+		response.ErrorJSON(ctx, "查询JVM监控趋势失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	response.SuccessJSON(ctx, gin.H{
+		"granularity": granularity,
+		"points":      rows,
+	}, constants.SD00002)
+}
+
+// CompareJvmMetric 对比多个JVM实例的同一指标
+// 返回对齐到同一时间轴的序列，没有数据的时间段为null，便于前端并排绘制
+func (c *JvmMetricController) CompareJvmMetric(ctx *gin.Context) {
+	var req models.JvmMetricCompareRequest
+	if err := request.BindSafely(ctx, &req); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+	req.MetricType = strings.ToUpper(req.MetricType)
+	source, ok := compareMetricSources[req.MetricType]
+	if !ok {
+		response.ErrorJSON(ctx, "不支持的对比指标: "+req.MetricType, constants.ED00006)
+		return
+	}
+	ids := uniqueNonEmpty(req.JvmResourceIds)
+	if len(ids) == 0 && req.ServiceGroupId == "" {
+		response.ErrorJSON(ctx, "jvmResourceIds和serviceGroupId不能同时为空", constants.ED00007)
+		return
+	}
+	if len(ids) > compareMaxInstances {
+		response.ErrorJSON(ctx, fmt.Sprintf("单次最多对比%d个实例", compareMaxInstances), constants.ED00006)
+		return
+	}
+
+	start, end, granularity, errMsg := parseTrendRange(req.StartTime, req.EndTime, req.Granularity)
+	if errMsg != "" {
+		response.ErrorJSON(ctx, errMsg, constants.ED00006)
+		return
+	}
+	step := rollupBucket5M
+	if granularity == models.JvmRollup1H {
+		step = rollupBucket1H
+	}
+	start = start.Truncate(step)
+	if end.Sub(start)/step > compareMaxBuckets {
+		response.ErrorJSON(ctx, "时间范围内的数据点过多，请缩小时间范围或使用1H粒度", constants.ED00006)
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	resources, err := c.dao.ListJvmResources(ctx, tenantId, ids, req.ServiceGroupId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询JVM实例失败", "error", err)
+		response.ErrorJSON(ctx, "查询JVM实例失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if len(ids) == 0 && len(resources) > compareMaxInstances {
+		response.ErrorJSON(ctx, fmt.Sprintf("服务分组下的实例超过%d个，请指定jvmResourceIds", compareMaxInstances), constants.ED00006)
+		return
+	}
+	resources = orderResources(resources, ids)
+
+	resourceIds := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		resourceIds = append(resourceIds, resource.JvmResourceId)
+	}
+	rows, err := c.dao.QueryMetricTrends(ctx, tenantId, resourceIds, granularity, start, end)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询JVM监控趋势失败", "error", err)
+		response.ErrorJSON(ctx, "查询JVM监控趋势失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	result := &models.JvmMetricCompareResult{
+		MetricType:  req.MetricType,
+		Granularity: granularity,
+		Timestamps:  []string{},
+		Series:      make([]*models.JvmCompareSeries, 0, len(resources)),
+	}
+	bucketIndex := make(map[int64]int)
+	for t := start; t.Before(end); t = t.Add(step) {
+		bucketIndex[t.Unix()] = len(result.Timestamps)
+		result.Timestamps = append(result.Timestamps, t.Format(alertTimeLayout))
+	}
+
+	seriesIndex := make(map[string]*models.JvmCompareSeries, len(resources))
+	for _, resource := range resources {
+		series := &models.JvmCompareSeries{
+			JvmResourceBrief: *resource,
+			Values:           make([]*float64, len(result.Timestamps)),
+		}
+		seriesIndex[resource.JvmResourceId] = series
+		result.Series = append(result.Series, series)
+	}
+	for _, row := range rows {
+		series, ok := seriesIndex[row.JvmResourceId]
+		if !ok {
+			continue
+		}
+		index, ok := bucketIndex[row.BucketTime.Unix()]
+		if !ok {
+			continue
+		}
+		if value, ok := source(row); ok {
+			series.Values[index] = &value
+		}
+	}
+
+	response.SuccessJSON(ctx, result, constants.SD00002)
+}
+
+// parseTrendRange 解析趋势查询的时间范围和粒度
+// 结束时间默认为当前时间，开始时间默认为结束时间前24小时；未指定粒度时按时间范围自动选择
+// 返回:
+//
+//	start, end: 查询时间范围
+//	granularity: 聚合粒度
+//	errMsg: 参数错误时返回错误描述
+func parseTrendRange(startTime, endTime, granularity string) (time.Time, time.Time, string, string) {
+	end := time.Now()
+	if endTime != "" {
+		t, err := time.ParseInLocation(alertTimeLayout, endTime, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, "", "endTime格式错误，应为 " + alertTimeLayout
+		}
+		end = t
+	}
+	start := end.Add(-24 * time.Hour)
+	if startTime != "" {
+		t, err := time.ParseInLocation(alertTimeLayout, startTime, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, "", "startTime格式错误，应为 " + alertTimeLayout
+		}
+		start = t
+	}
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, "", "startTime必须早于endTime"
+	}
+
+	granularity = strings.ToUpper(granularity)
+	switch granularity {
+	case models.JvmRollup5M, models.JvmRollup1H:
+	case "":
+		granularity = models.JvmRollup1H
+		if end.Sub(start) <= trend5MMaxRange {
+			granularity = models.JvmRollup5M
+		}
+	default:
+		return time.Time{}, time.Time{}, "", "granularity只能为5M或1H"
+	}
+	return start, end, granularity, ""
+}
+
+// uniqueNonEmpty 去除空值和重复值，保持原有顺序
+func uniqueNonEmpty(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		result = append(result, value)
+	}
+	return result
+}
+
+// orderResources 按请求中的ID顺序排列实例，ids 为空时保持查询顺序
+func orderResources(resources []*models.JvmResourceBrief, ids []string) []*models.JvmResourceBrief {
+	if len(ids) == 0 {
+		return resources
+	}
+	byId := make(map[string]*models.JvmResourceBrief, len(resources))
+	for _, resource := range resources {
+		byId[resource.JvmResourceId] = resource
+	}
+	ordered := make([]*models.JvmResourceBrief, 0, len(resources))
+	for _, id := range ids {
+		if resource, ok := byId[id]; ok {
+			ordered = append(ordered, resource)
+		}
+	}
+	return ordered
+}
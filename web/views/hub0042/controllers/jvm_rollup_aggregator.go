@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"gateway/web/views/hub0042/models"
+)
+
+// 聚合时间段长度
+const (
+	rollupBucket5M = 5 * time.Minute
+	rollupBucket1H = time.Hour
+)
+
+// rollupGcLookback 聚合GC增量时向前多读取的时长，用于取得时间段内第一个采集点的前一个采集点
+const rollupGcLookback = 10 * time.Minute
+
+// rollupKey 聚合分组键
+type rollupKey struct {
+	tenantId      string
+	jvmResourceId string
+	bucket        time.Time
+}
+
+// rollupAccumulator 单个分组的聚合中间结果，平均值先累加，最后除以采集点数
+type rollupAccumulator struct {
+	row             *models.JvmMetricRollup
+	heapUsageSum    float64
+	heapBytesSum    float64
+	nonHeapUsageSum float64
+	threadSum       float64
+}
+
+// rollupAccumulators 按分组键保存的聚合中间结果
+type rollupAccumulators map[rollupKey]*rollupAccumulator
+
+// get 获取分组的聚合中间结果，不存在时创建
+func (a rollupAccumulators) get(tenantId, jvmResourceId string, bucket time.Time) *rollupAccumulator {
+	key := rollupKey{tenantId: tenantId, jvmResourceId: jvmResourceId, bucket: bucket}
+	acc, ok := a[key]
+	if !ok {
+		acc = &rollupAccumulator{row: &models.JvmMetricRollup{
+			TenantId:      tenantId,
+			JvmResourceId: jvmResourceId,
+			BucketTime:    bucket,
+		}}
+		a[key] = acc
+	}
+	return acc
+}
+
+// rows 计算平均值并按租户、实例、时间排序返回聚合结果
+func (a rollupAccumulators) rows() []*models.JvmMetricRollup {
+	rows := make([]*models.JvmMetricRollup, 0, len(a))
+	for _, acc := range a {
+		row := acc.row
+		if row.MemorySampleCount > 0 {
+			row.HeapUsageAvg = roundRollup(acc.heapUsageSum / float64(row.MemorySampleCount))
+			row.HeapUsedBytesAvg = int64(acc.heapBytesSum / float64(row.MemorySampleCount))
+		}
+		if row.NonHeapSampleCount > 0 {
+			row.NonHeapUsageAvg = roundRollup(acc.nonHeapUsageSum / float64(row.NonHeapSampleCount))
+		}
+		if row.ThreadSampleCount > 0 {
+			row.ThreadCountAvg = roundRollup(acc.threadSum / float64(row.ThreadSampleCount))
+		}
+		row.GcTimeSeconds = math.Round(row.GcTimeSeconds*1000) / 1000
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].TenantId != rows[j].TenantId {
+			return rows[i].TenantId < rows[j].TenantId
+		}
+		if rows[i].JvmResourceId != rows[j].JvmResourceId {
+			return rows[i].JvmResourceId < rows[j].JvmResourceId
+		}
+		return rows[i].BucketTime.Before(rows[j].BucketTime)
+	})
+	return rows
+}
+
+// buildRollups5M 将 [start, end) 内的原始采集数据按5分钟聚合
+// GC为累积值，时间段内的次数和耗时取相邻采集点的差值，归入后一个采集点所在的时间段；
+// 差值为负说明JVM已重启，此时取后一个采集点的值。gcs 需要包含 start 之前 rollupGcLookback 内的采集点
+// 参数:
+//
+//	memories: 内存采集点
+//	threads: 线程采集点
+//	gcs: GC采集点
+//	start: 时间段起点（含），需按5分钟对齐
+//	end: 时间段终点（不含）
+//
+// 返回:
+//
+//	[]*models.JvmMetricRollup: 聚合结果，未填充通用字段
+func buildRollups5M(memories []*models.JvmMemoryPoint, threads []*models.JvmThreadPoint, gcs []*models.JvmGcPoint, start, end time.Time) []*models.JvmMetricRollup {
+	inRange := func(t time.Time) bool {
+		return !t.Before(start) && t.Before(end)
+	}
+	accs := make(rollupAccumulators)
+
+	for _, point := range memories {
+		if point == nil || !inRange(point.CollectionTime) {
+			continue
+		}
+		acc := accs.get(point.TenantId, point.JvmResourceId, point.CollectionTime.Truncate(rollupBucket5M))
+		switch point.MemoryType {
+		case models.JvmMemoryTypeHeap:
+			acc.row.MemorySampleCount++
+			acc.heapUsageSum += point.UsagePercent
+			acc.heapBytesSum += float64(point.UsedMemoryBytes)
+			acc.row.HeapUsageMax = math.Max(acc.row.HeapUsageMax, point.UsagePercent)
+			if point.UsedMemoryBytes > acc.row.HeapUsedBytesMax {
+				acc.row.HeapUsedBytesMax = point.UsedMemoryBytes
+			}
+		case models.JvmMemoryTypeNonHeap:
+			acc.row.NonHeapSampleCount++
+			acc.nonHeapUsageSum += point.UsagePercent
+			acc.row.NonHeapUsageMax = math.Max(acc.row.NonHeapUsageMax, point.UsagePercent)
+		}
+	}
+
+	for _, point := range threads {
+		if point == nil || !inRange(point.CollectionTime) {
+			continue
+		}
+		acc := accs.get(point.TenantId, point.JvmResourceId, point.CollectionTime.Truncate(rollupBucket5M))
+		acc.row.ThreadSampleCount++
+		acc.threadSum += float64(point.CurrentThreadCount)
+		if point.CurrentThreadCount > acc.row.ThreadCountMax {
+			acc.row.ThreadCountMax = point.CurrentThreadCount
+		}
+	}
+
+	byResource := make(map[[2]string][]*models.JvmGcPoint)
+	for _, point := range gcs {
+		if point == nil || !point.CollectionTime.Before(end) {
+			continue
+		}
+		key := [2]string{point.TenantId, point.JvmResourceId}
+		byResource[key] = append(byResource[key], point)
+	}
+	for _, points := range byResource {
+		sort.Slice(points, func(i, j int) bool {
+			return points[i].CollectionTime.Before(points[j].CollectionTime)
+		})
+		for i := 1; i < len(points); i++ {
+			prev, cur := points[i-1], points[i]
+			if !inRange(cur.CollectionTime) {
+				continue
+			}
+			ygc, fgc, gct := cur.Ygc-prev.Ygc, cur.Fgc-prev.Fgc, cur.Gct-prev.Gct
+			if ygc < 0 || fgc < 0 || gct < 0 {
+				ygc, fgc, gct = cur.Ygc, cur.Fgc, cur.Gct
+			}
+			acc := accs.get(cur.TenantId, cur.JvmResourceId, cur.CollectionTime.Truncate(rollupBucket5M))
+			acc.row.YgcCount += ygc
+			acc.row.FgcCount += fgc
+			acc.row.GcTimeSeconds += gct
+		}
+	}
+
+	return accs.rows()
+}
+
+// buildRollups1H 将5分钟聚合数据按小时汇总，平均值按采集点数加权，GC增量求和
+func buildRollups1H(rows5M []*models.JvmMetricRollup) []*models.JvmMetricRollup {
+	accs := make(rollupAccumulators)
+	for _, src := range rows5M {
+		if src == nil {
+			continue
+		}
+		acc := accs.get(src.TenantId, src.JvmResourceId, src.BucketTime.Truncate(rollupBucket1H))
+		row := acc.row
+
+		row.MemorySampleCount += src.MemorySampleCount
+		acc.heapUsageSum += src.HeapUsageAvg * float64(src.MemorySampleCount)
+		acc.heapBytesSum += float64(src.HeapUsedBytesAvg) * float64(src.MemorySampleCount)
+		row.HeapUsageMax = math.Max(row.HeapUsageMax, src.HeapUsageMax)
+		if src.HeapUsedBytesMax > row.HeapUsedBytesMax {
+			row.HeapUsedBytesMax = src.HeapUsedBytesMax
+		}
+
+		row.NonHeapSampleCount += src.NonHeapSampleCount
+		acc.nonHeapUsageSum += src.NonHeapUsageAvg * float64(src.NonHeapSampleCount)
+		row.NonHeapUsageMax = math.Max(row.NonHeapUsageMax, src.NonHeapUsageMax)
+
+		row.ThreadSampleCount += src.ThreadSampleCount
+		acc.threadSum += src.ThreadCountAvg * float64(src.ThreadSampleCount)
+		if src.ThreadCountMax > row.ThreadCountMax {
+			row.ThreadCountMax = src.ThreadCountMax
+		}
+
+		row.YgcCount += src.YgcCount
+		row.FgcCount += src.FgcCount
+		row.GcTimeSeconds += src.GcTimeSeconds
+	}
+	return accs.rows()
+}
+
+// roundRollup 保留两位小数，与表字段精度一致
+func roundRollup(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,327 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gateway/pkg/config"
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/pkg/timer"
+	"gateway/pkg/utils/random"
+	"gateway/web/views/hub0042/dao"
+	"gateway/web/views/hub0042/models"
+)
+
+// jvmRollupSchedulerId JVM监控数据聚合调度器ID
+const jvmRollupSchedulerId = "JVM_ROLLUP_scheduler"
+
+// jvmRollupTaskId JVM监控数据聚合与清理任务ID
+const jvmRollupTaskId = "JVM_METRIC_ROLLUP"
+
+// jvmRollupOperator 聚合任务写入数据时使用的操作人
+const jvmRollupOperator = "system"
+
+// jvmRollupSettings 聚合与清理配置，对应 web.jvm_rollup
+type jvmRollupSettings struct {
+	delay             time.Duration // 采集数据写入延迟，时间段结束后等待该时长再聚合
+	maxHoursPerRun    int           // 单次任务最多聚合的小时数，用于首次运行或停机后追赶
+	rawRetention      time.Duration // 原始采集数据保留时长，0表示不清理
+	rollup5MRetention time.Duration // 5分钟聚合数据保留时长，0表示不清理
+	rollup1HRetention time.Duration // 1小时聚合数据保留时长，0表示不清理
+}
+
+// loadJvmRollupSettings 读取聚合与清理配置
+func loadJvmRollupSettings() *jvmRollupSettings {
+	days := func(key string, defaultValue int) time.Duration {
+		return time.Duration(config.GetInt(key, defaultValue)) * 24 * time.Hour
+	}
+	s := &jvmRollupSettings{
+		delay:             time.Duration(config.GetInt("web.jvm_rollup.delay_seconds", 120)) * time.Second,
+		maxHoursPerRun:    config.GetInt("web.jvm_rollup.max_hours_per_run", 24),
+		rawRetention:      days("web.jvm_rollup.raw_retention_days", 7),
+		rollup5MRetention: days("web.jvm_rollup.rollup_5m_retention_days", 30),
+		rollup1HRetention: days("web.jvm_rollup.rollup_1h_retention_days", 365),
+	}
+	if s.maxHoursPerRun <= 0 {
+		s.maxHoursPerRun = 24
+	}
+	return s
+}
+
+// JvmRollupJob JVM监控数据聚合与清理任务
+// 周期将原始采集数据（HUB_MONITOR_JVM_MEMORY/THREAD/GC）按5分钟聚合到 HUB_MONITOR_JVM_METRIC_5M，
+// 再将5分钟数据按小时汇总到 HUB_MONITOR_JVM_METRIC_1H，并清理超过保留时长的数据；
+// 聚合进度保存在内存中，启动时从聚合表中最新的时间段继续，尚未聚合的原始数据不会被清理
+type JvmRollupJob struct {
+	dao *dao.JvmRollupDAO
+
+	mu      sync.Mutex // 保证同一时刻只有一轮聚合
+	next5M  time.Time  // 下一个待聚合的5分钟时间段起点
+	next1H  time.Time  // 下一个待汇总的1小时时间段起点
+	started bool       // 聚合进度是否已从数据库加载
+}
+
+// NewJvmRollupJob 创建JVM监控数据聚合与清理任务
+func NewJvmRollupJob(db database.Database) *JvmRollupJob {
+	return &JvmRollupJob{dao: dao.NewJvmRollupDAO(db)}
+}
+
+// Start 注册周期聚合任务
+// 多节点部署时启用 app.timer.cluster 后每轮只在一个节点执行，未启用时通过 web.jvm_rollup.enabled 只在一个节点开启
+// 返回:
+//
+//	error: 创建调度器或注册任务失败时返回错误
+func (j *JvmRollupJob) Start() error {
+	if !config.GetBool("web.jvm_rollup.enabled", true) {
+		logger.Info("JVM监控数据聚合未启用，跳过注册")
+		return nil
+	}
+
+	interval := time.Duration(config.GetInt("web.jvm_rollup.interval_seconds", 300)) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	pool := timer.GetTimerPool()
+	scheduler, err := pool.GetScheduler(jvmRollupSchedulerId)
+	if err != nil {
+		scheduler, err = pool.CreateScheduler(&timer.SchedulerConfig{
+			ID:               jvmRollupSchedulerId,
+			Name:             "JVM监控数据聚合调度器",
+			MaxWorkers:       1,
+			QueueSize:        10,
+			DefaultTimeout:   interval,
+			DefaultRetries:   0,
+			ScheduleInterval: time.Second,
+			Tasks:            make(map[string]*timer.TaskConfig),
+		})
+		if err != nil {
+			return fmt.Errorf("创建JVM监控数据聚合调度器失败: %w", err)
+		}
+	}
+
+	taskConfig := timer.NewTaskConfig(jvmRollupTaskId, "JVM监控数据聚合", timer.ScheduleTypeInterval)
+	taskConfig.Description = "按5分钟和1小时聚合JVM监控数据并清理过期数据"
+	taskConfig.Interval = interval
+	taskConfig.Timeout = interval
+	if err := scheduler.AddTask(taskConfig, &jvmRollupTaskExecutor{job: j}); err != nil {
+		return fmt.Errorf("注册JVM监控数据聚合任务失败: %w", err)
+	}
+	if !scheduler.IsRunning() {
+		if err := scheduler.Start(); err != nil {
+			return fmt.Errorf("启动JVM监控数据聚合调度器失败: %w", err)
+		}
+	}
+	logger.Info("JVM监控数据聚合任务注册完成", "interval", interval.String())
+	return nil
+}
+
+// RunOnce 执行一轮聚合和清理
+// 上一轮未完成时跳过本轮；聚合失败时保留进度，下一轮从失败的时间段重试
+// 参数:
+//
+//	ctx: 上下文对象
+//
+// 返回:
+//
+//	string: 本轮处理摘要
+//	error: 聚合或清理失败时返回错误
+func (j *JvmRollupJob) RunOnce(ctx context.Context) (string, error) {
+	if !j.mu.TryLock() {
+		logger.Warn("上一轮JVM监控数据聚合尚未完成，跳过本轮")
+		return "上一轮尚未完成", nil
+	}
+	defer j.mu.Unlock()
+
+	settings := loadJvmRollupSettings()
+	now := time.Now()
+	if err := j.loadProgress(ctx, settings, now); err != nil {
+		return "", err
+	}
+
+	rows5M, err := j.rollup5M(ctx, settings, now)
+	if err != nil {
+		return "", err
+	}
+	rows1H, err := j.rollup1H(ctx, settings)
+	if err != nil {
+		return "", err
+	}
+	purged, err := j.purge(ctx, settings, now)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("5分钟聚合 %d 行，1小时聚合 %d 行，清理 %d 行", rows5M, rows1H, purged), nil
+}
+
+// loadProgress 首次执行时从聚合表加载进度，聚合表为空时从对应源数据的保留时长之前开始
+func (j *JvmRollupJob) loadProgress(ctx context.Context, settings *jvmRollupSettings, now time.Time) error {
+	if j.started {
+		return nil
+	}
+
+	latest5M, err := j.dao.GetLatestRollupBucket(ctx, models.JvmRollup5M)
+	if err != nil {
+		return err
+	}
+	if latest5M != nil {
+		j.next5M = latest5M.Add(rollupBucket5M)
+	} else {
+		j.next5M = now.Add(-rollupStartLookback(settings.rawRetention)).Truncate(rollupBucket1H)
+	}
+
+	latest1H, err := j.dao.GetLatestRollupBucket(ctx, models.JvmRollup1H)
+	if err != nil {
+		return err
+	}
+	if latest1H != nil {
+		j.next1H = latest1H.Add(rollupBucket1H)
+	} else {
+		j.next1H = now.Add(-rollupStartLookback(settings.rollup5MRetention)).Truncate(rollupBucket1H)
+	}
+
+	j.started = true
+	return nil
+}
+
+// rollup5M 按小时分段聚合已结束的5分钟时间段，返回写入的行数
+func (j *JvmRollupJob) rollup5M(ctx context.Context, settings *jvmRollupSettings, now time.Time) (int, error) {
+	ready := now.Add(-settings.delay).Truncate(rollupBucket5M)
+	total := 0
+	for hours := 0; j.next5M.Before(ready) && hours < settings.maxHoursPerRun; hours++ {
+		start := j.next5M
+		end := start.Truncate(rollupBucket1H).Add(rollupBucket1H)
+		if end.After(ready) {
+			end = ready
+		}
+
+		memories, err := j.dao.ListMemoryPoints(ctx, start, end)
+		if err != nil {
+			return total, err
+		}
+		threads, err := j.dao.ListThreadPoints(ctx, start, end)
+		if err != nil {
+			return total, err
+		}
+		gcs, err := j.dao.ListGcPoints(ctx, start.Add(-rollupGcLookback), end)
+		if err != nil {
+			return total, err
+		}
+
+		rows := buildRollups5M(memories, threads, gcs, start, end)
+		fillRollupCommonFields(rows, now)
+		if err := j.dao.ReplaceRollups(ctx, models.JvmRollup5M, start, end, rows); err != nil {
+			return total, err
+		}
+		total += len(rows)
+		j.next5M = end
+	}
+	return total, nil
+}
+
+// rollup1H 汇总5分钟数据已完整覆盖的小时，返回写入的行数
+func (j *JvmRollupJob) rollup1H(ctx context.Context, settings *jvmRollupSettings) (int, error) {
+	ready := j.next5M.Truncate(rollupBucket1H)
+	if !j.next1H.Before(ready) {
+		return 0, nil
+	}
+	start := j.next1H
+	end := start.Add(time.Duration(settings.maxHoursPerRun) * rollupBucket1H)
+	if end.After(ready) {
+		end = ready
+	}
+
+	rows5M, err := j.dao.ListRollups(ctx, models.JvmRollup5M, start, end)
+	if err != nil {
+		return 0, err
+	}
+	rows := buildRollups1H(rows5M)
+	fillRollupCommonFields(rows, time.Now())
+	if err := j.dao.ReplaceRollups(ctx, models.JvmRollup1H, start, end, rows); err != nil {
+		return 0, err
+	}
+	j.next1H = end
+	return len(rows), nil
+}
+
+// purge 清理超过保留时长的数据，原始数据和5分钟数据只清理已经汇总过的部分，返回删除的行数
+func (j *JvmRollupJob) purge(ctx context.Context, settings *jvmRollupSettings, now time.Time) (int64, error) {
+	var total int64
+	if settings.rawRetention > 0 {
+		before := now.Add(-settings.rawRetention)
+		if before.After(j.next5M) {
+			before = j.next5M
+		}
+		affected, err := j.dao.PurgeRawData(ctx, before)
+		total += affected
+		if err != nil {
+			return total, err
+		}
+	}
+	if settings.rollup5MRetention > 0 {
+		before := now.Add(-settings.rollup5MRetention)
+		if before.After(j.next1H) {
+			before = j.next1H
+		}
+		affected, err := j.dao.PurgeRollups(ctx, models.JvmRollup5M, before)
+		total += affected
+		if err != nil {
+			return total, err
+		}
+	}
+	if settings.rollup1HRetention > 0 {
+		affected, err := j.dao.PurgeRollups(ctx, models.JvmRollup1H, now.Add(-settings.rollup1HRetention))
+		total += affected
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// rollupStartLookback 没有聚合记录时的起始回溯时长，源数据不清理时最多回溯7天
+func rollupStartLookback(retention time.Duration) time.Duration {
+	if retention <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return retention
+}
+
+// fillRollupCommonFields 填充聚合数据的通用字段
+func fillRollupCommonFields(rows []*models.JvmMetricRollup, now time.Time) {
+	for _, row := range rows {
+		row.AddTime, row.AddWho = now, jvmRollupOperator
+		row.EditTime, row.EditWho = now, jvmRollupOperator
+		row.OprSeqFlag = random.Generate32BitRandomString()
+		row.CurrentVersion = 1
+		row.ActiveFlag = "Y"
+	}
+}
+
+// jvmRollupTaskExecutor JVM监控数据聚合任务执行器，实现 timer.TaskExecutor 接口
+type jvmRollupTaskExecutor struct {
+	job *JvmRollupJob
+}
+
+// Execute 执行一轮聚合和清理
+func (e *jvmRollupTaskExecutor) Execute(ctx context.Context, params interface{}) (*timer.ExecuteResult, error) {
+	summary, err := e.job.RunOnce(ctx)
+	if err != nil {
+		return &timer.ExecuteResult{Success: false, Message: err.Error()}, err
+	}
+	return &timer.ExecuteResult{Success: true, Message: summary}, nil
+}
+
+// GetName 执行器名称
+func (e *jvmRollupTaskExecutor) GetName() string {
+	return "JvmMetricRollup"
+}
+
+// Close 无需释放资源
+func (e *jvmRollupTaskExecutor) Close() error {
+	return nil
+}
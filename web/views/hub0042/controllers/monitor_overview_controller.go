@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"math"
+	"time"
+
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/views/hub0042/dao"
+	"gateway/web/views/hub0042/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 监控概览统计参数
+const (
+	overviewActiveWindow  = 5 * time.Minute // 最近采集时间在该时长内的JVM实例/主机视为在线
+	overviewHighThreshold = 85.0            // 主机CPU/内存高使用率阈值（百分比）
+)
+
+// MonitorOverviewController 监控概览控制器，汇总JVM实例和主机的运行状态
+type MonitorOverviewController struct {
+	dao *dao.HostMetricDAO
+}
+
+// NewMonitorOverviewController 创建监控概览控制器
+func NewMonitorOverviewController(db database.Database) *MonitorOverviewController {
+	return &MonitorOverviewController{dao: dao.NewHostMetricDAO(db)}
+}
+
+// QueryMonitorOverview 查询监控概览
+// 主机数据包含网关内置采集器和采集端上报的数据，使用率取每台主机在线判断窗口内最新的采集值
+func (c *MonitorOverviewController) QueryMonitorOverview(ctx *gin.Context) {
+	tenantId := request.GetTenantID(ctx)
+	activeTime := time.Now().Add(-overviewActiveWindow)
+
+	jvm, err := c.dao.GetJvmOverview(ctx, tenantId, activeTime)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "统计JVM监控概览失败", "error", err)
+		response.ErrorJSON(ctx, "查询监控概览失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	host := &models.HostOverview{HighThreshold: overviewHighThreshold}
+	if host.Total, err = c.dao.CountServers(ctx, tenantId); err != nil {
+		logger.ErrorWithTrace(ctx, "统计服务器失败", "error", err)
+		response.ErrorJSON(ctx, "查询监控概览失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	cpus, err := c.dao.ListCpuUsage(ctx, tenantId, activeTime)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询主机CPU使用率失败", "error", err)
+		response.ErrorJSON(ctx, "查询监控概览失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	memories, err := c.dao.ListMemoryUsage(ctx, tenantId, activeTime)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询主机内存使用率失败", "error", err)
+		response.ErrorJSON(ctx, "查询监控概览失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	latestCpu, latestMemory := latestHostUsage(cpus), latestHostUsage(memories)
+	online := make(map[string]bool, len(latestCpu))
+	for serverId := range latestCpu {
+		online[serverId] = true
+	}
+	for serverId := range latestMemory {
+		online[serverId] = true
+	}
+	host.Online = len(online)
+	host.HighCpu, host.AvgCpuUsage = summarizeHostUsage(latestCpu, overviewHighThreshold)
+	host.HighMemory, host.AvgMemUsage = summarizeHostUsage(latestMemory, overviewHighThreshold)
+
+	response.SuccessJSON(ctx, &models.MonitorOverview{
+		Jvm:        jvm,
+		Host:       host,
+		ActiveTime: activeTime,
+	}, constants.SD00002)
+}
+
+// latestHostUsage 取每台主机最新的使用率
+func latestHostUsage(points []*models.HostUsagePoint) map[string]*models.HostUsagePoint {
+	latest := make(map[string]*models.HostUsagePoint, len(points))
+	for _, point := range points {
+		if point == nil {
+			continue
+		}
+		if current, ok := latest[point.MetricServerId]; !ok || point.CollectTime.After(current.CollectTime) {
+			latest[point.MetricServerId] = point
+		}
+	}
+	return latest
+}
+
+// summarizeHostUsage 统计使用率不低于阈值的主机数和平均使用率，平均值保留两位小数
+func summarizeHostUsage(latest map[string]*models.HostUsagePoint, threshold float64) (int, float64) {
+	if len(latest) == 0 {
+		return 0, 0
+	}
+	high, sum := 0, 0.0
+	for _, point := range latest {
+		if point.UsagePercent >= threshold {
+			high++
+		}
+		sum += point.UsagePercent
+	}
+	return high, math.Round(sum/float64(len(latest))*100) / 100
+}
@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"gateway/internal/servicecenter"
+	"gateway/internal/servicecenter/types"
+	"gateway/pkg/database"
+	"gateway/pkg/logger"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/utils/validation"
+	"gateway/web/views/hub0042/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxNodeWeight 手动调整权重的上限，与节点权重字段 DECIMAL(6,2) 的取值范围一致
+const maxNodeWeight = 10000
+
+// AdjustNodeWeight 手动调整节点权重
+// @Summary 手动调整节点权重
+// @Description 运行期覆盖节点的服务发现权重（0表示摘除节点流量），与节点注册的权重分开保存，调整立即推送给订阅者并记录操作审计
+// @Tags 服务监控
+// @Accept json
+// @Produce json
+// @Param request body models.NodeWeightRequest true "节点ID、权重和调整原因"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0042/nodes/weight [post]
+func (c *ServiceController) AdjustNodeWeight(ctx *gin.Context) {
+	var req models.NodeWeightRequest
+	if err := request.BindSafely(ctx, &req); err != nil {
+		if validationErr, ok := err.(*validation.Error); ok {
+			response.ValidationErrorJSON(ctx, validationErr, constants.ED00006)
+			return
+		}
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+	if req.Weight == nil {
+		response.ErrorJSON(ctx, "weight不能为空", constants.ED00006)
+		return
+	}
+	if *req.Weight < 0 || *req.Weight > maxNodeWeight {
+		response.ErrorJSON(ctx, fmt.Sprintf("weight取值范围为0-%d", maxNodeWeight), constants.ED00006)
+		return
+	}
+
+	c.setNodeWeightOverride(ctx, req.NodeId, req.Weight, req.Reason)
+}
+
+// ResetNodeWeight 恢复节点使用注册的权重
+// @Summary 恢复节点注册权重
+// @Description 清除手动调整的节点权重，节点恢复使用注册时上报的权重，调整立即推送给订阅者并记录操作审计
+// @Tags 服务监控
+// @Accept json
+// @Produce json
+// @Param request body models.NodeWeightRequest true "节点ID和调整原因"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0042/nodes/weight/reset [post]
+func (c *ServiceController) ResetNodeWeight(ctx *gin.Context) {
+	var req models.NodeWeightRequest
+	if err := request.BindSafely(ctx, &req); err != nil {
+		if validationErr, ok := err.(*validation.Error); ok {
+			response.ValidationErrorJSON(ctx, validationErr, constants.ED00006)
+			return
+		}
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	c.setNodeWeightOverride(ctx, req.NodeId, nil, req.Reason)
+}
+
+// setNodeWeightOverride 更新节点手动调整的权重并记录操作审计，weight 为 nil 时恢复使用注册权重
+func (c *ServiceController) setNodeWeightOverride(ctx *gin.Context, nodeId string, weight *float64, reason string) {
+	tenantId := request.GetTenantID(ctx)
+	operatorId := request.GetOperatorID(ctx)
+
+	serviceCenterManager := servicecenter.GetManager()
+	if serviceCenterManager == nil {
+		response.ErrorJSON(ctx, "服务中心管理器未初始化", constants.ED00009)
+		return
+	}
+
+	node, previous, err := serviceCenterManager.SetNodeWeightOverrideInCache(ctx, tenantId, nodeId, weight, operatorId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "调整节点权重失败", err, "nodeId", nodeId)
+		response.ErrorJSON(ctx, "调整节点权重失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	// 节点数据只保存在注册中心缓存中，不经过数据库，由审计中间件随本次请求写入操作审计
+	recordNodeWeightAudit(ctx.Request.Context(), node, previous, weight, reason)
+
+	logger.InfoWithTrace(ctx, "节点权重调整成功",
+		"nodeId", nodeId,
+		"tenantId", tenantId,
+		"operatorId", operatorId,
+		"serviceName", node.ServiceName,
+		"registeredWeight", node.Weight,
+		"discoveryWeight", node.DiscoveryWeight(),
+		"reason", reason)
+
+	response.SuccessJSON(ctx, node, constants.SD00004)
+}
+
+// recordNodeWeightAudit 记录节点权重调整的操作审计
+func recordNodeWeightAudit(ctx context.Context, node *types.ServiceNode, previous, current *float64, reason string) {
+	recorder := database.AuditRecorderFromContext(ctx)
+	if recorder == nil {
+		return
+	}
+
+	row := func(weightOverride *float64) map[string]interface{} {
+		data := map[string]interface{}{
+			"nodeId":           node.NodeId,
+			"serviceName":      node.ServiceName,
+			"ipAddress":        node.IpAddress,
+			"portNumber":       node.PortNumber,
+			"registeredWeight": node.Weight,
+			"weightOverride":   nil,
+		}
+		if weightOverride != nil {
+			data["weightOverride"] = *weightOverride
+		}
+		return data
+	}
+	before, after := row(previous), row(current)
+	if reason != "" {
+		after["reason"] = reason
+	}
+
+	recorder.Record(database.AuditChange{
+		Table:     "HUB_SERVICE_NODE",
+		Operation: database.AuditOperationUpdate,
+		Affected:  1,
+		Before:    []map[string]interface{}{before},
+		After:     []map[string]interface{}{after},
+		Diff: []map[string]database.AuditFieldChange{{
+			"weightOverride": {Before: before["weightOverride"], After: after["weightOverride"]},
+		}},
+	})
+}
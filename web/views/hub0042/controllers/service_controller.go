@@ -5,6 +5,7 @@ import (
 
 	"gateway/internal/servicecenter"
 	"gateway/internal/servicecenter/cache"
+	"gateway/internal/servicecenter/stats"
 	"gateway/internal/servicecenter/types"
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
@@ -546,6 +547,18 @@ func (c *ServiceController) EditNode(ctx *gin.Context) {
 	if req.ActiveFlag == "" {
 		req.ActiveFlag = currentNode.ActiveFlag
 	}
+	if req.ExtProperty == "" {
+		req.ExtProperty = currentNode.ExtProperty
+	}
+	// 手动调整的权重只能通过 adjustNodeWeight/resetNodeWeight 修改，编辑节点时保持不变
+	var weightOverride *float64
+	if weight, ok := currentNode.WeightOverride(); ok {
+		weightOverride = &weight
+	}
+	if err := req.SetWeightOverride(weightOverride); err != nil {
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
 
 	// 通过 ServiceCenterManager 更新节点缓存（不操作数据库，由外部异步同步服务负责持久化）
 	if err := serviceCenterManager.UpdateNodeInCache(ctx, &req); err != nil {
@@ -623,3 +636,33 @@ func (c *ServiceController) OfflineNode(ctx *gin.Context) {
 	// 直接返回节点对象（结构体有完整的 JSON tag）
 	response.SuccessJSON(ctx, currentNode, constants.SD00004)
 }
+
+// GetNodeHealthHistory 获取节点健康状态切换历史
+// @Summary 获取节点健康状态切换历史
+// @Description 返回注册中心运行期间记录的节点最近健康状态切换，以及是否因健康状态抖动处于隔离期
+// @Tags 服务监控
+// @Produce json
+// @Param nodeId query string true "节点ID"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0042/nodes/health-history [post]
+func (c *ServiceController) GetNodeHealthHistory(ctx *gin.Context) {
+	nodeId := request.GetParam(ctx, "nodeId")
+	tenantId := request.GetTenantID(ctx)
+
+	if nodeId == "" {
+		response.ErrorJSON(ctx, "nodeId不能为空", constants.ED00006)
+		return
+	}
+
+	history := stats.GetNodeHealthHistory(tenantId, nodeId)
+	if history == nil {
+		// 节点尚未记录过健康状态（如注册中心重启后）
+		history = &stats.NodeHealthHistory{
+			TenantId:    tenantId,
+			NodeId:      nodeId,
+			Transitions: []stats.HealthTransition{},
+		}
+	}
+
+	response.SuccessJSON(ctx, history, constants.SD00002)
+}
@@ -0,0 +1,153 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gateway/internal/metric_collect/types"
+	"gateway/pkg/database"
+	"gateway/pkg/utils/huberrors"
+	"gateway/web/views/hub0042/models"
+)
+
+// HostMetricDAO 主机指标写入与概览统计DAO
+// 主机指标复用网关内置采集器的 HUB_METRIC_* 表，上报数据与本机采集数据可在 hub0007 中统一查询
+type HostMetricDAO struct {
+	db database.Database
+}
+
+// NewHostMetricDAO 创建主机指标DAO
+func NewHostMetricDAO(db database.Database) *HostMetricDAO {
+	return &HostMetricDAO{db: db}
+}
+
+// SaveReports 在一个事务中写入一批上报数据
+// 服务器信息按主键存在则更新、不存在则插入，各类采集日志通过 BatchInsert 追加
+//
+// 返回:
+//
+//	error: 任一写入失败时整批回滚并返回错误
+func (dao *HostMetricDAO) SaveReports(ctx context.Context, rows *models.HostMetricRows) error {
+	return dao.db.InTx(ctx, nil, func(txCtx context.Context) error {
+		for _, server := range rows.Servers {
+			if err := dao.saveServer(txCtx, server); err != nil {
+				return err
+			}
+		}
+		if _, err := dao.db.BatchInsert(txCtx, (&types.CpuLog{}).TableName(), rows.Cpus, false); err != nil {
+			return huberrors.WrapError(err, "写入CPU采集日志失败")
+		}
+		if _, err := dao.db.BatchInsert(txCtx, (&types.MemoryLog{}).TableName(), rows.Memories, false); err != nil {
+			return huberrors.WrapError(err, "写入内存采集日志失败")
+		}
+		if _, err := dao.db.BatchInsert(txCtx, (&types.DiskPartitionLog{}).TableName(), rows.DiskPartitions, false); err != nil {
+			return huberrors.WrapError(err, "写入磁盘分区日志失败")
+		}
+		if _, err := dao.db.BatchInsert(txCtx, (&types.DiskIoLog{}).TableName(), rows.DiskIos, false); err != nil {
+			return huberrors.WrapError(err, "写入磁盘IO日志失败")
+		}
+		if _, err := dao.db.BatchInsert(txCtx, (&types.NetworkLog{}).TableName(), rows.Networks, false); err != nil {
+			return huberrors.WrapError(err, "写入网络采集日志失败")
+		}
+		if _, err := dao.db.BatchInsert(txCtx, (&types.ProcessStatsLog{}).TableName(), rows.ProcessStats, false); err != nil {
+			return huberrors.WrapError(err, "写入进程统计日志失败")
+		}
+		if _, err := dao.db.BatchInsert(txCtx, (&types.ProcessLog{}).TableName(), rows.Processes, false); err != nil {
+			return huberrors.WrapError(err, "写入进程采集日志失败")
+		}
+		return nil
+	})
+}
+
+// saveServer 写入服务器信息，已存在时只更新上报的非零字段，保留创建信息
+func (dao *HostMetricDAO) saveServer(ctx context.Context, server *types.ServerInfo) error {
+	table := server.TableName()
+	where := "tenantId = ? AND metricServerId = ?"
+	args := []interface{}{server.TenantId, server.MetricServerId}
+
+	var result struct {
+		Count int `db:"COUNT(*)"`
+	}
+	if err := dao.db.QueryOne(ctx, &result, "SELECT COUNT(*) FROM "+table+" WHERE "+where, args, false); err != nil {
+		return huberrors.WrapError(err, "查询服务器信息失败")
+	}
+
+	if result.Count == 0 {
+		if _, err := dao.db.Insert(ctx, table, server, false); err != nil {
+			return huberrors.WrapError(err, "写入服务器信息失败")
+		}
+		return nil
+	}
+
+	// 零值字段不参与更新，清空创建信息和版本号以保留原值
+	update := *server
+	update.AddTime = time.Time{}
+	update.AddWho = ""
+	update.CurrentVersion = 0
+	if _, err := dao.db.Update(ctx, table, &update, where, args, false, true); err != nil {
+		return huberrors.WrapError(err, "更新服务器信息失败")
+	}
+	return nil
+}
+
+// GetJvmOverview 统计租户的JVM实例状态和告警中的事件数
+// 参数:
+//
+//	ctx: 上下文
+//	tenantId: 租户ID
+//	activeTime: 采集时间不早于该时间的实例视为在线
+func (dao *HostMetricDAO) GetJvmOverview(ctx context.Context, tenantId string, activeTime time.Time) (*models.JvmOverview, error) {
+	query := `SELECT COUNT(*) AS total,
+			COALESCE(SUM(CASE WHEN collectionTime >= ? THEN 1 ELSE 0 END), 0) AS online,
+			COALESCE(SUM(CASE WHEN healthyFlag = 'N' THEN 1 ELSE 0 END), 0) AS unhealthy,
+			COALESCE(SUM(CASE WHEN requiresAttentionFlag = 'Y' THEN 1 ELSE 0 END), 0) AS requiresAttention
+		FROM HUB_MONITOR_JVM_RESOURCE WHERE tenantId = ? AND activeFlag = 'Y'`
+	overview := &models.JvmOverview{}
+	if err := dao.db.QueryOne(ctx, overview, query, []interface{}{activeTime, tenantId}, true); err != nil {
+		return nil, huberrors.WrapError(err, "统计JVM实例失败")
+	}
+
+	var firing struct {
+		Count int `db:"COUNT(*)"`
+	}
+	err := dao.db.QueryOne(ctx, &firing, "SELECT COUNT(*) FROM HUB_MONITOR_ALERT_EVENT WHERE tenantId = ? AND eventStatus = ? AND activeFlag = 'Y'",
+		[]interface{}{tenantId, models.AlertEventFiring}, true)
+	if err != nil {
+		return nil, huberrors.WrapError(err, "统计告警事件失败")
+	}
+	overview.FiringAlerts = firing.Count
+	return overview, nil
+}
+
+// CountServers 统计租户的服务器数
+func (dao *HostMetricDAO) CountServers(ctx context.Context, tenantId string) (int, error) {
+	var result struct {
+		Count int `db:"COUNT(*)"`
+	}
+	query := "SELECT COUNT(*) FROM " + (&types.ServerInfo{}).TableName() + " WHERE tenantId = ? AND activeFlag = 'Y'"
+	if err := dao.db.QueryOne(ctx, &result, query, []interface{}{tenantId}, true); err != nil {
+		return 0, huberrors.WrapError(err, "统计服务器失败")
+	}
+	return result.Count, nil
+}
+
+// ListCpuUsage 查询租户在 since 之后的CPU使用率采集点
+func (dao *HostMetricDAO) ListCpuUsage(ctx context.Context, tenantId string, since time.Time) ([]*models.HostUsagePoint, error) {
+	return dao.listUsage(ctx, (&types.CpuLog{}).TableName(), tenantId, since)
+}
+
+// ListMemoryUsage 查询租户在 since 之后的内存使用率采集点
+func (dao *HostMetricDAO) ListMemoryUsage(ctx context.Context, tenantId string, since time.Time) ([]*models.HostUsagePoint, error) {
+	return dao.listUsage(ctx, (&types.MemoryLog{}).TableName(), tenantId, since)
+}
+
+// listUsage 查询使用率采集点，表需包含 metricServerId、usagePercent、collectTime 字段
+func (dao *HostMetricDAO) listUsage(ctx context.Context, table, tenantId string, since time.Time) ([]*models.HostUsagePoint, error) {
+	query := "SELECT metricServerId, usagePercent, collectTime FROM " + table +
+		" WHERE tenantId = ? AND collectTime >= ? AND activeFlag = 'Y'"
+	var points []*models.HostUsagePoint
+	if err := dao.db.Query(ctx, &points, query, []interface{}{tenantId, since}, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询%s失败", table)
+	}
+	return points, nil
+}
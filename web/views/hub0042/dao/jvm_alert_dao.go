@@ -0,0 +1,454 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gateway/pkg/database"
+	"gateway/pkg/database/sqlutils"
+	"gateway/pkg/utils/empty"
+	"gateway/pkg/utils/huberrors"
+	"gateway/web/views/hub0042/models"
+)
+
+// jvmMetricQueries 各监控指标的采集数据查询，统一返回 JvmMetricSample 字段
+// 采集数据由应用端写入 HUB_MONITOR_JVM_* 表，网关错误率由网关自监控任务写入 HUB_MONITOR_APP_DATA，这里只读取
+var jvmMetricQueries = map[string]string{
+	models.AlertMetricHeapUsage: `
+		SELECT m.jvmResourceId, r.applicationName, r.hostName, r.hostIpAddress, m.usagePercent AS metricValue, m.collectionTime
+		FROM HUB_MONITOR_JVM_MEMORY m
+		JOIN HUB_MONITOR_JVM_RESOURCE r ON r.tenantId = m.tenantId AND r.jvmResourceId = m.jvmResourceId
+		WHERE m.tenantId = ? AND m.memoryType = 'HEAP' AND m.activeFlag = 'Y' AND m.collectionTime >= ?`,
+	models.AlertMetricNonHeapUsage: `
+		SELECT m.jvmResourceId, r.applicationName, r.hostName, r.hostIpAddress, m.usagePercent AS metricValue, m.collectionTime
+		FROM HUB_MONITOR_JVM_MEMORY m
+		JOIN HUB_MONITOR_JVM_RESOURCE r ON r.tenantId = m.tenantId AND r.jvmResourceId = m.jvmResourceId
+		WHERE m.tenantId = ? AND m.memoryType = 'NON_HEAP' AND m.activeFlag = 'Y' AND m.collectionTime >= ?`,
+	models.AlertMetricFGCCount: `
+		SELECT g.jvmResourceId, r.applicationName, r.hostName, r.hostIpAddress, g.fgc AS metricValue, g.collectionTime
+		FROM HUB_MONITOR_JVM_GC g
+		JOIN HUB_MONITOR_JVM_RESOURCE r ON r.tenantId = g.tenantId AND r.jvmResourceId = g.jvmResourceId
+		WHERE g.tenantId = ? AND g.activeFlag = 'Y' AND g.collectionTime >= ?`,
+	models.AlertMetricGCCount: `
+		SELECT g.jvmResourceId, r.applicationName, r.hostName, r.hostIpAddress, g.ygc + g.fgc AS metricValue, g.collectionTime
+		FROM HUB_MONITOR_JVM_GC g
+		JOIN HUB_MONITOR_JVM_RESOURCE r ON r.tenantId = g.tenantId AND r.jvmResourceId = g.jvmResourceId
+		WHERE g.tenantId = ? AND g.activeFlag = 'Y' AND g.collectionTime >= ?`,
+	models.AlertMetricThreadCount: `
+		SELECT t.jvmResourceId, r.applicationName, r.hostName, r.hostIpAddress, t.currentThreadCount AS metricValue, t.collectionTime
+		FROM HUB_MONITOR_JVM_THREAD t
+		JOIN HUB_MONITOR_JVM_RESOURCE r ON r.tenantId = t.tenantId AND r.jvmResourceId = t.jvmResourceId
+		WHERE t.tenantId = ? AND t.activeFlag = 'Y' AND t.collectionTime >= ?`,
+	models.AlertMetricDeadlock: `
+		SELECT d.jvmResourceId, r.applicationName, r.hostName, r.hostIpAddress,
+			CASE WHEN d.hasDeadlockFlag = 'Y' THEN d.deadlockThreadCount ELSE 0 END AS metricValue, d.collectionTime
+		FROM HUB_MONITOR_JVM_DEADLOCK d
+		JOIN HUB_MONITOR_JVM_RESOURCE r ON r.tenantId = d.tenantId AND r.jvmResourceId = d.jvmResourceId
+		WHERE d.tenantId = ? AND d.activeFlag = 'Y' AND d.collectionTime >= ?`,
+	models.AlertMetricGatewayErrorRate: `
+		SELECT a.jvmResourceId, r.applicationName, r.hostName, r.hostIpAddress, a.primaryValue AS metricValue, a.collectionTime
+		FROM HUB_MONITOR_APP_DATA a
+		JOIN HUB_MONITOR_JVM_RESOURCE r ON r.tenantId = a.tenantId AND r.jvmResourceId = a.jvmResourceId
+		WHERE a.tenantId = ? AND a.dataType = '` + models.AppDataTypeCustomMetric + `' AND a.dataName = '` + models.GatewayTrafficDataName + `'
+			AND a.primaryValue IS NOT NULL AND a.activeFlag = 'Y' AND a.collectionTime >= ?`,
+}
+
+// IsSupportedAlertMetric 是否支持指定监控指标
+func IsSupportedAlertMetric(metricType string) bool {
+	_, ok := jvmMetricQueries[metricType]
+	return ok
+}
+
+// JvmAlertDAO JVM告警DAO，对应表 HUB_MONITOR_ALERT_RULE、HUB_MONITOR_ALERT_SILENCE、HUB_MONITOR_ALERT_EVENT
+type JvmAlertDAO struct {
+	db database.Database
+}
+
+// NewJvmAlertDAO 创建JVM告警DAO
+func NewJvmAlertDAO(db database.Database) *JvmAlertDAO {
+	return &JvmAlertDAO{db: db}
+}
+
+// GetAlertRule 根据主键获取告警规则，不存在时返回nil
+func (dao *JvmAlertDAO) GetAlertRule(ctx context.Context, tenantId, alertRuleId string) (*models.AlertRule, error) {
+	if alertRuleId == "" {
+		return nil, errors.New("alertRuleId不能为空")
+	}
+
+	query := `SELECT * FROM HUB_MONITOR_ALERT_RULE WHERE tenantId = ? AND alertRuleId = ? AND activeFlag = 'Y'`
+	args := []interface{}{tenantId, alertRuleId}
+
+	var rule models.AlertRule
+	err := dao.db.QueryOne(ctx, &rule, query, args, true)
+	if err != nil {
+		if err == database.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, huberrors.WrapError(err, "查询告警规则失败")
+	}
+	return &rule, nil
+}
+
+// QueryAlertRules 分页查询告警规则
+func (dao *JvmAlertDAO) QueryAlertRules(ctx context.Context, tenantId string, q *models.AlertRuleListRequest, page, pageSize int) ([]*models.AlertRule, int, error) {
+	whereClause := "WHERE tenantId = ? AND activeFlag = 'Y'"
+	params := []interface{}{tenantId}
+
+	if q != nil {
+		if !empty.IsEmpty(q.RuleName) {
+			whereClause += " AND ruleName LIKE ?"
+			params = append(params, "%"+q.RuleName+"%")
+		}
+		if !empty.IsEmpty(q.MetricType) {
+			whereClause += " AND metricType = ?"
+			params = append(params, q.MetricType)
+		}
+		if !empty.IsEmpty(q.ApplicationName) {
+			whereClause += " AND applicationName = ?"
+			params = append(params, q.ApplicationName)
+		}
+		if !empty.IsEmpty(q.EnabledFlag) {
+			whereClause += " AND enabledFlag = ?"
+			params = append(params, q.EnabledFlag)
+		}
+	}
+
+	baseQuery := fmt.Sprintf(`
+		SELECT * FROM HUB_MONITOR_ALERT_RULE
+		%s
+		ORDER BY editTime DESC
+	`, whereClause)
+
+	var rows []*models.AlertRule
+	total, err := dao.queryPage(ctx, &rows, baseQuery, params, page, pageSize)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "查询告警规则失败")
+	}
+	if rows == nil {
+		rows = []*models.AlertRule{}
+	}
+	return rows, total, nil
+}
+
+// ListEnabledAlertRules 查询所有租户已启用的告警规则，供告警引擎周期评估
+func (dao *JvmAlertDAO) ListEnabledAlertRules(ctx context.Context) ([]*models.AlertRule, error) {
+	query := `SELECT * FROM HUB_MONITOR_ALERT_RULE WHERE activeFlag = 'Y' AND enabledFlag = 'Y' ORDER BY tenantId, alertRuleId`
+
+	var rows []*models.AlertRule
+	if err := dao.db.Query(ctx, &rows, query, []interface{}{}, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询启用的告警规则失败")
+	}
+	return rows, nil
+}
+
+// CreateAlertRule 创建告警规则
+func (dao *JvmAlertDAO) CreateAlertRule(ctx context.Context, rule *models.AlertRule) error {
+	if rule == nil {
+		return errors.New("rule不能为空")
+	}
+	_, err := dao.db.Insert(ctx, "HUB_MONITOR_ALERT_RULE", rule, true)
+	if err != nil {
+		return huberrors.WrapError(err, "创建告警规则失败")
+	}
+	return nil
+}
+
+// UpdateAlertRule 更新告警规则
+func (dao *JvmAlertDAO) UpdateAlertRule(ctx context.Context, rule *models.AlertRule) error {
+	if rule == nil {
+		return errors.New("rule不能为空")
+	}
+	where := "tenantId = ? AND alertRuleId = ?"
+	args := []interface{}{rule.TenantId, rule.AlertRuleId}
+	_, err := dao.db.Update(ctx, "HUB_MONITOR_ALERT_RULE", rule, where, args, true, false)
+	if err != nil {
+		return huberrors.WrapError(err, "更新告警规则失败")
+	}
+	return nil
+}
+
+// DeleteAlertRule 删除告警规则（逻辑删除），已产生的告警事件保留作为历史
+func (dao *JvmAlertDAO) DeleteAlertRule(ctx context.Context, tenantId, alertRuleId, operatorId string) error {
+	if alertRuleId == "" {
+		return errors.New("alertRuleId不能为空")
+	}
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, "UPDATE HUB_MONITOR_ALERT_RULE SET activeFlag = 'N', enabledFlag = 'N', editWho = ?, editTime = ? WHERE tenantId = ? AND alertRuleId = ?",
+		[]interface{}{operatorId, now, tenantId, alertRuleId}, true)
+	if err != nil {
+		return huberrors.WrapError(err, "删除告警规则失败")
+	}
+	return nil
+}
+
+// UpdateLastEvalTime 更新规则最近评估时间
+func (dao *JvmAlertDAO) UpdateLastEvalTime(ctx context.Context, tenantId, alertRuleId string, evalTime time.Time) error {
+	_, err := dao.db.Exec(ctx, "UPDATE HUB_MONITOR_ALERT_RULE SET lastEvalTime = ? WHERE tenantId = ? AND alertRuleId = ?",
+		[]interface{}{evalTime, tenantId, alertRuleId}, true)
+	if err != nil {
+		return huberrors.WrapError(err, "更新规则评估时间失败")
+	}
+	return nil
+}
+
+// ListMetricSamples 查询规则匹配范围内 since 之后的采集数据，排序由评估时按实例和采集时间完成
+func (dao *JvmAlertDAO) ListMetricSamples(ctx context.Context, rule *models.AlertRule, since time.Time) ([]*models.JvmMetricSample, error) {
+	query, ok := jvmMetricQueries[rule.MetricType]
+	if !ok {
+		return nil, fmt.Errorf("不支持的监控指标: %s", rule.MetricType)
+	}
+	params := []interface{}{rule.TenantId, since}
+
+	if !empty.IsEmpty(rule.ServiceGroupId) {
+		query += " AND r.serviceGroupId = ?"
+		params = append(params, rule.ServiceGroupId)
+	}
+	if !empty.IsEmpty(rule.ApplicationName) {
+		query += " AND r.applicationName = ?"
+		params = append(params, rule.ApplicationName)
+	}
+
+	var rows []*models.JvmMetricSample
+	if err := dao.db.Query(ctx, &rows, query, params, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询JVM采集数据失败")
+	}
+	return rows, nil
+}
+
+// MarkRequiresAttention 将JVM实例标记为需要立即关注，应用端下次上报时按上报值覆盖
+func (dao *JvmAlertDAO) MarkRequiresAttention(ctx context.Context, tenantId string, jvmResourceIds []string, now time.Time) error {
+	if len(jvmResourceIds) == 0 {
+		return nil
+	}
+	placeholders := strings.Repeat("?,", len(jvmResourceIds))
+	query := "UPDATE HUB_MONITOR_JVM_RESOURCE SET requiresAttentionFlag = 'Y', editTime = ? WHERE tenantId = ? AND jvmResourceId IN (" +
+		placeholders[:len(placeholders)-1] + ")"
+	args := []interface{}{now, tenantId}
+	for _, id := range jvmResourceIds {
+		args = append(args, id)
+	}
+	if _, err := dao.db.Exec(ctx, query, args, true); err != nil {
+		return huberrors.WrapError(err, "标记JVM实例需要关注失败")
+	}
+	return nil
+}
+
+// QueryAlertSilences 分页查询告警静默
+func (dao *JvmAlertDAO) QueryAlertSilences(ctx context.Context, tenantId string, q *models.AlertSilenceListRequest, page, pageSize int) ([]*models.AlertSilence, int, error) {
+	whereClause := "WHERE tenantId = ? AND activeFlag = 'Y'"
+	params := []interface{}{tenantId}
+
+	if q != nil {
+		if !empty.IsEmpty(q.AlertRuleId) {
+			whereClause += " AND alertRuleId = ?"
+			params = append(params, q.AlertRuleId)
+		}
+		if !empty.IsEmpty(q.ApplicationName) {
+			whereClause += " AND applicationName = ?"
+			params = append(params, q.ApplicationName)
+		}
+		if q.OnlyActive == "Y" {
+			whereClause += " AND endTime >= ?"
+			params = append(params, time.Now())
+		}
+	}
+
+	baseQuery := fmt.Sprintf(`
+		SELECT * FROM HUB_MONITOR_ALERT_SILENCE
+		%s
+		ORDER BY endTime DESC
+	`, whereClause)
+
+	var rows []*models.AlertSilence
+	total, err := dao.queryPage(ctx, &rows, baseQuery, params, page, pageSize)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "查询告警静默失败")
+	}
+	if rows == nil {
+		rows = []*models.AlertSilence{}
+	}
+	return rows, total, nil
+}
+
+// ListActiveSilences 查询租户在指定时间生效的告警静默
+func (dao *JvmAlertDAO) ListActiveSilences(ctx context.Context, tenantId string, now time.Time) ([]*models.AlertSilence, error) {
+	query := `SELECT * FROM HUB_MONITOR_ALERT_SILENCE WHERE tenantId = ? AND activeFlag = 'Y' AND startTime <= ? AND endTime >= ?`
+
+	var rows []*models.AlertSilence
+	if err := dao.db.Query(ctx, &rows, query, []interface{}{tenantId, now, now}, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询告警静默失败")
+	}
+	return rows, nil
+}
+
+// CreateAlertSilence 创建告警静默
+func (dao *JvmAlertDAO) CreateAlertSilence(ctx context.Context, silence *models.AlertSilence) error {
+	if silence == nil {
+		return errors.New("silence不能为空")
+	}
+	_, err := dao.db.Insert(ctx, "HUB_MONITOR_ALERT_SILENCE", silence, true)
+	if err != nil {
+		return huberrors.WrapError(err, "创建告警静默失败")
+	}
+	return nil
+}
+
+// DeleteAlertSilence 删除告警静默（逻辑删除），删除后立即恢复通知
+func (dao *JvmAlertDAO) DeleteAlertSilence(ctx context.Context, tenantId, alertSilenceId, operatorId string) error {
+	if alertSilenceId == "" {
+		return errors.New("alertSilenceId不能为空")
+	}
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, "UPDATE HUB_MONITOR_ALERT_SILENCE SET activeFlag = 'N', editWho = ?, editTime = ? WHERE tenantId = ? AND alertSilenceId = ?",
+		[]interface{}{operatorId, now, tenantId, alertSilenceId}, true)
+	if err != nil {
+		return huberrors.WrapError(err, "删除告警静默失败")
+	}
+	return nil
+}
+
+// QueryAlertEvents 分页查询告警历史，按首次触发时间倒序
+func (dao *JvmAlertDAO) QueryAlertEvents(ctx context.Context, tenantId string, q *models.AlertEventListRequest, page, pageSize int) ([]*models.AlertEvent, int, error) {
+	whereClause := "WHERE tenantId = ? AND activeFlag = 'Y'"
+	params := []interface{}{tenantId}
+
+	if q != nil {
+		if !empty.IsEmpty(q.AlertRuleId) {
+			whereClause += " AND alertRuleId = ?"
+			params = append(params, q.AlertRuleId)
+		}
+		if !empty.IsEmpty(q.MetricType) {
+			whereClause += " AND metricType = ?"
+			params = append(params, q.MetricType)
+		}
+		if !empty.IsEmpty(q.ApplicationName) {
+			whereClause += " AND applicationName = ?"
+			params = append(params, q.ApplicationName)
+		}
+		if !empty.IsEmpty(q.JvmResourceId) {
+			whereClause += " AND jvmResourceId = ?"
+			params = append(params, q.JvmResourceId)
+		}
+		if !empty.IsEmpty(q.EventStatus) {
+			whereClause += " AND eventStatus = ?"
+			params = append(params, q.EventStatus)
+		}
+		if !empty.IsEmpty(q.AlertLevel) {
+			whereClause += " AND alertLevel = ?"
+			params = append(params, q.AlertLevel)
+		}
+		if !empty.IsEmpty(q.StartTime) {
+			startTime, err := time.ParseInLocation("2006-01-02 15:04:05", q.StartTime, time.Local)
+			if err != nil {
+				return nil, 0, fmt.Errorf("开始时间格式错误: %w", err)
+			}
+			whereClause += " AND firstTriggerTime >= ?"
+			params = append(params, startTime)
+		}
+		if !empty.IsEmpty(q.EndTime) {
+			endTime, err := time.ParseInLocation("2006-01-02 15:04:05", q.EndTime, time.Local)
+			if err != nil {
+				return nil, 0, fmt.Errorf("结束时间格式错误: %w", err)
+			}
+			whereClause += " AND firstTriggerTime <= ?"
+			params = append(params, endTime)
+		}
+	}
+
+	baseQuery := fmt.Sprintf(`
+		SELECT * FROM HUB_MONITOR_ALERT_EVENT
+		%s
+		ORDER BY firstTriggerTime DESC
+	`, whereClause)
+
+	var rows []*models.AlertEvent
+	total, err := dao.queryPage(ctx, &rows, baseQuery, params, page, pageSize)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "查询告警历史失败")
+	}
+	if rows == nil {
+		rows = []*models.AlertEvent{}
+	}
+	return rows, total, nil
+}
+
+// ListFiringEvents 查询规则当前处于告警中的事件
+func (dao *JvmAlertDAO) ListFiringEvents(ctx context.Context, tenantId, alertRuleId string) ([]*models.AlertEvent, error) {
+	query := `SELECT * FROM HUB_MONITOR_ALERT_EVENT WHERE tenantId = ? AND alertRuleId = ? AND eventStatus = ? AND activeFlag = 'Y'`
+
+	var rows []*models.AlertEvent
+	if err := dao.db.Query(ctx, &rows, query, []interface{}{tenantId, alertRuleId, models.AlertEventFiring}, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询告警中的事件失败")
+	}
+	return rows, nil
+}
+
+// CreateAlertEvent 写入告警事件
+func (dao *JvmAlertDAO) CreateAlertEvent(ctx context.Context, event *models.AlertEvent) error {
+	if event == nil {
+		return errors.New("event不能为空")
+	}
+	_, err := dao.db.Insert(ctx, "HUB_MONITOR_ALERT_EVENT", event, true)
+	if err != nil {
+		return huberrors.WrapError(err, "写入告警事件失败")
+	}
+	return nil
+}
+
+// UpdateAlertEvent 更新告警事件
+func (dao *JvmAlertDAO) UpdateAlertEvent(ctx context.Context, event *models.AlertEvent) error {
+	if event == nil {
+		return errors.New("event不能为空")
+	}
+	where := "tenantId = ? AND alertEventId = ?"
+	args := []interface{}{event.TenantId, event.AlertEventId}
+	_, err := dao.db.Update(ctx, "HUB_MONITOR_ALERT_EVENT", event, where, args, true, false)
+	if err != nil {
+		return huberrors.WrapError(err, "更新告警事件失败")
+	}
+	return nil
+}
+
+// ResolveRuleEvents 将规则下告警中的事件全部置为已恢复，规则停用或删除时调用
+func (dao *JvmAlertDAO) ResolveRuleEvents(ctx context.Context, tenantId, alertRuleId, operatorId string) error {
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, "UPDATE HUB_MONITOR_ALERT_EVENT SET eventStatus = ?, resolvedTime = ?, editWho = ?, editTime = ? WHERE tenantId = ? AND alertRuleId = ? AND eventStatus = ?",
+		[]interface{}{models.AlertEventResolved, now, operatorId, now, tenantId, alertRuleId, models.AlertEventFiring}, true)
+	if err != nil {
+		return huberrors.WrapError(err, "恢复规则告警事件失败")
+	}
+	return nil
+}
+
+// queryPage 执行计数和分页查询，结果写入 dest
+func (dao *JvmAlertDAO) queryPage(ctx context.Context, dest interface{}, baseQuery string, params []interface{}, page, pageSize int) (int, error) {
+	countQuery, err := sqlutils.BuildCountQuery(baseQuery)
+	if err != nil {
+		return 0, huberrors.WrapError(err, "构建计数查询失败")
+	}
+
+	var countResult struct {
+		Count int `db:"COUNT(*)"`
+	}
+	if err := dao.db.QueryOne(ctx, &countResult, countQuery, params, true); err != nil {
+		return 0, err
+	}
+	if countResult.Count == 0 {
+		return 0, nil
+	}
+
+	dbType := sqlutils.GetDatabaseType(dao.db)
+	paginatedQuery, paginationArgs, err := sqlutils.BuildPaginationQuery(dbType, baseQuery, sqlutils.NewPaginationInfo(page, pageSize))
+	if err != nil {
+		return 0, huberrors.WrapError(err, "构建分页查询失败")
+	}
+
+	allArgs := append(params, paginationArgs...)
+	if err := dao.db.Query(ctx, dest, paginatedQuery, allArgs, true); err != nil {
+		return 0, err
+	}
+	return countResult.Count, nil
+}
@@ -0,0 +1,222 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gateway/pkg/database"
+	"gateway/pkg/database/sqlutils"
+	"gateway/pkg/utils/empty"
+	"gateway/pkg/utils/huberrors"
+	"gateway/web/views/hub0042/models"
+)
+
+// jvmDiagListColumns 诊断任务列表查询的字段，不包含结果原文
+const jvmDiagListColumns = `tenantId, diagTaskId, jvmResourceId, applicationName, diagType, taskStatus, requestTime, expireTime,
+	dispatchTime, completeTime, resultSize, threadCount, blockedThreadCount, errorMessage,
+	addTime, addWho, editTime, editWho, oprSeqFlag, currentVersion, activeFlag, noteText`
+
+// JvmDiagDAO JVM诊断采集任务DAO，对应表 HUB_MONITOR_JVM_DIAG_TASK
+type JvmDiagDAO struct {
+	db database.Database
+}
+
+// NewJvmDiagDAO 创建JVM诊断采集任务DAO
+func NewJvmDiagDAO(db database.Database) *JvmDiagDAO {
+	return &JvmDiagDAO{db: db}
+}
+
+// GetResourceApplication 查询JVM资源的应用名称，资源不存在时 found 为 false
+func (dao *JvmDiagDAO) GetResourceApplication(ctx context.Context, tenantId, jvmResourceId string) (string, bool, error) {
+	var result struct {
+		ApplicationName string `db:"applicationName"`
+	}
+	err := dao.db.QueryOne(ctx, &result, "SELECT applicationName FROM HUB_MONITOR_JVM_RESOURCE WHERE tenantId = ? AND jvmResourceId = ? AND activeFlag = 'Y'",
+		[]interface{}{tenantId, jvmResourceId}, true)
+	if err != nil {
+		if err == database.ErrRecordNotFound {
+			return "", false, nil
+		}
+		return "", false, huberrors.WrapError(err, "查询JVM资源失败")
+	}
+	return result.ApplicationName, true, nil
+}
+
+// CountOpenTasks 统计JVM实例未完成（待下发或已下发）的诊断任务数
+func (dao *JvmDiagDAO) CountOpenTasks(ctx context.Context, tenantId, jvmResourceId string) (int, error) {
+	var result struct {
+		Count int `db:"COUNT(*)"`
+	}
+	query := "SELECT COUNT(*) FROM HUB_MONITOR_JVM_DIAG_TASK WHERE tenantId = ? AND jvmResourceId = ? AND taskStatus IN (?, ?) AND activeFlag = 'Y'"
+	args := []interface{}{tenantId, jvmResourceId, models.JvmDiagPending, models.JvmDiagDispatched}
+	if err := dao.db.QueryOne(ctx, &result, query, args, true); err != nil {
+		return 0, huberrors.WrapError(err, "统计未完成的诊断任务失败")
+	}
+	return result.Count, nil
+}
+
+// CreateTask 写入诊断任务
+func (dao *JvmDiagDAO) CreateTask(ctx context.Context, task *models.JvmDiagTask) error {
+	if task == nil {
+		return errors.New("task不能为空")
+	}
+	if _, err := dao.db.Insert(ctx, task.TableName(), task, true); err != nil {
+		return huberrors.WrapError(err, "写入诊断任务失败")
+	}
+	return nil
+}
+
+// GetTask 查询诊断任务（包含结果原文），不存在时返回 nil
+func (dao *JvmDiagDAO) GetTask(ctx context.Context, tenantId, diagTaskId string) (*models.JvmDiagTask, error) {
+	if diagTaskId == "" {
+		return nil, errors.New("diagTaskId不能为空")
+	}
+
+	var task models.JvmDiagTask
+	err := dao.db.QueryOne(ctx, &task, "SELECT * FROM HUB_MONITOR_JVM_DIAG_TASK WHERE tenantId = ? AND diagTaskId = ? AND activeFlag = 'Y'",
+		[]interface{}{tenantId, diagTaskId}, true)
+	if err != nil {
+		if err == database.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, huberrors.WrapError(err, "查询诊断任务失败")
+	}
+	return &task, nil
+}
+
+// QueryTasks 分页查询诊断任务，不返回结果原文，按请求时间倒序
+func (dao *JvmDiagDAO) QueryTasks(ctx context.Context, tenantId string, q *models.JvmDiagTaskListRequest, page, pageSize int) ([]*models.JvmDiagTask, int, error) {
+	whereClause := "WHERE tenantId = ? AND activeFlag = 'Y'"
+	params := []interface{}{tenantId}
+
+	if q != nil {
+		if !empty.IsEmpty(q.JvmResourceId) {
+			whereClause += " AND jvmResourceId = ?"
+			params = append(params, q.JvmResourceId)
+		}
+		if !empty.IsEmpty(q.ApplicationName) {
+			whereClause += " AND applicationName = ?"
+			params = append(params, q.ApplicationName)
+		}
+		if !empty.IsEmpty(q.DiagType) {
+			whereClause += " AND diagType = ?"
+			params = append(params, q.DiagType)
+		}
+		if !empty.IsEmpty(q.TaskStatus) {
+			whereClause += " AND taskStatus = ?"
+			params = append(params, q.TaskStatus)
+		}
+	}
+
+	baseQuery := fmt.Sprintf(`
+		SELECT %s FROM HUB_MONITOR_JVM_DIAG_TASK
+		%s
+		ORDER BY requestTime DESC
+	`, jvmDiagListColumns, whereClause)
+
+	countQuery, err := sqlutils.BuildCountQuery(baseQuery)
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "构建计数查询失败")
+	}
+	var countResult struct {
+		Count int `db:"COUNT(*)"`
+	}
+	if err := dao.db.QueryOne(ctx, &countResult, countQuery, params, true); err != nil {
+		return nil, 0, huberrors.WrapError(err, "统计诊断任务失败")
+	}
+	if countResult.Count == 0 {
+		return []*models.JvmDiagTask{}, 0, nil
+	}
+
+	dbType := sqlutils.GetDatabaseType(dao.db)
+	paginatedQuery, paginationArgs, err := sqlutils.BuildPaginationQuery(dbType, baseQuery, sqlutils.NewPaginationInfo(page, pageSize))
+	if err != nil {
+		return nil, 0, huberrors.WrapError(err, "构建分页查询失败")
+	}
+	var rows []*models.JvmDiagTask
+	if err := dao.db.Query(ctx, &rows, paginatedQuery, append(params, paginationArgs...), true); err != nil {
+		return nil, 0, huberrors.WrapError(err, "查询诊断任务失败")
+	}
+	if rows == nil {
+		rows = []*models.JvmDiagTask{}
+	}
+	return rows, countResult.Count, nil
+}
+
+// ExpireTasks 将超过过期时间仍未完成的诊断任务置为已过期
+func (dao *JvmDiagDAO) ExpireTasks(ctx context.Context, tenantId string, now time.Time) error {
+	_, err := dao.db.Exec(ctx, `UPDATE HUB_MONITOR_JVM_DIAG_TASK SET taskStatus = ?, editTime = ?
+		WHERE tenantId = ? AND taskStatus IN (?, ?) AND expireTime < ? AND activeFlag = 'Y'`,
+		[]interface{}{models.JvmDiagExpired, now, tenantId, models.JvmDiagPending, models.JvmDiagDispatched, now}, true)
+	if err != nil {
+		return huberrors.WrapError(err, "更新过期诊断任务失败")
+	}
+	return nil
+}
+
+// DispatchTasks 领取JVM实例待下发的诊断任务并置为已下发
+// 按任务逐条使用状态条件更新，多个网关节点同时处理上报时同一任务只会下发一次
+// 参数:
+//
+//	ctx: 上下文
+//	tenantId: 租户ID
+//	jvmResourceIds: 本次上报的JVM资源ID
+//	operatorId: 写入 editWho 的操作人
+//	now: 下发时间
+//
+// 返回:
+//
+//	[]*models.JvmDiagTask: 本次下发的任务，不包含结果原文
+//	error: 查询或更新失败时返回错误
+func (dao *JvmDiagDAO) DispatchTasks(ctx context.Context, tenantId string, jvmResourceIds []string, operatorId string, now time.Time) ([]*models.JvmDiagTask, error) {
+	if len(jvmResourceIds) == 0 {
+		return nil, nil
+	}
+	placeholders := strings.Repeat("?,", len(jvmResourceIds))
+	query := "SELECT " + jvmDiagListColumns + " FROM HUB_MONITOR_JVM_DIAG_TASK WHERE tenantId = ? AND taskStatus = ? AND expireTime >= ? AND activeFlag = 'Y'" +
+		" AND jvmResourceId IN (" + placeholders[:len(placeholders)-1] + ") ORDER BY requestTime"
+	args := []interface{}{tenantId, models.JvmDiagPending, now}
+	for _, id := range jvmResourceIds {
+		args = append(args, id)
+	}
+	var pending []*models.JvmDiagTask
+	if err := dao.db.Query(ctx, &pending, query, args, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询待下发的诊断任务失败")
+	}
+
+	dispatched := make([]*models.JvmDiagTask, 0, len(pending))
+	for _, task := range pending {
+		affected, err := dao.db.Exec(ctx, `UPDATE HUB_MONITOR_JVM_DIAG_TASK SET taskStatus = ?, dispatchTime = ?, editTime = ?, editWho = ?
+			WHERE tenantId = ? AND diagTaskId = ? AND taskStatus = ?`,
+			[]interface{}{models.JvmDiagDispatched, now, now, operatorId, tenantId, task.DiagTaskId, models.JvmDiagPending}, true)
+		if err != nil {
+			return nil, huberrors.WrapError(err, "下发诊断任务失败")
+		}
+		if affected == 0 {
+			continue
+		}
+		dispatchTime := now
+		task.TaskStatus, task.DispatchTime = models.JvmDiagDispatched, &dispatchTime
+		dispatched = append(dispatched, task)
+	}
+	return dispatched, nil
+}
+
+// CompleteTask 写入已下发任务的采集结果，任务不是已下发状态（已完成、已过期等）时 updated 为 false
+func (dao *JvmDiagDAO) CompleteTask(ctx context.Context, task *models.JvmDiagTask) (bool, error) {
+	if task == nil {
+		return false, errors.New("task不能为空")
+	}
+	affected, err := dao.db.Exec(ctx, `UPDATE HUB_MONITOR_JVM_DIAG_TASK SET taskStatus = ?, completeTime = ?, resultText = ?, resultSize = ?,
+			threadCount = ?, blockedThreadCount = ?, errorMessage = ?, editTime = ?, editWho = ?
+		WHERE tenantId = ? AND diagTaskId = ? AND taskStatus = ?`,
+		[]interface{}{task.TaskStatus, task.CompleteTime, task.ResultText, task.ResultSize, task.ThreadCount, task.BlockedThreadCount,
+			task.ErrorMessage, task.EditTime, task.EditWho, task.TenantId, task.DiagTaskId, models.JvmDiagDispatched}, true)
+	if err != nil {
+		return false, huberrors.WrapError(err, "写入诊断结果失败")
+	}
+	return affected > 0, nil
+}
@@ -0,0 +1,108 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gateway/pkg/database"
+	"gateway/pkg/utils/huberrors"
+	"gateway/web/views/hub0042/models"
+)
+
+// JvmIngestDAO JVM采集数据写入DAO
+// 对应表 HUB_MONITOR_JVM_RESOURCE、HUB_MONITOR_JVM_MEMORY、HUB_MONITOR_JVM_GC、HUB_MONITOR_JVM_THREAD、HUB_MONITOR_APP_DATA
+type JvmIngestDAO struct {
+	db database.Database
+}
+
+// NewJvmIngestDAO 创建JVM采集数据写入DAO
+func NewJvmIngestDAO(db database.Database) *JvmIngestDAO {
+	return &JvmIngestDAO{db: db}
+}
+
+// SaveSnapshots 在一个事务中写入一批采集数据
+// 资源主表按主键存在则更新、不存在则插入，内存/GC/线程快照通过 BatchInsert 追加
+// 参数:
+//
+//	ctx: 上下文
+//	resources: JVM资源信息
+//	memories: 内存快照
+//	gcs: GC快照
+//	threads: 线程快照
+//
+// 返回:
+//
+//	error: 任一写入失败时整批回滚并返回错误
+func (dao *JvmIngestDAO) SaveSnapshots(ctx context.Context, resources []*models.JvmResource, memories []*models.JvmMemory,
+	gcs []*models.JvmGc, threads []*models.JvmThread) error {
+	return dao.db.InTx(ctx, nil, func(txCtx context.Context) error {
+		for _, resource := range resources {
+			if err := dao.saveResource(txCtx, resource); err != nil {
+				return err
+			}
+		}
+		if _, err := dao.db.BatchInsert(txCtx, "HUB_MONITOR_JVM_MEMORY", memories, false); err != nil {
+			return huberrors.WrapError(err, "写入JVM内存快照失败")
+		}
+		if _, err := dao.db.BatchInsert(txCtx, "HUB_MONITOR_JVM_GC", gcs, false); err != nil {
+			return huberrors.WrapError(err, "写入JVM GC快照失败")
+		}
+		if _, err := dao.db.BatchInsert(txCtx, "HUB_MONITOR_JVM_THREAD", threads, false); err != nil {
+			return huberrors.WrapError(err, "写入JVM线程快照失败")
+		}
+		return nil
+	})
+}
+
+// SaveAppData 在一个事务中写入资源信息和应用监控数据
+// 参数:
+//
+//	ctx: 上下文
+//	resource: 应用所属的资源信息，按主键存在则更新、不存在则插入
+//	appData: 应用监控数据，追加到 HUB_MONITOR_APP_DATA
+//
+// 返回:
+//
+//	error: 任一写入失败时整批回滚并返回错误
+func (dao *JvmIngestDAO) SaveAppData(ctx context.Context, resource *models.JvmResource, appData []*models.JvmAppData) error {
+	return dao.db.InTx(ctx, nil, func(txCtx context.Context) error {
+		if err := dao.saveResource(txCtx, resource); err != nil {
+			return err
+		}
+		if _, err := dao.db.BatchInsert(txCtx, "HUB_MONITOR_APP_DATA", appData, false); err != nil {
+			return huberrors.WrapError(err, "写入应用监控数据失败")
+		}
+		return nil
+	})
+}
+
+// saveResource 写入JVM资源信息，已存在时只更新上报的非零字段，保留创建信息
+func (dao *JvmIngestDAO) saveResource(ctx context.Context, resource *models.JvmResource) error {
+	where := "tenantId = ? AND serviceGroupId = ? AND jvmResourceId = ?"
+	args := []interface{}{resource.TenantId, resource.ServiceGroupId, resource.JvmResourceId}
+
+	var result struct {
+		Count int `db:"COUNT(*)"`
+	}
+	err := dao.db.QueryOne(ctx, &result, "SELECT COUNT(*) FROM HUB_MONITOR_JVM_RESOURCE WHERE "+where, args, false)
+	if err != nil {
+		return huberrors.WrapError(err, "查询JVM资源失败")
+	}
+
+	if result.Count == 0 {
+		if _, err := dao.db.Insert(ctx, "HUB_MONITOR_JVM_RESOURCE", resource, false); err != nil {
+			return huberrors.WrapError(err, "写入JVM资源失败")
+		}
+		return nil
+	}
+
+	// 零值字段不参与更新，清空创建信息和版本号以保留原值
+	update := *resource
+	update.AddTime = time.Time{}
+	update.AddWho = ""
+	update.CurrentVersion = 0
+	if _, err := dao.db.Update(ctx, "HUB_MONITOR_JVM_RESOURCE", &update, where, args, false, true); err != nil {
+		return huberrors.WrapError(err, "更新JVM资源失败")
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gateway/pkg/database"
+	"gateway/pkg/database/sqlutils"
+	"gateway/pkg/utils/huberrors"
+	"gateway/web/views/hub0042/models"
+)
+
+// jvmRollupTables 各聚合粒度对应的表
+var jvmRollupTables = map[string]string{
+	models.JvmRollup5M: "HUB_MONITOR_JVM_METRIC_5M",
+	models.JvmRollup1H: "HUB_MONITOR_JVM_METRIC_1H",
+}
+
+// jvmRawTables 按采集时间清理的原始采集数据表，资源主表只保存最新状态，不在清理范围内
+var jvmRawTables = []string{
+	"HUB_MONITOR_JVM_MEMORY",
+	"HUB_MONITOR_JVM_GC",
+	"HUB_MONITOR_JVM_THREAD",
+	"HUB_MONITOR_JVM_DEADLOCK",
+	"HUB_MONITOR_APP_DATA",
+}
+
+// JvmRollupDAO JVM监控数据聚合与清理DAO
+type JvmRollupDAO struct {
+	db database.Database
+}
+
+// NewJvmRollupDAO 创建JVM监控数据聚合与清理DAO
+func NewJvmRollupDAO(db database.Database) *JvmRollupDAO {
+	return &JvmRollupDAO{db: db}
+}
+
+// rollupTable 获取聚合粒度对应的表名
+func rollupTable(granularity string) (string, error) {
+	table, ok := jvmRollupTables[granularity]
+	if !ok {
+		return "", fmt.Errorf("不支持的聚合粒度: %s", granularity)
+	}
+	return table, nil
+}
+
+// ListMemoryPoints 查询 [start, end) 内所有租户的内存采集点
+func (dao *JvmRollupDAO) ListMemoryPoints(ctx context.Context, start, end time.Time) ([]*models.JvmMemoryPoint, error) {
+	query := `SELECT tenantId, jvmResourceId, memoryType, usagePercent, usedMemoryBytes, collectionTime
+		FROM HUB_MONITOR_JVM_MEMORY WHERE collectionTime >= ? AND collectionTime < ? AND activeFlag = 'Y'`
+	var points []*models.JvmMemoryPoint
+	if err := dao.db.Query(ctx, &points, query, []interface{}{start, end}, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询JVM内存采集数据失败")
+	}
+	return points, nil
+}
+
+// ListThreadPoints 查询 [start, end) 内所有租户的线程采集点
+func (dao *JvmRollupDAO) ListThreadPoints(ctx context.Context, start, end time.Time) ([]*models.JvmThreadPoint, error) {
+	query := `SELECT tenantId, jvmResourceId, currentThreadCount, collectionTime
+		FROM HUB_MONITOR_JVM_THREAD WHERE collectionTime >= ? AND collectionTime < ? AND activeFlag = 'Y'`
+	var points []*models.JvmThreadPoint
+	if err := dao.db.Query(ctx, &points, query, []interface{}{start, end}, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询JVM线程采集数据失败")
+	}
+	return points, nil
+}
+
+// ListGcPoints 查询 [start, end) 内所有租户的GC采集点
+func (dao *JvmRollupDAO) ListGcPoints(ctx context.Context, start, end time.Time) ([]*models.JvmGcPoint, error) {
+	query := `SELECT tenantId, jvmResourceId, ygc, fgc, gct, collectionTime
+		FROM HUB_MONITOR_JVM_GC WHERE collectionTime >= ? AND collectionTime < ? AND activeFlag = 'Y'`
+	var points []*models.JvmGcPoint
+	if err := dao.db.Query(ctx, &points, query, []interface{}{start, end}, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询JVM GC采集数据失败")
+	}
+	return points, nil
+}
+
+// ListRollups 查询 [start, end) 内所有租户的聚合数据
+func (dao *JvmRollupDAO) ListRollups(ctx context.Context, granularity string, start, end time.Time) ([]*models.JvmMetricRollup, error) {
+	table, err := rollupTable(granularity)
+	if err != nil {
+		return nil, err
+	}
+	query := "SELECT * FROM " + table + " WHERE bucketTime >= ? AND bucketTime < ?"
+	var rows []*models.JvmMetricRollup
+	if err := dao.db.Query(ctx, &rows, query, []interface{}{start, end}, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询JVM监控聚合数据失败")
+	}
+	return rows, nil
+}
+
+// ReplaceRollups 在一个事务中替换 [start, end) 内的聚合数据，重复聚合同一时间段时结果不重复
+func (dao *JvmRollupDAO) ReplaceRollups(ctx context.Context, granularity string, start, end time.Time, rows []*models.JvmMetricRollup) error {
+	table, err := rollupTable(granularity)
+	if err != nil {
+		return err
+	}
+	return dao.db.InTx(ctx, nil, func(txCtx context.Context) error {
+		if _, err := dao.db.Delete(txCtx, table, "bucketTime >= ? AND bucketTime < ?", []interface{}{start, end}, false); err != nil {
+			return huberrors.WrapError(err, "删除JVM监控聚合数据失败")
+		}
+		if _, err := dao.db.BatchInsert(txCtx, table, rows, false); err != nil {
+			return huberrors.WrapError(err, "写入JVM监控聚合数据失败")
+		}
+		return nil
+	})
+}
+
+// GetLatestRollupBucket 获取聚合表中最新的时间段起点
+// 返回:
+//
+//	*time.Time: 最新时间段起点，表中没有数据时返回nil
+//	error: 查询失败时返回错误
+func (dao *JvmRollupDAO) GetLatestRollupBucket(ctx context.Context, granularity string) (*time.Time, error) {
+	table, err := rollupTable(granularity)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err := sqlutils.BuildPaginationQuery(sqlutils.GetDatabaseType(dao.db),
+		"SELECT bucketTime FROM "+table+" ORDER BY bucketTime DESC", sqlutils.NewPaginationInfo(1, 1))
+	if err != nil {
+		return nil, huberrors.WrapError(err, "构建分页查询失败")
+	}
+
+	var row struct {
+		BucketTime time.Time `db:"bucketTime"`
+	}
+	if err := dao.db.QueryOne(ctx, &row, query, args, true); err != nil {
+		if errors.Is(err, database.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, huberrors.WrapError(err, "查询JVM监控聚合进度失败")
+	}
+	return &row.BucketTime, nil
+}
+
+// PurgeRawData 删除采集时间早于 before 的原始采集数据
+// 返回:
+//
+//	int64: 删除的行数
+//	error: 删除失败时返回错误，已删除的表不回滚
+func (dao *JvmRollupDAO) PurgeRawData(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+	for _, table := range jvmRawTables {
+		affected, err := dao.db.Delete(ctx, table, "collectionTime < ?", []interface{}{before}, true)
+		if err != nil {
+			return total, huberrors.WrapError(err, "清理%s失败", table)
+		}
+		total += affected
+	}
+	return total, nil
+}
+
+// PurgeRollups 删除时间段起点早于 before 的聚合数据
+func (dao *JvmRollupDAO) PurgeRollups(ctx context.Context, granularity string, before time.Time) (int64, error) {
+	table, err := rollupTable(granularity)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := dao.db.Delete(ctx, table, "bucketTime < ?", []interface{}{before}, true)
+	if err != nil {
+		return 0, huberrors.WrapError(err, "清理%s失败", table)
+	}
+	return affected, nil
+}
+
+// QueryMetricTrend 查询单个JVM实例在 [start, end) 内的聚合数据，按时间升序
+func (dao *JvmRollupDAO) QueryMetricTrend(ctx context.Context, tenantId, jvmResourceId, granularity string, start, end time.Time) ([]*models.JvmMetricRollup, error) {
+	table, err := rollupTable(granularity)
+	if err != nil {
+		return nil, err
+	}
+	query := "SELECT * FROM " + table + " WHERE tenantId = ? AND jvmResourceId = ? AND bucketTime >= ? AND bucketTime < ? ORDER BY bucketTime ASC"
+	var rows []*models.JvmMetricRollup
+	if err := dao.db.Query(ctx, &rows, query, []interface{}{tenantId, jvmResourceId, start, end}, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询JVM监控趋势失败")
+	}
+	return rows, nil
+}
+
+// ListJvmResources 查询JVM实例信息
+// jvmResourceIds 不为空时按ID查询，否则查询 serviceGroupId 下的所有实例
+func (dao *JvmRollupDAO) ListJvmResources(ctx context.Context, tenantId string, jvmResourceIds []string, serviceGroupId string) ([]*models.JvmResourceBrief, error) {
+	query := `SELECT jvmResourceId, serviceGroupId, applicationName, hostName, hostIpAddress
+		FROM HUB_MONITOR_JVM_RESOURCE WHERE tenantId = ? AND activeFlag = 'Y'`
+	args := []interface{}{tenantId}
+	if len(jvmResourceIds) > 0 {
+		placeholders := strings.Repeat("?,", len(jvmResourceIds))
+		query += " AND jvmResourceId IN (" + placeholders[:len(placeholders)-1] + ")"
+		for _, id := range jvmResourceIds {
+			args = append(args, id)
+		}
+	} else {
+		query += " AND serviceGroupId = ?"
+		args = append(args, serviceGroupId)
+	}
+	query += " ORDER BY applicationName, jvmResourceId"
+
+	var rows []*models.JvmResourceBrief
+	if err := dao.db.Query(ctx, &rows, query, args, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询JVM实例失败")
+	}
+	return rows, nil
+}
+
+// QueryMetricTrends 查询多个JVM实例在 [start, end) 内的聚合数据，按实例和时间升序
+func (dao *JvmRollupDAO) QueryMetricTrends(ctx context.Context, tenantId string, jvmResourceIds []string, granularity string, start, end time.Time) ([]*models.JvmMetricRollup, error) {
+	table, err := rollupTable(granularity)
+	if err != nil {
+		return nil, err
+	}
+	if len(jvmResourceIds) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.Repeat("?,", len(jvmResourceIds))
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE tenantId = ? AND jvmResourceId IN (%s) AND bucketTime >= ? AND bucketTime < ?
+		ORDER BY jvmResourceId, bucketTime`, table, placeholders[:len(placeholders)-1])
+	args := []interface{}{tenantId}
+	for _, id := range jvmResourceIds {
+		args = append(args, id)
+	}
+	args = append(args, start, end)
+
+	var rows []*models.JvmMetricRollup
+	if err := dao.db.Query(ctx, &rows, query, args, true); err != nil {
+		return nil, huberrors.WrapError(err, "查询JVM监控趋势失败")
+	}
+	return rows, nil
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"gateway/internal/metric_collect/types"
+	metricTypes "gateway/pkg/metric/types"
+)
+
+// HostMetricsReport 采集端上报的单台主机指标
+// metrics 与网关内置采集器 metric.CollectAll 的输出结构一致，写入 HUB_METRIC_* 表，由 hub0007 的服务器监控接口查询
+type HostMetricsReport struct {
+	MetricServerId string                  `json:"metricServerId"` // 服务器ID，由采集端生成并保持稳定
+	ServerLocation *string                 `json:"serverLocation"` // 服务器位置
+	Metrics        *metricTypes.AllMetrics `json:"metrics"`        // 指标数据，system 必填
+}
+
+// HostIngestRequest 主机指标批量上报请求
+type HostIngestRequest struct {
+	Reports []*HostMetricsReport `json:"reports"` // 主机指标
+}
+
+// HostIngestRejected 校验未通过的上报记录
+type HostIngestRejected struct {
+	Index          int    `json:"index"`          // 在 reports 中的下标
+	MetricServerId string `json:"metricServerId"` // 服务器ID
+	Reason         string `json:"reason"`         // 未通过原因
+}
+
+// HostIngestResult 主机指标上报结果
+type HostIngestResult struct {
+	Accepted int                   `json:"accepted"` // 写入成功的上报记录数
+	Rejected []*HostIngestRejected `json:"rejected"` // 校验未通过的上报记录
+}
+
+// HostMetricRows 一批上报转换后的待写入数据，对应 HUB_METRIC_* 表
+type HostMetricRows struct {
+	Servers        []*types.ServerInfo
+	Cpus           []*types.CpuLog
+	Memories       []*types.MemoryLog
+	DiskPartitions []*types.DiskPartitionLog
+	DiskIos        []*types.DiskIoLog
+	Networks       []*types.NetworkLog
+	ProcessStats   []*types.ProcessStatsLog
+	Processes      []*types.ProcessLog
+}
+
+// MonitorOverview 监控概览，汇总JVM和主机监控状态
+type MonitorOverview struct {
+	Jvm        *JvmOverview  `json:"jvm"`        // JVM监控
+	Host       *HostOverview `json:"host"`       // 主机监控
+	ActiveTime time.Time     `json:"activeTime"` // 在线判断基准，采集时间不早于该时间的实例/主机视为在线
+}
+
+// JvmOverview JVM监控概览
+type JvmOverview struct {
+	Total             int `json:"total" db:"total"`                         // JVM实例数
+	Online            int `json:"online" db:"online"`                       // 在线实例数
+	Unhealthy         int `json:"unhealthy" db:"unhealthy"`                 // 健康标记为异常的实例数
+	RequiresAttention int `json:"requiresAttention" db:"requiresAttention"` // 需要立即关注的实例数
+	FiringAlerts      int `json:"firingAlerts" db:"firingAlerts"`           // 告警中的事件数
+}
+
+// HostOverview 主机监控概览，使用率按每台主机在线判断窗口内最新的采集值统计
+type HostOverview struct {
+	Total         int     `json:"total"`         // 服务器数
+	Online        int     `json:"online"`        // 在线服务器数
+	HighCpu       int     `json:"highCpu"`       // CPU使用率不低于阈值的服务器数
+	HighMemory    int     `json:"highMemory"`    // 内存使用率不低于阈值的服务器数
+	AvgCpuUsage   float64 `json:"avgCpuUsage"`   // 在线服务器CPU使用率平均值（百分比）
+	AvgMemUsage   float64 `json:"avgMemUsage"`   // 在线服务器内存使用率平均值（百分比）
+	HighThreshold float64 `json:"highThreshold"` // 高使用率阈值（百分比）
+}
+
+// HostUsagePoint 概览统计使用的主机使用率采集点
+type HostUsagePoint struct {
+	MetricServerId string    `db:"metricServerId"`
+	UsagePercent   float64   `db:"usagePercent"`
+	CollectTime    time.Time `db:"collectTime"`
+}
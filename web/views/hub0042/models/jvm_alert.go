@@ -0,0 +1,238 @@
+package models
+
+import (
+	"time"
+)
+
+// JVM告警监控指标
+const (
+	AlertMetricHeapUsage    = "HEAP_USAGE"     // 堆内存使用率（百分比）
+	AlertMetricNonHeapUsage = "NON_HEAP_USAGE" // 非堆内存使用率（百分比）
+	AlertMetricFGCCount     = "FGC_COUNT"      // Full GC次数（累积值，通常配合RATE方式使用）
+	AlertMetricThreadCount  = "THREAD_COUNT"   // 当前线程数
+	AlertMetricDeadlock     = "DEADLOCK"       // 死锁线程数，未检测到死锁时为0
+
+	AlertMetricGCCount          = "GC_COUNT"           // 年轻代GC与Full GC次数之和（累积值），用于GC频率
+	AlertMetricGatewayErrorRate = "GATEWAY_ERROR_RATE" // 网关节点5xx错误率（百分比），来自网关自监控数据
+)
+
+// 告警规则评估方式
+const (
+	AlertEvaluateThreshold = "THRESHOLD" // 采集值与阈值比较
+	AlertEvaluateRate      = "RATE"      // 窗口内每分钟变化量与阈值比较
+	AlertEvaluateAnomaly   = "ANOMALY"   // 最新值相对基线窗口的偏离程度（z-score）与阈值（标准差倍数）比较
+)
+
+// 告警比较运算符
+const (
+	AlertOperatorGT  = "GT"  // 大于
+	AlertOperatorGTE = "GTE" // 大于等于
+	AlertOperatorLT  = "LT"  // 小于
+	AlertOperatorLTE = "LTE" // 小于等于
+)
+
+// 告警通知分组方式
+const (
+	AlertGroupByApplication = "APPLICATION" // 同一规则同一应用的实例合并为一条通知
+	AlertGroupByInstance    = "INSTANCE"    // 每个实例单独通知
+)
+
+// 告警事件状态
+const (
+	AlertEventFiring   = "FIRING"   // 告警中
+	AlertEventResolved = "RESOLVED" // 已恢复
+)
+
+// AlertRule JVM告警规则，对应表 HUB_MONITOR_ALERT_RULE
+// 规则按 metricType 读取 HUB_MONITOR_JVM_* 表（网关错误率读取 HUB_MONITOR_APP_DATA）的采集数据，逐个JVM实例评估
+type AlertRule struct {
+	// 主键字段
+	TenantId    string `json:"tenantId" form:"tenantId" db:"tenantId"`          // 租户ID
+	AlertRuleId string `json:"alertRuleId" form:"alertRuleId" db:"alertRuleId"` // 告警规则ID
+
+	// 规则定义
+	RuleName        string  `json:"ruleName" form:"ruleName" db:"ruleName"`                      // 规则名称
+	MetricType      string  `json:"metricType" form:"metricType" db:"metricType"`                // 监控指标(HEAP_USAGE,NON_HEAP_USAGE,FGC_COUNT,GC_COUNT,THREAD_COUNT,DEADLOCK,GATEWAY_ERROR_RATE)
+	EvaluateMode    string  `json:"evaluateMode" form:"evaluateMode" db:"evaluateMode"`          // 评估方式(THRESHOLD,RATE,ANOMALY)
+	CompareOperator string  `json:"compareOperator" form:"compareOperator" db:"compareOperator"` // 比较运算符(GT,GTE,LT,LTE)，异常检测方式下GT/GTE检测向上偏离，LT/LTE检测向下偏离
+	ThresholdValue  float64 `json:"thresholdValue" form:"thresholdValue" db:"thresholdValue"`    // 阈值，异常检测方式下为标准差倍数
+	DurationSeconds int     `json:"durationSeconds" form:"durationSeconds" db:"durationSeconds"` // 持续时长(秒)，阈值方式下持续满足条件才触发
+	WindowSeconds   int     `json:"windowSeconds" form:"windowSeconds" db:"windowSeconds"`       // 速率计算窗口(秒)，异常检测方式下为基线窗口(秒)
+
+	// 匹配范围
+	ServiceGroupId  string `json:"serviceGroupId" form:"serviceGroupId" db:"serviceGroupId"`    // 服务分组ID，为空匹配所有分组
+	ApplicationName string `json:"applicationName" form:"applicationName" db:"applicationName"` // 应用名称，为空匹配所有应用
+
+	// 通知配置
+	GroupBy               string     `json:"groupBy" form:"groupBy" db:"groupBy"`                                           // 通知分组方式(APPLICATION,INSTANCE)
+	AlertLevel            string     `json:"alertLevel" form:"alertLevel" db:"alertLevel"`                                  // 告警级别(INFO,WARN,ERROR,CRITICAL)
+	ChannelName           string     `json:"channelName" form:"channelName" db:"channelName"`                               // 告警渠道名称，为空使用默认渠道
+	RepeatIntervalSeconds int        `json:"repeatIntervalSeconds" form:"repeatIntervalSeconds" db:"repeatIntervalSeconds"` // 重复通知间隔(秒)，0表示只通知一次
+	NotifyResolvedFlag    string     `json:"notifyResolvedFlag" form:"notifyResolvedFlag" db:"notifyResolvedFlag"`          // 恢复时是否通知(Y是,N否)
+	EnabledFlag           string     `json:"enabledFlag" form:"enabledFlag" db:"enabledFlag"`                               // 是否启用(Y启用,N停用)
+	LastEvalTime          *time.Time `json:"lastEvalTime" form:"lastEvalTime" db:"lastEvalTime"`                            // 最近评估时间
+
+	// 通用字段
+	AddTime        time.Time `json:"addTime" form:"addTime" db:"addTime"`                      // 创建时间
+	AddWho         string    `json:"addWho" form:"addWho" db:"addWho"`                         // 创建人ID
+	EditTime       time.Time `json:"editTime" form:"editTime" db:"editTime"`                   // 最后修改时间
+	EditWho        string    `json:"editWho" form:"editWho" db:"editWho"`                      // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" form:"oprSeqFlag" db:"oprSeqFlag"`             // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" form:"currentVersion" db:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" form:"activeFlag" db:"activeFlag"`             // 活动状态标记(N非活动,Y活动)
+	NoteText       string    `json:"noteText" form:"noteText" db:"noteText"`                   // 备注信息
+}
+
+// TableName 返回表名
+func (AlertRule) TableName() string {
+	return "HUB_MONITOR_ALERT_RULE"
+}
+
+// AlertSilence JVM告警静默，对应表 HUB_MONITOR_ALERT_SILENCE
+// 匹配条件为空表示不限制，时间窗口内匹配的告警仍记录事件，但不发送通知
+type AlertSilence struct {
+	// 主键字段
+	TenantId       string `json:"tenantId" form:"tenantId" db:"tenantId"`                   // 租户ID
+	AlertSilenceId string `json:"alertSilenceId" form:"alertSilenceId" db:"alertSilenceId"` // 静默ID
+
+	// 匹配条件
+	AlertRuleId     string `json:"alertRuleId" form:"alertRuleId" db:"alertRuleId"`             // 告警规则ID，为空匹配所有规则
+	ApplicationName string `json:"applicationName" form:"applicationName" db:"applicationName"` // 应用名称，为空匹配所有应用
+	JvmResourceId   string `json:"jvmResourceId" form:"jvmResourceId" db:"jvmResourceId"`       // JVM资源ID，为空匹配所有实例
+
+	// 静默时间
+	StartTime  time.Time `json:"startTime" form:"startTime" db:"startTime"`    // 静默开始时间
+	EndTime    time.Time `json:"endTime" form:"endTime" db:"endTime"`          // 静默结束时间
+	ReasonText string    `json:"reasonText" form:"reasonText" db:"reasonText"` // 静默原因
+
+	// 通用字段
+	AddTime        time.Time `json:"addTime" form:"addTime" db:"addTime"`                      // 创建时间
+	AddWho         string    `json:"addWho" form:"addWho" db:"addWho"`                         // 创建人ID
+	EditTime       time.Time `json:"editTime" form:"editTime" db:"editTime"`                   // 最后修改时间
+	EditWho        string    `json:"editWho" form:"editWho" db:"editWho"`                      // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" form:"oprSeqFlag" db:"oprSeqFlag"`             // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" form:"currentVersion" db:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" form:"activeFlag" db:"activeFlag"`             // 活动状态标记(N非活动,Y活动)
+}
+
+// TableName 返回表名
+func (AlertSilence) TableName() string {
+	return "HUB_MONITOR_ALERT_SILENCE"
+}
+
+// Matches 判断静默是否在指定时间覆盖该规则和实例
+func (s *AlertSilence) Matches(alertRuleId, applicationName, jvmResourceId string, now time.Time) bool {
+	if now.Before(s.StartTime) || now.After(s.EndTime) {
+		return false
+	}
+	if s.AlertRuleId != "" && s.AlertRuleId != alertRuleId {
+		return false
+	}
+	if s.ApplicationName != "" && s.ApplicationName != applicationName {
+		return false
+	}
+	if s.JvmResourceId != "" && s.JvmResourceId != jvmResourceId {
+		return false
+	}
+	return true
+}
+
+// AlertEvent JVM告警事件，对应表 HUB_MONITOR_ALERT_EVENT
+// 同一规则同一实例同时只有一条 FIRING 事件，恢复后置为 RESOLVED，作为告警历史保留
+type AlertEvent struct {
+	// 主键字段
+	TenantId     string `json:"tenantId" form:"tenantId" db:"tenantId"`             // 租户ID
+	AlertEventId string `json:"alertEventId" form:"alertEventId" db:"alertEventId"` // 告警事件ID
+
+	// 规则信息
+	AlertRuleId string `json:"alertRuleId" form:"alertRuleId" db:"alertRuleId"` // 告警规则ID
+	RuleName    string `json:"ruleName" form:"ruleName" db:"ruleName"`          // 规则名称(冗余字段,便于查询显示)
+	MetricType  string `json:"metricType" form:"metricType" db:"metricType"`    // 监控指标
+	AlertLevel  string `json:"alertLevel" form:"alertLevel" db:"alertLevel"`    // 告警级别
+
+	// 实例信息
+	JvmResourceId   string `json:"jvmResourceId" form:"jvmResourceId" db:"jvmResourceId"`       // JVM资源ID
+	ApplicationName string `json:"applicationName" form:"applicationName" db:"applicationName"` // 应用名称
+	HostName        string `json:"hostName" form:"hostName" db:"hostName"`                      // 主机名
+	HostIpAddress   string `json:"hostIpAddress" form:"hostIpAddress" db:"hostIpAddress"`       // 主机IP地址
+	GroupKey        string `json:"groupKey" form:"groupKey" db:"groupKey"`                      // 通知分组键
+
+	// 事件状态
+	EventStatus      string     `json:"eventStatus" form:"eventStatus" db:"eventStatus"`                // 事件状态(FIRING,RESOLVED)
+	MetricValue      float64    `json:"metricValue" form:"metricValue" db:"metricValue"`                // 最近一次评估的指标值
+	ThresholdValue   float64    `json:"thresholdValue" form:"thresholdValue" db:"thresholdValue"`       // 触发时的阈值
+	MessageText      string     `json:"messageText" form:"messageText" db:"messageText"`                // 告警描述
+	FirstTriggerTime time.Time  `json:"firstTriggerTime" form:"firstTriggerTime" db:"firstTriggerTime"` // 首次触发时间
+	LastTriggerTime  time.Time  `json:"lastTriggerTime" form:"lastTriggerTime" db:"lastTriggerTime"`    // 最近一次满足条件的时间
+	ResolvedTime     *time.Time `json:"resolvedTime" form:"resolvedTime" db:"resolvedTime"`             // 恢复时间
+
+	// 通知信息
+	NotifyCount    int        `json:"notifyCount" form:"notifyCount" db:"notifyCount"`          // 通知次数
+	LastNotifyTime *time.Time `json:"lastNotifyTime" form:"lastNotifyTime" db:"lastNotifyTime"` // 最近通知时间
+	SilencedFlag   string     `json:"silencedFlag" form:"silencedFlag" db:"silencedFlag"`       // 是否被静默(Y是,N否)
+	AlertLogId     string     `json:"alertLogId" form:"alertLogId" db:"alertLogId"`             // 最近一次通知的告警日志ID
+
+	// 通用字段
+	AddTime        time.Time `json:"addTime" form:"addTime" db:"addTime"`                      // 创建时间
+	AddWho         string    `json:"addWho" form:"addWho" db:"addWho"`                         // 创建人ID
+	EditTime       time.Time `json:"editTime" form:"editTime" db:"editTime"`                   // 最后修改时间
+	EditWho        string    `json:"editWho" form:"editWho" db:"editWho"`                      // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" form:"oprSeqFlag" db:"oprSeqFlag"`             // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" form:"currentVersion" db:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" form:"activeFlag" db:"activeFlag"`             // 活动状态标记(N非活动,Y活动)
+}
+
+// TableName 返回表名
+func (AlertEvent) TableName() string {
+	return "HUB_MONITOR_ALERT_EVENT"
+}
+
+// JvmMetricSample 告警评估使用的单个采集点，由 HUB_MONITOR_JVM_* 表关联 HUB_MONITOR_JVM_RESOURCE 查询得到
+type JvmMetricSample struct {
+	JvmResourceId   string    `json:"jvmResourceId" db:"jvmResourceId"`     // JVM资源ID
+	ApplicationName string    `json:"applicationName" db:"applicationName"` // 应用名称
+	HostName        string    `json:"hostName" db:"hostName"`               // 主机名
+	HostIpAddress   string    `json:"hostIpAddress" db:"hostIpAddress"`     // 主机IP地址
+	MetricValue     float64   `json:"metricValue" db:"metricValue"`         // 指标值
+	CollectionTime  time.Time `json:"collectionTime" db:"collectionTime"`   // 采集时间
+}
+
+// AlertRuleListRequest 告警规则列表请求
+// 说明：分页参数通过 request.GetPaginationParams 读取，这里仅放筛选条件
+type AlertRuleListRequest struct {
+	RuleName        string `json:"ruleName" form:"ruleName"`               // 规则名称（模糊）
+	MetricType      string `json:"metricType" form:"metricType"`           // 监控指标（精确）
+	ApplicationName string `json:"applicationName" form:"applicationName"` // 应用名称（精确）
+	EnabledFlag     string `json:"enabledFlag" form:"enabledFlag"`         // 是否启用
+}
+
+// AlertSilenceListRequest 告警静默列表请求
+type AlertSilenceListRequest struct {
+	AlertRuleId     string `json:"alertRuleId" form:"alertRuleId"`         // 告警规则ID
+	ApplicationName string `json:"applicationName" form:"applicationName"` // 应用名称
+	OnlyActive      string `json:"onlyActive" form:"onlyActive"`           // 仅查询未过期的静默(Y是)
+}
+
+// AlertEventListRequest 告警历史列表请求
+type AlertEventListRequest struct {
+	AlertRuleId     string `json:"alertRuleId" form:"alertRuleId"`         // 告警规则ID
+	MetricType      string `json:"metricType" form:"metricType"`           // 监控指标
+	ApplicationName string `json:"applicationName" form:"applicationName"` // 应用名称
+	JvmResourceId   string `json:"jvmResourceId" form:"jvmResourceId"`     // JVM资源ID
+	EventStatus     string `json:"eventStatus" form:"eventStatus"`         // 事件状态(FIRING,RESOLVED)
+	AlertLevel      string `json:"alertLevel" form:"alertLevel"`           // 告警级别
+	StartTime       string `json:"startTime" form:"startTime"`             // 首次触发时间起（yyyy-MM-dd HH:mm:ss）
+	EndTime         string `json:"endTime" form:"endTime"`                 // 首次触发时间止（yyyy-MM-dd HH:mm:ss）
+}
+
+// AlertSilenceRequest 新增告警静默请求
+// 时间格式为 yyyy-MM-dd HH:mm:ss；startTime 为空时从当前时间开始，endTime 为空时按 durationMinutes 计算结束时间
+type AlertSilenceRequest struct {
+	AlertRuleId     string `json:"alertRuleId" form:"alertRuleId"`         // 告警规则ID，为空匹配所有规则
+	ApplicationName string `json:"applicationName" form:"applicationName"` // 应用名称，为空匹配所有应用
+	JvmResourceId   string `json:"jvmResourceId" form:"jvmResourceId"`     // JVM资源ID，为空匹配所有实例
+	StartTime       string `json:"startTime" form:"startTime"`             // 静默开始时间
+	EndTime         string `json:"endTime" form:"endTime"`                 // 静默结束时间
+	DurationMinutes int    `json:"durationMinutes" form:"durationMinutes"` // 静默时长（分钟），endTime 为空时使用
+	ReasonText      string `json:"reasonText" form:"reasonText"`           // 静默原因
+}
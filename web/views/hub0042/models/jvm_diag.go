@@ -0,0 +1,122 @@
+package models
+
+import (
+	"time"
+)
+
+// JVM诊断类型
+const (
+	JvmDiagThreadDump    = "THREAD_DUMP"    // 线程转储，结果为 jstack 格式文本
+	JvmDiagHeapHistogram = "HEAP_HISTOGRAM" // 类直方图，结果为 jmap -histo 格式文本
+)
+
+// JVM诊断任务状态
+// 任务创建后为待下发，采集端上报快照时随响应领取并置为已下发，采集端提交结果后置为已完成或失败；
+// 待下发或已下发的任务超过过期时间未完成则置为已过期
+const (
+	JvmDiagPending    = "PENDING"    // 待下发
+	JvmDiagDispatched = "DISPATCHED" // 已下发
+	JvmDiagCompleted  = "COMPLETED"  // 已完成
+	JvmDiagFailed     = "FAILED"     // 失败
+	JvmDiagExpired    = "EXPIRED"    // 已过期
+)
+
+// JvmDiagTask JVM诊断采集任务，对应表 HUB_MONITOR_JVM_DIAG_TASK
+type JvmDiagTask struct {
+	// 主键字段
+	TenantId   string `json:"tenantId" db:"tenantId"`     // 租户ID
+	DiagTaskId string `json:"diagTaskId" db:"diagTaskId"` // 诊断任务ID
+
+	// 采集请求
+	JvmResourceId   string     `json:"jvmResourceId" db:"jvmResourceId"`     // JVM资源ID
+	ApplicationName string     `json:"applicationName" db:"applicationName"` // 应用名称
+	DiagType        string     `json:"diagType" db:"diagType"`               // 诊断类型(THREAD_DUMP,HEAP_HISTOGRAM)
+	TaskStatus      string     `json:"taskStatus" db:"taskStatus"`           // 任务状态(PENDING,DISPATCHED,COMPLETED,FAILED,EXPIRED)
+	RequestTime     time.Time  `json:"requestTime" db:"requestTime"`         // 请求时间
+	ExpireTime      time.Time  `json:"expireTime" db:"expireTime"`           // 过期时间
+	DispatchTime    *time.Time `json:"dispatchTime" db:"dispatchTime"`       // 下发时间
+	CompleteTime    *time.Time `json:"completeTime" db:"completeTime"`       // 完成时间
+
+	// 采集结果
+	ResultText         *string `json:"resultText,omitempty" db:"resultText"`       // 采集结果原文，列表查询不返回
+	ResultSize         int     `json:"resultSize" db:"resultSize"`                 // 采集结果字节数
+	ThreadCount        int     `json:"threadCount" db:"threadCount"`               // 线程转储中的线程数
+	BlockedThreadCount int     `json:"blockedThreadCount" db:"blockedThreadCount"` // 线程转储中BLOCKED状态的线程数
+	ErrorMessage       *string `json:"errorMessage" db:"errorMessage"`             // 采集失败原因
+
+	// 通用字段
+	AddTime        time.Time `json:"addTime" db:"addTime"`               // 创建时间
+	AddWho         string    `json:"addWho" db:"addWho"`                 // 创建人ID
+	EditTime       time.Time `json:"editTime" db:"editTime"`             // 最后修改时间
+	EditWho        string    `json:"editWho" db:"editWho"`               // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" db:"oprSeqFlag"`         // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" db:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" db:"activeFlag"`         // 活动状态标记(N非活动,Y活动)
+	NoteText       *string   `json:"noteText" db:"noteText"`             // 备注信息
+}
+
+// TableName 返回表名
+func (JvmDiagTask) TableName() string {
+	return "HUB_MONITOR_JVM_DIAG_TASK"
+}
+
+// JvmDiagTaskRequest 新增诊断任务请求
+type JvmDiagTaskRequest struct {
+	JvmResourceId string `json:"jvmResourceId" form:"jvmResourceId"` // JVM资源ID
+	DiagType      string `json:"diagType" form:"diagType"`           // 诊断类型(THREAD_DUMP,HEAP_HISTOGRAM)
+	NoteText      string `json:"noteText" form:"noteText"`           // 备注信息
+}
+
+// JvmDiagTaskListRequest 诊断任务列表请求
+type JvmDiagTaskListRequest struct {
+	JvmResourceId   string `json:"jvmResourceId" form:"jvmResourceId"`     // JVM资源ID
+	ApplicationName string `json:"applicationName" form:"applicationName"` // 应用名称
+	DiagType        string `json:"diagType" form:"diagType"`               // 诊断类型
+	TaskStatus      string `json:"taskStatus" form:"taskStatus"`           // 任务状态
+}
+
+// JvmDiagCommand 下发给采集端的诊断任务，随快照上报的响应返回
+type JvmDiagCommand struct {
+	DiagTaskId    string    `json:"diagTaskId"`    // 诊断任务ID，提交结果时回传
+	JvmResourceId string    `json:"jvmResourceId"` // 需要采集的JVM资源ID
+	DiagType      string    `json:"diagType"`      // 诊断类型
+	ExpireTime    time.Time `json:"expireTime"`    // 过期时间，超过后提交的结果不再接收
+}
+
+// JvmDiagResultRequest 采集端提交诊断结果请求
+type JvmDiagResultRequest struct {
+	DiagTaskId    string `json:"diagTaskId"`    // 诊断任务ID
+	JvmResourceId string `json:"jvmResourceId"` // JVM资源ID，需与任务一致
+	Success       bool   `json:"success"`       // 是否采集成功
+	Content       string `json:"content"`       // 采集结果原文，成功时必填
+	ErrorMessage  string `json:"errorMessage"`  // 失败原因
+}
+
+// JvmDiagTaskDetail 诊断任务详情，包含按诊断类型解析后的结果
+type JvmDiagTaskDetail struct {
+	Task        *JvmDiagTask          `json:"task"`                  // 诊断任务，包含结果原文
+	Threads     []*JvmThreadDumpEntry `json:"threads,omitempty"`     // 线程转储解析结果，BLOCKED线程和阻塞其他线程的持锁线程排在前面
+	StateCounts map[string]int        `json:"stateCounts,omitempty"` // 线程转储中各状态的线程数
+	Histogram   []*JvmHistogramEntry  `json:"histogram,omitempty"`   // 类直方图解析结果，按占用字节数排序
+}
+
+// JvmThreadDumpEntry 线程转储中的单个线程
+type JvmThreadDumpEntry struct {
+	Name           string   `json:"name"`                    // 线程名称
+	State          string   `json:"state"`                   // 线程状态，如 RUNNABLE、BLOCKED、WAITING
+	Daemon         bool     `json:"daemon"`                  // 是否守护线程
+	Header         string   `json:"header"`                  // 线程头信息原文
+	StackTrace     []string `json:"stackTrace"`              // 调用栈及锁信息
+	WaitingToLock  string   `json:"waitingToLock,omitempty"` // 等待获取的监视器锁地址
+	LockedMonitors []string `json:"lockedMonitors"`          // 已持有的监视器锁地址
+	Blocked        bool     `json:"blocked"`                 // 是否处于BLOCKED状态，前端高亮显示
+	BlockingOthers bool     `json:"blockingOthers"`          // 是否持有BLOCKED线程等待的锁，前端高亮显示
+}
+
+// JvmHistogramEntry 类直方图中的单个类
+type JvmHistogramEntry struct {
+	Rank      int    `json:"rank"`      // 排名
+	Instances int64  `json:"instances"` // 实例数
+	Bytes     int64  `json:"bytes"`     // 占用字节数
+	ClassName string `json:"className"` // 类名
+}
@@ -0,0 +1,245 @@
+package models
+
+import (
+	"time"
+)
+
+// JVM内存类型
+const (
+	JvmMemoryTypeHeap    = "HEAP"     // 堆内存
+	JvmMemoryTypeNonHeap = "NON_HEAP" // 非堆内存
+)
+
+// JvmResource JVM资源监控主表，对应表 HUB_MONITOR_JVM_RESOURCE
+// jvmResourceId 由应用端生成，同一JVM实例保持不变，每次上报覆盖最新状态
+type JvmResource struct {
+	// 主键字段
+	TenantId       string `json:"tenantId" db:"tenantId"`             // 租户ID，由接入密钥决定，忽略上报值
+	ServiceGroupId string `json:"serviceGroupId" db:"serviceGroupId"` // 服务分组ID
+	JvmResourceId  string `json:"jvmResourceId" db:"jvmResourceId"`   // JVM资源记录ID
+
+	// 应用标识信息
+	ApplicationName string  `json:"applicationName" db:"applicationName"` // 应用名称
+	GroupName       string  `json:"groupName" db:"groupName"`             // 分组名称
+	HostName        *string `json:"hostName" db:"hostName"`               // 主机名
+	HostIpAddress   *string `json:"hostIpAddress" db:"hostIpAddress"`     // 主机IP地址
+
+	// 时间相关字段
+	CollectionTime time.Time `json:"collectionTime" db:"collectionTime"` // 数据采集时间
+	JvmStartTime   time.Time `json:"jvmStartTime" db:"jvmStartTime"`     // JVM启动时间
+	JvmUptimeMs    int64     `json:"jvmUptimeMs" db:"jvmUptimeMs"`       // JVM运行时长（毫秒）
+
+	// 健康状态字段
+	HealthyFlag           string  `json:"healthyFlag" db:"healthyFlag"`                     // JVM整体健康标记(Y健康,N异常)
+	HealthGrade           *string `json:"healthGrade" db:"healthGrade"`                     // JVM健康等级(EXCELLENT/GOOD/FAIR/POOR)
+	RequiresAttentionFlag string  `json:"requiresAttentionFlag" db:"requiresAttentionFlag"` // 是否需要立即关注(Y是,N否)
+	SummaryText           *string `json:"summaryText" db:"summaryText"`                     // 监控摘要信息
+	SystemPropertiesJson  *string `json:"systemPropertiesJson" db:"systemPropertiesJson"`   // JVM系统属性，JSON格式
+
+	// 通用字段
+	AddTime        time.Time `json:"addTime" db:"addTime"`               // 创建时间
+	AddWho         string    `json:"addWho" db:"addWho"`                 // 创建人ID
+	EditTime       time.Time `json:"editTime" db:"editTime"`             // 最后修改时间
+	EditWho        string    `json:"editWho" db:"editWho"`               // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" db:"oprSeqFlag"`         // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" db:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" db:"activeFlag"`         // 活动状态标记(N非活动,Y活动)
+	NoteText       *string   `json:"noteText" db:"noteText"`             // 备注信息
+}
+
+// JvmMemory JVM内存快照，对应表 HUB_MONITOR_JVM_MEMORY
+type JvmMemory struct {
+	// 主键字段
+	TenantId      string `json:"tenantId" db:"tenantId"`           // 租户ID
+	JvmMemoryId   string `json:"jvmMemoryId" db:"jvmMemoryId"`     // JVM内存记录ID，由服务端生成
+	JvmResourceId string `json:"jvmResourceId" db:"jvmResourceId"` // 关联的JVM资源ID
+
+	// 内存使用情况
+	MemoryType           string  `json:"memoryType" db:"memoryType"`                     // 内存类型(HEAP/NON_HEAP)
+	InitMemoryBytes      int64   `json:"initMemoryBytes" db:"initMemoryBytes"`           // 初始内存大小（字节）
+	UsedMemoryBytes      int64   `json:"usedMemoryBytes" db:"usedMemoryBytes"`           // 已使用内存大小（字节）
+	CommittedMemoryBytes int64   `json:"committedMemoryBytes" db:"committedMemoryBytes"` // 已提交内存大小（字节）
+	MaxMemoryBytes       int64   `json:"maxMemoryBytes" db:"maxMemoryBytes"`             // 最大内存大小（字节），-1表示无限制
+	UsagePercent         float64 `json:"usagePercent" db:"usagePercent"`                 // 内存使用率（百分比）
+	HealthyFlag          string  `json:"healthyFlag" db:"healthyFlag"`                   // 内存健康标记(Y健康,N异常)
+
+	CollectionTime time.Time `json:"collectionTime" db:"collectionTime"` // 数据采集时间
+
+	// 通用字段
+	AddTime        time.Time `json:"addTime" db:"addTime"`               // 创建时间
+	AddWho         string    `json:"addWho" db:"addWho"`                 // 创建人ID
+	EditTime       time.Time `json:"editTime" db:"editTime"`             // 最后修改时间
+	EditWho        string    `json:"editWho" db:"editWho"`               // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" db:"oprSeqFlag"`         // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" db:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" db:"activeFlag"`         // 活动状态标记(N非活动,Y活动)
+	NoteText       *string   `json:"noteText" db:"noteText"`             // 备注信息
+}
+
+// JvmGc JVM垃圾回收快照（jstat -gc 口径），对应表 HUB_MONITOR_JVM_GC
+type JvmGc struct {
+	// 主键字段
+	TenantId      string `json:"tenantId" db:"tenantId"`           // 租户ID
+	GcSnapshotId  string `json:"gcSnapshotId" db:"gcSnapshotId"`   // GC快照ID，由服务端生成
+	JvmResourceId string `json:"jvmResourceId" db:"jvmResourceId"` // 关联的JVM资源ID
+
+	// 累积统计
+	CollectionCount  int64 `json:"collectionCount" db:"collectionCount"`   // GC总次数
+	CollectionTimeMs int64 `json:"collectionTimeMs" db:"collectionTimeMs"` // GC总耗时（毫秒）
+
+	// 各内存区容量与使用量（KB）
+	S0c  int64 `json:"s0c" db:"s0c"`   // Survivor0容量
+	S1c  int64 `json:"s1c" db:"s1c"`   // Survivor1容量
+	S0u  int64 `json:"s0u" db:"s0u"`   // Survivor0使用量
+	S1u  int64 `json:"s1u" db:"s1u"`   // Survivor1使用量
+	Ec   int64 `json:"ec" db:"ec"`     // Eden区容量
+	Eu   int64 `json:"eu" db:"eu"`     // Eden区使用量
+	Oc   int64 `json:"oc" db:"oc"`     // 老年代容量
+	Ou   int64 `json:"ou" db:"ou"`     // 老年代使用量
+	Mc   int64 `json:"mc" db:"mc"`     // 元空间容量
+	Mu   int64 `json:"mu" db:"mu"`     // 元空间使用量
+	Ccsc int64 `json:"ccsc" db:"ccsc"` // 压缩类空间容量
+	Ccsu int64 `json:"ccsu" db:"ccsu"` // 压缩类空间使用量
+
+	// 回收次数与耗时
+	Ygc  int64   `json:"ygc" db:"ygc"`   // 年轻代GC次数
+	Ygct float64 `json:"ygct" db:"ygct"` // 年轻代GC总耗时（秒）
+	Fgc  int64   `json:"fgc" db:"fgc"`   // Full GC次数
+	Fgct float64 `json:"fgct" db:"fgct"` // Full GC总耗时（秒）
+	Gct  float64 `json:"gct" db:"gct"`   // GC总耗时（秒）
+
+	CollectionTime time.Time `json:"collectionTime" db:"collectionTime"` // 数据采集时间
+
+	// 通用字段
+	AddTime        time.Time `json:"addTime" db:"addTime"`               // 创建时间
+	AddWho         string    `json:"addWho" db:"addWho"`                 // 创建人ID
+	EditTime       time.Time `json:"editTime" db:"editTime"`             // 最后修改时间
+	EditWho        string    `json:"editWho" db:"editWho"`               // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" db:"oprSeqFlag"`         // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" db:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" db:"activeFlag"`         // 活动状态标记(N非活动,Y活动)
+	NoteText       *string   `json:"noteText" db:"noteText"`             // 备注信息
+}
+
+// JvmThread JVM线程快照，对应表 HUB_MONITOR_JVM_THREAD
+type JvmThread struct {
+	// 主键字段
+	TenantId      string `json:"tenantId" db:"tenantId"`           // 租户ID
+	JvmThreadId   string `json:"jvmThreadId" db:"jvmThreadId"`     // JVM线程记录ID，由服务端生成
+	JvmResourceId string `json:"jvmResourceId" db:"jvmResourceId"` // 关联的JVM资源ID
+
+	// 线程数量
+	CurrentThreadCount      int   `json:"currentThreadCount" db:"currentThreadCount"`           // 当前线程数
+	DaemonThreadCount       int   `json:"daemonThreadCount" db:"daemonThreadCount"`             // 守护线程数
+	UserThreadCount         int   `json:"userThreadCount" db:"userThreadCount"`                 // 用户线程数
+	PeakThreadCount         int   `json:"peakThreadCount" db:"peakThreadCount"`                 // 峰值线程数
+	TotalStartedThreadCount int64 `json:"totalStartedThreadCount" db:"totalStartedThreadCount"` // 累计启动线程数
+
+	// 计算指标
+	ThreadGrowthRatePercent  float64 `json:"threadGrowthRatePercent" db:"threadGrowthRatePercent"`   // 线程增长率（百分比）
+	DaemonThreadRatioPercent float64 `json:"daemonThreadRatioPercent" db:"daemonThreadRatioPercent"` // 守护线程比例（百分比）
+
+	// 监控功能支持情况
+	CpuTimeSupported     string `json:"cpuTimeSupported" db:"cpuTimeSupported"`         // 是否支持CPU时间监控(Y是,N否)
+	CpuTimeEnabled       string `json:"cpuTimeEnabled" db:"cpuTimeEnabled"`             // 是否启用CPU时间监控(Y是,N否)
+	MemoryAllocSupported string `json:"memoryAllocSupported" db:"memoryAllocSupported"` // 是否支持内存分配监控(Y是,N否)
+	MemoryAllocEnabled   string `json:"memoryAllocEnabled" db:"memoryAllocEnabled"`     // 是否启用内存分配监控(Y是,N否)
+	ContentionSupported  string `json:"contentionSupported" db:"contentionSupported"`   // 是否支持争用监控(Y是,N否)
+	ContentionEnabled    string `json:"contentionEnabled" db:"contentionEnabled"`       // 是否启用争用监控(Y是,N否)
+
+	// 健康状态
+	HealthyFlag           string  `json:"healthyFlag" db:"healthyFlag"`                     // 线程健康标记(Y健康,N异常)
+	HealthGrade           *string `json:"healthGrade" db:"healthGrade"`                     // 线程健康等级
+	RequiresAttentionFlag string  `json:"requiresAttentionFlag" db:"requiresAttentionFlag"` // 是否需要立即关注(Y是,N否)
+	PotentialIssuesJson   *string `json:"potentialIssuesJson" db:"potentialIssuesJson"`     // 潜在问题列表，JSON格式
+
+	CollectionTime time.Time `json:"collectionTime" db:"collectionTime"` // 数据采集时间
+
+	// 通用字段
+	AddTime        time.Time `json:"addTime" db:"addTime"`               // 创建时间
+	AddWho         string    `json:"addWho" db:"addWho"`                 // 创建人ID
+	EditTime       time.Time `json:"editTime" db:"editTime"`             // 最后修改时间
+	EditWho        string    `json:"editWho" db:"editWho"`               // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" db:"oprSeqFlag"`         // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" db:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" db:"activeFlag"`         // 活动状态标记(N非活动,Y活动)
+	NoteText       *string   `json:"noteText" db:"noteText"`             // 备注信息
+}
+
+// JvmSnapshot 单个JVM实例一次采集的数据
+// 子表快照的 tenantId、jvmResourceId 和主键由服务端填充，collectionTime 为空时使用资源的采集时间
+type JvmSnapshot struct {
+	Resource JvmResource  `json:"resource"` // JVM资源信息
+	Memory   []*JvmMemory `json:"memory"`   // 内存快照，通常包含HEAP和NON_HEAP各一条
+	Gc       *JvmGc       `json:"gc"`       // GC快照
+	Thread   *JvmThread   `json:"thread"`   // 线程快照
+}
+
+// JvmIngestRequest 采集数据批量上报请求
+type JvmIngestRequest struct {
+	Snapshots []*JvmSnapshot `json:"snapshots"` // 采集快照列表
+}
+
+// JvmIngestRejected 被拒绝的快照
+type JvmIngestRejected struct {
+	Index         int    `json:"index"`         // 快照在请求中的下标
+	JvmResourceId string `json:"jvmResourceId"` // JVM资源ID
+	Reason        string `json:"reason"`        // 拒绝原因
+}
+
+// JvmIngestResult 批量上报结果
+type JvmIngestResult struct {
+	Accepted  int                  `json:"accepted"`  // 写入成功的快照数
+	Rejected  []*JvmIngestRejected `json:"rejected"`  // 校验未通过的快照
+	DiagTasks []*JvmDiagCommand    `json:"diagTasks"` // 本批次实例待执行的诊断任务，采集端执行后通过 submitJvmDiagResult 提交结果
+}
+
+// 应用监控数据类型
+const (
+	AppDataTypeThreadPool     = "THREAD_POOL"     // 线程池
+	AppDataTypeConnectionPool = "CONNECTION_POOL" // 连接池
+	AppDataTypeCustomMetric   = "CUSTOM_METRIC"   // 自定义指标
+	AppDataTypeCachePool      = "CACHE_POOL"      // 缓存池
+	AppDataTypeMessageQueue   = "MESSAGE_QUEUE"   // 消息队列
+)
+
+// GatewayTrafficDataName 网关自监控中节点汇总流量指标的数据名称，primaryValue 为5xx错误率（百分比）
+const GatewayTrafficDataName = "gateway_traffic"
+
+// JvmAppData 应用监控数据，对应表 HUB_MONITOR_APP_DATA
+// 不同类型的数据结构保存在 dataJson 中，关键指标提取到 primaryValue/secondaryValue/statusValue 便于查询
+type JvmAppData struct {
+	// 主键字段
+	TenantId      string `json:"tenantId" db:"tenantId"`           // 租户ID
+	AppDataId     string `json:"appDataId" db:"appDataId"`         // 应用监控数据ID
+	JvmResourceId string `json:"jvmResourceId" db:"jvmResourceId"` // 关联的JVM资源ID
+
+	// 数据分类标识
+	DataType     string  `json:"dataType" db:"dataType"`         // 数据类型(THREAD_POOL,CONNECTION_POOL,CUSTOM_METRIC,CACHE_POOL,MESSAGE_QUEUE)
+	DataName     string  `json:"dataName" db:"dataName"`         // 数据名称
+	DataCategory *string `json:"dataCategory" db:"dataCategory"` // 数据分类
+
+	// 监控数据
+	DataJson       string   `json:"dataJson" db:"dataJson"`             // 监控数据，JSON格式
+	PrimaryValue   *float64 `json:"primaryValue" db:"primaryValue"`     // 主要指标值
+	SecondaryValue *float64 `json:"secondaryValue" db:"secondaryValue"` // 次要指标值
+	StatusValue    *string  `json:"statusValue" db:"statusValue"`       // 状态值
+
+	// 健康状态
+	HealthyFlag           string  `json:"healthyFlag" db:"healthyFlag"`                     // 健康标记(Y健康,N异常)
+	HealthGrade           *string `json:"healthGrade" db:"healthGrade"`                     // 健康等级(EXCELLENT/GOOD/FAIR/POOR/CRITICAL)
+	RequiresAttentionFlag string  `json:"requiresAttentionFlag" db:"requiresAttentionFlag"` // 是否需要立即关注(Y是,N否)
+	TagsJson              *string `json:"tagsJson" db:"tagsJson"`                           // 标签信息，JSON格式
+
+	CollectionTime time.Time `json:"collectionTime" db:"collectionTime"` // 数据采集时间
+
+	// 通用字段
+	AddTime        time.Time `json:"addTime" db:"addTime"`               // 创建时间
+	AddWho         string    `json:"addWho" db:"addWho"`                 // 创建人ID
+	EditTime       time.Time `json:"editTime" db:"editTime"`             // 最后修改时间
+	EditWho        string    `json:"editWho" db:"editWho"`               // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" db:"oprSeqFlag"`         // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" db:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" db:"activeFlag"`         // 活动状态标记(N非活动,Y活动)
+	NoteText       *string   `json:"noteText" db:"noteText"`             // 备注信息
+}
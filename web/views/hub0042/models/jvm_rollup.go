@@ -0,0 +1,129 @@
+package models
+
+import (
+	"time"
+)
+
+// JVM监控聚合粒度
+const (
+	JvmRollup5M = "5M" // 5分钟聚合，对应表 HUB_MONITOR_JVM_METRIC_5M
+	JvmRollup1H = "1H" // 1小时聚合，对应表 HUB_MONITOR_JVM_METRIC_1H
+)
+
+// JvmMetricRollup JVM监控聚合数据，对应表 HUB_MONITOR_JVM_METRIC_5M 和 HUB_MONITOR_JVM_METRIC_1H
+// 平均值按采集点数加权，1小时数据由5分钟数据汇总；GC次数和耗时为时间段内的增量
+type JvmMetricRollup struct {
+	// 主键字段
+	TenantId      string    `json:"tenantId" db:"tenantId"`           // 租户ID
+	JvmResourceId string    `json:"jvmResourceId" db:"jvmResourceId"` // JVM资源ID
+	BucketTime    time.Time `json:"bucketTime" db:"bucketTime"`       // 聚合时间段起点
+
+	// 内存指标
+	MemorySampleCount  int     `json:"memorySampleCount" db:"memorySampleCount"`   // 参与聚合的堆内存采集点数
+	HeapUsageAvg       float64 `json:"heapUsageAvg" db:"heapUsageAvg"`             // 堆内存使用率平均值（百分比）
+	HeapUsageMax       float64 `json:"heapUsageMax" db:"heapUsageMax"`             // 堆内存使用率最大值（百分比）
+	HeapUsedBytesAvg   int64   `json:"heapUsedBytesAvg" db:"heapUsedBytesAvg"`     // 堆内存已使用平均值（字节）
+	HeapUsedBytesMax   int64   `json:"heapUsedBytesMax" db:"heapUsedBytesMax"`     // 堆内存已使用最大值（字节）
+	NonHeapSampleCount int     `json:"nonHeapSampleCount" db:"nonHeapSampleCount"` // 参与聚合的非堆内存采集点数
+	NonHeapUsageAvg    float64 `json:"nonHeapUsageAvg" db:"nonHeapUsageAvg"`       // 非堆内存使用率平均值（百分比）
+	NonHeapUsageMax    float64 `json:"nonHeapUsageMax" db:"nonHeapUsageMax"`       // 非堆内存使用率最大值（百分比）
+
+	// 线程指标
+	ThreadSampleCount int     `json:"threadSampleCount" db:"threadSampleCount"` // 参与聚合的线程采集点数
+	ThreadCountAvg    float64 `json:"threadCountAvg" db:"threadCountAvg"`       // 线程数平均值
+	ThreadCountMax    int     `json:"threadCountMax" db:"threadCountMax"`       // 线程数最大值
+
+	// GC指标
+	YgcCount      int64   `json:"ygcCount" db:"ygcCount"`           // 时间段内年轻代GC次数
+	FgcCount      int64   `json:"fgcCount" db:"fgcCount"`           // 时间段内Full GC次数
+	GcTimeSeconds float64 `json:"gcTimeSeconds" db:"gcTimeSeconds"` // 时间段内GC耗时（秒）
+
+	// 通用字段
+	AddTime        time.Time `json:"addTime" db:"addTime"`               // 创建时间
+	AddWho         string    `json:"addWho" db:"addWho"`                 // 创建人ID
+	EditTime       time.Time `json:"editTime" db:"editTime"`             // 最后修改时间
+	EditWho        string    `json:"editWho" db:"editWho"`               // 最后修改人ID
+	OprSeqFlag     string    `json:"oprSeqFlag" db:"oprSeqFlag"`         // 操作序列标识
+	CurrentVersion int       `json:"currentVersion" db:"currentVersion"` // 当前版本号
+	ActiveFlag     string    `json:"activeFlag" db:"activeFlag"`         // 活动状态标记(N非活动,Y活动)
+}
+
+// JvmMemoryPoint 聚合使用的内存采集点
+type JvmMemoryPoint struct {
+	TenantId        string    `db:"tenantId"`
+	JvmResourceId   string    `db:"jvmResourceId"`
+	MemoryType      string    `db:"memoryType"`
+	UsagePercent    float64   `db:"usagePercent"`
+	UsedMemoryBytes int64     `db:"usedMemoryBytes"`
+	CollectionTime  time.Time `db:"collectionTime"`
+}
+
+// JvmThreadPoint 聚合使用的线程采集点
+type JvmThreadPoint struct {
+	TenantId           string    `db:"tenantId"`
+	JvmResourceId      string    `db:"jvmResourceId"`
+	CurrentThreadCount int       `db:"currentThreadCount"`
+	CollectionTime     time.Time `db:"collectionTime"`
+}
+
+// JvmGcPoint 聚合使用的GC采集点，均为JVM启动以来的累积值
+type JvmGcPoint struct {
+	TenantId       string    `db:"tenantId"`
+	JvmResourceId  string    `db:"jvmResourceId"`
+	Ygc            int64     `db:"ygc"`
+	Fgc            int64     `db:"fgc"`
+	Gct            float64   `db:"gct"`
+	CollectionTime time.Time `db:"collectionTime"`
+}
+
+// JvmMetricTrendRequest JVM监控趋势查询条件
+type JvmMetricTrendRequest struct {
+	JvmResourceId string `json:"jvmResourceId" form:"jvmResourceId"` // JVM资源ID
+	StartTime     string `json:"startTime" form:"startTime"`         // 开始时间，格式 2006-01-02 15:04:05
+	EndTime       string `json:"endTime" form:"endTime"`             // 结束时间，格式 2006-01-02 15:04:05
+	Granularity   string `json:"granularity" form:"granularity"`     // 聚合粒度(5M,1H)，为空时按时间范围自动选择
+}
+
+// JVM实例对比指标
+const (
+	JvmCompareHeapUsage    = "HEAP_USAGE"     // 堆内存使用率平均值（百分比）
+	JvmCompareNonHeapUsage = "NON_HEAP_USAGE" // 非堆内存使用率平均值（百分比）
+	JvmCompareGcTime       = "GC_TIME"        // 时间段内GC耗时（秒）
+	JvmCompareYgcCount     = "YGC_COUNT"      // 时间段内年轻代GC次数
+	JvmCompareFgcCount     = "FGC_COUNT"      // 时间段内Full GC次数
+	JvmCompareThreadCount  = "THREAD_COUNT"   // 线程数平均值
+)
+
+// JvmMetricCompareRequest JVM实例对比查询条件
+// jvmResourceIds 为空时对比 serviceGroupId 下的所有实例
+type JvmMetricCompareRequest struct {
+	JvmResourceIds []string `json:"jvmResourceIds" form:"jvmResourceIds"` // 参与对比的JVM资源ID
+	ServiceGroupId string   `json:"serviceGroupId" form:"serviceGroupId"` // 服务分组ID
+	MetricType     string   `json:"metricType" form:"metricType"`         // 对比指标
+	StartTime      string   `json:"startTime" form:"startTime"`           // 开始时间，格式 2006-01-02 15:04:05
+	EndTime        string   `json:"endTime" form:"endTime"`               // 结束时间，格式 2006-01-02 15:04:05
+	Granularity    string   `json:"granularity" form:"granularity"`       // 聚合粒度(5M,1H)，为空时按时间范围自动选择
+}
+
+// JvmResourceBrief 对比结果中的JVM实例信息
+type JvmResourceBrief struct {
+	JvmResourceId   string  `json:"jvmResourceId" db:"jvmResourceId"`     // JVM资源ID
+	ServiceGroupId  string  `json:"serviceGroupId" db:"serviceGroupId"`   // 服务分组ID
+	ApplicationName string  `json:"applicationName" db:"applicationName"` // 应用名称
+	HostName        *string `json:"hostName" db:"hostName"`               // 主机名
+	HostIpAddress   *string `json:"hostIpAddress" db:"hostIpAddress"`     // 主机IP地址
+}
+
+// JvmCompareSeries 单个实例的对比序列
+type JvmCompareSeries struct {
+	JvmResourceBrief
+	Values []*float64 `json:"values"` // 与时间轴一一对应的指标值，没有采集数据的时间段为null
+}
+
+// JvmMetricCompareResult JVM实例对比结果
+type JvmMetricCompareResult struct {
+	MetricType  string              `json:"metricType"`  // 对比指标
+	Granularity string              `json:"granularity"` // 聚合粒度
+	Timestamps  []string            `json:"timestamps"`  // 时间轴，各时间段起点
+	Series      []*JvmCompareSeries `json:"series"`      // 各实例的序列，指定jvmResourceIds时按请求顺序，否则按应用名称排列
+}
@@ -10,3 +10,10 @@ type ServiceQuery struct {
 	Environment  string `json:"environment" form:"environment" query:"environment"`    // 部署环境（DEVELOPMENT, STAGING, PRODUCTION）
 	ActiveFlag   string `json:"activeFlag" form:"activeFlag" query:"activeFlag"`       // 活动标记：Y-活动，N-非活动，空表示全部（默认查询活动状态）
 }
+
+// NodeWeightRequest 手动调整节点权重请求
+type NodeWeightRequest struct {
+	NodeId string   `json:"nodeId" form:"nodeId" binding:"required"` // 节点ID
+	Weight *float64 `json:"weight" form:"weight"`                    // 手动调整的权重，范围0-10000，0表示摘除节点流量
+	Reason string   `json:"reason" form:"reason"`                    // 调整原因，记录到操作审计
+}
@@ -3,7 +3,9 @@ package hub0042routes
 import (
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
+	"gateway/web/middleware/permission"
 	"gateway/web/routes"
+	"gateway/web/utils/export"
 	"gateway/web/views/hub0042/controllers"
 
 	"github.com/gin-gonic/gin"
@@ -40,6 +42,27 @@ func Init(router *gin.Engine, db database.Database) {
 
 	// 服务相关路由
 	initServiceRoutes(group, db)
+
+	// JVM告警相关路由
+	initJvmAlertRoutes(group, db)
+
+	// JVM监控数据聚合和趋势查询路由
+	initJvmMetricRoutes(group, db)
+
+	// 监控概览路由
+	initMonitorOverviewRoutes(group, db)
+
+	// JVM诊断采集（线程转储/类直方图）路由
+	initJvmDiagRoutes(group, db)
+
+	// 网关自监控上报任务，网关节点作为监控资源与JVM应用一起展示
+	initGatewaySelfMonitor(db)
+
+	// JVM采集数据上报路由，采集端使用访问密钥签名，不走会话认证
+	initJvmIngestRoutes(router, db)
+
+	// 主机指标上报路由，采集端使用访问密钥签名，不走会话认证
+	initHostIngestRoutes(router, db)
 }
 
 // initServiceRoutes 初始化服务相关路由
@@ -60,6 +83,8 @@ func initServiceRoutes(router *gin.RouterGroup, db database.Database) {
 	{
 		// 服务列表查询
 		serviceGroup.POST("/queryServices", serviceController.QueryServices)
+		// 服务列表导出，使用与查询相同的过滤参数，format=xlsx/csv
+		serviceGroup.POST("/exportServices", export.Handler("RegistryService", serviceController.QueryServices))
 
 		// 服务详情查询
 		serviceGroup.POST("/getService", serviceController.GetService)
@@ -72,9 +97,125 @@ func initServiceRoutes(router *gin.RouterGroup, db database.Database) {
 		// 节点编辑和下线
 		serviceGroup.POST("/editNode", serviceController.EditNode)
 		serviceGroup.POST("/offlineNode", serviceController.OfflineNode)
+
+		// 手动调整节点权重（0表示摘除流量）和恢复注册权重
+		serviceGroup.POST("/adjustNodeWeight", serviceController.AdjustNodeWeight)
+		serviceGroup.POST("/resetNodeWeight", serviceController.ResetNodeWeight)
+
+		// 节点操作使用节点操作分组下的按钮编码，按接口名只能推导出 hub0042:edit
+		permission.RegisterRoutePermission("POST", APIPrefix+"/editNode", ModuleName+":node:edit")
+		permission.RegisterRoutePermission("POST", APIPrefix+"/offlineNode", ModuleName+":node:offline")
+		permission.RegisterRoutePermission("POST", APIPrefix+"/adjustNodeWeight", ModuleName+":node:edit")
+		permission.RegisterRoutePermission("POST", APIPrefix+"/resetNodeWeight", ModuleName+":node:edit")
+
+		// 节点健康状态切换历史和抖动隔离状态
+		serviceGroup.POST("/getNodeHealthHistory", serviceController.GetNodeHealthHistory)
+	}
+}
+
+// initJvmAlertRoutes 初始化JVM告警相关路由，并注册告警规则的周期评估任务
+//
+// 参数:
+//   - router: Gin路由组
+//   - db: 数据库连接实例
+func initJvmAlertRoutes(router *gin.RouterGroup, db database.Database) {
+	engine := controllers.NewJvmAlertEngine(db)
+	if err := engine.Start(); err != nil {
+		logger.Error("注册JVM告警评估任务失败", "error", err)
+	}
+
+	jvmAlertController := controllers.NewJvmAlertController(db)
+	{
+		// 告警规则
+		router.POST("/queryAlertRules", jvmAlertController.QueryAlertRules)
+		router.POST("/getAlertRule", jvmAlertController.GetAlertRule)
+		router.POST("/addAlertRule", jvmAlertController.AddAlertRule)
+		router.POST("/editAlertRule", jvmAlertController.EditAlertRule)
+		router.POST("/deleteAlertRule", jvmAlertController.DeleteAlertRule)
+
+		// 告警静默
+		router.POST("/queryAlertSilences", jvmAlertController.QueryAlertSilences)
+		router.POST("/addAlertSilence", jvmAlertController.AddAlertSilence)
+		router.POST("/deleteAlertSilence", jvmAlertController.DeleteAlertSilence)
+
+		// 告警历史
+		router.POST("/queryAlertEvents", jvmAlertController.QueryAlertEvents)
+	}
+}
+
+// initJvmMetricRoutes 初始化JVM监控趋势路由，并注册监控数据聚合与清理任务
+//
+// 参数:
+//   - router: Gin路由组
+//   - db: 数据库连接实例
+func initJvmMetricRoutes(router *gin.RouterGroup, db database.Database) {
+	job := controllers.NewJvmRollupJob(db)
+	if err := job.Start(); err != nil {
+		logger.Error("注册JVM监控数据聚合任务失败", "error", err)
+	}
+
+	jvmMetricController := controllers.NewJvmMetricController(db)
+	router.POST("/queryJvmMetricTrend", jvmMetricController.QueryJvmMetricTrend)
+	router.POST("/compareJvmMetric", jvmMetricController.CompareJvmMetric)
+}
+
+// initMonitorOverviewRoutes 初始化监控概览路由
+//
+// 参数:
+//   - router: Gin路由组
+//   - db: 数据库连接实例
+func initMonitorOverviewRoutes(router *gin.RouterGroup, db database.Database) {
+	monitorOverviewController := controllers.NewMonitorOverviewController(db)
+	router.POST("/queryMonitorOverview", monitorOverviewController.QueryMonitorOverview)
+}
+
+// initJvmDiagRoutes 初始化JVM诊断采集路由
+//
+// 参数:
+//   - router: Gin路由组
+//   - db: 数据库连接实例
+func initJvmDiagRoutes(router *gin.RouterGroup, db database.Database) {
+	jvmDiagController := controllers.NewJvmDiagController(db)
+	router.POST("/addJvmDiagTask", jvmDiagController.AddJvmDiagTask)
+	router.POST("/queryJvmDiagTasks", jvmDiagController.QueryJvmDiagTasks)
+	router.POST("/getJvmDiagTask", jvmDiagController.GetJvmDiagTask)
+}
+
+// initGatewaySelfMonitor 注册网关自监控上报任务
+//
+// 参数:
+//   - db: 数据库连接实例
+func initGatewaySelfMonitor(db database.Database) {
+	job := controllers.NewGatewaySelfMonitorJob(db)
+	if err := job.Start(); err != nil {
+		logger.Error("注册网关自监控上报任务失败", "error", err)
 	}
 }
 
+// initJvmIngestRoutes 初始化JVM采集数据上报路由
+// 上报接口供采集端调用，使用请求签名认证，因此注册在权限路由组之外
+//
+// 参数:
+//   - router: Gin路由引擎实例
+//   - db: 数据库连接实例
+func initJvmIngestRoutes(router *gin.Engine, db database.Database) {
+	jvmIngestController := controllers.NewJvmIngestController(db)
+	router.POST(APIPrefix+"/ingestJvmSnapshots", routes.PublicAPI(), jvmIngestController.VerifySignature(), jvmIngestController.IngestBatch)
+	// 采集端执行随上报响应下发的诊断任务后提交结果
+	router.POST(APIPrefix+"/submitJvmDiagResult", routes.PublicAPI(), jvmIngestController.VerifySignature(), jvmIngestController.SubmitDiagResult)
+}
+
+// initHostIngestRoutes 初始化主机指标上报路由
+// 上报接口供独立部署的采集端调用，使用请求签名认证，因此注册在权限路由组之外
+//
+// 参数:
+//   - router: Gin路由引擎实例
+//   - db: 数据库连接实例
+func initHostIngestRoutes(router *gin.Engine, db database.Database) {
+	hostIngestController := controllers.NewHostIngestController(db)
+	router.POST(APIPrefix+"/ingestHostMetrics", routes.PublicAPI(), hostIngestController.VerifySignature(), hostIngestController.IngestBatch)
+}
+
 // RegisterRoutesFunc 返回路由注册函数
 // 此函数用于手动注册模块路由
 //
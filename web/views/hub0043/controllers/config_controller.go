@@ -266,6 +266,10 @@ func (c *ConfigController) EditConfig(ctx *gin.Context) {
 		response.ErrorJSON(ctx, "配置不存在", constants.ED00008)
 		return
 	}
+	if oldConfig.GrayRelease() != nil {
+		response.ErrorJSON(ctx, "配置正在灰度发布中，请先全量发布或回滚灰度", constants.ED00015)
+		return
+	}
 
 	// 设置版本号和时间（UpdateConfig 会自动递增版本号）
 	config.Version = oldConfig.Version
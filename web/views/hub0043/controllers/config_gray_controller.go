@@ -0,0 +1,409 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"gateway/internal/servicecenter"
+	pb "gateway/internal/servicecenter/server/proto"
+	"gateway/internal/servicecenter/types"
+	"gateway/pkg/logger"
+	"gateway/pkg/utils/random"
+	"gateway/web/utils/constants"
+	"gateway/web/utils/request"
+	"gateway/web/utils/response"
+	"gateway/web/utils/validation"
+	"gateway/web/views/hub0043/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 配置灰度发布
+//
+// 流程：
+//  1. PublishGray: 保存灰度内容和灰度规则（客户端IP列表或标签），只向命中规则的监听实例推送灰度内容，正式配置不变
+//  2. GetGrayRelease: 查看灰度状态和当前监听实例的命中情况，核对灰度范围
+//  3. PromoteGray: 灰度内容成为正式配置（版本号递增并记录历史），向全部监听实例推送
+//  4. CancelGray: 回滚灰度，清除灰度状态并向灰度实例重新推送正式配置
+//
+// 灰度期间不允许直接修改或回滚正式配置，需先全量发布或回滚灰度。
+
+// GrayWatcher 监听配置的客户端实例及其灰度命中情况
+type GrayWatcher struct {
+	types.ConfigClient
+	Gray bool `json:"gray"` // 是否命中灰度规则
+}
+
+// PublishGray 灰度发布配置
+// @Summary 灰度发布配置
+// @Description 向命中灰度规则（客户端IP或标签）的监听实例推送新配置，正式配置保持不变
+// @Tags 配置中心-灰度发布
+// @Accept json
+// @Produce json
+// @Param gray body models.GrayPublishRequest true "灰度发布请求"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0043/publishGray [post]
+func (c *ConfigController) PublishGray(ctx *gin.Context) {
+	var req models.GrayPublishRequest
+	if err := request.BindSafely(ctx, &req); err != nil {
+		if validationErr, ok := err.(*validation.Error); ok {
+			response.ValidationErrorJSON(ctx, validationErr, constants.ED00006)
+			return
+		}
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	requestCtx := ctx.Request.Context()
+
+	config, err := c.configDAO.GetConfigById(requestCtx, tenantId, req.NamespaceId, req.GroupName, req.ConfigDataId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询配置失败", err)
+		response.ErrorJSON(ctx, "查询配置失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if config == nil {
+		response.ErrorJSON(ctx, "配置不存在", constants.ED00008)
+		return
+	}
+
+	operatorId := request.GetOperatorID(ctx)
+	gray := &types.ConfigGrayRelease{
+		ConfigContent: req.ConfigContent,
+		ClientIps:     req.ClientIps,
+		Labels:        req.Labels,
+		BaseVersion:   config.Version,
+		ChangeReason:  req.ChangeReason,
+		StartTime:     time.Now(),
+		Operator:      operatorId,
+	}
+	if err := gray.Validate(); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00006)
+		return
+	}
+	if gray.Md5Value == config.Md5Value {
+		response.ErrorJSON(ctx, "灰度配置内容与正式配置相同", constants.ED00015)
+		return
+	}
+
+	// 重新发布灰度时，不再命中新规则的原灰度实例需要恢复正式配置
+	previous := config.GrayRelease()
+	official := *config
+
+	if err := config.SetGrayRelease(gray); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00009)
+		return
+	}
+	config.EditTime = time.Now()
+	config.EditWho = operatorId
+	if err := c.configDAO.UpdateExtProperty(requestCtx, config); err != nil {
+		logger.ErrorWithTrace(ctx, "保存灰度发布状态失败", err)
+		response.ErrorJSON(ctx, "保存灰度发布状态失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	notified := c.notifyGrayWatchers(requestCtx, tenantId, config.GrayView(), gray.Matches)
+	if previous != nil {
+		c.notifyGrayWatchers(requestCtx, tenantId, &official, func(client types.ConfigClient) bool {
+			return previous.Matches(client) && !gray.Matches(client)
+		})
+	}
+
+	logger.InfoWithTrace(ctx, "配置灰度发布成功",
+		"namespaceId", config.NamespaceId,
+		"groupName", config.GroupName,
+		"configDataId", config.ConfigDataId,
+		"baseVersion", config.Version,
+		"clientIps", gray.ClientIps,
+		"labels", gray.Labels,
+		"notified", notified)
+
+	response.SuccessJSON(ctx, gin.H{
+		"grayRelease": gray,
+		"notified":    notified,
+	}, constants.SD00004)
+}
+
+// GetGrayRelease 获取配置灰度发布状态
+// @Summary 获取配置灰度发布状态
+// @Description 获取灰度内容、灰度规则以及当前监听该配置的实例和灰度命中情况
+// @Tags 配置中心-灰度发布
+// @Accept json
+// @Produce json
+// @Param gray body models.GrayKeyRequest true "配置标识"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0043/getGrayRelease [post]
+func (c *ConfigController) GetGrayRelease(ctx *gin.Context) {
+	var req models.GrayKeyRequest
+	if err := request.BindSafely(ctx, &req); err != nil {
+		if validationErr, ok := err.(*validation.Error); ok {
+			response.ValidationErrorJSON(ctx, validationErr, constants.ED00006)
+			return
+		}
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	requestCtx := ctx.Request.Context()
+
+	config, err := c.configDAO.GetConfigById(requestCtx, tenantId, req.NamespaceId, req.GroupName, req.ConfigDataId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询配置失败", err)
+		response.ErrorJSON(ctx, "查询配置失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if config == nil {
+		response.ErrorJSON(ctx, "配置不存在", constants.ED00008)
+		return
+	}
+
+	gray := config.GrayRelease()
+	watchers := make([]*GrayWatcher, 0)
+	grayCount := 0
+	if instanceName := c.instanceNameOf(requestCtx, tenantId, config.NamespaceId); instanceName != "" && servicecenter.GetManager() != nil {
+		clients, err := servicecenter.GetManager().ListConfigWatchers(instanceName, tenantId, config.NamespaceId, config.GroupName, config.ConfigDataId)
+		if err != nil {
+			logger.WarnWithTrace(ctx, "获取配置监听实例失败", err, "instanceName", instanceName)
+		}
+		for _, client := range clients {
+			watcher := &GrayWatcher{ConfigClient: client, Gray: gray != nil && gray.Matches(client)}
+			if watcher.Gray {
+				grayCount++
+			}
+			watchers = append(watchers, watcher)
+		}
+	}
+
+	response.SuccessJSON(ctx, gin.H{
+		"version":     config.Version,
+		"md5Value":    config.Md5Value,
+		"grayRelease": gray,
+		"watchers":    watchers,
+		"grayCount":   grayCount,
+		"totalCount":  len(watchers),
+	}, constants.SD00002)
+}
+
+// PromoteGray 灰度配置全量发布
+// @Summary 灰度配置全量发布
+// @Description 灰度内容成为正式配置（版本号递增并记录配置历史），并推送给全部监听实例
+// @Tags 配置中心-灰度发布
+// @Accept json
+// @Produce json
+// @Param gray body models.GrayKeyRequest true "配置标识"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0043/promoteGray [post]
+func (c *ConfigController) PromoteGray(ctx *gin.Context) {
+	var req models.GrayKeyRequest
+	if err := request.BindSafely(ctx, &req); err != nil {
+		if validationErr, ok := err.(*validation.Error); ok {
+			response.ValidationErrorJSON(ctx, validationErr, constants.ED00006)
+			return
+		}
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	requestCtx := ctx.Request.Context()
+
+	oldConfig, err := c.configDAO.GetConfigById(requestCtx, tenantId, req.NamespaceId, req.GroupName, req.ConfigDataId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询配置失败", err)
+		response.ErrorJSON(ctx, "查询配置失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if oldConfig == nil {
+		response.ErrorJSON(ctx, "配置不存在", constants.ED00008)
+		return
+	}
+	gray := oldConfig.GrayRelease()
+	if gray == nil {
+		response.ErrorJSON(ctx, "配置未处于灰度发布中", constants.ED00015)
+		return
+	}
+
+	// 灰度内容成为正式配置（UpdateConfig 会递增版本号并计算MD5）
+	operatorId := request.GetOperatorID(ctx)
+	config := *oldConfig
+	config.ConfigContent = gray.ConfigContent
+	config.EditTime = time.Now()
+	config.EditWho = operatorId
+	if err := config.SetGrayRelease(nil); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00009)
+		return
+	}
+	if err := c.configDAO.UpdateConfig(requestCtx, &config); err != nil {
+		logger.ErrorWithTrace(ctx, "更新配置失败", err)
+		response.ErrorJSON(ctx, "更新配置失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	// UpdateConfig 跳过零值字段，扩展属性只剩灰度状态时需单独清除
+	if err := c.configDAO.UpdateExtProperty(requestCtx, &config); err != nil {
+		logger.ErrorWithTrace(ctx, "清除灰度发布状态失败", err)
+		response.ErrorJSON(ctx, "清除灰度发布状态失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	changeReason := req.ChangeReason
+	if changeReason == "" {
+		changeReason = gray.ChangeReason
+	}
+	if err := c.db.InTx(requestCtx, nil, func(txCtx context.Context) error {
+		now := time.Now()
+		history := &types.ConfigHistory{
+			ConfigHistoryId: random.Generate32BitRandomString(),
+			TenantId:        tenantId,
+			NamespaceId:     config.NamespaceId,
+			GroupName:       config.GroupName,
+			ConfigDataId:    config.ConfigDataId,
+			ChangeType:      types.ChangeTypeUpdate,
+			OldContent:      oldConfig.ConfigContent,
+			OldVersion:      oldConfig.Version,
+			OldMd5Value:     oldConfig.Md5Value,
+			NewContent:      config.ConfigContent,
+			NewVersion:      config.Version,
+			NewMd5Value:     config.Md5Value,
+			ChangeReason:    changeReason,
+			ChangedBy:       operatorId,
+			ChangedAt:       now,
+			AddTime:         now,
+			AddWho:          operatorId,
+			EditTime:        now,
+			EditWho:         operatorId,
+		}
+		return c.historyDAO.CreateHistory(txCtx, history)
+	}); err != nil {
+		logger.WarnWithTrace(ctx, "保存配置历史记录失败", err)
+		// 历史记录失败不影响主流程，只记录日志
+	}
+
+	c.notifyConfigChange(requestCtx, tenantId, config.NamespaceId, &config, "CONFIG_UPDATED")
+
+	logger.InfoWithTrace(ctx, "灰度配置全量发布成功",
+		"namespaceId", config.NamespaceId,
+		"groupName", config.GroupName,
+		"configDataId", config.ConfigDataId,
+		"oldVersion", oldConfig.Version,
+		"newVersion", config.Version)
+
+	response.SuccessJSON(ctx, &config, constants.SD00004)
+}
+
+// CancelGray 回滚灰度发布
+// @Summary 回滚灰度发布
+// @Description 清除灰度状态，并向命中灰度规则的监听实例重新推送正式配置
+// @Tags 配置中心-灰度发布
+// @Accept json
+// @Produce json
+// @Param gray body models.GrayKeyRequest true "配置标识"
+// @Success 200 {object} response.JsonData
+// @Router /api/hub0043/cancelGray [post]
+func (c *ConfigController) CancelGray(ctx *gin.Context) {
+	var req models.GrayKeyRequest
+	if err := request.BindSafely(ctx, &req); err != nil {
+		if validationErr, ok := err.(*validation.Error); ok {
+			response.ValidationErrorJSON(ctx, validationErr, constants.ED00006)
+			return
+		}
+		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
+		return
+	}
+
+	tenantId := request.GetTenantID(ctx)
+	requestCtx := ctx.Request.Context()
+
+	config, err := c.configDAO.GetConfigById(requestCtx, tenantId, req.NamespaceId, req.GroupName, req.ConfigDataId)
+	if err != nil {
+		logger.ErrorWithTrace(ctx, "查询配置失败", err)
+		response.ErrorJSON(ctx, "查询配置失败: "+err.Error(), constants.ED00009)
+		return
+	}
+	if config == nil {
+		response.ErrorJSON(ctx, "配置不存在", constants.ED00008)
+		return
+	}
+	gray := config.GrayRelease()
+	if gray == nil {
+		response.ErrorJSON(ctx, "配置未处于灰度发布中", constants.ED00015)
+		return
+	}
+
+	if err := config.SetGrayRelease(nil); err != nil {
+		response.ErrorJSON(ctx, err.Error(), constants.ED00009)
+		return
+	}
+	config.EditTime = time.Now()
+	config.EditWho = request.GetOperatorID(ctx)
+	if err := c.configDAO.UpdateExtProperty(requestCtx, config); err != nil {
+		logger.ErrorWithTrace(ctx, "清除灰度发布状态失败", err)
+		response.ErrorJSON(ctx, "清除灰度发布状态失败: "+err.Error(), constants.ED00009)
+		return
+	}
+
+	notified := c.notifyGrayWatchers(requestCtx, tenantId, config, gray.Matches)
+
+	logger.InfoWithTrace(ctx, "配置灰度发布已回滚",
+		"namespaceId", config.NamespaceId,
+		"groupName", config.GroupName,
+		"configDataId", config.ConfigDataId,
+		"version", config.Version,
+		"notified", notified)
+
+	response.SuccessJSON(ctx, gin.H{
+		"version":  config.Version,
+		"notified": notified,
+	}, constants.SD00004)
+}
+
+// notifyGrayWatchers 向命中 match 的监听实例推送配置
+// 返回通知的监听实例数量
+func (c *ConfigController) notifyGrayWatchers(ctx context.Context, tenantId string, config *types.ConfigData, match func(types.ConfigClient) bool) int {
+	instanceName := c.instanceNameOf(ctx, tenantId, config.NamespaceId)
+	if instanceName == "" || servicecenter.GetManager() == nil {
+		return 0
+	}
+
+	event := &pb.ConfigChangeEvent{
+		EventType:    "CONFIG_UPDATED",
+		Timestamp:    time.Now().Format("2006-01-02 15:04:05"),
+		NamespaceId:  config.NamespaceId,
+		GroupName:    config.GroupName,
+		ConfigDataId: config.ConfigDataId,
+		ContentMd5:   config.Md5Value,
+		Config: &pb.ConfigData{
+			NamespaceId:   config.NamespaceId,
+			GroupName:     config.GroupName,
+			ConfigDataId:  config.ConfigDataId,
+			ContentType:   config.ContentType,
+			ConfigContent: config.ConfigContent,
+			ContentMd5:    config.Md5Value,
+			ConfigDesc:    config.ConfigDescription,
+			ConfigVersion: config.Version,
+		},
+	}
+
+	notified, err := servicecenter.GetManager().NotifyMatchingConfigWatchers(ctx, instanceName, tenantId, config.NamespaceId, config.GroupName, config.ConfigDataId, event, match)
+	if err != nil {
+		logger.WarnWithTrace(ctx, "发送灰度配置事件通知失败", err,
+			"instanceName", instanceName,
+			"namespaceId", config.NamespaceId,
+			"configDataId", config.ConfigDataId)
+	}
+	return notified
+}
+
+// instanceNameOf 获取命名空间所属的服务中心实例名称，查询失败或未设置时返回空字符串
+func (c *ConfigController) instanceNameOf(ctx context.Context, tenantId, namespaceId string) string {
+	namespace, err := c.namespaceDAO.GetNamespace(ctx, tenantId, namespaceId)
+	if err != nil {
+		logger.WarnWithTrace(ctx, "查询命名空间失败", err, "namespaceId", namespaceId)
+		return ""
+	}
+	if namespace == nil {
+		return ""
+	}
+	return namespace.InstanceName
+}
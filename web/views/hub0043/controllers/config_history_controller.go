@@ -15,6 +15,7 @@ import (
 	"gateway/web/utils/constants"
 	"gateway/web/utils/request"
 	"gateway/web/utils/response"
+	"gateway/web/utils/validation"
 	hub0043dao "gateway/web/views/hub0043/dao"
 	"gateway/web/views/hub0043/models"
 
@@ -58,15 +59,14 @@ func (c *ConfigHistoryController) GetConfigHistory(ctx *gin.Context) {
 	// 直接使用 request 绑定查询对象
 	var req models.ConfigHistoryRequest
 	if err := request.BindSafely(ctx, &req); err != nil {
+		// namespaceId、groupName、configDataId 为必填，由 binding 标签校验
+		if validationErr, ok := err.(*validation.Error); ok {
+			response.ValidationErrorJSON(ctx, validationErr, constants.ED00006)
+			return
+		}
 		logger.WarnWithTrace(ctx, "绑定配置历史查询条件失败，使用默认条件", "error", err.Error())
 	}
 
-	// 验证必填字段
-	if req.NamespaceId == "" || req.GroupName == "" || req.ConfigDataId == "" {
-		response.ErrorJSON(ctx, "namespaceId、groupName和configDataId不能为空", constants.ED00006)
-		return
-	}
-
 	// 设置默认限制数量
 	if req.Limit <= 0 {
 		req.Limit = 50
@@ -137,16 +137,14 @@ func (c *ConfigHistoryController) GetHistoryById(ctx *gin.Context) {
 func (c *ConfigHistoryController) RollbackConfig(ctx *gin.Context) {
 	var req models.RollbackRequest
 	if err := request.BindSafely(ctx, &req); err != nil {
+		if validationErr, ok := err.(*validation.Error); ok {
+			response.ValidationErrorJSON(ctx, validationErr, constants.ED00006)
+			return
+		}
 		response.ErrorJSON(ctx, "参数错误: "+err.Error(), constants.ED00006)
 		return
 	}
 
-	// 验证必填字段
-	if req.ConfigHistoryId == "" {
-		response.ErrorJSON(ctx, "configHistoryId不能为空", constants.ED00006)
-		return
-	}
-
 	tenantId := request.GetTenantID(ctx)
 	requestCtx := ctx.Request.Context()
 
@@ -164,6 +162,10 @@ func (c *ConfigHistoryController) RollbackConfig(ctx *gin.Context) {
 
 	// 获取当前配置（用于记录回滚前的状态和获取 ContentType）
 	currentConfig, err := c.configDAO.GetConfigById(requestCtx, tenantId, history.NamespaceId, history.GroupName, history.ConfigDataId)
+	if currentConfig.GrayRelease() != nil {
+		response.ErrorJSON(ctx, "配置正在灰度发布中，请先全量发布或回滚灰度", constants.ED00015)
+		return
+	}
 	var contentType string
 	if err == nil && currentConfig != nil {
 		contentType = currentConfig.ContentType
@@ -247,6 +247,38 @@ func (dao *ConfigDAO) UpdateConfig(ctx context.Context, config *types.ConfigData
 	return nil
 }
 
+// UpdateExtProperty 只更新配置的扩展属性（用于保存灰度发布状态），不改变配置内容和版本号
+// 参数:
+//   - ctx: 上下文对象
+//   - config: 配置数据（必须包含主键字段、当前版本号和新的扩展属性）
+//
+// 返回:
+//   - error: 可能的错误，配置已被其他操作修改（版本号不一致）时返回错误
+func (dao *ConfigDAO) UpdateExtProperty(ctx context.Context, config *types.ConfigData) error {
+	if config == nil {
+		return errors.New("配置数据不能为空")
+	}
+
+	if config.EditTime.IsZero() {
+		config.EditTime = time.Now()
+	}
+
+	query := `UPDATE HUB_SERVICE_CONFIG_DATA SET extProperty = ?, editTime = ?, editWho = ?
+		WHERE tenantId = ? AND namespaceId = ? AND groupName = ? AND configDataId = ? AND version = ?`
+	args := []interface{}{config.ExtProperty, config.EditTime, config.EditWho,
+		config.TenantId, config.NamespaceId, config.GroupName, config.ConfigDataId, config.Version}
+
+	affected, err := dao.db.Exec(ctx, query, args, true)
+	if err != nil {
+		return huberrors.WrapError(err, "更新配置扩展属性失败")
+	}
+	if affected == 0 {
+		return errors.New("配置已被修改，请刷新后重试")
+	}
+
+	return nil
+}
+
 // DeleteConfig 删除配置（物理删除）
 // 参数:
 //   - ctx: 上下文对象
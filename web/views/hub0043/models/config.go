@@ -22,3 +22,22 @@ type RollbackRequest struct {
 	ConfigHistoryId string `json:"configHistoryId" form:"configHistoryId" binding:"required"` // 配置历史ID（唯一标识）
 	ChangeReason    string `json:"changeReason" form:"changeReason"`                          // 变更原因
 }
+
+// GrayKeyRequest 配置灰度发布操作请求（查询、全量发布、回滚）
+type GrayKeyRequest struct {
+	NamespaceId  string `json:"namespaceId" form:"namespaceId" binding:"required"`   // 命名空间ID
+	GroupName    string `json:"groupName" form:"groupName" binding:"required"`       // 分组名称
+	ConfigDataId string `json:"configDataId" form:"configDataId" binding:"required"` // 配置数据ID
+	ChangeReason string `json:"changeReason" form:"changeReason"`                    // 变更原因（全量发布时记录到配置历史）
+}
+
+// GrayPublishRequest 配置灰度发布请求
+type GrayPublishRequest struct {
+	NamespaceId   string            `json:"namespaceId" form:"namespaceId" binding:"required"`     // 命名空间ID
+	GroupName     string            `json:"groupName" form:"groupName" binding:"required"`         // 分组名称
+	ConfigDataId  string            `json:"configDataId" form:"configDataId" binding:"required"`   // 配置数据ID
+	ConfigContent string            `json:"configContent" form:"configContent" binding:"required"` // 灰度配置内容
+	ClientIps     []string          `json:"clientIps" form:"clientIps"`                            // 灰度客户端IP列表
+	Labels        map[string]string `json:"labels" form:"labels"`                                  // 灰度客户端标签，客户端需包含全部标签
+	ChangeReason  string            `json:"changeReason" form:"changeReason"`                      // 变更原因
+}
@@ -71,6 +71,12 @@ func initConfigRoutes(router *gin.RouterGroup, db database.Database) {
 		configGroup.POST("/addConfig", configController.AddConfig)
 		configGroup.POST("/editConfig", configController.EditConfig)
 		configGroup.POST("/deleteConfig", configController.DeleteConfig)
+
+		// 配置灰度发布
+		configGroup.POST("/publishGray", configController.PublishGray)
+		configGroup.POST("/getGrayRelease", configController.GetGrayRelease)
+		configGroup.POST("/promoteGray", configController.PromoteGray)
+		configGroup.POST("/cancelGray", configController.CancelGray)
 	}
 }
 
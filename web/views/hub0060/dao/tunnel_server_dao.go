@@ -10,7 +10,6 @@ import (
 	"gateway/pkg/logger"
 	"gateway/pkg/utils/huberrors"
 	"gateway/web/views/hub0060/models"
-	"strings"
 	"time"
 )
 
@@ -134,7 +133,7 @@ func (dao *TunnelServerDAO) GetTunnelServer(ctx context.Context, tunnelServerId
 	server := &types.TunnelServer{}
 	err := dao.db.QueryOne(ctx, server, query, []interface{}{tunnelServerId}, true)
 	if err != nil {
-		if strings.Contains(err.Error(), "no rows") || strings.Contains(err.Error(), "not found") {
+		if huberrors.IsNotFound(err) {
 			return nil, huberrors.WrapError(err, "隧道服务器不存在")
 		}
 		return nil, huberrors.WrapError(err, "获取隧道服务器信息失败")
@@ -3,7 +3,6 @@ package dao
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"gateway/internal/tunnel/types"
@@ -148,7 +147,7 @@ func (dao *StaticNodeDAO) GetStaticNode(ctx context.Context, nodeId, tenantId st
 	node := &types.TunnelStaticNode{}
 	err := dao.db.QueryOne(ctx, node, query, []interface{}{nodeId}, true)
 	if err != nil {
-		if strings.Contains(err.Error(), "no rows") {
+		if huberrors.IsNotFound(err) {
 			return nil, nil
 		}
 		return nil, huberrors.WrapError(err, "获取节点信息失败")
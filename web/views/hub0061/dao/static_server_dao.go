@@ -3,7 +3,6 @@ package dao
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"gateway/internal/tunnel/types"
@@ -159,7 +158,7 @@ func (dao *StaticServerDAO) GetStaticServer(ctx context.Context, serverId, tenan
 	server := &types.TunnelStaticServer{}
 	err := dao.db.QueryOne(ctx, server, query, []interface{}{serverId}, true)
 	if err != nil {
-		if strings.Contains(err.Error(), "no rows") {
+		if huberrors.IsNotFound(err) {
 			return nil, nil
 		}
 		return nil, huberrors.WrapError(err, "获取服务器信息失败")
@@ -3,6 +3,7 @@ package routes
 import (
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
+	"gateway/web/middleware/permission"
 	"gateway/web/routes"
 	"gateway/web/views/hub0062/controllers"
 
@@ -63,6 +64,8 @@ func RegisterHub0062Routes(router *gin.Engine, db database.Database) {
 		// 服务注册和注销
 		protectedGroup.POST("/registerService", serviceController.RegisterService)
 		protectedGroup.POST("/unregisterService", serviceController.UnregisterService)
+		permission.RegisterRoutePermission("POST", APIPrefix+"/registerService", ModuleName+":service:register")
+		permission.RegisterRoutePermission("POST", APIPrefix+"/unregisterService", ModuleName+":service:unregister")
 
 		// 关联数据查询
 		protectedGroup.POST("/getClientServices", clientController.GetClientServices)
@@ -3,6 +3,7 @@ package hub0080routes
 import (
 	"gateway/pkg/database"
 	"gateway/pkg/logger"
+	"gateway/web/middleware/permission"
 	"gateway/web/routes"
 	"gateway/web/views/hub0080/controllers"
 
@@ -75,6 +76,10 @@ func initAlertConfigRoutes(router *gin.RouterGroup, db database.Database) {
 
 		// 重载告警渠道配置（用于配置变更后即时生效）
 		router.POST("/reloadAlertChannel", ctrl.ReloadAlertChannel)
+
+		// 设置默认渠道和测试渠道有独立的按钮编码，按接口名只能推导出 hub0080:edit
+		permission.RegisterRoutePermission("POST", APIPrefix+"/setDefaultChannel", ModuleName+":setDefault")
+		permission.RegisterRoutePermission("POST", APIPrefix+"/testAlertChannel", ModuleName+":test")
 	}
 }
 
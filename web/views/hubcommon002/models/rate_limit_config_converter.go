@@ -94,6 +94,8 @@ func (c *RateLimitConfigConverter) normalizeAlgorithm(algorithm string) string {
 		return "token-bucket"
 	case "LEAKY_BUCKET":
 		return "leaky-bucket"
+	case "SPIKE_ARREST":
+		return "spike-arrest"
 	case "SLIDING_WINDOW":
 		return "sliding-window"
 	case "FIXED_WINDOW":
@@ -119,7 +121,7 @@ func (c *RateLimitConfigConverter) ValidateKeyStrategy(keyStrategy string) bool
 
 // ValidateAlgorithm 验证算法类型
 func (c *RateLimitConfigConverter) ValidateAlgorithm(algorithm string) bool {
-	validAlgorithms := []string{"token-bucket", "leaky-bucket", "sliding-window", "fixed-window", "none"}
+	validAlgorithms := []string{"token-bucket", "leaky-bucket", "spike-arrest", "sliding-window", "fixed-window", "none"}
 	for _, valid := range validAlgorithms {
 		if algorithm == valid {
 			return true